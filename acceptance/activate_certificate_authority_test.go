@@ -30,6 +30,14 @@ var _ = Describe("activate certificate authority", func() {
 				ghttp.VerifyRequest("POST", "/api/v0/certificate_authorities/some-id/activate"),
 				ghttp.VerifyJSON(`{}`),
 			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v0/certificate_authorities"),
+				ghttp.RespondWith(http.StatusOK, `{"certificate_authorities": [{"guid": "some-id", "active": true}]}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v0/deployed/certificates"),
+				ghttp.RespondWith(http.StatusOK, `{"certificates": []}`),
+			),
 		)
 
 		command := exec.Command(pathToMain,
@@ -45,7 +53,7 @@ var _ = Describe("activate certificate authority", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		Eventually(session).Should(gexec.Exit(0))
-		Expect(string(session.Out.Contents())).To(Equal("Certificate authority 'some-id' activated\n"))
+		Expect(string(session.Out.Contents())).To(Equal("Certificate authority 'some-id' activated\nNo deployed certificates to check\n"))
 	})
 
 	It("errors when the certificate authority does not exist", func() {
@@ -27,6 +27,14 @@ var _ = Describe("delete certificate authority", func() {
 	It("deletes a certificate authority", func() {
 		server.AppendHandlers(
 			ghttp.VerifyRequest("DELETE", "/api/v0/certificate_authorities/some-id"),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v0/certificate_authorities"),
+				ghttp.RespondWith(http.StatusOK, `{"certificate_authorities": [{"guid": "other-id", "active": true}]}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v0/deployed/certificates"),
+				ghttp.RespondWith(http.StatusOK, `{"certificates": []}`),
+			),
 		)
 
 		command := exec.Command(pathToMain,
@@ -42,7 +50,7 @@ var _ = Describe("delete certificate authority", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		Eventually(session).Should(gexec.Exit(0))
-		Expect(string(session.Out.Contents())).To(Equal("Certificate authority 'some-id' deleted\n"))
+		Expect(string(session.Out.Contents())).To(Equal("Certificate authority 'some-id' deleted\nNo deployed certificates to check\n"))
 	})
 
 	When("the certificate authority does not exist", func() {
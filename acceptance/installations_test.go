@@ -27,6 +27,7 @@ var _ = Describe("installations command", func() {
 			"finished_at": "2017-05-24T23:55:56.106Z",
 			"started_at": "2017-05-24T23:38:37.316Z",
 			"status": "succeeded",
+			"duration_seconds": 1038,
 			"id": 1
 		},
 		{
@@ -34,6 +35,7 @@ var _ = Describe("installations command", func() {
 			"finished_at": "2017-05-24T23:55:56.106Z",
 			"started_at": "2017-05-24T23:38:37.316Z",
 			"status": "failed",
+			"duration_seconds": 1038,
 			"id": 2
 		},
 		{
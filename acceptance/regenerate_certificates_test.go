@@ -19,6 +19,10 @@ var _ = Describe("regenerate certificates", func() {
 	BeforeEach(func() {
 		server = createTLSServer()
 		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v0/deployed/certificates"),
+				ghttp.RespondWith(http.StatusOK, `{"certificates": []}`),
+			),
 			ghttp.CombineHandlers(
 				ghttp.VerifyRequest("POST", "/api/v0/certificate_authorities/active/regenerate"),
 				ghttp.RespondWith(http.StatusOK, `{}`),
@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 const availableProductsEndpoint = "/api/v0/available_products"
@@ -26,6 +28,19 @@ type ProductInfo struct {
 
 type UploadAvailableProductOutput struct{}
 
+type UploadAvailableProductChunkInput struct {
+	Chunk       io.Reader
+	FileName    string
+	Offset      int64
+	ChunkLength int64
+	TotalLength int64
+}
+
+type UploadAvailableProductChunkOutput struct {
+	Offset   int64
+	Complete bool
+}
+
 type AvailableProductsOutput struct {
 	ProductsList []ProductInfo
 }
@@ -59,6 +74,96 @@ func (a Api) UploadAvailableProduct(input UploadAvailableProductInput) (UploadAv
 	return UploadAvailableProductOutput{}, nil
 }
 
+// UploadAvailableProductChunk sends a single byte range of a product file.
+// Ops Manager acknowledges partial uploads with a 308 and a Range header
+// containing the bytes it has persisted so far.
+func (a Api) UploadAvailableProductChunk(input UploadAvailableProductChunkInput) (UploadAvailableProductChunkOutput, error) {
+	if input.ChunkLength <= 0 {
+		return UploadAvailableProductChunkOutput{}, fmt.Errorf("cannot upload an empty chunk of %s", input.FileName)
+	}
+
+	req, err := http.NewRequest("POST", availableProductsEndpoint, input.Chunk)
+	if err != nil {
+		return UploadAvailableProductChunkOutput{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", input.FileName))
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", input.Offset, input.Offset+input.ChunkLength-1, input.TotalLength))
+	req.ContentLength = input.ChunkLength
+
+	resp, err := a.progressClient.Do(req)
+	if err != nil {
+		return UploadAvailableProductChunkOutput{}, fmt.Errorf("could not make api request to available_products endpoint: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPermanentRedirect {
+		offset, err := parseUploadRange("available_products", resp.Header.Get("Range"))
+		if err != nil {
+			return UploadAvailableProductChunkOutput{}, err
+		}
+
+		return UploadAvailableProductChunkOutput{Offset: offset}, nil
+	}
+
+	if err = validateStatusOK(resp); err != nil {
+		return UploadAvailableProductChunkOutput{}, err
+	}
+
+	return UploadAvailableProductChunkOutput{Offset: input.TotalLength, Complete: true}, nil
+}
+
+// GetAvailableProductUploadOffset asks Ops Manager how many bytes of a
+// previously interrupted chunked upload it has already persisted. Only a 308
+// acknowledges persisted bytes: any other reply, e.g. from an Ops Manager
+// that does not resume uploads, means the upload starts from the beginning.
+func (a Api) GetAvailableProductUploadOffset(fileName string, totalLength int64) (int64, error) {
+	req, err := http.NewRequest("POST", availableProductsEndpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", totalLength))
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("could not make api request to available_products endpoint: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPermanentRedirect {
+		return parseUploadRange("available_products", resp.Header.Get("Range"))
+	}
+
+	return 0, nil
+}
+
+// parseUploadRange converts a "bytes=0-N" Range header into the offset of
+// the next byte to send. A missing header means nothing has been persisted.
+func parseUploadRange(endpoint, header string) (int64, error) {
+	if header == "" {
+		return 0, nil
+	}
+
+	r := strings.TrimPrefix(header, "bytes=")
+	parts := strings.SplitN(r, "-", 2)
+	if len(parts) != 2 || parts[0] != "0" {
+		return 0, fmt.Errorf("could not parse Range header from %s endpoint: %q", endpoint, header)
+	}
+
+	last, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse Range header from %s endpoint: %q", endpoint, header)
+	}
+
+	return last + 1, nil
+}
+
 func (a Api) ListAvailableProducts() (AvailableProductsOutput, error) {
 	resp, err := a.sendAPIRequest("GET", availableProductsEndpoint, nil)
 	if err != nil {
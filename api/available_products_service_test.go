@@ -170,6 +170,172 @@ var _ = Describe("Available Products", func() {
 		})
 	})
 
+	Describe("UploadAvailableProductChunk", func() {
+		It("sends the chunk with its byte range", func() {
+			progressClient.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+					ghttp.VerifyContentType("application/octet-stream"),
+					ghttp.VerifyHeaderKV("Content-Range", "bytes 0-3/12"),
+					ghttp.VerifyHeaderKV("Content-Disposition", `attachment; filename="product.pivotal"`),
+					ghttp.VerifyBody([]byte("some")),
+					ghttp.RespondWith(http.StatusPermanentRedirect, nil, http.Header{
+						"Range": []string{"bytes=0-3"},
+					}),
+				),
+			)
+
+			output, err := service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+				Chunk:       strings.NewReader("some"),
+				FileName:    "product.pivotal",
+				Offset:      0,
+				ChunkLength: 4,
+				TotalLength: 12,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal(api.UploadAvailableProductChunkOutput{Offset: 4}))
+		})
+
+		It("reports completion once the final chunk is accepted", func() {
+			progressClient.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+					ghttp.VerifyHeaderKV("Content-Range", "bytes 4-11/12"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			output, err := service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+				Chunk:       strings.NewReader(" content"),
+				FileName:    "product.pivotal",
+				Offset:      4,
+				ChunkLength: 8,
+				TotalLength: 12,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal(api.UploadAvailableProductChunkOutput{Offset: 12, Complete: true}))
+		})
+
+		When("an error occurs", func() {
+			When("the chunk is empty", func() {
+				It("returns an error without sending it", func() {
+					_, err := service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+						Chunk:       strings.NewReader(""),
+						FileName:    "product.pivotal",
+						ChunkLength: 0,
+						TotalLength: 0,
+					})
+					Expect(err).To(MatchError("cannot upload an empty chunk of product.pivotal"))
+					Expect(progressClient.ReceivedRequests()).To(BeEmpty())
+				})
+			})
+
+			When("the client errors performing the request", func() {
+				It("returns an error", func() {
+					progressClient.Close()
+
+					_, err := service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+						Chunk:       strings.NewReader("some"),
+						ChunkLength: 4,
+						TotalLength: 12,
+					})
+					Expect(err).To(MatchError(ContainSubstring("could not make api request to available_products endpoint")))
+				})
+			})
+
+			When("the api returns a non-200 status code", func() {
+				It("returns an error", func() {
+					progressClient.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+							ghttp.RespondWith(http.StatusTeapot, `{}`),
+						),
+					)
+
+					_, err := service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+						Chunk:       strings.NewReader("some"),
+						ChunkLength: 4,
+						TotalLength: 12,
+					})
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			When("the api returns an invalid Range header", func() {
+				It("returns an error", func() {
+					progressClient.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+							ghttp.RespondWith(http.StatusPermanentRedirect, nil, http.Header{
+								"Range": []string{"bytes=4-junk"},
+							}),
+						),
+					)
+
+					_, err := service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+						Chunk:       strings.NewReader("some"),
+						ChunkLength: 4,
+						TotalLength: 12,
+					})
+					Expect(err).To(MatchError(`could not parse Range header from available_products endpoint: "bytes=4-junk"`))
+				})
+			})
+		})
+	})
+
+	Describe("GetAvailableProductUploadOffset", func() {
+		It("returns the offset of the next byte to upload", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+					ghttp.VerifyHeaderKV("Content-Range", "bytes */12"),
+					ghttp.VerifyHeaderKV("Content-Disposition", `attachment; filename="product.pivotal"`),
+					ghttp.RespondWith(http.StatusPermanentRedirect, nil, http.Header{
+						"Range": []string{"bytes=0-7"},
+					}),
+				),
+			)
+
+			offset, err := service.GetAvailableProductUploadOffset("product.pivotal", 12)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(int64(8)))
+		})
+
+		It("returns zero when nothing has been uploaded", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+					ghttp.RespondWith(http.StatusPermanentRedirect, nil),
+				),
+			)
+
+			offset, err := service.GetAvailableProductUploadOffset("product.pivotal", 12)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(int64(0)))
+		})
+
+		It("returns zero when the api does not acknowledge the persisted bytes", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/available_products"),
+					ghttp.RespondWith(http.StatusTeapot, `{}`),
+				),
+			)
+
+			offset, err := service.GetAvailableProductUploadOffset("product.pivotal", 12)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(int64(0)))
+
+			offset, err = service.GetAvailableProductUploadOffset("product.pivotal", 12)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(int64(0)))
+		})
+	})
+
 	Describe("ListAvailableProducts", func() {
 		It("lists available products", func() {
 			client.AppendHandlers(
@@ -26,7 +26,7 @@ func (a Api) GetBoshEnvironment() (GetBoshEnvironmentOutput, error) {
 
 	resp, err := a.client.Do(req)
 	if err != nil {
-		return GetBoshEnvironmentOutput{}, fmt.Errorf("could not make api request to director credentials endpoint: %s", err)
+		return GetBoshEnvironmentOutput{}, fmt.Errorf("could not make api request to director credentials endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -42,7 +42,7 @@ func (a Api) GetBoshEnvironment() (GetBoshEnvironmentOutput, error) {
 	output := credential{}
 	err = json.Unmarshal(respBody, &output)
 	if err != nil {
-		return GetBoshEnvironmentOutput{}, fmt.Errorf("could not unmarshal director credentials response: %s", err)
+		return GetBoshEnvironmentOutput{}, fmt.Errorf("could not unmarshal director credentials response: %w", err)
 	}
 	if err != nil {
 		return GetBoshEnvironmentOutput{}, err
@@ -67,3 +67,33 @@ func parseKeyValues(credentials string) map[string]string {
 	}
 	return values
 }
+
+type DirectorSSHCredentials struct {
+	Username   string
+	PrivateKey string
+}
+
+// GetDirectorSSHCredentials returns the credentials of the bbr user, which
+// can ssh into the director VM.
+func (a Api) GetDirectorSSHCredentials() (DirectorSSHCredentials, error) {
+	resp, err := a.sendAPIRequest("GET", "/api/v0/deployed/director/credentials/bbr_ssh_credentials", nil)
+	if err != nil {
+		return DirectorSSHCredentials{}, fmt.Errorf("could not make api request to director credentials endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return DirectorSSHCredentials{}, err
+	}
+
+	var output GetDeployedProductCredentialOutput
+	err = json.NewDecoder(resp.Body).Decode(&output)
+	if err != nil {
+		return DirectorSSHCredentials{}, fmt.Errorf("could not unmarshal director credentials response: %w", err)
+	}
+
+	return DirectorSSHCredentials{
+		Username:   "bbr",
+		PrivateKey: output.Credential.Value["private_key_pem"],
+	}, nil
+}
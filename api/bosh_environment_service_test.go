@@ -84,4 +84,54 @@ var _ = Describe("Credentials", func() {
 			})
 		})
 	})
+	Describe("GetDirectorSSHCredentials", func() {
+		It("returns the ssh key of the bbr user", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v0/deployed/director/credentials/bbr_ssh_credentials"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"credential": {
+							"type": "rsa_pkey_credentials",
+							"value": {"private_key_pem": "some-private-key", "public_key_pem": "some-public-key"}
+						}
+					}`),
+				),
+			)
+
+			credentials, err := service.GetDirectorSSHCredentials()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(credentials).To(Equal(api.DirectorSSHCredentials{
+				Username:   "bbr",
+				PrivateKey: "some-private-key",
+			}))
+		})
+
+		When("the request fails", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/deployed/director/credentials/bbr_ssh_credentials"),
+						ghttp.RespondWith(http.StatusInternalServerError, `{}`),
+					),
+				)
+
+				_, err := service.GetDirectorSSHCredentials()
+				Expect(err).To(MatchError(ContainSubstring("request failed")))
+			})
+		})
+
+		When("the response is not JSON", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/deployed/director/credentials/bbr_ssh_credentials"),
+						ghttp.RespondWith(http.StatusOK, `invalid-json`),
+					),
+				)
+
+				_, err := service.GetDirectorSSHCredentials()
+				Expect(err).To(MatchError(ContainSubstring("could not unmarshal")))
+			})
+		})
+	})
 })
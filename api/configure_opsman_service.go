@@ -21,6 +21,7 @@ type SSLCertificate struct {
 }
 
 type RBACSettings struct {
+	Enabled             *bool  `json:"-" yaml:"enabled,omitempty"`
 	SAMLAdminGroup      string `json:"rbac_saml_admin_group,omitempty" yaml:"rbac_saml_admin_group"`
 	SAMLGroupsAttribute string `json:"rbac_saml_groups_attribute,omitempty" yaml:"rbac_saml_groups_attribute"`
 	LDAPAdminGroupName  string `json:"ldap_rbac_admin_group_name,omitempty" yaml:"ldap_rbac_admin_group_name"`
@@ -31,6 +32,13 @@ type BannerSettings struct {
 	SSHBanner string `json:"ssh_banner_contents" yaml:"ssh_banner_contents"`
 }
 
+type UAALoginBannerSettings struct {
+	Text            string `json:"text" yaml:"text"`
+	Link            string `json:"link,omitempty" yaml:"link"`
+	TextColor       string `json:"text_color,omitempty" yaml:"text_color"`
+	BackgroundColor string `json:"background_color,omitempty" yaml:"background_color"`
+}
+
 type PivnetSettings struct {
 	APIToken string `json:"api_token" yaml:"api_token"`
 }
@@ -133,6 +141,43 @@ func (a Api) EnableRBAC(rbacSettings RBACSettings) error {
 	return a.updateSettings(body, "settings/rbac")
 }
 
+func (a Api) DisableRBAC() error {
+	req, err := http.NewRequest("DELETE", "/api/v0/settings/rbac", nil)
+	if err != nil {
+		return err // not tested
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	return validateStatusOK(resp)
+}
+
+func (a Api) UpdateUAALoginBanner(bannerSettings UAALoginBannerSettings) error {
+	payload, err := json.Marshal(bannerSettings)
+	if err != nil {
+		return err // not tested
+	}
+
+	body := strings.NewReader(fmt.Sprintf(
+		`{ "uaa_login_banner": %s}`, payload))
+	return a.updateSettings(body, "settings/uaa_login_banner")
+}
+
+// UpdateSettings updates any settings page under /api/v0/settings, for the
+// settings that do not have a dedicated method.
+func (a Api) UpdateSettings(name string, settings interface{}) error {
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	body := strings.NewReader(string(payload))
+	return a.updateSettings(body, "settings/"+name)
+}
+
 func (a Api) UpdateBanner(bannerSettings BannerSettings) error {
 	payload, err := json.Marshal(bannerSettings)
 	if err != nil {
@@ -263,6 +263,90 @@ var _ = Describe("ConfigureOpsmanService", func() {
 		})
 	})
 
+	Describe("DisableRBAC", func() {
+		It("disables RBAC on the ops manager", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v0/settings/rbac"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			err := service.DisableRBAC()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the api returns an error", func() {
+			It("returns the error to the user", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v0/settings/rbac"),
+						ghttp.RespondWith(http.StatusInternalServerError, "{}"),
+					),
+				)
+
+				err := service.DisableRBAC()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("500 Internal Server Error"))
+			})
+		})
+	})
+
+	Describe("UpdateUAALoginBanner", func() {
+		It("updates the UAA login banner in ops manager", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v0/settings/uaa_login_banner"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+					ghttp.VerifyJSON(`{
+					  "uaa_login_banner": {
+					    "text": "authorized use only",
+					    "text_color": "#ffffff"
+					  }
+					}`),
+				),
+			)
+
+			err := service.UpdateUAALoginBanner(api.UAALoginBannerSettings{
+				Text:      "authorized use only",
+				TextColor: "#ffffff",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("UpdateSettings", func() {
+		It("sends the settings to the named settings page", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v0/settings/some_page"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+					ghttp.VerifyJSON(`{"some_page": {"enabled": true}}`),
+				),
+			)
+
+			err := service.UpdateSettings("some_page", map[string]interface{}{
+				"some_page": map[string]interface{}{"enabled": true},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the api returns an error", func() {
+			It("returns the error to the user", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v0/settings/some_page"),
+						ghttp.RespondWith(http.StatusInternalServerError, "{}"),
+					),
+				)
+
+				err := service.UpdateSettings("some_page", map[string]interface{}{})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("500 Internal Server Error"))
+			})
+		})
+	})
+
 	Describe("UpdateSyslogSettings", func() {
 		It("Updates the syslog settings in ops manager", func() {
 			client.AppendHandlers(
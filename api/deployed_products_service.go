@@ -33,7 +33,7 @@ func (a Api) GetDeployedProductManifest(guid string) (string, error) {
 
 	var contents interface{}
 	if err := yaml.NewDecoder(resp.Body).Decode(&contents); err != nil {
-		return "", fmt.Errorf("could not parse json: %w", err)
+		return "", fmt.Errorf("could not parse manifest: %w", err)
 	}
 
 	manifest, err := yaml.Marshal(contents)
@@ -83,7 +83,7 @@ key-4: 2147483648
 			})
 		})
 
-		When("the returned JSON is invalid", func() {
+		When("the returned manifest is invalid", func() {
 			It("returns an error", func() {
 				client.AppendHandlers(
 					ghttp.CombineHandlers(
@@ -93,7 +93,7 @@ key-4: 2147483648
 				)
 
 				_, err := service.GetDeployedProductManifest("some-product-guid")
-				Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+				Expect(err).To(MatchError(ContainSubstring("could not parse manifest")))
 			})
 		})
 	})
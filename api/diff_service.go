@@ -39,7 +39,7 @@ type CPIConfigsDiff struct {
 func (a Api) DirectorDiff() (DirectorDiff, error) {
 	resp, err := a.sendAPIRequest("GET", "/api/v0/director/diff", nil)
 	if err != nil {
-		return DirectorDiff{}, fmt.Errorf("could not request director diff: %s", err)
+		return DirectorDiff{}, fmt.Errorf("could not request director diff: %w", err)
 	}
 
 	err = validateStatusOK(resp)
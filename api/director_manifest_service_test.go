@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"net/http"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+var _ = Describe("DirectorManifest", func() {
+	var (
+		client  *ghttp.Server
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = ghttp.NewServer()
+		service = api.New(api.ApiInput{
+			Client: httpClient{serverURI: client.URL()},
+		})
+	})
+
+	AfterEach(func() {
+		client.Close()
+	})
+
+	Describe("GetStagedDirectorManifest", func() {
+		It("returns the staged manifest of the director", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v0/staged/director/manifest"),
+					ghttp.RespondWith(http.StatusOK, `{"manifest": {"name": "p-bosh", "instance_groups": [{"name": "bosh", "instances": 1}]}}`),
+				),
+			)
+
+			manifest, err := service.GetStagedDirectorManifest()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manifest).To(MatchYAML(`
+name: p-bosh
+instance_groups:
+- name: bosh
+  instances: 1
+`))
+		})
+
+		When("the server returns a non-200 status code", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/staged/director/manifest"),
+						ghttp.RespondWith(http.StatusTeapot, `{}`),
+					),
+				)
+
+				_, err := service.GetStagedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+			})
+		})
+
+		When("the client request fails", func() {
+			It("returns an error", func() {
+				client.Close()
+
+				_, err := service.GetStagedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("could not make api request to staged director manifest endpoint")))
+			})
+		})
+	})
+
+	Describe("GetDeployedDirectorManifest", func() {
+		It("returns the deployed manifest of the director", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v0/deployed/director/manifest"),
+					ghttp.RespondWith(http.StatusOK, `{"name": "p-bosh", "instance_groups": [{"name": "bosh", "instances": 1}]}`),
+				),
+			)
+
+			manifest, err := service.GetDeployedDirectorManifest()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(manifest).To(MatchYAML(`
+name: p-bosh
+instance_groups:
+- name: bosh
+  instances: 1
+`))
+		})
+
+		When("the server returns a non-200 status code", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/deployed/director/manifest"),
+						ghttp.RespondWith(http.StatusTeapot, `{}`),
+					),
+				)
+
+				_, err := service.GetDeployedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+			})
+		})
+
+		When("the returned manifest is invalid", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/deployed/director/manifest"),
+						ghttp.RespondWith(http.StatusOK, `%%%`),
+					),
+				)
+
+				_, err := service.GetDeployedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("could not parse manifest")))
+			})
+		})
+
+		When("the client request fails", func() {
+			It("returns an error", func() {
+				client.Close()
+
+				_, err := service.GetDeployedDirectorManifest()
+				Expect(err).To(MatchError(ContainSubstring("could not make api request to deployed director manifest endpoint")))
+			})
+		})
+	})
+})
@@ -284,7 +284,7 @@ func (a Api) UpdateStagedDirectorIAASConfigurations(iaasConfig IAASConfiguration
 	iaasConfigurations := []*IAASConfiguration{}
 	err := yaml.Unmarshal(iaasConfig, &iaasConfigurations)
 	if err != nil {
-		return fmt.Errorf("could not unmarshal iaas_configurations object: %v", err)
+		return fmt.Errorf("could not unmarshal iaas_configurations object: %w", err)
 	}
 
 	iaasGetResp, err := a.sendAPIRequest("GET", "/api/v0/staged/director/iaas_configurations", nil)
@@ -301,7 +301,7 @@ func (a Api) UpdateStagedDirectorIAASConfigurations(iaasConfig IAASConfiguration
 	var existingIAASes IAASConfigurationsAPIPayload
 	err = yaml.Unmarshal(existingIAASJSON, &existingIAASes)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal JSON response from Ops Manager: %s", err)
+		return fmt.Errorf("failed to unmarshal JSON response from Ops Manager: %w", err)
 	}
 
 	var hasDefault bool
@@ -401,19 +401,19 @@ func (a Api) updateIAASConfigurationInDirectorProperties(iaasConfigurations []*I
 
 	resp, err := a.sendAPIRequest("GET", "/api/v0/staged/director/properties", nil)
 	if err != nil {
-		return fmt.Errorf("could not get IAAS configuration from the director: %s", err)
+		return fmt.Errorf("could not get IAAS configuration from the director: %w", err)
 	}
 	defer resp.Body.Close()
 
 	existingIAASJSON, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("could not read IAAS configuration: %s", err)
+		return fmt.Errorf("could not read IAAS configuration: %w", err)
 	}
 
 	var existingIAAS IAASConfigurationDirectorPropertiesPayload
 	err = json.Unmarshal(existingIAASJSON, &existingIAAS)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal JSON response from Ops Manager: %s", err)
+		return fmt.Errorf("failed to unmarshal JSON response from Ops Manager: %w", err)
 	}
 
 	if existingIAAS.IAASConfiguration != nil {
@@ -441,7 +441,7 @@ func (a Api) updateIAASConfigurationInDirectorProperties(iaasConfigurations []*I
 
 	err = a.UpdateStagedDirectorProperties(jsonData)
 	if err != nil {
-		return fmt.Errorf("failed to update IAAS configuration in the director properties: %s", err)
+		return fmt.Errorf("failed to update IAAS configuration in the director properties: %w", err)
 	}
 
 	return nil
@@ -57,3 +57,19 @@ func (a Api) DisableDirectorVerifiers(verifiers []string) error {
 
 	return nil
 }
+
+func (a Api) EnableDirectorVerifiers(verifiers []string) error {
+	for _, verifier := range verifiers {
+		resp, err := a.sendAPIRequest("PUT", fmt.Sprintf(disableDirectorVerifiersEndpointTemplate, verifier), []byte(`{ "enabled": true }`))
+		if err != nil {
+			return fmt.Errorf("could not make api request to enable_director_verifiers endpoint: %w", err)
+		}
+		resp.Body.Close()
+
+		if err = validateStatusOK(resp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -130,4 +130,31 @@ var _ = Describe("DisableDirectorVerifiersService", func() {
 			})
 		})
 	})
+
+	Describe("EnableDirectorVerifiers", func() {
+		It("enables a list of director verifiers", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v0/staged/director/verifiers/install_time/some-verifier-type"),
+					ghttp.VerifyJSON(`{"enabled": true}`),
+					ghttp.RespondWith(http.StatusOK, `{"type":"some-verifier-type", "enabled":true}`),
+				),
+			)
+
+			err := service.EnableDirectorVerifiers([]string{"some-verifier-type"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when the endpoint returns a non-200-OK status code", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v0/staged/director/verifiers/install_time/some-verifier-type"),
+					ghttp.RespondWith(http.StatusInternalServerError, `{}`),
+				),
+			)
+
+			err := service.EnableDirectorVerifiers([]string{"some-verifier-type"})
+			Expect(err).To(MatchError(ContainSubstring("unexpected response")))
+		})
+	})
 })
@@ -53,3 +53,19 @@ func (a Api) DisableProductVerifiers(verifiers []string, productGUID string) err
 
 	return nil
 }
+
+func (a Api) EnableProductVerifiers(verifiers []string, productGUID string) error {
+	for _, verifier := range verifiers {
+		resp, err := a.sendAPIRequest("PUT", fmt.Sprintf(disableProductVerifiersEndpointTemplate, productGUID, verifier), []byte(`{ "enabled": true }`))
+		if err != nil {
+			return fmt.Errorf("could not make api request to enable_product_verifiers endpoint: %w", err)
+		}
+		resp.Body.Close()
+
+		if err = validateStatusOK(resp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
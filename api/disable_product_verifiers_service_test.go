@@ -166,4 +166,26 @@ var _ = Describe("DisableProductVerifiersService", func() {
 			})
 		})
 	})
+
+	Describe("EnableProductVerifiers", func() {
+		It("enables a list of product verifiers", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v0/staged/products/cf-guid/verifiers/install_time/some-verifier-type"),
+					ghttp.RespondWith(http.StatusOK, `{"type":"some-verifier-type", "enabled":true}`),
+					ghttp.VerifyJSON(`{"enabled": true}`),
+				),
+			)
+
+			err := service.EnableProductVerifiers([]string{"some-verifier-type"}, "cf-guid")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when the http request could not be made", func() {
+			server.Close()
+
+			err := service.EnableProductVerifiers([]string{"some-verifier-type"}, "cf-guid")
+			Expect(err).To(MatchError(ContainSubstring("could not make api request to enable_product_verifiers endpoint")))
+		})
+	})
 })
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sort"
+)
+
+// Error is returned for a response of Ops Manager with an unexpected
+// status. Use errors.As, or IsStatus, to tell the failures apart:
+//
+//	var apiErr *api.Error
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity {
+//		fmt.Println(apiErr.Errors)
+//	}
+type Error struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	Body       []byte
+
+	// Errors are the errors of the body, by the name of the field they are
+	// about. Errors that are not about a field, such as those of
+	// {"errors": ["..."]}, are under "base", as Ops Manager itself does.
+	Errors map[string][]string
+
+	// dump is the whole response, which the message includes
+	dump []byte
+}
+
+func newError(resp *http.Response) error {
+	var body []byte
+	dump, err := httputil.DumpResponse(resp, true)
+	if err == nil {
+		// DumpResponse replaces the body with a copy, which can be read again
+		body, _ = io.ReadAll(resp.Body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	} else {
+		// the body was closed, or broke off, before the status was checked,
+		// so the error has the status and headers only
+		dump, _ = httputil.DumpResponse(resp, false)
+		dump = fmt.Appendf(dump, "(could not read the body: %s)", err)
+	}
+
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Endpoint:   requestPath(resp),
+		Body:       body,
+		Errors:     parseErrors(body),
+		dump:       dump,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+	}
+
+	return apiErr
+}
+
+func (e *Error) Error() string {
+	var from string
+	if e.Endpoint != "" {
+		from = " from " + e.Endpoint
+	}
+
+	return fmt.Sprintf("request failed: unexpected response%s:\n%s", from, e.dump)
+}
+
+// Messages lists the Errors, sorted, with the name of their field unless it
+// is "base".
+func (e *Error) Messages() []string {
+	var messages []string
+	for field, fieldErrors := range e.Errors {
+		for _, message := range fieldErrors {
+			if field != "base" {
+				message = field + ": " + message
+			}
+			messages = append(messages, message)
+		}
+	}
+	sort.Strings(messages)
+
+	return messages
+}
+
+// IsStatus is true when err is, or wraps, an Error with one of the statuses.
+func IsStatus(err error, statuses ...int) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	for _, status := range statuses {
+		if apiErr.StatusCode == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func requestPath(resp *http.Response) string {
+	if resp.Request == nil || resp.Request.URL == nil {
+		return ""
+	}
+
+	return resp.Request.URL.Path
+}
+
+// parseErrors reads the shapes of errors that Ops Manager and UAA respond
+// with: {"errors": {"field": ["..."]}}, {"errors": ["..."]} and
+// {"error": "...", "error_description": "..."}.
+func parseErrors(body []byte) map[string][]string {
+	var response struct {
+		Errors           json.RawMessage `json:"errors"`
+		Error            string          `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+	}
+	if json.Unmarshal(body, &response) != nil {
+		return nil
+	}
+
+	parsed := map[string][]string{}
+
+	var byField map[string]json.RawMessage
+	var list []string
+	switch {
+	case json.Unmarshal(response.Errors, &byField) == nil:
+		for field, raw := range byField {
+			var messages []string
+			var message string
+			switch {
+			case json.Unmarshal(raw, &messages) == nil:
+				parsed[field] = messages
+			case json.Unmarshal(raw, &message) == nil:
+				parsed[field] = []string{message}
+			default:
+				parsed[field] = []string{string(raw)}
+			}
+		}
+	case json.Unmarshal(response.Errors, &list) == nil:
+		parsed["base"] = list
+	}
+
+	if response.Error != "" {
+		message := response.Error
+		if response.ErrorDescription != "" {
+			message += ": " + response.ErrorDescription
+		}
+		parsed["base"] = append(parsed["base"], message)
+	}
+
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	return parsed
+}
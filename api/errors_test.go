@@ -0,0 +1,134 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+var _ = Describe("Error", func() {
+	var (
+		client  *ghttp.Server
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = ghttp.NewServer()
+		service = api.New(api.ApiInput{
+			Client: httpClient{serverURI: client.URL()},
+		})
+	})
+
+	AfterEach(func() {
+		client.Close()
+	})
+
+	It("carries the status, endpoint and body of the response", func() {
+		client.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v0/staged/products"),
+				ghttp.RespondWith(http.StatusUnauthorized, `{"error": "invalid_token", "error_description": "the token expired"}`),
+			),
+		)
+
+		_, err := service.ListStagedProducts()
+
+		var apiErr *api.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(apiErr.Method).To(Equal("GET"))
+		Expect(apiErr.Endpoint).To(Equal("/api/v0/staged/products"))
+		Expect(string(apiErr.Body)).To(Equal(`{"error": "invalid_token", "error_description": "the token expired"}`))
+		Expect(apiErr.Messages()).To(Equal([]string{"invalid_token: the token expired"}))
+		Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response from /api/v0/staged/products:\nHTTP/1.1 401 Unauthorized")))
+	})
+
+	It("parses the errors of fields and of the request", func() {
+		client.AppendHandlers(
+			ghttp.RespondWith(http.StatusOK, `[]`),
+			ghttp.RespondWith(http.StatusOK, `[]`),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/api/v0/installations"),
+				ghttp.RespondWith(http.StatusUnprocessableEntity, `{"errors": {"base": ["the director is not configured"], "cf": ["is invalid", "has no stemcell"]}}`),
+			),
+		)
+
+		_, err := service.CreateInstallation(false, true, false, nil, api.ApplyErrandChanges{})
+		Expect(err).To(MatchError(ContainSubstring("Tip: In Ops Manager 2.6 or newer")))
+
+		var apiErr *api.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Errors).To(Equal(map[string][]string{
+			"base": {"the director is not configured"},
+			"cf":   {"is invalid", "has no stemcell"},
+		}))
+		Expect(apiErr.Messages()).To(Equal([]string{
+			"cf: has no stemcell",
+			"cf: is invalid",
+			"the director is not configured",
+		}))
+	})
+
+	It("puts a list of errors under base", func() {
+		client.AppendHandlers(
+			ghttp.RespondWith(http.StatusNotFound, `{"errors": ["the product was not found"]}`),
+		)
+
+		_, err := service.ListStagedProducts()
+
+		var apiErr *api.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.Errors).To(Equal(map[string][]string{"base": {"the product was not found"}}))
+	})
+
+	It("has no errors when the body is not JSON", func() {
+		client.AppendHandlers(
+			ghttp.RespondWith(http.StatusBadGateway, `<html>bad gateway</html>`),
+		)
+
+		_, err := service.ListStagedProducts()
+
+		var apiErr *api.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(apiErr.Errors).To(BeNil())
+		Expect(string(apiErr.Body)).To(Equal(`<html>bad gateway</html>`))
+	})
+
+	It("carries the status when the body was closed before the status was checked", func() {
+		client.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/api/v0/staged/director/verifiers/install_time/some-verifier"),
+				ghttp.RespondWith(http.StatusUnprocessableEntity, `{"errors": ["the verifier does not exist"]}`),
+			),
+		)
+
+		err := service.EnableDirectorVerifiers([]string{"some-verifier"})
+
+		var apiErr *api.Error
+		Expect(errors.As(err, &apiErr)).To(BeTrue())
+		Expect(apiErr.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+		Expect(apiErr.Method).To(Equal("PUT"))
+		Expect(apiErr.Body).To(BeEmpty())
+		Expect(api.IsStatus(err, http.StatusUnprocessableEntity)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("HTTP/1.1 422 Unprocessable Entity")))
+		Expect(err).To(MatchError(ContainSubstring("could not read the body")))
+	})
+
+	Describe("IsStatus", func() {
+		It("matches the statuses of a wrapped error", func() {
+			err := fmt.Errorf("could not list: %w", &api.Error{StatusCode: http.StatusNotFound})
+
+			Expect(api.IsStatus(err, http.StatusNotFound)).To(BeTrue())
+			Expect(api.IsStatus(err, http.StatusUnauthorized, http.StatusNotFound)).To(BeTrue())
+			Expect(api.IsStatus(err, http.StatusUnauthorized)).To(BeFalse())
+			Expect(api.IsStatus(errors.New("404"), http.StatusNotFound)).To(BeFalse())
+		})
+	})
+})
@@ -2,12 +2,18 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"strings"
 )
 
+// ErrDownloadInterrupted is wrapped by the error of an export that stopped
+// before the whole installation was read. Downloading it again resumes it.
+var ErrDownloadInterrupted = errors.New("download of installation interrupted")
+
 type ImportInstallationInput struct {
 	ContentLength   int64
 	Installation    io.Reader
@@ -15,29 +21,175 @@ type ImportInstallationInput struct {
 	PollingInterval int
 }
 
-func (a Api) DownloadInstallationAssetCollection(outputFile string) error {
-	resp, err := a.sendProgressAPIRequest("GET", "/api/v0/installation_asset_collection", nil)
+type ImportInstallationChunkInput struct {
+	Chunk       io.Reader
+	FileName    string
+	Passphrase  string
+	Offset      int64
+	ChunkLength int64
+	TotalLength int64
+}
+
+type ImportInstallationChunkOutput struct {
+	Offset   int64
+	Complete bool
+}
+
+type DownloadInstallationAssetCollectionInput struct {
+	OutputFile string
+	Resume     bool
+}
+
+// installationDownloadState is kept next to the partial export so an
+// interrupted download can continue where it stopped, as long as Ops Manager
+// still serves the same archive.
+type installationDownloadState struct {
+	ETag          string `json:"etag,omitempty"`
+	LastModified  string `json:"last_modified,omitempty"`
+	ContentLength int64  `json:"content_length"`
+}
+
+// validator returns the If-Range value for the archive. Weak ETags cannot be
+// used for ranged requests.
+func (s installationDownloadState) validator() string {
+	if s.ETag != "" && !strings.HasPrefix(s.ETag, "W/") {
+		return s.ETag
+	}
+
+	return s.LastModified
+}
+
+func (a Api) DownloadInstallationAssetCollection(input DownloadInstallationAssetCollectionInput) error {
+	partialFile := input.OutputFile + ".partial"
+	stateFile := partialFile + ".json"
+
+	var (
+		state  installationDownloadState
+		offset int64
+	)
+	if input.Resume {
+		state, offset = readInstallationDownloadState(partialFile, stateFile)
+	}
+
+	req, err := http.NewRequest("GET", "/api/v0/installation_asset_collection", nil)
+	if err != nil {
+		return err
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", state.validator())
+	}
+
+	resp, err := doRequest(a.progressClient, req)
 	if err != nil {
 		return fmt.Errorf("could not make api request to installation_asset_collection endpoint: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if err = validateStatusOK(resp); err != nil {
-		return err
+	var outputFileHandle *os.File
+	if offset > 0 && resp.StatusCode == http.StatusPartialContent {
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return fmt.Errorf("cannot resume download: unexpected content range %q for offset %d", resp.Header.Get("Content-Range"), offset)
+		}
+
+		a.logger.Println(fmt.Sprintf("resuming download of installation at %d of %d bytes", offset, state.ContentLength))
+
+		outputFileHandle, err = os.OpenFile(partialFile, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return fmt.Errorf("cannot open partial output file: %w", err)
+		}
+	} else {
+		if err = validateStatusOK(resp); err != nil {
+			return err
+		}
+
+		offset = 0
+		state = installationDownloadState{
+			ETag:          resp.Header.Get("ETag"),
+			LastModified:  resp.Header.Get("Last-Modified"),
+			ContentLength: resp.ContentLength,
+		}
+
+		outputFileHandle, err = os.Create(partialFile)
+		if err != nil {
+			return fmt.Errorf("cannot create output file: %w", err)
+		}
+
+		err = writeInstallationDownloadState(stateFile, state)
+		if err != nil {
+			outputFileHandle.Close()
+			return err
+		}
 	}
+	defer outputFileHandle.Close()
 
-	outputFileHandle, err := os.Create(outputFile)
+	bytesWritten, err := io.Copy(outputFileHandle, resp.Body)
 	if err != nil {
-		return fmt.Errorf("cannot create output file: %w", err)
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			return fmt.Errorf("cannot write output file: %w", err)
+		}
+
+		return fmt.Errorf("%w at %d of %d bytes: %w", ErrDownloadInterrupted, offset+bytesWritten, state.ContentLength, err)
 	}
 
-	bytesWritten, err := io.Copy(outputFileHandle, resp.Body)
+	if offset+bytesWritten < state.ContentLength {
+		return fmt.Errorf("%w at %d of %d bytes", ErrDownloadInterrupted, offset+bytesWritten, state.ContentLength)
+	}
+
+	if offset+bytesWritten != state.ContentLength {
+		return fmt.Errorf("invalid response length (expected %d, got %d)", state.ContentLength, offset+bytesWritten)
+	}
+
+	err = outputFileHandle.Close()
 	if err != nil {
 		return fmt.Errorf("cannot write output file: %w", err)
 	}
 
-	if bytesWritten != resp.ContentLength {
-		return fmt.Errorf("invalid response length (expected %d, got %d)", resp.ContentLength, bytesWritten)
+	err = os.Rename(partialFile, input.OutputFile)
+	if err != nil {
+		return fmt.Errorf("cannot move partial output file into place: %w", err)
+	}
+
+	_ = os.Remove(stateFile)
+
+	return nil
+}
+
+// readInstallationDownloadState returns how much of the export is on disk.
+// Anything that cannot be resumed safely yields an offset of zero, which
+// starts the download over.
+func readInstallationDownloadState(partialFile, stateFile string) (installationDownloadState, int64) {
+	var state installationDownloadState
+
+	contents, err := os.ReadFile(stateFile)
+	if err != nil {
+		return state, 0
+	}
+
+	err = json.Unmarshal(contents, &state)
+	if err != nil || state.validator() == "" || state.ContentLength <= 0 {
+		return state, 0
+	}
+
+	info, err := os.Stat(partialFile)
+	if err != nil || info.Size() >= state.ContentLength {
+		return state, 0
+	}
+
+	return state, info.Size()
+}
+
+func writeInstallationDownloadState(stateFile string, state installationDownloadState) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(stateFile, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot write download state file: %w", err)
 	}
 
 	return nil
@@ -66,6 +218,71 @@ func (a Api) UploadInstallationAssetCollection(input ImportInstallationInput) er
 	return nil
 }
 
+// UploadInstallationAssetCollectionChunk sends a single byte range of an
+// installation archive, acknowledged the same way as product chunks. The
+// passphrase travels in a header as the body is the raw archive.
+func (a Api) UploadInstallationAssetCollectionChunk(input ImportInstallationChunkInput) (ImportInstallationChunkOutput, error) {
+	req, err := http.NewRequest("POST", "/api/v0/installation_asset_collection", input.Chunk)
+	if err != nil {
+		return ImportInstallationChunkOutput{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", input.FileName))
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", input.Offset, input.Offset+input.ChunkLength-1, input.TotalLength))
+	req.Header.Set("X-Decryption-Passphrase", input.Passphrase)
+	req.ContentLength = input.ChunkLength
+
+	resp, err := a.unauthedProgressClient.Do(req)
+	if err != nil {
+		return ImportInstallationChunkOutput{}, fmt.Errorf("could not make api request to installation_asset_collection endpoint: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPermanentRedirect {
+		offset, err := parseUploadRange("installation_asset_collection", resp.Header.Get("Range"))
+		if err != nil {
+			return ImportInstallationChunkOutput{}, err
+		}
+
+		return ImportInstallationChunkOutput{Offset: offset}, nil
+	}
+
+	if err = validateStatusOK(resp); err != nil {
+		return ImportInstallationChunkOutput{}, err
+	}
+
+	return ImportInstallationChunkOutput{Offset: input.TotalLength, Complete: true}, nil
+}
+
+// GetInstallationAssetCollectionUploadOffset asks Ops Manager how many bytes
+// of a previously interrupted chunked import it has already persisted. As for
+// products, any reply other than a 308 means the import starts over.
+func (a Api) GetInstallationAssetCollectionUploadOffset(fileName string, totalLength int64) (int64, error) {
+	req, err := http.NewRequest("POST", "/api/v0/installation_asset_collection", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", totalLength))
+
+	resp, err := a.unauthedClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("could not make api request to installation_asset_collection endpoint: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusPermanentRedirect {
+		return parseUploadRange("installation_asset_collection", resp.Header.Get("Range"))
+	}
+
+	return 0, nil
+}
+
 func (a Api) DeleteInstallationAssetCollection() (InstallationsServiceOutput, error) {
 	resp, err := a.sendAPIRequest("DELETE", "/api/v0/installation_asset_collection", []byte(`{"errands": {}}`))
 	if err != nil {
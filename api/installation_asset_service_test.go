@@ -1,10 +1,14 @@
 package api_test
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/ghttp"
 
 	"github.com/pivotal-cf/om/api"
@@ -19,16 +23,20 @@ var _ = Describe("InstallationAssetService", func() {
 		progressClient         *ghttp.Server
 		unauthedProgressClient *ghttp.Server
 		service                api.Api
+		stderr                 *gbytes.Buffer
 	)
 
 	BeforeEach(func() {
 		client = ghttp.NewServer()
 		progressClient = ghttp.NewServer()
 		unauthedProgressClient = ghttp.NewServer()
+		stderr = gbytes.NewBuffer()
 		service = api.New(api.ApiInput{
 			Client:                 httpClient{serverURI: client.URL()},
+			UnauthedClient:         httpClient{serverURI: client.URL()},
 			ProgressClient:         httpClient{serverURI: progressClient.URL()},
 			UnauthedProgressClient: httpClient{serverURI: unauthedProgressClient.URL()},
+			Logger:                 log.New(stderr, "", 0),
 		})
 	})
 
@@ -40,18 +48,11 @@ var _ = Describe("InstallationAssetService", func() {
 
 	Describe("DownloadInstallationAssetCollection", func() {
 		var (
-			outputFile *os.File
+			outputFile string
 		)
 
 		BeforeEach(func() {
-			var err error
-			outputFile, err = os.CreateTemp("", "")
-			Expect(err).ToNot(HaveOccurred())
-		})
-
-		AfterEach(func() {
-			err := os.Remove(outputFile.Name())
-			Expect(err).ToNot(HaveOccurred())
+			outputFile = filepath.Join(GinkgoT().TempDir(), "installation.zip")
 		})
 
 		It("makes a request to export the current Ops Manager installation", func() {
@@ -62,20 +63,135 @@ var _ = Describe("InstallationAssetService", func() {
 				),
 			)
 
-			err := service.DownloadInstallationAssetCollection(outputFile.Name())
+			err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+				OutputFile: outputFile,
+			})
 			Expect(err).ToNot(HaveOccurred())
 
 			By("writing the installation to a local file")
-			ins, err := os.ReadFile(outputFile.Name())
+			ins, err := os.ReadFile(outputFile)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(ins)).To(Equal("some-installation"))
+
+			By("cleaning up the partial download")
+			Expect(outputFile + ".partial").ToNot(BeAnExistingFile())
+			Expect(outputFile + ".partial.json").ToNot(BeAnExistingFile())
+		})
+
+		When("a previous download was interrupted", func() {
+			BeforeEach(func() {
+				err := os.WriteFile(outputFile+".partial", []byte("some-"), 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = os.WriteFile(outputFile+".partial.json", []byte(`{"etag":"\"some-etag\"","content_length":17}`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("resumes the download where it stopped", func() {
+				progressClient.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/installation_asset_collection"),
+						ghttp.VerifyHeaderKV("Range", "bytes=5-"),
+						ghttp.VerifyHeaderKV("If-Range", `"some-etag"`),
+						ghttp.RespondWith(http.StatusPartialContent, "installation", http.Header{
+							"Content-Range": []string{"bytes 5-16/17"},
+						}),
+					),
+				)
+
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: outputFile,
+					Resume:     true,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				ins, err := os.ReadFile(outputFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(ins)).To(Equal("some-installation"))
+				Expect(stderr).To(gbytes.Say("resuming download of installation at 5 of 17 bytes"))
+			})
+
+			It("starts over when the installation has changed since", func() {
+				progressClient.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/installation_asset_collection"),
+						ghttp.VerifyHeaderKV("Range", "bytes=5-"),
+						ghttp.RespondWith(http.StatusOK, "other-installation", http.Header{
+							"ETag": []string{`"other-etag"`},
+						}),
+					),
+				)
+
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: outputFile,
+					Resume:     true,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				ins, err := os.ReadFile(outputFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(ins)).To(Equal("other-installation"))
+			})
+
+			It("starts over when asked not to resume", func() {
+				progressClient.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/installation_asset_collection"),
+						func(w http.ResponseWriter, req *http.Request) {
+							Expect(req.Header.Get("Range")).To(BeEmpty())
+						},
+						ghttp.RespondWith(http.StatusOK, "some-installation"),
+					),
+				)
+
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: outputFile,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				ins, err := os.ReadFile(outputFile)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(ins)).To(Equal("some-installation"))
+			})
+		})
+
+		When("the response is cut short", func() {
+			It("keeps the partial download and its state to resume from", func() {
+				progressClient.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/installation_asset_collection"),
+						func(w http.ResponseWriter, req *http.Request) {
+							w.Header().Set("ETag", `"some-etag"`)
+							w.Header().Set("Content-Length", "17")
+							_, _ = w.Write([]byte("some-"))
+						},
+					),
+				)
+
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: outputFile,
+				})
+				Expect(err).To(MatchError(ContainSubstring("download of installation interrupted at 5 of 17 bytes")))
+				Expect(errors.Is(err, api.ErrDownloadInterrupted)).To(BeTrue())
+
+				Expect(outputFile).ToNot(BeAnExistingFile())
+				partial, err := os.ReadFile(outputFile + ".partial")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(partial)).To(Equal("some-"))
+
+				state, err := os.ReadFile(outputFile + ".partial.json")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(state).To(MatchJSON(`{"etag":"\"some-etag\"","content_length":17}`))
+			})
 		})
 
 		When("the client errors before the request", func() {
 			It("returns an error", func() {
 				progressClient.Close()
 
-				err := service.DownloadInstallationAssetCollection("fake-file")
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: outputFile,
+				})
 				Expect(err).To(MatchError(ContainSubstring("could not make api request to installation_asset_collection endpoint: could not send api request to GET /api/v0/installation_asset_collection")))
 			})
 		})
@@ -89,7 +205,9 @@ var _ = Describe("InstallationAssetService", func() {
 					),
 				)
 
-				err := service.DownloadInstallationAssetCollection("fake-file")
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: outputFile,
+				})
 				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
 			})
 		})
@@ -103,7 +221,9 @@ var _ = Describe("InstallationAssetService", func() {
 					),
 				)
 
-				err := service.DownloadInstallationAssetCollection("fake-dir/fake-file")
+				err := service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+					OutputFile: "fake-dir/fake-file",
+				})
 				Expect(err).To(MatchError(ContainSubstring("no such file")))
 			})
 		})
@@ -164,6 +284,125 @@ var _ = Describe("InstallationAssetService", func() {
 		})
 	})
 
+	Describe("UploadInstallationAssetCollectionChunk", func() {
+		It("sends the chunk with its byte range and the passphrase", func() {
+			unauthedProgressClient.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/installation_asset_collection"),
+					ghttp.VerifyContentType("application/octet-stream"),
+					ghttp.VerifyHeaderKV("Content-Range", "bytes 0-3/12"),
+					ghttp.VerifyHeaderKV("Content-Disposition", `attachment; filename="installation.zip"`),
+					ghttp.VerifyHeaderKV("X-Decryption-Passphrase", "some-passphrase"),
+					ghttp.VerifyBody([]byte("some")),
+					ghttp.RespondWith(http.StatusPermanentRedirect, nil, http.Header{
+						"Range": []string{"bytes=0-3"},
+					}),
+				),
+			)
+
+			output, err := service.UploadInstallationAssetCollectionChunk(api.ImportInstallationChunkInput{
+				Chunk:       strings.NewReader("some"),
+				FileName:    "installation.zip",
+				Passphrase:  "some-passphrase",
+				Offset:      0,
+				ChunkLength: 4,
+				TotalLength: 12,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal(api.ImportInstallationChunkOutput{Offset: 4}))
+		})
+
+		It("reports completion once the final chunk is accepted", func() {
+			unauthedProgressClient.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/installation_asset_collection"),
+					ghttp.VerifyHeaderKV("Content-Range", "bytes 4-11/12"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			output, err := service.UploadInstallationAssetCollectionChunk(api.ImportInstallationChunkInput{
+				Chunk:       strings.NewReader(" content"),
+				FileName:    "installation.zip",
+				Offset:      4,
+				ChunkLength: 8,
+				TotalLength: 12,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal(api.ImportInstallationChunkOutput{Offset: 12, Complete: true}))
+		})
+
+		When("the api returns an invalid Range header", func() {
+			It("returns an error", func() {
+				unauthedProgressClient.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v0/installation_asset_collection"),
+						ghttp.RespondWith(http.StatusPermanentRedirect, nil, http.Header{
+							"Range": []string{"bytes=4-junk"},
+						}),
+					),
+				)
+
+				_, err := service.UploadInstallationAssetCollectionChunk(api.ImportInstallationChunkInput{
+					Chunk:       strings.NewReader("some"),
+					ChunkLength: 4,
+					TotalLength: 12,
+				})
+				Expect(err).To(MatchError(`could not parse Range header from installation_asset_collection endpoint: "bytes=4-junk"`))
+			})
+		})
+
+		When("the api returns a non-200 status code", func() {
+			It("returns an error", func() {
+				unauthedProgressClient.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v0/installation_asset_collection"),
+						ghttp.RespondWith(http.StatusTeapot, `{}`),
+					),
+				)
+
+				_, err := service.UploadInstallationAssetCollectionChunk(api.ImportInstallationChunkInput{
+					Chunk:       strings.NewReader("some"),
+					ChunkLength: 4,
+					TotalLength: 12,
+				})
+				Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+			})
+		})
+	})
+
+	Describe("GetInstallationAssetCollectionUploadOffset", func() {
+		It("returns the offset of the next byte to upload", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/installation_asset_collection"),
+					ghttp.VerifyHeaderKV("Content-Range", "bytes */12"),
+					ghttp.VerifyHeaderKV("Content-Disposition", `attachment; filename="installation.zip"`),
+					ghttp.RespondWith(http.StatusPermanentRedirect, nil, http.Header{
+						"Range": []string{"bytes=0-7"},
+					}),
+				),
+			)
+
+			offset, err := service.GetInstallationAssetCollectionUploadOffset("installation.zip", 12)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(int64(8)))
+		})
+
+		It("returns zero when the api does not acknowledge the persisted bytes", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/installation_asset_collection"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			offset, err := service.GetInstallationAssetCollectionUploadOffset("installation.zip", 12)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(offset).To(Equal(int64(0)))
+		})
+	})
+
 	Describe("DeleteInstallationAssetCollection", func() {
 		It("makes a request to delete the installation on the Ops Manager", func() {
 			client.AppendHandlers(
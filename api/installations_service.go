@@ -132,7 +132,7 @@ func (a Api) CreateInstallation(ignoreWarnings bool, deployProducts bool, forceL
 
 	if err = validateStatusOK(resp); err != nil {
 		if resp.StatusCode == http.StatusUnprocessableEntity {
-			err = fmt.Errorf("%s\n%s", err.Error(), "Tip: In Ops Manager 2.6 or newer, you can use `om pre-deploy-check` to get a complete list of failed verifiers and om commands to disable them.")
+			err = fmt.Errorf("%w\n%s", err, "Tip: In Ops Manager 2.6 or newer, you can use `om pre-deploy-check` to get a complete list of failed verifiers and om commands to disable them.")
 		}
 
 		return InstallationsServiceOutput{}, err
@@ -195,6 +195,18 @@ func (a Api) GetInstallationLogs(id int) (InstallationsServiceOutput, error) {
 	return InstallationsServiceOutput{Logs: output.Logs}, nil
 }
 
+// AbortInstallation asks Ops Manager to stop the running installation. The
+// installation fails once BOSH has stopped its current task.
+func (a Api) AbortInstallation(id int) error {
+	resp, err := a.sendAPIRequest("POST", fmt.Sprintf("/api/v0/installations/%d/abort", id), nil)
+	if err != nil {
+		return fmt.Errorf("could not make api request to installations abort endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return validateStatusOK(resp)
+}
+
 func (a *Api) removeErrandsWithoutProductNameFlag(errands map[string]ProductErrand, productFlags []string) map[string]ProductErrand {
 	for productName := range errands {
 		shouldDelete := true
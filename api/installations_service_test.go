@@ -471,6 +471,43 @@ var _ = Describe("InstallationsService", func() {
 		})
 	})
 
+	Describe("AbortInstallation", func() {
+		It("aborts the installation", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v0/installations/3232/abort"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			err := service.AbortInstallation(3232)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the client returns a non-2XX", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/api/v0/installations/3232/abort"),
+						ghttp.RespondWith(http.StatusUnprocessableEntity, `{"errors": ["the installation has already finished"]}`),
+					),
+				)
+
+				err := service.AbortInstallation(3232)
+				Expect(api.IsStatus(err, http.StatusUnprocessableEntity)).To(BeTrue())
+			})
+		})
+
+		When("the client has an error during the request", func() {
+			It("returns an error", func() {
+				client.Close()
+
+				err := service.AbortInstallation(3232)
+				Expect(err).To(MatchError(ContainSubstring("could not make api request to installations abort endpoint")))
+			})
+		})
+	})
+
 	Describe("GetInstallationLogs", func() {
 		It("grabs the logs from the currently running installation", func() {
 			client.AppendHandlers(
@@ -42,7 +42,7 @@ func (a Api) ListStagedProductJobs(productGUID string) (map[string]string, error
 func (a Api) ConfigureJobResourceConfig(productGUID string, config map[string]interface{}) error {
 	jobs, err := a.ListStagedProductJobs(productGUID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch jobs: %s", err)
+		return fmt.Errorf("failed to fetch jobs: %w", err)
 	}
 
 	var names []string
@@ -60,22 +60,22 @@ func (a Api) ConfigureJobResourceConfig(productGUID string, config map[string]in
 
 		prop, err := a.getJSONProperties(config[name])
 		if err != nil {
-			return fmt.Errorf("could not unmarshall resource configuration for job %s: %v", name, err)
+			return fmt.Errorf("could not unmarshall resource configuration for job %s: %w", name, err)
 		}
 
 		jobProperties, err := a.GetStagedProductJobResourceConfig(productGUID, jobGUID)
 		if err != nil {
-			return fmt.Errorf("could not fetch existing job configuration for job %s: %s", name, err)
+			return fmt.Errorf("could not fetch existing job configuration for job %s: %w", name, err)
 		}
 
 		err = json.Unmarshal([]byte(prop), &jobProperties)
 		if err != nil {
-			return fmt.Errorf("failed to unmarshal jobProperties for job %s: %s", name, err)
+			return fmt.Errorf("failed to unmarshal jobProperties for job %s: %w", name, err)
 		}
 
 		err = a.updateStagedProductJobResourceConfig(productGUID, jobGUID, jobProperties)
 		if err != nil {
-			return fmt.Errorf("failed to configure resources for %s: %s", name, err)
+			return fmt.Errorf("failed to configure resources for %s: %w", name, err)
 		}
 	}
 
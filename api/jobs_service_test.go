@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -453,6 +454,27 @@ some-job:
 					Expect(err).To(MatchError(ContainSubstring("failed to configure resources for some-job")))
 				})
 			})
+
+			When("Ops Manager rejects the resource config", func() {
+				It("returns an error that wraps the status and errors of the response", func() {
+					client.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", "/api/v0/staged/products/some-product-guid/jobs/some-guid/resource_config"),
+							ghttp.RespondWith(http.StatusUnprocessableEntity, `{"errors": {"instances": ["must be greater than 0"]}}`),
+						),
+					)
+
+					err := service.ConfigureJobResourceConfig("some-product-guid", map[string]interface{}{
+						"some-job": map[interface{}]interface{}{"instances": 0},
+					})
+					Expect(err).To(MatchError(ContainSubstring("failed to configure resources for some-job")))
+					Expect(api.IsStatus(err, http.StatusUnprocessableEntity)).To(BeTrue())
+
+					var apiErr *api.Error
+					Expect(errors.As(err, &apiErr)).To(BeTrue())
+					Expect(apiErr.Errors).To(Equal(map[string][]string{"instances": {"must be greater than 0"}}))
+				})
+			})
 		})
 	})
 })
@@ -37,7 +37,7 @@ func (a Api) ListMultiStemcells() (ProductMultiStemcells, error) {
 	var productStemcells ProductMultiStemcells
 	err = json.NewDecoder(resp.Body).Decode(&productStemcells)
 	if err != nil {
-		return ProductMultiStemcells{}, fmt.Errorf("invalid JSON: %s", err)
+		return ProductMultiStemcells{}, fmt.Errorf("invalid JSON: %w", err)
 	}
 
 	return productStemcells, nil
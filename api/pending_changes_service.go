@@ -25,6 +25,7 @@ type ProductChange struct {
 	Action             string              `json:"action"`
 	Errands            []Errand            `json:"errands"`
 	CompletenessChecks *CompletenessChecks `json:"completeness_checks,omitempty"`
+	Reasons            []string            `json:"reasons,omitempty"`
 }
 
 func (a Api) ListStagedPendingChanges() (PendingChangesOutput, error) {
@@ -26,6 +26,13 @@ func sendRequest(client httpClient, method, endpoint string, jsonData []byte) (*
 
 	req.Header.Add("Content-Type", "application/json")
 
+	return doRequest(client, req)
+}
+
+func doRequest(client httpClient, req *http.Request) (*http.Response, error) {
+	// the clients resolve the URL of the request against the target
+	method, endpoint := req.Method, req.URL.String()
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("could not send api request to %s %s: %w", method, endpoint, err)
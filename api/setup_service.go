@@ -128,3 +128,34 @@ func (a Api) EnsureAvailability(input EnsureAvailabilityInput) (EnsureAvailabili
 
 	return EnsureAvailabilityOutput{Status: status}, nil
 }
+
+type unlockInput struct {
+	Passphrase string `json:"passphrase"`
+}
+
+// Unlock decrypts the installation with the passphrase, which is necessary
+// after the Ops Manager VM has been rebooted.
+func (a Api) Unlock(passphrase string) error {
+	payload, err := json.Marshal(unlockInput{Passphrase: passphrase})
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("PUT", "/api/v0/unlock", strings.NewReader(string(payload)))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := a.unauthedClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("could not make api request to unlock endpoint: %w", err)
+	}
+	defer response.Body.Close()
+
+	if err = validateStatusOK(response); err != nil {
+		return fmt.Errorf("could not unlock ops manager, check if the decryption passphrase is correct: %w", err)
+	}
+
+	return nil
+}
@@ -211,4 +211,43 @@ var _ = Describe("Setup", func() {
 			})
 		})
 	})
+
+	Describe("Unlock", func() {
+		It("decrypts the installation with the passphrase", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v0/unlock"),
+					ghttp.VerifyContentType("application/json"),
+					ghttp.VerifyJSON(`{"passphrase": "some-\"passphrase"}`),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			err := service.Unlock(`some-"passphrase`)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		When("the passphrase is not correct", func() {
+			It("returns an error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v0/unlock"),
+						ghttp.RespondWith(http.StatusForbidden, `{"errors": ["incorrect passphrase"]}`),
+					),
+				)
+
+				err := service.Unlock("some-passphrase")
+				Expect(err).To(MatchError(ContainSubstring("could not unlock ops manager, check if the decryption passphrase is correct")))
+			})
+		})
+
+		When("the request fails", func() {
+			It("returns an error", func() {
+				client.Close()
+
+				err := service.Unlock("some-passphrase")
+				Expect(err).To(MatchError(ContainSubstring("could not make api request to unlock endpoint")))
+			})
+		})
+	})
 })
@@ -29,6 +29,12 @@ type StagedProduct struct {
 	ProductVersion  string            `json:"product_version"`
 }
 
+type ProductDependency struct {
+	GUID           string `json:"guid"`
+	Type           string `json:"type"`
+	ProductVersion string `json:"product_version"`
+}
+
 type UnstageProductInput struct {
 	ProductName string `json:"name"`
 }
@@ -385,7 +391,7 @@ func (a Api) GetStagedProductManifest(guid string) (string, error) {
 	}
 	err = yaml.NewDecoder(resp.Body).Decode(&contents)
 	if err != nil {
-		return "", fmt.Errorf("could not parse json: %w", err)
+		return "", fmt.Errorf("could not parse manifest: %w", err)
 	}
 
 	manifest, err := yaml.Marshal(contents.Manifest)
@@ -449,6 +455,33 @@ func (a Api) GetStagedProductNetworksAndAZs(product string) (map[string]interfac
 	return networksResponse.Networks, nil
 }
 
+func (a Api) GetStagedProductDependencies(productGUID string) ([]ProductDependency, error) {
+	var dependenciesResponse struct {
+		Dependencies []ProductDependency `json:"dependencies"`
+	}
+
+	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/dependencies", productGUID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not make api request to staged product dependencies endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// older Ops Managers do not know about product dependencies
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err = validateStatusOK(resp); err != nil {
+		return nil, err
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&dependenciesResponse); err != nil {
+		return nil, fmt.Errorf("could not parse json: %w", err)
+	}
+
+	return dependenciesResponse.Dependencies, nil
+}
+
 func (a Api) fetchProductResource(guid, endpoint string) (*http.Response, error) {
 	resp, err := a.sendAPIRequest("GET", fmt.Sprintf("/api/v0/staged/products/%s/%s", guid, endpoint), nil)
 	if err != nil {
@@ -492,7 +525,7 @@ func (a Api) GetStagedProductJobMaxInFlight(productGUID string) (ProductJobMaxIn
 	var payload map[string]interface{}
 	err = json.Unmarshal(contents, &payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON response from server: %v", err)
+		return nil, fmt.Errorf("failed to unmarshal JSON response from server: %w", err)
 	}
 
 	ProductJobMaxInFlights, _ = payload["max_in_flight"].(map[string]interface{})
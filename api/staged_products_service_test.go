@@ -911,7 +911,7 @@ key-4: 2147483648
 			})
 		})
 
-		When("the returned JSON is invalid", func() {
+		When("the returned manifest is invalid", func() {
 			It("returns an error", func() {
 				client.AppendHandlers(
 					ghttp.CombineHandlers(
@@ -921,7 +921,7 @@ key-4: 2147483648
 				)
 
 				_, err := service.GetStagedProductManifest("some-product-guid")
-				Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+				Expect(err).To(MatchError(ContainSubstring("could not parse manifest")))
 			})
 		})
 	})
@@ -1033,6 +1033,74 @@ key-4: 2147483648
 		})
 	})
 
+	Describe("GetStagedProductDependencies", func() {
+		It("returns the products the product depends on", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v0/staged/products/some-product-guid/dependencies"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"dependencies": [{
+							"guid": "p-mysql-guid",
+							"type": "p-mysql",
+							"product_version": "2.10.0"
+						}]
+					}`),
+				),
+			)
+
+			dependencies, err := service.GetStagedProductDependencies("some-product-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dependencies).To(Equal([]api.ProductDependency{
+				{GUID: "p-mysql-guid", Type: "p-mysql", ProductVersion: "2.10.0"},
+			}))
+		})
+
+		When("the Ops Manager does not support product dependencies", func() {
+			It("returns no dependencies without error", func() {
+				client.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v0/staged/products/some-product-guid/dependencies"),
+						ghttp.RespondWith(http.StatusNotFound, ``),
+					),
+				)
+
+				dependencies, err := service.GetStagedProductDependencies("some-product-guid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(dependencies).To(BeEmpty())
+			})
+		})
+
+		Context("failure cases", func() {
+			When("the server returns an unexpected status code", func() {
+				It("returns an error", func() {
+					client.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v0/staged/products/some-product-guid/dependencies"),
+							ghttp.RespondWith(http.StatusTeapot, ``),
+						),
+					)
+
+					_, err := service.GetStagedProductDependencies("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+				})
+			})
+
+			When("the server returns invalid json", func() {
+				It("returns an error", func() {
+					client.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v0/staged/products/some-product-guid/dependencies"),
+							ghttp.RespondWith(http.StatusOK, `invalid-json`),
+						),
+					)
+
+					_, err := service.GetStagedProductDependencies("some-product-guid")
+					Expect(err).To(MatchError(ContainSubstring("could not parse json")))
+				})
+			})
+		})
+	})
+
 	Describe("GetStagedProductNetworksAndAZs", func() {
 		It("returns the networks + azs for a product", func() {
 			client.AppendHandlers(
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"path/filepath"
 )
 
@@ -33,7 +34,7 @@ func (a Api) ListStemcells() (ProductStemcells, error) {
 	var productStemcells ProductStemcells
 	err = json.NewDecoder(resp.Body).Decode(&productStemcells)
 	if err != nil {
-		return ProductStemcells{}, fmt.Errorf("invalid JSON: %s", err)
+		return ProductStemcells{}, fmt.Errorf("invalid JSON: %w", err)
 	}
 
 	return productStemcells, nil
@@ -60,7 +61,7 @@ func (a Api) AssignStemcell(input ProductStemcells) error {
 func (a Api) CheckStemcellAvailability(stemcellFilename string) (bool, error) {
 	report, err := a.GetDiagnosticReport()
 	if err != nil {
-		return false, fmt.Errorf("failed to get diagnostic report: %s", err)
+		return false, fmt.Errorf("failed to get diagnostic report: %w", err)
 	}
 
 	info, err := a.Info()
@@ -70,7 +71,7 @@ func (a Api) CheckStemcellAvailability(stemcellFilename string) (bool, error) {
 
 	validVersion, err := info.VersionAtLeast(2, 6)
 	if err != nil {
-		return false, fmt.Errorf("could not determine version was 2.6+ compatible: %s", err)
+		return false, fmt.Errorf("could not determine version was 2.6+ compatible: %w", err)
 	}
 
 	if validVersion {
@@ -89,3 +90,15 @@ func (a Api) CheckStemcellAvailability(stemcellFilename string) (bool, error) {
 
 	return false, nil
 }
+
+// DeleteStemcell deletes a stemcell uploaded to Ops Manager. Ops Manager
+// refuses to delete a stemcell that a staged or deployed product uses.
+func (a Api) DeleteStemcell(stemcellFilename string) error {
+	resp, err := a.sendAPIRequest("DELETE", fmt.Sprintf("/api/v0/stemcells/%s", url.PathEscape(filepath.Base(stemcellFilename))), nil)
+	if err != nil {
+		return fmt.Errorf("could not make api request to stemcells endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return validateStatusOK(resp)
+}
@@ -273,4 +273,30 @@ var _ = Describe("StemcellService", func() {
 			})
 		})
 	})
+
+	Describe("DeleteStemcell", func() {
+		It("deletes the stemcell", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v0/stemcells/light-bosh-stemcell-621.79-google-kvm-ubuntu-xenial-go_agent.tgz"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			err := service.DeleteStemcell("light-bosh-stemcell-621.79-google-kvm-ubuntu-xenial-go_agent.tgz")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when the stemcell cannot be deleted", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("DELETE", "/api/v0/stemcells/some-stemcell.tgz"),
+					ghttp.RespondWith(http.StatusUnprocessableEntity, `{"errors": ["stemcell is in use"]}`),
+				),
+			)
+
+			err := service.DeleteStemcell("some-stemcell.tgz")
+			Expect(err).To(MatchError(ContainSubstring("request failed")))
+		})
+	})
 })
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// UAAUser is the user om is authenticated as.
+type UAAUser struct {
+	ID       string `json:"user_id"`
+	UserName string `json:"user_name"`
+}
+
+func (a Api) GetCurrentUAAUser() (UAAUser, error) {
+	resp, err := a.sendAPIRequest("GET", "/uaa/userinfo", nil)
+	if err != nil {
+		return UAAUser{}, fmt.Errorf("failed to submit request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err = validateStatusOK(resp); err != nil {
+		return UAAUser{}, err
+	}
+
+	var user UAAUser
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return UAAUser{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return user, nil
+}
+
+// ChangeUAAUserPassword changes the password of a UAA user. UAA only lets
+// users change their own password when the old password is given.
+func (a Api) ChangeUAAUserPassword(userID, oldPassword, newPassword string) error {
+	body, err := json.Marshal(map[string]string{
+		"oldPassword": oldPassword,
+		"password":    newPassword,
+	})
+	if err != nil {
+		return err // not tested
+	}
+
+	resp, err := a.sendAPIRequest("PUT", fmt.Sprintf("/uaa/Users/%s/password", url.PathEscape(userID)), body)
+	if err != nil {
+		return fmt.Errorf("failed to submit request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return validateStatusOK(resp)
+}
+
+// ChangeUAAClientSecret changes the secret of a UAA client. The old secret
+// may be empty when the token of om has the clients.secret scope.
+func (a Api) ChangeUAAClientSecret(clientID, oldSecret, newSecret string) error {
+	secret := map[string]string{
+		"clientId": clientID,
+		"secret":   newSecret,
+	}
+	if oldSecret != "" {
+		secret["oldSecret"] = oldSecret
+	}
+
+	body, err := json.Marshal(secret)
+	if err != nil {
+		return err // not tested
+	}
+
+	resp, err := a.sendAPIRequest("PUT", fmt.Sprintf("/uaa/oauth/clients/%s/secret", url.PathEscape(clientID)), body)
+	if err != nil {
+		return fmt.Errorf("failed to submit request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return validateStatusOK(resp)
+}
@@ -0,0 +1,109 @@
+package api_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+var _ = Describe("UAA", func() {
+	var (
+		client  *ghttp.Server
+		service api.Api
+	)
+
+	BeforeEach(func() {
+		client = ghttp.NewServer()
+
+		service = api.New(api.ApiInput{
+			Client: httpClient{client.URL()},
+		})
+	})
+
+	AfterEach(func() {
+		client.Close()
+	})
+
+	Describe("GetCurrentUAAUser", func() {
+		It("gets the user om is authenticated as", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/uaa/userinfo"),
+					ghttp.RespondWith(http.StatusOK, `{"user_id": "some-user-id", "user_name": "admin"}`),
+				),
+			)
+
+			user, err := service.GetCurrentUAAUser()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user).To(Equal(api.UAAUser{ID: "some-user-id", UserName: "admin"}))
+		})
+
+		It("returns an error when the response is not 200", func() {
+			client.AppendHandlers(ghttp.RespondWith(http.StatusForbidden, `{}`))
+
+			_, err := service.GetCurrentUAAUser()
+			Expect(err).To(MatchError(ContainSubstring("request failed: unexpected response")))
+		})
+	})
+
+	Describe("ChangeUAAUserPassword", func() {
+		It("changes the password of the user", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/uaa/Users/some-user-id/password"),
+					ghttp.VerifyJSON(`{"oldPassword": "old-password", "password": "new-password"}`),
+					ghttp.RespondWith(http.StatusOK, `{"status": "ok"}`),
+				),
+			)
+
+			err := service.ChangeUAAUserPassword("some-user-id", "old-password", "new-password")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when the password is rejected", func() {
+			client.AppendHandlers(ghttp.RespondWith(http.StatusUnprocessableEntity, `{"error_description": "Password must contain at least 1 special characters."}`))
+
+			err := service.ChangeUAAUserPassword("some-user-id", "old-password", "new-password")
+			Expect(err).To(MatchError(ContainSubstring("Password must contain at least 1 special characters.")))
+		})
+	})
+
+	Describe("ChangeUAAClientSecret", func() {
+		It("changes the secret of the client", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/uaa/oauth/clients/some-client/secret"),
+					ghttp.VerifyJSON(`{"clientId": "some-client", "oldSecret": "old-secret", "secret": "new-secret"}`),
+					ghttp.RespondWith(http.StatusOK, `{"status": "ok"}`),
+				),
+			)
+
+			err := service.ChangeUAAClientSecret("some-client", "old-secret", "new-secret")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("leaves out the old secret when it is not given", func() {
+			client.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/uaa/oauth/clients/some-client/secret"),
+					ghttp.VerifyJSON(`{"clientId": "some-client", "secret": "new-secret"}`),
+					ghttp.RespondWith(http.StatusOK, `{"status": "ok"}`),
+				),
+			)
+
+			err := service.ChangeUAAClientSecret("some-client", "", "new-secret")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when the request fails to submit", func() {
+			client.Close()
+
+			err := service.ChangeUAAClientSecret("some-client", "", "new-secret")
+			Expect(err).To(MatchError(ContainSubstring("failed to submit request")))
+		})
+	})
+})
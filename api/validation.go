@@ -1,9 +1,7 @@
 package api
 
 import (
-	"fmt"
 	"net/http"
-	"net/http/httputil"
 )
 
 func validateStatusOK(resp *http.Response) error {
@@ -19,17 +17,7 @@ func validateStatusOKOrVerificationWarning(resp *http.Response, ignoreVerifierWa
 
 func validateStatus(resp *http.Response, status int) error {
 	if resp.StatusCode != status {
-		var requestURL string
-		if resp.Request != nil {
-			requestURL = fmt.Sprintf(" from %s", resp.Request.URL.Path)
-		}
-
-		out, err := httputil.DumpResponse(resp, true)
-		if err != nil {
-			return fmt.Errorf("request failed: unexpected response from %s: %w", requestURL, err)
-		}
-
-		return fmt.Errorf("request failed: unexpected response%s:\n%s", requestURL, out)
+		return newError(resp)
 	}
 
 	return nil
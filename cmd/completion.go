@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/jessevdk/go-flags"
+
+	"github.com/pivotal-cf/om/commands"
+)
+
+// describeCommands describes om, with its global flags, and every command
+// registered with parser for the completion scripts. Hidden commands and
+// flags are left out.
+func describeCommands(parser *flags.Parser) commands.CompletionCommand {
+	root := describeCommand(parser.Command)
+	root.Name = parser.Name
+
+	return root
+}
+
+func describeCommand(command *flags.Command) commands.CompletionCommand {
+	description := commands.CompletionCommand{
+		Name:        command.Name,
+		Description: command.ShortDescription,
+	}
+
+	for _, option := range collectAllOptions(command.Group) {
+		if option.Hidden {
+			continue
+		}
+
+		flag := commands.CompletionFlag{
+			Long:        option.LongNameWithNamespace(),
+			Description: option.Description,
+			Choices:     option.Choices,
+		}
+		if option.ShortName != 0 {
+			flag.Short = string(option.ShortName)
+		}
+
+		value := option.Value()
+		_, isBool := value.(*bool)
+		_, isBoolSlice := value.(*[]bool)
+		flag.TakesValue = !(isBool || isBoolSlice)
+
+		description.Flags = append(description.Flags, flag)
+	}
+
+	for _, subcommand := range command.Commands() {
+		if !subcommand.Hidden {
+			description.Commands = append(description.Commands, describeCommand(subcommand))
+		}
+	}
+
+	return description
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("completion", func() {
+	It("completes the registered commands and their flags", func() {
+		stdout := gbytes.NewBuffer()
+
+		err := Main(stdout, gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "completion", "bash"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say(`"vm-lifecycle"\) echo "create-vm `))
+		Expect(string(stdout.Contents())).To(ContainSubstring(`"configure-product") echo "--config -c `))
+		Expect(string(stdout.Contents())).To(ContainSubstring(`"staged-config --product-name"|"staged-config -p") om ${3:+--env "$3"} completion --list staged-products`))
+		Expect(string(stdout.Contents())).To(ContainSubstring(`" --log-format") echo "text json" ;;`))
+		Expect(string(stdout.Contents())).ToNot(ContainSubstring("--pivnet-file-glob"))
+	})
+})
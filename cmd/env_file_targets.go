@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// selectEnvFileTarget returns the env file with the target named by target
+// merged over its top-level options, when the env file has targets:
+//
+//	username: admin
+//	targets:
+//	  prod:
+//	    target: https://opsman.prod.example.com
+//	    password: ...
+//	  staging:
+//	    target: https://opsman.staging.example.com
+//
+// It also returns whether target named one of them, and not the location
+// of an Ops Manager.
+func selectEnvFileTarget(contents []byte, target string) ([]byte, bool, error) {
+	var envFile yaml.MapSlice
+	err := yaml.Unmarshal(contents, &envFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not parse env file: %s", err)
+	}
+
+	var (
+		options yaml.MapSlice
+		targets yaml.MapSlice
+		found   bool
+	)
+	for _, item := range envFile {
+		if item.Key != "targets" {
+			options = append(options, item)
+			continue
+		}
+
+		found = true
+		targets, err = toMapSlice(item.Value)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not parse targets of env file: %s", err)
+		}
+	}
+
+	if !found {
+		return contents, false, nil
+	}
+
+	names := map[string]yaml.MapSlice{}
+	for _, item := range targets {
+		name := fmt.Sprint(item.Key)
+		names[name], err = toMapSlice(item.Value)
+		if err != nil {
+			return nil, false, fmt.Errorf("could not parse target %s of env file: %s", name, err)
+		}
+	}
+
+	selected, named := names[target]
+	switch {
+	case named:
+	case target != "" && !strings.ContainsAny(target, ".:/"):
+		return nil, false, fmt.Errorf("env file has no target %q, the targets are: %s", target, strings.Join(sortedNames(names), ", "))
+	case target == "" && len(names) == 1:
+		selected = names[fmt.Sprint(targets[0].Key)]
+	case target == "" && !hasKey(options, "target"):
+		return nil, false, fmt.Errorf("env file has several targets, select one with --target or OM_TARGET: %s", strings.Join(sortedNames(names), ", "))
+	}
+
+	for _, item := range selected {
+		options = setKey(options, item)
+	}
+
+	contents, err = yaml.Marshal(options)
+	return contents, named, err
+}
+
+func toMapSlice(value interface{}) (yaml.MapSlice, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	mapSlice, ok := value.(yaml.MapSlice)
+	if !ok {
+		return nil, fmt.Errorf("expected a map, got %T", value)
+	}
+
+	return mapSlice, nil
+}
+
+func setKey(mapSlice yaml.MapSlice, item yaml.MapItem) yaml.MapSlice {
+	for i := range mapSlice {
+		if mapSlice[i].Key == item.Key {
+			mapSlice[i].Value = item.Value
+			return mapSlice
+		}
+	}
+
+	return append(mapSlice, item)
+}
+
+func hasKey(mapSlice yaml.MapSlice, key string) bool {
+	for _, item := range mapSlice {
+		if item.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedNames(targets map[string]yaml.MapSlice) []string {
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
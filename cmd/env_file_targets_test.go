@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("env file targets", func() {
+	var envFile string
+
+	BeforeEach(func() {
+		envFile = filepath.Join(GinkgoT().TempDir(), "env.yml")
+		Expect(os.WriteFile(envFile, []byte(`
+username: admin
+skip-ssl-validation: true
+targets:
+  prod:
+    target: https://opsman.prod.example.com
+    password: prod-password
+  staging:
+    target: https://opsman.staging.example.com
+    password: staging-password
+    skip-ssl-validation: false
+`), 0600)).To(Succeed())
+	})
+
+	It("uses the target named by --target over the top-level options", func() {
+		global := options{Env: envFile, Target: "staging"}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.Target).To(Equal("https://opsman.staging.example.com"))
+		Expect(global.Username).To(Equal("admin"))
+		Expect(global.Password).To(Equal("staging-password"))
+		Expect(global.SkipSSLValidation).To(BeFalse())
+	})
+
+	It("uses the top-level options of the env file for the other targets", func() {
+		global := options{Env: envFile, Target: "prod"}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.Target).To(Equal("https://opsman.prod.example.com"))
+		Expect(global.Password).To(Equal("prod-password"))
+		Expect(global.SkipSSLValidation).To(BeTrue())
+	})
+
+	It("uses the other options of the env file with the location of an Ops Manager", func() {
+		global := options{Env: envFile, Target: "https://opsman.example.com"}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.Target).To(Equal("https://opsman.example.com"))
+		Expect(global.Username).To(Equal("admin"))
+		Expect(global.Password).To(BeEmpty())
+	})
+
+	It("uses the only target without --target", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+targets:
+  prod:
+    target: https://opsman.prod.example.com
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.Target).To(Equal("https://opsman.prod.example.com"))
+	})
+
+	It("requires a target to be selected when there are several", func() {
+		global := options{Env: envFile}
+		err := setEnvFileProperties(&global)
+		Expect(err).To(MatchError("env file has several targets, select one with --target or OM_TARGET: prod, staging"))
+	})
+
+	It("returns an error for an unknown target", func() {
+		global := options{Env: envFile, Target: "dev"}
+		err := setEnvFileProperties(&global)
+		Expect(err).To(MatchError(`env file has no target "dev", the targets are: prod, staging`))
+	})
+
+	It("uses a request-backoff of 0 from the env file", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+target: https://opsman.example.com
+request-backoff: 0
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile, RequestBackoff: 1}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.RequestBackoff).To(Equal(0))
+	})
+
+	It("uses a rate-limit-retries of 0 from the env file", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+target: https://opsman.example.com
+rate-limit-retries: 0
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile, RateLimitRetries: 5}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.RateLimitRetries).To(Equal(0))
+	})
+
+	It("uses a gateway-grace-period of 0 from the env file", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+target: https://opsman.example.com
+gateway-grace-period: 0
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile, GatewayGracePeriod: 300}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.GatewayGracePeriod).To(Equal(0))
+	})
+
+	It("uses connection options of 0 from the env file", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+target: https://opsman.example.com
+idle-connection-timeout: 0
+tcp-keepalive: 0
+read-buffer-size: 0
+write-buffer-size: 0
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile, TCPKeepAlive: 30, ReadBufferSize: 4, WriteBufferSize: 4}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.IdleConnectionTimeout).To(Equal(0))
+		Expect(global.TCPKeepAlive).To(Equal(0))
+		Expect(global.ReadBufferSize).To(Equal(0))
+		Expect(global.WriteBufferSize).To(Equal(0))
+	})
+
+	It("uses the connection options of the env file", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+target: https://opsman.example.com
+idle-connection-timeout: 90
+tcp-keepalive: 15
+read-buffer-size: 64
+write-buffer-size: 32
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile, TCPKeepAlive: 30, ReadBufferSize: 4, WriteBufferSize: 4}
+		Expect(setEnvFileProperties(&global)).To(Succeed())
+
+		Expect(global.IdleConnectionTimeout).To(Equal(90))
+		Expect(global.TCPKeepAlive).To(Equal(15))
+		Expect(global.ReadBufferSize).To(Equal(64))
+		Expect(global.WriteBufferSize).To(Equal(32))
+	})
+
+	It("returns an error for an unknown option of a target", func() {
+		Expect(os.WriteFile(envFile, []byte(`
+targets:
+  prod:
+    target: https://opsman.prod.example.com
+    unknown: value
+`), 0600)).To(Succeed())
+
+		global := options{Env: envFile, Target: "prod"}
+		err := setEnvFileProperties(&global)
+		Expect(err).To(MatchError(ContainSubstring("could not parse env file: ")))
+	})
+})
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/network"
+)
+
+// newInitService reaches the Ops Manager given to init with the answers,
+// and the connection flags of om for everything init does not ask for.
+func newInitService(global options) func(commands.InitEnv) (commands.InitService, error) {
+	return func(env commands.InitEnv) (commands.InitService, error) {
+		requestTimeout := time.Duration(global.RequestTimeout) * time.Second
+		connectTimeout := time.Duration(global.ConnectTimeout) * time.Second
+
+		unauthenticatedClient, err := network.NewUnauthenticatedClient(env.Target, env.SkipSSLValidation, env.CACert, global.ClientCert, global.ClientKey, global.SOCKSProxy, connectTimeout, requestTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		oauthClient, err := network.NewOAuthClient(global.UAATarget, env.Target, env.Username, env.Password, env.ClientID, env.ClientSecret, env.SkipSSLValidation, env.CACert, global.ClientCert, global.ClientKey, global.SOCKSProxy, connectTimeout, requestTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		return api.New(api.ApiInput{
+			Client:                 oauthClient,
+			UnauthedClient:         unauthenticatedClient,
+			ProgressClient:         oauthClient,
+			UnauthedProgressClient: unauthenticatedClient,
+			Logger:                 log.New(io.Discard, "", 0),
+		}), nil
+	}
+}
@@ -25,6 +25,8 @@ func loadConfigFile(args []string, envFunc func() []string) ([]string, error) {
 		"configure-product",
 		"create-vm-extension",
 		"credentials",
+		"deploy-product",
+		"foreach",
 		"interpolate",
 		"nom",
 		"stage-product",
@@ -42,6 +44,8 @@ func loadConfigFile(args []string, envFunc func() []string) ([]string, error) {
 		VarsEnv    []string `long:"vars-env" env:"OM_VARS_ENV"`
 		VarsFile   []string `long:"vars-file"                  short:"l"`
 		Vars       []string `long:"var"                        short:"v"`
+		VarsStore  []string `long:"vars-store"`
+		Functions  bool     `long:"functions"`
 	}
 
 	parser := flags.NewParser(&config, flags.IgnoreUnknown)
@@ -60,6 +64,8 @@ func loadConfigFile(args []string, envFunc func() []string) ([]string, error) {
 		VarsEnvs:      config.VarsEnv,
 		VarsFiles:     config.VarsFile,
 		Vars:          config.Vars,
+		VarsStores:    config.VarsStore,
+		Functions:     config.Functions,
 		EnvironFunc:   envFunc,
 		OpsFiles:      nil,
 		ExpectAllKeys: true,
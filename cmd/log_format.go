@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+)
+
+// jsonLogWriter wraps every message written through it in a JSON object, so
+// logs from different om invocations can be indexed and correlated.
+type jsonLogWriter struct {
+	out       io.Writer
+	level     string
+	command   string
+	requestID string
+	now       func() time.Time
+}
+
+type jsonLogLine struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Command   string `json:"command,omitempty"`
+	RequestID string `json:"request_id"`
+	Message   string `json:"message"`
+}
+
+func newJSONLogWriter(out io.Writer, level, command, requestID string) *jsonLogWriter {
+	return &jsonLogWriter{
+		out:       out,
+		level:     level,
+		command:   command,
+		requestID: requestID,
+		now:       time.Now,
+	}
+}
+
+func (w *jsonLogWriter) Write(p []byte) (int, error) {
+	message := strings.TrimRight(string(p), "\n")
+	if strings.TrimSpace(message) == "" {
+		return len(p), nil
+	}
+
+	line, err := json.Marshal(jsonLogLine{
+		Timestamp: w.now().UTC().Format(time.RFC3339Nano),
+		Level:     w.level,
+		Command:   w.command,
+		RequestID: w.requestID,
+		Message:   message,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = w.out.Write(append(line, '\n'))
+	if err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// newRequestID identifies a single om invocation in its log lines.
+func newRequestID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
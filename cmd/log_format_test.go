@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"log"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("jsonLogWriter", func() {
+	var (
+		out    *gbytes.Buffer
+		writer *jsonLogWriter
+	)
+
+	BeforeEach(func() {
+		out = gbytes.NewBuffer()
+		writer = newJSONLogWriter(out, "info", "apply-changes", "some-request-id")
+		writer.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	})
+
+	It("prints every log message as a json object", func() {
+		logger := log.New(writer, "", 0)
+		logger.Println("attempting to apply changes")
+		logger.Printf("waiting for %s", "installation")
+
+		Expect(string(out.Contents())).To(Equal(
+			`{"timestamp":"2024-01-02T03:04:05Z","level":"info","command":"apply-changes","request_id":"some-request-id","message":"attempting to apply changes"}` + "\n" +
+				`{"timestamp":"2024-01-02T03:04:05Z","level":"info","command":"apply-changes","request_id":"some-request-id","message":"waiting for installation"}` + "\n",
+		))
+	})
+
+	It("skips empty messages", func() {
+		_, err := writer.Write([]byte("\n"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(out.Contents()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Main with --log-format json", func() {
+	It("prints logs as json", func() {
+		stdout, stderr := gbytes.NewBuffer(), gbytes.NewBuffer()
+		defer log.SetFlags(log.LstdFlags)
+		defer log.SetOutput(GinkgoWriter)
+
+		err := Main(stdout, stderr, "1.0.0", "1ms", []string{"om", "--log-format", "json", "--target", "https://example.com", "--skip-ssl-validation", "interpolate", "--config", "/does/not/exist"})
+		Expect(err).To(HaveOccurred())
+
+		// main prints the error with the standard logger
+		log.Print(err)
+		Expect(stderr).To(gbytes.Say(`\{"timestamp":"[^"]+","level":"error","command":"interpolate","request_id":"[0-9a-f]{16}","message":"`))
+	})
+})
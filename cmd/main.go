@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -20,6 +21,7 @@ import (
 	"github.com/pivotal-cf/om/interpolate"
 	"github.com/pivotal-cf/om/network"
 	"github.com/pivotal-cf/om/presenters"
+	"github.com/pivotal-cf/om/progress"
 	"github.com/pivotal-cf/om/renderers"
 	"gopkg.in/yaml.v2"
 )
@@ -29,28 +31,56 @@ type httpClient interface {
 }
 
 type options struct {
-	CACert               string `yaml:"ca-cert" long:"ca-cert" env:"OM_CA_CERT" description:"OpsManager CA certificate path or value"`
-	ClientID             string `yaml:"client-id"             short:"c"  long:"client-id"             env:"OM_CLIENT_ID"                           description:"Client ID for the Ops Manager VM (not required for unauthenticated commands)"`
-	ClientSecret         string `yaml:"client-secret"         short:"s"  long:"client-secret"         env:"OM_CLIENT_SECRET"                       description:"Client Secret for the Ops Manager VM (not required for unauthenticated commands)"`
-	ConnectTimeout       int    `yaml:"connect-timeout"       short:"o"  long:"connect-timeout"       env:"OM_CONNECT_TIMEOUT"     default:"10"    description:"timeout in seconds to make TCP connections"`
-	DecryptionPassphrase string `yaml:"decryption-passphrase" short:"d"  long:"decryption-passphrase" env:"OM_DECRYPTION_PASSPHRASE"               description:"Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)"`
-	Env                  string `                             short:"e"  long:"env"                                                                description:"env file with login credentials"`
-	Password             string `yaml:"password"              short:"p"  long:"password"              env:"OM_PASSWORD"                            description:"admin password for the Ops Manager VM (not required for unauthenticated commands)"`
-	RequestTimeout       int    `yaml:"request-timeout"       short:"r"  long:"request-timeout"       env:"OM_REQUEST_TIMEOUT"     default:"1800"  description:"timeout in seconds for HTTP requests to Ops Manager"`
-	SkipSSLValidation    bool   `yaml:"skip-ssl-validation"   short:"k"  long:"skip-ssl-validation"   env:"OM_SKIP_SSL_VALIDATION"                 description:"skip ssl certificate validation during http requests"`
-	Target               string `yaml:"target"                short:"t"  long:"target"                env:"OM_TARGET"                              description:"location of the Ops Manager VM"`
-	UAATarget            string `yaml:"uaa-target"                       long:"uaa-target"            env:"OM_UAA_TARGET"                          description:"optional location of the Ops Manager UAA"`
-	Trace                bool   `yaml:"trace"                            long:"trace"                 env:"OM_TRACE"                               description:"prints HTTP requests and response payloads"`
-	Username             string `yaml:"username"              short:"u"  long:"username"              env:"OM_USERNAME"                            description:"admin username for the Ops Manager VM (not required for unauthenticated commands)"`
-	VarsEnv              string `                                        long:"vars-env"              env:"OM_VARS_ENV"                            description:"load vars from environment variables by specifying a prefix (e.g.: 'MY' to load MY_var=value)"`
-	Version              bool   `                             short:"v"  long:"version"                                                            description:"prints the om release version"`
+	CACert                string `yaml:"ca-cert" long:"ca-cert" env:"OM_CA_CERT" description:"OpsManager CA certificate path or value"`
+	CACertFile            string `yaml:"ca-cert-file"                     long:"ca-cert-file"          env:"OM_CA_CERT_FILE"                        description:"PEM bundle of one or more CA certificates to trust in addition to the system CAs and --ca-cert"`
+	CacheDir              string `yaml:"cache-dir"                        long:"cache-dir"             env:"OM_CACHE_DIR"                           description:"directory to cache the product and director metadata responses carrying an ETag in, revalidated on every request so unchanged metadata is not transferred again (disabled when not set)"`
+	ClientCert            string `yaml:"client-cert"                      long:"client-cert"           env:"OM_CLIENT_CERT"                         description:"client certificate path or value, presented to Ops Manager for mutual TLS"`
+	ClientID              string `yaml:"client-id"             short:"c"  long:"client-id"             env:"OM_CLIENT_ID"                           description:"Client ID for the Ops Manager VM (not required for unauthenticated commands)"`
+	ClientKey             string `yaml:"client-key"                       long:"client-key"            env:"OM_CLIENT_KEY"                          description:"private key path or value for the client certificate"`
+	ClientSecret          string `yaml:"client-secret"         short:"s"  long:"client-secret"         env:"OM_CLIENT_SECRET"                       description:"Client Secret for the Ops Manager VM (not required for unauthenticated commands)"`
+	ConnectTimeout        int    `yaml:"connect-timeout"       short:"o"  long:"connect-timeout"       env:"OM_CONNECT_TIMEOUT"     default:"10"    description:"timeout in seconds to make TCP connections"`
+	DecryptionPassphrase  string `yaml:"decryption-passphrase" short:"d"  long:"decryption-passphrase" env:"OM_DECRYPTION_PASSPHRASE"               description:"Passphrase to decrypt the installation if the Ops Manager VM has been rebooted (optional for most commands)"`
+	Env                   string `                             short:"e"  long:"env"                                                                description:"env file with login credentials"`
+	HTTP2                 bool   `yaml:"http2"                            long:"http2"                 env:"OM_HTTP2"                               description:"attempt HTTP/2 with Ops Manager instead of HTTP/1.1"`
+	GatewayGracePeriod    int    `yaml:"gateway-grace-period"             long:"gateway-grace-period"  env:"OM_GATEWAY_GRACE_PERIOD" default:"300"  description:"seconds to keep retrying GET requests that fail with 502, 503 or 504 while Ops Manager is restarting (0 disables)"`
+	IdleConnectionTimeout int    `yaml:"idle-connection-timeout"          long:"idle-connection-timeout" env:"OM_IDLE_CONNECTION_TIMEOUT"           description:"seconds after which idle connections to Ops Manager are closed (0 keeps them open)"`
+	LogFormat             string `                                        long:"log-format"            env:"OM_LOG_FORMAT"          default:"text"  choice:"text" choice:"json" description:"format of log messages written to stderr; json prints one object per line with timestamp, level, command and request id"`
+	NoProgress            bool   `                                        long:"no-progress"           env:"OM_NO_PROGRESS"                         description:"hide the progress of uploads, downloads and installations, e.g. in CI logs that do not render the bars"`
+	OTLPEndpoint          string `yaml:"otlp-endpoint"                    long:"otlp-endpoint"         env:"OM_OTLP_ENDPOINT"                       description:"OTLP HTTP endpoint to export traces and metrics of the API calls to, e.g. http://localhost:4318"`
+	Password              string `yaml:"password"              short:"p"  long:"password"              env:"OM_PASSWORD"                            description:"admin password for the Ops Manager VM (not required for unauthenticated commands)"`
+	ProgressFormat        string `                                        long:"progress-format"       env:"OM_PROGRESS_FORMAT"     default:"text"  choice:"text" choice:"json" description:"format of the progress of uploads, downloads and installations written to stderr; json prints one event per line with the phase, bytes, total and rate"`
+	Quiet                 bool   `                                        long:"quiet"                 env:"OM_QUIET"                               description:"same as --no-progress"`
+	RateLimitRetries      int    `yaml:"rate-limit-retries"               long:"rate-limit-retries"    env:"OM_RATE_LIMIT_RETRIES"  default:"5"     description:"number of times to wait and retry requests rejected with 429 Too Many Requests, honouring Retry-After (0 disables)"`
+	ReadBufferSize        int    `yaml:"read-buffer-size"                 long:"read-buffer-size"      env:"OM_READ_BUFFER_SIZE"    default:"4"     description:"size in KB of the read buffer of each connection"`
+	RequestBackoff        int    `yaml:"request-backoff"                  long:"request-backoff"       env:"OM_REQUEST_BACKOFF"     default:"1"     description:"initial delay in seconds before retrying a failed request, doubled (with jitter) on each subsequent retry (0 retries at once)"`
+	RequestRetries        int    `yaml:"request-retries"                  long:"request-retries"       env:"OM_REQUEST_RETRIES"     default:"0"     description:"number of times to retry idempotent HTTP requests that fail with a network error"`
+	RequestTimeout        int    `yaml:"request-timeout"       short:"r"  long:"request-timeout"       env:"OM_REQUEST_TIMEOUT"     default:"1800"  description:"timeout in seconds for HTTP requests to Ops Manager"`
+	SkipSSLValidation     bool   `yaml:"skip-ssl-validation"   short:"k"  long:"skip-ssl-validation"   env:"OM_SKIP_SSL_VALIDATION"                 description:"skip ssl certificate validation during http requests"`
+	SOCKSProxy            string `yaml:"socks-proxy"                      long:"socks-proxy"           env:"OM_SOCKS_PROXY"                         description:"SOCKS5 proxy used to reach Ops Manager, as host:port or socks5://[user:password@]host:port"`
+	SSHJumpbox            string `yaml:"ssh-jumpbox"                      long:"ssh-jumpbox"           env:"OM_SSH_JUMPBOX"                         description:"jumpbox used by the ssh command to reach the VMs, as [user@]host[:port]"`
+	SSHJumpboxPrivateKey  string `yaml:"ssh-jumpbox-private-key"          long:"ssh-jumpbox-private-key" env:"OM_SSH_JUMPBOX_PRIVATE_KEY"           description:"path to the private key for the ssh jumpbox"`
+	TCPKeepAlive          int    `yaml:"tcp-keepalive"                    long:"tcp-keepalive"         env:"OM_TCP_KEEPALIVE"       default:"30"    description:"seconds between TCP keepalive probes of the connections (0 disables them)"`
+	Target                string `yaml:"target"                short:"t"  long:"target"                env:"OM_TARGET"                              description:"location of the Ops Manager VM, or the name of one of the targets of the env file"`
+	UAATarget             string `yaml:"uaa-target"                       long:"uaa-target"            env:"OM_UAA_TARGET"                          description:"optional location of the Ops Manager UAA"`
+	TokenCache            string `yaml:"token-cache"                      long:"token-cache"           env:"OM_TOKEN_CACHE"                         description:"path to a file used to cache UAA tokens between invocations (disabled when not set)"`
+	Trace                 bool   `yaml:"trace"                            long:"trace"                 env:"OM_TRACE"                               description:"prints HTTP requests and response payloads, with credentials redacted"`
+	TraceFile             string `yaml:"trace-file"                       long:"trace-file"            env:"OM_TRACE_FILE"                          description:"records HTTP requests and responses, with headers and timings, to the given file in HAR format"`
+	TransferTimeout       *int   `yaml:"transfer-timeout"                 long:"transfer-timeout"      env:"OM_TRANSFER_TIMEOUT"                    description:"timeout in seconds for requests uploading or downloading files, such as products, stemcells and installations (0 disables it, defaults to --request-timeout)"`
+	Username              string `yaml:"username"              short:"u"  long:"username"              env:"OM_USERNAME"                            description:"admin username for the Ops Manager VM (not required for unauthenticated commands)"`
+	VarsEnv               string `                                        long:"vars-env"              env:"OM_VARS_ENV"                            description:"load vars from environment variables by specifying a prefix (e.g.: 'MY' to load MY_var=value)"`
+	Version               bool   `                             short:"v"  long:"version"                                                            description:"prints the om release version"`
+	WriteBufferSize       int    `yaml:"write-buffer-size"                long:"write-buffer-size"     env:"OM_WRITE_BUFFER_SIZE"   default:"4"     description:"size in KB of the write buffer of each connection"`
+
+	// Plugins declares the paths of plugins by command name, from the env file only
+	Plugins map[string]string `yaml:"plugins"`
+
+	Notifications notificationsConfig `yaml:"notifications" no-flag:"true"`
 }
 
-func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationString string, args []string) error {
+func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationString string, args []string) (err error) {
 	applySleepDuration, _ := time.ParseDuration(applySleepDurationString)
 
 	stdout := log.New(sout, "", 0)
-	stderr := log.New(serr, "", 0)
 
 	var global options
 	parser := flags.NewParser(&global, flags.PassDoubleDash|flags.PassAfterNonOption)
@@ -66,31 +96,140 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		args[0] = "--help"
 	}
 
-	err := setEnvFileProperties(&global)
+	err = setEnvFileProperties(&global)
 	if err != nil {
 		return err
 	}
 
+	var logOutput, warningOutput io.Writer = serr, os.Stderr
+	if global.LogFormat == "json" {
+		var command string
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			command = args[0]
+		}
+
+		requestID := newRequestID()
+		logOutput = newJSONLogWriter(serr, "info", command, requestID)
+		warningOutput = newJSONLogWriter(serr, "warn", command, requestID)
+
+		// errors returned from Main are printed with the standard logger
+		log.SetFlags(0)
+		log.SetOutput(newJSONLogWriter(serr, "error", command, requestID))
+	}
+	var progressOutput io.Writer = os.Stderr
+	switch {
+	case global.NoProgress || global.Quiet:
+		// log messages are written as before, without the bars
+		logOutput = progress.NewQuietWriter(logOutput)
+		progressOutput = progress.NewQuietWriter(io.Discard)
+	case global.ProgressFormat == "json":
+		// log messages are written as before, the bars as JSON lines
+		logOutput = progress.NewJSONWriter(logOutput, serr)
+		progressOutput = progress.NewJSONWriter(os.Stderr, serr)
+	}
+	stderr := log.New(logOutput, "", 0)
+
+	var tel *telemetry
+	if global.OTLPEndpoint != "" {
+		var command string
+		if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+			command = args[0]
+		}
+
+		tel, err = newTelemetry(global.OTLPEndpoint, command, version)
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			if shutdownErr := tel.shutdown(err); shutdownErr != nil {
+				fmt.Fprintf(warningOutput, "Warning: %s\n", shutdownErr)
+			}
+		}()
+	}
+
 	requestTimeout := time.Duration(global.RequestTimeout) * time.Second
 	connectTimeout := time.Duration(global.ConnectTimeout) * time.Second
 
-	var unauthenticatedClient, authedClient, unauthenticatedProgressClient, authedProgressClient httpClient
-	unauthenticatedClient, err = network.NewUnauthenticatedClient(global.Target, global.SkipSSLValidation, global.CACert, connectTimeout, requestTimeout)
+	caCert, err := network.CABundle(global.CACert, global.CACertFile)
 	if err != nil {
 		return err
 	}
 
-	authedClient, err = network.NewOAuthClient(global.UAATarget, global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, global.CACert, connectTimeout, requestTimeout)
+	transport := network.TransportOptions{
+		HTTP2:           global.HTTP2,
+		IdleConnTimeout: time.Duration(global.IdleConnectionTimeout) * time.Second,
+		KeepAlive:       time.Duration(global.TCPKeepAlive) * time.Second,
+		ReadBufferSize:  global.ReadBufferSize * 1024,
+		WriteBufferSize: global.WriteBufferSize * 1024,
+	}
+	if global.TCPKeepAlive == 0 {
+		transport.KeepAlive = -1
+	}
+
+	transferTimeout := requestTimeout
+	if global.TransferTimeout != nil {
+		transferTimeout = time.Duration(*global.TransferTimeout) * time.Second
+	}
+
+	// products are streamed from --product-url with the network options used
+	// to reach Ops Manager
+	externalClient, err := network.NewExternalClient(global.SkipSSLValidation, caCert, global.SOCKSProxy, connectTimeout, transferTimeout)
+	if err != nil {
+		return err
+	}
+
+	unauthenticatedBaseClient, err := network.NewUnauthenticatedClient(global.Target, global.SkipSSLValidation, caCert, global.ClientCert, global.ClientKey, global.SOCKSProxy, connectTimeout, requestTimeout)
 	if err != nil {
 		return err
 	}
+	unauthenticatedBaseClient = unauthenticatedBaseClient.WithTransport(transport)
+
+	oauthClient, err := network.NewOAuthClient(global.UAATarget, global.Target, global.Username, global.Password, global.ClientID, global.ClientSecret, global.SkipSSLValidation, caCert, global.ClientCert, global.ClientKey, global.SOCKSProxy, connectTimeout, requestTimeout)
+	if err != nil {
+		return err
+	}
+	oauthClient = oauthClient.WithTransport(transport)
+
+	if global.TokenCache != "" {
+		oauthClient = oauthClient.WithTokenCache(global.TokenCache)
+	}
+
+	// the clients uploading and downloading files have their own timeout, so
+	// long transfers are not cut short by the timeout of the API calls
+	wrap := func(unauthenticatedClient, authedClient httpClient) (httpClient, httpClient) {
+		if global.RequestRetries > 0 {
+			requestBackoff := time.Duration(global.RequestBackoff) * time.Second
+			unauthenticatedClient = network.NewRetryClient(unauthenticatedClient, global.RequestRetries, requestBackoff, warningOutput)
+			authedClient = network.NewRetryClient(authedClient, global.RequestRetries, requestBackoff, warningOutput)
+		}
+
+		if global.RateLimitRetries > 0 {
+			requestBackoff := time.Duration(global.RequestBackoff) * time.Second
+			unauthenticatedClient = network.NewRateLimitClient(unauthenticatedClient, global.RateLimitRetries, requestBackoff, warningOutput)
+			authedClient = network.NewRateLimitClient(authedClient, global.RateLimitRetries, requestBackoff, warningOutput)
+		}
+
+		if global.GatewayGracePeriod > 0 {
+			gracePeriod := time.Duration(global.GatewayGracePeriod) * time.Second
+			requestBackoff := time.Duration(global.RequestBackoff) * time.Second
+			unauthenticatedClient = network.NewGatewayRetryClient(unauthenticatedClient, gracePeriod, requestBackoff, warningOutput)
+			authedClient = network.NewGatewayRetryClient(authedClient, gracePeriod, requestBackoff, warningOutput)
+		}
 
-	if global.DecryptionPassphrase != "" {
-		authedClient = network.NewDecryptClient(authedClient, unauthenticatedClient, global.DecryptionPassphrase, os.Stderr)
+		if global.DecryptionPassphrase != "" {
+			authedClient = network.NewDecryptClient(authedClient, unauthenticatedClient, global.DecryptionPassphrase, logOutput)
+		}
+
+		return unauthenticatedClient, authedClient
 	}
 
-	unauthenticatedProgressClient = network.NewProgressClient(unauthenticatedClient, os.Stderr)
-	authedProgressClient = network.NewProgressClient(authedClient, os.Stderr)
+	var unauthenticatedClient, authedClient, unauthenticatedProgressClient, authedProgressClient httpClient
+	unauthenticatedClient, authedClient = wrap(unauthenticatedBaseClient, oauthClient)
+	unauthenticatedProgressClient, authedProgressClient = wrap(unauthenticatedBaseClient.WithRequestTimeout(transferTimeout), oauthClient.WithRequestTimeout(transferTimeout))
+
+	unauthenticatedProgressClient = network.NewProgressClient(unauthenticatedProgressClient, progressOutput)
+	authedProgressClient = network.NewProgressClient(authedProgressClient, progressOutput)
 
 	if global.Trace {
 		unauthenticatedClient = network.NewTraceClient(unauthenticatedClient, os.Stderr)
@@ -99,6 +238,34 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		authedProgressClient = network.NewTraceClient(authedProgressClient, os.Stderr)
 	}
 
+	if tel != nil {
+		clients := []*httpClient{&unauthenticatedClient, &unauthenticatedProgressClient, &authedClient, &authedProgressClient}
+		for _, client := range clients {
+			*client, err = network.NewTelemetryClient(tel.ctx, *client, tel.tracer, tel.meter)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if global.TraceFile != "" {
+		recorder := network.NewHARRecorder(global.TraceFile, version, warningOutput)
+		unauthenticatedClient = network.NewHARClient(unauthenticatedClient, recorder)
+		unauthenticatedProgressClient = network.NewHARClient(unauthenticatedProgressClient, recorder)
+		authedClient = network.NewHARClient(authedClient, recorder)
+		authedProgressClient = network.NewHARClient(authedProgressClient, recorder)
+	}
+
+	// outermost, so the trace and the HAR file show the conditional requests
+	if global.CacheDir != "" {
+		identity := global.Username
+		if identity == "" {
+			identity = global.ClientID
+		}
+		unauthenticatedClient = network.NewCacheClient(unauthenticatedClient, global.CacheDir, "", warningOutput)
+		authedClient = network.NewCacheClient(authedClient, global.CacheDir, identity, warningOutput)
+	}
+
 	api := api.New(api.ApiInput{
 		Client:                 authedClient,
 		UnauthedClient:         unauthenticatedClient,
@@ -112,11 +279,17 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 
 	form := formcontent.NewForm()
 
-	metadataExtractor := extractor.NewMetadataExtractor()
+	metadataExtractor := extractor.NewMetadataExtractor(extractor.WithHTTPClient(externalClient))
 
-	presenter := presenters.NewPresenter(presenters.NewTablePresenter(tableWriter), presenters.NewJSONPresenter(os.Stdout))
+	presenter := presenters.NewPresenter(presenters.NewTablePresenter(tableWriter), presenters.NewJSONPresenter(os.Stdout), presenters.NewYAMLPresenter(os.Stdout))
 	envRendererFactory := renderers.NewFactory(renderers.NewEnvGetter())
 
+	// om foreach runs om again for every foundation
+	executable, err := os.Executable()
+	if err != nil {
+		executable = "om"
+	}
+
 	command, err := parser.AddCommand(
 		"vm-lifecycle",
 		"commands to manage the state of the Ops Manager VM",
@@ -133,7 +306,16 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		"activate-certificate-authority",
 		"activates a certificate authority on the Ops Manager",
 		"This authenticated command activates an existing certificate authority on the Ops Manager",
-		commands.NewActivateCertificateAuthority(api, stdout),
+		commands.NewActivateCertificateAuthority(api, stdout, applySleepDuration),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"add-trusted-certificate",
+		"adds certificates to the trusted certificates of the director",
+		"This authenticated command adds certificates to the trusted certificates of the director security settings, without resubmitting the rest of the director config. Certificates that are already trusted are skipped",
+		commands.NewAddTrustedCertificate(api, stdout),
 	)
 	if err != nil {
 		return err
@@ -142,7 +324,7 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		"apply-changes",
 		"triggers an install on the Ops Manager targeted",
 		"This authenticated command kicks off an install of any staged changes on the Ops Manager.",
-		commands.NewApplyChanges(api, api, logWriter, stdout, applySleepDuration),
+		commands.NewApplyChanges(api, api, logWriter, stdout, progressOutput, applySleepDuration),
 	)
 	if err != nil {
 		return err
@@ -176,6 +358,15 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"bosh",
+		"runs the bosh cli targeting the BOSH director",
+		"This authenticated command runs the bosh cli with the environment variables to target the BOSH director, e.g. om bosh -- deployments",
+		commands.NewBosh(api, global.Target, commands.RunBoshCLI),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"bosh-diff",
 		"displays BOSH manifest diff for the director and products",
@@ -212,11 +403,29 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"completion",
+		"prints a shell completion script",
+		"This command prints a script that completes the commands and flags of om for bash, zsh, fish or powershell, e.g. \"source <(om completion bash)\". The names of the staged products are completed with the credentials of the env file on the command line.",
+		commands.NewCompletion(func() commands.CompletionCommand { return describeCommands(parser) }, api, sout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"compare-config",
+		"compares a product config file with the staged configuration",
+		"This command compares the properties, networks, resource config, errands and syslog of a product config file with the staged configuration of the product and prints the differences as YAML. It exits 2 when differences exist.",
+		commands.NewCompareConfig(os.Environ, api, stdout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"config-template",
 		"generates a config template from a Pivnet product",
 		"this command generates a product configuration template from a .pivotal file or Pivnet",
-		commands.NewConfigTemplate(commands.DefaultConfigTemplateProvider()),
+		commands.NewConfigTemplate(commands.DefaultConfigTemplateProvider(), api),
 	)
 	if err != nil {
 		return err
@@ -239,6 +448,15 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"configure-errands",
+		"sets the states of the errands of staged products from a config file",
+		"This authenticated command sets the post-deploy and pre-delete states of errands across the staged products listed in a config file, and leaves the errands it does not list unchanged.",
+		commands.NewConfigureErrands(os.Environ, api, stdout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"configure-ldap-authentication",
 		"configures Ops Manager with LDAP authentication",
@@ -275,6 +493,15 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"configure-verifiers",
+		"enables and disables director and product verifiers from a config file",
+		"This authenticated command enables and disables the verifiers of the director and staged products listed in a config file, and leaves the verifiers it does not list unchanged.",
+		commands.NewConfigureVerifiers(os.Environ, api, stdout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"create-certificate-authority",
 		"creates a certificate authority on the Ops Manager",
@@ -287,7 +514,7 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	_, err = parser.AddCommand(
 		"create-vm-extension",
 		"creates/updates a VM extension",
-		"This creates/updates a VM extension",
+		"This creates/updates a VM extension, or the vm-extensions of the config file, the complete set of VM extensions. The VM extensions not in the set are reported, and deleted with --prune unless a job uses them",
 		commands.NewCreateVMExtension(os.Environ, api, stdout),
 	)
 	if err != nil {
@@ -324,7 +551,7 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		"delete-certificate-authority",
 		"deletes a certificate authority on the Ops Manager",
 		"This authenticated command deletes an existing certificate authority on the Ops Manager",
-		commands.NewDeleteCertificateAuthority(api, stdout),
+		commands.NewDeleteCertificateAuthority(api, stdout, applySleepDuration),
 	)
 	if err != nil {
 		return err
@@ -365,6 +592,33 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"delete-unused-stemcells",
+		"deletes the unused stemcells uploaded to Ops Manager",
+		"This command deletes the stemcells uploaded to Ops Manager that no staged or deployed product uses, keeping the newest of each operating system. Ops Manager refuses to delete stemcells in use.",
+		commands.NewDeleteUnusedStemcells(api, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"deploy-product",
+		"uploads, stages, configures and deploys a product",
+		"This command uploads a product file unless it has been uploaded, stages it, configures it with a config file and assigns it a stemcell when these are given, and applies its changes with --apply-changes. The product is not uploaded or staged again and configuring it again changes nothing, so the command can be run again after a failure.",
+		commands.NewDeployProduct(
+			metadataExtractor,
+			api,
+			commands.NewUploadProduct(form, metadataExtractor, api, stdout, commands.NewProductBlobstore, externalClient),
+			commands.NewStageProduct(api, stdout),
+			commands.NewConfigureProduct(os.Environ, api, global.Target, stdout),
+			commands.NewAssignStemcell(api, stdout),
+			commands.NewApplyChanges(api, api, logWriter, stdout, progressOutput, applySleepDuration),
+			stdout,
+		),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"deployed-manifest",
 		"prints the deployed manifest for a product",
@@ -414,7 +668,34 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		"download-product",
 		"downloads a specified product file from Pivotal Network",
 		"This command attempts to download a single product file from Pivotal Network. The API token used must be associated with a user account that has already accepted the EULA for the specified product",
-		commands.NewDownloadProduct(os.Environ, stdout, stderr, os.Stderr, api),
+		commands.NewDownloadProduct(os.Environ, stdout, stderr, progressOutput, api),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"download-required-stemcells",
+		"downloads the stemcells required by the staged products",
+		"This authenticated command downloads the stemcells that the staged products require and that are not uploaded to Ops Manager yet, and optionally uploads them",
+		commands.NewDownloadRequiredStemcells(os.Environ, stdout, stderr, progressOutput, form, api),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"enable-director-verifiers",
+		"enables director verifiers",
+		"This authenticated command enables director verifiers",
+		commands.NewEnableDirectorVerifiers(presenter, api, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"enable-product-verifiers",
+		"enables product verifiers",
+		"This authenticated command enables product verifiers",
+		commands.NewEnableProductVerifiers(presenter, api, stdout),
 	)
 	if err != nil {
 		return err
@@ -455,6 +736,15 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"foreach",
+		"runs an om command against several foundations at the same time",
+		"This command runs the om command given after -- against every foundation under the targets of an env file, at the same time, e.g. om foreach --targets foundations.yml -- staged-products. The output of each foundation is prefixed with its name, and a summary of the exit codes is printed at the end. It fails when the command fails on any foundation.",
+		commands.NewForeach(commands.NewExecRunner(executable), sout, serr),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"generate-certificate",
 		"generates a new certificate signed by Ops Manager's root CA",
@@ -491,11 +781,20 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"init",
+		"interactively writes an env file",
+		"This command asks for the target, credentials and CA of an Ops Manager, verifies that it can be reached and authenticated with, and writes an env file to use with --env.",
+		commands.NewInit(newInitService(global), os.Stdin, sout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"installation-log",
 		"output installation logs",
-		"This authenticated command retrieves the logs for a given installation.",
-		commands.NewInstallationLog(api, stdout),
+		"This authenticated command retrieves the logs for a given installation. Use --follow to stream the logs of a running installation until it finishes.",
+		commands.NewInstallationLog(api, logWriter, stdout, applySleepDuration),
 	)
 	if err != nil {
 		return err
@@ -518,6 +817,15 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"mirror-products",
+		"mirrors products and their stemcells from Pivotal Network to a blobstore",
+		"This command downloads the latest files matching each product from Pivotal Network and uploads the ones missing from an s3, gcs or azure blobstore, named so download-product can find them there",
+		commands.NewMirrorProducts(stdout, stderr, commands.NewMirrorDestination),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"pending-changes",
 		"checks for pending changes",
@@ -563,6 +871,15 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"remove-trusted-certificate",
+		"removes certificates from the trusted certificates of the director",
+		"This authenticated command removes certificates, given as PEM, by their SHA256 fingerprint or because they have expired, from the trusted certificates of the director security settings, without resubmitting the rest of the director config",
+		commands.NewRemoveTrustedCertificate(api, stdout, time.Now),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"revert-staged-changes",
 		"This command reverts the staged changes already on an Ops Manager.",
@@ -572,6 +889,43 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"rotate-admin-credentials",
+		"changes the password of the user om authenticates as",
+		"This command changes the password of the user om authenticates as with --username and --password, checks UAA grants a token for the new password, and optionally writes it to a file:// vars store",
+		commands.NewRotateAdminCredentials(os.Environ, api, oauthClient, global.Username, global.Password, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"rotate-certificate-authority",
+		"rotates the root certificate authority of the Ops Manager",
+		"This authenticated command creates a new certificate authority, applies changes so every VM trusts it, activates it, regenerates the certificates it signs, applies changes, then deletes the old certificate authorities and applies changes again.\n"+
+			"Use \"--state-file\" to resume a rotation from the step that failed.",
+		commands.NewRotateCertificateAuthority(api, logWriter, stdout, applySleepDuration),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"rotate-uaa-client-secret",
+		"changes the secret of a UAA client",
+		"This command changes the secret of a UAA client, by default the client om authenticates as with --client-id, checks UAA grants a token for the new secret, and optionally writes it to a file:// vars store",
+		commands.NewRotateUAAClientSecret(os.Environ, api, oauthClient, global.ClientID, global.ClientSecret, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"ssh",
+		"opens an ssh session on the Ops Manager VM or the BOSH director",
+		"This command opens an interactive ssh session, or runs the command given after --, on the Ops Manager VM or the BOSH director, e.g. om ssh -i opsman.pem -- sudo ls /var/tempest. The ssh key of the director is fetched from Ops Manager. The socks-proxy, ssh-jumpbox and ssh-jumpbox-private-key of the env file are used to reach the VMs",
+		commands.NewSSH(api, global.Target, global.SOCKSProxy, global.SSHJumpbox, global.SSHJumpboxPrivateKey, os.Stdin, sout, serr),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"ssl-certificate",
 		"gets certificate applied to Ops Manager",
@@ -626,6 +980,34 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"trusted-certificates",
+		"lists the trusted certificates of the director",
+		"This authenticated command lists the trusted certificates of the director security settings, with their subject, expiry and SHA256 fingerprint, and the other security settings",
+		commands.NewTrustedCertificates(api, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"unassign-multi-stemcell",
+		"removes stemcells from a product in the targeted Ops Manager 2.6+",
+		"This command will remove stemcells of an operating system or of a particular version from a specific product in Ops Manager 2.6+.\n"+
+			"The product keeps its other stemcells, so at least one stemcell has to remain assigned.",
+		commands.NewUnassignMultiStemcell(api, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"unlock",
+		"decrypts the installation after the Ops Manager VM has been rebooted",
+		"This unauthenticated command unlocks Ops Manager with the global decryption passphrase and waits until Ops Manager is available",
+		commands.NewUnlock(api, global.DecryptionPassphrase, stdout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"unstage-product",
 		"unstages a given product from the Ops Manager targeted",
@@ -635,11 +1017,20 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"update-ssl-certificate",
+		"validates and applies a custom certificate to Ops Manager",
+		"This authenticated command checks the certificate is valid, belongs to the private key, is followed by its intermediates and is valid for the target hostname, applies it to Ops Manager, and waits for Ops Manager to serve it. With --config, the certificate and key can come from a vars store",
+		commands.NewUpdateSSLCertificate(api, commands.NewTLSCertificateFetcher(global.Target, connectTimeout), global.Target, stdout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"upload-product",
 		"uploads a given product to the Ops Manager targeted",
 		"This command attempts to upload a product to the Ops Manager",
-		commands.NewUploadProduct(form, metadataExtractor, api, stdout),
+		commands.NewUploadProduct(form, metadataExtractor, api, stdout, commands.NewProductBlobstore, externalClient),
 	)
 	if err != nil {
 		return err
@@ -653,6 +1044,24 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 	if err != nil {
 		return err
 	}
+	_, err = parser.AddCommand(
+		"verifiers",
+		"lists director and product verifiers",
+		"This authenticated command lists whether each verifier of the director and staged products is enabled, in the format configure-verifiers reads.",
+		commands.NewVerifiers(api, stdout),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = parser.AddCommand(
+		"verify-downloads",
+		"checks downloaded files against the plan of download-product --plan-only",
+		"This command checks that the files in the plan written by download-product --plan-only are in a directory, with the planned sizes and shas, e.g. after carrying them over to an air-gapped environment. It fails when a file is missing or does not match.",
+		commands.NewVerifyDownloads(stdout),
+	)
+	if err != nil {
+		return err
+	}
 	_, err = parser.AddCommand(
 		"version",
 		"prints the om release version",
@@ -663,6 +1072,13 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		return err
 	}
 
+	// commands that are not built in are run as plugins
+	if len(args) > 0 && parser.Find(args[0]) == nil {
+		if path, ok := findPlugin(args[0], global.Plugins); ok {
+			return runPlugin(args[0], path, args[1:], global)
+		}
+	}
+
 	args, err = loadConfigFile(args, os.Environ)
 	if err != nil {
 		return err
@@ -674,12 +1090,35 @@ func Main(sout io.Writer, serr io.Writer, version string, applySleepDurationStri
 		return err
 	}
 
+	parser.CommandHandler = func(command flags.Commander, args []string) error {
+		if command == nil {
+			return nil
+		}
+
+		notifier, err := newNotifier(global.Notifications, parser.Active.Name, global.Target, warningOutput)
+		if err != nil {
+			return err
+		}
+		if notifier == nil {
+			return command.Execute(args)
+		}
+
+		notifier.start()
+		err = command.Execute(args)
+		notifier.finish(err)
+
+		return err
+	}
+
 	parser.Options |= flags.HelpFlag
 	if _, err = parser.ParseArgs(args); err != nil {
 		if e, ok := err.(*flags.Error); ok {
 			switch e.Type {
 			case flags.ErrHelp, flags.ErrCommandRequired:
 				parser.WriteHelp(os.Stdout)
+				if parser.Active == nil {
+					writePlugins(os.Stdout, listPlugins(global.Plugins))
+				}
 				return nil
 			}
 		}
@@ -713,11 +1152,29 @@ func setEnvFileProperties(global *options) error {
 		return err
 	}
 
+	contents, named, err := selectEnvFileTarget(contents, global.Target)
+	if err != nil {
+		return err
+	}
+
+	// the location of the Ops Manager comes from the selected target
+	if named {
+		global.Target = ""
+	}
+
 	err = yaml.UnmarshalStrict(contents, &opts)
 	if err != nil {
 		return fmt.Errorf("could not parse env file: %s", err)
 	}
 
+	// a setting of 0 cannot be told apart from a missing one in opts
+	var settings map[string]interface{}
+	_ = yaml.Unmarshal(contents, &settings)
+	envFileSets := func(name string) bool {
+		_, ok := settings[name]
+		return ok
+	}
+
 	if global.ClientID == "" {
 		global.ClientID = opts.ClientID
 	}
@@ -733,15 +1190,63 @@ func setEnvFileProperties(global *options) error {
 	if global.RequestTimeout == 1800 && opts.RequestTimeout != 0 {
 		global.RequestTimeout = opts.RequestTimeout
 	}
+	if global.GatewayGracePeriod == 300 && envFileSets("gateway-grace-period") {
+		global.GatewayGracePeriod = opts.GatewayGracePeriod
+	}
+	if global.RateLimitRetries == 5 && envFileSets("rate-limit-retries") {
+		global.RateLimitRetries = opts.RateLimitRetries
+	}
+	if global.RequestRetries == 0 && opts.RequestRetries != 0 {
+		global.RequestRetries = opts.RequestRetries
+	}
+	if global.RequestBackoff == 1 && envFileSets("request-backoff") {
+		global.RequestBackoff = opts.RequestBackoff
+	}
 	if !global.SkipSSLValidation {
 		global.SkipSSLValidation = opts.SkipSSLValidation
 	}
+	if !global.HTTP2 {
+		global.HTTP2 = opts.HTTP2
+	}
+	if global.IdleConnectionTimeout == 0 && envFileSets("idle-connection-timeout") {
+		global.IdleConnectionTimeout = opts.IdleConnectionTimeout
+	}
+	if global.TCPKeepAlive == 30 && envFileSets("tcp-keepalive") {
+		global.TCPKeepAlive = opts.TCPKeepAlive
+	}
+	if global.ReadBufferSize == 4 && envFileSets("read-buffer-size") {
+		global.ReadBufferSize = opts.ReadBufferSize
+	}
+	if global.WriteBufferSize == 4 && envFileSets("write-buffer-size") {
+		global.WriteBufferSize = opts.WriteBufferSize
+	}
+	if global.TransferTimeout == nil {
+		global.TransferTimeout = opts.TransferTimeout
+	}
+	if global.OTLPEndpoint == "" {
+		global.OTLPEndpoint = opts.OTLPEndpoint
+	}
+	if global.SOCKSProxy == "" {
+		global.SOCKSProxy = opts.SOCKSProxy
+	}
+	if global.SSHJumpbox == "" {
+		global.SSHJumpbox = opts.SSHJumpbox
+	}
+	if global.SSHJumpboxPrivateKey == "" {
+		global.SSHJumpboxPrivateKey = opts.SSHJumpboxPrivateKey
+	}
 	if global.Target == "" {
 		global.Target = opts.Target
 	}
+	if global.TokenCache == "" {
+		global.TokenCache = opts.TokenCache
+	}
 	if !global.Trace {
 		global.Trace = opts.Trace
 	}
+	if global.TraceFile == "" {
+		global.TraceFile = opts.TraceFile
+	}
 	if global.Username == "" {
 		global.Username = opts.Username
 	}
@@ -751,6 +1256,32 @@ func setEnvFileProperties(global *options) error {
 	if global.CACert == "" {
 		global.CACert = opts.CACert
 	}
+	if global.CACertFile == "" {
+		global.CACertFile = opts.CACertFile
+	}
+	if global.CacheDir == "" {
+		global.CacheDir = opts.CacheDir
+	}
+	if global.ClientCert == "" {
+		global.ClientCert = opts.ClientCert
+	}
+	if global.ClientKey == "" {
+		global.ClientKey = opts.ClientKey
+	}
+	if global.Plugins == nil {
+		// the paths of plugins are relative to the env file
+		global.Plugins = map[string]string{}
+		for name, path := range opts.Plugins {
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(global.Env), path)
+			}
+			global.Plugins[name] = path
+		}
+	}
+
+	if len(global.Notifications.Webhooks) == 0 {
+		global.Notifications = opts.Notifications
+	}
 
 	err = checkForVars(global)
 	if err != nil {
@@ -896,6 +1427,10 @@ func findUnknownFlags(selectedCmd *flags.Command, args []string) []string {
 	i := 0
 	for i < len(args) {
 		arg := args[i]
+		if arg == "--" {
+			// the args after -- are not flags of om, e.g. for om ssh
+			break
+		}
 		if !strings.HasPrefix(arg, "-") {
 			// Not a flag, and not a value for a previous flag (since we only check flags)
 			// Stop processing further, as all remaining args are positional
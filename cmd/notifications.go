@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const notificationTimeout = 10 * time.Second
+
+// notifyingCommands are the long-running commands whose lifecycle events
+// are posted to the webhooks.
+var notifyingCommands = []string{
+	"apply-changes",
+	"rotate-certificate-authority",
+	"upload-product",
+	"upload-stemcell",
+}
+
+// notificationsConfig declares the webhooks to post the lifecycle events of
+// the long-running commands to, from the env file only.
+type notificationsConfig struct {
+	Webhooks []webhookConfig `yaml:"webhooks"`
+}
+
+type webhookConfig struct {
+	URL string `yaml:"url"`
+	// Events are the events to post, e.g. apply-changes.failed. Every
+	// event is posted when none is given.
+	Events []string `yaml:"events"`
+}
+
+// notification is the body posted to the webhooks. Slack incoming webhooks
+// only read the text, the other fields are for other receivers.
+type notification struct {
+	Text     string  `json:"text"`
+	Event    string  `json:"event"`
+	Command  string  `json:"command"`
+	Target   string  `json:"target,omitempty"`
+	Duration float64 `json:"duration_seconds,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+// notifier posts the started, succeeded and failed events of a command. A
+// webhook that cannot be reached is reported as a warning, it does not fail
+// the command.
+type notifier struct {
+	client   httpClient
+	webhooks []webhookConfig
+	command  string
+	target   string
+	warnings io.Writer
+	started  time.Time
+}
+
+// newNotifier returns nil when the command has no lifecycle events or no
+// webhook is declared.
+func newNotifier(config notificationsConfig, command, target string, warnings io.Writer) (*notifier, error) {
+	if len(config.Webhooks) == 0 || !slices.Contains(notifyingCommands, command) {
+		return nil, nil
+	}
+
+	// the url of a webhook is its secret, so it is not printed
+	for i, webhook := range config.Webhooks {
+		webhookURL, err := url.Parse(webhook.URL)
+		if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") {
+			return nil, fmt.Errorf("the url of webhook %d of the notifications in the env file must be an http or https url", i+1)
+		}
+	}
+
+	return &notifier{
+		client:   &http.Client{Timeout: notificationTimeout},
+		webhooks: config.Webhooks,
+		command:  command,
+		target:   target,
+		warnings: warnings,
+	}, nil
+}
+
+func (n *notifier) start() {
+	n.started = time.Now()
+	n.notify("started", nil)
+}
+
+func (n *notifier) finish(commandErr error) {
+	if commandErr != nil {
+		n.notify("failed", commandErr)
+		return
+	}
+
+	n.notify("succeeded", nil)
+}
+
+func (n *notifier) notify(phase string, commandErr error) {
+	event := n.command + "." + phase
+
+	message := notification{
+		Text:    fmt.Sprintf("om %s %s", n.command, phase),
+		Event:   event,
+		Command: n.command,
+		Target:  n.target,
+	}
+	if n.target != "" {
+		message.Text += " on " + n.target
+	}
+	if phase != "started" {
+		duration := time.Since(n.started).Round(time.Second)
+		message.Duration = duration.Seconds()
+		message.Text += fmt.Sprintf(" after %s", duration)
+	}
+	if commandErr != nil {
+		message.Error = commandErr.Error()
+		message.Text += ": " + message.Error
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		fmt.Fprintf(n.warnings, "Warning: could not encode the %s notification: %s\n", event, err)
+		return
+	}
+
+	for _, webhook := range n.webhooks {
+		if len(webhook.Events) > 0 && !slices.Contains(webhook.Events, event) {
+			continue
+		}
+
+		err := n.post(webhook.URL, body)
+		if err != nil {
+			fmt.Fprintf(n.warnings, "Warning: could not post the %s notification: %s\n", event, err)
+		}
+	}
+}
+
+func (n *notifier) post(webhookURL string, body []byte) error {
+	request, err := http.NewRequest("POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("the webhook responded with %s", response.Status)
+	}
+
+	return nil
+}
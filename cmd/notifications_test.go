@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Main with notifications in the env file", func() {
+	var (
+		opsman  *ghttp.Server
+		webhook *ghttp.Server
+		bodies  [][]byte
+		dir     string
+		product string
+	)
+
+	writeEnvFile := func(notifications string) string {
+		envFile := filepath.Join(dir, "env.yml")
+		contents := fmt.Sprintf("target: %s\nusername: admin\npassword: secret\nnotifications:\n%s", opsman.URL(), notifications)
+		Expect(os.WriteFile(envFile, []byte(contents), 0600)).To(Succeed())
+		return envFile
+	}
+
+	receivedEvents := func() []map[string]interface{} {
+		var events []map[string]interface{}
+		for _, body := range bodies {
+			var event map[string]interface{}
+			Expect(json.Unmarshal(body, &event)).To(Succeed())
+			events = append(events, event)
+		}
+		return events
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+
+		opsman = ghttp.NewServer()
+		opsman.RouteToHandler("POST", "/uaa/oauth/token", ghttp.RespondWith(http.StatusOK, `{"access_token": "some-token", "token_type": "bearer", "expires_in": 3600}`, http.Header{"Content-Type": []string{"application/json"}}))
+		opsman.RouteToHandler("GET", "/api/v0/info", ghttp.RespondWith(http.StatusOK, `{"info": {"version": "3.0.1"}}`))
+		opsman.RouteToHandler("GET", "/api/v0/available_products", ghttp.RespondWith(http.StatusOK, `[{"name": "cf", "product_version": "2.0.0"}]`))
+
+		bodies = nil
+		webhook = ghttp.NewServer()
+		webhook.RouteToHandler("POST", "/hook", ghttp.CombineHandlers(
+			ghttp.VerifyContentType("application/json"),
+			func(w http.ResponseWriter, request *http.Request) {
+				body, err := io.ReadAll(request.Body)
+				Expect(err).ToNot(HaveOccurred())
+				bodies = append(bodies, body)
+			},
+			ghttp.RespondWith(http.StatusOK, "ok"),
+		))
+
+		product = filepath.Join(dir, "cf.pivotal")
+		file, err := os.Create(product)
+		Expect(err).ToNot(HaveOccurred())
+		zipWriter := zip.NewWriter(file)
+		metadata, err := zipWriter.Create("metadata/cf.yml")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = metadata.Write([]byte("name: cf\nproduct_version: 2.0.0\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(zipWriter.Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		opsman.Close()
+		webhook.Close()
+	})
+
+	It("posts the started and succeeded events of the command", func() {
+		envFile := writeEnvFile(fmt.Sprintf("  webhooks:\n  - url: %s/hook\n", webhook.URL()))
+
+		err := Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "--env", envFile, "upload-product", "--product", product})
+		Expect(err).ToNot(HaveOccurred())
+
+		events := receivedEvents()
+		Expect(events).To(HaveLen(2))
+		Expect(events[0]).To(Equal(map[string]interface{}{
+			"text":    "om upload-product started on " + opsman.URL(),
+			"event":   "upload-product.started",
+			"command": "upload-product",
+			"target":  opsman.URL(),
+		}))
+		Expect(events[1]["event"]).To(Equal("upload-product.succeeded"))
+		Expect(events[1]["text"]).To(HavePrefix("om upload-product succeeded on " + opsman.URL() + " after "))
+	})
+
+	It("posts the failed event with the error, only to the webhooks of that event", func() {
+		envFile := writeEnvFile(fmt.Sprintf("  webhooks:\n  - url: %[1]s/hook\n    events: [upload-product.failed]\n  - url: %[1]s/other-hook\n    events: [upload-product.succeeded]\n", webhook.URL()))
+
+		err := Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "--env", envFile, "upload-product", "--product", filepath.Join(dir, "missing.pivotal")})
+		Expect(err).To(HaveOccurred())
+
+		events := receivedEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0]["event"]).To(Equal("upload-product.failed"))
+		Expect(events[0]["error"]).To(Equal(err.Error()))
+		Expect(events[0]["text"]).To(HaveSuffix(": " + err.Error()))
+	})
+
+	It("does not fail the command when the webhook cannot be reached", func() {
+		webhook.RouteToHandler("POST", "/hook", ghttp.RespondWith(http.StatusInternalServerError, ""))
+		envFile := writeEnvFile(fmt.Sprintf("  webhooks:\n  - url: %s/hook\n", webhook.URL()))
+
+		err := Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "--env", envFile, "upload-product", "--product", product})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhook.ReceivedRequests()).To(HaveLen(2))
+	})
+
+	It("does not post the events of the other commands", func() {
+		envFile := writeEnvFile(fmt.Sprintf("  webhooks:\n  - url: %s/hook\n", webhook.URL()))
+
+		err := Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "--env", envFile, "curl", "--path", "/api/v0/info"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(webhook.ReceivedRequests()).To(BeEmpty())
+	})
+
+	It("rejects a webhook that is not an http url, without printing it", func() {
+		envFile := writeEnvFile("  webhooks:\n  - url: hooks.example.com/some-secret\n")
+
+		err := Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "--env", envFile, "upload-product", "--product", product})
+		Expect(err).To(MatchError("the url of webhook 1 of the notifications in the env file must be an http or https url"))
+	})
+})
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const pluginPrefix = "om-"
+
+// PluginError is returned when a plugin exits with a non-zero exit code,
+// so om can exit with the same code.
+type PluginError struct {
+	Name     string
+	ExitCode int
+}
+
+func (e PluginError) Error() string {
+	return fmt.Sprintf("plugin %s exited with exit code %d", e.Name, e.ExitCode)
+}
+
+// findPlugin returns the path of the plugin for the command name, either
+// declared in the plugins of the env file or an executable named om-<name>
+// in the PATH.
+func findPlugin(name string, declared map[string]string) (string, bool) {
+	if path, ok := declared[name]; ok {
+		return path, true
+	}
+
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, "-") {
+		return "", false
+	}
+
+	path, err := exec.LookPath(pluginPrefix + name)
+	if err != nil {
+		return "", false
+	}
+
+	return path, true
+}
+
+// listPlugins returns the names of the declared plugins and of the om-<name>
+// executables in the PATH.
+func listPlugins(declared map[string]string) []string {
+	found := map[string]bool{}
+	for name := range declared {
+		found[name] = true
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), pluginPrefix)
+			if !ok || entry.IsDir() {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil || info.Mode()&0111 == 0 {
+				continue
+			}
+
+			found[strings.TrimSuffix(name, filepath.Ext(name))] = true
+		}
+	}
+
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// runPlugin runs the plugin attached to the terminal of om, with the
+// options resolved from the flags, the environment and the env file.
+func runPlugin(name, path string, args []string, global options) error {
+	command := exec.Command(path, args...)
+	command.Env = append(os.Environ(), pluginEnvironment(global)...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	err := command.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return PluginError{Name: name, ExitCode: exitErr.ExitCode()}
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not run plugin %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func pluginEnvironment(global options) []string {
+	var env []string
+	set := func(name, value string) {
+		if value != "" {
+			env = append(env, name+"="+value)
+		}
+	}
+
+	if executable, err := os.Executable(); err == nil {
+		set("OM_BIN", executable)
+	}
+
+	set("OM_TARGET", global.Target)
+	set("OM_UAA_TARGET", global.UAATarget)
+	set("OM_USERNAME", global.Username)
+	set("OM_PASSWORD", global.Password)
+	set("OM_CLIENT_ID", global.ClientID)
+	set("OM_CLIENT_SECRET", global.ClientSecret)
+	set("OM_DECRYPTION_PASSPHRASE", global.DecryptionPassphrase)
+	set("OM_CA_CERT", global.CACert)
+	set("OM_CA_CERT_FILE", global.CACertFile)
+	set("OM_CLIENT_CERT", global.ClientCert)
+	set("OM_CLIENT_KEY", global.ClientKey)
+	set("OM_SOCKS_PROXY", global.SOCKSProxy)
+	set("OM_TOKEN_CACHE", global.TokenCache)
+	set("OM_CONNECT_TIMEOUT", strconv.Itoa(global.ConnectTimeout))
+	set("OM_REQUEST_TIMEOUT", strconv.Itoa(global.RequestTimeout))
+	if global.TransferTimeout != nil {
+		set("OM_TRANSFER_TIMEOUT", strconv.Itoa(*global.TransferTimeout))
+	}
+	if global.SkipSSLValidation {
+		set("OM_SKIP_SSL_VALIDATION", "true")
+	}
+
+	return env
+}
+
+func writePlugins(w io.Writer, names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Plugins:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("plugins", func() {
+	var (
+		binDir string
+		output string
+	)
+
+	writePlugin := func(path string, exitCode string) {
+		script := "#!/bin/sh\necho \"$OM_TARGET $OM_USERNAME $OM_PASSWORD $OM_SKIP_SSL_VALIDATION $*\" > " + output + "\nexit " + exitCode + "\n"
+		Expect(os.WriteFile(path, []byte(script), 0755)).To(Succeed())
+	}
+
+	main := func(args ...string) error {
+		return Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", append([]string{"om"}, args...))
+	}
+
+	BeforeEach(func() {
+		binDir = GinkgoT().TempDir()
+		output = filepath.Join(binDir, "output")
+		GinkgoT().Setenv("PATH", binDir)
+	})
+
+	It("runs om-<name> in the PATH with the resolved options", func() {
+		writePlugin(filepath.Join(binDir, "om-hello"), "0")
+
+		err := main("--target", "https://opsman.example.com", "--username", "admin", "--password", "secret", "-k", "hello", "--name", "world")
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, err := os.ReadFile(output)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("https://opsman.example.com admin secret true --name world\n"))
+	})
+
+	It("runs the plugins declared in the env file, relative to it", func() {
+		envDir := GinkgoT().TempDir()
+		writePlugin(filepath.Join(envDir, "hello.sh"), "0")
+
+		envFile := filepath.Join(envDir, "env.yml")
+		Expect(os.WriteFile(envFile, []byte("target: https://opsman.example.com\nplugins:\n  hello: hello.sh\n"), 0600)).To(Succeed())
+
+		err := main("--env", envFile, "hello")
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, err := os.ReadFile(output)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("https://opsman.example.com    \n"))
+	})
+
+	It("returns the exit code of the plugin", func() {
+		writePlugin(filepath.Join(binDir, "om-hello"), "3")
+
+		err := main("--target", "https://opsman.example.com", "hello")
+		Expect(err).To(Equal(PluginError{Name: "hello", ExitCode: 3}))
+		Expect(err).To(MatchError("plugin hello exited with exit code 3"))
+	})
+
+	It("prefers the built-in commands", func() {
+		writePlugin(filepath.Join(binDir, "om-version"), "0")
+
+		err := main("version")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(output).ToNot(BeAnExistingFile())
+	})
+
+	It("lists the plugins", func() {
+		writePlugin(filepath.Join(binDir, "om-hello"), "0")
+		Expect(os.WriteFile(filepath.Join(binDir, "om-not-executable"), nil, 0644)).To(Succeed())
+
+		Expect(listPlugins(map[string]string{"declared": "/some/path"})).To(Equal([]string{"declared", "hello"}))
+	})
+})
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const telemetryShutdownTimeout = 10 * time.Second
+
+// telemetry exports the spans and metrics of a run of om to an OTLP
+// endpoint. The spans of the requests are children of the span of the
+// command.
+type telemetry struct {
+	ctx            context.Context
+	tracer         trace.Tracer
+	meter          metric.Meter
+	span           trace.Span
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *sdkmetric.MeterProvider
+}
+
+func newTelemetry(endpoint, command, version string) (*telemetry, error) {
+	ctx := context.Background()
+
+	traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not create the OTLP trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not create the OTLP metric exporter: %w", err)
+	}
+
+	serviceResource := resource.NewSchemaless(
+		attribute.String("service.name", "om"),
+		attribute.String("service.version", version),
+	)
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(serviceResource),
+	)
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(serviceResource),
+	)
+
+	tracer := tracerProvider.Tracer("github.com/pivotal-cf/om")
+	ctx, span := tracer.Start(ctx, "om "+command, trace.WithAttributes(attribute.String("om.command", command)))
+
+	return &telemetry{
+		ctx:            ctx,
+		tracer:         tracer,
+		meter:          meterProvider.Meter("github.com/pivotal-cf/om"),
+		span:           span,
+		tracerProvider: tracerProvider,
+		meterProvider:  meterProvider,
+	}, nil
+}
+
+// shutdown ends the span of the command with its error and flushes the
+// spans and metrics.
+func (t *telemetry) shutdown(commandErr error) error {
+	if commandErr != nil {
+		t.span.RecordError(commandErr)
+	}
+	t.span.End()
+
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryShutdownTimeout)
+	defer cancel()
+
+	err := errors.Join(t.tracerProvider.Shutdown(ctx), t.meterProvider.Shutdown(ctx))
+	if err != nil {
+		return fmt.Errorf("could not export telemetry: %w", err)
+	}
+
+	return nil
+}
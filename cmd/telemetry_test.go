@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Main with --otlp-endpoint", func() {
+	It("exports the traces and metrics of the command", func() {
+		collector := ghttp.NewServer()
+		defer collector.Close()
+		collector.RouteToHandler("POST", "/v1/traces", ghttp.RespondWith(http.StatusOK, nil))
+		collector.RouteToHandler("POST", "/v1/metrics", ghttp.RespondWith(http.StatusOK, nil))
+
+		opsman := ghttp.NewServer()
+		defer opsman.Close()
+		opsman.RouteToHandler("POST", "/uaa/oauth/token", ghttp.RespondWith(http.StatusOK, `{"access_token": "some-token", "token_type": "bearer", "expires_in": 3600}`, http.Header{"Content-Type": []string{"application/json"}}))
+		opsman.RouteToHandler("GET", "/api/v0/info", ghttp.RespondWith(http.StatusOK, `{"info": {"version": "3.0.1"}}`))
+
+		err := Main(gbytes.NewBuffer(), gbytes.NewBuffer(), "1.0.0", "1ms", []string{"om", "--otlp-endpoint", collector.URL(), "--target", opsman.URL(), "--username", "admin", "--password", "secret", "curl", "--path", "/api/v0/info"})
+		Expect(err).ToNot(HaveOccurred())
+
+		var paths []string
+		for _, request := range collector.ReceivedRequests() {
+			paths = append(paths, request.URL.Path)
+		}
+		Expect(paths).To(ConsistOf("/v1/traces", "/v1/metrics"))
+	})
+})
@@ -9,10 +9,12 @@ import (
 )
 
 type ActivateCertificateAuthority struct {
-	service activateCertificateAuthorityService
-	logger  logger
-	Options struct {
+	service      activateCertificateAuthorityService
+	logger       logger
+	waitDuration time.Duration
+	Options      struct {
 		Id string `long:"id" required:"false" description:"certificate authority id"`
+		certificateAuthorityWaitOptions
 	}
 }
 
@@ -20,10 +22,11 @@ type ActivateCertificateAuthority struct {
 type activateCertificateAuthorityService interface {
 	ActivateCertificateAuthority(api.ActivateCertificateAuthorityInput) error
 	ListCertificateAuthorities() (api.CertificateAuthoritiesOutput, error)
+	ListCertificates(expiresWithin string) ([]api.ExpiringCertificate, error)
 }
 
-func NewActivateCertificateAuthority(service activateCertificateAuthorityService, logger logger) *ActivateCertificateAuthority {
-	return &ActivateCertificateAuthority{service: service, logger: logger}
+func NewActivateCertificateAuthority(service activateCertificateAuthorityService, logger logger, waitDuration time.Duration) *ActivateCertificateAuthority {
+	return &ActivateCertificateAuthority{service: service, logger: logger, waitDuration: waitDuration}
 }
 
 func (a ActivateCertificateAuthority) Execute(args []string) error {
@@ -54,7 +57,19 @@ func (a ActivateCertificateAuthority) Execute(args []string) error {
 
 	a.logger.Printf("Certificate authority '%s' activated\n", a.Options.Id)
 
-	return nil
+	if a.Options.SkipWait {
+		return nil
+	}
+
+	return waitForCertificateAuthorities(a.service, a.logger, a.Options.certificateAuthorityWaitOptions, a.waitDuration, "activated", func(cas []api.CA) bool {
+		for _, ca := range cas {
+			if ca.GUID == guid {
+				return ca.Active
+			}
+		}
+
+		return false
+	})
 }
 
 func getLatestCertificateAuthority(caList []api.CA) api.CA {
@@ -3,6 +3,8 @@ package commands_test
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -22,13 +24,14 @@ var _ = Describe("ActivateCertificateAuthority", func() {
 	BeforeEach(func() {
 		fakeService = &fakes.ActivateCertificateAuthorityService{}
 		fakeLogger = &fakes.Logger{}
-		command = commands.NewActivateCertificateAuthority(fakeService, fakeLogger)
+		command = commands.NewActivateCertificateAuthority(fakeService, fakeLogger, 0)
 	})
 
 	Describe("Execute", func() {
 		It("activates the specified certificate authority", func() {
 			err := executeCommand(command, []string{
 				"--id", "some-certificate-authority-id",
+				"--skip-wait",
 			})
 			Expect(err).ToNot(HaveOccurred())
 
@@ -43,7 +46,7 @@ var _ = Describe("ActivateCertificateAuthority", func() {
 		})
 
 		Context("with no guid specified", func() {
-			args := []string{}
+			args := []string{"--skip-wait"}
 			Context("with an inactive CA newer than the active CA", func() {
 				BeforeEach(func() {
 					fakeService.ListCertificateAuthoritiesReturns(api.CertificateAuthoritiesOutput{CAs: []api.CA{
@@ -228,12 +231,61 @@ var _ = Describe("ActivateCertificateAuthority", func() {
 			})
 		})
 
+		When("waiting for the activation to settle", func() {
+			var output func() string
+
+			BeforeEach(func() {
+				output = func() string {
+					var lines []string
+					for i := 0; i < fakeLogger.PrintfCallCount(); i++ {
+						format, content := fakeLogger.PrintfArgsForCall(i)
+						lines = append(lines, fmt.Sprintf(format, content...))
+					}
+					return strings.Join(lines, "")
+				}
+
+				fakeService.ListCertificateAuthoritiesReturnsOnCall(0, api.CertificateAuthoritiesOutput{CAs: []api.CA{{GUID: "new-ca", Active: false}}}, nil)
+				fakeService.ListCertificateAuthoritiesReturns(api.CertificateAuthoritiesOutput{CAs: []api.CA{{GUID: "new-ca", Active: true}}}, nil)
+				fakeService.ListCertificatesReturns([]api.ExpiringCertificate{
+					{ProductGUID: "cf-guid", VariablePath: "/cf/router_tls", ValidFrom: time.Now().Add(-time.Hour), ValidUntil: time.Now().Add(time.Hour)},
+				}, nil)
+			})
+
+			It("waits for the certificate authority to be active and checks the deployed certificates", func() {
+				err := executeCommand(command, []string{"--id", "new-ca"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.ListCertificateAuthoritiesCallCount()).To(Equal(2))
+				Expect(fakeService.ListCertificatesArgsForCall(0)).To(Equal(""))
+				Expect(output()).To(ContainSubstring("Waiting for the certificate authorities to be activated..."))
+				Expect(output()).To(HaveSuffix("Deployed certificates are valid (1 checked)\n"))
+			})
+
+			It("returns an error when a deployed certificate is not valid", func() {
+				fakeService.ListCertificatesReturns([]api.ExpiringCertificate{
+					{ProductGUID: "cf-guid", VariablePath: "/cf/router_tls", ValidUntil: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil)
+
+				err := executeCommand(command, []string{"--id", "new-ca"})
+				Expect(err).To(MatchError("the certificate authorities were activated, but 1 deployed certificates are not valid:\ncf-guid /cf/router_tls expired on 2020-01-01T00:00:00Z"))
+			})
+
+			It("returns an error when the activation does not settle in time", func() {
+				fakeService.ListCertificateAuthoritiesReturns(api.CertificateAuthoritiesOutput{CAs: []api.CA{{GUID: "new-ca", Active: false}}}, nil)
+
+				err := executeCommand(command, []string{"--id", "new-ca", "--timeout", "0"})
+				Expect(err).To(MatchError("the certificate authorities were not activated within 0s"))
+				Expect(fakeService.ListCertificatesCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the service fails to activate a certificate", func() {
 			It("returns an error", func() {
 				fakeService.ActivateCertificateAuthorityReturns(errors.New("failed to activate certificate"))
 
 				err := executeCommand(command, []string{
 					"--id", "some-certificate-authority-id",
+					"--skip-wait",
 				})
 				Expect(err).To(MatchError("failed to activate certificate"))
 			})
@@ -0,0 +1,64 @@
+package commands
+
+type AddTrustedCertificate struct {
+	service trustedCertificatesService
+	logger  logger
+	Options struct {
+		Certificate string `long:"certificate" short:"c" required:"true" description:"PEM encoded certificates to trust, or the path to a file holding them"`
+	}
+}
+
+func NewAddTrustedCertificate(service trustedCertificatesService, logger logger) *AddTrustedCertificate {
+	return &AddTrustedCertificate{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (a AddTrustedCertificate) Execute(args []string) error {
+	additions, err := readCertificates(a.Options.Certificate)
+	if err != nil {
+		return err
+	}
+
+	security, err := readSecurityConfiguration(a.service)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := parseTrustedCertificates(security)
+	if err != nil {
+		return err
+	}
+
+	trusted := map[string]bool{}
+	for _, certificate := range certificates {
+		trusted[certificate.fingerprint] = true
+	}
+
+	var added int
+	for _, certificate := range additions {
+		if trusted[certificate.fingerprint] {
+			a.logger.Printf("%s is already trusted", certificate)
+			continue
+		}
+
+		a.logger.Printf("trusting %s", certificate)
+		certificates = append(certificates, certificate)
+		trusted[certificate.fingerprint] = true
+		added++
+	}
+
+	if added == 0 {
+		return nil
+	}
+
+	err = writeSecurityConfiguration(a.service, security, certificates)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Printf("added %d trusted certificates, apply changes to deploy them", added)
+
+	return nil
+}
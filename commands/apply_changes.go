@@ -4,38 +4,55 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
 
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/progress"
 )
 
+// ErrApplyChangesTimedOut has its own exit code, so that pipelines can tell
+// an installation that did not finish within --timeout from one that failed.
+var ErrApplyChangesTimedOut = errors.New("apply changes timed out")
+
 type ApplyChanges struct {
 	service        applyChangesService
 	pendingService pendingChangesService
 	logger         logger
 	logWriter      logWriter
+	progressWriter io.Writer
 	waitDuration   time.Duration
 	Options        struct {
 		Config               string   `short:"c"   long:"config"               description:"path to yml file containing errand configuration (see docs/apply-changes/README.md for format)"`
 		IgnoreWarnings       bool     `short:"i"   long:"ignore-warnings"      description:"For convenience. Use other commands to disable particular verifiers if they are inappropriate."`
 		Reattach             bool     `long:"reattach" description:"reattach to an already running apply changes (if available)"`
-		RecreateVMs          bool     `long:"recreate-vms" description:"recreate all vms"`
+		RecreateVMs          bool     `long:"recreate-vms" description:"recreate all vms, or only the vms of the products given with --product-name"`
+		RecreateDirector     bool     `long:"recreate-director" description:"with --recreate-vms and --product-name, also recreate the director vm (OM 2.9+)"`
 		SkipDeployProducts   bool     `short:"s" long:"skip-deploy-products" description:"skip deploying products when applying changes - just update the director"`
 		ForceLatestVariables bool     `long:"force-latest-variables" description:"force any certificates or other BOSH variables to use their latest version even when a stemcell is not being upgraded"`
 		ProductNames         []string `short:"n"   long:"product-name"         description:"name of the product(s) to deploy, cannot be used in conjunction with --skip-deploy-products (OM 2.2+)"`
+		IncludeDependencies  bool     `long:"include-dependencies"             description:"with --product-name, also deploy the products they depend on that have pending changes, instead of only warning about them"`
+		Errands              []string `long:"errand"                           description:"post-deploy errand setting for this run only, overriding the config. Format: PRODUCT:ERRAND=STATE, where STATE is true, false, default or when-changed. Can be repeated"`
+		PreDeleteErrands     []string `long:"pre-delete-errand"                description:"pre-delete errand setting for this run only, overriding the config. Format: PRODUCT:ERRAND=STATE. Can be repeated"`
+		Timeout              int      `long:"timeout"                          description:"seconds to wait for the installation to finish before exiting with 6, 0 waits until it finishes"`
+		AbortOnTimeout       bool     `long:"abort-on-timeout"                 description:"with --timeout, abort the installation when it times out and stream its logs until it stops"`
 	}
 }
 
 //counterfeiter:generate -o ./fakes/apply_changes_service.go --fake-name ApplyChangesService . applyChangesService
 type applyChangesService interface {
+	AbortInstallation(id int) error
 	CreateInstallation(bool, bool, bool, []string, api.ApplyErrandChanges) (api.InstallationsServiceOutput, error)
 	GetInstallation(id int) (api.InstallationsServiceOutput, error)
 	GetInstallationLogs(id int) (api.InstallationsServiceOutput, error)
+	GetStagedProductDependencies(productGUID string) ([]api.ProductDependency, error)
 	Info() (api.Info, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
 	RunningInstallation() (api.InstallationsServiceOutput, error)
 	ListInstallations() ([]api.InstallationsServiceOutput, error)
 	UpdateStagedDirectorProperties(api.DirectorProperties) error
@@ -46,12 +63,13 @@ type logWriter interface {
 	Flush(logs string) error
 }
 
-func NewApplyChanges(service applyChangesService, pendingService pendingChangesService, logWriter logWriter, logger logger, waitDuration time.Duration) *ApplyChanges {
+func NewApplyChanges(service applyChangesService, pendingService pendingChangesService, logWriter logWriter, logger logger, progressWriter io.Writer, waitDuration time.Duration) *ApplyChanges {
 	return &ApplyChanges{
 		service:        service,
 		pendingService: pendingService,
 		logger:         logger,
 		logWriter:      logWriter,
+		progressWriter: progressWriter,
 		waitDuration:   waitDuration,
 	}
 }
@@ -61,6 +79,14 @@ func (ac ApplyChanges) Execute(args []string) error {
 		return errors.New("--recreate-vms cannot be used with --reattach because it requires the ability to update a director property")
 	}
 
+	if ac.Options.RecreateDirector && (!ac.Options.RecreateVMs || len(ac.Options.ProductNames) == 0) {
+		return errors.New("--recreate-director can only be used with --recreate-vms and --product-name")
+	}
+
+	if ac.Options.AbortOnTimeout && ac.Options.Timeout <= 0 {
+		return errors.New("--abort-on-timeout can only be used with --timeout")
+	}
+
 	errands := api.ApplyErrandChanges{}
 
 	if ac.Options.Config != "" {
@@ -75,6 +101,11 @@ func (ac ApplyChanges) Execute(args []string) error {
 		}
 	}
 
+	err := ac.overrideErrands(&errands)
+	if err != nil {
+		return err
+	}
+
 	var changedProducts []string
 	if len(ac.Options.ProductNames) > 0 {
 		if ac.Options.SkipDeployProducts {
@@ -87,7 +118,10 @@ func (ac ApplyChanges) Execute(args []string) error {
 		if ok, err := info.VersionAtLeast(2, 2); !ok {
 			return fmt.Errorf("--product-name is only available with Ops Manager 2.2 or later: you are running %s. Error: %w", info.Version, err)
 		}
-		changedProducts = ac.Options.ProductNames
+		changedProducts, err = ac.withDependencies(ac.Options.ProductNames)
+		if err != nil {
+			return err
+		}
 	}
 
 	installation, err := ac.service.RunningInstallation()
@@ -125,7 +159,12 @@ func (ac ApplyChanges) Execute(args []string) error {
 			for _, product := range ac.Options.ProductNames {
 				ac.logger.Printf("- %s", product)
 			}
-			ac.logger.Println("this will also recreate the director vm if there are changes")
+			if ac.Options.RecreateDirector {
+				ac.logger.Println("setting director to recreate director vm as well")
+				config.DirectorConfiguration.DirectorRecreate = true
+			} else {
+				ac.logger.Println("this will also recreate the director vm if there are changes")
+			}
 			config.DirectorConfiguration.ProductRecreate = true
 		} else if ac.Options.SkipDeployProducts {
 			ac.logger.Println("setting director to recreate director vm (available in Ops Manager 2.9+)")
@@ -147,6 +186,10 @@ func (ac ApplyChanges) Execute(args []string) error {
 		}
 
 		if !versionAtLeast29 {
+			if ac.Options.RecreateDirector {
+				return fmt.Errorf("--recreate-director is only available with Ops Manager 2.9 or later: you are running %s", info.Version)
+			}
+
 			config.DirectorConfiguration.ProductRecreate = true
 			config.DirectorConfiguration.DirectorRecreate = false
 		}
@@ -167,9 +210,157 @@ func (ac ApplyChanges) Execute(args []string) error {
 	return ac.waitForApplyChangesCompletion(installation)
 }
 
+// overrideErrands applies the --errand and --pre-delete-errand flags. They
+// are sent with the installation, so the staged errand settings are unchanged.
+func (ac ApplyChanges) overrideErrands(errands *api.ApplyErrandChanges) error {
+	set := func(flag, setting string, preDelete bool) error {
+		product, rest, ok := strings.Cut(setting, ":")
+		errand, state, ok2 := strings.Cut(rest, "=")
+		if !ok || !ok2 || product == "" || errand == "" {
+			return fmt.Errorf("%s %q must have the format PRODUCT:ERRAND=STATE", flag, setting)
+		}
+
+		var value interface{}
+		switch state {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "default", "when-changed":
+			value = state
+		default:
+			return fmt.Errorf("%s %q has an unknown state %q: use true, false, default or when-changed", flag, setting, state)
+		}
+
+		if errands.Errands == nil {
+			errands.Errands = map[string]api.ProductErrand{}
+		}
+
+		productErrand := errands.Errands[product]
+		if preDelete {
+			if productErrand.RunPreDelete == nil {
+				productErrand.RunPreDelete = map[string]interface{}{}
+			}
+			productErrand.RunPreDelete[errand] = value
+		} else {
+			if productErrand.RunPostDeploy == nil {
+				productErrand.RunPostDeploy = map[string]interface{}{}
+			}
+			productErrand.RunPostDeploy[errand] = value
+		}
+		errands.Errands[product] = productErrand
+
+		return nil
+	}
+
+	for _, setting := range ac.Options.Errands {
+		err := set("--errand", setting, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, setting := range ac.Options.PreDeleteErrands {
+		err := set("--pre-delete-errand", setting, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withDependencies finds the upstream products of the selected products that
+// have pending changes. Deploying without them can fail half way through, so
+// they are added with --include-dependencies and warned about otherwise.
+func (ac ApplyChanges) withDependencies(productNames []string) ([]string, error) {
+	stagedProducts, err := ac.service.ListStagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("could not list staged products: %s", err)
+	}
+
+	guids := map[string]string{}
+	for _, product := range stagedProducts.Products {
+		guids[product.Type] = product.GUID
+	}
+
+	pendingChanges, err := ac.pendingService.ListStagedPendingChanges()
+	if err != nil {
+		return nil, fmt.Errorf("could not list pending changes: %s", err)
+	}
+
+	changed := map[string]bool{}
+	for _, change := range pendingChanges.ChangeList {
+		if change.Action != "unchanged" {
+			changed[change.GUID] = true
+		}
+	}
+
+	visited := map[string]bool{}
+	for _, name := range productNames {
+		visited[name] = true
+	}
+
+	var missing []string
+	queue := append([]string{}, productNames...)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		guid, ok := guids[name]
+		if !ok {
+			continue
+		}
+
+		dependencies, err := ac.service.GetStagedProductDependencies(guid)
+		if err != nil {
+			return nil, fmt.Errorf("could not get the dependencies of %s: %s", name, err)
+		}
+
+		for _, dependency := range dependencies {
+			// the director is always deployed
+			if dependency.Type == "p-bosh" || visited[dependency.Type] {
+				continue
+			}
+			visited[dependency.Type] = true
+			queue = append(queue, dependency.Type)
+
+			if changed[dependency.GUID] {
+				missing = append(missing, dependency.Type)
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return productNames, nil
+	}
+
+	sort.Strings(missing)
+	if !ac.Options.IncludeDependencies {
+		ac.logger.Println("warning: the following products are dependencies of the selected products and have pending changes that will not be deployed:")
+		for _, name := range missing {
+			ac.logger.Printf("- %s", name)
+		}
+		ac.logger.Println("use --include-dependencies to deploy them as well")
+
+		return productNames, nil
+	}
+
+	ac.logger.Println("also deploying the following products that the selected products depend on:")
+	for _, name := range missing {
+		ac.logger.Printf("- %s", name)
+	}
+
+	return append(append([]string{}, productNames...), missing...), nil
+}
+
 func (ac ApplyChanges) waitForApplyChangesCompletion(installation api.InstallationsServiceOutput) error {
 	const maxRetries = 3
 
+	timeout := time.Duration(ac.Options.Timeout) * time.Second
+	deadline := time.Now().Add(timeout)
+	aborted := false
+
 	for {
 		var current api.InstallationsServiceOutput
 		var err error
@@ -198,12 +389,49 @@ func (ac ApplyChanges) waitForApplyChangesCompletion(installation api.Installati
 			return fmt.Errorf("installation failed to flush logs: %s", err)
 		}
 
+		ac.reportProgress(installation.ID, current)
+
 		if current.Status == api.StatusSucceeded {
 			return nil
 		} else if current.Status == api.StatusFailed {
+			if aborted {
+				return fmt.Errorf("%w: installation %d was aborted after %s", ErrApplyChangesTimedOut, installation.ID, timeout)
+			}
 			return errors.New("installation was unsuccessful")
 		}
 
+		if timeout > 0 && !aborted && time.Now().After(deadline) {
+			if !ac.Options.AbortOnTimeout {
+				return fmt.Errorf("%w: installation %d did not finish within %s and is still running", ErrApplyChangesTimedOut, installation.ID, timeout)
+			}
+
+			ac.logger.Printf("aborting installation %d, it did not finish within %s", installation.ID, timeout)
+			err = ac.service.AbortInstallation(installation.ID)
+			if err != nil {
+				return fmt.Errorf("could not abort installation %d: %w", installation.ID, err)
+			}
+			aborted = true
+		}
+
 		time.Sleep(ac.waitDuration)
 	}
 }
+
+// reportProgress reports the status of the installation for
+// --progress-format json.
+func (ac ApplyChanges) reportProgress(id int, installation api.InstallationsServiceOutput) {
+	event := progress.Event{
+		Phase:  "installation",
+		State:  "running",
+		ID:     id,
+		Status: installation.Status,
+	}
+	if installation.Status == api.StatusSucceeded || installation.Status == api.StatusFailed {
+		event.State = "finished"
+	}
+	if installation.StartedAt != nil {
+		event.Elapsed = int64(time.Since(*installation.StartedAt).Seconds())
+	}
+
+	progress.Report(ac.progressWriter, event)
+}
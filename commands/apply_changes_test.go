@@ -14,6 +14,7 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/progress"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -53,7 +54,7 @@ var _ = Describe("ApplyChanges", func() {
 		})
 
 		It("applies changes to the Ops Manager", func() {
-			command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 			err := executeCommand(command, []string{})
 			Expect(err).ToNot(HaveOccurred())
@@ -79,12 +80,24 @@ var _ = Describe("ApplyChanges", func() {
 			Expect(writer.FlushArgsForCall(2)).To(Equal("some other logs"))
 		})
 
+		It("reports the status of the installation to a JSON progress writer", func() {
+			events := gbytes.NewBuffer()
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, progress.NewJSONWriter(GinkgoWriter, events), 1)
+
+			err := executeCommand(command, []string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(events).To(gbytes.Say(`"phase":"installation","state":"running","id":311,"status":"running"`))
+			Expect(events).To(gbytes.Say(`"phase":"installation","state":"running","id":311,"status":"running"`))
+			Expect(events).To(gbytes.Say(`"phase":"installation","state":"finished","id":311,"status":"succeeded"`))
+		})
+
 		It("retries apply changes to the Ops Manager", func() {
 			service.GetInstallationReturnsOnCall(0, api.InstallationsServiceOutput{}, errors.New("some error"))
 			service.GetInstallationReturnsOnCall(1, api.InstallationsServiceOutput{Status: "running"}, nil)
 			service.GetInstallationReturnsOnCall(2, api.InstallationsServiceOutput{Status: "succeeded"}, nil)
 
-			command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 			err := executeCommand(command, []string{})
 			Expect(err).ToNot(HaveOccurred())
@@ -112,7 +125,7 @@ var _ = Describe("ApplyChanges", func() {
 			It("applies changes while ignoring warnings", func() {
 				service.InfoReturns(api.Info{Version: "2.3-build43"}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{"--ignore-warnings"})
 				Expect(err).ToNot(HaveOccurred())
@@ -126,7 +139,7 @@ var _ = Describe("ApplyChanges", func() {
 			It("applies changes while forcing the latest variable versions to be used", func() {
 				service.InfoReturns(api.Info{Version: "2.3-build43"}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{"--force-latest-variables"})
 				Expect(err).ToNot(HaveOccurred())
@@ -138,7 +151,7 @@ var _ = Describe("ApplyChanges", func() {
 
 		When("passed the skip-deploy-products flag", func() {
 			It("applies changes while not deploying products", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{"--skip-deploy-products"})
 				Expect(err).ToNot(HaveOccurred())
@@ -148,7 +161,7 @@ var _ = Describe("ApplyChanges", func() {
 			})
 
 			It("fails if product names were specified", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 				err := executeCommand(command, []string{"--skip-deploy-products", "--product-name", "product1"})
 				Expect(err).To(HaveOccurred())
 			})
@@ -160,13 +173,83 @@ var _ = Describe("ApplyChanges", func() {
 				service.CreateInstallationReturns(api.InstallationsServiceOutput{}, errors.New("error"))
 				service.RunningInstallationReturns(api.InstallationsServiceOutput{}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 				err := executeCommand(command, []string{"--product-name", "product1", "--product-name", "product2"})
 				Expect(err).To(HaveOccurred())
 
 				_, _, _, productNames, _ := service.CreateInstallationArgsForCall(0)
 				Expect(productNames).To(ConsistOf("product1", "product2"))
 			})
+
+			When("a selected product depends on products with pending changes", func() {
+				BeforeEach(func() {
+					service.InfoReturns(api.Info{Version: "2.10.0"}, nil)
+					service.ListStagedProductsReturns(api.StagedProductsOutput{
+						Products: []api.StagedProduct{
+							{GUID: "cf-guid", Type: "cf"},
+							{GUID: "mysql-guid", Type: "p-mysql"},
+							{GUID: "redis-guid", Type: "p-redis"},
+							{GUID: "dataflow-guid", Type: "p-dataflow"},
+						},
+					}, nil)
+					service.GetStagedProductDependenciesStub = func(guid string) ([]api.ProductDependency, error) {
+						switch guid {
+						case "dataflow-guid":
+							return []api.ProductDependency{
+								{GUID: "mysql-guid", Type: "p-mysql"},
+								{GUID: "bosh-guid", Type: "p-bosh"},
+							}, nil
+						case "mysql-guid":
+							return []api.ProductDependency{{GUID: "redis-guid", Type: "p-redis"}}, nil
+						}
+						return nil, nil
+					}
+					pendingService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+						ChangeList: []api.ProductChange{
+							{GUID: "bosh-guid", Action: "update"},
+							{GUID: "cf-guid", Action: "update"},
+							{GUID: "mysql-guid", Action: "unchanged"},
+							{GUID: "redis-guid", Action: "update"},
+							{GUID: "dataflow-guid", Action: "update"},
+						},
+					}, nil)
+				})
+
+				It("warns about the dependencies and only deploys the selected products", func() {
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+					err := executeCommand(command, []string{"--product-name", "p-dataflow"})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _, _, productNames, _ := service.CreateInstallationArgsForCall(0)
+					Expect(productNames).To(Equal([]string{"p-dataflow"}))
+
+					Expect(stderr).To(gbytes.Say("warning: the following products are dependencies of the selected products and have pending changes that will not be deployed:"))
+					Expect(stderr).To(gbytes.Say("- p-redis"))
+					Expect(stderr).To(gbytes.Say("use --include-dependencies to deploy them as well"))
+				})
+
+				It("deploys the dependencies as well with --include-dependencies", func() {
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+					err := executeCommand(command, []string{"--product-name", "p-dataflow", "--include-dependencies"})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _, _, productNames, _ := service.CreateInstallationArgsForCall(0)
+					Expect(productNames).To(Equal([]string{"p-dataflow", "p-redis"}))
+
+					Expect(stderr).To(gbytes.Say("also deploying the following products that the selected products depend on:"))
+					Expect(stderr).To(gbytes.Say("- p-redis"))
+				})
+
+				It("returns an error when the dependencies cannot be listed", func() {
+					service.GetStagedProductDependenciesStub = nil
+					service.GetStagedProductDependenciesReturns(nil, errors.New("some error"))
+
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+					err := executeCommand(command, []string{"--product-name", "p-dataflow"})
+					Expect(err).To(MatchError("could not get the dependencies of p-dataflow: some error"))
+					Expect(service.CreateInstallationCallCount()).To(Equal(0))
+				})
+			})
 		})
 
 		When("passed the reattach flag", func() {
@@ -179,7 +262,7 @@ var _ = Describe("ApplyChanges", func() {
 					StartedAt: &installationStartedAt,
 				}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{"--reattach"})
 				Expect(err).ToNot(HaveOccurred())
@@ -195,7 +278,7 @@ var _ = Describe("ApplyChanges", func() {
 
 			When("the recreate-vms flag is also passed", func() {
 				It("errors because this is a conflict", func() {
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 					err := executeCommand(command, []string{"--reattach", "--recreate-vms"})
 					Expect(err).To(MatchError(ContainSubstring("--recreate-vms cannot be used with --reattach because it requires the ability to update a director property")))
@@ -213,7 +296,7 @@ var _ = Describe("ApplyChanges", func() {
 					StartedAt: &installationStartedAt,
 				}, nil)
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{})
 				Expect(err).To(HaveOccurred())
@@ -226,7 +309,7 @@ var _ = Describe("ApplyChanges", func() {
 
 		When("passed the recreate-vms", func() {
 			It("ensures all vms are recreated", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{"--recreate-vms"})
 				Expect(err).ToNot(HaveOccurred())
@@ -245,7 +328,7 @@ var _ = Describe("ApplyChanges", func() {
 			})
 
 			It("ensures only the director is recreated", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{
 					"--recreate-vms",
@@ -266,7 +349,7 @@ var _ = Describe("ApplyChanges", func() {
 			})
 
 			It("ensures only products are updated", func() {
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{
 					"--recreate-vms",
@@ -290,11 +373,44 @@ var _ = Describe("ApplyChanges", func() {
 				Expect(stderr).To(gbytes.Say("this will also recreate the director vm if there are changes"))
 			})
 
+			It("recreates the director vm with the products when asked to", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+				err := executeCommand(command, []string{
+					"--recreate-vms",
+					"--recreate-director",
+					"--product-name", "cf",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(string(service.UpdateStagedDirectorPropertiesArgsForCall(0))).To(MatchJSON(`
+					{
+						"director_configuration": {
+							"bosh_recreate_on_next_deploy": true,
+							"bosh_director_recreate_on_next_deploy": true
+						}
+					}
+				`))
+				_, _, _, products, _ := service.CreateInstallationArgsForCall(0)
+				Expect(products).To(Equal([]string{"cf"}))
+
+				Expect(stderr).To(gbytes.Say("- cf"))
+				Expect(stderr).To(gbytes.Say("setting director to recreate director vm as well"))
+			})
+
+			It("requires --recreate-vms and --product-name with --recreate-director", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+				err := executeCommand(command, []string{"--recreate-vms", "--recreate-director"})
+				Expect(err).To(MatchError("--recreate-director can only be used with --recreate-vms and --product-name"))
+				Expect(service.UpdateStagedDirectorPropertiesCallCount()).To(Equal(0))
+			})
+
 			When("on a version less than 2.9", func() {
 				It("ensures only products are updated", func() {
 					service.InfoReturns(api.Info{Version: "2.6.0"}, nil)
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 					err := executeCommand(command, []string{
 						"--recreate-vms",
@@ -319,10 +435,26 @@ var _ = Describe("ApplyChanges", func() {
 				})
 			})
 
+			When("on a version less than 2.9 and --recreate-director is passed", func() {
+				It("returns an error", func() {
+					service.InfoReturns(api.Info{Version: "2.6.0"}, nil)
+
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+					err := executeCommand(command, []string{
+						"--recreate-vms",
+						"--recreate-director",
+						"--product-name", "cf",
+					})
+					Expect(err).To(MatchError("--recreate-director is only available with Ops Manager 2.9 or later: you are running 2.6.0"))
+					Expect(service.UpdateStagedDirectorPropertiesCallCount()).To(Equal(0))
+				})
+			})
+
 			When("the service returns an error", func() {
 				It("displays that error message", func() {
 					service.UpdateStagedDirectorPropertiesReturns(errors.New("testing"))
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 					err := executeCommand(command, []string{"--recreate-vms"})
 					Expect(err).To(MatchError(ContainSubstring("testing")))
@@ -390,7 +522,7 @@ errands:
 				})
 
 				It("calls the api with correct arguments", func() {
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 					err := executeCommand(command, []string{"--config", fileName})
 					Expect(err).ToNot(HaveOccurred())
@@ -435,11 +567,63 @@ errands:
 					Expect(writer.FlushArgsForCall(1)).To(Equal("these logs"))
 					Expect(writer.FlushArgsForCall(2)).To(Equal("some other logs"))
 				})
+				It("overrides the config with the errand flags for this run", func() {
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+					err := executeCommand(command, []string{
+						"--config", fileName,
+						"--errand", "product2_name:errand_a=true",
+						"--errand", "product3_name:smoke_tests=when-changed",
+						"--pre-delete-errand", "product1_name:errand_b=default",
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, _, _, _, errands := service.CreateInstallationArgsForCall(0)
+					Expect(errands).To(Equal(api.ApplyErrandChanges{
+						Errands: map[string]api.ProductErrand{
+							"product1_name": {
+								RunPostDeploy: map[string]interface{}{
+									"errand_c": "default",
+								},
+								RunPreDelete: map[string]interface{}{
+									"errand_a": true,
+									"errand_b": "default",
+								},
+							},
+							"product2_name": {
+								RunPostDeploy: map[string]interface{}{
+									"errand_a": true,
+								},
+								RunPreDelete: map[string]interface{}{
+									"errand_b": "default",
+								},
+							},
+							"product3_name": {
+								RunPostDeploy: map[string]interface{}{
+									"smoke_tests": "when-changed",
+								},
+							},
+						}}))
+				})
+			})
+
+			Context("given an invalid errand flag", func() {
+				It("returns an error", func() {
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+					err := executeCommand(command, []string{"--errand", "product1_name=true"})
+					Expect(err).To(MatchError(`--errand "product1_name=true" must have the format PRODUCT:ERRAND=STATE`))
+
+					command = commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+					err = executeCommand(command, []string{"--pre-delete-errand", "product1_name:errand_a=sometimes"})
+					Expect(err).To(MatchError(`--pre-delete-errand "product1_name:errand_a=sometimes" has an unknown state "sometimes": use true, false, default or when-changed`))
+					Expect(service.CreateInstallationCallCount()).To(Equal(0))
+				})
 			})
 
 			Context("given a file that does not exist", func() {
 				It("returns an error", func() {
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 					err := executeCommand(command, []string{"--config", "filedoesnotexist"})
 					Expect(err).To(MatchError("could not load config: open filedoesnotexist: no such file or directory"))
@@ -461,7 +645,7 @@ errands: lolololol
 				})
 
 				It("returns an error", func() {
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 					err := executeCommand(command, []string{"--config", fileName})
 					Expect(err).To(MatchError(ContainSubstring("line 3: cannot unmarshal !!str `lolololol`")))
@@ -469,12 +653,64 @@ errands: lolololol
 			})
 		})
 
+		When("passed the timeout flag", func() {
+			BeforeEach(func() {
+				service.GetInstallationStub = func(int) (api.InstallationsServiceOutput, error) {
+					time.Sleep(50 * time.Millisecond)
+					if service.AbortInstallationCallCount() > 0 {
+						return api.InstallationsServiceOutput{Status: "failed"}, nil
+					}
+					return api.InstallationsServiceOutput{Status: "running"}, nil
+				}
+			})
+
+			It("stops waiting for the installation when it times out", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+				err := executeCommand(command, []string{"--timeout", "1"})
+				Expect(err).To(MatchError("apply changes timed out: installation 311 did not finish within 1s and is still running"))
+				Expect(errors.Is(err, commands.ErrApplyChangesTimedOut)).To(BeTrue())
+				Expect(service.AbortInstallationCallCount()).To(Equal(0))
+			})
+
+			It("aborts the installation and streams its logs until it stops", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+				err := executeCommand(command, []string{"--timeout", "1", "--abort-on-timeout"})
+				Expect(err).To(MatchError("apply changes timed out: installation 311 was aborted after 1s"))
+				Expect(errors.Is(err, commands.ErrApplyChangesTimedOut)).To(BeTrue())
+
+				Expect(service.AbortInstallationCallCount()).To(Equal(1))
+				Expect(service.AbortInstallationArgsForCall(0)).To(Equal(311))
+				Expect(stderr).To(gbytes.Say("aborting installation 311, it did not finish within 1s"))
+
+				calls := service.GetInstallationCallCount()
+				Expect(service.GetInstallationLogsCallCount()).To(Equal(calls))
+				Expect(writer.FlushCallCount()).To(Equal(calls))
+			})
+
+			It("returns an error when the installation cannot be aborted", func() {
+				service.AbortInstallationReturns(errors.New("some error"))
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+				err := executeCommand(command, []string{"--timeout", "1", "--abort-on-timeout"})
+				Expect(err).To(MatchError("could not abort installation 311: some error"))
+			})
+
+			It("requires a timeout to abort on", func() {
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
+
+				err := executeCommand(command, []string{"--abort-on-timeout"})
+				Expect(err).To(MatchError("--abort-on-timeout can only be used with --timeout"))
+			})
+		})
+
 		It("handles a failed installation", func() {
 			service.CreateInstallationReturns(api.InstallationsServiceOutput{ID: 311}, nil)
 			service.GetInstallationReturnsOnCall(0, api.InstallationsServiceOutput{Status: "failed"}, nil)
 			service.GetInstallationLogsReturnsOnCall(0, api.InstallationsServiceOutput{Logs: "start of logs"}, nil)
 
-			command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+			command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 			err := executeCommand(command, []string{})
 			Expect(err).To(MatchError("installation was unsuccessful"))
@@ -484,7 +720,7 @@ errands: lolololol
 			It("returns an error", func() {
 				service.RunningInstallationReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{})
 				Expect(err).To(MatchError("could not check for any already running installation: some error"))
@@ -497,7 +733,7 @@ errands: lolololol
 				for _, version := range versions {
 					service.InfoReturns(api.Info{Version: version}, nil)
 
-					command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+					command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 					err := executeCommand(command, []string{"--product-name", "p-mysql"})
 					Expect(err.Error()).To(ContainSubstring(fmt.Sprintf("--product-name is only available with Ops Manager 2.2 or later: you are running %s", version)))
 				}
@@ -508,7 +744,7 @@ errands: lolololol
 			It("returns an error", func() {
 				service.CreateInstallationReturns(api.InstallationsServiceOutput{}, errors.New("some error"))
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{})
 				Expect(err).To(MatchError("installation failed to trigger: some error"))
@@ -522,7 +758,7 @@ errands: lolololol
 				service.GetInstallationReturnsOnCall(1, api.InstallationsServiceOutput{}, errors.New("second error"))
 				service.GetInstallationReturnsOnCall(2, api.InstallationsServiceOutput{}, errors.New("third error"))
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{})
 				Expect(err).To(MatchError("installation failed to get status after 3 attempts: third error"))
@@ -535,7 +771,7 @@ errands: lolololol
 				service.GetInstallationReturnsOnCall(0, api.InstallationsServiceOutput{Status: "running"}, nil)
 				service.GetInstallationLogsReturnsOnCall(0, api.InstallationsServiceOutput{}, errors.New("no"))
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{})
 				Expect(err).To(MatchError("installation failed to get logs: no"))
@@ -550,7 +786,7 @@ errands: lolololol
 
 				writer.FlushReturns(errors.New("yes"))
 
-				command := commands.NewApplyChanges(service, pendingService, writer, logger, 1)
+				command := commands.NewApplyChanges(service, pendingService, writer, logger, GinkgoWriter, 1)
 
 				err := executeCommand(command, []string{})
 				Expect(err).To(MatchError("installation failed to flush logs: yes"))
@@ -14,7 +14,7 @@ type AssignMultiStemcell struct {
 	Options struct {
 		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
 		ProductName        string                       `long:"product"  short:"p"  description:"name of Ops Manager tile to associate a stemcell to" required:"true"`
-		Stemcells          []string                     `long:"stemcell" short:"s"  description:"associate a particular stemcell version to a tile (ie 'ubuntu-trusty:123.4')" required:"true"`
+		Stemcells          []string                     `long:"stemcell" short:"s"  description:"associate a particular stemcell version to a tile (ie 'ubuntu-trusty:123.4'). the version can also be 'latest', a glob (ie 'ubuntu-xenial:621.*') or a constraint (ie 'ubuntu-xenial:~> 621.0')" required:"true"`
 	}
 }
 
@@ -112,22 +112,19 @@ func (as *AssignMultiStemcell) validateStemcellVersion(productStemcell api.Produ
 		}
 		os, version := parts[0], parts[1]
 
-		if version == "latest" {
-			var runner int
-			for i, available := range availableVersions {
-				if os == available.OS {
-					runner = i
-				}
+		var osVersions []string
+		for _, available := range availableVersions {
+			if os == available.OS {
+				osVersions = append(osVersions, available.Version)
 			}
+		}
 
-			stemcellGroup = append(stemcellGroup, availableVersions[runner])
-
-		} else {
-			for _, available := range availableVersions {
-				if os == available.OS && version == available.Version {
-					stemcellGroup = append(stemcellGroup, available)
-				}
-			}
+		stemcellVersion, err := resolveStemcellVersion(version, osVersions)
+		if err != nil {
+			return nil, err
+		}
+		if stemcellVersion != "" {
+			stemcellGroup = append(stemcellGroup, api.StemcellObject{OS: os, Version: stemcellVersion})
 		}
 
 		if len(stemcellGroup) < index+1 {
@@ -146,7 +143,11 @@ Available Stemcells for "%s": %s`, version, os, as.Options.ProductName, listOfSt
 }
 
 func (as AssignMultiStemcell) validateOpsManVersion() error {
-	info, err := as.service.Info()
+	return validateMultiStemcellOpsManVersion(as.service)
+}
+
+func validateMultiStemcellOpsManVersion(service interface{ Info() (api.Info, error) }) error {
+	info, err := service.Info()
 	if err != nil {
 		return errors.New("cannot retrieve version of Ops Manager")
 	}
@@ -125,6 +125,41 @@ var _ = Describe("AssignMutliStemcell", func() {
 		})
 	})
 
+	When("--stemcell is a glob or a constraint", func() {
+		BeforeEach(func() {
+			fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+				Products: []api.ProductMultiStemcell{
+					{
+						GUID:        "cf-guid",
+						ProductName: "cf",
+						AvailableVersions: []api.StemcellObject{
+							{OS: "ubuntu-xenial", Version: "621.100"},
+							{OS: "ubuntu-jammy", Version: "1.250"},
+							{OS: "ubuntu-xenial", Version: "621.99"},
+							{OS: "ubuntu-jammy", Version: "1.9"},
+						},
+					},
+				},
+			}, nil)
+		})
+
+		It("assigns the highest matching version of each operating system", func() {
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-xenial:621.*", "--stemcell", "ubuntu-jammy:< 1.100"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.AssignMultiStemcellArgsForCall(0).Products[0].StagedStemcells).To(Equal([]api.StemcellObject{
+				{OS: "ubuntu-xenial", Version: "621.100"},
+				{OS: "ubuntu-jammy", Version: "1.9"},
+			}))
+		})
+
+		It("returns an error when nothing matches", func() {
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-jammy:~> 2.0"})
+			Expect(err).To(MatchError(ContainSubstring("stemcell version ~> 2.0 for ubuntu-jammy not found in Ops Manager")))
+			Expect(fakeService.AssignMultiStemcellCallCount()).To(Equal(0))
+		})
+	})
+
 	When("given stemcell version is not available", func() {
 		BeforeEach(func() {
 			fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
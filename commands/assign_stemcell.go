@@ -2,8 +2,12 @@ package commands
 
 import (
 	"fmt"
+	"path"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-version"
+
 	"github.com/pivotal-cf/om/api"
 )
 
@@ -13,14 +17,16 @@ type AssignStemcell struct {
 	Options struct {
 		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
 		ProductName        string                       `long:"product"  short:"p"  description:"name of Ops Manager tile to associate a stemcell to" required:"true"`
-		StemcellVersion    string                       `long:"stemcell" short:"s"  description:"associate a particular stemcell version to a tile." default:"latest"`
+		StemcellVersion    string                       `long:"stemcell" short:"s"  description:"associate a particular stemcell version to a tile: a version, 'latest', a glob (ie '621.*') or a constraint (ie '~> 621.0'), optionally prefixed by an operating system (ie 'ubuntu-jammy:latest')" default:"latest"`
 	}
 }
 
 //counterfeiter:generate -o ./fakes/assign_stemcell_service.go --fake-name AssignStemcellService . assignStemcellService
 type assignStemcellService interface {
 	ListStemcells() (api.ProductStemcells, error)
+	ListMultiStemcells() (api.ProductMultiStemcells, error)
 	AssignStemcell(input api.ProductStemcells) error
+	Info() (api.Info, error)
 }
 
 func NewAssignStemcell(service assignStemcellService, logger logger) *AssignStemcell {
@@ -92,16 +98,118 @@ func (as *AssignStemcell) validateStemcellVersion(productStemcell api.ProductSte
 			productStemcell.RequiredStemcellVersion)
 	}
 
-	if as.Options.StemcellVersion == "latest" {
-		return availableVersions[len(availableVersions)-1], nil
+	stemcell := as.Options.StemcellVersion
+	if os, stemcellVersion, ok := strings.Cut(stemcell, ":"); ok {
+		osVersions, err := as.versionsForOS(os)
+		if err != nil {
+			return "", err
+		}
+
+		availableVersions, stemcell = osVersions, stemcellVersion
 	}
 
-	for _, version := range availableVersions {
-		if as.Options.StemcellVersion == version {
-			return as.Options.StemcellVersion, nil
-		}
+	stemcellVersion, err := resolveStemcellVersion(stemcell, availableVersions)
+	if err != nil {
+		return "", err
+	}
+	if stemcellVersion != "" {
+		return stemcellVersion, nil
 	}
 
 	return "", fmt.Errorf(`stemcell version %s not found in Ops Manager. 
 	Available Stemcells for "%s": %s`, as.Options.StemcellVersion, as.Options.ProductName, strings.Join(availableVersions, ", "))
 }
+
+// versionsForOS lists the stemcell versions of the operating system that are
+// available for the product, which only the stemcell associations of
+// Ops Manager 2.6+ include.
+func (as *AssignStemcell) versionsForOS(os string) ([]string, error) {
+	err := validateMultiStemcellOpsManVersion(as.service)
+	if err != nil {
+		return nil, fmt.Errorf("an operating system in \"--stemcell\" requires Ops Manager 2.6+: %w", err)
+	}
+
+	productStemcells, err := as.service.ListMultiStemcells()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, productStemcell := range productStemcells.Products {
+		if productStemcell.ProductName != as.Options.ProductName {
+			continue
+		}
+
+		for _, available := range productStemcell.AvailableVersions {
+			if available.OS == os {
+				versions = append(versions, available.Version)
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+// resolveStemcellVersion returns the version out of versions that stemcell
+// selects: the highest version for "latest", the highest version matching a
+// glob such as "621.*" or a constraint such as "~> 621.0", or the version
+// itself. It is empty when no version matches.
+func resolveStemcellVersion(stemcell string, versions []string) (string, error) {
+	var candidates []string
+
+	switch {
+	case stemcell == "latest":
+		candidates = versions
+	case strings.Contains(stemcell, "*"):
+		if _, err := path.Match(stemcell, ""); err != nil {
+			return "", fmt.Errorf("could not parse stemcell version glob %q: %w", stemcell, err)
+		}
+
+		for _, available := range versions {
+			if matched, _ := path.Match(stemcell, available); matched {
+				candidates = append(candidates, available)
+			}
+		}
+	case strings.ContainsAny(stemcell, "<>=~!,"):
+		constraints, err := version.NewConstraint(stemcell)
+		if err != nil {
+			return "", fmt.Errorf("could not parse stemcell version constraint %q: %w", stemcell, err)
+		}
+
+		for _, available := range versions {
+			if v, err := version.NewVersion(available); err == nil && constraints.Check(v) {
+				candidates = append(candidates, available)
+			}
+		}
+	default:
+		for _, available := range versions {
+			if available == stemcell {
+				return available, nil
+			}
+		}
+	}
+
+	return highestStemcellVersion(candidates), nil
+}
+
+// highestStemcellVersion falls back to the last version, as Ops Manager
+// lists stemcells from the oldest to the newest, when some of the versions
+// cannot be compared.
+func highestStemcellVersion(versions []string) string {
+	if len(versions) == 0 {
+		return ""
+	}
+
+	var parsed version.Collection
+	for _, available := range versions {
+		v, err := version.NewVersion(available)
+		if err != nil {
+			return versions[len(versions)-1]
+		}
+		parsed = append(parsed, v)
+	}
+
+	sort.Sort(parsed)
+
+	return parsed[len(parsed)-1].Original()
+}
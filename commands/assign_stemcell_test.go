@@ -76,6 +76,74 @@ var _ = Describe("AssignStemcell", func() {
 		})
 	})
 
+	When("--stemcell is a glob, a constraint or scoped to an operating system", func() {
+		BeforeEach(func() {
+			fakeService.ListStemcellsReturns(api.ProductStemcells{
+				Products: []api.ProductStemcell{
+					{
+						GUID:              "cf-guid",
+						ProductName:       "cf",
+						AvailableVersions: []string{"621.100", "621.99", "1.250", "1.9"},
+					},
+				},
+			}, nil)
+			fakeService.InfoReturns(api.Info{Version: "2.10.0"}, nil)
+			fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+				Products: []api.ProductMultiStemcell{
+					{ProductName: "other", AvailableVersions: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "1.900"}}},
+					{
+						ProductName: "cf",
+						AvailableVersions: []api.StemcellObject{
+							{OS: "ubuntu-xenial", Version: "621.100"},
+							{OS: "ubuntu-xenial", Version: "621.99"},
+							{OS: "ubuntu-jammy", Version: "1.250"},
+							{OS: "ubuntu-jammy", Version: "1.9"},
+						},
+					},
+				},
+			}, nil)
+		})
+
+		DescribeTable("assigns the highest matching version", func(stemcell, expected string) {
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", stemcell})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.AssignStemcellArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal(expected))
+		},
+			Entry("latest", "latest", "621.100"),
+			Entry("a glob", "1.*", "1.250"),
+			Entry("a constraint", "~> 621.0", "621.100"),
+			Entry("a range", ">= 1.0, < 621", "1.250"),
+			Entry("the latest of an operating system", "ubuntu-jammy:latest", "1.250"),
+			Entry("a constraint of an operating system", "ubuntu-xenial:< 621.100", "621.99"),
+		)
+
+		It("does not list the stemcells by operating system without one", func() {
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", "621.*"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeService.ListMultiStemcellsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when nothing matches", func() {
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-jammy:2.*"})
+			Expect(err).To(MatchError(ContainSubstring("stemcell version ubuntu-jammy:2.* not found in Ops Manager")))
+			Expect(err).To(MatchError(ContainSubstring(`Available Stemcells for "cf": 1.250, 1.9`)))
+			Expect(fakeService.AssignStemcellCallCount()).To(Equal(0))
+		})
+
+		It("returns an error for an invalid constraint", func() {
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", ">= six"})
+			Expect(err).To(MatchError(ContainSubstring(`could not parse stemcell version constraint ">= six"`)))
+		})
+
+		It("requires Ops Manager 2.6+ for an operating system", func() {
+			fakeService.InfoReturns(api.Info{Version: "2.5.0"}, nil)
+
+			err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-jammy:latest"})
+			Expect(err).To(MatchError(`an operating system in "--stemcell" requires Ops Manager 2.6+: this command can only be used with OpsManager 2.6+`))
+		})
+	})
+
 	When("there is no --stemcell provided", func() {
 		BeforeEach(func() {
 			fakeService.ListStemcellsReturns(api.ProductStemcells{
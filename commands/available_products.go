@@ -11,7 +11,7 @@ type AvailableProducts struct {
 	presenter presenters.FormattedPresenter
 	logger    logger
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
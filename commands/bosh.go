@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// BoshCLIFunc runs the bosh cli with the arguments, adding env to the
+// environment of om.
+type BoshCLIFunc func(args []string, env []string) error
+
+// BoshCLIError is returned when the bosh cli exits with a non-zero
+// exit code, so om can exit with the same code.
+type BoshCLIError struct {
+	ExitCode int
+}
+
+func (e BoshCLIError) Error() string {
+	return fmt.Sprintf("bosh exited with exit code %d", e.ExitCode)
+}
+
+type Bosh struct {
+	service    boshEnvironmentService
+	opsmanHost string
+	runBosh    BoshCLIFunc
+	Options    struct {
+		SSHPrivateKey string `long:"ssh-private-key" short:"i" description:"Location of ssh private key to use to tunnel through the Ops Manager VM. Only necessary if bosh director is not reachable without a tunnel."`
+	}
+}
+
+func NewBosh(service boshEnvironmentService, opsmanHost string, runBosh BoshCLIFunc) *Bosh {
+	return &Bosh{
+		service:    service,
+		opsmanHost: opsmanHost,
+		runBosh:    runBosh,
+	}
+}
+
+func (b Bosh) Execute(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no arguments for bosh were given, e.g. om bosh -- deployments")
+	}
+
+	boshEnvironment := BoshEnvironment{service: b.service, opsmanHost: b.opsmanHost}
+	boshEnvironment.Options.BoshVars = true
+	boshEnvironment.Options.SSHPrivateKey = b.Options.SSHPrivateKey
+
+	variables, _, err := boshEnvironment.variables()
+	if err != nil {
+		return err
+	}
+
+	var env []string
+	for _, name := range sortedKeys(variables) {
+		env = append(env, name+"="+variables[name])
+	}
+
+	return b.runBosh(args, env)
+}
+
+// RunBoshCLI runs the bosh cli found in the PATH, attached to the
+// terminal of om.
+func RunBoshCLI(args []string, env []string) error {
+	path, err := exec.LookPath("bosh")
+	if err != nil {
+		return fmt.Errorf("the cli 'bosh' is not available in PATH: %w", err)
+	}
+
+	command := exec.Command(path, args...)
+	command.Env = append(os.Environ(), env...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	err = command.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return BoshCLIError{ExitCode: exitErr.ExitCode()}
+	}
+
+	return err
+}
@@ -3,10 +3,13 @@ package commands
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
 	"github.com/fatih/color"
+	"gopkg.in/yaml.v2"
+
 	"github.com/pivotal-cf/om/api"
 )
 
@@ -14,9 +17,10 @@ type BoshDiff struct {
 	service boshDiffService
 	logger  logger
 	Options struct {
-		Product  []string `long:"product-name" short:"p" description:"Product to get diff for. Pass repeatedly for multiple products. If excluded, all staged non-director products will be shown."`
-		Director bool     `long:"director" short:"d" description:"Include director diffs. Can be combined with --product-name."`
-		Check    bool     `long:"check" description:"Exit 2 if there are any differences. Useful for validating that Ops Manager is in a clean state."`
+		Product    []string `long:"product-name" short:"p" description:"Product to get diff for. Pass repeatedly for multiple products. If excluded, all staged non-director products will be shown."`
+		Director   bool     `long:"director" short:"d" description:"Include director diffs. Can be combined with --product-name."`
+		Check      bool     `long:"check" description:"Exit 2 if there are any differences. Useful for validating that Ops Manager is in a clean state."`
+		Structured bool     `long:"structured" description:"Print the differences between the deployed and staged manifests as YAML, keyed by path."`
 	}
 }
 
@@ -27,6 +31,12 @@ type boshDiffService interface {
 	DirectorDiff() (api.DirectorDiff, error)
 	ProductDiff(productName string) (api.ProductDiff, error)
 	ListStagedProducts() (api.StagedProductsOutput, error)
+	GetStagedProductByName(product string) (api.StagedProductsFindOutput, error)
+	GetStagedProductManifest(guid string) (string, error)
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	GetDeployedProductManifest(guid string) (string, error)
+	GetStagedDirectorManifest() (string, error)
+	GetDeployedDirectorManifest() (string, error)
 }
 
 func NewBoshDiff(service boshDiffService, logger logger) *BoshDiff {
@@ -37,7 +47,10 @@ func NewBoshDiff(service boshDiffService, logger logger) *BoshDiff {
 }
 
 func (c BoshDiff) Execute(args []string) error {
-	var diffableProducts []string
+	if c.Options.Structured {
+		return c.structuredDiff()
+	}
+
 	var thereAreDiffs bool
 
 	showDirectorAndProducts := !c.Options.Director && len(c.Options.Product) == 0
@@ -60,20 +73,9 @@ func (c BoshDiff) Execute(args []string) error {
 		}
 	}
 
-	if showDirectorAndProducts {
-		stagedProducts, err := c.service.ListStagedProducts()
-		if err != nil {
-			return fmt.Errorf("could not discover staged products to diff: %s", err)
-		}
-
-		for _, product := range stagedProducts.Products {
-			if product.Type != "p-bosh" {
-				diffableProducts = append(diffableProducts, product.Type)
-			}
-		}
-		sort.Strings(diffableProducts)
-	} else {
-		diffableProducts = c.Options.Product
+	diffableProducts, err := c.diffableProducts(showDirectorAndProducts)
+	if err != nil {
+		return err
 	}
 
 	for _, product := range diffableProducts {
@@ -100,6 +102,148 @@ func (c BoshDiff) Execute(args []string) error {
 	return nil
 }
 
+func (c BoshDiff) diffableProducts(allProducts bool) ([]string, error) {
+	if !allProducts {
+		return c.Options.Product, nil
+	}
+
+	stagedProducts, err := c.service.ListStagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("could not discover staged products to diff: %s", err)
+	}
+
+	var diffableProducts []string
+	for _, product := range stagedProducts.Products {
+		if product.Type != "p-bosh" {
+			diffableProducts = append(diffableProducts, product.Type)
+		}
+	}
+	sort.Strings(diffableProducts)
+
+	return diffableProducts, nil
+}
+
+// structuredDiff compares the staged and deployed manifests itself, rather
+// than printing the diff Ops Manager renders, so that the changes can be
+// read by other tools.
+func (c BoshDiff) structuredDiff() error {
+	showDirectorAndProducts := !c.Options.Director && len(c.Options.Product) == 0
+
+	var diffs yaml.MapSlice
+
+	if c.Options.Director || showDirectorAndProducts {
+		staged, deployed, err := c.directorManifests()
+		if err != nil {
+			return err
+		}
+
+		changes, err := manifestChanges(staged, deployed)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			diffs = append(diffs, yaml.MapItem{Key: "director", Value: changes})
+		}
+	}
+
+	diffableProducts, err := c.diffableProducts(showDirectorAndProducts)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range diffableProducts {
+		staged, deployed, err := c.productManifests(product)
+		if err != nil {
+			return err
+		}
+
+		changes, err := manifestChanges(staged, deployed)
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			diffs = append(diffs, yaml.MapItem{Key: product, Value: changes})
+		}
+	}
+
+	if len(diffs) == 0 {
+		c.logger.Println("no changes")
+		return nil
+	}
+
+	output, err := yaml.Marshal(diffs)
+	if err != nil {
+		return fmt.Errorf("could not marshal diff: %s", err)
+	}
+	c.logger.Print(string(output))
+
+	if c.Options.Check {
+		return ErrBoshDiffChangesExist
+	}
+
+	return nil
+}
+
+func (c BoshDiff) directorManifests() (string, string, error) {
+	staged, err := c.service.GetStagedDirectorManifest()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch staged director manifest: %s", err)
+	}
+
+	deployed, err := c.service.GetDeployedDirectorManifest()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch deployed director manifest: %s", err)
+	}
+
+	return staged, deployed, nil
+}
+
+func (c BoshDiff) productManifests(productName string) (string, string, error) {
+	stagedProduct, err := c.service.GetStagedProductByName(productName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find product: %s", err)
+	}
+
+	staged, err := c.service.GetStagedProductManifest(stagedProduct.Product.GUID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch staged manifest for %s: %s", productName, err)
+	}
+
+	deployedProducts, err := c.service.ListDeployedProducts()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list deployed products: %s", err)
+	}
+
+	for _, product := range deployedProducts {
+		if product.Type == productName {
+			deployed, err := c.service.GetDeployedProductManifest(product.GUID)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to fetch deployed manifest for %s: %s", productName, err)
+			}
+
+			return staged, deployed, nil
+		}
+	}
+
+	// never deployed, so everything staged is an addition
+	return staged, "", nil
+}
+
+func manifestChanges(staged, deployed string) ([]yaml.MapSlice, error) {
+	var stagedManifest, deployedManifest interface{}
+	if err := yaml.Unmarshal([]byte(staged), &stagedManifest); err != nil {
+		return nil, fmt.Errorf("could not parse staged manifest: %s", err)
+	}
+	if err := yaml.Unmarshal([]byte(deployed), &deployedManifest); err != nil {
+		return nil, fmt.Errorf("could not parse deployed manifest: %s", err)
+	}
+
+	var changes []yaml.MapSlice
+	diffManifests("", deployedManifest, stagedManifest, &changes)
+
+	return changes, nil
+}
+
 func (c BoshDiff) printManifestDiff(diff api.ManifestDiff) bool {
 	switch diff.Status {
 	case "same":
@@ -167,3 +311,144 @@ func (c BoshDiff) colorizeDiff(diff string) string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// diffManifests records the differences between deployed and staged as
+// entries keyed by go-patch style paths. Lists whose elements all have a name
+// are matched by name (e.g. /instance_groups/name=router) rather than index.
+func diffManifests(path string, deployed, staged interface{}, changes *[]yaml.MapSlice) {
+	if deployedMap, ok := deployed.(map[interface{}]interface{}); ok {
+		if stagedMap, ok := staged.(map[interface{}]interface{}); ok {
+			diffMaps(path, deployedMap, stagedMap, changes)
+			return
+		}
+	}
+
+	if deployedList, ok := deployed.([]interface{}); ok {
+		if stagedList, ok := staged.([]interface{}); ok {
+			diffLists(path, deployedList, stagedList, changes)
+			return
+		}
+	}
+
+	if deployed == nil && staged != nil && path == "" {
+		diffMaps(path, nil, asMap(staged), changes)
+		return
+	}
+
+	if !reflect.DeepEqual(deployed, staged) {
+		*changes = append(*changes, manifestChange(path, "changed", deployed, staged))
+	}
+}
+
+func diffMaps(path string, deployed, staged map[interface{}]interface{}, changes *[]yaml.MapSlice) {
+	keys := map[string]interface{}{}
+	for key := range deployed {
+		keys[fmt.Sprint(key)] = key
+	}
+	for key := range staged {
+		keys[fmt.Sprint(key)] = key
+	}
+
+	var names []string
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := keys[name]
+		deployedValue, inDeployed := deployed[key]
+		stagedValue, inStaged := staged[key]
+		childPath := path + "/" + name
+
+		switch {
+		case !inDeployed:
+			*changes = append(*changes, manifestChange(childPath, "added", nil, stagedValue))
+		case !inStaged:
+			*changes = append(*changes, manifestChange(childPath, "removed", deployedValue, nil))
+		default:
+			diffManifests(childPath, deployedValue, stagedValue, changes)
+		}
+	}
+}
+
+func diffLists(path string, deployed, staged []interface{}, changes *[]yaml.MapSlice) {
+	deployedNames, deployedNamed := namedElements(deployed)
+	stagedNames, stagedNamed := namedElements(staged)
+
+	if !deployedNamed || !stagedNamed {
+		for index := 0; index < len(deployed) || index < len(staged); index++ {
+			childPath := fmt.Sprintf("%s/%d", path, index)
+			switch {
+			case index >= len(deployed):
+				*changes = append(*changes, manifestChange(childPath, "added", nil, staged[index]))
+			case index >= len(staged):
+				*changes = append(*changes, manifestChange(childPath, "removed", deployed[index], nil))
+			default:
+				diffManifests(childPath, deployed[index], staged[index], changes)
+			}
+		}
+		return
+	}
+
+	var order []string
+	seen := map[string]bool{}
+	for _, element := range deployed {
+		name := fmt.Sprint(asMap(element)["name"])
+		order = append(order, name)
+		seen[name] = true
+	}
+	for _, element := range staged {
+		if name := fmt.Sprint(asMap(element)["name"]); !seen[name] {
+			order = append(order, name)
+		}
+	}
+
+	for _, name := range order {
+		childPath := fmt.Sprintf("%s/name=%s", path, name)
+		deployedElement, inDeployed := deployedNames[name]
+		stagedElement, inStaged := stagedNames[name]
+
+		switch {
+		case !inDeployed:
+			*changes = append(*changes, manifestChange(childPath, "added", nil, stagedElement))
+		case !inStaged:
+			*changes = append(*changes, manifestChange(childPath, "removed", deployedElement, nil))
+		default:
+			diffManifests(childPath, deployedElement, stagedElement, changes)
+		}
+	}
+}
+
+func namedElements(list []interface{}) (map[string]interface{}, bool) {
+	elements := map[string]interface{}{}
+	for _, element := range list {
+		name, ok := asMap(element)["name"]
+		if !ok {
+			return nil, false
+		}
+		elements[fmt.Sprint(name)] = element
+	}
+
+	return elements, true
+}
+
+func asMap(value interface{}) map[interface{}]interface{} {
+	m, _ := value.(map[interface{}]interface{})
+	return m
+}
+
+func manifestChange(path, change string, deployed, staged interface{}) yaml.MapSlice {
+	entry := yaml.MapSlice{
+		{Key: "path", Value: path},
+		{Key: "change", Value: change},
+	}
+	if change != "added" {
+		entry = append(entry, yaml.MapItem{Key: "deployed", Value: deployed})
+	}
+	if change != "removed" {
+		entry = append(entry, yaml.MapItem{Key: "staged", Value: staged})
+	}
+
+	return entry
+}
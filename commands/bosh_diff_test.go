@@ -608,4 +608,188 @@ var _ = Describe("BoshDiff", func() {
 			})
 		})
 	})
+
+	When("the --structured flag is provided", func() {
+		BeforeEach(func() {
+			service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{
+				Product: api.StagedProduct{GUID: "cf-staged-guid", Type: "cf"},
+			}, nil)
+			service.ListDeployedProductsReturns([]api.DeployedProductOutput{
+				{Type: "p-bosh", GUID: "p-bosh-guid"},
+				{Type: "cf", GUID: "cf-deployed-guid"},
+			}, nil)
+		})
+
+		It("prints the differences between the staged and deployed director manifests", func() {
+			service.GetDeployedDirectorManifestReturns(`---
+name: p-bosh
+properties:
+  host: localhost
+  port: 25555
+  removed: true
+`, nil)
+			service.GetStagedDirectorManifestReturns(`---
+name: p-bosh
+properties:
+  host: example.com
+  port: 25555
+  added: false
+`, nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--director"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(logBuffer.Contents())).To(MatchYAML(`
+director:
+- path: /properties/added
+  change: added
+  staged: false
+- path: /properties/host
+  change: changed
+  deployed: localhost
+  staged: example.com
+- path: /properties/removed
+  change: removed
+  deployed: true
+`))
+			Expect(service.DirectorDiffCallCount()).To(Equal(0))
+			Expect(service.GetStagedProductByNameCallCount()).To(Equal(0))
+		})
+
+		It("matches list elements of the product manifests by name", func() {
+			service.GetDeployedProductManifestReturns(`---
+instance_groups:
+- name: router
+  instances: 1
+- name: diego_cell
+  instances: 3
+stemcells:
+- ubuntu-jammy
+`, nil)
+			service.GetStagedProductManifestReturns(`---
+instance_groups:
+- name: diego_cell
+  instances: 3
+- name: router
+  instances: 2
+- name: tcp_router
+  instances: 1
+stemcells:
+- ubuntu-jammy
+- windows
+`, nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--product-name", "cf"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(logBuffer.Contents())).To(MatchYAML(`
+cf:
+- path: /instance_groups/name=router/instances
+  change: changed
+  deployed: 1
+  staged: 2
+- path: /instance_groups/name=tcp_router
+  change: added
+  staged:
+    name: tcp_router
+    instances: 1
+- path: /stemcells/1
+  change: added
+  staged: windows
+`))
+
+			Expect(service.ProductDiffCallCount()).To(Equal(0))
+			Expect(service.GetStagedProductByNameArgsForCall(0)).To(Equal("cf"))
+			Expect(service.GetStagedProductManifestArgsForCall(0)).To(Equal("cf-staged-guid"))
+			Expect(service.GetDeployedProductManifestArgsForCall(0)).To(Equal("cf-deployed-guid"))
+		})
+
+		It("compares the director and all staged products when neither flag is provided", func() {
+			service.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+				{GUID: "p-bosh-guid", Type: "p-bosh"},
+				{GUID: "cf-staged-guid", Type: "cf"},
+			}}, nil)
+			service.GetDeployedDirectorManifestReturns("name: p-bosh\n", nil)
+			service.GetStagedDirectorManifestReturns("name: p-bosh\n", nil)
+			service.GetDeployedProductManifestReturns("name: cf\n", nil)
+			service.GetStagedProductManifestReturns("name: cf-new\n", nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(logBuffer.Contents())).To(MatchYAML(`
+cf:
+- path: /name
+  change: changed
+  deployed: cf
+  staged: cf-new
+`))
+			Expect(service.GetStagedProductByNameCallCount()).To(Equal(1))
+			Expect(service.GetStagedProductByNameArgsForCall(0)).To(Equal("cf"))
+		})
+
+		It("treats every staged value as added when the product has never been deployed", func() {
+			service.ListDeployedProductsReturns([]api.DeployedProductOutput{{Type: "p-bosh", GUID: "p-bosh-guid"}}, nil)
+			service.GetStagedProductManifestReturns("name: cf\n", nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--product-name", "cf"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(logBuffer.Contents())).To(MatchYAML(`
+cf:
+- path: /name
+  change: added
+  staged: cf
+`))
+			Expect(service.GetDeployedProductManifestCallCount()).To(Equal(0))
+		})
+
+		It("prints no changes when the manifests match", func() {
+			service.GetDeployedDirectorManifestReturns("name: p-bosh", nil)
+			service.GetStagedDirectorManifestReturns("name: p-bosh", nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--director", "--check"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(logBuffer).To(gbytes.Say("no changes"))
+		})
+
+		It("returns ErrBoshDiffChangesExist with --check when there are differences", func() {
+			service.GetDeployedProductManifestReturns("name: cf\n", nil)
+			service.GetStagedProductManifestReturns("name: cf-new\n", nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--product-name", "cf", "--check"})
+			Expect(err).To(MatchError(commands.ErrBoshDiffChangesExist))
+		})
+
+		It("returns an error when the staged director manifest cannot be fetched", func() {
+			service.GetStagedDirectorManifestReturns("", errors.New("something bad happened"))
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--director"})
+			Expect(err).To(MatchError("failed to fetch staged director manifest: something bad happened"))
+		})
+
+		It("returns an error when the product cannot be found", func() {
+			service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("product not found"))
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--product-name", "cf"})
+			Expect(err).To(MatchError("failed to find product: product not found"))
+		})
+
+		It("returns an error when the deployed manifest cannot be parsed", func() {
+			service.GetStagedProductManifestReturns("name: cf\n", nil)
+			service.GetDeployedProductManifestReturns("{{", nil)
+
+			diff := commands.NewBoshDiff(service, logger)
+			err = executeCommand(diff, []string{"--structured", "--product-name", "cf"})
+			Expect(err).To(MatchError(ContainSubstring("could not parse deployed manifest")))
+		})
+	})
 })
@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -20,6 +22,7 @@ type BoshEnvironment struct {
 	opsmanHost      string
 	Options         struct {
 		ShellType     string `long:"shell-type" description:"Prints for the given shell (posix|powershell)"`
+		Format        string `long:"format" short:"f" description:"Prints in the given format (posix|powershell|dotenv|json), defaults to the shell-type"`
 		BoshVars      bool   `long:"bosh" short:"b" description:"Prints the BOSH director environment variables"`
 		CredhubVars   bool   `long:"credhub" short:"c" description:"Prints the Credhub environment variables"`
 		Unset         bool   `long:"unset" short:"u" description:"Prints unset commands for the environment variables"`
@@ -61,18 +64,64 @@ func (be BoshEnvironment) stripTrailingSlash(host string) string {
 }
 
 func (be BoshEnvironment) Execute(args []string) error {
-	renderer, err := be.rendererFactory.Create(be.Options.ShellType)
+	format := be.Options.ShellType
+	if be.Options.Format != "" {
+		if be.Options.ShellType != "" && be.Options.ShellType != be.Options.Format {
+			return errors.New("cannot use both --format and --shell-type; please choose one or the other")
+		}
+		format = be.Options.Format
+	}
+
+	if be.Options.Unset && (format == "json" || format == renderers.ShellTypeDotenv) {
+		return fmt.Errorf("--unset cannot be used with --format %s", format)
+	}
+
+	var (
+		renderer renderers.Renderer
+		err      error
+	)
+	if format != "json" {
+		renderer, err = be.rendererFactory.Create(format)
+		if err != nil {
+			return err
+		}
+	}
+
+	variables, unsetVariables, err := be.variables()
 	if err != nil {
 		return err
 	}
+
+	if format == "json" {
+		contents, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			return err // not tested
+		}
+
+		be.logger.Println(string(contents))
+		return nil
+	}
+
+	if be.Options.Unset {
+		be.renderUnsetVariables(renderer, unsetVariables)
+	} else {
+		be.renderVariables(renderer, variables)
+	}
+
+	return nil
+}
+
+// variables are the environment variables that target the director and
+// Credhub, and the names of the variables to unset to untarget them.
+func (be BoshEnvironment) variables() (map[string]string, []string, error) {
 	boshEnvironment, err := be.service.GetBoshEnvironment()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	certificateAuthorities, err := be.service.ListCertificateAuthorities()
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
 	var boshCACerts string
@@ -124,7 +173,7 @@ func (be BoshEnvironment) Execute(args []string) error {
 	if be.Options.SSHPrivateKey != "" {
 		file, err := getKeyFilePath(be.Options.SSHPrivateKey)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 
 		proxy := fmt.Sprintf("ssh+socks5://ubuntu@%s:22?private-key=%s", be.Target(), file)
@@ -138,18 +187,12 @@ func (be BoshEnvironment) Execute(args []string) error {
 		}
 	}
 
-	if be.Options.Unset {
-		be.renderUnsetVariables(renderer, unsetVariables)
-	} else {
-		be.renderVariables(renderer, variables)
-	}
-
-	return nil
+	return variables, unsetVariables, nil
 }
 
 func (be BoshEnvironment) renderVariables(renderer renderers.Renderer, variables map[string]string) {
-	for k, v := range variables {
-		be.logger.Println(renderer.RenderEnvironmentVariable(k, v))
+	for _, k := range sortedKeys(variables) {
+		be.logger.Println(renderer.RenderEnvironmentVariable(k, variables[k]))
 	}
 }
 
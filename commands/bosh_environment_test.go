@@ -442,4 +442,86 @@ var _ = Describe("bosh-env", func() {
 			))
 		})
 	})
+
+	Context("printing in other formats", func() {
+		var (
+			command             *commands.BoshEnvironment
+			fakeService         *fakes.BoshEnvironmentService
+			fakeRendererFactory *fakes.RendererFactory
+			stdout              *fakes.Logger
+		)
+
+		BeforeEach(func() {
+			fakeService = &fakes.BoshEnvironmentService{}
+			fakeRendererFactory = &fakes.RendererFactory{}
+			stdout = &fakes.Logger{}
+			command = commands.NewBoshEnvironment(fakeService, stdout, "opsman.pivotal.io", fakeRendererFactory)
+			fakeService.GetBoshEnvironmentReturns(api.GetBoshEnvironmentOutput{
+				Client:       "opsmanager_client",
+				ClientSecret: "my-super-secret",
+				Environment:  "10.0.0.10",
+			}, nil)
+			fakeService.ListCertificateAuthoritiesReturns(api.CertificateAuthoritiesOutput{
+				CAs: []api.CA{
+					{
+						Active:  true,
+						CertPEM: "-----BEGIN CERTIFICATE-----\nMIIC+zCCAeOgAwIBAgI....",
+					},
+				},
+			}, nil)
+		})
+
+		It("prints the environment variables as JSON", func() {
+			err := executeCommand(command, []string{"--format", "json", "--bosh"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeRendererFactory.CreateCallCount()).To(Equal(0))
+			Expect(stdout.PrintlnCallCount()).To(Equal(1))
+			Expect(fmt.Sprint(stdout.PrintlnArgsForCall(0)...)).To(MatchJSON(`{
+				"BOSH_CA_CERT": "-----BEGIN CERTIFICATE-----\nMIIC+zCCAeOgAwIBAgI....",
+				"BOSH_CLIENT": "opsmanager_client",
+				"BOSH_CLIENT_SECRET": "my-super-secret",
+				"BOSH_ENVIRONMENT": "10.0.0.10"
+			}`))
+		})
+
+		It("prints the environment variables for a .env file, sorted by name", func() {
+			fakeRendererFactory.CreateReturns(renderers.NewDotenv(), nil)
+
+			err := executeCommand(command, []string{"--format", "dotenv", "--credhub"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeRendererFactory.CreateArgsForCall(0)).To(Equal("dotenv"))
+
+			var lines []string
+			for i := 0; i < stdout.PrintlnCallCount(); i++ {
+				lines = append(lines, fmt.Sprint(stdout.PrintlnArgsForCall(i)...))
+			}
+			Expect(lines).To(Equal([]string{
+				`CREDHUB_CA_CERT="-----BEGIN CERTIFICATE-----\nMIIC+zCCAeOgAwIBAgI...."`,
+				`CREDHUB_CLIENT="opsmanager_client"`,
+				`CREDHUB_SECRET="my-super-secret"`,
+				`CREDHUB_SERVER="https://10.0.0.10:8844"`,
+			}))
+		})
+
+		It("passes the format to the renderer factory for shells", func() {
+			fakeRendererFactory.CreateReturns(renderers.NewPowershell(), nil)
+
+			err := executeCommand(command, []string{"--format", "powershell", "--shell-type", "powershell"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeRendererFactory.CreateArgsForCall(0)).To(Equal("powershell"))
+		})
+
+		It("does not allow conflicting --format and --shell-type", func() {
+			err := executeCommand(command, []string{"--format", "json", "--shell-type", "posix"})
+			Expect(err).To(MatchError("cannot use both --format and --shell-type; please choose one or the other"))
+		})
+
+		It("does not allow --unset with formats that are not shells", func() {
+			err := executeCommand(command, []string{"--format", "json", "--unset"})
+			Expect(err).To(MatchError("--unset cannot be used with --format json"))
+		})
+	})
 })
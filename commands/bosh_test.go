@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("Bosh", func() {
+	var (
+		fakeService *fakes.BoshEnvironmentService
+		boshArgs    []string
+		boshEnv     []string
+		boshErr     error
+		command     *commands.Bosh
+	)
+
+	BeforeEach(func() {
+		fakeService = &fakes.BoshEnvironmentService{}
+		fakeService.GetBoshEnvironmentReturns(api.GetBoshEnvironmentOutput{
+			Client:       "opsmanager_client",
+			ClientSecret: "my-super-secret",
+			Environment:  "10.0.0.10",
+		}, nil)
+		fakeService.ListCertificateAuthoritiesReturns(api.CertificateAuthoritiesOutput{
+			CAs: []api.CA{
+				{Active: false, CertPEM: "old-cert"},
+				{Active: true, CertPEM: "active-cert"},
+			},
+		}, nil)
+
+		boshArgs, boshEnv, boshErr = nil, nil, nil
+		command = commands.NewBosh(fakeService, "https://opsman.example.com/", func(args []string, env []string) error {
+			boshArgs = args
+			boshEnv = env
+			return boshErr
+		})
+	})
+
+	It("runs bosh with the environment of the director", func() {
+		err := command.Execute([]string{"deployments", "--json"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(boshArgs).To(Equal([]string{"deployments", "--json"}))
+		Expect(boshEnv).To(Equal([]string{
+			"BOSH_CA_CERT=active-cert",
+			"BOSH_CLIENT=opsmanager_client",
+			"BOSH_CLIENT_SECRET=my-super-secret",
+			"BOSH_ENVIRONMENT=10.0.0.10",
+		}))
+	})
+
+	It("tunnels through the Ops Manager VM with an ssh key", func() {
+		keyFile := filepath.Join(GinkgoT().TempDir(), "opsman.pem")
+		Expect(os.WriteFile(keyFile, []byte("key"), 0600)).To(Succeed())
+
+		args, err := flags.NewParser(command, flags.HelpFlag|flags.PassDoubleDash).ParseArgs([]string{"--ssh-private-key", keyFile, "--", "vms"})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = command.Execute(args)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(boshArgs).To(Equal([]string{"vms"}))
+		Expect(boshEnv).To(ContainElement("BOSH_ALL_PROXY=ssh+socks5://ubuntu@opsman.example.com:22?private-key=" + keyFile))
+	})
+
+	It("returns the error of bosh", func() {
+		boshErr = commands.BoshCLIError{ExitCode: 3}
+
+		err := command.Execute([]string{"deployments"})
+		Expect(err).To(MatchError("bosh exited with exit code 3"))
+	})
+
+	It("requires arguments for bosh", func() {
+		err := command.Execute(nil)
+		Expect(err).To(MatchError("no arguments for bosh were given, e.g. om bosh -- deployments"))
+	})
+
+	It("returns an error when the director environment cannot be retrieved", func() {
+		fakeService.GetBoshEnvironmentReturns(api.GetBoshEnvironmentOutput{}, errors.New("some error"))
+
+		err := command.Execute([]string{"deployments"})
+		Expect(err).To(MatchError("some error"))
+	})
+
+	Describe("RunBoshCLI", func() {
+		var binDir string
+
+		BeforeEach(func() {
+			binDir = GinkgoT().TempDir()
+			GinkgoT().Setenv("PATH", binDir)
+		})
+
+		It("runs the bosh cli in the PATH with the environment", func() {
+			output := filepath.Join(binDir, "output")
+			script := "#!/bin/sh\necho \"$BOSH_ENVIRONMENT $*\" > " + output + "\nexit 3\n"
+			Expect(os.WriteFile(filepath.Join(binDir, "bosh"), []byte(script), 0755)).To(Succeed())
+
+			err := commands.RunBoshCLI([]string{"deployments"}, []string{"BOSH_ENVIRONMENT=10.0.0.10"})
+			Expect(err).To(Equal(commands.BoshCLIError{ExitCode: 3}))
+
+			contents, err := os.ReadFile(output)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("10.0.0.10 deployments\n"))
+		})
+
+		It("returns an error when bosh is not in the PATH", func() {
+			err := commands.RunBoshCLI([]string{"deployments"}, nil)
+			Expect(err).To(MatchError(ContainSubstring("the cli 'bosh' is not available in PATH")))
+		})
+	})
+})
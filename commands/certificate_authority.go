@@ -16,7 +16,7 @@ type CertificateAuthority struct {
 	Options   struct {
 		ID      string `long:"id" description:"ID of certificate to display. Required if there is more than one certificate authority"`
 		CertPEM bool   `long:"cert-pem" description:"Display the cert pem"`
-		Format  string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
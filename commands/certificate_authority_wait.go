@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+// certificateAuthorityWaitOptions are the options of the commands changing
+// the certificate authorities that wait for the change to settle.
+type certificateAuthorityWaitOptions struct {
+	SkipWait bool `long:"skip-wait"            description:"return without waiting for the change to settle and checking the deployed certificates"`
+	Timeout  int  `long:"timeout" default:"300" description:"seconds to wait for the change to settle"`
+}
+
+// waitForCertificateAuthorities polls the certificate authorities until
+// settled returns true for them, then checks that none of the deployed
+// certificates reports an error.
+func waitForCertificateAuthorities(service interface {
+	ListCertificateAuthorities() (api.CertificateAuthoritiesOutput, error)
+	ListCertificates(expiresWithin string) ([]api.ExpiringCertificate, error)
+}, logger logger, options certificateAuthorityWaitOptions, waitDuration time.Duration, change string, settled func([]api.CA) bool) error {
+	deadline := time.Now().Add(time.Duration(options.Timeout) * time.Second)
+	for {
+		caList, err := service.ListCertificateAuthorities()
+		if err != nil {
+			return fmt.Errorf("could not list certificate authorities: %s", err)
+		}
+
+		if settled(caList.CAs) {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the certificate authorities were not %s within %ds", change, options.Timeout)
+		}
+
+		logger.Printf("Waiting for the certificate authorities to be %s...\n", change)
+		time.Sleep(waitDuration)
+	}
+
+	certificates, err := service.ListCertificates("")
+	if err != nil {
+		return fmt.Errorf("could not list the deployed certificates: %s", err)
+	}
+
+	now := time.Now()
+	var problems []string
+	for _, certificate := range certificates {
+		switch {
+		case now.After(certificate.ValidUntil):
+			problems = append(problems, fmt.Sprintf("%s %s expired on %s", certificate.ProductGUID, certificatePath(certificate), certificate.ValidUntil.Format(time.RFC3339)))
+		case now.Before(certificate.ValidFrom):
+			problems = append(problems, fmt.Sprintf("%s %s is not valid until %s", certificate.ProductGUID, certificatePath(certificate), certificate.ValidFrom.Format(time.RFC3339)))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("the certificate authorities were %s, but %d deployed certificates are not valid:\n%s", change, len(problems), strings.Join(problems, "\n"))
+	}
+
+	if len(certificates) == 0 {
+		logger.Printf("No deployed certificates to check\n")
+		return nil
+	}
+
+	logger.Printf("Deployed certificates are valid (%d checked)\n", len(certificates))
+
+	return nil
+}
+
+func certificatePath(certificate api.ExpiringCertificate) string {
+	if certificate.VariablePath != "" {
+		return certificate.VariablePath
+	}
+
+	return certificate.PropertyReference
+}
@@ -0,0 +1,262 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/pivotal-cf/om/interpolate"
+)
+
+type CompareConfig struct {
+	service     stagedConfigService
+	logger      logger
+	environFunc func() []string
+	Options     struct {
+		ConfigFile         string   `long:"config"              short:"c"         description:"path to the product config file to compare with the staged configuration" required:"true"`
+		ProductName        string   `long:"product-name"        short:"p"         description:"name of the product, defaults to the product-name of the config file"`
+		IncludeCredentials bool     `long:"include-credentials"                   description:"compare credentials too. note: requires product to have been deployed"`
+		VarsFile           []string `long:"vars-file"           short:"l"         description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
+		VarsEnv            []string `long:"vars-env"            env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
+		Vars               []string `long:"var"                 short:"v"         description:"load variable from the command line. Format: VAR=VAL"`
+		OpsFile            []string `long:"ops-file"            short:"o"         description:"YAML operations file"`
+	}
+}
+
+var ErrConfigDriftExists = errors.New("Differences exist between the config file and the staged configuration")
+
+var configPlaceholder = regexp.MustCompile(`^\(\([^()]+\)\)$`)
+
+func NewCompareConfig(environFunc func() []string, service stagedConfigService, logger logger) *CompareConfig {
+	return &CompareConfig{
+		environFunc: environFunc,
+		service:     service,
+		logger:      logger,
+	}
+}
+
+func (cc CompareConfig) Execute(args []string) error {
+	contents, err := interpolate.Execute(interpolate.Options{
+		TemplateFile:  cc.Options.ConfigFile,
+		VarsFiles:     cc.Options.VarsFile,
+		EnvironFunc:   cc.environFunc,
+		Vars:          cc.Options.Vars,
+		VarsEnvs:      cc.Options.VarsEnv,
+		OpsFiles:      cc.Options.OpsFile,
+		ExpectAllKeys: false,
+	})
+	if err != nil {
+		return err
+	}
+
+	var desired map[string]interface{}
+	err = yaml.Unmarshal(contents, &desired)
+	if err != nil {
+		return fmt.Errorf("could not be parsed as valid configuration: %s: %s", cc.Options.ConfigFile, err)
+	}
+
+	productName := cc.Options.ProductName
+	if productName == "" {
+		productName, _ = desired["product-name"].(string)
+	}
+	if productName == "" {
+		return errors.New("the product name must be given with --product-name or product-name in the config file")
+	}
+
+	stagedConfig := StagedConfig{service: cc.service, logger: cc.logger}
+	stagedConfig.Options.Product = productName
+	stagedConfig.Options.IncludeCredentials = cc.Options.IncludeCredentials
+
+	productConfiguration, credentials, err := stagedConfig.productConfiguration()
+	if err != nil {
+		return err
+	}
+
+	// both sides go through YAML, so they have the same types to compare
+	liveContents, err := yaml.Marshal(productConfiguration)
+	if err != nil {
+		return err // not tested
+	}
+
+	var live map[string]interface{}
+	err = yaml.Unmarshal(liveContents, &live)
+	if err != nil {
+		return err // not tested
+	}
+
+	drift := configDrift{
+		credentials:        credentials,
+		includeCredentials: cc.Options.IncludeCredentials,
+	}
+	for _, key := range sortedKeys(desired) {
+		if key == "product-name" {
+			continue
+		}
+
+		liveValue, inLive := live[key]
+		drift.compare("/"+key, desired[key], liveValue, inLive)
+	}
+
+	if drift.skipped > 0 {
+		cc.logger.Printf("%d credentials and placeholders were not compared, use --include-credentials to compare the credentials\n", drift.skipped)
+	}
+
+	if len(drift.changes) == 0 {
+		cc.logger.Println("no differences")
+		return nil
+	}
+
+	output, err := yaml.Marshal(drift.changes)
+	if err != nil {
+		return fmt.Errorf("could not marshal diff: %s", err) // not tested
+	}
+	cc.logger.Print(string(output))
+
+	return ErrConfigDriftExists
+}
+
+// configDrift only compares what the config file sets, since a config file
+// usually leaves the defaults of a product out.
+type configDrift struct {
+	credentials        map[string]bool
+	includeCredentials bool
+	changes            []yaml.MapSlice
+	skipped            int
+}
+
+func (d *configDrift) compare(path string, desired, live interface{}, inLive bool) {
+	if d.isCredential(path, desired) {
+		d.skipped++
+		return
+	}
+
+	if !inLive {
+		d.changes = append(d.changes, configChange(path, "missing", desired, nil))
+		return
+	}
+
+	if desiredMap, ok := toStringMap(desired); ok {
+		if liveMap, ok := toStringMap(live); ok {
+			for _, key := range sortedKeys(desiredMap) {
+				liveValue, inLive := liveMap[key]
+				d.compare(path+"/"+key, desiredMap[key], liveValue, inLive)
+			}
+			return
+		}
+	}
+
+	if desiredList, ok := desired.([]interface{}); ok {
+		if liveList, ok := live.([]interface{}); ok {
+			desiredNames, desiredNamed := namedElements(desiredList)
+			liveNames, liveNamed := namedElements(liveList)
+			if desiredNamed && liveNamed && len(desiredList) == len(liveList) {
+				for _, element := range desiredList {
+					name := fmt.Sprint(asMap(element)["name"])
+					liveElement, inLive := liveNames[name]
+					d.compare(fmt.Sprintf("%s/name=%s", path, name), desiredNames[name], liveElement, inLive)
+				}
+				return
+			}
+
+			if len(desiredList) == len(liveList) {
+				for index := range desiredList {
+					d.compare(fmt.Sprintf("%s/%d", path, index), desiredList[index], liveList[index], true)
+				}
+				return
+			}
+		}
+	}
+
+	if !sameConfigValue(desired, live) {
+		d.changes = append(d.changes, configChange(path, "changed", desired, live))
+	}
+}
+
+// isCredential is true for the credential properties of the product and for
+// credentials nested in collections, unless credentials are compared.
+// Placeholders that were not interpolated are never compared.
+func (d *configDrift) isCredential(path string, desired interface{}) bool {
+	if value, ok := desired.(string); ok && configPlaceholder.MatchString(value) {
+		return true
+	}
+
+	if d.includeCredentials {
+		return false
+	}
+
+	if name, ok := strings.CutPrefix(path, "/product-properties/"); ok && d.credentials[name] {
+		return true
+	}
+
+	if desiredMap, ok := toStringMap(desired); ok {
+		for _, key := range []string{"secret", "password", "private_key_pem"} {
+			if _, ok := desiredMap[key]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func configChange(path, change string, desired, live interface{}) yaml.MapSlice {
+	entry := yaml.MapSlice{
+		{Key: "path", Value: path},
+		{Key: "change", Value: change},
+		{Key: "config", Value: desired},
+	}
+	if change != "missing" {
+		entry = append(entry, yaml.MapItem{Key: "staged", Value: live})
+	}
+
+	return entry
+}
+
+// sameConfigValue treats scalars with the same text as equal, e.g. a port
+// given as a string in the config file and returned as a number.
+func sameConfigValue(desired, live interface{}) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+
+	switch desired.(type) {
+	case map[interface{}]interface{}, map[string]interface{}, []interface{}:
+		return false
+	}
+	switch live.(type) {
+	case map[interface{}]interface{}, map[string]interface{}, []interface{}:
+		return false
+	}
+
+	return fmt.Sprint(desired) == fmt.Sprint(live)
+}
+
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return typed, true
+	case map[interface{}]interface{}:
+		converted := map[string]interface{}{}
+		for key, element := range typed {
+			converted[fmt.Sprint(key)] = element
+		}
+		return converted, true
+	}
+
+	return nil, false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,150 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("CompareConfig", func() {
+	var (
+		logger      *fakes.Logger
+		fakeService *fakes.StagedConfigService
+		command     *commands.CompareConfig
+	)
+
+	BeforeEach(func() {
+		logger = &fakes.Logger{}
+		fakeService = setFakeService(api.ResponseProperty{
+			Value:        "internal",
+			Type:         "selector",
+			Configurable: true,
+		}, true)
+		command = commands.NewCompareConfig(func() []string { return nil }, fakeService, logger)
+	})
+
+	It("reports no differences when the staged configuration matches the config file", func() {
+		configFile := writeTestConfigFile(`---
+product-name: some-product
+product-properties:
+  .properties.some-string-property:
+    value: some-value
+  .properties.some-secret-property:
+    value:
+      secret: ((some-secret))
+  .properties.simple-credentials:
+    value:
+      identity: admin
+      password: some-password
+resource-config:
+  some-job:
+    instances: "1"
+errand-config:
+  first-errand:
+    post-deploy-state: true
+`)
+
+		err := executeCommand(command, []string{"--config", configFile})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeService.GetStagedProductByNameArgsForCall(0)).To(Equal("some-product"))
+		_, redact := fakeService.GetStagedProductPropertiesArgsForCall(0)
+		Expect(redact).To(BeTrue())
+
+		format, content := logger.PrintfArgsForCall(0)
+		Expect(fmt.Sprintf(format, content...)).To(Equal("2 credentials and placeholders were not compared, use --include-credentials to compare the credentials\n"))
+		Expect(logger.PrintlnArgsForCall(0)).To(Equal([]interface{}{"no differences"}))
+	})
+
+	It("prints the differences and returns an error when the configuration drifted", func() {
+		configFile := writeTestConfigFile(`---
+product-name: some-product
+product-properties:
+  .properties.some-string-property:
+    value: other-value
+  .properties.some-new-property:
+    value: true
+resource-config:
+  some-job:
+    instances: 3
+    additional_vm_extensions: ["some-vm-extension"]
+errand-config:
+  second-errand:
+    post-deploy-state: true
+`)
+
+		err := executeCommand(command, []string{"--config", configFile})
+		Expect(err).To(MatchError(commands.ErrConfigDriftExists))
+
+		Expect(logger.PrintCallCount()).To(Equal(1))
+		Expect(fmt.Sprint(logger.PrintArgsForCall(0)...)).To(MatchYAML(`
+- path: /errand-config/second-errand/post-deploy-state
+  change: changed
+  config: true
+  staged: false
+- path: /product-properties/.properties.some-new-property
+  change: missing
+  config:
+    value: true
+- path: /product-properties/.properties.some-string-property/value
+  change: changed
+  config: other-value
+  staged: some-value
+- path: /resource-config/some-job/instances
+  change: changed
+  config: 3
+  staged: 1
+`))
+	})
+
+	It("compares the credentials with --include-credentials", func() {
+		fakeService = setFakeService(api.ResponseProperty{
+			Value:        "internal",
+			Type:         "selector",
+			Configurable: true,
+		}, false)
+		fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{{Type: "some-product", GUID: "some-product-guid"}}, nil)
+		fakeService.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{
+			Credential: api.Credential{Value: map[string]string{"secret": "some-secret-value"}},
+		}, nil)
+		command = commands.NewCompareConfig(func() []string { return nil }, fakeService, logger)
+
+		configFile := writeTestConfigFile(`---
+product-properties:
+  .properties.some-secret-property:
+    value:
+      secret: other-secret-value
+`)
+
+		err := executeCommand(command, []string{"--config", configFile, "--product-name", "some-product", "--include-credentials"})
+		Expect(err).To(MatchError(commands.ErrConfigDriftExists))
+		Expect(fmt.Sprint(logger.PrintArgsForCall(0)...)).To(MatchYAML(`
+- path: /product-properties/.properties.some-secret-property/value/secret
+  change: changed
+  config: other-secret-value
+  staged: some-secret-value
+`))
+	})
+
+	It("returns an error without a product name", func() {
+		configFile := writeTestConfigFile(`product-properties: {}`)
+
+		err := executeCommand(command, []string{"--config", configFile})
+		Expect(err).To(MatchError("the product name must be given with --product-name or product-name in the config file"))
+		Expect(fakeService.GetStagedProductByNameCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the staged configuration cannot be fetched", func() {
+		fakeService.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("some error"))
+		configFile := writeTestConfigFile(`product-name: some-product`)
+
+		err := executeCommand(command, []string{"--config", configFile})
+		Expect(err).To(MatchError("some error"))
+	})
+})
@@ -0,0 +1,425 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+const stagedProductsCompletion = "staged-products"
+
+// CompletionCommand describes om, or one of its commands, for the
+// completion scripts.
+type CompletionCommand struct {
+	Name        string
+	Description string
+	Flags       []CompletionFlag
+	Commands    []CompletionCommand
+}
+
+type CompletionFlag struct {
+	Long        string
+	Short       string
+	Description string
+	TakesValue  bool
+	Choices     []string
+}
+
+type Completion struct {
+	describe func() CompletionCommand
+	service  completionService
+	stdout   io.Writer
+	Options  struct {
+		List string `long:"list" hidden:"true" choice:"staged-products" description:"prints the values completed dynamically, one per line, instead of a script"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/completion_service.go --fake-name CompletionService . completionService
+type completionService interface {
+	ListStagedProducts() (api.StagedProductsOutput, error)
+}
+
+// NewCompletion takes describe, rather than the commands, since it is
+// registered before the commands it completes.
+func NewCompletion(describe func() CompletionCommand, service completionService, stdout io.Writer) *Completion {
+	return &Completion{
+		describe: describe,
+		service:  service,
+		stdout:   stdout,
+	}
+}
+
+func (c Completion) Execute(args []string) error {
+	if c.Options.List != "" {
+		return c.list()
+	}
+
+	if len(args) != 1 {
+		return errors.New("a shell is required, one of bash, zsh, fish or powershell, e.g. om completion bash")
+	}
+
+	var script string
+	switch args[0] {
+	case "bash":
+		script = bashCompletion(c.describe())
+	case "zsh":
+		// zsh runs the bash completion, so both complete the same way
+		script = "#compdef om\n# zsh completion for om, e.g. in ~/.zshrc: source <(om completion zsh)\n\nautoload -U +X bashcompinit && bashcompinit\n\n" + bashCompletion(c.describe())
+	case "fish":
+		script = fishCompletion(c.describe())
+	case "powershell":
+		script = powershellCompletion(c.describe())
+	default:
+		return fmt.Errorf("unsupported shell %q, use one of bash, zsh, fish or powershell", args[0])
+	}
+
+	_, err := io.WriteString(c.stdout, script)
+	return err
+}
+
+func (c Completion) list() error {
+	stagedProducts, err := c.service.ListStagedProducts()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, product := range stagedProducts.Products {
+		if product.Type != "p-bosh" {
+			names = append(names, product.Type)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, err = fmt.Fprintln(c.stdout, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completionPath is a command with the names of the commands leading to it,
+// e.g. "vm-lifecycle export-opsman-config", or "" for om itself.
+type completionPath struct {
+	path    string
+	command CompletionCommand
+}
+
+func completionPaths(root CompletionCommand) []completionPath {
+	paths := []completionPath{{command: root}}
+	for i := 0; i < len(paths); i++ {
+		for _, command := range paths[i].command.Commands {
+			paths = append(paths, completionPath{
+				path:    strings.TrimSpace(paths[i].path + " " + command.Name),
+				command: command,
+			})
+		}
+	}
+
+	return paths
+}
+
+func (p completionPath) commandNames() []string {
+	var names []string
+	for _, command := range p.command.Commands {
+		names = append(names, command.Name)
+	}
+
+	return names
+}
+
+func (p completionPath) flagNames(onlyWithValues bool) []string {
+	var names []string
+	for _, flag := range p.command.Flags {
+		if onlyWithValues && !flag.TakesValue {
+			continue
+		}
+
+		names = append(names, flag.names()...)
+	}
+
+	return names
+}
+
+func (f CompletionFlag) names() []string {
+	var names []string
+	if f.Long != "" {
+		names = append(names, "--"+f.Long)
+	}
+	if f.Short != "" {
+		names = append(names, "-"+f.Short)
+	}
+
+	return names
+}
+
+// dynamic is the list of values completed by asking Ops Manager, if any.
+func (f CompletionFlag) dynamic() string {
+	if f.Long == "product-name" {
+		return stagedProductsCompletion
+	}
+
+	return ""
+}
+
+func bashCompletion(root CompletionCommand) string {
+	paths := completionPaths(root)
+
+	var script strings.Builder
+	script.WriteString("# bash completion for om, e.g. in ~/.bashrc: source <(om completion bash)\n\n")
+
+	writeCase := func(function string, words func(completionPath) []string) {
+		fmt.Fprintf(&script, "%s() {\n    case \"$1\" in\n", function)
+		for _, p := range paths {
+			if values := words(p); len(values) > 0 {
+				fmt.Fprintf(&script, "    %q) echo %q ;;\n", p.path, strings.Join(values, " "))
+			}
+		}
+		script.WriteString("    esac\n}\n\n")
+	}
+
+	writeCase("_om_commands", completionPath.commandNames)
+	writeCase("_om_flags", func(p completionPath) []string { return p.flagNames(false) })
+	writeCase("_om_value_flags", func(p completionPath) []string { return p.flagNames(true) })
+
+	script.WriteString("_om_flag_values() {\n    case \"$1 $2\" in\n")
+	for _, p := range paths {
+		for _, flag := range p.command.Flags {
+			var patterns []string
+			for _, name := range flag.names() {
+				patterns = append(patterns, fmt.Sprintf("%q", p.path+" "+name))
+			}
+
+			switch {
+			case flag.dynamic() != "":
+				fmt.Fprintf(&script, "    %s) om ${3:+--env \"$3\"} completion --list %s 2>/dev/null ;;\n", strings.Join(patterns, "|"), flag.dynamic())
+			case len(flag.Choices) > 0:
+				fmt.Fprintf(&script, "    %s) echo %q ;;\n", strings.Join(patterns, "|"), strings.Join(flag.Choices, " "))
+			}
+		}
+	}
+	script.WriteString("    esac\n}\n\n")
+
+	script.WriteString(`_om() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+    local command="" env="" word values i
+
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        word="${COMP_WORDS[i]}"
+        if [[ -z "$command" && ( "$word" == "-e" || "$word" == "--env" ) ]]; then
+            env="${COMP_WORDS[i+1]}"
+        elif [[ " $(_om_commands "$command") " == *" $word "* ]]; then
+            command="${command:+$command }$word"
+        fi
+    done
+
+    if [[ "$prev" == -* ]]; then
+        values="$(_om_flag_values "$command" "$prev" "$env")"
+        if [[ -n "$values" ]]; then
+            COMPREPLY=($(compgen -W "$values" -- "$cur"))
+            return
+        fi
+
+        # the value of any other flag is completed as a file
+        if [[ " $(_om_value_flags "$command") " == *" $prev "* ]]; then
+            return
+        fi
+    fi
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$(_om_flags "$command")" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -W "$(_om_commands "$command")" -- "$cur"))
+    fi
+}
+
+complete -o default -F _om om
+`)
+
+	return script.String()
+}
+
+func fishCompletion(root CompletionCommand) string {
+	paths := completionPaths(root)
+
+	var script strings.Builder
+	script.WriteString("# fish completion for om, e.g.: om completion fish > ~/.config/fish/completions/om.fish\n\n")
+
+	script.WriteString("function __om_commands\n    switch \"$argv[1]\"\n")
+	for _, p := range paths {
+		if names := p.commandNames(); len(names) > 0 {
+			fmt.Fprintf(&script, "        case %s\n            printf '%%s\\n' %s\n", fishQuote(p.path), strings.Join(names, " "))
+		}
+	}
+	script.WriteString("    end\nend\n\n")
+
+	script.WriteString(`function __om_command
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l command ''
+    for token in $tokens
+        if contains -- $token (__om_commands "$command")
+            set command (string trim -- "$command $token")
+        end
+    end
+    echo $command
+end
+
+function __om_using
+    set -l command (__om_command)
+    test "$command" = "$argv[1]"
+end
+
+function __om_staged_products
+    set -l tokens (commandline -opc)
+    set -l index (contains -i -- --env $tokens; or contains -i -- -e $tokens)
+    if test -n "$index"; and test (count $tokens) -gt $index
+        om --env $tokens[(math $index + 1)] completion --list staged-products 2>/dev/null
+    else
+        om completion --list staged-products 2>/dev/null
+    end
+end
+
+`)
+
+	for _, p := range paths {
+		condition := fishQuote("__om_using " + fishQuote(p.path))
+
+		for _, command := range p.command.Commands {
+			fmt.Fprintf(&script, "complete -c om -n %s -f -a %s -d %s\n", condition, command.Name, fishQuote(command.Description))
+		}
+
+		for _, flag := range p.command.Flags {
+			line := fmt.Sprintf("complete -c om -n %s", condition)
+			if flag.Long != "" {
+				line += " -l " + flag.Long
+			}
+			if flag.Short != "" {
+				line += " -s " + flag.Short
+			}
+
+			switch {
+			case flag.dynamic() != "":
+				line += " -x -a '(__om_staged_products)'"
+			case len(flag.Choices) > 0:
+				line += " -x -a " + fishQuote(strings.Join(flag.Choices, " "))
+			case flag.TakesValue:
+				line += " -r -F"
+			}
+
+			fmt.Fprintf(&script, "%s -d %s\n", line, fishQuote(flag.Description))
+		}
+	}
+
+	return script.String()
+}
+
+func powershellCompletion(root CompletionCommand) string {
+	paths := completionPaths(root)
+
+	var script strings.Builder
+	script.WriteString("# powershell completion for om, e.g. in $PROFILE: om completion powershell | Out-String | Invoke-Expression\n\n")
+	script.WriteString("Register-ArgumentCompleter -Native -CommandName om -ScriptBlock {\n    param($wordToComplete, $commandAst, $cursorPosition)\n\n")
+
+	writeTable := func(name string, entries [][]string) {
+		fmt.Fprintf(&script, "    $%s = @{\n", name)
+		for _, entry := range entries {
+			var quoted []string
+			for _, value := range entry[1:] {
+				quoted = append(quoted, powershellQuote(value))
+			}
+			fmt.Fprintf(&script, "        %s = @(%s)\n", powershellQuote(entry[0]), strings.Join(quoted, ", "))
+		}
+		script.WriteString("    }\n")
+	}
+
+	// every entry is the key followed by its values
+	var commands, flags, valueFlags, choices, dynamic [][]string
+	for _, p := range paths {
+		if names := p.commandNames(); len(names) > 0 {
+			commands = append(commands, append([]string{p.path}, names...))
+		}
+		if names := p.flagNames(false); len(names) > 0 {
+			flags = append(flags, append([]string{p.path}, names...))
+		}
+		if names := p.flagNames(true); len(names) > 0 {
+			valueFlags = append(valueFlags, append([]string{p.path}, names...))
+		}
+
+		for _, flag := range p.command.Flags {
+			for _, name := range flag.names() {
+				key := p.path + " " + name
+				switch {
+				case flag.dynamic() != "":
+					dynamic = append(dynamic, []string{key, flag.dynamic()})
+				case len(flag.Choices) > 0:
+					choices = append(choices, append([]string{key}, flag.Choices...))
+				}
+			}
+		}
+	}
+
+	writeTable("commands", commands)
+	writeTable("flags", flags)
+	writeTable("valueFlags", valueFlags)
+	writeTable("choices", choices)
+	writeTable("dynamic", dynamic)
+
+	script.WriteString(`
+    $words = @($commandAst.CommandElements | Where-Object { $_.Extent.EndOffset -lt $cursorPosition } | Select-Object -Skip 1 | ForEach-Object { $_.Extent.Text })
+    $command = ''
+    $envFile = ''
+    for ($i = 0; $i -lt $words.Count; $i++) {
+        if ($command -eq '' -and ($words[$i] -eq '-e' -or $words[$i] -eq '--env') -and $i + 1 -lt $words.Count) {
+            $envFile = $words[$i + 1]
+        } elseif ($commands[$command] -contains $words[$i]) {
+            $command = "$command $($words[$i])".Trim()
+        }
+    }
+
+    $previous = ''
+    if ($words.Count -gt 0) {
+        $previous = $words[-1]
+    }
+    $key = "$command $previous"
+
+    if ($dynamic.ContainsKey($key)) {
+        $arguments = @()
+        if ($envFile -ne '') {
+            $arguments += '--env', $envFile
+        }
+        $candidates = @(om @arguments completion --list $dynamic[$key][0] 2>$null)
+    } elseif ($choices.ContainsKey($key)) {
+        $candidates = $choices[$key]
+    } elseif ($valueFlags[$command] -contains $previous) {
+        # the value of any other flag is completed as a file
+        return
+    } elseif ($wordToComplete.StartsWith('-')) {
+        $candidates = $flags[$command]
+    } else {
+        $candidates = $commands[$command]
+    }
+
+    $candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`)
+
+	return script.String()
+}
+
+func fishQuote(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func powershellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,163 @@
+package commands_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("Completion", func() {
+	var (
+		fakeService *fakes.CompletionService
+		stdout      *gbytes.Buffer
+		command     *commands.Completion
+	)
+
+	root := commands.CompletionCommand{
+		Name: "om",
+		Flags: []commands.CompletionFlag{
+			{Long: "env", Short: "e", TakesValue: true, Description: "env file"},
+			{Long: "log-format", TakesValue: true, Choices: []string{"text", "json"}},
+			{Long: "trace", Description: "prints HTTP requests"},
+		},
+		Commands: []commands.CompletionCommand{
+			{
+				Name:        "apply-changes",
+				Description: "triggers an install",
+				Flags: []commands.CompletionFlag{
+					{Long: "product-name", Short: "n", TakesValue: true},
+					{Long: "config", Short: "c", TakesValue: true},
+				},
+			},
+			{
+				Name:        "vm-lifecycle",
+				Description: "commands to manage the Ops Manager VM",
+				Commands: []commands.CompletionCommand{
+					{Name: "create-vm", Description: "creates the VM", Flags: []commands.CompletionFlag{{Long: "state-file", TakesValue: true}}},
+				},
+			},
+		},
+	}
+
+	BeforeEach(func() {
+		fakeService = &fakes.CompletionService{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewCompletion(func() commands.CompletionCommand { return root }, fakeService, stdout)
+	})
+
+	Describe("bash", func() {
+		var script string
+
+		BeforeEach(func() {
+			if _, err := exec.LookPath("bash"); err != nil {
+				Skip("bash is not available")
+			}
+
+			err := command.Execute([]string{"bash"})
+			Expect(err).ToNot(HaveOccurred())
+
+			script = filepath.Join(GinkgoT().TempDir(), "om.bash")
+			Expect(os.WriteFile(script, stdout.Contents(), 0600)).To(Succeed())
+		})
+
+		complete := func(line string) []string {
+			words := strings.Split(line, " ")
+			program := `source "$1"; shift; COMP_WORDS=("$@"); COMP_CWORD=$(($# - 1)); _om; printf '%s\n' "${COMPREPLY[@]}"`
+
+			output, err := exec.Command("bash", append([]string{"-c", program, "bash", script}, words...)...).Output()
+			Expect(err).ToNot(HaveOccurred())
+
+			return strings.Fields(string(output))
+		}
+
+		It("completes commands, subcommands and flags", func() {
+			Expect(complete("om ")).To(Equal([]string{"apply-changes", "vm-lifecycle"}))
+			Expect(complete("om vm-lifecycle c")).To(Equal([]string{"create-vm"}))
+			Expect(complete("om vm-lifecycle create-vm --")).To(Equal([]string{"--state-file"}))
+			Expect(complete("om --env env.yml apply-changes --c")).To(Equal([]string{"--config"}))
+			Expect(complete("om --t")).To(Equal([]string{"--trace"}))
+		})
+
+		It("completes the choices of flags and leaves other values to file completion", func() {
+			Expect(complete("om --log-format ")).To(Equal([]string{"text", "json"}))
+			Expect(complete("om apply-changes --config ")).To(BeEmpty())
+		})
+
+		It("completes the staged products with the env file", func() {
+			bin := GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(bin, "om"), []byte("#!/bin/sh\necho \"$*\" > \""+bin+"/args\"\necho cf\necho p-healthwatch\n"), 0755)).To(Succeed())
+			GinkgoT().Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+			Expect(complete("om -e env.yml apply-changes -n ")).To(Equal([]string{"cf", "p-healthwatch"}))
+
+			args, err := os.ReadFile(filepath.Join(bin, "args"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(args)).To(Equal("--env env.yml completion --list staged-products\n"))
+		})
+	})
+
+	It("prints a zsh script running the bash completion", func() {
+		err := command.Execute([]string{"zsh"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say("#compdef om"))
+		Expect(stdout).To(gbytes.Say("bashcompinit"))
+		Expect(stdout).To(gbytes.Say("complete -o default -F _om om"))
+	})
+
+	It("prints a fish script", func() {
+		err := command.Execute([]string{"fish"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(stdout.Contents())).To(ContainSubstring(`complete -c om -n '__om_using \'\'' -f -a apply-changes -d 'triggers an install'`))
+		Expect(string(stdout.Contents())).To(ContainSubstring(`complete -c om -n '__om_using \'apply-changes\'' -l product-name -s n -x -a '(__om_staged_products)' -d ''`))
+		Expect(string(stdout.Contents())).To(ContainSubstring(`complete -c om -n '__om_using \'vm-lifecycle create-vm\'' -l state-file -r -F -d ''`))
+		Expect(string(stdout.Contents())).To(ContainSubstring(`complete -c om -n '__om_using \'\'' -l log-format -x -a 'text json' -d ''`))
+	})
+
+	It("prints a powershell script", func() {
+		err := command.Execute([]string{"powershell"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(stdout.Contents())).To(ContainSubstring("Register-ArgumentCompleter -Native -CommandName om"))
+		Expect(string(stdout.Contents())).To(ContainSubstring("'vm-lifecycle' = @('create-vm')"))
+		Expect(string(stdout.Contents())).To(ContainSubstring("' --log-format' = @('text', 'json')"))
+		Expect(string(stdout.Contents())).To(ContainSubstring("'apply-changes -n' = @('staged-products')"))
+	})
+
+	It("lists the staged products", func() {
+		fakeService.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+			{Type: "p-bosh"},
+			{Type: "p-healthwatch"},
+			{Type: "cf"},
+		}}, nil)
+
+		command.Options.List = "staged-products"
+		err := command.Execute(nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(stdout.Contents())).To(Equal("cf\np-healthwatch\n"))
+	})
+
+	It("returns an error when the staged products cannot be listed", func() {
+		fakeService.ListStagedProductsReturns(api.StagedProductsOutput{}, errors.New("some error"))
+
+		command.Options.List = "staged-products"
+		err := command.Execute(nil)
+		Expect(err).To(MatchError("some error"))
+	})
+
+	It("requires a supported shell", func() {
+		Expect(command.Execute(nil)).To(MatchError(ContainSubstring("a shell is required")))
+		Expect(command.Execute([]string{"tcsh"})).To(MatchError(`unsupported shell "tcsh", use one of bash, zsh, fish or powershell`))
+	})
+})
@@ -7,11 +7,13 @@ import (
 
 	"github.com/pivotal-cf/om/configtemplate/generator"
 	"github.com/pivotal-cf/om/configtemplate/metadata"
+	"gopkg.in/yaml.v2"
 )
 
 type ConfigTemplate struct {
 	environFunc   envProvider
 	buildProvider configTemplateBuildProvider
+	service       stagedConfigService
 	Options       struct {
 		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
 
@@ -27,6 +29,8 @@ type ConfigTemplate struct {
 		OutputDirectory   string `long:"output-directory" description:"a directory to create templates under. must already exist." required:"true"`
 		ExcludeVersion    bool   `long:"exclude-version"  description:"if set, will not output a version-specific directory"`
 		SizeOfCollections int    `long:"size-of-collections"`
+		SeedFromStaged    bool   `long:"seed-from-staged" description:"fill the vars with the staged configuration of the product on the Ops Manager targeted with the global --env or --target flags"`
+		JSONSchema        bool   `long:"json-schema"      description:"also write product.schema.json, a JSON Schema to validate product config files with"`
 
 		PivnetFileGlobSupport string `long:"pivnet-file-glob" hidden:"true"`
 	}
@@ -52,14 +56,15 @@ type (
 	envProvider                 func() []string
 )
 
-func NewConfigTemplate(bp configTemplateBuildProvider) *ConfigTemplate {
-	return NewConfigTemplateWithEnvironment(bp, os.Environ)
+func NewConfigTemplate(bp configTemplateBuildProvider, service stagedConfigService) *ConfigTemplate {
+	return NewConfigTemplateWithEnvironment(bp, service, os.Environ)
 }
 
-func NewConfigTemplateWithEnvironment(bp configTemplateBuildProvider, environFunc envProvider) *ConfigTemplate {
+func NewConfigTemplateWithEnvironment(bp configTemplateBuildProvider, service stagedConfigService, environFunc envProvider) *ConfigTemplate {
 	return &ConfigTemplate{
 		environFunc:   environFunc,
 		buildProvider: bp,
+		service:       service,
 	}
 }
 
@@ -88,14 +93,48 @@ func (c *ConfigTemplate) Execute(args []string) error {
 		return fmt.Errorf("error getting metadata for %s at version %s: %s", c.Options.PivnetProductSlug, c.Options.ProductVersion, err)
 	}
 
-	return generator.NewExecutor(
+	executor := generator.NewExecutor(
 		metadataBytes,
 		c.Options.OutputDirectory,
 		c.Options.ExcludeVersion,
 		true,
 		c.Options.SizeOfCollections,
 		userSetSizeOfCollections,
-	).Generate()
+	)
+
+	if c.Options.SeedFromStaged {
+		stagedConfig, err := c.stagedConfig(metadataBytes)
+		if err != nil {
+			return err
+		}
+
+		executor.WithStagedConfig(stagedConfig)
+	}
+
+	if c.Options.JSONSchema {
+		executor.WithJSONSchema()
+	}
+
+	return executor.Generate()
+}
+
+// stagedConfig returns the staged configuration of the product of the
+// metadata, without credentials.
+func (c *ConfigTemplate) stagedConfig(metadataBytes []byte) ([]byte, error) {
+	metadata, err := generator.NewMetadata(metadataBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	stagedConfig := StagedConfig{service: c.service}
+	stagedConfig.Options.Product = metadata.ProductName()
+
+	productConfiguration, _, err := stagedConfig.productConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("could not get the staged config of %s: %s", metadata.ProductName(), err)
+	}
+
+	return yaml.Marshal(productConfiguration)
 }
 
 func (c *ConfigTemplate) newMetadataSource() (metadataSource MetadataProvider) {
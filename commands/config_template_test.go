@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
 )
@@ -30,7 +31,7 @@ var _ = Describe("ConfigTemplate", func() {
 				f := &fakes.MetadataProvider{}
 				f.MetadataBytesReturns([]byte(`{name: example-product, product_version: "1.1.1"}`), nil)
 				return f
-			})
+			}, nil)
 		})
 
 		Describe("upserting an entry in the output directory with template files", func() {
@@ -166,6 +167,96 @@ var _ = Describe("ConfigTemplate", func() {
 			})
 		})
 
+		When("seeding the vars from the staged config", func() {
+			var service *fakes.StagedConfigService
+
+			BeforeEach(func() {
+				service = &fakes.StagedConfigService{}
+				service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{Product: api.StagedProduct{GUID: "some-guid"}}, nil)
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.some_property": {Value: "live-value", Type: "string", Configurable: true},
+				}, nil)
+
+				command = commands.NewConfigTemplate(func(*commands.ConfigTemplate) commands.MetadataProvider {
+					f := &fakes.MetadataProvider{}
+					f.MetadataBytesReturns([]byte(`---
+name: example-product
+product_version: "1.1.1"
+form_types:
+- property_inputs:
+  - reference: .properties.some_property
+property_blueprints:
+- type: string
+  name: some_property
+  configurable: true
+  default: some-default
+`), nil)
+					return f
+				}, service)
+			})
+
+			It("fills the vars with the staged values of the product", func() {
+				tempDir := createOutputDirectory()
+
+				err := executeCommand(command, []string{
+					"--output-directory", tempDir,
+					"--product-path", "example-product.pivotal",
+					"--seed-from-staged",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(service.GetStagedProductByNameArgsForCall(0)).To(Equal("example-product"))
+
+				contents, err := os.ReadFile(filepath.Join(tempDir, "example-product", "1.1.1", "default-vars.yml"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(contents).To(MatchYAML(`some_property: live-value`))
+			})
+
+			It("returns an error when the product is not staged", func() {
+				service.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("product not found"))
+
+				err := executeCommand(command, []string{
+					"--output-directory", createOutputDirectory(),
+					"--product-path", "example-product.pivotal",
+					"--seed-from-staged",
+				})
+				Expect(err).To(MatchError("could not get the staged config of example-product: product not found"))
+			})
+		})
+
+		When("--json-schema is provided", func() {
+			It("writes a JSON Schema of the product config", func() {
+				command := commands.NewConfigTemplate(func(*commands.ConfigTemplate) commands.MetadataProvider {
+					f := &fakes.MetadataProvider{}
+					f.MetadataBytesReturns([]byte(`---
+name: example-product
+product_version: "1.1.1"
+form_types:
+- property_inputs:
+  - reference: .properties.some_property
+property_blueprints:
+- type: integer
+  name: some_property
+  configurable: true
+`), nil)
+					return f
+				}, nil)
+
+				tempDir := createOutputDirectory()
+				err := executeCommand(command, []string{
+					"--output-directory", tempDir,
+					"--product-path", "example-product.pivotal",
+					"--json-schema",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				contents, err := os.ReadFile(filepath.Join(tempDir, "example-product", "1.1.1", "product.schema.json"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(ContainSubstring(`".properties.some_property"`))
+				Expect(string(contents)).To(ContainSubstring(`"type": "integer"`))
+			})
+		})
+
 		When("the product has a collection", func() {
 			BeforeEach(func() {
 				command = commands.NewConfigTemplate(func(*commands.ConfigTemplate) commands.MetadataProvider {
@@ -186,7 +277,7 @@ property_blueprints:
     type: string
 `), nil)
 					return f
-				})
+				}, nil)
 			})
 
 			It("outputs 10 ops-files by default", func() {
@@ -250,7 +341,7 @@ property_blueprints:
 					f := &fakes.MetadataProvider{}
 					f.MetadataBytesReturns([]byte(`{name: example-product, product_version: "1.1.1"}`), nil)
 					return f
-				})
+				}, nil)
 			})
 			It("returns an error", func() {
 				err := executeCommand(command, []string{
@@ -268,7 +359,7 @@ property_blueprints:
 					f := &fakes.MetadataProvider{}
 					f.MetadataBytesReturns([]byte(`{name: example-product, product_version: "1.1.1"}`), nil)
 					return f
-				})
+				}, nil)
 			})
 			DescribeTable("returns an error", func(required, message string) {
 				args := []string{
@@ -300,7 +391,7 @@ property_blueprints:
 						f := &fakes.MetadataProvider{}
 						f.MetadataBytesReturns(nil, errors.New("cannot get metadata"))
 						return f
-					})
+					}, nil)
 				})
 
 				It("returns an error", func() {
@@ -323,7 +414,7 @@ property_blueprints:
 						f := &fakes.MetadataProvider{}
 						f.MetadataBytesReturns([]byte(`{name: example-product, product_version: ""}`), nil)
 						return f
-					})
+					}, nil)
 				})
 				It("errors", func() {
 					tempDir := createOutputDirectory()
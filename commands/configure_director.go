@@ -20,10 +20,13 @@ type ConfigureDirector struct {
 	Options     struct {
 		IgnoreVerifierWarnings bool     `long:"ignore-verifier-warnings"    description:"option to ignore verifier warnings. NOT RECOMMENDED UNLESS DISABLED IN OPS MANAGER"`
 		ConfigFile             string   `long:"config"    short:"c"         description:"path to yml file containing all config fields (see docs/configure-director/README.md for format)" required:"true"`
-		VarsFile               []string `long:"vars-file" short:"l"         description:"load variables from a YAML file"`
-		VarsEnv                []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+		VarsFile               []string `long:"vars-file" short:"l"         description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
+		VarsEnv                []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
 		Vars                   []string `long:"var"       short:"v"         description:"load variable from the command line. Format: VAR=VAL"`
 		OpsFile                []string `long:"ops-file"                    description:"YAML operations file"`
+		VarsStore              []string `long:"vars-store"                  description:"resolve variables from a credential store (credhub://, vault://, aws-sm://, aws-ssm:// or file:// URI), as PREFIX=URI to name them PREFIX_name; vars from files, flags and the environment take precedence, then the last store given"`
+		Functions              bool     `long:"functions"                   description:"enable the functions base64, sha256, password, join and split in the config, e.g. ((base64 some_var))"`
+		Merge                  bool     `long:"merge"                       description:"only add or update what the config file lists, keeping the networks, vm extensions and custom vm types it leaves out"`
 	}
 }
 
@@ -52,6 +55,7 @@ type configureDirectorService interface {
 	DeleteCustomVMTypes() error
 	DeleteVMExtension(name string) error
 	GetStagedProductByName(name string) (api.StagedProductsFindOutput, error)
+	GetStagedDirectorNetworks() (api.NetworksConfigurationOutput, error)
 	GetStagedProductManifest(guid string) (manifest string, err error)
 	Info() (api.Info, error)
 	ListInstallations() ([]api.InstallationsServiceOutput, error)
@@ -140,6 +144,8 @@ func (c ConfigureDirector) interpolateConfig() (*directorConfig, error) {
 	configContents, err := interpolate.Execute(interpolate.Options{
 		TemplateFile:  c.Options.ConfigFile,
 		VarsFiles:     c.Options.VarsFile,
+		VarsStores:    c.Options.VarsStore,
+		Functions:     c.Options.Functions,
 		EnvironFunc:   c.environFunc,
 		Vars:          c.Options.Vars,
 		VarsEnvs:      c.Options.VarsEnv,
@@ -318,6 +324,13 @@ func (c ConfigureDirector) configureNetworksConfiguration(config *directorConfig
 			return err
 		}
 
+		if c.Options.Merge {
+			networksConfiguration, err = c.mergeNetworks(networksConfiguration)
+			if err != nil {
+				return err
+			}
+		}
+
 		err = c.service.UpdateStagedDirectorNetworks(api.NetworkInput{
 			Networks: json.RawMessage(networksConfiguration),
 		})
@@ -330,6 +343,57 @@ func (c ConfigureDirector) configureNetworksConfiguration(config *directorConfig
 	return nil
 }
 
+// mergeNetworks adds the staged networks the config leaves out, as the
+// networks are replaced as a whole.
+func (c ConfigureDirector) mergeNetworks(networksConfiguration string) (string, error) {
+	existing, err := c.service.GetStagedDirectorNetworks()
+	if err != nil {
+		return "", fmt.Errorf("could not get the staged networks to merge with: %s", err)
+	}
+
+	existingConfiguration, err := getJSONProperties(existing)
+	if err != nil {
+		return "", err
+	}
+
+	var merged, staged map[string]interface{}
+	err = json.Unmarshal([]byte(networksConfiguration), &merged)
+	if err != nil {
+		return "", fmt.Errorf("could not parse networks-configuration: %s", err)
+	}
+	err = json.Unmarshal([]byte(existingConfiguration), &staged)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	if _, ok := merged["icmp_checks_enabled"]; !ok {
+		merged["icmp_checks_enabled"] = staged["icmp_checks_enabled"]
+	}
+
+	networks, _ := merged["networks"].([]interface{})
+	configured := map[interface{}]bool{}
+	for _, network := range networks {
+		if network, ok := network.(map[string]interface{}); ok {
+			configured[network["name"]] = true
+		}
+	}
+
+	stagedNetworks, _ := staged["networks"].([]interface{})
+	for _, network := range stagedNetworks {
+		if network, ok := network.(map[string]interface{}); ok && !configured[network["name"]] {
+			networks = append(networks, network)
+		}
+	}
+	merged["networks"] = networks
+
+	contents, err := json.Marshal(merged)
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return string(contents), nil
+}
+
 func (c ConfigureDirector) configureNetworkAssignment(config *directorConfig) error {
 	if config.NetworkAssignment != nil {
 		c.logger.Printf("started configuring network assignment options for bosh tile")
@@ -437,9 +501,11 @@ func (c ConfigureDirector) configureVMExtensions(config *directorConfig) error {
 			return err
 		}
 
-		err = c.deleteExtensions(extensionsToDelete)
-		if err != nil {
-			return err
+		if !c.Options.Merge {
+			err = c.deleteExtensions(extensionsToDelete)
+			if err != nil {
+				return err
+			}
 		}
 
 		c.logger.Printf("finished configuring vm extensions")
@@ -462,7 +528,24 @@ func (c ConfigureDirector) configureVMTypes(config *directorConfig) error {
 	existingVMTypes := make([]api.VMType, 0)
 
 	var err error
-	if !config.VMTypes.CustomTypesOnly {
+	switch {
+	case c.Options.Merge:
+		// the vm types are replaced as a whole, so the current ones are kept
+		existingVMTypes, err = c.service.ListVMTypes()
+		if err != nil {
+			return err
+		}
+
+		if config.VMTypes.CustomTypesOnly {
+			var customVMTypes []api.VMType
+			for _, vmType := range existingVMTypes {
+				if !vmType.BuiltIn {
+					customVMTypes = append(customVMTypes, vmType)
+				}
+			}
+			existingVMTypes = customVMTypes
+		}
+	case !config.VMTypes.CustomTypesOnly:
 		// delete all custom VM types
 		if err = c.service.DeleteCustomVMTypes(); err != nil {
 			return err
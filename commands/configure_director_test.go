@@ -473,6 +473,73 @@ vmtypes-configuration:
 			})
 		})
 
+		When("--merge is provided", func() {
+			It("does not delete the vm extensions the config leaves out", func() {
+				err = executeCommand(command, []string{
+					"--config", writeTestConfigFile(`vmextensions-configuration: [{name: a_vm_extension}]`),
+					"--merge",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.CreateStagedVMExtensionCallCount()).To(Equal(1))
+				Expect(service.DeleteVMExtensionCallCount()).To(Equal(0))
+			})
+
+			It("keeps the staged networks the config leaves out", func() {
+				service.GetStagedDirectorNetworksReturns(api.NetworksConfigurationOutput{
+					ICMP: true,
+					Networks: []api.NetworkConfigurationOutput{
+						{Name: "network-1"},
+						{Name: "network-2"},
+					},
+				}, nil)
+
+				err = executeCommand(command, []string{
+					"--config", writeTestConfigFile(`{"networks-configuration": {"networks": [{"name": "network-1", "subnets": []}, {"name": "network-3"}]}}`),
+					"--merge",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.UpdateStagedDirectorNetworksCallCount()).To(Equal(1))
+				Expect(string(service.UpdateStagedDirectorNetworksArgsForCall(0).Networks)).To(MatchJSON(`{
+					"icmp_checks_enabled": true,
+					"networks": [
+						{"name": "network-1", "subnets": []},
+						{"name": "network-3"},
+						{"name": "network-2"}
+					]
+				}`))
+			})
+
+			It("keeps the existing custom vm types", func() {
+				service.ListVMTypesReturns([]api.VMType{
+					{CreateVMType: api.CreateVMType{Name: "vmtype1", CPU: 2, RAM: 4096}, BuiltIn: true},
+					{CreateVMType: api.CreateVMType{Name: "custom1", CPU: 1, RAM: 1024}},
+				}, nil)
+
+				err = executeCommand(command, []string{
+					"--config", writeTestConfigFile(`{"vmtypes-configuration": {"custom_only": true, "vm_types": [{"name": "custom2", "cpu": 4}]}}`),
+					"--merge",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.DeleteCustomVMTypesCallCount()).To(Equal(0))
+				Expect(service.CreateCustomVMTypesArgsForCall(0)).To(Equal(api.CreateVMTypes{
+					VMTypes: []api.CreateVMType{
+						{Name: "custom1", CPU: 1, RAM: 1024},
+						{Name: "custom2", CPU: 4},
+					},
+				}))
+			})
+
+			It("returns an error when the staged networks cannot be fetched", func() {
+				service.GetStagedDirectorNetworksReturns(api.NetworksConfigurationOutput{}, errors.New("some error"))
+
+				err = executeCommand(command, []string{
+					"--config", writeTestConfigFile(`{"networks-configuration": {"networks": []}}`),
+					"--merge",
+				})
+				Expect(err).To(MatchError("could not get the staged networks to merge with: some error"))
+			})
+		})
+
 		When("only some of the configure-director top-level keys are provided", func() {
 			It("only updates the config for the provided flags, and sets others to empty", func() {
 				err := executeCommand(command, []string{
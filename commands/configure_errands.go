@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/config"
+	"github.com/pivotal-cf/om/interpolate"
+	"gopkg.in/yaml.v2"
+)
+
+type ConfigureErrands struct {
+	service     configureErrandsService
+	logger      logger
+	environFunc func() []string
+	Options     struct {
+		ConfigFile string   `long:"config"    short:"c"         description:"path to yml file mapping staged products to the states of their errands" required:"true"`
+		VarsFile   []string `long:"vars-file" short:"l"         description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
+		VarsEnv    []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
+		Vars       []string `long:"var"       short:"v"         description:"load variable from the command line. Format: VAR=VAL"`
+		OpsFile    []string `long:"ops-file"                    description:"YAML operations file"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/configure_errands_service.go --fake-name ConfigureErrandsService . configureErrandsService
+type configureErrandsService interface {
+	ListStagedProducts() (api.StagedProductsOutput, error)
+	ListStagedProductErrands(productID string) (api.ErrandsListOutput, error)
+	UpdateStagedProductErrands(productID, errandName string, postDeployState, preDeleteState interface{}) error
+}
+
+// errandsConfig sets errand states for every staged product that has the
+// errand, and for particular products. The states of a particular product
+// take precedence.
+type errandsConfig struct {
+	AllProducts map[string]config.ErrandConfig            `yaml:"all-products,omitempty"`
+	Products    map[string]map[string]config.ErrandConfig `yaml:"products,omitempty"`
+}
+
+// errandChange is an errand of a staged product that has to be updated to
+// match the config file.
+type errandChange struct {
+	productName string
+	productGUID string
+	errandName  string
+	errand      config.ErrandConfig
+}
+
+func NewConfigureErrands(environFunc func() []string, service configureErrandsService, logger logger) *ConfigureErrands {
+	return &ConfigureErrands{
+		environFunc: environFunc,
+		service:     service,
+		logger:      logger,
+	}
+}
+
+func (ce ConfigureErrands) Execute(args []string) error {
+	configContents, err := interpolate.Execute(interpolate.Options{
+		TemplateFile:  ce.Options.ConfigFile,
+		VarsFiles:     ce.Options.VarsFile,
+		EnvironFunc:   ce.environFunc,
+		Vars:          ce.Options.Vars,
+		VarsEnvs:      ce.Options.VarsEnv,
+		OpsFiles:      ce.Options.OpsFile,
+		ExpectAllKeys: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var cfg errandsConfig
+	err = yaml.UnmarshalStrict(configContents, &cfg)
+	if err != nil {
+		return fmt.Errorf("could not be parsed as valid configuration: %s: %s", ce.Options.ConfigFile, err)
+	}
+
+	stagedProducts, err := ce.service.ListStagedProducts()
+	if err != nil {
+		return fmt.Errorf("could not list the staged products: %s", err)
+	}
+
+	productGUIDs := map[string]string{}
+	for _, product := range stagedProducts.Products {
+		if product.Type != "p-bosh" {
+			productGUIDs[product.Type] = product.GUID
+		}
+	}
+
+	var missing []string
+	for _, productName := range sortedKeys(cfg.Products) {
+		if _, ok := productGUIDs[productName]; !ok {
+			missing = append(missing, fmt.Sprintf("product %s is not staged", productName))
+		}
+	}
+
+	// the errands of all products are listed before the first one is set, so
+	// a misspelled errand or product name stops the run with nothing applied
+	var changes []errandChange
+	foundAllProductsErrands := map[string]bool{}
+	for _, productName := range sortedKeys(productGUIDs) {
+		productErrands, listed := cfg.Products[productName]
+		if !listed && len(cfg.AllProducts) == 0 {
+			continue
+		}
+
+		errands, err := ce.service.ListStagedProductErrands(productGUIDs[productName])
+		if err != nil {
+			return fmt.Errorf("could not list the errands of %s: %s", productName, err)
+		}
+
+		existing := map[string]api.Errand{}
+		for _, errand := range errands.Errands {
+			existing[errand.Name] = errand
+		}
+
+		desired := map[string]config.ErrandConfig{}
+		for errandName, errand := range cfg.AllProducts {
+			if _, ok := existing[errandName]; ok {
+				desired[errandName] = errand
+				foundAllProductsErrands[errandName] = true
+			}
+		}
+		for errandName, errand := range productErrands {
+			if _, ok := existing[errandName]; !ok {
+				missing = append(missing, fmt.Sprintf("errand %s of product %s", errandName, productName))
+				continue
+			}
+			desired[errandName] = errand
+		}
+
+		for _, errandName := range sortedKeys(desired) {
+			if errandUpToDate(existing[errandName], desired[errandName]) {
+				continue
+			}
+
+			changes = append(changes, errandChange{
+				productName: productName,
+				productGUID: productGUIDs[productName],
+				errandName:  errandName,
+				errand:      desired[errandName],
+			})
+		}
+	}
+
+	for _, errandName := range sortedKeys(cfg.AllProducts) {
+		if !foundAllProductsErrands[errandName] {
+			missing = append(missing, fmt.Sprintf("errand %s of any staged product", errandName))
+		}
+	}
+
+	if len(missing) > 0 {
+		ce.logger.Println("The following products and errands do not exist:")
+		for _, name := range missing {
+			ce.logger.Printf("- %s\n", name)
+		}
+
+		ce.logger.Println("\nNo changes were made.\n")
+
+		return errors.New("product or errand does not exist")
+	}
+
+	if len(changes) == 0 {
+		ce.logger.Println("errands are already configured")
+		return nil
+	}
+
+	for _, change := range changes {
+		ce.logger.Printf("setting errand %s of %s\n", change.errandName, change.productName)
+
+		err = ce.service.UpdateStagedProductErrands(change.productGUID, change.errandName, change.errand.PostDeployState, change.errand.PreDeleteState)
+		if err != nil {
+			return fmt.Errorf("failed to set errand state for errand %s of %s: %s", change.errandName, change.productName, err)
+		}
+	}
+
+	return nil
+}
+
+// errandUpToDate is true when the states the config sets are the current
+// states of the errand. States may be booleans or strings like "when-changed".
+func errandUpToDate(existing api.Errand, desired config.ErrandConfig) bool {
+	if desired.PostDeployState != nil && fmt.Sprint(desired.PostDeployState) != fmt.Sprint(existing.PostDeploy) {
+		return false
+	}
+
+	if desired.PreDeleteState != nil && fmt.Sprint(desired.PreDeleteState) != fmt.Sprint(existing.PreDelete) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,150 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("ConfigureErrands", func() {
+	var (
+		service *fakes.ConfigureErrandsService
+		stdout  *gbytes.Buffer
+		command *commands.ConfigureErrands
+	)
+
+	BeforeEach(func() {
+		service = &fakes.ConfigureErrandsService{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewConfigureErrands(func() []string { return nil }, service, log.New(stdout, "", 0))
+
+		service.ListStagedProductsReturns(api.StagedProductsOutput{
+			Products: []api.StagedProduct{
+				{GUID: "p-bosh-guid", Type: "p-bosh"},
+				{GUID: "cf-guid", Type: "cf"},
+				{GUID: "p-redis-guid", Type: "p-redis"},
+			},
+		}, nil)
+		service.ListStagedProductErrandsStub = func(productID string) (api.ErrandsListOutput, error) {
+			switch productID {
+			case "cf-guid":
+				return api.ErrandsListOutput{Errands: []api.Errand{
+					{Name: "smoke_tests", PostDeploy: true},
+					{Name: "push-apps-manager", PostDeploy: "when-changed"},
+				}}, nil
+			case "p-redis-guid":
+				return api.ErrandsListOutput{Errands: []api.Errand{
+					{Name: "smoke_tests", PostDeploy: false},
+					{Name: "delete-all-service-instances", PreDelete: true},
+				}}, nil
+			}
+			return api.ErrandsListOutput{}, nil
+		}
+	})
+
+	It("sets the errands of every product that has them, preferring the states of a particular product", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`
+all-products:
+  smoke_tests:
+    post-deploy-state: false
+products:
+  cf:
+    push-apps-manager:
+      post-deploy-state: true
+  p-redis:
+    delete-all-service-instances:
+      pre-delete-state: false
+`)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(3))
+
+		productID, errandName, postDeploy, preDelete := service.UpdateStagedProductErrandsArgsForCall(0)
+		Expect([]interface{}{productID, errandName, postDeploy, preDelete}).To(Equal([]interface{}{"cf-guid", "push-apps-manager", true, nil}))
+
+		productID, errandName, postDeploy, preDelete = service.UpdateStagedProductErrandsArgsForCall(1)
+		Expect([]interface{}{productID, errandName, postDeploy, preDelete}).To(Equal([]interface{}{"cf-guid", "smoke_tests", false, nil}))
+
+		productID, errandName, postDeploy, preDelete = service.UpdateStagedProductErrandsArgsForCall(2)
+		Expect([]interface{}{productID, errandName, postDeploy, preDelete}).To(Equal([]interface{}{"p-redis-guid", "delete-all-service-instances", nil, false}))
+
+		Expect(stdout).To(gbytes.Say("setting errand push-apps-manager of cf"))
+		Expect(stdout).To(gbytes.Say("setting errand smoke_tests of cf"))
+		Expect(stdout).To(gbytes.Say("setting errand delete-all-service-instances of p-redis"))
+	})
+
+	It("does nothing when the errands are already configured", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`
+products:
+  cf:
+    push-apps-manager:
+      post-deploy-state: when-changed
+    smoke_tests:
+      post-deploy-state: true
+`)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+		Expect(service.ListStagedProductErrandsCallCount()).To(Equal(1))
+		Expect(stdout).To(gbytes.Say("errands are already configured"))
+	})
+
+	It("makes no changes when a product or errand does not exist", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`
+all-products:
+  smoke_tests:
+    post-deploy-state: false
+  run-everywhere:
+    post-deploy-state: true
+products:
+  cf:
+    typo:
+      post-deploy-state: false
+  p-mysql:
+    smoke_tests:
+      post-deploy-state: false
+`)})
+		Expect(err).To(MatchError("product or errand does not exist"))
+
+		Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("The following products and errands do not exist:"))
+		Expect(stdout).To(gbytes.Say("- product p-mysql is not staged"))
+		Expect(stdout).To(gbytes.Say("- errand typo of product cf"))
+		Expect(stdout).To(gbytes.Say("- errand run-everywhere of any staged product"))
+		Expect(stdout).To(gbytes.Say("No changes were made."))
+	})
+
+	It("returns an error when the config file is not valid", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{unknown: {}}`)})
+		Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+	})
+
+	It("returns an error when the staged products cannot be listed", func() {
+		service.ListStagedProductsReturns(api.StagedProductsOutput{}, errors.New("some error"))
+
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{all-products: {smoke_tests: {post-deploy-state: false}}}`)})
+		Expect(err).To(MatchError("could not list the staged products: some error"))
+	})
+
+	It("returns an error when the errands cannot be listed", func() {
+		service.ListStagedProductErrandsStub = nil
+		service.ListStagedProductErrandsReturns(api.ErrandsListOutput{}, errors.New("some error"))
+
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{all-products: {smoke_tests: {post-deploy-state: false}}}`)})
+		Expect(err).To(MatchError("could not list the errands of cf: some error"))
+	})
+
+	It("returns an error when an errand cannot be updated", func() {
+		service.UpdateStagedProductErrandsReturns(errors.New("some error"))
+
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{all-products: {smoke_tests: {post-deploy-state: false}}}`)})
+		Expect(err).To(MatchError("failed to set errand state for errand smoke_tests of cf: some error"))
+	})
+})
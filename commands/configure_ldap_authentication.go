@@ -1,8 +1,12 @@
 package commands
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/pivotal-cf/om/api"
 )
@@ -23,11 +27,12 @@ type ConfigureLDAPAuthentication struct {
 		GroupSearchFilter         string `long:"group-search-filter"              required:"true" description:"search filter to find the groups to which a user belongs, e.g. 'member={0}'"`
 		LDAPPassword              string `long:"ldap-password"                    required:"true" description:"password for ldap-username DN"`
 		LDAPRBACAdminGroup        string `long:"ldap-rbac-admin-group-name"       required:"true" description:"the name of LDAP group whose members should be considered admins of OpsManager"`
-		LDAPReferral              string `long:"ldap-referrals"                   required:"true" description:"configure the UAA LDAP referral behavior"`
+		LDAPReferral              string `long:"ldap-referrals"                   required:"true" description:"configure the UAA LDAP referral behavior: follow, ignore or throw"`
 		LDAPUsername              string `long:"ldap-username"                    required:"true" description:"DN for the LDAP credentials used to search the directory"`
 		LDAPMaxSearchDepth        uint   `long:"ldap-max-search-depth"                            description:"The LDAP group search depth. Allowed values are between 1 and 10. The default value is 1, which will turn off the nested group search."`
-		ServerSSLCert             string `long:"server-ssl-cert"                                  description:"the server certificate when using ldaps://"`
-		ServerURL                 string `long:"server-url"                       required:"true" description:"URL to the ldap server, must start with ldap:// or ldaps://"`
+		ServerSSLCert             string `long:"server-ssl-cert"                                  description:"the server certificate, or the CA certificates that signed it, when using ldaps://"`
+		ServerSSLCertFile         string `long:"server-ssl-cert-file"                             description:"path to a PEM file with the server certificate, or the CA certificates that signed it, when using ldaps://"`
+		ServerURL                 string `long:"server-url"                       required:"true" description:"URL to the ldap server, must start with ldap:// or ldaps://. Separate several URLs with spaces to fail over between servers"`
 		UserSearchBase            string `long:"user-search-base"                 required:"true" description:"a base at which the search starts, e.g. 'ou=users,dc=mycompany,dc=com'"`
 		UserSearchFilter          string `long:"user-search-filter"               required:"true" description:"search filter used for the query. Takes one parameter, user ID defined as {0}. e.g. 'cn={0}'"`
 		SkipCreateBoshAdminClient bool   `long:"skip-create-bosh-admin-client"                    description:"by default, this command creates a UAA client on the Bosh Director, whose credentials can be passed to the BOSH CLI to execute BOSH commands. This flag skips that."`
@@ -49,6 +54,11 @@ func (ca ConfigureLDAPAuthentication) Execute(args []string) error {
 		opsManUaaClientMsg string
 	)
 
+	serverSSLCert, err := ca.validate()
+	if err != nil {
+		return err
+	}
+
 	ensureAvailabilityOutput, err := ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 	if err != nil {
 		return fmt.Errorf("could not determine initial configuration status: %s", err)
@@ -82,7 +92,7 @@ func (ca ConfigureLDAPAuthentication) Execute(args []string) error {
 			LDAPReferral:       ca.Options.LDAPReferral,
 			LDAPUsername:       ca.Options.LDAPUsername,
 			LDAPMaxSearchDepth: ca.Options.LDAPMaxSearchDepth,
-			ServerSSLCert:      ca.Options.ServerSSLCert,
+			ServerSSLCert:      serverSSLCert,
 			ServerURL:          ca.Options.ServerURL,
 			UserSearchBase:     ca.Options.UserSearchBase,
 			UserSearchFilter:   ca.Options.UserSearchFilter,
@@ -178,3 +188,77 @@ This is only supported in OpsManager 3.0 and up.
 
 	return nil
 }
+
+// validate checks the settings UAA would otherwise only reject once the
+// authentication system starts, and returns the server certificate to upload.
+func (ca ConfigureLDAPAuthentication) validate() (string, error) {
+	for _, url := range strings.Fields(ca.Options.ServerURL) {
+		if !strings.HasPrefix(url, "ldap://") && !strings.HasPrefix(url, "ldaps://") {
+			return "", fmt.Errorf("--server-url %q must start with ldap:// or ldaps://", url)
+		}
+	}
+
+	switch ca.Options.LDAPReferral {
+	case "follow", "ignore", "throw":
+	default:
+		return "", fmt.Errorf("--ldap-referrals must be one of follow, ignore or throw, got %q", ca.Options.LDAPReferral)
+	}
+
+	if !strings.Contains(ca.Options.UserSearchFilter, "{0}") {
+		return "", errors.New("--user-search-filter must contain {0}, which is replaced by the user ID")
+	}
+
+	if !strings.Contains(ca.Options.GroupSearchFilter, "{0}") && !strings.Contains(ca.Options.GroupSearchFilter, "{1}") {
+		return "", errors.New("--group-search-filter must contain {0}, which is replaced by the user DN, or {1}, which is replaced by the user ID")
+	}
+
+	serverSSLCert := ca.Options.ServerSSLCert
+	if ca.Options.ServerSSLCertFile != "" {
+		if serverSSLCert != "" {
+			return "", errors.New("cannot use both --server-ssl-cert and --server-ssl-cert-file")
+		}
+
+		contents, err := os.ReadFile(ca.Options.ServerSSLCertFile)
+		if err != nil {
+			return "", fmt.Errorf("could not read --server-ssl-cert-file: %s", err)
+		}
+		serverSSLCert = string(contents)
+	}
+
+	if serverSSLCert != "" {
+		err := validatePEMCertificates(serverSSLCert)
+		if err != nil {
+			return "", fmt.Errorf("invalid server ssl cert: %s", err)
+		}
+	}
+
+	return serverSSLCert, nil
+}
+
+func validatePEMCertificates(certificates string) error {
+	rest := []byte(certificates)
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("found a %s, expected only certificates", block.Type)
+		}
+
+		_, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		found = true
+	}
+
+	if !found || strings.TrimSpace(string(rest)) != "" {
+		return errors.New("could not be parsed as PEM encoded certificates")
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package commands_test
 import (
 	"errors"
 	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/onsi/gomega/gbytes"
 
@@ -215,6 +217,44 @@ The "--ldap-max-search-depth" argument must be between 1 and 10.
 		})
 	})
 
+	When("a server ssl cert file is given", func() {
+		It("uploads the certificates in the file", func() {
+			ca := newTestCertificate("ldap-ca", nil, true)
+			certFile := filepath.Join(GinkgoT().TempDir(), "ca.pem")
+			Expect(os.WriteFile(certFile, []byte(ca.pem), 0600)).To(Succeed())
+
+			commandLineArgs = append(commandLineArgs, "--server-ssl-cert-file", certFile)
+			expectedPayload.LDAPSettings.ServerSSLCert = ca.pem
+
+			err := executeCommand(command, commandLineArgs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.SetupArgsForCall(0)).To(Equal(expectedPayload))
+		})
+
+		It("errors out when --server-ssl-cert is given as well", func() {
+			commandLineArgs = append(commandLineArgs, "--server-ssl-cert-file", "ca.pem", "--server-ssl-cert", "some-cert")
+
+			err := executeCommand(command, commandLineArgs)
+			Expect(err).To(MatchError("cannot use both --server-ssl-cert and --server-ssl-cert-file"))
+		})
+	})
+
+	When("the ldap settings are invalid", func() {
+		DescribeTable("errors out before configuring authentication", func(flag, value, message string) {
+			commandLineArgs = append(commandLineArgs, flag, value)
+
+			err := executeCommand(command, commandLineArgs)
+			Expect(err).To(MatchError(message))
+			Expect(service.EnsureAvailabilityCallCount()).To(Equal(0))
+		},
+			Entry("with a server url that is not ldap", "--server-url", "ldap://primary https://secondary", `--server-url "https://secondary" must start with ldap:// or ldaps://`),
+			Entry("with an unknown referral behavior", "--ldap-referrals", "chase", `--ldap-referrals must be one of follow, ignore or throw, got "chase"`),
+			Entry("with a user search filter without the user ID", "--user-search-filter", "cn=admin", "--user-search-filter must contain {0}, which is replaced by the user ID"),
+			Entry("with a group search filter without the user", "--group-search-filter", "member=admin", "--group-search-filter must contain {0}, which is replaced by the user DN, or {1}, which is replaced by the user ID"),
+			Entry("with a server ssl cert that is not a certificate", "--server-ssl-cert", "not-a-cert", "invalid server ssl cert: could not be parsed as PEM encoded certificates"),
+		)
+	})
+
 	When("the skip-create-bosh-admin-client flag is set", func() {
 		BeforeEach(func() {
 			commandLineArgs = append(commandLineArgs, "--skip-create-bosh-admin-client")
@@ -1,11 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/interpolate"
 	"gopkg.in/yaml.v2"
+	"regexp"
 	"sort"
 	"strings"
 )
@@ -16,10 +18,12 @@ type ConfigureOpsman struct {
 	environFunc func() []string
 	Options     struct {
 		ConfigFile string   `long:"config"    short:"c"         description:"path to yml file containing all config fields (see docs/configure-director/README.md for format)" required:"true"`
-		VarsFile   []string `long:"vars-file" short:"l"         description:"load variables from a YAML file"`
-		VarsEnv    []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+		VarsFile   []string `long:"vars-file" short:"l"         description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
+		VarsEnv    []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
 		Vars       []string `long:"var"       short:"v"         description:"load variable from the command line. Format: VAR=VAL"`
 		OpsFile    []string `long:"ops-file"                    description:"YAML operations file"`
+		VarsStore  []string `long:"vars-store"                  description:"resolve variables from a credential store (credhub://, vault://, aws-sm://, aws-ssm:// or file:// URI), as PREFIX=URI to name them PREFIX_name; vars from files, flags and the environment take precedence, then the last store given"`
+		Functions  bool     `long:"functions"                   description:"enable the functions base64, sha256, password, join and split in the config, e.g. ((base64 some_var))"`
 	}
 }
 
@@ -36,21 +40,30 @@ type opsmanConfig struct {
 	Banner *struct {
 		Settings api.BannerSettings `yaml:",inline"`
 	} `yaml:"banner-settings"`
+	UAALoginBanner *struct {
+		Settings api.UAALoginBannerSettings `yaml:",inline"`
+	} `yaml:"uaa-login-banner"`
 	Syslog *struct {
 		Settings api.SyslogSettings `yaml:",inline"`
 	} `yaml:"syslog-settings"`
 	TokenExpirations *struct {
 		Settings api.TokensExpiration `yaml:",inline"`
 	} `yaml:"tokens-expiration"`
-	Field map[string]interface{} `yaml:",inline"`
+	Settings map[string]interface{} `yaml:"opsman-settings"`
+	Field    map[string]interface{} `yaml:",inline"`
 }
 
+var settingsNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
 //counterfeiter:generate -o ./fakes/configure_opsman_service.go --fake-name ConfigureOpsmanService . configureOpsmanService
 type configureOpsmanService interface {
 	UpdateBanner(settings api.BannerSettings) error
 	UpdateSSLCertificate(api.SSLCertificateSettings) error
 	UpdatePivnetToken(settings api.PivnetSettings) error
 	EnableRBAC(rbacSettings api.RBACSettings) error
+	DisableRBAC() error
+	UpdateUAALoginBanner(settings api.UAALoginBannerSettings) error
+	UpdateSettings(name string, settings interface{}) error
 	UpdateSyslogSettings(syslogSettings api.SyslogSettings) error
 	UpdateTokensExpiration(tokenExpirations api.TokensExpiration) error
 }
@@ -94,12 +107,21 @@ func (c ConfigureOpsman) Execute(args []string) error {
 	}
 
 	if config.RBAC != nil {
-		c.logger.Printf("Updating RBAC Settings...\n")
-		err = c.service.EnableRBAC(config.RBAC.Settings)
-		if err != nil {
-			return err
+		if config.RBAC.Settings.Enabled != nil && !*config.RBAC.Settings.Enabled {
+			c.logger.Printf("Disabling RBAC...\n")
+			err = c.service.DisableRBAC()
+			if err != nil {
+				return err
+			}
+			c.logger.Printf("Successfully disabled RBAC.\n")
+		} else {
+			c.logger.Printf("Updating RBAC Settings...\n")
+			err = c.service.EnableRBAC(config.RBAC.Settings)
+			if err != nil {
+				return err
+			}
+			c.logger.Printf("Successfully applied RBAC Settings.\n")
 		}
-		c.logger.Printf("Successfully applied RBAC Settings.\n")
 	}
 
 	if config.Banner != nil {
@@ -111,6 +133,15 @@ func (c ConfigureOpsman) Execute(args []string) error {
 		c.logger.Printf("Successfully applied Banner.\n")
 	}
 
+	if config.UAALoginBanner != nil {
+		c.logger.Printf("Updating UAA login banner...\n")
+		err = c.service.UpdateUAALoginBanner(config.UAALoginBanner.Settings)
+		if err != nil {
+			return err
+		}
+		c.logger.Printf("Successfully applied UAA login banner.\n")
+	}
+
 	if config.Syslog != nil {
 		c.logger.Printf("Updating Syslog...\n")
 		payload := config.Syslog.Settings
@@ -131,6 +162,37 @@ func (c ConfigureOpsman) Execute(args []string) error {
 		c.logger.Printf("Successfully updated tokens expiration.\n")
 	}
 
+	err = c.updateSettings(config)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateSettings sends each opsman-settings entry as is to its settings page,
+// for the settings that have no key of their own.
+func (c ConfigureOpsman) updateSettings(config *opsmanConfig) error {
+	names := make([]string, 0, len(config.Settings))
+	for name := range config.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		payload, err := getJSONProperties(config.Settings[name])
+		if err != nil {
+			return fmt.Errorf("could not convert opsman-settings.%s to JSON: %s", name, err)
+		}
+
+		c.logger.Printf("Updating %s settings...\n", name)
+		err = c.service.UpdateSettings(name, json.RawMessage(payload))
+		if err != nil {
+			return err
+		}
+		c.logger.Printf("Successfully applied %s settings.\n", name)
+	}
+
 	return nil
 }
 
@@ -145,6 +207,8 @@ func (c ConfigureOpsman) interpolateConfig() (*opsmanConfig, error) {
 		EnvironFunc:   c.environFunc,
 		Vars:          c.Options.Vars,
 		VarsEnvs:      c.Options.VarsEnv,
+		VarsStores:    c.Options.VarsStore,
+		Functions:     c.Options.Functions,
 		OpsFiles:      c.Options.OpsFile,
 		ExpectAllKeys: true,
 	})
@@ -182,5 +246,11 @@ func (c ConfigureOpsman) validate(config *opsmanConfig) error {
 			return errors.New("can only set SAML or LDAP. Check the config file and use only the appropriate values.\nFor example config values, see the docs directory for documentation.")
 		}
 	}
+
+	for name := range config.Settings {
+		if !settingsNamePattern.MatchString(name) {
+			return fmt.Errorf("opsman-settings key %q is not a settings page name (e.g. pivotal_network_settings)", name)
+		}
+	}
 	return nil
 }
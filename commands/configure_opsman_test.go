@@ -206,6 +206,83 @@ tokens-expiration:
 			Expect(fakeService.UpdatePivnetTokenCallCount()).To(Equal(0))
 		})
 
+		It("disables rbac when enabled is false", func() {
+			configFileName := writeTestConfigFile(`
+rbac-settings:
+  enabled: false
+`)
+
+			err := executeCommand(command, []string{
+				"--config", configFileName,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.DisableRBACCallCount()).To(Equal(1))
+			Expect(fakeService.EnableRBACCallCount()).To(Equal(0))
+		})
+
+		It("updates the UAA login banner when given the proper keys", func() {
+			configFileName := writeTestConfigFile(`
+uaa-login-banner:
+  text: authorized use only
+  link: https://example.com/policy
+  text_color: "#ffffff"
+  background_color: "#000000"
+`)
+
+			err := executeCommand(command, []string{
+				"--config", configFileName,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.UpdateUAALoginBannerCallCount()).To(Equal(1))
+			Expect(fakeService.UpdateUAALoginBannerArgsForCall(0)).To(Equal(api.UAALoginBannerSettings{
+				Text:            "authorized use only",
+				Link:            "https://example.com/policy",
+				TextColor:       "#ffffff",
+				BackgroundColor: "#000000",
+			}))
+			Expect(fakeService.UpdateBannerCallCount()).To(Equal(0))
+		})
+
+		It("sends each opsman-settings entry to its settings page", func() {
+			configFileName := writeTestConfigFile(`
+opsman-settings:
+  ui:
+    ui_settings:
+      show_login_hints: false
+  advanced:
+    advanced_settings:
+      enabled: true
+`)
+
+			err := executeCommand(command, []string{
+				"--config", configFileName,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.UpdateSettingsCallCount()).To(Equal(2))
+			name, settings := fakeService.UpdateSettingsArgsForCall(0)
+			Expect(name).To(Equal("advanced"))
+			Expect(settings).To(MatchJSON(`{"advanced_settings": {"enabled": true}}`))
+			name, settings = fakeService.UpdateSettingsArgsForCall(1)
+			Expect(name).To(Equal("ui"))
+			Expect(settings).To(MatchJSON(`{"ui_settings": {"show_login_hints": false}}`))
+		})
+
+		It("returns an error when an opsman-settings key is not a settings page name", func() {
+			configFileName := writeTestConfigFile(`
+opsman-settings:
+  ../rbac: {}
+`)
+
+			err := executeCommand(command, []string{
+				"--config", configFileName,
+			})
+			Expect(err).To(MatchError(`opsman-settings key "../rbac" is not a settings page name (e.g. pivotal_network_settings)`))
+			Expect(fakeService.UpdateSettingsCallCount()).To(Equal(0))
+		})
+
 		It("returns an error if both ldap and saml keys provided", func() {
 			rbacConfig := `
 rbac-settings:
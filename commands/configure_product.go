@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pivotal-cf/om/interpolate"
@@ -21,22 +23,30 @@ type ConfigureProduct struct {
 	service     configureProductService
 	logger      logger
 	target      string
+	properties  map[string]api.ResponseProperty
 	Options     struct {
 		ConfigFile string   `long:"config"    short:"c"         description:"path to yml file containing all config fields (see docs/configure-product/README.md for format)" required:"true"`
-		VarsFile   []string `long:"vars-file" short:"l"         description:"load variables from a YAML file"`
+		VarsFile   []string `long:"vars-file" short:"l"         description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
 		Vars       []string `long:"var"       short:"v"         description:"load variable from the command line. Format: VAR=VAL"`
-		VarsEnv    []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+		VarsEnv    []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
 		OpsFile    []string `long:"ops-file"  short:"o"         description:"YAML operations file"`
+		VarsStore  []string `long:"vars-store"                  description:"resolve variables from a credential store (credhub://, vault://, aws-sm://, aws-ssm:// or file:// URI), as PREFIX=URI to name them PREFIX_name; vars from files, flags and the environment take precedence, then the last store given"`
+		Functions  bool     `long:"functions"                   description:"enable the functions base64, sha256, password, join and split in the config, e.g. ((base64 some_var))"`
+		DryRun     bool     `long:"dry-run"                     description:"interpolate and validate the config, print the payloads that would be sent to Ops Manager (with credentials redacted), and make no changes"`
 	}
 }
 
 //counterfeiter:generate -o ./fakes/configure_product_service.go --fake-name ConfigureProductService . configureProductService
 type configureProductService interface {
 	ConfigureJobResourceConfig(productGUID string, config map[string]interface{}) error
+	GetStagedProductProperties(product string, redact bool) (map[string]api.ResponseProperty, error)
 	ListInstallations() ([]api.InstallationsServiceOutput, error)
 	ListStagedPendingChanges() (api.PendingChangesOutput, error)
+	GetStagedDirectorNetworks() (api.NetworksConfigurationOutput, error)
+	GetStagedProductJobResourceConfig(productGUID, jobGUID string) (api.JobProperties, error)
 	ListStagedProductJobs(productGUID string) (map[string]string, error)
 	ListStagedProducts() (api.StagedProductsOutput, error)
+	ListStagedVMExtensions() ([]api.VMExtension, error)
 	UpdateStagedProductErrands(productID, errandName string, postDeployState, preDeleteState interface{}) error
 	UpdateStagedProductNetworksAndAZs(api.UpdateStagedProductNetworksAndAZsInput) error
 	UpdateStagedProductProperties(api.UpdateStagedProductPropertiesInput) error
@@ -47,6 +57,8 @@ type configureProductService interface {
 type configureProduct struct {
 	config.ProductConfiguration `yaml:",inline"`
 	ValidateConfigComplete      bool                   `yaml:"validate-config-complete"`
+	ValidateProperties          bool                   `yaml:"validate-properties"`
+	ValidateResourceConfig      bool                   `yaml:"validate-resource-config"`
 	Field                       map[string]interface{} `yaml:",inline"`
 }
 
@@ -59,7 +71,7 @@ func NewConfigureProduct(environFunc func() []string, service configureProductSe
 	}
 }
 
-func (cp ConfigureProduct) Execute(args []string) error {
+func (cp *ConfigureProduct) Execute(args []string) error {
 	err := checkRunningInstallation(cp.service.ListInstallations)
 	if err != nil {
 		return err
@@ -84,6 +96,35 @@ func (cp ConfigureProduct) Execute(args []string) error {
 		return err
 	}
 
+	mergesCollections := usesMergeStrategy(cfg.ProductProperties)
+	if cp.Options.DryRun || (cfg.ValidateProperties && cfg.ProductProperties != nil) || mergesCollections {
+		cp.properties, err = cp.service.GetStagedProductProperties(productGUID, true)
+		if err != nil {
+			return fmt.Errorf("failed to fetch product properties: %s", err)
+		}
+	}
+
+	if mergesCollections {
+		err = cp.mergeCollections(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.ValidateProperties {
+		err = cp.validateProperties(cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.ValidateResourceConfig && cfg.ResourceConfigProperties != nil {
+		err = cp.validateResourceConfig(cfg, productGUID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = cp.configureNetwork(cfg, productGUID)
 	if err != nil {
 		return err
@@ -114,6 +155,11 @@ func (cp ConfigureProduct) Execute(args []string) error {
 		return err
 	}
 
+	if cp.Options.DryRun {
+		cp.logger.Printf("dry run complete, no changes were made")
+		return nil
+	}
+
 	if cfg.ValidateConfigComplete {
 		if err := cp.validateConfigComplete(productGUID); err != nil {
 			return err
@@ -156,6 +202,10 @@ func (cp *ConfigureProduct) configureResourceConfiguration(cfg configureProduct,
 		return fmt.Errorf("could not decode product-resource json: %s", err)
 	}
 
+	if cp.Options.DryRun {
+		return cp.printDryRun("configure resources", userProvidedConfig)
+	}
+
 	cp.logger.Printf("applying resource configurations...")
 
 	err = cp.service.ConfigureJobResourceConfig(productGUID, userProvidedConfig)
@@ -190,6 +240,10 @@ func (cp *ConfigureProduct) configureMaxInFlight(cfg configureProduct, productGU
 		}
 	}
 
+	if cp.Options.DryRun {
+		return cp.printDryRun("set max in flight", jobsToMaxInFlight)
+	}
+
 	return cp.service.UpdateStagedProductJobMaxInFlight(productGUID, jobsToMaxInFlight)
 }
 
@@ -222,6 +276,10 @@ func (cp *ConfigureProduct) configureProperties(cfg configureProduct, productGUI
 		return err
 	}
 
+	if cp.Options.DryRun {
+		return cp.printDryRun("set properties", cp.redactProperties(productPropertiesJSON))
+	}
+
 	cp.logger.Printf("setting properties")
 	err = cp.service.UpdateStagedProductProperties(api.UpdateStagedProductPropertiesInput{
 		GUID:       productGUID,
@@ -246,6 +304,10 @@ func (cp *ConfigureProduct) configureNetwork(cfg configureProduct, productGUID s
 		return err
 	}
 
+	if cp.Options.DryRun {
+		return cp.printDryRun("set networks and AZs", json.RawMessage(networkProperties))
+	}
+
 	cp.logger.Printf("setting up network")
 	err = cp.service.UpdateStagedProductNetworksAndAZs(api.UpdateStagedProductNetworksAndAZsInput{
 		GUID:           productGUID,
@@ -271,6 +333,10 @@ func (cp *ConfigureProduct) configureSyslog(cfg configureProduct, productGUID st
 		return err
 	}
 
+	if cp.Options.DryRun {
+		return cp.printDryRun("set syslog configuration", json.RawMessage(syslogProperties))
+	}
+
 	cp.logger.Printf("setting up syslog")
 	err = cp.service.UpdateSyslogConfiguration(api.UpdateSyslogConfigurationInput{
 		GUID:                productGUID,
@@ -298,6 +364,18 @@ func (cp *ConfigureProduct) configureErrands(cfg configureProduct, productGUID s
 
 	sort.Strings(names)
 
+	if cp.Options.DryRun {
+		errands := map[string]interface{}{}
+		for _, name := range names {
+			errands[name] = map[string]interface{}{
+				"post_deploy": cfg.ErrandConfigs[name].PostDeployState,
+				"pre_delete":  cfg.ErrandConfigs[name].PreDeleteState,
+			}
+		}
+
+		return cp.printDryRun("set errand states", errands)
+	}
+
 	cp.logger.Printf("applying errand configuration for the following errands:")
 	for _, name := range names {
 		cp.logger.Printf("\t%s", name)
@@ -316,6 +394,8 @@ func (cp *ConfigureProduct) interpolateConfig(cfg configureProduct) (configurePr
 	configContents, err := interpolate.Execute(interpolate.Options{
 		TemplateFile:  cp.Options.ConfigFile,
 		VarsFiles:     cp.Options.VarsFile,
+		VarsStores:    cp.Options.VarsStore,
+		Functions:     cp.Options.Functions,
 		Vars:          cp.Options.Vars,
 		EnvironFunc:   cp.environFunc,
 		VarsEnvs:      cp.Options.VarsEnv,
@@ -397,3 +477,562 @@ func (cp ConfigureProduct) validateConfigComplete(productGUID string) error {
 	}
 	return nil
 }
+
+func (cp *ConfigureProduct) printDryRun(action string, payload interface{}) error {
+	contents, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not render %s payload: %s", action, err)
+	}
+
+	cp.logger.Printf("dry run: would %s with:\n%s", action, contents)
+
+	return nil
+}
+
+const redactedValue = "***"
+
+// redactProperties replaces the values of credential properties, including
+// credential fields within collections, so dry runs never print secrets.
+func (cp *ConfigureProduct) redactProperties(propertiesJSON string) interface{} {
+	var properties map[string]interface{}
+	err := json.Unmarshal([]byte(propertiesJSON), &properties)
+	if err != nil {
+		return json.RawMessage(propertiesJSON)
+	}
+
+	for name, property := range properties {
+		staged, ok := cp.properties[name]
+		if !ok {
+			continue
+		}
+
+		propertyMap, ok := property.(map[string]interface{})
+		if !ok || propertyMap["value"] == nil {
+			continue
+		}
+
+		if staged.IsCredential {
+			propertyMap["value"] = redactedValue
+			continue
+		}
+
+		entries, ok := propertyMap["value"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		fieldTypes := collectionFieldTypes(staged.Value)
+		for _, entry := range entries {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			for field, value := range fields {
+				if isCredentialField(fieldTypes, field, value) {
+					fields[field] = redactedValue
+				}
+			}
+		}
+	}
+
+	return properties
+}
+
+// credentialPropertyTypes are the types of the property blueprints whose
+// values are credentials. Their values are always maps, e.g. {secret: ...}.
+var credentialPropertyTypes = []string{
+	"secret",
+	"simple_credentials",
+	"rsa_cert_credentials",
+	"rsa_pkey_credentials",
+	"salted_credentials",
+}
+
+// isCredentialField reports whether a field of a collection entry holds a
+// credential. The types of the fields come from the blueprints of the staged
+// entries; a field that no staged entry has, e.g. in the first entry of a
+// collection, is a credential when its value has the shape of one.
+func isCredentialField(fieldTypes map[string]string, name string, value interface{}) bool {
+	fieldType, known := fieldTypes[name]
+	if !known {
+		_, structured := value.(map[string]interface{})
+		return structured
+	}
+
+	return slices.Contains(credentialPropertyTypes, fieldType)
+}
+
+// collectionFieldTypes are the blueprint types of the fields of the staged
+// entries of a collection.
+func collectionFieldTypes(value interface{}) map[string]string {
+	fields := map[string]string{}
+
+	entries, _ := value.([]interface{})
+	for _, entry := range entries {
+		entryMap, _ := entry.(map[interface{}]interface{})
+		for name, field := range entryMap {
+			fieldMap, _ := field.(map[interface{}]interface{})
+			fieldType, _ := fieldMap["type"].(string)
+			if isCredential, _ := fieldMap["credential"].(bool); isCredential && !slices.Contains(credentialPropertyTypes, fieldType) {
+				fieldType = "secret"
+			}
+			fields[fmt.Sprint(name)] = fieldType
+		}
+	}
+
+	return fields
+}
+
+const (
+	mergeStrategyReplace = "replace"
+	mergeStrategyAppend  = "append"
+	mergeStrategyByKey   = "merge-by-key"
+)
+
+func usesMergeStrategy(properties map[string]interface{}) bool {
+	for _, property := range properties {
+		propertyMap, _ := property.(map[interface{}]interface{})
+		if _, ok := propertyMap["merge-strategy"]; ok {
+			return true
+		}
+		if _, ok := propertyMap["merge-key"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeCollections replaces the value of collections with a merge-strategy
+// by their merge with the staged entries. Staged entries are sent back with
+// their guid, so they keep their identity, and their credentials, and their
+// order. The merge-strategy and merge-key are removed from the properties.
+func (cp *ConfigureProduct) mergeCollections(cfg configureProduct) error {
+	var names []string
+	for name := range cfg.ProductProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyMap, ok := cfg.ProductProperties[name].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		strategy, hasStrategy := propertyMap["merge-strategy"]
+		key, hasKey := propertyMap["merge-key"]
+		delete(propertyMap, "merge-strategy")
+		delete(propertyMap, "merge-key")
+
+		if !hasStrategy && !hasKey {
+			continue
+		}
+
+		strategyName := fmt.Sprint(strategy)
+		if !hasStrategy {
+			strategyName = mergeStrategyReplace
+		}
+
+		keyName := "name"
+		if hasKey {
+			if strategyName != mergeStrategyByKey {
+				return fmt.Errorf("could not merge %s: merge-key can only be used with the merge-strategy %s", name, mergeStrategyByKey)
+			}
+			keyName = fmt.Sprint(key)
+		}
+
+		if strategyName == mergeStrategyReplace {
+			continue
+		}
+		if strategyName != mergeStrategyAppend && strategyName != mergeStrategyByKey {
+			return fmt.Errorf("could not merge %s: unknown merge-strategy %q, use one of %s, %s or %s", name, strategyName, mergeStrategyByKey, mergeStrategyAppend, mergeStrategyReplace)
+		}
+
+		staged, ok := cp.properties[name]
+		if !ok || staged.Type != "collection" {
+			return fmt.Errorf("could not merge %s: it is not a collection property of %s", name, cfg.ProductName)
+		}
+
+		entries, ok := propertyMap["value"].([]interface{})
+		if !ok && propertyMap["value"] != nil {
+			return fmt.Errorf("could not merge %s: its value must be a list of entries", name)
+		}
+
+		var existing []map[interface{}]interface{}
+		stagedEntries, _ := staged.Value.([]interface{})
+		for _, entry := range stagedEntries {
+			existing = append(existing, stagedCollectionEntry(entry))
+		}
+
+		var merged []interface{}
+		if strategyName == mergeStrategyAppend {
+			for _, entry := range existing {
+				merged = append(merged, entry)
+			}
+			merged = append(merged, entries...)
+		} else {
+			var err error
+			merged, err = mergeCollectionByKey(existing, entries, keyName)
+			if err != nil {
+				return fmt.Errorf("could not merge %s: %s", name, err)
+			}
+		}
+
+		propertyMap["value"] = merged
+	}
+
+	return nil
+}
+
+// stagedCollectionEntry returns the guid and the configurable fields of a
+// staged entry, leaving out the credentials, which Ops Manager keeps for
+// entries with a guid.
+func stagedCollectionEntry(entry interface{}) map[interface{}]interface{} {
+	fields := map[interface{}]interface{}{}
+
+	entryMap, _ := entry.(map[interface{}]interface{})
+	for name, field := range entryMap {
+		fieldMap, _ := field.(map[interface{}]interface{})
+		configurable, _ := fieldMap["configurable"].(bool)
+		credential, _ := fieldMap["credential"].(bool)
+
+		if fmt.Sprint(name) == "guid" || (configurable && !credential) {
+			fields[name] = fieldMap["value"]
+		}
+	}
+
+	return fields
+}
+
+// mergeCollectionByKey updates the staged entries with the entries of the
+// config with the same value for the key, in place, and appends the others.
+func mergeCollectionByKey(existing []map[interface{}]interface{}, entries []interface{}, key string) ([]interface{}, error) {
+	updates := map[string]map[interface{}]interface{}{}
+	var added []interface{}
+
+	for index, entry := range entries {
+		entryMap, ok := entry.(map[interface{}]interface{})
+		if !ok || entryMap[key] == nil {
+			return nil, fmt.Errorf("entry %d has no %s to merge it by", index, key)
+		}
+
+		value := fmt.Sprint(entryMap[key])
+		if _, ok := updates[value]; ok {
+			return nil, fmt.Errorf("more than one entry has the %s %q", key, value)
+		}
+		updates[value] = entryMap
+		added = append(added, entryMap)
+	}
+
+	var merged []interface{}
+	for _, entry := range existing {
+		if entry[key] == nil {
+			merged = append(merged, entry)
+			continue
+		}
+
+		value := fmt.Sprint(entry[key])
+		update, ok := updates[value]
+		if !ok {
+			merged = append(merged, entry)
+			continue
+		}
+
+		for field, fieldValue := range update {
+			entry[field] = fieldValue
+		}
+		merged = append(merged, entry)
+		delete(updates, value)
+	}
+
+	for _, entry := range added {
+		if _, ok := updates[fmt.Sprint(entry.(map[interface{}]interface{})[key])]; ok {
+			merged = append(merged, entry)
+		}
+	}
+
+	return merged, nil
+}
+
+// validateProperties checks the product-properties against the staged
+// product's property blueprints so mistakes are caught before any PUT.
+// It is enabled with `validate-properties: true` in the config file.
+func (cp *ConfigureProduct) validateProperties(cfg configureProduct) error {
+	var problems []string
+
+	for name, property := range cfg.ProductProperties {
+		staged, ok := cp.properties[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a property of %s", name, cfg.ProductName))
+			continue
+		}
+
+		if propertyMap, ok := property.(map[interface{}]interface{}); ok {
+			if value, ok := propertyMap["value"]; ok && value != nil && !matchesPropertyType(staged.Type, value) {
+				problems = append(problems, fmt.Sprintf("%s must be of type %s, got %T", name, staged.Type, value))
+			}
+		}
+
+		problems = append(problems, cp.validateSelectors(cfg, name)...)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return fmt.Errorf("the product-properties in %s are not valid for %s:\n- %s", cp.Options.ConfigFile, cfg.ProductName, strings.Join(problems, "\n- "))
+}
+
+// validateResourceConfig checks the resource-config against the jobs of the
+// staged product, and that the VM extensions and additional networks are
+// defined in Ops Manager. Ops Manager leaves settings out of the resource
+// config of jobs that support them, so whether a job supports a setting is
+// left to Ops Manager to report when it is configured. It is enabled with
+// `validate-resource-config: true` in the config file.
+func (cp *ConfigureProduct) validateResourceConfig(cfg configureProduct, productGUID string) error {
+	jobs, err := cp.service.ListStagedProductJobs(productGUID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jobs: %s", err)
+	}
+
+	var (
+		problems     []string
+		vmExtensions map[string]bool
+		networks     map[string]bool
+	)
+
+	var names []string
+	for name := range cfg.ResourceConfigProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resourceConfig := cfg.ResourceConfigProperties[name]
+		_, ok := jobs[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not a job of %s", name, cfg.ProductName))
+			continue
+		}
+
+		if resourceConfig.MaxInFlight != nil && !validMaxInFlight(resourceConfig.MaxInFlight) {
+			problems = append(problems, fmt.Sprintf("%s: max_in_flight must be a number, a percentage or \"default\", got %v", name, resourceConfig.MaxInFlight))
+		}
+
+		if swap, ok := resourceConfig.JobProperties["swap_as_percent_of_memory_size"]; ok && !validSwapPercentage(swap) {
+			problems = append(problems, fmt.Sprintf("%s: swap_as_percent_of_memory_size must be a number from 0 to 100 or \"automatic\", got %v", name, swap))
+		}
+
+		if extensions, ok := resourceConfig.JobProperties["additional_vm_extensions"]; ok {
+			if vmExtensions == nil {
+				vmExtensions, err = cp.vmExtensionNames()
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, extension := range listOf(extensions) {
+				if !vmExtensions[fmt.Sprint(extension)] {
+					problems = append(problems, fmt.Sprintf("%s: the VM extension %v is not defined in Ops Manager", name, extension))
+				}
+			}
+		}
+
+		if additionalNetworks, ok := resourceConfig.JobProperties["additional_networks"]; ok {
+			if networks == nil {
+				networks, err = cp.networkNames()
+				if err != nil {
+					return err
+				}
+			}
+
+			for _, network := range listOf(additionalNetworks) {
+				networkMap, _ := network.(map[interface{}]interface{})
+				if networkName, ok := networkMap["name"]; ok && !networks[fmt.Sprint(networkName)] {
+					problems = append(problems, fmt.Sprintf("%s: the network %v is not defined in Ops Manager", name, networkName))
+				}
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return fmt.Errorf("the resource-config in %s is not valid for %s:\n- %s", cp.Options.ConfigFile, cfg.ProductName, strings.Join(problems, "\n- "))
+}
+
+func (cp *ConfigureProduct) vmExtensionNames() (map[string]bool, error) {
+	extensions, err := cp.service.ListStagedVMExtensions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch vm extensions: %s", err)
+	}
+
+	names := map[string]bool{}
+	for _, extension := range extensions {
+		names[extension.Name] = true
+	}
+
+	return names, nil
+}
+
+func (cp *ConfigureProduct) networkNames() (map[string]bool, error) {
+	networks, err := cp.service.GetStagedDirectorNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch networks: %s", err)
+	}
+
+	names := map[string]bool{}
+	for _, network := range networks.Networks {
+		names[network.Name] = true
+	}
+
+	return names, nil
+}
+
+func listOf(value interface{}) []interface{} {
+	list, _ := value.([]interface{})
+	return list
+}
+
+func validMaxInFlight(value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		return v > 0
+	case string:
+		if v == "default" {
+			return true
+		}
+		if strings.HasSuffix(v, "%") {
+			percentage, err := strconv.Atoi(strings.TrimSuffix(v, "%"))
+			return err == nil && percentage > 0 && percentage <= 100
+		}
+		count, err := strconv.Atoi(v)
+		return err == nil && count > 0
+	}
+
+	return false
+}
+
+func validSwapPercentage(value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		return v >= 0 && v <= 100
+	case string:
+		return v == "automatic"
+	}
+
+	return false
+}
+
+// validateSelectors ensures that a property nested under a selector option
+// (e.g. .properties.selector.option.property) is only set when that option is
+// selected, either in the config file or on the staged product.
+func (cp *ConfigureProduct) validateSelectors(cfg configureProduct, name string) []string {
+	var problems []string
+
+	components := strings.Split(name, ".")[1:] // the 0th item is an empty string due to `.some.other`
+	for i := 2; i < len(components); i++ {
+		selectorName := "." + strings.Join(components[:i], ".")
+		selector, ok := cp.properties[selectorName]
+		if !ok || selector.Type != "selector" {
+			continue
+		}
+
+		option := components[i]
+		selected, set := cp.selectedOption(cfg.ProductProperties[selectorName], selectorName, selector)
+		if !set {
+			problems = append(problems, fmt.Sprintf("%s requires the selector %s to be set", name, selectorName))
+			continue
+		}
+
+		if selected != "" && selected != option {
+			problems = append(problems, fmt.Sprintf("%s is only used when %s selects %q, but it selects %q", name, selectorName, option, selected))
+		}
+	}
+
+	return problems
+}
+
+// selectedOption is the name of the option a selector selects. The config
+// file can select an option by its name or by its select_value, but only the
+// select_value of the staged option is known, so the name is empty when the
+// select_value of another option is given. set is false when neither the
+// config file nor the staged product selects an option.
+func (cp *ConfigureProduct) selectedOption(configured interface{}, selectorName string, staged api.ResponseProperty) (string, bool) {
+	stagedValue, _ := staged.Value.(string)
+
+	resolve := func(candidate string) string {
+		if candidate == stagedValue && staged.SelectedOption != "" {
+			return staged.SelectedOption
+		}
+
+		for property := range cp.properties {
+			option, _, found := strings.Cut(strings.TrimPrefix(property, selectorName+"."), ".")
+			if found && strings.HasPrefix(property, selectorName+".") && option == candidate {
+				return candidate
+			}
+		}
+
+		return ""
+	}
+
+	if configuredMap, ok := configured.(map[interface{}]interface{}); ok {
+		set := false
+		for _, key := range []string{"selected_option", "option_value", "value"} {
+			value, ok := configuredMap[key].(string)
+			if !ok || value == "" {
+				continue
+			}
+			set = true
+
+			if option := resolve(value); option != "" {
+				return option, true
+			}
+		}
+
+		if set {
+			return "", true
+		}
+	}
+
+	if staged.SelectedOption != "" {
+		return staged.SelectedOption, true
+	}
+	if stagedValue != "" {
+		return resolve(stagedValue), true
+	}
+
+	return "", false
+}
+
+func matchesPropertyType(propertyType string, value interface{}) bool {
+	switch propertyType {
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer", "port":
+		switch v := value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		case string:
+			_, err := strconv.Atoi(v)
+			return err == nil
+		}
+		return false
+	case "collection":
+		_, ok := value.([]interface{})
+		return ok
+	}
+
+	if slices.Contains(credentialPropertyTypes, propertyType) {
+		_, ok := value.(map[interface{}]interface{})
+		return ok
+	}
+
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
@@ -320,6 +321,501 @@ var _ = Describe("ConfigureProduct", func() {
 			})
 		})
 
+		When("--dry-run is provided", func() {
+			BeforeEach(func() {
+				config = `
+product-name: cf
+product-properties:
+  .properties.some-string-property:
+    value: some-value
+  .properties.some-secret-property:
+    value:
+      secret: hunter2
+  .properties.some-collection:
+    value:
+    - name: first
+      password:
+        secret: collection-secret
+network-properties:
+  singleton_availability_zone:
+    name: az-one
+resource-config:
+  some-job:
+    instances: 2
+    max_in_flight: 1
+errand-config:
+  smoke-tests:
+    post-deploy-state: true
+`
+
+				service.ListStagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{{GUID: "some-product-guid", Type: "cf"}},
+				}, nil)
+				service.ListStagedProductJobsReturns(map[string]string{"some-job": "some-job-guid"}, nil)
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.some-string-property": {Value: "old-value"},
+					".properties.some-secret-property": {Value: map[string]interface{}{"secret": "***"}, IsCredential: true},
+					".properties.some-collection": {
+						Type: "collection",
+						Value: []interface{}{
+							map[interface{}]interface{}{
+								"name":     map[interface{}]interface{}{"value": "first", "credential": false},
+								"password": map[interface{}]interface{}{"value": map[interface{}]interface{}{"secret": "***"}, "credential": true},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("prints the redacted payloads and makes no changes", func() {
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+				err := executeCommand(client, []string{
+					"--config", configFile.Name(),
+					"--dry-run",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				guid, redact := service.GetStagedProductPropertiesArgsForCall(0)
+				Expect(guid).To(Equal("some-product-guid"))
+				Expect(redact).To(BeTrue())
+
+				Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(0))
+				Expect(service.UpdateStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+				Expect(service.ConfigureJobResourceConfigCallCount()).To(Equal(0))
+				Expect(service.UpdateStagedProductJobMaxInFlightCallCount()).To(Equal(0))
+				Expect(service.UpdateStagedProductErrandsCallCount()).To(Equal(0))
+				Expect(service.ListStagedPendingChangesCallCount()).To(Equal(0))
+
+				var output []string
+				for i := 0; i < logger.PrintfCallCount(); i++ {
+					format, content := logger.PrintfArgsForCall(i)
+					output = append(output, fmt.Sprintf(format, content...))
+				}
+
+				Expect(output).To(ContainElement(HavePrefix("dry run: would set networks and AZs with:")))
+				Expect(output).To(ContainElement(ContainSubstring(`"instances": 2`)))
+				Expect(output).To(ContainElement(ContainSubstring(`"some-job-guid": 1`)))
+				Expect(output).To(ContainElement(ContainSubstring(`"smoke-tests"`)))
+				Expect(output[len(output)-1]).To(Equal("dry run complete, no changes were made"))
+
+				var properties string
+				for _, line := range output {
+					if strings.HasPrefix(line, "dry run: would set properties") {
+						properties = line
+					}
+				}
+				Expect(properties).To(ContainSubstring("some-value"))
+				Expect(properties).ToNot(ContainSubstring("hunter2"))
+				Expect(properties).ToNot(ContainSubstring("collection-secret"))
+				Expect(properties).To(ContainSubstring(`"first"`))
+				Expect(properties).To(ContainSubstring(`"***"`))
+			})
+
+			It("redacts the credentials of the entries a collection does not have yet", func() {
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.some-string-property": {Value: "old-value"},
+					".properties.some-secret-property": {Value: map[string]interface{}{"secret": "***"}, IsCredential: true},
+					".properties.some-collection":      {Type: "collection", Value: []interface{}{}},
+				}, nil)
+
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+				err := executeCommand(client, []string{
+					"--config", configFile.Name(),
+					"--dry-run",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				var properties string
+				for i := 0; i < logger.PrintfCallCount(); i++ {
+					format, content := logger.PrintfArgsForCall(i)
+					if line := fmt.Sprintf(format, content...); strings.HasPrefix(line, "dry run: would set properties") {
+						properties = line
+					}
+				}
+				Expect(properties).ToNot(ContainSubstring("collection-secret"))
+				Expect(properties).To(ContainSubstring(`"first"`))
+			})
+
+			It("returns an error when the product properties cannot be fetched", func() {
+				service.GetStagedProductPropertiesReturns(nil, errors.New("some error"))
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+				err := executeCommand(client, []string{
+					"--config", configFile.Name(),
+					"--dry-run",
+				})
+				Expect(err).To(MatchError("failed to fetch product properties: some error"))
+			})
+		})
+
+		When("validating product properties against the staged product", func() {
+			BeforeEach(func() {
+				service.ListStagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{{GUID: "some-product-guid", Type: "cf"}},
+				}, nil)
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.enabled":                  {Type: "boolean"},
+					".properties.port":                     {Type: "port"},
+					".properties.auth":                     {Type: "selector", SelectedOption: "internal", Value: "Internal"},
+					".properties.auth.internal.password":   {Type: "secret", IsCredential: true},
+					".properties.auth.ldap.url":            {Type: "ldap_url"},
+					".properties.tls":                      {Type: "selector"},
+					".properties.tls.enabled.ciphers":      {Type: "string"},
+					".properties.unselected":               {Type: "selector", SelectedOption: "a"},
+					".properties.unselected.b.some-option": {Type: "string"},
+				}, nil)
+			})
+
+			When("the properties do not match the staged product", func() {
+				BeforeEach(func() {
+					config = `
+product-name: cf
+validate-properties: true
+product-properties:
+  .properties.enabled:
+    value: "yes"
+  .properties.port:
+    value: 443
+  .properties.typo:
+    value: something
+  .properties.auth.ldap.url:
+    value: ldap://example.com
+  .properties.tls.enabled.ciphers:
+    value: ECDHE
+`
+				})
+
+				It("reports unknown keys, wrong types and unselected options before making changes", func() {
+					client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+					err := executeCommand(client, []string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(fmt.Sprintf(`the product-properties in %s are not valid for cf:
+- .properties.auth.ldap.url is only used when .properties.auth selects "ldap", but it selects "internal"
+- .properties.enabled must be of type boolean, got string
+- .properties.tls.enabled.ciphers requires the selector .properties.tls to be set
+- .properties.typo is not a property of cf`, configFile.Name())))
+
+					Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(0))
+					Expect(service.UpdateStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+				})
+			})
+
+			When("a selector is chosen in the config file", func() {
+				BeforeEach(func() {
+					config = `
+product-name: cf
+validate-properties: true
+product-properties:
+  .properties.auth:
+    value: ldap
+  .properties.auth.ldap.url:
+    value: ldap://example.com
+  .properties.unselected:
+    selected_option: b
+    value: Option B
+  .properties.unselected.b.some-option:
+    value: something
+`
+				})
+
+				It("uses the selector chosen in the config file", func() {
+					client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+					err := executeCommand(client, []string{"--config", configFile.Name()})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(1))
+				})
+			})
+
+			When("a selector is chosen by its select_value", func() {
+				BeforeEach(func() {
+					config = `
+product-name: cf
+validate-properties: true
+product-properties:
+  .properties.auth:
+    value: Internal
+  .properties.auth.internal.password:
+    value: {secret: some-password}
+  .properties.unselected:
+    value: Option B
+  .properties.unselected.b.some-option:
+    value: something
+`
+				})
+
+				It("matches the select_value of the staged option, and does not reject the select_values it does not know", func() {
+					client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+					err := executeCommand(client, []string{"--config", configFile.Name()})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(1))
+				})
+			})
+
+			When("the config file sets other fields of a selector", func() {
+				BeforeEach(func() {
+					config = `
+product-name: cf
+validate-properties: true
+product-properties:
+  .properties.auth:
+    some-field: something
+  .properties.auth.ldap.url:
+    value: ldap://example.com
+`
+				})
+
+				It("uses the staged selection", func() {
+					client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+					err := executeCommand(client, []string{"--config", configFile.Name()})
+					Expect(err).To(MatchError(ContainSubstring(`.properties.auth.ldap.url is only used when .properties.auth selects "ldap", but it selects "internal"`)))
+				})
+			})
+
+			When("validate-properties is not set", func() {
+				BeforeEach(func() {
+					config = `
+product-name: cf
+product-properties:
+  .properties.typo:
+    value: something
+`
+				})
+
+				It("skips validation", func() {
+					client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+
+					err := executeCommand(client, []string{"--config", configFile.Name()})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(service.GetStagedProductPropertiesCallCount()).To(Equal(0))
+					Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		When("validating the resource config against the staged jobs", func() {
+			BeforeEach(func() {
+				service.ListStagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{{GUID: "some-product-guid", Type: "cf"}},
+				}, nil)
+				service.ListStagedProductJobsReturns(map[string]string{"router": "router-guid", "database": "database-guid"}, nil)
+				service.ListStagedVMExtensionsReturns([]api.VMExtension{{Name: "public-ip"}}, nil)
+				service.GetStagedDirectorNetworksReturns(api.NetworksConfigurationOutput{
+					Networks: []api.NetworkConfigurationOutput{{Name: "deployment"}, {Name: "services"}},
+				}, nil)
+			})
+
+			It("configures the resource config when it is valid", func() {
+				config = `
+product-name: cf
+validate-resource-config: true
+resource-config:
+  router:
+    additional_vm_extensions: [public-ip]
+    additional_networks: [{name: services}]
+    swap_as_percent_of_memory_size: 0
+    max_in_flight: 10%
+  database:
+    persistent_disk: {size_mb: "102400"}
+    max_in_flight: default
+`
+				Expect(os.WriteFile(configFile.Name(), []byte(config), 0600)).To(Succeed())
+
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+				err := executeCommand(client, []string{"--config", configFile.Name()})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.ConfigureJobResourceConfigCallCount()).To(Equal(1))
+				Expect(service.ListStagedVMExtensionsCallCount()).To(Equal(1))
+				Expect(service.GetStagedDirectorNetworksCallCount()).To(Equal(1))
+				Expect(service.GetStagedProductJobResourceConfigCallCount()).To(Equal(0))
+			})
+
+			It("reports the problems before making changes", func() {
+				config = `
+product-name: cf
+validate-resource-config: true
+network-properties:
+  singleton_availability_zone: {name: az-one}
+resource-config:
+  router:
+    persistent_disk: {size_mb: "1024"}
+    additional_vm_extensions: [public-ip, spot-instance]
+    additional_networks: [{name: missing}]
+    swap_as_percent_of_memory_size: 150
+    max_in_flight: 0
+  unknown-job:
+    instances: 1
+`
+				Expect(os.WriteFile(configFile.Name(), []byte(config), 0600)).To(Succeed())
+
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+				err := executeCommand(client, []string{"--config", configFile.Name()})
+				Expect(err).To(MatchError(fmt.Sprintf(`the resource-config in %s is not valid for cf:
+- router: max_in_flight must be a number, a percentage or "default", got 0
+- router: swap_as_percent_of_memory_size must be a number from 0 to 100 or "automatic", got 150
+- router: the VM extension spot-instance is not defined in Ops Manager
+- router: the network missing is not defined in Ops Manager
+- unknown-job is not a job of cf`, configFile.Name())))
+
+				Expect(service.UpdateStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+				Expect(service.ConfigureJobResourceConfigCallCount()).To(Equal(0))
+			})
+
+			It("skips validation by default", func() {
+				config = `
+product-name: cf
+resource-config:
+  unknown-job:
+    max_in_flight: 0
+`
+				Expect(os.WriteFile(configFile.Name(), []byte(config), 0600)).To(Succeed())
+
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+				err := executeCommand(client, []string{"--config", configFile.Name()})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(service.ListStagedProductJobsCallCount()).To(Equal(1))
+				Expect(service.ConfigureJobResourceConfigCallCount()).To(Equal(1))
+			})
+		})
+
+		When("a collection has a merge-strategy", func() {
+			stagedEntry := func(guid, name, certificate string) interface{} {
+				return map[interface{}]interface{}{
+					"guid":        map[interface{}]interface{}{"type": "uuid", "configurable": false, "credential": false, "value": guid},
+					"name":        map[interface{}]interface{}{"type": "string", "configurable": true, "credential": false, "value": name},
+					"certificate": map[interface{}]interface{}{"type": "rsa_cert_credentials", "configurable": true, "credential": true, "value": certificate},
+				}
+			}
+
+			configure := func(contents string) error {
+				Expect(os.WriteFile(configFile.Name(), []byte(contents), 0600)).To(Succeed())
+
+				client := commands.NewConfigureProduct(func() []string { return nil }, service, "", logger)
+				return executeCommand(client, []string{"--config", configFile.Name()})
+			}
+
+			setProperties := func() map[string]interface{} {
+				Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(1))
+
+				var properties map[string]interface{}
+				Expect(json.Unmarshal([]byte(service.UpdateStagedProductPropertiesArgsForCall(0).Properties), &properties)).To(Succeed())
+				return properties
+			}
+
+			BeforeEach(func() {
+				service.ListStagedProductsReturns(api.StagedProductsOutput{
+					Products: []api.StagedProduct{{GUID: "some-product-guid", Type: "cf"}},
+				}, nil)
+				service.GetStagedProductPropertiesReturns(map[string]api.ResponseProperty{
+					".properties.certs": {Type: "collection", Value: []interface{}{
+						stagedEntry("guid-a", "a", "***"),
+						stagedEntry("guid-b", "b", "***"),
+					}},
+					".properties.string": {Type: "string"},
+				}, nil)
+			})
+
+			It("updates the entries with the same key in place and appends the others", func() {
+				err := configure(`
+product-name: cf
+validate-properties: false
+product-properties:
+  .properties.certs:
+    merge-strategy: merge-by-key
+    value:
+    - name: c
+      certificate: {cert_pem: c-cert}
+    - name: b
+      certificate: {cert_pem: b-cert}
+`)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(setProperties()).To(Equal(map[string]interface{}{
+					".properties.certs": map[string]interface{}{
+						"value": []interface{}{
+							map[string]interface{}{"guid": "guid-a", "name": "a"},
+							map[string]interface{}{"guid": "guid-b", "name": "b", "certificate": map[string]interface{}{"cert_pem": "b-cert"}},
+							map[string]interface{}{"name": "c", "certificate": map[string]interface{}{"cert_pem": "c-cert"}},
+						},
+					},
+				}))
+			})
+
+			It("merges by another key", func() {
+				err := configure(`
+product-name: cf
+product-properties:
+  .properties.certs:
+    merge-strategy: merge-by-key
+    merge-key: guid
+    value:
+    - guid: guid-a
+      name: renamed
+`)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(setProperties()[".properties.certs"]).To(Equal(map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"guid": "guid-a", "name": "renamed"},
+						map[string]interface{}{"guid": "guid-b", "name": "b"},
+					},
+				}))
+			})
+
+			It("appends the entries after the staged ones", func() {
+				err := configure(`
+product-name: cf
+product-properties:
+  .properties.certs:
+    merge-strategy: append
+    value:
+    - name: a
+`)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(setProperties()[".properties.certs"]).To(Equal(map[string]interface{}{
+					"value": []interface{}{
+						map[string]interface{}{"guid": "guid-a", "name": "a"},
+						map[string]interface{}{"guid": "guid-b", "name": "b"},
+						map[string]interface{}{"name": "a"},
+					},
+				}))
+			})
+
+			It("replaces the collection", func() {
+				err := configure(`
+product-name: cf
+product-properties:
+  .properties.certs:
+    merge-strategy: replace
+    value:
+    - name: z
+`)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(setProperties()[".properties.certs"]).To(Equal(map[string]interface{}{
+					"value": []interface{}{map[string]interface{}{"name": "z"}},
+				}))
+			})
+
+			DescribeTable("rejects invalid merges before making changes", func(properties, message string) {
+				err := configure("product-name: cf\nproduct-properties:\n" + properties)
+				Expect(err).To(MatchError(message))
+				Expect(service.UpdateStagedProductNetworksAndAZsCallCount()).To(Equal(0))
+				Expect(service.UpdateStagedProductPropertiesCallCount()).To(Equal(0))
+			},
+				Entry("unknown strategy", "  .properties.certs: {merge-strategy: upsert, value: []}\n", `could not merge .properties.certs: unknown merge-strategy "upsert", use one of merge-by-key, append or replace`),
+				Entry("not a collection", "  .properties.string: {merge-strategy: append, value: []}\n", "could not merge .properties.string: it is not a collection property of cf"),
+				Entry("key without merge-by-key", "  .properties.certs: {merge-strategy: append, merge-key: guid, value: []}\n", "could not merge .properties.certs: merge-key can only be used with the merge-strategy merge-by-key"),
+				Entry("entry without the key", "  .properties.certs: {merge-strategy: merge-by-key, value: [{certificate: {}}]}\n", "could not merge .properties.certs: entry 0 has no name to merge it by"),
+				Entry("duplicate keys", "  .properties.certs: {merge-strategy: merge-by-key, value: [{name: a}, {name: a}]}\n", `could not merge .properties.certs: more than one entry has the name "a"`),
+			)
+		})
+
 		When("interpolating", func() {
 			var (
 				configFile *os.File
@@ -1,13 +1,16 @@
 package commands
 
 import (
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"github.com/pivotal-cf/om/api"
 )
 
 type ConfigureSAMLAuthentication struct {
-	service     configureAuthenticationService
+	service     configureSAMLAuthenticationService
 	logger      logger
 	environFunc func() []string
 	Options     struct {
@@ -17,16 +20,27 @@ type ConfigureSAMLAuthentication struct {
 		HTTPProxyURL              string `long:"http-proxy-url"                                   description:"proxy for outbound HTTP network traffic"`
 		HTTPSProxyURL             string `long:"https-proxy-url"                                  description:"proxy for outbound HTTPS network traffic"`
 		NoProxy                   string `long:"no-proxy"                                         description:"comma-separated list of hosts that do not go through the proxy"`
-		IDPMetadata               string `long:"saml-idp-metadata"                required:"true" description:"XML, or URL to XML, for the IDP that Ops Manager should use"`
-		BoshIDPMetadata           string `long:"saml-bosh-idp-metadata"           required:"true" description:"XML, or URL to XML, for the IDP that BOSH should use"`
+		IDPMetadata               string `long:"saml-idp-metadata"                                description:"XML, or URL to XML, for the IDP that Ops Manager should use. Required unless the metadata is built from --saml-idp-entity-id, --saml-idp-sso-url and --saml-idp-signing-cert"`
+		BoshIDPMetadata           string `long:"saml-bosh-idp-metadata"                           description:"XML, or URL to XML, for the IDP that BOSH should use. Defaults to the IDP metadata Ops Manager uses"`
+		IDPEntityID               string `long:"saml-idp-entity-id"                               description:"entity ID of the IDP, to build the IDP metadata from"`
+		IDPSSOURL                 string `long:"saml-idp-sso-url"                                 description:"single sign-on URL of the IDP, to build the IDP metadata from"`
+		IDPSLOURL                 string `long:"saml-idp-slo-url"                                 description:"single logout URL of the IDP, to build the IDP metadata from"`
+		IDPSigningCert            string `long:"saml-idp-signing-cert"                            description:"PEM encoded certificate the IDP signs its assertions with, to build the IDP metadata from"`
 		RBACAdminGroup            string `long:"saml-rbac-admin-group"            required:"true" description:"If SAML is specified, please provide the admin group for your SAML"`
 		RBACGroupsAttribute       string `long:"saml-rbac-groups-attribute"       required:"true" description:"If SAML is specified, please provide the groups attribute for your SAML"`
 		SkipCreateBoshAdminClient bool   `long:"skip-create-bosh-admin-client"                    description:"create a UAA client on the Bosh Director, whose credentials can be passed to the BOSH CLI to execute BOSH commands. Default is false."`
 		PrecreatedClientSecret    string `long:"precreated-client-secret"                         description:"create a UAA client on the Ops Manager vm, whose secret will be the value provided to this option"`
+		UpdateRBAC                bool   `long:"update-rbac"                                      description:"when authentication is already configured, update the RBAC admin group and groups attribute instead of skipping. Requires authenticating to Ops Manager"`
 	}
 }
 
-func NewConfigureSAMLAuthentication(environFunc func() []string, service configureAuthenticationService, logger logger) *ConfigureSAMLAuthentication {
+//counterfeiter:generate -o ./fakes/configure_saml_authentication_service.go --fake-name ConfigureSAMLAuthenticationService . configureSAMLAuthenticationService
+type configureSAMLAuthenticationService interface {
+	configureAuthenticationService
+	EnableRBAC(rbacSettings api.RBACSettings) error
+}
+
+func NewConfigureSAMLAuthentication(environFunc func() []string, service configureSAMLAuthenticationService, logger logger) *ConfigureSAMLAuthentication {
 	return &ConfigureSAMLAuthentication{
 		environFunc: environFunc,
 		service:     service,
@@ -40,6 +54,16 @@ func (ca ConfigureSAMLAuthentication) Execute(args []string) error {
 		opsManUaaClientMsg string
 	)
 
+	idpMetadata, err := ca.idpMetadata()
+	if err != nil {
+		return err
+	}
+
+	boshIDPMetadata := ca.Options.BoshIDPMetadata
+	if boshIDPMetadata == "" {
+		boshIDPMetadata = idpMetadata
+	}
+
 	ensureAvailabilityOutput, err := ca.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 	if err != nil {
 		return fmt.Errorf("could not determine initial configuration status: %s", err)
@@ -50,7 +74,21 @@ func (ca ConfigureSAMLAuthentication) Execute(args []string) error {
 	}
 
 	if ensureAvailabilityOutput.Status != api.EnsureAvailabilityStatusUnstarted {
-		ca.logger.Printf("configuration previously completed, skipping configuration")
+		if !ca.Options.UpdateRBAC {
+			ca.logger.Printf("configuration previously completed, skipping configuration")
+			return nil
+		}
+
+		ca.logger.Printf("configuration previously completed, updating RBAC settings...")
+		err = ca.service.EnableRBAC(api.RBACSettings{
+			SAMLAdminGroup:      ca.Options.RBACAdminGroup,
+			SAMLGroupsAttribute: ca.Options.RBACGroupsAttribute,
+		})
+		if err != nil {
+			return fmt.Errorf("could not update RBAC settings: %s", err)
+		}
+
+		ca.logger.Printf("RBAC settings updated")
 		return nil
 	}
 
@@ -64,8 +102,8 @@ func (ca ConfigureSAMLAuthentication) Execute(args []string) error {
 		HTTPSProxyURL:                    ca.Options.HTTPSProxyURL,
 		NoProxy:                          ca.Options.NoProxy,
 		EULAAccepted:                     "true",
-		IDPMetadata:                      ca.Options.IDPMetadata,
-		BoshIDPMetadata:                  ca.Options.BoshIDPMetadata,
+		IDPMetadata:                      idpMetadata,
+		BoshIDPMetadata:                  boshIDPMetadata,
 		RBACAdminGroup:                   ca.Options.RBACAdminGroup,
 		RBACGroupsAttribute:              ca.Options.RBACGroupsAttribute,
 	}
@@ -141,3 +179,81 @@ This is only supported in OpsManager 2.5 and up.
 
 	return nil
 }
+
+// idpMetadata returns --saml-idp-metadata, or builds the metadata from the
+// individual IDP settings for IDPs that do not publish their metadata.
+func (ca ConfigureSAMLAuthentication) idpMetadata() (string, error) {
+	building := ca.Options.IDPEntityID != "" || ca.Options.IDPSSOURL != "" || ca.Options.IDPSLOURL != "" || ca.Options.IDPSigningCert != ""
+
+	if ca.Options.IDPMetadata != "" {
+		if building {
+			return "", errors.New("cannot use --saml-idp-metadata with --saml-idp-entity-id, --saml-idp-sso-url, --saml-idp-slo-url or --saml-idp-signing-cert")
+		}
+
+		return ca.Options.IDPMetadata, nil
+	}
+
+	if ca.Options.IDPEntityID == "" || ca.Options.IDPSSOURL == "" || ca.Options.IDPSigningCert == "" {
+		return "", errors.New("either --saml-idp-metadata, or --saml-idp-entity-id, --saml-idp-sso-url and --saml-idp-signing-cert must be provided")
+	}
+
+	err := validatePEMCertificates(ca.Options.IDPSigningCert)
+	if err != nil {
+		return "", fmt.Errorf("invalid --saml-idp-signing-cert: %s", err)
+	}
+	block, _ := pem.Decode([]byte(ca.Options.IDPSigningCert))
+
+	return samlIDPMetadata(ca.Options.IDPEntityID, ca.Options.IDPSSOURL, ca.Options.IDPSLOURL, block.Bytes)
+}
+
+const (
+	samlRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	samlPostBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+)
+
+type samlEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type samlEntityDescriptor struct {
+	XMLName       xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID      string   `xml:"entityID,attr"`
+	IDPDescriptor struct {
+		ProtocolSupport string `xml:"protocolSupportEnumeration,attr"`
+		KeyDescriptor   struct {
+			Use     string `xml:"use,attr"`
+			KeyInfo struct {
+				XMLName     xml.Name `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+				Certificate string   `xml:"X509Data>X509Certificate"`
+			}
+		} `xml:"KeyDescriptor"`
+		SingleLogoutServices []samlEndpoint `xml:"SingleLogoutService"`
+		NameIDFormat         string         `xml:"NameIDFormat"`
+		SingleSignOnServices []samlEndpoint `xml:"SingleSignOnService"`
+	} `xml:"IDPSSODescriptor"`
+}
+
+func samlIDPMetadata(entityID, ssoURL, sloURL string, signingCert []byte) (string, error) {
+	descriptor := samlEntityDescriptor{EntityID: entityID}
+	descriptor.IDPDescriptor.ProtocolSupport = "urn:oasis:names:tc:SAML:2.0:protocol"
+	descriptor.IDPDescriptor.KeyDescriptor.Use = "signing"
+	descriptor.IDPDescriptor.KeyDescriptor.KeyInfo.Certificate = base64.StdEncoding.EncodeToString(signingCert)
+	descriptor.IDPDescriptor.NameIDFormat = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	descriptor.IDPDescriptor.SingleSignOnServices = []samlEndpoint{
+		{Binding: samlRedirectBinding, Location: ssoURL},
+		{Binding: samlPostBinding, Location: ssoURL},
+	}
+	if sloURL != "" {
+		descriptor.IDPDescriptor.SingleLogoutServices = []samlEndpoint{
+			{Binding: samlRedirectBinding, Location: sloURL},
+		}
+	}
+
+	contents, err := xml.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return "", err // not tested
+	}
+
+	return xml.Header + string(contents), nil
+}
@@ -1,6 +1,8 @@
 package commands_test
 
 import (
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
 
@@ -14,7 +16,7 @@ import (
 
 var _ = Describe("ConfigureSAMLAuthentication.Execute", func() {
 	var (
-		service         *fakes.ConfigureAuthenticationService
+		service         *fakes.ConfigureSAMLAuthenticationService
 		logger          *fakes.Logger
 		command         *commands.ConfigureSAMLAuthentication
 		commandLineArgs []string
@@ -22,7 +24,7 @@ var _ = Describe("ConfigureSAMLAuthentication.Execute", func() {
 	)
 
 	BeforeEach(func() {
-		service = &fakes.ConfigureAuthenticationService{}
+		service = &fakes.ConfigureSAMLAuthenticationService{}
 		logger = &fakes.Logger{}
 
 		eaOutputs := []api.EnsureAvailabilityOutput{
@@ -235,6 +237,90 @@ This was skipped due to the 'skip-create-bosh-admin-client' flag.
 		})
 	})
 
+	When("the IDP metadata is built from its settings", func() {
+		var signingCert testCertificate
+
+		BeforeEach(func() {
+			signingCert = newTestCertificate("idp-signing", nil, false)
+			commandLineArgs = []string{
+				"--decryption-passphrase", "some-passphrase",
+				"--saml-idp-entity-id", "https://idp.example.com/entity",
+				"--saml-idp-sso-url", "https://idp.example.com/sso",
+				"--saml-idp-slo-url", "https://idp.example.com/slo",
+				"--saml-idp-signing-cert", signingCert.pem,
+				"--saml-rbac-admin-group", "opsman.full_control",
+				"--saml-rbac-groups-attribute", "myenterprise",
+			}
+		})
+
+		It("sends the built metadata for Ops Manager and BOSH", func() {
+			err := executeCommand(command, commandLineArgs)
+			Expect(err).ToNot(HaveOccurred())
+
+			block, _ := pem.Decode([]byte(signingCert.pem))
+			payload := service.SetupArgsForCall(0)
+			Expect(payload.IDPMetadata).To(ContainSubstring(`entityID="https://idp.example.com/entity"`))
+			Expect(payload.IDPMetadata).To(ContainSubstring(`<SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso">`))
+			Expect(payload.IDPMetadata).To(ContainSubstring(`<SingleLogoutService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/slo">`))
+			Expect(payload.IDPMetadata).To(ContainSubstring(base64.StdEncoding.EncodeToString(block.Bytes)))
+			Expect(payload.BoshIDPMetadata).To(Equal(payload.IDPMetadata))
+		})
+
+		It("errors out when --saml-idp-metadata is given as well", func() {
+			commandLineArgs = append(commandLineArgs, "--saml-idp-metadata", "https://saml.example.com:8080")
+
+			err := executeCommand(command, commandLineArgs)
+			Expect(err).To(MatchError("cannot use --saml-idp-metadata with --saml-idp-entity-id, --saml-idp-sso-url, --saml-idp-slo-url or --saml-idp-signing-cert"))
+			Expect(service.EnsureAvailabilityCallCount()).To(Equal(0))
+		})
+
+		It("errors out when the signing cert is not a certificate", func() {
+			commandLineArgs = append(commandLineArgs, "--saml-idp-signing-cert", "not-a-cert")
+
+			err := executeCommand(command, commandLineArgs)
+			Expect(err).To(MatchError("invalid --saml-idp-signing-cert: could not be parsed as PEM encoded certificates"))
+		})
+	})
+
+	When("neither the IDP metadata nor its settings are given", func() {
+		It("returns an error", func() {
+			err := executeCommand(command, []string{
+				"--decryption-passphrase", "some-passphrase",
+				"--saml-rbac-admin-group", "opsman.full_control",
+				"--saml-rbac-groups-attribute", "myenterprise",
+			})
+			Expect(err).To(MatchError("either --saml-idp-metadata, or --saml-idp-entity-id, --saml-idp-sso-url and --saml-idp-signing-cert must be provided"))
+		})
+	})
+
+	When("the authentication setup has already been configured and --update-rbac is set", func() {
+		It("updates the RBAC settings", func() {
+			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{
+				Status: api.EnsureAvailabilityStatusComplete,
+			}, nil)
+
+			err := executeCommand(command, append(commandLineArgs, "--update-rbac"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(service.SetupCallCount()).To(Equal(0))
+			Expect(service.EnableRBACCallCount()).To(Equal(1))
+			Expect(service.EnableRBACArgsForCall(0)).To(Equal(api.RBACSettings{
+				SAMLAdminGroup:      "opsman.full_control",
+				SAMLGroupsAttribute: "myenterprise",
+			}))
+		})
+
+		It("returns an error when the RBAC settings cannot be updated", func() {
+			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{
+				Status: api.EnsureAvailabilityStatusComplete,
+			}, nil)
+			service.EnableRBACReturns(errors.New("unauthorized"))
+
+			err := executeCommand(command, append(commandLineArgs, "--update-rbac"))
+			Expect(err).To(MatchError("could not update RBAC settings: unauthorized"))
+		})
+	})
+
 	When("the initial configuration status cannot be determined", func() {
 		It("returns an error", func() {
 			service.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{}, errors.New("failed to fetch status"))
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/interpolate"
+	"gopkg.in/yaml.v2"
+)
+
+type ConfigureVerifiers struct {
+	service     configureVerifiersService
+	logger      logger
+	environFunc func() []string
+	Options     struct {
+		ConfigFile string   `long:"config"    short:"c"         description:"path to yml file mapping the verifier types of the director and products to whether they are enabled (see the output of the verifiers command)" required:"true"`
+		VarsFile   []string `long:"vars-file" short:"l"         description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
+		VarsEnv    []string `long:"vars-env"  env:"OM_VARS_ENV" description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
+		Vars       []string `long:"var"       short:"v"         description:"load variable from the command line. Format: VAR=VAL"`
+		OpsFile    []string `long:"ops-file"                    description:"YAML operations file"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/configure_verifiers_service.go --fake-name ConfigureVerifiersService . configureVerifiersService
+type configureVerifiersService interface {
+	ListDirectorVerifiers() ([]api.Verifier, error)
+	DisableDirectorVerifiers(verifierTypes []string) error
+	EnableDirectorVerifiers(verifierTypes []string) error
+	ListProductVerifiers(productName string) ([]api.Verifier, string, error)
+	DisableProductVerifiers(verifierTypes []string, productGUID string) error
+	EnableProductVerifiers(verifierTypes []string, productGUID string) error
+}
+
+// verifierChanges are the verifiers of the director or of a product that have
+// to be enabled or disabled to match the config file.
+type verifierChanges struct {
+	name        string
+	director    bool
+	productGUID string
+	enable      []string
+	disable     []string
+}
+
+func NewConfigureVerifiers(environFunc func() []string, service configureVerifiersService, logger logger) *ConfigureVerifiers {
+	return &ConfigureVerifiers{
+		environFunc: environFunc,
+		service:     service,
+		logger:      logger,
+	}
+}
+
+func (cv ConfigureVerifiers) Execute(args []string) error {
+	configContents, err := interpolate.Execute(interpolate.Options{
+		TemplateFile:  cv.Options.ConfigFile,
+		VarsFiles:     cv.Options.VarsFile,
+		EnvironFunc:   cv.environFunc,
+		Vars:          cv.Options.Vars,
+		VarsEnvs:      cv.Options.VarsEnv,
+		OpsFiles:      cv.Options.OpsFile,
+		ExpectAllKeys: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var config verifiersConfig
+	err = yaml.UnmarshalStrict(configContents, &config)
+	if err != nil {
+		return fmt.Errorf("could not be parsed as valid configuration: %s: %s", cv.Options.ConfigFile, err)
+	}
+
+	// every verifier is checked before anything changes, so a typo in the
+	// config does not leave the foundation half configured
+	var (
+		changes []verifierChanges
+		missing []string
+	)
+
+	if len(config.Director) > 0 {
+		directorVerifiers, err := cv.service.ListDirectorVerifiers()
+		if err != nil {
+			return fmt.Errorf("could not get available verifiers from Ops Manager: %s", err)
+		}
+
+		change, missingTypes := diffVerifiers(directorVerifiers, config.Director)
+		change.name = "director"
+		change.director = true
+		changes = append(changes, change)
+		for _, verifierType := range missingTypes {
+			missing = append(missing, "director: "+verifierType)
+		}
+	}
+
+	productNames := make([]string, 0, len(config.Products))
+	for productName := range config.Products {
+		productNames = append(productNames, productName)
+	}
+	sort.Strings(productNames)
+
+	for _, productName := range productNames {
+		productVerifiers, productGUID, err := cv.service.ListProductVerifiers(productName)
+		if err != nil {
+			return fmt.Errorf("could not get available verifiers of %s from Ops Manager: %s", productName, err)
+		}
+
+		change, missingTypes := diffVerifiers(productVerifiers, config.Products[productName])
+		change.name = productName
+		change.productGUID = productGUID
+		changes = append(changes, change)
+		for _, verifierType := range missingTypes {
+			missing = append(missing, productName+": "+verifierType)
+		}
+	}
+
+	if len(missing) > 0 {
+		cv.logger.Println("The following verifiers do not exist:")
+		for _, verifier := range missing {
+			cv.logger.Printf("- %s\n", verifier)
+		}
+
+		cv.logger.Println("\nNo changes were made.\n")
+
+		return errors.New("verifier does not exist")
+	}
+
+	for _, change := range changes {
+		err = cv.apply(change)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cv ConfigureVerifiers) apply(change verifierChanges) error {
+	if len(change.enable) == 0 && len(change.disable) == 0 {
+		cv.logger.Printf("verifiers of %s are already configured\n", change.name)
+		return nil
+	}
+
+	var err error
+	if len(change.enable) > 0 {
+		cv.logger.Printf("enabling verifiers of %s: %s\n", change.name, strings.Join(change.enable, ", "))
+		if change.director {
+			err = cv.service.EnableDirectorVerifiers(change.enable)
+		} else {
+			err = cv.service.EnableProductVerifiers(change.enable, change.productGUID)
+		}
+		if err != nil {
+			return fmt.Errorf("could not enable verifiers of %s in Ops Manager: %s", change.name, err)
+		}
+	}
+
+	if len(change.disable) > 0 {
+		cv.logger.Printf("disabling verifiers of %s: %s\n", change.name, strings.Join(change.disable, ", "))
+		if change.director {
+			err = cv.service.DisableDirectorVerifiers(change.disable)
+		} else {
+			err = cv.service.DisableProductVerifiers(change.disable, change.productGUID)
+		}
+		if err != nil {
+			return fmt.Errorf("could not disable verifiers of %s in Ops Manager: %s", change.name, err)
+		}
+	}
+
+	return nil
+}
+
+// diffVerifiers only changes the verifiers that the config lists and that
+// are not in the listed state already.
+func diffVerifiers(existing []api.Verifier, desired map[string]bool) (verifierChanges, []string) {
+	states := verifierStates(existing)
+
+	verifierTypes := make([]string, 0, len(desired))
+	for verifierType := range desired {
+		verifierTypes = append(verifierTypes, verifierType)
+	}
+	sort.Strings(verifierTypes)
+
+	var (
+		change  verifierChanges
+		missing []string
+	)
+	for _, verifierType := range verifierTypes {
+		enabled, ok := states[verifierType]
+		switch {
+		case !ok:
+			missing = append(missing, verifierType)
+		case desired[verifierType] && !enabled:
+			change.enable = append(change.enable, verifierType)
+		case !desired[verifierType] && enabled:
+			change.disable = append(change.disable, verifierType)
+		}
+	}
+
+	return change, missing
+}
@@ -0,0 +1,99 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("ConfigureVerifiers", func() {
+	var (
+		service *fakes.ConfigureVerifiersService
+		stdout  *gbytes.Buffer
+		command *commands.ConfigureVerifiers
+	)
+
+	BeforeEach(func() {
+		service = &fakes.ConfigureVerifiersService{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewConfigureVerifiers(func() []string { return nil }, service, log.New(stdout, "", 0))
+
+		service.ListDirectorVerifiersReturns([]api.Verifier{
+			{Type: "AlreadyDisabled", Enabled: false},
+			{Type: "ToDisable", Enabled: true},
+			{Type: "Unlisted", Enabled: true},
+		}, nil)
+		service.ListProductVerifiersReturns([]api.Verifier{
+			{Type: "ToEnable", Enabled: false},
+		}, "cf-guid", nil)
+	})
+
+	It("only changes the listed verifiers that are not in the listed state", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`
+director:
+  AlreadyDisabled: false
+  ToDisable: false
+products:
+  cf:
+    ToEnable: true
+`)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.EnableDirectorVerifiersCallCount()).To(Equal(0))
+		Expect(service.DisableDirectorVerifiersCallCount()).To(Equal(1))
+		Expect(service.DisableDirectorVerifiersArgsForCall(0)).To(Equal([]string{"ToDisable"}))
+
+		Expect(service.ListProductVerifiersArgsForCall(0)).To(Equal("cf"))
+		verifierTypes, productGUID := service.EnableProductVerifiersArgsForCall(0)
+		Expect(verifierTypes).To(Equal([]string{"ToEnable"}))
+		Expect(productGUID).To(Equal("cf-guid"))
+		Expect(service.DisableProductVerifiersCallCount()).To(Equal(0))
+
+		Expect(stdout).To(gbytes.Say("disabling verifiers of director: ToDisable"))
+		Expect(stdout).To(gbytes.Say("enabling verifiers of cf: ToEnable"))
+	})
+
+	It("does nothing when the verifiers are already configured", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{director: {AlreadyDisabled: false}}`)})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.DisableDirectorVerifiersCallCount()).To(Equal(0))
+		Expect(service.EnableDirectorVerifiersCallCount()).To(Equal(0))
+		Expect(service.ListProductVerifiersCallCount()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("verifiers of director are already configured"))
+	})
+
+	It("makes no changes when a verifier does not exist", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`
+director:
+  ToDisable: false
+products:
+  cf:
+    Missing: true
+`)})
+		Expect(err).To(MatchError("verifier does not exist"))
+
+		Expect(service.DisableDirectorVerifiersCallCount()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("- cf: Missing"))
+		Expect(stdout).To(gbytes.Say("No changes were made."))
+	})
+
+	It("returns an error when a verifier cannot be disabled", func() {
+		service.DisableDirectorVerifiersReturns(errors.New("some error"))
+
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{director: {ToDisable: false}}`)})
+		Expect(err).To(MatchError("could not disable verifiers of director in Ops Manager: some error"))
+	})
+
+	It("returns an error when the config has unknown keys", func() {
+		err := executeCommand(command, []string{"--config", writeTestConfigFile(`{directors: {}}`)})
+		Expect(err).To(MatchError(ContainSubstring("could not be parsed as valid configuration")))
+	})
+})
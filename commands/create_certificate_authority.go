@@ -1,6 +1,13 @@
 package commands
 
 import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
 )
@@ -9,9 +16,9 @@ type CreateCertificateAuthority struct {
 	service   createCertificateAuthorityService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		CertPem    string `long:"certificate-pem" required:"true" description:"certificate"`
+		CertPem    string `long:"certificate-pem" required:"true" description:"certificate, or a bundle of the certificate followed by its intermediates"`
 		PrivateKey string `long:"private-key-pem" required:"true" description:"private key"`
-		Format     string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
@@ -25,8 +32,13 @@ func NewCreateCertificateAuthority(service createCertificateAuthorityService, pr
 }
 
 func (c CreateCertificateAuthority) Execute(args []string) error {
+	certPem, err := certificateAuthorityChain(c.Options.CertPem, c.Options.PrivateKey)
+	if err != nil {
+		return err
+	}
+
 	caResp, err := c.service.CreateCertificateAuthority(api.CertificateAuthorityInput{
-		CertPem:       c.Options.CertPem,
+		CertPem:       certPem,
 		PrivateKeyPem: c.Options.PrivateKey,
 	})
 	if err != nil {
@@ -42,3 +54,90 @@ func (c CreateCertificateAuthority) Execute(args []string) error {
 
 	return nil
 }
+
+// certificateAuthorityChain validates a bundle of a CA and its intermediates
+// and returns it ordered from the CA the private key belongs to up to the
+// root, which is the order Ops Manager expects. A single certificate is
+// returned unchanged for Ops Manager to validate.
+func certificateAuthorityChain(certPem, privateKeyPem string) (string, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(certPem)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("could not parse certificate in the bundle: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) < 2 {
+		return certPem, nil
+	}
+
+	var first *x509.Certificate
+	for _, cert := range certs {
+		if publicKeyMatches(cert, privateKeyPem) {
+			first = cert
+			break
+		}
+	}
+	if first == nil {
+		return "", errors.New("none of the certificates in the bundle belong to the private key")
+	}
+
+	chain := []*x509.Certificate{first}
+	remaining := map[*x509.Certificate]bool{}
+	for _, cert := range certs {
+		if cert != first {
+			remaining[cert] = true
+		}
+	}
+
+	for len(remaining) > 0 {
+		current := chain[len(chain)-1]
+
+		var issuer *x509.Certificate
+		for cert := range remaining {
+			if bytes.Equal(current.RawIssuer, cert.RawSubject) {
+				issuer = cert
+			}
+		}
+		if issuer == nil {
+			return "", fmt.Errorf("the bundle does not contain the issuer of %q", current.Subject.String())
+		}
+
+		err := current.CheckSignatureFrom(issuer)
+		if err != nil {
+			return "", fmt.Errorf("%q is not signed by %q: %w", current.Subject.String(), issuer.Subject.String(), err)
+		}
+
+		chain = append(chain, issuer)
+		delete(remaining, issuer)
+	}
+
+	var ordered bytes.Buffer
+	for _, cert := range chain {
+		if !cert.IsCA {
+			return "", fmt.Errorf("%q in the bundle is not a certificate authority", cert.Subject.String())
+		}
+
+		_ = pem.Encode(&ordered, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	return ordered.String(), nil
+}
+
+func publicKeyMatches(cert *x509.Certificate, privateKeyPem string) bool {
+	_, err := tls.X509KeyPair(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), []byte(privateKeyPem))
+	return err == nil
+}
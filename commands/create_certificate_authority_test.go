@@ -1,7 +1,15 @@
 package commands_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"errors"
+	"math/big"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -107,6 +115,59 @@ var _ = Describe("CreateCertificateAuthority", func() {
 			})
 		})
 
+		When("the certificate is a bundle with intermediates", func() {
+			var (
+				root, intermediate, leaf testCertificate
+			)
+
+			BeforeEach(func() {
+				root = newTestCertificate("root", nil, true)
+				intermediate = newTestCertificate("intermediate", &root, true)
+				leaf = newTestCertificate("leaf", &intermediate, false)
+			})
+
+			It("posts the chain starting from the certificate of the private key", func() {
+				err := executeCommand(command, []string{
+					"--certificate-pem", root.pem + intermediate.pem,
+					"--private-key-pem", intermediate.keyPem,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.CreateCertificateAuthorityArgsForCall(0)).To(Equal(api.CertificateAuthorityInput{
+					CertPem:       intermediate.pem + root.pem,
+					PrivateKeyPem: intermediate.keyPem,
+				}))
+			})
+
+			It("errors when no certificate belongs to the private key", func() {
+				err := executeCommand(command, []string{
+					"--certificate-pem", intermediate.pem + root.pem,
+					"--private-key-pem", leaf.keyPem,
+				})
+				Expect(err).To(MatchError("none of the certificates in the bundle belong to the private key"))
+				Expect(fakeService.CreateCertificateAuthorityCallCount()).To(Equal(0))
+			})
+
+			It("errors when the chain is broken", func() {
+				other := newTestCertificate("other", nil, true)
+
+				err := executeCommand(command, []string{
+					"--certificate-pem", intermediate.pem + other.pem,
+					"--private-key-pem", intermediate.keyPem,
+				})
+				Expect(err).To(MatchError(`the bundle does not contain the issuer of "CN=intermediate"`))
+				Expect(fakeService.CreateCertificateAuthorityCallCount()).To(Equal(0))
+			})
+
+			It("errors when a certificate is not a certificate authority", func() {
+				err := executeCommand(command, []string{
+					"--certificate-pem", leaf.pem + intermediate.pem,
+					"--private-key-pem", leaf.keyPem,
+				})
+				Expect(err).To(MatchError(`"CN=leaf" in the bundle is not a certificate authority`))
+			})
+		})
+
 		When("the service fails to create a certificate", func() {
 			It("returns an error", func() {
 				fakeService.CreateCertificateAuthorityReturns(api.GenerateCAResponse{}, errors.New("failed to create certificate"))
@@ -120,3 +181,45 @@ var _ = Describe("CreateCertificateAuthority", func() {
 		})
 	})
 })
+
+type testCertificate struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	pem    string
+	keyPem string
+}
+
+func newTestCertificate(name string, parent *testCertificate, isCA bool) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	return testCertificate{
+		cert:   cert,
+		key:    key,
+		pem:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/config"
 	"github.com/pivotal-cf/om/interpolate"
@@ -13,6 +17,11 @@ import (
 //counterfeiter:generate -o ./fakes/create_vm_extension_service.go --fake-name CreateVMExtensionService . createVMExtensionService
 type createVMExtensionService interface {
 	CreateStagedVMExtension(api.CreateVMExtension) error
+	DeleteVMExtension(name string) error
+	GetStagedProductJobResourceConfig(productGUID, jobGUID string) (api.JobProperties, error)
+	ListStagedProductJobs(productGUID string) (map[string]string, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+	ListStagedVMExtensions() ([]api.VMExtension, error)
 }
 
 type CreateVMExtension struct {
@@ -25,6 +34,7 @@ type CreateVMExtension struct {
 		interpolateConfigFileOptions
 		OpsFile         []string `long:"ops-file"           short:"o"   description:"YAML operations file"`
 		CloudProperties string   `long:"cloud-properties"               description:"cloud properties in JSON format"`
+		Prune           bool     `long:"prune"                          description:"delete the vm extensions that are not in the vm-extensions of the config file and that no job uses"`
 	}
 }
 
@@ -48,6 +58,8 @@ func (c CreateVMExtension) Execute(args []string) error {
 			VarsFiles:     c.Options.VarsFile,
 			EnvironFunc:   c.environFunc,
 			VarsEnvs:      c.Options.VarsEnv,
+			VarsStores:    c.Options.VarsStore,
+			Functions:     c.Options.Functions,
 			Vars:          c.Options.Vars,
 			OpsFiles:      c.Options.OpsFile,
 			ExpectAllKeys: true,
@@ -61,6 +73,14 @@ func (c CreateVMExtension) Execute(args []string) error {
 			return fmt.Errorf("%s could not be parsed as valid configuration: %s", c.Options.ConfigFile, err)
 		}
 
+		if len(cfg.VMExtensions) > 0 {
+			if cfg.VMExtension.Name != "" {
+				return errors.New("Config file must contain either vm-extension-config or vm-extensions, not both")
+			}
+
+			return c.reconcile(cfg)
+		}
+
 		if cfg.VMExtension.Name == "" {
 			return errors.New("Config file must contain name element")
 		}
@@ -80,6 +100,10 @@ func (c CreateVMExtension) Execute(args []string) error {
 		cloudProperties = json.RawMessage(c.Options.CloudProperties)
 	}
 
+	if c.Options.Prune {
+		return errors.New("--prune requires a config file with vm-extensions, the complete set of vm extensions")
+	}
+
 	err := c.service.CreateStagedVMExtension(api.CreateVMExtension{
 		Name:            name,
 		CloudProperties: cloudProperties,
@@ -93,3 +117,132 @@ func (c CreateVMExtension) Execute(args []string) error {
 
 	return nil
 }
+
+// reconcile creates or updates the vm extensions of the config file, and
+// reports the other vm extensions and the ones no job uses. With --prune,
+// the other vm extensions are deleted unless a job uses them.
+func (c CreateVMExtension) reconcile(cfg config.VMExtensionConfig) error {
+	managed := map[string]bool{}
+	for _, extension := range cfg.VMExtensions {
+		if extension.Name == "" {
+			return errors.New("Config file must contain a name for each of the vm-extensions")
+		}
+
+		cloudProperties, err := getJSONProperties(extension.CloudProperties)
+		if err != nil {
+			return err
+		}
+
+		err = c.service.CreateStagedVMExtension(api.CreateVMExtension{
+			Name:            extension.Name,
+			CloudProperties: json.RawMessage(cloudProperties),
+		})
+		if err != nil {
+			return err
+		}
+
+		c.logger.Printf("VM Extension '%s' created/updated\n", extension.Name)
+		managed[extension.Name] = true
+	}
+
+	existing, err := c.service.ListStagedVMExtensions()
+	if err != nil {
+		return fmt.Errorf("could not list vm extensions: %s", err)
+	}
+
+	usage, err := c.vmExtensionUsage()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, extension := range existing {
+		names = append(names, extension.Name)
+	}
+	sort.Strings(names)
+
+	var (
+		summary   strings.Builder
+		unmanaged []string
+	)
+	table := tabwriter.NewWriter(&summary, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "VM EXTENSION\tMANAGED\tUSED BY")
+	for _, name := range names {
+		status := "yes"
+		if !managed[name] {
+			status = "no"
+			unmanaged = append(unmanaged, name)
+		}
+
+		usedBy := "-"
+		if len(usage[name]) > 0 {
+			usedBy = strings.Join(usage[name], ", ")
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\n", name, status, usedBy)
+	}
+	_ = table.Flush()
+	c.logger.Printf("%s", summary.String())
+
+	if len(unmanaged) == 0 {
+		return nil
+	}
+
+	if !c.Options.Prune {
+		c.logger.Printf("%d vm extensions are not in the config file, run with --prune to delete the ones no job uses", len(unmanaged))
+		return nil
+	}
+
+	for _, name := range unmanaged {
+		if len(usage[name]) > 0 {
+			c.logger.Printf("not deleting vm extension %s, it is used by %s", name, strings.Join(usage[name], ", "))
+			continue
+		}
+
+		err = c.service.DeleteVMExtension(name)
+		if err != nil {
+			return fmt.Errorf("could not delete vm extension %s: %s", name, err)
+		}
+
+		c.logger.Printf("deleted vm extension %s", name)
+	}
+
+	return nil
+}
+
+// vmExtensionUsage returns the jobs, as product/job, using each vm extension
+// as one of their additional_vm_extensions.
+func (c CreateVMExtension) vmExtensionUsage() (map[string][]string, error) {
+	stagedProducts, err := c.service.ListStagedProducts()
+	if err != nil {
+		return nil, fmt.Errorf("could not list staged products: %s", err)
+	}
+
+	usage := map[string][]string{}
+	for _, product := range stagedProducts.Products {
+		jobs, err := c.service.ListStagedProductJobs(product.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("could not list the jobs of %s: %s", product.Type, err)
+		}
+
+		var jobNames []string
+		for name := range jobs {
+			jobNames = append(jobNames, name)
+		}
+		sort.Strings(jobNames)
+
+		for _, jobName := range jobNames {
+			resourceConfig, err := c.service.GetStagedProductJobResourceConfig(product.GUID, jobs[jobName])
+			if err != nil {
+				return nil, fmt.Errorf("could not get the resource config of %s/%s: %s", product.Type, jobName, err)
+			}
+
+			for _, extension := range listOf(resourceConfig["additional_vm_extensions"]) {
+				name := fmt.Sprint(extension)
+				usage[name] = append(usage[name], product.Type+"/"+jobName)
+			}
+		}
+	}
+
+	return usage, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -164,6 +165,105 @@ var _ = Describe("CreateVMExtension", func() {
 			})
 		})
 
+		When("the config file lists the complete set of vm-extensions", func() {
+			var output func() string
+
+			BeforeEach(func() {
+				output = func() string {
+					var lines []string
+					for i := 0; i < fakeLogger.PrintfCallCount(); i++ {
+						format, content := fakeLogger.PrintfArgsForCall(i)
+						lines = append(lines, fmt.Sprintf(format, content...))
+					}
+					return strings.Join(lines, "\n")
+				}
+
+				fakeService.ListStagedVMExtensionsReturns([]api.VMExtension{
+					{Name: "public-ip"},
+					{Name: "old-elb"},
+					{Name: "router-elb"},
+					{Name: "legacy-disk"},
+				}, nil)
+				fakeService.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+					{Type: "cf", GUID: "cf-guid"},
+				}}, nil)
+				fakeService.ListStagedProductJobsReturns(map[string]string{"router": "router-guid", "diego_cell": "diego-cell-guid"}, nil)
+				fakeService.GetStagedProductJobResourceConfigStub = func(productGUID, jobGUID string) (api.JobProperties, error) {
+					if jobGUID == "router-guid" {
+						return api.JobProperties{"additional_vm_extensions": []interface{}{"router-elb", "legacy-disk"}}, nil
+					}
+					return api.JobProperties{}, nil
+				}
+
+				configFile, err = os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = configFile.WriteString(`---
+vm-extensions:
+- name: public-ip
+  cloud_properties:
+    associate_public_ip_address: true
+- name: router-elb
+  cloud_properties:
+    elbs: [some-elb]
+`)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("creates/updates them and reports the other vm extensions and the unused ones", func() {
+				err := executeCommand(command, []string{"--config", configFile.Name()})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.CreateStagedVMExtensionCallCount()).To(Equal(2))
+				Expect(fakeService.CreateStagedVMExtensionArgsForCall(0)).To(Equal(api.CreateVMExtension{
+					Name:            "public-ip",
+					CloudProperties: json.RawMessage(`{"associate_public_ip_address":true}`),
+				}))
+				Expect(fakeService.CreateStagedVMExtensionArgsForCall(1).Name).To(Equal("router-elb"))
+
+				Expect(output()).To(MatchRegexp(`legacy-disk\s+no\s+cf/router\n`))
+				Expect(output()).To(MatchRegexp(`old-elb\s+no\s+-\n`))
+				Expect(output()).To(MatchRegexp(`public-ip\s+yes\s+-\n`))
+				Expect(output()).To(MatchRegexp(`router-elb\s+yes\s+cf/router\n`))
+				Expect(output()).To(ContainSubstring("2 vm extensions are not in the config file, run with --prune to delete the ones no job uses"))
+
+				Expect(fakeService.DeleteVMExtensionCallCount()).To(Equal(0))
+			})
+
+			It("deletes the other vm extensions no job uses with --prune", func() {
+				err := executeCommand(command, []string{"--config", configFile.Name(), "--prune"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.DeleteVMExtensionCallCount()).To(Equal(1))
+				Expect(fakeService.DeleteVMExtensionArgsForCall(0)).To(Equal("old-elb"))
+
+				Expect(output()).To(ContainSubstring("not deleting vm extension legacy-disk, it is used by cf/router"))
+				Expect(output()).To(ContainSubstring("deleted vm extension old-elb"))
+			})
+
+			It("returns an error when the jobs using the vm extensions cannot be determined", func() {
+				fakeService.GetStagedProductJobResourceConfigReturns(nil, errors.New("some error"))
+				fakeService.GetStagedProductJobResourceConfigStub = nil
+
+				err := executeCommand(command, []string{"--config", configFile.Name(), "--prune"})
+				Expect(err).To(MatchError("could not get the resource config of cf/diego_cell: some error"))
+				Expect(fakeService.DeleteVMExtensionCallCount()).To(Equal(0))
+			})
+
+			It("returns an error when a vm extension cannot be deleted", func() {
+				fakeService.DeleteVMExtensionReturns(errors.New("some error"))
+
+				err := executeCommand(command, []string{"--config", configFile.Name(), "--prune"})
+				Expect(err).To(MatchError("could not delete vm extension old-elb: some error"))
+			})
+		})
+
+		It("requires the vm-extensions of a config file to prune", func() {
+			err := executeCommand(command, []string{"--name", "some-vm-extension", "--prune"})
+			Expect(err).To(MatchError("--prune requires a config file with vm-extensions, the complete set of vm extensions"))
+			Expect(fakeService.CreateStagedVMExtensionCallCount()).To(Equal(0))
+		})
+
 		When("the service fails to create a VM extension", func() {
 			It("returns an error", func() {
 				fakeService.CreateStagedVMExtensionReturns(errors.New("failed to create VM extension"))
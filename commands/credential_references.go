@@ -14,7 +14,7 @@ type CredentialReferences struct {
 	logger    logger
 	Options   struct {
 		Product string `long:"product-name" short:"p" required:"true" description:"name of deployed product"`
-		Format  string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
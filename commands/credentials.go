@@ -15,7 +15,7 @@ type Credentials struct {
 		Product             string `long:"product-name"         short:"p" required:"true" description:"name of deployed product"`
 		CredentialReference string `long:"credential-reference" short:"c" required:"true" description:"name of credential reference"`
 		CredentialField     string `long:"credential-field"     short:"f"                 description:"single credential field to output"`
-		Format              string `long:"format"               short:"t" default:"table" description:"Format to print as (options: table,json)"`
+		Format              string `long:"format"               short:"t" default:"table" choice:"table" choice:"json" choice:"yaml" description:"Format to print as"`
 	}
 }
 
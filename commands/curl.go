@@ -3,10 +3,14 @@ package commands
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pivotal-cf/om/api"
@@ -24,11 +28,12 @@ type Curl struct {
 	stdout      logger
 	stderr      logger
 	Options     struct {
-		Path    string   `long:"path"    short:"p" required:"true" description:"path to api endpoint"`
-		Method  string   `long:"request" short:"x"                 description:"http verb (defaults to GET, POST when 'data' specified"`
-		Data    string   `long:"data"    short:"d"                 description:"api request payload (prefix with @ to read file contents)"`
-		Silent  bool     `long:"silent"  short:"s"                 description:"only write response headers to stderr if response status is 4XX or 5XX"`
-		Headers []string `long:"header"  short:"H"                 description:"used to specify custom headers with your command" default:"Content-Type: application/json"`
+		Path             string   `long:"path"    short:"p" required:"true" description:"path to api endpoint"`
+		Method           string   `long:"request" short:"x"                 description:"http verb (defaults to GET, POST when 'data' specified"`
+		Data             string   `long:"data"    short:"d"                 description:"api request payload (prefix with @ to read file contents)"`
+		Silent           bool     `long:"silent"  short:"s"                 description:"only write response headers to stderr if response status is 4XX or 5XX"`
+		Headers          []string `long:"header"  short:"H"                 description:"used to specify custom headers with your command" default:"Content-Type: application/json"`
+		FollowPagination bool     `long:"follow-pagination"                 description:"fetch every page of a paginated GET response and print the lists they contain merged into one JSON document"`
 	}
 }
 
@@ -84,9 +89,44 @@ func (c Curl) Execute(args []string) error {
 		}
 	}
 
+	if c.Options.FollowPagination && input.Method != "GET" {
+		return errors.New("--follow-pagination can only be used with GET requests")
+	}
+
+	output, body, err := c.request(input)
+	if err != nil {
+		return err
+	}
+
+	if c.Options.FollowPagination && output.StatusCode < 400 && isJSONResponse(output.Headers) {
+		body, output, err = c.followPagination(input, output.Headers, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if isJSONResponse(output.Headers) {
+		var prettyJSON bytes.Buffer
+		err := json.Indent(&prettyJSON, body, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+		body = prettyJSON.Bytes()
+	}
+
+	c.stdout.Println(string(body))
+
+	if output.StatusCode >= 400 {
+		return fmt.Errorf("server responded with a %d error", output.StatusCode)
+	}
+
+	return nil
+}
+
+func (c Curl) request(input api.RequestServiceCurlInput) (api.RequestServiceCurlOutput, []byte, error) {
 	output, err := c.service.Curl(input)
 	if err != nil {
-		return fmt.Errorf("failed to make api request: %s", err)
+		return output, nil, fmt.Errorf("failed to make api request: %s", err)
 	}
 
 	writeHeadersToStderr := !c.Options.Silent || output.StatusCode >= 400
@@ -98,7 +138,7 @@ func (c Curl) Execute(args []string) error {
 	headers := bytes.NewBuffer([]byte{})
 	err = output.Headers.Write(headers)
 	if err != nil {
-		return fmt.Errorf("failed to write api response headers: %s", err)
+		return output, nil, fmt.Errorf("failed to write api response headers: %s", err)
 	}
 
 	if writeHeadersToStderr {
@@ -107,28 +147,172 @@ func (c Curl) Execute(args []string) error {
 
 	body, err := io.ReadAll(output.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read api response body: %s", err)
+		return output, nil, fmt.Errorf("failed to read api response body: %s", err)
 	}
 	defer output.Body.Close()
 
-	for _, contentType := range output.Headers["Content-Type"] {
-		if strings.HasPrefix(contentType, "application/json") {
-			var prettyJSON bytes.Buffer
-			err := json.Indent(&prettyJSON, body, "", "  ")
-			if err != nil {
-				panic(err)
-			}
-			body = prettyJSON.Bytes()
+	return output, body, nil
+}
+
+// followPagination requests the next page for as long as the last response
+// points to one. Lists in the pages are concatenated, every other field is
+// taken from the last page. When a page fails, its response is returned.
+func (c Curl) followPagination(input api.RequestServiceCurlInput, headers http.Header, body []byte) ([]byte, api.RequestServiceCurlOutput, error) {
+	var merged interface{}
+	err := json.Unmarshal(body, &merged)
+	if err != nil {
+		return nil, api.RequestServiceCurlOutput{}, fmt.Errorf("failed to parse paginated response: %s", err)
+	}
 
+	output := api.RequestServiceCurlOutput{StatusCode: http.StatusOK, Headers: headers}
+	page := merged
+	visited := map[string]bool{input.Path: true}
+	for {
+		next := nextPagePath(input.Path, output.Headers, page)
+		if next == "" {
 			break
 		}
+		if visited[next] {
+			return nil, output, fmt.Errorf("pagination returned %s more than once", next)
+		}
+		visited[next] = true
+
+		input.Path = next
+		input.Data = strings.NewReader("")
+
+		var pageBody []byte
+		output, pageBody, err = c.request(input)
+		if err != nil {
+			return nil, output, err
+		}
+		if output.StatusCode >= 400 {
+			return pageBody, output, nil
+		}
+
+		page = nil
+		err = json.Unmarshal(pageBody, &page)
+		if err != nil {
+			return nil, output, fmt.Errorf("failed to parse paginated response from %s: %s", next, err)
+		}
+
+		merged = mergePages(merged, page)
 	}
 
-	c.stdout.Println(string(body))
+	mergedBody, err := json.Marshal(merged)
+	if err != nil {
+		return nil, output, err
+	}
 
-	if output.StatusCode >= 400 {
-		return fmt.Errorf("server responded with a %d error", output.StatusCode)
+	return mergedBody, output, nil
+}
+
+var linkNextRegex = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="?next"?`)
+
+// nextPagePath finds the next page from a Link header, the pagination
+// metadata used by the Cloud Controller (pagination.next.href or next_url),
+// or the startIndex, itemsPerPage and totalResults of a UAA (SCIM) listing.
+func nextPagePath(currentPath string, headers http.Header, page interface{}) string {
+	for _, link := range headers.Values("Link") {
+		if matches := linkNextRegex.FindStringSubmatch(link); matches != nil {
+			return relativePagePath(currentPath, matches[1])
+		}
 	}
 
-	return nil
+	document, ok := page.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	if pagination, ok := document["pagination"].(map[string]interface{}); ok {
+		switch next := pagination["next"].(type) {
+		case string:
+			return relativePagePath(currentPath, next)
+		case map[string]interface{}:
+			if href, ok := next["href"].(string); ok {
+				return relativePagePath(currentPath, href)
+			}
+		}
+	}
+
+	for _, key := range []string{"next_url", "next"} {
+		if next, ok := document[key].(string); ok && next != "" {
+			return relativePagePath(currentPath, next)
+		}
+	}
+
+	startIndex, hasStart := document["startIndex"].(float64)
+	itemsPerPage, hasItems := document["itemsPerPage"].(float64)
+	totalResults, hasTotal := document["totalResults"].(float64)
+	if hasStart && hasItems && hasTotal && itemsPerPage > 0 && startIndex+itemsPerPage <= totalResults {
+		next, err := url.Parse(currentPath)
+		if err != nil {
+			return ""
+		}
+
+		query := next.Query()
+		query.Set("startIndex", strconv.Itoa(int(startIndex+itemsPerPage)))
+		if query.Get("count") == "" {
+			query.Set("count", strconv.Itoa(int(itemsPerPage)))
+		}
+		next.RawQuery = query.Encode()
+
+		return next.String()
+	}
+
+	return ""
+}
+
+// relativePagePath turns a link to a page, which may include the Ops Manager
+// host, into a path that can be requested with the curl service.
+func relativePagePath(currentPath, link string) string {
+	current, err := url.Parse(currentPath)
+	if err != nil {
+		return link
+	}
+
+	next, err := current.Parse(link)
+	if err != nil {
+		return link
+	}
+
+	return next.RequestURI()
+}
+
+func mergePages(merged, page interface{}) interface{} {
+	switch mergedValue := merged.(type) {
+	case []interface{}:
+		if pageList, ok := page.([]interface{}); ok {
+			return append(mergedValue, pageList...)
+		}
+	case map[string]interface{}:
+		pageMap, ok := page.(map[string]interface{})
+		if !ok {
+			return merged
+		}
+
+		for key, value := range pageMap {
+			mergedList, isMergedList := mergedValue[key].([]interface{})
+			pageList, isPageList := value.([]interface{})
+			if isMergedList && isPageList {
+				mergedValue[key] = append(mergedList, pageList...)
+				continue
+			}
+
+			mergedValue[key] = value
+		}
+
+		return mergedValue
+	}
+
+	return page
+}
+
+func isJSONResponse(headers http.Header) bool {
+	for _, contentType := range headers["Content-Type"] {
+		if strings.HasPrefix(contentType, "application/json") {
+			return true
+		}
+	}
+
+	return false
 }
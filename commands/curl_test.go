@@ -379,5 +379,81 @@ var _ = Describe("Curl", func() {
 				Expect(err).To(MatchError("server responded with a 401 error"))
 			})
 		})
+
+		When("--follow-pagination is specified", func() {
+			jsonResponse := func(statusCode int, body string, headers ...string) api.RequestServiceCurlOutput {
+				header := http.Header{"Content-Type": []string{"application/json"}}
+				for i := 0; i < len(headers); i += 2 {
+					header.Add(headers[i], headers[i+1])
+				}
+
+				return api.RequestServiceCurlOutput{StatusCode: statusCode, Headers: header, Body: stringCloser(body)}
+			}
+
+			It("follows pagination.next.href and merges the lists", func() {
+				fakeService.CurlReturnsOnCall(0, jsonResponse(http.StatusOK, `{"pagination": {"total_results": 3, "next": {"href": "https://example.com/v3/apps?page=2"}}, "resources": [{"name": "one"}, {"name": "two"}]}`), nil)
+				fakeService.CurlReturnsOnCall(1, jsonResponse(http.StatusOK, `{"pagination": {"total_results": 3, "next": null}, "resources": [{"name": "three"}]}`), nil)
+
+				err := executeCommand(command, []string{"--path", "/v3/apps", "--follow-pagination"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.CurlCallCount()).To(Equal(2))
+				Expect(fakeService.CurlArgsForCall(1).Path).To(Equal("/v3/apps?page=2"))
+				Expect(fakeService.CurlArgsForCall(1).Method).To(Equal("GET"))
+
+				content := stdout.PrintlnArgsForCall(0)
+				Expect(fmt.Sprint(content...)).To(MatchJSON(`{"pagination": {"total_results": 3, "next": null}, "resources": [{"name": "one"}, {"name": "two"}, {"name": "three"}]}`))
+			})
+
+			It("follows Link headers", func() {
+				fakeService.CurlReturnsOnCall(0, jsonResponse(http.StatusOK, `[1, 2]`, "Link", `</api/v0/things?page=2>; rel="next"`), nil)
+				fakeService.CurlReturnsOnCall(1, jsonResponse(http.StatusOK, `[3]`), nil)
+
+				err := executeCommand(command, []string{"--path", "/api/v0/things", "--follow-pagination"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.CurlArgsForCall(1).Path).To(Equal("/api/v0/things?page=2"))
+				content := stdout.PrintlnArgsForCall(0)
+				Expect(fmt.Sprint(content...)).To(MatchJSON(`[1, 2, 3]`))
+			})
+
+			It("requests the next startIndex of SCIM listings", func() {
+				fakeService.CurlReturnsOnCall(0, jsonResponse(http.StatusOK, `{"resources": [{"id": "a"}, {"id": "b"}], "startIndex": 1, "itemsPerPage": 2, "totalResults": 3}`), nil)
+				fakeService.CurlReturnsOnCall(1, jsonResponse(http.StatusOK, `{"resources": [{"id": "c"}], "startIndex": 3, "itemsPerPage": 2, "totalResults": 3}`), nil)
+
+				err := executeCommand(command, []string{"--path", "/uaa/Users?filter=active", "--follow-pagination"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.CurlCallCount()).To(Equal(2))
+				Expect(fakeService.CurlArgsForCall(1).Path).To(Equal("/uaa/Users?count=2&filter=active&startIndex=3"))
+				content := stdout.PrintlnArgsForCall(0)
+				Expect(fmt.Sprint(content...)).To(MatchJSON(`{"resources": [{"id": "a"}, {"id": "b"}, {"id": "c"}], "startIndex": 3, "itemsPerPage": 2, "totalResults": 3}`))
+			})
+
+			It("returns the error of a page that fails", func() {
+				fakeService.CurlReturnsOnCall(0, jsonResponse(http.StatusOK, `{"next_url": "/v2/apps?page=2", "resources": []}`), nil)
+				fakeService.CurlReturnsOnCall(1, jsonResponse(http.StatusInternalServerError, `{"error": "boom"}`), nil)
+
+				err := executeCommand(command, []string{"--path", "/v2/apps", "--follow-pagination"})
+				Expect(err).To(MatchError("server responded with a 500 error"))
+
+				content := stdout.PrintlnArgsForCall(0)
+				Expect(fmt.Sprint(content...)).To(MatchJSON(`{"error": "boom"}`))
+			})
+
+			It("stops when a page is returned more than once", func() {
+				fakeService.CurlReturnsOnCall(0, jsonResponse(http.StatusOK, `{"next_url": "/v2/apps?page=2", "resources": []}`), nil)
+				fakeService.CurlReturnsOnCall(1, jsonResponse(http.StatusOK, `{"next_url": "/v2/apps?page=2", "resources": []}`), nil)
+
+				err := executeCommand(command, []string{"--path", "/v2/apps", "--follow-pagination"})
+				Expect(err).To(MatchError("pagination returned /v2/apps?page=2 more than once"))
+			})
+
+			It("only supports GET requests", func() {
+				err := executeCommand(command, []string{"--path", "/v2/apps", "--data", "{}", "--follow-pagination"})
+				Expect(err).To(MatchError("--follow-pagination can only be used with GET requests"))
+				Expect(fakeService.CurlCallCount()).To(Equal(0))
+			})
+		})
 	})
 })
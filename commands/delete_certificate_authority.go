@@ -2,16 +2,19 @@ package commands
 
 import (
 	"errors"
+	"time"
 
 	"github.com/pivotal-cf/om/api"
 )
 
 type DeleteCertificateAuthority struct {
-	service deleteCertificateAuthorityService
-	logger  logger
-	Options struct {
+	service      deleteCertificateAuthorityService
+	logger       logger
+	waitDuration time.Duration
+	Options      struct {
 		Id          string `long:"id" required:"false" description:"certificate authority id"`
 		AllInactive bool   `long:"all-inactive" required:"false" description:"delete all inactive certificate authorities"`
+		certificateAuthorityWaitOptions
 	}
 }
 
@@ -19,10 +22,11 @@ type DeleteCertificateAuthority struct {
 type deleteCertificateAuthorityService interface {
 	DeleteCertificateAuthority(api.DeleteCertificateAuthorityInput) error
 	ListCertificateAuthorities() (api.CertificateAuthoritiesOutput, error)
+	ListCertificates(expiresWithin string) ([]api.ExpiringCertificate, error)
 }
 
-func NewDeleteCertificateAuthority(service deleteCertificateAuthorityService, logger logger) *DeleteCertificateAuthority {
-	return &DeleteCertificateAuthority{service: service, logger: logger}
+func NewDeleteCertificateAuthority(service deleteCertificateAuthorityService, logger logger, waitDuration time.Duration) *DeleteCertificateAuthority {
+	return &DeleteCertificateAuthority{service: service, logger: logger, waitDuration: waitDuration}
 }
 
 func (a DeleteCertificateAuthority) Execute(args []string) error {
@@ -51,7 +55,21 @@ func (a DeleteCertificateAuthority) Execute(args []string) error {
 		a.logger.Printf("Certificate authority '%s' deleted\n", caGuid)
 	}
 
-	return nil
+	if a.Options.SkipWait {
+		return nil
+	}
+
+	return waitForCertificateAuthorities(a.service, a.logger, a.Options.certificateAuthorityWaitOptions, a.waitDuration, "deleted", func(cas []api.CA) bool {
+		for _, ca := range cas {
+			for _, caGuid := range caGuids {
+				if ca.GUID == caGuid {
+					return false
+				}
+			}
+		}
+
+		return true
+	})
 }
 
 func (a DeleteCertificateAuthority) getInactiveCAs() ([]string, error) {
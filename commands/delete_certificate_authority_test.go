@@ -22,13 +22,14 @@ var _ = Describe("DeleteCertificateAuthority", func() {
 	BeforeEach(func() {
 		fakeService = &fakes.DeleteCertificateAuthorityService{}
 		fakeLogger = &fakes.Logger{}
-		command = commands.NewDeleteCertificateAuthority(fakeService, fakeLogger)
+		command = commands.NewDeleteCertificateAuthority(fakeService, fakeLogger, 0)
 	})
 
 	Describe("Execute", func() {
 		It("deletes the specified certificate authority", func() {
 			err := executeCommand(command, []string{
 				"--id", "some-certificate-authority-id",
+				"--skip-wait",
 			})
 			Expect(err).ToNot(HaveOccurred())
 
@@ -43,7 +44,7 @@ var _ = Describe("DeleteCertificateAuthority", func() {
 		})
 
 		When("using the --all-inactive flag", func() {
-			args := []string{"--all-inactive"}
+			args := []string{"--all-inactive", "--skip-wait"}
 
 			Context("with one inactive CA", func() {
 				BeforeEach(func() {
@@ -112,12 +113,36 @@ var _ = Describe("DeleteCertificateAuthority", func() {
 			})
 		})
 
+		When("waiting for the deletion to settle", func() {
+			It("waits for the certificate authority to be gone and checks the deployed certificates", func() {
+				fakeService.ListCertificateAuthoritiesReturnsOnCall(0, api.CertificateAuthoritiesOutput{CAs: []api.CA{{GUID: "old-ca"}, {GUID: "new-ca", Active: true}}}, nil)
+				fakeService.ListCertificateAuthoritiesReturns(api.CertificateAuthoritiesOutput{CAs: []api.CA{{GUID: "new-ca", Active: true}}}, nil)
+
+				err := executeCommand(command, []string{"--id", "old-ca"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.ListCertificateAuthoritiesCallCount()).To(Equal(2))
+				Expect(fakeService.ListCertificatesCallCount()).To(Equal(1))
+
+				format, content := fakeLogger.PrintfArgsForCall(fakeLogger.PrintfCallCount() - 1)
+				Expect(fmt.Sprintf(format, content...)).To(Equal("No deployed certificates to check\n"))
+			})
+
+			It("returns an error when the deployed certificates cannot be listed", func() {
+				fakeService.ListCertificatesReturns(nil, errors.New("some error"))
+
+				err := executeCommand(command, []string{"--id", "old-ca"})
+				Expect(err).To(MatchError("could not list the deployed certificates: some error"))
+			})
+		})
+
 		When("the service fails to delete a certificate", func() {
 			It("returns an error", func() {
 				fakeService.DeleteCertificateAuthorityReturns(errors.New("failed to delete certificate"))
 
 				err := executeCommand(command, []string{
 					"--id", "some-certificate-authority-id",
+					"--skip-wait",
 				})
 				Expect(err).To(MatchError("failed to delete certificate"))
 			})
@@ -1,17 +1,33 @@
 package commands
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+
 	"github.com/pivotal-cf/om/api"
 )
 
 type DeleteUnusedProducts struct {
 	service deleteUnusedProductsService
 	logger  logger
+	Options struct {
+		DryRun          bool     `long:"dry-run"                        description:"list the unused products that would be deleted, without deleting them"`
+		KeepNewest      int      `long:"keep-newest"                    description:"keep the newest N unused versions of each product, e.g. as rollback candidates"`
+		ProductNames    []string `long:"product-name"         short:"p" description:"only delete unused versions of this product. Can be repeated"`
+		ExcludeProducts []string `long:"exclude-product-name"           description:"never delete unused versions of this product. Can be repeated"`
+	}
 }
 
 //counterfeiter:generate -o ./fakes/delete_unused_products_service.go --fake-name DeleteUnusedProductsService . deleteUnusedProductsService
 type deleteUnusedProductsService interface {
 	DeleteAvailableProducts(input api.DeleteAvailableProductsInput) error
+	ListAvailableProducts() (api.AvailableProductsOutput, error)
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
 }
 
 func NewDeleteUnusedProducts(service deleteUnusedProductsService, logger logger) *DeleteUnusedProducts {
@@ -22,16 +38,134 @@ func NewDeleteUnusedProducts(service deleteUnusedProductsService, logger logger)
 }
 
 func (dup DeleteUnusedProducts) Execute(args []string) error {
-	dup.logger.Printf("trashing unused products")
+	if dup.Options.KeepNewest < 0 {
+		return errors.New("--keep-newest cannot be negative")
+	}
 
-	err := dup.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
-		ShouldDeleteAllProducts: true,
-	})
+	filtered := dup.Options.KeepNewest > 0 || len(dup.Options.ProductNames) > 0 || len(dup.Options.ExcludeProducts) > 0
+	if !filtered && !dup.Options.DryRun {
+		dup.logger.Printf("trashing unused products")
+
+		err := dup.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
+			ShouldDeleteAllProducts: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		dup.logger.Printf("done")
+
+		return nil
+	}
+
+	unused, err := dup.unusedProducts()
 	if err != nil {
 		return err
 	}
 
+	if len(unused) == 0 {
+		dup.logger.Printf("no unused products to delete")
+		return nil
+	}
+
+	if dup.Options.DryRun {
+		dup.logger.Println("the following unused products would be deleted:")
+		for _, product := range unused {
+			dup.logger.Printf("- %s %s\n", product.Name, product.Version)
+		}
+
+		return nil
+	}
+
+	for _, product := range unused {
+		dup.logger.Printf("trashing unused product %s %s\n", product.Name, product.Version)
+
+		err = dup.service.DeleteAvailableProducts(api.DeleteAvailableProductsInput{
+			ProductName:    product.Name,
+			ProductVersion: product.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("could not delete %s %s: %w", product.Name, product.Version, err)
+		}
+	}
+
 	dup.logger.Printf("done")
 
 	return nil
 }
+
+// unusedProducts are the uploaded products that are neither staged nor
+// deployed, and that the filters allow to delete. They are sorted by name,
+// newest version first.
+func (dup DeleteUnusedProducts) unusedProducts() ([]api.ProductInfo, error) {
+	availableProducts, err := dup.service.ListAvailableProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	stagedProducts, err := dup.service.ListStagedProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	deployedProducts, err := dup.service.ListDeployedProducts()
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[api.ProductInfo]bool{}
+	for _, product := range stagedProducts.Products {
+		used[api.ProductInfo{Name: product.Type, Version: product.ProductVersion}] = true
+	}
+	for _, product := range deployedProducts {
+		used[api.ProductInfo{Name: product.Type, Version: product.ProductVersion}] = true
+	}
+
+	var unused []api.ProductInfo
+	for _, product := range availableProducts.ProductsList {
+		if used[product] {
+			continue
+		}
+		if len(dup.Options.ProductNames) > 0 && !slices.Contains(dup.Options.ProductNames, product.Name) {
+			continue
+		}
+		if slices.Contains(dup.Options.ExcludeProducts, product.Name) {
+			continue
+		}
+
+		unused = append(unused, product)
+	}
+
+	sort.SliceStable(unused, func(i, j int) bool {
+		if unused[i].Name != unused[j].Name {
+			return unused[i].Name < unused[j].Name
+		}
+
+		return newerProductVersion(unused[i].Version, unused[j].Version)
+	})
+
+	var deletable []api.ProductInfo
+	kept := map[string]int{}
+	for _, product := range unused {
+		if kept[product.Name] < dup.Options.KeepNewest {
+			kept[product.Name]++
+			continue
+		}
+
+		deletable = append(deletable, product)
+	}
+
+	return deletable, nil
+}
+
+// newerProductVersion compares the versions as semver, and falls back to
+// comparing the text of versions that are not semver.
+func newerProductVersion(a, b string) bool {
+	versionA, errA := version.NewVersion(a)
+	versionB, errB := version.NewVersion(b)
+	if errA != nil || errB != nil {
+		return a > b
+	}
+
+	return versionA.GreaterThan(versionB)
+}
@@ -45,6 +45,105 @@ var _ = Describe("DeleteProduct", func() {
 		})
 	})
 
+	When("the unused products are filtered", func() {
+		BeforeEach(func() {
+			fakeService.ListAvailableProductsReturns(api.AvailableProductsOutput{
+				ProductsList: []api.ProductInfo{
+					{Name: "cf", Version: "2.0.0"},
+					{Name: "cf", Version: "2.2.0"},
+					{Name: "cf", Version: "2.10.0"},
+					{Name: "cf", Version: "2.11.0"},
+					{Name: "p-redis", Version: "1.0.0"},
+					{Name: "p-redis", Version: "1.1.0"},
+					{Name: "p-mysql", Version: "3.0.0"},
+				},
+			}, nil)
+			fakeService.ListStagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{Type: "p-bosh", ProductVersion: "3.0.0"},
+					{Type: "cf", ProductVersion: "2.11.0"},
+				},
+			}, nil)
+			fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
+				{Type: "cf", ProductVersion: "2.10.0"},
+				{Type: "p-redis", ProductVersion: "1.1.0"},
+			}, nil)
+		})
+
+		It("lists the unused products without deleting them in a dry run", func() {
+			err := executeCommand(command, []string{"--dry-run"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(0))
+
+			Expect(logger.PrintlnArgsForCall(0)).To(Equal([]interface{}{"the following unused products would be deleted:"}))
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, content := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, content...))
+			}
+			Expect(lines).To(Equal([]string{
+				"- cf 2.2.0\n",
+				"- cf 2.0.0\n",
+				"- p-mysql 3.0.0\n",
+				"- p-redis 1.0.0\n",
+			}))
+		})
+
+		It("keeps the newest unused versions of each product", func() {
+			err := executeCommand(command, []string{"--keep-newest", "1"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(1))
+			Expect(fakeService.DeleteAvailableProductsArgsForCall(0)).To(Equal(api.DeleteAvailableProductsInput{
+				ProductName:    "cf",
+				ProductVersion: "2.0.0",
+			}))
+
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("trashing unused product cf 2.0.0\n"))
+			format, content = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("done"))
+		})
+
+		It("only deletes the included products that are not excluded", func() {
+			err := executeCommand(command, []string{"--product-name", "cf", "--product-name", "p-redis", "--exclude-product-name", "p-redis"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(2))
+			Expect(fakeService.DeleteAvailableProductsArgsForCall(0).ProductVersion).To(Equal("2.2.0"))
+			Expect(fakeService.DeleteAvailableProductsArgsForCall(1).ProductVersion).To(Equal("2.0.0"))
+		})
+
+		It("does nothing when no unused product is left to delete", func() {
+			err := executeCommand(command, []string{"--product-name", "p-redis", "--keep-newest", "2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.DeleteAvailableProductsCallCount()).To(Equal(0))
+			format, content := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, content...)).To(Equal("no unused products to delete"))
+		})
+
+		It("returns an error when a product cannot be deleted", func() {
+			fakeService.DeleteAvailableProductsReturns(errors.New("something bad happened"))
+
+			err := executeCommand(command, []string{"--product-name", "p-mysql"})
+			Expect(err).To(MatchError("could not delete p-mysql 3.0.0: something bad happened"))
+		})
+
+		It("returns an error when the products cannot be listed", func() {
+			fakeService.ListDeployedProductsReturns(nil, errors.New("something bad happened"))
+
+			err := executeCommand(command, []string{"--dry-run"})
+			Expect(err).To(MatchError("something bad happened"))
+		})
+
+		It("does not allow keeping a negative number of versions", func() {
+			err := executeCommand(command, []string{"--keep-newest", "-1"})
+			Expect(err).To(MatchError("--keep-newest cannot be negative"))
+		})
+	})
+
 	When("an error occurs", func() {
 		When("deleting all products fails", func() {
 			It("returns an error", func() {
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+var stemcellFilenamePattern = regexp.MustCompile(`stemcell-(\d+(?:\.\d+)*)-(.+)\.tgz$`)
+
+type DeleteUnusedStemcells struct {
+	service deleteUnusedStemcellsService
+	logger  logger
+	Options struct {
+		KeepNewest int  `long:"keep-newest" description:"keep the newest N unused stemcells of each operating system"`
+		DryRun     bool `long:"dry-run"     description:"list the unused stemcells that would be deleted, without deleting them"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/delete_unused_stemcells_service.go --fake-name DeleteUnusedStemcellsService . deleteUnusedStemcellsService
+type deleteUnusedStemcellsService interface {
+	DeleteStemcell(stemcellFilename string) error
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+}
+
+// unusedStemcell is an uploaded stemcell that no staged or deployed product
+// uses.
+type unusedStemcell struct {
+	filename string
+	os       string
+	version  *version.Version
+}
+
+func NewDeleteUnusedStemcells(service deleteUnusedStemcellsService, logger logger) *DeleteUnusedStemcells {
+	return &DeleteUnusedStemcells{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (dus DeleteUnusedStemcells) Execute(args []string) error {
+	if dus.Options.KeepNewest < 0 {
+		return errors.New("--keep-newest cannot be negative")
+	}
+
+	// Ops Manager does not report when a stemcell was uploaded, so the only
+	// retention rule is the number of stemcells to keep
+	if dus.Options.KeepNewest == 0 {
+		return errors.New("--keep-newest is required, the number of unused stemcells of each operating system to keep")
+	}
+
+	stemcells, err := dus.unusedStemcells()
+	if err != nil {
+		return err
+	}
+
+	deletable := dus.deletable(stemcells)
+	if len(deletable) == 0 {
+		dus.logger.Printf("no unused stemcells to delete")
+		return nil
+	}
+
+	action := "deleted"
+	if dus.Options.DryRun {
+		action = "would be deleted"
+	}
+
+	var (
+		summary strings.Builder
+		deleted int
+		failed  int
+	)
+	table := tabwriter.NewWriter(&summary, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STEMCELL\tSTATUS")
+	for _, stemcell := range deletable {
+		status := action
+		if !dus.Options.DryRun {
+			err := dus.service.DeleteStemcell(stemcell.filename)
+			if err != nil {
+				status = fmt.Sprintf("could not delete: %s", err)
+				failed++
+			}
+		}
+
+		if status == action {
+			deleted++
+		}
+
+		fmt.Fprintf(table, "%s\t%s\n", stemcell.filename, status)
+	}
+	_ = table.Flush()
+	dus.logger.Printf("%s", summary.String())
+
+	if dus.Options.DryRun {
+		dus.logger.Printf("%d stemcells would be deleted", deleted)
+	} else {
+		dus.logger.Printf("deleted %d stemcells", deleted)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("could not delete %d of %d unused stemcells", failed, len(deletable))
+	}
+
+	return nil
+}
+
+// unusedStemcells are the stemcells uploaded to Ops Manager that no staged or
+// deployed product uses.
+func (dus DeleteUnusedStemcells) unusedStemcells() ([]unusedStemcell, error) {
+	report, err := dus.service.GetDiagnosticReport()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diagnostic report: %w", err)
+	}
+
+	used := map[string]bool{}
+	for _, product := range append(report.StagedProducts, report.DeployedProducts...) {
+		if product.Stemcell != "" {
+			used[product.Stemcell] = true
+		}
+		for _, stemcell := range product.Stemcells {
+			used[stemcell.Filename] = true
+		}
+	}
+
+	// Ops Manager before 2.6 only lists the filenames of the stemcells, e.g.
+	// bosh-stemcell-621.74-vsphere-esxi-ubuntu-xenial-go_agent.tgz
+	available := report.AvailableStemcells
+	if len(available) == 0 {
+		for _, filename := range report.Stemcells {
+			stemcell := api.Stemcell{Filename: filename}
+			if matches := stemcellFilenamePattern.FindStringSubmatch(filename); matches != nil {
+				stemcell.Version, stemcell.OS = matches[1], matches[2]
+			}
+			available = append(available, stemcell)
+		}
+	}
+
+	var unused []unusedStemcell
+	for _, stemcell := range available {
+		if used[stemcell.Filename] {
+			continue
+		}
+
+		stemcellVersion, err := version.NewVersion(stemcell.Version)
+		if err != nil {
+			// without a version, it cannot be told whether it is among the newest
+			continue
+		}
+
+		unused = append(unused, unusedStemcell{
+			filename: stemcell.Filename,
+			os:       stemcell.OS,
+			version:  stemcellVersion,
+		})
+	}
+
+	return unused, nil
+}
+
+// deletable are the stemcells that are not among the newest --keep-newest of
+// their operating system.
+func (dus DeleteUnusedStemcells) deletable(stemcells []unusedStemcell) []unusedStemcell {
+	sort.SliceStable(stemcells, func(i, j int) bool {
+		if stemcells[i].os != stemcells[j].os {
+			return stemcells[i].os < stemcells[j].os
+		}
+		return stemcells[i].version.GreaterThan(stemcells[j].version)
+	})
+
+	var deletable []unusedStemcell
+	kept := map[string]int{}
+	for _, stemcell := range stemcells {
+		if kept[stemcell.os] < dus.Options.KeepNewest {
+			kept[stemcell.os]++
+			continue
+		}
+
+		deletable = append(deletable, stemcell)
+	}
+
+	return deletable
+}
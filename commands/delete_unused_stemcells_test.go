@@ -0,0 +1,121 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("DeleteUnusedStemcells", func() {
+	var (
+		service *fakes.DeleteUnusedStemcellsService
+		stdout  *gbytes.Buffer
+		command *commands.DeleteUnusedStemcells
+	)
+
+	BeforeEach(func() {
+		service = &fakes.DeleteUnusedStemcellsService{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewDeleteUnusedStemcells(service, log.New(stdout, "", 0))
+
+		service.GetDiagnosticReportReturns(api.DiagnosticReport{
+			AvailableStemcells: []api.Stemcell{
+				{Filename: "jammy-1.10.tgz", OS: "ubuntu-jammy", Version: "1.10"},
+				{Filename: "jammy-1.9.tgz", OS: "ubuntu-jammy", Version: "1.9"},
+				{Filename: "jammy-1.8.tgz", OS: "ubuntu-jammy", Version: "1.8"},
+				{Filename: "jammy-1.7.tgz", OS: "ubuntu-jammy", Version: "1.7"},
+				{Filename: "jammy-1.6.tgz", OS: "ubuntu-jammy", Version: "1.6"},
+				{Filename: "windows-2019.1.tgz", OS: "windows2019", Version: "2019.1"},
+			},
+			StagedProducts: []api.DiagnosticProduct{
+				{Name: "cf", Stemcells: []api.Stemcell{{Filename: "jammy-1.10.tgz"}}},
+			},
+			DeployedProducts: []api.DiagnosticProduct{
+				{Name: "cf", Stemcell: "jammy-1.7.tgz"},
+			},
+		}, nil)
+	})
+
+	It("deletes the unused stemcells, keeping the newest of each operating system", func() {
+		err := executeCommand(command, []string{"--keep-newest", "1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.DeleteStemcellCallCount()).To(Equal(2))
+		Expect(service.DeleteStemcellArgsForCall(0)).To(Equal("jammy-1.8.tgz"))
+		Expect(service.DeleteStemcellArgsForCall(1)).To(Equal("jammy-1.6.tgz"))
+
+		Expect(string(stdout.Contents())).To(MatchRegexp(`jammy-1.8.tgz\s+deleted\n`))
+		Expect(stdout).To(gbytes.Say("deleted 2 stemcells"))
+	})
+
+	It("does not delete anything with --dry-run", func() {
+		err := executeCommand(command, []string{"--keep-newest", "1", "--dry-run"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.DeleteStemcellCallCount()).To(Equal(0))
+		Expect(string(stdout.Contents())).To(MatchRegexp(`jammy-1.6.tgz\s+would be deleted\n`))
+		Expect(stdout).To(gbytes.Say("2 stemcells would be deleted"))
+	})
+
+	It("reads the versions from the stemcell filenames of Ops Manager before 2.6", func() {
+		service.GetDiagnosticReportReturns(api.DiagnosticReport{
+			Stemcells: []string{
+				"bosh-stemcell-621.74-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+				"bosh-stemcell-621.90-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+				"bosh-stemcell-621.80-vsphere-esxi-ubuntu-xenial-go_agent.tgz",
+				"custom.tgz",
+			},
+		}, nil)
+
+		err := executeCommand(command, []string{"--keep-newest", "1"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.DeleteStemcellCallCount()).To(Equal(2))
+		Expect(service.DeleteStemcellArgsForCall(0)).To(Equal("bosh-stemcell-621.80-vsphere-esxi-ubuntu-xenial-go_agent.tgz"))
+		Expect(service.DeleteStemcellArgsForCall(1)).To(Equal("bosh-stemcell-621.74-vsphere-esxi-ubuntu-xenial-go_agent.tgz"))
+	})
+
+	It("reports the stemcells that cannot be deleted, and deletes the others", func() {
+		service.DeleteStemcellReturnsOnCall(0, errors.New("in use"))
+
+		err := executeCommand(command, []string{"--keep-newest", "1"})
+		Expect(err).To(MatchError("could not delete 1 of 2 unused stemcells"))
+
+		Expect(service.DeleteStemcellCallCount()).To(Equal(2))
+		Expect(string(stdout.Contents())).To(MatchRegexp(`jammy-1.8.tgz\s+could not delete: in use\n`))
+		Expect(stdout).To(gbytes.Say("deleted 1 stemcells"))
+	})
+
+	It("reports when there is nothing to delete", func() {
+		err := executeCommand(command, []string{"--keep-newest", "3"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.DeleteStemcellCallCount()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("no unused stemcells to delete"))
+	})
+
+	It("returns an error when the diagnostic report cannot be read", func() {
+		service.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("some error"))
+
+		err := executeCommand(command, []string{"--keep-newest", "1"})
+		Expect(err).To(MatchError("failed to get diagnostic report: some error"))
+	})
+
+	It("requires the number of stemcells to keep", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("--keep-newest is required, the number of unused stemcells of each operating system to keep"))
+		Expect(service.GetDiagnosticReportCallCount()).To(Equal(0))
+	})
+
+	It("rejects a negative number of stemcells to keep", func() {
+		err := executeCommand(command, []string{"--keep-newest", "-1"})
+		Expect(err).To(MatchError("--keep-newest cannot be negative"))
+	})
+})
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+// DeployProduct runs the steps of deploying a tile. None of them changes
+// anything when it has been run before, so it can be run again after a
+// failure.
+type DeployProduct struct {
+	metadataExtractor metadataExtractor
+	service           deployProductService
+	uploadProduct     *UploadProduct
+	stageProduct      *StageProduct
+	configureProduct  *ConfigureProduct
+	assignStemcell    *AssignStemcell
+	applyChanges      *ApplyChanges
+	logger            logger
+	Options           struct {
+		Product        string   `long:"product"         short:"p" required:"true" description:"path to the product file"`
+		ConfigFile     string   `long:"config"          short:"c"                 description:"path to the config of the product (see docs/configure-product/README.md for format). The product is not configured without it"`
+		VarsFile       []string `long:"vars-file"       short:"l"                 description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
+		Vars           []string `long:"var"             short:"v"                 description:"load variable from the command line. Format: VAR=VAL"`
+		VarsEnv        []string `long:"vars-env"        env:"OM_VARS_ENV"         description:"load variables from environment variables (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
+		OpsFile        []string `long:"ops-file"        short:"o"                 description:"YAML operations file"`
+		Stemcell       string   `long:"stemcell"        short:"s"                 description:"stemcell to assign to the product, as for assign-stemcell: a version, 'latest', a glob or a constraint. No stemcell is assigned without it"`
+		ApplyChanges   bool     `long:"apply-changes"                             description:"apply the changes of the product, and of no other product, when it has pending changes"`
+		IgnoreWarnings bool     `long:"ignore-warnings" short:"i"                 description:"with --apply-changes, ignore the warnings of the verifiers"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/deploy_product_service.go --fake-name DeployProductService . deployProductService
+type deployProductService interface {
+	ListStagedPendingChanges() (api.PendingChangesOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+}
+
+func NewDeployProduct(metadataExtractor metadataExtractor, service deployProductService, uploadProduct *UploadProduct, stageProduct *StageProduct, configureProduct *ConfigureProduct, assignStemcell *AssignStemcell, applyChanges *ApplyChanges, logger logger) *DeployProduct {
+	return &DeployProduct{
+		metadataExtractor: metadataExtractor,
+		service:           service,
+		uploadProduct:     uploadProduct,
+		stageProduct:      stageProduct,
+		configureProduct:  configureProduct,
+		assignStemcell:    assignStemcell,
+		applyChanges:      applyChanges,
+		logger:            logger,
+	}
+}
+
+func (dp DeployProduct) Execute(args []string) error {
+	metadata, err := dp.metadataExtractor.ExtractFromFile(dp.Options.Product)
+	if err != nil {
+		return fmt.Errorf("failed to extract product metadata: %s", err)
+	}
+
+	dp.logger.Printf("deploying %s %s", metadata.Name, metadata.Version)
+
+	dp.uploadProduct.Options.Product = dp.Options.Product
+	dp.uploadProduct.Options.PollingInterval = 1
+	dp.uploadProduct.Options.SkipIfPresent = true
+	err = dp.uploadProduct.Execute(nil)
+	if err != nil {
+		return err
+	}
+
+	dp.stageProduct.Options.Product = metadata.Name
+	dp.stageProduct.Options.Version = metadata.Version
+	err = dp.stageProduct.Execute(nil)
+	if err != nil {
+		return err
+	}
+
+	if dp.Options.ConfigFile != "" {
+		dp.configureProduct.Options.ConfigFile = dp.Options.ConfigFile
+		dp.configureProduct.Options.VarsFile = dp.Options.VarsFile
+		dp.configureProduct.Options.Vars = dp.Options.Vars
+		dp.configureProduct.Options.VarsEnv = dp.Options.VarsEnv
+		dp.configureProduct.Options.OpsFile = dp.Options.OpsFile
+		err = dp.configureProduct.Execute(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dp.Options.Stemcell != "" {
+		dp.assignStemcell.Options.ProductName = metadata.Name
+		dp.assignStemcell.Options.StemcellVersion = dp.Options.Stemcell
+		err = dp.assignStemcell.Execute(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !dp.Options.ApplyChanges {
+		dp.logger.Printf("finished deploying %s %s, apply changes to deploy it", metadata.Name, metadata.Version)
+		return nil
+	}
+
+	pending, err := dp.hasPendingChanges(metadata.Name)
+	if err != nil {
+		return err
+	}
+
+	if !pending {
+		dp.logger.Printf("%s has no pending changes, not applying changes", metadata.Name)
+		return nil
+	}
+
+	dp.applyChanges.Options.ProductNames = []string{metadata.Name}
+	dp.applyChanges.Options.IgnoreWarnings = dp.Options.IgnoreWarnings
+	return dp.applyChanges.Execute(nil)
+}
+
+func (dp DeployProduct) hasPendingChanges(productName string) (bool, error) {
+	stagedProducts, err := dp.service.ListStagedProducts()
+	if err != nil {
+		return false, fmt.Errorf("could not list staged products: %s", err)
+	}
+
+	var guid string
+	for _, product := range stagedProducts.Products {
+		if product.Type == productName {
+			guid = product.GUID
+		}
+	}
+
+	pendingChanges, err := dp.service.ListStagedPendingChanges()
+	if err != nil {
+		return false, fmt.Errorf("could not list pending changes: %s", err)
+	}
+
+	for _, change := range pendingChanges.ChangeList {
+		if change.GUID == guid && change.Action != "unchanged" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
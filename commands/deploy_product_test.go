@@ -0,0 +1,176 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/formcontent"
+)
+
+var _ = Describe("DeployProduct", func() {
+	var (
+		metadataExtractor *fakes.MetadataExtractor
+		service           *fakes.DeployProductService
+		uploadService     *fakes.UploadProductService
+		stageService      *fakes.StageProductService
+		configureService  *fakes.ConfigureProductService
+		assignService     *fakes.AssignStemcellService
+		applyService      *fakes.ApplyChangesService
+		stdout            *gbytes.Buffer
+		command           *commands.DeployProduct
+		productFile       string
+	)
+
+	BeforeEach(func() {
+		metadataExtractor = &fakes.MetadataExtractor{}
+		metadataExtractor.ExtractFromFileReturns(&extractor.Metadata{Name: "cf", Version: "2.0.0"}, nil)
+
+		service = &fakes.DeployProductService{}
+		service.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+			{Type: "p-bosh", GUID: "p-bosh-guid"},
+			{Type: "cf", GUID: "cf-guid"},
+		}}, nil)
+
+		uploadService = &fakes.UploadProductService{}
+
+		stageService = &fakes.StageProductService{}
+		stageService.CheckProductAvailabilityReturns(true, nil)
+
+		configureService = &fakes.ConfigureProductService{}
+		configureService.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+			{Type: "cf", GUID: "cf-guid"},
+		}}, nil)
+
+		assignService = &fakes.AssignStemcellService{}
+		assignService.ListStemcellsReturns(api.ProductStemcells{Products: []api.ProductStemcell{
+			{GUID: "cf-guid", ProductName: "cf", AvailableVersions: []string{"1234.5", "1234.6"}},
+		}}, nil)
+
+		applyService = &fakes.ApplyChangesService{}
+		applyService.InfoReturns(api.Info{Version: "2.9"}, nil)
+		applyService.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+			{Type: "cf", GUID: "cf-guid"},
+		}}, nil)
+		applyService.CreateInstallationReturns(api.InstallationsServiceOutput{ID: 311}, nil)
+		applyService.GetInstallationReturns(api.InstallationsServiceOutput{Status: "succeeded"}, nil)
+
+		multipart := &fakes.Multipart{}
+		multipart.FinalizeReturns(formcontent.ContentSubmission{ContentLength: 10})
+
+		stdout = gbytes.NewBuffer()
+		logger := log.New(stdout, "", 0)
+		command = commands.NewDeployProduct(
+			metadataExtractor,
+			service,
+			commands.NewUploadProduct(multipart, metadataExtractor, uploadService, logger, nil, http.DefaultClient),
+			commands.NewStageProduct(stageService, logger),
+			commands.NewConfigureProduct(func() []string { return nil }, configureService, "", logger),
+			commands.NewAssignStemcell(assignService, logger),
+			commands.NewApplyChanges(applyService, &fakes.PendingChangesService{}, &fakes.LogWriter{}, logger, GinkgoWriter, 1),
+			logger,
+		)
+
+		productFile = writeTestConfigFile("some product")
+	})
+
+	It("uploads, stages and configures the product and assigns its stemcell", func() {
+		configFile := writeTestConfigFile("product-name: cf\n")
+
+		err := executeCommand(command, []string{"--product", productFile, "--config", configFile, "--stemcell", "latest"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(uploadService.UploadAvailableProductCallCount()).To(Equal(1))
+		Expect(uploadService.UploadAvailableProductArgsForCall(0).PollingInterval).To(Equal(1))
+
+		Expect(stageService.StageCallCount()).To(Equal(1))
+		input, _ := stageService.StageArgsForCall(0)
+		Expect(input).To(Equal(api.StageProductInput{ProductName: "cf", ProductVersion: "2.0.0"}))
+
+		Expect(stdout).To(gbytes.Say("configuring cf..."))
+		Expect(stdout).To(gbytes.Say("finished configuring product"))
+
+		Expect(assignService.AssignStemcellCallCount()).To(Equal(1))
+		Expect(assignService.AssignStemcellArgsForCall(0).Products[0].StagedStemcellVersion).To(Equal("1234.6"))
+
+		Expect(stdout).To(gbytes.Say("finished deploying cf 2.0.0, apply changes to deploy it"))
+		Expect(applyService.CreateInstallationCallCount()).To(Equal(0))
+	})
+
+	It("does not upload or stage the product again", func() {
+		uploadService.CheckProductAvailabilityReturns(true, nil)
+		stageService.GetDiagnosticReportReturns(api.DiagnosticReport{StagedProducts: []api.DiagnosticProduct{
+			{Name: "cf", Version: "2.0.0"},
+		}}, nil)
+
+		err := executeCommand(command, []string{"--product", productFile})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(uploadService.UploadAvailableProductCallCount()).To(Equal(0))
+		Expect(stageService.StageCallCount()).To(Equal(0))
+		Expect(configureService.ListInstallationsCallCount()).To(Equal(0))
+		Expect(assignService.AssignStemcellCallCount()).To(Equal(0))
+	})
+
+	When("--apply-changes is given", func() {
+		It("applies the changes of the product only", func() {
+			service.ListStagedPendingChangesReturns(api.PendingChangesOutput{ChangeList: []api.ProductChange{
+				{GUID: "p-bosh-guid", Action: "update"},
+				{GUID: "cf-guid", Action: "install"},
+			}}, nil)
+
+			err := executeCommand(command, []string{"--product", productFile, "--apply-changes", "--ignore-warnings"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(applyService.CreateInstallationCallCount()).To(Equal(1))
+			ignoreWarnings, deployProducts, _, productNames, _ := applyService.CreateInstallationArgsForCall(0)
+			Expect(ignoreWarnings).To(BeTrue())
+			Expect(deployProducts).To(BeTrue())
+			Expect(productNames).To(Equal([]string{"cf"}))
+		})
+
+		It("does not apply changes when the product has no pending changes", func() {
+			service.ListStagedPendingChangesReturns(api.PendingChangesOutput{ChangeList: []api.ProductChange{
+				{GUID: "p-bosh-guid", Action: "update"},
+				{GUID: "cf-guid", Action: "unchanged"},
+			}}, nil)
+
+			err := executeCommand(command, []string{"--product", productFile, "--apply-changes"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stdout).To(gbytes.Say("cf has no pending changes, not applying changes"))
+			Expect(applyService.CreateInstallationCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the pending changes cannot be listed", func() {
+			service.ListStagedPendingChangesReturns(api.PendingChangesOutput{}, errors.New("some error"))
+
+			err := executeCommand(command, []string{"--product", productFile, "--apply-changes"})
+			Expect(err).To(MatchError("could not list pending changes: some error"))
+		})
+	})
+
+	It("stops at the step that fails", func() {
+		stageService.CheckProductAvailabilityReturns(false, nil)
+
+		err := executeCommand(command, []string{"--product", productFile, "--stemcell", "latest"})
+		Expect(err).To(MatchError("failed to stage product: cannot find product cf 2.0.0"))
+
+		Expect(assignService.AssignStemcellCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the metadata of the product cannot be read", func() {
+		metadataExtractor.ExtractFromFileReturns(nil, errors.New("not a zip"))
+
+		err := executeCommand(command, []string{"--product", productFile})
+		Expect(err).To(MatchError("failed to extract product metadata: not a zip"))
+	})
+})
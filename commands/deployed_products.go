@@ -11,7 +11,7 @@ type DeployedProducts struct {
 	presenter presenters.FormattedPresenter
 	service   deployedProductsService
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
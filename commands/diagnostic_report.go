@@ -1,7 +1,12 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
 )
@@ -10,6 +15,7 @@ type DiagnosticReport struct {
 	presenter presenters.FormattedPresenter
 	service   diagnosticReportService
 	Options   struct {
+		Fields []string `long:"field" description:"only print this field of the report, as a dot-separated path (e.g. stemcells, added_products.deployed). Can be given multiple times"`
 	}
 }
 
@@ -31,8 +37,93 @@ func (dr DiagnosticReport) Execute(args []string) error {
 		return fmt.Errorf("failed to retrieve diagnostic-report %s", err)
 	}
 
+	if len(dr.Options.Fields) > 0 {
+		diagnosticReport.FullReport, err = selectDiagnosticReportFields(diagnosticReport.FullReport, dr.Options.Fields)
+		if err != nil {
+			return err
+		}
+	}
+
 	dr.presenter.SetFormat("json")
 	dr.presenter.PresentDiagnosticReport(diagnosticReport)
 
 	return nil
 }
+
+// selectDiagnosticReportFields returns the value of a single field, or an
+// object with the value of each field under its path.
+func selectDiagnosticReportFields(fullReport string, fields []string) (string, error) {
+	var report interface{}
+	err := json.Unmarshal([]byte(fullReport), &report)
+	if err != nil {
+		return "", fmt.Errorf("could not parse the diagnostic report: %s", err)
+	}
+
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		value, err := diagnosticReportField(report, strings.Split(field, "."))
+		if err != nil {
+			return "", fmt.Errorf("could not select field %q of the diagnostic report: %s", field, err)
+		}
+
+		values[field] = value
+	}
+
+	var selected interface{} = values
+	if len(fields) == 1 {
+		selected = values[fields[0]]
+	}
+
+	contents, err := json.MarshalIndent(selected, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents) + "\n", nil
+}
+
+// diagnosticReportField follows the path through the report. A name in the
+// path applied to a list selects it from every element of the list.
+func diagnosticReportField(value interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		child, ok := v[path[0]]
+		if !ok {
+			var keys []string
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			return nil, fmt.Errorf("%q does not exist, available fields are: %s", path[0], strings.Join(keys, ", "))
+		}
+
+		return diagnosticReportField(child, path[1:])
+	case []interface{}:
+		if index, err := strconv.Atoi(path[0]); err == nil {
+			if index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("index %d is out of range for a list of %d elements", index, len(v))
+			}
+
+			return diagnosticReportField(v[index], path[1:])
+		}
+
+		values := []interface{}{}
+		for _, element := range v {
+			child, err := diagnosticReportField(element, path)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, child)
+		}
+
+		return values, nil
+	default:
+		return nil, fmt.Errorf("%q does not exist, the value is not an object", path[0])
+	}
+}
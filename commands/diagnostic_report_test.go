@@ -44,6 +44,48 @@ var _ = Describe("DiagnosticReport", func() {
 		})
 	})
 
+	Describe("selecting fields", func() {
+		BeforeEach(func() {
+			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{
+				FullReport: `{
+					"infrastructure_type": "vsphere",
+					"stemcells": ["light-bosh-stemcell-621.77-google-kvm-ubuntu-xenial-go_agent.tgz"],
+					"added_products": {
+						"deployed": [
+							{"name": "p-bosh", "version": "2.10.0", "stemcells": []},
+							{"name": "cf", "version": "2.11.3", "stemcells": []}
+						]
+					}
+				}`,
+			}, nil)
+		})
+
+		It("presents just the value of a field", func() {
+			err := executeCommand(command, []string{"--field", "stemcells"})
+			Expect(err).ToNot(HaveOccurred())
+
+			report := presenter.PresentDiagnosticReportArgsForCall(0)
+			Expect(report.FullReport).To(MatchJSON(`["light-bosh-stemcell-621.77-google-kvm-ubuntu-xenial-go_agent.tgz"]`))
+		})
+
+		It("presents fields of every element of a list", func() {
+			err := executeCommand(command, []string{"--field", "added_products.deployed.version", "--field", "added_products.deployed.0.name"})
+			Expect(err).ToNot(HaveOccurred())
+
+			report := presenter.PresentDiagnosticReportArgsForCall(0)
+			Expect(report.FullReport).To(MatchJSON(`{
+				"added_products.deployed.version": ["2.10.0", "2.11.3"],
+				"added_products.deployed.0.name": "p-bosh"
+			}`))
+		})
+
+		It("returns an error when a field does not exist", func() {
+			err := executeCommand(command, []string{"--field", "added_products.staged"})
+			Expect(err).To(MatchError(`could not select field "added_products.staged" of the diagnostic report: "staged" does not exist, available fields are: deployed`))
+			Expect(presenter.PresentDiagnosticReportCallCount()).To(Equal(0))
+		})
+	})
+
 	When("fetching the diagnostic report fails", func() {
 		It("returns an error", func() {
 			fakeService.GetDiagnosticReportReturns(api.DiagnosticReport{}, errors.New("beep boop"))
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+const downloadPlanFileName = "download-plan.json"
+
+// downloadPlan lists the files download-product would download, so they can
+// be downloaded somewhere else and checked with verify-downloads once they
+// have been carried over to an air-gapped environment.
+type downloadPlan struct {
+	Products []downloadPlanProduct `json:"products"`
+}
+
+type downloadPlanProduct struct {
+	Slug            string             `json:"slug"`
+	Version         string             `json:"version"`
+	Source          string             `json:"source"`
+	StemcellSlug    string             `json:"stemcell_slug,omitempty"`
+	StemcellVersion string             `json:"stemcell_version,omitempty"`
+	Files           []downloadPlanFile `json:"files"`
+}
+
+type downloadPlanFile struct {
+	Name     string `json:"name"`
+	Glob     string `json:"glob"`
+	SHA256   string `json:"sha256,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Stemcell bool   `json:"stemcell,omitempty"`
+}
+
+func newDownloadPlanFile(fileArtifact download_clients.FileArtifacter, glob, path string, stemcell bool) downloadPlanFile {
+	file := downloadPlanFile{
+		Name:     filepath.Base(path),
+		Glob:     glob,
+		SHA256:   fileArtifact.SHA256(),
+		Stemcell: stemcell,
+	}
+	if sizer, ok := fileArtifact.(download_clients.FileSizer); ok {
+		file.Size = sizer.Size()
+	}
+
+	return file
+}
+
+// planDownload resolves the files of the product, and of its stemcell, the
+// same way as downloading them, and adds them to the plan in the output
+// directory instead.
+func (c *DownloadProduct) planDownload(productVersion string) error {
+	slug := c.Options.PivnetProductSlug
+	product := downloadPlanProduct{
+		Slug:    slug,
+		Version: productVersion,
+		Source:  c.downloadClient.Name(),
+	}
+
+	var (
+		tile     download_clients.FileArtifacter
+		tilePath string
+	)
+
+	seen := map[string]bool{}
+	for _, glob := range c.Options.FileGlob {
+		fileArtifacts, err := c.downloadClient.GetLatestProductFiles(slug, productVersion, glob)
+		if err != nil {
+			return fmt.Errorf("could not plan product: %s", err)
+		}
+
+		for _, fileArtifact := range fileArtifacts {
+			if seen[fileArtifact.Name()] {
+				continue
+			}
+			seen[fileArtifact.Name()] = true
+
+			path := c.productFilePath(fileArtifact, fmt.Sprintf("[%s,%s]", slug, productVersion), c.Options.OutputDir)
+			product.Files = append(product.Files, newDownloadPlanFile(fileArtifact, glob, path, false))
+
+			// as when downloading, the stemcell is the one of the first tile
+			if tile == nil || (filepath.Ext(tilePath) != ".pivotal" && filepath.Ext(path) == ".pivotal") {
+				tile, tilePath = fileArtifact, path
+			}
+		}
+	}
+
+	if c.Options.StemcellIaas != "" {
+		err := c.planStemcell(&product, tile, tilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.writeDownloadPlan(product)
+}
+
+func (c *DownloadProduct) planStemcell(product *downloadPlanProduct, tile download_clients.FileArtifacter, tilePath string) error {
+	if filepath.Ext(tilePath) != ".pivotal" {
+		c.stderr.Printf("the planned file is not a .pivotal file. Not determining the required stemcell.")
+		return nil
+	}
+
+	// the sources other than pivnet read the stemcell from the tile, which is
+	// only there when it has been downloaded before
+	stemcell, err := c.downloadClient.GetLatestStemcellForProduct(tile, tilePath, c.Options.StemcellSlug)
+	if err != nil {
+		c.stderr.Printf("could not determine the required stemcell, the plan does not include it: %s", err)
+		return nil
+	}
+
+	stemcellVersion := stemcell.Version()
+	if c.Options.StemcellVersion != "" {
+		stemcellVersion = c.Options.StemcellVersion
+	}
+
+	stemcellOutputDirectory := c.Options.StemcellOutputDir
+	if stemcellOutputDirectory == "" {
+		stemcellOutputDirectory = c.Options.OutputDir
+	}
+
+	var fileArtifact download_clients.FileArtifacter
+	var glob string
+	for _, glob = range stemcellFileGlobs(c.Options.StemcellIaas, c.Options.StemcellHeavy) {
+		fileArtifact, err = c.downloadClient.GetLatestProductFile(stemcell.Slug(), stemcellVersion, glob)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("could not plan stemcell: %s", err)
+	}
+
+	path := c.productFilePath(fileArtifact, fmt.Sprintf("[%s,%s]", stemcell.Slug(), stemcellVersion), stemcellOutputDirectory)
+
+	product.StemcellSlug = stemcell.Slug()
+	product.StemcellVersion = stemcellVersion
+	product.Files = append(product.Files, newDownloadPlanFile(fileArtifact, glob, path, true))
+
+	return nil
+}
+
+// writeDownloadPlan adds the product to the plan in the output directory,
+// replacing an earlier plan of the same product, so the plans of several
+// products can share a directory.
+func (c *DownloadProduct) writeDownloadPlan(product downloadPlanProduct) error {
+	planPath := filepath.Join(c.Options.OutputDir, downloadPlanFileName)
+
+	plan, err := readDownloadPlan(planPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	replaced := false
+	for i := range plan.Products {
+		if plan.Products[i].Slug == product.Slug {
+			plan.Products[i] = product
+			replaced = true
+		}
+	}
+	if !replaced {
+		plan.Products = append(plan.Products, product)
+	}
+
+	c.stderr.Printf("Writing the plan of %d files to %s", len(product.Files), planPath)
+
+	contents, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode JSON for %s: %s", downloadPlanFileName, err)
+	}
+
+	err = os.WriteFile(planPath, append(contents, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("could not write %s: %s", planPath, err)
+	}
+
+	return nil
+}
+
+func readDownloadPlan(path string) (downloadPlan, error) {
+	var plan downloadPlan
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return plan, fmt.Errorf("could not read download plan: %w", err)
+	}
+
+	err = json.Unmarshal(contents, &plan)
+	if err != nil {
+		return plan, fmt.Errorf("could not parse download plan %s: %w", path, err)
+	}
+
+	return plan, nil
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pivotal-cf/om/download_clients"
 	"github.com/pivotal-cf/om/extractor"
@@ -16,38 +18,73 @@ import (
 )
 
 type PivnetOptions struct {
-	PivnetProductSlug   string `long:"pivnet-product-slug"   short:"p"                          description:"path to product" required:"true"`
-	PivnetDisableSSL    bool   `long:"pivnet-disable-ssl"                                       description:"whether to disable ssl validation when contacting the Pivotal Network"`
-	PivnetToken         string `long:"pivnet-api-token"      short:"t"                          description:"API token to use when interacting with Pivnet. Can be retrieved from your profile page in Pivnet."`
-	PivnetHost          string `long:"pivnet-host" description:"the API endpoint for Pivotal Network" default:"https://network.pivotal.io"`
-	FileGlob            string `long:"file-glob"             short:"f"  description:"glob to match files within Pivotal Network product to be downloaded."`
-	ProductVersion      string `long:"product-version"                                          description:"version of the product-slug to download files from. Incompatible with --product-version-regex flag."`
-	ProductVersionRegex string `long:"product-version-regex" short:"r"                          description:"regex pattern matching versions of the product-slug to download files from. Highest-versioned match will be used. Incompatible with --product-version flag."`
+	PivnetProductSlug        string   `long:"pivnet-product-slug"   short:"p"                          description:"path to product" required:"true"`
+	PivnetDisableSSL         bool     `long:"pivnet-disable-ssl"                                       description:"whether to disable ssl validation when contacting the Pivotal Network"`
+	PivnetToken              string   `long:"pivnet-api-token"      short:"t"                          description:"API token to use when interacting with Pivnet. Can be retrieved from your profile page in Pivnet."`
+	PivnetHost               string   `long:"pivnet-host" description:"the API endpoint for Pivotal Network" default:"https://network.pivotal.io"`
+	FileGlob                 []string `long:"file-glob"             short:"f"  description:"glob to match files within Pivotal Network product to be downloaded. Can be repeated to download every file matching any of the globs."`
+	ProductVersion           string   `long:"product-version"                                          description:"version of the product-slug to download files from. Incompatible with --product-version-regex flag."`
+	ProductVersionRegex      string   `long:"product-version-regex" short:"r"                          description:"regex pattern matching versions of the product-slug to download files from. Highest-versioned match will be used. Incompatible with --product-version flag."`
+	ProductVersionConstraint string   `long:"product-version-constraint"                               description:"semver constraint (e.g. '~> 2.13' or '>= 4.0, < 5') matching versions of the product-slug to download files from. Highest-versioned match will be used. Incompatible with --product-version and --product-version-regex flags."`
 
 	PivnetFileGlobSupport string `long:"pivnet-file-glob" hidden:"true"`
 }
 
+type BroadcomOptions struct {
+	BroadcomHost       string `long:"broadcom-host"        description:"the API endpoint for the Broadcom Support Portal" default:"https://support.broadcom.com"`
+	BroadcomToken      string `long:"broadcom-api-token"   description:"API token generated on the Broadcom Support Portal"`
+	BroadcomAcceptEULA bool   `long:"broadcom-accept-eula" description:"accept the EULA of the release being downloaded if it has not been accepted yet"`
+	BroadcomDisableSSL bool   `long:"broadcom-disable-ssl" description:"whether to disable ssl validation when contacting the Broadcom Support Portal"`
+}
+
 type GCSOptions struct {
 	GCSServiceAccountJSON string `long:"gcs-service-account-json" description:"the service account key JSON"`
 	GCSProjectID          string `long:"gcs-project-id"           description:"the project id for the bucket's gcp account"`
+	GCSAuthType           string `long:"gcs-auth-type"            description:"can be set to \"adc\" in order to use Application Default Credentials, such as GKE Workload Identity, instead of a service account key" default:"serviceaccount"`
 
 	GCPServiceAccountSupport string `long:"gcp-service-account-json" hidden:"true"`
 	GCPProjectIDSupport      string `long:"gcp-project-id"           hidden:"true"`
 }
 
 type S3Options struct {
-	S3AccessKeyID     string `long:"s3-access-key-id"                 description:"access key for the s3 compatible blobstore"`
-	S3AuthType        string `long:"s3-auth-type"                     description:"can be set to \"iam\" in order to allow use of instance credentials" default:"accesskey"`
-	S3SecretAccessKey string `long:"s3-secret-access-key"             description:"secret key for the s3 compatible blobstore"`
-	S3RegionName      string `long:"s3-region-name"                   description:"bucket region in the s3 compatible blobstore. If not using AWS, this value is 'region'"`
-	S3Endpoint        string `long:"s3-endpoint"                      description:"the endpoint to access the s3 compatible blobstore. If not using AWS, this is required"`
-	S3DisableSSL      bool   `long:"s3-disable-ssl"                   description:"whether to disable ssl (https or http) when contacting the s3 compatible blobstore"`
-	S3EnableV2Signing bool   `long:"s3-enable-v2-signing"             description:"whether to use v2 signing with your s3 compatible blobstore. (if you don't know what this is, leave blank, or set to 'false')"`
+	S3AccessKeyID          string `long:"s3-access-key-id"                 description:"access key for the s3 compatible blobstore"`
+	S3AuthType             string `long:"s3-auth-type"                     description:"can be set to \"iam\" in order to allow use of instance credentials" default:"accesskey"`
+	S3SecretAccessKey      string `long:"s3-secret-access-key"             description:"secret key for the s3 compatible blobstore"`
+	S3SessionToken         string `long:"s3-session-token"                 description:"session token to use with temporary access and secret keys"`
+	S3RoleARN              string `long:"s3-role-arn"                      description:"ARN of a role to assume through STS before accessing the bucket"`
+	S3ExternalID           string `long:"s3-external-id"                   description:"external ID to pass when assuming --s3-role-arn"`
+	S3RegionName           string `long:"s3-region-name"                   description:"bucket region in the s3 compatible blobstore. If not using AWS, this value is 'region'"`
+	S3Endpoint             string `long:"s3-endpoint"                      description:"the endpoint to access the s3 compatible blobstore. If not using AWS, this is required"`
+	S3DisableSSL           bool   `long:"s3-disable-ssl"                   description:"whether to disable ssl (https or http) when contacting the s3 compatible blobstore"`
+	S3EnableV2Signing      bool   `long:"s3-enable-v2-signing"             description:"whether to use v2 signing with your s3 compatible blobstore. (if you don't know what this is, leave blank, or set to 'false')"`
+	S3CACert               string `long:"s3-ca-cert"                       description:"CA certificate path or value to trust when contacting the s3 compatible blobstore"`
+	S3AddressingStyle      string `long:"s3-addressing-style"              description:"whether the bucket goes in the path or the host name of requests. 'auto' uses path-style for a custom --s3-endpoint" choice:"auto" choice:"path" choice:"virtual" default:"auto"`
+	S3SkipRegionValidation bool   `long:"s3-skip-region-validation"        description:"do not check the bucket can be reached in --s3-region-name, for s3 compatible blobstores that do not implement regions"`
+}
+
+type ArtifactoryOptions struct {
+	ArtifactoryURL             string `long:"artifactory-url"              description:"base URL of the Artifactory instance, e.g. https://artifactory.corp/artifactory"`
+	ArtifactoryRepository      string `long:"artifactory-repository"       description:"the Artifactory repository where the artifacts are stored"`
+	ArtifactoryAPIKey          string `long:"artifactory-api-key"          description:"API key for Artifactory. Incompatible with --artifactory-token"`
+	ArtifactoryToken           string `long:"artifactory-token"            description:"access token for Artifactory. Incompatible with --artifactory-api-key"`
+	ArtifactoryDisableSSL      bool   `long:"artifactory-disable-ssl"      description:"whether to disable ssl validation when contacting Artifactory"`
+	ArtifactoryPathLayout      string `long:"artifactory-path-layout"      description:"folder within the repository holding the files of a product version, with {slug} and {version} substituted" default:"{slug}/{version}"`
+	ArtifactorySlugProperty    string `long:"artifactory-slug-property"    description:"property holding the product slug, used with --artifactory-version-property" default:"product.slug"`
+	ArtifactoryVersionProperty string `long:"artifactory-version-property" description:"property holding the product version. When set, versions and files are found by property search instead of the path layout"`
 }
 
 type AzureOptions struct {
-	AzureStorageAccount string `long:"azure-storage-account" description:"the name of the storage account where the container exists"`
-	AzureKey            string `long:"azure-storage-key"     description:"the access key for the storage account"`
+	AzureStorageAccount string `long:"azure-storage-account"   description:"the name of the storage account where the container exists"`
+	AzureKey            string `long:"azure-storage-key"       description:"the access key for the storage account. Incompatible with --azure-sas-token"`
+	AzureSASToken       string `long:"azure-sas-token"         env:"AZURE_STORAGE_SAS_TOKEN"       description:"a SAS token granting read and list access to the container. Incompatible with --azure-storage-key"`
+	AzureEndpointSuffix string `long:"azure-endpoint-suffix"   env:"AZURE_STORAGE_ENDPOINT_SUFFIX" description:"the storage endpoint suffix of a sovereign cloud, e.g. core.usgovcloudapi.net for Azure Government or core.chinacloudapi.cn for Azure China"`
+}
+
+type OCIOptions struct {
+	OCIRepository string `long:"oci-repository" description:"registry and repository prefix where artifacts are stored, e.g. harbor.corp/tiles. Artifacts are pulled from <oci-repository>/<pivnet-product-slug>:<product-version>"`
+	OCIUsername   string `long:"oci-username"   description:"username for the OCI registry. If not provided, credentials are read from the docker config and its credential helpers"`
+	OCIPassword   string `long:"oci-password"   description:"password for the OCI registry"`
+	OCIInsecure   bool   `long:"oci-insecure"   description:"allow connecting to the OCI registry over http or with an untrusted certificate"`
 }
 
 type StemcellOptions struct {
@@ -58,7 +95,7 @@ type StemcellOptions struct {
 }
 
 type DownloadProductOptions struct {
-	Source            string `long:"source"                     short:"s" description:"enables download from external sources when set to [s3|gcs|azure|pivnet]" default:"pivnet"`
+	Source            string `long:"source"                     short:"s" description:"enables download from external sources when set to [s3|gcs|azure|oci|artifactory|broadcom|pivnet]" default:"pivnet"`
 	OutputDir         string `long:"output-directory"           short:"o" description:"directory path to which the file will be outputted. File Name will be preserved from Pivotal Network" required:"true"`
 	StemcellOutputDir string `long:"stemcell-output-directory" short:"d" description:"directory path to which the stemcell file will be outputted. If not provided, output-directory will be used."`
 
@@ -67,6 +104,8 @@ type DownloadProductOptions struct {
 	StemcellPath         string `long:"blobstore-stemcell-path" description:"specify the lookup path where the s3|gcs|azure stemcell artifacts are stored"`
 	CacheCleanup         string `long:"cache-cleanup" env:"CACHE_CLEANUP" description:"Delete everything except the latest artifact in output-dir and stemcell-output-dir, set to 'I acknowledge this will delete files in the output directories' to accept these terms"`
 	CheckAlreadyUploaded bool   `long:"check-already-uploaded" description:"Check if product is already uploaded on Ops Manager before downloading. This command is authenticated."`
+	ParallelConnections  int    `long:"parallel-connections" description:"number of concurrent ranged connections used to download each file from s3|gcs|azure, when the blobstore publishes its md5 to verify it with (pivnet downloads are always parallelized)" default:"1"`
+	PlanOnly             bool   `long:"plan-only" description:"write the files that would be downloaded, with their versions, sizes and shas, to download-plan.json in the output directory without downloading them. Check the files with verify-downloads"`
 
 	S3BucketSupport          string `long:"s3-bucket" hidden:"true"`
 	GCSBucketSupport         string `long:"gcs-bucket" hidden:"true"`
@@ -78,9 +117,12 @@ type DownloadProductOptions struct {
 	GCSStemcellPathSupport   string `long:"gcs-stemcell-path" hidden:"true"`
 	AzureStemcellPathSupport string `long:"azure-stemcell-path" hidden:"true"`
 
+	ArtifactoryOptions
 	AzureOptions
+	BroadcomOptions
 	GCSOptions
 	InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
+	OCIOptions
 	PivnetOptions
 	S3Options
 	StemcellOptions
@@ -128,7 +170,11 @@ func (c *DownloadProduct) Execute(args []string) error {
 		return err
 	}
 
-	productFileName, productFileArtifact, err := c.downloadProductFile(
+	if c.Options.PlanOnly {
+		return c.planDownload(productVersion)
+	}
+
+	artifacts, err := c.downloadProductFiles(
 		c.Options.PivnetProductSlug,
 		productVersion,
 		c.Options.FileGlob,
@@ -139,13 +185,23 @@ func (c *DownloadProduct) Execute(args []string) error {
 		return fmt.Errorf("could not download product: %s", err)
 	}
 
+	// the first tile downloaded is the one the stemcell and
+	// assign-stemcell input are determined for
+	productFileName, productFileArtifact := artifacts[0].Path, artifacts[0].artifact
+	for _, artifact := range artifacts {
+		if filepath.Ext(artifact.Path) == ".pivotal" {
+			productFileName, productFileArtifact = artifact.Path, artifact.artifact
+			break
+		}
+	}
+
 	if c.Options.StemcellIaas == "" {
-		return c.writeDownloadProductOutput(productFileName, productVersion, "", "")
+		return c.writeDownloadProductOutput(productFileName, productVersion, "", "", artifacts)
 	}
 
 	if filepath.Ext(productFileName) != ".pivotal" {
 		c.stderr.Printf("the downloaded file is not a .pivotal file. Not determining and fetching required stemcell.")
-		return c.writeDownloadProductOutput(productFileName, productVersion, "", "")
+		return c.writeDownloadProductOutput(productFileName, productVersion, "", "", artifacts)
 	}
 
 	stemcellVersion, stemcellFileName, err := c.downloadStemcell(productFileName, productVersion, productFileArtifact, c.Options.StemcellSlug)
@@ -153,7 +209,7 @@ func (c *DownloadProduct) Execute(args []string) error {
 		return err
 	}
 
-	err = c.writeDownloadProductOutput(productFileName, productVersion, stemcellFileName, stemcellVersion)
+	err = c.writeDownloadProductOutput(productFileName, productVersion, stemcellFileName, stemcellVersion, artifacts)
 	if err != nil {
 		return err
 	}
@@ -169,16 +225,7 @@ func (c *DownloadProduct) downloadStemcell(productFileName string, productVersio
 		return "", "", fmt.Errorf("could not get information about stemcell: %s", err)
 	}
 
-	stemcellGlobs := []string{
-		fmt.Sprintf("light*bosh*%s*", c.Options.StemcellIaas),
-		fmt.Sprintf("bosh*%s*", c.Options.StemcellIaas),
-	}
-
-	if c.Options.StemcellHeavy {
-		stemcellGlobs = []string{
-			fmt.Sprintf("bosh*%s*", c.Options.StemcellIaas),
-		}
-	}
+	stemcellGlobs := stemcellFileGlobs(c.Options.StemcellIaas, c.Options.StemcellHeavy)
 
 	stemcellVersion := stemcell.Version()
 	if c.Options.StemcellVersion != "" {
@@ -218,11 +265,25 @@ func (c *DownloadProduct) downloadStemcell(productFileName string, productVersio
 	return stemcellVersion, stemcellFileName, nil
 }
 
+// stemcellFileGlobs returns the globs to try, in order, to find the stemcell for
+// the IaaS. Light stemcells are preferred unless a heavy one is asked for.
+func stemcellFileGlobs(iaas string, heavy bool) []string {
+	if heavy {
+		return []string{fmt.Sprintf("bosh*%s*", iaas)}
+	}
+
+	return []string{
+		fmt.Sprintf("light*bosh*%s*", iaas),
+		fmt.Sprintf("bosh*%s*", iaas),
+	}
+}
+
 func (c *DownloadProduct) determineProductVersion() (string, error) {
 	return download_clients.DetermineProductVersion(
 		c.Options.PivnetProductSlug,
 		c.Options.ProductVersion,
 		c.Options.ProductVersionRegex,
+		c.Options.ProductVersionConstraint,
 		c.downloadClient,
 		c.stderr,
 	)
@@ -241,7 +302,7 @@ func (c *DownloadProduct) createClient() error {
 func (c *DownloadProduct) validate() error {
 	c.handleAliases()
 
-	if c.Options.FileGlob == "" {
+	if len(c.Options.FileGlob) == 0 {
 		return errors.New("--file-glob is required")
 	}
 
@@ -249,14 +310,26 @@ func (c *DownloadProduct) validate() error {
 		return errors.New("cannot use both --product-version and --product-version-regex; please choose one or the other")
 	}
 
-	if c.Options.ProductVersionRegex == "" && c.Options.ProductVersion == "" {
-		return errors.New("no version information provided; please provide either --product-version or --product-version-regex")
+	if c.Options.ProductVersionConstraint != "" && (c.Options.ProductVersion != "" || c.Options.ProductVersionRegex != "") {
+		return errors.New("cannot use --product-version-constraint with --product-version or --product-version-regex; please choose one")
+	}
+
+	if c.Options.ProductVersionRegex == "" && c.Options.ProductVersion == "" && c.Options.ProductVersionConstraint == "" {
+		return errors.New("no version information provided; please provide either --product-version, --product-version-regex or --product-version-constraint")
 	}
 
 	if c.Options.PivnetToken == "" && c.Options.Source == "pivnet" {
 		return errors.New(`could not execute "download-product": could not parse download-product flags: missing required flag "--pivnet-api-token"`)
 	}
 
+	if c.Options.BroadcomToken == "" && c.Options.Source == "broadcom" {
+		return errors.New(`could not execute "download-product": could not parse download-product flags: missing required flag "--broadcom-api-token"`)
+	}
+
+	if c.Options.ParallelConnections < 1 {
+		return errors.New("--parallel-connections must be at least 1")
+	}
+
 	if c.Options.StemcellHeavy && c.Options.StemcellIaas == "" {
 		return errors.New("--stemcell-heavy requires --stemcell-iaas to be defined")
 	}
@@ -317,7 +390,7 @@ func (c *DownloadProduct) handleAliases() {
 		c.Options.StemcellPath = c.Options.AzureStemcellPathSupport
 	}
 	if c.Options.PivnetFileGlobSupport != "" {
-		c.Options.FileGlob = c.Options.PivnetFileGlobSupport
+		c.Options.FileGlob = []string{c.Options.PivnetFileGlobSupport}
 	}
 	if c.Options.GCPServiceAccountSupport != "" {
 		c.Options.GCSServiceAccountJSON = c.Options.GCPServiceAccountSupport
@@ -327,7 +400,16 @@ func (c *DownloadProduct) handleAliases() {
 	}
 }
 
-func (c DownloadProduct) writeDownloadProductOutput(productFileName string, productVersion string, stemcellFileName string, stemcellVersion string) error {
+type downloadedArtifact struct {
+	Glob   string `json:"glob"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256,omitempty"`
+
+	artifact download_clients.FileArtifacter
+}
+
+func (c DownloadProduct) writeDownloadProductOutput(productFileName string, productVersion string, stemcellFileName string, stemcellVersion string, artifacts []downloadedArtifact) error {
 	downloadProductFilename := "download-file.json"
 	c.stderr.Printf("Writing a list of downloaded artifact to %s", downloadProductFilename)
 	downloadProductPayload := struct {
@@ -336,12 +418,15 @@ func (c DownloadProduct) writeDownloadProductOutput(productFileName string, prod
 		ProductVersion  string `json:"product_version,omitempty"`
 		StemcellPath    string `json:"stemcell_path,omitempty"`
 		StemcellVersion string `json:"stemcell_version,omitempty"`
+
+		Artifacts []downloadedArtifact `json:"artifacts,omitempty"`
 	}{
 		ProductPath:     productFileName,
 		StemcellPath:    stemcellFileName,
 		ProductSlug:     c.Options.PivnetProductSlug,
 		ProductVersion:  productVersion,
 		StemcellVersion: stemcellVersion,
+		Artifacts:       artifacts,
 	}
 
 	outputFile, err := os.Create(filepath.Join(c.Options.OutputDir, downloadProductFilename))
@@ -413,13 +498,64 @@ func (c *DownloadProduct) downloadProductFile(slug, version, glob, prefixPath st
 		return "", nil, err
 	}
 
-	var productFilePath string
+	productFilePath := c.productFilePath(fileArtifact, prefixPath, outputDir)
+
+	return c.downloadArtifact(fileArtifact, slug, glob, productFilePath, outputDir, []string{productFilePath})
+}
+
+// downloadProductFiles downloads every file matching any of the globs. All
+// destination paths are determined up front so that the cache cleanup of one
+// glob does not remove a file matched by another.
+func (c *DownloadProduct) downloadProductFiles(slug, version string, globs []string, prefixPath string, outputDir string) ([]downloadedArtifact, error) {
+	var (
+		artifacts []downloadedArtifact
+		keepPaths []string
+	)
+
+	seen := map[string]bool{}
+	for _, glob := range globs {
+		fileArtifacts, err := c.downloadClient.GetLatestProductFiles(slug, version, glob)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fileArtifact := range fileArtifacts {
+			if seen[fileArtifact.Name()] {
+				continue
+			}
+			seen[fileArtifact.Name()] = true
+
+			productFilePath := c.productFilePath(fileArtifact, prefixPath, outputDir)
+			keepPaths = append(keepPaths, productFilePath)
+			artifacts = append(artifacts, downloadedArtifact{
+				Glob:     glob,
+				Name:     fileArtifact.Name(),
+				Path:     productFilePath,
+				SHA256:   fileArtifact.SHA256(),
+				artifact: fileArtifact,
+			})
+		}
+	}
+
+	for _, artifact := range artifacts {
+		_, _, err := c.downloadArtifact(artifact.artifact, slug, artifact.Glob, artifact.Path, outputDir, keepPaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return artifacts, nil
+}
+
+func (c *DownloadProduct) productFilePath(fileArtifact download_clients.FileArtifacter, prefixPath string, outputDir string) string {
 	if c.Options.Source != "pivnet" || c.Options.Bucket == "" {
-		productFilePath = filepath.Join(outputDir, filepath.Base(fileArtifact.Name()))
-	} else {
-		productFilePath = filepath.Join(outputDir, prefixPath+filepath.Base(fileArtifact.Name()))
+		return filepath.Join(outputDir, filepath.Base(fileArtifact.Name()))
 	}
 
+	return filepath.Join(outputDir, prefixPath+filepath.Base(fileArtifact.Name()))
+}
+
+func (c *DownloadProduct) downloadArtifact(fileArtifact download_clients.FileArtifacter, slug, glob, productFilePath string, outputDir string, keepPaths []string) (string, download_clients.FileArtifacter, error) {
 	c.stderr.Printf("attempting to download the file %s from source %s", fileArtifact.Name(), c.downloadClient.Name())
 
 	// check for already downloaded file
@@ -469,7 +605,22 @@ func (c *DownloadProduct) downloadProductFile(slug, version, glob, prefixPath st
 	if exist {
 		c.stderr.Printf("%s already exists, skip downloading", productFilePath)
 
-		err = c.cleanupCacheArtifacts(outputDir, glob, productFilePath, slug)
+		// a file left behind by an earlier run must match what the source
+		// publishes now. Without a published sha there is nothing to verify,
+		// and the file kept the sha written when it was downloaded.
+		if fileArtifact.SHA256() != "" {
+			calculatedSum, err := c.verifyChecksum(productFilePath, fileArtifact.SHA256())
+			if err != nil {
+				return "", nil, fmt.Errorf("%w; remove the file to download it again", err)
+			}
+
+			err = writeChecksumFile(productFilePath, calculatedSum)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+		err = c.cleanupCacheArtifacts(outputDir, glob, keepPaths, slug)
 		if err != nil {
 			return "", nil, fmt.Errorf("could not cleanup cache: %w", err)
 		}
@@ -477,7 +628,7 @@ func (c *DownloadProduct) downloadProductFile(slug, version, glob, prefixPath st
 		return productFilePath, fileArtifact, nil
 	}
 
-	err = c.cleanupCacheArtifacts(outputDir, glob, productFilePath, slug)
+	err = c.cleanupCacheArtifacts(outputDir, glob, keepPaths, slug)
 	if err != nil {
 		return "", nil, fmt.Errorf("could not cleanup cache: %w", err)
 	}
@@ -496,22 +647,24 @@ func (c *DownloadProduct) downloadProductFile(slug, version, glob, prefixPath st
 	}
 
 	// check for correct sha on newly downloaded file
-	if ok, calculatedSum := c.shasumMatches(partialProductFilePath, fileArtifact.SHA256()); !ok {
-		e := fmt.Sprintf("the sha (%s) from %s does not match the calculated sha (%s) for the file %s",
-			fileArtifact.SHA256(),
-			c.downloadClient.Name(),
-			calculatedSum,
-			productFilePath)
-		c.stderr.Print(e)
+	calculatedSum, err := c.verifyChecksum(partialProductFilePath, fileArtifact.SHA256())
+	if err != nil {
+		c.stderr.Print(err)
 		_ = os.Remove(partialProductFilePath)
-		return productFilePath, fileArtifact, errors.New(e)
+		return productFilePath, fileArtifact, err
 	}
 
 	_ = os.Rename(partialProductFilePath, productFilePath)
+
+	err = writeChecksumFile(productFilePath, calculatedSum)
+	if err != nil {
+		return productFilePath, fileArtifact, err
+	}
+
 	return productFilePath, fileArtifact, nil
 }
 
-func (c *DownloadProduct) cleanupCacheArtifacts(outputDir string, glob string, productFilePath string, slug string) error {
+func (c *DownloadProduct) cleanupCacheArtifacts(outputDir string, glob string, keepPaths []string, slug string) error {
 	if c.Options.CacheCleanup == "I acknowledge this will delete files in the output directories" {
 
 		outputDirContents, err := os.ReadDir(outputDir)
@@ -527,9 +680,10 @@ func (c *DownloadProduct) cleanupCacheArtifacts(outputDir string, glob string, p
 				dirFilePath := path.Join(outputDir, file.Name())
 				c.stderr.Printf("checking if %q needs to cleaned up", file.Name())
 				if matchGlob, _ := filepath.Match(fileGlob, file.Name()); matchGlob {
-					if dirFilePath != productFilePath {
+					if !slices.Contains(keepPaths, strings.TrimSuffix(dirFilePath, checksumFileExtension)) {
 						c.stderr.Printf("cleaning up cached file: %s", dirFilePath)
 						_ = os.Remove(dirFilePath)
+						_ = os.Remove(dirFilePath + checksumFileExtension)
 					}
 				}
 			}
@@ -539,19 +693,40 @@ func (c *DownloadProduct) cleanupCacheArtifacts(outputDir string, glob string, p
 	return nil
 }
 
-func (c *DownloadProduct) shasumMatches(path, exepectedSum string) (bool, string) {
-	if exepectedSum == "" {
-		return true, ""
-	}
-
+// verifyChecksum calculates the sha256 of the file at path and compares it to
+// the sum published by the source, if there is one.
+func (c *DownloadProduct) verifyChecksum(path, expectedSum string) (string, error) {
 	c.stderr.Printf("calculating sha sum for %s", path)
 	validate := validator.NewSHA256Calculator()
 	calculatedSum, err := validate.Checksum(path)
 	if err != nil {
-		return false, ""
+		return "", fmt.Errorf("could not calculate the sha for the file %s: %w", strings.TrimSuffix(path, ".partial"), err)
+	}
+
+	if expectedSum != "" && calculatedSum != expectedSum {
+		return "", fmt.Errorf("the sha (%s) from %s does not match the calculated sha (%s) for the file %s",
+			expectedSum,
+			c.downloadClient.Name(),
+			calculatedSum,
+			strings.TrimSuffix(path, ".partial"))
+	}
+
+	return calculatedSum, nil
+}
+
+const checksumFileExtension = ".sha256"
+
+// writeChecksumFile records the sum next to the downloaded file in the format
+// understood by `sha256sum --check`.
+func writeChecksumFile(path, sum string) error {
+	checksumFilePath := path + checksumFileExtension
+
+	err := os.WriteFile(checksumFilePath, []byte(fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))), 0644)
+	if err != nil {
+		return fmt.Errorf("could not write %s: %w", checksumFilePath, err)
 	}
 
-	return calculatedSum == exepectedSum, calculatedSum
+	return nil
 }
 
 func checkFileExists(path string) (bool, error) {
@@ -580,6 +755,20 @@ func newDownloadClientFromSource(c DownloadProductOptions,
 	stderr *log.Logger,
 ) (download_clients.ProductDownloader, error) {
 	switch c.Source {
+	case "artifactory":
+		return download_clients.NewArtifactoryClient(
+			download_clients.ArtifactoryConfiguration{
+				URL:             c.ArtifactoryURL,
+				Repository:      c.ArtifactoryRepository,
+				APIKey:          c.ArtifactoryAPIKey,
+				Token:           c.ArtifactoryToken,
+				DisableSSL:      c.ArtifactoryDisableSSL,
+				PathLayout:      c.ArtifactoryPathLayout,
+				SlugProperty:    c.ArtifactorySlugProperty,
+				VersionProperty: c.ArtifactoryVersionProperty,
+			},
+			stderr,
+		)
 	case "azure":
 		return download_clients.NewAzureClient(
 			download_clients.StowWrapper{},
@@ -587,8 +776,22 @@ func newDownloadClientFromSource(c DownloadProductOptions,
 				Container:      c.Bucket,
 				StorageAccount: c.AzureStorageAccount,
 				Key:            c.AzureKey,
+				SASToken:       c.AzureSASToken,
+				EndpointSuffix: c.AzureEndpointSuffix,
 				ProductPath:    c.ProductPath,
 				StemcellPath:   c.StemcellPath,
+
+				ParallelConnections: c.ParallelConnections,
+			},
+			stderr,
+		)
+	case "broadcom":
+		return download_clients.NewBroadcomClient(
+			download_clients.BroadcomConfiguration{
+				Host:       c.BroadcomHost,
+				Token:      c.BroadcomToken,
+				AcceptEULA: c.BroadcomAcceptEULA,
+				DisableSSL: c.BroadcomDisableSSL,
 			},
 			stderr,
 		)
@@ -599,8 +802,11 @@ func newDownloadClientFromSource(c DownloadProductOptions,
 				Bucket:             c.Bucket,
 				ProjectID:          c.GCSProjectID,
 				ServiceAccountJSON: c.GCSServiceAccountJSON,
+				AuthType:           c.GCSAuthType,
 				ProductPath:        c.ProductPath,
 				StemcellPath:       c.StemcellPath,
+
+				ParallelConnections: c.ParallelConnections,
 			},
 			stderr,
 		)
@@ -612,12 +818,31 @@ func newDownloadClientFromSource(c DownloadProductOptions,
 				AccessKeyID:     c.S3AccessKeyID,
 				AuthType:        c.S3AuthType,
 				SecretAccessKey: c.S3SecretAccessKey,
+				SessionToken:    c.S3SessionToken,
+				RoleARN:         c.S3RoleARN,
+				ExternalID:      c.S3ExternalID,
 				RegionName:      c.S3RegionName,
 				Endpoint:        c.S3Endpoint,
 				DisableSSL:      c.S3DisableSSL,
 				EnableV2Signing: c.S3EnableV2Signing,
-				ProductPath:     c.ProductPath,
-				StemcellPath:    c.StemcellPath,
+
+				CACert:               c.S3CACert,
+				AddressingStyle:      c.S3AddressingStyle,
+				SkipRegionValidation: c.S3SkipRegionValidation,
+				ProductPath:          c.ProductPath,
+				StemcellPath:         c.StemcellPath,
+
+				ParallelConnections: c.ParallelConnections,
+			},
+			stderr,
+		)
+	case "oci":
+		return download_clients.NewOCIClient(
+			download_clients.OCIConfiguration{
+				Repository: c.OCIRepository,
+				Username:   c.OCIUsername,
+				Password:   c.OCIPassword,
+				Insecure:   c.OCIInsecure,
 			},
 			stderr,
 		)
@@ -634,3 +859,23 @@ func newDownloadClientFromSource(c DownloadProductOptions,
 
 	return nil, errors.New("could not find a plugin")
 }
+
+// newBlobstoreClient connects to the blobstore of options.Source with the
+// same options download-product reads from it with, for the commands that
+// need T of it as well. unsupported formats the error for the sources that
+// cannot do T.
+func newBlobstoreClient[T any](options DownloadProductOptions, stderr *log.Logger, unsupported string) (T, error) {
+	var blobstore T
+
+	client, err := newDownloadClientFromSource(options, io.Discard, nil, stderr)
+	if err != nil {
+		return blobstore, err
+	}
+
+	blobstore, ok := client.(T)
+	if !ok {
+		return blobstore, fmt.Errorf(unsupported, client.Name())
+	}
+
+	return blobstore, nil
+}
@@ -53,7 +53,7 @@ var _ = Describe("DownloadProduct", func() {
 				fa := &fakes.FileArtifacter{}
 				fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
 
-				fakeProductDownloader.GetLatestProductFileReturns(fa, nil)
+				fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 			})
 
 			It("downloads a product from the downloader", func() {
@@ -97,7 +97,7 @@ var _ = Describe("DownloadProduct", func() {
 				)
 				fa := &fakes.FileArtifacter{}
 				fa.NameReturns("/some-account/some-bucket/cf-2.1-build.11.pivotal")
-				fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+				fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 			})
 
 			It("downloads the highest version matching that regex", func() {
@@ -118,7 +118,7 @@ var _ = Describe("DownloadProduct", func() {
 				slug := fakeProductDownloader.GetAllProductVersionsArgsForCall(0)
 				Expect(slug).To(Equal("elastic-runtime"))
 
-				slug, version, _ := fakeProductDownloader.GetLatestProductFileArgsForCall(0)
+				slug, version, _ := fakeProductDownloader.GetLatestProductFilesArgsForCall(0)
 				Expect(slug).To(Equal("elastic-runtime"))
 				Expect(version).To(Equal("2.1.2"))
 
@@ -188,7 +188,7 @@ var _ = Describe("DownloadProduct", func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
 					fa.SHA256Returns("d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 					fakeProductDownloader.DownloadProductToFileStub = func(artifacter download_clients.FileArtifacter, file *os.File) error {
 						return os.WriteFile(file.Name(), []byte("contents"), 0777)
@@ -211,6 +211,46 @@ var _ = Describe("DownloadProduct", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(filepath.Join(tempDir, "cf-2.0-build.1.pivotal")).To(BeAnExistingFile())
 				})
+
+				It("writes the sha next to the downloaded file", func() {
+					tempDir, err := os.MkdirTemp("", "om-tests-")
+					Expect(err).ToNot(HaveOccurred())
+
+					err = executeCommand(command, []string{
+						"--pivnet-api-token", "token",
+						"--file-glob", "*.pivotal",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					contents, err := os.ReadFile(filepath.Join(tempDir, "cf-2.0-build.1.pivotal.sha256"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(string(contents)).To(Equal("d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8  cf-2.0-build.1.pivotal\n"))
+				})
+
+				When("the file has already been downloaded but does not match the sha", func() {
+					It("errors without replacing the file", func() {
+						tempDir, err := os.MkdirTemp("", "om-tests-")
+						Expect(err).ToNot(HaveOccurred())
+
+						err = os.WriteFile(filepath.Join(tempDir, "cf-2.0-build.1.pivotal"), []byte("tampered"), 0600)
+						Expect(err).ToNot(HaveOccurred())
+
+						err = executeCommand(command, []string{
+							"--pivnet-api-token", "token",
+							"--file-glob", "*.pivotal",
+							"--pivnet-product-slug", "elastic-runtime",
+							"--product-version", "2.0.0",
+							"--output-directory", tempDir,
+						})
+						Expect(err).To(MatchError(ContainSubstring("the sha (d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8) from")))
+						Expect(err).To(MatchError(ContainSubstring("remove the file to download it again")))
+						Expect(fakeProductDownloader.DownloadProductToFileCallCount()).To(Equal(0))
+						Expect(filepath.Join(tempDir, "cf-2.0-build.1.pivotal.sha256")).ToNot(BeAnExistingFile())
+					})
+				})
 			})
 
 			When("the shasum is invalid for the downloaded file", func() {
@@ -218,7 +258,7 @@ var _ = Describe("DownloadProduct", func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
 					fa.SHA256Returns("asdfasdf")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 					fakeProductDownloader.DownloadProductToFileStub = func(artifacter download_clients.FileArtifacter, file *os.File) error {
 						return os.WriteFile(file.Name(), []byte("contents"), 0777)
@@ -244,17 +284,153 @@ var _ = Describe("DownloadProduct", func() {
 			})
 		})
 
+		When("the downloader publishes no SHA sum for the file", func() {
+			It("writes the calculated sha next to the downloaded file", func() {
+				fa := &fakes.FileArtifacter{}
+				fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
+				fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
+
+				fakeProductDownloader.DownloadProductToFileStub = func(artifacter download_clients.FileArtifacter, file *os.File) error {
+					return os.WriteFile(file.Name(), []byte("contents"), 0777)
+				}
+
+				tempDir, err := os.MkdirTemp("", "om-tests-")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = executeCommand(command, []string{
+					"--pivnet-api-token", "token",
+					"--file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				contents, err := os.ReadFile(filepath.Join(tempDir, "cf-2.0-build.1.pivotal.sha256"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(Equal("d1b2a59fbea7e20077af9f91b27e95e865061b270be03ff539ab3b73587882e8  cf-2.0-build.1.pivotal\n"))
+			})
+		})
+
+		When("--plan-only is set", func() {
+			var tempDir string
+
+			BeforeEach(func() {
+				tempDir, err = os.MkdirTemp("", "om-tests-")
+				Expect(err).ToNot(HaveOccurred())
+
+				fa := &fakes.FileArtifacter{}
+				fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
+				fa.SHA256Returns("tile-sha")
+				fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{sizedFileArtifacter{fa, 1234}}, nil)
+
+				fa = &fakes.FileArtifacter{}
+				fa.NameReturns("light-bosh-stemcell-97.190-google.tgz")
+				fa.SHA256Returns("stemcell-sha")
+				fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+
+				sa := &fakes.StemcellArtifacter{}
+				sa.SlugReturns("stemcells-ubuntu-xenial")
+				sa.VersionReturns("97.190")
+				fakeProductDownloader.GetLatestStemcellForProductReturns(sa, nil)
+				fakeProductDownloader.NameReturns("pivnet")
+			})
+
+			It("writes the product and its stemcell to the plan without downloading them", func() {
+				err = executeCommand(command, []string{
+					"--pivnet-api-token", "token",
+					"--file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+					"--stemcell-iaas", "google",
+					"--plan-only",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeProductDownloader.DownloadProductToFileCallCount()).To(Equal(0))
+				Expect(filepath.Join(tempDir, "download-file.json")).ToNot(BeAnExistingFile())
+
+				_, tilePath, _ := fakeProductDownloader.GetLatestStemcellForProductArgsForCall(0)
+				Expect(tilePath).To(Equal(filepath.Join(tempDir, "cf-2.0-build.1.pivotal")))
+
+				plan, err := os.ReadFile(filepath.Join(tempDir, "download-plan.json"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(plan)).To(MatchJSON(`{
+					"products": [{
+						"slug": "elastic-runtime",
+						"version": "2.0.0",
+						"source": "pivnet",
+						"stemcell_slug": "stemcells-ubuntu-xenial",
+						"stemcell_version": "97.190",
+						"files": [
+							{"name": "cf-2.0-build.1.pivotal", "glob": "*.pivotal", "sha256": "tile-sha", "size": 1234},
+							{"name": "light-bosh-stemcell-97.190-google.tgz", "glob": "light*bosh*google*", "sha256": "stemcell-sha", "stemcell": true}
+						]
+					}]
+				}`))
+			})
+
+			It("replaces the product in the plan and keeps the other products", func() {
+				Expect(os.WriteFile(filepath.Join(tempDir, "download-plan.json"), []byte(`{"products": [
+					{"slug": "p-healthwatch", "version": "2.1.0", "source": "pivnet", "files": [{"name": "healthwatch.pivotal", "glob": "*.pivotal"}]},
+					{"slug": "elastic-runtime", "version": "1.0.0", "source": "pivnet", "files": []}
+				]}`), 0644)).To(Succeed())
+
+				err = executeCommand(command, []string{
+					"--pivnet-api-token", "token",
+					"--file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+					"--plan-only",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				plan, err := os.ReadFile(filepath.Join(tempDir, "download-plan.json"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(plan)).To(MatchJSON(`{
+					"products": [
+						{"slug": "p-healthwatch", "version": "2.1.0", "source": "pivnet", "files": [{"name": "healthwatch.pivotal", "glob": "*.pivotal"}]},
+						{"slug": "elastic-runtime", "version": "2.0.0", "source": "pivnet", "files": [
+							{"name": "cf-2.0-build.1.pivotal", "glob": "*.pivotal", "sha256": "tile-sha", "size": 1234}
+						]}
+					]
+				}`))
+			})
+
+			It("leaves the stemcell out of the plan when it cannot be determined without the tile", func() {
+				fakeProductDownloader.GetLatestStemcellForProductReturns(nil, errors.New("could not find the appropriate stemcell associated with the tile"))
+
+				err = executeCommand(command, []string{
+					"--pivnet-api-token", "token",
+					"--file-glob", "*.pivotal",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+					"--stemcell-iaas", "google",
+					"--plan-only",
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buffer).To(gbytes.Say("could not determine the required stemcell, the plan does not include it"))
+
+				plan, err := os.ReadFile(filepath.Join(tempDir, "download-plan.json"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(plan)).ToNot(ContainSubstring("stemcell"))
+			})
+		})
+
 		When("the stemcell-iaas flag is set", func() {
 			When("the product has an associated stemcell", func() {
 				BeforeEach(func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
 					fa.ProductMetadataReturns(&extractor.Metadata{Name: "fake-tile", Version: "2.0.0"}, nil)
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 					fa = &fakes.FileArtifacter{}
 					fa.NameReturns("stemcell.tgz")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, fa, nil)
+					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
 
 					sa := &fakes.StemcellArtifacter{}
 					sa.SlugReturns("stemcells-ubuntu-xenial")
@@ -284,7 +460,7 @@ var _ = Describe("DownloadProduct", func() {
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(fakeProductDownloader.GetLatestStemcellForProductCallCount()).To(Equal(1))
-					Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(2))
+					Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(1))
 					Expect(fakeProductDownloader.DownloadProductToFileCallCount()).To(Equal(2))
 					Expect(fakeProductDownloader.GetAllProductVersionsCallCount()).To(Equal(1))
 
@@ -304,8 +480,11 @@ var _ = Describe("DownloadProduct", func() {
 								"product_slug": "elastic-runtime",
 								"product_version": "2.0.0",
 								"stemcell_path": "%s",
-								"stemcell_version": "97.190"
-							}`, downloadedFilePath, downloadedStemcellFilePath)))
+								"stemcell_version": "97.190",
+								"artifacts": [
+									{"glob": "*.pivotal", "name": "/some-account/some-bucket/cf-2.0-build.1.pivotal", "path": "%s"}
+								]
+							}`, downloadedFilePath, downloadedStemcellFilePath, downloadedFilePath)))
 
 					fileName = path.Join(tempDir, "assign-stemcell.yml")
 					fileContent, err = os.ReadFile(fileName)
@@ -446,7 +625,7 @@ var _ = Describe("DownloadProduct", func() {
 							It("only deletes previous versions of the product", func() {
 								fa := &fakes.FileArtifacter{}
 								fa.NameReturns("light-bosh-google-2-stemcell.tgz")
-								fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, fa, nil)
+								fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
 
 								previousDownloadedProduct := tempFile(productOutputDir, "cf-2.0-build.*.pivotal")
 								previousDownloadedStemcell := tempFile(stemcellOutputDir, "light-bosh-google-1-stemcell*.tgz")
@@ -509,8 +688,11 @@ var _ = Describe("DownloadProduct", func() {
 								"product_slug": "elastic-runtime",
 								"product_version": "2.0.0",
 								"stemcell_path": "%s",
-								"stemcell_version": "100.00"
-							}`, downloadedFilePath, downloadedStemcellFilePath)))
+								"stemcell_version": "100.00",
+								"artifacts": [
+									{"glob": "*.pivotal", "name": "/some-account/some-bucket/cf-2.0-build.1.pivotal", "path": "%s"}
+								]
+							}`, downloadedFilePath, downloadedStemcellFilePath, downloadedFilePath)))
 
 						fileName = path.Join(tempDir, "assign-stemcell.yml")
 						fileContent, err = os.ReadFile(fileName)
@@ -529,7 +711,7 @@ var _ = Describe("DownloadProduct", func() {
 				BeforeEach(func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.tgz")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 				})
 
 				It("prints a warning and returns available file artifacts", func() {
@@ -556,8 +738,11 @@ var _ = Describe("DownloadProduct", func() {
 							{
 								"product_path": "%s",
 								"product_slug": "elastic-runtime",
-								"product_version": "2.0.0"
-							}`, downloadedFilePath)))
+								"product_version": "2.0.0",
+								"artifacts": [
+									{"glob": "*.tgz", "name": "/some-account/some-bucket/cf-2.0-build.1.tgz", "path": "%s"}
+								]
+							}`, downloadedFilePath, downloadedFilePath)))
 					Expect(buffer).Should(gbytes.Say("the downloaded file is not a .pivotal file. Not determining and fetching required stemcell."))
 				})
 			})
@@ -566,9 +751,9 @@ var _ = Describe("DownloadProduct", func() {
 				It("returns an error message", func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
+					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, nil, errors.New("some error"))
 					fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, nil, errors.New("some error"))
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(2, nil, errors.New("some error"))
 
 					sa := &fakes.StemcellArtifacter{}
 					sa.SlugReturns("stemcells-ubuntu-xenial")
@@ -602,15 +787,15 @@ var _ = Describe("DownloadProduct", func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
 					fa.ProductMetadataReturns(&extractor.Metadata{}, nil)
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 					fakeProductDownloader.DownloadProductToFileStub = func(artifacter download_clients.FileArtifacter, file *os.File) error {
 						createProductPivotalFile(file)
 						return nil
 					}
 
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, nil, errors.New("some-error"))
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(2, &fakes.FileArtifacter{}, nil)
+					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, nil, errors.New("some-error"))
+					fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, &fakes.FileArtifacter{}, nil)
 
 					sa := &fakes.StemcellArtifacter{}
 					sa.SlugReturns("stemcells-ubuntu-xenial")
@@ -630,11 +815,11 @@ var _ = Describe("DownloadProduct", func() {
 					})
 
 					Expect(err).ToNot(HaveOccurred())
-					Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(3))
-					_, _, glob := fakeProductDownloader.GetLatestProductFileArgsForCall(1)
+					Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(2))
+					_, _, glob := fakeProductDownloader.GetLatestProductFileArgsForCall(0)
 					Expect(glob).To(Equal("light*bosh*aws*"))
 
-					_, _, glob = fakeProductDownloader.GetLatestProductFileArgsForCall(2)
+					_, _, glob = fakeProductDownloader.GetLatestProductFileArgsForCall(1)
 					Expect(glob).To(Equal("bosh*aws*"))
 				})
 			})
@@ -644,14 +829,14 @@ var _ = Describe("DownloadProduct", func() {
 					fa := &fakes.FileArtifacter{}
 					fa.ProductMetadataReturns(&extractor.Metadata{}, nil)
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 					fakeProductDownloader.DownloadProductToFileStub = func(artifacter download_clients.FileArtifacter, file *os.File) error {
 						createProductPivotalFile(file)
 						return nil
 					}
 
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, &fakes.FileArtifacter{}, nil)
+					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, &fakes.FileArtifacter{}, nil)
 
 					sa := &fakes.StemcellArtifacter{}
 					sa.SlugReturns("stemcells-ubuntu-xenial")
@@ -673,8 +858,8 @@ var _ = Describe("DownloadProduct", func() {
 
 					Expect(err).ToNot(HaveOccurred())
 
-					Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(2))
-					_, _, glob := fakeProductDownloader.GetLatestProductFileArgsForCall(1)
+					Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(1))
+					_, _, glob := fakeProductDownloader.GetLatestProductFileArgsForCall(0)
 					Expect(glob).To(Equal("bosh*aws*"))
 				})
 
@@ -697,14 +882,14 @@ var _ = Describe("DownloadProduct", func() {
 				It("fails is --stemcell-heavy is provided but the heavy stemcell does not exist", func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 					fakeProductDownloader.DownloadProductToFileStub = func(artifacter download_clients.FileArtifacter, file *os.File) error {
 						createProductPivotalFile(file)
 						return nil
 					}
 
-					fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, nil, errors.New("no stemcell"))
+					fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, nil, errors.New("no stemcell"))
 
 					sa := &fakes.StemcellArtifacter{}
 					sa.SlugReturns("stemcells-ubuntu-xenial")
@@ -738,7 +923,7 @@ var _ = Describe("DownloadProduct", func() {
 					fa.ProductMetadataReturns(&extractor.Metadata{Name: "xenial-stemcells", Version: "100.0"}, nil)
 					fakeProductDownloader.GetAllProductVersionsReturns([]string{"100.0"}, nil)
 
-					fakeProductDownloader.GetLatestProductFileReturns(fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 				})
 
 				When("the stemcell already exists on the OpsManager", func() {
@@ -836,7 +1021,7 @@ var _ = Describe("DownloadProduct", func() {
 						fa.NameReturns("/some-account/some-bucket/cf-2.1-build.11.pivotal")
 						fa.ProductMetadataReturns(nil, errors.New("some error"))
 
-						fakeProductDownloader.GetLatestProductFileReturns(fa, nil)
+						fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 					})
 
 					It("does not download it", func() {
@@ -864,7 +1049,7 @@ var _ = Describe("DownloadProduct", func() {
 						fa.NameReturns("/some-account/some-bucket/cf-2.1-build.11.pivotal")
 						fa.ProductMetadataReturns(&extractor.Metadata{Name: "example-product", Version: "1.2.3"}, nil)
 
-						fakeProductDownloader.GetLatestProductFileReturns(fa, nil)
+						fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 					})
 
 					When("the product already exists on the OpsManager", func() {
@@ -970,11 +1155,11 @@ var _ = Describe("DownloadProduct", func() {
 				fa := &fakes.FileArtifacter{}
 				fa.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
 				fa.ProductMetadataReturns(&extractor.Metadata{}, nil)
-				fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
+				fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 
 				fa = &fakes.FileArtifacter{}
 				fa.NameReturns("/some-account/some-bucket/light-bosh-stemcell-97.19-google-kvm-ubuntu-xenial-go_agent.tgz")
-				fakeProductDownloader.GetLatestProductFileReturnsOnCall(1, fa, nil)
+				fakeProductDownloader.GetLatestProductFileReturnsOnCall(0, fa, nil)
 
 				sa := &fakes.StemcellArtifacter{}
 				sa.SlugReturns("stemcells-ubuntu-xenial")
@@ -1024,7 +1209,7 @@ var _ = Describe("DownloadProduct", func() {
 				BeforeEach(func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/my-great-product.pivotal")
-					fakeProductDownloader.GetLatestProductFileReturns(fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 				})
 
 				It("prefixes the filename with a bracketed slug and version", func() {
@@ -1064,7 +1249,12 @@ var _ = Describe("DownloadProduct", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(downloadReportFileName).To(BeAnExistingFile())
 					prefixedFileName := path.Join(tempDir, "[mayhem-crew,2.0.0]my-great-product.pivotal")
-					Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product_path": "%s", "product_slug": "mayhem-crew", "product_version": "2.0.0" }`, prefixedFileName)))
+					Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{
+						"product_path": "%s",
+						"product_slug": "mayhem-crew",
+						"product_version": "2.0.0",
+						"artifacts": [{"glob": "*.pivotal", "name": "/some-account/some-bucket/my-great-product.pivotal", "path": "%s"}]
+					}`, prefixedFileName, prefixedFileName)))
 				})
 			})
 
@@ -1072,7 +1262,7 @@ var _ = Describe("DownloadProduct", func() {
 				BeforeEach(func() {
 					fa := &fakes.FileArtifacter{}
 					fa.NameReturns("/some-account/some-bucket/my-great-product.pivotal")
-					fakeProductDownloader.GetLatestProductFileReturns(fa, nil)
+					fakeProductDownloader.GetLatestProductFilesReturns([]download_clients.FileArtifacter{fa}, nil)
 				})
 				It("doesn't prefix", func() {
 					tempDir, err := os.MkdirTemp("", "om-tests-")
@@ -1109,7 +1299,112 @@ var _ = Describe("DownloadProduct", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(downloadReportFileName).To(BeAnExistingFile())
 					unPrefixedFileName := path.Join(tempDir, "my-great-product.pivotal")
-					Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{"product_path": "%s", "product_slug": "mayhem-crew", "product_version": "2.0.0" }`, unPrefixedFileName)))
+					Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{
+						"product_path": "%s",
+						"product_slug": "mayhem-crew",
+						"product_version": "2.0.0",
+						"artifacts": [{"glob": "*.pivotal", "name": "/some-account/some-bucket/my-great-product.pivotal", "path": "%s"}]
+					}`, unPrefixedFileName, unPrefixedFileName)))
+				})
+			})
+		})
+
+		When("--file-glob is provided multiple times", func() {
+			BeforeEach(func() {
+				tile := &fakes.FileArtifacter{}
+				tile.NameReturns("/some-account/some-bucket/cf-2.0-build.1.pivotal")
+
+				windowsTile := &fakes.FileArtifacter{}
+				windowsTile.NameReturns("/some-account/some-bucket/pas-windows-2.0-build.1.pivotal")
+
+				injector := &fakes.FileArtifacter{}
+				injector.NameReturns("/some-account/some-bucket/winfs-injector-2.0.zip")
+
+				fakeProductDownloader.GetLatestProductFilesReturnsOnCall(0, []download_clients.FileArtifacter{tile, windowsTile}, nil)
+				fakeProductDownloader.GetLatestProductFilesReturnsOnCall(1, []download_clients.FileArtifacter{injector}, nil)
+			})
+
+			It("downloads every match and lists them in the download-file.json", func() {
+				tempDir, err := os.MkdirTemp("", "om-tests-")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = executeCommand(command, []string{
+					"--pivnet-api-token", "token",
+					"--file-glob", "*.pivotal",
+					"--file-glob", "*.zip",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(0))
+				Expect(fakeProductDownloader.GetLatestProductFilesCallCount()).To(Equal(2))
+				_, _, glob := fakeProductDownloader.GetLatestProductFilesArgsForCall(1)
+				Expect(glob).To(Equal("*.zip"))
+
+				Expect(fakeProductDownloader.DownloadProductToFileCallCount()).To(Equal(3))
+				Expect(filepath.Join(tempDir, "cf-2.0-build.1.pivotal")).To(BeAnExistingFile())
+				Expect(filepath.Join(tempDir, "pas-windows-2.0-build.1.pivotal")).To(BeAnExistingFile())
+				Expect(filepath.Join(tempDir, "winfs-injector-2.0.zip")).To(BeAnExistingFile())
+
+				fileContent, err := os.ReadFile(filepath.Join(tempDir, "download-file.json"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(fileContent)).To(MatchJSON(fmt.Sprintf(`{
+					"product_path": "%[1]s/cf-2.0-build.1.pivotal",
+					"product_slug": "elastic-runtime",
+					"product_version": "2.0.0",
+					"artifacts": [
+						{"glob": "*.pivotal", "name": "/some-account/some-bucket/cf-2.0-build.1.pivotal", "path": "%[1]s/cf-2.0-build.1.pivotal"},
+						{"glob": "*.pivotal", "name": "/some-account/some-bucket/pas-windows-2.0-build.1.pivotal", "path": "%[1]s/pas-windows-2.0-build.1.pivotal"},
+						{"glob": "*.zip", "name": "/some-account/some-bucket/winfs-injector-2.0.zip", "path": "%[1]s/winfs-injector-2.0.zip"}
+					]
+				}`, tempDir)))
+			})
+
+			It("does not clean up files matched by another glob", func() {
+				tempDir, err := os.MkdirTemp("", "om-tests-")
+				Expect(err).ToNot(HaveOccurred())
+
+				err = os.WriteFile(filepath.Join(tempDir, "cf-1.9-build.1.pivotal"), nil, 0600)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = executeCommand(command, []string{
+					"--pivnet-api-token", "token",
+					"--file-glob", "*.pivotal",
+					"--file-glob", "*.zip",
+					"--pivnet-product-slug", "elastic-runtime",
+					"--product-version", "2.0.0",
+					"--output-directory", tempDir,
+					"--cache-cleanup", "I acknowledge this will delete files in the output directories",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(filepath.Join(tempDir, "cf-1.9-build.1.pivotal")).ToNot(BeAnExistingFile())
+				Expect(filepath.Join(tempDir, "cf-2.0-build.1.pivotal")).To(BeAnExistingFile())
+				Expect(filepath.Join(tempDir, "pas-windows-2.0-build.1.pivotal")).To(BeAnExistingFile())
+				Expect(filepath.Join(tempDir, "winfs-injector-2.0.zip")).To(BeAnExistingFile())
+			})
+
+			When("a glob matches no file", func() {
+				BeforeEach(func() {
+					fakeProductDownloader.GetLatestProductFilesReturnsOnCall(1, nil, errors.New("the glob '*.zip' matches no file"))
+				})
+
+				It("returns an error", func() {
+					tempDir, err := os.MkdirTemp("", "om-tests-")
+					Expect(err).ToNot(HaveOccurred())
+
+					err = executeCommand(command, []string{
+						"--pivnet-api-token", "token",
+						"--file-glob", "*.pivotal",
+						"--file-glob", "*.zip",
+						"--pivnet-product-slug", "elastic-runtime",
+						"--product-version", "2.0.0",
+						"--output-directory", tempDir,
+					})
+					Expect(err).To(MatchError("could not download product: the glob '*.zip' matches no file"))
+					Expect(fakeProductDownloader.DownloadProductToFileCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1133,6 +1428,59 @@ var _ = Describe("DownloadProduct", func() {
 		})
 	})
 
+	When("--parallel-connections is less than 1", func() {
+		It("returns an error", func() {
+			tempDir, err := os.MkdirTemp("", "om-tests-")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = executeCommand(command, []string{
+				"--source", "s3",
+				"--s3-bucket", "bucket",
+				"--s3-region-name", "us-west-2",
+				"--file-glob", "*.pivotal",
+				"--pivnet-product-slug", "elastic-runtime",
+				"--product-version", "2.0.0",
+				"--output-directory", tempDir,
+				"--parallel-connections", "0",
+			})
+			Expect(err).To(MatchError(ContainSubstring("--parallel-connections must be at least 1")))
+		})
+	})
+
+	When("broadcom-api-token is missing while the source is broadcom", func() {
+		It("returns an error", func() {
+			tempDir, err := os.MkdirTemp("", "om-tests-")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = executeCommand(command, []string{
+				"--source", "broadcom",
+				"--file-glob", "*.pivotal",
+				"--pivnet-product-slug", "elastic-runtime",
+				"--product-version", "2.0.0",
+				"--output-directory", tempDir,
+			})
+			Expect(err).To(MatchError(`could not execute "download-product": could not parse download-product flags: missing required flag "--broadcom-api-token"`))
+		})
+	})
+
+	When("the artifactory source is missing its configuration", func() {
+		It("returns an error", func() {
+			tempDir, err := os.MkdirTemp("", "om-tests-")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = executeCommand(command, []string{
+				"--source", "artifactory",
+				"--artifactory-repository", "tiles-local",
+				"--file-glob", "*.pivotal",
+				"--pivnet-product-slug", "elastic-runtime",
+				"--product-version", "2.0.0",
+				"--output-directory", tempDir,
+			})
+			Expect(err).To(MatchError(ContainSubstring("could not find valid source for 'artifactory'")))
+			Expect(err).To(MatchError(ContainSubstring("'URL' failed on the 'required' tag")))
+		})
+	})
+
 	When("directory flags are provided pointing to directories that don't exist", func() {
 		var (
 			nonexistingDir string
@@ -1259,6 +1607,23 @@ var _ = Describe("DownloadProduct", func() {
 		})
 	})
 
+	When("both product-version-constraint and product-version are set", func() {
+		It("fails with an error saying that the user must pick one", func() {
+			tempDir, err := os.MkdirTemp("", "om-tests-")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = executeCommand(command, []string{
+				"--pivnet-api-token", "token",
+				"--file-glob", "*.pivotal",
+				"--pivnet-product-slug", "elastic-runtime",
+				"--product-version", "2.13.1",
+				"--product-version-constraint", "~> 2.13",
+				"--output-directory", tempDir,
+			})
+			Expect(err).To(MatchError(ContainSubstring("cannot use --product-version-constraint with --product-version or --product-version-regex; please choose one")))
+		})
+	})
+
 	When("neither product-version nor product-version-regex are set", func() {
 		It("fails with an error saying that the user must provide one or the other", func() {
 			tempDir, err := os.MkdirTemp("", "om-tests-")
@@ -1270,13 +1635,13 @@ var _ = Describe("DownloadProduct", func() {
 				"--pivnet-product-slug", "elastic-runtime",
 				"--output-directory", tempDir,
 			})
-			Expect(err).To(MatchError(ContainSubstring("no version information provided; please provide either --product-version or --product-version-regex")))
+			Expect(err).To(MatchError(ContainSubstring("no version information provided; please provide either --product-version, --product-version-regex or --product-version-constraint")))
 		})
 	})
 
 	When("the release specified is not available", func() {
 		BeforeEach(func() {
-			fakeProductDownloader.GetLatestProductFileReturns(nil, errors.New("some-error"))
+			fakeProductDownloader.GetLatestProductFilesReturns(nil, errors.New("some-error"))
 		})
 
 		It("returns an error", func() {
@@ -1315,3 +1680,12 @@ func createProductPivotalFile(file *os.File) {
 	Expect(err).ToNot(HaveOccurred())
 	Expect(z.Close()).To(Succeed())
 }
+
+type sizedFileArtifacter struct {
+	*fakes.FileArtifacter
+	size int64
+}
+
+func (f sizedFileArtifacter) Size() int64 {
+	return f.size
+}
@@ -0,0 +1,278 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+type DownloadRequiredStemcells struct {
+	environFunc    func() []string
+	progressWriter io.Writer
+	stdout         *log.Logger
+	stderr         *log.Logger
+	multipart      multipart
+	service        downloadRequiredStemcellsService
+	Options        struct {
+		Source    string `long:"source"           short:"s" description:"enables download from external sources when set to [s3|gcs|azure|oci|artifactory|broadcom|pivnet]" default:"pivnet"`
+		OutputDir string `long:"output-directory" short:"o" description:"directory path to which the stemcells will be outputted" required:"true"`
+
+		Bucket              string `long:"blobstore-bucket"        description:"bucket name where the stemcells reside in the s3|gcs|azure compatible blobstore"`
+		StemcellPath        string `long:"blobstore-stemcell-path" description:"specify the lookup path where the s3|gcs|azure stemcell artifacts are stored"`
+		ParallelConnections int    `long:"parallel-connections"    description:"number of concurrent ranged connections used to download each file from s3|gcs|azure, when the blobstore publishes its md5 to verify it with (pivnet downloads are always parallelized)" default:"1"`
+
+		PivnetToken      string `long:"pivnet-api-token"   short:"t" description:"API token to use when interacting with Pivnet. Can be retrieved from your profile page in Pivnet."`
+		PivnetDisableSSL bool   `long:"pivnet-disable-ssl"           description:"whether to disable ssl validation when contacting the Pivotal Network"`
+		PivnetHost       string `long:"pivnet-host"                  description:"the API endpoint for Pivotal Network" default:"https://network.pivotal.io"`
+
+		StemcellIaas  string `long:"stemcell-iaas"  required:"true" description:"download the stemcells for the specified iaas. for example 'vsphere' or 'vcloud' or 'openstack' or 'google' or 'azure' or 'aws'"`
+		StemcellHeavy bool   `long:"stemcell-heavy"                 description:"force the downloading of heavy stemcells, will fail if none exists"`
+
+		Upload   bool   `long:"upload"                   description:"upload the downloaded stemcells to Ops Manager"`
+		Floating string `long:"floating" default:"true" description:"assigns the uploaded stemcells to all compatible products"`
+
+		ArtifactoryOptions
+		AzureOptions
+		BroadcomOptions
+		GCSOptions
+		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
+		OCIOptions
+		S3Options
+	}
+}
+
+//counterfeiter:generate -o ./fakes/download_required_stemcells_service.go --fake-name DownloadRequiredStemcellsService . downloadRequiredStemcellsService
+type downloadRequiredStemcellsService interface {
+	Info() (api.Info, error)
+	ListMultiStemcells() (api.ProductMultiStemcells, error)
+	UploadStemcell(api.StemcellUploadInput) (api.StemcellUploadOutput, error)
+	CheckStemcellAvailability(string) (bool, error)
+	GetDiagnosticReport() (api.DiagnosticReport, error)
+}
+
+// stemcellRequirement is a stemcell that staged products need, and that no
+// stemcell uploaded to Ops Manager satisfies.
+type stemcellRequirement struct {
+	os       string
+	version  string
+	products []string
+}
+
+func NewDownloadRequiredStemcells(environFunc func() []string, stdout *log.Logger, stderr *log.Logger, progressWriter io.Writer, multipart multipart, service downloadRequiredStemcellsService) *DownloadRequiredStemcells {
+	return &DownloadRequiredStemcells{
+		environFunc:    environFunc,
+		stdout:         stdout,
+		stderr:         stderr,
+		progressWriter: progressWriter,
+		multipart:      multipart,
+		service:        service,
+	}
+}
+
+func (c *DownloadRequiredStemcells) Execute(args []string) error {
+	err := c.validate()
+	if err != nil {
+		return err
+	}
+
+	err = validateMultiStemcellOpsManVersion(c.service)
+	if err != nil {
+		return err
+	}
+
+	requirements, err := c.unmetRequirements()
+	if err != nil {
+		return err
+	}
+
+	if len(requirements) == 0 {
+		c.stderr.Println("the stemcell requirements of every staged product are met")
+		return nil
+	}
+
+	downloader, err := c.downloader()
+	if err != nil {
+		return err
+	}
+
+	var stemcellFiles []string
+	for _, requirement := range requirements {
+		stemcellFile, err := c.download(downloader, requirement)
+		if err != nil {
+			return err
+		}
+
+		stemcellFiles = append(stemcellFiles, stemcellFile)
+		c.stdout.Println(stemcellFile)
+	}
+
+	if !c.Options.Upload {
+		return nil
+	}
+
+	uploadStemcell := NewUploadStemcell(c.multipart, c.service, c.stderr)
+	uploadStemcell.Options.Stemcells = stemcellFiles
+	uploadStemcell.Options.Floating = c.Options.Floating
+	uploadStemcell.Options.Parallel = 2
+
+	return uploadStemcell.Execute(nil)
+}
+
+func (c *DownloadRequiredStemcells) validate() error {
+	if c.Options.PivnetToken == "" && c.Options.Source == "pivnet" {
+		return errors.New(`could not execute "download-required-stemcells": could not parse download-required-stemcells flags: missing required flag "--pivnet-api-token"`)
+	}
+
+	if c.Options.BroadcomToken == "" && c.Options.Source == "broadcom" {
+		return errors.New(`could not execute "download-required-stemcells": could not parse download-required-stemcells flags: missing required flag "--broadcom-api-token"`)
+	}
+
+	if c.Options.ParallelConnections < 1 {
+		return errors.New("--parallel-connections must be at least 1")
+	}
+
+	info, err := os.Stat(c.Options.OutputDir)
+	if err != nil {
+		return fmt.Errorf("--output-directory %q does not exist: %w", c.Options.OutputDir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("--output-directory %q is not a directory", c.Options.OutputDir)
+	}
+
+	return nil
+}
+
+// unmetRequirements returns the stemcells the staged products require, that
+// are not satisfied by any stemcell available on Ops Manager.
+func (c *DownloadRequiredStemcells) unmetRequirements() ([]*stemcellRequirement, error) {
+	productStemcells, err := c.service.ListMultiStemcells()
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := map[string]*stemcellRequirement{}
+	for _, product := range productStemcells.Products {
+		if product.StagedForDeletion {
+			continue
+		}
+
+		for _, required := range product.RequiredStemcells {
+			var availableVersions []string
+			for _, available := range product.AvailableVersions {
+				if available.OS == required.OS {
+					availableVersions = append(availableVersions, available.Version)
+				}
+			}
+
+			if _, err := download_clients.LatestCompatibleStemcellVersion(required.Version, availableVersions); err == nil {
+				continue
+			}
+
+			key := required.OS + ":" + required.Version
+			if requirements[key] == nil {
+				requirements[key] = &stemcellRequirement{os: required.OS, version: required.Version}
+			}
+			requirements[key].products = append(requirements[key].products, product.ProductName)
+		}
+	}
+
+	keys := make([]string, 0, len(requirements))
+	for key := range requirements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var unmet []*stemcellRequirement
+	for _, key := range keys {
+		unmet = append(unmet, requirements[key])
+	}
+
+	return unmet, nil
+}
+
+// downloader connects to the source with the same options download-product
+// uses, so both commands find stemcells in the same places.
+func (c *DownloadRequiredStemcells) downloader() (*DownloadProduct, error) {
+	downloader := &DownloadProduct{
+		environFunc:    c.environFunc,
+		progressWriter: c.progressWriter,
+		stdout:         c.stdout,
+		stderr:         c.stderr,
+		Options: DownloadProductOptions{
+			Source:              c.Options.Source,
+			OutputDir:           c.Options.OutputDir,
+			Bucket:              c.Options.Bucket,
+			StemcellPath:        c.Options.StemcellPath,
+			ParallelConnections: c.Options.ParallelConnections,
+			ArtifactoryOptions:  c.Options.ArtifactoryOptions,
+			AzureOptions:        c.Options.AzureOptions,
+			BroadcomOptions:     c.Options.BroadcomOptions,
+			GCSOptions:          c.Options.GCSOptions,
+			OCIOptions:          c.Options.OCIOptions,
+			PivnetOptions: PivnetOptions{
+				PivnetToken:      c.Options.PivnetToken,
+				PivnetDisableSSL: c.Options.PivnetDisableSSL,
+				PivnetHost:       c.Options.PivnetHost,
+			},
+			S3Options: c.Options.S3Options,
+			StemcellOptions: StemcellOptions{
+				StemcellIaas:  c.Options.StemcellIaas,
+				StemcellHeavy: c.Options.StemcellHeavy,
+			},
+		},
+	}
+	downloader.handleAliases()
+
+	err := downloader.createClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return downloader, nil
+}
+
+func (c *DownloadRequiredStemcells) download(downloader *DownloadProduct, requirement *stemcellRequirement) (string, error) {
+	slug, ok := download_clients.StemcellSlug(requirement.os)
+	if !ok {
+		return "", fmt.Errorf("could not determine where the %s stemcells required by %s are published", requirement.os, strings.Join(requirement.products, ", "))
+	}
+
+	c.stderr.Printf("%s require a %s stemcell compatible with version %s", strings.Join(requirement.products, ", "), requirement.os, requirement.version)
+
+	versions, err := downloader.downloadClient.GetAllProductVersions(slug)
+	if err != nil {
+		return "", fmt.Errorf("could not find the versions of %s: %w", slug, err)
+	}
+
+	version, err := download_clients.LatestCompatibleStemcellVersion(requirement.version, versions)
+	if err != nil {
+		return "", fmt.Errorf("could not find a %s stemcell for %s: %w", requirement.os, strings.Join(requirement.products, ", "), err)
+	}
+
+	var stemcellFile string
+	for _, glob := range stemcellFileGlobs(c.Options.StemcellIaas, c.Options.StemcellHeavy) {
+		stemcellFile, _, err = downloader.downloadProductFile(
+			slug,
+			version,
+			glob,
+			fmt.Sprintf("[%s,%s]", slug, version),
+			c.Options.OutputDir,
+		)
+		if err == nil {
+			return stemcellFile, nil
+		}
+	}
+
+	isHeavy := ""
+	if c.Options.StemcellHeavy {
+		isHeavy = "heavy "
+	}
+	return "", fmt.Errorf("could not download stemcell %s %s: %s\nNo %sstemcell identified for IaaS \"%s\". Correct the `stemcell-iaas` option to match the IaaS portion of the stemcell filename.", slug, version, err, isHeavy, c.Options.StemcellIaas)
+}
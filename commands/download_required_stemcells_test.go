@@ -0,0 +1,239 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	cmdFakes "github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/download_clients"
+	"github.com/pivotal-cf/om/download_clients/fakes"
+)
+
+var _ = Describe("DownloadRequiredStemcells", func() {
+	var (
+		command               *commands.DownloadRequiredStemcells
+		fakeProductDownloader *fakes.ProductDownloader
+		fakeService           *cmdFakes.DownloadRequiredStemcellsService
+		multipart             *cmdFakes.Multipart
+		stdout                *gbytes.Buffer
+		stderr                *gbytes.Buffer
+		outputDir             string
+	)
+
+	BeforeEach(func() {
+		var err error
+		outputDir, err = os.MkdirTemp("", "om-tests-")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, outputDir)
+
+		fakeProductDownloader = &fakes.ProductDownloader{}
+		fakeProductDownloader.NameReturns("pivnet")
+		fakeProductDownloader.GetAllProductVersionsReturns([]string{"1.10", "1.12", "2.3"}, nil)
+		fakeProductDownloader.GetLatestProductFileStub = func(slug, version, glob string) (download_clients.FileArtifacter, error) {
+			fa := &fakes.FileArtifacter{}
+			fa.NameReturns("light-bosh-stemcell-" + version + "-aws-xen-hvm-ubuntu-jammy-go_agent.tgz")
+			return fa, nil
+		}
+
+		fakeService = &cmdFakes.DownloadRequiredStemcellsService{}
+		fakeService.InfoReturns(api.Info{Version: "2.6.0"}, nil)
+		fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+			Products: []api.ProductMultiStemcell{
+				{
+					ProductName:       "cf",
+					RequiredStemcells: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "1.11"}},
+					AvailableVersions: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "1.10"}},
+				},
+				{
+					ProductName:       "p-isolation-segment",
+					RequiredStemcells: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "1.11"}},
+				},
+				{
+					ProductName:       "p-redis",
+					RequiredStemcells: []api.StemcellObject{{OS: "ubuntu-xenial", Version: "621.1"}},
+					AvailableVersions: []api.StemcellObject{{OS: "ubuntu-xenial", Version: "621.5"}},
+				},
+			},
+		}, nil)
+		multipart = &cmdFakes.Multipart{}
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+	})
+
+	JustBeforeEach(func() {
+		download_clients.NewPivnetClient = func(stdout *log.Logger, stderr *log.Logger, factory download_clients.PivnetFactory, token string, skipSSL bool, pivnetHost string) download_clients.ProductDownloader {
+			return fakeProductDownloader
+		}
+		command = commands.NewDownloadRequiredStemcells(func() []string { return nil }, log.New(stdout, "", 0), log.New(stderr, "", 0), stderr, multipart, fakeService)
+	})
+
+	It("downloads the latest compatible stemcell for the unmet requirements", func() {
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeProductDownloader.GetAllProductVersionsCallCount()).To(Equal(1))
+		Expect(fakeProductDownloader.GetAllProductVersionsArgsForCall(0)).To(Equal("stemcells-ubuntu-jammy"))
+
+		slug, version, glob := fakeProductDownloader.GetLatestProductFileArgsForCall(0)
+		Expect(slug).To(Equal("stemcells-ubuntu-jammy"))
+		Expect(version).To(Equal("1.12"))
+		Expect(glob).To(Equal("light*bosh*aws*"))
+
+		stemcellFile := filepath.Join(outputDir, "light-bosh-stemcell-1.12-aws-xen-hvm-ubuntu-jammy-go_agent.tgz")
+		Expect(stemcellFile).To(BeAnExistingFile())
+		Expect(stdout).To(gbytes.Say(regexp.QuoteMeta(stemcellFile)))
+		Expect(stderr).To(gbytes.Say("cf, p-isolation-segment require a ubuntu-jammy stemcell compatible with version 1.11"))
+
+		Expect(fakeService.UploadStemcellCallCount()).To(Equal(0))
+	})
+
+	It("uploads the downloaded stemcells when asked to", func() {
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+			"--upload",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeService.UploadStemcellCallCount()).To(Equal(1))
+		key, path := multipart.AddFileArgsForCall(0)
+		Expect(key).To(Equal("stemcell[file]"))
+		Expect(path).To(Equal(filepath.Join(outputDir, "light-bosh-stemcell-1.12-aws-xen-hvm-ubuntu-jammy-go_agent.tgz")))
+	})
+
+	It("does nothing when every requirement is met", func() {
+		fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+			Products: []api.ProductMultiStemcell{
+				{
+					ProductName:       "cf",
+					RequiredStemcells: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "1.11"}},
+					AvailableVersions: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "1.12"}},
+				},
+				{
+					ProductName:       "p-healthwatch",
+					StagedForDeletion: true,
+					RequiredStemcells: []api.StemcellObject{{OS: "ubuntu-jammy", Version: "2.1"}},
+				},
+			},
+		}, nil)
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeProductDownloader.GetLatestProductFileCallCount()).To(Equal(0))
+		Expect(stderr).To(gbytes.Say("the stemcell requirements of every staged product are met"))
+	})
+
+	It("falls back to the heavy stemcell when there is no light stemcell", func() {
+		fakeProductDownloader.GetLatestProductFileStub = func(slug, version, glob string) (download_clients.FileArtifacter, error) {
+			if glob == "light*bosh*aws*" {
+				return nil, errors.New("no light stemcell")
+			}
+
+			fa := &fakes.FileArtifacter{}
+			fa.NameReturns("bosh-stemcell-" + version + "-aws-xen-hvm-ubuntu-jammy-go_agent.tgz")
+			return fa, nil
+		}
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, glob := fakeProductDownloader.GetLatestProductFileArgsForCall(1)
+		Expect(glob).To(Equal("bosh*aws*"))
+	})
+
+	It("returns an error when the source has no compatible stemcell", func() {
+		fakeProductDownloader.GetAllProductVersionsReturns([]string{"1.10", "2.3"}, nil)
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).To(MatchError("could not find a ubuntu-jammy stemcell for cf, p-isolation-segment: no versions could be found equal to or greater than 1.11"))
+	})
+
+	It("returns an error when the stemcell cannot be downloaded", func() {
+		fakeProductDownloader.GetLatestProductFileStub = nil
+		fakeProductDownloader.GetLatestProductFileReturns(nil, errors.New("no such file"))
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+			"--stemcell-heavy",
+		})
+		Expect(err).To(MatchError(ContainSubstring("could not download stemcell stemcells-ubuntu-jammy 1.12: no such file\nNo heavy stemcell identified for IaaS \"aws\"")))
+	})
+
+	It("returns an error when the operating system is unknown", func() {
+		fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+			Products: []api.ProductMultiStemcell{
+				{
+					ProductName:       "cf",
+					RequiredStemcells: []api.StemcellObject{{OS: "centos", Version: "1.0"}},
+				},
+			},
+		}, nil)
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).To(MatchError("could not determine where the centos stemcells required by cf are published"))
+	})
+
+	It("requires Ops Manager 2.6+", func() {
+		fakeService.InfoReturns(api.Info{Version: "2.5.0"}, nil)
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).To(MatchError("this command can only be used with OpsManager 2.6+"))
+	})
+
+	It("requires a pivnet token for pivnet", func() {
+		err := executeCommand(command, []string{
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).To(MatchError(ContainSubstring(`missing required flag "--pivnet-api-token"`)))
+	})
+
+	It("returns an error when the stemcell associations cannot be listed", func() {
+		fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{}, errors.New("some error"))
+
+		err := executeCommand(command, []string{
+			"--pivnet-api-token", "token",
+			"--output-directory", outputDir,
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).To(MatchError("some error"))
+	})
+})
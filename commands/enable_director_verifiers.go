@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type EnableDirectorVerifiers struct {
+	service   enableDirectorVerifiersService
+	presenter presenters.FormattedPresenter
+	logger    logger
+	Options   struct {
+		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
+
+		VerifierTypes []string `long:"type" short:"t"  description:"verifier types to enable" required:"true"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/enableDirectorVerifiersService.go --fake-name EnableDirectorVerifiersService . enableDirectorVerifiersService
+type enableDirectorVerifiersService interface {
+	ListDirectorVerifiers() ([]api.Verifier, error)
+	EnableDirectorVerifiers(verifierTypes []string) error
+}
+
+func NewEnableDirectorVerifiers(presenter presenters.FormattedPresenter, service enableDirectorVerifiersService, logger logger) *EnableDirectorVerifiers {
+	return &EnableDirectorVerifiers{
+		service:   service,
+		presenter: presenter,
+		logger:    logger,
+	}
+}
+
+func (dv EnableDirectorVerifiers) Execute(args []string) error {
+	directorVerifiers, err := dv.service.ListDirectorVerifiers()
+	if err != nil {
+		return fmt.Errorf("could not get available verifiers from Ops Manager: %s", err)
+	}
+
+	var missingVerifiers []string
+	for _, verifier := range dv.Options.VerifierTypes {
+		found := false
+		for _, dverifier := range directorVerifiers {
+			if verifier == dverifier.Type {
+				found = true
+				continue
+			}
+		}
+
+		if !found {
+			missingVerifiers = append(missingVerifiers, verifier)
+		}
+	}
+
+	if len(missingVerifiers) > 0 {
+		dv.logger.Println("The following verifiers do not exist:")
+		for _, v := range missingVerifiers {
+			dv.logger.Printf("- %s\n", v)
+		}
+
+		dv.logger.Println("\nNo changes were made.\n")
+
+		return errors.New("verifier does not exist for director")
+	}
+
+	dv.logger.Println("Enabling Director Verifiers...\n")
+
+	err = dv.service.EnableDirectorVerifiers(dv.Options.VerifierTypes)
+	if err != nil {
+		return fmt.Errorf("could not enable verifiers in Ops Manager: %s", err)
+	}
+
+	dv.logger.Println("The following verifiers were enabled:")
+	for _, v := range dv.Options.VerifierTypes {
+		dv.logger.Printf("- %s\n", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+type EnableProductVerifiers struct {
+	service   enableProductVerifiersService
+	presenter presenters.FormattedPresenter
+	logger    logger
+	Options   struct {
+		ProductName   string   `long:"product-name" short:"n" required:"true" description:"the name of the product"`
+		VerifierTypes []string `long:"type" short:"t"  description:"verifier types to enable" required:"true"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/enableProductVerifiersService.go --fake-name EnableProductVerifiersService . enableProductVerifiersService
+type enableProductVerifiersService interface {
+	ListProductVerifiers(productName string) ([]api.Verifier, string, error)
+	EnableProductVerifiers(verifierTypes []string, productGUID string) error
+}
+
+func NewEnableProductVerifiers(presenter presenters.FormattedPresenter, service enableProductVerifiersService, logger logger) *EnableProductVerifiers {
+	return &EnableProductVerifiers{
+		service:   service,
+		presenter: presenter,
+		logger:    logger,
+	}
+}
+
+func (dpv EnableProductVerifiers) Execute(args []string) error {
+	productName := dpv.Options.ProductName
+	productVerifiers, productGUID, err := dpv.service.ListProductVerifiers(productName)
+	if err != nil {
+		return fmt.Errorf("could not get available verifiers from Ops Manager: %s", err)
+	}
+
+	var missingVerifiers []string
+	for _, verifier := range dpv.Options.VerifierTypes {
+		found := false
+		for _, pverifier := range productVerifiers {
+			if verifier == pverifier.Type {
+				found = true
+				continue
+			}
+		}
+
+		if !found {
+			missingVerifiers = append(missingVerifiers, verifier)
+		}
+	}
+
+	if len(missingVerifiers) > 0 {
+		dpv.logger.Printf("The following verifiers do not exist for %s:\n", productName)
+		for _, v := range missingVerifiers {
+			dpv.logger.Printf("- %s\n", v)
+		}
+
+		dpv.logger.Println("\nNo changes were made.\n")
+
+		return errors.New("verifier does not exist for product")
+	}
+
+	dpv.logger.Printf("Enabling Product Verifiers for %s...\n\n", productName)
+
+	err = dpv.service.EnableProductVerifiers(dpv.Options.VerifierTypes, productGUID)
+	if err != nil {
+		return fmt.Errorf("could not enable verifiers in Ops Manager: %s", err)
+	}
+
+	dpv.logger.Println("The following verifiers were enabled:")
+	for _, v := range dpv.Options.VerifierTypes {
+		dpv.logger.Printf("- %s\n", v)
+	}
+
+	return nil
+}
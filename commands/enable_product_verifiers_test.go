@@ -0,0 +1,107 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+	presenterfakes "github.com/pivotal-cf/om/presenters/fakes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnableProductVerifiers", func() {
+	var (
+		presenter *presenterfakes.FormattedPresenter
+		service   *fakes.EnableProductVerifiersService
+		command   *commands.EnableProductVerifiers
+		stderr    *gbytes.Buffer
+		logger    *log.Logger
+	)
+
+	BeforeEach(func() {
+		presenter = &presenterfakes.FormattedPresenter{}
+		service = &fakes.EnableProductVerifiersService{}
+		stderr = gbytes.NewBuffer()
+		logger = log.New(stderr, "", 0)
+		command = commands.NewEnableProductVerifiers(presenter, service, logger)
+	})
+
+	When("all provided verifiers exist", func() {
+		It("enables all the provided verifiers", func() {
+			verifierType1 := "some-verifier-type"
+			verifierType2 := "another-verifier-type"
+
+			service.ListProductVerifiersReturns([]api.Verifier{
+				{
+					Type:    verifierType1,
+					Enabled: true,
+				},
+				{
+					Type:    verifierType2,
+					Enabled: false,
+				},
+			}, "cf-guid", nil)
+			service.EnableProductVerifiersReturns(nil)
+
+			err := executeCommand(command, []string{"--product-name", "cf", "--type", verifierType1, "-t", verifierType2})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(service.ListProductVerifiersCallCount()).To(Equal(1))
+			Expect(service.ListProductVerifiersArgsForCall(0)).To(Equal("cf"))
+			Expect(service.EnableProductVerifiersCallCount()).To(Equal(1))
+			verifierTypes, guid := service.EnableProductVerifiersArgsForCall(0)
+			Expect(guid).To(Equal("cf-guid"))
+			Expect(verifierTypes).To(Equal([]string{verifierType1, verifierType2}))
+		})
+	})
+
+	When("listing the available verifiers fails", func() {
+		It("returns an error", func() {
+			service.ListProductVerifiersReturns(nil, "", errors.New("some error occurred"))
+
+			err := executeCommand(command, []string{"--product-name", "cf", "--type", "failing-verifier-type"})
+			Expect(err).To(MatchError("could not get available verifiers from Ops Manager: some error occurred"))
+		})
+	})
+
+	When("disabling verifiers fails", func() {
+		It("returns an error", func() {
+			service.ListProductVerifiersReturns([]api.Verifier{
+				{
+					Type:    "some-verifier-type",
+					Enabled: true,
+				},
+			}, "cf-guid", nil)
+
+			service.EnableProductVerifiersReturns(errors.New("some error occurred"))
+
+			err := executeCommand(command, []string{"--product-name", "cf", "--type", "some-verifier-type"})
+			Expect(err).To(MatchError("could not enable verifiers in Ops Manager: some error occurred"))
+		})
+	})
+
+	When("some of the provided verifiers don't exist", func() {
+		It("returns a list of the verifiers that weren't found", func() {
+			service.ListProductVerifiersReturns([]api.Verifier{{
+				Type:    "some-verifier-type",
+				Enabled: true,
+			}}, "cf-guid", nil)
+
+			err := executeCommand(command, []string{"--product-name", "cf", "--type", "missing-verifier-type", "-t", "another-missing-verifier-type"})
+			Expect(err).To(MatchError(ContainSubstring("verifier does not exist for product")))
+
+			Expect(service.EnableProductVerifiersCallCount()).To(Equal(0))
+
+			Expect(string(stderr.Contents())).To(ContainSubstring("The following verifiers do not exist for cf:"))
+			Expect(string(stderr.Contents())).To(ContainSubstring("- missing-verifier-type"))
+			Expect(string(stderr.Contents())).To(ContainSubstring("- another-missing-verifier-type"))
+			Expect(string(stderr.Contents())).To(ContainSubstring("No changes were made."))
+		})
+	})
+})
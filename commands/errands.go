@@ -20,7 +20,7 @@ type Errands struct {
 	service   errandsService
 	Options   struct {
 		ProductName string `long:"product-name" short:"p" required:"true" description:"name of product"`
-		Format      string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
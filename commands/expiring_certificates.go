@@ -1,10 +1,12 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,14 +19,43 @@ type expiringCertsService interface {
 	ListCertificates(string) ([]api.ExpiringCertificate, error)
 }
 
+// ErrExpiringCertificates and ErrExpiredCertificates have their own exit
+// codes, so that monitoring can tell a warning from an outage.
+var (
+	ErrExpiringCertificates = errors.New("found expiring certificates in the foundation")
+	ErrExpiredCertificates  = errors.New("found expired certificates in the foundation")
+)
+
 type ExpiringCerts struct {
 	logger  logger
 	api     expiringCertsService
 	Options struct {
-		ExpiresWithin string `long:"expires-within"  short:"e"  description:"timeframe in which to check expiration. Default: \"3m\".\n\t\t\t\tdays(d), weeks(w), months(m) and years(y) supported."`
+		ExpiresWithin       string            `long:"expires-within"      short:"e"  description:"timeframe in which to check expiration. Default: \"3m\".\n\t\t\t\tdays(d), weeks(w), months(m) and years(y) supported."`
+		ExpiresWithinByType map[string]string `long:"type-expires-within"            description:"timeframe for a type of certificate, as TYPE:TIMEFRAME (e.g. 'credhub:6m'). TYPE is a certificate location or property type. Can be repeated"`
+		Format              string            `long:"format"              short:"f"  description:"Format to print as" default:"text" choice:"text" choice:"json"`
 	}
 }
 
+type expiringCertificatesOutput struct {
+	Status       string                      `json:"status"`
+	Certificates []expiringCertificateOutput `json:"certificates"`
+}
+
+type expiringCertificateOutput struct {
+	Status                string    `json:"status"`
+	Location              string    `json:"location"`
+	ProductGUID           string    `json:"product_guid,omitempty"`
+	PropertyReference     string    `json:"property_reference,omitempty"`
+	VariablePath          string    `json:"variable_path,omitempty"`
+	PropertyType          string    `json:"property_type,omitempty"`
+	Issuer                string    `json:"issuer,omitempty"`
+	Configurable          bool      `json:"configurable"`
+	ValidFrom             time.Time `json:"valid_from"`
+	ValidUntil            time.Time `json:"valid_until"`
+	RotationProcedureName string    `json:"rotation_procedure_name,omitempty"`
+	RotationProcedureUrl  string    `json:"rotation_procedure_url,omitempty"`
+}
+
 func NewExpiringCertificates(service expiringCertsService, logger logger) *ExpiringCerts {
 	return &ExpiringCerts{
 		api:    service,
@@ -42,12 +73,48 @@ func (e *ExpiringCerts) Execute(args []string) error {
 		return err
 	}
 
-	e.logger.Println("Getting expiring certificates...")
-	expiringCerts, err := e.api.ListCertificates(e.Options.ExpiresWithin)
+	now := time.Now()
+
+	// the API can only filter with one timeframe, so it is asked for the
+	// widest one and the narrower ones are applied here
+	expiresWithin := e.Options.ExpiresWithin
+	for _, timeframe := range e.Options.ExpiresWithinByType {
+		if expiresWithinDeadline(now, timeframe).After(expiresWithinDeadline(now, expiresWithin)) {
+			expiresWithin = timeframe
+		}
+	}
+
+	if e.Options.Format != "json" {
+		e.logger.Println("Getting expiring certificates...")
+	}
+	expiringCerts, err := e.api.ListCertificates(expiresWithin)
 	if err != nil {
 		return fmt.Errorf("could not fetch expiring certificates: %s", err)
 	}
 
+	if len(e.Options.ExpiresWithinByType) > 0 {
+		expiringCerts = e.filterByType(now, expiringCerts)
+	}
+
+	var result error
+	if len(expiringCerts) > 0 {
+		result = ErrExpiringCertificates
+	}
+	for _, cert := range expiringCerts {
+		if now.After(cert.ValidUntil) {
+			result = ErrExpiredCertificates
+		}
+	}
+
+	if e.Options.Format == "json" {
+		err = e.printJSON(now, expiringCerts, result)
+		if err != nil {
+			return err
+		}
+
+		return result
+	}
+
 	if len(expiringCerts) == 0 {
 		e.logger.Printf(color.GreenString("[✓] No certificates are expiring in %s\n"), e.Options.ExpiresWithin)
 		return nil
@@ -94,7 +161,87 @@ func (e *ExpiringCerts) Execute(args []string) error {
 		}
 	}
 
-	return errors.New("found expiring certificates in the foundation")
+	return result
+}
+
+func (e *ExpiringCerts) filterByType(now time.Time, certs []api.ExpiringCertificate) []api.ExpiringCertificate {
+	var filtered []api.ExpiringCertificate
+	for _, cert := range certs {
+		timeframe := e.Options.ExpiresWithin
+		if typeTimeframe, ok := e.Options.ExpiresWithinByType[cert.PropertyType]; ok {
+			timeframe = typeTimeframe
+		}
+		if typeTimeframe, ok := e.Options.ExpiresWithinByType[cert.Location]; ok {
+			timeframe = typeTimeframe
+		}
+
+		if cert.ValidUntil.Before(expiresWithinDeadline(now, timeframe)) {
+			filtered = append(filtered, cert)
+		}
+	}
+
+	return filtered
+}
+
+func (e *ExpiringCerts) printJSON(now time.Time, certs []api.ExpiringCertificate, result error) error {
+	output := expiringCertificatesOutput{
+		Status:       "ok",
+		Certificates: []expiringCertificateOutput{},
+	}
+	switch result {
+	case ErrExpiringCertificates:
+		output.Status = "warning"
+	case ErrExpiredCertificates:
+		output.Status = "expired"
+	}
+
+	for _, cert := range certs {
+		status := "expiring"
+		if now.After(cert.ValidUntil) {
+			status = "expired"
+		}
+
+		output.Certificates = append(output.Certificates, expiringCertificateOutput{
+			Status:                status,
+			Location:              cert.Location,
+			ProductGUID:           cert.ProductGUID,
+			PropertyReference:     cert.PropertyReference,
+			VariablePath:          cert.VariablePath,
+			PropertyType:          cert.PropertyType,
+			Issuer:                cert.Issuer,
+			Configurable:          cert.Configurable,
+			ValidFrom:             cert.ValidFrom,
+			ValidUntil:            cert.ValidUntil,
+			RotationProcedureName: cert.RotationProcedureName,
+			RotationProcedureUrl:  cert.RotationProcedureUrl,
+		})
+	}
+
+	contents, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	e.logger.Println(string(contents))
+
+	return nil
+}
+
+// expiresWithinDeadline returns the time a validated timeframe like "3m"
+// ends at.
+func expiresWithinDeadline(now time.Time, timeframe string) time.Time {
+	amount, _ := strconv.Atoi(timeframe[:len(timeframe)-1])
+
+	switch timeframe[len(timeframe)-1] {
+	case 'w':
+		return now.AddDate(0, 0, 7*amount)
+	case 'm':
+		return now.AddDate(0, amount, 0)
+	case 'y':
+		return now.AddDate(amount, 0, 0)
+	}
+
+	return now.AddDate(0, 0, amount)
 }
 
 func (e *ExpiringCerts) earliestExpiryDate(certs []api.ExpiringCertificate) time.Time {
@@ -178,7 +325,23 @@ func (e *ExpiringCerts) printExpiringCertInfo(cert api.ExpiringCertificate, inde
 }
 
 func (e ExpiringCerts) validateConfig() error {
-	matched, err := regexp.MatchString("^[1-9]+\\d*[dwmy]$", e.Options.ExpiresWithin)
+	err := validateExpiresWithin(e.Options.ExpiresWithin)
+	if err != nil {
+		return err
+	}
+
+	for certType, timeframe := range e.Options.ExpiresWithinByType {
+		err = validateExpiresWithin(timeframe)
+		if err != nil {
+			return fmt.Errorf("invalid timeframe for %s: %w", certType, err)
+		}
+	}
+
+	return nil
+}
+
+func validateExpiresWithin(timeframe string) error {
+	matched, err := regexp.MatchString("^[1-9]+\\d*[dwmy]$", timeframe)
 	if err != nil {
 		return err
 	}
@@ -360,6 +360,83 @@ var _ = Describe("ExpiringCertificates", func() {
 		})
 	})
 
+	When("a timeframe is given per type of certificate", func() {
+		BeforeEach(func() {
+			service.ListCertificatesReturns([]api.ExpiringCertificate{
+				{Location: "credhub", VariablePath: "/credhub-cert", ValidUntil: time.Now().AddDate(0, 4, 0)},
+				{Location: "ops_manager", PropertyReference: ".properties.leaf", PropertyType: "rsa_cert_credentials", ValidUntil: time.Now().AddDate(0, 4, 0)},
+				{Location: "ops_manager", PropertyReference: ".properties.other", ValidUntil: time.Now().AddDate(0, 0, 10)},
+			}, nil)
+		})
+
+		It("asks for the widest timeframe and applies each timeframe to its type", func() {
+			command := commands.NewExpiringCertificates(service, logger)
+			err := executeCommand(command, []string{
+				"--expires-within", "1m",
+				"--type-expires-within", "credhub:6m",
+				"--type-expires-within", "rsa_cert_credentials:2w",
+			})
+			Expect(err).To(MatchError(commands.ErrExpiringCertificates))
+
+			Expect(service.ListCertificatesArgsForCall(0)).To(Equal("6m"))
+
+			contents := string(stdout.Contents())
+			Expect(contents).To(ContainSubstring("/credhub-cert: expiring"))
+			Expect(contents).To(ContainSubstring(".properties.other: expiring"))
+			Expect(contents).ToNot(ContainSubstring(".properties.leaf"))
+		})
+
+		It("validates each timeframe", func() {
+			command := commands.NewExpiringCertificates(service, logger)
+			err := executeCommand(command, []string{"--type-expires-within", "credhub:6s"})
+			Expect(err).To(MatchError(ContainSubstring("invalid timeframe for credhub: only d,w,m, or y are supported")))
+		})
+	})
+
+	When("the format is json", func() {
+		It("prints the certificates and the overall status", func() {
+			validUntil := time.Date(2015, 12, 12, 12, 12, 12, 0, time.UTC)
+			service.ListCertificatesReturns([]api.ExpiringCertificate{
+				{
+					Location:              "ops_manager",
+					ProductGUID:           "cf-guid",
+					PropertyReference:     ".properties.cert",
+					PropertyType:          "rsa_cert_credentials",
+					Configurable:          true,
+					ValidUntil:            validUntil,
+					RotationProcedureName: "Standard Procedure",
+				},
+			}, nil)
+
+			command := commands.NewExpiringCertificates(service, logger)
+			err := executeCommand(command, []string{"--format", "json"})
+			Expect(err).To(MatchError(commands.ErrExpiredCertificates))
+
+			Expect(stdout.Contents()).To(MatchJSON(`{
+				"status": "expired",
+				"certificates": [{
+					"status": "expired",
+					"location": "ops_manager",
+					"product_guid": "cf-guid",
+					"property_reference": ".properties.cert",
+					"property_type": "rsa_cert_credentials",
+					"configurable": true,
+					"valid_from": "0001-01-01T00:00:00Z",
+					"valid_until": "2015-12-12T12:12:12Z",
+					"rotation_procedure_name": "Standard Procedure"
+				}]
+			}`))
+		})
+
+		It("reports ok when nothing is expiring", func() {
+			command := commands.NewExpiringCertificates(service, logger)
+			err := executeCommand(command, []string{"--format", "json"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stdout.Contents()).To(MatchJSON(`{"status": "ok", "certificates": []}`))
+		})
+	})
+
 	When("certs cannot be fetched", func() {
 		It("returns an error", func() {
 			service.ListCertificatesReturns(nil, errors.New("an api error"))
@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"regexp"
+	"time"
 
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
@@ -21,7 +22,8 @@ type ExpiringLicenses struct {
 		Staged        bool   `long:"staged" short:"s" description:"Specify to include staged products. Can be used with other options."`
 		Deployed      bool   `long:"deployed" short:"d" description:"Specify to deployed products. Can be used with other options."`
 		ExpiresWithin string `long:"expires-within"  short:"e"  description:"timeframe in which to check expiration. Default: \"3m\".\n\t\t\t\tdays(d), weeks(w), months(m) and years(y) supported."`
-		Format        string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		ExitCode      bool   `long:"exit-code" description:"exit with 3 when licenses are expiring and 4 when they have expired or are missing, instead of 0"`
+		formatOptions
 	}
 }
 
@@ -29,6 +31,13 @@ const (
 	DefaultExpiresWithin = "3m"
 )
 
+// ErrExpiringLicenses and ErrExpiredLicenses share the exit codes of the
+// expiring certificates errors.
+var (
+	ErrExpiringLicenses = errors.New("found expiring licenses in the foundation")
+	ErrExpiredLicenses  = errors.New("found expired or missing licenses in the foundation")
+)
+
 func NewExpiringLicenses(presenter presenters.FormattedPresenter, service expiringLicensesService, logger logger) *ExpiringLicenses {
 	return &ExpiringLicenses{
 		presenter: presenter,
@@ -53,7 +62,19 @@ func (e *ExpiringLicenses) Execute(args []string) error {
 
 	e.presenter.SetFormat(e.Options.Format)
 	e.presenter.PresentLicensedProducts(expiringLicenses)
-	return nil
+
+	if !e.Options.ExitCode || len(expiringLicenses) == 0 {
+		return nil
+	}
+
+	for _, license := range expiringLicenses {
+		// a license without an expiry date has not been configured yet
+		if license.ExpiresAt.IsZero() || time.Now().After(license.ExpiresAt) {
+			return ErrExpiredLicenses
+		}
+	}
+
+	return ErrExpiringLicenses
 }
 
 func (e ExpiringLicenses) validateConfig() error {
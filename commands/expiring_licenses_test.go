@@ -225,4 +225,36 @@ var _ = Describe("ExpiringLicenses", func() {
 			Expect(presentedLicenses).To(Equal(expiringLicenses))
 		})
 	})
+
+	When("--exit-code is set", func() {
+		It("exits with the expiring licenses error when licenses are expiring", func() {
+			service.ListExpiringLicensesReturns([]api.ExpiringLicenseOutput{
+				{ProductName: "cf", GUID: "cf-guid", ExpiresAt: time.Now().AddDate(0, 1, 0)},
+			}, nil)
+
+			command := commands.NewExpiringLicenses(presenter, service, logger)
+			err := executeCommand(command, []string{"--exit-code"})
+			Expect(err).To(MatchError(commands.ErrExpiringLicenses))
+			Expect(presenter.PresentLicensedProductsCallCount()).To(Equal(1))
+		})
+
+		It("exits with the expired licenses error when a license has expired or is missing", func() {
+			service.ListExpiringLicensesReturns([]api.ExpiringLicenseOutput{
+				{ProductName: "cf", GUID: "cf-guid", ExpiresAt: time.Now().AddDate(0, 1, 0)},
+				{ProductName: "p-mysql", GUID: "mysql-guid"},
+			}, nil)
+
+			command := commands.NewExpiringLicenses(presenter, service, logger)
+			err := executeCommand(command, []string{"--exit-code", "--format", "json"})
+			Expect(err).To(MatchError(commands.ErrExpiredLicenses))
+		})
+
+		It("succeeds when no licenses are expiring", func() {
+			service.ListExpiringLicensesReturns([]api.ExpiringLicenseOutput{}, nil)
+
+			command := commands.NewExpiringLicenses(presenter, service, logger)
+			err := executeCommand(command, []string{"--exit-code"})
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
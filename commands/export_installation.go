@@ -1,20 +1,28 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
 )
 
 type ExportInstallation struct {
 	logger  logger
 	service exportInstallationService
 	Options struct {
-		OutputFile string `long:"output-file"      short:"o"  required:"true" description:"output path to write installation to"`
+		OutputFile    string `long:"output-file"    short:"o" required:"true" description:"output path to write installation to"`
+		Retries       int    `long:"retries"                  default:"3"     description:"number of times to resume the download after it is interrupted"`
+		RetryInterval int    `long:"retry-interval"           default:"10"    description:"interval (in seconds) to wait before resuming an interrupted download"`
+		NoResume      bool   `long:"no-resume"                                description:"discard a partial download left behind by an earlier export instead of resuming it"`
 	}
 }
 
 //counterfeiter:generate -o ./fakes/export_installation_service.go --fake-name ExportInstallationService . exportInstallationService
 type exportInstallationService interface {
-	DownloadInstallationAssetCollection(outputFile string) error
+	DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput) error
 }
 
 func NewExportInstallation(service exportInstallationService, logger logger) *ExportInstallation {
@@ -27,7 +35,19 @@ func NewExportInstallation(service exportInstallationService, logger logger) *Ex
 func (ei ExportInstallation) Execute(args []string) error {
 	ei.logger.Printf("exporting installation")
 
-	err := ei.service.DownloadInstallationAssetCollection(ei.Options.OutputFile)
+	var err error
+	for i := 0; i <= ei.Options.Retries; i++ {
+		err = ei.service.DownloadInstallationAssetCollection(api.DownloadInstallationAssetCollectionInput{
+			OutputFile: ei.Options.OutputFile,
+			Resume:     i > 0 || !ei.Options.NoResume,
+		})
+		if err == nil || i == ei.Options.Retries || !isInterruptedDownload(err) {
+			break
+		}
+
+		ei.logger.Printf("retrying installation export after error: %s\n", err)
+		time.Sleep(time.Second * time.Duration(ei.Options.RetryInterval))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to export installation: %s", err)
 	}
@@ -36,3 +56,16 @@ func (ei ExportInstallation) Execute(args []string) error {
 
 	return nil
 }
+
+// isInterruptedDownload is true for an export that stopped part way, which
+// the next try resumes, and for a request that could not reach Ops Manager.
+// A request that timed out waiting for Ops Manager is not tried again.
+func isInterruptedDownload(err error) bool {
+	if errors.Is(err, api.ErrDownloadInterrupted) {
+		return true
+	}
+
+	// not net.Error, which the errors of the file system satisfy too
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && !opErr.Timeout()
+}
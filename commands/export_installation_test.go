@@ -3,7 +3,9 @@ package commands_test
 import (
 	"errors"
 	"fmt"
+	"net"
 
+	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
 
@@ -32,8 +34,11 @@ var _ = Describe("ExportInstallation", func() {
 
 		By("calling export on the installation service")
 		Expect(fakeService.DownloadInstallationAssetCollectionCallCount()).To(Equal(1))
-		outputFile := fakeService.DownloadInstallationAssetCollectionArgsForCall(0)
-		Expect(outputFile).To(Equal("/path/to/output.zip"))
+		input := fakeService.DownloadInstallationAssetCollectionArgsForCall(0)
+		Expect(input).To(Equal(api.DownloadInstallationAssetCollectionInput{
+			OutputFile: "/path/to/output.zip",
+			Resume:     true,
+		}))
 
 		By("printing correct log messages")
 		Expect(logger.PrintfCallCount()).To(Equal(2))
@@ -44,13 +49,66 @@ var _ = Describe("ExportInstallation", func() {
 		Expect(fmt.Sprintf(format, v...)).To(Equal("finished exporting installation"))
 	})
 
+	It("starts over when asked not to resume an earlier export", func() {
+		command := commands.NewExportInstallation(fakeService, logger)
+
+		err := executeCommand(command, []string{
+			"--output-file", "/path/to/output.zip",
+			"--no-resume",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		input := fakeService.DownloadInstallationAssetCollectionArgsForCall(0)
+		Expect(input.Resume).To(BeFalse())
+	})
+
+	When("the download is interrupted", func() {
+		It("resumes the download", func() {
+			command := commands.NewExportInstallation(fakeService, logger)
+			fakeService.DownloadInstallationAssetCollectionReturnsOnCall(0, fmt.Errorf("%w at 5 of 17 bytes", api.ErrDownloadInterrupted))
+
+			err := executeCommand(command, []string{
+				"--output-file", "/path/to/output.zip",
+				"--no-resume",
+				"--retry-interval", "0",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.DownloadInstallationAssetCollectionCallCount()).To(Equal(2))
+			input := fakeService.DownloadInstallationAssetCollectionArgsForCall(1)
+			Expect(input.Resume).To(BeTrue())
+
+			format, v := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("retrying installation export after error: download of installation interrupted at 5 of 17 bytes\n"))
+		})
+	})
+
+	When("Ops Manager cannot be reached", func() {
+		It("returns an error after retrying", func() {
+			command := commands.NewExportInstallation(fakeService, logger)
+			fakeService.DownloadInstallationAssetCollectionReturns(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+			err := executeCommand(command, []string{
+				"--output-file", "/some/path",
+				"--retries", "2",
+				"--retry-interval", "0",
+			})
+			Expect(err).To(MatchError("failed to export installation: dial tcp: connection refused"))
+			Expect(fakeService.DownloadInstallationAssetCollectionCallCount()).To(Equal(3))
+		})
+	})
+
 	When("the installation cannot be exported", func() {
-		It("returns an error", func() {
+		It("returns the error without retrying", func() {
 			command := commands.NewExportInstallation(fakeService, logger)
-			fakeService.DownloadInstallationAssetCollectionReturns(errors.New("some error"))
+			fakeService.DownloadInstallationAssetCollectionReturns(errors.New("cannot create output file: permission denied"))
 
-			err := executeCommand(command, []string{"--output-file", "/some/path"})
-			Expect(err).To(MatchError("failed to export installation: some error"))
+			err := executeCommand(command, []string{
+				"--output-file", "/some/path",
+				"--retry-interval", "0",
+			})
+			Expect(err).To(MatchError("failed to export installation: cannot create output file: permission denied"))
+			Expect(fakeService.DownloadInstallationAssetCollectionCallCount()).To(Equal(1))
 		})
 	})
 })
@@ -8,6 +8,17 @@ import (
 )
 
 type ApplyChangesService struct {
+	AbortInstallationStub        func(int) error
+	abortInstallationMutex       sync.RWMutex
+	abortInstallationArgsForCall []struct {
+		arg1 int
+	}
+	abortInstallationReturns struct {
+		result1 error
+	}
+	abortInstallationReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateInstallationStub        func(bool, bool, bool, []string, api.ApplyErrandChanges) (api.InstallationsServiceOutput, error)
 	createInstallationMutex       sync.RWMutex
 	createInstallationArgsForCall []struct {
@@ -51,6 +62,19 @@ type ApplyChangesService struct {
 		result1 api.InstallationsServiceOutput
 		result2 error
 	}
+	GetStagedProductDependenciesStub        func(string) ([]api.ProductDependency, error)
+	getStagedProductDependenciesMutex       sync.RWMutex
+	getStagedProductDependenciesArgsForCall []struct {
+		arg1 string
+	}
+	getStagedProductDependenciesReturns struct {
+		result1 []api.ProductDependency
+		result2 error
+	}
+	getStagedProductDependenciesReturnsOnCall map[int]struct {
+		result1 []api.ProductDependency
+		result2 error
+	}
 	InfoStub        func() (api.Info, error)
 	infoMutex       sync.RWMutex
 	infoArgsForCall []struct {
@@ -75,6 +99,18 @@ type ApplyChangesService struct {
 		result1 []api.InstallationsServiceOutput
 		result2 error
 	}
+	ListStagedProductsStub        func() (api.StagedProductsOutput, error)
+	listStagedProductsMutex       sync.RWMutex
+	listStagedProductsArgsForCall []struct {
+	}
+	listStagedProductsReturns struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	listStagedProductsReturnsOnCall map[int]struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
 	RunningInstallationStub        func() (api.InstallationsServiceOutput, error)
 	runningInstallationMutex       sync.RWMutex
 	runningInstallationArgsForCall []struct {
@@ -102,6 +138,66 @@ type ApplyChangesService struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ApplyChangesService) AbortInstallation(arg1 int) error {
+	fake.abortInstallationMutex.Lock()
+	ret, specificReturn := fake.abortInstallationReturnsOnCall[len(fake.abortInstallationArgsForCall)]
+	fake.abortInstallationArgsForCall = append(fake.abortInstallationArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("AbortInstallation", []interface{}{arg1})
+	fake.abortInstallationMutex.Unlock()
+	if fake.AbortInstallationStub != nil {
+		return fake.AbortInstallationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.abortInstallationReturns
+	return fakeReturns.result1
+}
+
+func (fake *ApplyChangesService) AbortInstallationCallCount() int {
+	fake.abortInstallationMutex.RLock()
+	defer fake.abortInstallationMutex.RUnlock()
+	return len(fake.abortInstallationArgsForCall)
+}
+
+func (fake *ApplyChangesService) AbortInstallationCalls(stub func(int) error) {
+	fake.abortInstallationMutex.Lock()
+	defer fake.abortInstallationMutex.Unlock()
+	fake.AbortInstallationStub = stub
+}
+
+func (fake *ApplyChangesService) AbortInstallationArgsForCall(i int) int {
+	fake.abortInstallationMutex.RLock()
+	defer fake.abortInstallationMutex.RUnlock()
+	argsForCall := fake.abortInstallationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ApplyChangesService) AbortInstallationReturns(result1 error) {
+	fake.abortInstallationMutex.Lock()
+	defer fake.abortInstallationMutex.Unlock()
+	fake.AbortInstallationStub = nil
+	fake.abortInstallationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ApplyChangesService) AbortInstallationReturnsOnCall(i int, result1 error) {
+	fake.abortInstallationMutex.Lock()
+	defer fake.abortInstallationMutex.Unlock()
+	fake.AbortInstallationStub = nil
+	if fake.abortInstallationReturnsOnCall == nil {
+		fake.abortInstallationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.abortInstallationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ApplyChangesService) CreateInstallation(arg1 bool, arg2 bool, arg3 bool, arg4 []string, arg5 api.ApplyErrandChanges) (api.InstallationsServiceOutput, error) {
 	var arg4Copy []string
 	if arg4 != nil {
@@ -300,6 +396,69 @@ func (fake *ApplyChangesService) GetInstallationLogsReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *ApplyChangesService) GetStagedProductDependencies(arg1 string) ([]api.ProductDependency, error) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	ret, specificReturn := fake.getStagedProductDependenciesReturnsOnCall[len(fake.getStagedProductDependenciesArgsForCall)]
+	fake.getStagedProductDependenciesArgsForCall = append(fake.getStagedProductDependenciesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStagedProductDependencies", []interface{}{arg1})
+	fake.getStagedProductDependenciesMutex.Unlock()
+	if fake.GetStagedProductDependenciesStub != nil {
+		return fake.GetStagedProductDependenciesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedProductDependenciesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ApplyChangesService) GetStagedProductDependenciesCallCount() int {
+	fake.getStagedProductDependenciesMutex.RLock()
+	defer fake.getStagedProductDependenciesMutex.RUnlock()
+	return len(fake.getStagedProductDependenciesArgsForCall)
+}
+
+func (fake *ApplyChangesService) GetStagedProductDependenciesCalls(stub func(string) ([]api.ProductDependency, error)) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	defer fake.getStagedProductDependenciesMutex.Unlock()
+	fake.GetStagedProductDependenciesStub = stub
+}
+
+func (fake *ApplyChangesService) GetStagedProductDependenciesArgsForCall(i int) string {
+	fake.getStagedProductDependenciesMutex.RLock()
+	defer fake.getStagedProductDependenciesMutex.RUnlock()
+	argsForCall := fake.getStagedProductDependenciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ApplyChangesService) GetStagedProductDependenciesReturns(result1 []api.ProductDependency, result2 error) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	defer fake.getStagedProductDependenciesMutex.Unlock()
+	fake.GetStagedProductDependenciesStub = nil
+	fake.getStagedProductDependenciesReturns = struct {
+		result1 []api.ProductDependency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ApplyChangesService) GetStagedProductDependenciesReturnsOnCall(i int, result1 []api.ProductDependency, result2 error) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	defer fake.getStagedProductDependenciesMutex.Unlock()
+	fake.GetStagedProductDependenciesStub = nil
+	if fake.getStagedProductDependenciesReturnsOnCall == nil {
+		fake.getStagedProductDependenciesReturnsOnCall = make(map[int]struct {
+			result1 []api.ProductDependency
+			result2 error
+		})
+	}
+	fake.getStagedProductDependenciesReturnsOnCall[i] = struct {
+		result1 []api.ProductDependency
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ApplyChangesService) Info() (api.Info, error) {
 	fake.infoMutex.Lock()
 	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
@@ -410,6 +569,61 @@ func (fake *ApplyChangesService) ListInstallationsReturnsOnCall(i int, result1 [
 	}{result1, result2}
 }
 
+func (fake *ApplyChangesService) ListStagedProducts() (api.StagedProductsOutput, error) {
+	fake.listStagedProductsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductsReturnsOnCall[len(fake.listStagedProductsArgsForCall)]
+	fake.listStagedProductsArgsForCall = append(fake.listStagedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStagedProducts", []interface{}{})
+	fake.listStagedProductsMutex.Unlock()
+	if fake.ListStagedProductsStub != nil {
+		return fake.ListStagedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ApplyChangesService) ListStagedProductsCallCount() int {
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	return len(fake.listStagedProductsArgsForCall)
+}
+
+func (fake *ApplyChangesService) ListStagedProductsCalls(stub func() (api.StagedProductsOutput, error)) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = stub
+}
+
+func (fake *ApplyChangesService) ListStagedProductsReturns(result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	fake.listStagedProductsReturns = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ApplyChangesService) ListStagedProductsReturnsOnCall(i int, result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	if fake.listStagedProductsReturnsOnCall == nil {
+		fake.listStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductsReturnsOnCall[i] = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ApplyChangesService) RunningInstallation() (api.InstallationsServiceOutput, error) {
 	fake.runningInstallationMutex.Lock()
 	ret, specificReturn := fake.runningInstallationReturnsOnCall[len(fake.runningInstallationArgsForCall)]
@@ -528,16 +742,22 @@ func (fake *ApplyChangesService) UpdateStagedDirectorPropertiesReturnsOnCall(i i
 func (fake *ApplyChangesService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.abortInstallationMutex.RLock()
+	defer fake.abortInstallationMutex.RUnlock()
 	fake.createInstallationMutex.RLock()
 	defer fake.createInstallationMutex.RUnlock()
 	fake.getInstallationMutex.RLock()
 	defer fake.getInstallationMutex.RUnlock()
 	fake.getInstallationLogsMutex.RLock()
 	defer fake.getInstallationLogsMutex.RUnlock()
+	fake.getStagedProductDependenciesMutex.RLock()
+	defer fake.getStagedProductDependenciesMutex.RUnlock()
 	fake.infoMutex.RLock()
 	defer fake.infoMutex.RUnlock()
 	fake.listInstallationsMutex.RLock()
 	defer fake.listInstallationsMutex.RUnlock()
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
 	fake.runningInstallationMutex.RLock()
 	defer fake.runningInstallationMutex.RUnlock()
 	fake.updateStagedDirectorPropertiesMutex.RLock()
@@ -19,6 +19,30 @@ type AssignStemcellService struct {
 	assignStemcellReturnsOnCall map[int]struct {
 		result1 error
 	}
+	InfoStub        func() (api.Info, error)
+	infoMutex       sync.RWMutex
+	infoArgsForCall []struct {
+	}
+	infoReturns struct {
+		result1 api.Info
+		result2 error
+	}
+	infoReturnsOnCall map[int]struct {
+		result1 api.Info
+		result2 error
+	}
+	ListMultiStemcellsStub        func() (api.ProductMultiStemcells, error)
+	listMultiStemcellsMutex       sync.RWMutex
+	listMultiStemcellsArgsForCall []struct {
+	}
+	listMultiStemcellsReturns struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}
+	listMultiStemcellsReturnsOnCall map[int]struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}
 	ListStemcellsStub        func() (api.ProductStemcells, error)
 	listStemcellsMutex       sync.RWMutex
 	listStemcellsArgsForCall []struct {
@@ -95,6 +119,116 @@ func (fake *AssignStemcellService) AssignStemcellReturnsOnCall(i int, result1 er
 	}{result1}
 }
 
+func (fake *AssignStemcellService) Info() (api.Info, error) {
+	fake.infoMutex.Lock()
+	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
+	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Info", []interface{}{})
+	fake.infoMutex.Unlock()
+	if fake.InfoStub != nil {
+		return fake.InfoStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.infoReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AssignStemcellService) InfoCallCount() int {
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	return len(fake.infoArgsForCall)
+}
+
+func (fake *AssignStemcellService) InfoCalls(stub func() (api.Info, error)) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = stub
+}
+
+func (fake *AssignStemcellService) InfoReturns(result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	fake.infoReturns = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AssignStemcellService) InfoReturnsOnCall(i int, result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	if fake.infoReturnsOnCall == nil {
+		fake.infoReturnsOnCall = make(map[int]struct {
+			result1 api.Info
+			result2 error
+		})
+	}
+	fake.infoReturnsOnCall[i] = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AssignStemcellService) ListMultiStemcells() (api.ProductMultiStemcells, error) {
+	fake.listMultiStemcellsMutex.Lock()
+	ret, specificReturn := fake.listMultiStemcellsReturnsOnCall[len(fake.listMultiStemcellsArgsForCall)]
+	fake.listMultiStemcellsArgsForCall = append(fake.listMultiStemcellsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListMultiStemcells", []interface{}{})
+	fake.listMultiStemcellsMutex.Unlock()
+	if fake.ListMultiStemcellsStub != nil {
+		return fake.ListMultiStemcellsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listMultiStemcellsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AssignStemcellService) ListMultiStemcellsCallCount() int {
+	fake.listMultiStemcellsMutex.RLock()
+	defer fake.listMultiStemcellsMutex.RUnlock()
+	return len(fake.listMultiStemcellsArgsForCall)
+}
+
+func (fake *AssignStemcellService) ListMultiStemcellsCalls(stub func() (api.ProductMultiStemcells, error)) {
+	fake.listMultiStemcellsMutex.Lock()
+	defer fake.listMultiStemcellsMutex.Unlock()
+	fake.ListMultiStemcellsStub = stub
+}
+
+func (fake *AssignStemcellService) ListMultiStemcellsReturns(result1 api.ProductMultiStemcells, result2 error) {
+	fake.listMultiStemcellsMutex.Lock()
+	defer fake.listMultiStemcellsMutex.Unlock()
+	fake.ListMultiStemcellsStub = nil
+	fake.listMultiStemcellsReturns = struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AssignStemcellService) ListMultiStemcellsReturnsOnCall(i int, result1 api.ProductMultiStemcells, result2 error) {
+	fake.listMultiStemcellsMutex.Lock()
+	defer fake.listMultiStemcellsMutex.Unlock()
+	fake.ListMultiStemcellsStub = nil
+	if fake.listMultiStemcellsReturnsOnCall == nil {
+		fake.listMultiStemcellsReturnsOnCall = make(map[int]struct {
+			result1 api.ProductMultiStemcells
+			result2 error
+		})
+	}
+	fake.listMultiStemcellsReturnsOnCall[i] = struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *AssignStemcellService) ListStemcells() (api.ProductStemcells, error) {
 	fake.listStemcellsMutex.Lock()
 	ret, specificReturn := fake.listStemcellsReturnsOnCall[len(fake.listStemcellsArgsForCall)]
@@ -155,6 +289,10 @@ func (fake *AssignStemcellService) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.assignStemcellMutex.RLock()
 	defer fake.assignStemcellMutex.RUnlock()
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	fake.listMultiStemcellsMutex.RLock()
+	defer fake.listMultiStemcellsMutex.RUnlock()
 	fake.listStemcellsMutex.RLock()
 	defer fake.listStemcellsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
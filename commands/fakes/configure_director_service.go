@@ -63,6 +63,18 @@ type ConfigureDirectorService struct {
 	deleteVMExtensionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	GetStagedDirectorNetworksStub        func() (api.NetworksConfigurationOutput, error)
+	getStagedDirectorNetworksMutex       sync.RWMutex
+	getStagedDirectorNetworksArgsForCall []struct {
+	}
+	getStagedDirectorNetworksReturns struct {
+		result1 api.NetworksConfigurationOutput
+		result2 error
+	}
+	getStagedDirectorNetworksReturnsOnCall map[int]struct {
+		result1 api.NetworksConfigurationOutput
+		result2 error
+	}
 	GetStagedProductByNameStub        func(string) (api.StagedProductsFindOutput, error)
 	getStagedProductByNameMutex       sync.RWMutex
 	getStagedProductByNameArgsForCall []struct {
@@ -491,6 +503,61 @@ func (fake *ConfigureDirectorService) DeleteVMExtensionReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *ConfigureDirectorService) GetStagedDirectorNetworks() (api.NetworksConfigurationOutput, error) {
+	fake.getStagedDirectorNetworksMutex.Lock()
+	ret, specificReturn := fake.getStagedDirectorNetworksReturnsOnCall[len(fake.getStagedDirectorNetworksArgsForCall)]
+	fake.getStagedDirectorNetworksArgsForCall = append(fake.getStagedDirectorNetworksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetStagedDirectorNetworks", []interface{}{})
+	fake.getStagedDirectorNetworksMutex.Unlock()
+	if fake.GetStagedDirectorNetworksStub != nil {
+		return fake.GetStagedDirectorNetworksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedDirectorNetworksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureDirectorService) GetStagedDirectorNetworksCallCount() int {
+	fake.getStagedDirectorNetworksMutex.RLock()
+	defer fake.getStagedDirectorNetworksMutex.RUnlock()
+	return len(fake.getStagedDirectorNetworksArgsForCall)
+}
+
+func (fake *ConfigureDirectorService) GetStagedDirectorNetworksCalls(stub func() (api.NetworksConfigurationOutput, error)) {
+	fake.getStagedDirectorNetworksMutex.Lock()
+	defer fake.getStagedDirectorNetworksMutex.Unlock()
+	fake.GetStagedDirectorNetworksStub = stub
+}
+
+func (fake *ConfigureDirectorService) GetStagedDirectorNetworksReturns(result1 api.NetworksConfigurationOutput, result2 error) {
+	fake.getStagedDirectorNetworksMutex.Lock()
+	defer fake.getStagedDirectorNetworksMutex.Unlock()
+	fake.GetStagedDirectorNetworksStub = nil
+	fake.getStagedDirectorNetworksReturns = struct {
+		result1 api.NetworksConfigurationOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureDirectorService) GetStagedDirectorNetworksReturnsOnCall(i int, result1 api.NetworksConfigurationOutput, result2 error) {
+	fake.getStagedDirectorNetworksMutex.Lock()
+	defer fake.getStagedDirectorNetworksMutex.Unlock()
+	fake.GetStagedDirectorNetworksStub = nil
+	if fake.getStagedDirectorNetworksReturnsOnCall == nil {
+		fake.getStagedDirectorNetworksReturnsOnCall = make(map[int]struct {
+			result1 api.NetworksConfigurationOutput
+			result2 error
+		})
+	}
+	fake.getStagedDirectorNetworksReturnsOnCall[i] = struct {
+		result1 api.NetworksConfigurationOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ConfigureDirectorService) GetStagedProductByName(arg1 string) (api.StagedProductsFindOutput, error) {
 	fake.getStagedProductByNameMutex.Lock()
 	ret, specificReturn := fake.getStagedProductByNameReturnsOnCall[len(fake.getStagedProductByNameArgsForCall)]
@@ -1152,6 +1219,8 @@ func (fake *ConfigureDirectorService) Invocations() map[string][][]interface{} {
 	defer fake.deleteCustomVMTypesMutex.RUnlock()
 	fake.deleteVMExtensionMutex.RLock()
 	defer fake.deleteVMExtensionMutex.RUnlock()
+	fake.getStagedDirectorNetworksMutex.RLock()
+	defer fake.getStagedDirectorNetworksMutex.RUnlock()
 	fake.getStagedProductByNameMutex.RLock()
 	defer fake.getStagedProductByNameMutex.RUnlock()
 	fake.getStagedProductManifestMutex.RLock()
@@ -0,0 +1,261 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type ConfigureErrandsService struct {
+	ListStagedProductErrandsStub        func(string) (api.ErrandsListOutput, error)
+	listStagedProductErrandsMutex       sync.RWMutex
+	listStagedProductErrandsArgsForCall []struct {
+		arg1 string
+	}
+	listStagedProductErrandsReturns struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}
+	listStagedProductErrandsReturnsOnCall map[int]struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}
+	ListStagedProductsStub        func() (api.StagedProductsOutput, error)
+	listStagedProductsMutex       sync.RWMutex
+	listStagedProductsArgsForCall []struct {
+	}
+	listStagedProductsReturns struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	listStagedProductsReturnsOnCall map[int]struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	UpdateStagedProductErrandsStub        func(string, string, interface{}, interface{}) error
+	updateStagedProductErrandsMutex       sync.RWMutex
+	updateStagedProductErrandsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 interface{}
+		arg4 interface{}
+	}
+	updateStagedProductErrandsReturns struct {
+		result1 error
+	}
+	updateStagedProductErrandsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductErrands(arg1 string) (api.ErrandsListOutput, error) {
+	fake.listStagedProductErrandsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductErrandsReturnsOnCall[len(fake.listStagedProductErrandsArgsForCall)]
+	fake.listStagedProductErrandsArgsForCall = append(fake.listStagedProductErrandsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListStagedProductErrands", []interface{}{arg1})
+	fake.listStagedProductErrandsMutex.Unlock()
+	if fake.ListStagedProductErrandsStub != nil {
+		return fake.ListStagedProductErrandsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedProductErrandsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductErrandsCallCount() int {
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	return len(fake.listStagedProductErrandsArgsForCall)
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductErrandsCalls(stub func(string) (api.ErrandsListOutput, error)) {
+	fake.listStagedProductErrandsMutex.Lock()
+	defer fake.listStagedProductErrandsMutex.Unlock()
+	fake.ListStagedProductErrandsStub = stub
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductErrandsArgsForCall(i int) string {
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	argsForCall := fake.listStagedProductErrandsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductErrandsReturns(result1 api.ErrandsListOutput, result2 error) {
+	fake.listStagedProductErrandsMutex.Lock()
+	defer fake.listStagedProductErrandsMutex.Unlock()
+	fake.ListStagedProductErrandsStub = nil
+	fake.listStagedProductErrandsReturns = struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductErrandsReturnsOnCall(i int, result1 api.ErrandsListOutput, result2 error) {
+	fake.listStagedProductErrandsMutex.Lock()
+	defer fake.listStagedProductErrandsMutex.Unlock()
+	fake.ListStagedProductErrandsStub = nil
+	if fake.listStagedProductErrandsReturnsOnCall == nil {
+		fake.listStagedProductErrandsReturnsOnCall = make(map[int]struct {
+			result1 api.ErrandsListOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductErrandsReturnsOnCall[i] = struct {
+		result1 api.ErrandsListOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureErrandsService) ListStagedProducts() (api.StagedProductsOutput, error) {
+	fake.listStagedProductsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductsReturnsOnCall[len(fake.listStagedProductsArgsForCall)]
+	fake.listStagedProductsArgsForCall = append(fake.listStagedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStagedProducts", []interface{}{})
+	fake.listStagedProductsMutex.Unlock()
+	if fake.ListStagedProductsStub != nil {
+		return fake.ListStagedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductsCallCount() int {
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	return len(fake.listStagedProductsArgsForCall)
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductsCalls(stub func() (api.StagedProductsOutput, error)) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = stub
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductsReturns(result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	fake.listStagedProductsReturns = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureErrandsService) ListStagedProductsReturnsOnCall(i int, result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	if fake.listStagedProductsReturnsOnCall == nil {
+		fake.listStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductsReturnsOnCall[i] = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureErrandsService) UpdateStagedProductErrands(arg1 string, arg2 string, arg3 interface{}, arg4 interface{}) error {
+	fake.updateStagedProductErrandsMutex.Lock()
+	ret, specificReturn := fake.updateStagedProductErrandsReturnsOnCall[len(fake.updateStagedProductErrandsArgsForCall)]
+	fake.updateStagedProductErrandsArgsForCall = append(fake.updateStagedProductErrandsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 interface{}
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("UpdateStagedProductErrands", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateStagedProductErrandsMutex.Unlock()
+	if fake.UpdateStagedProductErrandsStub != nil {
+		return fake.UpdateStagedProductErrandsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateStagedProductErrandsReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureErrandsService) UpdateStagedProductErrandsCallCount() int {
+	fake.updateStagedProductErrandsMutex.RLock()
+	defer fake.updateStagedProductErrandsMutex.RUnlock()
+	return len(fake.updateStagedProductErrandsArgsForCall)
+}
+
+func (fake *ConfigureErrandsService) UpdateStagedProductErrandsCalls(stub func(string, string, interface{}, interface{}) error) {
+	fake.updateStagedProductErrandsMutex.Lock()
+	defer fake.updateStagedProductErrandsMutex.Unlock()
+	fake.UpdateStagedProductErrandsStub = stub
+}
+
+func (fake *ConfigureErrandsService) UpdateStagedProductErrandsArgsForCall(i int) (string, string, interface{}, interface{}) {
+	fake.updateStagedProductErrandsMutex.RLock()
+	defer fake.updateStagedProductErrandsMutex.RUnlock()
+	argsForCall := fake.updateStagedProductErrandsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ConfigureErrandsService) UpdateStagedProductErrandsReturns(result1 error) {
+	fake.updateStagedProductErrandsMutex.Lock()
+	defer fake.updateStagedProductErrandsMutex.Unlock()
+	fake.UpdateStagedProductErrandsStub = nil
+	fake.updateStagedProductErrandsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureErrandsService) UpdateStagedProductErrandsReturnsOnCall(i int, result1 error) {
+	fake.updateStagedProductErrandsMutex.Lock()
+	defer fake.updateStagedProductErrandsMutex.Unlock()
+	fake.UpdateStagedProductErrandsStub = nil
+	if fake.updateStagedProductErrandsReturnsOnCall == nil {
+		fake.updateStagedProductErrandsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateStagedProductErrandsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureErrandsService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listStagedProductErrandsMutex.RLock()
+	defer fake.listStagedProductErrandsMutex.RUnlock()
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	fake.updateStagedProductErrandsMutex.RLock()
+	defer fake.updateStagedProductErrandsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ConfigureErrandsService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
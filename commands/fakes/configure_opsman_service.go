@@ -8,6 +8,16 @@ import (
 )
 
 type ConfigureOpsmanService struct {
+	DisableRBACStub        func() error
+	disableRBACMutex       sync.RWMutex
+	disableRBACArgsForCall []struct {
+	}
+	disableRBACReturns struct {
+		result1 error
+	}
+	disableRBACReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EnableRBACStub        func(api.RBACSettings) error
 	enableRBACMutex       sync.RWMutex
 	enableRBACArgsForCall []struct {
@@ -52,6 +62,18 @@ type ConfigureOpsmanService struct {
 	updateSSLCertificateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateSettingsStub        func(string, interface{}) error
+	updateSettingsMutex       sync.RWMutex
+	updateSettingsArgsForCall []struct {
+		arg1 string
+		arg2 interface{}
+	}
+	updateSettingsReturns struct {
+		result1 error
+	}
+	updateSettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateSyslogSettingsStub        func(api.SyslogSettings) error
 	updateSyslogSettingsMutex       sync.RWMutex
 	updateSyslogSettingsArgsForCall []struct {
@@ -74,10 +96,73 @@ type ConfigureOpsmanService struct {
 	updateTokensExpirationReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateUAALoginBannerStub        func(api.UAALoginBannerSettings) error
+	updateUAALoginBannerMutex       sync.RWMutex
+	updateUAALoginBannerArgsForCall []struct {
+		arg1 api.UAALoginBannerSettings
+	}
+	updateUAALoginBannerReturns struct {
+		result1 error
+	}
+	updateUAALoginBannerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ConfigureOpsmanService) DisableRBAC() error {
+	fake.disableRBACMutex.Lock()
+	ret, specificReturn := fake.disableRBACReturnsOnCall[len(fake.disableRBACArgsForCall)]
+	fake.disableRBACArgsForCall = append(fake.disableRBACArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DisableRBAC", []interface{}{})
+	fake.disableRBACMutex.Unlock()
+	if fake.DisableRBACStub != nil {
+		return fake.DisableRBACStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.disableRBACReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureOpsmanService) DisableRBACCallCount() int {
+	fake.disableRBACMutex.RLock()
+	defer fake.disableRBACMutex.RUnlock()
+	return len(fake.disableRBACArgsForCall)
+}
+
+func (fake *ConfigureOpsmanService) DisableRBACCalls(stub func() error) {
+	fake.disableRBACMutex.Lock()
+	defer fake.disableRBACMutex.Unlock()
+	fake.DisableRBACStub = stub
+}
+
+func (fake *ConfigureOpsmanService) DisableRBACReturns(result1 error) {
+	fake.disableRBACMutex.Lock()
+	defer fake.disableRBACMutex.Unlock()
+	fake.DisableRBACStub = nil
+	fake.disableRBACReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureOpsmanService) DisableRBACReturnsOnCall(i int, result1 error) {
+	fake.disableRBACMutex.Lock()
+	defer fake.disableRBACMutex.Unlock()
+	fake.DisableRBACStub = nil
+	if fake.disableRBACReturnsOnCall == nil {
+		fake.disableRBACReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.disableRBACReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ConfigureOpsmanService) EnableRBAC(arg1 api.RBACSettings) error {
 	fake.enableRBACMutex.Lock()
 	ret, specificReturn := fake.enableRBACReturnsOnCall[len(fake.enableRBACArgsForCall)]
@@ -318,6 +403,67 @@ func (fake *ConfigureOpsmanService) UpdateSSLCertificateReturnsOnCall(i int, res
 	}{result1}
 }
 
+func (fake *ConfigureOpsmanService) UpdateSettings(arg1 string, arg2 interface{}) error {
+	fake.updateSettingsMutex.Lock()
+	ret, specificReturn := fake.updateSettingsReturnsOnCall[len(fake.updateSettingsArgsForCall)]
+	fake.updateSettingsArgsForCall = append(fake.updateSettingsArgsForCall, struct {
+		arg1 string
+		arg2 interface{}
+	}{arg1, arg2})
+	fake.recordInvocation("UpdateSettings", []interface{}{arg1, arg2})
+	fake.updateSettingsMutex.Unlock()
+	if fake.UpdateSettingsStub != nil {
+		return fake.UpdateSettingsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateSettingsReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureOpsmanService) UpdateSettingsCallCount() int {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	return len(fake.updateSettingsArgsForCall)
+}
+
+func (fake *ConfigureOpsmanService) UpdateSettingsCalls(stub func(string, interface{}) error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = stub
+}
+
+func (fake *ConfigureOpsmanService) UpdateSettingsArgsForCall(i int) (string, interface{}) {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	argsForCall := fake.updateSettingsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ConfigureOpsmanService) UpdateSettingsReturns(result1 error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = nil
+	fake.updateSettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureOpsmanService) UpdateSettingsReturnsOnCall(i int, result1 error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = nil
+	if fake.updateSettingsReturnsOnCall == nil {
+		fake.updateSettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ConfigureOpsmanService) UpdateSyslogSettings(arg1 api.SyslogSettings) error {
 	fake.updateSyslogSettingsMutex.Lock()
 	ret, specificReturn := fake.updateSyslogSettingsReturnsOnCall[len(fake.updateSyslogSettingsArgsForCall)]
@@ -438,9 +584,71 @@ func (fake *ConfigureOpsmanService) UpdateTokensExpirationReturnsOnCall(i int, r
 	}{result1}
 }
 
+func (fake *ConfigureOpsmanService) UpdateUAALoginBanner(arg1 api.UAALoginBannerSettings) error {
+	fake.updateUAALoginBannerMutex.Lock()
+	ret, specificReturn := fake.updateUAALoginBannerReturnsOnCall[len(fake.updateUAALoginBannerArgsForCall)]
+	fake.updateUAALoginBannerArgsForCall = append(fake.updateUAALoginBannerArgsForCall, struct {
+		arg1 api.UAALoginBannerSettings
+	}{arg1})
+	fake.recordInvocation("UpdateUAALoginBanner", []interface{}{arg1})
+	fake.updateUAALoginBannerMutex.Unlock()
+	if fake.UpdateUAALoginBannerStub != nil {
+		return fake.UpdateUAALoginBannerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateUAALoginBannerReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureOpsmanService) UpdateUAALoginBannerCallCount() int {
+	fake.updateUAALoginBannerMutex.RLock()
+	defer fake.updateUAALoginBannerMutex.RUnlock()
+	return len(fake.updateUAALoginBannerArgsForCall)
+}
+
+func (fake *ConfigureOpsmanService) UpdateUAALoginBannerCalls(stub func(api.UAALoginBannerSettings) error) {
+	fake.updateUAALoginBannerMutex.Lock()
+	defer fake.updateUAALoginBannerMutex.Unlock()
+	fake.UpdateUAALoginBannerStub = stub
+}
+
+func (fake *ConfigureOpsmanService) UpdateUAALoginBannerArgsForCall(i int) api.UAALoginBannerSettings {
+	fake.updateUAALoginBannerMutex.RLock()
+	defer fake.updateUAALoginBannerMutex.RUnlock()
+	argsForCall := fake.updateUAALoginBannerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureOpsmanService) UpdateUAALoginBannerReturns(result1 error) {
+	fake.updateUAALoginBannerMutex.Lock()
+	defer fake.updateUAALoginBannerMutex.Unlock()
+	fake.UpdateUAALoginBannerStub = nil
+	fake.updateUAALoginBannerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureOpsmanService) UpdateUAALoginBannerReturnsOnCall(i int, result1 error) {
+	fake.updateUAALoginBannerMutex.Lock()
+	defer fake.updateUAALoginBannerMutex.Unlock()
+	fake.UpdateUAALoginBannerStub = nil
+	if fake.updateUAALoginBannerReturnsOnCall == nil {
+		fake.updateUAALoginBannerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateUAALoginBannerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ConfigureOpsmanService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.disableRBACMutex.RLock()
+	defer fake.disableRBACMutex.RUnlock()
 	fake.enableRBACMutex.RLock()
 	defer fake.enableRBACMutex.RUnlock()
 	fake.updateBannerMutex.RLock()
@@ -449,10 +657,14 @@ func (fake *ConfigureOpsmanService) Invocations() map[string][][]interface{} {
 	defer fake.updatePivnetTokenMutex.RUnlock()
 	fake.updateSSLCertificateMutex.RLock()
 	defer fake.updateSSLCertificateMutex.RUnlock()
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
 	fake.updateSyslogSettingsMutex.RLock()
 	defer fake.updateSyslogSettingsMutex.RUnlock()
 	fake.updateTokensExpirationMutex.RLock()
 	defer fake.updateTokensExpirationMutex.RUnlock()
+	fake.updateUAALoginBannerMutex.RLock()
+	defer fake.updateUAALoginBannerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
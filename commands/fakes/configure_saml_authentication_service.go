@@ -0,0 +1,333 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type ConfigureSAMLAuthenticationService struct {
+	EnableRBACStub        func(api.RBACSettings) error
+	enableRBACMutex       sync.RWMutex
+	enableRBACArgsForCall []struct {
+		arg1 api.RBACSettings
+	}
+	enableRBACReturns struct {
+		result1 error
+	}
+	enableRBACReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureAvailabilityStub        func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
+	ensureAvailabilityMutex       sync.RWMutex
+	ensureAvailabilityArgsForCall []struct {
+		arg1 api.EnsureAvailabilityInput
+	}
+	ensureAvailabilityReturns struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}
+	ensureAvailabilityReturnsOnCall map[int]struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}
+	InfoStub        func() (api.Info, error)
+	infoMutex       sync.RWMutex
+	infoArgsForCall []struct {
+	}
+	infoReturns struct {
+		result1 api.Info
+		result2 error
+	}
+	infoReturnsOnCall map[int]struct {
+		result1 api.Info
+		result2 error
+	}
+	SetupStub        func(api.SetupInput) (api.SetupOutput, error)
+	setupMutex       sync.RWMutex
+	setupArgsForCall []struct {
+		arg1 api.SetupInput
+	}
+	setupReturns struct {
+		result1 api.SetupOutput
+		result2 error
+	}
+	setupReturnsOnCall map[int]struct {
+		result1 api.SetupOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnableRBAC(arg1 api.RBACSettings) error {
+	fake.enableRBACMutex.Lock()
+	ret, specificReturn := fake.enableRBACReturnsOnCall[len(fake.enableRBACArgsForCall)]
+	fake.enableRBACArgsForCall = append(fake.enableRBACArgsForCall, struct {
+		arg1 api.RBACSettings
+	}{arg1})
+	fake.recordInvocation("EnableRBAC", []interface{}{arg1})
+	fake.enableRBACMutex.Unlock()
+	if fake.EnableRBACStub != nil {
+		return fake.EnableRBACStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enableRBACReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnableRBACCallCount() int {
+	fake.enableRBACMutex.RLock()
+	defer fake.enableRBACMutex.RUnlock()
+	return len(fake.enableRBACArgsForCall)
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnableRBACCalls(stub func(api.RBACSettings) error) {
+	fake.enableRBACMutex.Lock()
+	defer fake.enableRBACMutex.Unlock()
+	fake.EnableRBACStub = stub
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnableRBACArgsForCall(i int) api.RBACSettings {
+	fake.enableRBACMutex.RLock()
+	defer fake.enableRBACMutex.RUnlock()
+	argsForCall := fake.enableRBACArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnableRBACReturns(result1 error) {
+	fake.enableRBACMutex.Lock()
+	defer fake.enableRBACMutex.Unlock()
+	fake.EnableRBACStub = nil
+	fake.enableRBACReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnableRBACReturnsOnCall(i int, result1 error) {
+	fake.enableRBACMutex.Lock()
+	defer fake.enableRBACMutex.Unlock()
+	fake.EnableRBACStub = nil
+	if fake.enableRBACReturnsOnCall == nil {
+		fake.enableRBACReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enableRBACReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnsureAvailability(arg1 api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+	fake.ensureAvailabilityMutex.Lock()
+	ret, specificReturn := fake.ensureAvailabilityReturnsOnCall[len(fake.ensureAvailabilityArgsForCall)]
+	fake.ensureAvailabilityArgsForCall = append(fake.ensureAvailabilityArgsForCall, struct {
+		arg1 api.EnsureAvailabilityInput
+	}{arg1})
+	fake.recordInvocation("EnsureAvailability", []interface{}{arg1})
+	fake.ensureAvailabilityMutex.Unlock()
+	if fake.EnsureAvailabilityStub != nil {
+		return fake.EnsureAvailabilityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.ensureAvailabilityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnsureAvailabilityCallCount() int {
+	fake.ensureAvailabilityMutex.RLock()
+	defer fake.ensureAvailabilityMutex.RUnlock()
+	return len(fake.ensureAvailabilityArgsForCall)
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnsureAvailabilityCalls(stub func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)) {
+	fake.ensureAvailabilityMutex.Lock()
+	defer fake.ensureAvailabilityMutex.Unlock()
+	fake.EnsureAvailabilityStub = stub
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnsureAvailabilityArgsForCall(i int) api.EnsureAvailabilityInput {
+	fake.ensureAvailabilityMutex.RLock()
+	defer fake.ensureAvailabilityMutex.RUnlock()
+	argsForCall := fake.ensureAvailabilityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnsureAvailabilityReturns(result1 api.EnsureAvailabilityOutput, result2 error) {
+	fake.ensureAvailabilityMutex.Lock()
+	defer fake.ensureAvailabilityMutex.Unlock()
+	fake.EnsureAvailabilityStub = nil
+	fake.ensureAvailabilityReturns = struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) EnsureAvailabilityReturnsOnCall(i int, result1 api.EnsureAvailabilityOutput, result2 error) {
+	fake.ensureAvailabilityMutex.Lock()
+	defer fake.ensureAvailabilityMutex.Unlock()
+	fake.EnsureAvailabilityStub = nil
+	if fake.ensureAvailabilityReturnsOnCall == nil {
+		fake.ensureAvailabilityReturnsOnCall = make(map[int]struct {
+			result1 api.EnsureAvailabilityOutput
+			result2 error
+		})
+	}
+	fake.ensureAvailabilityReturnsOnCall[i] = struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) Info() (api.Info, error) {
+	fake.infoMutex.Lock()
+	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
+	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Info", []interface{}{})
+	fake.infoMutex.Unlock()
+	if fake.InfoStub != nil {
+		return fake.InfoStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.infoReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureSAMLAuthenticationService) InfoCallCount() int {
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	return len(fake.infoArgsForCall)
+}
+
+func (fake *ConfigureSAMLAuthenticationService) InfoCalls(stub func() (api.Info, error)) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = stub
+}
+
+func (fake *ConfigureSAMLAuthenticationService) InfoReturns(result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	fake.infoReturns = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) InfoReturnsOnCall(i int, result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	if fake.infoReturnsOnCall == nil {
+		fake.infoReturnsOnCall = make(map[int]struct {
+			result1 api.Info
+			result2 error
+		})
+	}
+	fake.infoReturnsOnCall[i] = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) Setup(arg1 api.SetupInput) (api.SetupOutput, error) {
+	fake.setupMutex.Lock()
+	ret, specificReturn := fake.setupReturnsOnCall[len(fake.setupArgsForCall)]
+	fake.setupArgsForCall = append(fake.setupArgsForCall, struct {
+		arg1 api.SetupInput
+	}{arg1})
+	fake.recordInvocation("Setup", []interface{}{arg1})
+	fake.setupMutex.Unlock()
+	if fake.SetupStub != nil {
+		return fake.SetupStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.setupReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureSAMLAuthenticationService) SetupCallCount() int {
+	fake.setupMutex.RLock()
+	defer fake.setupMutex.RUnlock()
+	return len(fake.setupArgsForCall)
+}
+
+func (fake *ConfigureSAMLAuthenticationService) SetupCalls(stub func(api.SetupInput) (api.SetupOutput, error)) {
+	fake.setupMutex.Lock()
+	defer fake.setupMutex.Unlock()
+	fake.SetupStub = stub
+}
+
+func (fake *ConfigureSAMLAuthenticationService) SetupArgsForCall(i int) api.SetupInput {
+	fake.setupMutex.RLock()
+	defer fake.setupMutex.RUnlock()
+	argsForCall := fake.setupArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureSAMLAuthenticationService) SetupReturns(result1 api.SetupOutput, result2 error) {
+	fake.setupMutex.Lock()
+	defer fake.setupMutex.Unlock()
+	fake.SetupStub = nil
+	fake.setupReturns = struct {
+		result1 api.SetupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) SetupReturnsOnCall(i int, result1 api.SetupOutput, result2 error) {
+	fake.setupMutex.Lock()
+	defer fake.setupMutex.Unlock()
+	fake.SetupStub = nil
+	if fake.setupReturnsOnCall == nil {
+		fake.setupReturnsOnCall = make(map[int]struct {
+			result1 api.SetupOutput
+			result2 error
+		})
+	}
+	fake.setupReturnsOnCall[i] = struct {
+		result1 api.SetupOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureSAMLAuthenticationService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.enableRBACMutex.RLock()
+	defer fake.enableRBACMutex.RUnlock()
+	fake.ensureAvailabilityMutex.RLock()
+	defer fake.ensureAvailabilityMutex.RUnlock()
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	fake.setupMutex.RLock()
+	defer fake.setupMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ConfigureSAMLAuthenticationService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
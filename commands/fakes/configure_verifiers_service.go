@@ -0,0 +1,503 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type ConfigureVerifiersService struct {
+	DisableDirectorVerifiersStub        func([]string) error
+	disableDirectorVerifiersMutex       sync.RWMutex
+	disableDirectorVerifiersArgsForCall []struct {
+		arg1 []string
+	}
+	disableDirectorVerifiersReturns struct {
+		result1 error
+	}
+	disableDirectorVerifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DisableProductVerifiersStub        func([]string, string) error
+	disableProductVerifiersMutex       sync.RWMutex
+	disableProductVerifiersArgsForCall []struct {
+		arg1 []string
+		arg2 string
+	}
+	disableProductVerifiersReturns struct {
+		result1 error
+	}
+	disableProductVerifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnableDirectorVerifiersStub        func([]string) error
+	enableDirectorVerifiersMutex       sync.RWMutex
+	enableDirectorVerifiersArgsForCall []struct {
+		arg1 []string
+	}
+	enableDirectorVerifiersReturns struct {
+		result1 error
+	}
+	enableDirectorVerifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnableProductVerifiersStub        func([]string, string) error
+	enableProductVerifiersMutex       sync.RWMutex
+	enableProductVerifiersArgsForCall []struct {
+		arg1 []string
+		arg2 string
+	}
+	enableProductVerifiersReturns struct {
+		result1 error
+	}
+	enableProductVerifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListDirectorVerifiersStub        func() ([]api.Verifier, error)
+	listDirectorVerifiersMutex       sync.RWMutex
+	listDirectorVerifiersArgsForCall []struct {
+	}
+	listDirectorVerifiersReturns struct {
+		result1 []api.Verifier
+		result2 error
+	}
+	listDirectorVerifiersReturnsOnCall map[int]struct {
+		result1 []api.Verifier
+		result2 error
+	}
+	ListProductVerifiersStub        func(string) ([]api.Verifier, string, error)
+	listProductVerifiersMutex       sync.RWMutex
+	listProductVerifiersArgsForCall []struct {
+		arg1 string
+	}
+	listProductVerifiersReturns struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}
+	listProductVerifiersReturnsOnCall map[int]struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ConfigureVerifiersService) DisableDirectorVerifiers(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.disableDirectorVerifiersMutex.Lock()
+	ret, specificReturn := fake.disableDirectorVerifiersReturnsOnCall[len(fake.disableDirectorVerifiersArgsForCall)]
+	fake.disableDirectorVerifiersArgsForCall = append(fake.disableDirectorVerifiersArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("DisableDirectorVerifiers", []interface{}{arg1Copy})
+	fake.disableDirectorVerifiersMutex.Unlock()
+	if fake.DisableDirectorVerifiersStub != nil {
+		return fake.DisableDirectorVerifiersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.disableDirectorVerifiersReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureVerifiersService) DisableDirectorVerifiersCallCount() int {
+	fake.disableDirectorVerifiersMutex.RLock()
+	defer fake.disableDirectorVerifiersMutex.RUnlock()
+	return len(fake.disableDirectorVerifiersArgsForCall)
+}
+
+func (fake *ConfigureVerifiersService) DisableDirectorVerifiersCalls(stub func([]string) error) {
+	fake.disableDirectorVerifiersMutex.Lock()
+	defer fake.disableDirectorVerifiersMutex.Unlock()
+	fake.DisableDirectorVerifiersStub = stub
+}
+
+func (fake *ConfigureVerifiersService) DisableDirectorVerifiersArgsForCall(i int) []string {
+	fake.disableDirectorVerifiersMutex.RLock()
+	defer fake.disableDirectorVerifiersMutex.RUnlock()
+	argsForCall := fake.disableDirectorVerifiersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureVerifiersService) DisableDirectorVerifiersReturns(result1 error) {
+	fake.disableDirectorVerifiersMutex.Lock()
+	defer fake.disableDirectorVerifiersMutex.Unlock()
+	fake.DisableDirectorVerifiersStub = nil
+	fake.disableDirectorVerifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) DisableDirectorVerifiersReturnsOnCall(i int, result1 error) {
+	fake.disableDirectorVerifiersMutex.Lock()
+	defer fake.disableDirectorVerifiersMutex.Unlock()
+	fake.DisableDirectorVerifiersStub = nil
+	if fake.disableDirectorVerifiersReturnsOnCall == nil {
+		fake.disableDirectorVerifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.disableDirectorVerifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) DisableProductVerifiers(arg1 []string, arg2 string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.disableProductVerifiersMutex.Lock()
+	ret, specificReturn := fake.disableProductVerifiersReturnsOnCall[len(fake.disableProductVerifiersArgsForCall)]
+	fake.disableProductVerifiersArgsForCall = append(fake.disableProductVerifiersArgsForCall, struct {
+		arg1 []string
+		arg2 string
+	}{arg1Copy, arg2})
+	fake.recordInvocation("DisableProductVerifiers", []interface{}{arg1Copy, arg2})
+	fake.disableProductVerifiersMutex.Unlock()
+	if fake.DisableProductVerifiersStub != nil {
+		return fake.DisableProductVerifiersStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.disableProductVerifiersReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureVerifiersService) DisableProductVerifiersCallCount() int {
+	fake.disableProductVerifiersMutex.RLock()
+	defer fake.disableProductVerifiersMutex.RUnlock()
+	return len(fake.disableProductVerifiersArgsForCall)
+}
+
+func (fake *ConfigureVerifiersService) DisableProductVerifiersCalls(stub func([]string, string) error) {
+	fake.disableProductVerifiersMutex.Lock()
+	defer fake.disableProductVerifiersMutex.Unlock()
+	fake.DisableProductVerifiersStub = stub
+}
+
+func (fake *ConfigureVerifiersService) DisableProductVerifiersArgsForCall(i int) ([]string, string) {
+	fake.disableProductVerifiersMutex.RLock()
+	defer fake.disableProductVerifiersMutex.RUnlock()
+	argsForCall := fake.disableProductVerifiersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ConfigureVerifiersService) DisableProductVerifiersReturns(result1 error) {
+	fake.disableProductVerifiersMutex.Lock()
+	defer fake.disableProductVerifiersMutex.Unlock()
+	fake.DisableProductVerifiersStub = nil
+	fake.disableProductVerifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) DisableProductVerifiersReturnsOnCall(i int, result1 error) {
+	fake.disableProductVerifiersMutex.Lock()
+	defer fake.disableProductVerifiersMutex.Unlock()
+	fake.DisableProductVerifiersStub = nil
+	if fake.disableProductVerifiersReturnsOnCall == nil {
+		fake.disableProductVerifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.disableProductVerifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) EnableDirectorVerifiers(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.enableDirectorVerifiersMutex.Lock()
+	ret, specificReturn := fake.enableDirectorVerifiersReturnsOnCall[len(fake.enableDirectorVerifiersArgsForCall)]
+	fake.enableDirectorVerifiersArgsForCall = append(fake.enableDirectorVerifiersArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("EnableDirectorVerifiers", []interface{}{arg1Copy})
+	fake.enableDirectorVerifiersMutex.Unlock()
+	if fake.EnableDirectorVerifiersStub != nil {
+		return fake.EnableDirectorVerifiersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enableDirectorVerifiersReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureVerifiersService) EnableDirectorVerifiersCallCount() int {
+	fake.enableDirectorVerifiersMutex.RLock()
+	defer fake.enableDirectorVerifiersMutex.RUnlock()
+	return len(fake.enableDirectorVerifiersArgsForCall)
+}
+
+func (fake *ConfigureVerifiersService) EnableDirectorVerifiersCalls(stub func([]string) error) {
+	fake.enableDirectorVerifiersMutex.Lock()
+	defer fake.enableDirectorVerifiersMutex.Unlock()
+	fake.EnableDirectorVerifiersStub = stub
+}
+
+func (fake *ConfigureVerifiersService) EnableDirectorVerifiersArgsForCall(i int) []string {
+	fake.enableDirectorVerifiersMutex.RLock()
+	defer fake.enableDirectorVerifiersMutex.RUnlock()
+	argsForCall := fake.enableDirectorVerifiersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureVerifiersService) EnableDirectorVerifiersReturns(result1 error) {
+	fake.enableDirectorVerifiersMutex.Lock()
+	defer fake.enableDirectorVerifiersMutex.Unlock()
+	fake.EnableDirectorVerifiersStub = nil
+	fake.enableDirectorVerifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) EnableDirectorVerifiersReturnsOnCall(i int, result1 error) {
+	fake.enableDirectorVerifiersMutex.Lock()
+	defer fake.enableDirectorVerifiersMutex.Unlock()
+	fake.EnableDirectorVerifiersStub = nil
+	if fake.enableDirectorVerifiersReturnsOnCall == nil {
+		fake.enableDirectorVerifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enableDirectorVerifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) EnableProductVerifiers(arg1 []string, arg2 string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.enableProductVerifiersMutex.Lock()
+	ret, specificReturn := fake.enableProductVerifiersReturnsOnCall[len(fake.enableProductVerifiersArgsForCall)]
+	fake.enableProductVerifiersArgsForCall = append(fake.enableProductVerifiersArgsForCall, struct {
+		arg1 []string
+		arg2 string
+	}{arg1Copy, arg2})
+	fake.recordInvocation("EnableProductVerifiers", []interface{}{arg1Copy, arg2})
+	fake.enableProductVerifiersMutex.Unlock()
+	if fake.EnableProductVerifiersStub != nil {
+		return fake.EnableProductVerifiersStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enableProductVerifiersReturns
+	return fakeReturns.result1
+}
+
+func (fake *ConfigureVerifiersService) EnableProductVerifiersCallCount() int {
+	fake.enableProductVerifiersMutex.RLock()
+	defer fake.enableProductVerifiersMutex.RUnlock()
+	return len(fake.enableProductVerifiersArgsForCall)
+}
+
+func (fake *ConfigureVerifiersService) EnableProductVerifiersCalls(stub func([]string, string) error) {
+	fake.enableProductVerifiersMutex.Lock()
+	defer fake.enableProductVerifiersMutex.Unlock()
+	fake.EnableProductVerifiersStub = stub
+}
+
+func (fake *ConfigureVerifiersService) EnableProductVerifiersArgsForCall(i int) ([]string, string) {
+	fake.enableProductVerifiersMutex.RLock()
+	defer fake.enableProductVerifiersMutex.RUnlock()
+	argsForCall := fake.enableProductVerifiersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ConfigureVerifiersService) EnableProductVerifiersReturns(result1 error) {
+	fake.enableProductVerifiersMutex.Lock()
+	defer fake.enableProductVerifiersMutex.Unlock()
+	fake.EnableProductVerifiersStub = nil
+	fake.enableProductVerifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) EnableProductVerifiersReturnsOnCall(i int, result1 error) {
+	fake.enableProductVerifiersMutex.Lock()
+	defer fake.enableProductVerifiersMutex.Unlock()
+	fake.EnableProductVerifiersStub = nil
+	if fake.enableProductVerifiersReturnsOnCall == nil {
+		fake.enableProductVerifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enableProductVerifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ConfigureVerifiersService) ListDirectorVerifiers() ([]api.Verifier, error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	ret, specificReturn := fake.listDirectorVerifiersReturnsOnCall[len(fake.listDirectorVerifiersArgsForCall)]
+	fake.listDirectorVerifiersArgsForCall = append(fake.listDirectorVerifiersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDirectorVerifiers", []interface{}{})
+	fake.listDirectorVerifiersMutex.Unlock()
+	if fake.ListDirectorVerifiersStub != nil {
+		return fake.ListDirectorVerifiersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDirectorVerifiersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ConfigureVerifiersService) ListDirectorVerifiersCallCount() int {
+	fake.listDirectorVerifiersMutex.RLock()
+	defer fake.listDirectorVerifiersMutex.RUnlock()
+	return len(fake.listDirectorVerifiersArgsForCall)
+}
+
+func (fake *ConfigureVerifiersService) ListDirectorVerifiersCalls(stub func() ([]api.Verifier, error)) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = stub
+}
+
+func (fake *ConfigureVerifiersService) ListDirectorVerifiersReturns(result1 []api.Verifier, result2 error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = nil
+	fake.listDirectorVerifiersReturns = struct {
+		result1 []api.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureVerifiersService) ListDirectorVerifiersReturnsOnCall(i int, result1 []api.Verifier, result2 error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = nil
+	if fake.listDirectorVerifiersReturnsOnCall == nil {
+		fake.listDirectorVerifiersReturnsOnCall = make(map[int]struct {
+			result1 []api.Verifier
+			result2 error
+		})
+	}
+	fake.listDirectorVerifiersReturnsOnCall[i] = struct {
+		result1 []api.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ConfigureVerifiersService) ListProductVerifiers(arg1 string) ([]api.Verifier, string, error) {
+	fake.listProductVerifiersMutex.Lock()
+	ret, specificReturn := fake.listProductVerifiersReturnsOnCall[len(fake.listProductVerifiersArgsForCall)]
+	fake.listProductVerifiersArgsForCall = append(fake.listProductVerifiersArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListProductVerifiers", []interface{}{arg1})
+	fake.listProductVerifiersMutex.Unlock()
+	if fake.ListProductVerifiersStub != nil {
+		return fake.ListProductVerifiersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.listProductVerifiersReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ConfigureVerifiersService) ListProductVerifiersCallCount() int {
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	return len(fake.listProductVerifiersArgsForCall)
+}
+
+func (fake *ConfigureVerifiersService) ListProductVerifiersCalls(stub func(string) ([]api.Verifier, string, error)) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = stub
+}
+
+func (fake *ConfigureVerifiersService) ListProductVerifiersArgsForCall(i int) string {
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	argsForCall := fake.listProductVerifiersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ConfigureVerifiersService) ListProductVerifiersReturns(result1 []api.Verifier, result2 string, result3 error) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = nil
+	fake.listProductVerifiersReturns = struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ConfigureVerifiersService) ListProductVerifiersReturnsOnCall(i int, result1 []api.Verifier, result2 string, result3 error) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = nil
+	if fake.listProductVerifiersReturnsOnCall == nil {
+		fake.listProductVerifiersReturnsOnCall = make(map[int]struct {
+			result1 []api.Verifier
+			result2 string
+			result3 error
+		})
+	}
+	fake.listProductVerifiersReturnsOnCall[i] = struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ConfigureVerifiersService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.disableDirectorVerifiersMutex.RLock()
+	defer fake.disableDirectorVerifiersMutex.RUnlock()
+	fake.disableProductVerifiersMutex.RLock()
+	defer fake.disableProductVerifiersMutex.RUnlock()
+	fake.enableDirectorVerifiersMutex.RLock()
+	defer fake.enableDirectorVerifiersMutex.RUnlock()
+	fake.enableProductVerifiersMutex.RLock()
+	defer fake.enableProductVerifiersMutex.RUnlock()
+	fake.listDirectorVerifiersMutex.RLock()
+	defer fake.listDirectorVerifiersMutex.RUnlock()
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ConfigureVerifiersService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,191 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+)
+
+type CredentialsVerifier struct {
+	ForgetTokenStub        func(string, string, string, string) error
+	forgetTokenMutex       sync.RWMutex
+	forgetTokenArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	forgetTokenReturns struct {
+		result1 error
+	}
+	forgetTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	VerifyCredentialsStub        func(string, string, string, string) error
+	verifyCredentialsMutex       sync.RWMutex
+	verifyCredentialsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	verifyCredentialsReturns struct {
+		result1 error
+	}
+	verifyCredentialsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CredentialsVerifier) ForgetToken(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.forgetTokenMutex.Lock()
+	ret, specificReturn := fake.forgetTokenReturnsOnCall[len(fake.forgetTokenArgsForCall)]
+	fake.forgetTokenArgsForCall = append(fake.forgetTokenArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ForgetToken", []interface{}{arg1, arg2, arg3, arg4})
+	fake.forgetTokenMutex.Unlock()
+	if fake.ForgetTokenStub != nil {
+		return fake.ForgetTokenStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.forgetTokenReturns
+	return fakeReturns.result1
+}
+
+func (fake *CredentialsVerifier) ForgetTokenCallCount() int {
+	fake.forgetTokenMutex.RLock()
+	defer fake.forgetTokenMutex.RUnlock()
+	return len(fake.forgetTokenArgsForCall)
+}
+
+func (fake *CredentialsVerifier) ForgetTokenCalls(stub func(string, string, string, string) error) {
+	fake.forgetTokenMutex.Lock()
+	defer fake.forgetTokenMutex.Unlock()
+	fake.ForgetTokenStub = stub
+}
+
+func (fake *CredentialsVerifier) ForgetTokenArgsForCall(i int) (string, string, string, string) {
+	fake.forgetTokenMutex.RLock()
+	defer fake.forgetTokenMutex.RUnlock()
+	argsForCall := fake.forgetTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *CredentialsVerifier) ForgetTokenReturns(result1 error) {
+	fake.forgetTokenMutex.Lock()
+	defer fake.forgetTokenMutex.Unlock()
+	fake.ForgetTokenStub = nil
+	fake.forgetTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CredentialsVerifier) ForgetTokenReturnsOnCall(i int, result1 error) {
+	fake.forgetTokenMutex.Lock()
+	defer fake.forgetTokenMutex.Unlock()
+	fake.ForgetTokenStub = nil
+	if fake.forgetTokenReturnsOnCall == nil {
+		fake.forgetTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.forgetTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CredentialsVerifier) VerifyCredentials(arg1 string, arg2 string, arg3 string, arg4 string) error {
+	fake.verifyCredentialsMutex.Lock()
+	ret, specificReturn := fake.verifyCredentialsReturnsOnCall[len(fake.verifyCredentialsArgsForCall)]
+	fake.verifyCredentialsArgsForCall = append(fake.verifyCredentialsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("VerifyCredentials", []interface{}{arg1, arg2, arg3, arg4})
+	fake.verifyCredentialsMutex.Unlock()
+	if fake.VerifyCredentialsStub != nil {
+		return fake.VerifyCredentialsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.verifyCredentialsReturns
+	return fakeReturns.result1
+}
+
+func (fake *CredentialsVerifier) VerifyCredentialsCallCount() int {
+	fake.verifyCredentialsMutex.RLock()
+	defer fake.verifyCredentialsMutex.RUnlock()
+	return len(fake.verifyCredentialsArgsForCall)
+}
+
+func (fake *CredentialsVerifier) VerifyCredentialsCalls(stub func(string, string, string, string) error) {
+	fake.verifyCredentialsMutex.Lock()
+	defer fake.verifyCredentialsMutex.Unlock()
+	fake.VerifyCredentialsStub = stub
+}
+
+func (fake *CredentialsVerifier) VerifyCredentialsArgsForCall(i int) (string, string, string, string) {
+	fake.verifyCredentialsMutex.RLock()
+	defer fake.verifyCredentialsMutex.RUnlock()
+	argsForCall := fake.verifyCredentialsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *CredentialsVerifier) VerifyCredentialsReturns(result1 error) {
+	fake.verifyCredentialsMutex.Lock()
+	defer fake.verifyCredentialsMutex.Unlock()
+	fake.VerifyCredentialsStub = nil
+	fake.verifyCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CredentialsVerifier) VerifyCredentialsReturnsOnCall(i int, result1 error) {
+	fake.verifyCredentialsMutex.Lock()
+	defer fake.verifyCredentialsMutex.Unlock()
+	fake.VerifyCredentialsStub = nil
+	if fake.verifyCredentialsReturnsOnCall == nil {
+		fake.verifyCredentialsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyCredentialsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CredentialsVerifier) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.forgetTokenMutex.RLock()
+	defer fake.forgetTokenMutex.RUnlock()
+	fake.verifyCredentialsMutex.RLock()
+	defer fake.verifyCredentialsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CredentialsVerifier) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
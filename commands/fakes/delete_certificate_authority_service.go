@@ -31,6 +31,19 @@ type DeleteCertificateAuthorityService struct {
 		result1 api.CertificateAuthoritiesOutput
 		result2 error
 	}
+	ListCertificatesStub        func(string) ([]api.ExpiringCertificate, error)
+	listCertificatesMutex       sync.RWMutex
+	listCertificatesArgsForCall []struct {
+		arg1 string
+	}
+	listCertificatesReturns struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}
+	listCertificatesReturnsOnCall map[int]struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -150,6 +163,69 @@ func (fake *DeleteCertificateAuthorityService) ListCertificateAuthoritiesReturns
 	}{result1, result2}
 }
 
+func (fake *DeleteCertificateAuthorityService) ListCertificates(arg1 string) ([]api.ExpiringCertificate, error) {
+	fake.listCertificatesMutex.Lock()
+	ret, specificReturn := fake.listCertificatesReturnsOnCall[len(fake.listCertificatesArgsForCall)]
+	fake.listCertificatesArgsForCall = append(fake.listCertificatesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListCertificates", []interface{}{arg1})
+	fake.listCertificatesMutex.Unlock()
+	if fake.ListCertificatesStub != nil {
+		return fake.ListCertificatesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listCertificatesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DeleteCertificateAuthorityService) ListCertificatesCallCount() int {
+	fake.listCertificatesMutex.RLock()
+	defer fake.listCertificatesMutex.RUnlock()
+	return len(fake.listCertificatesArgsForCall)
+}
+
+func (fake *DeleteCertificateAuthorityService) ListCertificatesCalls(stub func(string) ([]api.ExpiringCertificate, error)) {
+	fake.listCertificatesMutex.Lock()
+	defer fake.listCertificatesMutex.Unlock()
+	fake.ListCertificatesStub = stub
+}
+
+func (fake *DeleteCertificateAuthorityService) ListCertificatesArgsForCall(i int) string {
+	fake.listCertificatesMutex.RLock()
+	defer fake.listCertificatesMutex.RUnlock()
+	argsForCall := fake.listCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DeleteCertificateAuthorityService) ListCertificatesReturns(result1 []api.ExpiringCertificate, result2 error) {
+	fake.listCertificatesMutex.Lock()
+	defer fake.listCertificatesMutex.Unlock()
+	fake.ListCertificatesStub = nil
+	fake.listCertificatesReturns = struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeleteCertificateAuthorityService) ListCertificatesReturnsOnCall(i int, result1 []api.ExpiringCertificate, result2 error) {
+	fake.listCertificatesMutex.Lock()
+	defer fake.listCertificatesMutex.Unlock()
+	fake.ListCertificatesStub = nil
+	if fake.listCertificatesReturnsOnCall == nil {
+		fake.listCertificatesReturnsOnCall = make(map[int]struct {
+			result1 []api.ExpiringCertificate
+			result2 error
+		})
+	}
+	fake.listCertificatesReturnsOnCall[i] = struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *DeleteCertificateAuthorityService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -157,6 +233,8 @@ func (fake *DeleteCertificateAuthorityService) Invocations() map[string][][]inte
 	defer fake.deleteCertificateAuthorityMutex.RUnlock()
 	fake.listCertificateAuthoritiesMutex.RLock()
 	defer fake.listCertificateAuthoritiesMutex.RUnlock()
+	fake.listCertificatesMutex.RLock()
+	defer fake.listCertificatesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,177 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type DeleteUnusedStemcellsService struct {
+	DeleteStemcellStub        func(string) error
+	deleteStemcellMutex       sync.RWMutex
+	deleteStemcellArgsForCall []struct {
+		arg1 string
+	}
+	deleteStemcellReturns struct {
+		result1 error
+	}
+	deleteStemcellReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetDiagnosticReportStub        func() (api.DiagnosticReport, error)
+	getDiagnosticReportMutex       sync.RWMutex
+	getDiagnosticReportArgsForCall []struct {
+	}
+	getDiagnosticReportReturns struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}
+	getDiagnosticReportReturnsOnCall map[int]struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DeleteUnusedStemcellsService) DeleteStemcell(arg1 string) error {
+	fake.deleteStemcellMutex.Lock()
+	ret, specificReturn := fake.deleteStemcellReturnsOnCall[len(fake.deleteStemcellArgsForCall)]
+	fake.deleteStemcellArgsForCall = append(fake.deleteStemcellArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteStemcell", []interface{}{arg1})
+	fake.deleteStemcellMutex.Unlock()
+	if fake.DeleteStemcellStub != nil {
+		return fake.DeleteStemcellStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteStemcellReturns
+	return fakeReturns.result1
+}
+
+func (fake *DeleteUnusedStemcellsService) DeleteStemcellCallCount() int {
+	fake.deleteStemcellMutex.RLock()
+	defer fake.deleteStemcellMutex.RUnlock()
+	return len(fake.deleteStemcellArgsForCall)
+}
+
+func (fake *DeleteUnusedStemcellsService) DeleteStemcellCalls(stub func(string) error) {
+	fake.deleteStemcellMutex.Lock()
+	defer fake.deleteStemcellMutex.Unlock()
+	fake.DeleteStemcellStub = stub
+}
+
+func (fake *DeleteUnusedStemcellsService) DeleteStemcellArgsForCall(i int) string {
+	fake.deleteStemcellMutex.RLock()
+	defer fake.deleteStemcellMutex.RUnlock()
+	argsForCall := fake.deleteStemcellArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DeleteUnusedStemcellsService) DeleteStemcellReturns(result1 error) {
+	fake.deleteStemcellMutex.Lock()
+	defer fake.deleteStemcellMutex.Unlock()
+	fake.DeleteStemcellStub = nil
+	fake.deleteStemcellReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DeleteUnusedStemcellsService) DeleteStemcellReturnsOnCall(i int, result1 error) {
+	fake.deleteStemcellMutex.Lock()
+	defer fake.deleteStemcellMutex.Unlock()
+	fake.DeleteStemcellStub = nil
+	if fake.deleteStemcellReturnsOnCall == nil {
+		fake.deleteStemcellReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteStemcellReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *DeleteUnusedStemcellsService) GetDiagnosticReport() (api.DiagnosticReport, error) {
+	fake.getDiagnosticReportMutex.Lock()
+	ret, specificReturn := fake.getDiagnosticReportReturnsOnCall[len(fake.getDiagnosticReportArgsForCall)]
+	fake.getDiagnosticReportArgsForCall = append(fake.getDiagnosticReportArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetDiagnosticReport", []interface{}{})
+	fake.getDiagnosticReportMutex.Unlock()
+	if fake.GetDiagnosticReportStub != nil {
+		return fake.GetDiagnosticReportStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDiagnosticReportReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DeleteUnusedStemcellsService) GetDiagnosticReportCallCount() int {
+	fake.getDiagnosticReportMutex.RLock()
+	defer fake.getDiagnosticReportMutex.RUnlock()
+	return len(fake.getDiagnosticReportArgsForCall)
+}
+
+func (fake *DeleteUnusedStemcellsService) GetDiagnosticReportCalls(stub func() (api.DiagnosticReport, error)) {
+	fake.getDiagnosticReportMutex.Lock()
+	defer fake.getDiagnosticReportMutex.Unlock()
+	fake.GetDiagnosticReportStub = stub
+}
+
+func (fake *DeleteUnusedStemcellsService) GetDiagnosticReportReturns(result1 api.DiagnosticReport, result2 error) {
+	fake.getDiagnosticReportMutex.Lock()
+	defer fake.getDiagnosticReportMutex.Unlock()
+	fake.GetDiagnosticReportStub = nil
+	fake.getDiagnosticReportReturns = struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeleteUnusedStemcellsService) GetDiagnosticReportReturnsOnCall(i int, result1 api.DiagnosticReport, result2 error) {
+	fake.getDiagnosticReportMutex.Lock()
+	defer fake.getDiagnosticReportMutex.Unlock()
+	fake.GetDiagnosticReportStub = nil
+	if fake.getDiagnosticReportReturnsOnCall == nil {
+		fake.getDiagnosticReportReturnsOnCall = make(map[int]struct {
+			result1 api.DiagnosticReport
+			result2 error
+		})
+	}
+	fake.getDiagnosticReportReturnsOnCall[i] = struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeleteUnusedStemcellsService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteStemcellMutex.RLock()
+	defer fake.deleteStemcellMutex.RUnlock()
+	fake.getDiagnosticReportMutex.RLock()
+	defer fake.getDiagnosticReportMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DeleteUnusedStemcellsService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
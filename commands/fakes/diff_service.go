@@ -20,6 +20,81 @@ type BoshDiffService struct {
 		result1 api.DirectorDiff
 		result2 error
 	}
+	GetDeployedDirectorManifestStub        func() (string, error)
+	getDeployedDirectorManifestMutex       sync.RWMutex
+	getDeployedDirectorManifestArgsForCall []struct {
+	}
+	getDeployedDirectorManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeployedDirectorManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetDeployedProductManifestStub        func(string) (string, error)
+	getDeployedProductManifestMutex       sync.RWMutex
+	getDeployedProductManifestArgsForCall []struct {
+		arg1 string
+	}
+	getDeployedProductManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeployedProductManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetStagedDirectorManifestStub        func() (string, error)
+	getStagedDirectorManifestMutex       sync.RWMutex
+	getStagedDirectorManifestArgsForCall []struct {
+	}
+	getStagedDirectorManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getStagedDirectorManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetStagedProductByNameStub        func(string) (api.StagedProductsFindOutput, error)
+	getStagedProductByNameMutex       sync.RWMutex
+	getStagedProductByNameArgsForCall []struct {
+		arg1 string
+	}
+	getStagedProductByNameReturns struct {
+		result1 api.StagedProductsFindOutput
+		result2 error
+	}
+	getStagedProductByNameReturnsOnCall map[int]struct {
+		result1 api.StagedProductsFindOutput
+		result2 error
+	}
+	GetStagedProductManifestStub        func(string) (string, error)
+	getStagedProductManifestMutex       sync.RWMutex
+	getStagedProductManifestArgsForCall []struct {
+		arg1 string
+	}
+	getStagedProductManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getStagedProductManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ListDeployedProductsStub        func() ([]api.DeployedProductOutput, error)
+	listDeployedProductsMutex       sync.RWMutex
+	listDeployedProductsArgsForCall []struct {
+	}
+	listDeployedProductsReturns struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
+	listDeployedProductsReturnsOnCall map[int]struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
 	ListStagedProductsStub        func() (api.StagedProductsOutput, error)
 	listStagedProductsMutex       sync.RWMutex
 	listStagedProductsArgsForCall []struct {
@@ -104,6 +179,360 @@ func (fake *BoshDiffService) DirectorDiffReturnsOnCall(i int, result1 api.Direct
 	}{result1, result2}
 }
 
+func (fake *BoshDiffService) GetDeployedDirectorManifest() (string, error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	ret, specificReturn := fake.getDeployedDirectorManifestReturnsOnCall[len(fake.getDeployedDirectorManifestArgsForCall)]
+	fake.getDeployedDirectorManifestArgsForCall = append(fake.getDeployedDirectorManifestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetDeployedDirectorManifest", []interface{}{})
+	fake.getDeployedDirectorManifestMutex.Unlock()
+	if fake.GetDeployedDirectorManifestStub != nil {
+		return fake.GetDeployedDirectorManifestStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeployedDirectorManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BoshDiffService) GetDeployedDirectorManifestCallCount() int {
+	fake.getDeployedDirectorManifestMutex.RLock()
+	defer fake.getDeployedDirectorManifestMutex.RUnlock()
+	return len(fake.getDeployedDirectorManifestArgsForCall)
+}
+
+func (fake *BoshDiffService) GetDeployedDirectorManifestCalls(stub func() (string, error)) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = stub
+}
+
+func (fake *BoshDiffService) GetDeployedDirectorManifestReturns(result1 string, result2 error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = nil
+	fake.getDeployedDirectorManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetDeployedDirectorManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = nil
+	if fake.getDeployedDirectorManifestReturnsOnCall == nil {
+		fake.getDeployedDirectorManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeployedDirectorManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetDeployedProductManifest(arg1 string) (string, error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	ret, specificReturn := fake.getDeployedProductManifestReturnsOnCall[len(fake.getDeployedProductManifestArgsForCall)]
+	fake.getDeployedProductManifestArgsForCall = append(fake.getDeployedProductManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetDeployedProductManifest", []interface{}{arg1})
+	fake.getDeployedProductManifestMutex.Unlock()
+	if fake.GetDeployedProductManifestStub != nil {
+		return fake.GetDeployedProductManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeployedProductManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BoshDiffService) GetDeployedProductManifestCallCount() int {
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	return len(fake.getDeployedProductManifestArgsForCall)
+}
+
+func (fake *BoshDiffService) GetDeployedProductManifestCalls(stub func(string) (string, error)) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = stub
+}
+
+func (fake *BoshDiffService) GetDeployedProductManifestArgsForCall(i int) string {
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	argsForCall := fake.getDeployedProductManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BoshDiffService) GetDeployedProductManifestReturns(result1 string, result2 error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = nil
+	fake.getDeployedProductManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetDeployedProductManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = nil
+	if fake.getDeployedProductManifestReturnsOnCall == nil {
+		fake.getDeployedProductManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeployedProductManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetStagedDirectorManifest() (string, error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	ret, specificReturn := fake.getStagedDirectorManifestReturnsOnCall[len(fake.getStagedDirectorManifestArgsForCall)]
+	fake.getStagedDirectorManifestArgsForCall = append(fake.getStagedDirectorManifestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetStagedDirectorManifest", []interface{}{})
+	fake.getStagedDirectorManifestMutex.Unlock()
+	if fake.GetStagedDirectorManifestStub != nil {
+		return fake.GetStagedDirectorManifestStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedDirectorManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BoshDiffService) GetStagedDirectorManifestCallCount() int {
+	fake.getStagedDirectorManifestMutex.RLock()
+	defer fake.getStagedDirectorManifestMutex.RUnlock()
+	return len(fake.getStagedDirectorManifestArgsForCall)
+}
+
+func (fake *BoshDiffService) GetStagedDirectorManifestCalls(stub func() (string, error)) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = stub
+}
+
+func (fake *BoshDiffService) GetStagedDirectorManifestReturns(result1 string, result2 error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = nil
+	fake.getStagedDirectorManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetStagedDirectorManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = nil
+	if fake.getStagedDirectorManifestReturnsOnCall == nil {
+		fake.getStagedDirectorManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStagedDirectorManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetStagedProductByName(arg1 string) (api.StagedProductsFindOutput, error) {
+	fake.getStagedProductByNameMutex.Lock()
+	ret, specificReturn := fake.getStagedProductByNameReturnsOnCall[len(fake.getStagedProductByNameArgsForCall)]
+	fake.getStagedProductByNameArgsForCall = append(fake.getStagedProductByNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStagedProductByName", []interface{}{arg1})
+	fake.getStagedProductByNameMutex.Unlock()
+	if fake.GetStagedProductByNameStub != nil {
+		return fake.GetStagedProductByNameStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedProductByNameReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BoshDiffService) GetStagedProductByNameCallCount() int {
+	fake.getStagedProductByNameMutex.RLock()
+	defer fake.getStagedProductByNameMutex.RUnlock()
+	return len(fake.getStagedProductByNameArgsForCall)
+}
+
+func (fake *BoshDiffService) GetStagedProductByNameCalls(stub func(string) (api.StagedProductsFindOutput, error)) {
+	fake.getStagedProductByNameMutex.Lock()
+	defer fake.getStagedProductByNameMutex.Unlock()
+	fake.GetStagedProductByNameStub = stub
+}
+
+func (fake *BoshDiffService) GetStagedProductByNameArgsForCall(i int) string {
+	fake.getStagedProductByNameMutex.RLock()
+	defer fake.getStagedProductByNameMutex.RUnlock()
+	argsForCall := fake.getStagedProductByNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BoshDiffService) GetStagedProductByNameReturns(result1 api.StagedProductsFindOutput, result2 error) {
+	fake.getStagedProductByNameMutex.Lock()
+	defer fake.getStagedProductByNameMutex.Unlock()
+	fake.GetStagedProductByNameStub = nil
+	fake.getStagedProductByNameReturns = struct {
+		result1 api.StagedProductsFindOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetStagedProductByNameReturnsOnCall(i int, result1 api.StagedProductsFindOutput, result2 error) {
+	fake.getStagedProductByNameMutex.Lock()
+	defer fake.getStagedProductByNameMutex.Unlock()
+	fake.GetStagedProductByNameStub = nil
+	if fake.getStagedProductByNameReturnsOnCall == nil {
+		fake.getStagedProductByNameReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsFindOutput
+			result2 error
+		})
+	}
+	fake.getStagedProductByNameReturnsOnCall[i] = struct {
+		result1 api.StagedProductsFindOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetStagedProductManifest(arg1 string) (string, error) {
+	fake.getStagedProductManifestMutex.Lock()
+	ret, specificReturn := fake.getStagedProductManifestReturnsOnCall[len(fake.getStagedProductManifestArgsForCall)]
+	fake.getStagedProductManifestArgsForCall = append(fake.getStagedProductManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStagedProductManifest", []interface{}{arg1})
+	fake.getStagedProductManifestMutex.Unlock()
+	if fake.GetStagedProductManifestStub != nil {
+		return fake.GetStagedProductManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedProductManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BoshDiffService) GetStagedProductManifestCallCount() int {
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	return len(fake.getStagedProductManifestArgsForCall)
+}
+
+func (fake *BoshDiffService) GetStagedProductManifestCalls(stub func(string) (string, error)) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = stub
+}
+
+func (fake *BoshDiffService) GetStagedProductManifestArgsForCall(i int) string {
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	argsForCall := fake.getStagedProductManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *BoshDiffService) GetStagedProductManifestReturns(result1 string, result2 error) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = nil
+	fake.getStagedProductManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) GetStagedProductManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = nil
+	if fake.getStagedProductManifestReturnsOnCall == nil {
+		fake.getStagedProductManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStagedProductManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) ListDeployedProducts() ([]api.DeployedProductOutput, error) {
+	fake.listDeployedProductsMutex.Lock()
+	ret, specificReturn := fake.listDeployedProductsReturnsOnCall[len(fake.listDeployedProductsArgsForCall)]
+	fake.listDeployedProductsArgsForCall = append(fake.listDeployedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDeployedProducts", []interface{}{})
+	fake.listDeployedProductsMutex.Unlock()
+	if fake.ListDeployedProductsStub != nil {
+		return fake.ListDeployedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDeployedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BoshDiffService) ListDeployedProductsCallCount() int {
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
+	return len(fake.listDeployedProductsArgsForCall)
+}
+
+func (fake *BoshDiffService) ListDeployedProductsCalls(stub func() ([]api.DeployedProductOutput, error)) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = stub
+}
+
+func (fake *BoshDiffService) ListDeployedProductsReturns(result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	fake.listDeployedProductsReturns = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BoshDiffService) ListDeployedProductsReturnsOnCall(i int, result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	if fake.listDeployedProductsReturnsOnCall == nil {
+		fake.listDeployedProductsReturnsOnCall = make(map[int]struct {
+			result1 []api.DeployedProductOutput
+			result2 error
+		})
+	}
+	fake.listDeployedProductsReturnsOnCall[i] = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BoshDiffService) ListStagedProducts() (api.StagedProductsOutput, error) {
 	fake.listStagedProductsMutex.Lock()
 	ret, specificReturn := fake.listStagedProductsReturnsOnCall[len(fake.listStagedProductsArgsForCall)]
@@ -227,6 +656,18 @@ func (fake *BoshDiffService) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.directorDiffMutex.RLock()
 	defer fake.directorDiffMutex.RUnlock()
+	fake.getDeployedDirectorManifestMutex.RLock()
+	defer fake.getDeployedDirectorManifestMutex.RUnlock()
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	fake.getStagedDirectorManifestMutex.RLock()
+	defer fake.getStagedDirectorManifestMutex.RUnlock()
+	fake.getStagedProductByNameMutex.RLock()
+	defer fake.getStagedProductByNameMutex.RUnlock()
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
 	fake.listStagedProductsMutex.RLock()
 	defer fake.listStagedProductsMutex.RUnlock()
 	fake.productDiffMutex.RLock()
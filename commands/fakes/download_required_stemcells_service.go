@@ -0,0 +1,398 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type DownloadRequiredStemcellsService struct {
+	CheckStemcellAvailabilityStub        func(string) (bool, error)
+	checkStemcellAvailabilityMutex       sync.RWMutex
+	checkStemcellAvailabilityArgsForCall []struct {
+		arg1 string
+	}
+	checkStemcellAvailabilityReturns struct {
+		result1 bool
+		result2 error
+	}
+	checkStemcellAvailabilityReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	GetDiagnosticReportStub        func() (api.DiagnosticReport, error)
+	getDiagnosticReportMutex       sync.RWMutex
+	getDiagnosticReportArgsForCall []struct {
+	}
+	getDiagnosticReportReturns struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}
+	getDiagnosticReportReturnsOnCall map[int]struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}
+	InfoStub        func() (api.Info, error)
+	infoMutex       sync.RWMutex
+	infoArgsForCall []struct {
+	}
+	infoReturns struct {
+		result1 api.Info
+		result2 error
+	}
+	infoReturnsOnCall map[int]struct {
+		result1 api.Info
+		result2 error
+	}
+	ListMultiStemcellsStub        func() (api.ProductMultiStemcells, error)
+	listMultiStemcellsMutex       sync.RWMutex
+	listMultiStemcellsArgsForCall []struct {
+	}
+	listMultiStemcellsReturns struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}
+	listMultiStemcellsReturnsOnCall map[int]struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}
+	UploadStemcellStub        func(api.StemcellUploadInput) (api.StemcellUploadOutput, error)
+	uploadStemcellMutex       sync.RWMutex
+	uploadStemcellArgsForCall []struct {
+		arg1 api.StemcellUploadInput
+	}
+	uploadStemcellReturns struct {
+		result1 api.StemcellUploadOutput
+		result2 error
+	}
+	uploadStemcellReturnsOnCall map[int]struct {
+		result1 api.StemcellUploadOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *DownloadRequiredStemcellsService) CheckStemcellAvailability(arg1 string) (bool, error) {
+	fake.checkStemcellAvailabilityMutex.Lock()
+	ret, specificReturn := fake.checkStemcellAvailabilityReturnsOnCall[len(fake.checkStemcellAvailabilityArgsForCall)]
+	fake.checkStemcellAvailabilityArgsForCall = append(fake.checkStemcellAvailabilityArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("CheckStemcellAvailability", []interface{}{arg1})
+	fake.checkStemcellAvailabilityMutex.Unlock()
+	if fake.CheckStemcellAvailabilityStub != nil {
+		return fake.CheckStemcellAvailabilityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checkStemcellAvailabilityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DownloadRequiredStemcellsService) CheckStemcellAvailabilityCallCount() int {
+	fake.checkStemcellAvailabilityMutex.RLock()
+	defer fake.checkStemcellAvailabilityMutex.RUnlock()
+	return len(fake.checkStemcellAvailabilityArgsForCall)
+}
+
+func (fake *DownloadRequiredStemcellsService) CheckStemcellAvailabilityCalls(stub func(string) (bool, error)) {
+	fake.checkStemcellAvailabilityMutex.Lock()
+	defer fake.checkStemcellAvailabilityMutex.Unlock()
+	fake.CheckStemcellAvailabilityStub = stub
+}
+
+func (fake *DownloadRequiredStemcellsService) CheckStemcellAvailabilityArgsForCall(i int) string {
+	fake.checkStemcellAvailabilityMutex.RLock()
+	defer fake.checkStemcellAvailabilityMutex.RUnlock()
+	argsForCall := fake.checkStemcellAvailabilityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DownloadRequiredStemcellsService) CheckStemcellAvailabilityReturns(result1 bool, result2 error) {
+	fake.checkStemcellAvailabilityMutex.Lock()
+	defer fake.checkStemcellAvailabilityMutex.Unlock()
+	fake.CheckStemcellAvailabilityStub = nil
+	fake.checkStemcellAvailabilityReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) CheckStemcellAvailabilityReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkStemcellAvailabilityMutex.Lock()
+	defer fake.checkStemcellAvailabilityMutex.Unlock()
+	fake.CheckStemcellAvailabilityStub = nil
+	if fake.checkStemcellAvailabilityReturnsOnCall == nil {
+		fake.checkStemcellAvailabilityReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.checkStemcellAvailabilityReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) GetDiagnosticReport() (api.DiagnosticReport, error) {
+	fake.getDiagnosticReportMutex.Lock()
+	ret, specificReturn := fake.getDiagnosticReportReturnsOnCall[len(fake.getDiagnosticReportArgsForCall)]
+	fake.getDiagnosticReportArgsForCall = append(fake.getDiagnosticReportArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetDiagnosticReport", []interface{}{})
+	fake.getDiagnosticReportMutex.Unlock()
+	if fake.GetDiagnosticReportStub != nil {
+		return fake.GetDiagnosticReportStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDiagnosticReportReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DownloadRequiredStemcellsService) GetDiagnosticReportCallCount() int {
+	fake.getDiagnosticReportMutex.RLock()
+	defer fake.getDiagnosticReportMutex.RUnlock()
+	return len(fake.getDiagnosticReportArgsForCall)
+}
+
+func (fake *DownloadRequiredStemcellsService) GetDiagnosticReportCalls(stub func() (api.DiagnosticReport, error)) {
+	fake.getDiagnosticReportMutex.Lock()
+	defer fake.getDiagnosticReportMutex.Unlock()
+	fake.GetDiagnosticReportStub = stub
+}
+
+func (fake *DownloadRequiredStemcellsService) GetDiagnosticReportReturns(result1 api.DiagnosticReport, result2 error) {
+	fake.getDiagnosticReportMutex.Lock()
+	defer fake.getDiagnosticReportMutex.Unlock()
+	fake.GetDiagnosticReportStub = nil
+	fake.getDiagnosticReportReturns = struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) GetDiagnosticReportReturnsOnCall(i int, result1 api.DiagnosticReport, result2 error) {
+	fake.getDiagnosticReportMutex.Lock()
+	defer fake.getDiagnosticReportMutex.Unlock()
+	fake.GetDiagnosticReportStub = nil
+	if fake.getDiagnosticReportReturnsOnCall == nil {
+		fake.getDiagnosticReportReturnsOnCall = make(map[int]struct {
+			result1 api.DiagnosticReport
+			result2 error
+		})
+	}
+	fake.getDiagnosticReportReturnsOnCall[i] = struct {
+		result1 api.DiagnosticReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) Info() (api.Info, error) {
+	fake.infoMutex.Lock()
+	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
+	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Info", []interface{}{})
+	fake.infoMutex.Unlock()
+	if fake.InfoStub != nil {
+		return fake.InfoStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.infoReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DownloadRequiredStemcellsService) InfoCallCount() int {
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	return len(fake.infoArgsForCall)
+}
+
+func (fake *DownloadRequiredStemcellsService) InfoCalls(stub func() (api.Info, error)) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = stub
+}
+
+func (fake *DownloadRequiredStemcellsService) InfoReturns(result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	fake.infoReturns = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) InfoReturnsOnCall(i int, result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	if fake.infoReturnsOnCall == nil {
+		fake.infoReturnsOnCall = make(map[int]struct {
+			result1 api.Info
+			result2 error
+		})
+	}
+	fake.infoReturnsOnCall[i] = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) ListMultiStemcells() (api.ProductMultiStemcells, error) {
+	fake.listMultiStemcellsMutex.Lock()
+	ret, specificReturn := fake.listMultiStemcellsReturnsOnCall[len(fake.listMultiStemcellsArgsForCall)]
+	fake.listMultiStemcellsArgsForCall = append(fake.listMultiStemcellsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListMultiStemcells", []interface{}{})
+	fake.listMultiStemcellsMutex.Unlock()
+	if fake.ListMultiStemcellsStub != nil {
+		return fake.ListMultiStemcellsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listMultiStemcellsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DownloadRequiredStemcellsService) ListMultiStemcellsCallCount() int {
+	fake.listMultiStemcellsMutex.RLock()
+	defer fake.listMultiStemcellsMutex.RUnlock()
+	return len(fake.listMultiStemcellsArgsForCall)
+}
+
+func (fake *DownloadRequiredStemcellsService) ListMultiStemcellsCalls(stub func() (api.ProductMultiStemcells, error)) {
+	fake.listMultiStemcellsMutex.Lock()
+	defer fake.listMultiStemcellsMutex.Unlock()
+	fake.ListMultiStemcellsStub = stub
+}
+
+func (fake *DownloadRequiredStemcellsService) ListMultiStemcellsReturns(result1 api.ProductMultiStemcells, result2 error) {
+	fake.listMultiStemcellsMutex.Lock()
+	defer fake.listMultiStemcellsMutex.Unlock()
+	fake.ListMultiStemcellsStub = nil
+	fake.listMultiStemcellsReturns = struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) ListMultiStemcellsReturnsOnCall(i int, result1 api.ProductMultiStemcells, result2 error) {
+	fake.listMultiStemcellsMutex.Lock()
+	defer fake.listMultiStemcellsMutex.Unlock()
+	fake.ListMultiStemcellsStub = nil
+	if fake.listMultiStemcellsReturnsOnCall == nil {
+		fake.listMultiStemcellsReturnsOnCall = make(map[int]struct {
+			result1 api.ProductMultiStemcells
+			result2 error
+		})
+	}
+	fake.listMultiStemcellsReturnsOnCall[i] = struct {
+		result1 api.ProductMultiStemcells
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) UploadStemcell(arg1 api.StemcellUploadInput) (api.StemcellUploadOutput, error) {
+	fake.uploadStemcellMutex.Lock()
+	ret, specificReturn := fake.uploadStemcellReturnsOnCall[len(fake.uploadStemcellArgsForCall)]
+	fake.uploadStemcellArgsForCall = append(fake.uploadStemcellArgsForCall, struct {
+		arg1 api.StemcellUploadInput
+	}{arg1})
+	fake.recordInvocation("UploadStemcell", []interface{}{arg1})
+	fake.uploadStemcellMutex.Unlock()
+	if fake.UploadStemcellStub != nil {
+		return fake.UploadStemcellStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.uploadStemcellReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DownloadRequiredStemcellsService) UploadStemcellCallCount() int {
+	fake.uploadStemcellMutex.RLock()
+	defer fake.uploadStemcellMutex.RUnlock()
+	return len(fake.uploadStemcellArgsForCall)
+}
+
+func (fake *DownloadRequiredStemcellsService) UploadStemcellCalls(stub func(api.StemcellUploadInput) (api.StemcellUploadOutput, error)) {
+	fake.uploadStemcellMutex.Lock()
+	defer fake.uploadStemcellMutex.Unlock()
+	fake.UploadStemcellStub = stub
+}
+
+func (fake *DownloadRequiredStemcellsService) UploadStemcellArgsForCall(i int) api.StemcellUploadInput {
+	fake.uploadStemcellMutex.RLock()
+	defer fake.uploadStemcellMutex.RUnlock()
+	argsForCall := fake.uploadStemcellArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *DownloadRequiredStemcellsService) UploadStemcellReturns(result1 api.StemcellUploadOutput, result2 error) {
+	fake.uploadStemcellMutex.Lock()
+	defer fake.uploadStemcellMutex.Unlock()
+	fake.UploadStemcellStub = nil
+	fake.uploadStemcellReturns = struct {
+		result1 api.StemcellUploadOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) UploadStemcellReturnsOnCall(i int, result1 api.StemcellUploadOutput, result2 error) {
+	fake.uploadStemcellMutex.Lock()
+	defer fake.uploadStemcellMutex.Unlock()
+	fake.UploadStemcellStub = nil
+	if fake.uploadStemcellReturnsOnCall == nil {
+		fake.uploadStemcellReturnsOnCall = make(map[int]struct {
+			result1 api.StemcellUploadOutput
+			result2 error
+		})
+	}
+	fake.uploadStemcellReturnsOnCall[i] = struct {
+		result1 api.StemcellUploadOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DownloadRequiredStemcellsService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkStemcellAvailabilityMutex.RLock()
+	defer fake.checkStemcellAvailabilityMutex.RUnlock()
+	fake.getDiagnosticReportMutex.RLock()
+	defer fake.getDiagnosticReportMutex.RUnlock()
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	fake.listMultiStemcellsMutex.RLock()
+	defer fake.listMultiStemcellsMutex.RUnlock()
+	fake.uploadStemcellMutex.RLock()
+	defer fake.uploadStemcellMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *DownloadRequiredStemcellsService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,182 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type EnableDirectorVerifiersService struct {
+	EnableDirectorVerifiersStub        func([]string) error
+	enableDirectorVerifiersMutex       sync.RWMutex
+	enableDirectorVerifiersArgsForCall []struct {
+		arg1 []string
+	}
+	enableDirectorVerifiersReturns struct {
+		result1 error
+	}
+	enableDirectorVerifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListDirectorVerifiersStub        func() ([]api.Verifier, error)
+	listDirectorVerifiersMutex       sync.RWMutex
+	listDirectorVerifiersArgsForCall []struct {
+	}
+	listDirectorVerifiersReturns struct {
+		result1 []api.Verifier
+		result2 error
+	}
+	listDirectorVerifiersReturnsOnCall map[int]struct {
+		result1 []api.Verifier
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EnableDirectorVerifiersService) EnableDirectorVerifiers(arg1 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.enableDirectorVerifiersMutex.Lock()
+	ret, specificReturn := fake.enableDirectorVerifiersReturnsOnCall[len(fake.enableDirectorVerifiersArgsForCall)]
+	fake.enableDirectorVerifiersArgsForCall = append(fake.enableDirectorVerifiersArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("EnableDirectorVerifiers", []interface{}{arg1Copy})
+	fake.enableDirectorVerifiersMutex.Unlock()
+	if fake.EnableDirectorVerifiersStub != nil {
+		return fake.EnableDirectorVerifiersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enableDirectorVerifiersReturns
+	return fakeReturns.result1
+}
+
+func (fake *EnableDirectorVerifiersService) EnableDirectorVerifiersCallCount() int {
+	fake.enableDirectorVerifiersMutex.RLock()
+	defer fake.enableDirectorVerifiersMutex.RUnlock()
+	return len(fake.enableDirectorVerifiersArgsForCall)
+}
+
+func (fake *EnableDirectorVerifiersService) EnableDirectorVerifiersCalls(stub func([]string) error) {
+	fake.enableDirectorVerifiersMutex.Lock()
+	defer fake.enableDirectorVerifiersMutex.Unlock()
+	fake.EnableDirectorVerifiersStub = stub
+}
+
+func (fake *EnableDirectorVerifiersService) EnableDirectorVerifiersArgsForCall(i int) []string {
+	fake.enableDirectorVerifiersMutex.RLock()
+	defer fake.enableDirectorVerifiersMutex.RUnlock()
+	argsForCall := fake.enableDirectorVerifiersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EnableDirectorVerifiersService) EnableDirectorVerifiersReturns(result1 error) {
+	fake.enableDirectorVerifiersMutex.Lock()
+	defer fake.enableDirectorVerifiersMutex.Unlock()
+	fake.EnableDirectorVerifiersStub = nil
+	fake.enableDirectorVerifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EnableDirectorVerifiersService) EnableDirectorVerifiersReturnsOnCall(i int, result1 error) {
+	fake.enableDirectorVerifiersMutex.Lock()
+	defer fake.enableDirectorVerifiersMutex.Unlock()
+	fake.EnableDirectorVerifiersStub = nil
+	if fake.enableDirectorVerifiersReturnsOnCall == nil {
+		fake.enableDirectorVerifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enableDirectorVerifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EnableDirectorVerifiersService) ListDirectorVerifiers() ([]api.Verifier, error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	ret, specificReturn := fake.listDirectorVerifiersReturnsOnCall[len(fake.listDirectorVerifiersArgsForCall)]
+	fake.listDirectorVerifiersArgsForCall = append(fake.listDirectorVerifiersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDirectorVerifiers", []interface{}{})
+	fake.listDirectorVerifiersMutex.Unlock()
+	if fake.ListDirectorVerifiersStub != nil {
+		return fake.ListDirectorVerifiersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDirectorVerifiersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *EnableDirectorVerifiersService) ListDirectorVerifiersCallCount() int {
+	fake.listDirectorVerifiersMutex.RLock()
+	defer fake.listDirectorVerifiersMutex.RUnlock()
+	return len(fake.listDirectorVerifiersArgsForCall)
+}
+
+func (fake *EnableDirectorVerifiersService) ListDirectorVerifiersCalls(stub func() ([]api.Verifier, error)) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = stub
+}
+
+func (fake *EnableDirectorVerifiersService) ListDirectorVerifiersReturns(result1 []api.Verifier, result2 error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = nil
+	fake.listDirectorVerifiersReturns = struct {
+		result1 []api.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EnableDirectorVerifiersService) ListDirectorVerifiersReturnsOnCall(i int, result1 []api.Verifier, result2 error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = nil
+	if fake.listDirectorVerifiersReturnsOnCall == nil {
+		fake.listDirectorVerifiersReturnsOnCall = make(map[int]struct {
+			result1 []api.Verifier
+			result2 error
+		})
+	}
+	fake.listDirectorVerifiersReturnsOnCall[i] = struct {
+		result1 []api.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EnableDirectorVerifiersService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.enableDirectorVerifiersMutex.RLock()
+	defer fake.enableDirectorVerifiersMutex.RUnlock()
+	fake.listDirectorVerifiersMutex.RLock()
+	defer fake.listDirectorVerifiersMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *EnableDirectorVerifiersService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
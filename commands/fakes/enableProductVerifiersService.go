@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type EnableProductVerifiersService struct {
+	EnableProductVerifiersStub        func([]string, string) error
+	enableProductVerifiersMutex       sync.RWMutex
+	enableProductVerifiersArgsForCall []struct {
+		arg1 []string
+		arg2 string
+	}
+	enableProductVerifiersReturns struct {
+		result1 error
+	}
+	enableProductVerifiersReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListProductVerifiersStub        func(string) ([]api.Verifier, string, error)
+	listProductVerifiersMutex       sync.RWMutex
+	listProductVerifiersArgsForCall []struct {
+		arg1 string
+	}
+	listProductVerifiersReturns struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}
+	listProductVerifiersReturnsOnCall map[int]struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *EnableProductVerifiersService) EnableProductVerifiers(arg1 []string, arg2 string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.enableProductVerifiersMutex.Lock()
+	ret, specificReturn := fake.enableProductVerifiersReturnsOnCall[len(fake.enableProductVerifiersArgsForCall)]
+	fake.enableProductVerifiersArgsForCall = append(fake.enableProductVerifiersArgsForCall, struct {
+		arg1 []string
+		arg2 string
+	}{arg1Copy, arg2})
+	fake.recordInvocation("EnableProductVerifiers", []interface{}{arg1Copy, arg2})
+	fake.enableProductVerifiersMutex.Unlock()
+	if fake.EnableProductVerifiersStub != nil {
+		return fake.EnableProductVerifiersStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.enableProductVerifiersReturns
+	return fakeReturns.result1
+}
+
+func (fake *EnableProductVerifiersService) EnableProductVerifiersCallCount() int {
+	fake.enableProductVerifiersMutex.RLock()
+	defer fake.enableProductVerifiersMutex.RUnlock()
+	return len(fake.enableProductVerifiersArgsForCall)
+}
+
+func (fake *EnableProductVerifiersService) EnableProductVerifiersCalls(stub func([]string, string) error) {
+	fake.enableProductVerifiersMutex.Lock()
+	defer fake.enableProductVerifiersMutex.Unlock()
+	fake.EnableProductVerifiersStub = stub
+}
+
+func (fake *EnableProductVerifiersService) EnableProductVerifiersArgsForCall(i int) ([]string, string) {
+	fake.enableProductVerifiersMutex.RLock()
+	defer fake.enableProductVerifiersMutex.RUnlock()
+	argsForCall := fake.enableProductVerifiersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *EnableProductVerifiersService) EnableProductVerifiersReturns(result1 error) {
+	fake.enableProductVerifiersMutex.Lock()
+	defer fake.enableProductVerifiersMutex.Unlock()
+	fake.EnableProductVerifiersStub = nil
+	fake.enableProductVerifiersReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EnableProductVerifiersService) EnableProductVerifiersReturnsOnCall(i int, result1 error) {
+	fake.enableProductVerifiersMutex.Lock()
+	defer fake.enableProductVerifiersMutex.Unlock()
+	fake.EnableProductVerifiersStub = nil
+	if fake.enableProductVerifiersReturnsOnCall == nil {
+		fake.enableProductVerifiersReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.enableProductVerifiersReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EnableProductVerifiersService) ListProductVerifiers(arg1 string) ([]api.Verifier, string, error) {
+	fake.listProductVerifiersMutex.Lock()
+	ret, specificReturn := fake.listProductVerifiersReturnsOnCall[len(fake.listProductVerifiersArgsForCall)]
+	fake.listProductVerifiersArgsForCall = append(fake.listProductVerifiersArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListProductVerifiers", []interface{}{arg1})
+	fake.listProductVerifiersMutex.Unlock()
+	if fake.ListProductVerifiersStub != nil {
+		return fake.ListProductVerifiersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.listProductVerifiersReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *EnableProductVerifiersService) ListProductVerifiersCallCount() int {
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	return len(fake.listProductVerifiersArgsForCall)
+}
+
+func (fake *EnableProductVerifiersService) ListProductVerifiersCalls(stub func(string) ([]api.Verifier, string, error)) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = stub
+}
+
+func (fake *EnableProductVerifiersService) ListProductVerifiersArgsForCall(i int) string {
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	argsForCall := fake.listProductVerifiersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *EnableProductVerifiersService) ListProductVerifiersReturns(result1 []api.Verifier, result2 string, result3 error) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = nil
+	fake.listProductVerifiersReturns = struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *EnableProductVerifiersService) ListProductVerifiersReturnsOnCall(i int, result1 []api.Verifier, result2 string, result3 error) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = nil
+	if fake.listProductVerifiersReturnsOnCall == nil {
+		fake.listProductVerifiersReturnsOnCall = make(map[int]struct {
+			result1 []api.Verifier
+			result2 string
+			result3 error
+		})
+	}
+	fake.listProductVerifiersReturnsOnCall[i] = struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *EnableProductVerifiersService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.enableProductVerifiersMutex.RLock()
+	defer fake.enableProductVerifiersMutex.RUnlock()
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *EnableProductVerifiersService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
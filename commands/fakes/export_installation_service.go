@@ -3,13 +3,15 @@ package fakes
 
 import (
 	"sync"
+
+	"github.com/pivotal-cf/om/api"
 )
 
 type ExportInstallationService struct {
-	DownloadInstallationAssetCollectionStub        func(string) error
+	DownloadInstallationAssetCollectionStub        func(api.DownloadInstallationAssetCollectionInput) error
 	downloadInstallationAssetCollectionMutex       sync.RWMutex
 	downloadInstallationAssetCollectionArgsForCall []struct {
-		arg1 string
+		arg1 api.DownloadInstallationAssetCollectionInput
 	}
 	downloadInstallationAssetCollectionReturns struct {
 		result1 error
@@ -21,11 +23,11 @@ type ExportInstallationService struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ExportInstallationService) DownloadInstallationAssetCollection(arg1 string) error {
+func (fake *ExportInstallationService) DownloadInstallationAssetCollection(arg1 api.DownloadInstallationAssetCollectionInput) error {
 	fake.downloadInstallationAssetCollectionMutex.Lock()
 	ret, specificReturn := fake.downloadInstallationAssetCollectionReturnsOnCall[len(fake.downloadInstallationAssetCollectionArgsForCall)]
 	fake.downloadInstallationAssetCollectionArgsForCall = append(fake.downloadInstallationAssetCollectionArgsForCall, struct {
-		arg1 string
+		arg1 api.DownloadInstallationAssetCollectionInput
 	}{arg1})
 	fake.recordInvocation("DownloadInstallationAssetCollection", []interface{}{arg1})
 	fake.downloadInstallationAssetCollectionMutex.Unlock()
@@ -45,13 +47,13 @@ func (fake *ExportInstallationService) DownloadInstallationAssetCollectionCallCo
 	return len(fake.downloadInstallationAssetCollectionArgsForCall)
 }
 
-func (fake *ExportInstallationService) DownloadInstallationAssetCollectionCalls(stub func(string) error) {
+func (fake *ExportInstallationService) DownloadInstallationAssetCollectionCalls(stub func(api.DownloadInstallationAssetCollectionInput) error) {
 	fake.downloadInstallationAssetCollectionMutex.Lock()
 	defer fake.downloadInstallationAssetCollectionMutex.Unlock()
 	fake.DownloadInstallationAssetCollectionStub = stub
 }
 
-func (fake *ExportInstallationService) DownloadInstallationAssetCollectionArgsForCall(i int) string {
+func (fake *ExportInstallationService) DownloadInstallationAssetCollectionArgsForCall(i int) api.DownloadInstallationAssetCollectionInput {
 	fake.downloadInstallationAssetCollectionMutex.RLock()
 	defer fake.downloadInstallationAssetCollectionMutex.RUnlock()
 	argsForCall := fake.downloadInstallationAssetCollectionArgsForCall[i]
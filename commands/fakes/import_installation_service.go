@@ -21,6 +21,20 @@ type ImportInstallationService struct {
 		result1 api.EnsureAvailabilityOutput
 		result2 error
 	}
+	GetInstallationAssetCollectionUploadOffsetStub        func(string, int64) (int64, error)
+	getInstallationAssetCollectionUploadOffsetMutex       sync.RWMutex
+	getInstallationAssetCollectionUploadOffsetArgsForCall []struct {
+		arg1 string
+		arg2 int64
+	}
+	getInstallationAssetCollectionUploadOffsetReturns struct {
+		result1 int64
+		result2 error
+	}
+	getInstallationAssetCollectionUploadOffsetReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	UploadInstallationAssetCollectionStub        func(api.ImportInstallationInput) error
 	uploadInstallationAssetCollectionMutex       sync.RWMutex
 	uploadInstallationAssetCollectionArgsForCall []struct {
@@ -32,6 +46,19 @@ type ImportInstallationService struct {
 	uploadInstallationAssetCollectionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UploadInstallationAssetCollectionChunkStub        func(api.ImportInstallationChunkInput) (api.ImportInstallationChunkOutput, error)
+	uploadInstallationAssetCollectionChunkMutex       sync.RWMutex
+	uploadInstallationAssetCollectionChunkArgsForCall []struct {
+		arg1 api.ImportInstallationChunkInput
+	}
+	uploadInstallationAssetCollectionChunkReturns struct {
+		result1 api.ImportInstallationChunkOutput
+		result2 error
+	}
+	uploadInstallationAssetCollectionChunkReturnsOnCall map[int]struct {
+		result1 api.ImportInstallationChunkOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -99,6 +126,70 @@ func (fake *ImportInstallationService) EnsureAvailabilityReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *ImportInstallationService) GetInstallationAssetCollectionUploadOffset(arg1 string, arg2 int64) (int64, error) {
+	fake.getInstallationAssetCollectionUploadOffsetMutex.Lock()
+	ret, specificReturn := fake.getInstallationAssetCollectionUploadOffsetReturnsOnCall[len(fake.getInstallationAssetCollectionUploadOffsetArgsForCall)]
+	fake.getInstallationAssetCollectionUploadOffsetArgsForCall = append(fake.getInstallationAssetCollectionUploadOffsetArgsForCall, struct {
+		arg1 string
+		arg2 int64
+	}{arg1, arg2})
+	fake.recordInvocation("GetInstallationAssetCollectionUploadOffset", []interface{}{arg1, arg2})
+	fake.getInstallationAssetCollectionUploadOffsetMutex.Unlock()
+	if fake.GetInstallationAssetCollectionUploadOffsetStub != nil {
+		return fake.GetInstallationAssetCollectionUploadOffsetStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInstallationAssetCollectionUploadOffsetReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImportInstallationService) GetInstallationAssetCollectionUploadOffsetCallCount() int {
+	fake.getInstallationAssetCollectionUploadOffsetMutex.RLock()
+	defer fake.getInstallationAssetCollectionUploadOffsetMutex.RUnlock()
+	return len(fake.getInstallationAssetCollectionUploadOffsetArgsForCall)
+}
+
+func (fake *ImportInstallationService) GetInstallationAssetCollectionUploadOffsetCalls(stub func(string, int64) (int64, error)) {
+	fake.getInstallationAssetCollectionUploadOffsetMutex.Lock()
+	defer fake.getInstallationAssetCollectionUploadOffsetMutex.Unlock()
+	fake.GetInstallationAssetCollectionUploadOffsetStub = stub
+}
+
+func (fake *ImportInstallationService) GetInstallationAssetCollectionUploadOffsetArgsForCall(i int) (string, int64) {
+	fake.getInstallationAssetCollectionUploadOffsetMutex.RLock()
+	defer fake.getInstallationAssetCollectionUploadOffsetMutex.RUnlock()
+	argsForCall := fake.getInstallationAssetCollectionUploadOffsetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ImportInstallationService) GetInstallationAssetCollectionUploadOffsetReturns(result1 int64, result2 error) {
+	fake.getInstallationAssetCollectionUploadOffsetMutex.Lock()
+	defer fake.getInstallationAssetCollectionUploadOffsetMutex.Unlock()
+	fake.GetInstallationAssetCollectionUploadOffsetStub = nil
+	fake.getInstallationAssetCollectionUploadOffsetReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImportInstallationService) GetInstallationAssetCollectionUploadOffsetReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.getInstallationAssetCollectionUploadOffsetMutex.Lock()
+	defer fake.getInstallationAssetCollectionUploadOffsetMutex.Unlock()
+	fake.GetInstallationAssetCollectionUploadOffsetStub = nil
+	if fake.getInstallationAssetCollectionUploadOffsetReturnsOnCall == nil {
+		fake.getInstallationAssetCollectionUploadOffsetReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.getInstallationAssetCollectionUploadOffsetReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImportInstallationService) UploadInstallationAssetCollection(arg1 api.ImportInstallationInput) error {
 	fake.uploadInstallationAssetCollectionMutex.Lock()
 	ret, specificReturn := fake.uploadInstallationAssetCollectionReturnsOnCall[len(fake.uploadInstallationAssetCollectionArgsForCall)]
@@ -159,13 +250,80 @@ func (fake *ImportInstallationService) UploadInstallationAssetCollectionReturnsO
 	}{result1}
 }
 
+func (fake *ImportInstallationService) UploadInstallationAssetCollectionChunk(arg1 api.ImportInstallationChunkInput) (api.ImportInstallationChunkOutput, error) {
+	fake.uploadInstallationAssetCollectionChunkMutex.Lock()
+	ret, specificReturn := fake.uploadInstallationAssetCollectionChunkReturnsOnCall[len(fake.uploadInstallationAssetCollectionChunkArgsForCall)]
+	fake.uploadInstallationAssetCollectionChunkArgsForCall = append(fake.uploadInstallationAssetCollectionChunkArgsForCall, struct {
+		arg1 api.ImportInstallationChunkInput
+	}{arg1})
+	fake.recordInvocation("UploadInstallationAssetCollectionChunk", []interface{}{arg1})
+	fake.uploadInstallationAssetCollectionChunkMutex.Unlock()
+	if fake.UploadInstallationAssetCollectionChunkStub != nil {
+		return fake.UploadInstallationAssetCollectionChunkStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.uploadInstallationAssetCollectionChunkReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImportInstallationService) UploadInstallationAssetCollectionChunkCallCount() int {
+	fake.uploadInstallationAssetCollectionChunkMutex.RLock()
+	defer fake.uploadInstallationAssetCollectionChunkMutex.RUnlock()
+	return len(fake.uploadInstallationAssetCollectionChunkArgsForCall)
+}
+
+func (fake *ImportInstallationService) UploadInstallationAssetCollectionChunkCalls(stub func(api.ImportInstallationChunkInput) (api.ImportInstallationChunkOutput, error)) {
+	fake.uploadInstallationAssetCollectionChunkMutex.Lock()
+	defer fake.uploadInstallationAssetCollectionChunkMutex.Unlock()
+	fake.UploadInstallationAssetCollectionChunkStub = stub
+}
+
+func (fake *ImportInstallationService) UploadInstallationAssetCollectionChunkArgsForCall(i int) api.ImportInstallationChunkInput {
+	fake.uploadInstallationAssetCollectionChunkMutex.RLock()
+	defer fake.uploadInstallationAssetCollectionChunkMutex.RUnlock()
+	argsForCall := fake.uploadInstallationAssetCollectionChunkArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ImportInstallationService) UploadInstallationAssetCollectionChunkReturns(result1 api.ImportInstallationChunkOutput, result2 error) {
+	fake.uploadInstallationAssetCollectionChunkMutex.Lock()
+	defer fake.uploadInstallationAssetCollectionChunkMutex.Unlock()
+	fake.UploadInstallationAssetCollectionChunkStub = nil
+	fake.uploadInstallationAssetCollectionChunkReturns = struct {
+		result1 api.ImportInstallationChunkOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImportInstallationService) UploadInstallationAssetCollectionChunkReturnsOnCall(i int, result1 api.ImportInstallationChunkOutput, result2 error) {
+	fake.uploadInstallationAssetCollectionChunkMutex.Lock()
+	defer fake.uploadInstallationAssetCollectionChunkMutex.Unlock()
+	fake.UploadInstallationAssetCollectionChunkStub = nil
+	if fake.uploadInstallationAssetCollectionChunkReturnsOnCall == nil {
+		fake.uploadInstallationAssetCollectionChunkReturnsOnCall = make(map[int]struct {
+			result1 api.ImportInstallationChunkOutput
+			result2 error
+		})
+	}
+	fake.uploadInstallationAssetCollectionChunkReturnsOnCall[i] = struct {
+		result1 api.ImportInstallationChunkOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImportInstallationService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.ensureAvailabilityMutex.RLock()
 	defer fake.ensureAvailabilityMutex.RUnlock()
+	fake.getInstallationAssetCollectionUploadOffsetMutex.RLock()
+	defer fake.getInstallationAssetCollectionUploadOffsetMutex.RUnlock()
 	fake.uploadInstallationAssetCollectionMutex.RLock()
 	defer fake.uploadInstallationAssetCollectionMutex.RUnlock()
+	fake.uploadInstallationAssetCollectionChunkMutex.RLock()
+	defer fake.uploadInstallationAssetCollectionChunkMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
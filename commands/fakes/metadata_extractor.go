@@ -21,6 +21,19 @@ type MetadataExtractor struct {
 		result1 *extractor.Metadata
 		result2 error
 	}
+	ExtractFromURLStub        func(string) (*extractor.Metadata, error)
+	extractFromURLMutex       sync.RWMutex
+	extractFromURLArgsForCall []struct {
+		arg1 string
+	}
+	extractFromURLReturns struct {
+		result1 *extractor.Metadata
+		result2 error
+	}
+	extractFromURLReturnsOnCall map[int]struct {
+		result1 *extractor.Metadata
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -88,11 +101,76 @@ func (fake *MetadataExtractor) ExtractFromFileReturnsOnCall(i int, result1 *extr
 	}{result1, result2}
 }
 
+func (fake *MetadataExtractor) ExtractFromURL(arg1 string) (*extractor.Metadata, error) {
+	fake.extractFromURLMutex.Lock()
+	ret, specificReturn := fake.extractFromURLReturnsOnCall[len(fake.extractFromURLArgsForCall)]
+	fake.extractFromURLArgsForCall = append(fake.extractFromURLArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ExtractFromURL", []interface{}{arg1})
+	fake.extractFromURLMutex.Unlock()
+	if fake.ExtractFromURLStub != nil {
+		return fake.ExtractFromURLStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.extractFromURLReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *MetadataExtractor) ExtractFromURLCallCount() int {
+	fake.extractFromURLMutex.RLock()
+	defer fake.extractFromURLMutex.RUnlock()
+	return len(fake.extractFromURLArgsForCall)
+}
+
+func (fake *MetadataExtractor) ExtractFromURLCalls(stub func(string) (*extractor.Metadata, error)) {
+	fake.extractFromURLMutex.Lock()
+	defer fake.extractFromURLMutex.Unlock()
+	fake.ExtractFromURLStub = stub
+}
+
+func (fake *MetadataExtractor) ExtractFromURLArgsForCall(i int) string {
+	fake.extractFromURLMutex.RLock()
+	defer fake.extractFromURLMutex.RUnlock()
+	argsForCall := fake.extractFromURLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *MetadataExtractor) ExtractFromURLReturns(result1 *extractor.Metadata, result2 error) {
+	fake.extractFromURLMutex.Lock()
+	defer fake.extractFromURLMutex.Unlock()
+	fake.ExtractFromURLStub = nil
+	fake.extractFromURLReturns = struct {
+		result1 *extractor.Metadata
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MetadataExtractor) ExtractFromURLReturnsOnCall(i int, result1 *extractor.Metadata, result2 error) {
+	fake.extractFromURLMutex.Lock()
+	defer fake.extractFromURLMutex.Unlock()
+	fake.ExtractFromURLStub = nil
+	if fake.extractFromURLReturnsOnCall == nil {
+		fake.extractFromURLReturnsOnCall = make(map[int]struct {
+			result1 *extractor.Metadata
+			result2 error
+		})
+	}
+	fake.extractFromURLReturnsOnCall[i] = struct {
+		result1 *extractor.Metadata
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *MetadataExtractor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.extractFromFileMutex.RLock()
 	defer fake.extractFromFileMutex.RUnlock()
+	fake.extractFromURLMutex.RLock()
+	defer fake.extractFromURLMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
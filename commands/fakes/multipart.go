@@ -2,6 +2,7 @@
 package fakes
 
 import (
+	"io"
 	"sync"
 
 	"github.com/pivotal-cf/om/formcontent"
@@ -32,6 +33,20 @@ type Multipart struct {
 	addFileReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AddFileFromReaderStub        func(string, string, io.Reader, int64) error
+	addFileFromReaderMutex       sync.RWMutex
+	addFileFromReaderArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 io.Reader
+		arg4 int64
+	}
+	addFileFromReaderReturns struct {
+		result1 error
+	}
+	addFileFromReaderReturnsOnCall map[int]struct {
+		result1 error
+	}
 	FinalizeStub        func() formcontent.ContentSubmission
 	finalizeMutex       sync.RWMutex
 	finalizeArgsForCall []struct {
@@ -172,6 +187,69 @@ func (fake *Multipart) AddFileReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Multipart) AddFileFromReader(arg1 string, arg2 string, arg3 io.Reader, arg4 int64) error {
+	fake.addFileFromReaderMutex.Lock()
+	ret, specificReturn := fake.addFileFromReaderReturnsOnCall[len(fake.addFileFromReaderArgsForCall)]
+	fake.addFileFromReaderArgsForCall = append(fake.addFileFromReaderArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 io.Reader
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("AddFileFromReader", []interface{}{arg1, arg2, arg3, arg4})
+	fake.addFileFromReaderMutex.Unlock()
+	if fake.AddFileFromReaderStub != nil {
+		return fake.AddFileFromReaderStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.addFileFromReaderReturns
+	return fakeReturns.result1
+}
+
+func (fake *Multipart) AddFileFromReaderCallCount() int {
+	fake.addFileFromReaderMutex.RLock()
+	defer fake.addFileFromReaderMutex.RUnlock()
+	return len(fake.addFileFromReaderArgsForCall)
+}
+
+func (fake *Multipart) AddFileFromReaderCalls(stub func(string, string, io.Reader, int64) error) {
+	fake.addFileFromReaderMutex.Lock()
+	defer fake.addFileFromReaderMutex.Unlock()
+	fake.AddFileFromReaderStub = stub
+}
+
+func (fake *Multipart) AddFileFromReaderArgsForCall(i int) (string, string, io.Reader, int64) {
+	fake.addFileFromReaderMutex.RLock()
+	defer fake.addFileFromReaderMutex.RUnlock()
+	argsForCall := fake.addFileFromReaderArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Multipart) AddFileFromReaderReturns(result1 error) {
+	fake.addFileFromReaderMutex.Lock()
+	defer fake.addFileFromReaderMutex.Unlock()
+	fake.AddFileFromReaderStub = nil
+	fake.addFileFromReaderReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Multipart) AddFileFromReaderReturnsOnCall(i int, result1 error) {
+	fake.addFileFromReaderMutex.Lock()
+	defer fake.addFileFromReaderMutex.Unlock()
+	fake.AddFileFromReaderStub = nil
+	if fake.addFileFromReaderReturnsOnCall == nil {
+		fake.addFileFromReaderReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addFileFromReaderReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Multipart) Finalize() formcontent.ContentSubmission {
 	fake.finalizeMutex.Lock()
 	ret, specificReturn := fake.finalizeReturnsOnCall[len(fake.finalizeArgsForCall)]
@@ -254,6 +332,8 @@ func (fake *Multipart) Invocations() map[string][][]interface{} {
 	defer fake.addFieldMutex.RUnlock()
 	fake.addFileMutex.RLock()
 	defer fake.addFileMutex.RUnlock()
+	fake.addFileFromReaderMutex.RLock()
+	defer fake.addFileFromReaderMutex.RUnlock()
 	fake.finalizeMutex.RLock()
 	defer fake.finalizeMutex.RUnlock()
 	fake.resetMutex.RLock()
@@ -8,6 +8,68 @@ import (
 )
 
 type PendingChangesService struct {
+	GetDeployedDirectorManifestStub        func() (string, error)
+	getDeployedDirectorManifestMutex       sync.RWMutex
+	getDeployedDirectorManifestArgsForCall []struct {
+	}
+	getDeployedDirectorManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeployedDirectorManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetDeployedProductManifestStub        func(string) (string, error)
+	getDeployedProductManifestMutex       sync.RWMutex
+	getDeployedProductManifestArgsForCall []struct {
+		arg1 string
+	}
+	getDeployedProductManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeployedProductManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetStagedDirectorManifestStub        func() (string, error)
+	getStagedDirectorManifestMutex       sync.RWMutex
+	getStagedDirectorManifestArgsForCall []struct {
+	}
+	getStagedDirectorManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getStagedDirectorManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetStagedProductManifestStub        func(string) (string, error)
+	getStagedProductManifestMutex       sync.RWMutex
+	getStagedProductManifestArgsForCall []struct {
+		arg1 string
+	}
+	getStagedProductManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getStagedProductManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ListDeployedProductsStub        func() ([]api.DeployedProductOutput, error)
+	listDeployedProductsMutex       sync.RWMutex
+	listDeployedProductsArgsForCall []struct {
+	}
+	listDeployedProductsReturns struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
+	listDeployedProductsReturnsOnCall map[int]struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
 	ListStagedPendingChangesStub        func() (api.PendingChangesOutput, error)
 	listStagedPendingChangesMutex       sync.RWMutex
 	listStagedPendingChangesArgsForCall []struct {
@@ -20,10 +82,313 @@ type PendingChangesService struct {
 		result1 api.PendingChangesOutput
 		result2 error
 	}
+	ListStagedProductsStub        func() (api.StagedProductsOutput, error)
+	listStagedProductsMutex       sync.RWMutex
+	listStagedProductsArgsForCall []struct {
+	}
+	listStagedProductsReturns struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	listStagedProductsReturnsOnCall map[int]struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *PendingChangesService) GetDeployedDirectorManifest() (string, error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	ret, specificReturn := fake.getDeployedDirectorManifestReturnsOnCall[len(fake.getDeployedDirectorManifestArgsForCall)]
+	fake.getDeployedDirectorManifestArgsForCall = append(fake.getDeployedDirectorManifestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetDeployedDirectorManifest", []interface{}{})
+	fake.getDeployedDirectorManifestMutex.Unlock()
+	if fake.GetDeployedDirectorManifestStub != nil {
+		return fake.GetDeployedDirectorManifestStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeployedDirectorManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PendingChangesService) GetDeployedDirectorManifestCallCount() int {
+	fake.getDeployedDirectorManifestMutex.RLock()
+	defer fake.getDeployedDirectorManifestMutex.RUnlock()
+	return len(fake.getDeployedDirectorManifestArgsForCall)
+}
+
+func (fake *PendingChangesService) GetDeployedDirectorManifestCalls(stub func() (string, error)) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = stub
+}
+
+func (fake *PendingChangesService) GetDeployedDirectorManifestReturns(result1 string, result2 error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = nil
+	fake.getDeployedDirectorManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetDeployedDirectorManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = nil
+	if fake.getDeployedDirectorManifestReturnsOnCall == nil {
+		fake.getDeployedDirectorManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeployedDirectorManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetDeployedProductManifest(arg1 string) (string, error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	ret, specificReturn := fake.getDeployedProductManifestReturnsOnCall[len(fake.getDeployedProductManifestArgsForCall)]
+	fake.getDeployedProductManifestArgsForCall = append(fake.getDeployedProductManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetDeployedProductManifest", []interface{}{arg1})
+	fake.getDeployedProductManifestMutex.Unlock()
+	if fake.GetDeployedProductManifestStub != nil {
+		return fake.GetDeployedProductManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeployedProductManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PendingChangesService) GetDeployedProductManifestCallCount() int {
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	return len(fake.getDeployedProductManifestArgsForCall)
+}
+
+func (fake *PendingChangesService) GetDeployedProductManifestCalls(stub func(string) (string, error)) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = stub
+}
+
+func (fake *PendingChangesService) GetDeployedProductManifestArgsForCall(i int) string {
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	argsForCall := fake.getDeployedProductManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PendingChangesService) GetDeployedProductManifestReturns(result1 string, result2 error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = nil
+	fake.getDeployedProductManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetDeployedProductManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = nil
+	if fake.getDeployedProductManifestReturnsOnCall == nil {
+		fake.getDeployedProductManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeployedProductManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetStagedDirectorManifest() (string, error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	ret, specificReturn := fake.getStagedDirectorManifestReturnsOnCall[len(fake.getStagedDirectorManifestArgsForCall)]
+	fake.getStagedDirectorManifestArgsForCall = append(fake.getStagedDirectorManifestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetStagedDirectorManifest", []interface{}{})
+	fake.getStagedDirectorManifestMutex.Unlock()
+	if fake.GetStagedDirectorManifestStub != nil {
+		return fake.GetStagedDirectorManifestStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedDirectorManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PendingChangesService) GetStagedDirectorManifestCallCount() int {
+	fake.getStagedDirectorManifestMutex.RLock()
+	defer fake.getStagedDirectorManifestMutex.RUnlock()
+	return len(fake.getStagedDirectorManifestArgsForCall)
+}
+
+func (fake *PendingChangesService) GetStagedDirectorManifestCalls(stub func() (string, error)) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = stub
+}
+
+func (fake *PendingChangesService) GetStagedDirectorManifestReturns(result1 string, result2 error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = nil
+	fake.getStagedDirectorManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetStagedDirectorManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = nil
+	if fake.getStagedDirectorManifestReturnsOnCall == nil {
+		fake.getStagedDirectorManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStagedDirectorManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetStagedProductManifest(arg1 string) (string, error) {
+	fake.getStagedProductManifestMutex.Lock()
+	ret, specificReturn := fake.getStagedProductManifestReturnsOnCall[len(fake.getStagedProductManifestArgsForCall)]
+	fake.getStagedProductManifestArgsForCall = append(fake.getStagedProductManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStagedProductManifest", []interface{}{arg1})
+	fake.getStagedProductManifestMutex.Unlock()
+	if fake.GetStagedProductManifestStub != nil {
+		return fake.GetStagedProductManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedProductManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PendingChangesService) GetStagedProductManifestCallCount() int {
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	return len(fake.getStagedProductManifestArgsForCall)
+}
+
+func (fake *PendingChangesService) GetStagedProductManifestCalls(stub func(string) (string, error)) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = stub
+}
+
+func (fake *PendingChangesService) GetStagedProductManifestArgsForCall(i int) string {
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	argsForCall := fake.getStagedProductManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *PendingChangesService) GetStagedProductManifestReturns(result1 string, result2 error) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = nil
+	fake.getStagedProductManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) GetStagedProductManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = nil
+	if fake.getStagedProductManifestReturnsOnCall == nil {
+		fake.getStagedProductManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStagedProductManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) ListDeployedProducts() ([]api.DeployedProductOutput, error) {
+	fake.listDeployedProductsMutex.Lock()
+	ret, specificReturn := fake.listDeployedProductsReturnsOnCall[len(fake.listDeployedProductsArgsForCall)]
+	fake.listDeployedProductsArgsForCall = append(fake.listDeployedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDeployedProducts", []interface{}{})
+	fake.listDeployedProductsMutex.Unlock()
+	if fake.ListDeployedProductsStub != nil {
+		return fake.ListDeployedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDeployedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PendingChangesService) ListDeployedProductsCallCount() int {
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
+	return len(fake.listDeployedProductsArgsForCall)
+}
+
+func (fake *PendingChangesService) ListDeployedProductsCalls(stub func() ([]api.DeployedProductOutput, error)) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = stub
+}
+
+func (fake *PendingChangesService) ListDeployedProductsReturns(result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	fake.listDeployedProductsReturns = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) ListDeployedProductsReturnsOnCall(i int, result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	if fake.listDeployedProductsReturnsOnCall == nil {
+		fake.listDeployedProductsReturnsOnCall = make(map[int]struct {
+			result1 []api.DeployedProductOutput
+			result2 error
+		})
+	}
+	fake.listDeployedProductsReturnsOnCall[i] = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PendingChangesService) ListStagedPendingChanges() (api.PendingChangesOutput, error) {
 	fake.listStagedPendingChangesMutex.Lock()
 	ret, specificReturn := fake.listStagedPendingChangesReturnsOnCall[len(fake.listStagedPendingChangesArgsForCall)]
@@ -79,11 +444,78 @@ func (fake *PendingChangesService) ListStagedPendingChangesReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *PendingChangesService) ListStagedProducts() (api.StagedProductsOutput, error) {
+	fake.listStagedProductsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductsReturnsOnCall[len(fake.listStagedProductsArgsForCall)]
+	fake.listStagedProductsArgsForCall = append(fake.listStagedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStagedProducts", []interface{}{})
+	fake.listStagedProductsMutex.Unlock()
+	if fake.ListStagedProductsStub != nil {
+		return fake.ListStagedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PendingChangesService) ListStagedProductsCallCount() int {
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	return len(fake.listStagedProductsArgsForCall)
+}
+
+func (fake *PendingChangesService) ListStagedProductsCalls(stub func() (api.StagedProductsOutput, error)) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = stub
+}
+
+func (fake *PendingChangesService) ListStagedProductsReturns(result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	fake.listStagedProductsReturns = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PendingChangesService) ListStagedProductsReturnsOnCall(i int, result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	if fake.listStagedProductsReturnsOnCall == nil {
+		fake.listStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductsReturnsOnCall[i] = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PendingChangesService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getDeployedDirectorManifestMutex.RLock()
+	defer fake.getDeployedDirectorManifestMutex.RUnlock()
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	fake.getStagedDirectorManifestMutex.RLock()
+	defer fake.getStagedDirectorManifestMutex.RUnlock()
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
 	fake.listStagedPendingChangesMutex.RLock()
 	defer fake.listStagedPendingChangesMutex.RUnlock()
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
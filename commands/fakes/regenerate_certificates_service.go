@@ -3,9 +3,36 @@ package fakes
 
 import (
 	"sync"
+
+	"github.com/pivotal-cf/om/api"
 )
 
 type RegenerateCertificatesService struct {
+	ListCertificatesStub        func(string) ([]api.ExpiringCertificate, error)
+	listCertificatesMutex       sync.RWMutex
+	listCertificatesArgsForCall []struct {
+		arg1 string
+	}
+	listCertificatesReturns struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}
+	listCertificatesReturnsOnCall map[int]struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}
+	ListDeployedProductsStub        func() ([]api.DeployedProductOutput, error)
+	listDeployedProductsMutex       sync.RWMutex
+	listDeployedProductsArgsForCall []struct {
+	}
+	listDeployedProductsReturns struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
+	listDeployedProductsReturnsOnCall map[int]struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
 	RegenerateCertificatesStub        func() error
 	regenerateCertificatesMutex       sync.RWMutex
 	regenerateCertificatesArgsForCall []struct {
@@ -20,6 +47,124 @@ type RegenerateCertificatesService struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *RegenerateCertificatesService) ListCertificates(arg1 string) ([]api.ExpiringCertificate, error) {
+	fake.listCertificatesMutex.Lock()
+	ret, specificReturn := fake.listCertificatesReturnsOnCall[len(fake.listCertificatesArgsForCall)]
+	fake.listCertificatesArgsForCall = append(fake.listCertificatesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListCertificates", []interface{}{arg1})
+	fake.listCertificatesMutex.Unlock()
+	if fake.ListCertificatesStub != nil {
+		return fake.ListCertificatesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listCertificatesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RegenerateCertificatesService) ListCertificatesCallCount() int {
+	fake.listCertificatesMutex.RLock()
+	defer fake.listCertificatesMutex.RUnlock()
+	return len(fake.listCertificatesArgsForCall)
+}
+
+func (fake *RegenerateCertificatesService) ListCertificatesCalls(stub func(string) ([]api.ExpiringCertificate, error)) {
+	fake.listCertificatesMutex.Lock()
+	defer fake.listCertificatesMutex.Unlock()
+	fake.ListCertificatesStub = stub
+}
+
+func (fake *RegenerateCertificatesService) ListCertificatesArgsForCall(i int) string {
+	fake.listCertificatesMutex.RLock()
+	defer fake.listCertificatesMutex.RUnlock()
+	argsForCall := fake.listCertificatesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RegenerateCertificatesService) ListCertificatesReturns(result1 []api.ExpiringCertificate, result2 error) {
+	fake.listCertificatesMutex.Lock()
+	defer fake.listCertificatesMutex.Unlock()
+	fake.ListCertificatesStub = nil
+	fake.listCertificatesReturns = struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegenerateCertificatesService) ListCertificatesReturnsOnCall(i int, result1 []api.ExpiringCertificate, result2 error) {
+	fake.listCertificatesMutex.Lock()
+	defer fake.listCertificatesMutex.Unlock()
+	fake.ListCertificatesStub = nil
+	if fake.listCertificatesReturnsOnCall == nil {
+		fake.listCertificatesReturnsOnCall = make(map[int]struct {
+			result1 []api.ExpiringCertificate
+			result2 error
+		})
+	}
+	fake.listCertificatesReturnsOnCall[i] = struct {
+		result1 []api.ExpiringCertificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegenerateCertificatesService) ListDeployedProducts() ([]api.DeployedProductOutput, error) {
+	fake.listDeployedProductsMutex.Lock()
+	ret, specificReturn := fake.listDeployedProductsReturnsOnCall[len(fake.listDeployedProductsArgsForCall)]
+	fake.listDeployedProductsArgsForCall = append(fake.listDeployedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDeployedProducts", []interface{}{})
+	fake.listDeployedProductsMutex.Unlock()
+	if fake.ListDeployedProductsStub != nil {
+		return fake.ListDeployedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDeployedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RegenerateCertificatesService) ListDeployedProductsCallCount() int {
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
+	return len(fake.listDeployedProductsArgsForCall)
+}
+
+func (fake *RegenerateCertificatesService) ListDeployedProductsCalls(stub func() ([]api.DeployedProductOutput, error)) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = stub
+}
+
+func (fake *RegenerateCertificatesService) ListDeployedProductsReturns(result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	fake.listDeployedProductsReturns = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RegenerateCertificatesService) ListDeployedProductsReturnsOnCall(i int, result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	if fake.listDeployedProductsReturnsOnCall == nil {
+		fake.listDeployedProductsReturnsOnCall = make(map[int]struct {
+			result1 []api.DeployedProductOutput
+			result2 error
+		})
+	}
+	fake.listDeployedProductsReturnsOnCall[i] = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *RegenerateCertificatesService) RegenerateCertificates() error {
 	fake.regenerateCertificatesMutex.Lock()
 	ret, specificReturn := fake.regenerateCertificatesReturnsOnCall[len(fake.regenerateCertificatesArgsForCall)]
@@ -75,6 +220,10 @@ func (fake *RegenerateCertificatesService) RegenerateCertificatesReturnsOnCall(i
 func (fake *RegenerateCertificatesService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.listCertificatesMutex.RLock()
+	defer fake.listCertificatesMutex.RUnlock()
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
 	fake.regenerateCertificatesMutex.RLock()
 	defer fake.regenerateCertificatesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
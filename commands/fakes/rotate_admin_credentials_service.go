@@ -0,0 +1,181 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type RotateAdminCredentialsService struct {
+	ChangeUAAUserPasswordStub        func(string, string, string) error
+	changeUAAUserPasswordMutex       sync.RWMutex
+	changeUAAUserPasswordArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	changeUAAUserPasswordReturns struct {
+		result1 error
+	}
+	changeUAAUserPasswordReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetCurrentUAAUserStub        func() (api.UAAUser, error)
+	getCurrentUAAUserMutex       sync.RWMutex
+	getCurrentUAAUserArgsForCall []struct {
+	}
+	getCurrentUAAUserReturns struct {
+		result1 api.UAAUser
+		result2 error
+	}
+	getCurrentUAAUserReturnsOnCall map[int]struct {
+		result1 api.UAAUser
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RotateAdminCredentialsService) ChangeUAAUserPassword(arg1 string, arg2 string, arg3 string) error {
+	fake.changeUAAUserPasswordMutex.Lock()
+	ret, specificReturn := fake.changeUAAUserPasswordReturnsOnCall[len(fake.changeUAAUserPasswordArgsForCall)]
+	fake.changeUAAUserPasswordArgsForCall = append(fake.changeUAAUserPasswordArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ChangeUAAUserPassword", []interface{}{arg1, arg2, arg3})
+	fake.changeUAAUserPasswordMutex.Unlock()
+	if fake.ChangeUAAUserPasswordStub != nil {
+		return fake.ChangeUAAUserPasswordStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.changeUAAUserPasswordReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateAdminCredentialsService) ChangeUAAUserPasswordCallCount() int {
+	fake.changeUAAUserPasswordMutex.RLock()
+	defer fake.changeUAAUserPasswordMutex.RUnlock()
+	return len(fake.changeUAAUserPasswordArgsForCall)
+}
+
+func (fake *RotateAdminCredentialsService) ChangeUAAUserPasswordCalls(stub func(string, string, string) error) {
+	fake.changeUAAUserPasswordMutex.Lock()
+	defer fake.changeUAAUserPasswordMutex.Unlock()
+	fake.ChangeUAAUserPasswordStub = stub
+}
+
+func (fake *RotateAdminCredentialsService) ChangeUAAUserPasswordArgsForCall(i int) (string, string, string) {
+	fake.changeUAAUserPasswordMutex.RLock()
+	defer fake.changeUAAUserPasswordMutex.RUnlock()
+	argsForCall := fake.changeUAAUserPasswordArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RotateAdminCredentialsService) ChangeUAAUserPasswordReturns(result1 error) {
+	fake.changeUAAUserPasswordMutex.Lock()
+	defer fake.changeUAAUserPasswordMutex.Unlock()
+	fake.ChangeUAAUserPasswordStub = nil
+	fake.changeUAAUserPasswordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateAdminCredentialsService) ChangeUAAUserPasswordReturnsOnCall(i int, result1 error) {
+	fake.changeUAAUserPasswordMutex.Lock()
+	defer fake.changeUAAUserPasswordMutex.Unlock()
+	fake.ChangeUAAUserPasswordStub = nil
+	if fake.changeUAAUserPasswordReturnsOnCall == nil {
+		fake.changeUAAUserPasswordReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.changeUAAUserPasswordReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateAdminCredentialsService) GetCurrentUAAUser() (api.UAAUser, error) {
+	fake.getCurrentUAAUserMutex.Lock()
+	ret, specificReturn := fake.getCurrentUAAUserReturnsOnCall[len(fake.getCurrentUAAUserArgsForCall)]
+	fake.getCurrentUAAUserArgsForCall = append(fake.getCurrentUAAUserArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetCurrentUAAUser", []interface{}{})
+	fake.getCurrentUAAUserMutex.Unlock()
+	if fake.GetCurrentUAAUserStub != nil {
+		return fake.GetCurrentUAAUserStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getCurrentUAAUserReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateAdminCredentialsService) GetCurrentUAAUserCallCount() int {
+	fake.getCurrentUAAUserMutex.RLock()
+	defer fake.getCurrentUAAUserMutex.RUnlock()
+	return len(fake.getCurrentUAAUserArgsForCall)
+}
+
+func (fake *RotateAdminCredentialsService) GetCurrentUAAUserCalls(stub func() (api.UAAUser, error)) {
+	fake.getCurrentUAAUserMutex.Lock()
+	defer fake.getCurrentUAAUserMutex.Unlock()
+	fake.GetCurrentUAAUserStub = stub
+}
+
+func (fake *RotateAdminCredentialsService) GetCurrentUAAUserReturns(result1 api.UAAUser, result2 error) {
+	fake.getCurrentUAAUserMutex.Lock()
+	defer fake.getCurrentUAAUserMutex.Unlock()
+	fake.GetCurrentUAAUserStub = nil
+	fake.getCurrentUAAUserReturns = struct {
+		result1 api.UAAUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateAdminCredentialsService) GetCurrentUAAUserReturnsOnCall(i int, result1 api.UAAUser, result2 error) {
+	fake.getCurrentUAAUserMutex.Lock()
+	defer fake.getCurrentUAAUserMutex.Unlock()
+	fake.GetCurrentUAAUserStub = nil
+	if fake.getCurrentUAAUserReturnsOnCall == nil {
+		fake.getCurrentUAAUserReturnsOnCall = make(map[int]struct {
+			result1 api.UAAUser
+			result2 error
+		})
+	}
+	fake.getCurrentUAAUserReturnsOnCall[i] = struct {
+		result1 api.UAAUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateAdminCredentialsService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.changeUAAUserPasswordMutex.RLock()
+	defer fake.changeUAAUserPasswordMutex.RUnlock()
+	fake.getCurrentUAAUserMutex.RLock()
+	defer fake.getCurrentUAAUserMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RotateAdminCredentialsService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,1640 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type RotateCertificateAuthorityService struct {
+	AbortInstallationStub        func(int) error
+	abortInstallationMutex       sync.RWMutex
+	abortInstallationArgsForCall []struct {
+		arg1 int
+	}
+	abortInstallationReturns struct {
+		result1 error
+	}
+	abortInstallationReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ActivateCertificateAuthorityStub        func(api.ActivateCertificateAuthorityInput) error
+	activateCertificateAuthorityMutex       sync.RWMutex
+	activateCertificateAuthorityArgsForCall []struct {
+		arg1 api.ActivateCertificateAuthorityInput
+	}
+	activateCertificateAuthorityReturns struct {
+		result1 error
+	}
+	activateCertificateAuthorityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateCertificateAuthorityStub        func(api.CertificateAuthorityInput) (api.GenerateCAResponse, error)
+	createCertificateAuthorityMutex       sync.RWMutex
+	createCertificateAuthorityArgsForCall []struct {
+		arg1 api.CertificateAuthorityInput
+	}
+	createCertificateAuthorityReturns struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}
+	createCertificateAuthorityReturnsOnCall map[int]struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}
+	CreateInstallationStub        func(bool, bool, bool, []string, api.ApplyErrandChanges) (api.InstallationsServiceOutput, error)
+	createInstallationMutex       sync.RWMutex
+	createInstallationArgsForCall []struct {
+		arg1 bool
+		arg2 bool
+		arg3 bool
+		arg4 []string
+		arg5 api.ApplyErrandChanges
+	}
+	createInstallationReturns struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	createInstallationReturnsOnCall map[int]struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	DeleteCertificateAuthorityStub        func(api.DeleteCertificateAuthorityInput) error
+	deleteCertificateAuthorityMutex       sync.RWMutex
+	deleteCertificateAuthorityArgsForCall []struct {
+		arg1 api.DeleteCertificateAuthorityInput
+	}
+	deleteCertificateAuthorityReturns struct {
+		result1 error
+	}
+	deleteCertificateAuthorityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GenerateCertificateAuthorityStub        func() (api.GenerateCAResponse, error)
+	generateCertificateAuthorityMutex       sync.RWMutex
+	generateCertificateAuthorityArgsForCall []struct {
+	}
+	generateCertificateAuthorityReturns struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}
+	generateCertificateAuthorityReturnsOnCall map[int]struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}
+	GetDeployedDirectorManifestStub        func() (string, error)
+	getDeployedDirectorManifestMutex       sync.RWMutex
+	getDeployedDirectorManifestArgsForCall []struct {
+	}
+	getDeployedDirectorManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeployedDirectorManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetDeployedProductManifestStub        func(string) (string, error)
+	getDeployedProductManifestMutex       sync.RWMutex
+	getDeployedProductManifestArgsForCall []struct {
+		arg1 string
+	}
+	getDeployedProductManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getDeployedProductManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetInstallationStub        func(int) (api.InstallationsServiceOutput, error)
+	getInstallationMutex       sync.RWMutex
+	getInstallationArgsForCall []struct {
+		arg1 int
+	}
+	getInstallationReturns struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	getInstallationReturnsOnCall map[int]struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	GetInstallationLogsStub        func(int) (api.InstallationsServiceOutput, error)
+	getInstallationLogsMutex       sync.RWMutex
+	getInstallationLogsArgsForCall []struct {
+		arg1 int
+	}
+	getInstallationLogsReturns struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	getInstallationLogsReturnsOnCall map[int]struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	GetStagedDirectorManifestStub        func() (string, error)
+	getStagedDirectorManifestMutex       sync.RWMutex
+	getStagedDirectorManifestArgsForCall []struct {
+	}
+	getStagedDirectorManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getStagedDirectorManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetStagedProductDependenciesStub        func(string) ([]api.ProductDependency, error)
+	getStagedProductDependenciesMutex       sync.RWMutex
+	getStagedProductDependenciesArgsForCall []struct {
+		arg1 string
+	}
+	getStagedProductDependenciesReturns struct {
+		result1 []api.ProductDependency
+		result2 error
+	}
+	getStagedProductDependenciesReturnsOnCall map[int]struct {
+		result1 []api.ProductDependency
+		result2 error
+	}
+	GetStagedProductManifestStub        func(string) (string, error)
+	getStagedProductManifestMutex       sync.RWMutex
+	getStagedProductManifestArgsForCall []struct {
+		arg1 string
+	}
+	getStagedProductManifestReturns struct {
+		result1 string
+		result2 error
+	}
+	getStagedProductManifestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	InfoStub        func() (api.Info, error)
+	infoMutex       sync.RWMutex
+	infoArgsForCall []struct {
+	}
+	infoReturns struct {
+		result1 api.Info
+		result2 error
+	}
+	infoReturnsOnCall map[int]struct {
+		result1 api.Info
+		result2 error
+	}
+	ListCertificateAuthoritiesStub        func() (api.CertificateAuthoritiesOutput, error)
+	listCertificateAuthoritiesMutex       sync.RWMutex
+	listCertificateAuthoritiesArgsForCall []struct {
+	}
+	listCertificateAuthoritiesReturns struct {
+		result1 api.CertificateAuthoritiesOutput
+		result2 error
+	}
+	listCertificateAuthoritiesReturnsOnCall map[int]struct {
+		result1 api.CertificateAuthoritiesOutput
+		result2 error
+	}
+	ListDeployedProductsStub        func() ([]api.DeployedProductOutput, error)
+	listDeployedProductsMutex       sync.RWMutex
+	listDeployedProductsArgsForCall []struct {
+	}
+	listDeployedProductsReturns struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
+	listDeployedProductsReturnsOnCall map[int]struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}
+	ListInstallationsStub        func() ([]api.InstallationsServiceOutput, error)
+	listInstallationsMutex       sync.RWMutex
+	listInstallationsArgsForCall []struct {
+	}
+	listInstallationsReturns struct {
+		result1 []api.InstallationsServiceOutput
+		result2 error
+	}
+	listInstallationsReturnsOnCall map[int]struct {
+		result1 []api.InstallationsServiceOutput
+		result2 error
+	}
+	ListStagedPendingChangesStub        func() (api.PendingChangesOutput, error)
+	listStagedPendingChangesMutex       sync.RWMutex
+	listStagedPendingChangesArgsForCall []struct {
+	}
+	listStagedPendingChangesReturns struct {
+		result1 api.PendingChangesOutput
+		result2 error
+	}
+	listStagedPendingChangesReturnsOnCall map[int]struct {
+		result1 api.PendingChangesOutput
+		result2 error
+	}
+	ListStagedProductsStub        func() (api.StagedProductsOutput, error)
+	listStagedProductsMutex       sync.RWMutex
+	listStagedProductsArgsForCall []struct {
+	}
+	listStagedProductsReturns struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	listStagedProductsReturnsOnCall map[int]struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	RegenerateCertificatesStub        func() error
+	regenerateCertificatesMutex       sync.RWMutex
+	regenerateCertificatesArgsForCall []struct {
+	}
+	regenerateCertificatesReturns struct {
+		result1 error
+	}
+	regenerateCertificatesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunningInstallationStub        func() (api.InstallationsServiceOutput, error)
+	runningInstallationMutex       sync.RWMutex
+	runningInstallationArgsForCall []struct {
+	}
+	runningInstallationReturns struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	runningInstallationReturnsOnCall map[int]struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}
+	UpdateStagedDirectorPropertiesStub        func(api.DirectorProperties) error
+	updateStagedDirectorPropertiesMutex       sync.RWMutex
+	updateStagedDirectorPropertiesArgsForCall []struct {
+		arg1 api.DirectorProperties
+	}
+	updateStagedDirectorPropertiesReturns struct {
+		result1 error
+	}
+	updateStagedDirectorPropertiesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RotateCertificateAuthorityService) AbortInstallation(arg1 int) error {
+	fake.abortInstallationMutex.Lock()
+	ret, specificReturn := fake.abortInstallationReturnsOnCall[len(fake.abortInstallationArgsForCall)]
+	fake.abortInstallationArgsForCall = append(fake.abortInstallationArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("AbortInstallation", []interface{}{arg1})
+	fake.abortInstallationMutex.Unlock()
+	if fake.AbortInstallationStub != nil {
+		return fake.AbortInstallationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.abortInstallationReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateCertificateAuthorityService) AbortInstallationCallCount() int {
+	fake.abortInstallationMutex.RLock()
+	defer fake.abortInstallationMutex.RUnlock()
+	return len(fake.abortInstallationArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) AbortInstallationCalls(stub func(int) error) {
+	fake.abortInstallationMutex.Lock()
+	defer fake.abortInstallationMutex.Unlock()
+	fake.AbortInstallationStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) AbortInstallationArgsForCall(i int) int {
+	fake.abortInstallationMutex.RLock()
+	defer fake.abortInstallationMutex.RUnlock()
+	argsForCall := fake.abortInstallationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) AbortInstallationReturns(result1 error) {
+	fake.abortInstallationMutex.Lock()
+	defer fake.abortInstallationMutex.Unlock()
+	fake.AbortInstallationStub = nil
+	fake.abortInstallationReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) AbortInstallationReturnsOnCall(i int, result1 error) {
+	fake.abortInstallationMutex.Lock()
+	defer fake.abortInstallationMutex.Unlock()
+	fake.AbortInstallationStub = nil
+	if fake.abortInstallationReturnsOnCall == nil {
+		fake.abortInstallationReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.abortInstallationReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) ActivateCertificateAuthority(arg1 api.ActivateCertificateAuthorityInput) error {
+	fake.activateCertificateAuthorityMutex.Lock()
+	ret, specificReturn := fake.activateCertificateAuthorityReturnsOnCall[len(fake.activateCertificateAuthorityArgsForCall)]
+	fake.activateCertificateAuthorityArgsForCall = append(fake.activateCertificateAuthorityArgsForCall, struct {
+		arg1 api.ActivateCertificateAuthorityInput
+	}{arg1})
+	fake.recordInvocation("ActivateCertificateAuthority", []interface{}{arg1})
+	fake.activateCertificateAuthorityMutex.Unlock()
+	if fake.ActivateCertificateAuthorityStub != nil {
+		return fake.ActivateCertificateAuthorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.activateCertificateAuthorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateCertificateAuthorityService) ActivateCertificateAuthorityCallCount() int {
+	fake.activateCertificateAuthorityMutex.RLock()
+	defer fake.activateCertificateAuthorityMutex.RUnlock()
+	return len(fake.activateCertificateAuthorityArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) ActivateCertificateAuthorityCalls(stub func(api.ActivateCertificateAuthorityInput) error) {
+	fake.activateCertificateAuthorityMutex.Lock()
+	defer fake.activateCertificateAuthorityMutex.Unlock()
+	fake.ActivateCertificateAuthorityStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) ActivateCertificateAuthorityArgsForCall(i int) api.ActivateCertificateAuthorityInput {
+	fake.activateCertificateAuthorityMutex.RLock()
+	defer fake.activateCertificateAuthorityMutex.RUnlock()
+	argsForCall := fake.activateCertificateAuthorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) ActivateCertificateAuthorityReturns(result1 error) {
+	fake.activateCertificateAuthorityMutex.Lock()
+	defer fake.activateCertificateAuthorityMutex.Unlock()
+	fake.ActivateCertificateAuthorityStub = nil
+	fake.activateCertificateAuthorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) ActivateCertificateAuthorityReturnsOnCall(i int, result1 error) {
+	fake.activateCertificateAuthorityMutex.Lock()
+	defer fake.activateCertificateAuthorityMutex.Unlock()
+	fake.ActivateCertificateAuthorityStub = nil
+	if fake.activateCertificateAuthorityReturnsOnCall == nil {
+		fake.activateCertificateAuthorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.activateCertificateAuthorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) CreateCertificateAuthority(arg1 api.CertificateAuthorityInput) (api.GenerateCAResponse, error) {
+	fake.createCertificateAuthorityMutex.Lock()
+	ret, specificReturn := fake.createCertificateAuthorityReturnsOnCall[len(fake.createCertificateAuthorityArgsForCall)]
+	fake.createCertificateAuthorityArgsForCall = append(fake.createCertificateAuthorityArgsForCall, struct {
+		arg1 api.CertificateAuthorityInput
+	}{arg1})
+	fake.recordInvocation("CreateCertificateAuthority", []interface{}{arg1})
+	fake.createCertificateAuthorityMutex.Unlock()
+	if fake.CreateCertificateAuthorityStub != nil {
+		return fake.CreateCertificateAuthorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createCertificateAuthorityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) CreateCertificateAuthorityCallCount() int {
+	fake.createCertificateAuthorityMutex.RLock()
+	defer fake.createCertificateAuthorityMutex.RUnlock()
+	return len(fake.createCertificateAuthorityArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) CreateCertificateAuthorityCalls(stub func(api.CertificateAuthorityInput) (api.GenerateCAResponse, error)) {
+	fake.createCertificateAuthorityMutex.Lock()
+	defer fake.createCertificateAuthorityMutex.Unlock()
+	fake.CreateCertificateAuthorityStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) CreateCertificateAuthorityArgsForCall(i int) api.CertificateAuthorityInput {
+	fake.createCertificateAuthorityMutex.RLock()
+	defer fake.createCertificateAuthorityMutex.RUnlock()
+	argsForCall := fake.createCertificateAuthorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) CreateCertificateAuthorityReturns(result1 api.GenerateCAResponse, result2 error) {
+	fake.createCertificateAuthorityMutex.Lock()
+	defer fake.createCertificateAuthorityMutex.Unlock()
+	fake.CreateCertificateAuthorityStub = nil
+	fake.createCertificateAuthorityReturns = struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) CreateCertificateAuthorityReturnsOnCall(i int, result1 api.GenerateCAResponse, result2 error) {
+	fake.createCertificateAuthorityMutex.Lock()
+	defer fake.createCertificateAuthorityMutex.Unlock()
+	fake.CreateCertificateAuthorityStub = nil
+	if fake.createCertificateAuthorityReturnsOnCall == nil {
+		fake.createCertificateAuthorityReturnsOnCall = make(map[int]struct {
+			result1 api.GenerateCAResponse
+			result2 error
+		})
+	}
+	fake.createCertificateAuthorityReturnsOnCall[i] = struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) CreateInstallation(arg1 bool, arg2 bool, arg3 bool, arg4 []string, arg5 api.ApplyErrandChanges) (api.InstallationsServiceOutput, error) {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.createInstallationMutex.Lock()
+	ret, specificReturn := fake.createInstallationReturnsOnCall[len(fake.createInstallationArgsForCall)]
+	fake.createInstallationArgsForCall = append(fake.createInstallationArgsForCall, struct {
+		arg1 bool
+		arg2 bool
+		arg3 bool
+		arg4 []string
+		arg5 api.ApplyErrandChanges
+	}{arg1, arg2, arg3, arg4Copy, arg5})
+	fake.recordInvocation("CreateInstallation", []interface{}{arg1, arg2, arg3, arg4Copy, arg5})
+	fake.createInstallationMutex.Unlock()
+	if fake.CreateInstallationStub != nil {
+		return fake.CreateInstallationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createInstallationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) CreateInstallationCallCount() int {
+	fake.createInstallationMutex.RLock()
+	defer fake.createInstallationMutex.RUnlock()
+	return len(fake.createInstallationArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) CreateInstallationCalls(stub func(bool, bool, bool, []string, api.ApplyErrandChanges) (api.InstallationsServiceOutput, error)) {
+	fake.createInstallationMutex.Lock()
+	defer fake.createInstallationMutex.Unlock()
+	fake.CreateInstallationStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) CreateInstallationArgsForCall(i int) (bool, bool, bool, []string, api.ApplyErrandChanges) {
+	fake.createInstallationMutex.RLock()
+	defer fake.createInstallationMutex.RUnlock()
+	argsForCall := fake.createInstallationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *RotateCertificateAuthorityService) CreateInstallationReturns(result1 api.InstallationsServiceOutput, result2 error) {
+	fake.createInstallationMutex.Lock()
+	defer fake.createInstallationMutex.Unlock()
+	fake.CreateInstallationStub = nil
+	fake.createInstallationReturns = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) CreateInstallationReturnsOnCall(i int, result1 api.InstallationsServiceOutput, result2 error) {
+	fake.createInstallationMutex.Lock()
+	defer fake.createInstallationMutex.Unlock()
+	fake.CreateInstallationStub = nil
+	if fake.createInstallationReturnsOnCall == nil {
+		fake.createInstallationReturnsOnCall = make(map[int]struct {
+			result1 api.InstallationsServiceOutput
+			result2 error
+		})
+	}
+	fake.createInstallationReturnsOnCall[i] = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) DeleteCertificateAuthority(arg1 api.DeleteCertificateAuthorityInput) error {
+	fake.deleteCertificateAuthorityMutex.Lock()
+	ret, specificReturn := fake.deleteCertificateAuthorityReturnsOnCall[len(fake.deleteCertificateAuthorityArgsForCall)]
+	fake.deleteCertificateAuthorityArgsForCall = append(fake.deleteCertificateAuthorityArgsForCall, struct {
+		arg1 api.DeleteCertificateAuthorityInput
+	}{arg1})
+	fake.recordInvocation("DeleteCertificateAuthority", []interface{}{arg1})
+	fake.deleteCertificateAuthorityMutex.Unlock()
+	if fake.DeleteCertificateAuthorityStub != nil {
+		return fake.DeleteCertificateAuthorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteCertificateAuthorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateCertificateAuthorityService) DeleteCertificateAuthorityCallCount() int {
+	fake.deleteCertificateAuthorityMutex.RLock()
+	defer fake.deleteCertificateAuthorityMutex.RUnlock()
+	return len(fake.deleteCertificateAuthorityArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) DeleteCertificateAuthorityCalls(stub func(api.DeleteCertificateAuthorityInput) error) {
+	fake.deleteCertificateAuthorityMutex.Lock()
+	defer fake.deleteCertificateAuthorityMutex.Unlock()
+	fake.DeleteCertificateAuthorityStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) DeleteCertificateAuthorityArgsForCall(i int) api.DeleteCertificateAuthorityInput {
+	fake.deleteCertificateAuthorityMutex.RLock()
+	defer fake.deleteCertificateAuthorityMutex.RUnlock()
+	argsForCall := fake.deleteCertificateAuthorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) DeleteCertificateAuthorityReturns(result1 error) {
+	fake.deleteCertificateAuthorityMutex.Lock()
+	defer fake.deleteCertificateAuthorityMutex.Unlock()
+	fake.DeleteCertificateAuthorityStub = nil
+	fake.deleteCertificateAuthorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) DeleteCertificateAuthorityReturnsOnCall(i int, result1 error) {
+	fake.deleteCertificateAuthorityMutex.Lock()
+	defer fake.deleteCertificateAuthorityMutex.Unlock()
+	fake.DeleteCertificateAuthorityStub = nil
+	if fake.deleteCertificateAuthorityReturnsOnCall == nil {
+		fake.deleteCertificateAuthorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteCertificateAuthorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) GenerateCertificateAuthority() (api.GenerateCAResponse, error) {
+	fake.generateCertificateAuthorityMutex.Lock()
+	ret, specificReturn := fake.generateCertificateAuthorityReturnsOnCall[len(fake.generateCertificateAuthorityArgsForCall)]
+	fake.generateCertificateAuthorityArgsForCall = append(fake.generateCertificateAuthorityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GenerateCertificateAuthority", []interface{}{})
+	fake.generateCertificateAuthorityMutex.Unlock()
+	if fake.GenerateCertificateAuthorityStub != nil {
+		return fake.GenerateCertificateAuthorityStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.generateCertificateAuthorityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GenerateCertificateAuthorityCallCount() int {
+	fake.generateCertificateAuthorityMutex.RLock()
+	defer fake.generateCertificateAuthorityMutex.RUnlock()
+	return len(fake.generateCertificateAuthorityArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GenerateCertificateAuthorityCalls(stub func() (api.GenerateCAResponse, error)) {
+	fake.generateCertificateAuthorityMutex.Lock()
+	defer fake.generateCertificateAuthorityMutex.Unlock()
+	fake.GenerateCertificateAuthorityStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GenerateCertificateAuthorityReturns(result1 api.GenerateCAResponse, result2 error) {
+	fake.generateCertificateAuthorityMutex.Lock()
+	defer fake.generateCertificateAuthorityMutex.Unlock()
+	fake.GenerateCertificateAuthorityStub = nil
+	fake.generateCertificateAuthorityReturns = struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GenerateCertificateAuthorityReturnsOnCall(i int, result1 api.GenerateCAResponse, result2 error) {
+	fake.generateCertificateAuthorityMutex.Lock()
+	defer fake.generateCertificateAuthorityMutex.Unlock()
+	fake.GenerateCertificateAuthorityStub = nil
+	if fake.generateCertificateAuthorityReturnsOnCall == nil {
+		fake.generateCertificateAuthorityReturnsOnCall = make(map[int]struct {
+			result1 api.GenerateCAResponse
+			result2 error
+		})
+	}
+	fake.generateCertificateAuthorityReturnsOnCall[i] = struct {
+		result1 api.GenerateCAResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedDirectorManifest() (string, error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	ret, specificReturn := fake.getDeployedDirectorManifestReturnsOnCall[len(fake.getDeployedDirectorManifestArgsForCall)]
+	fake.getDeployedDirectorManifestArgsForCall = append(fake.getDeployedDirectorManifestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetDeployedDirectorManifest", []interface{}{})
+	fake.getDeployedDirectorManifestMutex.Unlock()
+	if fake.GetDeployedDirectorManifestStub != nil {
+		return fake.GetDeployedDirectorManifestStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeployedDirectorManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedDirectorManifestCallCount() int {
+	fake.getDeployedDirectorManifestMutex.RLock()
+	defer fake.getDeployedDirectorManifestMutex.RUnlock()
+	return len(fake.getDeployedDirectorManifestArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedDirectorManifestCalls(stub func() (string, error)) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedDirectorManifestReturns(result1 string, result2 error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = nil
+	fake.getDeployedDirectorManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedDirectorManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeployedDirectorManifestMutex.Lock()
+	defer fake.getDeployedDirectorManifestMutex.Unlock()
+	fake.GetDeployedDirectorManifestStub = nil
+	if fake.getDeployedDirectorManifestReturnsOnCall == nil {
+		fake.getDeployedDirectorManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeployedDirectorManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedProductManifest(arg1 string) (string, error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	ret, specificReturn := fake.getDeployedProductManifestReturnsOnCall[len(fake.getDeployedProductManifestArgsForCall)]
+	fake.getDeployedProductManifestArgsForCall = append(fake.getDeployedProductManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetDeployedProductManifest", []interface{}{arg1})
+	fake.getDeployedProductManifestMutex.Unlock()
+	if fake.GetDeployedProductManifestStub != nil {
+		return fake.GetDeployedProductManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDeployedProductManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedProductManifestCallCount() int {
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	return len(fake.getDeployedProductManifestArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedProductManifestCalls(stub func(string) (string, error)) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedProductManifestArgsForCall(i int) string {
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	argsForCall := fake.getDeployedProductManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedProductManifestReturns(result1 string, result2 error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = nil
+	fake.getDeployedProductManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetDeployedProductManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getDeployedProductManifestMutex.Lock()
+	defer fake.getDeployedProductManifestMutex.Unlock()
+	fake.GetDeployedProductManifestStub = nil
+	if fake.getDeployedProductManifestReturnsOnCall == nil {
+		fake.getDeployedProductManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDeployedProductManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallation(arg1 int) (api.InstallationsServiceOutput, error) {
+	fake.getInstallationMutex.Lock()
+	ret, specificReturn := fake.getInstallationReturnsOnCall[len(fake.getInstallationArgsForCall)]
+	fake.getInstallationArgsForCall = append(fake.getInstallationArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("GetInstallation", []interface{}{arg1})
+	fake.getInstallationMutex.Unlock()
+	if fake.GetInstallationStub != nil {
+		return fake.GetInstallationStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInstallationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationCallCount() int {
+	fake.getInstallationMutex.RLock()
+	defer fake.getInstallationMutex.RUnlock()
+	return len(fake.getInstallationArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationCalls(stub func(int) (api.InstallationsServiceOutput, error)) {
+	fake.getInstallationMutex.Lock()
+	defer fake.getInstallationMutex.Unlock()
+	fake.GetInstallationStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationArgsForCall(i int) int {
+	fake.getInstallationMutex.RLock()
+	defer fake.getInstallationMutex.RUnlock()
+	argsForCall := fake.getInstallationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationReturns(result1 api.InstallationsServiceOutput, result2 error) {
+	fake.getInstallationMutex.Lock()
+	defer fake.getInstallationMutex.Unlock()
+	fake.GetInstallationStub = nil
+	fake.getInstallationReturns = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationReturnsOnCall(i int, result1 api.InstallationsServiceOutput, result2 error) {
+	fake.getInstallationMutex.Lock()
+	defer fake.getInstallationMutex.Unlock()
+	fake.GetInstallationStub = nil
+	if fake.getInstallationReturnsOnCall == nil {
+		fake.getInstallationReturnsOnCall = make(map[int]struct {
+			result1 api.InstallationsServiceOutput
+			result2 error
+		})
+	}
+	fake.getInstallationReturnsOnCall[i] = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationLogs(arg1 int) (api.InstallationsServiceOutput, error) {
+	fake.getInstallationLogsMutex.Lock()
+	ret, specificReturn := fake.getInstallationLogsReturnsOnCall[len(fake.getInstallationLogsArgsForCall)]
+	fake.getInstallationLogsArgsForCall = append(fake.getInstallationLogsArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("GetInstallationLogs", []interface{}{arg1})
+	fake.getInstallationLogsMutex.Unlock()
+	if fake.GetInstallationLogsStub != nil {
+		return fake.GetInstallationLogsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getInstallationLogsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationLogsCallCount() int {
+	fake.getInstallationLogsMutex.RLock()
+	defer fake.getInstallationLogsMutex.RUnlock()
+	return len(fake.getInstallationLogsArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationLogsCalls(stub func(int) (api.InstallationsServiceOutput, error)) {
+	fake.getInstallationLogsMutex.Lock()
+	defer fake.getInstallationLogsMutex.Unlock()
+	fake.GetInstallationLogsStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationLogsArgsForCall(i int) int {
+	fake.getInstallationLogsMutex.RLock()
+	defer fake.getInstallationLogsMutex.RUnlock()
+	argsForCall := fake.getInstallationLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationLogsReturns(result1 api.InstallationsServiceOutput, result2 error) {
+	fake.getInstallationLogsMutex.Lock()
+	defer fake.getInstallationLogsMutex.Unlock()
+	fake.GetInstallationLogsStub = nil
+	fake.getInstallationLogsReturns = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetInstallationLogsReturnsOnCall(i int, result1 api.InstallationsServiceOutput, result2 error) {
+	fake.getInstallationLogsMutex.Lock()
+	defer fake.getInstallationLogsMutex.Unlock()
+	fake.GetInstallationLogsStub = nil
+	if fake.getInstallationLogsReturnsOnCall == nil {
+		fake.getInstallationLogsReturnsOnCall = make(map[int]struct {
+			result1 api.InstallationsServiceOutput
+			result2 error
+		})
+	}
+	fake.getInstallationLogsReturnsOnCall[i] = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedDirectorManifest() (string, error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	ret, specificReturn := fake.getStagedDirectorManifestReturnsOnCall[len(fake.getStagedDirectorManifestArgsForCall)]
+	fake.getStagedDirectorManifestArgsForCall = append(fake.getStagedDirectorManifestArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetStagedDirectorManifest", []interface{}{})
+	fake.getStagedDirectorManifestMutex.Unlock()
+	if fake.GetStagedDirectorManifestStub != nil {
+		return fake.GetStagedDirectorManifestStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedDirectorManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedDirectorManifestCallCount() int {
+	fake.getStagedDirectorManifestMutex.RLock()
+	defer fake.getStagedDirectorManifestMutex.RUnlock()
+	return len(fake.getStagedDirectorManifestArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedDirectorManifestCalls(stub func() (string, error)) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedDirectorManifestReturns(result1 string, result2 error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = nil
+	fake.getStagedDirectorManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedDirectorManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStagedDirectorManifestMutex.Lock()
+	defer fake.getStagedDirectorManifestMutex.Unlock()
+	fake.GetStagedDirectorManifestStub = nil
+	if fake.getStagedDirectorManifestReturnsOnCall == nil {
+		fake.getStagedDirectorManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStagedDirectorManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductDependencies(arg1 string) ([]api.ProductDependency, error) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	ret, specificReturn := fake.getStagedProductDependenciesReturnsOnCall[len(fake.getStagedProductDependenciesArgsForCall)]
+	fake.getStagedProductDependenciesArgsForCall = append(fake.getStagedProductDependenciesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStagedProductDependencies", []interface{}{arg1})
+	fake.getStagedProductDependenciesMutex.Unlock()
+	if fake.GetStagedProductDependenciesStub != nil {
+		return fake.GetStagedProductDependenciesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedProductDependenciesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductDependenciesCallCount() int {
+	fake.getStagedProductDependenciesMutex.RLock()
+	defer fake.getStagedProductDependenciesMutex.RUnlock()
+	return len(fake.getStagedProductDependenciesArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductDependenciesCalls(stub func(string) ([]api.ProductDependency, error)) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	defer fake.getStagedProductDependenciesMutex.Unlock()
+	fake.GetStagedProductDependenciesStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductDependenciesArgsForCall(i int) string {
+	fake.getStagedProductDependenciesMutex.RLock()
+	defer fake.getStagedProductDependenciesMutex.RUnlock()
+	argsForCall := fake.getStagedProductDependenciesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductDependenciesReturns(result1 []api.ProductDependency, result2 error) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	defer fake.getStagedProductDependenciesMutex.Unlock()
+	fake.GetStagedProductDependenciesStub = nil
+	fake.getStagedProductDependenciesReturns = struct {
+		result1 []api.ProductDependency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductDependenciesReturnsOnCall(i int, result1 []api.ProductDependency, result2 error) {
+	fake.getStagedProductDependenciesMutex.Lock()
+	defer fake.getStagedProductDependenciesMutex.Unlock()
+	fake.GetStagedProductDependenciesStub = nil
+	if fake.getStagedProductDependenciesReturnsOnCall == nil {
+		fake.getStagedProductDependenciesReturnsOnCall = make(map[int]struct {
+			result1 []api.ProductDependency
+			result2 error
+		})
+	}
+	fake.getStagedProductDependenciesReturnsOnCall[i] = struct {
+		result1 []api.ProductDependency
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductManifest(arg1 string) (string, error) {
+	fake.getStagedProductManifestMutex.Lock()
+	ret, specificReturn := fake.getStagedProductManifestReturnsOnCall[len(fake.getStagedProductManifestArgsForCall)]
+	fake.getStagedProductManifestArgsForCall = append(fake.getStagedProductManifestArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStagedProductManifest", []interface{}{arg1})
+	fake.getStagedProductManifestMutex.Unlock()
+	if fake.GetStagedProductManifestStub != nil {
+		return fake.GetStagedProductManifestStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStagedProductManifestReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductManifestCallCount() int {
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	return len(fake.getStagedProductManifestArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductManifestCalls(stub func(string) (string, error)) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductManifestArgsForCall(i int) string {
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	argsForCall := fake.getStagedProductManifestArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductManifestReturns(result1 string, result2 error) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = nil
+	fake.getStagedProductManifestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) GetStagedProductManifestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getStagedProductManifestMutex.Lock()
+	defer fake.getStagedProductManifestMutex.Unlock()
+	fake.GetStagedProductManifestStub = nil
+	if fake.getStagedProductManifestReturnsOnCall == nil {
+		fake.getStagedProductManifestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getStagedProductManifestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) Info() (api.Info, error) {
+	fake.infoMutex.Lock()
+	ret, specificReturn := fake.infoReturnsOnCall[len(fake.infoArgsForCall)]
+	fake.infoArgsForCall = append(fake.infoArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Info", []interface{}{})
+	fake.infoMutex.Unlock()
+	if fake.InfoStub != nil {
+		return fake.InfoStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.infoReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) InfoCallCount() int {
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	return len(fake.infoArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) InfoCalls(stub func() (api.Info, error)) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) InfoReturns(result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	fake.infoReturns = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) InfoReturnsOnCall(i int, result1 api.Info, result2 error) {
+	fake.infoMutex.Lock()
+	defer fake.infoMutex.Unlock()
+	fake.InfoStub = nil
+	if fake.infoReturnsOnCall == nil {
+		fake.infoReturnsOnCall = make(map[int]struct {
+			result1 api.Info
+			result2 error
+		})
+	}
+	fake.infoReturnsOnCall[i] = struct {
+		result1 api.Info
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListCertificateAuthorities() (api.CertificateAuthoritiesOutput, error) {
+	fake.listCertificateAuthoritiesMutex.Lock()
+	ret, specificReturn := fake.listCertificateAuthoritiesReturnsOnCall[len(fake.listCertificateAuthoritiesArgsForCall)]
+	fake.listCertificateAuthoritiesArgsForCall = append(fake.listCertificateAuthoritiesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListCertificateAuthorities", []interface{}{})
+	fake.listCertificateAuthoritiesMutex.Unlock()
+	if fake.ListCertificateAuthoritiesStub != nil {
+		return fake.ListCertificateAuthoritiesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listCertificateAuthoritiesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) ListCertificateAuthoritiesCallCount() int {
+	fake.listCertificateAuthoritiesMutex.RLock()
+	defer fake.listCertificateAuthoritiesMutex.RUnlock()
+	return len(fake.listCertificateAuthoritiesArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) ListCertificateAuthoritiesCalls(stub func() (api.CertificateAuthoritiesOutput, error)) {
+	fake.listCertificateAuthoritiesMutex.Lock()
+	defer fake.listCertificateAuthoritiesMutex.Unlock()
+	fake.ListCertificateAuthoritiesStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) ListCertificateAuthoritiesReturns(result1 api.CertificateAuthoritiesOutput, result2 error) {
+	fake.listCertificateAuthoritiesMutex.Lock()
+	defer fake.listCertificateAuthoritiesMutex.Unlock()
+	fake.ListCertificateAuthoritiesStub = nil
+	fake.listCertificateAuthoritiesReturns = struct {
+		result1 api.CertificateAuthoritiesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListCertificateAuthoritiesReturnsOnCall(i int, result1 api.CertificateAuthoritiesOutput, result2 error) {
+	fake.listCertificateAuthoritiesMutex.Lock()
+	defer fake.listCertificateAuthoritiesMutex.Unlock()
+	fake.ListCertificateAuthoritiesStub = nil
+	if fake.listCertificateAuthoritiesReturnsOnCall == nil {
+		fake.listCertificateAuthoritiesReturnsOnCall = make(map[int]struct {
+			result1 api.CertificateAuthoritiesOutput
+			result2 error
+		})
+	}
+	fake.listCertificateAuthoritiesReturnsOnCall[i] = struct {
+		result1 api.CertificateAuthoritiesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListDeployedProducts() ([]api.DeployedProductOutput, error) {
+	fake.listDeployedProductsMutex.Lock()
+	ret, specificReturn := fake.listDeployedProductsReturnsOnCall[len(fake.listDeployedProductsArgsForCall)]
+	fake.listDeployedProductsArgsForCall = append(fake.listDeployedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDeployedProducts", []interface{}{})
+	fake.listDeployedProductsMutex.Unlock()
+	if fake.ListDeployedProductsStub != nil {
+		return fake.ListDeployedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDeployedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) ListDeployedProductsCallCount() int {
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
+	return len(fake.listDeployedProductsArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) ListDeployedProductsCalls(stub func() ([]api.DeployedProductOutput, error)) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) ListDeployedProductsReturns(result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	fake.listDeployedProductsReturns = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListDeployedProductsReturnsOnCall(i int, result1 []api.DeployedProductOutput, result2 error) {
+	fake.listDeployedProductsMutex.Lock()
+	defer fake.listDeployedProductsMutex.Unlock()
+	fake.ListDeployedProductsStub = nil
+	if fake.listDeployedProductsReturnsOnCall == nil {
+		fake.listDeployedProductsReturnsOnCall = make(map[int]struct {
+			result1 []api.DeployedProductOutput
+			result2 error
+		})
+	}
+	fake.listDeployedProductsReturnsOnCall[i] = struct {
+		result1 []api.DeployedProductOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListInstallations() ([]api.InstallationsServiceOutput, error) {
+	fake.listInstallationsMutex.Lock()
+	ret, specificReturn := fake.listInstallationsReturnsOnCall[len(fake.listInstallationsArgsForCall)]
+	fake.listInstallationsArgsForCall = append(fake.listInstallationsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListInstallations", []interface{}{})
+	fake.listInstallationsMutex.Unlock()
+	if fake.ListInstallationsStub != nil {
+		return fake.ListInstallationsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listInstallationsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) ListInstallationsCallCount() int {
+	fake.listInstallationsMutex.RLock()
+	defer fake.listInstallationsMutex.RUnlock()
+	return len(fake.listInstallationsArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) ListInstallationsCalls(stub func() ([]api.InstallationsServiceOutput, error)) {
+	fake.listInstallationsMutex.Lock()
+	defer fake.listInstallationsMutex.Unlock()
+	fake.ListInstallationsStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) ListInstallationsReturns(result1 []api.InstallationsServiceOutput, result2 error) {
+	fake.listInstallationsMutex.Lock()
+	defer fake.listInstallationsMutex.Unlock()
+	fake.ListInstallationsStub = nil
+	fake.listInstallationsReturns = struct {
+		result1 []api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListInstallationsReturnsOnCall(i int, result1 []api.InstallationsServiceOutput, result2 error) {
+	fake.listInstallationsMutex.Lock()
+	defer fake.listInstallationsMutex.Unlock()
+	fake.ListInstallationsStub = nil
+	if fake.listInstallationsReturnsOnCall == nil {
+		fake.listInstallationsReturnsOnCall = make(map[int]struct {
+			result1 []api.InstallationsServiceOutput
+			result2 error
+		})
+	}
+	fake.listInstallationsReturnsOnCall[i] = struct {
+		result1 []api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedPendingChanges() (api.PendingChangesOutput, error) {
+	fake.listStagedPendingChangesMutex.Lock()
+	ret, specificReturn := fake.listStagedPendingChangesReturnsOnCall[len(fake.listStagedPendingChangesArgsForCall)]
+	fake.listStagedPendingChangesArgsForCall = append(fake.listStagedPendingChangesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStagedPendingChanges", []interface{}{})
+	fake.listStagedPendingChangesMutex.Unlock()
+	if fake.ListStagedPendingChangesStub != nil {
+		return fake.ListStagedPendingChangesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedPendingChangesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedPendingChangesCallCount() int {
+	fake.listStagedPendingChangesMutex.RLock()
+	defer fake.listStagedPendingChangesMutex.RUnlock()
+	return len(fake.listStagedPendingChangesArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedPendingChangesCalls(stub func() (api.PendingChangesOutput, error)) {
+	fake.listStagedPendingChangesMutex.Lock()
+	defer fake.listStagedPendingChangesMutex.Unlock()
+	fake.ListStagedPendingChangesStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedPendingChangesReturns(result1 api.PendingChangesOutput, result2 error) {
+	fake.listStagedPendingChangesMutex.Lock()
+	defer fake.listStagedPendingChangesMutex.Unlock()
+	fake.ListStagedPendingChangesStub = nil
+	fake.listStagedPendingChangesReturns = struct {
+		result1 api.PendingChangesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedPendingChangesReturnsOnCall(i int, result1 api.PendingChangesOutput, result2 error) {
+	fake.listStagedPendingChangesMutex.Lock()
+	defer fake.listStagedPendingChangesMutex.Unlock()
+	fake.ListStagedPendingChangesStub = nil
+	if fake.listStagedPendingChangesReturnsOnCall == nil {
+		fake.listStagedPendingChangesReturnsOnCall = make(map[int]struct {
+			result1 api.PendingChangesOutput
+			result2 error
+		})
+	}
+	fake.listStagedPendingChangesReturnsOnCall[i] = struct {
+		result1 api.PendingChangesOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedProducts() (api.StagedProductsOutput, error) {
+	fake.listStagedProductsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductsReturnsOnCall[len(fake.listStagedProductsArgsForCall)]
+	fake.listStagedProductsArgsForCall = append(fake.listStagedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStagedProducts", []interface{}{})
+	fake.listStagedProductsMutex.Unlock()
+	if fake.ListStagedProductsStub != nil {
+		return fake.ListStagedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedProductsCallCount() int {
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	return len(fake.listStagedProductsArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedProductsCalls(stub func() (api.StagedProductsOutput, error)) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedProductsReturns(result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	fake.listStagedProductsReturns = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) ListStagedProductsReturnsOnCall(i int, result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	if fake.listStagedProductsReturnsOnCall == nil {
+		fake.listStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductsReturnsOnCall[i] = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) RegenerateCertificates() error {
+	fake.regenerateCertificatesMutex.Lock()
+	ret, specificReturn := fake.regenerateCertificatesReturnsOnCall[len(fake.regenerateCertificatesArgsForCall)]
+	fake.regenerateCertificatesArgsForCall = append(fake.regenerateCertificatesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RegenerateCertificates", []interface{}{})
+	fake.regenerateCertificatesMutex.Unlock()
+	if fake.RegenerateCertificatesStub != nil {
+		return fake.RegenerateCertificatesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.regenerateCertificatesReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateCertificateAuthorityService) RegenerateCertificatesCallCount() int {
+	fake.regenerateCertificatesMutex.RLock()
+	defer fake.regenerateCertificatesMutex.RUnlock()
+	return len(fake.regenerateCertificatesArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) RegenerateCertificatesCalls(stub func() error) {
+	fake.regenerateCertificatesMutex.Lock()
+	defer fake.regenerateCertificatesMutex.Unlock()
+	fake.RegenerateCertificatesStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) RegenerateCertificatesReturns(result1 error) {
+	fake.regenerateCertificatesMutex.Lock()
+	defer fake.regenerateCertificatesMutex.Unlock()
+	fake.RegenerateCertificatesStub = nil
+	fake.regenerateCertificatesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) RegenerateCertificatesReturnsOnCall(i int, result1 error) {
+	fake.regenerateCertificatesMutex.Lock()
+	defer fake.regenerateCertificatesMutex.Unlock()
+	fake.RegenerateCertificatesStub = nil
+	if fake.regenerateCertificatesReturnsOnCall == nil {
+		fake.regenerateCertificatesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.regenerateCertificatesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) RunningInstallation() (api.InstallationsServiceOutput, error) {
+	fake.runningInstallationMutex.Lock()
+	ret, specificReturn := fake.runningInstallationReturnsOnCall[len(fake.runningInstallationArgsForCall)]
+	fake.runningInstallationArgsForCall = append(fake.runningInstallationArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RunningInstallation", []interface{}{})
+	fake.runningInstallationMutex.Unlock()
+	if fake.RunningInstallationStub != nil {
+		return fake.RunningInstallationStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.runningInstallationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *RotateCertificateAuthorityService) RunningInstallationCallCount() int {
+	fake.runningInstallationMutex.RLock()
+	defer fake.runningInstallationMutex.RUnlock()
+	return len(fake.runningInstallationArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) RunningInstallationCalls(stub func() (api.InstallationsServiceOutput, error)) {
+	fake.runningInstallationMutex.Lock()
+	defer fake.runningInstallationMutex.Unlock()
+	fake.RunningInstallationStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) RunningInstallationReturns(result1 api.InstallationsServiceOutput, result2 error) {
+	fake.runningInstallationMutex.Lock()
+	defer fake.runningInstallationMutex.Unlock()
+	fake.RunningInstallationStub = nil
+	fake.runningInstallationReturns = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) RunningInstallationReturnsOnCall(i int, result1 api.InstallationsServiceOutput, result2 error) {
+	fake.runningInstallationMutex.Lock()
+	defer fake.runningInstallationMutex.Unlock()
+	fake.RunningInstallationStub = nil
+	if fake.runningInstallationReturnsOnCall == nil {
+		fake.runningInstallationReturnsOnCall = make(map[int]struct {
+			result1 api.InstallationsServiceOutput
+			result2 error
+		})
+	}
+	fake.runningInstallationReturnsOnCall[i] = struct {
+		result1 api.InstallationsServiceOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *RotateCertificateAuthorityService) UpdateStagedDirectorProperties(arg1 api.DirectorProperties) error {
+	fake.updateStagedDirectorPropertiesMutex.Lock()
+	ret, specificReturn := fake.updateStagedDirectorPropertiesReturnsOnCall[len(fake.updateStagedDirectorPropertiesArgsForCall)]
+	fake.updateStagedDirectorPropertiesArgsForCall = append(fake.updateStagedDirectorPropertiesArgsForCall, struct {
+		arg1 api.DirectorProperties
+	}{arg1})
+	fake.recordInvocation("UpdateStagedDirectorProperties", []interface{}{arg1})
+	fake.updateStagedDirectorPropertiesMutex.Unlock()
+	if fake.UpdateStagedDirectorPropertiesStub != nil {
+		return fake.UpdateStagedDirectorPropertiesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateStagedDirectorPropertiesReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateCertificateAuthorityService) UpdateStagedDirectorPropertiesCallCount() int {
+	fake.updateStagedDirectorPropertiesMutex.RLock()
+	defer fake.updateStagedDirectorPropertiesMutex.RUnlock()
+	return len(fake.updateStagedDirectorPropertiesArgsForCall)
+}
+
+func (fake *RotateCertificateAuthorityService) UpdateStagedDirectorPropertiesCalls(stub func(api.DirectorProperties) error) {
+	fake.updateStagedDirectorPropertiesMutex.Lock()
+	defer fake.updateStagedDirectorPropertiesMutex.Unlock()
+	fake.UpdateStagedDirectorPropertiesStub = stub
+}
+
+func (fake *RotateCertificateAuthorityService) UpdateStagedDirectorPropertiesArgsForCall(i int) api.DirectorProperties {
+	fake.updateStagedDirectorPropertiesMutex.RLock()
+	defer fake.updateStagedDirectorPropertiesMutex.RUnlock()
+	argsForCall := fake.updateStagedDirectorPropertiesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *RotateCertificateAuthorityService) UpdateStagedDirectorPropertiesReturns(result1 error) {
+	fake.updateStagedDirectorPropertiesMutex.Lock()
+	defer fake.updateStagedDirectorPropertiesMutex.Unlock()
+	fake.UpdateStagedDirectorPropertiesStub = nil
+	fake.updateStagedDirectorPropertiesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) UpdateStagedDirectorPropertiesReturnsOnCall(i int, result1 error) {
+	fake.updateStagedDirectorPropertiesMutex.Lock()
+	defer fake.updateStagedDirectorPropertiesMutex.Unlock()
+	fake.UpdateStagedDirectorPropertiesStub = nil
+	if fake.updateStagedDirectorPropertiesReturnsOnCall == nil {
+		fake.updateStagedDirectorPropertiesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateStagedDirectorPropertiesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateCertificateAuthorityService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.abortInstallationMutex.RLock()
+	defer fake.abortInstallationMutex.RUnlock()
+	fake.activateCertificateAuthorityMutex.RLock()
+	defer fake.activateCertificateAuthorityMutex.RUnlock()
+	fake.createCertificateAuthorityMutex.RLock()
+	defer fake.createCertificateAuthorityMutex.RUnlock()
+	fake.createInstallationMutex.RLock()
+	defer fake.createInstallationMutex.RUnlock()
+	fake.deleteCertificateAuthorityMutex.RLock()
+	defer fake.deleteCertificateAuthorityMutex.RUnlock()
+	fake.generateCertificateAuthorityMutex.RLock()
+	defer fake.generateCertificateAuthorityMutex.RUnlock()
+	fake.getDeployedDirectorManifestMutex.RLock()
+	defer fake.getDeployedDirectorManifestMutex.RUnlock()
+	fake.getDeployedProductManifestMutex.RLock()
+	defer fake.getDeployedProductManifestMutex.RUnlock()
+	fake.getInstallationMutex.RLock()
+	defer fake.getInstallationMutex.RUnlock()
+	fake.getInstallationLogsMutex.RLock()
+	defer fake.getInstallationLogsMutex.RUnlock()
+	fake.getStagedDirectorManifestMutex.RLock()
+	defer fake.getStagedDirectorManifestMutex.RUnlock()
+	fake.getStagedProductDependenciesMutex.RLock()
+	defer fake.getStagedProductDependenciesMutex.RUnlock()
+	fake.getStagedProductManifestMutex.RLock()
+	defer fake.getStagedProductManifestMutex.RUnlock()
+	fake.infoMutex.RLock()
+	defer fake.infoMutex.RUnlock()
+	fake.listCertificateAuthoritiesMutex.RLock()
+	defer fake.listCertificateAuthoritiesMutex.RUnlock()
+	fake.listDeployedProductsMutex.RLock()
+	defer fake.listDeployedProductsMutex.RUnlock()
+	fake.listInstallationsMutex.RLock()
+	defer fake.listInstallationsMutex.RUnlock()
+	fake.listStagedPendingChangesMutex.RLock()
+	defer fake.listStagedPendingChangesMutex.RUnlock()
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	fake.regenerateCertificatesMutex.RLock()
+	defer fake.regenerateCertificatesMutex.RUnlock()
+	fake.runningInstallationMutex.RLock()
+	defer fake.runningInstallationMutex.RUnlock()
+	fake.updateStagedDirectorPropertiesMutex.RLock()
+	defer fake.updateStagedDirectorPropertiesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RotateCertificateAuthorityService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+)
+
+type RotateUAAClientSecretService struct {
+	ChangeUAAClientSecretStub        func(string, string, string) error
+	changeUAAClientSecretMutex       sync.RWMutex
+	changeUAAClientSecretArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	changeUAAClientSecretReturns struct {
+		result1 error
+	}
+	changeUAAClientSecretReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *RotateUAAClientSecretService) ChangeUAAClientSecret(arg1 string, arg2 string, arg3 string) error {
+	fake.changeUAAClientSecretMutex.Lock()
+	ret, specificReturn := fake.changeUAAClientSecretReturnsOnCall[len(fake.changeUAAClientSecretArgsForCall)]
+	fake.changeUAAClientSecretArgsForCall = append(fake.changeUAAClientSecretArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ChangeUAAClientSecret", []interface{}{arg1, arg2, arg3})
+	fake.changeUAAClientSecretMutex.Unlock()
+	if fake.ChangeUAAClientSecretStub != nil {
+		return fake.ChangeUAAClientSecretStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.changeUAAClientSecretReturns
+	return fakeReturns.result1
+}
+
+func (fake *RotateUAAClientSecretService) ChangeUAAClientSecretCallCount() int {
+	fake.changeUAAClientSecretMutex.RLock()
+	defer fake.changeUAAClientSecretMutex.RUnlock()
+	return len(fake.changeUAAClientSecretArgsForCall)
+}
+
+func (fake *RotateUAAClientSecretService) ChangeUAAClientSecretCalls(stub func(string, string, string) error) {
+	fake.changeUAAClientSecretMutex.Lock()
+	defer fake.changeUAAClientSecretMutex.Unlock()
+	fake.ChangeUAAClientSecretStub = stub
+}
+
+func (fake *RotateUAAClientSecretService) ChangeUAAClientSecretArgsForCall(i int) (string, string, string) {
+	fake.changeUAAClientSecretMutex.RLock()
+	defer fake.changeUAAClientSecretMutex.RUnlock()
+	argsForCall := fake.changeUAAClientSecretArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *RotateUAAClientSecretService) ChangeUAAClientSecretReturns(result1 error) {
+	fake.changeUAAClientSecretMutex.Lock()
+	defer fake.changeUAAClientSecretMutex.Unlock()
+	fake.ChangeUAAClientSecretStub = nil
+	fake.changeUAAClientSecretReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateUAAClientSecretService) ChangeUAAClientSecretReturnsOnCall(i int, result1 error) {
+	fake.changeUAAClientSecretMutex.Lock()
+	defer fake.changeUAAClientSecretMutex.Unlock()
+	fake.ChangeUAAClientSecretStub = nil
+	if fake.changeUAAClientSecretReturnsOnCall == nil {
+		fake.changeUAAClientSecretReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.changeUAAClientSecretReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *RotateUAAClientSecretService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.changeUAAClientSecretMutex.RLock()
+	defer fake.changeUAAClientSecretMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *RotateUAAClientSecretService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
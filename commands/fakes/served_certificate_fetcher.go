@@ -0,0 +1,103 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"crypto/x509"
+	"sync"
+)
+
+type ServedCertificateFetcher struct {
+	ServedCertificateStub        func() (*x509.Certificate, error)
+	servedCertificateMutex       sync.RWMutex
+	servedCertificateArgsForCall []struct {
+	}
+	servedCertificateReturns struct {
+		result1 *x509.Certificate
+		result2 error
+	}
+	servedCertificateReturnsOnCall map[int]struct {
+		result1 *x509.Certificate
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ServedCertificateFetcher) ServedCertificate() (*x509.Certificate, error) {
+	fake.servedCertificateMutex.Lock()
+	ret, specificReturn := fake.servedCertificateReturnsOnCall[len(fake.servedCertificateArgsForCall)]
+	fake.servedCertificateArgsForCall = append(fake.servedCertificateArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ServedCertificate", []interface{}{})
+	fake.servedCertificateMutex.Unlock()
+	if fake.ServedCertificateStub != nil {
+		return fake.ServedCertificateStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.servedCertificateReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ServedCertificateFetcher) ServedCertificateCallCount() int {
+	fake.servedCertificateMutex.RLock()
+	defer fake.servedCertificateMutex.RUnlock()
+	return len(fake.servedCertificateArgsForCall)
+}
+
+func (fake *ServedCertificateFetcher) ServedCertificateCalls(stub func() (*x509.Certificate, error)) {
+	fake.servedCertificateMutex.Lock()
+	defer fake.servedCertificateMutex.Unlock()
+	fake.ServedCertificateStub = stub
+}
+
+func (fake *ServedCertificateFetcher) ServedCertificateReturns(result1 *x509.Certificate, result2 error) {
+	fake.servedCertificateMutex.Lock()
+	defer fake.servedCertificateMutex.Unlock()
+	fake.ServedCertificateStub = nil
+	fake.servedCertificateReturns = struct {
+		result1 *x509.Certificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ServedCertificateFetcher) ServedCertificateReturnsOnCall(i int, result1 *x509.Certificate, result2 error) {
+	fake.servedCertificateMutex.Lock()
+	defer fake.servedCertificateMutex.Unlock()
+	fake.ServedCertificateStub = nil
+	if fake.servedCertificateReturnsOnCall == nil {
+		fake.servedCertificateReturnsOnCall = make(map[int]struct {
+			result1 *x509.Certificate
+			result2 error
+		})
+	}
+	fake.servedCertificateReturnsOnCall[i] = struct {
+		result1 *x509.Certificate
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ServedCertificateFetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.servedCertificateMutex.RLock()
+	defer fake.servedCertificateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ServedCertificateFetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
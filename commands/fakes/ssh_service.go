@@ -0,0 +1,173 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type SSHService struct {
+	GetBoshEnvironmentStub        func() (api.GetBoshEnvironmentOutput, error)
+	getBoshEnvironmentMutex       sync.RWMutex
+	getBoshEnvironmentArgsForCall []struct {
+	}
+	getBoshEnvironmentReturns struct {
+		result1 api.GetBoshEnvironmentOutput
+		result2 error
+	}
+	getBoshEnvironmentReturnsOnCall map[int]struct {
+		result1 api.GetBoshEnvironmentOutput
+		result2 error
+	}
+	GetDirectorSSHCredentialsStub        func() (api.DirectorSSHCredentials, error)
+	getDirectorSSHCredentialsMutex       sync.RWMutex
+	getDirectorSSHCredentialsArgsForCall []struct {
+	}
+	getDirectorSSHCredentialsReturns struct {
+		result1 api.DirectorSSHCredentials
+		result2 error
+	}
+	getDirectorSSHCredentialsReturnsOnCall map[int]struct {
+		result1 api.DirectorSSHCredentials
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SSHService) GetBoshEnvironment() (api.GetBoshEnvironmentOutput, error) {
+	fake.getBoshEnvironmentMutex.Lock()
+	ret, specificReturn := fake.getBoshEnvironmentReturnsOnCall[len(fake.getBoshEnvironmentArgsForCall)]
+	fake.getBoshEnvironmentArgsForCall = append(fake.getBoshEnvironmentArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetBoshEnvironment", []interface{}{})
+	fake.getBoshEnvironmentMutex.Unlock()
+	if fake.GetBoshEnvironmentStub != nil {
+		return fake.GetBoshEnvironmentStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getBoshEnvironmentReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSHService) GetBoshEnvironmentCallCount() int {
+	fake.getBoshEnvironmentMutex.RLock()
+	defer fake.getBoshEnvironmentMutex.RUnlock()
+	return len(fake.getBoshEnvironmentArgsForCall)
+}
+
+func (fake *SSHService) GetBoshEnvironmentCalls(stub func() (api.GetBoshEnvironmentOutput, error)) {
+	fake.getBoshEnvironmentMutex.Lock()
+	defer fake.getBoshEnvironmentMutex.Unlock()
+	fake.GetBoshEnvironmentStub = stub
+}
+
+func (fake *SSHService) GetBoshEnvironmentReturns(result1 api.GetBoshEnvironmentOutput, result2 error) {
+	fake.getBoshEnvironmentMutex.Lock()
+	defer fake.getBoshEnvironmentMutex.Unlock()
+	fake.GetBoshEnvironmentStub = nil
+	fake.getBoshEnvironmentReturns = struct {
+		result1 api.GetBoshEnvironmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHService) GetBoshEnvironmentReturnsOnCall(i int, result1 api.GetBoshEnvironmentOutput, result2 error) {
+	fake.getBoshEnvironmentMutex.Lock()
+	defer fake.getBoshEnvironmentMutex.Unlock()
+	fake.GetBoshEnvironmentStub = nil
+	if fake.getBoshEnvironmentReturnsOnCall == nil {
+		fake.getBoshEnvironmentReturnsOnCall = make(map[int]struct {
+			result1 api.GetBoshEnvironmentOutput
+			result2 error
+		})
+	}
+	fake.getBoshEnvironmentReturnsOnCall[i] = struct {
+		result1 api.GetBoshEnvironmentOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHService) GetDirectorSSHCredentials() (api.DirectorSSHCredentials, error) {
+	fake.getDirectorSSHCredentialsMutex.Lock()
+	ret, specificReturn := fake.getDirectorSSHCredentialsReturnsOnCall[len(fake.getDirectorSSHCredentialsArgsForCall)]
+	fake.getDirectorSSHCredentialsArgsForCall = append(fake.getDirectorSSHCredentialsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetDirectorSSHCredentials", []interface{}{})
+	fake.getDirectorSSHCredentialsMutex.Unlock()
+	if fake.GetDirectorSSHCredentialsStub != nil {
+		return fake.GetDirectorSSHCredentialsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getDirectorSSHCredentialsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSHService) GetDirectorSSHCredentialsCallCount() int {
+	fake.getDirectorSSHCredentialsMutex.RLock()
+	defer fake.getDirectorSSHCredentialsMutex.RUnlock()
+	return len(fake.getDirectorSSHCredentialsArgsForCall)
+}
+
+func (fake *SSHService) GetDirectorSSHCredentialsCalls(stub func() (api.DirectorSSHCredentials, error)) {
+	fake.getDirectorSSHCredentialsMutex.Lock()
+	defer fake.getDirectorSSHCredentialsMutex.Unlock()
+	fake.GetDirectorSSHCredentialsStub = stub
+}
+
+func (fake *SSHService) GetDirectorSSHCredentialsReturns(result1 api.DirectorSSHCredentials, result2 error) {
+	fake.getDirectorSSHCredentialsMutex.Lock()
+	defer fake.getDirectorSSHCredentialsMutex.Unlock()
+	fake.GetDirectorSSHCredentialsStub = nil
+	fake.getDirectorSSHCredentialsReturns = struct {
+		result1 api.DirectorSSHCredentials
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHService) GetDirectorSSHCredentialsReturnsOnCall(i int, result1 api.DirectorSSHCredentials, result2 error) {
+	fake.getDirectorSSHCredentialsMutex.Lock()
+	defer fake.getDirectorSSHCredentialsMutex.Unlock()
+	fake.GetDirectorSSHCredentialsStub = nil
+	if fake.getDirectorSSHCredentialsReturnsOnCall == nil {
+		fake.getDirectorSSHCredentialsReturnsOnCall = make(map[int]struct {
+			result1 api.DirectorSSHCredentials
+			result2 error
+		})
+	}
+	fake.getDirectorSSHCredentialsReturnsOnCall[i] = struct {
+		result1 api.DirectorSSHCredentials
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getBoshEnvironmentMutex.RLock()
+	defer fake.getBoshEnvironmentMutex.RUnlock()
+	fake.getDirectorSSHCredentialsMutex.RLock()
+	defer fake.getDirectorSSHCredentialsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SSHService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,186 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type UnlockService struct {
+	EnsureAvailabilityStub        func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
+	ensureAvailabilityMutex       sync.RWMutex
+	ensureAvailabilityArgsForCall []struct {
+		arg1 api.EnsureAvailabilityInput
+	}
+	ensureAvailabilityReturns struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}
+	ensureAvailabilityReturnsOnCall map[int]struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}
+	UnlockStub        func(string) error
+	unlockMutex       sync.RWMutex
+	unlockArgsForCall []struct {
+		arg1 string
+	}
+	unlockReturns struct {
+		result1 error
+	}
+	unlockReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *UnlockService) EnsureAvailability(arg1 api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+	fake.ensureAvailabilityMutex.Lock()
+	ret, specificReturn := fake.ensureAvailabilityReturnsOnCall[len(fake.ensureAvailabilityArgsForCall)]
+	fake.ensureAvailabilityArgsForCall = append(fake.ensureAvailabilityArgsForCall, struct {
+		arg1 api.EnsureAvailabilityInput
+	}{arg1})
+	fake.recordInvocation("EnsureAvailability", []interface{}{arg1})
+	fake.ensureAvailabilityMutex.Unlock()
+	if fake.EnsureAvailabilityStub != nil {
+		return fake.EnsureAvailabilityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.ensureAvailabilityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *UnlockService) EnsureAvailabilityCallCount() int {
+	fake.ensureAvailabilityMutex.RLock()
+	defer fake.ensureAvailabilityMutex.RUnlock()
+	return len(fake.ensureAvailabilityArgsForCall)
+}
+
+func (fake *UnlockService) EnsureAvailabilityCalls(stub func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)) {
+	fake.ensureAvailabilityMutex.Lock()
+	defer fake.ensureAvailabilityMutex.Unlock()
+	fake.EnsureAvailabilityStub = stub
+}
+
+func (fake *UnlockService) EnsureAvailabilityArgsForCall(i int) api.EnsureAvailabilityInput {
+	fake.ensureAvailabilityMutex.RLock()
+	defer fake.ensureAvailabilityMutex.RUnlock()
+	argsForCall := fake.ensureAvailabilityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *UnlockService) EnsureAvailabilityReturns(result1 api.EnsureAvailabilityOutput, result2 error) {
+	fake.ensureAvailabilityMutex.Lock()
+	defer fake.ensureAvailabilityMutex.Unlock()
+	fake.EnsureAvailabilityStub = nil
+	fake.ensureAvailabilityReturns = struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *UnlockService) EnsureAvailabilityReturnsOnCall(i int, result1 api.EnsureAvailabilityOutput, result2 error) {
+	fake.ensureAvailabilityMutex.Lock()
+	defer fake.ensureAvailabilityMutex.Unlock()
+	fake.EnsureAvailabilityStub = nil
+	if fake.ensureAvailabilityReturnsOnCall == nil {
+		fake.ensureAvailabilityReturnsOnCall = make(map[int]struct {
+			result1 api.EnsureAvailabilityOutput
+			result2 error
+		})
+	}
+	fake.ensureAvailabilityReturnsOnCall[i] = struct {
+		result1 api.EnsureAvailabilityOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *UnlockService) Unlock(arg1 string) error {
+	fake.unlockMutex.Lock()
+	ret, specificReturn := fake.unlockReturnsOnCall[len(fake.unlockArgsForCall)]
+	fake.unlockArgsForCall = append(fake.unlockArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Unlock", []interface{}{arg1})
+	fake.unlockMutex.Unlock()
+	if fake.UnlockStub != nil {
+		return fake.UnlockStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unlockReturns
+	return fakeReturns.result1
+}
+
+func (fake *UnlockService) UnlockCallCount() int {
+	fake.unlockMutex.RLock()
+	defer fake.unlockMutex.RUnlock()
+	return len(fake.unlockArgsForCall)
+}
+
+func (fake *UnlockService) UnlockCalls(stub func(string) error) {
+	fake.unlockMutex.Lock()
+	defer fake.unlockMutex.Unlock()
+	fake.UnlockStub = stub
+}
+
+func (fake *UnlockService) UnlockArgsForCall(i int) string {
+	fake.unlockMutex.RLock()
+	defer fake.unlockMutex.RUnlock()
+	argsForCall := fake.unlockArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *UnlockService) UnlockReturns(result1 error) {
+	fake.unlockMutex.Lock()
+	defer fake.unlockMutex.Unlock()
+	fake.UnlockStub = nil
+	fake.unlockReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *UnlockService) UnlockReturnsOnCall(i int, result1 error) {
+	fake.unlockMutex.Lock()
+	defer fake.unlockMutex.Unlock()
+	fake.UnlockStub = nil
+	if fake.unlockReturnsOnCall == nil {
+		fake.unlockReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unlockReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *UnlockService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.ensureAvailabilityMutex.RLock()
+	defer fake.ensureAvailabilityMutex.RUnlock()
+	fake.unlockMutex.RLock()
+	defer fake.unlockMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *UnlockService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
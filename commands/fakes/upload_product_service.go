@@ -22,6 +22,20 @@ type UploadProductService struct {
 		result1 bool
 		result2 error
 	}
+	GetAvailableProductUploadOffsetStub        func(string, int64) (int64, error)
+	getAvailableProductUploadOffsetMutex       sync.RWMutex
+	getAvailableProductUploadOffsetArgsForCall []struct {
+		arg1 string
+		arg2 int64
+	}
+	getAvailableProductUploadOffsetReturns struct {
+		result1 int64
+		result2 error
+	}
+	getAvailableProductUploadOffsetReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	UploadAvailableProductStub        func(api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error)
 	uploadAvailableProductMutex       sync.RWMutex
 	uploadAvailableProductArgsForCall []struct {
@@ -35,6 +49,19 @@ type UploadProductService struct {
 		result1 api.UploadAvailableProductOutput
 		result2 error
 	}
+	UploadAvailableProductChunkStub        func(api.UploadAvailableProductChunkInput) (api.UploadAvailableProductChunkOutput, error)
+	uploadAvailableProductChunkMutex       sync.RWMutex
+	uploadAvailableProductChunkArgsForCall []struct {
+		arg1 api.UploadAvailableProductChunkInput
+	}
+	uploadAvailableProductChunkReturns struct {
+		result1 api.UploadAvailableProductChunkOutput
+		result2 error
+	}
+	uploadAvailableProductChunkReturnsOnCall map[int]struct {
+		result1 api.UploadAvailableProductChunkOutput
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -103,6 +130,70 @@ func (fake *UploadProductService) CheckProductAvailabilityReturnsOnCall(i int, r
 	}{result1, result2}
 }
 
+func (fake *UploadProductService) GetAvailableProductUploadOffset(arg1 string, arg2 int64) (int64, error) {
+	fake.getAvailableProductUploadOffsetMutex.Lock()
+	ret, specificReturn := fake.getAvailableProductUploadOffsetReturnsOnCall[len(fake.getAvailableProductUploadOffsetArgsForCall)]
+	fake.getAvailableProductUploadOffsetArgsForCall = append(fake.getAvailableProductUploadOffsetArgsForCall, struct {
+		arg1 string
+		arg2 int64
+	}{arg1, arg2})
+	fake.recordInvocation("GetAvailableProductUploadOffset", []interface{}{arg1, arg2})
+	fake.getAvailableProductUploadOffsetMutex.Unlock()
+	if fake.GetAvailableProductUploadOffsetStub != nil {
+		return fake.GetAvailableProductUploadOffsetStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getAvailableProductUploadOffsetReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *UploadProductService) GetAvailableProductUploadOffsetCallCount() int {
+	fake.getAvailableProductUploadOffsetMutex.RLock()
+	defer fake.getAvailableProductUploadOffsetMutex.RUnlock()
+	return len(fake.getAvailableProductUploadOffsetArgsForCall)
+}
+
+func (fake *UploadProductService) GetAvailableProductUploadOffsetCalls(stub func(string, int64) (int64, error)) {
+	fake.getAvailableProductUploadOffsetMutex.Lock()
+	defer fake.getAvailableProductUploadOffsetMutex.Unlock()
+	fake.GetAvailableProductUploadOffsetStub = stub
+}
+
+func (fake *UploadProductService) GetAvailableProductUploadOffsetArgsForCall(i int) (string, int64) {
+	fake.getAvailableProductUploadOffsetMutex.RLock()
+	defer fake.getAvailableProductUploadOffsetMutex.RUnlock()
+	argsForCall := fake.getAvailableProductUploadOffsetArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *UploadProductService) GetAvailableProductUploadOffsetReturns(result1 int64, result2 error) {
+	fake.getAvailableProductUploadOffsetMutex.Lock()
+	defer fake.getAvailableProductUploadOffsetMutex.Unlock()
+	fake.GetAvailableProductUploadOffsetStub = nil
+	fake.getAvailableProductUploadOffsetReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *UploadProductService) GetAvailableProductUploadOffsetReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.getAvailableProductUploadOffsetMutex.Lock()
+	defer fake.getAvailableProductUploadOffsetMutex.Unlock()
+	fake.GetAvailableProductUploadOffsetStub = nil
+	if fake.getAvailableProductUploadOffsetReturnsOnCall == nil {
+		fake.getAvailableProductUploadOffsetReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.getAvailableProductUploadOffsetReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *UploadProductService) UploadAvailableProduct(arg1 api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error) {
 	fake.uploadAvailableProductMutex.Lock()
 	ret, specificReturn := fake.uploadAvailableProductReturnsOnCall[len(fake.uploadAvailableProductArgsForCall)]
@@ -166,13 +257,80 @@ func (fake *UploadProductService) UploadAvailableProductReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *UploadProductService) UploadAvailableProductChunk(arg1 api.UploadAvailableProductChunkInput) (api.UploadAvailableProductChunkOutput, error) {
+	fake.uploadAvailableProductChunkMutex.Lock()
+	ret, specificReturn := fake.uploadAvailableProductChunkReturnsOnCall[len(fake.uploadAvailableProductChunkArgsForCall)]
+	fake.uploadAvailableProductChunkArgsForCall = append(fake.uploadAvailableProductChunkArgsForCall, struct {
+		arg1 api.UploadAvailableProductChunkInput
+	}{arg1})
+	fake.recordInvocation("UploadAvailableProductChunk", []interface{}{arg1})
+	fake.uploadAvailableProductChunkMutex.Unlock()
+	if fake.UploadAvailableProductChunkStub != nil {
+		return fake.UploadAvailableProductChunkStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.uploadAvailableProductChunkReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *UploadProductService) UploadAvailableProductChunkCallCount() int {
+	fake.uploadAvailableProductChunkMutex.RLock()
+	defer fake.uploadAvailableProductChunkMutex.RUnlock()
+	return len(fake.uploadAvailableProductChunkArgsForCall)
+}
+
+func (fake *UploadProductService) UploadAvailableProductChunkCalls(stub func(api.UploadAvailableProductChunkInput) (api.UploadAvailableProductChunkOutput, error)) {
+	fake.uploadAvailableProductChunkMutex.Lock()
+	defer fake.uploadAvailableProductChunkMutex.Unlock()
+	fake.UploadAvailableProductChunkStub = stub
+}
+
+func (fake *UploadProductService) UploadAvailableProductChunkArgsForCall(i int) api.UploadAvailableProductChunkInput {
+	fake.uploadAvailableProductChunkMutex.RLock()
+	defer fake.uploadAvailableProductChunkMutex.RUnlock()
+	argsForCall := fake.uploadAvailableProductChunkArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *UploadProductService) UploadAvailableProductChunkReturns(result1 api.UploadAvailableProductChunkOutput, result2 error) {
+	fake.uploadAvailableProductChunkMutex.Lock()
+	defer fake.uploadAvailableProductChunkMutex.Unlock()
+	fake.UploadAvailableProductChunkStub = nil
+	fake.uploadAvailableProductChunkReturns = struct {
+		result1 api.UploadAvailableProductChunkOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *UploadProductService) UploadAvailableProductChunkReturnsOnCall(i int, result1 api.UploadAvailableProductChunkOutput, result2 error) {
+	fake.uploadAvailableProductChunkMutex.Lock()
+	defer fake.uploadAvailableProductChunkMutex.Unlock()
+	fake.UploadAvailableProductChunkStub = nil
+	if fake.uploadAvailableProductChunkReturnsOnCall == nil {
+		fake.uploadAvailableProductChunkReturnsOnCall = make(map[int]struct {
+			result1 api.UploadAvailableProductChunkOutput
+			result2 error
+		})
+	}
+	fake.uploadAvailableProductChunkReturnsOnCall[i] = struct {
+		result1 api.UploadAvailableProductChunkOutput
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *UploadProductService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.checkProductAvailabilityMutex.RLock()
 	defer fake.checkProductAvailabilityMutex.RUnlock()
+	fake.getAvailableProductUploadOffsetMutex.RLock()
+	defer fake.getAvailableProductUploadOffsetMutex.RUnlock()
 	fake.uploadAvailableProductMutex.RLock()
 	defer fake.uploadAvailableProductMutex.RUnlock()
+	fake.uploadAvailableProductChunkMutex.RLock()
+	defer fake.uploadAvailableProductChunkMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
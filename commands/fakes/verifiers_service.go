@@ -0,0 +1,256 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type VerifiersService struct {
+	ListDirectorVerifiersStub        func() ([]api.Verifier, error)
+	listDirectorVerifiersMutex       sync.RWMutex
+	listDirectorVerifiersArgsForCall []struct {
+	}
+	listDirectorVerifiersReturns struct {
+		result1 []api.Verifier
+		result2 error
+	}
+	listDirectorVerifiersReturnsOnCall map[int]struct {
+		result1 []api.Verifier
+		result2 error
+	}
+	ListProductVerifiersStub        func(string) ([]api.Verifier, string, error)
+	listProductVerifiersMutex       sync.RWMutex
+	listProductVerifiersArgsForCall []struct {
+		arg1 string
+	}
+	listProductVerifiersReturns struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}
+	listProductVerifiersReturnsOnCall map[int]struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}
+	ListStagedProductsStub        func() (api.StagedProductsOutput, error)
+	listStagedProductsMutex       sync.RWMutex
+	listStagedProductsArgsForCall []struct {
+	}
+	listStagedProductsReturns struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	listStagedProductsReturnsOnCall map[int]struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *VerifiersService) ListDirectorVerifiers() ([]api.Verifier, error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	ret, specificReturn := fake.listDirectorVerifiersReturnsOnCall[len(fake.listDirectorVerifiersArgsForCall)]
+	fake.listDirectorVerifiersArgsForCall = append(fake.listDirectorVerifiersArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListDirectorVerifiers", []interface{}{})
+	fake.listDirectorVerifiersMutex.Unlock()
+	if fake.ListDirectorVerifiersStub != nil {
+		return fake.ListDirectorVerifiersStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listDirectorVerifiersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *VerifiersService) ListDirectorVerifiersCallCount() int {
+	fake.listDirectorVerifiersMutex.RLock()
+	defer fake.listDirectorVerifiersMutex.RUnlock()
+	return len(fake.listDirectorVerifiersArgsForCall)
+}
+
+func (fake *VerifiersService) ListDirectorVerifiersCalls(stub func() ([]api.Verifier, error)) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = stub
+}
+
+func (fake *VerifiersService) ListDirectorVerifiersReturns(result1 []api.Verifier, result2 error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = nil
+	fake.listDirectorVerifiersReturns = struct {
+		result1 []api.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *VerifiersService) ListDirectorVerifiersReturnsOnCall(i int, result1 []api.Verifier, result2 error) {
+	fake.listDirectorVerifiersMutex.Lock()
+	defer fake.listDirectorVerifiersMutex.Unlock()
+	fake.ListDirectorVerifiersStub = nil
+	if fake.listDirectorVerifiersReturnsOnCall == nil {
+		fake.listDirectorVerifiersReturnsOnCall = make(map[int]struct {
+			result1 []api.Verifier
+			result2 error
+		})
+	}
+	fake.listDirectorVerifiersReturnsOnCall[i] = struct {
+		result1 []api.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *VerifiersService) ListProductVerifiers(arg1 string) ([]api.Verifier, string, error) {
+	fake.listProductVerifiersMutex.Lock()
+	ret, specificReturn := fake.listProductVerifiersReturnsOnCall[len(fake.listProductVerifiersArgsForCall)]
+	fake.listProductVerifiersArgsForCall = append(fake.listProductVerifiersArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListProductVerifiers", []interface{}{arg1})
+	fake.listProductVerifiersMutex.Unlock()
+	if fake.ListProductVerifiersStub != nil {
+		return fake.ListProductVerifiersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.listProductVerifiersReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *VerifiersService) ListProductVerifiersCallCount() int {
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	return len(fake.listProductVerifiersArgsForCall)
+}
+
+func (fake *VerifiersService) ListProductVerifiersCalls(stub func(string) ([]api.Verifier, string, error)) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = stub
+}
+
+func (fake *VerifiersService) ListProductVerifiersArgsForCall(i int) string {
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	argsForCall := fake.listProductVerifiersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *VerifiersService) ListProductVerifiersReturns(result1 []api.Verifier, result2 string, result3 error) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = nil
+	fake.listProductVerifiersReturns = struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *VerifiersService) ListProductVerifiersReturnsOnCall(i int, result1 []api.Verifier, result2 string, result3 error) {
+	fake.listProductVerifiersMutex.Lock()
+	defer fake.listProductVerifiersMutex.Unlock()
+	fake.ListProductVerifiersStub = nil
+	if fake.listProductVerifiersReturnsOnCall == nil {
+		fake.listProductVerifiersReturnsOnCall = make(map[int]struct {
+			result1 []api.Verifier
+			result2 string
+			result3 error
+		})
+	}
+	fake.listProductVerifiersReturnsOnCall[i] = struct {
+		result1 []api.Verifier
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *VerifiersService) ListStagedProducts() (api.StagedProductsOutput, error) {
+	fake.listStagedProductsMutex.Lock()
+	ret, specificReturn := fake.listStagedProductsReturnsOnCall[len(fake.listStagedProductsArgsForCall)]
+	fake.listStagedProductsArgsForCall = append(fake.listStagedProductsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ListStagedProducts", []interface{}{})
+	fake.listStagedProductsMutex.Unlock()
+	if fake.ListStagedProductsStub != nil {
+		return fake.ListStagedProductsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listStagedProductsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *VerifiersService) ListStagedProductsCallCount() int {
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	return len(fake.listStagedProductsArgsForCall)
+}
+
+func (fake *VerifiersService) ListStagedProductsCalls(stub func() (api.StagedProductsOutput, error)) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = stub
+}
+
+func (fake *VerifiersService) ListStagedProductsReturns(result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	fake.listStagedProductsReturns = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *VerifiersService) ListStagedProductsReturnsOnCall(i int, result1 api.StagedProductsOutput, result2 error) {
+	fake.listStagedProductsMutex.Lock()
+	defer fake.listStagedProductsMutex.Unlock()
+	fake.ListStagedProductsStub = nil
+	if fake.listStagedProductsReturnsOnCall == nil {
+		fake.listStagedProductsReturnsOnCall = make(map[int]struct {
+			result1 api.StagedProductsOutput
+			result2 error
+		})
+	}
+	fake.listStagedProductsReturnsOnCall[i] = struct {
+		result1 api.StagedProductsOutput
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *VerifiersService) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listDirectorVerifiersMutex.RLock()
+	defer fake.listDirectorVerifiersMutex.RUnlock()
+	fake.listProductVerifiersMutex.RLock()
+	defer fake.listProductVerifiersMutex.RUnlock()
+	fake.listStagedProductsMutex.RLock()
+	defer fake.listStagedProductsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *VerifiersService) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+type Foreach struct {
+	runner  foreachRunner
+	stdout  io.Writer
+	stderr  io.Writer
+	Options struct {
+		Targets     string   `long:"targets"    short:"t" required:"true" description:"path to an env file with the foundations under targets"`
+		Foundations []string `long:"foundation" short:"f"                 description:"name of a foundation of the targets to run the command against, instead of all of them. Can be repeated"`
+		Parallel    int      `long:"parallel"   default:"0"              description:"number of foundations to run the command against at the same time, all of them when 0"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/foreach_runner.go --fake-name ForeachRunner . foreachRunner
+type foreachRunner interface {
+	Run(args []string, stdout, stderr io.Writer) (int, error)
+}
+
+func NewForeach(runner foreachRunner, stdout, stderr io.Writer) *Foreach {
+	return &Foreach{
+		runner: runner,
+		stdout: stdout,
+		stderr: stderr,
+	}
+}
+
+type foreachResult struct {
+	foundation string
+	exitCode   int
+	duration   time.Duration
+	err        error
+}
+
+func (f Foreach) Execute(args []string) error {
+	if len(args) == 0 {
+		return errors.New("a command is required after --, e.g. om foreach --targets foundations.yml -- staged-products")
+	}
+
+	if f.Options.Parallel < 0 {
+		return errors.New("--parallel must be at least 0")
+	}
+
+	foundations, err := f.foundations()
+	if err != nil {
+		return err
+	}
+
+	parallel := f.Options.Parallel
+	if parallel == 0 || parallel > len(foundations) {
+		parallel = len(foundations)
+	}
+
+	results := make([]foreachResult, len(foundations))
+	slots := make(chan struct{}, parallel)
+
+	// the lines of every foundation are prefixed with its name, and written
+	// whole, so the output of the foundations does not mix within a line
+	var mutex sync.Mutex
+
+	var wg sync.WaitGroup
+	for i, foundation := range foundations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			stdout := &prefixWriter{prefix: "[" + foundation + "] ", out: f.stdout, mutex: &mutex}
+			stderr := &prefixWriter{prefix: "[" + foundation + "] ", out: f.stderr, mutex: &mutex}
+
+			start := time.Now()
+			exitCode, err := f.runner.Run(append([]string{"--env", f.Options.Targets, "--target", foundation}, args...), stdout, stderr)
+			stdout.flush()
+			stderr.flush()
+
+			results[i] = foreachResult{
+				foundation: foundation,
+				exitCode:   exitCode,
+				duration:   time.Since(start).Round(time.Second),
+				err:        err,
+			}
+		}()
+	}
+	wg.Wait()
+
+	var failed []string
+	table := tabwriter.NewWriter(f.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FOUNDATION\tEXIT CODE\tDURATION")
+	for _, result := range results {
+		exitCode := fmt.Sprint(result.exitCode)
+		if result.err != nil {
+			exitCode = fmt.Sprintf("- (%s)", result.err)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\n", result.foundation, exitCode, result.duration)
+
+		if result.err != nil || result.exitCode != 0 {
+			failed = append(failed, result.foundation)
+		}
+	}
+	_ = table.Flush()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("the command failed on %d of %d foundations: %s", len(failed), len(foundations), strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// foundations returns the names of the targets of the env file, in order,
+// or the ones given with --foundation.
+func (f Foreach) foundations() ([]string, error) {
+	contents, err := os.ReadFile(f.Options.Targets)
+	if err != nil {
+		return nil, fmt.Errorf("could not read targets file: %w", err)
+	}
+
+	var envFile struct {
+		Targets yaml.MapSlice `yaml:"targets"`
+	}
+	err = yaml.Unmarshal(contents, &envFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse targets file: %w", err)
+	}
+
+	var names []string
+	for _, item := range envFile.Targets {
+		names = append(names, fmt.Sprint(item.Key))
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("targets file %s has no targets", f.Options.Targets)
+	}
+
+	if len(f.Options.Foundations) == 0 {
+		return names, nil
+	}
+
+	for _, foundation := range f.Options.Foundations {
+		if !slices.Contains(names, foundation) {
+			return nil, fmt.Errorf("targets file has no foundation %q, the foundations are: %s", foundation, strings.Join(names, ", "))
+		}
+	}
+
+	return f.Options.Foundations, nil
+}
+
+// prefixWriter writes the complete lines written to it with a prefix. The
+// rest of the last line is written by flush.
+type prefixWriter struct {
+	prefix string
+	out    io.Writer
+	mutex  *sync.Mutex
+	buffer []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buffer = append(w.buffer, p...)
+
+	end := bytes.LastIndexByte(w.buffer, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+
+	w.write(w.buffer[:end+1])
+	w.buffer = w.buffer[end+1:]
+
+	return len(p), nil
+}
+
+func (w *prefixWriter) flush() {
+	if len(w.buffer) > 0 {
+		w.write(append(w.buffer, '\n'))
+		w.buffer = nil
+	}
+}
+
+func (w *prefixWriter) write(lines []byte) {
+	var prefixed bytes.Buffer
+	for _, line := range bytes.SplitAfter(lines, []byte("\n")) {
+		if len(line) > 0 {
+			prefixed.WriteString(w.prefix)
+			prefixed.Write(line)
+		}
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, _ = w.out.Write(prefixed.Bytes())
+}
+
+// ExecRunner runs om itself, as another process, for om foreach.
+type ExecRunner struct {
+	path string
+}
+
+func NewExecRunner(path string) ExecRunner {
+	return ExecRunner{path: path}
+}
+
+func (r ExecRunner) Run(args []string, stdout, stderr io.Writer) (int, error) {
+	command := exec.Command(r.path, args...)
+	command.Stdout = stdout
+	command.Stderr = stderr
+
+	err := command.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not run om: %w", err)
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,188 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("Foreach", func() {
+	var (
+		runner  *fakes.ForeachRunner
+		stdout  *gbytes.Buffer
+		stderr  *gbytes.Buffer
+		command *commands.Foreach
+		targets string
+	)
+
+	BeforeEach(func() {
+		runner = &fakes.ForeachRunner{}
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+		command = commands.NewForeach(runner, stdout, stderr)
+
+		targets = writeTestConfigFile(`---
+username: admin
+targets:
+  prod:
+    target: https://opsman.prod.example.com
+  staging:
+    target: https://opsman.staging.example.com
+  dev:
+    target: https://opsman.dev.example.com
+`)
+	})
+
+	It("runs the command against every foundation of the targets", func() {
+		runner.RunStub = func(args []string, stdout, stderr io.Writer) (int, error) {
+			fmt.Fprintf(stdout, "staged products of %s\nmore", args[3])
+			fmt.Fprintln(stderr, "a warning")
+			return 0, nil
+		}
+
+		command.Options.Targets = targets
+		err := command.Execute([]string{"staged-products", "--format", "json"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runner.RunCallCount()).To(Equal(3))
+		var calls [][]string
+		for i := 0; i < runner.RunCallCount(); i++ {
+			args, _, _ := runner.RunArgsForCall(i)
+			calls = append(calls, args)
+		}
+		Expect(calls).To(ConsistOf(
+			[]string{"--env", targets, "--target", "prod", "staged-products", "--format", "json"},
+			[]string{"--env", targets, "--target", "staging", "staged-products", "--format", "json"},
+			[]string{"--env", targets, "--target", "dev", "staged-products", "--format", "json"},
+		))
+
+		output := string(stdout.Contents())
+		Expect(output).To(ContainSubstring("[prod] staged products of prod\n[prod] more\n"))
+		Expect(output).To(ContainSubstring("[staging] staged products of staging\n[staging] more\n"))
+		Expect(output).To(ContainSubstring("[dev] staged products of dev\n[dev] more\n"))
+		Expect(string(stderr.Contents())).To(ContainSubstring("[dev] a warning\n"))
+
+		Expect(output).To(MatchRegexp(`FOUNDATION\s+EXIT CODE\s+DURATION\nprod\s+0\s+0s\nstaging\s+0\s+0s\ndev\s+0\s+0s\n$`))
+	})
+
+	It("returns an error naming the foundations the command failed on", func() {
+		runner.RunStub = func(args []string, stdout, stderr io.Writer) (int, error) {
+			switch args[3] {
+			case "staging":
+				return 2, nil
+			case "dev":
+				return 0, errors.New("some error")
+			}
+			return 0, nil
+		}
+
+		command.Options.Targets = targets
+		err := command.Execute([]string{"pending-changes", "--check"})
+		Expect(err).To(MatchError("the command failed on 2 of 3 foundations: staging, dev"))
+
+		Expect(string(stdout.Contents())).To(MatchRegexp(`prod\s+0\s+0s\nstaging\s+2\s+0s\ndev\s+- \(some error\)\s+0s\n$`))
+	})
+
+	It("runs the command against the foundations given with --foundation", func() {
+		command.Options.Targets = targets
+		command.Options.Foundations = []string{"dev", "prod"}
+		err := command.Execute([]string{"staged-products"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runner.RunCallCount()).To(Equal(2))
+		Expect(string(stdout.Contents())).To(MatchRegexp(`dev\s+0\s+0s\nprod\s+0\s+0s\n$`))
+	})
+
+	It("runs the command against as many foundations at the same time as --parallel", func() {
+		var running, maxRunning int32
+		runner.RunStub = func(args []string, stdout, stderr io.Writer) (int, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			for {
+				seen := atomic.LoadInt32(&maxRunning)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			return 0, nil
+		}
+
+		command.Options.Targets = targets
+		command.Options.Parallel = 2
+		err := command.Execute([]string{"staged-products"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(runner.RunCallCount()).To(Equal(3))
+		Expect(atomic.LoadInt32(&maxRunning)).To(BeNumerically("<=", 2))
+	})
+
+	When("the arguments are invalid", func() {
+		It("requires a command", func() {
+			command.Options.Targets = targets
+			err := command.Execute(nil)
+			Expect(err).To(MatchError(ContainSubstring("a command is required after --")))
+		})
+
+		It("requires --parallel to be at least 0", func() {
+			command.Options.Targets = targets
+			command.Options.Parallel = -1
+			err := command.Execute([]string{"staged-products"})
+			Expect(err).To(MatchError("--parallel must be at least 0"))
+		})
+
+		It("returns an error for a foundation that is not in the targets", func() {
+			command.Options.Targets = targets
+			command.Options.Foundations = []string{"qa"}
+			err := command.Execute([]string{"staged-products"})
+			Expect(err).To(MatchError(`targets file has no foundation "qa", the foundations are: prod, staging, dev`))
+			Expect(runner.RunCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when the targets file has no targets", func() {
+			command.Options.Targets = writeTestConfigFile("target: https://opsman.example.com\n")
+
+			err := command.Execute([]string{"staged-products"})
+			Expect(err).To(MatchError(ContainSubstring("has no targets")))
+		})
+
+		It("returns an error when the targets file cannot be read", func() {
+			command.Options.Targets = "/not/a/file.yml"
+			err := command.Execute([]string{"staged-products"})
+			Expect(err).To(MatchError(ContainSubstring("could not read targets file")))
+		})
+	})
+})
+
+var _ = Describe("ExecRunner", func() {
+	It("runs the executable and returns its exit code", func() {
+		executable := filepath.Join(GinkgoT().TempDir(), "om")
+		Expect(os.WriteFile(executable, []byte("#!/bin/sh\necho \"$*\"\necho oops >&2\nexit 3\n"), 0755)).To(Succeed())
+
+		stdout := gbytes.NewBuffer()
+		stderr := gbytes.NewBuffer()
+		exitCode, err := commands.NewExecRunner(executable).Run([]string{"--target", "prod", "staged-products"}, stdout, stderr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(exitCode).To(Equal(3))
+		Expect(string(stdout.Contents())).To(Equal("--target prod staged-products\n"))
+		Expect(string(stderr.Contents())).To(Equal("oops\n"))
+	})
+
+	It("returns an error when the executable cannot be run", func() {
+		_, err := commands.NewExecRunner("/not/an/executable").Run(nil, io.Discard, io.Discard)
+		Expect(err).To(MatchError(ContainSubstring("could not run om")))
+	})
+})
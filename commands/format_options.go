@@ -0,0 +1,7 @@
+package commands
+
+// formatOptions is embedded by commands that print through a presenter so
+// they all accept the same --format values.
+type formatOptions struct {
+	Format string `long:"format" short:"f" default:"table" choice:"table" choice:"json" choice:"yaml" description:"Format to print as"`
+}
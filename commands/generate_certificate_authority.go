@@ -9,7 +9,7 @@ type GenerateCertificateAuthority struct {
 	service   generateCertificateAuthorityService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
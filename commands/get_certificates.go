@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 
@@ -102,7 +103,7 @@ func (cmd *GetCertificates) Execute(args []string) error {
 					CredentialReference: cert.PropertyReference,
 				})
 				if err != nil {
-					if strings.Contains(err.Error(), "404") {
+					if api.IsStatus(err, http.StatusNotFound) {
 						errorMsg = fmt.Sprintf("credential not found for reference '%s'", cert.PropertyReference)
 					} else {
 						errorMsg = err.Error()
@@ -3,6 +3,7 @@ package commands_test
 import (
 	"errors"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/pivotal-cf/om/api"
@@ -193,7 +194,7 @@ svTEb6EMuB8T2B9Rtg==
 					},
 				}, nil)
 
-				service.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{}, errors.New("request failed: unexpected response 404"))
+				service.GetDeployedProductCredentialReturns(api.GetDeployedProductCredentialOutput{}, &api.Error{StatusCode: http.StatusNotFound})
 
 				command.Options.Product = "cf"
 				err := command.Execute([]string{})
@@ -4,14 +4,20 @@ import (
 	"archive/zip"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/pivotal-cf/om/api"
 )
 
-const maxRetries = 3
+const (
+	maxInstallationUploadRetries = 2
+	maxAvailabilityRetries       = 3
+)
 
 type ImportInstallation struct {
 	multipart  multipart
@@ -21,14 +27,18 @@ type ImportInstallation struct {
 	Options    struct {
 		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
 
-		Installation    string `long:"installation"          short:"i"  required:"true" description:"path to installation."`
-		PollingInterval int    `long:"polling-interval"      short:"p"                 description:"interval (in seconds) to check OpsManager availability" default:"10"`
+		Installation        string `long:"installation"          short:"i"  required:"true" description:"path to installation."`
+		PollingInterval     int    `long:"polling-interval"      short:"p"                 description:"interval (in seconds) to check OpsManager availability" default:"10"`
+		AvailabilityTimeout int    `long:"availability-timeout"                            description:"time (in seconds) to keep waiting for Ops Manager while it restarts after the import. Without it, om gives up after 3 failed checks"`
+		ChunkSize           int64  `long:"chunk-size"                                      description:"upload the installation in resumable chunks of this size (in MB); an interrupted upload resumes from the last acknowledged chunk"`
 	}
 }
 
 //counterfeiter:generate -o ./fakes/import_installation_service.go --fake-name ImportInstallationService . importInstallationService
 type importInstallationService interface {
 	UploadInstallationAssetCollection(api.ImportInstallationInput) error
+	UploadInstallationAssetCollectionChunk(api.ImportInstallationChunkInput) (api.ImportInstallationChunkOutput, error)
+	GetInstallationAssetCollectionUploadOffset(fileName string, totalLength int64) (int64, error)
 	EnsureAvailability(input api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
 }
 
@@ -57,9 +67,31 @@ func (ii *ImportInstallation) Execute(args []string) error {
 		return nil
 	}
 
+	if ii.Options.ChunkSize > 0 {
+		err = ii.uploadInChunks()
+	} else {
+		err = ii.upload()
+	}
+	if err != nil {
+		return err
+	}
+
+	ii.logger.Printf("waiting for import to complete, this should take only a couple minutes...")
+
+	err = ii.ensureAvailability()
+	if err != nil {
+		return err
+	}
+
+	ii.logger.Printf("finished import")
+
+	return nil
+}
+
+func (ii *ImportInstallation) upload() error {
 	ii.logger.Printf("processing installation")
 
-	err = ii.multipart.AddFile("installation[file]", ii.Options.Installation)
+	err := ii.multipart.AddFile("installation[file]", ii.Options.Installation)
 	if err != nil {
 		return fmt.Errorf("failed to load installation: %s", err)
 	}
@@ -70,9 +102,6 @@ func (ii *ImportInstallation) Execute(args []string) error {
 	}
 
 	submission := ii.multipart.Finalize()
-	if err != nil {
-		return fmt.Errorf("failed to create multipart form: %s", err)
-	}
 
 	ii.logger.Printf("beginning installation import to Ops Manager")
 
@@ -85,26 +114,120 @@ func (ii *ImportInstallation) Execute(args []string) error {
 		return fmt.Errorf("failed to import installation: %s", err)
 	}
 
-	ii.logger.Printf("waiting for import to complete, this should take only a couple minutes...")
+	return nil
+}
 
-	err = ii.ensureAvailability()
+func (ii *ImportInstallation) uploadInChunks() error {
+	file, err := os.Open(ii.Options.Installation)
+	if err != nil {
+		return fmt.Errorf("failed to load installation: %s", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to load installation: %s", err)
+	}
+
+	fileName := filepath.Base(ii.Options.Installation)
+	totalLength := info.Size()
+	chunkSize := ii.Options.ChunkSize * 1024 * 1024
+
+	offset, err := ii.uploadOffset(fileName, totalLength, chunkSize)
 	if err != nil {
 		return err
 	}
 
-	ii.logger.Printf("finished import")
+	if offset > 0 {
+		ii.logger.Printf("resuming installation import at byte %d of %d", offset, totalLength)
+	} else {
+		ii.logger.Printf("beginning chunked installation import to Ops Manager")
+	}
+
+	retries := 0
+	for {
+		chunkLength := chunkSize
+		if remaining := totalLength - offset; remaining < chunkLength {
+			chunkLength = remaining
+		}
+
+		var output api.ImportInstallationChunkOutput
+		output, err = ii.service.UploadInstallationAssetCollectionChunk(api.ImportInstallationChunkInput{
+			Chunk:       io.NewSectionReader(file, offset, chunkLength),
+			FileName:    fileName,
+			Passphrase:  ii.passphrase,
+			Offset:      offset,
+			ChunkLength: chunkLength,
+			TotalLength: totalLength,
+		})
+		if err == nil && !output.Complete && output.Offset <= offset {
+			err = fmt.Errorf("no bytes were acknowledged after offset %d", offset)
+		}
+
+		if err != nil {
+			if retries >= maxInstallationUploadRetries {
+				return fmt.Errorf("failed to import installation: %s", err)
+			}
+			retries++
+
+			ii.logger.Printf("retrying installation import after error: %s\n", err)
+
+			offset, err = ii.uploadOffset(fileName, totalLength, chunkSize)
+			if err != nil {
+				return err
+			}
+
+			ii.logger.Printf("resuming installation import at byte %d of %d", offset, totalLength)
+			continue
+		}
+
+		retries = 0
+		if output.Complete {
+			break
+		}
+		offset = output.Offset
+	}
 
 	return nil
 }
 
+// uploadOffset is the byte to resume the import at. As for upload-product,
+// the last chunk is sent again when Ops Manager reports every byte as
+// persisted, so the import is only done once it acknowledges a sent chunk.
+func (ii *ImportInstallation) uploadOffset(fileName string, totalLength, chunkSize int64) (int64, error) {
+	offset, err := ii.service.GetInstallationAssetCollectionUploadOffset(fileName, totalLength)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine installation upload offset: %s", err)
+	}
+
+	if offset >= totalLength {
+		offset = totalLength - chunkSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return offset, nil
+}
+
+// ensureAvailability polls until the import has completed. Ops Manager
+// restarts its web server during an import, so errors that come from it
+// being unreachable are tolerated for a few tries, or until the availability
+// timeout passes when one is given.
 func (ii ImportInstallation) ensureAvailability() error {
 	var tryCount int
+	deadline := time.Now().Add(time.Second * time.Duration(ii.Options.AvailabilityTimeout))
 
 	for {
 		time.Sleep(time.Second * time.Duration(ii.Options.PollingInterval))
 		ensureAvailabilityOutput, err := ii.service.EnsureAvailability(api.EnsureAvailabilityInput{})
 		if err != nil {
-			if isErrThatMightResolveOnRetry(err, tryCount) {
+			retry := tryCount < maxAvailabilityRetries
+			if ii.Options.AvailabilityTimeout > 0 {
+				retry = time.Now().Before(deadline)
+			}
+
+			if isErrThatMightResolveOnRetry(err) && retry {
 				ii.logger.Printf("waiting for ops manager web server boots up...")
 				tryCount++
 				continue
@@ -147,7 +270,32 @@ func (ii *ImportInstallation) validate(args []string) error {
 	return nil
 }
 
-func isErrThatMightResolveOnRetry(err error, tryCount int) bool {
-	return (strings.Contains(err.Error(), "connection refused") || strings.Contains(err.Error(), "bad gateway")) &&
-		tryCount < maxRetries
+// isErrThatMightResolveOnRetry reports the errors of a web server that is
+// restarting: network errors, a connection closed mid-response, and the
+// responses of the proxy in front of it.
+func isErrThatMightResolveOnRetry(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	// not net.Error, which the errors of the file system satisfy too
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, transient := range []string{
+		"connection refused",
+		"connection reset",
+		"bad gateway",
+		"service unavailable",
+		"gateway timeout",
+	} {
+		if strings.Contains(message, transient) {
+			return true
+		}
+	}
+
+	return false
 }
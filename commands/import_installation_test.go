@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/pivotal-cf/om/api"
@@ -196,7 +197,7 @@ var _ = Describe("ImportInstallation", func() {
 			Expect(fmt.Sprintf(format, v...)).To(Equal("finished import"))
 		})
 
-		It("it only retries 3 times before giving up", func(ctx context.Context) {
+		It("gives up once the availability timeout has passed", func(ctx context.Context) {
 			ctx, cancel := context.WithTimeout(ctx, time.Minute)
 			defer cancel()
 			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
@@ -209,10 +210,81 @@ var _ = Describe("ImportInstallation", func() {
 
 			err := executeCommandWithContext(ctx, command, []string{
 				"--polling-interval", "0",
+				"--availability-timeout", "1",
 				"--installation", installationFile,
 			})
 			Expect(err).To(MatchError(ContainSubstring("could not check Ops Manager Status:")))
 		}, SpecTimeout(time.Minute))
+
+		It("gives up after a few tries without an availability timeout", func() {
+			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+				if fakeService.EnsureAvailabilityCallCount() > 1 {
+					return api.EnsureAvailabilityOutput{}, errors.New("connection refused")
+				}
+
+				return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil
+			}
+
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+			})
+			Expect(err).To(MatchError("could not check Ops Manager Status: connection refused"))
+			Expect(fakeService.EnsureAvailabilityCallCount()).To(Equal(5))
+		})
+
+		It("does not retry errors that only mention an eof", func() {
+			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+				if fakeService.EnsureAvailabilityCallCount() > 1 {
+					return api.EnsureAvailabilityOutput{}, errors.New("could not parse geofence")
+				}
+
+				return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil
+			}
+
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+			})
+			Expect(err).To(MatchError("could not check Ops Manager Status: could not parse geofence"))
+			Expect(fakeService.EnsureAvailabilityCallCount()).To(Equal(2))
+		})
+
+		It("does not retry errors of the file system", func() {
+			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+				if fakeService.EnsureAvailabilityCallCount() > 1 {
+					return api.EnsureAvailabilityOutput{}, &os.PathError{Op: "open", Path: "some-file", Err: syscall.ENOENT}
+				}
+
+				return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil
+			}
+
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+			})
+			Expect(err).To(MatchError("could not check Ops Manager Status: open some-file: no such file or directory"))
+			Expect(fakeService.EnsureAvailabilityCallCount()).To(Equal(2))
+		})
+
+		It("retries a response that was cut off", func() {
+			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+				switch fakeService.EnsureAvailabilityCallCount() {
+				case 1:
+					return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil
+				case 2:
+					return api.EnsureAvailabilityOutput{}, fmt.Errorf("could not read response: %w", io.ErrUnexpectedEOF)
+				}
+
+				return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil
+			}
+
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 
 	When("EnsureAvailability returns 'bad gateway'", func() {
@@ -270,7 +342,7 @@ var _ = Describe("ImportInstallation", func() {
 			Expect(fmt.Sprintf(format, v...)).To(Equal("finished import"))
 		})
 
-		It("it only retries 3 times before giving up", func(ctx context.Context) {
+		It("gives up once the availability timeout has passed", func(ctx context.Context) {
 			ctx, cancel := context.WithTimeout(ctx, time.Minute)
 			defer cancel()
 			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
@@ -283,12 +355,125 @@ var _ = Describe("ImportInstallation", func() {
 
 			err := executeCommandWithContext(ctx, command, []string{
 				"--polling-interval", "0",
+				"--availability-timeout", "1",
 				"--installation", installationFile,
 			})
 			Expect(err).To(MatchError(ContainSubstring("could not check Ops Manager Status:")))
 		}, SpecTimeout(time.Minute))
 	})
 
+	When("the installation is uploaded in chunks", func() {
+		var command *commands.ImportInstallation
+
+		BeforeEach(func() {
+			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+				if fakeService.EnsureAvailabilityCallCount() == 1 {
+					return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil
+				}
+				return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil
+			}
+
+			fakeService.UploadInstallationAssetCollectionChunkStub = func(input api.ImportInstallationChunkInput) (api.ImportInstallationChunkOutput, error) {
+				_, err := io.ReadAll(input.Chunk)
+				Expect(err).ToNot(HaveOccurred())
+
+				end := input.Offset + input.ChunkLength
+				return api.ImportInstallationChunkOutput{Offset: end, Complete: end == input.TotalLength}, nil
+			}
+
+			command = commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+		})
+
+		It("resumes from the offset Ops Manager has already persisted", func() {
+			info, err := os.Stat(installationFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeService.GetInstallationAssetCollectionUploadOffsetReturns(10, nil)
+
+			err = executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+				"--chunk-size", "1",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(multipart.AddFileCallCount()).To(Equal(0))
+			Expect(fakeService.UploadInstallationAssetCollectionCallCount()).To(Equal(0))
+
+			Expect(fakeService.UploadInstallationAssetCollectionChunkCallCount()).To(Equal(1))
+			input := fakeService.UploadInstallationAssetCollectionChunkArgsForCall(0)
+			Expect(input.Offset).To(Equal(int64(10)))
+			Expect(input.ChunkLength).To(Equal(info.Size() - 10))
+			Expect(input.TotalLength).To(Equal(info.Size()))
+			Expect(input.Passphrase).To(Equal("some-passphrase"))
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal(fmt.Sprintf("resuming installation import at byte 10 of %d", info.Size())))
+		})
+
+		It("retries a failed chunk from the offset Ops Manager reports", func() {
+			uploadChunk := fakeService.UploadInstallationAssetCollectionChunkStub
+			fakeService.UploadInstallationAssetCollectionChunkStub = func(input api.ImportInstallationChunkInput) (api.ImportInstallationChunkOutput, error) {
+				if fakeService.UploadInstallationAssetCollectionChunkCallCount() == 1 {
+					return api.ImportInstallationChunkOutput{}, errors.New("connection reset")
+				}
+				return uploadChunk(input)
+			}
+			fakeService.GetInstallationAssetCollectionUploadOffsetReturnsOnCall(1, 5, nil)
+
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+				"--chunk-size", "1",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.GetInstallationAssetCollectionUploadOffsetCallCount()).To(Equal(2))
+			Expect(fakeService.UploadInstallationAssetCollectionChunkCallCount()).To(Equal(2))
+			Expect(fakeService.UploadInstallationAssetCollectionChunkArgsForCall(1).Offset).To(Equal(int64(5)))
+
+			format, v := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("retrying installation import after error: connection reset\n"))
+		})
+
+		It("gives up after repeated failures", func() {
+			fakeService.UploadInstallationAssetCollectionChunkReturns(api.ImportInstallationChunkOutput{}, errors.New("some installation error"))
+
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+				"--chunk-size", "1",
+			})
+			Expect(err).To(MatchError("failed to import installation: some installation error"))
+			Expect(fakeService.UploadInstallationAssetCollectionChunkCallCount()).To(Equal(3))
+		})
+	})
+
+	When("Ops Manager is unavailable while it restarts", func() {
+		It("keeps waiting through gateway errors until the availability timeout", func() {
+			multipart.FinalizeReturns(formcontent.ContentSubmission{})
+			fakeService.EnsureAvailabilityStub = func(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error) {
+				switch fakeService.EnsureAvailabilityCallCount() {
+				case 1:
+					return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil
+				case 7:
+					return api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil
+				default:
+					return api.EnsureAvailabilityOutput{}, errors.New("Unexpected response code: 503 Service Unavailable")
+				}
+			}
+
+			command := commands.NewImportInstallation(multipart, fakeService, "some-passphrase", logger)
+			err := executeCommand(command, []string{
+				"--polling-interval", "0",
+				"--installation", installationFile,
+				"--availability-timeout", "60",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeService.EnsureAvailabilityCallCount()).To(Equal(7))
+		})
+	})
+
 	When("the global decryption-passphrase is not provided", func() {
 		It("returns an error", func() {
 			command := commands.NewImportInstallation(multipart, fakeService, "", logger)
@@ -0,0 +1,283 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+// InitEnv is what init asks for, in the format of the env file.
+type InitEnv struct {
+	Target            string `yaml:"target"`
+	Username          string `yaml:"username,omitempty"`
+	Password          string `yaml:"password,omitempty"`
+	ClientID          string `yaml:"client-id,omitempty"`
+	ClientSecret      string `yaml:"client-secret,omitempty"`
+	CACert            string `yaml:"ca-cert,omitempty"`
+	SkipSSLValidation bool   `yaml:"skip-ssl-validation,omitempty"`
+}
+
+//counterfeiter:generate -o ./fakes/init_service.go --fake-name InitService . InitService
+type InitService interface {
+	Info() (api.Info, error)
+	EnsureAvailability(api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+}
+
+type Init struct {
+	newService func(InitEnv) (InitService, error)
+	stdin      io.Reader
+	stdout     io.Writer
+	Options    struct {
+		OutputFile string `long:"output-file" short:"o" default:"env.yml" description:"path of the env file to write"`
+		Force      bool   `long:"force"                                    description:"overwrite the env file when it exists"`
+	}
+}
+
+// NewInit takes newService to reach the Ops Manager of the answers, since
+// it is not known before init asks for it.
+func NewInit(newService func(InitEnv) (InitService, error), stdin io.Reader, stdout io.Writer) *Init {
+	return &Init{
+		newService: newService,
+		stdin:      stdin,
+		stdout:     stdout,
+	}
+}
+
+func (i Init) Execute(args []string) error {
+	if _, err := os.Stat(i.Options.OutputFile); err == nil && !i.Options.Force {
+		return fmt.Errorf("%s already exists, use --force to overwrite it", i.Options.OutputFile)
+	}
+
+	p := prompter{reader: bufio.NewReader(i.stdin), stdin: i.stdin, stdout: i.stdout}
+
+	var env InitEnv
+	var err error
+
+	env.Target, err = p.ask("Ops Manager target, e.g. https://opsman.example.com", "")
+	if err != nil {
+		return err
+	}
+
+	method, err := p.choose("Authenticate with a username and password, or a UAA client", []string{"user", "client"})
+	if err != nil {
+		return err
+	}
+
+	secretName, secretEnvVar := "password", "OM_PASSWORD"
+	if method == "client" {
+		secretName, secretEnvVar = "client secret", "OM_CLIENT_SECRET"
+		if env.ClientID, err = p.ask("Client ID", ""); err != nil {
+			return err
+		}
+		if env.ClientSecret, err = p.secret("Client secret"); err != nil {
+			return err
+		}
+	} else {
+		if env.Username, err = p.ask("Username", "admin"); err != nil {
+			return err
+		}
+		if env.Password, err = p.secret("Password"); err != nil {
+			return err
+		}
+	}
+
+	env.CACert, err = p.optional("Path to the CA certificate of Ops Manager (empty to use the system CAs)")
+	if err != nil {
+		return err
+	}
+
+	if env.CACert == "" {
+		env.SkipSSLValidation, err = p.confirm("Skip SSL validation", false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = i.verify(env); err != nil {
+		fmt.Fprintf(i.stdout, "Could not verify the connection: %s\n", err)
+
+		write, err := p.confirm("Write the env file anyway", false)
+		if err != nil {
+			return err
+		}
+		if !write {
+			return errors.New("the env file was not written")
+		}
+	}
+
+	storeSecret, err := p.confirm(fmt.Sprintf("Write the %s to the env file (otherwise set %s)", secretName, secretEnvVar), true)
+	if err != nil {
+		return err
+	}
+	if !storeSecret {
+		env.Password, env.ClientSecret = "", ""
+	}
+
+	contents, err := yaml.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(i.Options.OutputFile, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write env file: %w", err)
+	}
+
+	fmt.Fprintf(i.stdout, "Wrote %s, use it with: om --env %s <command>\n", i.Options.OutputFile, i.Options.OutputFile)
+	if !storeSecret {
+		fmt.Fprintf(i.stdout, "Set %s before running om\n", secretEnvVar)
+	}
+
+	return nil
+}
+
+// verify reaches Ops Manager, then authenticates once its authentication
+// has been configured.
+func (i Init) verify(env InitEnv) error {
+	service, err := i.newService(env)
+	if err != nil {
+		return err
+	}
+
+	info, err := service.Info()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(i.stdout, "Reached Ops Manager %s\n", info.Version)
+
+	availability, err := service.EnsureAvailability(api.EnsureAvailabilityInput{})
+	if err != nil {
+		return err
+	}
+
+	if availability.Status == api.EnsureAvailabilityStatusUnstarted {
+		fmt.Fprintln(i.stdout, "The authentication of Ops Manager is not configured yet, configure it with: om --env <env file> configure-authentication")
+		return nil
+	}
+
+	_, err = service.ListStagedProducts()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(i.stdout, "Authenticated successfully")
+
+	return nil
+}
+
+type prompter struct {
+	reader *bufio.Reader
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+// ask asks until there is an answer, or returns fallback for an empty one
+// when fallback is set.
+func (p prompter) ask(question, fallback string) (string, error) {
+	for {
+		if fallback != "" {
+			fmt.Fprintf(p.stdout, "%s [%s]: ", question, fallback)
+		} else {
+			fmt.Fprintf(p.stdout, "%s: ", question)
+		}
+
+		answer, err := p.line()
+		if err != nil {
+			return "", err
+		}
+
+		if answer != "" {
+			return answer, nil
+		}
+		if fallback != "" {
+			return fallback, nil
+		}
+	}
+}
+
+func (p prompter) optional(question string) (string, error) {
+	fmt.Fprintf(p.stdout, "%s: ", question)
+	return p.line()
+}
+
+func (p prompter) choose(question string, choices []string) (string, error) {
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s)", question, strings.Join(choices, "/")), choices[0])
+		if err != nil {
+			return "", err
+		}
+
+		for _, choice := range choices {
+			if strings.EqualFold(answer, choice) {
+				return choice, nil
+			}
+		}
+	}
+}
+
+func (p prompter) confirm(question string, fallback bool) (bool, error) {
+	options := "y/N"
+	if fallback {
+		options = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(p.stdout, "%s? [%s]: ", question, options)
+
+		answer, err := p.line()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return fallback, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// secret does not echo the answer when om is run from a terminal.
+func (p prompter) secret(question string) (string, error) {
+	f, ok := p.stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return p.ask(question, "")
+	}
+
+	for {
+		fmt.Fprintf(p.stdout, "%s: ", question)
+
+		answer, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(p.stdout)
+		if err != nil {
+			return "", fmt.Errorf("could not read %s: %w", strings.ToLower(question), err)
+		}
+
+		if len(answer) > 0 {
+			return string(answer), nil
+		}
+	}
+}
+
+func (p prompter) line() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		if err == io.EOF {
+			return "", errors.New("the setup was cancelled, stdin was closed")
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
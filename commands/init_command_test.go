@@ -0,0 +1,163 @@
+package commands_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("Init", func() {
+	var (
+		fakeService *fakes.InitService
+		serviceEnv  commands.InitEnv
+		serviceErr  error
+		envFile     string
+		stdout      *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fakeService = &fakes.InitService{}
+		fakeService.InfoReturns(api.Info{Version: "3.0.1"}, nil)
+		fakeService.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil)
+
+		serviceEnv, serviceErr = commands.InitEnv{}, nil
+		envFile = filepath.Join(GinkgoT().TempDir(), "env.yml")
+		stdout = gbytes.NewBuffer()
+	})
+
+	run := func(answers string, args ...string) error {
+		command := commands.NewInit(func(env commands.InitEnv) (commands.InitService, error) {
+			serviceEnv = env
+			return fakeService, serviceErr
+		}, strings.NewReader(answers), stdout)
+
+		_, err := flags.NewParser(command, flags.HelpFlag|flags.PassDoubleDash).ParseArgs(append([]string{"--output-file", envFile}, args...))
+		Expect(err).ToNot(HaveOccurred())
+
+		return command.Execute(nil)
+	}
+
+	readEnvFile := func() string {
+		contents, err := os.ReadFile(envFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(contents)
+	}
+
+	It("writes the env file after verifying the connection", func() {
+		err := run("https://opsman.example.com\n\n\nsecret\n/path/to/ca.pem\n\n")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(serviceEnv).To(Equal(commands.InitEnv{
+			Target:   "https://opsman.example.com",
+			Username: "admin",
+			Password: "secret",
+			CACert:   "/path/to/ca.pem",
+		}))
+		Expect(fakeService.ListStagedProductsCallCount()).To(Equal(1))
+		Expect(stdout).To(gbytes.Say(`Reached Ops Manager 3.0.1`))
+		Expect(stdout).To(gbytes.Say(`Authenticated successfully`))
+		Expect(stdout).To(gbytes.Say(`Wrote .*env.yml, use it with: om --env .*env.yml <command>`))
+
+		Expect(readEnvFile()).To(MatchYAML(`
+target: https://opsman.example.com
+username: admin
+password: secret
+ca-cert: /path/to/ca.pem
+`))
+
+		info, err := os.Stat(envFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("authenticates with a client and leaves the secret out when asked to", func() {
+		err := run("opsman.example.com\nclient\nautomation\nclient-secret\n\ny\nn\n")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(serviceEnv.ClientSecret).To(Equal("client-secret"))
+		Expect(readEnvFile()).To(MatchYAML(`
+target: opsman.example.com
+client-id: automation
+skip-ssl-validation: true
+`))
+		Expect(stdout).To(gbytes.Say("Set OM_CLIENT_SECRET before running om"))
+	})
+
+	It("asks again until an answer is valid", func() {
+		err := run("\nopsman.example.com\nsomething\nuser\nadmin\n\nsecret\n\nmaybe\nn\n\n")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(readEnvFile()).To(MatchYAML(`
+target: opsman.example.com
+username: admin
+password: secret
+`))
+	})
+
+	It("does not verify the credentials before the authentication is configured", func() {
+		fakeService.EnsureAvailabilityReturns(api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusUnstarted}, nil)
+
+		err := run("opsman.example.com\n\n\nsecret\n\n\n\n")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeService.ListStagedProductsCallCount()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("configure it with: om --env <env file> configure-authentication"))
+	})
+
+	When("the connection cannot be verified", func() {
+		BeforeEach(func() {
+			fakeService.ListStagedProductsReturns(api.StagedProductsOutput{}, errors.New("invalid credentials"))
+		})
+
+		It("writes the env file when asked to anyway", func() {
+			err := run("opsman.example.com\n\n\nwrong\n\n\ny\n\n")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stdout).To(gbytes.Say("Could not verify the connection: invalid credentials"))
+			Expect(readEnvFile()).To(ContainSubstring("password: wrong"))
+		})
+
+		It("does not write the env file otherwise", func() {
+			err := run("opsman.example.com\n\n\nwrong\n\n\n\n")
+			Expect(err).To(MatchError("the env file was not written"))
+
+			_, err = os.Stat(envFile)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("reports when Ops Manager cannot be reached", func() {
+			serviceErr = errors.New("invalid ca cert")
+
+			err := run("opsman.example.com\n\n\nsecret\n/does/not/exist\n\n")
+			Expect(err).To(MatchError("the env file was not written"))
+			Expect(stdout).To(gbytes.Say("Could not verify the connection: invalid ca cert"))
+		})
+	})
+
+	It("does not overwrite an env file without --force", func() {
+		Expect(os.WriteFile(envFile, []byte("target: old"), 0600)).To(Succeed())
+
+		err := run("")
+		Expect(err).To(MatchError(ContainSubstring("env.yml already exists, use --force to overwrite it")))
+
+		err = run("opsman.example.com\n\n\nsecret\n\n\n\n", "--force")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(readEnvFile()).To(ContainSubstring("target: opsman.example.com"))
+	})
+
+	It("stops when stdin is closed", func() {
+		err := run("opsman.example.com\n")
+		Expect(err).To(MatchError("the setup was cancelled, stdin was closed"))
+	})
+})
@@ -1,30 +1,45 @@
 package commands
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/pivotal-cf/om/api"
 )
 
+const maxInstallationLogReconnects = 10
+
 type InstallationLog struct {
-	service installationLogService
-	logger  logger
-	Options struct {
-		Id int `long:"id" required:"true" description:"id of the installation to retrieve logs for"`
+	service      installationLogService
+	logWriter    logWriter
+	logger       logger
+	waitDuration time.Duration
+	Options      struct {
+		Id     int  `long:"id"     required:"true" description:"id of the installation to retrieve logs for"`
+		Follow bool `long:"follow" short:"f"       description:"keep streaming the logs until the installation finishes, reconnecting if the connection drops"`
 	}
 }
 
 //counterfeiter:generate -o ./fakes/installation_log_service.go --fake-name InstallationLogService . installationLogService
 type installationLogService interface {
+	GetInstallation(id int) (api.InstallationsServiceOutput, error)
 	GetInstallationLogs(id int) (api.InstallationsServiceOutput, error)
 }
 
-func NewInstallationLog(service installationLogService, logger logger) *InstallationLog {
+func NewInstallationLog(service installationLogService, logWriter logWriter, logger logger, waitDuration time.Duration) *InstallationLog {
 	return &InstallationLog{
-		service: service,
-		logger:  logger,
+		service:      service,
+		logWriter:    logWriter,
+		logger:       logger,
+		waitDuration: waitDuration,
 	}
 }
 
 func (i InstallationLog) Execute(args []string) error {
+	if i.Options.Follow {
+		return i.follow()
+	}
+
 	output, err := i.service.GetInstallationLogs(i.Options.Id)
 	if err != nil {
 		return err
@@ -32,3 +47,53 @@ func (i InstallationLog) Execute(args []string) error {
 	i.logger.Print(output.Logs)
 	return nil
 }
+
+// follow polls the installation until it finishes. The log writer only emits
+// output past what it has already written, so after a dropped connection the
+// stream resumes where it left off.
+func (i InstallationLog) follow() error {
+	failures := 0
+
+	for {
+		status, logs, err := i.poll()
+		if err != nil {
+			failures++
+			if failures > maxInstallationLogReconnects {
+				return fmt.Errorf("could not follow logs for installation %d after %d attempts: %s", i.Options.Id, maxInstallationLogReconnects, err)
+			}
+
+			i.logger.Printf("lost connection to Ops Manager, reconnecting (attempt %d of %d): %s", failures, maxInstallationLogReconnects, err)
+			time.Sleep(i.waitDuration)
+			continue
+		}
+		failures = 0
+
+		err = i.logWriter.Flush(logs)
+		if err != nil {
+			return fmt.Errorf("installation failed to flush logs: %s", err)
+		}
+
+		switch status {
+		case api.StatusSucceeded:
+			return nil
+		case api.StatusFailed:
+			return fmt.Errorf("installation %d was unsuccessful", i.Options.Id)
+		}
+
+		time.Sleep(i.waitDuration)
+	}
+}
+
+func (i InstallationLog) poll() (string, string, error) {
+	installation, err := i.service.GetInstallation(i.Options.Id)
+	if err != nil {
+		return "", "", err
+	}
+
+	output, err := i.service.GetInstallationLogs(i.Options.Id)
+	if err != nil {
+		return "", "", err
+	}
+
+	return installation.Status, output.Logs, nil
+}
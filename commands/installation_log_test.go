@@ -2,6 +2,8 @@ package commands_test
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -15,13 +17,15 @@ var _ = Describe("InstallationLog", func() {
 	var (
 		command     *commands.InstallationLog
 		fakeService *fakes.InstallationLogService
+		logWriter   *fakes.LogWriter
 		logger      *fakes.Logger
 	)
 
 	BeforeEach(func() {
 		logger = &fakes.Logger{}
+		logWriter = &fakes.LogWriter{}
 		fakeService = &fakes.InstallationLogService{}
-		command = commands.NewInstallationLog(fakeService, logger)
+		command = commands.NewInstallationLog(fakeService, logWriter, logger, time.Millisecond)
 	})
 
 	Describe("Execute", func() {
@@ -41,6 +45,58 @@ var _ = Describe("InstallationLog", func() {
 			outputLogs := logger.PrintArgsForCall(0)[0]
 			Expect(outputLogs).To(Equal("some log output"))
 		})
+
+		When("--follow is provided", func() {
+			It("streams the logs until the installation succeeds", func() {
+				fakeService.GetInstallationReturnsOnCall(0, api.InstallationsServiceOutput{Status: api.StatusRunning}, nil)
+				fakeService.GetInstallationReturnsOnCall(1, api.InstallationsServiceOutput{Status: api.StatusSucceeded}, nil)
+				fakeService.GetInstallationLogsReturnsOnCall(0, api.InstallationsServiceOutput{Logs: "start"}, nil)
+				fakeService.GetInstallationLogsReturnsOnCall(1, api.InstallationsServiceOutput{Logs: "start\nfinish"}, nil)
+
+				err := executeCommand(command, []string{"--id", "999", "--follow"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.GetInstallationArgsForCall(0)).To(Equal(999))
+				Expect(logWriter.FlushCallCount()).To(Equal(2))
+				Expect(logWriter.FlushArgsForCall(0)).To(Equal("start"))
+				Expect(logWriter.FlushArgsForCall(1)).To(Equal("start\nfinish"))
+				Expect(logger.PrintCallCount()).To(Equal(0))
+			})
+
+			It("returns an error when the installation fails", func() {
+				fakeService.GetInstallationReturns(api.InstallationsServiceOutput{Status: api.StatusFailed}, nil)
+
+				err := executeCommand(command, []string{"--id", "999", "--follow"})
+				Expect(err).To(MatchError("installation 999 was unsuccessful"))
+				Expect(logWriter.FlushCallCount()).To(Equal(1))
+			})
+
+			It("reconnects and resumes after the connection drops", func() {
+				fakeService.GetInstallationReturnsOnCall(0, api.InstallationsServiceOutput{Status: api.StatusRunning}, nil)
+				fakeService.GetInstallationReturnsOnCall(1, api.InstallationsServiceOutput{}, errors.New("connection reset"))
+				fakeService.GetInstallationReturnsOnCall(2, api.InstallationsServiceOutput{Status: api.StatusSucceeded}, nil)
+				fakeService.GetInstallationLogsReturns(api.InstallationsServiceOutput{Logs: "some logs"}, nil)
+
+				err := executeCommand(command, []string{"--id", "999", "--follow"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.GetInstallationCallCount()).To(Equal(3))
+				Expect(logWriter.FlushCallCount()).To(Equal(2))
+
+				format, v := logger.PrintfArgsForCall(0)
+				Expect(fmt.Sprintf(format, v...)).To(Equal("lost connection to Ops Manager, reconnecting (attempt 1 of 10): connection reset"))
+			})
+
+			It("gives up after repeated connection failures", func() {
+				fakeService.GetInstallationLogsReturns(api.InstallationsServiceOutput{}, errors.New("connection refused"))
+
+				err := executeCommand(command, []string{"--id", "999", "--follow"})
+				Expect(err).To(MatchError("could not follow logs for installation 999 after 10 attempts: connection refused"))
+				Expect(fakeService.GetInstallationLogsCallCount()).To(Equal(11))
+				Expect(logWriter.FlushCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the api fails to retrieve the installation log", func() {
 			It("returns an error", func() {
 				fakeService.GetInstallationLogsReturns(
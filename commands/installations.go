@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/models"
 	"github.com/pivotal-cf/om/presenters"
@@ -10,7 +13,12 @@ type Installations struct {
 	service   installationsService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
+		Since  string   `long:"since"                   description:"only list installations started at or after this time, as RFC3339 (2006-01-02T15:04:05Z) or a duration ago (e.g. 72h)"`
+		Until  string   `long:"until"                   description:"only list installations started before this time, as RFC3339 (2006-01-02T15:04:05Z) or a duration ago (e.g. 24h)"`
+		Status []string `long:"status" choice:"running" choice:"succeeded" choice:"failed" description:"only list installations with this status. Can be given multiple times"`
+		Limit  int      `long:"limit"                   description:"maximum number of installations to list, newest first (0 lists all)"`
+		Offset int      `long:"offset"                  description:"number of the newest matching installations to skip, to page through the history with --limit"`
 	}
 }
 
@@ -27,24 +35,95 @@ func NewInstallations(service installationsService, presenter presenters.Formatt
 }
 
 func (i Installations) Execute(args []string) error {
+	now := time.Now()
+
+	since, err := parseInstallationTime("since", i.Options.Since, now)
+	if err != nil {
+		return err
+	}
+
+	until, err := parseInstallationTime("until", i.Options.Until, now)
+	if err != nil {
+		return err
+	}
+
+	if i.Options.Limit < 0 || i.Options.Offset < 0 {
+		return fmt.Errorf("--limit and --offset cannot be negative")
+	}
+
 	installationsOutput, err := i.service.ListInstallations()
 	if err != nil {
 		return err
 	}
 
+	statuses := map[string]bool{}
+	for _, status := range i.Options.Status {
+		statuses[status] = true
+	}
+
 	var installations []models.Installation
 	for _, installation := range installationsOutput {
+		if len(statuses) > 0 && !statuses[installation.Status] {
+			continue
+		}
+
+		if since != nil && (installation.StartedAt == nil || installation.StartedAt.Before(*since)) {
+			continue
+		}
+
+		if until != nil && (installation.StartedAt == nil || !installation.StartedAt.Before(*until)) {
+			continue
+		}
+
+		var duration *int
+		if installation.StartedAt != nil && installation.FinishedAt != nil {
+			seconds := int(installation.FinishedAt.Sub(*installation.StartedAt).Seconds())
+			duration = &seconds
+		}
+
 		installations = append(installations, models.Installation{
 			Id:         installation.ID,
 			User:       installation.UserName,
 			Status:     installation.Status,
 			StartedAt:  installation.StartedAt,
 			FinishedAt: installation.FinishedAt,
+			Duration:   duration,
 		})
 	}
 
+	// the api lists the newest installations first
+	if i.Options.Offset >= len(installations) {
+		installations = nil
+	} else {
+		installations = installations[i.Options.Offset:]
+	}
+
+	if i.Options.Limit > 0 && i.Options.Limit < len(installations) {
+		installations = installations[:i.Options.Limit]
+	}
+
 	i.presenter.SetFormat(i.Options.Format)
 	i.presenter.PresentInstallations(installations)
 
 	return nil
 }
+
+// parseInstallationTime accepts an RFC3339 time, or a duration which is
+// subtracted from now.
+func parseInstallationTime(name, value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse --%s %q: expected an RFC3339 time (e.g. 2006-01-02T15:04:05Z) or a duration (e.g. 72h)", name, value)
+	}
+
+	t := now.Add(-duration)
+	return &t, nil
+}
@@ -57,6 +57,7 @@ var _ = Describe("Installations", func() {
 		})
 
 		It("lists recent installations as a table", func() {
+			oneMinute := 60
 			err := executeCommand(command, []string{})
 			Expect(err).ToNot(HaveOccurred())
 
@@ -69,6 +70,7 @@ var _ = Describe("Installations", func() {
 					Status:     "succeeded",
 					StartedAt:  parseTime("2017-05-24T23:38:37.316Z"),
 					FinishedAt: parseTime("2017-05-24T23:39:37.316Z"),
+					Duration:   &oneMinute,
 				},
 				models.Installation{
 					Id:        2,
@@ -78,6 +80,65 @@ var _ = Describe("Installations", func() {
 				}))
 		})
 
+		Describe("filtering", func() {
+			BeforeEach(func() {
+				fakeService.ListInstallationsReturns([]api.InstallationsServiceOutput{
+					{ID: 5, Status: "running", StartedAt: parseTime("2017-05-28T10:00:00Z")},
+					{ID: 4, Status: "succeeded", StartedAt: parseTime("2017-05-27T10:00:00Z"), FinishedAt: parseTime("2017-05-27T11:00:00Z")},
+					{ID: 3, Status: "failed", StartedAt: parseTime("2017-05-26T10:00:00Z"), FinishedAt: parseTime("2017-05-26T10:10:00Z")},
+					{ID: 2, Status: "succeeded", StartedAt: parseTime("2017-05-25T10:00:00Z"), FinishedAt: parseTime("2017-05-25T10:30:00Z")},
+					{ID: 1, Status: "succeeded", StartedAt: parseTime("2017-05-24T10:00:00Z"), FinishedAt: parseTime("2017-05-24T10:30:00Z")},
+				}, nil)
+			})
+
+			presentedIDs := func() []int {
+				var ids []int
+				for _, installation := range fakePresenter.PresentInstallationsArgsForCall(0) {
+					ids = append(ids, installation.Id)
+				}
+				return ids
+			}
+
+			It("lists the installations started in the time range", func() {
+				err := executeCommand(command, []string{"--since", "2017-05-25T10:00:00Z", "--until", "2017-05-28T10:00:00Z"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(presentedIDs()).To(Equal([]int{4, 3, 2}))
+			})
+
+			It("accepts durations relative to now", func() {
+				err := executeCommand(command, []string{"--until", "1h"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(presentedIDs()).To(Equal([]int{5, 4, 3, 2, 1}))
+			})
+
+			It("lists the installations with the statuses", func() {
+				err := executeCommand(command, []string{"--status", "failed", "--status", "running"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(presentedIDs()).To(Equal([]int{5, 3}))
+			})
+
+			It("pages through the matching installations", func() {
+				err := executeCommand(command, []string{"--status", "succeeded", "--offset", "1", "--limit", "1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(presentedIDs()).To(Equal([]int{2}))
+
+				installation := fakePresenter.PresentInstallationsArgsForCall(0)[0]
+				Expect(*installation.Duration).To(Equal(1800))
+			})
+
+			It("lists nothing when the offset is past the installations", func() {
+				err := executeCommand(command, []string{"--offset", "10"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(presentedIDs()).To(BeEmpty())
+			})
+
+			It("returns an error when the time cannot be parsed", func() {
+				err := executeCommand(command, []string{"--since", "yesterday"})
+				Expect(err).To(MatchError(ContainSubstring(`could not parse --since "yesterday"`)))
+				Expect(fakeService.ListInstallationsCallCount()).To(Equal(0))
+			})
+		})
+
 		When("the format flag is provided", func() {
 			It("sets the format on the presenter", func() {
 				err := executeCommand(command, []string{"--format", "json"})
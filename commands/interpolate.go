@@ -12,16 +12,20 @@ import (
 
 type interpolateOptions struct {
 	ConfigFile string   `long:"config"       short:"c"     description:"path for file to be interpolated"`
-	VarsEnv    []string `long:"vars-env" env:"OM_VARS_ENV" description:"load variables from environment variables matching the provided prefix (e.g.: 'MY' to load MY_var=value)"`
-	VarsFile   []string `long:"vars-file"    short:"l"     description:"load variables from a YAML file"`
+	VarsEnv    []string `long:"vars-env" env:"OM_VARS_ENV" description:"load variables from environment variables matching the provided prefix (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
+	VarsFile   []string `long:"vars-file"    short:"l"     description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
 	Vars       []string `long:"var"          short:"v"     description:"load variable from the command line. Format: VAR=VAL"`
+	VarsStore  []string `long:"vars-store"                 description:"resolve variables from a credential store (credhub://, vault://, aws-sm://, aws-ssm:// or file:// URI), as PREFIX=URI to name them PREFIX_name; vars from files, flags and the environment take precedence, then the last store given"`
+	Functions  bool     `long:"functions"                  description:"enable the functions base64, sha256, password, join and split in the config, e.g. ((base64 some_var))"`
 }
 
 type interpolateConfigFileOptions struct {
 	ConfigFile string   `long:"config"                     short:"c" description:"path to yml file for configuration (keys must match the following command line flags)"`
-	VarsEnv    []string `long:"vars-env" env:"OM_VARS_ENV"           description:"load variables from environment variables matching the provided prefix (e.g.: 'MY' to load MY_var=value)"`
-	VarsFile   []string `long:"vars-file"                  short:"l" description:"load variables from a YAML file"`
+	VarsEnv    []string `long:"vars-env" env:"OM_VARS_ENV"           description:"load variables from environment variables matching the provided prefix (e.g.: 'MY' to load MY_var=value, or 'PREFIX=MY' to load it as PREFIX_var)"`
+	VarsFile   []string `long:"vars-file"                  short:"l" description:"load variables from a YAML file, as PREFIX=PATH to name them PREFIX_name"`
 	Vars       []string `long:"var"                        short:"v" description:"load variable from the command line. Format: VAR=VAL"`
+	VarsStore  []string `long:"vars-store"                           description:"resolve variables from a credential store (credhub://, vault://, aws-sm://, aws-ssm:// or file:// URI), as PREFIX=URI to name them PREFIX_name; vars from files, flags and the environment take precedence, then the last store given"`
+	Functions  bool     `long:"functions"                            description:"enable the functions base64, sha256, password, join and split in the config, e.g. ((base64 some_var))"`
 }
 
 func (*interpolateConfigFileOptions) UnmarshalFlag(value string) error {
@@ -89,6 +93,8 @@ func (c Interpolate) Execute(args []string) error {
 	bytes, err := interpolate.Execute(interpolate.Options{
 		TemplateFile:  c.Options.ConfigFile,
 		VarsFiles:     c.Options.VarsFile,
+		VarsStores:    c.Options.VarsStore,
+		Functions:     c.Options.Functions,
 		Vars:          c.Options.Vars,
 		EnvironFunc:   c.environFunc,
 		VarsEnvs:      c.Options.VarsEnv,
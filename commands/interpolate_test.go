@@ -226,6 +226,24 @@ hello: world`))
 			})
 		})
 
+		When("the functions flag is set", func() {
+			It("evaluates the functions in the config", func() {
+				err := os.WriteFile(inputFile, []byte(`{"a": "((base64 interpolated-value))"}`), 0755)
+				Expect(err).ToNot(HaveOccurred())
+				err = os.WriteFile(varsFile, []byte(`{"interpolated-value": "b"}`), 0755)
+				Expect(err).ToNot(HaveOccurred())
+				err = executeCommand(command, []string{
+					"--config", inputFile,
+					"--vars-file", varsFile,
+					"--functions",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				content := logger.PrintArgsForCall(0)
+				Expect(content[0].(string)).To(MatchYAML(`a: Yg==`))
+			})
+		})
+
 		When("the skip-missing flag is set", func() {
 			When("there are missing parameters", func() {
 				It("succeeds", func() {
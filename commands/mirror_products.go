@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/om/download_clients"
+	"github.com/pivotal-cf/om/validator"
+)
+
+type MirrorProductsOptions struct {
+	Products    []string `long:"product"     required:"true" description:"product to mirror, as SLUG[:VERSION_CONSTRAINT[:FILE_GLOB]] (e.g. 'cf:~> 2.13:srt-*.pivotal'). Without a constraint the latest version is mirrored, without a glob every '*.pivotal' file. Can be repeated."`
+	Destination string   `long:"destination" required:"true" description:"blobstore to mirror the products to" choice:"s3" choice:"gcs" choice:"azure"`
+
+	Bucket       string `long:"blobstore-bucket"        required:"true" description:"bucket name to mirror the products to in the s3|gcs|azure compatible blobstore"`
+	ProductPath  string `long:"blobstore-product-path"                  description:"path in the bucket to mirror the product artifacts to"`
+	StemcellPath string `long:"blobstore-stemcell-path"                 description:"path in the bucket to mirror the stemcell artifacts to"`
+
+	PivnetToken      string `long:"pivnet-api-token"   short:"t" description:"API token to use when interacting with Pivnet. Can be retrieved from your profile page in Pivnet."`
+	PivnetDisableSSL bool   `long:"pivnet-disable-ssl"           description:"whether to disable ssl validation when contacting the Pivotal Network"`
+	PivnetHost       string `long:"pivnet-host"                  description:"the API endpoint for Pivotal Network" default:"https://network.pivotal.io"`
+
+	StemcellIaas  string `long:"stemcell-iaas"  description:"also mirror the latest stemcell each product needs for the specified iaas. for example 'vsphere' or 'google' or 'aws'"`
+	StemcellHeavy bool   `long:"stemcell-heavy" description:"mirror heavy stemcells, will fail if none exists"`
+
+	AzureOptions
+	GCSOptions
+	InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
+	S3Options
+}
+
+type MirrorDestinationFunc func(MirrorProductsOptions, *log.Logger) (download_clients.ProductUploader, error)
+
+type MirrorProducts struct {
+	stdout         *log.Logger
+	stderr         *log.Logger
+	newDestination MirrorDestinationFunc
+	Options        MirrorProductsOptions
+}
+
+func NewMirrorProducts(stdout *log.Logger, stderr *log.Logger, newDestination MirrorDestinationFunc) *MirrorProducts {
+	return &MirrorProducts{
+		stdout:         stdout,
+		stderr:         stderr,
+		newDestination: newDestination,
+	}
+}
+
+// NewMirrorDestination connects to the --destination blobstore the products
+// are mirrored to.
+func NewMirrorDestination(options MirrorProductsOptions, stderr *log.Logger) (download_clients.ProductUploader, error) {
+	return newBlobstoreClient[download_clients.ProductUploader](DownloadProductOptions{
+		Source:       options.Destination,
+		Bucket:       options.Bucket,
+		ProductPath:  options.ProductPath,
+		StemcellPath: options.StemcellPath,
+		AzureOptions: options.AzureOptions,
+		GCSOptions:   options.GCSOptions,
+		S3Options:    options.S3Options,
+	}, stderr, "cannot mirror products to %s")
+}
+
+type mirrorProductSpec struct {
+	slug              string
+	versionConstraint string
+	fileGlob          string
+}
+
+func parseMirrorProductSpec(value string) (mirrorProductSpec, error) {
+	parts := strings.SplitN(value, ":", 3)
+
+	spec := mirrorProductSpec{
+		slug:     strings.TrimSpace(parts[0]),
+		fileGlob: "*.pivotal",
+	}
+	if len(parts) > 1 {
+		spec.versionConstraint = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 && parts[2] != "" {
+		spec.fileGlob = parts[2]
+	}
+
+	if spec.slug == "" {
+		return mirrorProductSpec{}, fmt.Errorf("the product %q must start with a slug", value)
+	}
+
+	return spec, nil
+}
+
+func (c *MirrorProducts) Execute(args []string) error {
+	var specs []mirrorProductSpec
+	for _, product := range c.Options.Products {
+		spec, err := parseMirrorProductSpec(product)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+
+	if c.Options.PivnetToken == "" {
+		return errors.New("--pivnet-api-token is required")
+	}
+
+	source := download_clients.NewPivnetClient(
+		c.stdout,
+		c.stderr,
+		download_clients.DefaultPivnetFactory,
+		c.Options.PivnetToken,
+		c.Options.PivnetDisableSSL,
+		c.Options.PivnetHost,
+	)
+
+	destination, err := c.newDestination(c.Options, c.stderr)
+	if err != nil {
+		return fmt.Errorf("could not find valid destination for '%s': %w", c.Options.Destination, err)
+	}
+
+	tempDir, err := os.MkdirTemp("", "om-mirror-products")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	mirror := &productMirror{
+		source:      source,
+		destination: destination,
+		tempDir:     tempDir,
+		stderr:      c.stderr,
+		stemcells:   map[string]bool{},
+	}
+
+	for _, spec := range specs {
+		err = c.mirrorProduct(mirror, spec)
+		if err != nil {
+			return fmt.Errorf("could not mirror %s: %w", spec.slug, err)
+		}
+	}
+
+	c.stderr.Printf("mirrored %d files to %s, %d were already present", mirror.uploaded, destination.Name(), mirror.skipped)
+
+	return nil
+}
+
+func (c *MirrorProducts) mirrorProduct(mirror *productMirror, spec mirrorProductSpec) error {
+	// without a constraint, any version will do and the latest is mirrored
+	versionRegex := ""
+	if spec.versionConstraint == "" {
+		versionRegex = ".*"
+	}
+
+	version, err := download_clients.DetermineProductVersion(spec.slug, "", versionRegex, spec.versionConstraint, mirror.source, c.stderr)
+	if err != nil {
+		return err
+	}
+
+	files, err := mirror.source.GetLatestProductFiles(spec.slug, version, spec.fileGlob)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		err = mirror.mirrorFile(spec.slug, version, file, mirror.destination.ProductFileExists, mirror.destination.UploadProductFile)
+		if err != nil {
+			return err
+		}
+
+		if c.Options.StemcellIaas == "" || filepath.Ext(file.Name()) != ".pivotal" {
+			continue
+		}
+
+		err = c.mirrorStemcell(mirror, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *MirrorProducts) mirrorStemcell(mirror *productMirror, productFile download_clients.FileArtifacter) error {
+	stemcell, err := mirror.source.GetLatestStemcellForProduct(productFile, "", "")
+	if err != nil {
+		return fmt.Errorf("could not get information about stemcell: %w", err)
+	}
+
+	// products often share a stemcell
+	stemcellKey := fmt.Sprintf("[%s,%s]", stemcell.Slug(), stemcell.Version())
+	if mirror.stemcells[stemcellKey] {
+		return nil
+	}
+	mirror.stemcells[stemcellKey] = true
+
+	var stemcellFile download_clients.FileArtifacter
+	for _, glob := range stemcellFileGlobs(c.Options.StemcellIaas, c.Options.StemcellHeavy) {
+		stemcellFile, err = mirror.source.GetLatestProductFile(stemcell.Slug(), stemcell.Version(), glob)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("could not find stemcell %s %s for IaaS %q: %w", stemcell.Slug(), stemcell.Version(), c.Options.StemcellIaas, err)
+	}
+
+	return mirror.mirrorFile(stemcell.Slug(), stemcell.Version(), stemcellFile, mirror.destination.StemcellFileExists, mirror.destination.UploadStemcellFile)
+}
+
+type productMirror struct {
+	source      download_clients.ProductDownloader
+	destination download_clients.ProductUploader
+	tempDir     string
+	stderr      *log.Logger
+	stemcells   map[string]bool
+	uploaded    int
+	skipped     int
+}
+
+// mirrorFile copies the file to the destination under the [slug,version]
+// prefixed name download-product expects, unless it is already there. It is
+// staged in the temporary directory one file at a time.
+func (m *productMirror) mirrorFile(
+	slug, version string,
+	file download_clients.FileArtifacter,
+	exists func(string) (bool, error),
+	upload func(string, *os.File) error,
+) error {
+	fileName := fmt.Sprintf("[%s,%s]%s", slug, version, filepath.Base(file.Name()))
+
+	found, err := exists(fileName)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		m.stderr.Printf("%s is already on %s, skipping", fileName, m.destination.Name())
+		m.skipped++
+		return nil
+	}
+
+	m.stderr.Printf("mirroring %s from %s to %s", fileName, m.source.Name(), m.destination.Name())
+
+	stagedFilePath := filepath.Join(m.tempDir, fileName)
+	stagedFile, err := os.Create(stagedFilePath)
+	if err != nil {
+		return fmt.Errorf("could not create file %s: %w", stagedFilePath, err)
+	}
+	defer os.Remove(stagedFilePath)
+	defer stagedFile.Close()
+
+	err = m.source.DownloadProductToFile(file, stagedFile)
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", file.Name(), err)
+	}
+
+	if file.SHA256() != "" {
+		calculatedSum, err := validator.NewSHA256Calculator().Checksum(stagedFilePath)
+		if err != nil {
+			return fmt.Errorf("could not calculate the sha for the file %s: %w", fileName, err)
+		}
+
+		if calculatedSum != file.SHA256() {
+			return fmt.Errorf("the sha (%s) from %s does not match the calculated sha (%s) for the file %s", file.SHA256(), m.source.Name(), calculatedSum, fileName)
+		}
+	}
+
+	_, err = stagedFile.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	err = upload(fileName, stagedFile)
+	if err != nil {
+		return err
+	}
+
+	m.uploaded++
+	return nil
+}
@@ -0,0 +1,164 @@
+package commands_test
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/download_clients"
+	"github.com/pivotal-cf/om/download_clients/fakes"
+)
+
+var _ = Describe("MirrorProducts", func() {
+	var (
+		command     *commands.MirrorProducts
+		source      *fakes.ProductDownloader
+		destination *fakes.ProductUploader
+		stderr      *gbytes.Buffer
+		uploaded    map[string]string
+	)
+
+	BeforeEach(func() {
+		source = &fakes.ProductDownloader{}
+		source.NameReturns("pivnet")
+		source.GetAllProductVersionsReturns([]string{"2.13.1", "2.13.4", "3.0.0"}, nil)
+		source.DownloadProductToFileStub = func(fa download_clients.FileArtifacter, file *os.File) error {
+			_, err := file.WriteString("contents of " + fa.Name())
+			return err
+		}
+
+		destination = &fakes.ProductUploader{}
+		destination.NameReturns("s3")
+
+		uploaded = map[string]string{}
+		upload := func(fileName string, file *os.File) error {
+			contents, err := io.ReadAll(file)
+			uploaded[fileName] = string(contents)
+			return err
+		}
+		destination.UploadProductFileStub = upload
+		destination.UploadStemcellFileStub = upload
+
+		download_clients.NewPivnetClient = func(stdout *log.Logger, stderr *log.Logger, factory download_clients.PivnetFactory, token string, skipSSL bool, pivnetHost string) download_clients.ProductDownloader {
+			Expect(token).To(Equal("some-token"))
+			return source
+		}
+
+		stderr = gbytes.NewBuffer()
+		command = commands.NewMirrorProducts(log.New(GinkgoWriter, "", 0), log.New(stderr, "", 0), func(options commands.MirrorProductsOptions, _ *log.Logger) (download_clients.ProductUploader, error) {
+			Expect(options.Destination).To(Equal("s3"))
+			Expect(options.Bucket).To(Equal("mirror"))
+			return destination, nil
+		})
+	})
+
+	fileArtifact := func(name, sha string) *fakes.FileArtifacter {
+		fa := &fakes.FileArtifacter{}
+		fa.NameReturns(name)
+		fa.SHA256Returns(sha)
+		return fa
+	}
+
+	It("uploads the latest matching files that are missing from the blobstore", func() {
+		source.GetLatestProductFilesReturns([]download_clients.FileArtifacter{
+			fileArtifact("product-files/srt-2.13.4.pivotal", ""),
+			fileArtifact("product-files/srt-2.13.4.yml", ""),
+		}, nil)
+		destination.ProductFileExistsCalls(func(fileName string) (bool, error) {
+			return fileName == "[cf,2.13.4]srt-2.13.4.yml", nil
+		})
+
+		err := executeCommand(command, []string{
+			"--product", "cf:>= 2.13, < 3:srt-*",
+			"--destination", "s3",
+			"--blobstore-bucket", "mirror",
+			"--pivnet-api-token", "some-token",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		slug, version, glob := source.GetLatestProductFilesArgsForCall(0)
+		Expect([]string{slug, version, glob}).To(Equal([]string{"cf", "2.13.4", "srt-*"}))
+
+		Expect(uploaded).To(Equal(map[string]string{
+			"[cf,2.13.4]srt-2.13.4.pivotal": "contents of product-files/srt-2.13.4.pivotal",
+		}))
+		Expect(stderr).To(gbytes.Say(`\[cf,2.13.4\]srt-2.13.4.yml is already on s3, skipping`))
+		Expect(stderr).To(gbytes.Say(`mirrored 1 files to s3, 1 were already present`))
+	})
+
+	It("mirrors the stemcell of each tile once", func() {
+		source.GetLatestProductFilesReturns([]download_clients.FileArtifacter{
+			fileArtifact("srt-2.13.4.pivotal", ""),
+		}, nil)
+
+		stemcell := &fakes.StemcellArtifacter{}
+		stemcell.SlugReturns("stemcells-ubuntu-jammy")
+		stemcell.VersionReturns("1.100")
+		source.GetLatestStemcellForProductReturns(stemcell, nil)
+		source.GetLatestProductFileReturns(fileArtifact("light-bosh-stemcell-1.100-aws-xen-hvm-ubuntu-jammy-go_agent.tgz", ""), nil)
+
+		err := executeCommand(command, []string{
+			"--product", "cf",
+			"--product", "p-isolation-segment",
+			"--destination", "s3",
+			"--blobstore-bucket", "mirror",
+			"--pivnet-api-token", "some-token",
+			"--stemcell-iaas", "aws",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(source.GetLatestStemcellForProductCallCount()).To(Equal(2))
+		Expect(destination.UploadStemcellFileCallCount()).To(Equal(1))
+
+		slug, version, glob := source.GetLatestProductFileArgsForCall(0)
+		Expect([]string{slug, version, glob}).To(Equal([]string{"stemcells-ubuntu-jammy", "1.100", "light*bosh*aws*"}))
+		Expect(uploaded).To(HaveKey("[stemcells-ubuntu-jammy,1.100]light-bosh-stemcell-1.100-aws-xen-hvm-ubuntu-jammy-go_agent.tgz"))
+		Expect(uploaded).To(HaveKey("[cf,3.0.0]srt-2.13.4.pivotal"))
+		Expect(uploaded).To(HaveKey("[p-isolation-segment,3.0.0]srt-2.13.4.pivotal"))
+	})
+
+	It("does not upload a file that does not match the sha from the source", func() {
+		source.GetLatestProductFilesReturns([]download_clients.FileArtifacter{
+			fileArtifact("srt-2.13.4.pivotal", "not-the-sha"),
+		}, nil)
+
+		err := executeCommand(command, []string{
+			"--product", "cf",
+			"--destination", "s3",
+			"--blobstore-bucket", "mirror",
+			"--pivnet-api-token", "some-token",
+		})
+		Expect(err).To(MatchError(ContainSubstring("could not mirror cf: the sha (not-the-sha) from pivnet does not match the calculated sha")))
+		Expect(destination.UploadProductFileCallCount()).To(Equal(0))
+	})
+
+	It("requires each product to have a slug", func() {
+		err := executeCommand(command, []string{
+			"--product", ":~> 2.13",
+			"--destination", "s3",
+			"--blobstore-bucket", "mirror",
+			"--pivnet-api-token", "some-token",
+		})
+		Expect(err).To(MatchError(`the product ":~> 2.13" must start with a slug`))
+	})
+
+	It("returns an error when the destination cannot be used", func() {
+		command = commands.NewMirrorProducts(log.New(GinkgoWriter, "", 0), log.New(stderr, "", 0), func(commands.MirrorProductsOptions, *log.Logger) (download_clients.ProductUploader, error) {
+			return nil, errors.New("bad credentials")
+		})
+
+		err := executeCommand(command, []string{
+			"--product", "cf",
+			"--destination", "s3",
+			"--blobstore-bucket", "mirror",
+			"--pivnet-api-token", "some-token",
+		})
+		Expect(err).To(MatchError("could not find valid destination for 's3': bad credentials"))
+	})
+})
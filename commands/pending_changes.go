@@ -1,9 +1,14 @@
 package commands
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/presenters"
 )
@@ -12,8 +17,9 @@ type PendingChanges struct {
 	service   pendingChangesService
 	presenter presenters.FormattedPresenter
 	Options   struct {
-		Check  bool   `long:"check" description:"Exit 1 if there are any pending changes. Useful for validating that Ops Manager is in a clean state."`
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Check  bool `long:"check"  description:"Exit 2 if there are pending changes that can be applied, or 5 if some of them are incomplete. Useful for validating that Ops Manager is in a clean state."`
+		Detail bool `long:"detail" description:"show why each product is changing: a new version, a stemcell, a release or a configuration change, or errands to run"`
+		formatOptions
 	}
 	logger logger
 }
@@ -21,8 +27,32 @@ type PendingChanges struct {
 //counterfeiter:generate -o ./fakes/pending_changes_service.go --fake-name PendingChangesService . pendingChangesService
 type pendingChangesService interface {
 	ListStagedPendingChanges() (api.PendingChangesOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	GetStagedProductManifest(guid string) (string, error)
+	GetDeployedProductManifest(guid string) (string, error)
+	GetStagedDirectorManifest() (string, error)
+	GetDeployedDirectorManifest() (string, error)
+}
+
+// ErrPendingChangesExist and ErrPendingChangesIncomplete have their own exit
+// codes, so that pipelines can tell an apply that is needed from one that
+// would fail.
+var (
+	ErrPendingChangesExist      = errors.New("there are pending changes")
+	ErrPendingChangesIncomplete = errors.New("there are incomplete pending changes")
+)
+
+// pendingChangesError keeps the list of problems as the message while
+// matching one of the errors above with errors.Is.
+type pendingChangesError struct {
+	message string
+	err     error
 }
 
+func (e pendingChangesError) Error() string { return e.message }
+func (e pendingChangesError) Unwrap() error { return e.err }
+
 func NewPendingChanges(presenter presenters.FormattedPresenter, service pendingChangesService, logger logger) *PendingChanges {
 	return &PendingChanges{
 		service:   service,
@@ -37,6 +67,13 @@ func (pc PendingChanges) Execute(args []string) error {
 		return fmt.Errorf("failed to retrieve pending changes %s", err)
 	}
 
+	if pc.Options.Detail {
+		output, err = pc.withReasons(output)
+		if err != nil {
+			return err
+		}
+	}
+
 	pc.presenter.SetFormat(pc.Options.Format)
 	pc.presenter.PresentPendingChanges(output)
 
@@ -57,6 +94,8 @@ func (pc PendingChanges) Execute(args []string) error {
 		}
 	}
 
+	incomplete := len(errs) > 0
+
 	for _, ProductChange := range output.ChangeList {
 		if ProductChange.Action != "unchanged" {
 			errs = append(errs, "there are pending changes.\nGo into the Ops Manager UI, unstage changes, and try again")
@@ -66,10 +105,174 @@ func (pc PendingChanges) Execute(args []string) error {
 
 	if len(errs) > 0 {
 		if pc.Options.Check {
-			return fmt.Errorf("%s\nPlease validate your Ops Manager installation in the UI", strings.Join(errs, ",\n"))
+			result := ErrPendingChangesExist
+			if incomplete {
+				result = ErrPendingChangesIncomplete
+			}
+
+			return pendingChangesError{
+				message: fmt.Sprintf("%s\nPlease validate your Ops Manager installation in the UI", strings.Join(errs, ",\n")),
+				err:     result,
+			}
 		}
 
 		pc.logger.Printf("Warnings:\n%s", strings.Join(errs, ",\n"))
 	}
 	return nil
 }
+
+// withReasons compares each changing product with what is deployed. The
+// reasons are added to the JSON report too, keeping the fields from the API.
+func (pc PendingChanges) withReasons(output api.PendingChangesOutput) (api.PendingChangesOutput, error) {
+	stagedProducts, err := pc.service.ListStagedProducts()
+	if err != nil {
+		return output, fmt.Errorf("could not list staged products: %s", err)
+	}
+
+	deployedProducts, err := pc.service.ListDeployedProducts()
+	if err != nil {
+		return output, fmt.Errorf("could not list deployed products: %s", err)
+	}
+
+	staged := map[string]api.StagedProduct{}
+	for _, product := range stagedProducts.Products {
+		staged[product.GUID] = product
+	}
+
+	deployed := map[string]api.DeployedProductOutput{}
+	for _, product := range deployedProducts {
+		deployed[product.GUID] = product
+	}
+
+	reasons := map[string][]string{}
+	for i, change := range output.ChangeList {
+		if change.Action == "unchanged" {
+			continue
+		}
+
+		change.Reasons, err = pc.reasons(change, staged[change.GUID], deployed[change.GUID])
+		if err != nil {
+			return output, fmt.Errorf("could not determine why %s is changing: %s", change.GUID, err)
+		}
+
+		output.ChangeList[i] = change
+		reasons[change.GUID] = change.Reasons
+	}
+
+	if output.FullReport != "" {
+		var report []map[string]interface{}
+		err = json.Unmarshal([]byte(output.FullReport), &report)
+		if err != nil {
+			return output, fmt.Errorf("could not parse pending changes report: %s", err) // not tested
+		}
+
+		for _, change := range report {
+			if guid, ok := change["guid"].(string); ok && len(reasons[guid]) > 0 {
+				change["reasons"] = reasons[guid]
+			}
+		}
+
+		contents, err := json.Marshal(report)
+		if err != nil {
+			return output, err // not tested
+		}
+		output.FullReport = string(contents)
+	}
+
+	return output, nil
+}
+
+func (pc PendingChanges) reasons(change api.ProductChange, staged api.StagedProduct, deployed api.DeployedProductOutput) ([]string, error) {
+	var reasons []string
+
+	switch change.Action {
+	case "install":
+		reasons = append(reasons, fmt.Sprintf("not deployed yet (version %s)", staged.ProductVersion))
+	case "delete":
+		reasons = append(reasons, "staged for deletion")
+	default:
+		versionChanged := staged.ProductVersion != deployed.ProductVersion
+		if versionChanged {
+			reasons = append(reasons, fmt.Sprintf("new version staged (%s -> %s)", deployed.ProductVersion, staged.ProductVersion))
+		}
+
+		manifestReasons, err := pc.manifestReasons(staged.Type, change.GUID, versionChanged)
+		if err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, manifestReasons...)
+	}
+
+	if len(change.Errands) > 0 {
+		var names []string
+		for _, errand := range change.Errands {
+			names = append(names, errand.Name)
+		}
+		reasons = append(reasons, "errands to run: "+strings.Join(names, ", "))
+	}
+
+	return reasons, nil
+}
+
+// manifestReasons groups the differences between the staged and deployed
+// manifests. Release changes are expected with a new version, so they are only
+// reported on their own.
+func (pc PendingChanges) manifestReasons(productType, guid string, versionChanged bool) ([]string, error) {
+	var (
+		stagedManifest, deployedManifest string
+		err                              error
+	)
+	if productType == "p-bosh" {
+		stagedManifest, err = pc.service.GetStagedDirectorManifest()
+		if err == nil {
+			deployedManifest, err = pc.service.GetDeployedDirectorManifest()
+		}
+	} else {
+		stagedManifest, err = pc.service.GetStagedProductManifest(guid)
+		if err == nil {
+			deployedManifest, err = pc.service.GetDeployedProductManifest(guid)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stagedContents, deployedContents map[string]interface{}
+	if err = yaml.Unmarshal([]byte(stagedManifest), &stagedContents); err != nil {
+		return nil, fmt.Errorf("could not parse staged manifest: %s", err)
+	}
+	if err = yaml.Unmarshal([]byte(deployedManifest), &deployedContents); err != nil {
+		return nil, fmt.Errorf("could not parse deployed manifest: %s", err)
+	}
+
+	var stemcellChanged, releaseChanged, configurationChanged bool
+	for _, contents := range []map[string]interface{}{stagedContents, deployedContents} {
+		for key := range contents {
+			if reflect.DeepEqual(stagedContents[key], deployedContents[key]) {
+				continue
+			}
+
+			switch key {
+			case "stemcells":
+				stemcellChanged = true
+			case "releases":
+				releaseChanged = true
+			default:
+				configurationChanged = true
+			}
+		}
+	}
+
+	var reasons []string
+	if stemcellChanged {
+		reasons = append(reasons, "stemcell change")
+	}
+	if releaseChanged && !versionChanged {
+		reasons = append(reasons, "release change")
+	}
+	if configurationChanged {
+		reasons = append(reasons, "configuration change")
+	}
+
+	return reasons, nil
+}
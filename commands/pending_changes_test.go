@@ -136,6 +136,7 @@ var _ = Describe("PendingChanges.Execute", func() {
 				err := executeCommand(command, options)
 				Expect(presenter.PresentPendingChangesCallCount()).To(Equal(1))
 				Expect(err).To(HaveOccurred())
+				Expect(errors.Is(err, commands.ErrPendingChangesExist)).To(BeTrue())
 			})
 		})
 
@@ -178,6 +179,7 @@ var _ = Describe("PendingChanges.Execute", func() {
 					Expect(presenter.PresentPendingChangesCallCount()).To(Equal(1))
 					Expect(err).To(MatchError(ContainSubstring("configuration is incomplete for guid some-product-without-errands")))
 					Expect(err).To(MatchError(ContainSubstring("Please validate your Ops Manager installation in the UI")))
+					Expect(errors.Is(err, commands.ErrPendingChangesIncomplete)).To(BeTrue())
 				})
 
 				It("returns an error for stemcell_present: false", func() {
@@ -291,6 +293,88 @@ var _ = Describe("PendingChanges.Execute", func() {
 		})
 	})
 
+	When("the detail flag is provided", func() {
+		BeforeEach(func() {
+			pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{GUID: "p-bosh-guid", Action: "unchanged"},
+					{GUID: "cf-guid", Action: "update", Errands: []api.Errand{{Name: "smoke_tests"}}},
+					{GUID: "mysql-guid", Action: "update"},
+					{GUID: "redis-guid", Action: "install"},
+					{GUID: "dataflow-guid", Action: "delete"},
+				},
+				FullReport: `[{"guid":"p-bosh-guid","action":"unchanged"},{"guid":"cf-guid","action":"update","extra":true}]`,
+			}, nil)
+			pcService.ListStagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "p-bosh-guid", Type: "p-bosh", ProductVersion: "3.0.0"},
+					{GUID: "cf-guid", Type: "cf", ProductVersion: "6.0.1"},
+					{GUID: "mysql-guid", Type: "p-mysql", ProductVersion: "3.1.0"},
+					{GUID: "redis-guid", Type: "p-redis", ProductVersion: "3.2.0"},
+				},
+			}, nil)
+			pcService.ListDeployedProductsReturns([]api.DeployedProductOutput{
+				{GUID: "p-bosh-guid", Type: "p-bosh", ProductVersion: "3.0.0"},
+				{GUID: "cf-guid", Type: "cf", ProductVersion: "6.0.0"},
+				{GUID: "mysql-guid", Type: "p-mysql", ProductVersion: "3.1.0"},
+				{GUID: "dataflow-guid", Type: "p-dataflow", ProductVersion: "1.0.0"},
+			}, nil)
+			pcService.GetStagedProductManifestStub = func(guid string) (string, error) {
+				if guid == "cf-guid" {
+					return "name: cf\nreleases: [{name: cf, version: 2}]\nstemcells: [{version: 2}]\n", nil
+				}
+				return "name: p-mysql\nreleases: [{name: mysql, version: 2}]\nstemcells: [{version: 1}]\nfeatures: {a: b}\n", nil
+			}
+			pcService.GetDeployedProductManifestStub = func(guid string) (string, error) {
+				if guid == "cf-guid" {
+					return "name: cf\nreleases: [{name: cf, version: 1}]\nstemcells: [{version: 1}]\n", nil
+				}
+				return "name: p-mysql\nreleases: [{name: mysql, version: 1}]\nstemcells: [{version: 1}]\n", nil
+			}
+		})
+
+		It("shows why each product is changing", func() {
+			err := executeCommand(command, []string{"--detail"})
+			Expect(err).ToNot(HaveOccurred())
+
+			output := presenter.PresentPendingChangesArgsForCall(0)
+			Expect(output.ChangeList[0].Reasons).To(BeEmpty())
+			Expect(output.ChangeList[1].Reasons).To(Equal([]string{"new version staged (6.0.0 -> 6.0.1)", "stemcell change", "errands to run: smoke_tests"}))
+			Expect(output.ChangeList[2].Reasons).To(Equal([]string{"release change", "configuration change"}))
+			Expect(output.ChangeList[3].Reasons).To(Equal([]string{"not deployed yet (version 3.2.0)"}))
+			Expect(output.ChangeList[4].Reasons).To(Equal([]string{"staged for deletion"}))
+
+			Expect(output.FullReport).To(MatchJSON(`[
+				{"guid":"p-bosh-guid","action":"unchanged"},
+				{"guid":"cf-guid","action":"update","extra":true,"reasons":["new version staged (6.0.0 -> 6.0.1)","stemcell change","errands to run: smoke_tests"]}
+			]`))
+		})
+
+		It("compares the director manifests for the director", func() {
+			pcService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{{GUID: "p-bosh-guid", Action: "update"}},
+			}, nil)
+			pcService.GetStagedDirectorManifestReturns("name: p-bosh\nproperties: {a: 2}\n", nil)
+			pcService.GetDeployedDirectorManifestReturns("name: p-bosh\nproperties: {a: 1}\n", nil)
+
+			err := executeCommand(command, []string{"--detail"})
+			Expect(err).ToNot(HaveOccurred())
+
+			output := presenter.PresentPendingChangesArgsForCall(0)
+			Expect(output.ChangeList[0].Reasons).To(Equal([]string{"configuration change"}))
+			Expect(pcService.GetStagedProductManifestCallCount()).To(Equal(0))
+		})
+
+		It("returns an error when a manifest cannot be fetched", func() {
+			pcService.GetDeployedProductManifestStub = nil
+			pcService.GetDeployedProductManifestReturns("", errors.New("some error"))
+
+			err := executeCommand(command, []string{"--detail"})
+			Expect(err).To(MatchError("could not determine why cf-guid is changing: some error"))
+			Expect(presenter.PresentPendingChangesCallCount()).To(Equal(0))
+		})
+	})
+
 	When("the format flag is provided", func() {
 		It("sets the format on the presenter", func() {
 			err := executeCommand(command, []string{"--format", "json"})
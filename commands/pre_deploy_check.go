@@ -1,8 +1,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -15,10 +17,31 @@ type PreDeployCheck struct {
 	presenter presenters.FormattedPresenter
 	logger    logger
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Format          string   `long:"format"          short:"f" default:"table" choice:"table" choice:"json" description:"Format to print as (options: table,json)"`
+		IgnoreVerifiers []string `long:"ignore-verifier"                           description:"verifier type whose failures do not fail the check. Can be repeated"`
 	}
 }
 
+type preDeployCheckOutput struct {
+	Complete bool                   `json:"complete"`
+	Checks   []preDeployCheckResult `json:"checks"`
+}
+
+type preDeployCheckResult struct {
+	Scope      string           `json:"scope"`
+	Identifier string           `json:"identifier"`
+	Complete   bool             `json:"complete"`
+	Issues     []preDeployIssue `json:"issues"`
+}
+
+type preDeployIssue struct {
+	Kind      string   `json:"kind"`
+	Name      string   `json:"name,omitempty"`
+	Severity  string   `json:"severity"`
+	Ignorable bool     `json:"ignorable,omitempty"`
+	Messages  []string `json:"messages,omitempty"`
+}
+
 //counterfeiter:generate -o ./fakes/pre_deploy_check_service.go --fake-name PreDeployCheckService . preDeployCheckService
 type preDeployCheckService interface {
 	Info() (api.Info, error)
@@ -37,7 +60,10 @@ func NewPreDeployCheck(presenter presenters.FormattedPresenter, service preDeplo
 func (pc PreDeployCheck) Execute(args []string) error {
 	var errorBuffer []string
 
-	pc.logger.Println("Scanning OpsManager now ...\n")
+	text := pc.Options.Format != "json"
+	if text {
+		pc.logger.Println("Scanning OpsManager now ...\n")
+	}
 
 	info, err := pc.service.Info()
 	if err != nil {
@@ -52,12 +78,15 @@ func (pc PreDeployCheck) Execute(args []string) error {
 		return fmt.Errorf("while getting director: %s", err)
 	}
 
-	directorOk := pendingDirectorChanges.EndpointResults.Complete
+	output := preDeployCheckOutput{Complete: true}
+
+	directorOk, ignored := pc.ignoreVerifiers(&pendingDirectorChanges.EndpointResults)
+	output.Checks = append(output.Checks, pc.result("director", directorOk, pendingDirectorChanges.EndpointResults, ignored))
 	if !directorOk {
 		errs := pc.determineDirectorErrors(pendingDirectorChanges)
 		errorBuffer = append(errorBuffer, errs...)
-	} else {
-		pc.logger.Printf(color.GreenString("[✓] director: %s", pendingDirectorChanges.EndpointResults.Identifier))
+	} else if text {
+		pc.logger.Printf(color.GreenString("[✓] director: %s%s", pendingDirectorChanges.EndpointResults.Identifier, ignoredVerifiersNote(ignored)))
 	}
 
 	pendingProductChanges, err := pc.service.ListAllPendingProductChanges()
@@ -70,12 +99,30 @@ func (pc PreDeployCheck) Execute(args []string) error {
 			continue
 		}
 
-		if !change.EndpointResults.Complete {
+		productOk, ignored := pc.ignoreVerifiers(&change.EndpointResults)
+		output.Checks = append(output.Checks, pc.result("product", productOk, change.EndpointResults, ignored))
+		if !productOk {
 			errs := pc.determineProductErrors(change)
 			errorBuffer = append(errorBuffer, errs...)
-		} else {
-			pc.logger.Printf(color.GreenString("[✓] product: %s", change.EndpointResults.Identifier))
+		} else if text {
+			pc.logger.Printf(color.GreenString("[✓] product: %s%s", change.EndpointResults.Identifier, ignoredVerifiersNote(ignored)))
+		}
+	}
+
+	if !text {
+		output.Complete = len(errorBuffer) == 0
+
+		contents, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return err
+		}
+		pc.logger.Println(string(contents))
+
+		if !output.Complete {
+			return errors.New("OpsManager is not fully configured")
 		}
+
+		return nil
 	}
 
 	if len(errorBuffer) > 0 {
@@ -88,6 +135,110 @@ func (pc PreDeployCheck) Execute(args []string) error {
 	return nil
 }
 
+// ignoreVerifiers removes the --ignore-verifier verifiers from the check and
+// reports whether it passes without them. A check that is incomplete for a
+// reason the pre deploy check does not list still fails.
+func (pc PreDeployCheck) ignoreVerifiers(check *api.PreDeployCheck) (bool, []api.PreDeployVerifier) {
+	if check.Complete {
+		return true, nil
+	}
+
+	var remaining, ignored []api.PreDeployVerifier
+	for _, verifier := range check.Verifiers {
+		if slices.Contains(pc.Options.IgnoreVerifiers, verifier.Type) {
+			ignored = append(ignored, verifier)
+		} else {
+			remaining = append(remaining, verifier)
+		}
+	}
+	check.Verifiers = remaining
+
+	return len(ignored) > 0 && len(preDeployIssues(*check)) == 0, ignored
+}
+
+func (pc PreDeployCheck) result(scope string, ok bool, check api.PreDeployCheck, ignored []api.PreDeployVerifier) preDeployCheckResult {
+	issues := preDeployIssues(check)
+	for _, verifier := range ignored {
+		issues = append(issues, preDeployIssue{
+			Kind:      "verifier",
+			Name:      verifier.Type,
+			Severity:  "ignored",
+			Ignorable: verifier.Ignorable,
+			Messages:  verifier.Errors,
+		})
+	}
+
+	if issues == nil {
+		issues = []preDeployIssue{}
+	}
+
+	return preDeployCheckResult{
+		Scope:      scope,
+		Identifier: check.Identifier,
+		Complete:   ok,
+		Issues:     issues,
+	}
+}
+
+func preDeployIssues(check api.PreDeployCheck) []preDeployIssue {
+	if check.Complete {
+		return nil
+	}
+
+	var issues []preDeployIssue
+	if !check.Network.Assigned {
+		issues = append(issues, preDeployIssue{Kind: "network", Severity: "error", Messages: []string{"Network is not assigned"}})
+	}
+
+	if !check.AvailabilityZone.Assigned {
+		issues = append(issues, preDeployIssue{Kind: "availability_zone", Severity: "error", Messages: []string{"Availability Zone is not assigned"}})
+	}
+
+	for _, stemcell := range check.Stemcells {
+		if !stemcell.Assigned {
+			issues = append(issues, preDeployIssue{
+				Kind:     "stemcell",
+				Name:     stemcell.RequiredStemcellOS,
+				Severity: "error",
+				Messages: []string{fmt.Sprintf("Required stemcell OS: %s version %s", stemcell.RequiredStemcellOS, stemcell.RequiredStemcellVersion)},
+			})
+		}
+	}
+
+	for _, property := range check.Properties {
+		issues = append(issues, preDeployIssue{Kind: "property", Name: property.Name, Severity: "error", Messages: property.Errors})
+	}
+
+	for _, job := range check.Resources.Jobs {
+		issues = append(issues, preDeployIssue{Kind: "resource", Name: job.Identifier, Severity: "error", Messages: job.Errors})
+	}
+
+	for _, verifier := range check.Verifiers {
+		issues = append(issues, preDeployIssue{
+			Kind:      "verifier",
+			Name:      verifier.Type,
+			Severity:  "error",
+			Ignorable: verifier.Ignorable,
+			Messages:  verifier.Errors,
+		})
+	}
+
+	return issues
+}
+
+func ignoredVerifiersNote(ignored []api.PreDeployVerifier) string {
+	if len(ignored) == 0 {
+		return ""
+	}
+
+	var types []string
+	for _, verifier := range ignored {
+		types = append(types, verifier.Type)
+	}
+
+	return fmt.Sprintf(" (ignored verifiers: %s)", strings.Join(types, ", "))
+}
+
 var boldError = color.New(color.Bold)
 
 func (pc PreDeployCheck) determineDirectorErrors(directorOutput api.PendingDirectorChangesOutput) []string {
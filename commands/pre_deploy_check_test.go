@@ -391,6 +391,103 @@ var _ = Describe("PreDeployCheck.Execute", func() {
 		})
 	})
 
+	When("a failing verifier is ignored", func() {
+		BeforeEach(func() {
+			service.ListAllPendingProductChangesReturns([]api.PendingProductChangesOutput{
+				{
+					EndpointResults: api.PreDeployCheck{
+						Identifier:       "p-guid",
+						Complete:         false,
+						Network:          api.PreDeployNetwork{Assigned: true},
+						AvailabilityZone: api.PreDeployAvailabilityZone{Assigned: true},
+						Verifiers: []api.PreDeployVerifier{
+							{Type: "SomeVerifier", Errors: []string{"some verifier failed"}, Ignorable: true},
+						},
+					},
+				},
+			}, nil)
+		})
+
+		It("passes the product", func() {
+			command := commands.NewPreDeployCheck(presenter, service, logger)
+			err := executeCommand(command, []string{"--ignore-verifier", "SomeVerifier"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(stdout.Contents())).To(ContainSubstring("[✓] product: p-guid (ignored verifiers: SomeVerifier)"))
+		})
+
+		It("still fails for the other issues of the product", func() {
+			service.ListAllPendingProductChangesReturns([]api.PendingProductChangesOutput{
+				{
+					EndpointResults: api.PreDeployCheck{
+						Identifier: "p-guid",
+						Complete:   false,
+						Verifiers: []api.PreDeployVerifier{
+							{Type: "SomeVerifier", Errors: []string{"some verifier failed"}},
+						},
+					},
+				},
+			}, nil)
+
+			command := commands.NewPreDeployCheck(presenter, service, logger)
+			err := executeCommand(command, []string{"--ignore-verifier", "SomeVerifier"})
+			Expect(err).To(MatchError("OpsManager is not fully configured"))
+
+			Expect(string(stdout.Contents())).To(ContainSubstring("Network is not assigned"))
+			Expect(string(stdout.Contents())).ToNot(ContainSubstring("SomeVerifier"))
+		})
+	})
+
+	When("the format is json", func() {
+		It("lists every check and its issues", func() {
+			service.ListAllPendingProductChangesReturns([]api.PendingProductChangesOutput{
+				{
+					EndpointResults: api.PreDeployCheck{
+						Identifier:       "p-guid",
+						Complete:         false,
+						Network:          api.PreDeployNetwork{Assigned: true},
+						AvailabilityZone: api.PreDeployAvailabilityZone{Assigned: true},
+						Properties: []api.PreDeployProperty{
+							{Name: ".properties.some-property", Errors: []string{"can't be blank"}},
+						},
+						Verifiers: []api.PreDeployVerifier{
+							{Type: "SomeVerifier", Errors: []string{"some verifier failed"}, Ignorable: true},
+						},
+					},
+				},
+			}, nil)
+
+			command := commands.NewPreDeployCheck(presenter, service, logger)
+			err := executeCommand(command, []string{"--format", "json", "--ignore-verifier", "SomeVerifier"})
+			Expect(err).To(MatchError("OpsManager is not fully configured"))
+
+			Expect(stdout.Contents()).To(MatchJSON(`{
+				"complete": false,
+				"checks": [
+					{"scope": "director", "identifier": "p-bosh-guid", "complete": true, "issues": []},
+					{"scope": "product", "identifier": "p-guid", "complete": false, "issues": [
+						{"kind": "property", "name": ".properties.some-property", "severity": "error", "messages": ["can't be blank"]},
+						{"kind": "verifier", "name": "SomeVerifier", "severity": "ignored", "ignorable": true, "messages": ["some verifier failed"]}
+					]}
+				]
+			}`))
+		})
+
+		It("is complete when everything is configured", func() {
+			command := commands.NewPreDeployCheck(presenter, service, logger)
+			err := executeCommand(command, []string{"--format", "json"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stdout.Contents()).To(MatchJSON(`{
+				"complete": true,
+				"checks": [
+					{"scope": "director", "identifier": "p-bosh-guid", "complete": true, "issues": []},
+					{"scope": "product", "identifier": "p-guid", "complete": true, "issues": []}
+				]
+			}`))
+		})
+	})
+
 	It("only works for version 2.6+", func() {
 		for _, validVersion := range []string{"2.6.0", "2.7.0", "2.8.0"} {
 			service.InfoReturns(api.Info{Version: validVersion}, nil)
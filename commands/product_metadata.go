@@ -1,10 +1,13 @@
 package commands
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/pivotal-cf/om/configtemplate/generator"
 	"github.com/pivotal-cf/om/configtemplate/metadata"
+	"github.com/pivotal-cf/om/extractor"
+	"gopkg.in/yaml.v2"
 )
 
 type ProductMetadata struct {
@@ -17,6 +20,10 @@ type ProductMetadata struct {
 
 		ProductName    bool `long:"product-name"    description:"show product name"`
 		ProductVersion bool `long:"product-version" description:"show product version"`
+		Requirements   bool `long:"requirements"    description:"show the products, stemcells and Ops Manager version the product requires"`
+		Full           bool `long:"full"            description:"show the requirements, and the releases, instance groups and errands of the product"`
+
+		Format string `long:"format" default:"yaml" choice:"yaml" choice:"json" description:"format of the requirements and the full metadata"`
 
 		PivnetApiToken       string `long:"pivnet-api-token"`
 		PivnetProductSlug    string `long:"pivnet-product-slug"    description:"the product name in pivnet"`
@@ -46,7 +53,7 @@ func NewProductMetadata(bp productMetadataBuildProvider, stdout logger) *Product
 func newProductMetadata(bp productMetadataBuildProvider, stdout logger) *ProductMetadata {
 	return &ProductMetadata{
 		buildProvider: bp,
-		stdout: stdout,
+		stdout:        stdout,
 	}
 }
 
@@ -62,6 +69,10 @@ func (t ProductMetadata) Execute(args []string) error {
 		return fmt.Errorf("error getting metadata for %s at version %s: %s", t.Options.PivnetProductSlug, t.Options.PivnetProductVersion, err)
 	}
 
+	if t.Options.Requirements || t.Options.Full {
+		return t.printDocument(metadataBytes)
+	}
+
 	meta, err := generator.NewMetadata(metadataBytes)
 	if err != nil {
 		return err
@@ -83,8 +94,16 @@ func (t *ProductMetadata) newMetadataSource() (metadataSource MetadataProvider)
 }
 
 func (t *ProductMetadata) Validate() error {
-	if !t.Options.ProductName && !t.Options.ProductVersion {
-		return errors.New("you must specify product-name and/or product-version")
+	if !t.Options.ProductName && !t.Options.ProductVersion && !t.Options.Requirements && !t.Options.Full {
+		return errors.New("you must specify product-name and/or product-version, requirements or full")
+	}
+
+	if t.Options.Requirements && t.Options.Full {
+		return errors.New("requirements cannot be combined with full, they are part of the full metadata")
+	}
+
+	if (t.Options.Requirements || t.Options.Full) && (t.Options.ProductName || t.Options.ProductVersion) {
+		return errors.New("requirements and full cannot be combined with product-name or product-version, they are part of both")
 	}
 
 	if t.Options.PivnetApiToken != "" && t.Options.PivnetProductSlug != "" && t.Options.PivnetProductVersion != "" && t.Options.ProductPath == "" {
@@ -97,3 +116,180 @@ func (t *ProductMetadata) Validate() error {
 
 	return errors.New("cannot load tile metadata: please provide either pivnet flags OR product-path")
 }
+
+type productRequirements struct {
+	ProductName                 string                        `json:"product-name"                  yaml:"product-name"`
+	ProductVersion              string                        `json:"product-version"               yaml:"product-version"`
+	MinimumOpsManagerVersion    string                        `json:"minimum-opsman-version"        yaml:"minimum-opsman-version"`
+	MinimumVersionForUpgrade    string                        `json:"minimum-version-for-upgrade"   yaml:"minimum-version-for-upgrade"`
+	RequiresProductVersions     []productRequirement          `json:"requires-product-versions"     yaml:"requires-product-versions"`
+	StemcellCriteria            stemcellCriteriaRequirement   `json:"stemcell-criteria"             yaml:"stemcell-criteria"`
+	AdditionalStemcellsCriteria []stemcellCriteriaRequirement `json:"additional-stemcells-criteria" yaml:"additional-stemcells-criteria"`
+}
+
+type productRequirement struct {
+	Name    string `json:"name"    yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+}
+
+type stemcellCriteriaRequirement struct {
+	OS                         string `json:"os"                            yaml:"os"`
+	Version                    string `json:"version"                       yaml:"version"`
+	EnablePatchSecurityUpdates bool   `json:"enable-patch-security-updates" yaml:"enable-patch-security-updates"`
+	RequiresCPI                bool   `json:"requires-cpi"                  yaml:"requires-cpi"`
+}
+
+type productFullMetadata struct {
+	productRequirements `yaml:",inline"`
+
+	Releases       []productRelease       `json:"releases"        yaml:"releases"`
+	InstanceGroups []productInstanceGroup `json:"instance-groups" yaml:"instance-groups"`
+	Errands        []productErrand        `json:"errands"         yaml:"errands"`
+}
+
+type productRelease struct {
+	Name    string `json:"name"    yaml:"name"`
+	Version string `json:"version" yaml:"version"`
+	File    string `json:"file"    yaml:"file"`
+	SHA1    string `json:"sha1"    yaml:"sha1"`
+}
+
+type productInstanceGroup struct {
+	Name             string       `json:"name"              yaml:"name"`
+	Label            string       `json:"label"             yaml:"label"`
+	DefaultInstances int          `json:"default-instances" yaml:"default-instances"`
+	Errand           bool         `json:"errand"            yaml:"errand"`
+	Jobs             []productJob `json:"jobs"              yaml:"jobs"`
+}
+
+type productJob struct {
+	Name    string `json:"name"    yaml:"name"`
+	Release string `json:"release" yaml:"release"`
+}
+
+type productErrand struct {
+	Name      string   `json:"name"      yaml:"name"`
+	Label     string   `json:"label"     yaml:"label"`
+	Type      string   `json:"type"      yaml:"type"`
+	Colocated bool     `json:"colocated" yaml:"colocated"`
+	Instances []string `json:"instances" yaml:"instances"`
+}
+
+// printDocument prints what has to be in place before the product can be
+// uploaded, so pipelines can check it first, and with full what the product
+// contains, e.g. to track the CVEs of its releases. The oldest Ops Manager
+// the product can be imported into is its metadata version.
+func (t ProductMetadata) printDocument(metadataBytes []byte) error {
+	meta, err := extractor.ParseMetadata(metadataBytes)
+	if err != nil {
+		return err
+	}
+
+	requirements := productRequirements{
+		ProductName:                 meta.Name,
+		ProductVersion:              meta.Version,
+		MinimumOpsManagerVersion:    meta.MetadataVersion,
+		MinimumVersionForUpgrade:    meta.MinimumVersionForUpgrade,
+		RequiresProductVersions:     []productRequirement{},
+		StemcellCriteria:            newStemcellCriteriaRequirement(meta.StemcellCriteria),
+		AdditionalStemcellsCriteria: []stemcellCriteriaRequirement{},
+	}
+
+	for _, product := range meta.RequiresProductVersions {
+		requirements.RequiresProductVersions = append(requirements.RequiresProductVersions, productRequirement(product))
+	}
+
+	for _, criteria := range meta.AdditionalStemcellsCriteria {
+		requirements.AdditionalStemcellsCriteria = append(requirements.AdditionalStemcellsCriteria, newStemcellCriteriaRequirement(criteria))
+	}
+
+	var document interface{} = requirements
+	if t.Options.Full {
+		document, err = newProductFullMetadata(meta, requirements)
+		if err != nil {
+			return err
+		}
+	}
+
+	var output []byte
+	if t.Options.Format == "json" {
+		output, err = json.MarshalIndent(document, "", "  ")
+	} else {
+		output, err = yaml.Marshal(document)
+	}
+	if err != nil {
+		return err
+	}
+
+	t.stdout.Println(string(output))
+
+	return nil
+}
+
+func newStemcellCriteriaRequirement(criteria extractor.StemcellCriteria) stemcellCriteriaRequirement {
+	return stemcellCriteriaRequirement{
+		OS:                         criteria.OS,
+		Version:                    criteria.Version,
+		EnablePatchSecurityUpdates: criteria.PatchSecurityUpdates,
+		RequiresCPI:                criteria.RequiresCPI,
+	}
+}
+
+func newProductFullMetadata(meta *extractor.Metadata, requirements productRequirements) (productFullMetadata, error) {
+	contents, err := meta.Contents()
+	if err != nil {
+		return productFullMetadata{}, err
+	}
+
+	full := productFullMetadata{
+		productRequirements: requirements,
+		Releases:            []productRelease{},
+		InstanceGroups:      []productInstanceGroup{},
+		Errands:             []productErrand{},
+	}
+
+	for _, release := range contents.Releases {
+		full.Releases = append(full.Releases, productRelease(release))
+	}
+
+	for _, jobType := range contents.JobTypes {
+		instanceGroup := productInstanceGroup{
+			Name:             jobType.Name,
+			Label:            jobType.Label,
+			DefaultInstances: jobType.InstanceDefinition.Default,
+			Errand:           jobType.Errand,
+			Jobs:             []productJob{},
+		}
+		for _, template := range jobType.Templates {
+			instanceGroup.Jobs = append(instanceGroup.Jobs, productJob(template))
+		}
+
+		full.InstanceGroups = append(full.InstanceGroups, instanceGroup)
+	}
+
+	errandTypes := []struct {
+		name    string
+		errands []extractor.Errand
+	}{
+		{"post-deploy", contents.PostDeployErrands},
+		{"pre-delete", contents.PreDeleteErrands},
+	}
+	for _, errandType := range errandTypes {
+		for _, errand := range errandType.errands {
+			instances := errand.Instances
+			if instances == nil {
+				instances = []string{}
+			}
+
+			full.Errands = append(full.Errands, productErrand{
+				Name:      errand.Name,
+				Label:     errand.Label,
+				Type:      errandType.name,
+				Colocated: errand.Colocated,
+				Instances: instances,
+			})
+		}
+	}
+
+	return full, nil
+}
@@ -52,6 +52,191 @@ var _ = Describe("ProductMetadata", func() {
 			Expect(content).To(ContainElement("1.1.1"))
 		})
 
+		Describe("requirements", func() {
+			BeforeEach(func() {
+				command = commands.NewProductMetadata(func(*commands.ProductMetadata) commands.MetadataProvider {
+					f := &fakes.MetadataProvider{}
+					f.MetadataBytesReturns([]byte(`
+name: example-product
+product_version: "1.1.1"
+metadata_version: "2.10"
+minimum_version_for_upgrade: 1.0.0
+requires_product_versions:
+- name: cf
+  version: "~> 2.13"
+stemcell_criteria:
+  os: ubuntu-jammy
+  version: "1.18"
+  enable_patch_security_updates: true
+additional_stemcells_criteria:
+- os: windows2019
+  version: "2019.60"
+`), nil)
+					return f
+				}, stdout)
+			})
+
+			It("shows the products, stemcells and Ops Manager version the product requires", func() {
+				err = executeCommand(command, []string{"-p", "product-filename", "--requirements"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(stdout.PrintlnArgsForCall(0)[0]).To(MatchYAML(`
+product-name: example-product
+product-version: 1.1.1
+minimum-opsman-version: "2.10"
+minimum-version-for-upgrade: 1.0.0
+requires-product-versions:
+- name: cf
+  version: "~> 2.13"
+stemcell-criteria:
+  os: ubuntu-jammy
+  version: "1.18"
+  enable-patch-security-updates: true
+  requires-cpi: false
+additional-stemcells-criteria:
+- os: windows2019
+  version: "2019.60"
+  enable-patch-security-updates: false
+  requires-cpi: false
+`))
+			})
+
+			It("shows them as JSON", func() {
+				err = executeCommand(command, []string{"-p", "product-filename", "--requirements", "--format", "json"})
+				Expect(err).ToNot(HaveOccurred())
+
+				output := stdout.PrintlnArgsForCall(0)[0]
+				Expect(output).To(ContainSubstring(`"minimum-opsman-version": "2.10"`))
+				Expect(output).To(ContainSubstring(`"requires-product-versions": [`))
+			})
+
+			It("cannot be combined with product-name or product-version", func() {
+				err = executeCommand(command, []string{"-p", "product-filename", "--requirements", "--product-name"})
+				Expect(err).To(MatchError(ContainSubstring("requirements and full cannot be combined with product-name or product-version")))
+
+				err = executeCommand(command, []string{"-p", "product-filename", "--requirements", "--full"})
+				Expect(err).To(MatchError(ContainSubstring("requirements cannot be combined with full")))
+			})
+		})
+
+		Describe("full", func() {
+			BeforeEach(func() {
+				command = commands.NewProductMetadata(func(*commands.ProductMetadata) commands.MetadataProvider {
+					f := &fakes.MetadataProvider{}
+					f.MetadataBytesReturns([]byte(`
+name: example-product
+product_version: "1.1.1"
+metadata_version: "2.10"
+stemcell_criteria:
+  os: ubuntu-jammy
+  version: "1.18"
+releases:
+- name: bpm
+  version: 1.1.3
+  file: bpm-1.1.3.tgz
+  sha1: e54f7dc6b041598b493bfa8756954d90c0433125
+job_types:
+- name: broker
+  label: Service Broker
+  instance_definition:
+    default: 2
+  templates:
+  - name: bpm
+    release: bpm
+  - name: register-broker
+    release: example
+- name: smoke-tests
+  label: Smoke Tests
+  errand: true
+  instance_definition:
+    default: 1
+  templates:
+  - name: smoke-tests
+    release: example
+post_deploy_errands:
+- name: register-broker
+  label: Register Broker
+  colocated: true
+  instances:
+  - broker/first
+- name: smoke-tests
+pre_delete_errands:
+- name: deregister-broker
+  colocated: true
+  instances:
+  - broker/first
+`), nil)
+					return f
+				}, stdout)
+			})
+
+			It("shows the releases, instance groups and errands with the requirements", func() {
+				err = executeCommand(command, []string{"-p", "product-filename", "--full"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(stdout.PrintlnArgsForCall(0)[0]).To(MatchYAML(`
+product-name: example-product
+product-version: 1.1.1
+minimum-opsman-version: "2.10"
+minimum-version-for-upgrade: ""
+requires-product-versions: []
+stemcell-criteria:
+  os: ubuntu-jammy
+  version: "1.18"
+  enable-patch-security-updates: false
+  requires-cpi: false
+additional-stemcells-criteria: []
+releases:
+- name: bpm
+  version: 1.1.3
+  file: bpm-1.1.3.tgz
+  sha1: e54f7dc6b041598b493bfa8756954d90c0433125
+instance-groups:
+- name: broker
+  label: Service Broker
+  default-instances: 2
+  errand: false
+  jobs:
+  - name: bpm
+    release: bpm
+  - name: register-broker
+    release: example
+- name: smoke-tests
+  label: Smoke Tests
+  default-instances: 1
+  errand: true
+  jobs:
+  - name: smoke-tests
+    release: example
+errands:
+- name: register-broker
+  label: Register Broker
+  type: post-deploy
+  colocated: true
+  instances: [broker/first]
+- name: smoke-tests
+  label: ""
+  type: post-deploy
+  colocated: false
+  instances: []
+- name: deregister-broker
+  label: ""
+  type: pre-delete
+  colocated: true
+  instances: [broker/first]
+`))
+			})
+
+			It("shows them as JSON", func() {
+				err = executeCommand(command, []string{"-p", "product-filename", "--full", "--format", "json"})
+				Expect(err).ToNot(HaveOccurred())
+
+				output := stdout.PrintlnArgsForCall(0)[0]
+				Expect(output).To(ContainSubstring(`"product-name": "example-product"`))
+				Expect(output).To(ContainSubstring(`"sha1": "e54f7dc6b041598b493bfa8756954d90c0433125"`))
+			})
+		})
+
 		Describe("flag handling", func() {
 			When("the required flags are not specified", func() {
 				It("returns an error", func() {
@@ -26,10 +26,10 @@ type Products struct {
 	presenter      presenters.FormattedPresenter
 	productService productService
 	Options        struct {
-		Available bool   `long:"available" short:"a" description:"Specify to include available products. Can be used with other options."`
-		Staged    bool   `long:"staged" short:"s" description:"Specify to include staged products. Can be used with other options."`
-		Deployed  bool   `long:"deployed" short:"d" description:"Specify to deployed products. Can be used with other options."`
-		Format    string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		Available bool `long:"available" short:"a" description:"Specify to include available products. Can be used with other options."`
+		Staged    bool `long:"staged" short:"s" description:"Specify to include staged products. Can be used with other options."`
+		Deployed  bool `long:"deployed" short:"d" description:"Specify to deployed products. Can be used with other options."`
+		formatOptions
 	}
 }
 
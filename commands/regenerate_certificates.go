@@ -1,12 +1,26 @@
 package commands
 
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pivotal-cf/om/api"
+)
+
 type RegenerateCertificates struct {
 	service regenerateCertificatesService
 	logger  logger
+	Options struct {
+		ProductNames []string `long:"product-name" short:"p" description:"only regenerate when a non-configurable certificate of this product would be regenerated. Can be repeated"`
+		Types        []string `long:"type"         short:"t" description:"only regenerate when a non-configurable certificate of this property type (e.g. rsa_cert_credentials) would be regenerated. Can be repeated"`
+		DryRun       bool     `long:"dry-run"                description:"list the certificates that would be regenerated without regenerating them"`
+	}
 }
 
 //counterfeiter:generate -o ./fakes/regenerate_certificates_service.go --fake-name RegenerateCertificatesService . regenerateCertificatesService
 type regenerateCertificatesService interface {
+	ListCertificates(expiresWithin string) ([]api.ExpiringCertificate, error)
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
 	RegenerateCertificates() error
 }
 
@@ -15,12 +29,92 @@ func NewRegenerateCertificates(service regenerateCertificatesService, logger log
 }
 
 func (r RegenerateCertificates) Execute(_ []string) error {
-	err := r.service.RegenerateCertificates()
+	certs, err := r.nonConfigurableCertificates()
+	if err != nil {
+		return err
+	}
+
+	filtered := len(r.Options.ProductNames) > 0 || len(r.Options.Types) > 0
+	if filtered && len(certs) == 0 {
+		r.logger.Printf("No non-configurable certificates match the filters, nothing to regenerate.\n")
+		return nil
+	}
+
+	if len(certs) > 0 {
+		r.logger.Printf("The following non-configurable certificates will be regenerated:\n")
+		for _, cert := range certs {
+			r.logger.Printf("  %s\n", describeCertificate(cert))
+		}
+	}
+
+	// the API regenerates every non-configurable certificate at once
+	if filtered {
+		r.logger.Printf("Ops Manager regenerates the non-configurable certificates of the other products and types with them.\n")
+	}
+
+	if r.Options.DryRun {
+		return nil
+	}
+
+	err = r.service.RegenerateCertificates()
 	if err != nil {
 		return err
 	}
 
 	r.logger.Printf("Certificates regenerated.\n")
+	r.logger.Printf("Apply changes is required to deploy the regenerated certificates.\n")
 
 	return nil
 }
+
+func (r RegenerateCertificates) nonConfigurableCertificates() ([]api.ExpiringCertificate, error) {
+	certs, err := r.service.ListCertificates("")
+	if err != nil {
+		return nil, fmt.Errorf("could not list the deployed certificates: %w", err)
+	}
+
+	var productGUIDs []string
+	if len(r.Options.ProductNames) > 0 {
+		products, err := r.service.ListDeployedProducts()
+		if err != nil {
+			return nil, fmt.Errorf("could not list the deployed products: %w", err)
+		}
+
+		for _, product := range products {
+			if slices.Contains(r.Options.ProductNames, product.Type) {
+				productGUIDs = append(productGUIDs, product.GUID)
+			}
+		}
+	}
+
+	var nonConfigurable []api.ExpiringCertificate
+	for _, cert := range certs {
+		if cert.Configurable {
+			continue
+		}
+		if len(r.Options.ProductNames) > 0 && !slices.Contains(productGUIDs, cert.ProductGUID) {
+			continue
+		}
+		if len(r.Options.Types) > 0 && !slices.Contains(r.Options.Types, cert.PropertyType) {
+			continue
+		}
+
+		nonConfigurable = append(nonConfigurable, cert)
+	}
+
+	return nonConfigurable, nil
+}
+
+func describeCertificate(cert api.ExpiringCertificate) string {
+	name := cert.PropertyReference
+	if cert.VariablePath != "" {
+		name = cert.VariablePath
+	}
+
+	owner := cert.ProductGUID
+	if owner == "" {
+		owner = cert.Location
+	}
+
+	return fmt.Sprintf("%s: %s (expires %s)", owner, name, cert.ValidUntil.Format("2006-01-02"))
+}
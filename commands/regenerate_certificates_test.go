@@ -1,11 +1,14 @@
 package commands_test
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
 )
@@ -17,6 +20,15 @@ var _ = Describe("RegenerateCertificates", func() {
 		command     *commands.RegenerateCertificates
 	)
 
+	logLines := func() []string {
+		var lines []string
+		for i := 0; i < fakeLogger.PrintfCallCount(); i++ {
+			format, content := fakeLogger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
 	BeforeEach(func() {
 		fakeService = &fakes.RegenerateCertificatesService{}
 		fakeLogger = &fakes.Logger{}
@@ -29,9 +41,73 @@ var _ = Describe("RegenerateCertificates", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fakeService.RegenerateCertificatesCallCount()).To(Equal(1))
 
-			Expect(fakeLogger.PrintfCallCount()).To(Equal(1))
-			format, content := fakeLogger.PrintfArgsForCall(0)
-			Expect(fmt.Sprintf(format, content...)).To(Equal("Certificates regenerated.\n"))
+			Expect(logLines()).To(Equal([]string{
+				"Certificates regenerated.\n",
+				"Apply changes is required to deploy the regenerated certificates.\n",
+			}))
+		})
+
+		When("there are non-configurable certificates", func() {
+			BeforeEach(func() {
+				validUntil := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+				fakeService.ListCertificatesReturns([]api.ExpiringCertificate{
+					{ProductGUID: "cf-guid", PropertyReference: ".uaa.service_provider_key_credentials", PropertyType: "rsa_cert_credentials", ValidUntil: validUntil},
+					{ProductGUID: "cf-guid", PropertyReference: ".properties.networking_poe_ssl_certs", Configurable: true, ValidUntil: validUntil},
+					{Location: "credhub", VariablePath: "/p-bosh/cf/diego_instance_identity_ca", PropertyType: "certificate", ValidUntil: validUntil},
+				}, nil)
+				fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
+					{Type: "cf", GUID: "cf-guid"},
+					{Type: "p-mysql", GUID: "mysql-guid"},
+				}, nil)
+			})
+
+			It("reports the certificates that will be regenerated", func() {
+				err := executeCommand(command, []string{})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.ListCertificatesArgsForCall(0)).To(Equal(""))
+				Expect(logLines()).To(Equal([]string{
+					"The following non-configurable certificates will be regenerated:\n",
+					"  cf-guid: .uaa.service_provider_key_credentials (expires 2030-01-02)\n",
+					"  credhub: /p-bosh/cf/diego_instance_identity_ca (expires 2030-01-02)\n",
+					"Certificates regenerated.\n",
+					"Apply changes is required to deploy the regenerated certificates.\n",
+				}))
+			})
+
+			It("filters the report by product and type", func() {
+				err := executeCommand(command, []string{"--product-name", "cf", "--type", "rsa_cert_credentials"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeService.RegenerateCertificatesCallCount()).To(Equal(1))
+
+				Expect(logLines()).To(ContainElement("  cf-guid: .uaa.service_provider_key_credentials (expires 2030-01-02)\n"))
+				Expect(logLines()).ToNot(ContainElement(ContainSubstring("credhub")))
+			})
+
+			It("does not regenerate when no certificate matches the filters", func() {
+				err := executeCommand(command, []string{"--product-name", "p-mysql"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeService.RegenerateCertificatesCallCount()).To(Equal(0))
+
+				Expect(logLines()).To(Equal([]string{
+					"No non-configurable certificates match the filters, nothing to regenerate.\n",
+				}))
+			})
+
+			It("only reports the certificates with --dry-run", func() {
+				err := executeCommand(command, []string{"--dry-run"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeService.RegenerateCertificatesCallCount()).To(Equal(0))
+				Expect(logLines()).To(HaveLen(3))
+			})
+		})
+
+		It("returns an error when the certificates cannot be listed", func() {
+			fakeService.ListCertificatesReturns(nil, errors.New("some error"))
+
+			err := executeCommand(command, []string{})
+			Expect(err).To(MatchError("could not list the deployed certificates: some error"))
+			Expect(fakeService.RegenerateCertificatesCallCount()).To(Equal(0))
 		})
 	})
 })
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type RemoveTrustedCertificate struct {
+	service trustedCertificatesService
+	logger  logger
+	now     func() time.Time
+	Options struct {
+		Certificate string   `long:"certificate"  short:"c" description:"PEM encoded certificates to stop trusting, or the path to a file holding them"`
+		Fingerprint []string `long:"fingerprint"  short:"f" description:"SHA256 fingerprint, as listed by trusted-certificates, of a certificate to stop trusting"`
+		Expired     bool     `long:"expired"                description:"stop trusting the certificates that have expired"`
+	}
+}
+
+func NewRemoveTrustedCertificate(service trustedCertificatesService, logger logger, now func() time.Time) *RemoveTrustedCertificate {
+	return &RemoveTrustedCertificate{
+		service: service,
+		logger:  logger,
+		now:     now,
+	}
+}
+
+func (r RemoveTrustedCertificate) Execute(args []string) error {
+	if r.Options.Certificate == "" && len(r.Options.Fingerprint) == 0 && !r.Options.Expired {
+		return errors.New("--certificate, --fingerprint or --expired is required")
+	}
+
+	removals := map[string]bool{}
+	for _, fingerprint := range r.Options.Fingerprint {
+		removals[strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))] = true
+	}
+
+	if r.Options.Certificate != "" {
+		certificates, err := readCertificates(r.Options.Certificate)
+		if err != nil {
+			return err
+		}
+
+		for _, certificate := range certificates {
+			removals[certificate.fingerprint] = true
+		}
+	}
+
+	security, err := readSecurityConfiguration(r.service)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := parseTrustedCertificates(security)
+	if err != nil {
+		return err
+	}
+
+	var kept []trustedCertificate
+	for _, certificate := range certificates {
+		expired := r.Options.Expired && r.now().After(certificate.cert.NotAfter)
+		if !removals[certificate.fingerprint] && !expired {
+			kept = append(kept, certificate)
+			continue
+		}
+
+		r.logger.Printf("no longer trusting %s", certificate)
+		delete(removals, certificate.fingerprint)
+	}
+
+	for fingerprint := range removals {
+		r.logger.Printf("%s is not among the trusted certificates", fingerprint)
+	}
+
+	removed := len(certificates) - len(kept)
+	if removed == 0 {
+		return nil
+	}
+
+	err = writeSecurityConfiguration(r.service, security, kept)
+	if err != nil {
+		return err
+	}
+
+	r.logger.Printf("removed %d trusted certificates, apply changes to deploy the change", removed)
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package commands_test
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("RemoveTrustedCertificate", func() {
+	var (
+		service *fakes.TrustedCertificatesService
+		stdout  *gbytes.Buffer
+		now     time.Time
+		command *commands.RemoveTrustedCertificate
+		first   testCertificate
+		second  testCertificate
+	)
+
+	BeforeEach(func() {
+		first = newTestCertificate("corporate-root", nil, true)
+		second = newTestCertificate("partner-root", nil, true)
+
+		service = &fakes.TrustedCertificatesService{}
+		service.GetStagedDirectorPropertiesReturns(trustedCertificatesProperties(first.pem+second.pem), nil)
+
+		stdout = gbytes.NewBuffer()
+		now = time.Now()
+		command = commands.NewRemoveTrustedCertificate(service, log.New(stdout, "", 0), func() time.Time { return now })
+	})
+
+	trustedCertificates := func() string {
+		var payload struct {
+			SecurityConfiguration struct {
+				TrustedCertificates string `json:"trusted_certificates"`
+			} `json:"security_configuration"`
+		}
+		Expect(json.Unmarshal(service.UpdateStagedDirectorPropertiesArgsForCall(0), &payload)).To(Succeed())
+		return payload.SecurityConfiguration.TrustedCertificates
+	}
+
+	It("removes the certificate with the fingerprint", func() {
+		fingerprint := strings.ToUpper(fingerprintOf(first))
+
+		err := executeCommand(command, []string{"--fingerprint", fingerprint})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(trustedCertificates()).To(Equal(second.pem))
+		Expect(stdout).To(gbytes.Say("no longer trusting CN=corporate-root"))
+		Expect(stdout).To(gbytes.Say("removed 1 trusted certificates, apply changes to deploy the change"))
+	})
+
+	It("removes the given certificate", func() {
+		err := executeCommand(command, []string{"--certificate", second.pem})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(trustedCertificates()).To(Equal(first.pem))
+	})
+
+	It("removes the expired certificates", func() {
+		now = now.Add(2 * time.Hour)
+
+		err := executeCommand(command, []string{"--expired"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(trustedCertificates()).To(BeEmpty())
+	})
+
+	It("does not update the director when nothing matches", func() {
+		err := executeCommand(command, []string{"--fingerprint", "abcdef"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say("abcdef is not among the trusted certificates"))
+		Expect(service.UpdateStagedDirectorPropertiesCallCount()).To(Equal(0))
+	})
+
+	It("requires the certificates to remove", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("--certificate, --fingerprint or --expired is required"))
+	})
+})
@@ -1,15 +1,27 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/pivotal-cf/om/api"
 )
 
 type RevertStagedChanges struct {
 	service revertStagedChangesService
 	logger  logger
+	Options struct {
+		ProductNames []string `long:"product-name" short:"n" description:"only revert when every pending change belongs to this product (use p-bosh for the director). Can be repeated"`
+	}
 }
 
+//counterfeiter:generate -o ./fakes/revert_staged_changes_service.go --fake-name RevertStagedChangesService . revertStagedChangesService
 type revertStagedChangesService interface {
+	ListDeployedProducts() ([]api.DeployedProductOutput, error)
+	ListStagedPendingChanges() (api.PendingChangesOutput, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
 	RevertStagedChanges() (bool, error)
 }
 
@@ -18,6 +30,13 @@ func NewRevertStagedChanges(service revertStagedChangesService, logger logger) *
 }
 
 func (r RevertStagedChanges) Execute(_ []string) error {
+	if len(r.Options.ProductNames) > 0 {
+		err := r.checkProducts()
+		if err != nil {
+			return err
+		}
+	}
+
 	reverted, err := r.service.RevertStagedChanges()
 
 	if err != nil {
@@ -32,3 +51,60 @@ func (r RevertStagedChanges) Execute(_ []string) error {
 
 	return nil
 }
+
+// checkProducts makes sure only the named products have pending changes.
+// Ops Manager reverts the staged changes of every product at once, so the
+// changes of other products would be lost otherwise.
+func (r RevertStagedChanges) checkProducts() error {
+	pendingChanges, err := r.service.ListStagedPendingChanges()
+	if err != nil {
+		return fmt.Errorf("could not list pending changes: %s", err)
+	}
+
+	stagedProducts, err := r.service.ListStagedProducts()
+	if err != nil {
+		return fmt.Errorf("could not list staged products: %s", err)
+	}
+
+	deployedProducts, err := r.service.ListDeployedProducts()
+	if err != nil {
+		return fmt.Errorf("could not list deployed products: %s", err)
+	}
+
+	// products pending deletion are only in the deployed products
+	names := map[string]string{}
+	for _, product := range deployedProducts {
+		names[product.GUID] = product.Type
+	}
+	for _, product := range stagedProducts.Products {
+		names[product.GUID] = product.Type
+	}
+
+	var others []string
+	for _, change := range pendingChanges.ChangeList {
+		if change.Action == "unchanged" {
+			continue
+		}
+
+		name, ok := names[change.GUID]
+		if !ok {
+			name = change.GUID
+		}
+		if !slices.Contains(r.Options.ProductNames, name) {
+			others = append(others, fmt.Sprintf("%s (%s)", name, change.Action))
+		}
+	}
+
+	if len(others) > 0 {
+		sort.Strings(others)
+		r.logger.Printf("Ops Manager reverts the pending changes of every product at once.\n")
+		r.logger.Printf("The following products also have pending changes:\n")
+		for _, other := range others {
+			r.logger.Printf("- %s\n", other)
+		}
+
+		return errors.New("pending changes of other products would be reverted, no changes were reverted")
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package commands_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("RevertStagedChanges", func() {
+	var (
+		fakeService *fakes.RevertStagedChangesService
+		fakeLogger  *fakes.Logger
+		command     *commands.RevertStagedChanges
+	)
+
+	logLines := func() []string {
+		var lines []string
+		for i := 0; i < fakeLogger.PrintfCallCount(); i++ {
+			format, content := fakeLogger.PrintfArgsForCall(i)
+			lines = append(lines, fmt.Sprintf(format, content...))
+		}
+		return lines
+	}
+
+	BeforeEach(func() {
+		fakeService = &fakes.RevertStagedChangesService{}
+		fakeLogger = &fakes.Logger{}
+		command = commands.NewRevertStagedChanges(fakeService, fakeLogger)
+
+		fakeService.RevertStagedChangesReturns(true, nil)
+	})
+
+	It("reverts the staged changes", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeService.RevertStagedChangesCallCount()).To(Equal(1))
+		Expect(fakeService.ListStagedPendingChangesCallCount()).To(Equal(0))
+		Expect(logLines()).To(Equal([]string{"Changes reverted.\n"}))
+	})
+
+	It("reports when there is nothing to revert", func() {
+		fakeService.RevertStagedChangesReturns(false, nil)
+
+		err := executeCommand(command, []string{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logLines()).To(Equal([]string{"No changes to revert.\n"}))
+	})
+
+	It("returns an error when the revert fails", func() {
+		fakeService.RevertStagedChangesReturns(false, errors.New("some error"))
+
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("revert staged changes command failed: some error"))
+	})
+
+	When("--product-name is provided", func() {
+		BeforeEach(func() {
+			fakeService.ListStagedProductsReturns(api.StagedProductsOutput{
+				Products: []api.StagedProduct{
+					{GUID: "bosh-guid", Type: "p-bosh"},
+					{GUID: "cf-guid", Type: "cf"},
+				},
+			}, nil)
+			fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{
+				{GUID: "bosh-guid", Type: "p-bosh"},
+				{GUID: "cf-guid", Type: "cf"},
+				{GUID: "mysql-guid", Type: "p-mysql"},
+			}, nil)
+		})
+
+		It("reverts when only the named products have pending changes", func() {
+			fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{GUID: "bosh-guid", Action: "unchanged"},
+					{GUID: "cf-guid", Action: "update"},
+				},
+			}, nil)
+
+			err := executeCommand(command, []string{"--product-name", "cf"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeService.RevertStagedChangesCallCount()).To(Equal(1))
+		})
+
+		It("does not revert when other products have pending changes", func() {
+			fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{
+				ChangeList: []api.ProductChange{
+					{GUID: "bosh-guid", Action: "update"},
+					{GUID: "cf-guid", Action: "update"},
+					{GUID: "mysql-guid", Action: "delete"},
+				},
+			}, nil)
+
+			err := executeCommand(command, []string{"--product-name", "cf"})
+			Expect(err).To(MatchError("pending changes of other products would be reverted, no changes were reverted"))
+			Expect(fakeService.RevertStagedChangesCallCount()).To(Equal(0))
+
+			Expect(logLines()).To(Equal([]string{
+				"Ops Manager reverts the pending changes of every product at once.\n",
+				"The following products also have pending changes:\n",
+				"- p-bosh (update)\n",
+				"- p-mysql (delete)\n",
+			}))
+		})
+
+		It("returns an error when the pending changes cannot be listed", func() {
+			fakeService.ListStagedPendingChangesReturns(api.PendingChangesOutput{}, errors.New("some error"))
+
+			err := executeCommand(command, []string{"--product-name", "cf"})
+			Expect(err).To(MatchError("could not list pending changes: some error"))
+			Expect(fakeService.RevertStagedChangesCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/interpolate"
+)
+
+type RotateAdminCredentials struct {
+	service     rotateAdminCredentialsService
+	verifier    credentialsVerifier
+	username    string
+	password    string
+	environFunc func() []string
+	logger      logger
+	Options     struct {
+		NewPassword string `long:"new-password" env:"OM_NEW_PASSWORD"                          description:"the new password. A password is generated when it is not given"`
+		VarsStore   string `long:"vars-store"                                                  description:"vars store to write the new password to, e.g. file://creds.yml"`
+		VarName     string `long:"var-name"                           default:"admin_password" description:"name of the variable the new password is written to"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/rotate_admin_credentials_service.go --fake-name RotateAdminCredentialsService . rotateAdminCredentialsService
+type rotateAdminCredentialsService interface {
+	GetCurrentUAAUser() (api.UAAUser, error)
+	ChangeUAAUserPassword(userID, oldPassword, newPassword string) error
+}
+
+//counterfeiter:generate -o ./fakes/credentials_verifier.go --fake-name CredentialsVerifier . credentialsVerifier
+type credentialsVerifier interface {
+	VerifyCredentials(username, password, clientID, clientSecret string) error
+	ForgetToken(username, password, clientID, clientSecret string) error
+}
+
+func NewRotateAdminCredentials(environFunc func() []string, service rotateAdminCredentialsService, verifier credentialsVerifier, username, password string, logger logger) *RotateAdminCredentials {
+	return &RotateAdminCredentials{
+		service:     service,
+		verifier:    verifier,
+		username:    username,
+		password:    password,
+		environFunc: environFunc,
+		logger:      logger,
+	}
+}
+
+func (r RotateAdminCredentials) Execute(args []string) error {
+	if r.username == "" || r.password == "" {
+		return errors.New("rotate-admin-credentials changes the password of the user om authenticates as, so --username and --password are required")
+	}
+
+	if r.Options.NewPassword == "" && r.Options.VarsStore == "" {
+		return errors.New("--new-password or --vars-store is required, so a generated password is not lost")
+	}
+
+	// the vars store is opened before changing anything, so the new password
+	// can be written to it
+	var store interpolate.VarsStoreWriter
+	if r.Options.VarsStore != "" {
+		var err error
+		store, err = interpolate.NewVarsStoreWriter(r.Options.VarsStore, r.environFunc)
+		if errors.Is(err, interpolate.ErrVarsStoreNotWritable) {
+			return fmt.Errorf("the new password cannot be written to the vars store: %s. Set it in that store and pass it with --new-password instead", err)
+		}
+		if err != nil {
+			return fmt.Errorf("could not open vars store: %s", err)
+		}
+	}
+
+	newPassword := r.Options.NewPassword
+	if newPassword == "" {
+		var err error
+		newPassword, err = interpolate.GeneratePassword(interpolate.DefaultPasswordLength)
+		if err != nil {
+			return err
+		}
+	}
+
+	user, err := r.service.GetCurrentUAAUser()
+	if err != nil {
+		return fmt.Errorf("could not find the UAA user of %s: %s", r.username, err)
+	}
+
+	err = r.service.ChangeUAAUserPassword(user.ID, r.password, newPassword)
+	if err != nil {
+		return fmt.Errorf("could not change the password of %s: %s", r.username, err)
+	}
+
+	r.logger.Printf("changed the password of %s", r.username)
+
+	err = r.verifier.ForgetToken(r.username, r.password, "", "")
+	if err != nil {
+		r.logger.Printf("Warning: could not remove the cached token of the old password: %s", err)
+	}
+
+	if store != nil {
+		err = store.Put(r.Options.VarName, newPassword)
+		if err != nil {
+			// a generated password is not known anywhere else, so it is
+			// reported rather than lost
+			if r.Options.NewPassword == "" {
+				return fmt.Errorf("the password of %s was changed to %q, but could not be written to the vars store: %s", r.username, newPassword, err)
+			}
+
+			return fmt.Errorf("the password of %s was changed, but could not be written to the vars store: %s", r.username, err)
+		}
+
+		r.logger.Printf("wrote the new password to %s in the vars store", r.Options.VarName)
+	}
+
+	err = r.verifier.VerifyCredentials(r.username, newPassword, "", "")
+	if err != nil {
+		return fmt.Errorf("the password of %s was changed, but UAA does not grant a token for the new password: %s", r.username, err)
+	}
+
+	r.logger.Printf("verified the new password of %s", r.username)
+
+	return nil
+}
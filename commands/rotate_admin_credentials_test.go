@@ -0,0 +1,132 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("RotateAdminCredentials", func() {
+	var (
+		service  *fakes.RotateAdminCredentialsService
+		verifier *fakes.CredentialsVerifier
+		stdout   *gbytes.Buffer
+		command  *commands.RotateAdminCredentials
+	)
+
+	BeforeEach(func() {
+		service = &fakes.RotateAdminCredentialsService{}
+		service.GetCurrentUAAUserReturns(api.UAAUser{ID: "admin-guid", UserName: "admin"}, nil)
+
+		verifier = &fakes.CredentialsVerifier{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewRotateAdminCredentials(func() []string { return nil }, service, verifier, "admin", "old-password", log.New(stdout, "", 0))
+	})
+
+	It("changes the password and verifies the new one", func() {
+		err := executeCommand(command, []string{"--new-password", "new-password"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.ChangeUAAUserPasswordCallCount()).To(Equal(1))
+		userID, oldPassword, newPassword := service.ChangeUAAUserPasswordArgsForCall(0)
+		Expect(userID).To(Equal("admin-guid"))
+		Expect(oldPassword).To(Equal("old-password"))
+		Expect(newPassword).To(Equal("new-password"))
+
+		Expect(verifier.VerifyCredentialsCallCount()).To(Equal(1))
+		username, password, clientID, clientSecret := verifier.VerifyCredentialsArgsForCall(0)
+		Expect([]string{username, password, clientID, clientSecret}).To(Equal([]string{"admin", "new-password", "", ""}))
+
+		Expect(verifier.ForgetTokenCallCount()).To(Equal(1))
+		username, password, clientID, clientSecret = verifier.ForgetTokenArgsForCall(0)
+		Expect([]string{username, password, clientID, clientSecret}).To(Equal([]string{"admin", "old-password", "", ""}))
+
+		Expect(stdout).To(gbytes.Say("changed the password of admin"))
+		Expect(stdout).To(gbytes.Say("verified the new password of admin"))
+	})
+
+	It("only warns when the cached token of the old password cannot be removed", func() {
+		verifier.ForgetTokenReturns(errors.New("permission denied"))
+
+		err := executeCommand(command, []string{"--new-password", "new-password"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(gbytes.Say("Warning: could not remove the cached token of the old password: permission denied"))
+	})
+
+	It("generates a password and writes it to the vars store", func() {
+		storePath := filepath.Join(GinkgoT().TempDir(), "creds.yml")
+
+		err := executeCommand(command, []string{"--vars-store", "file://" + storePath, "--var-name", "opsman_password"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, newPassword := service.ChangeUAAUserPasswordArgsForCall(0)
+		Expect(newPassword).To(HaveLen(32))
+
+		contents, err := os.ReadFile(storePath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML("opsman_password: " + newPassword))
+
+		Expect(stdout).To(gbytes.Say("wrote the new password to opsman_password in the vars store"))
+	})
+
+	It("reports a generated password that cannot be written to the vars store", func() {
+		storePath := filepath.Join(GinkgoT().TempDir(), "missing", "creds.yml")
+
+		err := executeCommand(command, []string{"--vars-store", "file://" + storePath})
+
+		_, _, newPassword := service.ChangeUAAUserPasswordArgsForCall(0)
+		Expect(err).To(MatchError(ContainSubstring(`the password of admin was changed to "` + newPassword + `", but could not be written to the vars store: could not write vars store`)))
+		Expect(verifier.VerifyCredentialsCallCount()).To(Equal(0))
+	})
+
+	It("only names a given password that cannot be written to the vars store", func() {
+		storePath := filepath.Join(GinkgoT().TempDir(), "missing", "creds.yml")
+
+		err := executeCommand(command, []string{"--new-password", "new-password", "--vars-store", "file://" + storePath})
+		Expect(err).To(MatchError(ContainSubstring("the password of admin was changed, but could not be written to the vars store: could not write vars store")))
+		Expect(err).ToNot(MatchError(ContainSubstring("new-password")))
+	})
+
+	It("requires om to authenticate with a username and password", func() {
+		command = commands.NewRotateAdminCredentials(nil, service, verifier, "", "", log.New(stdout, "", 0))
+
+		err := executeCommand(command, []string{"--new-password", "new-password"})
+		Expect(err).To(MatchError(ContainSubstring("--username and --password are required")))
+		Expect(service.ChangeUAAUserPasswordCallCount()).To(Equal(0))
+	})
+
+	It("does not generate a password that would not be written anywhere", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("--new-password or --vars-store is required, so a generated password is not lost"))
+	})
+
+	It("does not change the password when the vars store cannot be written to", func() {
+		err := executeCommand(command, []string{"--vars-store", "vault://vault.example.com/secret"})
+		Expect(err).To(MatchError(`the new password cannot be written to the vars store: vars store "vault://vault.example.com/secret" cannot be written to: only file:// vars stores can be written to. Set it in that store and pass it with --new-password instead`))
+		Expect(service.ChangeUAAUserPasswordCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the password cannot be changed", func() {
+		service.ChangeUAAUserPasswordReturns(errors.New("password too short"))
+
+		err := executeCommand(command, []string{"--new-password", "new"})
+		Expect(err).To(MatchError("could not change the password of admin: password too short"))
+		Expect(verifier.VerifyCredentialsCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the new password cannot be verified", func() {
+		verifier.VerifyCredentialsReturns(errors.New("unauthorized"))
+
+		err := executeCommand(command, []string{"--new-password", "new-password"})
+		Expect(err).To(MatchError("the password of admin was changed, but UAA does not grant a token for the new password: unauthorized"))
+	})
+})
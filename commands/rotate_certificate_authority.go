@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/stepstate"
+)
+
+const (
+	caRotationCreate                = "create-certificate-authority"
+	caRotationApplyNewCA            = "apply-changes-with-new-certificate-authority"
+	caRotationActivate              = "activate-certificate-authority"
+	caRotationRegenerate            = "regenerate-certificates"
+	caRotationApplyRegeneratedCerts = "apply-changes-with-regenerated-certificates"
+	caRotationDeleteOldCAs          = "delete-old-certificate-authorities"
+	caRotationApplyWithoutOldCAs    = "apply-changes-without-old-certificate-authorities"
+)
+
+// caRotationSteps follows the documented order: every VM has to trust the new
+// CA before it signs anything, and has to stop using the old CA before it is
+// removed, so each change to the CAs is followed by an apply changes.
+var caRotationSteps = []string{
+	caRotationCreate,
+	caRotationApplyNewCA,
+	caRotationActivate,
+	caRotationRegenerate,
+	caRotationApplyRegeneratedCerts,
+	caRotationDeleteOldCAs,
+	caRotationApplyWithoutOldCAs,
+}
+
+type RotateCertificateAuthority struct {
+	service      rotateCertificateAuthorityService
+	logger       logger
+	logWriter    logWriter
+	waitDuration time.Duration
+	state        caRotationState
+	Options      struct {
+		CertPem        string `long:"certificate-pem"  description:"certificate of the new certificate authority, optionally followed by its intermediates. Generated by Ops Manager when not provided"`
+		PrivateKey     string `long:"private-key-pem"  description:"private key of the new certificate authority, required with --certificate-pem"`
+		StateFile      string `long:"state-file"       description:"file to record the completed steps in, so that re-running a failed rotation continues where it stopped. Removed once the rotation is done"`
+		IgnoreWarnings bool   `long:"ignore-warnings" short:"i" description:"ignore verifier warnings when applying changes"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/rotate_certificate_authority_service.go --fake-name RotateCertificateAuthorityService . rotateCertificateAuthorityService
+type rotateCertificateAuthorityService interface {
+	applyChangesService
+	pendingChangesService
+	ActivateCertificateAuthority(api.ActivateCertificateAuthorityInput) error
+	CreateCertificateAuthority(api.CertificateAuthorityInput) (api.GenerateCAResponse, error)
+	DeleteCertificateAuthority(api.DeleteCertificateAuthorityInput) error
+	GenerateCertificateAuthority() (api.GenerateCAResponse, error)
+	ListCertificateAuthorities() (api.CertificateAuthoritiesOutput, error)
+	RegenerateCertificates() error
+}
+
+// caRotationState is the state of a rotation that has not finished. The
+// state file is removed once the old CAs are deleted, so the next run
+// starts a new rotation.
+type caRotationState struct {
+	NewCA           string   `yaml:"new_certificate_authority,omitempty"`
+	OldCAs          []string `yaml:"old_certificate_authorities,omitempty"`
+	stepstate.State `yaml:",inline"`
+}
+
+func NewRotateCertificateAuthority(service rotateCertificateAuthorityService, logWriter logWriter, logger logger, waitDuration time.Duration) *RotateCertificateAuthority {
+	return &RotateCertificateAuthority{
+		service:      service,
+		logger:       logger,
+		logWriter:    logWriter,
+		waitDuration: waitDuration,
+	}
+}
+
+func (r *RotateCertificateAuthority) Execute(args []string) error {
+	if (r.Options.CertPem == "") != (r.Options.PrivateKey == "") {
+		return errors.New("--certificate-pem and --private-key-pem must be provided together")
+	}
+
+	err := r.loadState()
+	if err != nil {
+		return err
+	}
+
+	for _, step := range caRotationSteps {
+		if r.state.Done(step) {
+			r.logger.Printf("skipping %s, it was completed by a previous run", step)
+			continue
+		}
+
+		r.logger.Printf("rotating certificate authority: %s", step)
+		err = r.runStep(step)
+		if err != nil {
+			return fmt.Errorf("could not %s: %w", step, err)
+		}
+
+		r.state.Complete(step)
+		err = r.saveState()
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Options.StateFile != "" {
+		err = stepstate.Remove(r.Options.StateFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.logger.Printf("certificate authority '%s' is active and the old certificate authorities were deleted", r.state.NewCA)
+
+	return nil
+}
+
+func (r *RotateCertificateAuthority) runStep(step string) error {
+	switch step {
+	case caRotationCreate:
+		return r.createCA()
+	case caRotationActivate:
+		return r.service.ActivateCertificateAuthority(api.ActivateCertificateAuthorityInput{GUID: r.state.NewCA})
+	case caRotationRegenerate:
+		return r.service.RegenerateCertificates()
+	case caRotationDeleteOldCAs:
+		return r.deleteOldCAs()
+	case caRotationApplyNewCA, caRotationApplyRegeneratedCerts, caRotationApplyWithoutOldCAs:
+		return r.applyChanges()
+	}
+
+	return fmt.Errorf("unknown step %q", step)
+}
+
+func (r *RotateCertificateAuthority) createCA() error {
+	cas, err := r.service.ListCertificateAuthorities()
+	if err != nil {
+		return err
+	}
+
+	r.state.OldCAs = nil
+	for _, ca := range cas.CAs {
+		r.state.OldCAs = append(r.state.OldCAs, ca.GUID)
+	}
+
+	var response api.GenerateCAResponse
+	if r.Options.CertPem != "" {
+		var certPem string
+		certPem, err = certificateAuthorityChain(r.Options.CertPem, r.Options.PrivateKey)
+		if err != nil {
+			return err
+		}
+
+		response, err = r.service.CreateCertificateAuthority(api.CertificateAuthorityInput{
+			CertPem:       certPem,
+			PrivateKeyPem: r.Options.PrivateKey,
+		})
+	} else {
+		response, err = r.service.GenerateCertificateAuthority()
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range response.Warnings {
+		r.logger.Printf("warning: %s", warning)
+	}
+
+	r.state.NewCA = response.GUID
+	r.logger.Printf("created certificate authority '%s'", response.GUID)
+
+	return nil
+}
+
+// deleteOldCAs only deletes the CAs that are still there and inactive, so it
+// can be re-run after a partial failure.
+func (r *RotateCertificateAuthority) deleteOldCAs() error {
+	cas, err := r.service.ListCertificateAuthorities()
+	if err != nil {
+		return err
+	}
+
+	for _, ca := range cas.CAs {
+		if ca.Active || !slices.Contains(r.state.OldCAs, ca.GUID) {
+			continue
+		}
+
+		err = r.service.DeleteCertificateAuthority(api.DeleteCertificateAuthorityInput{GUID: ca.GUID})
+		if err != nil {
+			return err
+		}
+
+		r.logger.Printf("deleted certificate authority '%s'", ca.GUID)
+	}
+
+	return nil
+}
+
+// applyChanges reattaches to an installation that is already running, so a
+// rotation interrupted during an apply waits for it rather than failing.
+func (r *RotateCertificateAuthority) applyChanges() error {
+	applyChanges := NewApplyChanges(r.service, r.service, r.logWriter, r.logger, io.Discard, r.waitDuration)
+	applyChanges.Options.IgnoreWarnings = r.Options.IgnoreWarnings
+	applyChanges.Options.Reattach = true
+
+	return applyChanges.Execute(nil)
+}
+
+func (r *RotateCertificateAuthority) loadState() error {
+	r.state = caRotationState{}
+
+	if r.Options.StateFile == "" {
+		return nil
+	}
+
+	return stepstate.Read(r.Options.StateFile, &r.state)
+}
+
+func (r *RotateCertificateAuthority) saveState() error {
+	if r.Options.StateFile == "" {
+		return nil
+	}
+
+	return stepstate.Write(r.Options.StateFile, r.state)
+}
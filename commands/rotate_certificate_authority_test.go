@@ -0,0 +1,162 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/onsi/gomega/gbytes"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RotateCertificateAuthority", func() {
+	var (
+		service *fakes.RotateCertificateAuthorityService
+		writer  *fakes.LogWriter
+		stdout  *gbytes.Buffer
+		command *commands.RotateCertificateAuthority
+		calls   []string
+	)
+
+	BeforeEach(func() {
+		service = &fakes.RotateCertificateAuthorityService{}
+		writer = &fakes.LogWriter{}
+		stdout = gbytes.NewBuffer()
+		calls = nil
+
+		service.ListCertificateAuthoritiesStub = func() (api.CertificateAuthoritiesOutput, error) {
+			calls = append(calls, "list")
+			activated := service.ActivateCertificateAuthorityCallCount() > 0
+			return api.CertificateAuthoritiesOutput{CAs: []api.CA{
+				{GUID: "old-guid", Active: !activated},
+				{GUID: "new-guid", Active: activated},
+			}}, nil
+		}
+		service.GenerateCertificateAuthorityStub = func() (api.GenerateCAResponse, error) {
+			calls = append(calls, "generate")
+			return api.GenerateCAResponse{CA: api.CA{GUID: "new-guid"}}, nil
+		}
+		service.CreateCertificateAuthorityStub = func(api.CertificateAuthorityInput) (api.GenerateCAResponse, error) {
+			calls = append(calls, "create")
+			return api.GenerateCAResponse{CA: api.CA{GUID: "new-guid"}}, nil
+		}
+		service.ActivateCertificateAuthorityStub = func(input api.ActivateCertificateAuthorityInput) error {
+			calls = append(calls, "activate "+input.GUID)
+			return nil
+		}
+		service.RegenerateCertificatesStub = func() error {
+			calls = append(calls, "regenerate")
+			return nil
+		}
+		service.DeleteCertificateAuthorityStub = func(input api.DeleteCertificateAuthorityInput) error {
+			calls = append(calls, "delete "+input.GUID)
+			return nil
+		}
+		service.CreateInstallationStub = func(bool, bool, bool, []string, api.ApplyErrandChanges) (api.InstallationsServiceOutput, error) {
+			calls = append(calls, "apply")
+			return api.InstallationsServiceOutput{ID: 1}, nil
+		}
+		service.GetInstallationReturns(api.InstallationsServiceOutput{Status: api.StatusSucceeded}, nil)
+
+		command = commands.NewRotateCertificateAuthority(service, writer, log.New(stdout, "", 0), 0)
+	})
+
+	It("creates, activates and deletes the certificate authorities, applying changes after each", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(calls).To(Equal([]string{
+			"list",
+			"generate",
+			"apply",
+			"activate new-guid",
+			"regenerate",
+			"apply",
+			"list",
+			"delete old-guid",
+			"apply",
+		}))
+		Expect(stdout).To(gbytes.Say("certificate authority 'new-guid' is active"))
+	})
+
+	It("creates the certificate authority from the provided certificate", func() {
+		err := executeCommand(command, []string{
+			"--certificate-pem", "some-cert",
+			"--private-key-pem", "some-key",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.GenerateCertificateAuthorityCallCount()).To(Equal(0))
+		Expect(service.CreateCertificateAuthorityArgsForCall(0)).To(Equal(api.CertificateAuthorityInput{
+			CertPem:       "some-cert",
+			PrivateKeyPem: "some-key",
+		}))
+	})
+
+	It("resumes from the step that failed when a state file is given", func() {
+		stateFile := filepath.Join(GinkgoT().TempDir(), "state.yml")
+
+		service.RegenerateCertificatesReturns(errors.New("regenerate failed"))
+		err := executeCommand(command, []string{"--state-file", stateFile})
+		Expect(err).To(MatchError(ContainSubstring("could not regenerate-certificates: regenerate failed")))
+
+		contents, err := os.ReadFile(stateFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		var state map[string]interface{}
+		Expect(yaml.Unmarshal(contents, &state)).To(Succeed())
+		Expect(state).To(HaveKeyWithValue("new_certificate_authority", "new-guid"))
+		Expect(state).To(HaveKeyWithValue("completed", ConsistOf(
+			"create-certificate-authority",
+			"apply-changes-with-new-certificate-authority",
+			"activate-certificate-authority",
+		)))
+
+		service.RegenerateCertificatesReturns(nil)
+		calls = nil
+		err = executeCommand(command, []string{"--state-file", stateFile})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.GenerateCertificateAuthorityCallCount()).To(Equal(1))
+		Expect(service.ActivateCertificateAuthorityCallCount()).To(Equal(1))
+		Expect(service.RegenerateCertificatesCallCount()).To(Equal(2))
+		Expect(calls).To(Equal([]string{
+			"apply",
+			"list",
+			"delete old-guid",
+			"apply",
+		}))
+		Expect(stateFile).ToNot(BeAnExistingFile())
+
+		calls = nil
+		err = executeCommand(command, []string{"--state-file", stateFile})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(service.GenerateCertificateAuthorityCallCount()).To(Equal(2))
+	})
+
+	It("reattaches to an apply changes that is still running", func() {
+		startedAt := time.Now()
+		service.RunningInstallationReturnsOnCall(0, api.InstallationsServiceOutput{ID: 42, StartedAt: &startedAt}, nil)
+
+		err := executeCommand(command, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.GetInstallationArgsForCall(0)).To(Equal(42))
+		Expect(service.CreateInstallationCallCount()).To(Equal(2))
+	})
+
+	It("requires the private key with the certificate", func() {
+		err := executeCommand(command, []string{"--certificate-pem", "some-cert"})
+		Expect(err).To(MatchError("--certificate-pem and --private-key-pem must be provided together"))
+		Expect(service.Invocations()).To(BeEmpty())
+	})
+})
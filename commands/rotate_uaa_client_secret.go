@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pivotal-cf/om/interpolate"
+)
+
+type RotateUAAClientSecret struct {
+	service      rotateUAAClientSecretService
+	verifier     credentialsVerifier
+	clientID     string
+	clientSecret string
+	environFunc  func() []string
+	logger       logger
+	Options      struct {
+		ClientID  string `long:"uaa-client-id"                                             description:"the client whose secret is changed. Defaults to the client om authenticates as"`
+		OldSecret string `long:"old-secret"    env:"OM_OLD_SECRET"                         description:"the current secret of the client. Defaults to --client-secret for the client om authenticates as, and may be left out when om has the clients.secret scope"`
+		NewSecret string `long:"new-secret"    env:"OM_NEW_SECRET"                         description:"the new secret. A secret is generated when it is not given"`
+		VarsStore string `long:"vars-store"                                                description:"vars store to write the new secret to, e.g. file://creds.yml"`
+		VarName   string `long:"var-name"                          default:"client_secret" description:"name of the variable the new secret is written to"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/rotate_uaa_client_secret_service.go --fake-name RotateUAAClientSecretService . rotateUAAClientSecretService
+type rotateUAAClientSecretService interface {
+	ChangeUAAClientSecret(clientID, oldSecret, newSecret string) error
+}
+
+func NewRotateUAAClientSecret(environFunc func() []string, service rotateUAAClientSecretService, verifier credentialsVerifier, clientID, clientSecret string, logger logger) *RotateUAAClientSecret {
+	return &RotateUAAClientSecret{
+		service:      service,
+		verifier:     verifier,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		environFunc:  environFunc,
+		logger:       logger,
+	}
+}
+
+func (r RotateUAAClientSecret) Execute(args []string) error {
+	clientID := r.Options.ClientID
+	oldSecret := r.Options.OldSecret
+	if clientID == "" {
+		clientID = r.clientID
+	}
+	if clientID == "" {
+		return errors.New("--uaa-client-id is required when om does not authenticate with --client-id")
+	}
+	if oldSecret == "" && clientID == r.clientID {
+		oldSecret = r.clientSecret
+	}
+
+	if r.Options.NewSecret == "" && r.Options.VarsStore == "" {
+		return errors.New("--new-secret or --vars-store is required, so a generated secret is not lost")
+	}
+
+	// the vars store is opened before changing anything, so the new secret
+	// can be written to it
+	var store interpolate.VarsStoreWriter
+	if r.Options.VarsStore != "" {
+		var err error
+		store, err = interpolate.NewVarsStoreWriter(r.Options.VarsStore, r.environFunc)
+		if errors.Is(err, interpolate.ErrVarsStoreNotWritable) {
+			return fmt.Errorf("the new secret cannot be written to the vars store: %s. Set it in that store and pass it with --new-secret instead", err)
+		}
+		if err != nil {
+			return fmt.Errorf("could not open vars store: %s", err)
+		}
+	}
+
+	newSecret := r.Options.NewSecret
+	if newSecret == "" {
+		var err error
+		newSecret, err = interpolate.GeneratePassword(interpolate.DefaultPasswordLength)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := r.service.ChangeUAAClientSecret(clientID, oldSecret, newSecret)
+	if err != nil {
+		return fmt.Errorf("could not change the secret of %s: %s", clientID, err)
+	}
+
+	r.logger.Printf("changed the secret of %s", clientID)
+
+	// the token cached for the old secret can only be found with it
+	if oldSecret != "" {
+		err = r.verifier.ForgetToken("", "", clientID, oldSecret)
+		if err != nil {
+			r.logger.Printf("Warning: could not remove the cached token of the old secret: %s", err)
+		}
+	}
+
+	if store != nil {
+		err = store.Put(r.Options.VarName, newSecret)
+		if err != nil {
+			// a generated secret is not known anywhere else, so it is
+			// reported rather than lost
+			if r.Options.NewSecret == "" {
+				return fmt.Errorf("the secret of %s was changed to %q, but could not be written to the vars store: %s", clientID, newSecret, err)
+			}
+
+			return fmt.Errorf("the secret of %s was changed, but could not be written to the vars store: %s", clientID, err)
+		}
+
+		r.logger.Printf("wrote the new secret to %s in the vars store", r.Options.VarName)
+	}
+
+	err = r.verifier.VerifyCredentials("", "", clientID, newSecret)
+	if err != nil {
+		return fmt.Errorf("the secret of %s was changed, but UAA does not grant a token for the new secret: %s", clientID, err)
+	}
+
+	r.logger.Printf("verified the new secret of %s", clientID)
+
+	return nil
+}
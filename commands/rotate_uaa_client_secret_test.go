@@ -0,0 +1,104 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("RotateUAAClientSecret", func() {
+	var (
+		service  *fakes.RotateUAAClientSecretService
+		verifier *fakes.CredentialsVerifier
+		stdout   *gbytes.Buffer
+		command  *commands.RotateUAAClientSecret
+	)
+
+	BeforeEach(func() {
+		service = &fakes.RotateUAAClientSecretService{}
+		verifier = &fakes.CredentialsVerifier{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewRotateUAAClientSecret(func() []string { return nil }, service, verifier, "automation", "old-secret", log.New(stdout, "", 0))
+	})
+
+	It("changes the secret of the client om authenticates as and verifies the new one", func() {
+		err := executeCommand(command, []string{"--new-secret", "new-secret"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.ChangeUAAClientSecretCallCount()).To(Equal(1))
+		clientID, oldSecret, newSecret := service.ChangeUAAClientSecretArgsForCall(0)
+		Expect([]string{clientID, oldSecret, newSecret}).To(Equal([]string{"automation", "old-secret", "new-secret"}))
+
+		username, password, clientID, clientSecret := verifier.VerifyCredentialsArgsForCall(0)
+		Expect([]string{username, password, clientID, clientSecret}).To(Equal([]string{"", "", "automation", "new-secret"}))
+
+		username, password, clientID, clientSecret = verifier.ForgetTokenArgsForCall(0)
+		Expect([]string{username, password, clientID, clientSecret}).To(Equal([]string{"", "", "automation", "old-secret"}))
+
+		Expect(stdout).To(gbytes.Say("changed the secret of automation"))
+		Expect(stdout).To(gbytes.Say("verified the new secret of automation"))
+	})
+
+	It("changes the secret of another client", func() {
+		err := executeCommand(command, []string{"--uaa-client-id", "concourse", "--new-secret", "new-secret"})
+		Expect(err).ToNot(HaveOccurred())
+
+		clientID, oldSecret, _ := service.ChangeUAAClientSecretArgsForCall(0)
+		Expect(clientID).To(Equal("concourse"))
+		Expect(oldSecret).To(BeEmpty())
+		Expect(verifier.ForgetTokenCallCount()).To(Equal(0))
+	})
+
+	It("generates a secret and writes it to the vars store", func() {
+		storePath := filepath.Join(GinkgoT().TempDir(), "creds.yml")
+
+		err := executeCommand(command, []string{"--vars-store", "file://" + storePath})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, _, newSecret := service.ChangeUAAClientSecretArgsForCall(0)
+		Expect(newSecret).To(HaveLen(32))
+
+		contents, err := os.ReadFile(storePath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML("client_secret: " + newSecret))
+	})
+
+	It("reports a generated secret that cannot be written to the vars store", func() {
+		storePath := filepath.Join(GinkgoT().TempDir(), "missing", "creds.yml")
+
+		err := executeCommand(command, []string{"--vars-store", "file://" + storePath})
+
+		_, _, newSecret := service.ChangeUAAClientSecretArgsForCall(0)
+		Expect(err).To(MatchError(ContainSubstring(`the secret of automation was changed to "` + newSecret + `", but could not be written to the vars store: could not write vars store`)))
+	})
+
+	It("requires a client", func() {
+		command = commands.NewRotateUAAClientSecret(nil, service, verifier, "", "", log.New(stdout, "", 0))
+
+		err := executeCommand(command, []string{"--new-secret", "new-secret"})
+		Expect(err).To(MatchError("--uaa-client-id is required when om does not authenticate with --client-id"))
+	})
+
+	It("returns an error when the secret cannot be changed", func() {
+		service.ChangeUAAClientSecretReturns(errors.New("forbidden"))
+
+		err := executeCommand(command, []string{"--new-secret", "new-secret"})
+		Expect(err).To(MatchError("could not change the secret of automation: forbidden"))
+		Expect(verifier.VerifyCredentialsCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the new secret cannot be verified", func() {
+		verifier.VerifyCredentialsReturns(errors.New("unauthorized"))
+
+		err := executeCommand(command, []string{"--new-secret", "new-secret"})
+		Expect(err).To(MatchError("the secret of automation was changed, but UAA does not grant a token for the new secret: unauthorized"))
+	})
+})
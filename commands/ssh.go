@@ -0,0 +1,338 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/network"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/net/proxy"
+	"golang.org/x/term"
+)
+
+// SSHCommandError is returned when the command run over ssh exits with a
+// non-zero exit code, so om can exit with the same code.
+type SSHCommandError struct {
+	ExitCode int
+}
+
+func (e SSHCommandError) Error() string {
+	return fmt.Sprintf("ssh command exited with exit code %d", e.ExitCode)
+}
+
+type SSH struct {
+	service           sshService
+	opsmanHost        string
+	socksProxy        string
+	jumpbox           string
+	jumpboxPrivateKey string
+	stdin             io.Reader
+	stdout            io.Writer
+	stderr            io.Writer
+	Options           struct {
+		VM                      string `long:"vm"              default:"opsman" choice:"opsman" choice:"director" description:"the VM to ssh into"`
+		SSHPrivateKey           string `long:"ssh-private-key" short:"i"        env:"OM_SSH_PRIVATE_KEY"          description:"path to the private key of the ubuntu user of the Ops Manager VM. Required for the opsman VM, for the director it tunnels through the Ops Manager VM"`
+		Port                    int    `long:"port"            default:"22"     description:"ssh port of the VM"`
+		KnownHosts              string `long:"known-hosts"     env:"OM_SSH_KNOWN_HOSTS" description:"path to a known_hosts file to verify the host keys with (default: ~/.ssh/known_hosts)"`
+		SkipHostKeyVerification bool   `long:"skip-host-key-verification" description:"do not verify the host keys of the VMs. This is insecure"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/ssh_service.go --fake-name SSHService . sshService
+type sshService interface {
+	GetBoshEnvironment() (api.GetBoshEnvironmentOutput, error)
+	GetDirectorSSHCredentials() (api.DirectorSSHCredentials, error)
+}
+
+// sshHop is one of the hosts a connection passes through, in order,
+// the last one being the VM itself.
+type sshHop struct {
+	address string
+	config  *ssh.ClientConfig
+}
+
+func NewSSH(service sshService, opsmanHost, socksProxy, jumpbox, jumpboxPrivateKey string, stdin io.Reader, stdout, stderr io.Writer) *SSH {
+	return &SSH{
+		service:           service,
+		opsmanHost:        opsmanHost,
+		socksProxy:        socksProxy,
+		jumpbox:           jumpbox,
+		jumpboxPrivateKey: jumpboxPrivateKey,
+		stdin:             stdin,
+		stdout:            stdout,
+		stderr:            stderr,
+	}
+}
+
+func (s SSH) Execute(args []string) error {
+	hops, err := s.hops()
+	if err != nil {
+		return err
+	}
+
+	client, closeAll, err := s.connect(hops)
+	defer closeAll()
+	if err != nil {
+		return err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("could not open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	session.Stdin = s.stdin
+	session.Stdout = s.stdout
+	session.Stderr = s.stderr
+
+	if len(args) > 0 {
+		err = session.Run(strings.Join(args, " "))
+	} else {
+		err = s.shell(session)
+	}
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return SSHCommandError{ExitCode: exitErr.ExitStatus()}
+	}
+
+	return err
+}
+
+func (s SSH) hops() ([]sshHop, error) {
+	hostKeyCallback, err := s.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	var hops []sshHop
+	if s.jumpbox != "" {
+		user, address := splitSSHAddress(s.jumpbox, "ubuntu", 22)
+
+		keyFile := s.jumpboxPrivateKey
+		if keyFile == "" {
+			keyFile = s.Options.SSHPrivateKey
+		}
+		if keyFile == "" {
+			return nil, errors.New("--ssh-jumpbox-private-key is required to use the ssh jumpbox")
+		}
+
+		signer, err := readSSHSigner(keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		hops = append(hops, newSSHHop(address, user, signer, hostKeyCallback))
+	}
+
+	opsmanHostname := stripPort(BoshEnvironment{opsmanHost: s.opsmanHost}.Target())
+
+	switch s.Options.VM {
+	case "director":
+		if s.Options.SSHPrivateKey != "" {
+			signer, err := readSSHSigner(s.Options.SSHPrivateKey)
+			if err != nil {
+				return nil, err
+			}
+
+			hops = append(hops, newSSHHop(net.JoinHostPort(opsmanHostname, "22"), "ubuntu", signer, hostKeyCallback))
+		}
+
+		boshEnvironment, err := s.service.GetBoshEnvironment()
+		if err != nil {
+			return nil, err
+		}
+
+		credentials, err := s.service.GetDirectorSSHCredentials()
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err := ssh.ParsePrivateKey([]byte(credentials.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the ssh key of the director: %w", err)
+		}
+
+		address := net.JoinHostPort(boshEnvironment.Environment, fmt.Sprint(s.Options.Port))
+		hops = append(hops, newSSHHop(address, credentials.Username, signer, hostKeyCallback))
+	default:
+		if s.Options.SSHPrivateKey == "" {
+			return nil, errors.New("--ssh-private-key is required to ssh into the Ops Manager VM")
+		}
+
+		signer, err := readSSHSigner(s.Options.SSHPrivateKey)
+		if err != nil {
+			return nil, err
+		}
+
+		address := net.JoinHostPort(opsmanHostname, fmt.Sprint(s.Options.Port))
+		hops = append(hops, newSSHHop(address, "ubuntu", signer, hostKeyCallback))
+	}
+
+	return hops, nil
+}
+
+func (s SSH) hostKeyCallback() (ssh.HostKeyCallback, error) {
+	if s.Options.SkipHostKeyVerification {
+		if s.Options.KnownHosts != "" {
+			return nil, errors.New("--known-hosts cannot be used with --skip-host-key-verification")
+		}
+
+		fmt.Fprintln(s.stderr, "Warning: host keys are not verified")
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	knownHosts := s.Options.KnownHosts
+	if knownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("could not find the known hosts, use --known-hosts: %w", err)
+		}
+		knownHosts = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	callback, err := knownhosts.New(knownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("could not read known hosts, use --known-hosts or --skip-host-key-verification: %w", err)
+	}
+
+	return callback, nil
+}
+
+// connect dials the first hop, through the SOCKS proxy when one is set,
+// and every further hop through the previous one.
+func (s SSH) connect(hops []sshHop) (*ssh.Client, func(), error) {
+	var clients []*ssh.Client
+	closeAll := func() {
+		for i := len(clients) - 1; i >= 0; i-- {
+			_ = clients[i].Close()
+		}
+	}
+
+	var dialer proxy.Dialer = proxy.Direct
+	if s.socksProxy != "" {
+		proxyURL, err := network.ParseSOCKSProxy(s.socksProxy)
+		if err != nil {
+			return nil, closeAll, err
+		}
+
+		dialer, err = proxy.FromURL(proxyURL, proxy.Direct)
+		if err != nil {
+			return nil, closeAll, fmt.Errorf("could not use socks proxy: %w", err)
+		}
+	}
+
+	for _, hop := range hops {
+		var (
+			conn net.Conn
+			err  error
+		)
+		if len(clients) == 0 {
+			conn, err = dialer.Dial("tcp", hop.address)
+		} else {
+			conn, err = clients[len(clients)-1].Dial("tcp", hop.address)
+		}
+		if err != nil {
+			return nil, closeAll, fmt.Errorf("could not connect to %s: %w", hop.address, err)
+		}
+
+		clientConn, chans, reqs, err := ssh.NewClientConn(conn, hop.address, hop.config)
+		if err != nil {
+			_ = conn.Close()
+			return nil, closeAll, fmt.Errorf("could not ssh into %s: %w", hop.address, err)
+		}
+
+		clients = append(clients, ssh.NewClient(clientConn, chans, reqs))
+	}
+
+	return clients[len(clients)-1], closeAll, nil
+}
+
+// shell starts an interactive shell, with a terminal when om is run
+// from one.
+func (s SSH) shell(session *ssh.Session) error {
+	if f, ok := s.stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		width, height, err := term.GetSize(int(f.Fd()))
+		if err != nil {
+			width, height = 80, 24
+		}
+
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return fmt.Errorf("could not set up the terminal: %w", err)
+		}
+		defer func() { _ = term.Restore(int(f.Fd()), state) }()
+
+		terminal := os.Getenv("TERM")
+		if terminal == "" {
+			terminal = "xterm"
+		}
+
+		err = session.RequestPty(terminal, height, width, ssh.TerminalModes{ssh.ECHO: 1})
+		if err != nil {
+			return fmt.Errorf("could not request a terminal: %w", err)
+		}
+	}
+
+	err := session.Shell()
+	if err != nil {
+		return fmt.Errorf("could not start shell: %w", err)
+	}
+
+	return session.Wait()
+}
+
+func newSSHHop(address, user string, signer ssh.Signer, hostKeyCallback ssh.HostKeyCallback) sshHop {
+	return sshHop{
+		address: address,
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+		},
+	}
+}
+
+func readSSHSigner(keyFile string) (ssh.Signer, error) {
+	contents, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ssh private key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(contents)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse ssh private key %s: %w", keyFile, err)
+	}
+
+	return signer, nil
+}
+
+// splitSSHAddress splits [user@]host[:port] into the user and host:port.
+func splitSSHAddress(value, defaultUser string, defaultPort int) (string, string) {
+	user := defaultUser
+	if i := strings.LastIndex(value, "@"); i >= 0 {
+		user, value = value[:i], value[i+1:]
+	}
+
+	if _, _, err := net.SplitHostPort(value); err != nil {
+		value = net.JoinHostPort(value, fmt.Sprint(defaultPort))
+	}
+
+	return user, value
+}
+
+func stripPort(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		return hostname
+	}
+
+	return host
+}
@@ -0,0 +1,349 @@
+package commands_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("SSH", func() {
+	var (
+		fakeService *fakes.SSHService
+		server      *sshTestServer
+		keyPEM      string
+		keyFile     string
+		stdout      *gbytes.Buffer
+		stderr      *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		keyPEM = newSSHTestKey()
+		keyFile = filepath.Join(GinkgoT().TempDir(), "opsman.pem")
+		Expect(os.WriteFile(keyFile, []byte(keyPEM), 0600)).To(Succeed())
+
+		server = newSSHTestServer(keyPEM)
+		DeferCleanup(server.Close)
+
+		home := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		Expect(os.Mkdir(filepath.Join(home, ".ssh"), 0700)).To(Succeed())
+		line := fmt.Sprintf("[127.0.0.1]:%s %s", server.Port(), ssh.MarshalAuthorizedKey(server.hostKey.PublicKey()))
+		Expect(os.WriteFile(filepath.Join(home, ".ssh", "known_hosts"), []byte(line), 0600)).To(Succeed())
+
+		fakeService = &fakes.SSHService{}
+		fakeService.GetBoshEnvironmentReturns(api.GetBoshEnvironmentOutput{Environment: "127.0.0.1"}, nil)
+		fakeService.GetDirectorSSHCredentialsReturns(api.DirectorSSHCredentials{Username: "bbr", PrivateKey: keyPEM}, nil)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+	})
+
+	execute := func(command *commands.SSH, args ...string) error {
+		rest, err := flags.NewParser(command, flags.HelpFlag|flags.PassDoubleDash).ParseArgs(args)
+		Expect(err).ToNot(HaveOccurred())
+
+		return command.Execute(rest)
+	}
+
+	It("runs a command on the Ops Manager VM", func() {
+		command := commands.NewSSH(fakeService, "https://127.0.0.1/", "", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "--ssh-private-key", keyFile, "--port", server.Port(), "--", "sudo", "ls")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say("ran sudo ls"))
+		Expect(stderr.Contents()).To(BeEmpty())
+		Expect(server.Users()).To(Equal([]string{"ubuntu"}))
+		Expect(fakeService.GetDirectorSSHCredentialsCallCount()).To(Equal(0))
+	})
+
+	It("starts a shell without a command", func() {
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "-i", keyFile, "--port", server.Port())
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say("started shell"))
+	})
+
+	It("runs a command on the director with the credentials of the bbr user", func() {
+		command := commands.NewSSH(fakeService, "https://opsman.example.com", "", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "--vm", "director", "--port", server.Port(), "--", "monit", "summary")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say("ran monit summary"))
+		Expect(server.Users()).To(Equal([]string{"bbr"}))
+	})
+
+	It("connects through the jumpbox", func() {
+		command := commands.NewSSH(fakeService, "https://127.0.0.1", "", "jumper@127.0.0.1:"+server.Port(), keyFile, strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "--vm", "director", "--port", server.Port(), "--", "hostname")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(stdout).To(gbytes.Say("ran hostname"))
+		Expect(server.Users()).To(Equal([]string{"jumper", "bbr"}))
+	})
+
+	It("verifies host keys with known hosts", func() {
+		knownHosts := filepath.Join(GinkgoT().TempDir(), "known_hosts")
+		line := fmt.Sprintf("[127.0.0.1]:%s %s", server.Port(), ssh.MarshalAuthorizedKey(server.hostKey.PublicKey()))
+		Expect(os.WriteFile(knownHosts, []byte(line), 0600)).To(Succeed())
+
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+		err := execute(command, "-i", keyFile, "--port", server.Port(), "--known-hosts", knownHosts, "--", "hostname")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stderr.Contents()).To(BeEmpty())
+
+		Expect(os.WriteFile(knownHosts, []byte(fmt.Sprintf("[127.0.0.1]:%s %s", server.Port(), keyPEMPublicKey(newSSHTestKey()))), 0600)).To(Succeed())
+
+		command = commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+		err = execute(command, "-i", keyFile, "--port", server.Port(), "--known-hosts", knownHosts, "--", "hostname")
+		Expect(err).To(MatchError(ContainSubstring("key mismatch")))
+	})
+
+	It("verifies host keys with ~/.ssh/known_hosts by default", func() {
+		Expect(os.WriteFile(filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"), []byte(fmt.Sprintf("[127.0.0.1]:%s %s", server.Port(), keyPEMPublicKey(newSSHTestKey()))), 0600)).To(Succeed())
+
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+		err := execute(command, "-i", keyFile, "--port", server.Port(), "--", "hostname")
+		Expect(err).To(MatchError(ContainSubstring("key mismatch")))
+	})
+
+	It("returns an error when there are no known hosts", func() {
+		Expect(os.Remove(filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"))).To(Succeed())
+
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+		err := execute(command, "-i", keyFile, "--port", server.Port(), "--", "hostname")
+		Expect(err).To(MatchError(ContainSubstring("could not read known hosts, use --known-hosts or --skip-host-key-verification")))
+	})
+
+	It("does not verify host keys with --skip-host-key-verification", func() {
+		Expect(os.Remove(filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"))).To(Succeed())
+
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+		err := execute(command, "-i", keyFile, "--port", server.Port(), "--skip-host-key-verification", "--", "hostname")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stderr).To(gbytes.Say("Warning: host keys are not verified"))
+
+		err = execute(command, "-i", keyFile, "--port", server.Port(), "--skip-host-key-verification", "--known-hosts", "/some/known_hosts", "--", "hostname")
+		Expect(err).To(MatchError("--known-hosts cannot be used with --skip-host-key-verification"))
+	})
+
+	It("returns the exit code of the command", func() {
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "-i", keyFile, "--port", server.Port(), "--", "exit", "3")
+		Expect(err).To(Equal(commands.SSHCommandError{ExitCode: 3}))
+		Expect(err).To(MatchError("ssh command exited with exit code 3"))
+	})
+
+	It("requires the ssh key of the Ops Manager VM", func() {
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "--", "hostname")
+		Expect(err).To(MatchError("--ssh-private-key is required to ssh into the Ops Manager VM"))
+	})
+
+	It("returns an error when the director credentials cannot be retrieved", func() {
+		fakeService.GetDirectorSSHCredentialsReturns(api.DirectorSSHCredentials{}, errors.New("some error"))
+		command := commands.NewSSH(fakeService, "127.0.0.1", "", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "--vm", "director", "--", "hostname")
+		Expect(err).To(MatchError("some error"))
+	})
+
+	It("returns an error when the socks proxy is not valid", func() {
+		command := commands.NewSSH(fakeService, "127.0.0.1", "http://proxy:8080", "", "", strings.NewReader(""), stdout, stderr)
+
+		err := execute(command, "-i", keyFile, "--", "hostname")
+		Expect(err).To(MatchError(ContainSubstring("must use the socks5 or socks5h scheme")))
+	})
+})
+
+func newSSHTestKey() string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	Expect(err).ToNot(HaveOccurred())
+
+	return string(pem.EncodeToMemory(block))
+}
+
+func keyPEMPublicKey(keyPEM string) []byte {
+	signer, err := ssh.ParsePrivateKey([]byte(keyPEM))
+	Expect(err).ToNot(HaveOccurred())
+
+	return ssh.MarshalAuthorizedKey(signer.PublicKey())
+}
+
+// sshTestServer accepts the given key, runs sessions by echoing the
+// command, and forwards connections like a jumpbox.
+type sshTestServer struct {
+	listener net.Listener
+	hostKey  ssh.Signer
+	mutex    sync.Mutex
+	users    []string
+}
+
+func newSSHTestServer(authorizedKeyPEM string) *sshTestServer {
+	hostKey, err := ssh.ParsePrivateKey([]byte(newSSHTestKey()))
+	Expect(err).ToNot(HaveOccurred())
+
+	authorizedKey, err := ssh.ParsePrivateKey([]byte(authorizedKeyPEM))
+	Expect(err).ToNot(HaveOccurred())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+
+	server := &sshTestServer{listener: listener, hostKey: hostKey}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorizedKey.PublicKey().Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+
+			server.mutex.Lock()
+			server.users = append(server.users, conn.User())
+			server.mutex.Unlock()
+
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go server.serve(conn, config)
+		}
+	}()
+
+	return server
+}
+
+func (s *sshTestServer) Port() string {
+	return fmt.Sprint(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *sshTestServer) Users() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string{}, s.users...)
+}
+
+func (s *sshTestServer) Close() {
+	_ = s.listener.Close()
+}
+
+func (s *sshTestServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		switch newChannel.ChannelType() {
+		case "session":
+			channel, requests, err := newChannel.Accept()
+			if err != nil {
+				continue
+			}
+			go serveSSHTestSession(channel, requests)
+		case "direct-tcpip":
+			var target struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}
+			if err := ssh.Unmarshal(newChannel.ExtraData(), &target); err != nil {
+				_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+
+			forwarded, err := net.Dial("tcp", net.JoinHostPort(target.Host, fmt.Sprint(target.Port)))
+			if err != nil {
+				_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+				continue
+			}
+
+			channel, requests, err := newChannel.Accept()
+			if err != nil {
+				_ = forwarded.Close()
+				continue
+			}
+			go ssh.DiscardRequests(requests)
+			go func() {
+				_, _ = io.Copy(channel, forwarded)
+				_ = channel.Close()
+			}()
+			go func() {
+				_, _ = io.Copy(forwarded, channel)
+				_ = forwarded.Close()
+			}()
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+		}
+	}
+}
+
+func serveSSHTestSession(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	for request := range requests {
+		var exitStatus uint32
+		switch request.Type {
+		case "exec":
+			var payload struct{ Command string }
+			_ = ssh.Unmarshal(request.Payload, &payload)
+
+			if code, found := strings.CutPrefix(payload.Command, "exit "); found {
+				_, _ = fmt.Sscan(code, &exitStatus)
+			} else {
+				_, _ = fmt.Fprintf(channel, "ran %s\n", payload.Command)
+			}
+		case "shell":
+			_, _ = fmt.Fprintln(channel, "started shell")
+		default:
+			_ = request.Reply(false, nil)
+			continue
+		}
+
+		_ = request.Reply(true, nil)
+
+		status := make([]byte, 4)
+		binary.BigEndian.PutUint32(status, exitStatus)
+		_, _ = channel.SendRequest("exit-status", false, status)
+
+		return
+	}
+}
@@ -2,7 +2,7 @@ package commands
 
 import (
 	"fmt"
-
+	"os"
 	"strings"
 
 	"github.com/pivotal-cf/om/api"
@@ -18,6 +18,13 @@ type StagedConfig struct {
 		Product             string `long:"product-name" short:"p" required:"true" description:"name of product"`
 		IncludeCredentials  bool   `long:"include-credentials" short:"c" description:"include credentials. note: requires product to have been deployed"`
 		IncludePlaceholders bool   `long:"include-placeholders" short:"r" description:"replace obscured credentials with interpolatable placeholders"`
+
+		IncludeCredential []string `long:"include-credential" description:"only include the credentials matching this property path glob (starting with a \".\") or credential type. can be specified multiple times"`
+		ExcludeCredential []string `long:"exclude-credential" description:"leave out the credentials matching this property path glob (starting with a \".\") or credential type. can be specified multiple times"`
+
+		PlaceholderPrefix    string `long:"placeholder-prefix" description:"prefix the name of each placeholder, e.g. with the path of the credentials in CredHub"`
+		PlaceholderSeparator string `long:"placeholder-separator" default:"_" description:"join the segments of the property path with this value in the name of placeholders"`
+		PlaceholderMapping   string `long:"placeholder-mapping" description:"path to a yml file mapping property paths (e.g. '.properties.some_secret' or '.properties.some_collection.0.some_field') to the name of their placeholder"`
 	}
 }
 
@@ -44,15 +51,36 @@ func NewStagedConfig(service stagedConfigService, logger logger) *StagedConfig {
 }
 
 func (ec StagedConfig) Execute(args []string) error {
-	info, err := ec.service.Info()
+	config, _, err := ec.productConfiguration()
 	if err != nil {
 		return err
 	}
 
+	output, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal config: %s", err) // un-tested
+	}
+
+	ec.logger.Println(string(output))
+	return nil
+}
+
+// productConfiguration also returns the names of the credential properties,
+// which are left out unless credentials or placeholders are included.
+func (ec StagedConfig) productConfiguration() (config.ProductConfiguration, map[string]bool, error) {
+	if len(ec.Options.IncludeCredential)+len(ec.Options.ExcludeCredential) > 0 && !ec.Options.IncludeCredentials && !ec.Options.IncludePlaceholders {
+		return config.ProductConfiguration{}, nil, fmt.Errorf("--include-credential and --exclude-credential require --include-credentials or --include-placeholders")
+	}
+
+	info, err := ec.service.Info()
+	if err != nil {
+		return config.ProductConfiguration{}, nil, err
+	}
+
 	if ec.Options.IncludeCredentials {
 		deployedProducts, err := ec.service.ListDeployedProducts()
 		if err != nil {
-			return err
+			return config.ProductConfiguration{}, nil, err
 		}
 		var productDeployed bool
 		for _, p := range deployedProducts {
@@ -62,25 +90,37 @@ func (ec StagedConfig) Execute(args []string) error {
 			}
 		}
 		if !productDeployed {
-			return fmt.Errorf("cannot retrieve credentials for product '%s': deploy the product and retry", ec.Options.Product)
+			return config.ProductConfiguration{}, nil, fmt.Errorf("cannot retrieve credentials for product '%s': deploy the product and retry", ec.Options.Product)
 		}
 	}
 
 	findOutput, err := ec.service.GetStagedProductByName(ec.Options.Product)
 	if err != nil {
-		return err
+		return config.ProductConfiguration{}, nil, err
 	}
 	productGUID := findOutput.Product.GUID
 
 	properties, err := ec.service.GetStagedProductProperties(productGUID, !ec.Options.IncludeCredentials)
 	if err != nil {
-		return err
+		return config.ProductConfiguration{}, nil, err
+	}
+
+	var placeholders configparser.PlaceholderNames
+	if ec.Options.IncludePlaceholders {
+		placeholders, err = loadPlaceholderNames(ec.Options.PlaceholderPrefix, ec.Options.PlaceholderSeparator, ec.Options.PlaceholderMapping)
+		if err != nil {
+			return config.ProductConfiguration{}, nil, err
+		}
 	}
 
 	configurableProperties := map[string]interface{}{}
 	selectorProperties := map[string]string{}
+	credentials := map[string]bool{}
 
 	for name, property := range properties {
+		if property.IsCredential {
+			credentials[name] = true
+		}
 		if property.Value == nil {
 			continue
 		}
@@ -95,10 +135,14 @@ func (ec StagedConfig) Execute(args []string) error {
 
 		parser := configparser.NewConfigParser()
 		propertyName := configparser.NewPropertyName(name)
-		output, err = parser.ParseProperties(propertyName, property, ec.chooseCredentialHandler(productGUID))
+		handler, err := ec.chooseCredentialHandler(productGUID, placeholders)
+		if err != nil {
+			return config.ProductConfiguration{}, nil, err
+		}
+		output, err = parser.ParseProperties(propertyName, property, handler)
 
 		if err != nil {
-			return err
+			return config.ProductConfiguration{}, nil, err
 		}
 		if len(output) > 0 {
 			configurableProperties[name] = output
@@ -118,17 +162,17 @@ func (ec StagedConfig) Execute(args []string) error {
 
 	networks, err := ec.service.GetStagedProductNetworksAndAZs(productGUID)
 	if err != nil {
-		return err
+		return config.ProductConfiguration{}, nil, err
 	}
 
 	jobs, err := ec.service.ListStagedProductJobs(productGUID)
 	if err != nil {
-		return err
+		return config.ProductConfiguration{}, nil, err
 	}
 
 	jobsToMaxInFlight, err := ec.service.GetStagedProductJobMaxInFlight(productGUID)
 	if err != nil {
-		return err
+		return config.ProductConfiguration{}, nil, err
 	}
 
 	var syslogProperties map[string]interface{}
@@ -139,7 +183,7 @@ func (ec StagedConfig) Execute(args []string) error {
 		}
 		syslogProperties, err = ec.service.GetStagedProductSyslogConfiguration(productGUID)
 		if err != nil {
-			return fmt.Errorf("syslog properties are only available in Ops Manager 2.4 or later. You are running: %s; %s %w", info.Version, errStr, err)
+			return config.ProductConfiguration{}, nil, fmt.Errorf("syslog properties are only available in Ops Manager 2.4 or later. You are running: %s; %s %w", info.Version, errStr, err)
 		}
 	}
 
@@ -148,7 +192,7 @@ func (ec StagedConfig) Execute(args []string) error {
 	for name, jobGUID := range jobs {
 		jobProperties, err := ec.service.GetStagedProductJobResourceConfig(productGUID, jobGUID)
 		if err != nil {
-			return err
+			return config.ProductConfiguration{}, nil, err
 		}
 		rc := config.ResourceConfig{
 			JobProperties: jobProperties,
@@ -161,7 +205,7 @@ func (ec StagedConfig) Execute(args []string) error {
 
 	errandsListOutput, err := ec.service.ListStagedProductErrands(productGUID)
 	if err != nil {
-		return err
+		return config.ProductConfiguration{}, nil, err
 	}
 
 	errandConfigs := map[string]config.ErrandConfig{}
@@ -174,7 +218,7 @@ func (ec StagedConfig) Execute(args []string) error {
 		errandConfigs[errand.Name] = errandConfig
 	}
 
-	config := config.ProductConfiguration{
+	productConfiguration := config.ProductConfiguration{
 		ProductName:              ec.Options.Product,
 		ProductProperties:        configurableProperties,
 		NetworkProperties:        networks,
@@ -183,23 +227,49 @@ func (ec StagedConfig) Execute(args []string) error {
 		SyslogProperties:         syslogProperties,
 	}
 
-	output, err := yaml.Marshal(config)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal config: %s", err) // un-tested
+	return productConfiguration, credentials, nil
+}
+
+func (ec StagedConfig) chooseCredentialHandler(productGUID string, placeholders configparser.PlaceholderNames) (configparser.CredentialHandler, error) {
+	var handler configparser.CredentialHandler
+	switch {
+	case ec.Options.IncludePlaceholders:
+		handler = configparser.NewNamedPlaceholderHandler(placeholders)
+	case ec.Options.IncludeCredentials:
+		handler = configparser.NewGetCredentialHandler(productGUID, ec.service)
+	default:
+		return configparser.NewNilHandler(), nil
 	}
 
-	ec.logger.Println(string(output))
-	return nil
+	if len(ec.Options.IncludeCredential)+len(ec.Options.ExcludeCredential) == 0 {
+		return handler, nil
+	}
+
+	return configparser.NewFilterHandler(ec.Options.IncludeCredential, ec.Options.ExcludeCredential, handler)
 }
 
-func (ec StagedConfig) chooseCredentialHandler(productGUID string) configparser.CredentialHandler {
-	if ec.Options.IncludePlaceholders {
-		return configparser.NewPlaceholderHandler()
+// loadPlaceholderNames reads the mapping file, when there is one, of the
+// placeholder naming flags shared by staged-config and
+// staged-director-config.
+func loadPlaceholderNames(prefix, separator, mappingFile string) (configparser.PlaceholderNames, error) {
+	names := configparser.PlaceholderNames{
+		Prefix:    prefix,
+		Separator: separator,
 	}
 
-	if ec.Options.IncludeCredentials {
-		return configparser.NewGetCredentialHandler(productGUID, ec.service)
+	if mappingFile == "" {
+		return names, nil
+	}
+
+	contents, err := os.ReadFile(mappingFile)
+	if err != nil {
+		return configparser.PlaceholderNames{}, fmt.Errorf("could not read placeholder mapping: %w", err)
+	}
+
+	err = yaml.UnmarshalStrict(contents, &names.Mapping)
+	if err != nil {
+		return configparser.PlaceholderNames{}, fmt.Errorf("could not parse placeholder mapping %s: %w", mappingFile, err)
 	}
 
-	return configparser.NewNilHandler()
+	return names, nil
 }
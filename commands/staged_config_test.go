@@ -3,6 +3,8 @@ package commands_test
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
@@ -10,6 +12,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
 )
 
 var _ = Describe("StagedConfig", func() {
@@ -166,6 +169,42 @@ syslog-properties:
 			})
 		})
 
+		When("--include-placeholders is used with the placeholder naming flags", func() {
+			It("names the placeholders with the prefix, separator and mapping", func() {
+				mappingFile := filepath.Join(GinkgoT().TempDir(), "mapping.yml")
+				Expect(os.WriteFile(mappingFile, []byte(`
+.properties.some-secret-property: /concourse/main/some_secret
+.properties.collection.1.certificate2: /concourse/main/second_cert
+`), 0600)).To(Succeed())
+
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := executeCommand(command, []string{
+					"--product-name", "some-product",
+					"--include-placeholders",
+					"--placeholder-prefix", "cf/",
+					"--placeholder-separator", "/",
+					"--placeholder-mapping", mappingFile,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				output := logger.PrintlnArgsForCall(0)[0].(string)
+				Expect(output).To(ContainSubstring("secret: ((/concourse/main/some_secret.secret))"))
+				Expect(output).To(ContainSubstring("password: ((cf/properties/simple-credentials.password))"))
+				Expect(output).To(ContainSubstring("cert_pem: ((cf/properties/collection/0/certificate.cert_pem))"))
+				Expect(output).To(ContainSubstring("cert_pem: ((/concourse/main/second_cert.cert_pem))"))
+			})
+
+			It("errors when the mapping cannot be read", func() {
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := executeCommand(command, []string{
+					"--product-name", "some-product",
+					"--include-placeholders",
+					"--placeholder-mapping", "/does/not/exist.yml",
+				})
+				Expect(err).To(MatchError(ContainSubstring("could not read placeholder mapping")))
+			})
+		})
+
 		When("--include-credentials is used", func() {
 			BeforeEach(func() {
 				fakeService = setFakeService(internalSelector, false)
@@ -249,6 +288,43 @@ syslog-properties:
 `))
 			})
 
+			It("only includes the credentials matching the filters", func() {
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := executeCommand(command, []string{
+					"--product-name", "some-product",
+					"--include-credentials",
+					"--include-credential", ".properties.*-credentials",
+					"--include-credential", "secret",
+					"--exclude-credential", "salted_credentials",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				output := logger.PrintlnArgsForCall(0)
+				var config struct {
+					ProductProperties map[string]interface{} `yaml:"product-properties"`
+				}
+				Expect(yaml.Unmarshal([]byte(output[0].(string)), &config)).To(Succeed())
+				Expect(config.ProductProperties).To(HaveKey(".properties.some-secret-property"))
+				Expect(config.ProductProperties).To(HaveKey(".properties.simple-credentials"))
+				Expect(config.ProductProperties).To(HaveKey(".properties.rsa-cert-credentials"))
+				Expect(config.ProductProperties).To(HaveKey(".properties.rsa-pkey-credentials"))
+				Expect(config.ProductProperties).ToNot(HaveKey(".properties.salted-credentials"))
+				Expect(config.ProductProperties).To(HaveKeyWithValue(".properties.collection", map[interface{}]interface{}{
+					"value": []interface{}{map[interface{}]interface{}{"name": "Certificate"}},
+				}))
+				Expect(config.ProductProperties).To(HaveKey(".properties.some-string-property"))
+			})
+
+			It("requires credentials or placeholders to filter them", func() {
+				command := commands.NewStagedConfig(fakeService, logger)
+				err := executeCommand(command, []string{
+					"--product-name", "some-product",
+					"--exclude-credential", "secret",
+				})
+				Expect(err).To(MatchError("--include-credential and --exclude-credential require --include-credentials or --include-placeholders"))
+				Expect(fakeService.InfoCallCount()).To(Equal(0))
+			})
+
 			Context("and the product has not yet been deployed", func() {
 				BeforeEach(func() {
 					fakeService.ListDeployedProductsReturns([]api.DeployedProductOutput{}, nil)
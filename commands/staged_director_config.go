@@ -2,11 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path"
 	"reflect"
 	"strconv"
 	"strings"
 
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/configparser"
 	"gopkg.in/yaml.v2"
 )
 
@@ -15,9 +18,132 @@ type StagedDirectorConfig struct {
 	stderr  logger
 	service stagedDirectorConfigService
 	Options struct {
-		IncludePlaceholders bool `long:"include-placeholders" short:"r" description:"Replace obscured credentials to interpolatable placeholders.\n\t\t\t\t    To include credentials hidden by OpsMan, use with \"--no-redact\""`
-		NoRedact            bool `long:"no-redact" description:"Redact IaaS values from director configuration"`
+		IncludePlaceholders bool   `long:"include-placeholders" short:"r" description:"Replace obscured credentials to interpolatable placeholders.\n\t\t\t\t    To include credentials hidden by OpsMan, use with \"--no-redact\""`
+		NoRedact            bool   `long:"no-redact" description:"Redact IaaS values from director configuration"`
+		RedactionPolicy     string `long:"redaction-policy" description:"path to yml file listing the paths (e.g. 'iaas-configurations.*.secret_access_key') to include credentials for, replace with placeholders, or exclude. Paths the policy does not list follow the other flags"`
+
+		PlaceholderPrefix    string `long:"placeholder-prefix" description:"prefix the name of each placeholder, e.g. with the path of the credentials in CredHub"`
+		PlaceholderSeparator string `long:"placeholder-separator" default:"_" description:"join the segments of the path with this value in the name of placeholders"`
+		PlaceholderMapping   string `long:"placeholder-mapping" description:"path to a yml file mapping paths (e.g. 'iaas-configurations.0.secret_access_key') to the name of their placeholder"`
+	}
+}
+
+// redactionPolicy lists path patterns, relative to the root of the
+// config and matched with path.Match, for each way of handling a value.
+type redactionPolicy struct {
+	Include     []string `yaml:"include"`
+	Placeholder []string `yaml:"placeholder"`
+	Exclude     []string `yaml:"exclude"`
+
+	// credentials is the config as Ops Manager returns it unredacted, for
+	// the included paths to be read from
+	credentials map[string]interface{}
+}
+
+const (
+	redactInclude     = "include"
+	redactPlaceholder = "placeholder"
+	redactExclude     = "exclude"
+)
+
+func loadRedactionPolicy(policyFile string) (*redactionPolicy, error) {
+	contents, err := os.ReadFile(policyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read redaction policy: %w", err)
 	}
+
+	var policy redactionPolicy
+	err = yaml.UnmarshalStrict(contents, &policy)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse redaction policy %s: %w", policyFile, err)
+	}
+
+	for _, patterns := range [][]string{policy.Include, policy.Placeholder, policy.Exclude} {
+		for _, pattern := range patterns {
+			_, err = path.Match(pattern, "")
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q in redaction policy: %w", pattern, err)
+			}
+		}
+	}
+
+	return &policy, nil
+}
+
+// action returns how the value at the path is handled. When more than one
+// pattern matches, the most restrictive one wins.
+func (p *redactionPolicy) action(valuePath string) string {
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, valuePath); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case matches(p.Exclude):
+		return redactExclude
+	case matches(p.Placeholder):
+		return redactPlaceholder
+	case matches(p.Include):
+		return redactInclude
+	}
+
+	return ""
+}
+
+// credential returns the unredacted value at the path, falling back to the
+// value when it cannot be found.
+func (p *redactionPolicy) credential(valuePath string, value interface{}) interface{} {
+	if p.credentials == nil {
+		return value
+	}
+
+	current := reflect.ValueOf(p.credentials)
+	for _, segment := range strings.Split(valuePath, ".") {
+		for current.Kind() == reflect.Interface {
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Map:
+			var next reflect.Value
+			iter := current.MapRange()
+			for iter.Next() {
+				if fmt.Sprintf("%v", iter.Key()) == segment {
+					next = iter.Value()
+				}
+			}
+			if !next.IsValid() {
+				return value
+			}
+			current = next
+		case reflect.Slice:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index >= current.Len() {
+				return value
+			}
+			current = current.Index(index)
+		default:
+			return value
+		}
+	}
+
+	return current.Interface()
+}
+
+// includesIAAS is true when the policy keeps some of the iaas
+// configuration, which is otherwise removed entirely when redacting.
+func (p *redactionPolicy) includesIAAS() bool {
+	for _, pattern := range append(p.Include, p.Placeholder...) {
+		if strings.HasPrefix(pattern, "iaas-configurations") || strings.HasPrefix(pattern, "properties-configuration.iaas_configuration") {
+			return true
+		}
+	}
+
+	return false
 }
 
 //counterfeiter:generate -o ./fakes/staged_director_config_service.go --fake-name StagedDirectorConfigService . stagedDirectorConfigService
@@ -47,6 +173,26 @@ func NewStagedDirectorConfig(service stagedDirectorConfigService, stdout logger,
 }
 
 func (sdc StagedDirectorConfig) Execute(args []string) error {
+	placeholders, err := loadPlaceholderNames(sdc.Options.PlaceholderPrefix, sdc.Options.PlaceholderSeparator, sdc.Options.PlaceholderMapping)
+	if err != nil {
+		return err
+	}
+
+	var policy *redactionPolicy
+	if sdc.Options.RedactionPolicy != "" {
+		policy, err = loadRedactionPolicy(sdc.Options.RedactionPolicy)
+		if err != nil {
+			return err
+		}
+
+		if len(policy.Include) > 0 && !sdc.Options.NoRedact {
+			policy.credentials, err = sdc.unredactedCredentials()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	stagedDirector, err := sdc.service.GetStagedProductByName("p-bosh")
 	if err != nil {
 		return err
@@ -136,12 +282,12 @@ func (sdc StagedDirectorConfig) Execute(args []string) error {
 	}
 	config["resource-configuration"] = resourceConfigs
 
-	if !sdc.Options.NoRedact && !sdc.Options.IncludePlaceholders {
+	if !sdc.Options.NoRedact && !sdc.Options.IncludePlaceholders && (policy == nil || !policy.includesIAAS()) {
 		sdc.removeAllIAASConfiguration(config)
 	}
 
 	for key, value := range config {
-		returnedVal, err := sdc.filterSecrets(key, key, value)
+		returnedVal, err := sdc.filterSecrets(policy, placeholders, key, key, value)
 		if err != nil {
 			return err
 		}
@@ -162,6 +308,26 @@ func (sdc StagedDirectorConfig) Execute(args []string) error {
 	return nil
 }
 
+func (sdc StagedDirectorConfig) unredactedCredentials() (map[string]interface{}, error) {
+	properties, err := sdc.service.GetStagedDirectorProperties(false)
+	if err != nil {
+		return nil, err
+	}
+
+	multiIaasConfigs, err := sdc.service.GetStagedDirectorIaasConfigurations(false)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := map[string]interface{}{}
+	if multiIaasConfigs != nil {
+		sdc.removePropertiesIAASConfig(credentials, multiIaasConfigs, properties)
+	}
+	credentials["properties-configuration"] = properties
+
+	return credentials, nil
+}
+
 func (sdc StagedDirectorConfig) removePropertiesIAASConfig(config map[string]interface{}, multiIaasConfigs map[string][]map[string]interface{}, properties map[string]interface{}) {
 	config["iaas-configurations"] = multiIaasConfigs["iaas_configurations"]
 	delete(properties, "iaas_configuration")
@@ -205,9 +371,21 @@ func (sdc StagedDirectorConfig) removeAllIAASConfiguration(config map[string]int
 	delete(config, "iaas-configurations")
 }
 
-func (sdc StagedDirectorConfig) filterSecrets(prefix string, keyName string, value interface{}) (interface{}, error) {
+func (sdc StagedDirectorConfig) filterSecrets(policy *redactionPolicy, placeholders configparser.PlaceholderNames, valuePath string, keyName string, value interface{}) (interface{}, error) {
 	filters := []string{"password", "user", "key"}
 
+	var action string
+	if policy != nil {
+		action = policy.action(valuePath)
+	}
+
+	switch action {
+	case redactExclude:
+		return nil, nil
+	case redactInclude:
+		return policy.credential(valuePath, value), nil
+	}
+
 	switch v := reflect.ValueOf(value); v.Kind() {
 	case reflect.Map:
 		elements := map[string]interface{}{}
@@ -215,7 +393,7 @@ func (sdc StagedDirectorConfig) filterSecrets(prefix string, keyName string, val
 		for iter.Next() {
 			innerKey := fmt.Sprintf("%s", iter.Key())
 			innerValue := iter.Value()
-			returnedVal, err := sdc.filterSecrets(prefix+"_"+innerKey, innerKey, innerValue.Interface())
+			returnedVal, err := sdc.filterSecrets(policy, placeholders, valuePath+"."+innerKey, innerKey, innerValue.Interface())
 
 			if err != nil {
 				return nil, err
@@ -228,7 +406,7 @@ func (sdc StagedDirectorConfig) filterSecrets(prefix string, keyName string, val
 	case reflect.Slice:
 		elements := []interface{}{}
 		for i := 0; i < v.Len(); i++ {
-			returnedVal, err := sdc.filterSecrets(prefix+"_"+strconv.Itoa(i), "", v.Index(i).Interface())
+			returnedVal, err := sdc.filterSecrets(policy, placeholders, valuePath+"."+strconv.Itoa(i), "", v.Index(i).Interface())
 			if err != nil {
 				return nil, err
 			}
@@ -238,22 +416,32 @@ func (sdc StagedDirectorConfig) filterSecrets(prefix string, keyName string, val
 		}
 		return elements, nil
 	case reflect.String, reflect.Int, reflect.Bool:
-		if strings.Contains(prefix, "iaas_configuration") {
+		if action == redactPlaceholder {
+			return "((" + placeholders.Name(valuePath) + "))", nil
+		}
+
+		// the policy kept the iaas configuration, but only for its paths
+		iaasUnlessKept := policy != nil && !sdc.Options.NoRedact && !sdc.Options.IncludePlaceholders
+		if iaasUnlessKept && (strings.Contains(valuePath, "iaas_configuration") || strings.Contains(valuePath, "iaas-configurations")) {
+			return nil, nil
+		}
+
+		if strings.Contains(valuePath, "iaas_configuration") {
 			if sdc.Options.IncludePlaceholders {
-				return "((" + prefix + "))", nil
+				return "((" + placeholders.Name(valuePath) + "))", nil
 			}
 		}
 
-		if strings.Contains(prefix, "iaas-configurations") {
+		if strings.Contains(valuePath, "iaas-configurations") {
 			if sdc.Options.IncludePlaceholders {
-				return "((" + prefix + "))", nil
+				return "((" + placeholders.Name(valuePath) + "))", nil
 			}
 		}
 
 		for _, filter := range filters {
 			if strings.Contains(keyName, filter) {
 				if sdc.Options.IncludePlaceholders {
-					return "((" + prefix + "))", nil
+					return "((" + placeholders.Name(valuePath) + "))", nil
 				}
 				if sdc.Options.NoRedact {
 					return value, nil
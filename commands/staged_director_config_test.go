@@ -5,6 +5,8 @@ import (
 	"github.com/pivotal-cf/om/commands/fakes"
 
 	"errors"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -964,6 +966,129 @@ vmtypes-configuration: {}
 			})
 		})
 
+		Describe("with --redaction-policy", func() {
+			var policyFile string
+
+			writePolicy := func(policy string) {
+				policyFile = filepath.Join(GinkgoT().TempDir(), "policy.yml")
+				Expect(os.WriteFile(policyFile, []byte(policy), 0600)).To(Succeed())
+			}
+
+			BeforeEach(func() {
+				fakeService.GetStagedDirectorPropertiesStub = func(redact bool) (map[string]interface{}, error) {
+					password := "***"
+					if !redact {
+						password = "some_password"
+					}
+
+					return map[string]interface{}{
+						"director_configuration": map[string]interface{}{
+							"max_threads": 5,
+							"encryption": map[string]interface{}{
+								"providers": map[string]interface{}{
+									"partition_password": password,
+									"client_key":         "user_provided_key",
+									"client_user":        "user",
+								},
+							},
+						},
+						"iaas_configuration": map[interface{}]interface{}{
+							"project": "project-id",
+							"key":     "some-key",
+						},
+						"syslog_configuration": map[string]interface{}{
+							"syslogconfig": "awesome",
+						},
+					}, nil
+				}
+			})
+
+			It("includes, replaces with placeholders or excludes the values at the paths of the policy", func() {
+				writePolicy(`
+include:
+- properties-configuration.director_configuration.encryption.providers.partition_password
+placeholder:
+- "*.client_key"
+exclude:
+- properties-configuration.syslog_configuration
+`)
+				command := commands.NewStagedDirectorConfig(fakeService, stdout, stderr)
+				err := executeCommand(command, []string{"--redaction-policy", policyFile})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeService.GetStagedDirectorPropertiesCallCount()).To(Equal(2))
+				Expect(fakeService.GetStagedDirectorPropertiesArgsForCall(0)).To(BeFalse())
+				Expect(fakeService.GetStagedDirectorPropertiesArgsForCall(1)).To(BeTrue())
+
+				output := stdout.PrintlnArgsForCall(0)
+				Expect(output[0]).To(ContainSubstring(`properties-configuration:
+  director_configuration:
+    encryption:
+      providers:
+        client_key: ((properties-configuration_director_configuration_encryption_providers_client_key))
+        partition_password: some_password
+    max_threads: 5
+`))
+				Expect(output[0]).ToNot(ContainSubstring("syslog"))
+				Expect(output[0]).ToNot(ContainSubstring("project-id"))
+			})
+
+			It("keeps only the paths of the policy in the iaas configuration", func() {
+				writePolicy(`
+include:
+- properties-configuration.iaas_configuration.project
+`)
+				command := commands.NewStagedDirectorConfig(fakeService, stdout, stderr)
+				err := executeCommand(command, []string{"--redaction-policy", policyFile})
+				Expect(err).ToNot(HaveOccurred())
+
+				output := stdout.PrintlnArgsForCall(0)
+				Expect(output[0]).To(ContainSubstring(`  iaas_configuration:
+    project: project-id
+`))
+				Expect(output[0]).ToNot(ContainSubstring("some-key"))
+			})
+
+			It("errors on an invalid path", func() {
+				writePolicy(`exclude: ["[a-"]`)
+
+				command := commands.NewStagedDirectorConfig(fakeService, stdout, stderr)
+				err := executeCommand(command, []string{"--redaction-policy", policyFile})
+				Expect(err).To(MatchError(ContainSubstring(`invalid path "[a-" in redaction policy`)))
+			})
+		})
+
+		Describe("with the placeholder naming flags", func() {
+			It("names the placeholders with the prefix, separator and mapping", func() {
+				mappingFile := filepath.Join(GinkgoT().TempDir(), "mapping.yml")
+				Expect(os.WriteFile(mappingFile, []byte(`properties-configuration.iaas_configuration.key: /concourse/main/gcp_key`), 0600)).To(Succeed())
+
+				command := commands.NewStagedDirectorConfig(fakeService, stdout, stderr)
+				err := executeCommand(command, []string{
+					"--include-placeholders",
+					"--placeholder-prefix", "director/",
+					"--placeholder-separator", "-",
+					"--placeholder-mapping", mappingFile,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				output := stdout.PrintlnArgsForCall(0)
+				Expect(output[0]).To(ContainSubstring("client_key: ((director/properties-configuration-director_configuration-encryption-providers-client_key))"))
+				Expect(output[0]).To(ContainSubstring("project: ((director/properties-configuration-iaas_configuration-project))"))
+				Expect(output[0]).To(ContainSubstring("key: ((/concourse/main/gcp_key))"))
+			})
+
+			It("errors when the mapping cannot be parsed", func() {
+				mappingFile := filepath.Join(GinkgoT().TempDir(), "mapping.yml")
+				Expect(os.WriteFile(mappingFile, []byte(`- not a map`), 0600)).To(Succeed())
+
+				command := commands.NewStagedDirectorConfig(fakeService, stdout, stderr)
+				err := executeCommand(command, []string{"--include-placeholders", "--placeholder-mapping", mappingFile})
+				Expect(err).To(MatchError(ContainSubstring("could not parse placeholder mapping " + mappingFile)))
+				Expect(fakeService.GetStagedProductByNameCallCount()).To(Equal(0))
+			})
+		})
+
 		When("looking up the director GUID fails", func() {
 			BeforeEach(func() {
 				fakeService.GetStagedProductByNameReturns(api.StagedProductsFindOutput{}, errors.New("some-error"))
@@ -10,7 +10,7 @@ type StagedProducts struct {
 	presenter presenters.FormattedPresenter
 	service   diagnosticReportService
 	Options   struct {
-		Format string `long:"format" short:"f" default:"table" description:"Format to print as (options: table,json)"`
+		formatOptions
 	}
 }
 
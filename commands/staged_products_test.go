@@ -3,6 +3,7 @@ package commands_test
 import (
 	"errors"
 
+	"github.com/jessevdk/go-flags"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -63,6 +64,18 @@ var _ = Describe("StagedProducts", func() {
 				Expect(presenter.SetFormatCallCount()).To(Equal(1))
 				Expect(presenter.SetFormatArgsForCall(0)).To(Equal("json"))
 			})
+
+			It("accepts yaml", func() {
+				err := executeCommand(command, []string{"--format", "yaml"})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(presenter.SetFormatArgsForCall(0)).To(Equal("yaml"))
+			})
+
+			It("rejects unknown formats", func() {
+				_, err := flags.NewParser(command, flags.HelpFlag).ParseArgs([]string{"--format", "xml"})
+				Expect(err).To(MatchError(ContainSubstring("Allowed values are: table, json or yaml")))
+			})
 		})
 
 		When("fetching the diagnostic report fails", func() {
@@ -0,0 +1,192 @@
+package commands
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/network"
+)
+
+type TrustedCertificates struct {
+	service trustedCertificatesService
+	logger  logger
+	Options struct {
+		PEM bool `long:"pem" description:"print the trusted certificates as PEM instead of a table"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/trusted_certificates_service.go --fake-name TrustedCertificatesService . trustedCertificatesService
+type trustedCertificatesService interface {
+	GetStagedDirectorProperties(redact bool) (map[string]interface{}, error)
+	UpdateStagedDirectorProperties(api.DirectorProperties) error
+}
+
+func NewTrustedCertificates(service trustedCertificatesService, logger logger) *TrustedCertificates {
+	return &TrustedCertificates{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (t TrustedCertificates) Execute(args []string) error {
+	security, err := readSecurityConfiguration(t.service)
+	if err != nil {
+		return err
+	}
+
+	certificates, err := parseTrustedCertificates(security)
+	if err != nil {
+		return err
+	}
+
+	if t.Options.PEM {
+		t.logger.Printf("%s", joinTrustedCertificates(certificates))
+		return nil
+	}
+
+	var summary strings.Builder
+	table := tabwriter.NewWriter(&summary, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SUBJECT\tEXPIRES\tSHA256 FINGERPRINT")
+	for _, certificate := range certificates {
+		fmt.Fprintf(table, "%s\t%s\t%s\n", certificate.cert.Subject, certificate.cert.NotAfter.Format("2006-01-02"), certificate.fingerprint)
+	}
+	_ = table.Flush()
+	t.logger.Printf("%s", summary.String())
+
+	var keys []string
+	for key := range security {
+		if key != "trusted_certificates" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		t.logger.Printf("%s: %v", key, security[key])
+	}
+
+	return nil
+}
+
+type trustedCertificate struct {
+	pem         string
+	cert        *x509.Certificate
+	fingerprint string
+}
+
+// readSecurityConfiguration returns the security_configuration of the staged
+// director properties, which holds the trusted certificates.
+func readSecurityConfiguration(service trustedCertificatesService) (map[string]interface{}, error) {
+	properties, err := service.GetStagedDirectorProperties(false)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the director properties: %s", err)
+	}
+
+	security := map[string]interface{}{}
+	settings, _ := properties["security_configuration"].(map[interface{}]interface{})
+	for key, value := range settings {
+		security[fmt.Sprint(key)] = value
+	}
+
+	return security, nil
+}
+
+// writeSecurityConfiguration updates the trusted certificates of the
+// director, resubmitting the rest of the security configuration unchanged.
+func writeSecurityConfiguration(service trustedCertificatesService, security map[string]interface{}, certificates []trustedCertificate) error {
+	security["trusted_certificates"] = joinTrustedCertificates(certificates)
+
+	payload, err := getJSONProperties(map[string]interface{}{"security_configuration": security})
+	if err != nil {
+		return err
+	}
+
+	err = service.UpdateStagedDirectorProperties(api.DirectorProperties(payload))
+	if err != nil {
+		return fmt.Errorf("could not update the trusted certificates of the director: %s", err)
+	}
+
+	return nil
+}
+
+func parseTrustedCertificates(security map[string]interface{}) ([]trustedCertificate, error) {
+	pemCertificates, _ := security["trusted_certificates"].(string)
+
+	certificates, err := parseCertificates(pemCertificates)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the trusted certificates of the director: %s", err)
+	}
+
+	return certificates, nil
+}
+
+func parseCertificates(pemCertificates string) ([]trustedCertificate, error) {
+	var certificates []trustedCertificate
+
+	rest := []byte(pemCertificates)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("found a %s, expected only certificates", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprint := sha256.Sum256(cert.Raw)
+		certificates = append(certificates, trustedCertificate{
+			pem:         string(pem.EncodeToMemory(block)),
+			cert:        cert,
+			fingerprint: hex.EncodeToString(fingerprint[:]),
+		})
+	}
+
+	return certificates, nil
+}
+
+func joinTrustedCertificates(certificates []trustedCertificate) string {
+	var pems []string
+	for _, certificate := range certificates {
+		pems = append(pems, certificate.pem)
+	}
+
+	return strings.Join(pems, "")
+}
+
+// readCertificates accepts either PEM encoded certificates or the path to a
+// file holding them.
+func readCertificates(value string) ([]trustedCertificate, error) {
+	value, err := network.ReadPEM(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not read certificate: %s", err)
+	}
+
+	certificates, err := parseCertificates(value)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse certificate: %s", err)
+	}
+
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("could not parse certificate: no PEM encoded certificates found")
+	}
+
+	return certificates, nil
+}
+
+func (t trustedCertificate) String() string {
+	return fmt.Sprintf("%s (%s)", t.cert.Subject, t.fingerprint)
+}
@@ -0,0 +1,85 @@
+package commands_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+func trustedCertificatesProperties(pems string) map[string]interface{} {
+	return map[string]interface{}{
+		"security_configuration": map[interface{}]interface{}{
+			"trusted_certificates":             pems,
+			"generate_vm_passwords":            true,
+			"opsmanager_root_ca_trusted_certs": false,
+		},
+	}
+}
+
+func fingerprintOf(certificate testCertificate) string {
+	sum := sha256.Sum256(certificate.cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("TrustedCertificates", func() {
+	var (
+		service *fakes.TrustedCertificatesService
+		stdout  *gbytes.Buffer
+		command *commands.TrustedCertificates
+		first   testCertificate
+		second  testCertificate
+	)
+
+	BeforeEach(func() {
+		first = newTestCertificate("corporate-root", nil, true)
+		second = newTestCertificate("partner-root", nil, true)
+
+		service = &fakes.TrustedCertificatesService{}
+		service.GetStagedDirectorPropertiesReturns(trustedCertificatesProperties(first.pem+second.pem), nil)
+
+		stdout = gbytes.NewBuffer()
+		command = commands.NewTrustedCertificates(service, log.New(stdout, "", 0))
+	})
+
+	It("lists the trusted certificates and the other security settings", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.GetStagedDirectorPropertiesArgsForCall(0)).To(BeFalse())
+
+		Expect(stdout).To(gbytes.Say(`SUBJECT\s+EXPIRES\s+SHA256 FINGERPRINT`))
+		Expect(stdout).To(gbytes.Say(`CN=corporate-root\s+\d{4}-\d{2}-\d{2}\s+` + fingerprintOf(first)))
+		Expect(stdout).To(gbytes.Say(`CN=partner-root\s+\d{4}-\d{2}-\d{2}\s+` + fingerprintOf(second)))
+		Expect(stdout).To(gbytes.Say("generate_vm_passwords: true"))
+		Expect(stdout).To(gbytes.Say("opsmanager_root_ca_trusted_certs: false"))
+	})
+
+	It("prints the trusted certificates as PEM", func() {
+		err := executeCommand(command, []string{"--pem"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(stdout.Contents())).To(Equal(first.pem + second.pem))
+	})
+
+	It("returns an error when the trusted certificates cannot be parsed", func() {
+		service.GetStagedDirectorPropertiesReturns(trustedCertificatesProperties("-----BEGIN CERTIFICATE-----\nbm90IGEgY2VydA==\n-----END CERTIFICATE-----\n"), nil)
+
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError(ContainSubstring("could not parse the trusted certificates of the director")))
+	})
+
+	It("returns an error when the director properties cannot be fetched", func() {
+		service.GetStagedDirectorPropertiesReturns(nil, errors.New("some error"))
+
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("could not get the director properties: some error"))
+	})
+})
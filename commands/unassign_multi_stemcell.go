@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type UnassignMultiStemcell struct {
+	logger  logger
+	service assignMultiStemcellService
+	Options struct {
+		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
+		ProductName        string                       `long:"product"  short:"p"  description:"name of Ops Manager tile to remove a stemcell association from" required:"true"`
+		Stemcells          []string                     `long:"stemcell" short:"s"  description:"remove the stemcells of an operating system (ie 'ubuntu-trusty') or a particular stemcell version (ie 'ubuntu-trusty:123.4') from the tile" required:"true"`
+	}
+}
+
+func NewUnassignMultiStemcell(service assignMultiStemcellService, logger logger) *UnassignMultiStemcell {
+	return &UnassignMultiStemcell{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (us UnassignMultiStemcell) Execute(args []string) error {
+	err := validateMultiStemcellOpsManVersion(us.service)
+	if err != nil {
+		return err
+	}
+
+	productStemcells, err := us.service.ListMultiStemcells()
+	if err != nil {
+		return err
+	}
+
+	var productStemcell *api.ProductMultiStemcell
+	for i, product := range productStemcells.Products {
+		if product.ProductName == us.Options.ProductName {
+			productStemcell = &productStemcells.Products[i]
+		}
+	}
+	if productStemcell == nil {
+		return fmt.Errorf("could not list product stemcell: product \"%s\" not found", us.Options.ProductName)
+	}
+
+	if productStemcell.StagedForDeletion {
+		return fmt.Errorf("could not unassign stemcell: product \"%s\" is staged for deletion", us.Options.ProductName)
+	}
+
+	remaining := productStemcell.StagedStemcells
+	for _, option := range us.Options.Stemcells {
+		os, version, _ := strings.Cut(option, ":")
+
+		var kept []api.StemcellObject
+		for _, stemcell := range remaining {
+			if stemcell.OS != os || (version != "" && stemcell.Version != version) {
+				kept = append(kept, stemcell)
+			}
+		}
+
+		if len(kept) == len(remaining) {
+			return fmt.Errorf("stemcell %s is not assigned to product \"%s\". Assigned stemcells: %s",
+				option,
+				us.Options.ProductName,
+				strings.Join(getAllStemcells(productStemcell.StagedStemcells), ", "),
+			)
+		}
+		remaining = kept
+	}
+
+	// a product cannot be deployed without a stemcell
+	if len(remaining) == 0 {
+		return fmt.Errorf("could not unassign stemcell: product \"%s\" would not have any stemcell left. assign-multi-stemcell another stemcell, and try again", us.Options.ProductName)
+	}
+
+	us.logger.Printf(
+		"unassigning stemcells from product \"%s\", keeping: \"%s\"...\n",
+		us.Options.ProductName,
+		strings.Join(getAllStemcells(remaining), ", "),
+	)
+	err = us.service.AssignMultiStemcell(api.ProductMultiStemcells{
+		Products: []api.ProductMultiStemcell{
+			{
+				GUID:            productStemcell.GUID,
+				StagedStemcells: remaining,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	us.logger.Println("unassigned stemcells successfully")
+	return nil
+}
@@ -0,0 +1,112 @@
+package commands_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("UnassignMultiStemcell", func() {
+	var (
+		fakeService *fakes.AssignMultiStemcellService
+		logger      *fakes.Logger
+		command     *commands.UnassignMultiStemcell
+	)
+
+	BeforeEach(func() {
+		fakeService = &fakes.AssignMultiStemcellService{}
+		fakeService.InfoReturns(api.Info{Version: "2.6.0"}, nil)
+		fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+			Products: []api.ProductMultiStemcell{
+				{
+					GUID:        "cf-guid",
+					ProductName: "cf",
+					StagedStemcells: []api.StemcellObject{
+						{OS: "ubuntu-xenial", Version: "621.1"},
+						{OS: "ubuntu-jammy", Version: "1.10"},
+						{OS: "ubuntu-jammy", Version: "1.12"},
+					},
+				},
+			},
+		}, nil)
+		logger = &fakes.Logger{}
+		command = commands.NewUnassignMultiStemcell(fakeService, logger)
+	})
+
+	It("removes the stemcells of an operating system", func() {
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-xenial"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeService.AssignMultiStemcellArgsForCall(0)).To(Equal(api.ProductMultiStemcells{
+			Products: []api.ProductMultiStemcell{
+				{
+					GUID: "cf-guid",
+					StagedStemcells: []api.StemcellObject{
+						{OS: "ubuntu-jammy", Version: "1.10"},
+						{OS: "ubuntu-jammy", Version: "1.12"},
+					},
+				},
+			},
+		}))
+
+		format, content := logger.PrintfArgsForCall(0)
+		Expect(format).To(Equal("unassigning stemcells from product \"%s\", keeping: \"%s\"...\n"))
+		Expect(content).To(Equal([]interface{}{"cf", "ubuntu-jammy 1.10, ubuntu-jammy 1.12"}))
+		Expect(logger.PrintlnArgsForCall(0)).To(Equal([]interface{}{"unassigned stemcells successfully"}))
+	})
+
+	It("removes a particular stemcell version", func() {
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-jammy:1.10"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeService.AssignMultiStemcellArgsForCall(0).Products[0].StagedStemcells).To(Equal([]api.StemcellObject{
+			{OS: "ubuntu-xenial", Version: "621.1"},
+			{OS: "ubuntu-jammy", Version: "1.12"},
+		}))
+	})
+
+	It("returns an error when the stemcell is not assigned", func() {
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-jammy:1.11"})
+		Expect(err).To(MatchError(`stemcell ubuntu-jammy:1.11 is not assigned to product "cf". Assigned stemcells: ubuntu-xenial 621.1, ubuntu-jammy 1.10, ubuntu-jammy 1.12`))
+		Expect(fakeService.AssignMultiStemcellCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when no stemcell would be left", func() {
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-xenial", "--stemcell", "ubuntu-jammy"})
+		Expect(err).To(MatchError(`could not unassign stemcell: product "cf" would not have any stemcell left. assign-multi-stemcell another stemcell, and try again`))
+		Expect(fakeService.AssignMultiStemcellCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the product is not staged", func() {
+		err := executeCommand(command, []string{"--product", "p-mysql", "--stemcell", "ubuntu-xenial"})
+		Expect(err).To(MatchError(`could not list product stemcell: product "p-mysql" not found`))
+	})
+
+	It("returns an error when the product is staged for deletion", func() {
+		fakeService.ListMultiStemcellsReturns(api.ProductMultiStemcells{
+			Products: []api.ProductMultiStemcell{{GUID: "cf-guid", ProductName: "cf", StagedForDeletion: true}},
+		}, nil)
+
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-xenial"})
+		Expect(err).To(MatchError(`could not unassign stemcell: product "cf" is staged for deletion`))
+	})
+
+	It("requires Ops Manager 2.6+", func() {
+		fakeService.InfoReturns(api.Info{Version: "2.5.0"}, nil)
+
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-xenial"})
+		Expect(err).To(MatchError("this command can only be used with OpsManager 2.6+"))
+	})
+
+	It("returns an error when the stemcells cannot be assigned", func() {
+		fakeService.AssignMultiStemcellReturns(errors.New("some error"))
+
+		err := executeCommand(command, []string{"--product", "cf", "--stemcell", "ubuntu-xenial"})
+		Expect(err).To(MatchError("some error"))
+	})
+})
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+type Unlock struct {
+	service    unlockService
+	passphrase string
+	logger     logger
+	Options    struct {
+		PollingInterval int `long:"polling-interval" short:"p" description:"interval (in seconds) to check OpsManager availability" default:"10"`
+		Timeout         int `long:"timeout"                    description:"time (in seconds) to keep waiting for Ops Manager to become available"  default:"1800"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/unlock_service.go --fake-name UnlockService . unlockService
+type unlockService interface {
+	Unlock(passphrase string) error
+	EnsureAvailability(input api.EnsureAvailabilityInput) (api.EnsureAvailabilityOutput, error)
+}
+
+func NewUnlock(service unlockService, passphrase string, logger logger) *Unlock {
+	return &Unlock{
+		service:    service,
+		passphrase: passphrase,
+		logger:     logger,
+	}
+}
+
+func (u Unlock) Execute(args []string) error {
+	if u.passphrase == "" {
+		return errors.New("the global decryption-passphrase argument is required for this command")
+	}
+
+	start := time.Now()
+	deadline := start.Add(time.Second * time.Duration(u.Options.Timeout))
+	pollingInterval := time.Second * time.Duration(u.Options.PollingInterval)
+
+	u.logger.Printf("unlocking ops manager...")
+
+	// the web server may still be booting after the VM has been restarted
+	for {
+		err := u.service.Unlock(u.passphrase)
+		if err == nil {
+			break
+		}
+
+		if !isErrThatMightResolveOnRetry(err) || !time.Now().Before(deadline) {
+			return err
+		}
+
+		u.logger.Printf("waiting for ops manager web server boots up...")
+		time.Sleep(pollingInterval)
+	}
+
+	u.logger.Printf("waiting for ops manager to become available...")
+
+	for {
+		ensureAvailabilityOutput, err := u.service.EnsureAvailability(api.EnsureAvailabilityInput{})
+		if err != nil && !isErrThatMightResolveOnRetry(err) {
+			return fmt.Errorf("could not check Ops Manager status: %s", err)
+		}
+
+		if err == nil && ensureAvailabilityOutput.Status == api.EnsureAvailabilityStatusComplete {
+			u.logger.Printf("ops manager is unlocked and available")
+			return nil
+		}
+
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("timed out after %ds waiting for Ops Manager to become available", u.Options.Timeout)
+		}
+
+		u.logger.Printf("still waiting for ops manager to become available (%s elapsed)...", time.Since(start).Round(time.Second))
+		time.Sleep(pollingInterval)
+	}
+}
@@ -0,0 +1,87 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("Unlock", func() {
+	var (
+		service *fakes.UnlockService
+		stdout  *gbytes.Buffer
+		command *commands.Unlock
+	)
+
+	BeforeEach(func() {
+		service = &fakes.UnlockService{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewUnlock(service, "some-passphrase", log.New(stdout, "", 0))
+
+		service.EnsureAvailabilityReturnsOnCall(0, api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusPending}, nil)
+		service.EnsureAvailabilityReturnsOnCall(1, api.EnsureAvailabilityOutput{}, errors.New("502 Bad Gateway"))
+		service.EnsureAvailabilityReturnsOnCall(2, api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusComplete}, nil)
+	})
+
+	It("unlocks ops manager and waits until it is available", func() {
+		err := executeCommand(command, []string{"--polling-interval", "0"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.UnlockCallCount()).To(Equal(1))
+		Expect(service.UnlockArgsForCall(0)).To(Equal("some-passphrase"))
+		Expect(service.EnsureAvailabilityCallCount()).To(Equal(3))
+
+		Expect(stdout).To(gbytes.Say("unlocking ops manager..."))
+		Expect(stdout).To(gbytes.Say("waiting for ops manager to become available..."))
+		Expect(stdout).To(gbytes.Say(`still waiting for ops manager to become available \(0s elapsed\)...`))
+		Expect(stdout).To(gbytes.Say("ops manager is unlocked and available"))
+	})
+
+	It("retries unlocking while the web server boots", func() {
+		service.UnlockReturnsOnCall(0, errors.New("dial tcp: connection refused"))
+
+		err := executeCommand(command, []string{"--polling-interval", "0"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.UnlockCallCount()).To(Equal(2))
+		Expect(stdout).To(gbytes.Say("waiting for ops manager web server boots up..."))
+	})
+
+	It("returns an error when ops manager does not become available in time", func() {
+		service.EnsureAvailabilityReturnsOnCall(0, api.EnsureAvailabilityOutput{Status: api.EnsureAvailabilityStatusPending}, nil)
+
+		err := executeCommand(command, []string{"--polling-interval", "0", "--timeout", "0"})
+		Expect(err).To(MatchError("timed out after 0s waiting for Ops Manager to become available"))
+		Expect(service.EnsureAvailabilityCallCount()).To(Equal(1))
+	})
+
+	It("returns an error when the passphrase is not correct", func() {
+		service.UnlockReturns(errors.New("could not unlock ops manager, check if the decryption passphrase is correct"))
+
+		err := executeCommand(command, []string{"--polling-interval", "0"})
+		Expect(err).To(MatchError("could not unlock ops manager, check if the decryption passphrase is correct"))
+		Expect(service.UnlockCallCount()).To(Equal(1))
+		Expect(service.EnsureAvailabilityCallCount()).To(Equal(0))
+	})
+
+	It("returns an error when the availability cannot be checked", func() {
+		service.EnsureAvailabilityReturnsOnCall(0, api.EnsureAvailabilityOutput{}, errors.New("Unexpected response code: 500"))
+
+		err := executeCommand(command, []string{"--polling-interval", "0"})
+		Expect(err).To(MatchError("could not check Ops Manager status: Unexpected response code: 500"))
+	})
+
+	It("requires the decryption passphrase", func() {
+		command = commands.NewUnlock(service, "", log.New(stdout, "", 0))
+
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("the global decryption-passphrase argument is required for this command"))
+	})
+})
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/network"
+)
+
+// certificateExpiryWarning is how long before it expires a certificate is
+// uploaded with a warning.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+type UpdateSSLCertificate struct {
+	service updateSSLCertificateService
+	fetcher servedCertificateFetcher
+	target  string
+	logger  logger
+	Options struct {
+		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
+
+		CertificatePem  string `long:"certificate-pem" required:"true" description:"the certificate, followed by its intermediates, as PEM or the path to a file holding them"`
+		PrivateKeyPem   string `long:"private-key-pem" required:"true" description:"the private key of the certificate, as PEM or the path to a file holding it"`
+		Hostname        string `long:"hostname"                        description:"hostname the certificate must be valid for. Defaults to the host of the target"`
+		SkipVerify      bool   `long:"skip-verify"                     description:"do not wait for Ops Manager to serve the new certificate"`
+		PollingInterval int    `long:"polling-interval" default:"5"    description:"seconds between checks of the certificate Ops Manager serves"`
+		Timeout         int    `long:"timeout"          default:"300"  description:"seconds to wait for Ops Manager to serve the new certificate"`
+	}
+}
+
+//counterfeiter:generate -o ./fakes/update_ssl_certificate_service.go --fake-name UpdateSSLCertificateService . updateSSLCertificateService
+type updateSSLCertificateService interface {
+	UpdateSSLCertificate(api.SSLCertificateSettings) error
+}
+
+//counterfeiter:generate -o ./fakes/served_certificate_fetcher.go --fake-name ServedCertificateFetcher . servedCertificateFetcher
+type servedCertificateFetcher interface {
+	ServedCertificate() (*x509.Certificate, error)
+}
+
+func NewUpdateSSLCertificate(service updateSSLCertificateService, fetcher servedCertificateFetcher, target string, logger logger) *UpdateSSLCertificate {
+	return &UpdateSSLCertificate{
+		service: service,
+		fetcher: fetcher,
+		target:  target,
+		logger:  logger,
+	}
+}
+
+func (u UpdateSSLCertificate) Execute(args []string) error {
+	certificatePem, err := network.ReadPEM(u.Options.CertificatePem)
+	if err != nil {
+		return fmt.Errorf("could not read --certificate-pem: %s", err)
+	}
+
+	privateKeyPem, err := network.ReadPEM(u.Options.PrivateKeyPem)
+	if err != nil {
+		return fmt.Errorf("could not read --private-key-pem: %s", err)
+	}
+
+	hostname := u.Options.Hostname
+	if hostname == "" {
+		hostname, err = targetHostname(u.target)
+		if err != nil {
+			return err
+		}
+	}
+
+	certificate, err := u.validate(certificatePem, privateKeyPem, hostname)
+	if err != nil {
+		return fmt.Errorf("the certificate was not uploaded: %s", err)
+	}
+
+	err = u.service.UpdateSSLCertificate(api.SSLCertificateSettings{
+		CertPem:       certificatePem,
+		PrivateKeyPem: privateKeyPem,
+	})
+	if err != nil {
+		return fmt.Errorf("could not update the SSL certificate: %s", err)
+	}
+
+	u.logger.Printf("uploaded the SSL certificate %s", certificate)
+
+	if u.Options.SkipVerify {
+		return nil
+	}
+
+	return u.waitForCertificate(certificate)
+}
+
+// validate checks the certificate can be served for hostname before it is
+// uploaded, as Ops Manager would only be reachable again once it has been
+// replaced.
+func (u UpdateSSLCertificate) validate(certificatePem, privateKeyPem, hostname string) (trustedCertificate, error) {
+	chain, err := parseCertificates(certificatePem)
+	if err != nil {
+		return trustedCertificate{}, fmt.Errorf("could not parse the certificate: %s", err)
+	}
+	if len(chain) == 0 {
+		return trustedCertificate{}, errors.New("could not parse the certificate: no PEM encoded certificates found")
+	}
+
+	leaf := chain[0]
+	now := time.Now()
+	if now.Before(leaf.cert.NotBefore) {
+		return trustedCertificate{}, fmt.Errorf("the certificate is not valid until %s", leaf.cert.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(leaf.cert.NotAfter) {
+		return trustedCertificate{}, fmt.Errorf("the certificate expired on %s", leaf.cert.NotAfter.Format(time.RFC3339))
+	}
+	if now.Add(certificateExpiryWarning).After(leaf.cert.NotAfter) {
+		u.logger.Printf("warning: the certificate expires on %s", leaf.cert.NotAfter.Format(time.RFC3339))
+	}
+
+	if !publicKeyMatches(leaf.cert, privateKeyPem) {
+		return trustedCertificate{}, errors.New("the private key does not belong to the certificate")
+	}
+
+	for i := 1; i < len(chain); i++ {
+		err = chain[i-1].cert.CheckSignatureFrom(chain[i].cert)
+		if err != nil {
+			return trustedCertificate{}, fmt.Errorf("%s is not signed by the next certificate, %s: the intermediates must follow the certificate in order", chain[i-1].cert.Subject, chain[i].cert.Subject)
+		}
+		if now.After(chain[i].cert.NotAfter) {
+			return trustedCertificate{}, fmt.Errorf("the intermediate %s expired on %s", chain[i].cert.Subject, chain[i].cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+
+	err = leaf.cert.VerifyHostname(hostname)
+	if err != nil {
+		return trustedCertificate{}, fmt.Errorf("the certificate is not valid for %s: %s", hostname, err)
+	}
+
+	return leaf, nil
+}
+
+// waitForCertificate polls the certificate Ops Manager serves until it is the
+// uploaded one, as Ops Manager restarts its web server to serve it.
+func (u UpdateSSLCertificate) waitForCertificate(certificate trustedCertificate) error {
+	deadline := time.Now().Add(time.Duration(u.Options.Timeout) * time.Second)
+	for {
+		served, err := u.fetcher.ServedCertificate()
+		if err == nil && bytes.Equal(served.Raw, certificate.cert.Raw) {
+			u.logger.Printf("Ops Manager serves the new SSL certificate")
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return fmt.Errorf("Ops Manager did not serve the new SSL certificate within %ds: %s", u.Options.Timeout, err)
+			}
+			return fmt.Errorf("Ops Manager did not serve the new SSL certificate within %ds, it serves %s", u.Options.Timeout, served.Subject)
+		}
+
+		u.logger.Printf("waiting for Ops Manager to serve the new SSL certificate...")
+		time.Sleep(time.Duration(u.Options.PollingInterval) * time.Second)
+	}
+}
+
+func targetHostname(target string) (string, error) {
+	if !strings.Contains(target, "://") {
+		target = "https://" + target
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Hostname() == "" {
+		return "", fmt.Errorf("could not determine the hostname of the target %q, use --hostname", target)
+	}
+
+	return parsed.Hostname(), nil
+}
+
+// TLSCertificateFetcher returns the certificate served on the target.
+type TLSCertificateFetcher struct {
+	target  string
+	timeout time.Duration
+}
+
+func NewTLSCertificateFetcher(target string, timeout time.Duration) TLSCertificateFetcher {
+	return TLSCertificateFetcher{
+		target:  target,
+		timeout: timeout,
+	}
+}
+
+func (f TLSCertificateFetcher) ServedCertificate() (*x509.Certificate, error) {
+	if !strings.Contains(f.target, "://") {
+		f.target = "https://" + f.target
+	}
+
+	parsed, err := url.Parse(f.target)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse target %q: %s", f.target, err)
+	}
+
+	address := parsed.Host
+	if parsed.Port() == "" {
+		address = net.JoinHostPort(parsed.Hostname(), "443")
+	}
+
+	// the served certificate is compared with the uploaded one, it does not
+	// need to be trusted
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: f.timeout}, "tcp", address, &tls.Config{
+		InsecureSkipVerify: true,
+		ServerName:         parsed.Hostname(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %s", address, err)
+	}
+	defer conn.Close()
+
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("%s did not present a certificate", address)
+	}
+
+	return certificates[0], nil
+}
@@ -0,0 +1,185 @@
+package commands_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+func newServerCertificate(hostname string, notAfter time.Time, parent testCertificate) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: hostname},
+		DNSNames:     []string{hostname},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent.cert, &key.PublicKey, parent.key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	return testCertificate{
+		cert:   cert,
+		key:    key,
+		pem:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPem: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})),
+	}
+}
+
+var _ = Describe("UpdateSSLCertificate", func() {
+	var (
+		service      *fakes.UpdateSSLCertificateService
+		fetcher      *fakes.ServedCertificateFetcher
+		stdout       *gbytes.Buffer
+		command      *commands.UpdateSSLCertificate
+		intermediate testCertificate
+		certificate  testCertificate
+	)
+
+	BeforeEach(func() {
+		root := newTestCertificate("root", nil, true)
+		intermediate = newTestCertificate("intermediate", &root, true)
+		certificate = newServerCertificate("opsman.example.com", time.Now().Add(365*24*time.Hour), intermediate)
+
+		service = &fakes.UpdateSSLCertificateService{}
+		fetcher = &fakes.ServedCertificateFetcher{}
+		fetcher.ServedCertificateReturns(certificate.cert, nil)
+
+		stdout = gbytes.NewBuffer()
+		command = commands.NewUpdateSSLCertificate(service, fetcher, "https://opsman.example.com", log.New(stdout, "", 0))
+	})
+
+	It("uploads the certificate and waits for Ops Manager to serve it", func() {
+		fetcher.ServedCertificateReturnsOnCall(0, intermediate.cert, nil)
+		fetcher.ServedCertificateReturnsOnCall(1, nil, errors.New("connection refused"))
+
+		err := executeCommand(command, []string{
+			"--certificate-pem", certificate.pem + intermediate.pem,
+			"--private-key-pem", certificate.keyPem,
+			"--polling-interval", "0",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.UpdateSSLCertificateArgsForCall(0)).To(Equal(api.SSLCertificateSettings{
+			CertPem:       certificate.pem + intermediate.pem,
+			PrivateKeyPem: certificate.keyPem,
+		}))
+
+		Expect(fetcher.ServedCertificateCallCount()).To(Equal(3))
+		Expect(stdout).To(gbytes.Say("uploaded the SSL certificate CN=opsman.example.com"))
+		Expect(stdout).To(gbytes.Say("waiting for Ops Manager to serve the new SSL certificate..."))
+		Expect(stdout).To(gbytes.Say("Ops Manager serves the new SSL certificate"))
+	})
+
+	It("reads the certificate and key from files", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "cert.pem"), []byte(certificate.pem), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "key.pem"), []byte(certificate.keyPem), 0600)).To(Succeed())
+
+		err := executeCommand(command, []string{
+			"--certificate-pem", filepath.Join(dir, "cert.pem"),
+			"--private-key-pem", filepath.Join(dir, "key.pem"),
+			"--skip-verify",
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.UpdateSSLCertificateArgsForCall(0).CertPem).To(Equal(certificate.pem))
+		Expect(fetcher.ServedCertificateCallCount()).To(Equal(0))
+	})
+
+	DescribeTable("does not upload an invalid certificate",
+		func(certificatePem func() string, privateKeyPem func() string, extraArgs []string, expectedError string) {
+			args := append([]string{"--certificate-pem", certificatePem(), "--private-key-pem", privateKeyPem()}, extraArgs...)
+
+			err := executeCommand(command, args)
+			Expect(err).To(MatchError(ContainSubstring(expectedError)))
+			Expect(service.UpdateSSLCertificateCallCount()).To(Equal(0))
+		},
+		Entry("an expired certificate",
+			func() string {
+				return newServerCertificate("opsman.example.com", time.Now().Add(-time.Minute), intermediate).pem
+			},
+			func() string { return certificate.keyPem },
+			nil, "the certificate was not uploaded: the certificate expired on"),
+		Entry("a key of another certificate",
+			func() string { return certificate.pem },
+			func() string { return intermediate.keyPem },
+			nil, "the private key does not belong to the certificate"),
+		Entry("intermediates out of order",
+			func() string { return certificate.pem + newTestCertificate("other", nil, true).pem },
+			func() string { return certificate.keyPem },
+			nil, "CN=opsman.example.com is not signed by the next certificate, CN=other"),
+		Entry("another hostname",
+			func() string { return certificate.pem },
+			func() string { return certificate.keyPem },
+			[]string{"--hostname", "opsman.example.org"}, "the certificate is not valid for opsman.example.org"),
+	)
+
+	It("returns an error when Ops Manager does not serve the new certificate in time", func() {
+		fetcher.ServedCertificateReturns(intermediate.cert, nil)
+
+		err := executeCommand(command, []string{
+			"--certificate-pem", certificate.pem,
+			"--private-key-pem", certificate.keyPem,
+			"--polling-interval", "0",
+			"--timeout", "0",
+		})
+		Expect(err).To(MatchError("Ops Manager did not serve the new SSL certificate within 0s, it serves CN=intermediate"))
+	})
+
+	It("returns an error when the certificate cannot be updated", func() {
+		service.UpdateSSLCertificateReturns(errors.New("some error"))
+
+		err := executeCommand(command, []string{"--certificate-pem", certificate.pem, "--private-key-pem", certificate.keyPem})
+		Expect(err).To(MatchError("could not update the SSL certificate: some error"))
+	})
+})
+
+var _ = Describe("TLSCertificateFetcher", func() {
+	It("returns the certificate the target serves", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		served, err := commands.NewTLSCertificateFetcher(server.URL, time.Second).ServedCertificate()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(served.Raw).To(Equal(server.TLS.Certificates[0].Certificate[0]))
+	})
+
+	It("returns an error when the target cannot be reached", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		_, err := commands.NewTLSCertificateFetcher(server.URL, time.Second).ServedCertificate()
+		Expect(err).To(MatchError(ContainSubstring("could not connect to")))
+	})
+})
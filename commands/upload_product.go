@@ -1,8 +1,22 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
 	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/download_clients"
 	"github.com/pivotal-cf/om/extractor"
 	"github.com/pivotal-cf/om/validator"
 )
@@ -10,59 +24,149 @@ import (
 const maxProductUploadRetries = 2
 
 type UploadProduct struct {
-	multipart multipart
-	logger    logger
-	service   uploadProductService
-	Options   struct {
+	multipart    multipart
+	logger       logger
+	service      uploadProductService
+	newBlobstore ProductBlobstoreFunc
+	client       productURLClient
+	Options      struct {
 		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
 
-		Product         string `long:"product"          short:"p"   description:"path to product" required:"true"`
+		Product         string `long:"product"          short:"p"   description:"path to product"`
+		ProductURL      string `long:"product-url"                  description:"URL of the product to stream to Ops Manager without saving it to disk; the server must support range requests. An s3://, gs:// or azure:// URI is read with the blobstore flags of download-product"`
 		PollingInterval int    `long:"polling-interval" short:"i"  description:"interval (in seconds) at which to print status" default:"1"`
 		Shasum          string `long:"shasum"                       description:"shasum of the provided product file to be used for validation"`
 		Version         string `long:"product-version"              description:"version of the provided product file to be used for validation"`
+		ChunkSize       int64  `long:"chunk-size"                   description:"upload the product in resumable chunks of this size (in MB); an interrupted upload resumes from the last acknowledged chunk"`
+		SkipIfPresent   bool   `long:"skip-if-present"              description:"check whether the product version is already available before calculating the shasum of the product, so nothing but its metadata is read when it is"`
+
+		Blobstore productBlobstoreOptions `group:"blobstore"`
 	}
 	metadataExtractor metadataExtractor
 }
 
+// productBlobstoreOptions are the download-product options an s3://, gs:// or
+// azure:// --product-url is read with; the bucket comes from the URI.
+type productBlobstoreOptions struct {
+	S3AccessKeyID          string `long:"s3-access-key-id"          description:"access key for the s3 compatible blobstore"`
+	S3AuthType             string `long:"s3-auth-type"              description:"can be set to \"iam\" in order to allow use of instance credentials" default:"accesskey"`
+	S3SecretAccessKey      string `long:"s3-secret-access-key"      description:"secret key for the s3 compatible blobstore"`
+	S3SessionToken         string `long:"s3-session-token"          description:"session token to use with temporary access and secret keys"`
+	S3RoleARN              string `long:"s3-role-arn"               description:"ARN of a role to assume through STS before accessing the bucket"`
+	S3ExternalID           string `long:"s3-external-id"            description:"external ID to pass when assuming --s3-role-arn"`
+	S3RegionName           string `long:"s3-region-name"            description:"bucket region in the s3 compatible blobstore. If not using AWS, this value is 'region'"`
+	S3Endpoint             string `long:"s3-endpoint"               description:"the endpoint to access the s3 compatible blobstore. If not using AWS, this is required"`
+	S3DisableSSL           bool   `long:"s3-disable-ssl"            description:"whether to disable ssl (https or http) when contacting the s3 compatible blobstore"`
+	S3CACert               string `long:"s3-ca-cert"                description:"CA certificate path or value to trust when contacting the s3 compatible blobstore"`
+	S3AddressingStyle      string `long:"s3-addressing-style"       description:"whether the bucket goes in the path or the host name of requests. 'auto' uses path-style for a custom --s3-endpoint" choice:"auto" choice:"path" choice:"virtual" default:"auto"`
+	S3SkipRegionValidation bool   `long:"s3-skip-region-validation" description:"do not check the bucket can be reached in --s3-region-name, for s3 compatible blobstores that do not implement regions"`
+
+	GCSServiceAccountJSON string `long:"gcs-service-account-json" description:"the service account key JSON"`
+	GCSProjectID          string `long:"gcs-project-id"           description:"the project id for the bucket's gcp account"`
+	GCSAuthType           string `long:"gcs-auth-type"            description:"can be set to \"adc\" in order to use Application Default Credentials, such as GKE Workload Identity, instead of a service account key" default:"serviceaccount"`
+
+	AzureStorageAccount string `long:"azure-storage-account" description:"the name of the storage account where the container exists"`
+	AzureKey            string `long:"azure-storage-key"     description:"the access key for the storage account. Incompatible with --azure-sas-token"`
+	AzureSASToken       string `long:"azure-sas-token"       env:"AZURE_STORAGE_SAS_TOKEN"       description:"a SAS token granting read access to the container. Incompatible with --azure-storage-key"`
+	AzureEndpointSuffix string `long:"azure-endpoint-suffix" env:"AZURE_STORAGE_ENDPOINT_SUFFIX" description:"the storage endpoint suffix of a sovereign cloud, e.g. core.usgovcloudapi.net for Azure Government or core.chinacloudapi.cn for Azure China"`
+}
+
+func (o productBlobstoreOptions) downloadProductOptions(source, bucket string) DownloadProductOptions {
+	return DownloadProductOptions{
+		Source: source,
+		Bucket: bucket,
+		S3Options: S3Options{
+			S3AccessKeyID:          o.S3AccessKeyID,
+			S3AuthType:             o.S3AuthType,
+			S3SecretAccessKey:      o.S3SecretAccessKey,
+			S3SessionToken:         o.S3SessionToken,
+			S3RoleARN:              o.S3RoleARN,
+			S3ExternalID:           o.S3ExternalID,
+			S3RegionName:           o.S3RegionName,
+			S3Endpoint:             o.S3Endpoint,
+			S3DisableSSL:           o.S3DisableSSL,
+			S3CACert:               o.S3CACert,
+			S3AddressingStyle:      o.S3AddressingStyle,
+			S3SkipRegionValidation: o.S3SkipRegionValidation,
+		},
+		GCSOptions: GCSOptions{
+			GCSServiceAccountJSON: o.GCSServiceAccountJSON,
+			GCSProjectID:          o.GCSProjectID,
+			GCSAuthType:           o.GCSAuthType,
+		},
+		AzureOptions: AzureOptions{
+			AzureStorageAccount: o.AzureStorageAccount,
+			AzureKey:            o.AzureKey,
+			AzureSASToken:       o.AzureSASToken,
+			AzureEndpointSuffix: o.AzureEndpointSuffix,
+		},
+	}
+}
+
 //counterfeiter:generate -o ./fakes/upload_product_service.go --fake-name UploadProductService . uploadProductService
 type uploadProductService interface {
 	UploadAvailableProduct(api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error)
 	CheckProductAvailability(string, string) (bool, error)
+	UploadAvailableProductChunk(api.UploadAvailableProductChunkInput) (api.UploadAvailableProductChunkOutput, error)
+	GetAvailableProductUploadOffset(string, int64) (int64, error)
 }
 
 //counterfeiter:generate -o ./fakes/metadata_extractor.go --fake-name MetadataExtractor . metadataExtractor
 type metadataExtractor interface {
 	ExtractFromFile(string) (*extractor.Metadata, error)
+	ExtractFromURL(string) (*extractor.Metadata, error)
 }
 
-func NewUploadProduct(multipart multipart, metadataExtractor metadataExtractor, service uploadProductService, logger logger) *UploadProduct {
+// productURLClient fetches the product of a --product-url that is not in a
+// blobstore.
+type productURLClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// ProductBlobstoreFunc connects to the blobstore of a --product-url URI.
+type ProductBlobstoreFunc func(options DownloadProductOptions) (download_clients.ProductOpener, error)
+
+// blobstoreSources are the URI schemes of the blobstores a product can be
+// streamed from, with the download-product source they are read with.
+var blobstoreSources = map[string]string{
+	"s3":    "s3",
+	"gs":    "gcs",
+	"azure": "azure",
+}
+
+func NewUploadProduct(multipart multipart, metadataExtractor metadataExtractor, service uploadProductService, logger logger, newBlobstore ProductBlobstoreFunc, client productURLClient) *UploadProduct {
 	return &UploadProduct{
 		multipart:         multipart,
 		metadataExtractor: metadataExtractor,
 		logger:            logger,
 		service:           service,
+		newBlobstore:      newBlobstore,
+		client:            client,
 	}
 }
 
+// NewProductBlobstore connects to the blobstore of an s3://, gs:// or
+// azure:// --product-url.
+func NewProductBlobstore(options DownloadProductOptions) (download_clients.ProductOpener, error) {
+	return newBlobstoreClient[download_clients.ProductOpener](options, log.New(io.Discard, "", 0), "cannot stream products from %s")
+}
+
 func (up UploadProduct) Execute(args []string) error {
-	if up.Options.Shasum != "" {
-		shaValidator := validator.NewSHA256Calculator()
-		shasum, err := shaValidator.Checksum(up.Options.Product)
+	err := up.validate()
+	if err != nil {
+		return err
+	}
 
+	if !up.Options.SkipIfPresent {
+		err = up.verifyShasum()
 		if err != nil {
 			return err
 		}
-
-		if shasum != up.Options.Shasum {
-			return fmt.Errorf("expected shasum %s does not match file shasum %s", up.Options.Shasum, shasum)
-		}
-
-		up.logger.Printf("expected shasum matches product shasum.")
 	}
 
-	metadata, err := up.metadataExtractor.ExtractFromFile(up.Options.Product)
+	metadata, err := up.extractMetadata()
 	if err != nil {
-		return fmt.Errorf("failed to extract product metadata: %s", err)
+		return err
 	}
 
 	if up.Options.Version != "" {
@@ -83,10 +187,22 @@ func (up UploadProduct) Execute(args []string) error {
 		return nil
 	}
 
+	if up.Options.SkipIfPresent {
+		err = up.verifyShasum()
+		if err != nil {
+			return err
+		}
+	}
+
+	if up.Options.ChunkSize > 0 {
+		return up.uploadInChunks()
+	}
+
 	for i := 0; i <= maxProductUploadRetries; i++ {
 		up.logger.Printf("processing product")
 
-		err = up.multipart.AddFile("product[file]", up.Options.Product)
+		var stream *productStream
+		stream, err = up.addProduct()
 		if err != nil {
 			return fmt.Errorf("failed to load product: %s", err)
 		}
@@ -101,6 +217,18 @@ func (up UploadProduct) Execute(args []string) error {
 			ContentLength:   submission.ContentLength,
 			PollingInterval: up.Options.PollingInterval,
 		})
+		if stream != nil {
+			stream.Close()
+
+			// there is no point in streaming the same wrong bytes again
+			if stream.err != nil {
+				return fmt.Errorf("failed to upload product: %s", stream.err)
+			}
+			if err == nil && up.Options.Shasum != "" {
+				up.logger.Printf("expected shasum matches product shasum.")
+			}
+		}
+
 		if err != nil && i < maxProductUploadRetries {
 			up.logger.Printf("retrying product upload after error: %s\n", err)
 			up.multipart.Reset()
@@ -125,3 +253,305 @@ func (up UploadProduct) Execute(args []string) error {
 
 	return nil
 }
+
+func (up UploadProduct) validate() error {
+	if up.Options.Product == "" && up.Options.ProductURL == "" {
+		return errors.New("either --product or --product-url must be provided")
+	}
+
+	if up.Options.Product != "" && up.Options.ProductURL != "" {
+		return errors.New("--product and --product-url cannot be used together")
+	}
+
+	if up.Options.ProductURL != "" && up.Options.ChunkSize > 0 {
+		return errors.New("--chunk-size cannot be used with --product-url")
+	}
+
+	return nil
+}
+
+func (up UploadProduct) extractMetadata() (*extractor.Metadata, error) {
+	if up.isBlobstoreURL() {
+		file, err := up.openBlobstoreFile()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract product metadata: %s", err)
+		}
+
+		metadata, err := file.ProductMetadata()
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract product metadata: %s", err)
+		}
+
+		return metadata, nil
+	}
+
+	if up.Options.ProductURL != "" {
+		// the metadata is read with range requests, the shasum is verified
+		// as the product is streamed
+		metadata, err := up.metadataExtractor.ExtractFromURL(up.Options.ProductURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract product metadata: %s", err)
+		}
+
+		return metadata, nil
+	}
+
+	metadata, err := up.metadataExtractor.ExtractFromFile(up.Options.Product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract product metadata: %s", err)
+	}
+
+	return metadata, nil
+}
+
+// verifyShasum reads the whole product file. A product from a URL is
+// verified as it is streamed instead.
+func (up UploadProduct) verifyShasum() error {
+	if up.Options.Shasum == "" || up.Options.ProductURL != "" {
+		return nil
+	}
+
+	shaValidator := validator.NewSHA256Calculator()
+	shasum, err := shaValidator.Checksum(up.Options.Product)
+	if err != nil {
+		return err
+	}
+
+	if shasum != up.Options.Shasum {
+		return fmt.Errorf("expected shasum %s does not match file shasum %s", up.Options.Shasum, shasum)
+	}
+
+	up.logger.Printf("expected shasum matches product shasum.")
+
+	return nil
+}
+
+// addProduct adds the product file to the form. When the product comes from
+// a URL, the returned stream is read as the form is uploaded and must be
+// closed afterwards.
+func (up UploadProduct) addProduct() (*productStream, error) {
+	if up.Options.ProductURL == "" {
+		return nil, up.multipart.AddFile("product[file]", up.Options.Product)
+	}
+
+	productURL, err := url.Parse(up.Options.ProductURL)
+	if err != nil {
+		return nil, err
+	}
+
+	body, contentLength, err := up.openProductURL(productURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := path.Base(productURL.Path)
+	if fileName == "." || fileName == "/" {
+		fileName = "product.pivotal"
+	}
+
+	stream := &productStream{
+		body:     body,
+		hash:     sha256.New(),
+		expected: up.Options.Shasum,
+	}
+
+	err = up.multipart.AddFileFromReader("product[file]", fileName, stream, contentLength)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (up UploadProduct) openProductURL(productURL *url.URL) (io.ReadCloser, int64, error) {
+	if up.isBlobstoreURL() {
+		file, err := up.openBlobstoreFile()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		body, err := file.Open()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return body, file.Size(), nil
+	}
+
+	request, err := http.NewRequest("GET", up.Options.ProductURL, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response, err := up.client.Do(request)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		response.Body.Close()
+		return nil, 0, fmt.Errorf("unexpected response %s from %s", response.Status, productURL.Redacted())
+	}
+
+	return response.Body, response.ContentLength, nil
+}
+
+func (up UploadProduct) isBlobstoreURL() bool {
+	productURL, err := url.Parse(up.Options.ProductURL)
+	if err != nil {
+		return false
+	}
+
+	_, ok := blobstoreSources[productURL.Scheme]
+	return ok
+}
+
+// openBlobstoreFile opens the product of an s3://bucket/path, gs://bucket/path
+// or azure://container/path URI.
+func (up UploadProduct) openBlobstoreFile() (download_clients.BlobFile, error) {
+	productURL, err := url.Parse(up.Options.ProductURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := strings.TrimPrefix(productURL.Path, "/")
+	if productURL.Host == "" || fileName == "" {
+		return nil, fmt.Errorf("%s must name the bucket and the path of the product, e.g. %s://bucket/path/product.pivotal", up.Options.ProductURL, productURL.Scheme)
+	}
+
+	blobstore, err := up.newBlobstore(up.Options.Blobstore.downloadProductOptions(blobstoreSources[productURL.Scheme], productURL.Host))
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to %s: %w", productURL.Scheme, err)
+	}
+
+	return blobstore.OpenFile(fileName)
+}
+
+// productStream hashes the product as it is read and, when a shasum is
+// expected, fails the read at the end of the product if it does not match.
+// That fails the upload before Ops Manager receives the end of the form.
+type productStream struct {
+	body     io.ReadCloser
+	hash     hash.Hash
+	expected string
+	err      error
+}
+
+func (s *productStream) Read(p []byte) (int, error) {
+	n, err := s.body.Read(p)
+	s.hash.Write(p[:n])
+
+	if err == io.EOF && s.expected != "" {
+		shasum := hex.EncodeToString(s.hash.Sum(nil))
+		if shasum != s.expected {
+			s.err = fmt.Errorf("expected shasum %s does not match product shasum %s", s.expected, shasum)
+			return n, s.err
+		}
+	}
+
+	return n, err
+}
+
+func (s *productStream) Close() error {
+	return s.body.Close()
+}
+
+func (up UploadProduct) uploadInChunks() error {
+	file, err := os.Open(up.Options.Product)
+	if err != nil {
+		return fmt.Errorf("failed to load product: %s", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to load product: %s", err)
+	}
+
+	// a byte range cannot describe an empty file
+	if info.Size() == 0 {
+		return fmt.Errorf("failed to load product: %s is empty", up.Options.Product)
+	}
+
+	fileName := filepath.Base(up.Options.Product)
+	totalLength := info.Size()
+	chunkSize := up.Options.ChunkSize * 1024 * 1024
+
+	offset, err := up.uploadOffset(fileName, totalLength, chunkSize)
+	if err != nil {
+		return err
+	}
+
+	if offset > 0 {
+		up.logger.Printf("resuming product upload at byte %d of %d", offset, totalLength)
+	} else {
+		up.logger.Printf("beginning chunked product upload to Ops Manager")
+	}
+
+	retries := 0
+	for {
+		chunkLength := chunkSize
+		if remaining := totalLength - offset; remaining < chunkLength {
+			chunkLength = remaining
+		}
+
+		var output api.UploadAvailableProductChunkOutput
+		output, err = up.service.UploadAvailableProductChunk(api.UploadAvailableProductChunkInput{
+			Chunk:       io.NewSectionReader(file, offset, chunkLength),
+			FileName:    fileName,
+			Offset:      offset,
+			ChunkLength: chunkLength,
+			TotalLength: totalLength,
+		})
+		if err == nil && !output.Complete && output.Offset <= offset {
+			err = fmt.Errorf("no bytes were acknowledged after offset %d", offset)
+		}
+
+		if err != nil {
+			if retries >= maxProductUploadRetries {
+				return fmt.Errorf("failed to upload product: %s", err)
+			}
+			retries++
+
+			up.logger.Printf("retrying product upload after error: %s\n", err)
+
+			offset, err = up.uploadOffset(fileName, totalLength, chunkSize)
+			if err != nil {
+				return err
+			}
+
+			up.logger.Printf("resuming product upload at byte %d of %d", offset, totalLength)
+			continue
+		}
+
+		retries = 0
+		if output.Complete {
+			break
+		}
+		offset = output.Offset
+	}
+
+	up.logger.Printf("finished upload")
+
+	return nil
+}
+
+// uploadOffset is the byte to resume the upload at. The upload is only done
+// once Ops Manager acknowledges the last chunk, so when it reports every byte
+// as persisted the last chunk is sent again.
+func (up UploadProduct) uploadOffset(fileName string, totalLength, chunkSize int64) (int64, error) {
+	offset, err := up.service.GetAvailableProductUploadOffset(fileName, totalLength)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine product upload offset: %s", err)
+	}
+
+	if offset >= totalLength {
+		offset = totalLength - chunkSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return offset, nil
+}
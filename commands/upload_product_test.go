@@ -5,15 +5,21 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
+	"github.com/jessevdk/go-flags"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
 
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/commands"
 	"github.com/pivotal-cf/om/commands/fakes"
+	"github.com/pivotal-cf/om/download_clients"
+	downloadFakes "github.com/pivotal-cf/om/download_clients/fakes"
 	"github.com/pivotal-cf/om/extractor"
 	"github.com/pivotal-cf/om/formcontent"
 
@@ -45,7 +51,7 @@ var _ = Describe("UploadProduct", func() {
 		}
 		multipart.FinalizeReturns(submission)
 
-		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 
 		err := executeCommand(command, []string{
 			"--product", "/path/to/some-product.tgz",
@@ -76,7 +82,7 @@ var _ = Describe("UploadProduct", func() {
 
 	When("the polling interval is provided", func() {
 		It("passes the value to the products service", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			err := executeCommand(command, []string{
 				"--product", "/path/to/some-product.tgz",
 				"--polling-interval", "48",
@@ -88,7 +94,7 @@ var _ = Describe("UploadProduct", func() {
 
 	When("the same product is already present", func() {
 		It("does nothing and exits gracefully", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			metadataExtractor.ExtractFromFileReturns(&extractor.Metadata{
 				Name:    "cf",
 				Version: "1.5.0",
@@ -112,6 +118,54 @@ var _ = Describe("UploadProduct", func() {
 		})
 	})
 
+	When("--skip-if-present is set", func() {
+		It("skips the shasum of a product that is already present", func() {
+			metadataExtractor.ExtractFromFileReturns(&extractor.Metadata{
+				Name:    "cf",
+				Version: "1.5.0",
+			}, nil)
+			fakeService.CheckProductAvailabilityReturns(true, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", "/path/to/missing.tgz",
+				"--shasum", "not-the-correct-shasum",
+				"--skip-if-present",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(0))
+
+			name, version := fakeService.CheckProductAvailabilityArgsForCall(0)
+			Expect([]string{name, version}).To(Equal([]string{"cf", "1.5.0"}))
+
+			Expect(logger.PrintfCallCount()).To(Equal(1))
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("product cf 1.5.0 is already uploaded, nothing to be done"))
+		})
+
+		It("verifies the shasum before uploading a product that is not present", func() {
+			file, err := os.CreateTemp("", "test-file.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(file.Name())
+
+			_, err = file.WriteString("testing-shasum")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			fakeService.CheckProductAvailabilityReturns(false, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err = executeCommand(command, []string{
+				"--product", file.Name(),
+				"--shasum", "not-the-correct-shasum",
+				"--skip-if-present",
+			})
+			Expect(err).To(MatchError("expected shasum not-the-correct-shasum does not match file shasum 2815ab9694a4a2cfd59424a734833010e143a0b2db20be3741507f177f289f44"))
+			Expect(fakeService.CheckProductAvailabilityCallCount()).To(Equal(1))
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(0))
+		})
+	})
+
 	When("the --shasum flag is defined", func() {
 		It("proceeds normally when the sha sums match", func() {
 			file, err := os.CreateTemp("", "test-file.yaml")
@@ -123,7 +177,7 @@ var _ = Describe("UploadProduct", func() {
 			err = file.Close()
 			Expect(err).ToNot(HaveOccurred())
 
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			metadataExtractor.ExtractFromFileReturns(&extractor.Metadata{
 				Name:    "cf",
 				Version: "1.5.0",
@@ -157,7 +211,7 @@ var _ = Describe("UploadProduct", func() {
 			err = file.Close()
 			Expect(err).ToNot(HaveOccurred())
 
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			err = executeCommand(command, []string{
 				"--product", file.Name(),
 				"--shasum", "not-the-correct-shasum",
@@ -167,7 +221,7 @@ var _ = Describe("UploadProduct", func() {
 		})
 
 		It("fails when the file can not calculate a shasum", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			err := executeCommand(command, []string{
 				"--product", "/path/to/testing.tgz",
 				"--shasum", "not-the-correct-shasum",
@@ -189,7 +243,7 @@ var _ = Describe("UploadProduct", func() {
 				Name:    "cf",
 				Version: "1.5.0",
 			}, nil)
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			fakeService.CheckProductAvailabilityStub = func(name, version string) (bool, error) {
 				if name == "cf" && version == "1.5.0" {
 					return true, nil
@@ -220,7 +274,7 @@ var _ = Describe("UploadProduct", func() {
 				Name:    "cf",
 				Version: "1.5.0",
 			}, nil)
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			err = executeCommand(command, []string{
 				"--product", file.Name(),
 				"--product-version", "2.5.0",
@@ -236,7 +290,7 @@ var _ = Describe("UploadProduct", func() {
 				stdout := gbytes.NewBuffer()
 				logger := log.New(stdout, "", 0)
 
-				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+				command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 
 				fakeService.UploadAvailableProductReturnsOnCall(0, api.UploadAvailableProductOutput{}, fmt.Errorf("some upload error: %w", io.EOF))
 				fakeService.UploadAvailableProductReturnsOnCall(1, api.UploadAvailableProductOutput{}, nil)
@@ -261,7 +315,7 @@ var _ = Describe("UploadProduct", func() {
 		})
 
 		It("tries again", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 
 			fakeService.UploadAvailableProductReturnsOnCall(0, api.UploadAvailableProductOutput{}, fmt.Errorf("some upload error: %w", io.EOF))
 			fakeService.UploadAvailableProductReturnsOnCall(1, api.UploadAvailableProductOutput{}, nil)
@@ -279,7 +333,7 @@ var _ = Describe("UploadProduct", func() {
 
 	When("the product fails to upload three times", func() {
 		It("returns an error", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 
 			fakeService.CheckProductAvailabilityReturns(false, nil)
 			fakeService.UploadAvailableProductReturns(api.UploadAvailableProductOutput{}, fmt.Errorf("some upload error: %w", io.EOF))
@@ -296,10 +350,389 @@ var _ = Describe("UploadProduct", func() {
 		})
 	})
 
+	When("the --chunk-size flag is provided", func() {
+		var productFile string
+
+		BeforeEach(func() {
+			file, err := os.CreateTemp("", "product-*.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = file.Write(make([]byte, 3*1024*1024))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+
+			productFile = file.Name()
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(productFile)).To(Succeed())
+		})
+
+		It("uploads the product one chunk at a time", func() {
+			fakeService.UploadAvailableProductChunkReturnsOnCall(0, api.UploadAvailableProductChunkOutput{Offset: 2 * 1024 * 1024}, nil)
+			fakeService.UploadAvailableProductChunkReturnsOnCall(1, api.UploadAvailableProductChunkOutput{Offset: 3 * 1024 * 1024, Complete: true}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(multipart.AddFileCallCount()).To(Equal(0))
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(0))
+
+			fileName, total := fakeService.GetAvailableProductUploadOffsetArgsForCall(0)
+			Expect(fileName).To(Equal(filepath.Base(productFile)))
+			Expect(total).To(Equal(int64(3 * 1024 * 1024)))
+
+			Expect(fakeService.UploadAvailableProductChunkCallCount()).To(Equal(2))
+
+			input := fakeService.UploadAvailableProductChunkArgsForCall(0)
+			Expect(input.Offset).To(Equal(int64(0)))
+			Expect(input.ChunkLength).To(Equal(int64(2 * 1024 * 1024)))
+			Expect(input.TotalLength).To(Equal(int64(3 * 1024 * 1024)))
+
+			input = fakeService.UploadAvailableProductChunkArgsForCall(1)
+			Expect(input.Offset).To(Equal(int64(2 * 1024 * 1024)))
+			Expect(input.ChunkLength).To(Equal(int64(1024 * 1024)))
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("beginning chunked product upload to Ops Manager"))
+
+			format, v = logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("finished upload"))
+		})
+
+		It("does not upload an empty product in chunks", func() {
+			Expect(os.Truncate(productFile, 0)).To(Succeed())
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).To(MatchError(fmt.Sprintf("failed to load product: %s is empty", productFile)))
+
+			Expect(fakeService.GetAvailableProductUploadOffsetCallCount()).To(Equal(0))
+			Expect(fakeService.UploadAvailableProductChunkCallCount()).To(Equal(0))
+		})
+
+		It("resumes a previously interrupted upload", func() {
+			fakeService.GetAvailableProductUploadOffsetReturns(2*1024*1024, nil)
+			fakeService.UploadAvailableProductChunkReturns(api.UploadAvailableProductChunkOutput{Offset: 3 * 1024 * 1024, Complete: true}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.UploadAvailableProductChunkCallCount()).To(Equal(1))
+			Expect(fakeService.UploadAvailableProductChunkArgsForCall(0).Offset).To(Equal(int64(2 * 1024 * 1024)))
+
+			format, v := logger.PrintfArgsForCall(0)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("resuming product upload at byte 2097152 of 3145728"))
+		})
+
+		It("sends the last chunk again when Ops Manager reports every byte as persisted", func() {
+			fakeService.GetAvailableProductUploadOffsetReturns(3*1024*1024, nil)
+			fakeService.UploadAvailableProductChunkReturns(api.UploadAvailableProductChunkOutput{Offset: 3 * 1024 * 1024, Complete: true}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.UploadAvailableProductChunkCallCount()).To(Equal(1))
+			input := fakeService.UploadAvailableProductChunkArgsForCall(0)
+			Expect(input.Offset).To(Equal(int64(1024 * 1024)))
+			Expect(input.ChunkLength).To(Equal(int64(2 * 1024 * 1024)))
+		})
+
+		It("resumes from the last acknowledged offset after a failed chunk", func() {
+			fakeService.GetAvailableProductUploadOffsetReturnsOnCall(0, 0, nil)
+			fakeService.GetAvailableProductUploadOffsetReturnsOnCall(1, 1024*1024, nil)
+			fakeService.UploadAvailableProductChunkReturnsOnCall(0, api.UploadAvailableProductChunkOutput{}, fmt.Errorf("some upload error: %w", io.EOF))
+			fakeService.UploadAvailableProductChunkReturnsOnCall(1, api.UploadAvailableProductChunkOutput{Offset: 3 * 1024 * 1024, Complete: true}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeService.GetAvailableProductUploadOffsetCallCount()).To(Equal(2))
+			Expect(fakeService.UploadAvailableProductChunkCallCount()).To(Equal(2))
+
+			input := fakeService.UploadAvailableProductChunkArgsForCall(1)
+			Expect(input.Offset).To(Equal(int64(1024 * 1024)))
+			Expect(input.ChunkLength).To(Equal(int64(2 * 1024 * 1024)))
+
+			format, v := logger.PrintfArgsForCall(1)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("retrying product upload after error: some upload error: EOF\n"))
+
+			format, v = logger.PrintfArgsForCall(2)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("resuming product upload at byte 1048576 of 3145728"))
+		})
+
+		It("returns an error when a chunk keeps failing", func() {
+			fakeService.UploadAvailableProductChunkReturns(api.UploadAvailableProductChunkOutput{}, errors.New("some chunk error"))
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).To(MatchError("failed to upload product: some chunk error"))
+			Expect(fakeService.UploadAvailableProductChunkCallCount()).To(Equal(3))
+		})
+
+		It("returns an error when Ops Manager does not acknowledge any bytes", func() {
+			fakeService.UploadAvailableProductChunkReturns(api.UploadAvailableProductChunkOutput{Offset: 0}, nil)
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).To(MatchError("failed to upload product: no bytes were acknowledged after offset 0"))
+		})
+
+		It("returns an error when the upload offset cannot be determined", func() {
+			fakeService.GetAvailableProductUploadOffsetReturns(0, errors.New("some offset error"))
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+			err := executeCommand(command, []string{
+				"--product", productFile,
+				"--chunk-size", "2",
+			})
+			Expect(err).To(MatchError("failed to determine product upload offset: some offset error"))
+		})
+	})
+
+	When("the --product-url flag is provided", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			server.RouteToHandler("GET", "/tiles/some-product.pivotal", ghttp.RespondWith(http.StatusOK, "some product contents"))
+
+			metadataExtractor.ExtractFromURLReturns(&extractor.Metadata{Name: "some-product", Version: "1.2.3"}, nil)
+
+			fakeService.UploadAvailableProductStub = func(api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error) {
+				_, _, content, _ := multipart.AddFileFromReaderArgsForCall(multipart.AddFileFromReaderCallCount() - 1)
+				_, err := io.ReadAll(content)
+				return api.UploadAvailableProductOutput{}, err
+			}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("fails the upload when the shasum does not match", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+
+			err := executeCommand(command, []string{
+				"--product-url", server.URL() + "/tiles/some-product.pivotal",
+				"--shasum", "not-the-shasum",
+			})
+			Expect(err).To(MatchError(ContainSubstring("expected shasum not-the-shasum does not match product shasum")))
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
+		})
+
+		It("uploads the product without saving it to disk", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+
+			err := executeCommand(command, []string{
+				"--product-url", server.URL() + "/tiles/some-product.pivotal",
+				"--shasum", "c4e2d2a93560f5d3e7893194ff60d1a5587fd87386edee35ceca2fb2302c68ad",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(metadataExtractor.ExtractFromURLArgsForCall(0)).To(Equal(server.URL() + "/tiles/some-product.pivotal"))
+			Expect(metadataExtractor.ExtractFromFileCallCount()).To(Equal(0))
+			Expect(multipart.AddFileCallCount()).To(Equal(0))
+
+			key, fileName, _, length := multipart.AddFileFromReaderArgsForCall(0)
+			Expect(key).To(Equal("product[file]"))
+			Expect(fileName).To(Equal("some-product.pivotal"))
+			Expect(length).To(Equal(int64(len("some product contents"))))
+
+			Expect(logger.PrintfCallCount()).To(BeNumerically(">", 0))
+			format, v := logger.PrintfArgsForCall(logger.PrintfCallCount() - 2)
+			Expect(fmt.Sprintf(format, v...)).To(Equal("expected shasum matches product shasum."))
+		})
+
+		It("fetches the product with the given client", func() {
+			tlsServer := ghttp.NewTLSServer()
+			defer tlsServer.Close()
+			tlsServer.RouteToHandler("GET", "/tiles/some-product.pivotal", ghttp.RespondWith(http.StatusOK, "some product contents"))
+
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, tlsServer.HTTPTestServer.Client())
+
+			err := executeCommand(command, []string{"--product-url", tlsServer.URL() + "/tiles/some-product.pivotal"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(tlsServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns an error when the product cannot be fetched", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+
+			server.RouteToHandler("GET", "/tiles/missing.pivotal", ghttp.RespondWith(http.StatusNotFound, ""))
+
+			err := executeCommand(command, []string{"--product-url", server.URL() + "/tiles/missing.pivotal"})
+			Expect(err).To(MatchError(ContainSubstring("failed to load product: unexpected response 404 Not Found")))
+		})
+
+		It("does not allow --product as well", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", server.URL(), "--product", "/some/path"})
+			Expect(err).To(MatchError("--product and --product-url cannot be used together"))
+		})
+
+		It("does not allow --chunk-size", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", server.URL(), "--chunk-size", "10"})
+			Expect(err).To(MatchError("--chunk-size cannot be used with --product-url"))
+		})
+	})
+
+	When("the --product-url flag is a blobstore URI", func() {
+		var (
+			blobstore    *downloadFakes.ProductOpener
+			file         *downloadFakes.BlobFile
+			newBlobstore commands.ProductBlobstoreFunc
+			options      []commands.DownloadProductOptions
+		)
+
+		BeforeEach(func() {
+			file = &downloadFakes.BlobFile{}
+			file.SizeReturns(int64(len("some product contents")))
+			file.ProductMetadataReturns(&extractor.Metadata{Name: "some-product", Version: "1.2.3"}, nil)
+			file.OpenStub = func() (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("some product contents")), nil
+			}
+
+			blobstore = &downloadFakes.ProductOpener{}
+			blobstore.OpenFileReturns(file, nil)
+
+			options = nil
+			newBlobstore = func(o commands.DownloadProductOptions) (download_clients.ProductOpener, error) {
+				options = append(options, o)
+				return blobstore, nil
+			}
+
+			fakeService.UploadAvailableProductStub = func(api.UploadAvailableProductInput) (api.UploadAvailableProductOutput, error) {
+				_, _, content, _ := multipart.AddFileFromReaderArgsForCall(multipart.AddFileFromReaderCallCount() - 1)
+				_, err := io.ReadAll(content)
+				return api.UploadAvailableProductOutput{}, err
+			}
+		})
+
+		It("streams the product from the blobstore with the download-product credentials", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err := executeCommand(command, []string{
+				"--product-url", "s3://some-bucket/products/some-product.pivotal",
+				"--s3-access-key-id", "some-key",
+				"--s3-secret-access-key", "some-secret",
+				"--s3-region-name", "us-west-1",
+				"--shasum", "c4e2d2a93560f5d3e7893194ff60d1a5587fd87386edee35ceca2fb2302c68ad",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(options[0].Source).To(Equal("s3"))
+			Expect(options[0].Bucket).To(Equal("some-bucket"))
+			Expect(options[0].S3AccessKeyID).To(Equal("some-key"))
+			Expect(options[0].S3RegionName).To(Equal("us-west-1"))
+			Expect(blobstore.OpenFileArgsForCall(0)).To(Equal("products/some-product.pivotal"))
+
+			Expect(metadataExtractor.ExtractFromURLCallCount()).To(Equal(0))
+			Expect(fakeService.CheckProductAvailabilityCallCount()).To(Equal(1))
+			name, version := fakeService.CheckProductAvailabilityArgsForCall(0)
+			Expect(name).To(Equal("some-product"))
+			Expect(version).To(Equal("1.2.3"))
+
+			key, fileName, _, length := multipart.AddFileFromReaderArgsForCall(0)
+			Expect(key).To(Equal("product[file]"))
+			Expect(fileName).To(Equal("some-product.pivotal"))
+			Expect(length).To(Equal(int64(len("some product contents"))))
+			Expect(fakeService.UploadAvailableProductCallCount()).To(Equal(1))
+		})
+
+		It("reads gs:// URIs from gcs and azure:// URIs from azure", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", "gs://some-bucket/some-product.pivotal"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[0].Source).To(Equal("gcs"))
+
+			command = commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err = executeCommand(command, []string{"--product-url", "azure://some-container/some-product.pivotal"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[len(options)-1].Source).To(Equal("azure"))
+			Expect(options[len(options)-1].Bucket).To(Equal("some-container"))
+		})
+
+		It("only accepts the blobstore options a read needs", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", "s3://some-bucket/some-product.pivotal"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(options[0].S3AuthType).To(Equal("accesskey"))
+			Expect(options[0].S3AddressingStyle).To(Equal("auto"))
+			Expect(options[0].ProductPath).To(BeEmpty())
+
+			_, err = flags.NewParser(command, flags.None).ParseArgs([]string{"--product-url", "s3://some-bucket/some-product.pivotal", "--s3-enable-v2-signing"})
+			Expect(err).To(MatchError("unknown flag `s3-enable-v2-signing'"))
+		})
+
+		It("requires the bucket and the path of the product", func() {
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", "s3://some-bucket"})
+			Expect(err).To(MatchError("failed to extract product metadata: s3://some-bucket must name the bucket and the path of the product, e.g. s3://bucket/path/product.pivotal"))
+		})
+
+		It("returns an error when the blobstore cannot be reached", func() {
+			newBlobstore = func(commands.DownloadProductOptions) (download_clients.ProductOpener, error) {
+				return nil, errors.New("some error")
+			}
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", "s3://some-bucket/some-product.pivotal"})
+			Expect(err).To(MatchError("failed to extract product metadata: could not connect to s3: some error"))
+		})
+
+		It("returns an error when the metadata cannot be read", func() {
+			file.ProductMetadataReturns(nil, errors.New("not a zip"))
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, newBlobstore, http.DefaultClient)
+
+			err := executeCommand(command, []string{"--product-url", "s3://some-bucket/some-product.pivotal"})
+			Expect(err).To(MatchError("failed to extract product metadata: not a zip"))
+		})
+	})
+
+	It("requires --product or --product-url", func() {
+		command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
+
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("either --product or --product-url must be provided"))
+	})
+
 	When("extracting the product metadata returns an error", func() {
 		It("returns an error", func() {
 			metadataExtractor.ExtractFromFileReturns(&extractor.Metadata{}, errors.New("some error"))
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			err := executeCommand(command, []string{"--product", "/some/path"})
 			Expect(err).To(MatchError("failed to extract product metadata: some error"))
 		})
@@ -308,7 +741,7 @@ var _ = Describe("UploadProduct", func() {
 	When("checking for product availability returns an error", func() {
 		It("returns an error", func() {
 			fakeService.CheckProductAvailabilityReturns(true, errors.New("some error"))
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			err := executeCommand(command, []string{"--product", "/some/path"})
 			Expect(err).To(MatchError("failed to check product availability: some error"))
 		})
@@ -316,7 +749,7 @@ var _ = Describe("UploadProduct", func() {
 
 	When("adding the file fails", func() {
 		It("returns an error", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			multipart.AddFileReturns(errors.New("bad file"))
 
 			err := executeCommand(command, []string{"--product", "/some/path"})
@@ -326,7 +759,7 @@ var _ = Describe("UploadProduct", func() {
 
 	When("the product cannot be uploaded", func() {
 		It("returns an error", func() {
-			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger)
+			command := commands.NewUploadProduct(multipart, metadataExtractor, fakeService, logger, nil, http.DefaultClient)
 			fakeService.UploadAvailableProductReturns(api.UploadAvailableProductOutput{}, errors.New("some product error"))
 
 			err := executeCommand(command, []string{"--product", "/some/path"})
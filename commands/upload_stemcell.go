@@ -6,9 +6,13 @@ import (
 	"github.com/pivotal-cf/om/api"
 	"github.com/pivotal-cf/om/formcontent"
 	"github.com/pivotal-cf/om/validator"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
+	"text/tabwriter"
 )
 
 const maxStemcellUploadRetries = 2
@@ -20,10 +24,11 @@ type UploadStemcell struct {
 	Options   struct {
 		InterpolateOptions interpolateConfigFileOptions `group:"config file interpolation"`
 
-		Stemcell string `long:"stemcell" short:"s" required:"true" description:"path to stemcell"`
-		Force    bool   `long:"force"    short:"f"                 description:"upload stemcell even if it already exists on the target Ops Manager"`
-		Floating string `long:"floating" default:"true"            description:"assigns the stemcell to all compatible products "`
-		Shasum   string `long:"shasum"                             description:"shasum of the provided product file to be used for validation"`
+		Stemcells []string `long:"stemcell" short:"s" required:"true" description:"path to stemcell, or to a directory of stemcells (*.tgz). Can be repeated to upload several stemcells at once"`
+		Force     bool     `long:"force"    short:"f"                 description:"upload stemcell even if it already exists on the target Ops Manager"`
+		Floating  string   `long:"floating" default:"true"            description:"assigns the stemcell to all compatible products "`
+		Shasum    string   `long:"shasum"                             description:"shasum of the provided product file to be used for validation"`
+		Parallel  int      `long:"parallel" default:"2"               description:"number of stemcells to upload at the same time when uploading several stemcells"`
 	}
 }
 
@@ -32,6 +37,7 @@ type multipart interface {
 	Finalize() formcontent.ContentSubmission
 	Reset()
 	AddFile(key, path string) error
+	AddFileFromReader(key, fileName string, content io.Reader, length int64) error
 	AddField(key, value string) error
 }
 
@@ -52,14 +58,23 @@ func NewUploadStemcell(multipart multipart, service uploadStemcellService, logge
 }
 
 func (us UploadStemcell) Execute(args []string) error {
-	err := us.validate()
+	stemcells, err := us.stemcellFiles()
 	if err != nil {
 		return err
 	}
 
-	stemcellFilename := us.Options.Stemcell
+	err = us.validate(stemcells)
+	if err != nil {
+		return err
+	}
+
+	if len(stemcells) > 1 {
+		return us.uploadStemcells(stemcells)
+	}
+
+	stemcellFilename := stemcells[0]
 	if !us.Options.Force {
-		exists, err := us.checkStemcellUploaded()
+		exists, err := us.checkStemcellUploaded(stemcellFilename)
 		if err != nil {
 			return err
 		}
@@ -69,33 +84,69 @@ func (us UploadStemcell) Execute(args []string) error {
 		}
 	}
 
-	prefixRegex := regexp.MustCompile(`^\[.*?,.*?\](.+)$`)
-	if prefixRegex.MatchString(filepath.Base(stemcellFilename)) {
-		matches := prefixRegex.FindStringSubmatch(filepath.Base(stemcellFilename))
+	stemcellFilename, cleanup, err := prefixlessStemcellFilename(stemcellFilename)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-		stemcellAbsPath, err := filepath.Abs(stemcellFilename)
-		if err != nil {
-			return err
+	err = us.uploadStemcell(stemcellFilename)
+	if err != nil {
+		return fmt.Errorf("failed to upload stemcell: %s", err)
+	}
+
+	us.logger.Printf("finished upload")
+
+	return nil
+}
+
+// stemcellFiles expands the directories given with --stemcell to the
+// stemcells in them.
+func (us UploadStemcell) stemcellFiles() ([]string, error) {
+	var stemcells []string
+	for _, path := range us.Options.Stemcells {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			stemcells = append(stemcells, path)
+			continue
 		}
 
-		symlinkedStemcell := filepath.Join(filepath.Dir(stemcellAbsPath), matches[1])
-		err = os.Symlink(stemcellAbsPath, symlinkedStemcell)
+		matches, err := filepath.Glob(filepath.Join(path, "*.tgz"))
 		if err != nil {
-			return err
+			return nil, err // not tested
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no stemcells (*.tgz) found in directory %s", path)
 		}
-		stemcellFilename = symlinkedStemcell
+		stemcells = append(stemcells, matches...)
+	}
+
+	return stemcells, nil
+}
 
-		defer os.Remove(symlinkedStemcell)
+// prefixlessStemcellFilename links stemcells downloaded with a
+// "[product,version]" prefix to their original name, which Ops Manager
+// expects.
+func prefixlessStemcellFilename(stemcellFilename string) (string, func(), error) {
+	prefixRegex := regexp.MustCompile(`^\[.*?,.*?\](.+)$`)
+	if !prefixRegex.MatchString(filepath.Base(stemcellFilename)) {
+		return stemcellFilename, func() {}, nil
 	}
 
-	err = us.uploadStemcell(stemcellFilename)
+	matches := prefixRegex.FindStringSubmatch(filepath.Base(stemcellFilename))
+
+	stemcellAbsPath, err := filepath.Abs(stemcellFilename)
 	if err != nil {
-		return fmt.Errorf("failed to upload stemcell: %s", err)
+		return "", nil, err
 	}
 
-	us.logger.Printf("finished upload")
+	symlinkedStemcell := filepath.Join(filepath.Dir(stemcellAbsPath), matches[1])
+	err = os.Symlink(stemcellAbsPath, symlinkedStemcell)
+	if err != nil {
+		return "", nil, err
+	}
 
-	return nil
+	return symlinkedStemcell, func() { os.Remove(symlinkedStemcell) }, nil
 }
 
 func (us UploadStemcell) uploadStemcell(stemcellFilename string) (err error) {
@@ -133,14 +184,22 @@ func (us UploadStemcell) uploadStemcell(stemcellFilename string) (err error) {
 	return err
 }
 
-func (us UploadStemcell) validate() error {
+func (us UploadStemcell) validate(stemcells []string) error {
 	if us.Options.Floating != "true" && us.Options.Floating != "false" {
 		return errors.New("--floating must be \"true\" or \"false\". Default: true")
 	}
 
+	if us.Options.Parallel < 1 {
+		return errors.New("--parallel must be at least 1")
+	}
+
 	if us.Options.Shasum != "" {
+		if len(stemcells) > 1 {
+			return errors.New("--shasum can only be used when uploading a single stemcell")
+		}
+
 		shaValidator := validator.NewSHA256Calculator()
-		shasum, err := shaValidator.Checksum(us.Options.Stemcell)
+		shasum, err := shaValidator.Checksum(stemcells[0])
 
 		if err != nil {
 			return err
@@ -156,10 +215,10 @@ func (us UploadStemcell) validate() error {
 	return nil
 }
 
-func (us UploadStemcell) checkStemcellUploaded() (exists bool, err error) {
+func (us UploadStemcell) checkStemcellUploaded(stemcellFilename string) (exists bool, err error) {
 	us.logger.Printf("processing stemcell")
 
-	found, err := us.service.CheckStemcellAvailability(us.Options.Stemcell)
+	found, err := us.service.CheckStemcellAvailability(stemcellFilename)
 	if err != nil {
 		return false, err
 	}
@@ -170,3 +229,146 @@ func (us UploadStemcell) checkStemcellUploaded() (exists bool, err error) {
 
 	return found, nil
 }
+
+type stemcellUploadResult struct {
+	stemcell string
+	status   string
+	failed   bool
+}
+
+// uploadStemcells uploads several stemcells at the same time. Each upload
+// has its own form, so they do not share the multipart of the command.
+func (us UploadStemcell) uploadStemcells(stemcells []string) error {
+	us.logger.Printf("uploading %d stemcells, %d at a time", len(stemcells), us.Options.Parallel)
+
+	results := make([]stemcellUploadResult, len(stemcells))
+	slots := make(chan struct{}, us.Options.Parallel)
+
+	var wg sync.WaitGroup
+	for i, stemcell := range stemcells {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			results[i] = us.uploadOneOfStemcells(stemcell)
+		}()
+	}
+	wg.Wait()
+
+	var (
+		summary strings.Builder
+		failed  int
+	)
+	table := tabwriter.NewWriter(&summary, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STEMCELL\tSTATUS")
+	for _, result := range results {
+		fmt.Fprintf(table, "%s\t%s\n", result.stemcell, result.status)
+		if result.failed {
+			failed++
+		}
+	}
+	_ = table.Flush()
+	us.logger.Printf("%s", summary.String())
+
+	if failed > 0 {
+		return fmt.Errorf("failed to upload %d of %d stemcells", failed, len(stemcells))
+	}
+
+	return nil
+}
+
+func (us UploadStemcell) uploadOneOfStemcells(stemcellFilename string) stemcellUploadResult {
+	name := filepath.Base(stemcellFilename)
+	result := stemcellUploadResult{stemcell: name}
+	fail := func(err error) stemcellUploadResult {
+		us.logger.Printf("[%s] failed to upload stemcell: %s", name, err)
+		result.status = "failed: " + err.Error()
+		result.failed = true
+		return result
+	}
+
+	if !us.Options.Force {
+		found, err := us.service.CheckStemcellAvailability(stemcellFilename)
+		if err != nil {
+			return fail(err)
+		}
+
+		if found {
+			us.logger.Printf("[%s] stemcell has already been uploaded", name)
+			result.status = "skipped: already uploaded"
+			return result
+		}
+	}
+
+	stemcellFilename, cleanup, err := prefixlessStemcellFilename(stemcellFilename)
+	if err != nil {
+		return fail(err)
+	}
+	defer cleanup()
+
+	for i := 0; i <= maxStemcellUploadRetries; i++ {
+		form := formcontent.NewForm()
+		err = form.AddFile("stemcell[file]", stemcellFilename)
+		if err != nil {
+			return fail(err)
+		}
+
+		err = form.AddField("stemcell[floating]", us.Options.Floating)
+		if err != nil {
+			return fail(err) // not tested
+		}
+
+		submission := form.Finalize()
+
+		us.logger.Printf("[%s] beginning stemcell upload to Ops Manager", name)
+		_, err = us.service.UploadStemcell(api.StemcellUploadInput{
+			Stemcell: &stemcellProgressReader{
+				reader: submission.Content,
+				total:  submission.ContentLength,
+				report: func(percent int64) { us.logger.Printf("[%s] %d%% uploaded", name, percent) },
+			},
+			ContentType:   submission.ContentType,
+			ContentLength: submission.ContentLength,
+		})
+		if err == nil {
+			break
+		}
+
+		form.Reset()
+		if i < maxStemcellUploadRetries {
+			us.logger.Printf("[%s] retrying stemcell upload after error: %s", name, err)
+		}
+	}
+	if err != nil {
+		return fail(err)
+	}
+
+	us.logger.Printf("[%s] finished upload", name)
+	result.status = "uploaded"
+
+	return result
+}
+
+// stemcellProgressReader reports every quarter of an upload, which stays
+// readable when several stemcells are uploaded at the same time.
+type stemcellProgressReader struct {
+	reader   io.Reader
+	total    int64
+	read     int64
+	reported int64
+	report   func(percent int64)
+}
+
+func (r *stemcellProgressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+
+	for r.total > 0 && r.reported < 100 && r.read*100 >= (r.reported+25)*r.total {
+		r.reported += 25
+		r.report(r.reported)
+	}
+
+	return n, err
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/jessevdk/go-flags"
 
@@ -406,6 +408,113 @@ var _ = Describe("UploadStemcell", func() {
 		})
 	})
 
+	When("several stemcells are provided", func() {
+		var (
+			dir      string
+			uploaded map[string]string
+			lock     sync.Mutex
+		)
+
+		logLines := func() []string {
+			var lines []string
+			for i := 0; i < logger.PrintfCallCount(); i++ {
+				format, v := logger.PrintfArgsForCall(i)
+				lines = append(lines, fmt.Sprintf(format, v...))
+			}
+			return lines
+		}
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			for _, name := range []string{"light-jammy-1.10.tgz", "light-jammy-1.12.tgz", "light-xenial-621.1.tgz"} {
+				Expect(os.WriteFile(filepath.Join(dir, name), []byte("contents of "+name), 0600)).To(Succeed())
+			}
+			Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a stemcell"), 0600)).To(Succeed())
+
+			uploaded = map[string]string{}
+			fakeService.CheckStemcellAvailabilityStub = func(path string) (bool, error) {
+				return filepath.Base(path) == "light-jammy-1.10.tgz", nil
+			}
+			fakeService.UploadStemcellStub = func(input api.StemcellUploadInput) (api.StemcellUploadOutput, error) {
+				contents, err := io.ReadAll(input.Stemcell)
+				Expect(err).ToNot(HaveOccurred())
+
+				lock.Lock()
+				defer lock.Unlock()
+				for _, name := range []string{"light-jammy-1.12.tgz", "light-xenial-621.1.tgz"} {
+					if strings.Contains(string(contents), "contents of "+name) {
+						uploaded[name] = input.ContentType
+					}
+				}
+				return api.StemcellUploadOutput{}, nil
+			}
+		})
+
+		It("uploads the stemcells of a directory at the same time and prints a summary", func() {
+			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			err := executeCommand(command, []string{"--stemcell", dir, "--parallel", "3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(multipart.AddFileCallCount()).To(Equal(0))
+			Expect(fakeService.UploadStemcellCallCount()).To(Equal(2))
+			Expect(uploaded).To(HaveKeyWithValue("light-jammy-1.12.tgz", ContainSubstring("multipart/form-data")))
+			Expect(uploaded).To(HaveKey("light-xenial-621.1.tgz"))
+
+			lines := logLines()
+			Expect(lines[0]).To(Equal("uploading 3 stemcells, 3 at a time"))
+			Expect(lines).To(ContainElements(
+				"[light-jammy-1.10.tgz] stemcell has already been uploaded",
+				"[light-jammy-1.12.tgz] beginning stemcell upload to Ops Manager",
+				"[light-jammy-1.12.tgz] 100% uploaded",
+				"[light-xenial-621.1.tgz] finished upload",
+			))
+			Expect(lines[len(lines)-1]).To(Equal(`STEMCELL                STATUS
+light-jammy-1.10.tgz    skipped: already uploaded
+light-jammy-1.12.tgz    uploaded
+light-xenial-621.1.tgz  uploaded
+`))
+		})
+
+		It("reports the stemcells that failed to upload", func() {
+			fakeService.CheckStemcellAvailabilityStub = nil
+			fakeService.UploadStemcellStub = func(input api.StemcellUploadInput) (api.StemcellUploadOutput, error) {
+				contents, _ := io.ReadAll(input.Stemcell)
+				if strings.Contains(string(contents), "contents of light-xenial-621.1.tgz") {
+					return api.StemcellUploadOutput{}, errors.New("some stemcell error")
+				}
+				return api.StemcellUploadOutput{}, nil
+			}
+
+			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			err := executeCommand(command, []string{
+				"--stemcell", filepath.Join(dir, "light-jammy-1.12.tgz"),
+				"--stemcell", filepath.Join(dir, "light-xenial-621.1.tgz"),
+				"--floating", "false",
+			})
+			Expect(err).To(MatchError("failed to upload 1 of 2 stemcells"))
+
+			// the failed upload is retried
+			Expect(fakeService.UploadStemcellCallCount()).To(Equal(4))
+
+			lines := logLines()
+			Expect(lines).To(ContainElement("[light-xenial-621.1.tgz] retrying stemcell upload after error: some stemcell error"))
+			Expect(lines[len(lines)-1]).To(ContainSubstring("light-xenial-621.1.tgz  failed: some stemcell error"))
+		})
+
+		It("does not allow --shasum", func() {
+			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			err := executeCommand(command, []string{"--stemcell", dir, "--shasum", "some-shasum"})
+			Expect(err).To(MatchError("--shasum can only be used when uploading a single stemcell"))
+			Expect(fakeService.UploadStemcellCallCount()).To(Equal(0))
+		})
+
+		It("returns an error for a directory without stemcells", func() {
+			command := commands.NewUploadStemcell(multipart, fakeService, logger)
+			err := executeCommand(command, []string{"--stemcell", GinkgoT().TempDir()})
+			Expect(err).To(MatchError(ContainSubstring("no stemcells (*.tgz) found in directory")))
+		})
+	})
+
 	When("the file cannot be opened", func() {
 		It("returns an error", func() {
 			fakeService.InfoReturns(api.Info{Version: "2.2-build.1"}, nil)
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pivotal-cf/om/api"
+	"gopkg.in/yaml.v2"
+)
+
+type Verifiers struct {
+	service verifiersService
+	logger  logger
+	Options struct {
+		ProductNames []string `long:"product-name" short:"n" description:"only list the verifiers of this staged product, instead of the director and every staged product. Can be repeated"`
+		Format       string   `long:"format"       short:"f" default:"yaml" choice:"yaml" choice:"json" description:"Format to print as"`
+	}
+}
+
+// verifiersConfig maps each verifier type to whether it is enabled. It is
+// both what the verifiers command prints and what configure-verifiers reads.
+type verifiersConfig struct {
+	Director map[string]bool            `yaml:"director,omitempty" json:"director,omitempty"`
+	Products map[string]map[string]bool `yaml:"products,omitempty" json:"products,omitempty"`
+}
+
+//counterfeiter:generate -o ./fakes/verifiers_service.go --fake-name VerifiersService . verifiersService
+type verifiersService interface {
+	ListDirectorVerifiers() ([]api.Verifier, error)
+	ListProductVerifiers(productName string) ([]api.Verifier, string, error)
+	ListStagedProducts() (api.StagedProductsOutput, error)
+}
+
+func NewVerifiers(service verifiersService, logger logger) *Verifiers {
+	return &Verifiers{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (v Verifiers) Execute(args []string) error {
+	var config verifiersConfig
+
+	productNames := v.Options.ProductNames
+	if len(productNames) == 0 {
+		directorVerifiers, err := v.service.ListDirectorVerifiers()
+		if err != nil {
+			return fmt.Errorf("could not get the director verifiers: %s", err)
+		}
+		config.Director = verifierStates(directorVerifiers)
+
+		stagedProducts, err := v.service.ListStagedProducts()
+		if err != nil {
+			return fmt.Errorf("could not list the staged products: %s", err)
+		}
+
+		for _, product := range stagedProducts.Products {
+			if product.Type != "p-bosh" {
+				productNames = append(productNames, product.Type)
+			}
+		}
+	}
+
+	for _, productName := range productNames {
+		productVerifiers, _, err := v.service.ListProductVerifiers(productName)
+		if err != nil {
+			return fmt.Errorf("could not get the verifiers of %s: %s", productName, err)
+		}
+
+		if config.Products == nil {
+			config.Products = map[string]map[string]bool{}
+		}
+		config.Products[productName] = verifierStates(productVerifiers)
+	}
+
+	var (
+		contents []byte
+		err      error
+	)
+	if v.Options.Format == "json" {
+		contents, err = json.MarshalIndent(config, "", "  ")
+	} else {
+		contents, err = yaml.Marshal(config)
+	}
+	if err != nil {
+		return err // not tested
+	}
+
+	v.logger.Println(string(contents))
+
+	return nil
+}
+
+func verifierStates(verifiers []api.Verifier) map[string]bool {
+	states := map[string]bool{}
+	for _, verifier := range verifiers {
+		states[verifier.Type] = verifier.Enabled
+	}
+
+	return states
+}
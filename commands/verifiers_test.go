@@ -0,0 +1,69 @@
+package commands_test
+
+import (
+	"errors"
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/commands"
+	"github.com/pivotal-cf/om/commands/fakes"
+)
+
+var _ = Describe("Verifiers", func() {
+	var (
+		service *fakes.VerifiersService
+		stdout  *gbytes.Buffer
+		command *commands.Verifiers
+	)
+
+	BeforeEach(func() {
+		service = &fakes.VerifiersService{}
+		stdout = gbytes.NewBuffer()
+		command = commands.NewVerifiers(service, log.New(stdout, "", 0))
+
+		service.ListDirectorVerifiersReturns([]api.Verifier{
+			{Type: "DirectorVerifier", Enabled: true},
+		}, nil)
+		service.ListStagedProductsReturns(api.StagedProductsOutput{Products: []api.StagedProduct{
+			{Type: "p-bosh", GUID: "p-bosh-guid"},
+			{Type: "cf", GUID: "cf-guid"},
+		}}, nil)
+		service.ListProductVerifiersReturns([]api.Verifier{
+			{Type: "ProductVerifier", Enabled: false},
+		}, "cf-guid", nil)
+	})
+
+	It("lists the verifiers of the director and every staged product", func() {
+		err := executeCommand(command, []string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.ListProductVerifiersCallCount()).To(Equal(1))
+		Expect(service.ListProductVerifiersArgsForCall(0)).To(Equal("cf"))
+		Expect(stdout.Contents()).To(MatchYAML(`
+director:
+  DirectorVerifier: true
+products:
+  cf:
+    ProductVerifier: false
+`))
+	})
+
+	It("only lists the verifiers of the given products", func() {
+		err := executeCommand(command, []string{"--product-name", "cf", "--format", "json"})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(service.ListDirectorVerifiersCallCount()).To(Equal(0))
+		Expect(stdout.Contents()).To(MatchJSON(`{"products": {"cf": {"ProductVerifier": false}}}`))
+	})
+
+	It("returns an error when the verifiers of a product cannot be listed", func() {
+		service.ListProductVerifiersReturns(nil, "", errors.New("some error"))
+
+		err := executeCommand(command, []string{})
+		Expect(err).To(MatchError("could not get the verifiers of cf: some error"))
+	})
+})
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pivotal-cf/om/validator"
+)
+
+type VerifyDownloads struct {
+	logger  logger
+	Options struct {
+		Plan              string `long:"plan"               short:"p" required:"true" description:"path to the download-plan.json written by download-product --plan-only"`
+		Directory         string `long:"directory"          short:"d"                 description:"directory holding the downloaded files. Defaults to the directory of the plan"`
+		StemcellDirectory string `long:"stemcell-directory"                           description:"directory holding the downloaded stemcells. Defaults to --directory"`
+	}
+}
+
+func NewVerifyDownloads(logger logger) *VerifyDownloads {
+	return &VerifyDownloads{
+		logger: logger,
+	}
+}
+
+func (v VerifyDownloads) Execute(args []string) error {
+	plan, err := readDownloadPlan(v.Options.Plan)
+	if err != nil {
+		return err
+	}
+
+	directory := v.Options.Directory
+	if directory == "" {
+		directory = filepath.Dir(v.Options.Plan)
+	}
+
+	stemcellDirectory := v.Options.StemcellDirectory
+	if stemcellDirectory == "" {
+		stemcellDirectory = directory
+	}
+
+	var (
+		summary strings.Builder
+		total   int
+		failed  int
+	)
+	table := tabwriter.NewWriter(&summary, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "PRODUCT\tFILE\tSTATUS")
+	for _, product := range plan.Products {
+		for _, file := range product.Files {
+			path := filepath.Join(directory, file.Name)
+			if file.Stemcell {
+				path = filepath.Join(stemcellDirectory, file.Name)
+			}
+
+			status, ok := v.verify(path, file)
+			if !ok {
+				failed++
+			}
+			total++
+
+			fmt.Fprintf(table, "%s %s\t%s\t%s\n", product.Slug, product.Version, file.Name, status)
+		}
+	}
+	_ = table.Flush()
+	v.logger.Printf("%s", summary.String())
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d files do not match the download plan", failed, total)
+	}
+
+	return nil
+}
+
+// verify compares the file with its size and sha in the plan, when the
+// source published them.
+func (v VerifyDownloads) verify(path string, file downloadPlanFile) (string, bool) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "missing", false
+	}
+	if err != nil {
+		return fmt.Sprintf("could not read the file: %s", err), false
+	}
+
+	if file.Size != 0 && info.Size() != file.Size {
+		return fmt.Sprintf("size is %d bytes, expected %d", info.Size(), file.Size), false
+	}
+
+	calculatedSum, err := validator.NewSHA256Calculator().Checksum(path)
+	if err != nil {
+		return fmt.Sprintf("could not calculate the sha: %s", err), false
+	}
+
+	if file.SHA256 == "" {
+		return fmt.Sprintf("ok, the source published no sha, calculated %s", calculatedSum), true
+	}
+
+	if calculatedSum != file.SHA256 {
+		return fmt.Sprintf("sha is %s, expected %s", calculatedSum, file.SHA256), false
+	}
+
+	return "ok", true
+}
@@ -0,0 +1,91 @@
+package commands_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/commands"
+)
+
+var _ = Describe("VerifyDownloads", func() {
+	var (
+		stdout   *gbytes.Buffer
+		command  *commands.VerifyDownloads
+		dir      string
+		planPath string
+	)
+
+	sha := func(contents string) string {
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(contents)))
+	}
+
+	BeforeEach(func() {
+		stdout = gbytes.NewBuffer()
+		command = commands.NewVerifyDownloads(log.New(stdout, "", 0))
+
+		dir = GinkgoT().TempDir()
+		planPath = filepath.Join(dir, "download-plan.json")
+		Expect(os.WriteFile(planPath, []byte(fmt.Sprintf(`{"products": [{
+			"slug": "elastic-runtime",
+			"version": "2.0.0",
+			"source": "pivnet",
+			"files": [
+				{"name": "cf.pivotal", "glob": "*.pivotal", "sha256": %q, "size": 4},
+				{"name": "stemcell.tgz", "glob": "light*bosh*google*", "sha256": %q, "stemcell": true}
+			]
+		}]}`, sha("tile"), sha("stemcell"))), 0644)).To(Succeed())
+	})
+
+	It("succeeds when the files match the plan", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "cf.pivotal"), []byte("tile"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "stemcell.tgz"), []byte("stemcell"), 0644)).To(Succeed())
+
+		err := executeCommand(command, []string{"--plan", planPath})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(string(stdout.Contents())).To(MatchRegexp(`elastic-runtime 2.0.0\s+cf.pivotal\s+ok\n`))
+		Expect(string(stdout.Contents())).To(MatchRegexp(`elastic-runtime 2.0.0\s+stemcell.tgz\s+ok\n`))
+	})
+
+	It("reports the files that are missing or do not match", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "cf.pivotal"), []byte("tiles"), 0644)).To(Succeed())
+
+		err := executeCommand(command, []string{"--plan", planPath})
+		Expect(err).To(MatchError("2 of 2 files do not match the download plan"))
+
+		Expect(string(stdout.Contents())).To(ContainSubstring("size is 5 bytes, expected 4"))
+		Expect(string(stdout.Contents())).To(MatchRegexp(`stemcell.tgz\s+missing\n`))
+	})
+
+	It("compares the sha of the files", func() {
+		Expect(os.WriteFile(filepath.Join(dir, "cf.pivotal"), []byte("tilt"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "stemcell.tgz"), []byte("stemcell"), 0644)).To(Succeed())
+
+		err := executeCommand(command, []string{"--plan", planPath})
+		Expect(err).To(MatchError("1 of 2 files do not match the download plan"))
+
+		Expect(string(stdout.Contents())).To(ContainSubstring(fmt.Sprintf("sha is %s, expected %s", sha("tilt"), sha("tile"))))
+	})
+
+	It("looks for the files in the given directories", func() {
+		files := GinkgoT().TempDir()
+		stemcells := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(files, "cf.pivotal"), []byte("tile"), 0644)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(stemcells, "stemcell.tgz"), []byte("stemcell"), 0644)).To(Succeed())
+
+		err := executeCommand(command, []string{"--plan", planPath, "--directory", files, "--stemcell-directory", stemcells})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns an error when the plan cannot be read", func() {
+		err := executeCommand(command, []string{"--plan", filepath.Join(dir, "missing.json")})
+		Expect(err).To(MatchError(ContainSubstring("could not read download plan")))
+	})
+})
@@ -21,6 +21,13 @@ type VMExtensionConfig struct {
 		Name            string                 `yaml:"name"`
 		CloudProperties map[string]interface{} `yaml:"cloud_properties,omitempty"`
 	} `yaml:"vm-extension-config,omitempty"`
+
+	// VMExtensions is the complete set of vm extensions, the ones that are
+	// not listed are reported and can be pruned.
+	VMExtensions []struct {
+		Name            string                 `yaml:"name"`
+		CloudProperties map[string]interface{} `yaml:"cloud_properties,omitempty"`
+	} `yaml:"vm-extensions,omitempty"`
 }
 
 type ResourceConfig struct {
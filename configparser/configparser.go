@@ -2,9 +2,11 @@ package configparser
 
 import (
 	"fmt"
-	"github.com/pivotal-cf/om/api"
+	"path"
 	"strconv"
 	"strings"
+
+	"github.com/pivotal-cf/om/api"
 )
 
 type getCredential interface {
@@ -25,21 +27,49 @@ func NewPropertyName(prefix string) PropertyName {
 	}
 }
 
-func (n *PropertyName) placeholderName() string {
-	name := n.prefix
+// placeholderPath includes the index of collection items, e.g.
+// .properties.users.0.password, so each item has a placeholder of its own.
+func (n *PropertyName) placeholderPath() string {
 	if n.collectionName != "" {
-		name = n.prefix + "_" + strconv.Itoa(n.index) + "_" + n.collectionName
-	}
-
-	return strings.Replace(
-		strings.TrimLeft(
-			name,
-			".",
-		),
-		".",
-		"_",
-		-1,
-	)
+		return n.prefix + "." + strconv.Itoa(n.index) + "." + n.collectionName
+	}
+
+	return n.prefix
+}
+
+// PlaceholderNames names the placeholders that stand in for credentials.
+// The zero value names them after their path, e.g. properties_some_secret
+// for .properties.some_secret.
+type PlaceholderNames struct {
+	// Prefix is prepended to every name that is not mapped.
+	Prefix string
+	// Separator joins the segments of the path, "_" when empty.
+	Separator string
+	// Mapping names the placeholders of paths, without the prefix.
+	Mapping map[string]string
+}
+
+func (n PlaceholderNames) Name(path string) string {
+	if name, ok := n.Mapping[path]; ok {
+		return name
+	}
+
+	separator := n.Separator
+	if separator == "" {
+		separator = "_"
+	}
+
+	return n.Prefix + strings.ReplaceAll(strings.TrimLeft(path, "."), ".", separator)
+}
+
+// Path is the name of the property, followed by the name of the field for
+// the fields of collection items, e.g. .properties.users.password.
+func (n PropertyName) Path() string {
+	if n.collectionName != "" {
+		return n.prefix + "." + n.collectionName
+	}
+
+	return n.prefix
 }
 
 type configParser struct{}
@@ -100,6 +130,47 @@ func (p *configParser) handleCollection(name PropertyName, property api.Response
 	return nil, nil
 }
 
+// NewFilterHandler leaves out the credentials it is not given by
+// handler. A filter starting with a "." matches the Path of the property
+// as a glob, any other filter matches the credential type. Credentials match
+// when they match one of include, or when include is empty, and none of
+// exclude.
+func NewFilterHandler(include, exclude []string, handler CredentialHandler) (CredentialHandler, error) {
+	for _, filter := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(filter, ""); err != nil {
+			return nil, fmt.Errorf("invalid credential filter %q: %w", filter, err)
+		}
+	}
+
+	matches := func(filters []string, name PropertyName, property api.ResponseProperty) bool {
+		for _, filter := range filters {
+			if !strings.HasPrefix(filter, ".") {
+				if filter == property.Type {
+					return true
+				}
+				continue
+			}
+
+			if ok, _ := path.Match(filter, name.Path()); ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return func(name PropertyName, property api.ResponseProperty) (map[string]interface{}, error) {
+		if len(include) > 0 && !matches(include, name, property) {
+			return nil, nil
+		}
+		if matches(exclude, name, property) {
+			return nil, nil
+		}
+
+		return handler(name, property)
+	}, nil
+}
+
 func NewNilHandler() CredentialHandler {
 	return func(name PropertyName, property api.ResponseProperty) (map[string]interface{}, error) {
 		return nil, nil
@@ -107,44 +178,49 @@ func NewNilHandler() CredentialHandler {
 }
 
 func NewPlaceholderHandler() CredentialHandler {
-	var output map[string]interface{}
+	return NewNamedPlaceholderHandler(PlaceholderNames{})
+}
 
+func NewNamedPlaceholderHandler(names PlaceholderNames) CredentialHandler {
 	return func(name PropertyName, property api.ResponseProperty) (map[string]interface{}, error) {
+		var output map[string]interface{}
+		placeholderName := names.Name(name.placeholderPath())
+
 		switch property.Type {
 
 		case "secret":
 			output = map[string]interface{}{
 				"value": map[string]string{
-					"secret": fmt.Sprintf("((%s.secret))", name.placeholderName()),
+					"secret": fmt.Sprintf("((%s.secret))", placeholderName),
 				},
 			}
 		case "simple_credentials":
 			output = map[string]interface{}{
 				"value": map[string]string{
-					"identity": fmt.Sprintf("((%s.identity))", name.placeholderName()),
-					"password": fmt.Sprintf("((%s.password))", name.placeholderName()),
+					"identity": fmt.Sprintf("((%s.identity))", placeholderName),
+					"password": fmt.Sprintf("((%s.password))", placeholderName),
 				},
 			}
 		case "rsa_cert_credentials":
 			output = map[string]interface{}{
 				"value": map[string]string{
-					"cert_pem":        fmt.Sprintf("((%s.cert_pem))", name.placeholderName()),
-					"private_key_pem": fmt.Sprintf("((%s.private_key_pem))", name.placeholderName()),
+					"cert_pem":        fmt.Sprintf("((%s.cert_pem))", placeholderName),
+					"private_key_pem": fmt.Sprintf("((%s.private_key_pem))", placeholderName),
 				},
 			}
 		case "rsa_pkey_credentials":
 			output = map[string]interface{}{
 				"value": map[string]string{
-					"public_key_pem":  fmt.Sprintf("((%s.public_key_pem))", name.placeholderName()),
-					"private_key_pem": fmt.Sprintf("((%s.private_key_pem))", name.placeholderName()),
+					"public_key_pem":  fmt.Sprintf("((%s.public_key_pem))", placeholderName),
+					"private_key_pem": fmt.Sprintf("((%s.private_key_pem))", placeholderName),
 				},
 			}
 		case "salted_credentials":
 			output = map[string]interface{}{
 				"value": map[string]string{
-					"identity": fmt.Sprintf("((%s.identity))", name.placeholderName()),
-					"password": fmt.Sprintf("((%s.password))", name.placeholderName()),
-					"salt":     fmt.Sprintf("((%s.salt))", name.placeholderName()),
+					"identity": fmt.Sprintf("((%s.identity))", placeholderName),
+					"password": fmt.Sprintf("((%s.password))", placeholderName),
+					"salt":     fmt.Sprintf("((%s.salt))", placeholderName),
 				},
 			}
 		}
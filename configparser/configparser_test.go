@@ -234,4 +234,79 @@ var _ = Describe("Config Parser", func() {
 
 		})
 	})
+
+	Context("given named placeholder handler", func() {
+		It("names the placeholders with the prefix, separator and mapping", func() {
+			output, err := getOutput(configparser.NewNamedPlaceholderHandler(configparser.PlaceholderNames{
+				Prefix:    "/cf/",
+				Separator: "-",
+				Mapping: map[string]string{
+					".properties.simple-credentials":       "/shared/admin",
+					".properties.collection.0.certificate": "/shared/cert",
+				},
+			}))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(output).To(ContainSubstring("((/cf/properties-some-secret-property.secret))"))
+			Expect(output).To(ContainSubstring("((/shared/admin.identity))"))
+			Expect(output).To(ContainSubstring("((/shared/cert.cert_pem))"))
+			Expect(output).To(ContainSubstring("((/cf/properties-collection-1-certificate2.cert_pem))"))
+		})
+	})
+
+	Context("given filter handler", func() {
+		It("only hands the matching credentials to the handler", func() {
+			handler, err := configparser.NewFilterHandler(
+				[]string{".properties.collection.*", "simple_credentials", "secret"},
+				[]string{".properties.collection.certificate2"},
+				configparser.NewPlaceholderHandler(),
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			output, err := getOutput(handler)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(output).To(MatchYAML(`---
+.properties.collection:
+  value:
+  - name: Certificate
+    certificate:
+      cert_pem: ((properties_collection_0_certificate.cert_pem))
+      private_key_pem: ((properties_collection_0_certificate.private_key_pem))
+.properties.some-secret-property:
+  value:
+    secret: ((properties_some-secret-property.secret))
+.properties.simple-credentials:
+  value:
+    identity: ((properties_simple-credentials.identity))
+    password: ((properties_simple-credentials.password))
+.properties.some-string-property:
+  value: some-value
+.properties.some-selector:
+  value: internal
+.properties.some-selector.not-internal.some-string-property:
+  value: some-value
+.properties.some-selector-with-selected-value:
+  selected_option: beginner
+  value: Hello World
+`))
+		})
+
+		It("hands all the credentials but the excluded ones to the handler without includes", func() {
+			handler, err := configparser.NewFilterHandler(nil, []string{".properties.collection*", "rsa_cert_credentials", "salted_credentials"}, configparser.NewPlaceholderHandler())
+			Expect(err).ToNot(HaveOccurred())
+
+			output, err := getOutput(handler)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(ContainSubstring("properties_some-secret-property.secret"))
+			Expect(output).To(ContainSubstring("properties_simple-credentials.password"))
+			Expect(output).ToNot(ContainSubstring("cert_pem"))
+			Expect(output).ToNot(ContainSubstring("salt"))
+		})
+
+		It("returns an error for an invalid glob", func() {
+			_, err := configparser.NewFilterHandler([]string{".properties.[a"}, nil, configparser.NewNilHandler())
+			Expect(err).To(MatchError(ContainSubstring(`invalid credential filter ".properties.[a"`)))
+		})
+	})
 })
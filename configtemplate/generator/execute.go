@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -16,6 +17,8 @@ type Executor struct {
 	includeErrands             bool
 	sizeOfCollections          int
 	userSetSizeOfCollections   bool
+	stagedConfig               []byte
+	jsonSchema                 bool
 }
 
 func NewExecutor(metadataBytes []byte, baseDirectory string, doNotIncludeProductVersion, includeErrands bool, sizeOfCollections int, userSetSizeOfCollections bool) *Executor {
@@ -79,6 +82,22 @@ func (e *Executor) Generate() error {
 		return err
 	}
 
+	if e.jsonSchema {
+		schema, err := CreateJSONSchema(metadata)
+		if err != nil {
+			return err
+		}
+
+		contents, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err = os.WriteFile(path.Join(targetDirectory, "product.schema.json"), append(contents, '\n'), 0755); err != nil {
+			return err
+		}
+	}
+
 	networkOpsFiles, err := CreateNetworkOpsFiles(metadata)
 	if err != nil {
 		return err
@@ -164,6 +183,10 @@ func (e *Executor) Generate() error {
 		}
 	}
 
+	if e.stagedConfig != nil {
+		return e.seedVars(targetDirectory, template, productPropertyOpsFiles)
+	}
+
 	return nil
 }
 
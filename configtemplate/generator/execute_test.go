@@ -186,6 +186,67 @@ var _ = Describe("Executor", func() {
 			Expect(template.NetworkProperties).ToNot(BeNil())
 			Expect(template.ResourceConfig).ToNot(BeNil())
 		})
+
+		It("seeds the vars with a staged config", func() {
+			metadataBytes, err := getFileBytes("./fixtures/metadata/pks.yml")
+			Expect(err).ToNot(HaveOccurred())
+
+			gen := generator.NewExecutor(metadataBytes, tmpPath, true, true, 10, false).WithStagedConfig([]byte(`
+product-name: pivotal-container-service
+network-properties:
+  network:
+    name: live-network
+  other_availability_zones:
+  - name: az2
+  singleton_availability_zone:
+    name: az1
+product-properties:
+  .pivotal-container-service.pks_tls:
+    value:
+      cert_pem: ((pks_tls_cert))
+  .properties.pks_api_hostname:
+    value: api.pks.example.com
+  .properties.plan2_selector:
+    value: Plan Active
+    selected_option: active
+  .properties.plan2_selector.active.name:
+    value: medium
+resource-config:
+  pivotal-container-service:
+    instance_type:
+      id: large
+errand-config:
+  delete-all-clusters:
+    pre-delete-state: false
+`))
+			err = gen.Generate()
+			Expect(err).ToNot(HaveOccurred())
+
+			productPath := path.Join(tmpPath, "pivotal-container-service")
+			readVars := func(name string) map[string]interface{} {
+				contents, err := os.ReadFile(path.Join(productPath, name))
+				Expect(err).ToNot(HaveOccurred())
+
+				vars := map[string]interface{}{}
+				Expect(yaml.Unmarshal(contents, &vars)).To(Succeed())
+				return vars
+			}
+
+			requiredVars := readVars("required-vars.yml")
+			Expect(requiredVars).To(HaveKeyWithValue("network_name", "live-network"))
+			Expect(requiredVars).To(HaveKeyWithValue("singleton_availability_zone", "az1"))
+			Expect(requiredVars).To(HaveKeyWithValue("pks_api_hostname", "api.pks.example.com"))
+			Expect(requiredVars).To(HaveKeyWithValue("service_network_name", ""))
+			Expect(requiredVars).To(HaveKeyWithValue("pivotal-container-service_pks_tls_certificate", ""))
+
+			Expect(readVars("default-vars.yml")).To(HaveKeyWithValue("plan2_selector_active_name", "medium"))
+			Expect(readVars("resource-vars.yml")).To(HaveKeyWithValue("resource-var-pivotal-container-service_instance_type", "large"))
+			Expect(readVars("errand-vars.yml")).To(HaveKeyWithValue("delete-all-clusters_pre_delete_state", false))
+
+			contents, err := os.ReadFile(path.Join(productPath, "staged-features.yml"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`[features/plan2_selector-active.yml]`))
+		})
 	})
 })
 
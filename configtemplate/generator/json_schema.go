@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"fmt"
+)
+
+// WithJSONSchema also writes product.schema.json, see CreateJSONSchema.
+func (e *Executor) WithJSONSchema() *Executor {
+	e.jsonSchema = true
+	return e
+}
+
+// CreateJSONSchema describes the product configuration files accepted by
+// configure-product for the tile, so they can be validated without an
+// Ops Manager. Placeholders have to be interpolated before validating.
+func CreateJSONSchema(metadata *Metadata) (map[string]interface{}, error) {
+	productProperties := map[string]interface{}{}
+	for _, propertyInput := range metadata.PropertyInputs() {
+		propertyBlueprint, err := metadata.GetPropertyBlueprint(propertyInput.Reference)
+		if err != nil {
+			return nil, fmt.Errorf("could not create json schema: %s", err)
+		}
+
+		if !propertyBlueprint.IsConfigurable() {
+			continue
+		}
+
+		productProperties[propertyInput.Reference] = propertySchema(propertyBlueprint)
+
+		// the properties of every option of a selector can be configured,
+		// only the ones of the selected option are used
+		for _, optionTemplate := range propertyBlueprint.OptionTemplates {
+			for _, optionBlueprint := range optionTemplate.PropertyBlueprints {
+				if !optionBlueprint.IsConfigurable() {
+					continue
+				}
+
+				reference := fmt.Sprintf("%s.%s.%s", propertyInput.Reference, optionTemplate.Name, optionBlueprint.Name)
+				productProperties[reference] = propertySchema(&optionBlueprint)
+			}
+		}
+	}
+
+	resourceConfig := map[string]interface{}{}
+	for _, job := range metadata.JobTypes {
+		if job.IsIncluded() {
+			resourceConfig[job.Name] = map[string]interface{}{"type": "object"}
+		}
+	}
+
+	errandState := map[string]interface{}{"type": []string{"boolean", "string"}}
+	errandConfig := map[string]interface{}{}
+	for _, errand := range metadata.Errands() {
+		errandConfig[errand.Name] = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"post-deploy-state": errandState,
+				"pre-delete-state":  errandState,
+			},
+			"additionalProperties": false,
+		}
+	}
+
+	return map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"title":   fmt.Sprintf("%s %s product configuration", metadata.ProductName(), metadata.ProductVersion()),
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"product-name": map[string]interface{}{"const": metadata.ProductName()},
+			"product-properties": map[string]interface{}{
+				"type":                 "object",
+				"properties":           productProperties,
+				"additionalProperties": false,
+			},
+			"network-properties": map[string]interface{}{"type": "object"},
+			"resource-config": map[string]interface{}{
+				"type":                 "object",
+				"properties":           resourceConfig,
+				"additionalProperties": false,
+			},
+			"errand-config": map[string]interface{}{
+				"type":                 "object",
+				"properties":           errandConfig,
+				"additionalProperties": false,
+			},
+			"syslog-properties":        map[string]interface{}{"type": "object"},
+			"validate-config-complete": map[string]interface{}{"type": "boolean"},
+			"validate-properties":      map[string]interface{}{"type": "boolean"},
+		},
+		"additionalProperties": false,
+	}, nil
+}
+
+func propertySchema(propertyBlueprint *PropertyBlueprint) map[string]interface{} {
+	properties := map[string]interface{}{
+		"value": valueSchema(propertyBlueprint),
+	}
+
+	if propertyBlueprint.IsSelector() {
+		var selectedOptions []interface{}
+		for _, optionTemplate := range propertyBlueprint.OptionTemplates {
+			selectedOptions = append(selectedOptions, optionTemplate.Name)
+		}
+
+		properties["selected_option"] = map[string]interface{}{"enum": selectedOptions}
+	}
+
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+func valueSchema(propertyBlueprint *PropertyBlueprint) map[string]interface{} {
+	switch {
+	case propertyBlueprint.IsSelector():
+		var selectValues []interface{}
+		for _, optionTemplate := range propertyBlueprint.OptionTemplates {
+			selectValues = append(selectValues, optionTemplate.SelectValue)
+		}
+
+		return map[string]interface{}{"enum": selectValues}
+	case propertyBlueprint.IsMultiSelect():
+		return map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"enum": optionNames(propertyBlueprint)},
+		}
+	case propertyBlueprint.Type == "dropdown_select":
+		return map[string]interface{}{"enum": optionNames(propertyBlueprint)}
+	case propertyBlueprint.IsAZList():
+		return map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		}
+	case propertyBlueprint.IsCollection():
+		itemProperties := map[string]interface{}{}
+		for _, subPropertyBlueprint := range propertyBlueprint.PropertyBlueprints {
+			itemProperties[subPropertyBlueprint.Name] = valueSchema(&subPropertyBlueprint)
+		}
+
+		return map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type":       "object",
+				"properties": itemProperties,
+			},
+		}
+	case propertyBlueprint.IsSecret():
+		return objectSchema("secret")
+	case propertyBlueprint.IsSimpleCredentials(), propertyBlueprint.Type == "salted_credentials":
+		return objectSchema("identity", "password")
+	case propertyBlueprint.IsCertificate():
+		return objectSchema("cert_pem", "private_key_pem")
+	case propertyBlueprint.Type == "rsa_pkey_credentials":
+		return objectSchema("private_key_pem")
+	case propertyBlueprint.IsBool():
+		return map[string]interface{}{"type": "boolean"}
+	case propertyBlueprint.IsInt():
+		return map[string]interface{}{"type": "integer"}
+	case propertyBlueprint.IsString():
+		return map[string]interface{}{"type": "string"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func optionNames(propertyBlueprint *PropertyBlueprint) []interface{} {
+	var names []interface{}
+	for _, option := range propertyBlueprint.Options {
+		names = append(names, option.Name)
+	}
+
+	return names
+}
+
+func objectSchema(fields ...string) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for _, field := range fields {
+		properties[field] = map[string]interface{}{"type": "string"}
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
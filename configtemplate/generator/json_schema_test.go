@@ -0,0 +1,122 @@
+package generator_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/configtemplate/generator"
+)
+
+var _ = Describe("JSON Schema", func() {
+	schemaFor := func(fixture string) map[string]interface{} {
+		metadataBytes, err := getFileBytes(fixture)
+		Expect(err).ToNot(HaveOccurred())
+
+		metadata, err := generator.NewMetadata(metadataBytes)
+		Expect(err).ToNot(HaveOccurred())
+
+		schema, err := generator.CreateJSONSchema(metadata)
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, err := json.Marshal(schema)
+		Expect(err).ToNot(HaveOccurred())
+
+		var generic map[string]interface{}
+		Expect(json.Unmarshal(contents, &generic)).To(Succeed())
+		return generic
+	}
+
+	productProperties := func(schema map[string]interface{}) map[string]interface{} {
+		properties := schema["properties"].(map[string]interface{})
+		return properties["product-properties"].(map[string]interface{})
+	}
+
+	It("describes the product properties", func() {
+		schema := schemaFor("./fixtures/metadata/pks.yml")
+		Expect(schema).To(HaveKeyWithValue("title", "pivotal-container-service 1.1.3-build.11 product configuration"))
+		Expect(schema["properties"]).To(HaveKeyWithValue("product-name", map[string]interface{}{"const": "pivotal-container-service"}))
+
+		properties := productProperties(schema)
+		Expect(properties).To(HaveKeyWithValue("additionalProperties", false))
+
+		propertySchemas := properties["properties"].(map[string]interface{})
+		Expect(propertySchemas[".properties.pks_api_hostname"]).To(Equal(map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value": map[string]interface{}{"type": "string"},
+			},
+		}))
+		Expect(propertySchemas[".properties.plan2_selector"]).To(Equal(map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value":           map[string]interface{}{"enum": []interface{}{"Plan Inactive", "Plan Active"}},
+				"selected_option": map[string]interface{}{"enum": []interface{}{"inactive", "active"}},
+			},
+		}))
+		Expect(propertySchemas).To(HaveKey(".properties.plan2_selector.active.name"))
+		Expect(propertySchemas[".pivotal-container-service.pks_tls"]).To(Equal(map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"value": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"cert_pem":        map[string]interface{}{"type": "string"},
+						"private_key_pem": map[string]interface{}{"type": "string"},
+					},
+					"additionalProperties": false,
+				},
+			},
+		}))
+	})
+
+	It("describes the items of collections", func() {
+		schema := schemaFor("./fixtures/metadata/iso-segment.yml")
+
+		propertySchemas := productProperties(schema)["properties"].(map[string]interface{})
+		Expect(propertySchemas[".properties.networking_poe_ssl_certs"]).To(HaveKeyWithValue("properties", HaveKeyWithValue("value", map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string"},
+					"certificate": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"cert_pem":        map[string]interface{}{"type": "string"},
+							"private_key_pem": map[string]interface{}{"type": "string"},
+						},
+						"additionalProperties": false,
+					},
+				},
+			},
+		})))
+	})
+
+	It("writes product.schema.json for all the fixtures", func() {
+		tmpPath := GinkgoT().TempDir()
+
+		fixtures, err := filepath.Glob("./fixtures/metadata/*.yml")
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, fixtureFilename := range fixtures {
+			metadataBytes, err := getFileBytes(fixtureFilename)
+			Expect(err).ToNot(HaveOccurred())
+
+			metadata, err := generator.NewMetadata(metadataBytes)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = generator.NewExecutor(metadataBytes, tmpPath, false, true, 10, false).WithJSONSchema().Generate()
+			Expect(err).ToNot(HaveOccurred(), fmt.Sprintf("expected %s to be a valid fixture", fixtureFilename))
+
+			contents, err := os.ReadFile(path.Join(tmpPath, metadata.ProductName(), metadata.ProductVersion(), "product.schema.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(json.Valid(contents)).To(BeTrue())
+		}
+	})
+})
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var placeholderPattern = regexp.MustCompile(`^\(\(([^()]+)\)\)$`)
+
+// WithStagedConfig seeds the vars of the generated template with a product
+// configuration as printed by staged-config, so the template starts from
+// the configuration of a foundation instead of the defaults of the tile.
+func (e *Executor) WithStagedConfig(stagedConfig []byte) *Executor {
+	e.stagedConfig = stagedConfig
+	return e
+}
+
+// seedVars sets every var whose placeholder has a value at the same place
+// in the staged config. Vars of the features whose selector option is
+// selected in the staged config are seeded as well, and those features are
+// listed in staged-features.yml.
+func (e *Executor) seedVars(targetDirectory string, template *Template, featureOpsFiles map[string][]Ops) error {
+	var stagedConfig interface{}
+	err := yaml.Unmarshal(e.stagedConfig, &stagedConfig)
+	if err != nil {
+		return fmt.Errorf("could not parse staged config: %s", err)
+	}
+
+	generated, err := toGeneric(template)
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]interface{}{}
+	collectStagedVars(generated, stagedConfig, vars)
+
+	stagedFeatures := []string{}
+	for _, name := range sortedOpsFileNames(featureOpsFiles) {
+		ops := featureOpsFiles[name]
+		if !featureSelected(ops, stagedConfig) {
+			continue
+		}
+
+		stagedFeatures = append(stagedFeatures, path.Join("features", fmt.Sprintf("%s.yml", name)))
+		for _, op := range ops {
+			value, err := toGeneric(op.Value)
+			if err != nil {
+				return err
+			}
+
+			collectStagedVars(value, lookupOpsPath(stagedConfig, op.Path), vars)
+		}
+	}
+
+	varsFiles := []string{"default-vars.yml", "required-vars.yml", "resource-vars.yml", "errand-vars.yml"}
+	fileVars := map[string]map[string]interface{}{}
+	for _, name := range varsFiles {
+		filename := path.Join(targetDirectory, name)
+
+		contents, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+
+		fileVars[name] = map[string]interface{}{}
+		err = yaml.Unmarshal(contents, fileVars[name])
+		if err != nil {
+			return fmt.Errorf("could not parse %s: %s", filename, err)
+		}
+	}
+
+	for key, value := range vars {
+		// vars only used by the staged features are not in any file yet
+		file := "default-vars.yml"
+		for _, name := range varsFiles {
+			if _, ok := fileVars[name][key]; ok {
+				file = name
+				break
+			}
+		}
+
+		fileVars[file][key] = value
+	}
+
+	for _, name := range varsFiles {
+		if err = e.writeYamlFile(path.Join(targetDirectory, name), fileVars[name]); err != nil {
+			return err
+		}
+	}
+
+	return e.writeYamlFile(path.Join(targetDirectory, "staged-features.yml"), stagedFeatures)
+}
+
+// collectStagedVars walks the generated template and the staged config
+// together, recording the staged value of every placeholder.
+func collectStagedVars(generated, staged interface{}, vars map[string]interface{}) {
+	if staged == nil {
+		return
+	}
+
+	switch g := generated.(type) {
+	case string:
+		matches := placeholderPattern.FindStringSubmatch(g)
+		if matches != nil && !containsPlaceholder(staged) {
+			vars[matches[1]] = staged
+		}
+	case map[interface{}]interface{}:
+		stagedMap, ok := staged.(map[interface{}]interface{})
+		if !ok {
+			return
+		}
+
+		// the keys are walked in order, so a var used in several places is
+		// seeded the same way every time
+		var keys []string
+		for key := range g {
+			keys = append(keys, fmt.Sprint(key))
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			collectStagedVars(g[key], stagedMap[key], vars)
+		}
+	case []interface{}:
+		stagedList, ok := staged.([]interface{})
+		if !ok {
+			return
+		}
+
+		for i := 0; i < len(g) && i < len(stagedList); i++ {
+			collectStagedVars(g[i], stagedList[i], vars)
+		}
+	}
+}
+
+// featureSelected is true when the feature selects the option of a selector
+// that is selected in the staged config.
+func featureSelected(ops []Ops, stagedConfig interface{}) bool {
+	if len(ops) == 0 {
+		return false
+	}
+
+	selection, ok := ops[0].Value.(*OpsValue)
+	if !ok || selection.SelectedOption == "" {
+		return false
+	}
+
+	property, ok := lookupOpsPath(stagedConfig, ops[0].Path).(map[interface{}]interface{})
+	if !ok {
+		return false
+	}
+
+	selected := property["selected_option"]
+	if selected == nil {
+		selected = property["value"]
+	}
+
+	return fmt.Sprint(selected) == selection.SelectedOption
+}
+
+// lookupOpsPath returns the value at the path of an ops file operation,
+// e.g. /product-properties/.properties.some_selector?
+func lookupOpsPath(value interface{}, opsPath string) interface{} {
+	for _, key := range strings.Split(strings.Trim(opsPath, "/"), "/") {
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil
+		}
+
+		value = m[strings.TrimSuffix(key, "?")]
+	}
+
+	return value
+}
+
+func containsPlaceholder(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && placeholderPattern.MatchString(s)
+}
+
+func sortedOpsFileNames(opsFiles map[string][]Ops) []string {
+	var names []string
+	for name := range opsFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func toGeneric(value interface{}) (interface{}, error) {
+	contents, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	err = yaml.Unmarshal(contents, &generic)
+	return generic, err
+}
@@ -0,0 +1,435 @@
+package download_clients
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/progress"
+)
+
+const (
+	artifactorySlugPlaceholder    = "{slug}"
+	artifactoryVersionPlaceholder = "{version}"
+)
+
+type ArtifactoryConfiguration struct {
+	URL        string `validate:"required"`
+	Repository string `validate:"required"`
+	APIKey     string
+	Token      string
+	DisableSSL bool
+
+	// PathLayout is where the files of a product version live within the
+	// repository, e.g. "tiles/{slug}/{version}".
+	PathLayout string
+
+	// When VersionProperty is set, versions and files are found by searching
+	// for artifacts with these properties rather than by walking PathLayout.
+	SlugProperty    string
+	VersionProperty string
+}
+
+type artifactoryClient struct {
+	url             string
+	repository      string
+	apiKey          string
+	token           string
+	pathLayout      string
+	slugProperty    string
+	versionProperty string
+	httpClient      *http.Client
+	stderr          *log.Logger
+}
+
+// NewArtifactoryClient returns a downloader for files stored in a JFrog
+// Artifactory repository. By default a product version is a folder in the
+// repository, found by substituting the product slug and version into the
+// path layout. Alternatively, a version property can be given so that the
+// versions are discovered from properties set on the files themselves.
+// Stemcells are looked up the same way, using their Pivotal Network slug.
+func NewArtifactoryClient(config ArtifactoryConfiguration, stderr *log.Logger) (ProductDownloader, error) {
+	validate := validator.New()
+	err := validate.Struct(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.APIKey != "" && config.Token != "" {
+		return nil, fmt.Errorf("cannot use both an Artifactory API key and an access token; please choose one")
+	}
+
+	pathLayout := strings.Trim(config.PathLayout, "/")
+	if pathLayout == "" {
+		pathLayout = artifactorySlugPlaceholder + "/" + artifactoryVersionPlaceholder
+	}
+
+	if config.VersionProperty != "" {
+		if config.SlugProperty == "" {
+			return nil, fmt.Errorf("a slug property is required when discovering versions with the %q property", config.VersionProperty)
+		}
+	} else if !strings.Contains(pathLayout, artifactorySlugPlaceholder) || !slices.Contains(strings.Split(pathLayout, "/"), artifactoryVersionPlaceholder) {
+		return nil, fmt.Errorf("the path layout %q must contain %s and have %s as a folder of its own", pathLayout, artifactorySlugPlaceholder, artifactoryVersionPlaceholder)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.DisableSSL {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &artifactoryClient{
+		url:             strings.TrimSuffix(config.URL, "/"),
+		repository:      strings.Trim(config.Repository, "/"),
+		apiKey:          config.APIKey,
+		token:           config.Token,
+		pathLayout:      pathLayout,
+		slugProperty:    config.SlugProperty,
+		versionProperty: config.VersionProperty,
+		httpClient:      &http.Client{Transport: transport},
+		stderr:          stderr,
+	}, nil
+}
+
+func (a *artifactoryClient) Name() string {
+	return "artifactory"
+}
+
+func (a *artifactoryClient) GetAllProductVersions(slug string) ([]string, error) {
+	if a.versionProperty != "" {
+		return a.versionsFromProperties(slug)
+	}
+
+	versionFolder, _, _ := strings.Cut(a.pathLayout, artifactoryVersionPlaceholder)
+	versionFolder = strings.ReplaceAll(strings.TrimSuffix(versionFolder, "/"), artifactorySlugPlaceholder, slug)
+
+	entries, err := a.list(versionFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.Folder {
+			versions = append(versions, strings.TrimPrefix(entry.URI, "/"))
+		}
+	}
+
+	return versions, nil
+}
+
+func (a *artifactoryClient) GetLatestProductFile(slug, version, glob string) (FileArtifacter, error) {
+	matchedFiles, availableFiles, err := a.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchedFiles) > 1 {
+		var names []string
+		for _, file := range matchedFiles {
+			names = append(names, file.path)
+		}
+		return nil, fmt.Errorf("the glob '%s' matches multiple files. Write your glob to match exactly one of the following:\n  %s", glob, strings.Join(names, "\n  "))
+	}
+
+	if len(matchedFiles) == 0 {
+		return nil, noArtifactoryFileError(glob, availableFiles)
+	}
+
+	return matchedFiles[0], nil
+}
+
+func (a *artifactoryClient) GetLatestProductFiles(slug, version, glob string) ([]FileArtifacter, error) {
+	matchedFiles, availableFiles, err := a.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchedFiles) == 0 {
+		return nil, noArtifactoryFileError(glob, availableFiles)
+	}
+
+	var fileArtifacts []FileArtifacter
+	for _, file := range matchedFiles {
+		fileArtifacts = append(fileArtifacts, file)
+	}
+
+	return fileArtifacts, nil
+}
+
+func noArtifactoryFileError(glob string, availableFiles []string) error {
+	if len(availableFiles) == 0 {
+		availableFiles = []string{"none"}
+	}
+	return fmt.Errorf("the glob '%s' matches no file\navailable files: %s", glob, strings.Join(availableFiles, ", "))
+}
+
+func (a *artifactoryClient) matchingFiles(slug, version, glob string) ([]*artifactoryFileArtifact, []string, error) {
+	var (
+		files []*artifactoryFileArtifact
+		err   error
+	)
+
+	if a.versionProperty != "" {
+		files, err = a.filesFromProperties(slug, version)
+	} else {
+		files, err = a.filesFromLayout(slug, version)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		availableFiles []string
+		matchedFiles   []*artifactoryFileArtifact
+	)
+	for _, file := range files {
+		availableFiles = append(availableFiles, file.path)
+
+		if matched, _ := filepath.Match(glob, path.Base(file.path)); matched {
+			matchedFiles = append(matchedFiles, file)
+		}
+	}
+
+	return matchedFiles, availableFiles, nil
+}
+
+func (a *artifactoryClient) filesFromLayout(slug, version string) ([]*artifactoryFileArtifact, error) {
+	folder := strings.ReplaceAll(a.pathLayout, artifactorySlugPlaceholder, slug)
+	folder = strings.ReplaceAll(folder, artifactoryVersionPlaceholder, version)
+
+	entries, err := a.list(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*artifactoryFileArtifact
+	for _, entry := range entries {
+		if entry.Folder {
+			continue
+		}
+
+		files = append(files, &artifactoryFileArtifact{
+			path:   folder + entry.URI,
+			sha256: entry.SHA256,
+			size:   entry.Size,
+		})
+	}
+
+	return files, nil
+}
+
+func (a *artifactoryClient) filesFromProperties(slug, version string) ([]*artifactoryFileArtifact, error) {
+	results, err := a.search(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*artifactoryFileArtifact
+	for _, result := range results {
+		if !slices.Contains(result.Properties[a.versionProperty], version) {
+			continue
+		}
+
+		files = append(files, &artifactoryFileArtifact{
+			path:   strings.TrimPrefix(result.Path, "/"),
+			sha256: result.Checksums.SHA256,
+		})
+	}
+
+	return files, nil
+}
+
+func (a *artifactoryClient) versionsFromProperties(slug string) ([]string, error) {
+	results, err := a.search(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, result := range results {
+		for _, version := range result.Properties[a.versionProperty] {
+			if !slices.Contains(versions, version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+type artifactoryListEntry struct {
+	URI    string `json:"uri"`
+	Folder bool   `json:"folder"`
+	SHA256 string `json:"sha2"`
+	Size   int64  `json:"size"`
+}
+
+// list uses the file list API to return the direct children of folder.
+func (a *artifactoryClient) list(folder string) ([]artifactoryListEntry, error) {
+	endpoint := fmt.Sprintf("%s/api/storage/%s/%s?list&deep=0&listFolders=1", a.url, a.repository, escapePath(folder))
+
+	var listing struct {
+		Files []artifactoryListEntry `json:"files"`
+	}
+	err := a.getJSON(endpoint, nil, &listing)
+	if err != nil {
+		return nil, fmt.Errorf("could not list %s/%s: %w", a.repository, folder, err)
+	}
+
+	return listing.Files, nil
+}
+
+type artifactorySearchResult struct {
+	Path       string              `json:"path"`
+	Properties map[string][]string `json:"properties"`
+	Checksums  struct {
+		SHA256 string `json:"sha256"`
+	} `json:"checksums"`
+}
+
+// search uses the property search API to find every file in the repository
+// with the slug property set to slug.
+func (a *artifactoryClient) search(slug string) ([]artifactorySearchResult, error) {
+	query := url.Values{}
+	query.Set(a.slugProperty, slug)
+	query.Set("repos", a.repository)
+	endpoint := fmt.Sprintf("%s/api/search/prop?%s", a.url, query.Encode())
+
+	var results struct {
+		Results []artifactorySearchResult `json:"results"`
+	}
+	err := a.getJSON(endpoint, http.Header{"X-Result-Detail": []string{"info, properties"}}, &results)
+	if err != nil {
+		return nil, fmt.Errorf("could not search %s for %s=%s: %w", a.repository, a.slugProperty, slug, err)
+	}
+
+	return results.Results, nil
+}
+
+func (a *artifactoryClient) getJSON(endpoint string, header http.Header, v interface{}) error {
+	response, err := a.get(endpoint, header)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+func (a *artifactoryClient) get(endpoint string, header http.Header) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	request.Header.Set("User-Agent", userAgent)
+	if a.apiKey != "" {
+		request.Header.Set("X-JFrog-Art-Api", a.apiKey)
+	}
+	if a.token != "" {
+		request.Header.Set("Authorization", "Bearer "+a.token)
+	}
+
+	response, err := a.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("unexpected response %s: %s", response.Status, strings.TrimSpace(string(body)))
+	}
+
+	return response, nil
+}
+
+func (a *artifactoryClient) DownloadProductToFile(fa FileArtifacter, destinationFile *os.File) error {
+	fileArtifact, ok := fa.(*artifactoryFileArtifact)
+	if !ok {
+		return fmt.Errorf("cannot download %s: not an artifactory artifact", fa.Name())
+	}
+
+	response, err := a.get(fmt.Sprintf("%s/%s/%s", a.url, a.repository, escapePath(fileArtifact.path)), nil)
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", fileArtifact.path, err)
+	}
+	defer response.Body.Close()
+
+	progressBar := progress.NewBar(a.stderr.Writer(), "download")
+	progressBar.SetTotal(response.ContentLength)
+	progressBar.Start()
+	defer progressBar.Finish()
+
+	_, err = io.Copy(destinationFile, progressBar.NewProxyReader(response.Body))
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", fileArtifact.path, err)
+	}
+
+	return nil
+}
+
+func (a *artifactoryClient) GetLatestStemcellForProduct(_ FileArtifacter, downloadedProductFileName string, _ string) (StemcellArtifacter, error) {
+	definedStemcell, err := stemcellFromProduct(downloadedProductFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := stemcellVersionPartsFromString(definedStemcell.Version()); err != nil {
+		return nil, err
+	}
+
+	allStemcellVersions, err := a.GetAllProductVersions(definedStemcell.Slug())
+	if err != nil {
+		return nil, fmt.Errorf("could not find stemcells on %s: %s", a.Name(), err)
+	}
+
+	return latestCompatibleStemcell(definedStemcell, allStemcellVersions)
+}
+
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+type artifactoryFileArtifact struct {
+	path   string
+	sha256 string
+
+	// size is only listed by the file list API, not the property search
+	size int64
+}
+
+func (f artifactoryFileArtifact) ProductMetadata() (*extractor.Metadata, error) {
+	return nil, fmt.Errorf("%w \"%s\"", ErrCannotExtractMetadata, "artifactory")
+}
+
+func (f artifactoryFileArtifact) Name() string {
+	return f.path
+}
+
+func (f artifactoryFileArtifact) SHA256() string {
+	return f.sha256
+}
+
+func (f artifactoryFileArtifact) Size() int64 {
+	return f.size
+}
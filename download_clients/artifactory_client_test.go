@@ -0,0 +1,172 @@
+package download_clients_test
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+var _ = Describe("artifactoryClient", func() {
+	var (
+		server *ghttp.Server
+		stderr *log.Logger
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		stderr = log.New(GinkgoWriter, "", 0)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func(config download_clients.ArtifactoryConfiguration) download_clients.ProductDownloader {
+		config.URL = server.URL() + "/artifactory"
+		config.Repository = "tiles-local"
+
+		client, err := download_clients.NewArtifactoryClient(config, stderr)
+		Expect(err).ToNot(HaveOccurred())
+		return client
+	}
+
+	When("using a path layout", func() {
+		It("lists the version folders of a product", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/artifactory/api/storage/tiles-local/products/cf", "list&deep=0&listFolders=1"),
+				ghttp.VerifyHeaderKV("X-JFrog-Art-Api", "some-api-key"),
+				ghttp.RespondWith(http.StatusOK, `{"files": [
+					{"uri": "/2.0.0", "folder": true},
+					{"uri": "/2.1.0", "folder": true},
+					{"uri": "/README.md", "folder": false}
+				]}`),
+			))
+
+			client := newClient(download_clients.ArtifactoryConfiguration{
+				APIKey:     "some-api-key",
+				PathLayout: "products/{slug}/{version}",
+			})
+
+			versions, err := client.GetAllProductVersions("cf")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]string{"2.0.0", "2.1.0"}))
+		})
+
+		It("finds the file matching the glob and downloads it", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/artifactory/api/storage/tiles-local/cf/2.0.0", "list&deep=0&listFolders=1"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+					ghttp.RespondWith(http.StatusOK, `{"files": [
+						{"uri": "/cf-2.0.0.pivotal", "folder": false, "sha2": "some-sha", "size": 1234},
+						{"uri": "/cf-2.0.0.yml", "folder": false}
+					]}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/artifactory/tiles-local/cf/2.0.0/cf-2.0.0.pivotal"),
+					ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+					ghttp.RespondWith(http.StatusOK, "tile contents"),
+				),
+			)
+
+			client := newClient(download_clients.ArtifactoryConfiguration{Token: "some-token"})
+
+			file, err := client.GetLatestProductFile("cf", "2.0.0", "*.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Name()).To(Equal("cf/2.0.0/cf-2.0.0.pivotal"))
+			Expect(file.SHA256()).To(Equal("some-sha"))
+			Expect(file.(download_clients.FileSizer).Size()).To(Equal(int64(1234)))
+
+			tempFile, err := os.CreateTemp("", "")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(tempFile.Name())
+
+			err = client.DownloadProductToFile(file, tempFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.ReadFile(tempFile.Name())).To(Equal([]byte("tile contents")))
+		})
+
+		It("errors when the glob matches no file", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"files": [{"uri": "/cf-2.0.0.pivotal", "folder": false}]}`))
+
+			client := newClient(download_clients.ArtifactoryConfiguration{})
+
+			_, err := client.GetLatestProductFile("cf", "2.0.0", "*.zip")
+			Expect(err).To(MatchError("the glob '*.zip' matches no file\navailable files: cf/2.0.0/cf-2.0.0.pivotal"))
+		})
+
+		It("errors when Artifactory rejects the request", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusUnauthorized, `{"errors": [{"status": 401, "message": "Bad credentials"}]}`))
+
+			client := newClient(download_clients.ArtifactoryConfiguration{APIKey: "wrong"})
+
+			_, err := client.GetAllProductVersions("cf")
+			Expect(err).To(MatchError(ContainSubstring("could not list tiles-local/cf: unexpected response 401 Unauthorized")))
+			Expect(err).To(MatchError(ContainSubstring("Bad credentials")))
+		})
+	})
+
+	When("using properties to discover versions", func() {
+		BeforeEach(func() {
+			server.RouteToHandler("GET", "/artifactory/api/search/prop", ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/artifactory/api/search/prop", "product.slug=cf&repos=tiles-local"),
+				ghttp.VerifyHeaderKV("X-Result-Detail", "info, properties"),
+				ghttp.RespondWith(http.StatusOK, `{"results": [
+					{"path": "/mirror/cf-2.0.0.pivotal", "properties": {"product.version": ["2.0.0"]}, "checksums": {"sha256": "sha-2.0.0"}},
+					{"path": "/mirror/cf-2.0.0.yml", "properties": {"product.version": ["2.0.0"]}},
+					{"path": "/mirror/cf-2.1.0.pivotal", "properties": {"product.version": ["2.1.0"]}, "checksums": {"sha256": "sha-2.1.0"}}
+				]}`),
+			))
+		})
+
+		It("returns every version set on the files", func() {
+			client := newClient(download_clients.ArtifactoryConfiguration{
+				SlugProperty:    "product.slug",
+				VersionProperty: "product.version",
+			})
+
+			versions, err := client.GetAllProductVersions("cf")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal([]string{"2.0.0", "2.1.0"}))
+		})
+
+		It("returns the files of the version matching the glob", func() {
+			client := newClient(download_clients.ArtifactoryConfiguration{
+				SlugProperty:    "product.slug",
+				VersionProperty: "product.version",
+			})
+
+			files, err := client.GetLatestProductFiles("cf", "2.0.0", "*")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(files).To(HaveLen(2))
+			Expect(files[0].Name()).To(Equal("mirror/cf-2.0.0.pivotal"))
+			Expect(files[0].SHA256()).To(Equal("sha-2.0.0"))
+			Expect(files[1].Name()).To(Equal("mirror/cf-2.0.0.yml"))
+		})
+	})
+
+	It("requires the path layout to contain the slug and version", func() {
+		_, err := download_clients.NewArtifactoryClient(download_clients.ArtifactoryConfiguration{
+			URL:        "https://artifactory.example.com/artifactory",
+			Repository: "tiles-local",
+			PathLayout: "tiles/{version}-{slug}",
+		}, stderr)
+		Expect(err).To(MatchError(`the path layout "tiles/{version}-{slug}" must contain {slug} and have {version} as a folder of its own`))
+	})
+
+	It("does not allow both an api key and a token", func() {
+		_, err := download_clients.NewArtifactoryClient(download_clients.ArtifactoryConfiguration{
+			URL:        "https://artifactory.example.com/artifactory",
+			Repository: "tiles-local",
+			APIKey:     "key",
+			Token:      "token",
+		}, stderr)
+		Expect(err).To(MatchError("cannot use both an Artifactory API key and an access token; please choose one"))
+	})
+})
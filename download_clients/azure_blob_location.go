@@ -0,0 +1,221 @@
+package download_clients
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	az "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/graymeta/stow"
+)
+
+// azureBlobKind is a read-only stow location for Azure blob storage that,
+// unlike the stow azure location, can authenticate with a SAS token and talk
+// to the sovereign clouds. It does not list containers, so a SAS token scoped
+// to a single container is enough.
+const azureBlobKind = "azure-blob"
+
+const (
+	azureConfigAccount        = "account"
+	azureConfigKey            = "key"
+	azureConfigSASToken       = "sas_token"
+	azureConfigEndpointSuffix = "endpoint_suffix"
+)
+
+var errAzureBlobReadOnly = errors.New("the azure-blob location is read-only")
+
+func init() {
+	validatefn := func(config stow.Config) error {
+		_, err := newAzureBlobClient(config)
+		return err
+	}
+	makefn := func(config stow.Config) (stow.Location, error) {
+		client, err := newAzureBlobClient(config)
+		if err != nil {
+			return nil, err
+		}
+		return &azureBlobLocation{client: client}, nil
+	}
+	kindfn := func(u *url.URL) bool {
+		return u.Scheme == azureBlobKind
+	}
+	stow.Register(azureBlobKind, makefn, kindfn, validatefn)
+}
+
+func newAzureBlobClient(config stow.Config) (*az.BlobStorageClient, error) {
+	account, _ := config.Config(azureConfigAccount)
+	if account == "" {
+		return nil, errors.New("missing account")
+	}
+
+	endpointSuffix, _ := config.Config(azureConfigEndpointSuffix)
+	if endpointSuffix == "" {
+		endpointSuffix = az.DefaultBaseURL
+	}
+
+	var client az.Client
+	if sasToken, _ := config.Config(azureConfigSASToken); sasToken != "" {
+		token, err := url.ParseQuery(strings.TrimPrefix(sasToken, "?"))
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the SAS token: %w", err)
+		}
+		client = az.NewAccountSASClient(account, token, azure.Environment{StorageEndpointSuffix: endpointSuffix})
+	} else {
+		key, _ := config.Config(azureConfigKey)
+		if key == "" {
+			return nil, errors.New("missing key or SAS token")
+		}
+
+		var err error
+		client, err = az.NewClient(account, key, endpointSuffix, az.DefaultAPIVersion, true)
+		if err != nil {
+			return nil, fmt.Errorf("bad credentials: %w", err)
+		}
+	}
+
+	blobClient := client.GetBlobService()
+	return &blobClient, nil
+}
+
+type azureBlobLocation struct {
+	client *az.BlobStorageClient
+}
+
+func (l *azureBlobLocation) Close() error {
+	return nil
+}
+
+func (l *azureBlobLocation) Container(id string) (stow.Container, error) {
+	return &azureBlobContainer{container: l.client.GetContainerReference(id)}, nil
+}
+
+func (l *azureBlobLocation) CreateContainer(string) (stow.Container, error) {
+	return nil, errAzureBlobReadOnly
+}
+
+func (l *azureBlobLocation) Containers(string, string, int) ([]stow.Container, string, error) {
+	return nil, "", errors.New("the azure-blob location does not list containers")
+}
+
+func (l *azureBlobLocation) RemoveContainer(string) error {
+	return errAzureBlobReadOnly
+}
+
+func (l *azureBlobLocation) ItemByURL(*url.URL) (stow.Item, error) {
+	return nil, errors.New("the azure-blob location does not look up items by url")
+}
+
+type azureBlobContainer struct {
+	container *az.Container
+}
+
+func (c *azureBlobContainer) ID() string {
+	return c.container.Name
+}
+
+func (c *azureBlobContainer) Name() string {
+	return c.container.Name
+}
+
+func (c *azureBlobContainer) Item(id string) (stow.Item, error) {
+	blob := c.container.GetBlobReference(id)
+	err := blob.GetProperties(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureBlobItem{blob: blob}, nil
+}
+
+func (c *azureBlobContainer) Items(prefix, cursor string, count int) ([]stow.Item, string, error) {
+	response, err := c.container.ListBlobs(az.ListBlobsParameters{
+		Prefix:     prefix,
+		Marker:     cursor,
+		MaxResults: uint(count),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]stow.Item, len(response.Blobs))
+	for i := range response.Blobs {
+		blob := response.Blobs[i]
+		blob.Container = c.container
+		items[i] = &azureBlobItem{blob: &blob}
+	}
+
+	return items, response.NextMarker, nil
+}
+
+func (c *azureBlobContainer) RemoveItem(string) error {
+	return errAzureBlobReadOnly
+}
+
+func (c *azureBlobContainer) Put(string, io.Reader, int64, map[string]interface{}) (stow.Item, error) {
+	return nil, errAzureBlobReadOnly
+}
+
+type azureBlobItem struct {
+	blob *az.Blob
+}
+
+func (i *azureBlobItem) ID() string {
+	return i.blob.Name
+}
+
+func (i *azureBlobItem) Name() string {
+	return i.blob.Name
+}
+
+func (i *azureBlobItem) URL() *url.URL {
+	u, _ := url.Parse(i.blob.GetURL())
+	return u
+}
+
+func (i *azureBlobItem) Size() (int64, error) {
+	return i.blob.Properties.ContentLength, nil
+}
+
+func (i *azureBlobItem) Open() (io.ReadCloser, error) {
+	return i.blob.Get(nil)
+}
+
+func (i *azureBlobItem) OpenRange(start, end uint64) (io.ReadCloser, error) {
+	return i.blob.GetRange(&az.GetBlobRangeOptions{
+		Range: &az.BlobRange{Start: start, End: end},
+	})
+}
+
+func (i *azureBlobItem) ETag() (string, error) {
+	return i.blob.Properties.Etag, nil
+}
+
+// PublishedMD5 is the Content-MD5 of the blob, which Azure only sets for
+// blobs uploaded in a single request or by clients that set it.
+func (i *azureBlobItem) PublishedMD5() string {
+	sum, err := base64.StdEncoding.DecodeString(i.blob.Properties.ContentMD5)
+	if err != nil || len(sum) != md5.Size {
+		return ""
+	}
+
+	return hex.EncodeToString(sum)
+}
+
+func (i *azureBlobItem) LastMod() (time.Time, error) {
+	return time.Time(i.blob.Properties.LastModified), nil
+}
+
+func (i *azureBlobItem) Metadata() (map[string]interface{}, error) {
+	metadata := map[string]interface{}{}
+	for key, value := range i.blob.Metadata {
+		metadata[key] = value
+	}
+	return metadata, nil
+}
@@ -1,18 +1,24 @@
 package download_clients
 
 import (
+	"errors"
+	"log"
+
 	"github.com/graymeta/stow"
 	"github.com/graymeta/stow/azure"
 	"gopkg.in/go-playground/validator.v9"
-	"log"
 )
 
 type AzureConfiguration struct {
 	StorageAccount string `validate:"required"`
-	Key            string `validate:"required"`
+	Key            string
+	SASToken       string
+	EndpointSuffix string
 	Container      string `validate:"required"`
 	ProductPath    string
 	StemcellPath   string
+
+	ParallelConnections int
 }
 
 func NewAzureClient(stower Stower, config AzureConfiguration, stderr *log.Logger) (stowClient, error) {
@@ -22,10 +28,28 @@ func NewAzureClient(stower Stower, config AzureConfiguration, stderr *log.Logger
 		return stowClient{}, err
 	}
 
+	if config.Key == "" && config.SASToken == "" {
+		return stowClient{}, errors.New("either the flag \"azure-storage-key\" or \"azure-sas-token\" is required")
+	}
+	if config.Key != "" && config.SASToken != "" {
+		return stowClient{}, errors.New("cannot use both \"azure-storage-key\" and \"azure-sas-token\"; please choose one")
+	}
+
+	if config.SASToken == "" && config.EndpointSuffix == "" {
+		stowConfig := stow.ConfigMap{
+			azure.ConfigAccount: config.StorageAccount,
+			azure.ConfigKey:     config.Key,
+		}
+
+		return NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "azure", config.Container).WithParallelConnections(config.ParallelConnections), nil
+	}
+
 	stowConfig := stow.ConfigMap{
-		azure.ConfigAccount: config.StorageAccount,
-		azure.ConfigKey:     config.Key,
+		azureConfigAccount:        config.StorageAccount,
+		azureConfigKey:            config.Key,
+		azureConfigSASToken:       config.SASToken,
+		azureConfigEndpointSuffix: config.EndpointSuffix,
 	}
 
-	return NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "azure", config.Container), nil
+	return NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "azure", config.Container).WithParallelConnections(config.ParallelConnections).withDialKind(azureBlobKind), nil
 }
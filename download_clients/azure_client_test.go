@@ -0,0 +1,95 @@
+package download_clients_test
+
+import (
+	"log"
+
+	"github.com/graymeta/stow"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+var _ = Describe("AzureClient", func() {
+	var (
+		stderr *log.Logger
+		stower *mockStower
+		config download_clients.AzureConfiguration
+	)
+
+	BeforeEach(func() {
+		stderr = log.New(GinkgoWriter, "", 0)
+		stower = newMockStower([]mockItem{newMockItem("[product-slug,1.1.1]somefile-0.0.2.zip")})
+		config = download_clients.AzureConfiguration{
+			StorageAccount: "account",
+			Container:      "container",
+		}
+	})
+
+	It("authenticates with the storage key", func() {
+		config.Key = "some-key"
+
+		client, err := download_clients.NewAzureClient(stower, config, stderr)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.GetAllProductVersions("product-slug")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stower.kind).To(Equal("azure"))
+		Expect(client.Name()).To(Equal("azure"))
+	})
+
+	It("authenticates with a SAS token", func() {
+		config.SASToken = "sv=2020-08-04&sr=c&sp=rl&sig=abc"
+
+		client, err := download_clients.NewAzureClient(stower, config, stderr)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.GetAllProductVersions("product-slug")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stower.kind).To(Equal("azure-blob"))
+		Expect(client.Name()).To(Equal("azure"))
+
+		sasToken, _ := stower.config.Config("sas_token")
+		Expect(sasToken).To(Equal("sv=2020-08-04&sr=c&sp=rl&sig=abc"))
+	})
+
+	It("connects to a sovereign cloud using its endpoint suffix", func() {
+		config.Key = "c29tZS1rZXk="
+		config.EndpointSuffix = "core.usgovcloudapi.net"
+
+		client, err := download_clients.NewAzureClient(stower, config, stderr)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.GetAllProductVersions("product-slug")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stower.kind).To(Equal("azure-blob"))
+
+		endpointSuffix, _ := stower.config.Config("endpoint_suffix")
+		Expect(endpointSuffix).To(Equal("core.usgovcloudapi.net"))
+
+		location, err := download_clients.StowWrapper{}.Dial("azure-blob", stower.config)
+		Expect(err).ToNot(HaveOccurred())
+
+		container, err := location.Container("container")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(container.ID()).To(Equal("container"))
+	})
+
+	It("requires either a key or a SAS token", func() {
+		_, err := download_clients.NewAzureClient(stower, config, stderr)
+		Expect(err).To(MatchError(`either the flag "azure-storage-key" or "azure-sas-token" is required`))
+	})
+
+	It("does not allow both a key and a SAS token", func() {
+		config.Key = "some-key"
+		config.SASToken = "sv=2020-08-04&sig=abc"
+
+		_, err := download_clients.NewAzureClient(stower, config, stderr)
+		Expect(err).To(MatchError(`cannot use both "azure-storage-key" and "azure-sas-token"; please choose one`))
+	})
+
+	It("cannot dial the azure-blob location without credentials", func() {
+		_, err := download_clients.StowWrapper{}.Dial("azure-blob", stow.ConfigMap{"account": "account"})
+		Expect(err).To(MatchError("missing key or SAS token"))
+	})
+})
@@ -0,0 +1,359 @@
+package download_clients
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/progress"
+)
+
+type BroadcomConfiguration struct {
+	Host       string `validate:"required"`
+	Token      string `validate:"required"`
+	AcceptEULA bool
+	DisableSSL bool
+}
+
+type broadcomClient struct {
+	host        string
+	token       string
+	acceptEULA  bool
+	accessToken string
+	httpClient  *http.Client
+	stderr      *log.Logger
+}
+
+// NewBroadcomClient returns a downloader for the Broadcom Support Portal,
+// which replaced Tanzu Network as the home of Tanzu product downloads. The
+// refresh token generated on the portal is exchanged for an access token
+// before the first request. Releases with a EULA that has not been accepted
+// cannot be downloaded unless AcceptEULA is set, in which case it is accepted
+// on the user's behalf.
+func NewBroadcomClient(config BroadcomConfiguration, stderr *log.Logger) (ProductDownloader, error) {
+	validate := validator.New()
+	err := validate.Struct(config)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.DisableSSL {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &broadcomClient{
+		host:       strings.TrimSuffix(config.Host, "/"),
+		token:      config.Token,
+		acceptEULA: config.AcceptEULA,
+		httpClient: &http.Client{Transport: transport},
+		stderr:     stderr,
+	}, nil
+}
+
+func (b *broadcomClient) Name() string {
+	return "broadcom"
+}
+
+type broadcomRelease struct {
+	Version string `json:"version"`
+	EULA    struct {
+		Slug     string `json:"slug"`
+		Accepted bool   `json:"accepted"`
+	} `json:"eula"`
+}
+
+type broadcomFile struct {
+	Name        string `json:"name"`
+	SHA256      string `json:"sha256"`
+	DownloadURL string `json:"download_url"`
+}
+
+func (b *broadcomClient) GetAllProductVersions(slug string) ([]string, error) {
+	releases, err := b.releases(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, release := range releases {
+		versions = append(versions, release.Version)
+	}
+
+	return versions, nil
+}
+
+func (b *broadcomClient) GetLatestProductFile(slug, version, glob string) (FileArtifacter, error) {
+	matchedFiles, availableFiles, err := b.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchedFiles) > 1 {
+		var names []string
+		for _, file := range matchedFiles {
+			names = append(names, file.name)
+		}
+		return nil, fmt.Errorf("the glob '%s' matches multiple files. Write your glob to match exactly one of the following:\n  %s", glob, strings.Join(names, "\n  "))
+	}
+
+	if len(matchedFiles) == 0 {
+		return nil, noBroadcomFileError(version, glob, availableFiles)
+	}
+
+	return matchedFiles[0], nil
+}
+
+func (b *broadcomClient) GetLatestProductFiles(slug, version, glob string) ([]FileArtifacter, error) {
+	matchedFiles, availableFiles, err := b.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchedFiles) == 0 {
+		return nil, noBroadcomFileError(version, glob, availableFiles)
+	}
+
+	var fileArtifacts []FileArtifacter
+	for _, file := range matchedFiles {
+		fileArtifacts = append(fileArtifacts, file)
+	}
+
+	return fileArtifacts, nil
+}
+
+func noBroadcomFileError(version, glob string, availableFiles []string) error {
+	if len(availableFiles) == 0 {
+		availableFiles = []string{"none"}
+	}
+	return fmt.Errorf("for product version %s: the glob '%s' matches no file\navailable files: %s", version, glob, strings.Join(availableFiles, ", "))
+}
+
+func (b *broadcomClient) matchingFiles(slug, version, glob string) ([]*broadcomFileArtifact, []string, error) {
+	releases, err := b.releases(slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var release *broadcomRelease
+	for i := range releases {
+		if releases[i].Version == version {
+			release = &releases[i]
+			break
+		}
+	}
+	if release == nil {
+		return nil, nil, fmt.Errorf("could not find release %s of %s on %s", version, slug, b.Name())
+	}
+
+	err = b.ensureEULAAccepted(slug, *release)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var files struct {
+		Files []broadcomFile `json:"files"`
+	}
+	err = b.do(http.MethodGet, fmt.Sprintf("/api/v1/products/%s/releases/%s/files", url.PathEscape(slug), url.PathEscape(version)), &files)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not list files of %s %s: %w", slug, version, err)
+	}
+
+	var (
+		availableFiles []string
+		matchedFiles   []*broadcomFileArtifact
+	)
+	for _, file := range files.Files {
+		availableFiles = append(availableFiles, file.Name)
+
+		if matched, _ := filepath.Match(glob, file.Name); matched {
+			matchedFiles = append(matchedFiles, &broadcomFileArtifact{
+				name:        file.Name,
+				sha256:      file.SHA256,
+				downloadURL: file.DownloadURL,
+			})
+		}
+	}
+
+	return matchedFiles, availableFiles, nil
+}
+
+func (b *broadcomClient) ensureEULAAccepted(slug string, release broadcomRelease) error {
+	if release.EULA.Slug == "" || release.EULA.Accepted {
+		return nil
+	}
+
+	if !b.acceptEULA {
+		return fmt.Errorf("the EULA %q for %s %s has not been accepted. Accept it on the Broadcom Support Portal, or pass --broadcom-accept-eula to accept it", release.EULA.Slug, slug, release.Version)
+	}
+
+	b.stderr.Printf("accepting the EULA %q for %s %s", release.EULA.Slug, slug, release.Version)
+	err := b.do(http.MethodPost, fmt.Sprintf("/api/v1/products/%s/releases/%s/eula_acceptance", url.PathEscape(slug), url.PathEscape(release.Version)), nil)
+	if err != nil {
+		return fmt.Errorf("could not accept the EULA for %s %s: %w", slug, release.Version, err)
+	}
+
+	return nil
+}
+
+func (b *broadcomClient) releases(slug string) ([]broadcomRelease, error) {
+	var releases struct {
+		Releases []broadcomRelease `json:"releases"`
+	}
+	err := b.do(http.MethodGet, fmt.Sprintf("/api/v1/products/%s/releases", url.PathEscape(slug)), &releases)
+	if err != nil {
+		return nil, fmt.Errorf("could not list releases of %s: %w", slug, err)
+	}
+
+	return releases.Releases, nil
+}
+
+// authenticate exchanges the refresh token for an access token, once.
+func (b *broadcomClient) authenticate() error {
+	if b.accessToken != "" {
+		return nil
+	}
+
+	response, err := b.httpClient.PostForm(b.host+"/api/v1/authentication/access_tokens", url.Values{"refresh_token": {b.token}})
+	if err != nil {
+		return fmt.Errorf("could not authenticate with %s: %w", b.Name(), err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not authenticate with %s: %w", b.Name(), broadcomResponseError(response))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&token)
+	if err != nil {
+		return fmt.Errorf("could not parse the access token from %s: %w", b.Name(), err)
+	}
+
+	b.accessToken = token.AccessToken
+	return nil
+}
+
+func (b *broadcomClient) do(method, endpoint string, v interface{}) error {
+	response, err := b.request(method, b.host+endpoint)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if v == nil {
+		return nil
+	}
+
+	return json.NewDecoder(response.Body).Decode(v)
+}
+
+func (b *broadcomClient) request(method, endpoint string) (*http.Response, error) {
+	err := b.authenticate()
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set("Authorization", "Bearer "+b.accessToken)
+
+	response, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		return nil, broadcomResponseError(response)
+	}
+
+	return response, nil
+}
+
+func broadcomResponseError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return fmt.Errorf("unexpected response %s: %s", response.Status, strings.TrimSpace(string(body)))
+}
+
+func (b *broadcomClient) DownloadProductToFile(fa FileArtifacter, destinationFile *os.File) error {
+	fileArtifact, ok := fa.(*broadcomFileArtifact)
+	if !ok {
+		return fmt.Errorf("cannot download %s: not a broadcom artifact", fa.Name())
+	}
+
+	// the download URL redirects to signed storage, so the access token
+	// is not sent beyond the portal itself
+	response, err := b.request(http.MethodGet, fileArtifact.downloadURL)
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", fileArtifact.name, err)
+	}
+	defer response.Body.Close()
+
+	progressBar := progress.NewBar(b.stderr.Writer(), "download")
+	progressBar.SetTotal(response.ContentLength)
+	progressBar.Start()
+	defer progressBar.Finish()
+
+	_, err = io.Copy(destinationFile, progressBar.NewProxyReader(response.Body))
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", fileArtifact.name, err)
+	}
+
+	return nil
+}
+
+func (b *broadcomClient) GetLatestStemcellForProduct(_ FileArtifacter, downloadedProductFileName string, stemcellSlug string) (StemcellArtifacter, error) {
+	definedStemcell, err := stemcellFromProduct(downloadedProductFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if stemcellSlug != "" {
+		definedStemcell.slug = stemcellSlug
+	}
+
+	if _, _, err := stemcellVersionPartsFromString(definedStemcell.Version()); err != nil {
+		return nil, err
+	}
+
+	allStemcellVersions, err := b.GetAllProductVersions(definedStemcell.Slug())
+	if err != nil {
+		return nil, fmt.Errorf("could not find stemcells on %s: %s", b.Name(), err)
+	}
+
+	return latestCompatibleStemcell(definedStemcell, allStemcellVersions)
+}
+
+type broadcomFileArtifact struct {
+	name        string
+	sha256      string
+	downloadURL string
+}
+
+func (f broadcomFileArtifact) ProductMetadata() (*extractor.Metadata, error) {
+	return nil, fmt.Errorf("%w \"%s\"", ErrCannotExtractMetadata, "broadcom")
+}
+
+func (f broadcomFileArtifact) Name() string {
+	return f.name
+}
+
+func (f broadcomFileArtifact) SHA256() string {
+	return f.sha256
+}
@@ -0,0 +1,140 @@
+package download_clients_test
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+var _ = Describe("broadcomClient", func() {
+	var (
+		server *ghttp.Server
+		stderr *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		stderr = gbytes.NewBuffer()
+
+		server.RouteToHandler("POST", "/api/v1/authentication/access_tokens", ghttp.CombineHandlers(
+			ghttp.VerifyForm(map[string][]string{"refresh_token": {"some-refresh-token"}}),
+			ghttp.RespondWith(http.StatusOK, `{"access_token": "some-access-token"}`),
+		))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	newClient := func(acceptEULA bool) download_clients.ProductDownloader {
+		client, err := download_clients.NewBroadcomClient(download_clients.BroadcomConfiguration{
+			Host:       server.URL(),
+			Token:      "some-refresh-token",
+			AcceptEULA: acceptEULA,
+		}, log.New(stderr, "", 0))
+		Expect(err).ToNot(HaveOccurred())
+		return client
+	}
+
+	releases := func(accepted bool) http.HandlerFunc {
+		eula := `{"slug": "vmware-general-terms", "accepted": false}`
+		if accepted {
+			eula = `{"slug": "vmware-general-terms", "accepted": true}`
+		}
+
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/products/cf/releases"),
+			ghttp.VerifyHeaderKV("Authorization", "Bearer some-access-token"),
+			ghttp.RespondWith(http.StatusOK, `{"releases": [
+				{"version": "2.0.0", "eula": `+eula+`},
+				{"version": "2.1.0", "eula": `+eula+`}
+			]}`),
+		)
+	}
+
+	It("lists the versions of a product", func() {
+		server.AppendHandlers(releases(true))
+
+		versions, err := newClient(false).GetAllProductVersions("cf")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions).To(Equal([]string{"2.0.0", "2.1.0"}))
+	})
+
+	It("finds the file matching the glob and downloads it", func() {
+		server.AppendHandlers(
+			releases(true),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/products/cf/releases/2.0.0/files"),
+				ghttp.RespondWith(http.StatusOK, `{"files": [
+					{"name": "cf-2.0.0.pivotal", "sha256": "some-sha", "download_url": "`+server.URL()+`/downloads/cf-2.0.0.pivotal"},
+					{"name": "cf-2.0.0.yml"}
+				]}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/downloads/cf-2.0.0.pivotal"),
+				ghttp.VerifyHeaderKV("Authorization", "Bearer some-access-token"),
+				ghttp.RespondWith(http.StatusOK, "tile contents"),
+			),
+		)
+
+		client := newClient(false)
+
+		file, err := client.GetLatestProductFile("cf", "2.0.0", "*.pivotal")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(file.Name()).To(Equal("cf-2.0.0.pivotal"))
+		Expect(file.SHA256()).To(Equal("some-sha"))
+
+		tempFile, err := os.CreateTemp("", "")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.Remove(tempFile.Name())
+
+		err = client.DownloadProductToFile(file, tempFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.ReadFile(tempFile.Name())).To(Equal([]byte("tile contents")))
+	})
+
+	It("errors when the release does not exist", func() {
+		server.AppendHandlers(releases(true))
+
+		_, err := newClient(false).GetLatestProductFile("cf", "3.0.0", "*.pivotal")
+		Expect(err).To(MatchError("could not find release 3.0.0 of cf on broadcom"))
+	})
+
+	When("the EULA has not been accepted", func() {
+		It("errors unless asked to accept it", func() {
+			server.AppendHandlers(releases(false))
+
+			_, err := newClient(false).GetLatestProductFile("cf", "2.0.0", "*.pivotal")
+			Expect(err).To(MatchError(ContainSubstring(`the EULA "vmware-general-terms" for cf 2.0.0 has not been accepted`)))
+		})
+
+		It("accepts it when asked to", func() {
+			server.AppendHandlers(
+				releases(false),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/products/cf/releases/2.0.0/eula_acceptance"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+				ghttp.RespondWith(http.StatusOK, `{"files": [{"name": "cf-2.0.0.pivotal"}]}`),
+			)
+
+			_, err := newClient(true).GetLatestProductFile("cf", "2.0.0", "*.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stderr).To(gbytes.Say(`accepting the EULA "vmware-general-terms" for cf 2.0.0`))
+		})
+	})
+
+	It("errors when the refresh token is rejected", func() {
+		server.RouteToHandler("POST", "/api/v1/authentication/access_tokens", ghttp.RespondWith(http.StatusUnauthorized, `{"message": "invalid refresh token"}`))
+
+		_, err := newClient(false).GetAllProductVersions("cf")
+		Expect(err).To(MatchError(ContainSubstring("could not authenticate with broadcom: unexpected response 401 Unauthorized")))
+	})
+})
@@ -20,6 +20,7 @@ func DetermineProductVersion(
 	slug string,
 	exactVersion string,
 	versionRegex string,
+	versionConstraint string,
 	versioner productVersioner,
 	stderr *log.Logger,
 ) (string, error) {
@@ -42,6 +43,18 @@ func DetermineProductVersion(
 		return foundVersion, nil
 	}
 
+	if versionConstraint != "" {
+		foundVersion, err := findLatestVersionFromConstraint(productVersions, versionConstraint, stderr)
+		if err != nil {
+			msg := fmt.Errorf("no valid versions found for product %q and product version constraint %q\nexisting versions: %s", slug, versionConstraint, existingVersions)
+			if productVersionError != nil {
+				msg = fmt.Errorf("%w: %s", productVersionError, msg)
+			}
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return foundVersion, nil
+	}
+
 	if exactVersion != "" {
 		for _, version := range productVersions {
 			if version == exactVersion {
@@ -85,3 +98,34 @@ func findLatestVersionFromRegex(productVersions []string, regex string, stderr *
 
 	return versions[len(versions)-1].Original(), nil
 }
+
+// findLatestVersionFromConstraint returns the highest version satisfying a
+// constraint such as "~> 2.13" or ">= 4.0, < 5". Pre-releases only match
+// constraints that name a pre-release themselves.
+func findLatestVersionFromConstraint(productVersions []string, constraint string, stderr *log.Logger) (string, error) {
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return "", fmt.Errorf("could not parse version constraint %q: %w", constraint, err)
+	}
+
+	var versions version.Collection
+	for _, productVersion := range productVersions {
+		v, err := version.NewVersion(productVersion)
+		if err != nil {
+			stderr.Printf("warning: could not parse semver version from: %s", productVersion)
+			continue
+		}
+
+		if constraints.Check(v) {
+			versions = append(versions, v)
+		}
+	}
+
+	sort.Sort(versions)
+
+	if len(versions) == 0 {
+		return "", errors.New("no available version found")
+	}
+
+	return versions[len(versions)-1].Original(), nil
+}
@@ -23,6 +23,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"2.2.2",
 					"",
+					"",
 					versioner,
 					nil,
 				)
@@ -40,6 +41,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"4.5.6",
 					"",
+					"",
 					versioner,
 					nil,
 				)
@@ -57,6 +59,7 @@ var _ = Describe("DetermineProductVersion", func() {
 				"product",
 				"",
 				`2\.2\..*`,
+				"",
 				versioner,
 				nil,
 			)
@@ -73,6 +76,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"",
 					`[a--z]`,
+					"",
 					versioner,
 					nil,
 				)
@@ -89,6 +93,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"",
 					`2\.2\..*`,
+					"",
 					versioner,
 					nil,
 				)
@@ -106,6 +111,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"",
 					`2\.2\..*`,
+					"",
 					versioner,
 					nil,
 				)
@@ -126,6 +132,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"",
 					`2\.2\..*`,
+					"",
 					versioner,
 					logger,
 				)
@@ -143,6 +150,7 @@ var _ = Describe("DetermineProductVersion", func() {
 					"product",
 					"",
 					`2\.2\..*`,
+					"",
 					versioner,
 					nil,
 				)
@@ -151,4 +159,73 @@ var _ = Describe("DetermineProductVersion", func() {
 			})
 		})
 	})
+
+	When("a version constraint is provided", func() {
+		It("returns the latest version satisfying the constraint", func() {
+			versioner := &fakes.ProductVersioner{}
+			versioner.GetAllProductVersionsReturns([]string{"2.12.9", "2.13.4", "2.13.10", "3.0.0", "2.14.0-build.1"}, nil)
+
+			version, err := download_clients.DetermineProductVersion(
+				"product",
+				"",
+				"",
+				"~> 2.13",
+				versioner,
+				nil,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("2.13.10"))
+		})
+
+		It("supports ranges", func() {
+			versioner := &fakes.ProductVersioner{}
+			versioner.GetAllProductVersionsReturns([]string{"3.9.1", "4.0.2", "4.8.0", "5.0.0"}, nil)
+
+			version, err := download_clients.DetermineProductVersion(
+				"product",
+				"",
+				"",
+				">= 4.0, < 5",
+				versioner,
+				nil,
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("4.8.0"))
+		})
+
+		When("no versions satisfy the constraint", func() {
+			It("returns an error listing the existing versions", func() {
+				versioner := &fakes.ProductVersioner{}
+				versioner.GetAllProductVersionsReturns([]string{"1.2.1"}, nil)
+
+				_, err := download_clients.DetermineProductVersion(
+					"product",
+					"",
+					"",
+					"~> 2.13",
+					versioner,
+					nil,
+				)
+				Expect(err).To(MatchError(ContainSubstring("no available version found")))
+				Expect(err).To(MatchError(ContainSubstring("no valid versions found for product \"product\" and product version constraint \"~> 2.13\"\nexisting versions: 1.2.1")))
+			})
+		})
+
+		When("the constraint cannot be parsed", func() {
+			It("returns an error", func() {
+				versioner := &fakes.ProductVersioner{}
+				versioner.GetAllProductVersionsReturns([]string{"1.2.1"}, nil)
+
+				_, err := download_clients.DetermineProductVersion(
+					"product",
+					"",
+					"",
+					"about 2",
+					versioner,
+					nil,
+				)
+				Expect(err).To(MatchError(ContainSubstring(`could not parse version constraint "about 2"`)))
+			})
+		})
+	})
 })
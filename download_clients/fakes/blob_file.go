@@ -0,0 +1,241 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/pivotal-cf/om/download_clients"
+	"github.com/pivotal-cf/om/extractor"
+)
+
+type BlobFile struct {
+	OpenStub        func() (io.ReadCloser, error)
+	openMutex       sync.RWMutex
+	openArgsForCall []struct {
+	}
+	openReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	openReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	ProductMetadataStub        func() (*extractor.Metadata, error)
+	productMetadataMutex       sync.RWMutex
+	productMetadataArgsForCall []struct {
+	}
+	productMetadataReturns struct {
+		result1 *extractor.Metadata
+		result2 error
+	}
+	productMetadataReturnsOnCall map[int]struct {
+		result1 *extractor.Metadata
+		result2 error
+	}
+	SizeStub        func() int64
+	sizeMutex       sync.RWMutex
+	sizeArgsForCall []struct {
+	}
+	sizeReturns struct {
+		result1 int64
+	}
+	sizeReturnsOnCall map[int]struct {
+		result1 int64
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BlobFile) Open() (io.ReadCloser, error) {
+	fake.openMutex.Lock()
+	ret, specificReturn := fake.openReturnsOnCall[len(fake.openArgsForCall)]
+	fake.openArgsForCall = append(fake.openArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Open", []interface{}{})
+	fake.openMutex.Unlock()
+	if fake.OpenStub != nil {
+		return fake.OpenStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.openReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BlobFile) OpenCallCount() int {
+	fake.openMutex.RLock()
+	defer fake.openMutex.RUnlock()
+	return len(fake.openArgsForCall)
+}
+
+func (fake *BlobFile) OpenCalls(stub func() (io.ReadCloser, error)) {
+	fake.openMutex.Lock()
+	defer fake.openMutex.Unlock()
+	fake.OpenStub = stub
+}
+
+func (fake *BlobFile) OpenReturns(result1 io.ReadCloser, result2 error) {
+	fake.openMutex.Lock()
+	defer fake.openMutex.Unlock()
+	fake.OpenStub = nil
+	fake.openReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlobFile) OpenReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.openMutex.Lock()
+	defer fake.openMutex.Unlock()
+	fake.OpenStub = nil
+	if fake.openReturnsOnCall == nil {
+		fake.openReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.openReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlobFile) ProductMetadata() (*extractor.Metadata, error) {
+	fake.productMetadataMutex.Lock()
+	ret, specificReturn := fake.productMetadataReturnsOnCall[len(fake.productMetadataArgsForCall)]
+	fake.productMetadataArgsForCall = append(fake.productMetadataArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ProductMetadata", []interface{}{})
+	fake.productMetadataMutex.Unlock()
+	if fake.ProductMetadataStub != nil {
+		return fake.ProductMetadataStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.productMetadataReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BlobFile) ProductMetadataCallCount() int {
+	fake.productMetadataMutex.RLock()
+	defer fake.productMetadataMutex.RUnlock()
+	return len(fake.productMetadataArgsForCall)
+}
+
+func (fake *BlobFile) ProductMetadataCalls(stub func() (*extractor.Metadata, error)) {
+	fake.productMetadataMutex.Lock()
+	defer fake.productMetadataMutex.Unlock()
+	fake.ProductMetadataStub = stub
+}
+
+func (fake *BlobFile) ProductMetadataReturns(result1 *extractor.Metadata, result2 error) {
+	fake.productMetadataMutex.Lock()
+	defer fake.productMetadataMutex.Unlock()
+	fake.ProductMetadataStub = nil
+	fake.productMetadataReturns = struct {
+		result1 *extractor.Metadata
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlobFile) ProductMetadataReturnsOnCall(i int, result1 *extractor.Metadata, result2 error) {
+	fake.productMetadataMutex.Lock()
+	defer fake.productMetadataMutex.Unlock()
+	fake.ProductMetadataStub = nil
+	if fake.productMetadataReturnsOnCall == nil {
+		fake.productMetadataReturnsOnCall = make(map[int]struct {
+			result1 *extractor.Metadata
+			result2 error
+		})
+	}
+	fake.productMetadataReturnsOnCall[i] = struct {
+		result1 *extractor.Metadata
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BlobFile) Size() int64 {
+	fake.sizeMutex.Lock()
+	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
+	fake.sizeArgsForCall = append(fake.sizeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Size", []interface{}{})
+	fake.sizeMutex.Unlock()
+	if fake.SizeStub != nil {
+		return fake.SizeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sizeReturns
+	return fakeReturns.result1
+}
+
+func (fake *BlobFile) SizeCallCount() int {
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	return len(fake.sizeArgsForCall)
+}
+
+func (fake *BlobFile) SizeCalls(stub func() int64) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = stub
+}
+
+func (fake *BlobFile) SizeReturns(result1 int64) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	fake.sizeReturns = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *BlobFile) SizeReturnsOnCall(i int, result1 int64) {
+	fake.sizeMutex.Lock()
+	defer fake.sizeMutex.Unlock()
+	fake.SizeStub = nil
+	if fake.sizeReturnsOnCall == nil {
+		fake.sizeReturnsOnCall = make(map[int]struct {
+			result1 int64
+		})
+	}
+	fake.sizeReturnsOnCall[i] = struct {
+		result1 int64
+	}{result1}
+}
+
+func (fake *BlobFile) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.openMutex.RLock()
+	defer fake.openMutex.RUnlock()
+	fake.productMetadataMutex.RLock()
+	defer fake.productMetadataMutex.RUnlock()
+	fake.sizeMutex.RLock()
+	defer fake.sizeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BlobFile) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ download_clients.BlobFile = new(BlobFile)
@@ -49,6 +49,21 @@ type ProductDownloader struct {
 		result1 download_clients.FileArtifacter
 		result2 error
 	}
+	GetLatestProductFilesStub        func(string, string, string) ([]download_clients.FileArtifacter, error)
+	getLatestProductFilesMutex       sync.RWMutex
+	getLatestProductFilesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getLatestProductFilesReturns struct {
+		result1 []download_clients.FileArtifacter
+		result2 error
+	}
+	getLatestProductFilesReturnsOnCall map[int]struct {
+		result1 []download_clients.FileArtifacter
+		result2 error
+	}
 	GetLatestStemcellForProductStub        func(download_clients.FileArtifacter, string, string) (download_clients.StemcellArtifacter, error)
 	getLatestStemcellForProductMutex       sync.RWMutex
 	getLatestStemcellForProductArgsForCall []struct {
@@ -267,6 +282,71 @@ func (fake *ProductDownloader) GetLatestProductFileReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *ProductDownloader) GetLatestProductFiles(arg1 string, arg2 string, arg3 string) ([]download_clients.FileArtifacter, error) {
+	fake.getLatestProductFilesMutex.Lock()
+	ret, specificReturn := fake.getLatestProductFilesReturnsOnCall[len(fake.getLatestProductFilesArgsForCall)]
+	fake.getLatestProductFilesArgsForCall = append(fake.getLatestProductFilesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetLatestProductFiles", []interface{}{arg1, arg2, arg3})
+	fake.getLatestProductFilesMutex.Unlock()
+	if fake.GetLatestProductFilesStub != nil {
+		return fake.GetLatestProductFilesStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getLatestProductFilesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ProductDownloader) GetLatestProductFilesCallCount() int {
+	fake.getLatestProductFilesMutex.RLock()
+	defer fake.getLatestProductFilesMutex.RUnlock()
+	return len(fake.getLatestProductFilesArgsForCall)
+}
+
+func (fake *ProductDownloader) GetLatestProductFilesCalls(stub func(string, string, string) ([]download_clients.FileArtifacter, error)) {
+	fake.getLatestProductFilesMutex.Lock()
+	defer fake.getLatestProductFilesMutex.Unlock()
+	fake.GetLatestProductFilesStub = stub
+}
+
+func (fake *ProductDownloader) GetLatestProductFilesArgsForCall(i int) (string, string, string) {
+	fake.getLatestProductFilesMutex.RLock()
+	defer fake.getLatestProductFilesMutex.RUnlock()
+	argsForCall := fake.getLatestProductFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ProductDownloader) GetLatestProductFilesReturns(result1 []download_clients.FileArtifacter, result2 error) {
+	fake.getLatestProductFilesMutex.Lock()
+	defer fake.getLatestProductFilesMutex.Unlock()
+	fake.GetLatestProductFilesStub = nil
+	fake.getLatestProductFilesReturns = struct {
+		result1 []download_clients.FileArtifacter
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductDownloader) GetLatestProductFilesReturnsOnCall(i int, result1 []download_clients.FileArtifacter, result2 error) {
+	fake.getLatestProductFilesMutex.Lock()
+	defer fake.getLatestProductFilesMutex.Unlock()
+	fake.GetLatestProductFilesStub = nil
+	if fake.getLatestProductFilesReturnsOnCall == nil {
+		fake.getLatestProductFilesReturnsOnCall = make(map[int]struct {
+			result1 []download_clients.FileArtifacter
+			result2 error
+		})
+	}
+	fake.getLatestProductFilesReturnsOnCall[i] = struct {
+		result1 []download_clients.FileArtifacter
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ProductDownloader) GetLatestStemcellForProduct(arg1 download_clients.FileArtifacter, arg2 string, arg3 string) (download_clients.StemcellArtifacter, error) {
 	fake.getLatestStemcellForProductMutex.Lock()
 	ret, specificReturn := fake.getLatestStemcellForProductReturnsOnCall[len(fake.getLatestStemcellForProductArgsForCall)]
@@ -275,7 +355,7 @@ func (fake *ProductDownloader) GetLatestStemcellForProduct(arg1 download_clients
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
-	fake.recordInvocation("GetLatestStemcellForProduct", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetLatestStemcellForProduct", []interface{}{arg1, arg2, arg3})
 	fake.getLatestStemcellForProductMutex.Unlock()
 	if fake.GetLatestStemcellForProductStub != nil {
 		return fake.GetLatestStemcellForProductStub(arg1, arg2, arg3)
@@ -393,6 +473,8 @@ func (fake *ProductDownloader) Invocations() map[string][][]interface{} {
 	defer fake.getAllProductVersionsMutex.RUnlock()
 	fake.getLatestProductFileMutex.RLock()
 	defer fake.getLatestProductFileMutex.RUnlock()
+	fake.getLatestProductFilesMutex.RLock()
+	defer fake.getLatestProductFilesMutex.RUnlock()
 	fake.getLatestStemcellForProductMutex.RLock()
 	defer fake.getLatestStemcellForProductMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -0,0 +1,179 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"sync"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+type ProductOpener struct {
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	OpenFileStub        func(string) (download_clients.BlobFile, error)
+	openFileMutex       sync.RWMutex
+	openFileArgsForCall []struct {
+		arg1 string
+	}
+	openFileReturns struct {
+		result1 download_clients.BlobFile
+		result2 error
+	}
+	openFileReturnsOnCall map[int]struct {
+		result1 download_clients.BlobFile
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ProductOpener) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if fake.NameStub != nil {
+		return fake.NameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.nameReturns
+	return fakeReturns.result1
+}
+
+func (fake *ProductOpener) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *ProductOpener) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *ProductOpener) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ProductOpener) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ProductOpener) OpenFile(arg1 string) (download_clients.BlobFile, error) {
+	fake.openFileMutex.Lock()
+	ret, specificReturn := fake.openFileReturnsOnCall[len(fake.openFileArgsForCall)]
+	fake.openFileArgsForCall = append(fake.openFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("OpenFile", []interface{}{arg1})
+	fake.openFileMutex.Unlock()
+	if fake.OpenFileStub != nil {
+		return fake.OpenFileStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.openFileReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ProductOpener) OpenFileCallCount() int {
+	fake.openFileMutex.RLock()
+	defer fake.openFileMutex.RUnlock()
+	return len(fake.openFileArgsForCall)
+}
+
+func (fake *ProductOpener) OpenFileCalls(stub func(string) (download_clients.BlobFile, error)) {
+	fake.openFileMutex.Lock()
+	defer fake.openFileMutex.Unlock()
+	fake.OpenFileStub = stub
+}
+
+func (fake *ProductOpener) OpenFileArgsForCall(i int) string {
+	fake.openFileMutex.RLock()
+	defer fake.openFileMutex.RUnlock()
+	argsForCall := fake.openFileArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ProductOpener) OpenFileReturns(result1 download_clients.BlobFile, result2 error) {
+	fake.openFileMutex.Lock()
+	defer fake.openFileMutex.Unlock()
+	fake.OpenFileStub = nil
+	fake.openFileReturns = struct {
+		result1 download_clients.BlobFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductOpener) OpenFileReturnsOnCall(i int, result1 download_clients.BlobFile, result2 error) {
+	fake.openFileMutex.Lock()
+	defer fake.openFileMutex.Unlock()
+	fake.OpenFileStub = nil
+	if fake.openFileReturnsOnCall == nil {
+		fake.openFileReturnsOnCall = make(map[int]struct {
+			result1 download_clients.BlobFile
+			result2 error
+		})
+	}
+	fake.openFileReturnsOnCall[i] = struct {
+		result1 download_clients.BlobFile
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductOpener) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	fake.openFileMutex.RLock()
+	defer fake.openFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ProductOpener) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ download_clients.ProductOpener = new(ProductOpener)
@@ -0,0 +1,408 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fakes
+
+import (
+	"os"
+	"sync"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+type ProductUploader struct {
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	ProductFileExistsStub        func(string) (bool, error)
+	productFileExistsMutex       sync.RWMutex
+	productFileExistsArgsForCall []struct {
+		arg1 string
+	}
+	productFileExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	productFileExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	StemcellFileExistsStub        func(string) (bool, error)
+	stemcellFileExistsMutex       sync.RWMutex
+	stemcellFileExistsArgsForCall []struct {
+		arg1 string
+	}
+	stemcellFileExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	stemcellFileExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	UploadProductFileStub        func(string, *os.File) error
+	uploadProductFileMutex       sync.RWMutex
+	uploadProductFileArgsForCall []struct {
+		arg1 string
+		arg2 *os.File
+	}
+	uploadProductFileReturns struct {
+		result1 error
+	}
+	uploadProductFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UploadStemcellFileStub        func(string, *os.File) error
+	uploadStemcellFileMutex       sync.RWMutex
+	uploadStemcellFileArgsForCall []struct {
+		arg1 string
+		arg2 *os.File
+	}
+	uploadStemcellFileReturns struct {
+		result1 error
+	}
+	uploadStemcellFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ProductUploader) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if fake.NameStub != nil {
+		return fake.NameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.nameReturns
+	return fakeReturns.result1
+}
+
+func (fake *ProductUploader) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *ProductUploader) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *ProductUploader) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ProductUploader) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *ProductUploader) ProductFileExists(arg1 string) (bool, error) {
+	fake.productFileExistsMutex.Lock()
+	ret, specificReturn := fake.productFileExistsReturnsOnCall[len(fake.productFileExistsArgsForCall)]
+	fake.productFileExistsArgsForCall = append(fake.productFileExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ProductFileExists", []interface{}{arg1})
+	fake.productFileExistsMutex.Unlock()
+	if fake.ProductFileExistsStub != nil {
+		return fake.ProductFileExistsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.productFileExistsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ProductUploader) ProductFileExistsCallCount() int {
+	fake.productFileExistsMutex.RLock()
+	defer fake.productFileExistsMutex.RUnlock()
+	return len(fake.productFileExistsArgsForCall)
+}
+
+func (fake *ProductUploader) ProductFileExistsCalls(stub func(string) (bool, error)) {
+	fake.productFileExistsMutex.Lock()
+	defer fake.productFileExistsMutex.Unlock()
+	fake.ProductFileExistsStub = stub
+}
+
+func (fake *ProductUploader) ProductFileExistsArgsForCall(i int) string {
+	fake.productFileExistsMutex.RLock()
+	defer fake.productFileExistsMutex.RUnlock()
+	argsForCall := fake.productFileExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ProductUploader) ProductFileExistsReturns(result1 bool, result2 error) {
+	fake.productFileExistsMutex.Lock()
+	defer fake.productFileExistsMutex.Unlock()
+	fake.ProductFileExistsStub = nil
+	fake.productFileExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductUploader) ProductFileExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.productFileExistsMutex.Lock()
+	defer fake.productFileExistsMutex.Unlock()
+	fake.ProductFileExistsStub = nil
+	if fake.productFileExistsReturnsOnCall == nil {
+		fake.productFileExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.productFileExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductUploader) StemcellFileExists(arg1 string) (bool, error) {
+	fake.stemcellFileExistsMutex.Lock()
+	ret, specificReturn := fake.stemcellFileExistsReturnsOnCall[len(fake.stemcellFileExistsArgsForCall)]
+	fake.stemcellFileExistsArgsForCall = append(fake.stemcellFileExistsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("StemcellFileExists", []interface{}{arg1})
+	fake.stemcellFileExistsMutex.Unlock()
+	if fake.StemcellFileExistsStub != nil {
+		return fake.StemcellFileExistsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.stemcellFileExistsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ProductUploader) StemcellFileExistsCallCount() int {
+	fake.stemcellFileExistsMutex.RLock()
+	defer fake.stemcellFileExistsMutex.RUnlock()
+	return len(fake.stemcellFileExistsArgsForCall)
+}
+
+func (fake *ProductUploader) StemcellFileExistsCalls(stub func(string) (bool, error)) {
+	fake.stemcellFileExistsMutex.Lock()
+	defer fake.stemcellFileExistsMutex.Unlock()
+	fake.StemcellFileExistsStub = stub
+}
+
+func (fake *ProductUploader) StemcellFileExistsArgsForCall(i int) string {
+	fake.stemcellFileExistsMutex.RLock()
+	defer fake.stemcellFileExistsMutex.RUnlock()
+	argsForCall := fake.stemcellFileExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ProductUploader) StemcellFileExistsReturns(result1 bool, result2 error) {
+	fake.stemcellFileExistsMutex.Lock()
+	defer fake.stemcellFileExistsMutex.Unlock()
+	fake.StemcellFileExistsStub = nil
+	fake.stemcellFileExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductUploader) StemcellFileExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.stemcellFileExistsMutex.Lock()
+	defer fake.stemcellFileExistsMutex.Unlock()
+	fake.StemcellFileExistsStub = nil
+	if fake.stemcellFileExistsReturnsOnCall == nil {
+		fake.stemcellFileExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.stemcellFileExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ProductUploader) UploadProductFile(arg1 string, arg2 *os.File) error {
+	fake.uploadProductFileMutex.Lock()
+	ret, specificReturn := fake.uploadProductFileReturnsOnCall[len(fake.uploadProductFileArgsForCall)]
+	fake.uploadProductFileArgsForCall = append(fake.uploadProductFileArgsForCall, struct {
+		arg1 string
+		arg2 *os.File
+	}{arg1, arg2})
+	fake.recordInvocation("UploadProductFile", []interface{}{arg1, arg2})
+	fake.uploadProductFileMutex.Unlock()
+	if fake.UploadProductFileStub != nil {
+		return fake.UploadProductFileStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.uploadProductFileReturns
+	return fakeReturns.result1
+}
+
+func (fake *ProductUploader) UploadProductFileCallCount() int {
+	fake.uploadProductFileMutex.RLock()
+	defer fake.uploadProductFileMutex.RUnlock()
+	return len(fake.uploadProductFileArgsForCall)
+}
+
+func (fake *ProductUploader) UploadProductFileCalls(stub func(string, *os.File) error) {
+	fake.uploadProductFileMutex.Lock()
+	defer fake.uploadProductFileMutex.Unlock()
+	fake.UploadProductFileStub = stub
+}
+
+func (fake *ProductUploader) UploadProductFileArgsForCall(i int) (string, *os.File) {
+	fake.uploadProductFileMutex.RLock()
+	defer fake.uploadProductFileMutex.RUnlock()
+	argsForCall := fake.uploadProductFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ProductUploader) UploadProductFileReturns(result1 error) {
+	fake.uploadProductFileMutex.Lock()
+	defer fake.uploadProductFileMutex.Unlock()
+	fake.UploadProductFileStub = nil
+	fake.uploadProductFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductUploader) UploadProductFileReturnsOnCall(i int, result1 error) {
+	fake.uploadProductFileMutex.Lock()
+	defer fake.uploadProductFileMutex.Unlock()
+	fake.UploadProductFileStub = nil
+	if fake.uploadProductFileReturnsOnCall == nil {
+		fake.uploadProductFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadProductFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductUploader) UploadStemcellFile(arg1 string, arg2 *os.File) error {
+	fake.uploadStemcellFileMutex.Lock()
+	ret, specificReturn := fake.uploadStemcellFileReturnsOnCall[len(fake.uploadStemcellFileArgsForCall)]
+	fake.uploadStemcellFileArgsForCall = append(fake.uploadStemcellFileArgsForCall, struct {
+		arg1 string
+		arg2 *os.File
+	}{arg1, arg2})
+	fake.recordInvocation("UploadStemcellFile", []interface{}{arg1, arg2})
+	fake.uploadStemcellFileMutex.Unlock()
+	if fake.UploadStemcellFileStub != nil {
+		return fake.UploadStemcellFileStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.uploadStemcellFileReturns
+	return fakeReturns.result1
+}
+
+func (fake *ProductUploader) UploadStemcellFileCallCount() int {
+	fake.uploadStemcellFileMutex.RLock()
+	defer fake.uploadStemcellFileMutex.RUnlock()
+	return len(fake.uploadStemcellFileArgsForCall)
+}
+
+func (fake *ProductUploader) UploadStemcellFileCalls(stub func(string, *os.File) error) {
+	fake.uploadStemcellFileMutex.Lock()
+	defer fake.uploadStemcellFileMutex.Unlock()
+	fake.UploadStemcellFileStub = stub
+}
+
+func (fake *ProductUploader) UploadStemcellFileArgsForCall(i int) (string, *os.File) {
+	fake.uploadStemcellFileMutex.RLock()
+	defer fake.uploadStemcellFileMutex.RUnlock()
+	argsForCall := fake.uploadStemcellFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ProductUploader) UploadStemcellFileReturns(result1 error) {
+	fake.uploadStemcellFileMutex.Lock()
+	defer fake.uploadStemcellFileMutex.Unlock()
+	fake.UploadStemcellFileStub = nil
+	fake.uploadStemcellFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductUploader) UploadStemcellFileReturnsOnCall(i int, result1 error) {
+	fake.uploadStemcellFileMutex.Lock()
+	defer fake.uploadStemcellFileMutex.Unlock()
+	fake.UploadStemcellFileStub = nil
+	if fake.uploadStemcellFileReturnsOnCall == nil {
+		fake.uploadStemcellFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.uploadStemcellFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ProductUploader) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	fake.productFileExistsMutex.RLock()
+	defer fake.productFileExistsMutex.RUnlock()
+	fake.stemcellFileExistsMutex.RLock()
+	defer fake.stemcellFileExistsMutex.RUnlock()
+	fake.uploadProductFileMutex.RLock()
+	defer fake.uploadProductFileMutex.RUnlock()
+	fake.uploadStemcellFileMutex.RLock()
+	defer fake.uploadStemcellFileMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ProductUploader) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ download_clients.ProductUploader = new(ProductUploader)
@@ -41,6 +41,10 @@ func (f PivnetFileArtifact) SHA256() string {
 	return f.productFile.SHA256
 }
 
+func (f PivnetFileArtifact) Size() int64 {
+	return int64(f.productFile.Size)
+}
+
 type stowFileArtifact struct {
 	name   string
 	sha256 string
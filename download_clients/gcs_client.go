@@ -1,19 +1,24 @@
 package download_clients
 
 import (
+	"errors"
+	"log"
+
 	"github.com/graymeta/stow"
 	"github.com/graymeta/stow/google"
 	storage "google.golang.org/api/storage/v1beta2"
 	"gopkg.in/go-playground/validator.v9"
-	"log"
 )
 
 type GCSConfiguration struct {
 	Bucket             string `validate:"required"`
-	ServiceAccountJSON string `validate:"required"`
+	ServiceAccountJSON string
 	ProjectID          string `validate:"required"`
 	ProductPath        string
 	StemcellPath       string
+	AuthType           string
+
+	ParallelConnections int
 }
 
 func NewGCSClient(stower Stower, config GCSConfiguration, stderr *log.Logger) (stowClient, error) {
@@ -23,11 +28,41 @@ func NewGCSClient(stower Stower, config GCSConfiguration, stderr *log.Logger) (s
 		return stowClient{}, err
 	}
 
+	if config.AuthType == "" {
+		config.AuthType = "serviceaccount"
+	}
+
+	err = validateServiceAccountAuthType(config)
+	if err != nil {
+		return stowClient{}, err
+	}
+
+	// with an empty service account JSON, stow falls back to the
+	// Application Default Credentials, which includes GKE Workload Identity
+	serviceAccountJSON := config.ServiceAccountJSON
+	if config.AuthType == "adc" {
+		serviceAccountJSON = ""
+	}
+
 	stowConfig := stow.ConfigMap{
-		google.ConfigJSON:      config.ServiceAccountJSON,
+		google.ConfigJSON:      serviceAccountJSON,
 		google.ConfigProjectId: config.ProjectID,
 		google.ConfigScopes:    storage.DevstorageReadOnlyScope,
 	}
 
-	return NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "google", config.Bucket), nil
+	return NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "google", config.Bucket).WithParallelConnections(config.ParallelConnections), nil
+}
+
+func validateServiceAccountAuthType(config GCSConfiguration) error {
+	switch config.AuthType {
+	case "adc":
+		return nil
+	case "serviceaccount":
+		if config.ServiceAccountJSON == "" {
+			return errors.New("the flag \"gcs-service-account-json\" is required when the \"gcs-auth-type\" is \"serviceaccount\"")
+		}
+		return nil
+	}
+
+	return errors.New("the \"gcs-auth-type\" must be either \"serviceaccount\" or \"adc\"")
 }
@@ -0,0 +1,61 @@
+package download_clients_test
+
+import (
+	"log"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/download_clients"
+)
+
+var _ = Describe("GCSClient", func() {
+	var (
+		stderr *log.Logger
+		config download_clients.GCSConfiguration
+	)
+
+	BeforeEach(func() {
+		stderr = log.New(GinkgoWriter, "", 0)
+		config = download_clients.GCSConfiguration{
+			Bucket:             "bucket",
+			ProjectID:          "project-id",
+			ServiceAccountJSON: `{"type": "service_account"}`,
+		}
+	})
+
+	It("authenticates with the service account by default", func() {
+		client, err := download_clients.NewGCSClient(&mockStower{}, config, stderr)
+		Expect(err).ToNot(HaveOccurred())
+
+		serviceAccountJSON, _ := client.Config.Config("json")
+		Expect(serviceAccountJSON).To(Equal(`{"type": "service_account"}`))
+	})
+
+	It("requires the service account when using the default auth type", func() {
+		config.ServiceAccountJSON = ""
+
+		_, err := download_clients.NewGCSClient(&mockStower{}, config, stderr)
+		Expect(err).To(MatchError(`the flag "gcs-service-account-json" is required when the "gcs-auth-type" is "serviceaccount"`))
+	})
+
+	When("the auth type is adc", func() {
+		It("leaves the service account out so Application Default Credentials are used", func() {
+			config.AuthType = "adc"
+
+			client, err := download_clients.NewGCSClient(&mockStower{}, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			serviceAccountJSON, present := client.Config.Config("json")
+			Expect(present).To(BeTrue())
+			Expect(serviceAccountJSON).To(BeEmpty())
+		})
+	})
+
+	It("rejects unknown auth types", func() {
+		config.AuthType = "metadata"
+
+		_, err := download_clients.NewGCSClient(&mockStower{}, config, stderr)
+		Expect(err).To(MatchError(`the "gcs-auth-type" must be either "serviceaccount" or "adc"`))
+	})
+})
@@ -1,6 +1,7 @@
 package download_clients
 
 import (
+	"io"
 	"os"
 
 	"github.com/pivotal-cf/om/extractor"
@@ -13,6 +14,12 @@ type FileArtifacter interface {
 	ProductMetadata() (*extractor.Metadata, error)
 }
 
+// FileSizer is implemented by the file artifacts of the sources that publish
+// the size of their files.
+type FileSizer interface {
+	Size() int64
+}
+
 //counterfeiter:generate -o ./fakes/stemcell_artifacter.go --fake-name StemcellArtifacter . StemcellArtifacter
 type StemcellArtifacter interface {
 	Slug() string
@@ -24,6 +31,38 @@ type ProductDownloader interface {
 	Name() string
 	GetAllProductVersions(slug string) ([]string, error)
 	GetLatestProductFile(slug, version, glob string) (FileArtifacter, error)
+	GetLatestProductFiles(slug, version, glob string) ([]FileArtifacter, error)
 	DownloadProductToFile(fa FileArtifacter, file *os.File) error
 	GetLatestStemcellForProduct(fa FileArtifacter, downloadedProductFileName string, stemcellSlug string) (StemcellArtifacter, error)
 }
+
+// ProductUploader is a blobstore that products and stemcells can be mirrored
+// to, under the names download-product looks for.
+//
+//counterfeiter:generate -o ./fakes/product_uploader_service.go --fake-name ProductUploader . ProductUploader
+type ProductUploader interface {
+	Name() string
+	ProductFileExists(fileName string) (bool, error)
+	StemcellFileExists(fileName string) (bool, error)
+	UploadProductFile(fileName string, file *os.File) error
+	UploadStemcellFile(fileName string, file *os.File) error
+}
+
+// ProductOpener is a blobstore that products can be read from without
+// downloading them first, e.g. to stream them to Ops Manager.
+//
+//counterfeiter:generate -o ./fakes/product_opener_service.go --fake-name ProductOpener . ProductOpener
+type ProductOpener interface {
+	Name() string
+	OpenFile(fileName string) (BlobFile, error)
+}
+
+// BlobFile is a file in a blobstore. Its metadata is read with byte ranges,
+// so only the end of the product and its metadata file are read.
+//
+//counterfeiter:generate -o ./fakes/blob_file.go --fake-name BlobFile . BlobFile
+type BlobFile interface {
+	Size() int64
+	ProductMetadata() (*extractor.Metadata, error)
+	Open() (io.ReadCloser, error)
+}
@@ -0,0 +1,278 @@
+package download_clients
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"gopkg.in/go-playground/validator.v9"
+
+	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/progress"
+)
+
+// ociTitleAnnotation is the layer annotation ORAS (and Harbor) use to record the
+// original filename of a pushed artifact.
+const ociTitleAnnotation = "org.opencontainers.image.title"
+
+type OCIConfiguration struct {
+	Repository string `validate:"required"`
+	Username   string
+	Password   string
+	Insecure   bool
+}
+
+type ociClient struct {
+	repository    string
+	nameOptions   []name.Option
+	remoteOptions []remote.Option
+	stderr        *log.Logger
+}
+
+// NewOCIClient returns a downloader that pulls artifacts from an OCI registry.
+// Products are expected at <repository>/<slug>:<version>, with each file pushed
+// as a layer annotated with its filename. Credentials come from the docker
+// config (including credential helpers) unless a username is provided.
+// Stemcells are looked up the same way, using their Pivotal Network slug.
+func NewOCIClient(config OCIConfiguration, stderr *log.Logger) (ProductDownloader, error) {
+	validate := validator.New()
+	err := validate.Struct(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameOptions []name.Option
+	transport := remote.DefaultTransport.(*http.Transport).Clone()
+	if config.Insecure {
+		nameOptions = append(nameOptions, name.Insecure)
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	remoteOptions := []remote.Option{
+		remote.WithTransport(transport),
+		remote.WithUserAgent(userAgent),
+	}
+	if config.Username != "" {
+		remoteOptions = append(remoteOptions, remote.WithAuth(&authn.Basic{
+			Username: config.Username,
+			Password: config.Password,
+		}))
+	} else {
+		remoteOptions = append(remoteOptions, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+
+	return &ociClient{
+		repository:    strings.TrimSuffix(config.Repository, "/"),
+		nameOptions:   nameOptions,
+		remoteOptions: remoteOptions,
+		stderr:        stderr,
+	}, nil
+}
+
+func (o *ociClient) Name() string {
+	return "oci"
+}
+
+func (o *ociClient) GetAllProductVersions(slug string) ([]string, error) {
+	repo, err := name.NewRepository(o.repository+"/"+slug, o.nameOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse repository for %s: %w", slug, err)
+	}
+
+	tags, err := remote.List(repo, o.remoteOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("could not list tags for %s: %w", repo, err)
+	}
+
+	var versions []string
+	for _, tag := range tags {
+		versions = append(versions, versionFromTag(tag))
+	}
+
+	return versions, nil
+}
+
+func (o *ociClient) GetLatestProductFile(slug, version, glob string) (FileArtifacter, error) {
+	matchedFiles, availableFiles, err := o.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchedFiles) > 1 {
+		var names []string
+		for _, file := range matchedFiles {
+			names = append(names, file.name)
+		}
+		return nil, fmt.Errorf("the glob '%s' matches multiple files. Write your glob to match exactly one of the following:\n  %s", glob, strings.Join(names, "\n  "))
+	}
+
+	if len(matchedFiles) == 0 {
+		return nil, noOCIFileError(glob, availableFiles)
+	}
+
+	return matchedFiles[0], nil
+}
+
+func (o *ociClient) GetLatestProductFiles(slug, version, glob string) ([]FileArtifacter, error) {
+	matchedFiles, availableFiles, err := o.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matchedFiles) == 0 {
+		return nil, noOCIFileError(glob, availableFiles)
+	}
+
+	var fileArtifacts []FileArtifacter
+	for _, file := range matchedFiles {
+		fileArtifacts = append(fileArtifacts, file)
+	}
+
+	return fileArtifacts, nil
+}
+
+func noOCIFileError(glob string, availableFiles []string) error {
+	if len(availableFiles) == 0 {
+		availableFiles = []string{"none"}
+	}
+	return fmt.Errorf("the glob '%s' matches no file\navailable files: %s", glob, strings.Join(availableFiles, ", "))
+}
+
+func (o *ociClient) matchingFiles(slug, version, glob string) ([]*ociFileArtifact, []string, error) {
+	ref, err := name.NewTag(fmt.Sprintf("%s/%s:%s", o.repository, slug, tagFromVersion(version)), o.nameOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse reference for %s %s: %w", slug, version, err)
+	}
+
+	image, err := remote.Image(ref, o.remoteOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not fetch %s: %w", ref, err)
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read manifest for %s: %w", ref, err)
+	}
+
+	var (
+		availableFiles []string
+		matchedFiles   []*ociFileArtifact
+	)
+	for _, layer := range manifest.Layers {
+		title := layer.Annotations[ociTitleAnnotation]
+		if title == "" {
+			continue
+		}
+		availableFiles = append(availableFiles, title)
+
+		if matched, _ := filepath.Match(glob, title); matched {
+			var sha256 string
+			if layer.Digest.Algorithm == "sha256" {
+				sha256 = layer.Digest.Hex
+			}
+
+			matchedFiles = append(matchedFiles, &ociFileArtifact{
+				name:   title,
+				sha256: sha256,
+				size:   layer.Size,
+				ref:    ref.Context().Digest(layer.Digest.String()),
+			})
+		}
+	}
+
+	return matchedFiles, availableFiles, nil
+}
+
+func (o *ociClient) DownloadProductToFile(fa FileArtifacter, destinationFile *os.File) error {
+	fileArtifact, ok := fa.(*ociFileArtifact)
+	if !ok {
+		return fmt.Errorf("cannot download %s: not an oci artifact", fa.Name())
+	}
+
+	layer, err := remote.Layer(fileArtifact.ref, o.remoteOptions...)
+	if err != nil {
+		return fmt.Errorf("could not fetch %s: %w", fileArtifact.ref, err)
+	}
+
+	size, err := layer.Size()
+	if err != nil {
+		return fmt.Errorf("could not determine size of %s: %w", fileArtifact.name, err)
+	}
+
+	blob, err := layer.Compressed()
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", fileArtifact.name, err)
+	}
+	defer blob.Close()
+
+	progressBar := progress.NewBar(o.stderr.Writer(), "download")
+	progressBar.SetTotal(size)
+	progressBar.Start()
+	defer progressBar.Finish()
+
+	_, err = io.Copy(destinationFile, progressBar.NewProxyReader(blob))
+	if err != nil {
+		return fmt.Errorf("could not download %s: %w", fileArtifact.name, err)
+	}
+
+	return nil
+}
+
+func (o *ociClient) GetLatestStemcellForProduct(_ FileArtifacter, downloadedProductFileName string, _ string) (StemcellArtifacter, error) {
+	definedStemcell, err := stemcellFromProduct(downloadedProductFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err := stemcellVersionPartsFromString(definedStemcell.Version()); err != nil {
+		return nil, err
+	}
+
+	allStemcellVersions, err := o.GetAllProductVersions(definedStemcell.Slug())
+	if err != nil {
+		return nil, fmt.Errorf("could not find stemcells on %s: %s", o.Name(), err)
+	}
+
+	return latestCompatibleStemcell(definedStemcell, allStemcellVersions)
+}
+
+// tagFromVersion maps a product version onto a valid OCI tag. Tags cannot
+// contain '+', so build metadata is pushed with '_' in its place.
+func tagFromVersion(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+func versionFromTag(tag string) string {
+	return strings.ReplaceAll(tag, "_", "+")
+}
+
+type ociFileArtifact struct {
+	name   string
+	sha256 string
+	size   int64
+	ref    name.Digest
+}
+
+func (f ociFileArtifact) ProductMetadata() (*extractor.Metadata, error) {
+	return nil, fmt.Errorf("%w \"%s\"", ErrCannotExtractMetadata, "oci")
+}
+
+func (f ociFileArtifact) Name() string {
+	return f.name
+}
+
+func (f ociFileArtifact) SHA256() string {
+	return f.sha256
+}
+
+func (f ociFileArtifact) Size() int64 {
+	return f.size
+}
@@ -0,0 +1,174 @@
+package download_clients_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/pivotal-cf/om/download_clients"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ociClient", func() {
+	var (
+		server     *httptest.Server
+		repository string
+		client     download_clients.ProductDownloader
+		stderr     *log.Logger
+	)
+
+	push := func(reference string, files map[string]string) {
+		image := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+		for filename, contents := range files {
+			var err error
+			image, err = mutate.Append(image, mutate.Addendum{
+				Layer:       static.NewLayer([]byte(contents), "application/octet-stream"),
+				Annotations: map[string]string{"org.opencontainers.image.title": filename},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		ref, err := name.ParseReference(reference)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(remote.Write(ref, image)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		server = httptest.NewServer(registry.New(registry.Logger(log.New(GinkgoWriter, "", 0))))
+		repository = strings.TrimPrefix(server.URL, "http://") + "/tiles"
+		stderr = log.New(GinkgoWriter, "", 0)
+
+		var err error
+		client, err = download_clients.NewOCIClient(download_clients.OCIConfiguration{
+			Repository: repository,
+			Insecure:   true,
+		}, stderr)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("requires a repository", func() {
+		_, err := download_clients.NewOCIClient(download_clients.OCIConfiguration{}, stderr)
+		Expect(err).To(MatchError(ContainSubstring("Field validation for 'Repository' failed on the 'required' tag")))
+	})
+
+	Describe("GetAllProductVersions", func() {
+		It("returns the tags of the product repository as versions", func() {
+			push(repository+"/srt:2.13.5", map[string]string{"srt-2.13.5.pivotal": "tile"})
+			push(repository+"/srt:2.13.6_LTS-T", map[string]string{"srt-2.13.6.pivotal": "tile"})
+
+			versions, err := client.GetAllProductVersions("srt")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(ConsistOf("2.13.5", "2.13.6+LTS-T"))
+		})
+
+		It("returns an error when the repository does not exist", func() {
+			_, err := client.GetAllProductVersions("missing")
+			Expect(err).To(MatchError(ContainSubstring("could not list tags for " + repository + "/missing")))
+		})
+	})
+
+	Describe("GetLatestProductFile", func() {
+		BeforeEach(func() {
+			push(repository+"/srt:2.13.6_LTS-T", map[string]string{
+				"srt-2.13.6.pivotal": "tile contents",
+				"srt-2.13.6.txt":     "release notes",
+			})
+		})
+
+		It("returns the file matching the glob with its digest as the sha", func() {
+			file, err := client.GetLatestProductFile("srt", "2.13.6+LTS-T", "*.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Name()).To(Equal("srt-2.13.6.pivotal"))
+			Expect(file.SHA256()).To(Equal(fmt.Sprintf("%x", sha256.Sum256([]byte("tile contents")))))
+			Expect(file.(download_clients.FileSizer).Size()).To(Equal(int64(len("tile contents"))))
+
+			_, err = file.ProductMetadata()
+			Expect(err).To(MatchError(download_clients.ErrCannotExtractMetadata))
+		})
+
+		It("returns an error when the glob matches multiple files", func() {
+			_, err := client.GetLatestProductFile("srt", "2.13.6+LTS-T", "srt-*")
+			Expect(err).To(MatchError(ContainSubstring("the glob 'srt-*' matches multiple files")))
+		})
+
+		It("returns an error when the glob matches no files", func() {
+			_, err := client.GetLatestProductFile("srt", "2.13.6+LTS-T", "*.tgz")
+			Expect(err).To(MatchError(ContainSubstring("the glob '*.tgz' matches no file\navailable files: ")))
+		})
+
+		It("returns an error when the version does not exist", func() {
+			_, err := client.GetLatestProductFile("srt", "9.9.9", "*.pivotal")
+			Expect(err).To(MatchError(ContainSubstring("could not fetch " + repository + "/srt:9.9.9")))
+		})
+	})
+
+	Describe("DownloadProductToFile", func() {
+		It("writes the blob to the file", func() {
+			push(repository+"/srt:2.13.5", map[string]string{"srt-2.13.5.pivotal": "tile contents"})
+
+			fileArtifact, err := client.GetLatestProductFile("srt", "2.13.5", "*.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+
+			file, err := os.CreateTemp("", "")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(file.Name())
+
+			err = client.DownloadProductToFile(fileArtifact, file)
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := os.ReadFile(file.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("tile contents"))
+		})
+
+		It("returns an error for artifacts from other sources", func() {
+			file, err := os.CreateTemp("", "")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(file.Name())
+
+			err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+			Expect(err).To(MatchError(ContainSubstring("not an oci artifact")))
+		})
+	})
+
+	Describe("GetLatestStemcellForProduct", func() {
+		It("returns the latest compatible stemcell from the registry", func() {
+			for _, version := range []string{"97.10", "97.28", "97.101", "98.1"} {
+				push(repository+"/stemcells-ubuntu-jammy:"+version, map[string]string{"stemcell.tgz": version})
+			}
+
+			tile := createPivotalFile("[example-product,1.0-build.0]example*pivotal", "ubuntu-jammy", "97.28")
+
+			stemcell, err := client.GetLatestStemcellForProduct(nil, tile, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stemcell.Slug()).To(Equal("stemcells-ubuntu-jammy"))
+			Expect(stemcell.Version()).To(Equal("97.101"))
+		})
+
+		It("returns an error when no compatible stemcell exists", func() {
+			push(repository+"/stemcells-ubuntu-jammy:97.10", map[string]string{"stemcell.tgz": "old"})
+
+			tile := createPivotalFile("[example-product,1.0-build.0]example*pivotal", "ubuntu-jammy", "97.28")
+
+			_, err := client.GetLatestStemcellForProduct(nil, tile, "")
+			Expect(err).To(MatchError("no versions could be found equal to or greater than 97.28"))
+		})
+	})
+})
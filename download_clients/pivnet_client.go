@@ -91,38 +91,70 @@ func (p *pivnetClient) Name() string {
 }
 
 func (p *pivnetClient) GetLatestProductFile(slug, version, glob string) (FileArtifacter, error) {
+	release, productFiles, err := p.productFilesForGlob(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkForSingleProductFile(glob, productFiles); err != nil {
+		return nil, fmt.Errorf("for product version %s: %s", version, err)
+	}
+
+	return &PivnetFileArtifact{
+		releaseID:   release.ID,
+		slug:        slug,
+		productFile: productFiles[0],
+		client:      p.client,
+	}, nil
+}
+
+func (p *pivnetClient) GetLatestProductFiles(slug, version, glob string) ([]FileArtifacter, error) {
+	release, productFiles, err := p.productFilesForGlob(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(productFiles) == 0 {
+		return nil, fmt.Errorf("for product version %s: the glob '%s' matches no file", version, glob)
+	}
+
+	var fileArtifacts []FileArtifacter
+	for _, productFile := range productFiles {
+		fileArtifacts = append(fileArtifacts, &PivnetFileArtifact{
+			releaseID:   release.ID,
+			slug:        slug,
+			productFile: productFile,
+			client:      p.client,
+		})
+	}
+
+	return fileArtifacts, nil
+}
+
+func (p *pivnetClient) productFilesForGlob(slug, version, glob string) (pivnet.Release, []pivnet.ProductFile, error) {
 	// 1. Check the release for given version / slug
 	release, err := p.downloader.ReleaseForVersion(slug, version)
 	if err != nil {
-		return nil, fmt.Errorf("could not fetch the release for %s %s: %s", slug, version, err)
+		return pivnet.Release{}, nil, fmt.Errorf("could not fetch the release for %s %s: %s", slug, version, err)
 	}
 
 	err = p.downloader.AcceptEULA(slug, release.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not accept EULA for download product file %s at version %s: %s", slug, version, err)
+		return pivnet.Release{}, nil, fmt.Errorf("could not accept EULA for download product file %s at version %s: %s", slug, version, err)
 	}
 
 	// 2. Get filename from pivnet
 	productFiles, err := p.downloader.ProductFilesForRelease(slug, release.ID)
 	if err != nil {
-		return nil, fmt.Errorf("could not fetch the product files for %s %s: %s", slug, version, err)
+		return pivnet.Release{}, nil, fmt.Errorf("could not fetch the product files for %s %s: %s", slug, version, err)
 	}
 
 	productFiles, err = p.filter.ProductFileKeysByGlobs(productFiles, []string{glob})
 	if err != nil {
-		return nil, fmt.Errorf("could not glob product files: %s", err)
-	}
-
-	if err := p.checkForSingleProductFile(glob, productFiles); err != nil {
-		return nil, fmt.Errorf("for product version %s: %s", version, err)
+		return pivnet.Release{}, nil, fmt.Errorf("could not glob product files: %s", err)
 	}
 
-	return &PivnetFileArtifact{
-		releaseID:   release.ID,
-		slug:        slug,
-		productFile: productFiles[0],
-		client:      p.client,
-	}, nil
+	return release, productFiles, nil
 }
 
 func (p *pivnetClient) DownloadProductToFile(fa FileArtifacter, file *os.File) error {
@@ -247,6 +247,22 @@ var _ = Describe("PivnetClient", func() {
 			_, err := client.GetLatestProductFile("someslug", "1.0.0", "*.zip")
 			Expect(err).To(MatchError(ContainSubstring("the glob '*.zip' matches multiple files.")))
 		})
+
+		It("returns every file matching the glob when asked for all of them", func() {
+			fakePivnetDownloader.ReleaseForVersionReturns(createRelease("1.0.0"), nil)
+			fakePivnetDownloader.ProductFilesForReleaseReturns([]pivnet.ProductFile{
+				createProductFile("someslug.zip"),
+				createProductFile("anotherslug.zip"),
+				createProductFile("someslug.pivotal"),
+			}, nil)
+
+			client := download_clients.NewPivnetClient(stdout, stderr, fakePivnetFactory, "", true, "")
+			fileArtifacts, err := client.GetLatestProductFiles("someslug", "1.0.0", "*.zip")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fileArtifacts).To(HaveLen(2))
+			Expect(fileArtifacts[0].Name()).To(Equal("someslug.zip"))
+			Expect(fileArtifacts[1].Name()).To(Equal("anotherslug.zip"))
+		})
 	})
 
 	Context("DownloadProductToFile", func() {
@@ -2,6 +2,7 @@ package download_clients
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"strconv"
 
@@ -14,6 +15,9 @@ type S3Configuration struct {
 	Bucket          string `validate:"required"`
 	AccessKeyID     string
 	SecretAccessKey string
+	SessionToken    string
+	RoleARN         string
+	ExternalID      string
 	RegionName      string `validate:"required"`
 	Endpoint        string
 	DisableSSL      bool
@@ -21,6 +25,12 @@ type S3Configuration struct {
 	ProductPath     string
 	StemcellPath    string
 	AuthType        string
+
+	CACert               string
+	AddressingStyle      string
+	SkipRegionValidation bool
+
+	ParallelConnections int
 }
 
 func NewS3Client(stower Stower, config S3Configuration, stderr *log.Logger) (stowClient, error) {
@@ -41,6 +51,10 @@ func NewS3Client(stower Stower, config S3Configuration, stderr *log.Logger) (sto
 		return stowClient{}, err
 	}
 
+	if config.ExternalID != "" && config.RoleARN == "" {
+		return stowClient{}, errors.New("the flag \"s3-external-id\" can only be used with \"s3-role-arn\"")
+	}
+
 	stowConfig := stow.ConfigMap{
 		s3.ConfigAccessKeyID: config.AccessKeyID,
 		s3.ConfigSecretKey:   config.SecretAccessKey,
@@ -51,7 +65,47 @@ func NewS3Client(stower Stower, config S3Configuration, stderr *log.Logger) (sto
 		s3.ConfigAuthType:    config.AuthType,
 	}
 
-	return NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "s3", config.Bucket), nil
+	pathStyle, err := s3PathStyle(config)
+	if err != nil {
+		return stowClient{}, err
+	}
+
+	client := NewStowClient(stower, stderr, stowConfig, config.ProductPath, config.StemcellPath, "s3", config.Bucket).WithParallelConnections(config.ParallelConnections)
+
+	// the stow s3 location always uses path-style addressing with a custom
+	// endpoint, so it is only dialled when nothing else is asked for
+	if config.SessionToken == "" && config.RoleARN == "" && config.CACert == "" && !config.SkipRegionValidation && pathStyle == (config.Endpoint != "") {
+		return client, nil
+	}
+
+	if config.EnableV2Signing {
+		return stowClient{}, errors.New("v2 signing cannot be used with \"s3-session-token\", \"s3-role-arn\", \"s3-ca-cert\", \"s3-addressing-style\" or \"s3-skip-region-validation\"")
+	}
+
+	stowConfig[s3ConfigSessionToken] = config.SessionToken
+	stowConfig[s3ConfigRoleARN] = config.RoleARN
+	stowConfig[s3ConfigExternalID] = config.ExternalID
+	stowConfig[s3ConfigCACert] = config.CACert
+	stowConfig[s3ConfigPathStyle] = strconv.FormatBool(pathStyle)
+	stowConfig[s3ConfigSkipRegionValidation] = strconv.FormatBool(config.SkipRegionValidation)
+
+	return client.withDialKind(s3ExtendedKind), nil
+}
+
+// s3PathStyle reports whether the bucket goes in the path of the URL rather
+// than its host name. By default, that is the case for custom endpoints,
+// which are rarely set up with a wildcard DNS entry for their buckets.
+func s3PathStyle(config S3Configuration) (bool, error) {
+	switch config.AddressingStyle {
+	case "", "auto":
+		return config.Endpoint != "", nil
+	case "path":
+		return true, nil
+	case "virtual":
+		return false, nil
+	default:
+		return false, fmt.Errorf("the flag \"s3-addressing-style\" must be one of auto, path, or virtual, got %q", config.AddressingStyle)
+	}
 }
 
 func validateAccessKeyAuthType(config S3Configuration) error {
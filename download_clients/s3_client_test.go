@@ -2,9 +2,11 @@ package download_clients_test
 
 import (
 	"archive/zip"
+	"encoding/pem"
 	"errors"
 	"io"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"strings"
@@ -13,6 +15,7 @@ import (
 	"github.com/graymeta/stow"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 
 	"github.com/pivotal-cf/om/download_clients"
 )
@@ -153,6 +156,239 @@ var _ = Describe("S3Client", func() {
 		})
 	})
 
+	Describe("temporary credentials", func() {
+		var config download_clients.S3Configuration
+
+		BeforeEach(func() {
+			config = download_clients.S3Configuration{
+				Bucket:          "bucket",
+				AccessKeyID:     "access-key-id",
+				SecretAccessKey: "secret-access-key",
+				RegionName:      "us-west-2",
+			}
+		})
+
+		It("uses the stow s3 location when no session token or role is given", func() {
+			stower := newMockStower([]mockItem{newMockItem("[product-slug,1.1.1]somefile-0.0.2.zip")})
+			client, err := download_clients.NewS3Client(stower, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.GetAllProductVersions("product-slug")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stower.kind).To(Equal("s3"))
+		})
+
+		It("assumes the role with the external id", func() {
+			config.RoleARN = "arn:aws:iam::123456789012:role/tiles"
+			config.ExternalID = "some-external-id"
+
+			stower := newMockStower([]mockItem{newMockItem("[product-slug,1.1.1]somefile-0.0.2.zip")})
+			client, err := download_clients.NewS3Client(stower, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.GetAllProductVersions("product-slug")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stower.kind).To(Equal("s3-extended"))
+			Expect(client.Name()).To(Equal("s3"))
+
+			roleARN, _ := stower.config.Config("role_arn")
+			Expect(roleARN).To(Equal("arn:aws:iam::123456789012:role/tiles"))
+			externalID, _ := stower.config.Config("external_id")
+			Expect(externalID).To(Equal("some-external-id"))
+		})
+
+		It("sends the session token with each request", func() {
+			server := ghttp.NewServer()
+			defer server.Close()
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("HEAD", "/bucket"),
+				func(w http.ResponseWriter, r *http.Request) {
+					Expect(r.Header.Get("X-Amz-Security-Token")).To(Equal("some-session-token"))
+				},
+			))
+
+			config.SessionToken = "some-session-token"
+			config.Endpoint = server.URL()
+
+			client, err := download_clients.NewS3Client(download_clients.StowWrapper{}, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			location, err := download_clients.StowWrapper{}.Dial("s3-extended", client.Config)
+			Expect(err).ToNot(HaveOccurred())
+
+			container, err := location.Container("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(container.ID()).To(Equal("bucket"))
+		})
+
+		It("requires a role for the external id", func() {
+			config.ExternalID = "some-external-id"
+
+			_, err := download_clients.NewS3Client(&mockStower{}, config, stderr)
+			Expect(err).To(MatchError(`the flag "s3-external-id" can only be used with "s3-role-arn"`))
+		})
+
+		It("does not support v2 signing", func() {
+			config.SessionToken = "some-session-token"
+			config.EnableV2Signing = true
+
+			_, err := download_clients.NewS3Client(&mockStower{}, config, stderr)
+			Expect(err).To(MatchError(`v2 signing cannot be used with "s3-session-token", "s3-role-arn", "s3-ca-cert", "s3-addressing-style" or "s3-skip-region-validation"`))
+		})
+	})
+
+	Describe("mirroring files", func() {
+		var (
+			config download_clients.S3Configuration
+			stower *mockStower
+		)
+
+		BeforeEach(func() {
+			config = download_clients.S3Configuration{
+				Bucket:          "bucket",
+				AccessKeyID:     "access-key-id",
+				SecretAccessKey: "secret-access-key",
+				RegionName:      "region",
+				ProductPath:     "/tiles/",
+				StemcellPath:    "stemcells",
+			}
+
+			stower = newMockStower([]mockItem{newMockItem("tiles/[product-slug,1.1.1]product.pivotal")})
+			stower.location = mockLocation{container: &mockContainer{uploaded: map[string]string{}}}
+		})
+
+		It("checks for the file under the product or stemcell path", func() {
+			client, err := download_clients.NewS3Client(stower, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			exists, err := client.ProductFileExists("[product-slug,1.1.1]product.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+
+			exists, err = client.StemcellFileExists("[product-slug,1.1.1]product.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+
+		It("uploads the file under the product or stemcell path", func() {
+			client, err := download_clients.NewS3Client(stower, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			file, err := os.CreateTemp("", "")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(file.Name())
+			_, err = file.WriteString("stemcell contents")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = file.Seek(0, io.SeekStart)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = client.UploadStemcellFile("[stemcells-ubuntu-jammy,1.1]light-bosh-stemcell.tgz", file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stower.location.container.uploaded).To(Equal(map[string]string{
+				"stemcells/[stemcells-ubuntu-jammy,1.1]light-bosh-stemcell.tgz": "stemcell contents",
+			}))
+		})
+	})
+
+	Describe("s3 compatible stores", func() {
+		var config download_clients.S3Configuration
+
+		BeforeEach(func() {
+			config = download_clients.S3Configuration{
+				Bucket:          "bucket",
+				AccessKeyID:     "access-key-id",
+				SecretAccessKey: "secret-access-key",
+				RegionName:      "region",
+				Endpoint:        "https://minio.example.com",
+			}
+		})
+
+		It("uses the stow s3 location for path-style addressing on a custom endpoint", func() {
+			config.AddressingStyle = "path"
+
+			stower := newMockStower([]mockItem{newMockItem("[product-slug,1.1.1]somefile-0.0.2.zip")})
+			client, err := download_clients.NewS3Client(stower, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.GetAllProductVersions("product-slug")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stower.kind).To(Equal("s3"))
+		})
+
+		It("supports virtual-hosted addressing on a custom endpoint", func() {
+			config.AddressingStyle = "virtual"
+
+			stower := newMockStower([]mockItem{newMockItem("[product-slug,1.1.1]somefile-0.0.2.zip")})
+			client, err := download_clients.NewS3Client(stower, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = client.GetAllProductVersions("product-slug")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stower.kind).To(Equal("s3-extended"))
+
+			pathStyle, _ := stower.config.Config("path_style")
+			Expect(pathStyle).To(Equal("false"))
+		})
+
+		It("errors on an unknown addressing style", func() {
+			config.AddressingStyle = "dns"
+
+			_, err := download_clients.NewS3Client(&mockStower{}, config, stderr)
+			Expect(err).To(MatchError(`the flag "s3-addressing-style" must be one of auto, path, or virtual, got "dns"`))
+		})
+
+		It("trusts the custom CA", func() {
+			server := ghttp.NewTLSServer()
+			defer server.Close()
+			server.AppendHandlers(ghttp.VerifyRequest("HEAD", "/bucket"))
+
+			config.Endpoint = server.URL()
+			config.CACert = string(pem.EncodeToMemory(&pem.Block{
+				Type:  "CERTIFICATE",
+				Bytes: server.HTTPTestServer.Certificate().Raw,
+			}))
+
+			client, err := download_clients.NewS3Client(download_clients.StowWrapper{}, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			location, err := download_clients.StowWrapper{}.Dial("s3-extended", client.Config)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = location.Container("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("errors when the CA cannot be used", func() {
+			config.CACert = "-----BEGIN CERTIFICATE-----\nnot a cert\n-----END CERTIFICATE-----"
+
+			client, err := download_clients.NewS3Client(download_clients.StowWrapper{}, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = download_clients.StowWrapper{}.Dial("s3-extended", client.Config)
+			Expect(err).To(MatchError(ContainSubstring("could not use the s3 ca cert: no certificates found")))
+		})
+
+		It("does not check the bucket when region validation is skipped", func() {
+			server := ghttp.NewServer()
+			defer server.Close()
+
+			config.Endpoint = server.URL()
+			config.SkipRegionValidation = true
+
+			client, err := download_clients.NewS3Client(download_clients.StowWrapper{}, config, stderr)
+			Expect(err).ToNot(HaveOccurred())
+
+			location, err := download_clients.StowWrapper{}.Dial("s3-extended", client.Config)
+			Expect(err).ToNot(HaveOccurred())
+
+			container, err := location.Container("bucket")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(container.ID()).To(Equal("bucket"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
 	It("returns an error on stower failure", func() {
 		dialError := errors.New("dial error")
 		itemsList := []mockItem{{}}
@@ -182,6 +418,7 @@ type mockStower struct {
 	dialCallCount int
 	dialError     error
 	config        download_clients.StowConfiger
+	kind          string
 }
 
 func newMockStower(itemsList []mockItem) *mockStower {
@@ -192,6 +429,7 @@ func newMockStower(itemsList []mockItem) *mockStower {
 
 func (s *mockStower) Dial(kind string, config download_clients.StowConfiger) (stow.Location, error) {
 	s.config = config
+	s.kind = kind
 	s.dialCallCount++
 	if s.dialError != nil {
 		return nil, s.dialError
@@ -234,7 +472,8 @@ func (m mockLocation) ItemByURL(url *url.URL) (stow.Item, error) {
 }
 
 type mockContainer struct {
-	item mockItem
+	item     stow.Item
+	uploaded map[string]string
 }
 
 func (m mockContainer) ID() string {
@@ -253,6 +492,13 @@ func (m mockContainer) RemoveItem(id string) error {
 	return nil
 }
 func (m mockContainer) Put(name string, r io.Reader, size int64, metadata map[string]interface{}) (stow.Item, error) {
+	if m.uploaded != nil {
+		contents, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		m.uploaded[name] = string(contents)
+	}
 	return mockItem{}, nil
 }
 
@@ -0,0 +1,335 @@
+package download_clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/graymeta/stow"
+	stows3 "github.com/graymeta/stow/s3"
+
+	"github.com/pivotal-cf/om/network"
+)
+
+// s3ExtendedKind is a stow location for S3 that, unlike the stow
+// s3 location, accepts session tokens, can assume a role through STS before
+// accessing the bucket, and covers what S3-compatible stores such as MinIO
+// need: a choice of addressing style, a custom CA and no bucket region check.
+const s3ExtendedKind = "s3-extended"
+
+const (
+	s3ConfigSessionToken = "session_token"
+	s3ConfigRoleARN      = "role_arn"
+	s3ConfigExternalID   = "external_id"
+
+	s3ConfigCACert               = "ca_cert"
+	s3ConfigPathStyle            = "path_style"
+	s3ConfigSkipRegionValidation = "skip_region_validation"
+)
+
+const s3RoleSessionName = "om-download-product"
+
+var errS3ExtendedUnsupported = errors.New("the s3-extended location does not create or remove buckets or objects")
+
+func init() {
+	validatefn := func(config stow.Config) error {
+		_, err := newS3ExtendedClient(config)
+		return err
+	}
+	makefn := func(config stow.Config) (stow.Location, error) {
+		client, err := newS3ExtendedClient(config)
+		if err != nil {
+			return nil, err
+		}
+		skipRegionValidation, _ := config.Config(s3ConfigSkipRegionValidation)
+		return &s3ExtendedLocation{client: client, skipRegionValidation: skipRegionValidation == "true"}, nil
+	}
+	kindfn := func(u *url.URL) bool {
+		return u.Scheme == s3ExtendedKind
+	}
+	stow.Register(s3ExtendedKind, makefn, kindfn, validatefn)
+}
+
+func newS3ExtendedClient(config stow.Config) (*s3.S3, error) {
+	region, _ := config.Config(stows3.ConfigRegion)
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	if v2Signing, _ := config.Config(stows3.ConfigV2Signing); v2Signing == "true" {
+		return nil, errors.New("the s3-extended location does not support v2 signing")
+	}
+
+	awsConfig := aws.NewConfig().WithRegion(region)
+
+	// iam leaves the credentials to the default chain: environment,
+	// shared config, web identity and instance roles
+	if authType, _ := config.Config(stows3.ConfigAuthType); authType != "iam" {
+		accessKeyID, _ := config.Config(stows3.ConfigAccessKeyID)
+		secretKey, _ := config.Config(stows3.ConfigSecretKey)
+		sessionToken, _ := config.Config(s3ConfigSessionToken)
+		awsConfig.WithCredentials(credentials.NewStaticCredentials(accessKeyID, secretKey, sessionToken))
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the s3 session: %w", err)
+	}
+
+	// the endpoint and CA only apply to s3, STS is always AWS's own
+	s3Config := aws.NewConfig()
+	if caCert, _ := config.Config(s3ConfigCACert); caCert != "" {
+		httpClient, err := s3HTTPClient(caCert)
+		if err != nil {
+			return nil, err
+		}
+		s3Config.WithHTTPClient(httpClient)
+	}
+	if endpoint, _ := config.Config(stows3.ConfigEndpoint); endpoint != "" {
+		s3Config.WithEndpoint(endpoint)
+	}
+	if pathStyle, _ := config.Config(s3ConfigPathStyle); pathStyle == "true" {
+		s3Config.WithS3ForcePathStyle(true)
+	}
+	if disableSSL, _ := config.Config(stows3.ConfigDisableSSL); disableSSL == "true" {
+		s3Config.WithDisableSSL(true)
+	}
+
+	if roleARN, _ := config.Config(s3ConfigRoleARN); roleARN != "" {
+		externalID, _ := config.Config(s3ConfigExternalID)
+		s3Config.WithCredentials(stscreds.NewCredentials(sess, roleARN, func(p *stscreds.AssumeRoleProvider) {
+			p.RoleSessionName = s3RoleSessionName
+			if externalID != "" {
+				p.ExternalID = aws.String(externalID)
+			}
+		}))
+	}
+
+	return s3.New(sess, s3Config), nil
+}
+
+type s3ExtendedLocation struct {
+	client               *s3.S3
+	skipRegionValidation bool
+}
+
+// s3HTTPClient trusts the CA certificate, given as a path or PEM, in
+// addition to the system roots. It is set on the s3 client rather than the
+// session so AWS_CA_BUNDLE, which the session applies, does not replace it.
+func s3HTTPClient(caCert string) (*http.Client, error) {
+	caCert, err := network.ReadPEM(caCert)
+	if err != nil {
+		return nil, fmt.Errorf("could not load the s3 ca cert from file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(caCert)) {
+		return nil, errors.New("could not use the s3 ca cert: no certificates found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func (l *s3ExtendedLocation) Close() error {
+	return nil
+}
+
+// Container checks the bucket can be reached in the configured region,
+// unless asked not to for stores that answer HEAD on a bucket with an error
+// when the region does not match one of their own.
+func (l *s3ExtendedLocation) Container(id string) (stow.Container, error) {
+	if l.skipRegionValidation {
+		return &s3ExtendedContainer{client: l.client, bucket: id}, nil
+	}
+
+	_, err := l.client.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(id)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3ExtendedContainer{client: l.client, bucket: id}, nil
+}
+
+func (l *s3ExtendedLocation) CreateContainer(string) (stow.Container, error) {
+	return nil, errS3ExtendedUnsupported
+}
+
+func (l *s3ExtendedLocation) Containers(string, string, int) ([]stow.Container, string, error) {
+	return nil, "", errors.New("the s3-extended location does not list buckets")
+}
+
+func (l *s3ExtendedLocation) RemoveContainer(string) error {
+	return errS3ExtendedUnsupported
+}
+
+func (l *s3ExtendedLocation) ItemByURL(*url.URL) (stow.Item, error) {
+	return nil, errors.New("the s3-extended location does not look up items by url")
+}
+
+type s3ExtendedContainer struct {
+	client *s3.S3
+	bucket string
+}
+
+func (c *s3ExtendedContainer) ID() string {
+	return c.bucket
+}
+
+func (c *s3ExtendedContainer) Name() string {
+	return c.bucket
+}
+
+func (c *s3ExtendedContainer) Item(id string) (stow.Item, error) {
+	head, err := c.client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3ExtendedItem{
+		container:    c,
+		key:          id,
+		size:         aws.Int64Value(head.ContentLength),
+		etag:         aws.StringValue(head.ETag),
+		lastModified: aws.TimeValue(head.LastModified),
+		etagIsMD5:    aws.StringValue(head.SSECustomerAlgorithm) == "" && !strings.HasPrefix(aws.StringValue(head.ServerSideEncryption), "aws:kms"),
+	}, nil
+}
+
+func (c *s3ExtendedContainer) Items(prefix, cursor string, count int) ([]stow.Item, string, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(c.bucket),
+		MaxKeys: aws.Int64(int64(count)),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if cursor != "" {
+		input.ContinuationToken = aws.String(cursor)
+	}
+
+	response, err := c.client.ListObjectsV2(input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]stow.Item, len(response.Contents))
+	for i, object := range response.Contents {
+		items[i] = &s3ExtendedItem{
+			container:    c,
+			key:          aws.StringValue(object.Key),
+			size:         aws.Int64Value(object.Size),
+			etag:         aws.StringValue(object.ETag),
+			lastModified: aws.TimeValue(object.LastModified),
+		}
+	}
+
+	return items, aws.StringValue(response.NextContinuationToken), nil
+}
+
+func (c *s3ExtendedContainer) RemoveItem(string) error {
+	return errS3ExtendedUnsupported
+}
+
+// Put uploads in parts, as the stow s3 location does, so tiles larger than
+// the 5GB limit of a single PUT can be mirrored.
+func (c *s3ExtendedContainer) Put(name string, r io.Reader, size int64, _ map[string]interface{}) (stow.Item, error) {
+	_, err := s3manager.NewUploaderWithClient(c.client).Upload(&s3manager.UploadInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(name),
+		Body:   r,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3ExtendedItem{container: c, key: name, size: size, lastModified: time.Now()}, nil
+}
+
+type s3ExtendedItem struct {
+	container    *s3ExtendedContainer
+	key          string
+	size         int64
+	etag         string
+	lastModified time.Time
+	// etagIsMD5 is only known for the items looked up by name, the ETag of
+	// an object encrypted with SSE-KMS or SSE-C is not its MD5
+	etagIsMD5 bool
+}
+
+func (i *s3ExtendedItem) ID() string {
+	return i.key
+}
+
+func (i *s3ExtendedItem) Name() string {
+	return i.key
+}
+
+func (i *s3ExtendedItem) URL() *url.URL {
+	return &url.URL{Scheme: "s3", Host: i.container.bucket, Path: "/" + i.key}
+}
+
+func (i *s3ExtendedItem) Size() (int64, error) {
+	return i.size, nil
+}
+
+func (i *s3ExtendedItem) Open() (io.ReadCloser, error) {
+	return i.get(nil)
+}
+
+func (i *s3ExtendedItem) OpenRange(start, end uint64) (io.ReadCloser, error) {
+	return i.get(aws.String(fmt.Sprintf("bytes=%d-%d", start, end)))
+}
+
+func (i *s3ExtendedItem) get(byteRange *string) (io.ReadCloser, error) {
+	response, err := i.container.client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(i.container.bucket),
+		Key:    aws.String(i.key),
+		Range:  byteRange,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (i *s3ExtendedItem) ETag() (string, error) {
+	return i.etag, nil
+}
+
+func (i *s3ExtendedItem) PublishedMD5() string {
+	if !i.etagIsMD5 {
+		return ""
+	}
+
+	return etagMD5(i.etag)
+}
+
+func (i *s3ExtendedItem) LastMod() (time.Time, error) {
+	return i.lastModified, nil
+}
+
+func (i *s3ExtendedItem) Metadata() (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
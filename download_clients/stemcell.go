@@ -1,5 +1,36 @@
 package download_clients
 
+import "fmt"
+
+var stemcellNameToPivnetProductName = map[string]string{
+	"ubuntu-jammy":  "stemcells-ubuntu-jammy",
+	"ubuntu-xenial": "stemcells-ubuntu-xenial",
+	"ubuntu-trusty": "stemcells",
+	"windows2016":   "stemcells-windows-server",
+	"windows1803":   "stemcells-windows-server",
+	"windows2019":   "stemcells-windows-server",
+	"windows2025":   "stemcells-windows-server",
+	"ubuntu-noble":  "stemcells-ubuntu-noble",
+}
+
+// StemcellSlug returns the slug the stemcells of an operating system (ie
+// 'ubuntu-jammy') are published under.
+func StemcellSlug(os string) (string, bool) {
+	slug, ok := stemcellNameToPivnetProductName[os]
+	return slug, ok
+}
+
+// LatestCompatibleStemcellVersion picks the highest version from versions that
+// satisfies the stemcell version a product requires.
+func LatestCompatibleStemcellVersion(requiredVersion string, versions []string) (string, error) {
+	latest, err := latestCompatibleStemcell(&stemcell{version: requiredVersion}, versions)
+	if err != nil {
+		return "", err
+	}
+
+	return latest.Version(), nil
+}
+
 type stemcell struct {
 	slug    string
 	version string
@@ -12,3 +43,35 @@ func (s stemcell) Slug() string {
 func (s stemcell) Version() string {
 	return s.version
 }
+
+// latestCompatibleStemcell picks the highest version from versions that shares
+// the major version of the defined stemcell and is not older than it.
+func latestCompatibleStemcell(definedStemcell *stemcell, versions []string) (*stemcell, error) {
+	definedMajor, definedPatch, err := stemcellVersionPartsFromString(definedStemcell.Version())
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredVersions []string
+	for _, version := range versions {
+		major, patch, _ := stemcellVersionPartsFromString(version)
+
+		if major == definedMajor && patch >= definedPatch {
+			filteredVersions = append(filteredVersions, version)
+		}
+	}
+
+	if len(filteredVersions) == 0 {
+		return nil, fmt.Errorf("no versions could be found equal to or greater than %s", definedStemcell.Version())
+	}
+
+	latestVersion, err := getLatestStemcellVersion(filteredVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stemcell{
+		version: latestVersion,
+		slug:    definedStemcell.Slug(),
+	}, nil
+}
@@ -1,6 +1,8 @@
 package download_clients
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"log"
@@ -8,11 +10,13 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 
-	"github.com/cheggaaa/pb/v3"
+	"cloud.google.com/go/storage"
 	"github.com/graymeta/stow"
 
 	"github.com/pivotal-cf/om/extractor"
+	"github.com/pivotal-cf/om/progress"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -38,13 +42,15 @@ func (d StowWrapper) Walk(container stow.Container, prefix string, pageSize int,
 }
 
 type stowClient struct {
-	stower       Stower
-	bucket       string
-	Config       stow.Config
-	productPath  string
-	stemcellPath string
-	kind         string
-	stderr       *log.Logger
+	stower              Stower
+	bucket              string
+	Config              stow.Config
+	productPath         string
+	stemcellPath        string
+	kind                string
+	dialKind            string
+	stderr              *log.Logger
+	parallelConnections int
 }
 
 func NewStowClient(stower Stower, stderr *log.Logger, config stow.ConfigMap, productPath string, stemcellPath string, kind string, bucket string) stowClient {
@@ -55,16 +61,40 @@ func NewStowClient(stower Stower, stderr *log.Logger, config stow.ConfigMap, pro
 		productPath:  productPath,
 		stemcellPath: stemcellPath,
 		kind:         kind,
+		dialKind:     kind,
 		stderr:       stderr,
 	}
 }
 
+// WithParallelConnections returns a copy of the client that downloads files
+// as n concurrent byte ranges when the underlying blobstore supports it.
+func (s stowClient) WithParallelConnections(n int) stowClient {
+	s.parallelConnections = n
+	return s
+}
+
+// withDialKind returns a copy of the client that connects using a different
+// stow location than the one it is named after.
+func (s stowClient) withDialKind(kind string) stowClient {
+	s.dialKind = kind
+	return s
+}
+
 func (s stowClient) Name() string {
 	return s.kind
 }
 
 func (s stowClient) GetAllProductVersions(slug string) ([]string, error) {
-	return s.getAllProductVersionsFromPath(slug, s.productPath)
+	versions, err := s.getAllProductVersionsFromPath(slug, s.productPath)
+	if err != nil && s.stemcellPath != s.productPath {
+		// stemcells are looked up by their slug too, e.g. by download-required-stemcells
+		stemcellVersions, stemcellErr := s.getAllProductVersionsFromPath(slug, s.stemcellPath)
+		if stemcellErr == nil {
+			return stemcellVersions, nil
+		}
+	}
+
+	return versions, err
 }
 
 func (s stowClient) getAllProductVersionsFromPath(slug, path string) ([]string, error) {
@@ -127,7 +157,7 @@ func (s *stowClient) listFiles() ([]string, error) {
 }
 
 func (s *stowClient) getContainer() (stow.Container, error) {
-	location, err := s.stower.Dial(s.kind, s.Config)
+	location, err := s.stower.Dial(s.dialKind, s.Config)
 	if err != nil {
 		return nil, err
 	}
@@ -152,11 +182,54 @@ func (s *stowClient) getContainer() (stow.Container, error) {
 }
 
 func (s stowClient) GetLatestProductFile(slug, version, glob string) (FileArtifacter, error) {
-	files, err := s.listFiles()
+	prefixedFilepaths, globMatchedFilepaths, err := s.matchingFiles(slug, version, glob)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(globMatchedFilepaths) > 1 {
+		return nil, fmt.Errorf("the glob '%s' matches multiple files. Write your glob to match exactly one of the following:\n  %s", glob, strings.Join(globMatchedFilepaths, "\n  "))
+	}
+
+	if len(globMatchedFilepaths) == 0 {
+		return nil, noStowFileError(glob, prefixedFilepaths)
+	}
+
+	return &stowFileArtifact{name: globMatchedFilepaths[0], source: s.kind}, nil
+}
+
+func (s stowClient) GetLatestProductFiles(slug, version, glob string) ([]FileArtifacter, error) {
+	prefixedFilepaths, globMatchedFilepaths, err := s.matchingFiles(slug, version, glob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(globMatchedFilepaths) == 0 {
+		return nil, noStowFileError(glob, prefixedFilepaths)
+	}
+
+	var fileArtifacts []FileArtifacter
+	for _, filepath := range globMatchedFilepaths {
+		fileArtifacts = append(fileArtifacts, &stowFileArtifact{name: filepath, source: s.kind})
+	}
+
+	return fileArtifacts, nil
+}
+
+func noStowFileError(glob string, prefixedFilepaths []string) error {
+	availableFiles := strings.Join(prefixedFilepaths, ", ")
+	if availableFiles == "" {
+		availableFiles = "none"
+	}
+	return fmt.Errorf("the glob '%s' matches no file\navailable files: %s", glob, availableFiles)
+}
+
+func (s stowClient) matchingFiles(slug, version, glob string) ([]string, []string, error) {
+	files, err := s.listFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	validFile := regexp.MustCompile(
 		fmt.Sprintf(`^/?(%s|%s)/?\[%s,%s\]`,
 			regexp.QuoteMeta(strings.Trim(s.productPath, "/")),
@@ -175,7 +248,7 @@ func (s stowClient) GetLatestProductFile(slug, version, glob string) (FileArtifa
 	}
 
 	if len(prefixedFilepaths) == 0 {
-		return nil, fmt.Errorf("no product files with expected prefix [%s,%s] found. Please ensure the file you're trying to download was initially persisted from Pivotal Network net using an appropriately configured download-product command", slug, version)
+		return nil, nil, fmt.Errorf("no product files with expected prefix [%s,%s] found. Please ensure the file you're trying to download was initially persisted from Pivotal Network net using an appropriately configured download-product command", slug, version)
 	}
 
 	for _, f := range prefixedFilepaths {
@@ -188,34 +261,192 @@ func (s stowClient) GetLatestProductFile(slug, version, glob string) (FileArtifa
 		}
 	}
 
-	if len(globMatchedFilepaths) > 1 {
-		return nil, fmt.Errorf("the glob '%s' matches multiple files. Write your glob to match exactly one of the following:\n  %s", glob, strings.Join(globMatchedFilepaths, "\n  "))
+	return prefixedFilepaths, globMatchedFilepaths, nil
+}
+
+func (s stowClient) DownloadProductToFile(fa FileArtifacter, destinationFile *os.File) error {
+	if s.parallelConnections > 1 {
+		return s.downloadRangesToFile(fa.Name(), destinationFile)
 	}
 
-	if len(globMatchedFilepaths) == 0 {
-		availableFiles := strings.Join(prefixedFilepaths, ", ")
-		if availableFiles == "" {
-			availableFiles = "none"
+	blobReader, size, err := s.initializeBlobReader(fa.Name())
+	if err != nil {
+		return err
+	}
+
+	progressBar, wrappedBlobReader := s.startProgressBar("download", size, blobReader)
+	defer progressBar.Finish()
+
+	if err = s.streamBufferToFile(destinationFile, wrappedBlobReader); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type byteRange struct {
+	start, end int64
+}
+
+func splitIntoRanges(size int64, count int) []byteRange {
+	var ranges []byteRange
+
+	chunkSize := size / int64(count)
+	for i := 0; i < count; i++ {
+		start := int64(i) * chunkSize
+		end := start + chunkSize - 1
+		if i == count-1 {
+			end = size - 1
 		}
-		return nil, fmt.Errorf("the glob '%s' matches no file\navailable files: %s", glob, availableFiles)
+		ranges = append(ranges, byteRange{start: start, end: end})
 	}
 
-	return &stowFileArtifact{name: globMatchedFilepaths[0], source: s.kind}, nil
+	return ranges
 }
 
-func (s stowClient) DownloadProductToFile(fa FileArtifacter, destinationFile *os.File) error {
-	blobReader, size, err := s.initializeBlobReader(fa.Name())
+func (s stowClient) downloadRangesToFile(filename string, destinationFile *os.File) error {
+	container, err := s.getContainer()
+	if err != nil {
+		return err
+	}
+
+	item, err := container.Item(filename)
 	if err != nil {
 		return err
 	}
 
-	progressBar, wrappedBlobReader := s.startProgressBar(size, blobReader)
+	size, err := item.Size()
+	if err != nil {
+		return err
+	}
+
+	// the ranges are written out of order, so the reassembled file can only
+	// be verified against the checksum the blobstore publishes
+	var checksum string
+	ranger, ok := item.(stow.ItemRanger)
+	if !ok || size < int64(s.parallelConnections) {
+		s.stderr.Printf("%s does not support ranged downloads for %s, downloading over a single connection", s.kind, filename)
+	} else if checksum = s.publishedMD5(item); checksum == "" {
+		s.stderr.Printf("%s publishes no checksum for %s to verify ranged downloads with, downloading over a single connection", s.kind, filename)
+	}
+
+	if checksum == "" {
+		blobReader, err := item.Open()
+		if err != nil {
+			return err
+		}
+		defer blobReader.Close()
+
+		progressBar, wrappedBlobReader := s.startProgressBar("download", size, blobReader)
+		defer progressBar.Finish()
+
+		return s.streamBufferToFile(destinationFile, wrappedBlobReader)
+	}
+
+	progressBar, _ := s.startProgressBar("download", size, nil)
 	defer progressBar.Finish()
 
-	if err = s.streamBufferToFile(destinationFile, wrappedBlobReader); err != nil {
+	ranges := splitIntoRanges(size, s.parallelConnections)
+	errs := make([]error, len(ranges))
+
+	var wg sync.WaitGroup
+	for i, r := range ranges {
+		wg.Add(1)
+		go func(i int, r byteRange) {
+			defer wg.Done()
+			errs[i] = s.downloadRange(ranger, r, destinationFile, progressBar)
+		}(i, r)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("could not download %s: %w", filename, err)
+		}
+	}
+
+	info, err := destinationFile.Stat()
+	if err != nil {
 		return err
 	}
 
+	if info.Size() != size {
+		return fmt.Errorf("could not download %s: reassembled file is %d bytes, expected %d", filename, info.Size(), size)
+	}
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, io.NewSectionReader(destinationFile, 0, size)); err != nil {
+		return fmt.Errorf("could not verify %s: %w", filename, err)
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+		return fmt.Errorf("could not download %s: the md5 of the reassembled file is %s, expected %s", filename, sum, checksum)
+	}
+
+	return nil
+}
+
+// md5Publisher is an item of the locations of om that knows whether its
+// blobstore publishes the MD5 of its contents.
+type md5Publisher interface {
+	PublishedMD5() string
+}
+
+var md5Pattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// publishedMD5 is the MD5 of the contents of the item, as hex, published by
+// the blobstore, or "" when it publishes none.
+func (s stowClient) publishedMD5(item stow.Item) string {
+	switch item := item.(type) {
+	case md5Publisher:
+		return item.PublishedMD5()
+	case interface{ StorageObject() *storage.ObjectAttrs }:
+		// composite objects have no MD5
+		if attrs := item.StorageObject(); attrs != nil && len(attrs.MD5) > 0 {
+			return hex.EncodeToString(attrs.MD5)
+		}
+		return ""
+	}
+
+	if s.kind != "s3" {
+		return ""
+	}
+
+	etag, err := item.ETag()
+	if err != nil {
+		return ""
+	}
+
+	return etagMD5(etag)
+}
+
+// etagMD5 is the MD5 an S3 ETag holds. The ETag of an object uploaded in
+// parts is not an MD5 and ends in the number of parts, e.g. "...-12".
+func etagMD5(etag string) string {
+	etag = strings.ToLower(strings.Trim(etag, `"`))
+	if !md5Pattern.MatchString(etag) {
+		return ""
+	}
+
+	return etag
+}
+
+func (s stowClient) downloadRange(ranger stow.ItemRanger, r byteRange, destinationFile *os.File, progressBar *progress.Bar) error {
+	rangeReader, err := ranger.OpenRange(uint64(r.start), uint64(r.end))
+	if err != nil {
+		return fmt.Errorf("range %d-%d: %w", r.start, r.end, err)
+	}
+	defer rangeReader.Close()
+
+	written, err := io.Copy(io.NewOffsetWriter(destinationFile, r.start), progressBar.NewProxyReader(rangeReader))
+	if err != nil {
+		return fmt.Errorf("range %d-%d: %w", r.start, r.end, err)
+	}
+
+	if expected := r.end - r.start + 1; written != expected {
+		return fmt.Errorf("range %d-%d: expected %d bytes, received %d", r.start, r.end, expected, written)
+	}
+
 	return nil
 }
 
@@ -238,15 +469,15 @@ func (s *stowClient) initializeBlobReader(filename string) (blobToRead io.ReadCl
 	return blobToRead, fileSize, err
 }
 
-func (s stowClient) startProgressBar(size int64, item io.Reader) (*pb.ProgressBar, io.Reader) {
-	progressBar := pb.Default.New(0)
-	progressBar.SetWriter(s.stderr.Writer())
-	progressBar.Set(pb.Bytes, true)
+func (s stowClient) startProgressBar(phase string, size int64, item io.Reader) (*progress.Bar, io.Reader) {
+	progressBar := progress.NewBar(s.stderr.Writer(), phase)
 	progressBar.SetTotal(size)
 	progressBar.SetMaxWidth(80)
-	reader := progressBar.NewProxyReader(item)
 	progressBar.Start()
-	return progressBar, reader
+	if item == nil {
+		return progressBar, nil
+	}
+	return progressBar, progressBar.NewProxyReader(item)
 }
 
 func (s stowClient) streamBufferToFile(destinationFile *os.File, wrappedBlobReader io.Reader) error {
@@ -254,44 +485,173 @@ func (s stowClient) streamBufferToFile(destinationFile *os.File, wrappedBlobRead
 	return err
 }
 
-func (s stowClient) GetLatestStemcellForProduct(_ FileArtifacter, downloadedProductFileName string, stemcellSlug string) (StemcellArtifacter, error) {
-	definedStemcell, err := stemcellFromProduct(downloadedProductFileName)
+func (s stowClient) ProductFileExists(fileName string) (bool, error) {
+	return s.fileExists(s.objectKey(s.productPath, fileName))
+}
+
+func (s stowClient) StemcellFileExists(fileName string) (bool, error) {
+	return s.fileExists(s.objectKey(s.stemcellPath, fileName))
+}
+
+func (s stowClient) UploadProductFile(fileName string, file *os.File) error {
+	return s.uploadFile(s.objectKey(s.productPath, fileName), file)
+}
+
+func (s stowClient) UploadStemcellFile(fileName string, file *os.File) error {
+	return s.uploadFile(s.objectKey(s.stemcellPath, fileName), file)
+}
+
+func (s stowClient) objectKey(path, fileName string) string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return fileName
+	}
+
+	return path + "/" + fileName
+}
+
+func (s stowClient) fileExists(key string) (bool, error) {
+	container, err := s.getContainer()
 	if err != nil {
-		return nil, err
+		return false, err
+	}
+
+	found := false
+	err = s.stower.Walk(container, key, 100, func(item stow.Item, err error) error {
+		if err != nil {
+			return err
+		}
+		if item.ID() == key {
+			found = true
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not check for %s on %s: %w", key, s.kind, err)
+	}
+
+	return found, nil
+}
+
+func (s stowClient) uploadFile(key string, file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	container, err := s.getContainer()
+	if err != nil {
+		return err
 	}
 
-	definedMajor, definedPatch, err := stemcellVersionPartsFromString(definedStemcell.Version())
+	progressBar, reader := s.startProgressBar("upload", info.Size(), file)
+	defer progressBar.Finish()
+
+	_, err = container.Put(key, reader, info.Size(), nil)
+	if err != nil {
+		return fmt.Errorf("could not upload %s to %s: %w", key, s.kind, err)
+	}
+
+	return nil
+}
+
+// OpenFile opens a file of the bucket, by its full path, to be read without
+// downloading it first.
+func (s stowClient) OpenFile(fileName string) (BlobFile, error) {
+	container, err := s.getContainer()
 	if err != nil {
 		return nil, err
 	}
 
-	allStemcellVersions, err := s.getAllProductVersionsFromPath(definedStemcell.Slug(), s.stemcellPath)
+	item, err := container.Item(fileName)
 	if err != nil {
-		return nil, fmt.Errorf("could not find stemcells on %s: %s", s.kind, err)
+		return nil, fmt.Errorf("could not find %s on %s: %w", fileName, s.kind, err)
 	}
 
-	var filteredVersions []string
-	for _, version := range allStemcellVersions {
-		major, patch, _ := stemcellVersionPartsFromString(version)
+	size, err := item.Size()
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the size of %s on %s: %w", fileName, s.kind, err)
+	}
 
-		if major == definedMajor && patch >= definedPatch {
-			filteredVersions = append(filteredVersions, version)
-		}
+	return stowBlobFile{item: item, size: size, source: s.kind}, nil
+}
+
+type stowBlobFile struct {
+	item   stow.Item
+	size   int64
+	source string
+}
+
+func (f stowBlobFile) Size() int64 {
+	return f.size
+}
+
+func (f stowBlobFile) Open() (io.ReadCloser, error) {
+	return f.item.Open()
+}
+
+func (f stowBlobFile) ProductMetadata() (*extractor.Metadata, error) {
+	ranger, ok := f.item.(stow.ItemRanger)
+	if !ok {
+		return nil, fmt.Errorf("%w \"%s\"", ErrCannotExtractMetadata, f.source)
 	}
 
-	if len(filteredVersions) == 0 {
-		return nil, fmt.Errorf("no versions could be found equal to or greater than %s", definedStemcell.Version())
+	return extractor.NewMetadataExtractor().ExtractFromReaderAt(rangeReaderAt{ranger: ranger, size: f.size}, f.size)
+}
+
+// rangeReaderAt reads each ReadAt as a byte range of the item.
+type rangeReaderAt struct {
+	ranger stow.ItemRanger
+	size   int64
+}
+
+func (r rangeReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
 	}
 
-	latestVersion, err := getLatestStemcellVersion(filteredVersions)
+	end := off + int64(len(p)) - 1
+	if end >= r.size {
+		end = r.size - 1
+	}
+
+	rangeReader, err := r.ranger.OpenRange(uint64(off), uint64(end))
+	if err != nil {
+		return 0, fmt.Errorf("range %d-%d: %w", off, end, err)
+	}
+	defer rangeReader.Close()
+
+	n, err := io.ReadFull(rangeReader, p[:end-off+1])
+	if err != nil {
+		return n, fmt.Errorf("range %d-%d: %w", off, end, err)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (s stowClient) GetLatestStemcellForProduct(_ FileArtifacter, downloadedProductFileName string, stemcellSlug string) (StemcellArtifacter, error) {
+	definedStemcell, err := stemcellFromProduct(downloadedProductFileName)
 	if err != nil {
 		return nil, err
 	}
 
-	return &stemcell{
-		version: latestVersion,
-		slug:    definedStemcell.Slug(),
-	}, nil
+	if _, _, err := stemcellVersionPartsFromString(definedStemcell.Version()); err != nil {
+		return nil, err
+	}
+
+	allStemcellVersions, err := s.getAllProductVersionsFromPath(definedStemcell.Slug(), s.stemcellPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find stemcells on %s: %s", s.kind, err)
+	}
+
+	return latestCompatibleStemcell(definedStemcell, allStemcellVersions)
 }
 
 func stemcellFromProduct(filename string) (*stemcell, error) {
@@ -301,17 +661,6 @@ func stemcellFromProduct(filename string) (*stemcell, error) {
 		return nil, fmt.Errorf("could not find the appropriate stemcell associated with the tile %q: %s", filename, err)
 	}
 
-	stemcellNameToPivnetProductName := map[string]string{
-		"ubuntu-jammy":  "stemcells-ubuntu-jammy",
-		"ubuntu-xenial": "stemcells-ubuntu-xenial",
-		"ubuntu-trusty": "stemcells",
-		"windows2016":   "stemcells-windows-server",
-		"windows1803":   "stemcells-windows-server",
-		"windows2019":   "stemcells-windows-server",
-		"windows2025":   "stemcells-windows-server",
-		"ubuntu-noble":  "stemcells-ubuntu-noble",
-	}
-
 	return &stemcell{
 		slug:    stemcellNameToPivnetProductName[metadata.StemcellCriteria.OS],
 		version: metadata.StemcellCriteria.Version,
@@ -1,10 +1,15 @@
 package download_clients_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"sync"
 
 	"github.com/graymeta/stow"
 
@@ -12,6 +17,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 // should delete most of s3_client_test (validation stays)
@@ -127,6 +133,21 @@ var _ = Describe("stowClient", func() {
 				Expect(err).To(MatchError(ContainSubstring("no files matching pivnet-product-slug someslug found")))
 			})
 		})
+
+		When("the slug is a stemcell in the stemcell path", func() {
+			It("reports the versions of the stemcell", func() {
+				stower := newMockStower([]mockItem{
+					newMockItem("/products/[cf,2.0.0]cf.pivotal"),
+					newMockItem("/stemcells/[stemcells-ubuntu-jammy,1.10]light-bosh-stemcell-1.10-aws.tgz"),
+					newMockItem("/stemcells/[stemcells-ubuntu-jammy,1.12]light-bosh-stemcell-1.12-aws.tgz"),
+				})
+				client := download_clients.NewStowClient(stower, nil, stow.ConfigMap{"endpoint": "endpoint"}, "/products", "/stemcells", "", "bucket")
+
+				versions, err := client.GetAllProductVersions("stemcells-ubuntu-jammy")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions).To(Equal([]string{"1.10", "1.12"}))
+			})
+		})
 	})
 
 	Describe("GetLatestProductFile", func() {
@@ -172,6 +193,23 @@ var _ = Describe("stowClient", func() {
 			Expect(err).To(MatchError(ContainSubstring("the glob '*vsphere*ova' matches multiple files. Write your glob to match exactly one of the following")))
 		})
 
+		It("returns every file matching the glob when asked for all of them", func() {
+			itemsList := []mockItem{
+				newMockItem("[product-slug,1.0.0]pcf-vsphere-2.1-build.341.ova"),
+				newMockItem("[product-slug,1.1.1]pcf-vsphere-2.1-build.345.ova"),
+				newMockItem("[product-slug,1.1.1]pcf-vsphere-2.1-build.348.ova"),
+			}
+
+			stower := newMockStower(itemsList)
+			client := download_clients.NewStowClient(stower, nil, stow.ConfigMap{"endpoint": "endpoint"}, "", "", "", "bucket")
+
+			fileArtifacts, err := client.GetLatestProductFiles("product-slug", "1.1.1", "*vsphere*ova")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fileArtifacts).To(HaveLen(2))
+			Expect(fileArtifacts[0].Name()).To(Equal("[product-slug,1.1.1]pcf-vsphere-2.1-build.345.ova"))
+			Expect(fileArtifacts[1].Name()).To(Equal("[product-slug,1.1.1]pcf-vsphere-2.1-build.348.ova"))
+		})
+
 		It("errors when zero prefixed files match the glob", func() {
 			itemsList := []mockItem{
 				newMockItem("[product-slug,1.0.0]pcf-vsphere-2.1-build.341.ova"),
@@ -267,6 +305,120 @@ var _ = Describe("stowClient", func() {
 			Expect(err).To(MatchError(ContainSubstring("could not reach provided endpoint and bucket 'endpoint/bucket': expected element type <Error> but have StowErrorType")))
 		})
 
+		When("parallel connections are configured", func() {
+			var (
+				item   *mockRangeItem
+				stower *mockStower
+			)
+
+			BeforeEach(func() {
+				item = &mockRangeItem{
+					mockItem: newMockItem(file.Name()),
+					contents: "the quick brown fox jumps over the lazy dog",
+					etag:     `"77add1d5f41223d5582fca736a5cb335"`,
+				}
+				container := mockContainer{item: item}
+				stower = &mockStower{
+					location: mockLocation{container: &container},
+				}
+			})
+
+			It("downloads the file as concurrent byte ranges and reassembles it", func() {
+				client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket").WithParallelConnections(4)
+
+				file, err := os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.Remove(file.Name())
+
+				err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+				Expect(err).ToNot(HaveOccurred())
+
+				contents, err := os.ReadFile(file.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(Equal("the quick brown fox jumps over the lazy dog"))
+
+				Expect(item.requestedRanges()).To(ConsistOf("0-9", "10-19", "20-29", "30-42"))
+			})
+
+			It("returns an error when a range fails to download", func() {
+				item.rangeError = errors.New("connection reset")
+				client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket").WithParallelConnections(4)
+
+				file, err := os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.Remove(file.Name())
+
+				err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+				Expect(err).To(MatchError(ContainSubstring("connection reset")))
+			})
+
+			It("returns an error when a range is truncated", func() {
+				item.truncate = true
+				client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket").WithParallelConnections(4)
+
+				file, err := os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.Remove(file.Name())
+
+				err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+				Expect(err).To(MatchError(ContainSubstring("expected 10 bytes, received 9")))
+			})
+
+			It("returns an error when the reassembled file does not match the published checksum", func() {
+				item.etag = `"00000000000000000000000000000000"`
+				client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket").WithParallelConnections(4)
+
+				file, err := os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.Remove(file.Name())
+
+				err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+				Expect(err).To(MatchError(ContainSubstring("the md5 of the reassembled file is 77add1d5f41223d5582fca736a5cb335, expected 00000000000000000000000000000000")))
+			})
+
+			DescribeTable("falls back to a single connection when no checksum is published", func(etag string) {
+				item.etag = etag
+				output := gbytes.NewBuffer()
+				client := download_clients.NewStowClient(stower, log.New(output, "", 0), stow.ConfigMap{}, "", "", "s3", "bucket").WithParallelConnections(4)
+
+				file, err := os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.Remove(file.Name())
+
+				err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+				Expect(err).ToNot(HaveOccurred())
+
+				contents, err := os.ReadFile(file.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(Equal(fileContents))
+
+				Expect(item.requestedRanges()).To(BeEmpty())
+				Expect(output).To(gbytes.Say("publishes no checksum"))
+			},
+				Entry("without an ETag", ""),
+				Entry("with the ETag of an object uploaded in parts", `"77add1d5f41223d5582fca736a5cb335-3"`),
+			)
+
+			It("falls back to a single connection when the item does not support ranges", func() {
+				plainItem := newMockItem(file.Name())
+				container := mockContainer{item: plainItem}
+				stower.location = mockLocation{container: &container}
+
+				client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket").WithParallelConnections(4)
+
+				file, err := os.CreateTemp("", "")
+				Expect(err).ToNot(HaveOccurred())
+				defer os.Remove(file.Name())
+
+				err = client.DownloadProductToFile(createPivnetFileArtifact(), file)
+				Expect(err).ToNot(HaveOccurred())
+
+				contents, err := os.ReadFile(file.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(Equal(fileContents))
+			})
+		})
+
 		It("errors when cannot open file", func() {
 			item := newMockItem(file.Name())
 			item.fileError = errors.New("could not open file")
@@ -284,6 +436,70 @@ var _ = Describe("stowClient", func() {
 		})
 	})
 
+	Describe("OpenFile", func() {
+		It("reads the metadata of the product with byte ranges and streams the product", func() {
+			var product bytes.Buffer
+			zipWriter := zip.NewWriter(&product)
+			metadataFile, err := zipWriter.Create("metadata/cf.yml")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = metadataFile.Write([]byte("name: cf\nproduct_version: 2.0.0\n"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(zipWriter.Close()).To(Succeed())
+
+			item := &mockRangeItem{
+				mockItem: newMockItem("products/cf.pivotal"),
+				contents: product.String(),
+			}
+			stower := &mockStower{
+				location: mockLocation{container: &mockContainer{item: item}},
+			}
+
+			client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket")
+
+			file, err := client.OpenFile("products/cf.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file.Size()).To(Equal(int64(product.Len())))
+
+			metadata, err := file.ProductMetadata()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(metadata.Name).To(Equal("cf"))
+			Expect(metadata.Version).To(Equal("2.0.0"))
+			Expect(item.requestedRanges()).ToNot(BeEmpty())
+
+			reader, err := file.Open()
+			Expect(err).ToNot(HaveOccurred())
+			defer reader.Close()
+			contents, err := io.ReadAll(reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("hello world"))
+		})
+
+		It("returns an error when the blobstore cannot read byte ranges", func() {
+			stower := &mockStower{
+				location: mockLocation{container: &mockContainer{item: newMockItem("products/cf.pivotal")}},
+			}
+
+			client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket")
+
+			file, err := client.OpenFile("products/cf.pivotal")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = file.ProductMetadata()
+			Expect(err).To(MatchError(download_clients.ErrCannotExtractMetadata))
+		})
+
+		It("returns an error when the bucket cannot be reached", func() {
+			stower := &mockStower{
+				location: mockLocation{containerError: errors.New("no such bucket")},
+			}
+
+			client := download_clients.NewStowClient(stower, stderr, stow.ConfigMap{}, "", "", "s3", "bucket")
+
+			_, err := client.OpenFile("products/cf.pivotal")
+			Expect(err).To(MatchError(ContainSubstring("could not reach provided bucket 'bucket': no such bucket")))
+		})
+	})
+
 	Describe("GetLatestStemcellForProduct", func() {
 		When("the bucket has stemcells that product can used", func() {
 			DescribeTable("returns the latest stemcell", func(stemcellName, stemcellProductName, stemcellPath string) {
@@ -385,3 +601,46 @@ var _ = Describe("stowClient", func() {
 		})
 	})
 })
+
+type mockRangeItem struct {
+	mockItem
+	contents   string
+	etag       string
+	rangeError error
+	truncate   bool
+
+	mutex  sync.Mutex
+	ranges []string
+}
+
+func (m *mockRangeItem) ETag() (string, error) {
+	return m.etag, nil
+}
+
+func (m *mockRangeItem) Size() (int64, error) {
+	return int64(len(m.contents)), nil
+}
+
+func (m *mockRangeItem) OpenRange(start, end uint64) (io.ReadCloser, error) {
+	m.mutex.Lock()
+	m.ranges = append(m.ranges, fmt.Sprintf("%d-%d", start, end))
+	m.mutex.Unlock()
+
+	if m.rangeError != nil {
+		return nil, m.rangeError
+	}
+
+	chunk := m.contents[start : end+1]
+	if m.truncate {
+		chunk = chunk[:len(chunk)-1]
+	}
+
+	return io.NopCloser(strings.NewReader(chunk)), nil
+}
+
+func (m *mockRangeItem) requestedRanges() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.ranges
+}
@@ -23,6 +23,17 @@ func (me *MetadataExtractor) ExtractFromFile(productPath string) (*Metadata, err
 	return fromZipFiles(zipReader.File)
 }
 
+// ExtractFromReaderAt reads the metadata of a product that is not a local
+// file, e.g. one read with byte ranges from a blobstore.
+func (me *MetadataExtractor) ExtractFromReaderAt(reader io.ReaderAt, size int64) (*Metadata, error) {
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, fmt.Errorf("could not create zip reader: %w", err)
+	}
+
+	return fromZipFiles(zipReader.File)
+}
+
 func fromZipFiles(files []*zip.File) (*Metadata, error) {
 	for _, file := range files {
 		matched := metadataRegexp.MatchString(file.Name)
@@ -51,8 +62,14 @@ func captureMetadata(file *zip.File) (*Metadata, error) {
 		return nil, err
 	}
 
+	return ParseMetadata(contents)
+}
+
+// ParseMetadata reads the metadata file of a product, e.g. as printed by
+// product-metadata.
+func ParseMetadata(contents []byte) (*Metadata, error) {
 	metadata := &Metadata{Raw: contents}
-	err = yaml.Unmarshal(contents, &metadata)
+	err := yaml.Unmarshal(contents, &metadata)
 	if err != nil {
 		return nil, fmt.Errorf("could not extract product metadata: %s", err)
 	}
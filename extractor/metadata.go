@@ -1,12 +1,87 @@
 package extractor
 
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
 type Metadata struct {
-	Name             string `yaml:"name"`
-	Version          string `yaml:"product_version"`
-	StemcellCriteria struct {
-		OS                   string `yaml:"os"`
-		Version              string `yaml:"version"`
-		PatchSecurityUpdates bool   `yaml:"enable_patch_security_updates"`
-	} `yaml:"stemcell_criteria"`
-	Raw []byte
+	Name                        string             `yaml:"name"`
+	Version                     string             `yaml:"product_version"`
+	StemcellCriteria            StemcellCriteria   `yaml:"stemcell_criteria"`
+	AdditionalStemcellsCriteria []StemcellCriteria `yaml:"additional_stemcells_criteria"`
+	RequiresProductVersions     []ProductVersion   `yaml:"requires_product_versions"`
+
+	// MetadataVersion is the oldest Ops Manager version the product can be
+	// imported into
+	MetadataVersion          string `yaml:"metadata_version"`
+	MinimumVersionForUpgrade string `yaml:"minimum_version_for_upgrade"`
+	Raw                      []byte
+}
+
+type StemcellCriteria struct {
+	OS                   string `yaml:"os"`
+	Version              string `yaml:"version"`
+	PatchSecurityUpdates bool   `yaml:"enable_patch_security_updates"`
+	RequiresCPI          bool   `yaml:"requires_cpi"`
+}
+
+// ProductVersion is a product and a version constraint, e.g. cf and "~> 2.13".
+type ProductVersion struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+// Contents are the releases, instance groups and errands of the product.
+type Contents struct {
+	Releases          []Release `yaml:"releases"`
+	JobTypes          []JobType `yaml:"job_types"`
+	PostDeployErrands []Errand  `yaml:"post_deploy_errands"`
+	PreDeleteErrands  []Errand  `yaml:"pre_delete_errands"`
+}
+
+// Contents are only read when asked for, so products with unusual job
+// types can still be uploaded.
+func (m *Metadata) Contents() (*Contents, error) {
+	contents := &Contents{}
+	err := yaml.Unmarshal(m.Raw, contents)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract product contents: %s", err)
+	}
+
+	return contents, nil
+}
+
+// Release is a BOSH release embedded in the product.
+type Release struct {
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+	File    string `yaml:"file"`
+	SHA1    string `yaml:"sha1"`
+}
+
+// JobType is an instance group of the product, or an errand run on its own
+// instance group.
+type JobType struct {
+	Name               string `yaml:"name"`
+	Label              string `yaml:"label"`
+	Errand             bool   `yaml:"errand"`
+	InstanceDefinition struct {
+		Default int `yaml:"default"`
+	} `yaml:"instance_definition"`
+	Templates []JobTemplate `yaml:"templates"`
+}
+
+// JobTemplate is a BOSH job of an instance group.
+type JobTemplate struct {
+	Name    string `yaml:"name"`
+	Release string `yaml:"release"`
+}
+
+type Errand struct {
+	Name      string   `yaml:"name"`
+	Label     string   `yaml:"label"`
+	Colocated bool     `yaml:"colocated"`
+	Instances []string `yaml:"instances"`
 }
@@ -306,4 +306,74 @@ var _ = Describe("MetadataExtractor", func() {
 			})
 		})
 	})
+
+	Describe("ParseMetadata", func() {
+		It("reads what the product requires", func() {
+			metadata, err := extractor.ParseMetadata([]byte(`
+name: some-product
+product_version: 1.8.14
+metadata_version: "2.10"
+minimum_version_for_upgrade: 1.7.0
+requires_product_versions:
+- name: cf
+  version: "~> 2.13"
+stemcell_criteria:
+  os: ubuntu-jammy
+  version: "1.18"
+  requires_cpi: true
+additional_stemcells_criteria:
+- os: windows2019
+  version: "2019.60"
+`))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(metadata.MetadataVersion).To(Equal("2.10"))
+			Expect(metadata.MinimumVersionForUpgrade).To(Equal("1.7.0"))
+			Expect(metadata.RequiresProductVersions).To(Equal([]extractor.ProductVersion{{Name: "cf", Version: "~> 2.13"}}))
+			Expect(metadata.StemcellCriteria).To(Equal(extractor.StemcellCriteria{OS: "ubuntu-jammy", Version: "1.18", RequiresCPI: true}))
+			Expect(metadata.AdditionalStemcellsCriteria).To(Equal([]extractor.StemcellCriteria{{OS: "windows2019", Version: "2019.60"}}))
+		})
+
+		It("reads the releases, job types and errands when asked to", func() {
+			metadata, err := extractor.ParseMetadata([]byte(`
+name: some-product
+product_version: 1.8.14
+releases:
+- name: bpm
+  version: 1.1.3
+  file: bpm-1.1.3.tgz
+job_types:
+- name: broker
+  instance_definition:
+    default: 2
+  templates:
+  - name: bpm
+    release: bpm
+post_deploy_errands:
+- name: smoke-tests
+`))
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := metadata.Contents()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents.Releases).To(Equal([]extractor.Release{{Name: "bpm", Version: "1.1.3", File: "bpm-1.1.3.tgz"}}))
+			Expect(contents.JobTypes).To(HaveLen(1))
+			Expect(contents.JobTypes[0].InstanceDefinition.Default).To(Equal(2))
+			Expect(contents.JobTypes[0].Templates).To(Equal([]extractor.JobTemplate{{Name: "bpm", Release: "bpm"}}))
+			Expect(contents.PostDeployErrands).To(Equal([]extractor.Errand{{Name: "smoke-tests"}}))
+		})
+
+		It("reports releases, job types or errands that cannot be read", func() {
+			metadata, err := extractor.ParseMetadata([]byte("name: some-product\nproduct_version: 1.8.14\njob_types: not-a-list\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = metadata.Contents()
+			Expect(err).To(MatchError(ContainSubstring("could not extract product contents")))
+		})
+
+		It("requires the name and version of the product", func() {
+			_, err := extractor.ParseMetadata([]byte(`metadata_version: "2.10"`))
+			Expect(err).To(MatchError("could not extract product metadata: could not find product details in metadata file"))
+		})
+	})
 })
@@ -17,11 +17,18 @@ type Form struct {
 	pw          *io.PipeWriter
 	formFields  *bytes.Buffer
 	formWriter  *multipart.Writer
-	files       []string
+	files       []formFile
 	fileKeys    []*bytes.Buffer
 	doneWriting chan error
 }
 
+// formFile is either a path on disk or, for files streamed from elsewhere,
+// a reader of known length.
+type formFile struct {
+	path    string
+	content io.Reader
+}
+
 type ContentSubmission struct {
 	Content       io.Reader
 	ContentType   string
@@ -85,15 +92,34 @@ func (f *Form) AddFile(key string, path string) error {
 		return err
 	}
 
+	return f.addFile(key, filepath.Base(path), formFile{path: path}, fileLength)
+}
+
+// AddFileFromReader adds a file that is read from content as the form is
+// written, rather than from disk. The length must be known up front so the
+// content length of the form can be.
+func (f *Form) AddFileFromReader(key string, fileName string, content io.Reader, length int64) error {
+	if length < 0 {
+		return errors.New("the length of the file provided is not known")
+	}
+
+	if length == 0 {
+		return errors.New("file provided has no content")
+	}
+
+	return f.addFile(key, fileName, formFile{content: content}, length)
+}
+
+func (f *Form) addFile(key string, fileName string, file formFile, fileLength int64) error {
 	buf := &bytes.Buffer{}
 
 	fileKey := multipart.NewWriter(buf)
-	err = fileKey.SetBoundary(f.boundary)
+	err := fileKey.SetBoundary(f.boundary)
 	if err != nil {
 		return err
 	}
 
-	_, err = fileKey.CreateFormFile(key, filepath.Base(path))
+	_, err = fileKey.CreateFormFile(key, fileName)
 	if err != nil {
 		return err
 	}
@@ -102,7 +128,7 @@ func (f *Form) AddFile(key string, path string) error {
 	f.length += fileLength
 	f.length += int64(buf.Len())
 
-	f.files = append(f.files, path)
+	f.files = append(f.files, file)
 	f.fileKeys = append(f.fileKeys, buf)
 
 	return nil
@@ -173,8 +199,7 @@ func (f *Form) writeToPipe() {
 			return
 		}
 
-		fileName := f.files[i]
-		err = writeFileToPipe(fileName, f.pw)
+		err = writeFileToPipe(f.files[i], f.pw)
 		if err != nil {
 			_ = f.pw.CloseWithError(err)
 			f.doneWriting <- err
@@ -205,8 +230,13 @@ func (f *Form) writeToPipe() {
 	f.doneWriting <- nil
 }
 
-func writeFileToPipe(fileName string, writer *io.PipeWriter) error {
-	fileContent, err := os.Open(fileName)
+func writeFileToPipe(file formFile, writer *io.PipeWriter) error {
+	if file.content != nil {
+		_, err := io.Copy(writer, file.content)
+		return err
+	}
+
+	fileContent, err := os.Open(file.path)
 	if err != nil {
 		return err
 	}
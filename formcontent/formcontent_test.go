@@ -1,8 +1,11 @@
 package formcontent_test
 
 import (
+	"errors"
 	"io"
 	"os"
+	"strings"
+	"testing/iotest"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -136,6 +139,47 @@ var _ = Describe("Formcontent", func() {
 		})
 	})
 
+	Describe("AddFileFromReader", func() {
+		BeforeEach(func() {
+			form = formcontent.NewForm()
+		})
+
+		It("writes out the content of the reader as a multipart form using the writer", func() {
+			err := form.AddFileFromReader("something[file]", "some-file.pivotal", strings.NewReader("some content"), 12)
+			Expect(err).ToNot(HaveOccurred())
+
+			submission := form.Finalize()
+
+			content, err := io.ReadAll(submission.Content)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(submission.ContentLength).To(BeNumerically("==", len(content)))
+
+			Expect(string(content)).To(MatchRegexp(`^--\w+\r\nContent-Disposition: form-data; name=\"something\[file\]\"; filename=\"some-file.pivotal\"\r\n` +
+				`Content-Type: application/octet-stream\r\n\r\n` +
+				`some content` +
+				`\r\n--\w+--\r\n$`))
+		})
+
+		When("the length is not known", func() {
+			It("returns an error", func() {
+				err := form.AddFileFromReader("foo", "some-file.pivotal", strings.NewReader("some content"), -1)
+				Expect(err).To(MatchError("the length of the file provided is not known"))
+			})
+		})
+
+		When("the reader fails", func() {
+			It("fails the content of the form", func() {
+				err := form.AddFileFromReader("foo", "some-file.pivotal", iotest.ErrReader(errors.New("connection reset")), 12)
+				Expect(err).ToNot(HaveOccurred())
+
+				submission := form.Finalize()
+
+				_, err = io.ReadAll(submission.Content)
+				Expect(err).To(MatchError("connection reset"))
+			})
+		})
+	})
+
 	Describe("AddField", func() {
 		BeforeEach(func() {
 			form = formcontent.NewForm()
@@ -0,0 +1,153 @@
+package interpolate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// awsVariables resolves variables from AWS Secrets Manager (aws-sm://) or SSM
+// Parameter Store (aws-ssm://). A variable name is appended to the path of
+// the vars store URI, so ((db/password)) with aws-ssm://us-east-1/foundation
+// reads the parameter /foundation/db/password. Values that are JSON objects
+// can be indexed, e.g. ((db.password)).
+type awsVariables struct {
+	prefix string
+	get    func(name string) (string, error)
+
+	// hierarchical stores (SSM) join names as paths
+	hierarchical bool
+	notFoundCode string
+}
+
+// newAWSVariables builds a client from a URI of the form
+//
+//	aws-sm://[region]/[prefix]?role-arn=...&profile=...&endpoint=...
+//
+// The region defaults to AWS_REGION. Credentials are taken from
+// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY when set, otherwise from the
+// SDK's default chain (shared config, instance or task role). When role-arn is
+// given, that role is assumed with those credentials.
+func newAWSVariables(uri *url.URL, environ map[string]string) (*awsVariables, error) {
+	query := uri.Query()
+
+	region := uri.Host
+	if region == "" {
+		region = environ["AWS_REGION"]
+	}
+	if region == "" {
+		region = environ["AWS_DEFAULT_REGION"]
+	}
+	if region == "" {
+		return nil, fmt.Errorf("%s vars store %q must include a region or AWS_REGION must be set", uri.Scheme, uri.Redacted())
+	}
+
+	config := aws.NewConfig().WithRegion(region)
+	if endpoint := query.Get("endpoint"); endpoint != "" {
+		config = config.WithEndpoint(endpoint)
+	}
+	if accessKeyID := environ["AWS_ACCESS_KEY_ID"]; accessKeyID != "" {
+		config = config.WithCredentials(credentials.NewStaticCredentials(accessKeyID, environ["AWS_SECRET_ACCESS_KEY"], environ["AWS_SESSION_TOKEN"]))
+	}
+
+	awsSession, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		Profile:           query.Get("profile"),
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create aws session: %s", err)
+	}
+
+	var clientConfigs []*aws.Config
+	if roleARN := query.Get("role-arn"); roleARN != "" {
+		clientConfigs = append(clientConfigs, &aws.Config{
+			Credentials: stscreds.NewCredentials(awsSession, roleARN, func(p *stscreds.AssumeRoleProvider) {
+				if externalID := query.Get("external-id"); externalID != "" {
+					p.ExternalID = aws.String(externalID)
+				}
+			}),
+		})
+	}
+
+	switch uri.Scheme {
+	case "aws-sm":
+		client := secretsmanager.New(awsSession, clientConfigs...)
+		return &awsVariables{
+			prefix:       strings.TrimPrefix(uri.Path, "/"),
+			notFoundCode: secretsmanager.ErrCodeResourceNotFoundException,
+			get: func(name string) (string, error) {
+				output, err := client.GetSecretValue(&secretsmanager.GetSecretValueInput{SecretId: aws.String(name)})
+				if err != nil {
+					return "", err
+				}
+				if output.SecretString == nil {
+					return string(output.SecretBinary), nil
+				}
+				return *output.SecretString, nil
+			},
+		}, nil
+	case "aws-ssm":
+		client := ssm.New(awsSession, clientConfigs...)
+		return &awsVariables{
+			prefix:       uri.Path,
+			hierarchical: true,
+			notFoundCode: ssm.ErrCodeParameterNotFound,
+			get: func(name string) (string, error) {
+				output, err := client.GetParameter(&ssm.GetParameterInput{Name: aws.String(name), WithDecryption: aws.Bool(true)})
+				if err != nil {
+					return "", err
+				}
+				return aws.StringValue(output.Parameter.Value), nil
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported aws vars store %q", uri.Scheme)
+}
+
+func (a *awsVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	name := varDef.Name
+	switch {
+	case a.hierarchical && !strings.HasPrefix(name, "/"):
+		name = path.Join("/", a.prefix, name)
+	case !a.hierarchical:
+		// secret names are not paths, the prefix is prepended as is
+		name = a.prefix + name
+	}
+
+	value, err := a.get(name)
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == a.notFoundCode {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("could not get %s from aws: %w", name, err)
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(value), "{") {
+		var object map[string]interface{}
+		if json.Unmarshal([]byte(value), &object) == nil {
+			return normalizeValue(object), true, nil
+		}
+	}
+
+	return value, true, nil
+}
+
+func (a *awsVariables) List() ([]template.VariableDefinition, error) {
+	return nil, nil
+}
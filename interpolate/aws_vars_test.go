@@ -0,0 +1,154 @@
+package interpolate_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/interpolate"
+)
+
+var _ = Describe("AWS vars stores", func() {
+	var (
+		server  *ghttp.Server
+		environ []string
+		values  map[string]string
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		environ = []string{"AWS_ACCESS_KEY_ID=some-key-id", "AWS_SECRET_ACCESS_KEY=some-secret-key"}
+		values = map[string]string{}
+
+		server.RouteToHandler("POST", "/", func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+
+			var input map[string]interface{}
+			Expect(json.Unmarshal(body, &input)).To(Succeed())
+
+			w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+
+			switch r.Header.Get("X-Amz-Target") {
+			case "secretsmanager.GetSecretValue":
+				value, ok := values[input["SecretId"].(string)]
+				if !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"__type": "ResourceNotFoundException", "message": "Secrets Manager can't find the specified secret."}`))
+					return
+				}
+				output, _ := json.Marshal(map[string]string{"Name": input["SecretId"].(string), "SecretString": value})
+				_, _ = w.Write(output)
+			case "AmazonSSM.GetParameter":
+				Expect(input["WithDecryption"]).To(BeTrue())
+
+				value, ok := values[input["Name"].(string)]
+				if !ok {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"__type": "ParameterNotFound"}`))
+					return
+				}
+				output, _ := json.Marshal(map[string]interface{}{"Parameter": map[string]string{"Name": input["Name"].(string), "Value": value}})
+				_, _ = w.Write(output)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"__type": "UnknownOperationException"}`))
+			}
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	storeURI := func(scheme, path string) string {
+		return scheme + "://us-east-1" + path + "?endpoint=" + url.QueryEscape(server.URL())
+	}
+
+	execute := func(template string, stores ...string) (string, error) {
+		contents, err := interpolate.Execute(interpolate.Options{
+			TemplateFile:  writeFile(template),
+			VarsStores:    stores,
+			EnvironFunc:   func() []string { return environ },
+			ExpectAllKeys: true,
+		})
+		return string(contents), err
+	}
+
+	When("using Secrets Manager", func() {
+		It("prepends the prefix to the secret name and indexes JSON secrets", func() {
+			values["foundation/prod/opsman-password"] = "from-secrets-manager"
+			values["foundation/prod/database"] = `{"username": "admin", "password": "db-password"}`
+
+			contents, err := execute(`{password: ((opsman-password)), db: ((database.password))}`, storeURI("aws-sm", "/foundation/prod/"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-secrets-manager, db: db-password}`))
+		})
+
+		It("reports secrets that do not exist as missing", func() {
+			_, err := execute(`{password: ((unknown))}`, storeURI("aws-sm", "/foundation/prod/"))
+			Expect(err).To(MatchError(ContainSubstring("Expected to find variables: unknown")))
+		})
+	})
+
+	When("using SSM Parameter Store", func() {
+		It("joins relative names to the path prefix", func() {
+			values["/foundation/prod/opsman/password"] = "from-ssm"
+			values["/absolute/name"] = "absolute-value"
+
+			contents, err := execute(`{password: ((opsman/password)), other: ((/absolute/name))}`, storeURI("aws-ssm", "/foundation/prod"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-ssm, other: absolute-value}`))
+		})
+
+		It("uses AWS_REGION when the URI has no region", func() {
+			values["/foundation/password"] = "from-ssm"
+			environ = append(environ, "AWS_REGION=us-west-2")
+
+			contents, err := execute(`{password: ((password))}`, "aws-ssm:///foundation?endpoint="+url.QueryEscape(server.URL()))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-ssm}`))
+		})
+
+		It("falls back to the static vars first", func() {
+			contents, err := interpolate.Execute(interpolate.Options{
+				TemplateFile:  writeFile(`{password: ((password))}`),
+				Vars:          []string{"password=static"},
+				VarsStores:    []string{storeURI("aws-ssm", "/foundation")},
+				EnvironFunc:   func() []string { return environ },
+				ExpectAllKeys: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(MatchYAML(`{password: static}`))
+		})
+
+		It("looks up the last store given first", func() {
+			values["/foundation/password"] = "from-foundation"
+			values["/foundation/product/password"] = "from-product"
+			values["/foundation/username"] = "admin"
+
+			contents, err := execute(`{password: ((password)), username: ((username))}`, storeURI("aws-ssm", "/foundation"), storeURI("aws-ssm", "/foundation/product"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-product, username: admin}`))
+		})
+
+		It("names the vars of a store given as PREFIX=URI with the prefix", func() {
+			values["/foundation/password"] = "from-foundation"
+			values["/foundation/product/password"] = "from-product"
+
+			contents, err := execute(`{shared: ((shared_password)), product: ((password))}`, "shared="+storeURI("aws-ssm", "/foundation"), storeURI("aws-ssm", "/foundation/product"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{shared: from-foundation, product: from-product}`))
+		})
+	})
+
+	It("requires a region", func() {
+		_, err := execute(`{password: ((password))}`, "aws-ssm:///foundation")
+		Expect(err).To(MatchError(`aws-ssm vars store "aws-ssm:///foundation" must include a region or AWS_REGION must be set`))
+	})
+})
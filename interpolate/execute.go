@@ -14,11 +14,13 @@ type Options struct {
 	TemplateFile  string
 	VarsEnvs      []string
 	VarsFiles     []string
+	VarsStores    []string
 	Vars          []string
 	OpsFiles      []string
 	EnvironFunc   func() []string
 	ExpectAllKeys bool
 	Path          string
+	Functions     bool
 }
 
 func Execute(o Options) ([]byte, error) {
@@ -27,14 +29,21 @@ func Execute(o Options) ([]byte, error) {
 		return nil, fmt.Errorf("could not read file (%s): %s", o.TemplateFile, err.Error())
 	}
 
-	tpl := template.NewTemplate(contents)
+	if len(o.VarsStores) > 0 {
+		contents = rewriteKeySeparators(contents)
+	}
 
 	// the following was taken from bosh cli
 	// https://github.com/cloudfoundry/bosh-cli/blob/9c1c210c83673a780e3787a91f444541755e6585/cmd/opts/var_flags.go
 	// we cannot use it directly because of the use of `jhanda`
 	staticVars := template.StaticVariables{}
 
-	for _, prefix := range o.VarsEnvs {
+	// sources are applied from the lowest to the highest precedence:
+	// vars stores, vars envs, vars files and then individual vars, each in
+	// the order they were given, so a later source overrides an earlier one.
+	for _, varsEnv := range o.VarsEnvs {
+		keyPrefix, prefix := splitSourcePrefix(varsEnv, false)
+
 		varsEnvArg := &template.VarsEnvArg{EnvironFunc: o.EnvironFunc}
 		err := varsEnvArg.UnmarshalFlag(prefix)
 		if err != nil {
@@ -42,7 +51,7 @@ func Execute(o Options) ([]byte, error) {
 		}
 
 		for k, v := range varsEnvArg.Vars {
-			staticVars[k] = maintainMultilineStringForEnvVar(
+			staticVars[prefixedName(keyPrefix, k)] = maintainMultilineStringForEnvVar(
 				o.EnvironFunc,
 				fmt.Sprintf("%s_%s", prefix, k),
 				v,
@@ -50,15 +59,17 @@ func Execute(o Options) ([]byte, error) {
 		}
 	}
 
-	for _, v := range o.VarsFiles {
+	for _, varsFile := range o.VarsFiles {
+		keyPrefix, path := splitSourcePrefix(varsFile, true)
+
 		varFilesArg := &template.VarsFileArg{}
-		err := varFilesArg.UnmarshalFlag(v)
+		err := varFilesArg.UnmarshalFlag(path)
 		if err != nil {
 			return nil, err
 		}
 
 		for k, v := range varFilesArg.Vars {
-			staticVars[k] = v
+			staticVars[prefixedName(keyPrefix, k)] = v
 		}
 	}
 
@@ -72,6 +83,46 @@ func Execute(o Options) ([]byte, error) {
 		staticVars[varArg.Name] = maintainMultilineString(v, varArg.Value)
 	}
 
+	var (
+		vars   template.Variables = staticVars
+		stores []template.Variables
+	)
+	if len(o.VarsStores) > 0 {
+		// the first variables with a value win, so the static variables
+		// come first and the vars stores from the last to the first
+		varss := make([]template.Variables, len(o.VarsStores)+1)
+		varss[0] = staticVars
+		for i, varsStore := range o.VarsStores {
+			keyPrefix, uri := splitSourcePrefix(varsStore, false)
+
+			store, err := newVarsStore(uri, o.EnvironFunc)
+			if err != nil {
+				return nil, err
+			}
+
+			if keyPrefix != "" {
+				store = prefixedVariables{prefix: keyPrefix, vars: store}
+			}
+			varss[len(o.VarsStores)-i] = store
+			stores = append(stores, store)
+		}
+		vars = template.NewMultiVars(varss)
+	}
+
+	if o.Functions {
+		contents, err = (&functions{
+			staticVars:    staticVars,
+			vars:          vars,
+			stores:        stores,
+			expectAllKeys: o.ExpectAllKeys,
+		}).evaluate(contents)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tpl := template.NewTemplate(contents)
+
 	ops := patch.Ops{}
 	for _, path := range o.OpsFiles {
 		var opDefs []patch.OpDefinition
@@ -96,7 +147,7 @@ func Execute(o Options) ([]byte, error) {
 		return nil, fmt.Errorf("cannot parse path: %s", err)
 	}
 
-	firstPassBytes, err := tpl.Evaluate(staticVars, ops, evalOpts)
+	firstPassBytes, err := tpl.Evaluate(vars, ops, evalOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +157,7 @@ func Execute(o Options) ([]byte, error) {
 	}
 
 	secondPassTemplate := template.NewTemplate(firstPassBytes)
-	secondPassBytes, err := secondPassTemplate.Evaluate(staticVars, nil, evalOpts)
+	secondPassBytes, err := secondPassTemplate.Evaluate(vars, nil, evalOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,69 @@
+package interpolate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"gopkg.in/yaml.v2"
+)
+
+// fileVariables is a vars store backed by a YAML file, e.g. file://creds.yml.
+// The file is created when a value is persisted to it.
+type fileVariables struct {
+	path string
+	vars map[interface{}]interface{}
+}
+
+func newFileVariables(uri *url.URL) (*fileVariables, error) {
+	path := uri.Host + uri.Path
+	if path == "" {
+		return nil, errors.New("file vars store requires a path, e.g. file://creds.yml")
+	}
+
+	vars := map[interface{}]interface{}{}
+
+	contents, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("could not read vars store %s: %s", path, err)
+	}
+
+	err = yaml.Unmarshal(contents, &vars)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal vars store %s: %s", path, err)
+	}
+
+	return &fileVariables{path: path, vars: vars}, nil
+}
+
+func (f *fileVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	value, found := f.vars[varDef.Name]
+	return value, found, nil
+}
+
+func (f *fileVariables) List() ([]template.VariableDefinition, error) {
+	var varDefs []template.VariableDefinition
+	for name := range f.vars {
+		varDefs = append(varDefs, template.VariableDefinition{Name: fmt.Sprint(name)})
+	}
+
+	return varDefs, nil
+}
+
+func (f *fileVariables) Put(name string, value interface{}) error {
+	f.vars[name] = value
+
+	contents, err := yaml.Marshal(f.vars)
+	if err != nil {
+		return fmt.Errorf("could not marshal vars store %s: %s", f.path, err)
+	}
+
+	err = os.WriteFile(f.path, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write vars store %s: %s", f.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package interpolate
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var (
+	functionRegex         = regexp.MustCompile(`\(\((base64|sha256|password|join|split)((?:\s+(?:"[^"]*"|[-/\.\w\pL]+))+)\s*\)\)`)
+	functionArgumentRegex = regexp.MustCompile(`"([^"]*)"|([-/\.\w\pL]+)`)
+)
+
+const (
+	passwordCharacters    = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	DefaultPasswordLength = 32
+)
+
+// functionArgument is either a "literal" or the name of a variable.
+type functionArgument struct {
+	literal string
+	name    string
+}
+
+// functions evaluates the function calls of the template, e.g.
+// ((base64 some_var)) or ((join "," "a" some_list)), before the template
+// itself is evaluated. The result of every call is added to staticVars
+// under a generated name the call is replaced with, so it is substituted
+// like any other variable.
+type functions struct {
+	staticVars    template.StaticVariables
+	vars          template.Variables
+	stores        []template.Variables
+	expectAllKeys bool
+	count         int
+}
+
+func (f *functions) evaluate(contents []byte) ([]byte, error) {
+	var evaluateErr error
+
+	contents = functionRegex.ReplaceAllFunc(contents, func(call []byte) []byte {
+		if evaluateErr != nil {
+			return call
+		}
+
+		matches := functionRegex.FindSubmatch(call)
+		name := string(matches[1])
+
+		var args []functionArgument
+		for _, arg := range functionArgumentRegex.FindAllSubmatch(matches[2], -1) {
+			if arg[2] != nil {
+				args = append(args, functionArgument{name: string(arg[2])})
+			} else {
+				args = append(args, functionArgument{literal: string(arg[1])})
+			}
+		}
+
+		value, found, err := f.call(name, args)
+		if err != nil {
+			evaluateErr = fmt.Errorf("could not evaluate %s: %s", call, err)
+			return call
+		}
+
+		if !found {
+			return call
+		}
+
+		f.count++
+		varName := fmt.Sprintf("om_function_%d", f.count)
+		f.staticVars[varName] = value
+
+		return []byte("((" + varName + "))")
+	})
+
+	return contents, evaluateErr
+}
+
+func (f *functions) call(name string, args []functionArgument) (interface{}, bool, error) {
+	if name == "password" {
+		return f.password(args)
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, found, err := f.value(arg)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !found {
+			if f.expectAllKeys {
+				return nil, false, fmt.Errorf("Expected to find variables: %s", arg.name)
+			}
+
+			return nil, false, nil
+		}
+
+		values[i] = value
+	}
+
+	if (name == "base64" || name == "sha256") && len(values) != 1 {
+		return nil, false, fmt.Errorf("%s requires one argument", name)
+	}
+
+	switch name {
+	case "base64":
+		s, err := functionString(name, values, 0)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return base64.StdEncoding.EncodeToString([]byte(s)), true, nil
+	case "sha256":
+		s, err := functionString(name, values, 0)
+		if err != nil {
+			return nil, false, err
+		}
+
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:]), true, nil
+	case "join":
+		if len(values) < 2 {
+			return nil, false, fmt.Errorf("join requires a separator and the values to join")
+		}
+
+		separator, err := functionString(name, values[:1], 0)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var pieces []string
+		for _, value := range values[1:] {
+			list, ok := value.([]interface{})
+			if !ok {
+				list = []interface{}{value}
+			}
+
+			for _, item := range list {
+				if !isScalar(item) {
+					return nil, false, fmt.Errorf("join requires strings, got %T", item)
+				}
+				pieces = append(pieces, fmt.Sprint(item))
+			}
+		}
+
+		return strings.Join(pieces, separator), true, nil
+	default:
+		if len(values) != 2 {
+			return nil, false, fmt.Errorf("split requires a separator and the value to split")
+		}
+
+		separator, err := functionString(name, values, 0)
+		if err != nil {
+			return nil, false, err
+		}
+
+		s, err := functionString(name, values, 1)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var pieces []interface{}
+		for _, piece := range strings.Split(s, separator) {
+			pieces = append(pieces, piece)
+		}
+
+		return pieces, true, nil
+	}
+}
+
+// password returns the value of the variable named by the first argument,
+// or generates it and persists it to the vars store with the highest
+// precedence that can be written to.
+func (f *functions) password(args []functionArgument) (interface{}, bool, error) {
+	if len(args) == 0 || len(args) > 2 || args[0].name == "" {
+		return nil, false, fmt.Errorf("password requires the name of the variable and optionally a length")
+	}
+
+	value, found, err := f.value(args[0])
+	if err != nil || found {
+		return value, found, err
+	}
+
+	length := DefaultPasswordLength
+	if len(args) == 2 {
+		lengthArg := args[1].literal
+		if lengthArg == "" {
+			lengthArg = args[1].name
+		}
+
+		length, err = strconv.Atoi(lengthArg)
+		if err != nil || length <= 0 {
+			return nil, false, fmt.Errorf("password length must be a positive number, got %q", lengthArg)
+		}
+	}
+
+	var store VarsStoreWriter
+	for i := len(f.stores) - 1; i >= 0 && store == nil; i-- {
+		store, _ = f.stores[i].(VarsStoreWriter)
+	}
+
+	if store == nil {
+		return nil, false, fmt.Errorf("a file:// vars store is required to persist the generated password %s", args[0].name)
+	}
+
+	password, err := GeneratePassword(length)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = store.Put(args[0].name, password)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// ((name)) is substituted with the same password in the rest of the template
+	f.staticVars[args[0].name] = password
+
+	return password, true, nil
+}
+
+// GeneratePassword returns a random password of letters and digits, as
+// generated by ((password name)).
+func GeneratePassword(length int) (string, error) {
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordCharacters))))
+		if err != nil {
+			return "", fmt.Errorf("could not generate password: %s", err)
+		}
+		password[i] = passwordCharacters[n.Int64()]
+	}
+
+	return string(password), nil
+}
+
+func (f *functions) value(arg functionArgument) (interface{}, bool, error) {
+	if arg.name == "" {
+		return arg.literal, true, nil
+	}
+
+	pieces := strings.Split(arg.name, ".")
+
+	value, found, err := f.vars.Get(template.VariableDefinition{Name: pieces[0]})
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, key := range pieces[1:] {
+		if !found {
+			break
+		}
+
+		m, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("%s is not a map", arg.name)
+		}
+
+		value, found = m[key]
+	}
+
+	return value, found, nil
+}
+
+func functionString(name string, values []interface{}, index int) (string, error) {
+	if index >= len(values) {
+		return "", fmt.Errorf("%s requires %d arguments", name, index+1)
+	}
+
+	if !isScalar(values[index]) {
+		return "", fmt.Errorf("%s requires a string, got %T", name, values[index])
+	}
+
+	return fmt.Sprint(values[index]), nil
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int64, uint64, float64:
+		return true
+	}
+
+	return false
+}
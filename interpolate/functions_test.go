@@ -0,0 +1,113 @@
+package interpolate_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pivotal-cf/om/interpolate"
+)
+
+var _ = Describe("Functions", func() {
+	execute := func(template string, options interpolate.Options) (string, error) {
+		options.TemplateFile = writeFile(template)
+		options.Functions = true
+		options.ExpectAllKeys = true
+		options.EnvironFunc = func() []string { return nil }
+
+		contents, err := interpolate.Execute(options)
+		return string(contents), err
+	}
+
+	It("encodes with base64 and hashes with sha256", func() {
+		contents, err := execute(`{encoded: ((base64 secret)), hashed: ((sha256 "literal")), url: "https://((base64 secret))@example.com"}`, interpolate.Options{
+			Vars: []string{"secret=hunter2"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML(`{encoded: aHVudGVyMg==, hashed: 829f8d848b44fa3098194754af5b60e2fb1517b0195956841beb6cac9bc68067, url: "https://aHVudGVyMg==@example.com"}`))
+	})
+
+	It("joins and splits strings", func() {
+		contents, err := execute(`{joined: ((join ", " zones "z3")), split: ((split "," hosts))}`, interpolate.Options{
+			VarsFiles: []string{writeFile(`{zones: [z1, z2], hosts: "a.example.com,b.example.com"}`)},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML(`{joined: "z1, z2, z3", split: [a.example.com, b.example.com]}`))
+	})
+
+	It("reads nested fields of variables", func() {
+		contents, err := execute(`{encoded: ((base64 cert.private_key))}`, interpolate.Options{
+			VarsFiles: []string{writeFile(`{cert: {private_key: key}}`)},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML(`{encoded: a2V5}`))
+	})
+
+	Describe("password", func() {
+		var storePath string
+
+		BeforeEach(func() {
+			storePath = filepath.Join(GinkgoT().TempDir(), "creds.yml")
+		})
+
+		It("generates a password and persists it to the file vars store", func() {
+			contents, err := execute(`{password: ((password db_password 16)), again: ((db_password))}`, interpolate.Options{
+				VarsStores: []string{"file://" + storePath},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			var output map[string]string
+			Expect(yaml.Unmarshal([]byte(contents), &output)).To(Succeed())
+			Expect(output["password"]).To(MatchRegexp(`^[a-zA-Z0-9]{16}$`))
+			Expect(output["again"]).To(Equal(output["password"]))
+
+			stored, err := os.ReadFile(storePath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stored).To(MatchYAML(`db_password: ` + output["password"]))
+
+			By("reusing the persisted password")
+			contents, err = execute(`{password: ((password db_password))}`, interpolate.Options{
+				VarsStores: []string{"file://" + storePath},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`password: ` + output["password"]))
+		})
+
+		It("uses the value of an existing variable", func() {
+			contents, err := execute(`{password: ((password db_password))}`, interpolate.Options{
+				Vars: []string{"db_password=existing"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`password: existing`))
+		})
+
+		It("requires a file vars store to persist the password", func() {
+			_, err := execute(`{password: ((password db_password))}`, interpolate.Options{})
+			Expect(err).To(MatchError("could not evaluate ((password db_password)): a file:// vars store is required to persist the generated password db_password"))
+		})
+	})
+
+	It("returns an error when a variable is missing", func() {
+		_, err := execute(`{encoded: ((base64 missing))}`, interpolate.Options{})
+		Expect(err).To(MatchError("could not evaluate ((base64 missing)): Expected to find variables: missing"))
+	})
+
+	It("returns an error when the argument is not a string", func() {
+		_, err := execute(`{encoded: ((base64 zones))}`, interpolate.Options{
+			VarsFiles: []string{writeFile(`{zones: [z1, z2]}`)},
+		})
+		Expect(err).To(MatchError("could not evaluate ((base64 zones)): base64 requires a string, got []interface {}"))
+	})
+
+	It("leaves the functions as is when not enabled", func() {
+		contents, err := interpolate.Execute(interpolate.Options{
+			TemplateFile: writeFile(`{encoded: ((base64 secret))}`),
+			Vars:         []string{"secret=hunter2"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(MatchYAML(`{encoded: ((base64 secret))}`))
+	})
+})
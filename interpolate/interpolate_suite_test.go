@@ -128,6 +128,18 @@ var _ = Describe("Execute", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(contents).To(MatchYAML(`age: 456`))
 		})
+
+		It("names the vars of a prefix given as PREFIX=ENV_PREFIX with the prefix", func() {
+			contents, err := interpolate.Execute(interpolate.Options{
+				TemplateFile: writeFile(`{shared: ((shared_name)), name: ((name))}`),
+				VarsEnvs:     []string{"shared=FOUNDATION", "PRODUCT"},
+				EnvironFunc: func() []string {
+					return []string{"FOUNDATION_name=Bob", "PRODUCT_name=Susie"}
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{shared: Bob, name: Susie}`))
+		})
 	})
 
 	When("vars files are specified", func() {
@@ -152,6 +164,34 @@ var _ = Describe("Execute", func() {
 			Expect(contents).To(MatchYAML(`name: Susie`))
 		})
 
+		It("names the vars of a file given as PREFIX=PATH with the prefix", func() {
+			contents, err := interpolate.Execute(interpolate.Options{
+				TemplateFile: writeFile(`{shared: ((shared_username)), name: ((username))}`),
+				VarsFiles: []string{
+					"shared=" + writeFile(`username: Bob`),
+					writeFile(`username: Susie`),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{shared: Bob, name: Susie}`))
+		})
+
+		It("loads a vars file whose path contains =", func() {
+			wd, err := os.Getwd()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(os.Chdir(GinkgoT().TempDir())).To(Succeed())
+			DeferCleanup(os.Chdir, wd)
+
+			Expect(os.WriteFile("shared=vars.yml", []byte(`username: Bob`), 0600)).To(Succeed())
+
+			contents, err := interpolate.Execute(interpolate.Options{
+				TemplateFile: writeFile(`{name: ((username))}`),
+				VarsFiles:    []string{"shared=vars.yml"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`name: Bob`))
+		})
+
 		It("allows individual vars to override vars files", func() {
 			contents, err := interpolate.Execute(interpolate.Options{
 				TemplateFile: writeFile(`{name: ((username))}`),
@@ -0,0 +1,67 @@
+package interpolate
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var sourcePrefixRegex = regexp.MustCompile(`^([a-zA-Z][\w-]*)=(.+)$`)
+
+// splitSourcePrefix splits a vars source given as PREFIX=SOURCE into the
+// prefix and the source. The vars of the source are then named
+// PREFIX_name, so sources with the same names do not collide.
+// A vars file whose path contains = is used as a whole.
+func splitSourcePrefix(value string, isFile bool) (string, string) {
+	matches := sourcePrefixRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return "", value
+	}
+
+	if isFile {
+		if _, err := os.Stat(value); err == nil {
+			return "", value
+		}
+	}
+
+	return matches[1], matches[2]
+}
+
+func prefixedName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "_" + name
+}
+
+// prefixedVariables exposes the variables of a vars store under a prefix.
+type prefixedVariables struct {
+	prefix string
+	vars   template.Variables
+}
+
+func (p prefixedVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	name, found := strings.CutPrefix(varDef.Name, p.prefix+"_")
+	if !found {
+		return nil, false, nil
+	}
+
+	varDef.Name = name
+	return p.vars.Get(varDef)
+}
+
+func (p prefixedVariables) List() ([]template.VariableDefinition, error) {
+	varDefs, err := p.vars.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range varDefs {
+		varDefs[i].Name = prefixedName(p.prefix, varDefs[i].Name)
+	}
+
+	return varDefs, nil
+}
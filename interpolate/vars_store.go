@@ -0,0 +1,124 @@
+package interpolate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// newVarsStore returns the variables backed by the credential store
+// described by uri. The scheme selects the store, e.g. credhub://.
+func newVarsStore(uri string, environFunc func() []string) (template.Variables, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		// the parse error quotes the uri, and with it its credentials
+		return nil, fmt.Errorf("could not parse vars store %s", redactURI(uri))
+	}
+
+	environ := environment(environFunc)
+
+	switch parsed.Scheme {
+	case "credhub":
+		return newCredHubVariables(parsed, environ)
+	case "vault":
+		return newVaultVariables(parsed, environ)
+	case "aws-sm", "aws-ssm":
+		return newAWSVariables(parsed, environ)
+	case "file":
+		return newFileVariables(parsed)
+	default:
+		return nil, fmt.Errorf("unsupported vars store %q: the scheme must be credhub, vault, aws-sm, aws-ssm or file", redactURI(uri))
+	}
+}
+
+// VarsStoreWriter is implemented by the vars stores values, such as
+// generated passwords, can be persisted to.
+type VarsStoreWriter interface {
+	Put(name string, value interface{}) error
+}
+
+// ErrVarsStoreNotWritable is returned by NewVarsStoreWriter for the vars
+// stores that can only be read from.
+var ErrVarsStoreNotWritable = errors.New("only file:// vars stores can be written to")
+
+// NewVarsStoreWriter returns the vars store described by uri when values can
+// be written to it. Only file:// vars stores can be written to.
+func NewVarsStoreWriter(uri string, environFunc func() []string) (VarsStoreWriter, error) {
+	// the scheme is checked first, so a store that cannot be written to is
+	// reported as such rather than by the credentials it is missing
+	if parsed, err := url.Parse(uri); err == nil && parsed.Scheme != "file" {
+		return nil, fmt.Errorf("vars store %q cannot be written to: %w", redactURI(uri), ErrVarsStoreNotWritable)
+	}
+
+	store, err := newVarsStore(uri, environFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, ok := store.(VarsStoreWriter)
+	if !ok {
+		return nil, fmt.Errorf("vars store %q cannot be written to: %w", redactURI(uri), ErrVarsStoreNotWritable)
+	}
+
+	return writer, nil
+}
+
+var keySeparatorRegex = regexp.MustCompile(`\(\((!?[-/\.\w\pL]+)#([-\.\w\pL]+)\)\)`)
+
+// rewriteKeySeparators turns ((path#key)) into ((path.key)) so the template
+// evaluator, which does not allow # in variable names, looks up key in the
+// secret stored at path.
+func rewriteKeySeparators(contents []byte) []byte {
+	return keySeparatorRegex.ReplaceAll(contents, []byte("(($1.$2))"))
+}
+
+func environment(environFunc func() []string) map[string]string {
+	if environFunc == nil {
+		environFunc = os.Environ
+	}
+
+	environ := map[string]string{}
+	for _, env := range environFunc() {
+		pieces := strings.SplitN(env, "=", 2)
+		if len(pieces) == 2 {
+			environ[pieces[0]] = pieces[1]
+		}
+	}
+
+	return environ
+}
+
+func redactURI(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "<invalid vars store URI>"
+	}
+
+	return parsed.Redacted()
+}
+
+// normalizeValue converts JSON decoded maps into the map type the template
+// evaluator expects, so nested fields such as ((cert.private_key)) resolve.
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		normalized := map[interface{}]interface{}{}
+		for key, value := range v {
+			normalized[key] = normalizeValue(value)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, value := range v {
+			normalized[i] = normalizeValue(value)
+		}
+		return normalized
+	}
+
+	return value
+}
@@ -0,0 +1,274 @@
+package interpolate
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+
+	"github.com/pivotal-cf/om/network"
+)
+
+const defaultKubernetesJWTPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// vaultVariables resolves variables from HashiCorp Vault. A variable names a
+// secret path and, optionally, a key within it: ((secret/path#key)) or
+// ((secret/path.key)); the template evaluator looks up the key in the secret.
+// Relative paths are looked up under the path of the vars store URI, absolute
+// paths (starting with /) are used as is.
+type vaultVariables struct {
+	client    *http.Client
+	address   string
+	prefix    string
+	namespace string
+	kvVersion string
+
+	login func() (string, error)
+	token string
+}
+
+// newVaultVariables builds a client from a URI of the form
+//
+//	vault://host[:port][/path]?auth=token|approle|kubernetes&kv-version=1|2&role=...&ca-cert=...&skip-tls-validation=true
+//
+// The host defaults to VAULT_ADDR. Credentials come from the environment:
+// VAULT_TOKEN for token auth (the default), VAULT_ROLE_ID and VAULT_SECRET_ID
+// for approle, and the pod's service account token for kubernetes.
+func newVaultVariables(uri *url.URL, environ map[string]string) (*vaultVariables, error) {
+	query := uri.Query()
+
+	address := "https://" + uri.Host
+	if uri.Host == "" {
+		address = strings.TrimSuffix(environ["VAULT_ADDR"], "/")
+	}
+	if address == "" {
+		return nil, fmt.Errorf("vault vars store %q must include a host or VAULT_ADDR must be set", uri.Redacted())
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: query.Get("skip-tls-validation") == "true",
+	}
+
+	caCert := query.Get("ca-cert")
+	if caCert == "" {
+		caCert = environ["VAULT_CACERT"]
+	}
+	if caCert != "" {
+		contents, err := network.ReadPEM(caCert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read vault ca-cert: %s", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(contents)) {
+			return nil, errors.New("could not use vault ca-cert: no certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	kvVersion := query.Get("kv-version")
+	if kvVersion == "" {
+		kvVersion = "2"
+	}
+	if kvVersion != "1" && kvVersion != "2" {
+		return nil, fmt.Errorf("vault kv-version must be 1 or 2, got %q", kvVersion)
+	}
+
+	namespace := query.Get("namespace")
+	if namespace == "" {
+		namespace = environ["VAULT_NAMESPACE"]
+	}
+
+	v := &vaultVariables{
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConfig},
+		},
+		address:   address,
+		prefix:    uri.Path,
+		namespace: namespace,
+		kvVersion: kvVersion,
+	}
+
+	switch authMethod := query.Get("auth"); authMethod {
+	case "", "token":
+		token := environ["VAULT_TOKEN"]
+		if token == "" {
+			return nil, errors.New("vault token auth requires VAULT_TOKEN to be set")
+		}
+		v.login = func() (string, error) { return token, nil }
+	case "approle":
+		roleID := query.Get("role-id")
+		if roleID == "" {
+			roleID = environ["VAULT_ROLE_ID"]
+		}
+		secretID := environ["VAULT_SECRET_ID"]
+		if roleID == "" || secretID == "" {
+			return nil, errors.New("vault approle auth requires a role-id (or VAULT_ROLE_ID) and VAULT_SECRET_ID")
+		}
+
+		mount := mountOrDefault(query.Get("auth-mount"), "approle")
+		v.login = func() (string, error) {
+			return v.authenticate(mount, map[string]string{"role_id": roleID, "secret_id": secretID})
+		}
+	case "kubernetes":
+		role := query.Get("role")
+		if role == "" {
+			return nil, errors.New("vault kubernetes auth requires a role")
+		}
+
+		jwtPath := query.Get("jwt-path")
+		if jwtPath == "" {
+			jwtPath = defaultKubernetesJWTPath
+		}
+
+		mount := mountOrDefault(query.Get("auth-mount"), "kubernetes")
+		v.login = func() (string, error) {
+			jwt, err := os.ReadFile(jwtPath)
+			if err != nil {
+				return "", fmt.Errorf("could not read kubernetes service account token: %s", err)
+			}
+
+			return v.authenticate(mount, map[string]string{"role": role, "jwt": strings.TrimSpace(string(jwt))})
+		}
+	default:
+		return nil, fmt.Errorf("unsupported vault auth %q: must be token, approle or kubernetes", authMethod)
+	}
+
+	return v, nil
+}
+
+func mountOrDefault(mount, defaultMount string) string {
+	if mount == "" {
+		return defaultMount
+	}
+
+	return strings.Trim(mount, "/")
+}
+
+func (v *vaultVariables) Get(varDef template.VariableDefinition) (interface{}, bool, error) {
+	secretPath := varDef.Name
+	if !strings.HasPrefix(secretPath, "/") {
+		secretPath = path.Join("/", v.prefix, secretPath)
+	}
+	secretPath = strings.TrimPrefix(secretPath, "/")
+
+	if v.token == "" {
+		token, err := v.login()
+		if err != nil {
+			return nil, false, err
+		}
+		v.token = token
+	}
+
+	data, found, err := v.read(secretPath)
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	return normalizeValue(data), true, nil
+}
+
+func (v *vaultVariables) List() ([]template.VariableDefinition, error) {
+	return nil, nil
+}
+
+func (v *vaultVariables) read(secretPath string) (map[string]interface{}, bool, error) {
+	apiPath := secretPath
+	if v.kvVersion == "2" {
+		// KV v2 nests secrets under data/ after the mount
+		pieces := strings.SplitN(secretPath, "/", 2)
+		if len(pieces) == 2 {
+			apiPath = pieces[0] + "/data/" + pieces[1]
+		}
+	}
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	status, err := v.do(http.MethodGet, apiPath, nil, &response)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not read %s from vault: %w", secretPath, err)
+	}
+	if status == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if v.kvVersion == "2" {
+		data, _ := response.Data["data"].(map[string]interface{})
+		return data, data != nil, nil
+	}
+
+	return response.Data, response.Data != nil, nil
+}
+
+func (v *vaultVariables) authenticate(mount string, payload map[string]string) (string, error) {
+	var response struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+
+	_, err := v.do(http.MethodPost, "auth/"+mount+"/login", payload, &response)
+	if err != nil {
+		return "", fmt.Errorf("could not log in to vault: %w", err)
+	}
+	if response.Auth.ClientToken == "" {
+		return "", errors.New("could not log in to vault: no client token was returned")
+	}
+
+	return response.Auth.ClientToken, nil
+}
+
+func (v *vaultVariables) do(method, apiPath string, payload interface{}, output interface{}) (int, error) {
+	var body bytes.Buffer
+	if payload != nil {
+		err := json.NewEncoder(&body).Encode(payload)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	request, err := http.NewRequest(method, v.address+"/v1/"+apiPath, &body)
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if v.token != "" {
+		request.Header.Set("X-Vault-Token", v.token)
+	}
+	if v.namespace != "" {
+		request.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	response, err := v.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return response.StatusCode, nil
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(response.Body).Decode(&vaultErr)
+
+		return response.StatusCode, fmt.Errorf("unexpected status %d: %s", response.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+
+	return response.StatusCode, json.NewDecoder(response.Body).Decode(output)
+}
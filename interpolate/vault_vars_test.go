@@ -0,0 +1,133 @@
+package interpolate_test
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/interpolate"
+)
+
+var _ = Describe("Vault vars store", func() {
+	var (
+		server  *ghttp.Server
+		environ []string
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewTLSServer()
+		environ = nil
+
+		server.RouteToHandler("GET", "/v1/secret/data/om/product", ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+			ghttp.RespondWith(http.StatusOK, `{"data": {"data": {"password": "from-vault", "port": 8443}, "metadata": {"version": 1}}}`),
+		))
+		server.RouteToHandler("GET", "/v1/kv/om/product", ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("X-Vault-Token", "some-token"),
+			ghttp.RespondWith(http.StatusOK, `{"data": {"password": "from-kv-v1"}}`),
+		))
+		server.RouteToHandler("GET", "/v1/secret/data/om/unknown", ghttp.RespondWith(http.StatusNotFound, `{"errors": []}`))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	storeURI := func(path, query string) string {
+		u, err := url.Parse(server.URL())
+		Expect(err).ToNot(HaveOccurred())
+
+		return "vault://" + u.Host + path + "?skip-tls-validation=true" + query
+	}
+
+	execute := func(template string, stores ...string) (string, error) {
+		contents, err := interpolate.Execute(interpolate.Options{
+			TemplateFile:  writeFile(template),
+			VarsStores:    stores,
+			EnvironFunc:   func() []string { return environ },
+			ExpectAllKeys: true,
+		})
+		return string(contents), err
+	}
+
+	When("using token auth", func() {
+		BeforeEach(func() {
+			environ = []string{"VAULT_TOKEN=some-token"}
+		})
+
+		It("resolves path#key placeholders from KV v2", func() {
+			contents, err := execute(`{password: ((secret/om/product#password)), port: ((/secret/om/product.port))}`, storeURI("", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-vault, port: 8443}`))
+		})
+
+		It("resolves paths relative to the store prefix", func() {
+			contents, err := execute(`{password: ((product#password))}`, storeURI("/secret/om", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-vault}`))
+		})
+
+		It("supports KV v1", func() {
+			contents, err := execute(`{password: ((kv/om/product#password))}`, storeURI("", "&kv-version=1"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-kv-v1}`))
+		})
+
+		It("reports secrets that do not exist as missing", func() {
+			_, err := execute(`{password: ((secret/om/unknown#password))}`, storeURI("", ""))
+			Expect(err).To(MatchError(ContainSubstring("Expected to find variables: secret/om/unknown")))
+		})
+
+		It("uses VAULT_ADDR when the URI has no host", func() {
+			environ = append(environ, "VAULT_ADDR="+server.URL())
+
+			contents, err := execute(`{password: ((secret/om/product#password))}`, "vault://?skip-tls-validation=true")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).To(MatchYAML(`{password: from-vault}`))
+		})
+	})
+
+	It("requires VAULT_TOKEN for token auth", func() {
+		_, err := execute(`{password: ((secret/om/product#password))}`, storeURI("", ""))
+		Expect(err).To(MatchError("vault token auth requires VAULT_TOKEN to be set"))
+	})
+
+	It("logs in with approle", func() {
+		environ = []string{"VAULT_ROLE_ID=some-role-id", "VAULT_SECRET_ID=some-secret-id"}
+		server.RouteToHandler("POST", "/v1/auth/approle/login", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"role_id": "some-role-id", "secret_id": "some-secret-id"}`),
+			ghttp.RespondWith(http.StatusOK, `{"auth": {"client_token": "some-token"}}`),
+		))
+
+		contents, err := execute(`{password: ((secret/om/product#password))}`, storeURI("", "&auth=approle"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML(`{password: from-vault}`))
+	})
+
+	It("logs in with the kubernetes service account token", func() {
+		jwtPath := filepath.Join(GinkgoT().TempDir(), "token")
+		Expect(os.WriteFile(jwtPath, []byte("some-jwt\n"), 0600)).To(Succeed())
+
+		server.RouteToHandler("POST", "/v1/auth/kubernetes/login", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"role": "om", "jwt": "some-jwt"}`),
+			ghttp.RespondWith(http.StatusOK, `{"auth": {"client_token": "some-token"}}`),
+		))
+
+		contents, err := execute(`{password: ((secret/om/product#password))}`, storeURI("", "&auth=kubernetes&role=om&jwt-path="+url.QueryEscape(jwtPath)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML(`{password: from-vault}`))
+	})
+
+	It("returns the error from a failed login", func() {
+		environ = []string{"VAULT_ROLE_ID=some-role-id", "VAULT_SECRET_ID=wrong"}
+		server.RouteToHandler("POST", "/v1/auth/approle/login", ghttp.RespondWith(http.StatusBadRequest, `{"errors": ["invalid secret id"]}`))
+
+		_, err := execute(`{password: ((secret/om/product#password))}`, storeURI("", "&auth=approle"))
+		Expect(err).To(MatchError(ContainSubstring("could not log in to vault: unexpected status 400: invalid secret id")))
+	})
+})
@@ -17,10 +17,39 @@ var applySleepDurationString = "10s"
 func main() {
 	err := cmd.Main(os.Stdout, os.Stderr, version, applySleepDurationString, os.Args)
 	if err != nil {
-		if errors.Is(err, commands.ErrBoshDiffChangesExist) {
+		// bosh, commands run over ssh and plugins have reported their errors already
+		var boshErr commands.BoshCLIError
+		if errors.As(err, &boshErr) {
+			os.Exit(boshErr.ExitCode)
+		}
+		var sshErr commands.SSHCommandError
+		if errors.As(err, &sshErr) {
+			os.Exit(sshErr.ExitCode)
+		}
+		var pluginErr cmd.PluginError
+		if errors.As(err, &pluginErr) {
+			os.Exit(pluginErr.ExitCode)
+		}
+		if errors.Is(err, commands.ErrBoshDiffChangesExist) || errors.Is(err, commands.ErrPendingChangesExist) || errors.Is(err, commands.ErrConfigDriftExists) {
 			log.Print(err)
 			os.Exit(2)
 		}
+		if errors.Is(err, commands.ErrExpiringCertificates) || errors.Is(err, commands.ErrExpiringLicenses) {
+			log.Print(err)
+			os.Exit(3)
+		}
+		if errors.Is(err, commands.ErrExpiredCertificates) || errors.Is(err, commands.ErrExpiredLicenses) {
+			log.Print(err)
+			os.Exit(4)
+		}
+		if errors.Is(err, commands.ErrPendingChangesIncomplete) {
+			log.Print(err)
+			os.Exit(5)
+		}
+		if errors.Is(err, commands.ErrApplyChangesTimedOut) {
+			log.Print(err)
+			os.Exit(6)
+		}
 		log.Fatal(err)
 	}
 }
@@ -8,6 +8,7 @@ type Installation struct {
 	StartedAt  *time.Time `json:"started_at"`
 	Status     string     `json:"status"`
 	User       string     `json:"user"`
+	Duration   *int       `json:"duration_seconds,omitempty"`
 }
 
 type Product struct {
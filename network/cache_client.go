@@ -0,0 +1,244 @@
+package network
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cacheEntrySuffix = ".json"
+	cacheMaxAge      = 7 * 24 * time.Hour
+)
+
+// cacheablePaths are the metadata endpoints whose responses are cached.
+// Responses with credentials, e.g. of .../credentials, manifests or
+// ?redact=false, are never written to disk.
+var cacheablePaths = regexp.MustCompile(`^/api/v0/(` + strings.Join([]string{
+	`info`,
+	`available_products`,
+	`diagnostic_report`,
+	`(staged|deployed)/products`,
+	`staged/products/[^/]+/(properties|jobs|errands|networks_and_azs|syslog_configuration|max_in_flight)`,
+	`staged/products/[^/]+/jobs/[^/]+/resource_config`,
+	`staged/director/(properties|availability_zones|networks)`,
+}, "|") + `)$`)
+
+// CacheClient keeps the responses to GET requests of product and director
+// metadata that carry an ETag in a directory, and revalidates them with
+// If-None-Match, so the large metadata of products is only transferred again
+// when it has changed. Entries are keyed by URL and identity, and are all
+// dropped as soon as a request changes anything in Ops Manager.
+type CacheClient struct {
+	client   httpClient
+	dir      string
+	identity string
+	writer   io.Writer
+}
+
+type cacheEntry struct {
+	URL        string      `json:"url"`
+	ETag       string      `json:"etag"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewCacheClient caches in dir for identity, e.g. the user or client, since
+// the responses depend on the permissions of whoever requested them. Problems
+// with the cache are written to writer and never fail a request.
+func NewCacheClient(client httpClient, dir, identity string, writer io.Writer) *CacheClient {
+	return &CacheClient{
+		client:   client,
+		dir:      dir,
+		identity: identity,
+		writer:   writer,
+	}
+}
+
+func (c *CacheClient) Do(request *http.Request) (*http.Response, error) {
+	switch {
+	case request.Method == http.MethodHead, request.Method == http.MethodOptions:
+		return c.client.Do(request)
+	case request.Method != http.MethodGet:
+		response, err := c.client.Do(request)
+		if err == nil && response.StatusCode < 400 {
+			c.clear()
+		}
+
+		return response, err
+	case request.Header.Get("Range") != "", request.Header.Get("If-None-Match") != "":
+		return c.client.Do(request)
+	case !cacheablePaths.MatchString(request.URL.Path), request.URL.Query().Get("redact") == "false":
+		return c.client.Do(request)
+	}
+
+	path := c.entryPath(request)
+	entry, found := c.load(path)
+	if found {
+		request.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && response.StatusCode == http.StatusNotModified {
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+
+		return entry.response(request), nil
+	}
+
+	etag := response.Header.Get("ETag")
+	if response.StatusCode != http.StatusOK || etag == "" || strings.Contains(response.Header.Get("Cache-Control"), "no-store") {
+		if found {
+			_ = os.Remove(path)
+		}
+
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.store(path, cacheEntry{
+		URL:        request.URL.String(),
+		ETag:       etag,
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       body,
+	})
+
+	return response, nil
+}
+
+func (c *CacheClient) entryPath(request *http.Request) string {
+	key := sha256.Sum256([]byte(strings.Join([]string{c.identity, request.Header.Get("Accept"), request.URL.String()}, "\n")))
+	return filepath.Join(c.dir, hex.EncodeToString(key[:])+cacheEntrySuffix)
+}
+
+func (c *CacheClient) load(path string) (cacheEntry, bool) {
+	var entry cacheEntry
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+
+	err = json.Unmarshal(contents, &entry)
+	if err != nil || entry.ETag == "" {
+		// a corrupt entry is dropped and fetched again
+		_ = os.Remove(path)
+		return entry, false
+	}
+
+	return entry, true
+}
+
+func (c *CacheClient) store(path string, entry cacheEntry) {
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(c.dir, 0700)
+	if err != nil {
+		c.warn(err)
+		return
+	}
+
+	// written to a temporary file first, so concurrent commands never read
+	// half an entry
+	file, err := os.CreateTemp(c.dir, ".entry-*")
+	if err != nil {
+		c.warn(err)
+		return
+	}
+	_, err = file.Write(contents)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		c.warn(err)
+		return
+	}
+
+	c.prune()
+}
+
+// prune removes the entries that have not been used for a week.
+func (c *CacheClient) prune() {
+	c.removeEntries(func(info os.FileInfo) bool {
+		return time.Since(info.ModTime()) > cacheMaxAge
+	})
+}
+
+func (c *CacheClient) clear() {
+	c.removeEntries(func(os.FileInfo) bool { return true })
+}
+
+func (c *CacheClient) removeEntries(remove func(os.FileInfo) bool) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), cacheEntrySuffix) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err == nil && remove(info) {
+			_ = os.Remove(filepath.Join(c.dir, dirEntry.Name()))
+		}
+	}
+}
+
+func (c *CacheClient) warn(err error) {
+	_, _ = fmt.Fprintf(c.writer, "could not write to the response cache in %s: %s\n", c.dir, err)
+}
+
+func (e cacheEntry) response(request *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       request,
+	}
+}
@@ -0,0 +1,185 @@
+package network_test
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Cache Client", func() {
+	var (
+		fakeClient  *fakes.HttpClient
+		cacheDir    string
+		cacheClient *network.CacheClient
+		out         *gbytes.Buffer
+	)
+
+	respond := func(status int, etag, body string) *http.Response {
+		header := http.Header{"Content-Type": []string{"application/json"}}
+		if etag != "" {
+			header.Set("ETag", etag)
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	get := func(path string) *http.Response {
+		request, err := http.NewRequest("GET", "https://opsman.example.com"+path, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := cacheClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+
+		return response
+	}
+
+	readBody := func(response *http.Response) string {
+		body, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(body)
+	}
+
+	entries := func() []string {
+		matches, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+		Expect(err).ToNot(HaveOccurred())
+
+		return matches
+	}
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		cacheDir = filepath.Join(GinkgoT().TempDir(), "cache")
+		out = gbytes.NewBuffer()
+
+		cacheClient = network.NewCacheClient(fakeClient, cacheDir, "admin", out)
+	})
+
+	It("serves the cached body when the ETag still matches", func() {
+		fakeClient.DoReturnsOnCall(0, respond(http.StatusOK, `"v1"`, `{"metadata": "large"}`), nil)
+		fakeClient.DoReturnsOnCall(1, respond(http.StatusNotModified, `"v1"`, ""), nil)
+
+		Expect(readBody(get("/api/v0/staged/products/some-guid/properties"))).To(Equal(`{"metadata": "large"}`))
+		Expect(fakeClient.DoArgsForCall(0).Header.Get("If-None-Match")).To(BeEmpty())
+
+		response := get("/api/v0/staged/products/some-guid/properties")
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(readBody(response)).To(Equal(`{"metadata": "large"}`))
+		Expect(fakeClient.DoArgsForCall(1).Header.Get("If-None-Match")).To(Equal(`"v1"`))
+	})
+
+	It("replaces the cached body when it has changed", func() {
+		fakeClient.DoReturnsOnCall(0, respond(http.StatusOK, `"v1"`, "old"), nil)
+		fakeClient.DoReturnsOnCall(1, respond(http.StatusOK, `"v2"`, "new"), nil)
+		fakeClient.DoReturnsOnCall(2, respond(http.StatusNotModified, "", ""), nil)
+
+		get("/api/v0/info")
+		Expect(readBody(get("/api/v0/info"))).To(Equal("new"))
+		Expect(readBody(get("/api/v0/info"))).To(Equal("new"))
+		Expect(fakeClient.DoArgsForCall(2).Header.Get("If-None-Match")).To(Equal(`"v2"`))
+	})
+
+	It("keeps entries apart by identity", func() {
+		fakeClient.DoReturns(respond(http.StatusOK, `"v1"`, "body"), nil)
+
+		get("/api/v0/info")
+		cacheClient = network.NewCacheClient(fakeClient, cacheDir, "some-client", out)
+		get("/api/v0/info")
+
+		Expect(fakeClient.DoArgsForCall(1).Header.Get("If-None-Match")).To(BeEmpty())
+		Expect(entries()).To(HaveLen(2))
+	})
+
+	It("does not cache responses without an ETag, errors, or no-store responses", func() {
+		fakeClient.DoReturnsOnCall(0, respond(http.StatusOK, "", "no etag"), nil)
+		fakeClient.DoReturnsOnCall(1, respond(http.StatusNotFound, `"v1"`, "not found"), nil)
+		noStore := respond(http.StatusOK, `"v1"`, "secret")
+		noStore.Header.Set("Cache-Control", "no-store")
+		fakeClient.DoReturnsOnCall(2, noStore, nil)
+
+		Expect(readBody(get("/api/v0/info"))).To(Equal("no etag"))
+		Expect(readBody(get("/api/v0/staged/products"))).To(Equal("not found"))
+		Expect(readBody(get("/api/v0/deployed/products"))).To(Equal("secret"))
+		Expect(entries()).To(BeEmpty())
+	})
+
+	It("does not cache responses with credentials", func() {
+		fakeClient.DoReturns(respond(http.StatusOK, `"v1"`, `{"credential": "secret"}`), nil)
+
+		Expect(readBody(get("/api/v0/deployed/products/some-guid/credentials/.properties.some-credentials"))).To(Equal(`{"credential": "secret"}`))
+		get("/api/v0/deployed/director/credentials/bosh_commandline_credentials")
+		get("/api/v0/staged/products/some-guid/manifest")
+		get("/api/v0/staged/products/some-guid/properties?redact=false")
+		Expect(entries()).To(BeEmpty())
+
+		get("/api/v0/deployed/products/some-guid/credentials/.properties.some-credentials")
+		Expect(fakeClient.DoArgsForCall(4).Header.Get("If-None-Match")).To(BeEmpty())
+	})
+
+	It("drops every entry when a request changes something", func() {
+		fakeClient.DoReturnsOnCall(0, respond(http.StatusOK, `"v1"`, "body"), nil)
+		fakeClient.DoReturnsOnCall(1, respond(http.StatusOK, "", ""), nil)
+		fakeClient.DoReturnsOnCall(2, respond(http.StatusOK, `"v2"`, "body"), nil)
+
+		get("/api/v0/staged/products")
+		Expect(entries()).To(HaveLen(1))
+
+		request, err := http.NewRequest("PUT", "https://opsman.example.com/api/v0/staged/products/some-guid/properties", strings.NewReader("{}"))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = cacheClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries()).To(BeEmpty())
+
+		get("/api/v0/staged/products")
+		Expect(fakeClient.DoArgsForCall(2).Header.Get("If-None-Match")).To(BeEmpty())
+	})
+
+	It("removes entries not used for a week", func() {
+		fakeClient.DoReturns(respond(http.StatusOK, `"v1"`, "body"), nil)
+
+		get("/api/v0/staged/products")
+		Expect(entries()).To(HaveLen(1))
+		old := entries()[0]
+		lastWeek := time.Now().Add(-8 * 24 * time.Hour)
+		Expect(os.Chtimes(old, lastWeek, lastWeek)).To(Succeed())
+
+		get("/api/v0/deployed/products")
+		Expect(entries()).To(HaveLen(1))
+		Expect(entries()[0]).ToNot(Equal(old))
+	})
+
+	It("passes range requests through", func() {
+		fakeClient.DoReturns(respond(http.StatusPartialContent, `"v1"`, "part"), nil)
+
+		request, err := http.NewRequest("GET", "https://opsman.example.com/download", nil)
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Range", "bytes=0-3")
+
+		_, err = cacheClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries()).To(BeEmpty())
+	})
+
+	It("warns instead of failing when the cache cannot be written", func() {
+		Expect(os.WriteFile(cacheDir, []byte("not a directory"), 0600)).To(Succeed())
+		fakeClient.DoReturns(respond(http.StatusOK, `"v1"`, "body"), nil)
+
+		Expect(readBody(get("/api/v0/info"))).To(Equal("body"))
+		Expect(out).To(gbytes.Say("could not write to the response cache in " + cacheDir))
+	})
+})
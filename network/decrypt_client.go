@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -17,7 +18,10 @@ type DecryptClient struct {
 	unauthedClient httpClient
 	authedClient   httpClient
 
-	tried bool // to enforce only unlock once in the entire run
+	// unlock only once in the entire run, even when requests are sent
+	// from several goroutines
+	unlock    sync.Once
+	unlockErr error
 
 	decryptionPassphrase string
 	writer               io.Writer
@@ -33,12 +37,12 @@ func NewDecryptClient(authdClient httpClient, unAuthedClient httpClient, decrypt
 }
 
 func (c *DecryptClient) Do(request *http.Request) (*http.Response, error) {
-	if !c.tried {
-		if err := c.decrypt(); err != nil {
-			return nil, err
-		}
+	c.unlock.Do(func() {
+		c.unlockErr = c.decrypt()
+	})
+	if c.unlockErr != nil {
+		return nil, c.unlockErr
 	}
-	c.tried = true
 
 	return c.authedClient.Do(request)
 }
@@ -86,7 +90,7 @@ func (c *DecryptClient) decrypt() error {
 	return c.waitUntilAvailable()
 }
 
-func (c DecryptClient) waitUntilAvailable() error {
+func (c *DecryptClient) waitUntilAvailable() error {
 	trial := 1
 	for {
 		if trial == 2 {
@@ -142,7 +146,7 @@ func IsTemporary(err error) bool {
 	return ok && te.Temporary()
 }
 
-func (c DecryptClient) checkAvailability() error {
+func (c *DecryptClient) checkAvailability() error {
 	// the below code is copied from api/setup_service. Don't really want to import api here as it will break
 	// dag dependency graph. It's probably make sense to separate that logic from api package into a standalone one
 	// to just maintain the dependencies.
@@ -0,0 +1,21 @@
+package network
+
+import (
+	"net/http"
+	"time"
+)
+
+// NewExternalClient returns a client for servers other than Ops Manager and
+// UAA, e.g. the server a product is streamed from. It trusts the same CAs,
+// skips the same certificate validation and goes through the same proxy as
+// the clients of Ops Manager, but follows redirects and sends no client
+// certificate.
+func NewExternalClient(insecureSkipVerify bool, caCert string, socksProxy string, connectTimeout time.Duration, requestTimeout time.Duration) (*http.Client, error) {
+	client, err := newHTTPClient(insecureSkipVerify, caCert, "", "", socksProxy, requestTimeout, connectTimeout, TransportOptions{})
+	if err != nil {
+		return nil, err
+	}
+	client.CheckRedirect = nil
+
+	return client, nil
+}
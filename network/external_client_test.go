@@ -0,0 +1,75 @@
+package network_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/pivotal-cf/om/network"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExternalClient", func() {
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/redirect", func(w http.ResponseWriter, req *http.Request) {
+			http.Redirect(w, req, "/product", http.StatusFound)
+		})
+		mux.HandleFunc("/product", func(w http.ResponseWriter, req *http.Request) {
+			_, _ = w.Write([]byte("some product"))
+		})
+		server = httptest.NewTLSServer(mux)
+		server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("follows redirects", func() {
+		client, err := network.NewExternalClient(true, "", "", 5*time.Second, 30*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := client.Get(server.URL + "/redirect")
+		Expect(err).ToNot(HaveOccurred())
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("some product"))
+	})
+
+	It("validates the certificate of the server unless told not to", func() {
+		client, err := network.NewExternalClient(false, "", "", 5*time.Second, 30*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.Get(server.URL + "/product")
+		Expect(err).To(MatchError(ContainSubstring("certificate")))
+	})
+
+	It("trusts the given CA", func() {
+		cert, err := x509.ParseCertificate(server.TLS.Certificates[0].Certificate[0])
+		Expect(err).ToNot(HaveOccurred())
+		pemCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+
+		client, err := network.NewExternalClient(false, pemCert, "", 5*time.Second, 30*time.Second)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := client.Get(server.URL + "/product")
+		Expect(err).ToNot(HaveOccurred())
+		response.Body.Close()
+	})
+
+	It("returns an error when the CA cannot be used", func() {
+		_, err := network.NewExternalClient(false, "-----BEGIN CERTIFICATE-----invalid", "", 5*time.Second, 30*time.Second)
+		Expect(err).To(MatchError("could not use ca cert"))
+	})
+})
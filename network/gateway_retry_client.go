@@ -0,0 +1,83 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const maxGatewayRetryInterval = 30 * time.Second
+
+type GatewayRetryClient struct {
+	client      httpClient
+	gracePeriod time.Duration
+	interval    time.Duration
+	writer      io.Writer
+}
+
+// NewGatewayRetryClient retries GET and HEAD requests that fail with 502, 503
+// or 504 until gracePeriod has passed. Ops Manager returns these briefly while
+// it or its proxy restarts, e.g. while the director is being redeployed. The
+// wait between attempts starts at interval and doubles up to 30 seconds. An
+// interval of 0 retries at once.
+func NewGatewayRetryClient(client httpClient, gracePeriod, interval time.Duration, writer io.Writer) *GatewayRetryClient {
+	return &GatewayRetryClient{
+		client:      client,
+		gracePeriod: gracePeriod,
+		interval:    interval,
+		writer:      writer,
+	}
+}
+
+func (c *GatewayRetryClient) Do(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		return c.client.Do(request)
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		response, err := c.client.Do(request)
+		if err != nil || !isGatewayError(response.StatusCode) {
+			return response, err
+		}
+
+		// doubled until the maximum, so a large interval cannot overflow, and
+		// an interval of 0 retries at once
+		delay := c.interval
+		for i := 1; i < attempt && delay < maxGatewayRetryInterval; i++ {
+			delay *= 2
+		}
+		if delay > maxGatewayRetryInterval {
+			delay = maxGatewayRetryInterval
+		}
+
+		remaining := c.gracePeriod - time.Since(start)
+		if remaining <= 0 {
+			return response, nil
+		}
+		if delay > remaining {
+			delay = remaining
+		}
+
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+
+		_, _ = fmt.Fprintf(c.writer, "request %s %s returned %d %s; retrying in %s (giving up in %s)\n", request.Method, request.URL.Path, response.StatusCode, http.StatusText(response.StatusCode), delay, remaining.Round(time.Second))
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func isGatewayError(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
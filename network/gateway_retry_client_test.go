@@ -0,0 +1,131 @@
+package network_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Gateway Retry Client", func() {
+	var (
+		fakeClient  *fakes.HttpClient
+		retryClient *network.GatewayRetryClient
+
+		out *gbytes.Buffer
+	)
+
+	gatewayError := func(statusCode int) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(strings.NewReader("<html>Bad Gateway</html>")),
+		}
+	}
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		out = gbytes.NewBuffer()
+
+		retryClient = network.NewGatewayRetryClient(fakeClient, time.Second, time.Millisecond, out)
+	})
+
+	It("retries GET requests until the gateway recovers", func() {
+		response := &http.Response{StatusCode: http.StatusOK}
+		fakeClient.DoReturnsOnCall(0, gatewayError(http.StatusBadGateway), nil)
+		fakeClient.DoReturnsOnCall(1, gatewayError(http.StatusServiceUnavailable), nil)
+		fakeClient.DoReturnsOnCall(2, gatewayError(http.StatusGatewayTimeout), nil)
+		fakeClient.DoReturnsOnCall(3, response, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/installations/1", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp).To(Equal(response))
+		Expect(fakeClient.DoCallCount()).To(Equal(4))
+		Expect(out).To(gbytes.Say(`request GET /api/v0/installations/1 returned 502 Bad Gateway; retrying in 1ms`))
+	})
+
+	It("returns the gateway error once the grace period has passed", func() {
+		retryClient = network.NewGatewayRetryClient(fakeClient, 20*time.Millisecond, 5*time.Millisecond, out)
+		fakeClient.DoStub = func(*http.Request) (*http.Response, error) {
+			return gatewayError(http.StatusBadGateway), nil
+		}
+
+		request, err := http.NewRequest("GET", "/api/v0/installations/1", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(fakeClient.DoCallCount()).To(BeNumerically(">", 1))
+	})
+
+	It("retries at once with an interval of 0", func() {
+		retryClient = network.NewGatewayRetryClient(fakeClient, time.Minute, 0, out)
+		response := &http.Response{StatusCode: http.StatusOK}
+		for i := 0; i < 10; i++ {
+			fakeClient.DoReturnsOnCall(i, gatewayError(http.StatusBadGateway), nil)
+		}
+		fakeClient.DoReturnsOnCall(10, response, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/installations/1", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		start := time.Now()
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp).To(Equal(response))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		Expect(out).To(gbytes.Say(`retrying in 0s`))
+	})
+
+	It("waits at most 30 seconds between attempts", func() {
+		retryClient = network.NewGatewayRetryClient(fakeClient, time.Hour, 24*time.Hour, out)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		fakeClient.DoStub = func(*http.Request) (*http.Response, error) {
+			cancel()
+			return gatewayError(http.StatusBadGateway), nil
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "GET", "/api/v0/installations/1", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(out).To(gbytes.Say(`retrying in 30s`))
+	})
+
+	It("does not retry other status codes", func() {
+		fakeClient.DoReturns(gatewayError(http.StatusInternalServerError), nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusInternalServerError))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry requests that may change state", func() {
+		fakeClient.DoReturns(gatewayError(http.StatusBadGateway), nil)
+
+		request, err := http.NewRequest("POST", "/api/v0/installations", strings.NewReader("{}"))
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadGateway))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+})
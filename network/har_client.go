@@ -0,0 +1,415 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HARRecorder collects the requests made by one or more HARClients and keeps
+// them, with credentials redacted, in a HAR (HTTP Archive 1.2) file. An entry
+// is appended once its response has been read, and the file is valid JSON
+// after every write, so it is complete even when om exits with an error.
+type HARRecorder struct {
+	path    string
+	creator harCreator
+	writer  io.Writer
+
+	mutex sync.Mutex
+	file  *os.File
+	// completedEnd is the offset at which the entries still waiting for
+	// their response bodies are written
+	completedEnd int64
+	completed    int
+	pending      map[int]*harEntry
+	order        []int
+	nextID       int
+	failed       bool
+}
+
+func NewHARRecorder(path, version string, writer io.Writer) *HARRecorder {
+	return &HARRecorder{
+		path:    path,
+		creator: harCreator{Name: "om", Version: version},
+		writer:  writer,
+		pending: map[int]*harEntry{},
+	}
+}
+
+type HARClient struct {
+	client   httpClient
+	recorder *HARRecorder
+}
+
+func NewHARClient(client httpClient, recorder *HARRecorder) *HARClient {
+	return &HARClient{
+		client:   client,
+		recorder: recorder,
+	}
+}
+
+func (c *HARClient) Do(request *http.Request) (*http.Response, error) {
+	entry := harEntry{
+		StartedDateTime: time.Now().UTC().Format(time.RFC3339Nano),
+		Request:         newHARRequest(request),
+		Cache:           struct{}{},
+	}
+
+	if request.Body != nil && request.ContentLength > 0 && request.ContentLength < maxBodySize {
+		body, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+
+		entry.Request.BodySize = len(body)
+		entry.Request.PostData = &harPostData{
+			MimeType: request.Header.Get("Content-Type"),
+			Text:     string(redactBody(request.Header.Get("Content-Type"), body)),
+		}
+	}
+
+	start := time.Now()
+	response, err := c.client.Do(request)
+	wait := time.Since(start)
+	if err != nil {
+		entry.Time = milliseconds(wait)
+		entry.Timings = harTimings{Wait: milliseconds(wait)}
+		entry.Response = harResponse{
+			StatusText: err.Error(),
+			Headers:    []harNameValue{},
+			Cookies:    []harNameValue{},
+			BodySize:   -1,
+		}
+		c.recorder.record(entry)
+
+		return nil, err
+	}
+
+	entry.Time = milliseconds(wait)
+	entry.Timings = harTimings{Wait: milliseconds(wait)}
+	entry.Response = harResponse{
+		Status:      response.StatusCode,
+		StatusText:  http.StatusText(response.StatusCode),
+		HTTPVersion: response.Proto,
+		Headers:     harHeaders(response.Header),
+		Cookies:     []harNameValue{},
+		Content:     harContent{MimeType: response.Header.Get("Content-Type")},
+		RedirectURL: response.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    int(response.ContentLength),
+	}
+	id := c.recorder.start(entry)
+
+	// the body is recorded as the caller reads it, so streamed responses
+	// (e.g. a followed installation log) are not held up
+	response.Body = &harBody{
+		ReadCloser: response.Body,
+		start:      time.Now(),
+		done: func(body []byte, receive time.Duration) {
+			c.recorder.finish(id, func(entry *harEntry) {
+				entry.Time += milliseconds(receive)
+				entry.Timings.Receive = milliseconds(receive)
+				entry.Response.Content.Size = len(body)
+				entry.Response.Content.Text = string(redactBody(entry.Response.Content.MimeType, body))
+			})
+		},
+	}
+
+	return response, nil
+}
+
+// record writes an entry that is complete.
+func (r *HARRecorder) record(entry harEntry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.write(&entry)
+}
+
+// start writes an entry whose response body is still to be read, after the
+// completed entries, until finish completes it.
+func (r *HARRecorder) start(entry harEntry) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	id := r.nextID
+	r.nextID++
+	r.pending[id] = &entry
+	r.order = append(r.order, id)
+	r.write(nil)
+
+	return id
+}
+
+func (r *HARRecorder) finish(id int, change func(*harEntry)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry := r.pending[id]
+	change(entry)
+
+	delete(r.pending, id)
+	for i, pendingID := range r.order {
+		if pendingID == id {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+
+	r.write(entry)
+}
+
+// write appends the completed entry, if any, after the entries already
+// written and rewrites the pending entries and the end of the file after it,
+// so every entry is only written once it is complete.
+func (r *HARRecorder) write(completed *harEntry) {
+	if r.failed {
+		return
+	}
+
+	err := r.writeEntries(completed)
+	if err != nil {
+		r.failed = true
+		_, _ = fmt.Fprintf(r.writer, "could not write trace file %s: %s\n", r.path, err)
+	}
+}
+
+func (r *HARRecorder) writeEntries(completed *harEntry) error {
+	if r.file == nil {
+		file, err := os.OpenFile(r.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+
+		creator, err := json.MarshalIndent(r.creator, "    ", "  ")
+		if err != nil {
+			return err
+		}
+
+		header := fmt.Sprintf("{\n  \"log\": {\n    \"version\": \"1.2\",\n    \"creator\": %s,\n    \"entries\": [", creator)
+		if _, err = file.WriteAt([]byte(header), 0); err != nil {
+			return err
+		}
+
+		r.file = file
+		r.completedEnd = int64(len(header))
+	}
+
+	if completed != nil {
+		contents, err := r.marshalEntry(*completed, r.completed)
+		if err != nil {
+			return err
+		}
+
+		if _, err = r.file.WriteAt(contents, r.completedEnd); err != nil {
+			return err
+		}
+		r.completedEnd += int64(len(contents))
+		r.completed++
+	}
+
+	var tail []byte
+	for i, id := range r.order {
+		contents, err := r.marshalEntry(*r.pending[id], r.completed+i)
+		if err != nil {
+			return err
+		}
+		tail = append(tail, contents...)
+	}
+	tail = append(tail, "\n    ]\n  }\n}\n"...)
+
+	if _, err := r.file.WriteAt(tail, r.completedEnd); err != nil {
+		return err
+	}
+
+	return r.file.Truncate(r.completedEnd + int64(len(tail)))
+}
+
+// marshalEntry is the entry at the given position of the entries, indented
+// as it is in the file.
+func (r *HARRecorder) marshalEntry(entry harEntry, position int) ([]byte, error) {
+	contents, err := json.MarshalIndent(entry, "      ", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	separator := ",\n      "
+	if position == 0 {
+		separator = "\n      "
+	}
+
+	return append([]byte(separator), contents...), nil
+}
+
+// harBody records up to maxBodySize of a response body and reports it once
+// the body has been read to the end or closed.
+type harBody struct {
+	io.ReadCloser
+	start time.Time
+	done  func([]byte, time.Duration)
+
+	body     []byte
+	finished bool
+}
+
+func (b *harBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if remaining := maxBodySize - len(b.body); remaining > 0 {
+		b.body = append(b.body, p[:min(n, remaining)]...)
+	}
+	if err == io.EOF {
+		b.finish()
+	}
+
+	return n, err
+}
+
+func (b *harBody) Close() error {
+	b.finish()
+
+	return b.ReadCloser.Close()
+}
+
+func (b *harBody) finish() {
+	if b.finished {
+		return
+	}
+	b.finished = true
+
+	b.done(b.body, time.Since(b.start))
+}
+
+func newHARRequest(request *http.Request) harRequest {
+	headers := request.Header.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	if request.Host != "" && headers.Get("Host") == "" {
+		headers.Set("Host", request.Host)
+	}
+
+	queryString := []harNameValue{}
+	for name, values := range redactValues(request.URL.Query()) {
+		for _, value := range values {
+			queryString = append(queryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(queryString, func(i, j int) bool { return queryString[i].Name < queryString[j].Name })
+
+	httpVersion := request.Proto
+	if httpVersion == "" {
+		httpVersion = "HTTP/1.1"
+	}
+
+	return harRequest{
+		Method:      request.Method,
+		URL:         redactURL(request.URL),
+		HTTPVersion: httpVersion,
+		Headers:     harHeaders(headers),
+		QueryString: queryString,
+		Cookies:     []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    int(request.ContentLength),
+	}
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := []harNameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			if isSensitiveHeader(name) {
+				value = redacted
+			}
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
@@ -0,0 +1,197 @@
+package network_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("HAR Client", func() {
+	var (
+		fakeClient *fakes.HttpClient
+		harClient  *network.HARClient
+		harPath    string
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		harPath = filepath.Join(GinkgoT().TempDir(), "trace.har")
+		harClient = network.NewHARClient(fakeClient, network.NewHARRecorder(harPath, "1.2.3", GinkgoWriter))
+	})
+
+	readHAR := func() map[string]interface{} {
+		contents, err := os.ReadFile(harPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		var har map[string]interface{}
+		Expect(json.Unmarshal(contents, &har)).To(Succeed())
+
+		return har["log"].(map[string]interface{})
+	}
+
+	It("records the request and response", func() {
+		fakeClient.DoStub = func(request *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(request.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal(`{"name": "some-product"}`))
+
+			return &http.Response{
+				StatusCode:    http.StatusCreated,
+				Proto:         "HTTP/1.1",
+				Header:        http.Header{"Content-Type": []string{"application/json"}},
+				ContentLength: 15,
+				Body:          io.NopCloser(strings.NewReader(`{"id": "guid"}` + "\n")),
+			}, nil
+		}
+
+		request, err := http.NewRequest("POST", "https://example.com/api/v0/products?force=true", strings.NewReader(`{"name": "some-product"}`))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Content-Type", "application/json")
+
+		response, err := harClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+
+		body, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(`{"id": "guid"}` + "\n"))
+		Expect(response.Body.Close()).To(Succeed())
+
+		log := readHAR()
+		Expect(log["version"]).To(Equal("1.2"))
+		Expect(log["creator"]).To(Equal(map[string]interface{}{"name": "om", "version": "1.2.3"}))
+
+		entries := log["entries"].([]interface{})
+		Expect(entries).To(HaveLen(1))
+
+		entry := entries[0].(map[string]interface{})
+		Expect(entry).To(HaveKey("startedDateTime"))
+		Expect(entry).To(HaveKey("timings"))
+
+		harRequest := entry["request"].(map[string]interface{})
+		Expect(harRequest["method"]).To(Equal("POST"))
+		Expect(harRequest["url"]).To(Equal("https://example.com/api/v0/products?force=true"))
+		Expect(harRequest["headers"]).To(ContainElement(map[string]interface{}{"name": "Content-Type", "value": "application/json"}))
+		Expect(harRequest["queryString"]).To(Equal([]interface{}{map[string]interface{}{"name": "force", "value": "true"}}))
+		Expect(harRequest["postData"]).To(Equal(map[string]interface{}{"mimeType": "application/json", "text": `{"name": "some-product"}`}))
+
+		harResponse := entry["response"].(map[string]interface{})
+		Expect(harResponse["status"]).To(BeNumerically("==", 201))
+		Expect(harResponse["statusText"]).To(Equal("Created"))
+		Expect(harResponse["content"]).To(Equal(map[string]interface{}{"size": float64(15), "mimeType": "application/json", "text": `{"id": "guid"}` + "\n"}))
+	})
+
+	It("records the response before its body is read", func() {
+		fakeClient.DoReturns(&http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("streamed")),
+		}, nil)
+
+		request, err := http.NewRequest("GET", "https://example.com/api/v0/installations/1/logs", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = harClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+
+		entries := readHAR()["entries"].([]interface{})
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].(map[string]interface{})["response"].(map[string]interface{})["status"]).To(BeNumerically("==", 200))
+	})
+
+	It("appends each response once its body has been read", func() {
+		fakeClient.DoReturnsOnCall(0, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("streamed")),
+		}, nil)
+		fakeClient.DoReturnsOnCall(1, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader("info")),
+		}, nil)
+
+		request, err := http.NewRequest("GET", "https://example.com/api/v0/installations/1/logs", nil)
+		Expect(err).ToNot(HaveOccurred())
+		streamed, err := harClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+
+		request, err = http.NewRequest("GET", "https://example.com/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+		response, err := harClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Body.Close()).To(Succeed())
+
+		Expect(streamed.Body.Close()).To(Succeed())
+
+		entries := readHAR()["entries"].([]interface{})
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].(map[string]interface{})["request"].(map[string]interface{})["url"]).To(Equal("https://example.com/api/v0/info"))
+		Expect(entries[1].(map[string]interface{})["request"].(map[string]interface{})["url"]).To(Equal("https://example.com/api/v0/installations/1/logs"))
+	})
+
+	It("reports when the file cannot be written", func() {
+		output := gbytes.NewBuffer()
+		harPath = filepath.Join(GinkgoT().TempDir(), "missing", "trace.har")
+		harClient = network.NewHARClient(fakeClient, network.NewHARRecorder(harPath, "1.2.3", output))
+		fakeClient.DoReturns(nil, errors.New("connection refused"))
+
+		request, err := http.NewRequest("GET", "https://example.com/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = harClient.Do(request)
+		Expect(err).To(MatchError("connection refused"))
+		Expect(output).To(gbytes.Say("could not write trace file .*trace.har"))
+	})
+
+	It("redacts credentials", func() {
+		fakeClient.DoReturns(&http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}, "Set-Cookie": []string{"session=some-session"}},
+			Body:       io.NopCloser(strings.NewReader(`{"credential": {"type": "simple_credentials", "value": {"identity": "admin", "password": "some-password"}}}`)),
+		}, nil)
+
+		request, err := http.NewRequest("GET", "https://example.com/api/v0/deployed/products/cf/credentials/.uaa.admin_credentials?access_token=some-token", nil)
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Authorization", "Bearer some-token")
+
+		response, err := harClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		contents, err := os.ReadFile(harPath)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).ToNot(ContainSubstring("some-token"))
+		Expect(string(contents)).ToNot(ContainSubstring("some-password"))
+		Expect(string(contents)).ToNot(ContainSubstring("some-session"))
+
+		entry := readHAR()["entries"].([]interface{})[0].(map[string]interface{})
+		Expect(entry["request"].(map[string]interface{})["headers"]).To(ContainElement(map[string]interface{}{"name": "Authorization", "value": "[REDACTED]"}))
+		Expect(entry["response"].(map[string]interface{})["content"].(map[string]interface{})["text"]).To(MatchJSON(`{"credential": {"type": "simple_credentials", "value": "[REDACTED]"}}`))
+	})
+
+	It("records requests that fail", func() {
+		fakeClient.DoReturns(nil, errors.New("connection refused"))
+
+		request, err := http.NewRequest("GET", "https://example.com/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = harClient.Do(request)
+		Expect(err).To(MatchError("connection refused"))
+
+		entries := readHAR()["entries"].([]interface{})
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].(map[string]interface{})["response"].(map[string]interface{})["statusText"]).To(Equal("connection refused"))
+	})
+})
@@ -8,12 +8,30 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 )
 
-func newHTTPClient(insecureSkipVerify bool, caCert string, requestTimeout time.Duration, connectTimeout time.Duration) (*http.Client, error) {
+// TransportOptions tune the connections of the clients. The zero value keeps
+// the defaults: HTTP/1.1, idle connections kept open, 30 second TCP
+// keepalives and the buffer sizes of net/http.
+type TransportOptions struct {
+	// HTTP2 attempts HTTP/2 with servers that support it.
+	HTTP2 bool
+	// IdleConnTimeout closes connections idle for longer, 0 keeps them open.
+	IdleConnTimeout time.Duration
+	// KeepAlive is the interval of TCP keepalive probes, a negative interval
+	// disables them.
+	KeepAlive time.Duration
+	// ReadBufferSize and WriteBufferSize are the sizes in bytes of the
+	// buffers of a connection.
+	ReadBufferSize  int
+	WriteBufferSize int
+}
+
+func newHTTPClient(insecureSkipVerify bool, caCert string, clientCert string, clientKey string, socksProxy string, requestTimeout time.Duration, connectTimeout time.Duration, transport TransportOptions) (*http.Client, error) {
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: insecureSkipVerify,
 		MinVersion:         tls.VersionTLS12,
@@ -22,25 +40,75 @@ func newHTTPClient(insecureSkipVerify bool, caCert string, requestTimeout time.D
 	if err != nil {
 		return nil, err
 	}
+	err = setClientCert(clientCert, clientKey, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if socksProxy != "" {
+		proxyURL, err := ParseSOCKSProxy(socksProxy)
+		if err != nil {
+			return nil, err
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
 	return &http.Client{
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
-		Transport: &http.Transport{
-			Proxy:           http.ProxyFromEnvironment,
-			TLSClientConfig: tlsConfig,
-			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
-				d := net.Dialer{
-					Timeout:   connectTimeout,
-					KeepAlive: 30 * time.Second,
-				}
-				return d.DialContext(ctx, network, addr)
-			},
-		},
-		Timeout: requestTimeout,
+		Transport: newTransport(tlsConfig, proxy, connectTimeout, transport),
+		Timeout:   requestTimeout,
 	}, nil
 }
 
+func newTransport(tlsConfig *tls.Config, proxy func(*http.Request) (*url.URL, error), connectTimeout time.Duration, options TransportOptions) *http.Transport {
+	keepAlive := options.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = 30 * time.Second
+	}
+
+	return &http.Transport{
+		Proxy:           proxy,
+		TLSClientConfig: tlsConfig,
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			d := net.Dialer{
+				Timeout:   connectTimeout,
+				KeepAlive: keepAlive,
+			}
+			return d.DialContext(ctx, network, addr)
+		},
+		ForceAttemptHTTP2: options.HTTP2,
+		IdleConnTimeout:   options.IdleConnTimeout,
+		ReadBufferSize:    options.ReadBufferSize,
+		WriteBufferSize:   options.WriteBufferSize,
+	}
+}
+
+// ParseSOCKSProxy accepts either host:port or a socks5:// (or socks5h://) URL,
+// optionally carrying user:password credentials for the proxy.
+func ParseSOCKSProxy(socksProxy string) (*url.URL, error) {
+	if !strings.Contains(socksProxy, "://") {
+		socksProxy = "socks5://" + socksProxy
+	}
+
+	proxyURL, err := url.Parse(socksProxy)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse socks proxy: %w", err)
+	}
+
+	if proxyURL.Scheme != "socks5" && proxyURL.Scheme != "socks5h" {
+		return nil, fmt.Errorf("socks proxy %q must use the socks5 or socks5h scheme", proxyURL.Redacted())
+	}
+
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("socks proxy %q must include a host", proxyURL.Redacted())
+	}
+
+	return proxyURL, nil
+}
+
 func setCACert(caCert string, tlsConfig *tls.Config) error {
 	if caCert == "" {
 		return nil
@@ -50,12 +118,9 @@ func setCACert(caCert string, tlsConfig *tls.Config) error {
 	if err != nil {
 		caCertPool = x509.NewCertPool()
 	}
-	if !strings.Contains(caCert, "BEGIN") {
-		contents, err := os.ReadFile(caCert)
-		if err != nil {
-			return fmt.Errorf("could not load ca cert from file: %s", err)
-		}
-		caCert = string(contents)
+	caCert, err = ReadPEM(caCert)
+	if err != nil {
+		return fmt.Errorf("could not load ca cert from file: %s", err)
 	}
 	if ok := caCertPool.AppendCertsFromPEM([]byte(caCert)); !ok {
 		return errors.New("could not use ca cert")
@@ -64,3 +129,74 @@ func setCACert(caCert string, tlsConfig *tls.Config) error {
 	tlsConfig.RootCAs = caCertPool
 	return nil
 }
+
+// CABundle joins the CA certificate, given as a path or a value, with the
+// PEM bundle of caCertFile, which may hold several CAs. Either can be empty.
+// The clients trust the result in addition to the system CAs.
+func CABundle(caCert string, caCertFile string) (string, error) {
+	if caCertFile == "" {
+		return caCert, nil
+	}
+
+	contents, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return "", fmt.Errorf("could not load ca cert file: %s", err)
+	}
+	if ok := x509.NewCertPool().AppendCertsFromPEM(contents); !ok {
+		return "", fmt.Errorf("could not use ca cert file %s: it has no PEM certificates", caCertFile)
+	}
+
+	if caCert == "" {
+		return string(contents), nil
+	}
+
+	caCert, err = ReadPEM(caCert)
+	if err != nil {
+		return "", fmt.Errorf("could not load ca cert from file: %s", err)
+	}
+
+	return strings.TrimRight(caCert, "\n") + "\n" + string(contents), nil
+}
+
+func setClientCert(clientCert string, clientKey string, tlsConfig *tls.Config) error {
+	if clientCert == "" && clientKey == "" {
+		return nil
+	}
+
+	if clientCert == "" || clientKey == "" {
+		return errors.New("client-cert and client-key must be provided together")
+	}
+
+	certPEM, err := ReadPEM(clientCert)
+	if err != nil {
+		return fmt.Errorf("could not load client cert from file: %s", err)
+	}
+
+	keyPEM, err := ReadPEM(clientKey)
+	if err != nil {
+		return fmt.Errorf("could not load client key from file: %s", err)
+	}
+
+	cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	if err != nil {
+		return fmt.Errorf("could not use client cert: %s", err)
+	}
+
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return nil
+}
+
+// ReadPEM returns value unchanged when it already holds PEM data, otherwise
+// it treats value as a path and returns the file contents.
+func ReadPEM(value string) (string, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return value, nil
+	}
+
+	contents, err := os.ReadFile(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(contents), nil
+}
@@ -1,8 +1,21 @@
 package network_test
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log"
+	"math/big"
+	"net"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/cloudfoundry/go-socks5"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -21,3 +34,62 @@ func writeFile(contents string) string {
 	Expect(err).ToNot(HaveOccurred())
 	return file.Name()
 }
+
+type socksTestResolver struct{}
+
+// Resolve maps every name to loopback, so hosts like opsman.internal are only
+// reachable by tunnelling through the proxy.
+func (socksTestResolver) Resolve(ctx context.Context, _ string) (context.Context, net.IP, error) {
+	return ctx, net.IPv4(127, 0, 0, 1), nil
+}
+
+func startSOCKSProxy(username, password string) net.Listener {
+	server, err := socks5.New(&socks5.Config{
+		Credentials: socks5.StaticCredentials{username: password},
+		Resolver:    socksTestResolver{},
+		Logger:      log.New(GinkgoWriter, "", 0),
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return listener
+}
+
+// generateClientCertificate returns a self-signed client certificate and its
+// private key, both PEM encoded.
+func generateClientCertificate() (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "om-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+
+	return cert, certPEM, keyPEM
+}
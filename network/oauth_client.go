@@ -1,8 +1,14 @@
 package network
 
 import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry-community/go-uaa"
@@ -11,35 +17,65 @@ import (
 
 type OAuthClient struct {
 	caCert             string
+	clientCert         string
+	clientKey          string
 	clientID           string
 	clientSecret       string
 	insecureSkipVerify bool
 	password           string
 	opsmanTarget       string
 	uaaTarget          string
-	token              *oauth2.Token
+	socksProxy         string
+	token              *oauthToken
+	tokenCache         *TokenCache
+	transport          TransportOptions
 	username           string
 	connectTimeout     time.Duration
 	requestTimeout     time.Duration
 }
 
+// oauthToken is shared by the copies of a client, so they grant one token.
+// It is locked while the token is read or granted, so requests sent from
+// several goroutines do not race on it.
+type oauthToken struct {
+	mu    sync.Mutex
+	value *oauth2.Token
+}
+
 func NewOAuthClient(
 	uaaTarget, opsmanTarget string,
 	username, password string,
 	clientID, clientSecret string,
 	insecureSkipVerify bool,
 	caCert string,
+	clientCert string,
+	clientKey string,
+	socksProxy string,
 	connectTimeout time.Duration,
 	requestTimeout time.Duration,
 ) (*OAuthClient, error) {
+	if socksProxy != "" {
+		if _, err := ParseSOCKSProxy(socksProxy); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setClientCert(clientCert, clientKey, &tls.Config{}); err != nil {
+		return nil, err
+	}
+
 	return &OAuthClient{
 		caCert:             caCert,
+		clientCert:         clientCert,
+		clientKey:          clientKey,
 		clientID:           clientID,
 		clientSecret:       clientSecret,
 		insecureSkipVerify: insecureSkipVerify,
 		password:           password,
 		uaaTarget:          uaaTarget,
 		opsmanTarget:       opsmanTarget,
+		socksProxy:         socksProxy,
+		token:              &oauthToken{},
 		username:           username,
 		connectTimeout:     connectTimeout,
 		requestTimeout:     requestTimeout,
@@ -47,7 +83,6 @@ func NewOAuthClient(
 }
 
 func (oc *OAuthClient) Do(request *http.Request) (*http.Response, error) {
-	token := oc.token
 	opsmanTarget, uaaTarget, err := parseOpsmanAndUAAURLs(oc.opsmanTarget, oc.uaaTarget)
 	if err != nil {
 		return nil, err
@@ -59,22 +94,188 @@ func (oc *OAuthClient) Do(request *http.Request) (*http.Response, error) {
 	client, err := newHTTPClient(
 		oc.insecureSkipVerify,
 		oc.caCert,
+		oc.clientCert,
+		oc.clientKey,
+		oc.socksProxy,
 		oc.requestTimeout,
 		oc.connectTimeout,
+		oc.transport,
 	)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if token != nil && token.Valid() {
-		request.Header.Set(
-			"Authorization",
-			fmt.Sprintf("Bearer %s", token.AccessToken),
-		)
-		return client.Do(request)
+	token, err := oc.validToken(request.Context(), client, uaaTarget.String())
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set(
+		"Authorization",
+		fmt.Sprintf("Bearer %s", token.AccessToken),
+	)
+
+	return client.Do(request)
+}
+
+// validToken returns the token of the client, retrieving a new one when it
+// has expired.
+func (oc *OAuthClient) validToken(ctx context.Context, client *http.Client, uaaTarget string) (*oauth2.Token, error) {
+	oc.token.mu.Lock()
+	defer oc.token.mu.Unlock()
+
+	if oc.token.value != nil && oc.token.value.Valid() {
+		return oc.token.value, nil
+	}
+
+	token, err := oc.retrieveToken(ctx, client, uaaTarget)
+	if err != nil {
+		return nil, err
 	}
 
+	oc.token.value = token
+	return token, nil
+}
+
+// WithTokenCache makes the client reuse tokens persisted at path by previous
+// invocations, refreshing them when they have expired.
+func (oc *OAuthClient) WithTokenCache(path string) *OAuthClient {
+	oc.tokenCache = NewTokenCache(path)
+	return oc
+}
+
+// WithTransport makes the connections of the client tuned by options.
+func (oc *OAuthClient) WithTransport(options TransportOptions) *OAuthClient {
+	oc.transport = options
+	return oc
+}
+
+// WithRequestTimeout returns a copy of the client whose requests, including
+// reading the response body, time out after timeout instead. 0 disables the
+// timeout. The copy shares the token of the client.
+func (oc *OAuthClient) WithRequestTimeout(timeout time.Duration) *OAuthClient {
+	client := *oc
+	client.requestTimeout = timeout
+	return &client
+}
+
+// VerifyCredentials checks UAA grants a token for the credentials, e.g. after
+// rotating them. It neither uses nor replaces the token of the client.
+func (oc *OAuthClient) VerifyCredentials(username, password, clientID, clientSecret string) error {
+	_, uaaTarget, err := parseOpsmanAndUAAURLs(oc.opsmanTarget, oc.uaaTarget)
+	if err != nil {
+		return err
+	}
+
+	client, err := newHTTPClient(
+		oc.insecureSkipVerify,
+		oc.caCert,
+		oc.clientCert,
+		oc.clientKey,
+		oc.socksProxy,
+		oc.requestTimeout,
+		oc.connectTimeout,
+		oc.transport,
+	)
+	if err != nil {
+		return err
+	}
+
+	verifier := *oc
+	verifier.username, verifier.password = username, password
+	verifier.clientID, verifier.clientSecret = clientID, clientSecret
+
+	_, err = verifier.grantToken(context.Background(), client, uaaTarget.String())
+	return err
+}
+
+// ForgetToken removes the token cached for the credentials, e.g. once they
+// have been rotated, so that it is not reused until it expires.
+func (oc *OAuthClient) ForgetToken(username, password, clientID, clientSecret string) error {
+	if oc.tokenCache == nil {
+		return nil
+	}
+
+	_, uaaTarget, err := parseOpsmanAndUAAURLs(oc.opsmanTarget, oc.uaaTarget)
+	if err != nil {
+		return err
+	}
+
+	forgotten := *oc
+	forgotten.username, forgotten.password = username, password
+	forgotten.clientID, forgotten.clientSecret = clientID, clientSecret
+
+	unlock, err := oc.tokenCache.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = oc.tokenCache.delete(forgotten.tokenCacheKey(uaaTarget.String()))
+	if err != nil {
+		return fmt.Errorf("could not write token cache: %w", err)
+	}
+
+	return nil
+}
+
+func (oc *OAuthClient) retrieveToken(ctx context.Context, client *http.Client, uaaTarget string) (*oauth2.Token, error) {
+	if oc.tokenCache == nil {
+		return oc.grantToken(ctx, client, uaaTarget)
+	}
+
+	unlock, err := oc.tokenCache.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	key := oc.tokenCacheKey(uaaTarget)
+
+	// an unreadable cache falls back to a fresh grant and is overwritten below
+	cached, _ := oc.tokenCache.load(key)
+	if cached != nil && cached.Valid() {
+		return cached, nil
+	}
+
+	var token *oauth2.Token
+	if cached != nil && cached.RefreshToken != "" {
+		token, _ = oc.refreshToken(ctx, client, uaaTarget, cached.RefreshToken)
+	}
+
+	if token == nil {
+		token, err = oc.grantToken(ctx, client, uaaTarget)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = oc.tokenCache.save(key, token)
+	if err != nil {
+		return nil, fmt.Errorf("could not write token cache: %w", err)
+	}
+
+	return token, nil
+}
+
+// tokenCacheKey identifies the cached token of the credentials. It includes
+// a hash of the password or secret, so a token is not reused with a wrong or
+// rotated one.
+func (oc *OAuthClient) tokenCacheKey(uaaTarget string) string {
+	if oc.username != "" && oc.password != "" {
+		return fmt.Sprintf("%s user:%s %s", uaaTarget, oc.username, credentialHash(uaaTarget, oc.username, oc.password))
+	}
+
+	return fmt.Sprintf("%s client:%s %s", uaaTarget, oc.clientID, credentialHash(uaaTarget, oc.clientID, oc.clientSecret))
+}
+
+func credentialHash(uaaTarget, identity, secret string) string {
+	sum := sha256.Sum256([]byte(uaaTarget + "\x00" + identity + "\x00" + secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (oc *OAuthClient) grantToken(ctx context.Context, client *http.Client, uaaTarget string) (*oauth2.Token, error) {
 	options := []uaa.Option{
 		uaa.WithSkipSSLValidation(oc.insecureSkipVerify),
 		uaa.WithClient(client),
@@ -99,7 +300,7 @@ func (oc *OAuthClient) Do(request *http.Request) (*http.Response, error) {
 	}
 
 	api, err := uaa.New(
-		uaaTarget.String(),
+		uaaTarget,
 		authOption,
 		options...,
 	)
@@ -107,8 +308,9 @@ func (oc *OAuthClient) Do(request *http.Request) (*http.Response, error) {
 		return nil, fmt.Errorf("could not init UAA client: %w", err)
 	}
 
+	var token *oauth2.Token
 	for i := 0; i <= 2; i++ {
-		token, err = api.Token(request.Context())
+		token, err = api.Token(ctx)
 		if err == nil {
 			break
 		}
@@ -118,12 +320,26 @@ func (oc *OAuthClient) Do(request *http.Request) (*http.Response, error) {
 		return nil, fmt.Errorf("token could not be retrieved from target url: %w", err)
 	}
 
-	request.Header.Set(
-		"Authorization",
-		fmt.Sprintf("Bearer %s", token.AccessToken),
-	)
+	return token, nil
+}
+
+// refreshToken exchanges a cached refresh token for a new access token. UAA
+// may rotate the refresh token, so the returned token replaces the cached one.
+func (oc *OAuthClient) refreshToken(ctx context.Context, client *http.Client, uaaTarget string, refreshToken string) (*oauth2.Token, error) {
+	clientID, clientSecret := oc.clientID, oc.clientSecret
+	if oc.username != "" && oc.password != "" {
+		clientID, clientSecret = "opsman", ""
+	}
 
-	oc.token = token
+	config := &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  strings.TrimSuffix(uaaTarget, "/") + "/oauth/token?token_format=jwt",
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+	}
 
-	return client.Do(request)
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
+	return config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
 }
@@ -1,13 +1,18 @@
 package network_test
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -31,7 +36,7 @@ var _ = Describe("OAuthClient", func() {
 	Describe("Do", func() {
 		When("with a request timeout", func() {
 			It("use that timeout value", func() {
-				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", time.Nanosecond, time.Nanosecond)
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Nanosecond, time.Nanosecond)
 				Expect(err).ToNot(HaveOccurred())
 
 				req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -60,7 +65,7 @@ var _ = Describe("OAuthClient", func() {
 					ghttp.RespondWith(http.StatusOK, nil),
 				)
 
-				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", time.Duration(100)*time.Millisecond, time.Duration(100)*time.Millisecond)
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(100)*time.Millisecond, time.Duration(100)*time.Millisecond)
 				Expect(err).ToNot(HaveOccurred())
 
 				req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -71,6 +76,43 @@ var _ = Describe("OAuthClient", func() {
 			})
 		})
 
+		When("copied with another request timeout", func() {
+			It("uses that timeout and shares the token", func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "some-opsman-token",
+						"token_type": "bearer",
+						"expires_in": 3600
+						}`, http.Header{
+						"Content-Type": []string{"application/json"},
+					}),
+					ghttp.RespondWith(http.StatusOK, nil),
+					ghttp.CombineHandlers(
+						ghttp.VerifyHeader(http.Header{"Authorization": []string{"Bearer some-opsman-token"}}),
+						func(http.ResponseWriter, *http.Request) {
+							time.Sleep(time.Duration(300) * time.Millisecond)
+						},
+					),
+				)
+
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(200)*time.Millisecond)
+				Expect(err).ToNot(HaveOccurred())
+				transferClient := client.WithRequestTimeout(0)
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+
+				req, err = http.NewRequest("GET", "/some/upload", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = transferClient.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(server.ReceivedRequests()).To(HaveLen(3))
+			})
+		})
+
 		When("a token expires", func() {
 			It("will refresh it", func() {
 				server.AppendHandlers(
@@ -92,7 +134,7 @@ var _ = Describe("OAuthClient", func() {
 					ghttp.RespondWith(http.StatusOK, nil),
 				)
 
-				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", time.Duration(100)*time.Millisecond, time.Duration(100)*time.Millisecond)
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(100)*time.Millisecond, time.Duration(100)*time.Millisecond)
 				Expect(err).ToNot(HaveOccurred())
 
 				for i := 0; i < 2; i++ {
@@ -119,7 +161,7 @@ var _ = Describe("OAuthClient", func() {
 					ghttp.RespondWith(http.StatusOK, ""),
 				)
 
-				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", time.Duration(100)*time.Millisecond, time.Duration(100)*time.Millisecond)
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(100)*time.Millisecond, time.Duration(100)*time.Millisecond)
 				Expect(err).ToNot(HaveOccurred())
 
 				for i := 0; i < 2; i++ {
@@ -152,7 +194,7 @@ var _ = Describe("OAuthClient", func() {
 			))
 			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
 
-			client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 			Expect(err).ToNot(HaveOccurred())
 
 			req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -181,7 +223,7 @@ var _ = Describe("OAuthClient", func() {
 			))
 			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
 
-			client, err := network.NewOAuthClient("", server.URL(), "", "", "client_id", "client_secret", true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			client, err := network.NewOAuthClient("", server.URL(), "", "", "client_id", "client_secret", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 			Expect(err).ToNot(HaveOccurred())
 
 			req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -193,13 +235,249 @@ var _ = Describe("OAuthClient", func() {
 			Expect(resp.StatusCode).To(Equal(http.StatusOK))
 		})
 
+		When("a token cache is provided", func() {
+			var (
+				cachePath   string
+				tokenGrants func() int
+			)
+
+			BeforeEach(func() {
+				dir, err := os.MkdirTemp("", "om-token-cache")
+				Expect(err).ToNot(HaveOccurred())
+				DeferCleanup(os.RemoveAll, dir)
+				cachePath = filepath.Join(dir, "tokens.json")
+
+				tokenGrants = func() int {
+					count := 0
+					for _, request := range server.ReceivedRequests() {
+						if request.URL.Path == "/uaa/oauth/token" {
+							count++
+						}
+					}
+					return count
+				}
+			})
+
+			newClient := func() *network.OAuthClient {
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				return client.WithTokenCache(cachePath)
+			}
+
+			doRequest := func(client *network.OAuthClient) {
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				resp, err := client.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			}
+
+			writeCache := func(token map[string]interface{}) {
+				uaaTarget := server.URL() + "/uaa"
+				sum := sha256.Sum256([]byte(uaaTarget + "\x00opsman-username\x00opsman-password"))
+				contents, err := json.Marshal(map[string]interface{}{
+					uaaTarget + " user:opsman-username " + hex.EncodeToString(sum[:]): token,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(os.WriteFile(cachePath, contents, 0600)).To(Succeed())
+			}
+
+			It("reuses the cached token across clients", func() {
+				setupBasicOauth(server)
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Authorization", "Bearer some-opsman-token"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+
+				doRequest(newClient())
+				doRequest(newClient())
+				Expect(tokenGrants()).To(Equal(1))
+
+				contents, err := os.ReadFile(cachePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(ContainSubstring("some-opsman-token"))
+				Expect(string(contents)).ToNot(ContainSubstring("opsman-password"))
+
+				info, err := os.Stat(cachePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+				Expect(cachePath + ".lock").ToNot(BeAnExistingFile())
+			})
+
+			It("refreshes an expired token and stores the rotated refresh token", func() {
+				writeCache(map[string]interface{}{
+					"access_token":  "expired-token",
+					"refresh_token": "old-refresh-token",
+					"expiry":        time.Now().Add(-time.Hour),
+				})
+
+				server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.CombineHandlers(
+					ghttp.VerifyBasicAuth("opsman", ""),
+					ghttp.VerifyForm(url.Values{
+						"grant_type":    []string{"refresh_token"},
+						"refresh_token": []string{"old-refresh-token"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "refreshed-token",
+						"refresh_token": "new-refresh-token",
+						"token_type": "bearer",
+						"expires_in": 3600
+					}`, http.Header{"Content-Type": []string{"application/json"}}),
+				))
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Authorization", "Bearer refreshed-token"),
+					ghttp.RespondWith(http.StatusOK, nil),
+				))
+
+				doRequest(newClient())
+
+				contents, err := os.ReadFile(cachePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(ContainSubstring("new-refresh-token"))
+				Expect(string(contents)).ToNot(ContainSubstring("old-refresh-token"))
+			})
+
+			It("falls back to a new grant when the refresh token is rejected", func() {
+				writeCache(map[string]interface{}{
+					"access_token":  "expired-token",
+					"refresh_token": "revoked-refresh-token",
+					"expiry":        time.Now().Add(-time.Hour),
+				})
+
+				var grantTypes []string
+				server.RouteToHandler("POST", "/uaa/oauth/token", func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.ParseForm()).To(Succeed())
+					grantTypes = append(grantTypes, req.Form.Get("grant_type"))
+
+					w.Header().Set("Content-Type", "application/json")
+					if req.Form.Get("grant_type") == "refresh_token" {
+						w.WriteHeader(http.StatusUnauthorized)
+						_, _ = w.Write([]byte(`{"error": "invalid_token"}`))
+						return
+					}
+					_, _ = w.Write([]byte(`{"access_token": "some-opsman-token", "token_type": "bearer", "expires_in": 3600}`))
+				})
+				server.AppendHandlers(ghttp.RespondWith(http.StatusOK, nil))
+
+				doRequest(newClient())
+				Expect(grantTypes).To(Equal([]string{"refresh_token", "password"}))
+			})
+
+			It("does not reuse the cached token with another password", func() {
+				setupBasicOauth(server)
+				doRequest(newClient())
+
+				client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "wrong-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.RespondWith(http.StatusUnauthorized, `{"error": "unauthorized"}`))
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.WithTokenCache(cachePath).Do(req)
+				Expect(err).To(MatchError(ContainSubstring("token could not be retrieved from target url")))
+
+				contents, err := os.ReadFile(cachePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).ToNot(ContainSubstring("password"))
+			})
+
+			It("forgets the cached token of rotated credentials", func() {
+				setupBasicOauth(server)
+				client := newClient()
+				doRequest(client)
+
+				Expect(client.ForgetToken("opsman-username", "opsman-password", "", "")).To(Succeed())
+
+				contents, err := os.ReadFile(cachePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).ToNot(ContainSubstring("some-opsman-token"))
+				Expect(cachePath + ".lock").ToNot(BeAnExistingFile())
+			})
+
+			It("replaces a corrupt cache file", func() {
+				Expect(os.WriteFile(cachePath, []byte("not json"), 0600)).To(Succeed())
+				setupBasicOauth(server)
+
+				doRequest(newClient())
+
+				contents, err := os.ReadFile(cachePath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(contents)).To(ContainSubstring("some-opsman-token"))
+			})
+		})
+
+		When("a client certificate is provided", func() {
+			It("presents it when fetching the token and making the request", func() {
+				cert, certPEM, keyPEM := generateClientCertificate()
+
+				clientCAs := x509.NewCertPool()
+				clientCAs.AddCert(cert)
+
+				mtlsServer := ghttp.NewUnstartedServer()
+				mtlsServer.HTTPTestServer.TLS = &tls.Config{
+					ClientAuth: tls.RequireAndVerifyClientCert,
+					ClientCAs:  clientCAs,
+				}
+				mtlsServer.HTTPTestServer.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
+				mtlsServer.HTTPTestServer.StartTLS()
+				defer mtlsServer.Close()
+
+				setupBasicOauth(mtlsServer)
+
+				client, err := network.NewOAuthClient("", mtlsServer.URL(), "opsman-username", "opsman-password", "", "", true, "", certPEM, keyPEM, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				resp, err := client.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns an error when the key is missing", func() {
+				_, certPEM, _ := generateClientCertificate()
+
+				_, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", certPEM, "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).To(MatchError("client-cert and client-key must be provided together"))
+			})
+		})
+
+		When("a socks proxy is provided", func() {
+			It("fetches the token and makes the request through the proxy", func() {
+				setupBasicOauth(server)
+
+				proxy := startSOCKSProxy("proxy-user", "proxy-password")
+				defer proxy.Close()
+
+				serverURL, err := url.Parse(server.URL())
+				Expect(err).ToNot(HaveOccurred())
+
+				client, err := network.NewOAuthClient("", "https://opsman.internal:"+serverURL.Port(), "opsman-username", "opsman-password", "", "", true, "", "", "", "proxy-user:proxy-password@"+proxy.Addr().String(), time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
+				Expect(err).ToNot(HaveOccurred())
+
+				resp, err := client.Do(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns an error when the proxy cannot be parsed", func() {
+				_, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "socks5://", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).To(MatchError(`socks proxy "socks5:" must include a host`))
+			})
+		})
+
 		It("enforces minimum TLS version 1.2", func() {
 			nonTLS12Server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
 			nonTLS12Server.TLS.MaxVersion = tls.VersionTLS11
 			nonTLS12Server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
 			defer nonTLS12Server.Close()
 
-			client, err := network.NewOAuthClient("", nonTLS12Server.URL, "", "", "client_id", "client_secret", true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			client, err := network.NewOAuthClient("", nonTLS12Server.URL, "", "", "client_id", "client_secret", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 			Expect(err).ToNot(HaveOccurred())
 
 			req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -219,7 +497,7 @@ var _ = Describe("OAuthClient", func() {
 				noScheme.Scheme = ""
 				finalURL := noScheme.String()[2:] // removing leading "//"
 
-				client, err := network.NewOAuthClient("", finalURL, "opsman-username", "opsman-password", "", "", true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				client, err := network.NewOAuthClient("", finalURL, "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 				Expect(err).ToNot(HaveOccurred())
 
 				req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -235,7 +513,7 @@ var _ = Describe("OAuthClient", func() {
 		When("insecureSkipVerify is configured", func() {
 			When("it is set to false", func() {
 				It("throws an error for invalid certificates", func() {
-					client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", false, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", false, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 					Expect(err).ToNot(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -250,7 +528,7 @@ var _ = Describe("OAuthClient", func() {
 				It("does not verify certificates", func() {
 					setupBasicOauth(server)
 
-					client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 					Expect(err).ToNot(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -276,6 +554,8 @@ var _ = Describe("OAuthClient", func() {
 					"", "",
 					false,
 					pemCert,
+					"", "",
+					"",
 					time.Duration(5)*time.Second, time.Duration(30)*time.Second,
 				)
 
@@ -301,6 +581,8 @@ var _ = Describe("OAuthClient", func() {
 					"", "",
 					false,
 					pemCert,
+					"", "",
+					"",
 					time.Duration(5)*time.Second, time.Duration(30)*time.Second,
 				)
 
@@ -326,7 +608,7 @@ var _ = Describe("OAuthClient", func() {
 				})
 
 				It("returns an error", func() {
-					client, err := network.NewOAuthClient("", badServer.URL, "username", "password", "", "", true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient("", badServer.URL, "username", "password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 					Expect(err).ToNot(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -339,7 +621,7 @@ var _ = Describe("OAuthClient", func() {
 
 			When("the UAA and Opsman target url are empty", func() {
 				It("returns an error", func() {
-					client, err := network.NewOAuthClient("", "", "username", "password", "", "", false, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+					client, err := network.NewOAuthClient("", "", "username", "password", "", "", false, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 					Expect(err).ToNot(HaveOccurred())
 
 					req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -351,6 +633,41 @@ var _ = Describe("OAuthClient", func() {
 			})
 		})
 	})
+
+	Describe("VerifyCredentials", func() {
+		It("grants a token for the given credentials", func() {
+			server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.CombineHandlers(
+				ghttp.VerifyBasicAuth("some-client", "new-secret"),
+				ghttp.VerifyForm(url.Values{
+					"grant_type": []string{"client_credentials"},
+				}),
+				ghttp.RespondWith(http.StatusOK, `{
+					"access_token": "some-opsman-token",
+					"token_type": "bearer",
+					"expires_in": 3600
+					}`, http.Header{
+					"Content-Type": []string{"application/json"},
+				}),
+			))
+
+			client, err := network.NewOAuthClient("", server.URL(), "", "", "some-client", "old-secret", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = client.VerifyCredentials("", "", "some-client", "new-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns an error when UAA does not grant a token", func() {
+			server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.RespondWith(http.StatusUnauthorized, `{"error": "unauthorized"}`))
+
+			client, err := network.NewOAuthClient("", server.URL(), "opsman-username", "opsman-password", "", "", true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = client.VerifyCredentials("opsman-username", "wrong-password", "", "")
+			Expect(err).To(MatchError(ContainSubstring("token could not be retrieved from target url")))
+		})
+	})
 })
 
 func setupBasicOauth(server *ghttp.Server) {
@@ -1,9 +1,11 @@
 package network
 
 import (
-	"github.com/cheggaaa/pb/v3"
+	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/pivotal-cf/om/progress"
 )
 
 type ProgressClient struct {
@@ -19,10 +21,12 @@ func NewProgressClient(client httpClient, stderr io.Writer) ProgressClient {
 }
 
 func (pc ProgressClient) Do(req *http.Request) (*http.Response, error) {
-	bar := pb.Default.New(0)
-	bar.SetWriter(pc.stderr)
-	bar.Set(pb.Bytes, true)
-	bar.SetMaxWidth(80)
+	phase := "download"
+	if req.Method == http.MethodPost || req.Method == http.MethodPut {
+		phase = "upload"
+	}
+
+	bar := progress.NewBar(pc.stderr, phase).SetMaxWidth(80)
 
 	switch req.Method {
 	case http.MethodPost, http.MethodPut:
@@ -40,6 +44,14 @@ func (pc ProgressClient) Do(req *http.Request) (*http.Response, error) {
 	if req.Method == http.MethodGet {
 		resp.Body = bar.NewProxyReader(resp.Body)
 		bar.SetTotal(resp.ContentLength)
+
+		// a resumed download reports progress against the whole file
+		var start, end, total int64
+		_, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total)
+		if resp.StatusCode == http.StatusPartialContent && err == nil {
+			bar.SetTotal(total)
+			bar.SetCurrent(start)
+		}
 	}
 
 	return resp, nil
@@ -10,6 +10,7 @@ import (
 
 	"github.com/pivotal-cf/om/network"
 	"github.com/pivotal-cf/om/network/fakes"
+	"github.com/pivotal-cf/om/progress"
 
 	. "github.com/onsi/ginkgo/v2"
 
@@ -62,6 +63,28 @@ var _ = Describe("ProgressClient", func() {
 			Eventually(buffer).Should(gbytes.Say("---] 100.00%"))
 		})
 
+		It("reports the progress as JSON lines to a progress JSON writer", func() {
+			client.DoStub = func(req *http.Request) (*http.Response, error) {
+				_, err := io.Copy(io.Discard, req.Body)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(req.Body.Close()).To(Succeed())
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+			}
+
+			events := gbytes.NewBuffer()
+			progressClient = network.NewProgressClient(client, progress.NewJSONWriter(buffer, events))
+
+			req, err := http.NewRequest("POST", "/some/endpoint", strings.NewReader("some content"))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = progressClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(events).To(gbytes.Say(`"phase":"upload","state":"started","total":12`))
+			Expect(events).To(gbytes.Say(`"phase":"upload","state":"finished","bytes":12,"total":12`))
+			Expect(buffer.Contents()).To(BeEmpty())
+		})
+
 		It("makes a request to download the product to the Ops Manager", func() {
 			client.DoReturns(&http.Response{
 				StatusCode:    http.StatusOK,
@@ -89,6 +112,28 @@ var _ = Describe("ProgressClient", func() {
 			Expect(request.URL.Path).To(Equal("/some/endpoint"))
 		})
 
+		It("reports the progress of a resumed download against the whole file", func() {
+			client.DoReturns(&http.Response{
+				StatusCode:    http.StatusPartialContent,
+				ContentLength: int64(len([]byte("response"))),
+				Header:        http.Header{"Content-Range": []string{"bytes 12-19/20"}},
+				Body:          io.NopCloser(strings.NewReader("response")),
+			}, nil)
+
+			req, err := http.NewRequest("GET", "/some/endpoint", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp, err := progressClient.Do(req)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = io.ReadAll(resp.Body)
+			Expect(err).ToNot(HaveOccurred())
+			resp.Body.Close()
+
+			Eventually(buffer).Should(gbytes.Say("20 B / 20 B"))
+			Eventually(buffer).Should(gbytes.Say("---] 100.00%"))
+		})
+
 		When("an error occurs", func() {
 			When("the client errors performing the request", func() {
 				It("returns an error", func() {
@@ -0,0 +1,100 @@
+package network
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	minRateLimitWait = time.Second
+	maxRateLimitWait = 5 * time.Minute
+)
+
+type RateLimitClient struct {
+	client  httpClient
+	retries int
+	backoff time.Duration
+	writer  io.Writer
+}
+
+// NewRateLimitClient waits and retries requests that Ops Manager rejects with
+// 429 Too Many Requests, up to retries times. It waits for as long as the
+// Retry-After header asks (capped at five minutes), or an exponentially
+// increasing interval starting at backoff, or at a second when backoff is
+// zero, when the header is missing.
+func NewRateLimitClient(client httpClient, retries int, backoff time.Duration, writer io.Writer) *RateLimitClient {
+	return &RateLimitClient{
+		client:  client,
+		retries: retries,
+		backoff: backoff,
+		writer:  writer,
+	}
+}
+
+func (c *RateLimitClient) Do(request *http.Request) (*http.Response, error) {
+	// a rejected request was not processed, so any method can be sent again
+	// as long as its body can be replayed
+	replayable := request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+
+	for attempt := 1; ; attempt++ {
+		response, err := c.client.Do(request)
+		if err != nil || response.StatusCode != http.StatusTooManyRequests || attempt > c.retries || !replayable {
+			return response, err
+		}
+
+		delay := c.delay(response.Header.Get("Retry-After"), attempt)
+		_, _ = io.Copy(io.Discard, response.Body)
+		_ = response.Body.Close()
+
+		_, _ = fmt.Fprintf(c.writer, "request %s %s was rate limited (attempt %d of %d); retrying in %s\n", request.Method, request.URL.Path, attempt, c.retries+1, delay)
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(delay):
+		}
+
+		if request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("could not rewind request body for retry: %w", err)
+			}
+		}
+	}
+}
+
+// delay reads Retry-After as either a number of seconds or an HTTP date.
+func (c *RateLimitClient) delay(retryAfter string, attempt int) time.Duration {
+	retryAfter = strings.TrimSpace(retryAfter)
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		delay = time.Until(date)
+	} else {
+		// without Retry-After, the request is never sent again at once
+		backoff := c.backoff
+		if backoff <= 0 {
+			backoff = minRateLimitWait
+		}
+
+		delay = backoff << (attempt - 1)
+		if delay <= 0 {
+			delay = maxRateLimitWait
+		}
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRateLimitWait {
+		delay = maxRateLimitWait
+	}
+
+	return delay
+}
@@ -0,0 +1,134 @@
+package network_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Rate Limit Client", func() {
+	var (
+		fakeClient      *fakes.HttpClient
+		rateLimitClient *network.RateLimitClient
+
+		out *gbytes.Buffer
+	)
+
+	tooManyRequests := func(retryAfter string) *http.Response {
+		header := http.Header{}
+		if retryAfter != "" {
+			header.Set("Retry-After", retryAfter)
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader("slow down")),
+		}
+	}
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		out = gbytes.NewBuffer()
+
+		rateLimitClient = network.NewRateLimitClient(fakeClient, 2, time.Millisecond, out)
+	})
+
+	It("waits for Retry-After and retries the request", func() {
+		response := &http.Response{StatusCode: http.StatusOK}
+		fakeClient.DoReturnsOnCall(0, tooManyRequests("0"), nil)
+		fakeClient.DoReturnsOnCall(1, response, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/staged/products", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := rateLimitClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp).To(Equal(response))
+		Expect(fakeClient.DoCallCount()).To(Equal(2))
+		Expect(out).To(gbytes.Say(`request GET /api/v0/staged/products was rate limited \(attempt 1 of 3\); retrying in 0s`))
+	})
+
+	It("accepts Retry-After as an http date", func() {
+		fakeClient.DoReturnsOnCall(0, tooManyRequests(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)), nil)
+		fakeClient.DoReturnsOnCall(1, &http.Response{StatusCode: http.StatusOK}, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = rateLimitClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.DoCallCount()).To(Equal(2))
+	})
+
+	It("replays the body of other methods", func() {
+		var bodies []string
+		fakeClient.DoStub = func(request *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(request.Body)
+			Expect(err).ToNot(HaveOccurred())
+			bodies = append(bodies, string(body))
+
+			if len(bodies) == 1 {
+				return tooManyRequests(""), nil
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}
+
+		request, err := http.NewRequest("POST", "/api/v0/installations", strings.NewReader(`{"deploy_products": "all"}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = rateLimitClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies).To(Equal([]string{`{"deploy_products": "all"}`, `{"deploy_products": "all"}`}))
+	})
+
+	It("waits at least a second without Retry-After when the backoff is zero", func() {
+		rateLimitClient = network.NewRateLimitClient(fakeClient, 2, 0, out)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		fakeClient.DoStub = func(*http.Request) (*http.Response, error) {
+			cancel()
+			return tooManyRequests(""), nil
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = rateLimitClient.Do(request)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(out).To(gbytes.Say(`retrying in 1s`))
+	})
+
+	It("returns the 429 response once the retries are used up", func() {
+		fakeClient.DoReturns(tooManyRequests("0"), nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := rateLimitClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(fakeClient.DoCallCount()).To(Equal(3))
+	})
+
+	It("does not retry requests whose body cannot be replayed", func() {
+		fakeClient.DoReturns(tooManyRequests("0"), nil)
+
+		request, err := http.NewRequest("POST", "/api/v0/available_products", io.NopCloser(strings.NewReader("product")))
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := rateLimitClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+})
@@ -0,0 +1,166 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"set-cookie":          true,
+	"x-vault-token":       true,
+}
+
+var sensitiveKeyFragments = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"private_key",
+	"private-key",
+}
+
+// sensitiveJSONValueRegex catches credential fields in bodies that cannot be
+// decoded, e.g. truncated or chunked responses.
+var sensitiveJSONValueRegex = regexp.MustCompile(`(?i)("[^"]*(?:password|passphrase|secret|token|private_key|private-key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+func isSensitiveHeader(name string) bool {
+	return sensitiveHeaders[strings.ToLower(name)]
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactBody masks known credential fields in JSON and form encoded bodies.
+func redactBody(contentType string, body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			return []byte(redactValues(values).Encode())
+		}
+	}
+
+	var document interface{}
+	if json.Unmarshal(body, &document) == nil {
+		redactedDocument := redactJSON("", document)
+		if reflect.DeepEqual(document, redactedDocument) {
+			return body
+		}
+
+		redactedBody, err := json.Marshal(redactedDocument)
+		if err == nil {
+			return redactedBody
+		}
+	}
+
+	return sensitiveJSONValueRegex.ReplaceAll(body, []byte(`${1}"`+redacted+`"`))
+}
+
+func redactJSON(key string, value interface{}) interface{} {
+	if key != "" && isSensitiveKey(key) {
+		return redacted
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redactedMap := map[string]interface{}{}
+		for childKey, childValue := range v {
+			// credential endpoints return the secret under credential.value
+			if key == "credential" && childKey == "value" {
+				redactedMap[childKey] = redacted
+				continue
+			}
+			redactedMap[childKey] = redactJSON(childKey, childValue)
+		}
+		return redactedMap
+	case []interface{}:
+		redactedList := make([]interface{}, len(v))
+		for i, childValue := range v {
+			redactedList[i] = redactJSON("", childValue)
+		}
+		return redactedList
+	}
+
+	return value
+}
+
+func redactValues(values url.Values) url.Values {
+	redactedValues := url.Values{}
+	for key, value := range values {
+		if isSensitiveKey(key) {
+			redactedValues[key] = []string{redacted}
+			continue
+		}
+		redactedValues[key] = value
+	}
+
+	return redactedValues
+}
+
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redactedURL := *u
+	if redactedURL.User != nil {
+		redactedURL.User = url.User(redactedURL.User.Username())
+	}
+	if redactedURL.RawQuery != "" {
+		redactedURL.RawQuery = redactValues(redactedURL.Query()).Encode()
+	}
+
+	return redactedURL.String()
+}
+
+// redactDump masks the credentials in a request or response as printed by
+// httputil.DumpRequest and httputil.DumpResponse.
+func redactDump(dump []byte) []byte {
+	head, body, hasBody := bytes.Cut(dump, []byte("\r\n\r\n"))
+
+	var contentType string
+	lines := strings.Split(string(head), "\r\n")
+	for i, line := range lines {
+		name, _, found := strings.Cut(line, ":")
+		if !found || i == 0 {
+			continue
+		}
+
+		if strings.EqualFold(name, "Content-Type") {
+			contentType = strings.TrimSpace(strings.TrimPrefix(line, name+":"))
+		}
+		if isSensitiveHeader(name) {
+			lines[i] = name + ": " + redacted
+		}
+	}
+
+	redactedDump := []byte(strings.Join(lines, "\r\n"))
+	if !hasBody {
+		return redactedDump
+	}
+
+	redactedDump = append(redactedDump, []byte("\r\n\r\n")...)
+	return append(redactedDump, redactBody(contentType, body)...)
+}
@@ -0,0 +1,97 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const maxRetryBackoff = time.Minute
+
+type RetryClient struct {
+	client  httpClient
+	retries int
+	backoff time.Duration
+	writer  io.Writer
+}
+
+// NewRetryClient retries idempotent requests that fail with a network error up
+// to retries times, waiting an exponentially increasing, jittered interval
+// (starting at backoff) between attempts. A backoff of zero retries at once.
+func NewRetryClient(client httpClient, retries int, backoff time.Duration, writer io.Writer) *RetryClient {
+	return &RetryClient{
+		client:  client,
+		retries: retries,
+		backoff: backoff,
+		writer:  writer,
+	}
+}
+
+func (c *RetryClient) Do(request *http.Request) (*http.Response, error) {
+	if !isRetryable(request) {
+		return c.client.Do(request)
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, err := c.client.Do(request)
+		if err == nil || attempt > c.retries || !isNetworkError(request, err) {
+			return response, err
+		}
+
+		delay := c.delay(attempt)
+		_, _ = fmt.Fprintf(c.writer, "request %s %s failed (attempt %d of %d): %s; retrying in %s\n", request.Method, request.URL.Path, attempt, c.retries+1, err, delay)
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(delay):
+		}
+
+		if request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("could not rewind request body for retry: %w", err)
+			}
+		}
+	}
+}
+
+func (c *RetryClient) delay(attempt int) time.Duration {
+	if c.backoff <= 0 {
+		return 0
+	}
+
+	delay := c.backoff << (attempt - 1)
+	if delay > maxRetryBackoff || delay <= 0 {
+		delay = maxRetryBackoff
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// isRetryable only allows idempotent methods whose body, if any, can be
+// replayed. Uploads stream their body and are never retried here.
+func isRetryable(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+func isNetworkError(request *http.Request, err error) bool {
+	if request.Context().Err() != nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,159 @@
+package network_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Retry Client", func() {
+	var (
+		fakeClient  *fakes.HttpClient
+		retryClient *network.RetryClient
+		networkErr  error
+
+		out *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		networkErr = &url.Error{Op: "Get", URL: "/api/v0/info", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+		out = gbytes.NewBuffer()
+
+		retryClient = network.NewRetryClient(fakeClient, 2, time.Millisecond, out)
+	})
+
+	It("returns the response when the request succeeds", func() {
+		response := &http.Response{StatusCode: http.StatusOK}
+		fakeClient.DoReturns(response, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp).To(Equal(response))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("retries idempotent requests that fail with a network error", func() {
+		response := &http.Response{StatusCode: http.StatusOK}
+		fakeClient.DoReturnsOnCall(0, nil, networkErr)
+		fakeClient.DoReturnsOnCall(1, response, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		resp, err := retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(resp).To(Equal(response))
+		Expect(fakeClient.DoCallCount()).To(Equal(2))
+		Expect(out).To(gbytes.Say(`request GET /api/v0/info failed \(attempt 1 of 3\): .*connection refused; retrying in`))
+	})
+
+	It("replays the request body on each attempt", func() {
+		var bodies []string
+		fakeClient.DoStub = func(request *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(request.Body)
+			Expect(err).ToNot(HaveOccurred())
+			bodies = append(bodies, string(body))
+
+			if len(bodies) < 3 {
+				return nil, networkErr
+			}
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}
+
+		request, err := http.NewRequest("PUT", "/api/v0/staged/director/properties", strings.NewReader(`{"some":"properties"}`))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bodies).To(Equal([]string{`{"some":"properties"}`, `{"some":"properties"}`, `{"some":"properties"}`}))
+	})
+
+	It("returns the last error once the retries are exhausted", func() {
+		fakeClient.DoReturns(nil, networkErr)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError(networkErr))
+		Expect(fakeClient.DoCallCount()).To(Equal(3))
+	})
+
+	It("retries at once when the backoff is zero", func() {
+		retryClient = network.NewRetryClient(fakeClient, 2, 0, out)
+		fakeClient.DoReturns(nil, networkErr)
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError(networkErr))
+		Expect(fakeClient.DoCallCount()).To(Equal(3))
+		Expect(out).To(gbytes.Say(`retrying in 0s`))
+	})
+
+	It("does not retry errors that are not network errors", func() {
+		fakeClient.DoReturns(nil, errors.New("target flag is required"))
+
+		request, err := http.NewRequest("GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError("target flag is required"))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry non-idempotent requests", func() {
+		fakeClient.DoReturns(nil, networkErr)
+
+		request, err := http.NewRequest("POST", "/api/v0/installations", strings.NewReader("{}"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError(networkErr))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("does not retry requests whose body cannot be replayed", func() {
+		fakeClient.DoReturns(nil, networkErr)
+
+		request, err := http.NewRequest("PUT", "/api/v0/stemcells", io.NopCloser(strings.NewReader("stream")))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError(networkErr))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+
+	It("stops retrying when the request context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		fakeClient.DoStub = func(*http.Request) (*http.Response, error) {
+			cancel()
+			return nil, networkErr
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "GET", "/api/v0/info", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = retryClient.Do(request)
+		Expect(err).To(MatchError(networkErr))
+		Expect(fakeClient.DoCallCount()).To(Equal(1))
+	})
+})
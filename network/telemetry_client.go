@@ -0,0 +1,135 @@
+package network
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	idSegmentRegex   = regexp.MustCompile(`^\d+$`)
+	guidSegmentRegex = regexp.MustCompile(`^[\w-]+-[0-9a-f]{20}$|^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// TelemetryClient records a span and the duration of every request,
+// until the body of the response is closed so downloads are included.
+type TelemetryClient struct {
+	client   httpClient
+	ctx      context.Context
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+}
+
+// NewTelemetryClient starts the spans of the requests as children of the
+// span in ctx, e.g. the span of the command.
+func NewTelemetryClient(ctx context.Context, client httpClient, tracer trace.Tracer, meter metric.Meter) (*TelemetryClient, error) {
+	duration, err := meter.Float64Histogram(
+		"om.http.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("duration of the requests to Ops Manager, until the response body is closed"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TelemetryClient{
+		client:   client,
+		ctx:      ctx,
+		tracer:   tracer,
+		duration: duration,
+	}, nil
+}
+
+func (c *TelemetryClient) Do(request *http.Request) (*http.Response, error) {
+	route := requestRoute(request.URL.Path)
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", request.Method),
+		attribute.String("http.route", route),
+	}
+
+	ctx, span := c.tracer.Start(c.ctx, request.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes...),
+		trace.WithAttributes(attribute.String("url.path", request.URL.Path)),
+	)
+	if request.URL.Host != "" {
+		span.SetAttributes(attribute.String("server.address", request.URL.Host))
+	}
+	if request.ContentLength > 0 {
+		span.SetAttributes(attribute.Int64("http.request.body.size", request.ContentLength))
+	}
+
+	start := time.Now()
+	end := func(statusCode int, err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			attributes = append(attributes, attribute.String("error.type", "request"))
+		}
+		if statusCode != 0 {
+			span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+			attributes = append(attributes, attribute.Int("http.response.status_code", statusCode))
+			if statusCode >= 400 {
+				span.SetStatus(codes.Error, http.StatusText(statusCode))
+			}
+		}
+
+		c.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attributes...))
+		span.End()
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		end(0, err)
+		return nil, err
+	}
+
+	if response.Body == nil {
+		end(response.StatusCode, nil)
+		return response, nil
+	}
+
+	response.Body = &telemetryBody{
+		ReadCloser: response.Body,
+		end:        func() { end(response.StatusCode, nil) },
+	}
+
+	return response, nil
+}
+
+// requestRoute replaces the IDs and GUIDs in the path, so requests to the
+// same endpoint are grouped together.
+func requestRoute(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		switch {
+		case idSegmentRegex.MatchString(segment):
+			segments[i] = "{id}"
+		case guidSegmentRegex.MatchString(segment):
+			segments[i] = "{guid}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+type telemetryBody struct {
+	io.ReadCloser
+	once sync.Once
+	end  func()
+}
+
+func (b *telemetryBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.end)
+	return err
+}
@@ -0,0 +1,116 @@
+package network_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/pivotal-cf/om/network"
+	"github.com/pivotal-cf/om/network/fakes"
+)
+
+var _ = Describe("Telemetry Client", func() {
+	var (
+		fakeClient *fakes.HttpClient
+		spans      *tracetest.InMemoryExporter
+		reader     *sdkmetric.ManualReader
+		client     *network.TelemetryClient
+	)
+
+	BeforeEach(func() {
+		fakeClient = &fakes.HttpClient{}
+		spans = tracetest.NewInMemoryExporter()
+		reader = sdkmetric.NewManualReader()
+
+		tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(spans)).Tracer("om")
+		meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("om")
+
+		var err error
+		client, err = network.NewTelemetryClient(context.Background(), fakeClient, tracer, meter)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	durations := func() []metricdata.HistogramDataPoint[float64] {
+		var metrics metricdata.ResourceMetrics
+		Expect(reader.Collect(context.Background(), &metrics)).To(Succeed())
+		Expect(metrics.ScopeMetrics).To(HaveLen(1))
+		Expect(metrics.ScopeMetrics[0].Metrics[0].Name).To(Equal("om.http.client.request.duration"))
+
+		return metrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64]).DataPoints
+	}
+
+	It("records a span and the duration of a request until its body is closed", func() {
+		fakeClient.DoReturns(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil)
+
+		request, err := http.NewRequest("GET", "https://opsman.example.com/api/v0/staged/products/cf-0123456789abcdef0123/jobs", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(spans.GetSpans()).To(BeEmpty())
+
+		Expect(response.Body.Close()).To(Succeed())
+		Expect(response.Body.Close()).To(Succeed())
+
+		Expect(spans.GetSpans()).To(HaveLen(1))
+		span := spans.GetSpans()[0]
+		Expect(span.Name).To(Equal("GET /api/v0/staged/products/{guid}/jobs"))
+		Expect(span.Attributes).To(ContainElements(
+			attribute.String("url.path", "/api/v0/staged/products/cf-0123456789abcdef0123/jobs"),
+			attribute.String("server.address", "opsman.example.com"),
+			attribute.Int("http.response.status_code", http.StatusOK),
+		))
+		Expect(span.Status.Code).To(Equal(codes.Unset))
+
+		points := durations()
+		Expect(points).To(HaveLen(1))
+		Expect(points[0].Count).To(Equal(uint64(1)))
+		Expect(points[0].Attributes.ToSlice()).To(ConsistOf(
+			attribute.String("http.request.method", "GET"),
+			attribute.String("http.route", "/api/v0/staged/products/{guid}/jobs"),
+			attribute.Int("http.response.status_code", http.StatusOK),
+		))
+	})
+
+	It("groups the requests to installations by route", func() {
+		fakeClient.DoReturns(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+		request, err := http.NewRequest("GET", "/api/v0/installations/42", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.Body.Close()).To(Succeed())
+
+		Expect(spans.GetSpans()[0].Name).To(Equal("GET /api/v0/installations/{id}"))
+		Expect(spans.GetSpans()[0].Status.Code).To(Equal(codes.Error))
+	})
+
+	It("records the errors of requests", func() {
+		fakeClient.DoReturns(nil, errors.New("connection refused"))
+
+		request, err := http.NewRequest("POST", "/api/v0/installations", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.Do(request)
+		Expect(err).To(MatchError("connection refused"))
+
+		Expect(spans.GetSpans()).To(HaveLen(1))
+		Expect(spans.GetSpans()[0].Status).To(Equal(sdktrace.Status{Code: codes.Error, Description: "connection refused"}))
+		Expect(durations()[0].Attributes.ToSlice()).To(ContainElement(attribute.String("error.type", "request")))
+	})
+})
@@ -0,0 +1,136 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	tokenCacheLockTimeout = 30 * time.Second
+	tokenCacheStaleLock   = 2 * time.Minute
+)
+
+// TokenCache persists UAA tokens on disk so that separate om invocations can
+// reuse an access token (or refresh it) instead of performing a new grant.
+// Entries are keyed by UAA target, identity and a hash of the secret; secrets
+// are never written.
+type TokenCache struct {
+	path string
+}
+
+func NewTokenCache(path string) *TokenCache {
+	return &TokenCache{path: path}
+}
+
+// lock serialises access to the cache across processes with an exclusive lock
+// file next to it, so concurrent commands do not race to rotate the same
+// refresh token. Locks left behind by a crashed process expire.
+func (tc *TokenCache) lock() (func(), error) {
+	lockPath := tc.path + ".lock"
+	deadline := time.Now().Add(tokenCacheLockTimeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = file.Close()
+			return func() { _ = os.Remove(lockPath) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("could not lock token cache: %w", err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > tokenCacheStaleLock {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("could not lock token cache: timed out waiting for %s", lockPath)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func (tc *TokenCache) load(key string) (*oauth2.Token, error) {
+	entries, err := tc.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	return entries[key], nil
+}
+
+func (tc *TokenCache) save(key string, token *oauth2.Token) error {
+	entries, err := tc.entries()
+	if err != nil {
+		// an unreadable cache is replaced rather than blocking authentication
+		entries = map[string]*oauth2.Token{}
+	}
+	entries[key] = token
+
+	return tc.write(entries)
+}
+
+func (tc *TokenCache) delete(key string) error {
+	entries, err := tc.entries()
+	if err != nil {
+		entries = map[string]*oauth2.Token{}
+	}
+
+	if _, ok := entries[key]; !ok && err == nil {
+		return nil
+	}
+	delete(entries, key)
+
+	return tc.write(entries)
+}
+
+func (tc *TokenCache) write(entries map[string]*oauth2.Token) error {
+	contents, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(tc.path), filepath.Base(tc.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(contents)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), tc.path)
+}
+
+func (tc *TokenCache) entries() (map[string]*oauth2.Token, error) {
+	entries := map[string]*oauth2.Token{}
+
+	contents, err := os.ReadFile(tc.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(contents, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse token cache %s: %w", tc.path, err)
+	}
+
+	return entries, nil
+}
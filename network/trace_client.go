@@ -38,7 +38,7 @@ func (c *TraceClient) Do(request *http.Request) (*http.Response, error) {
 		return nil, err
 	}
 
-	fmt.Fprintf(c.writer, "%s\n", string(requestOutput))
+	fmt.Fprintf(c.writer, "%s\n", string(redactDump(requestOutput)))
 
 	response, err := c.client.Do(request)
 	if err != nil {
@@ -53,7 +53,7 @@ func (c *TraceClient) Do(request *http.Request) (*http.Response, error) {
 	if err != nil {
 		return nil, err
 	}
-	fmt.Fprintf(c.writer, "%s\n", string(responseOutput))
+	fmt.Fprintf(c.writer, "%s\n", string(redactDump(responseOutput)))
 
 	return response, nil
 }
@@ -63,6 +63,38 @@ var _ = Describe("Trace Client", func() {
 		Expect(out).To(gbytes.Say(string(expectedContents)))
 	})
 
+	When("the request and response contain credentials", func() {
+		It("redacts them", func() {
+			request, err := http.NewRequest("POST", "https://example.com/uaa/oauth/token", strings.NewReader("grant_type=password&username=admin&password=some-password"))
+			Expect(err).ToNot(HaveOccurred())
+			request.Header.Set("Authorization", "Basic b3BzbWFuOg==")
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			response.Header = http.Header{"Content-Type": []string{"application/json"}}
+			response.Body = io.NopCloser(strings.NewReader(`{"access_token": "some-token", "token_type": "bearer", "credential": {"type": "secret", "value": "some-secret"}}`))
+
+			_, err = traceClient.Do(request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(ContainSubstring("Authorization: [REDACTED]"))
+			Expect(string(out.Contents())).To(ContainSubstring("username=admin"))
+			Expect(string(out.Contents())).To(ContainSubstring(`"type":"secret"`))
+			for _, secret := range []string{"b3BzbWFuOg==", "some-password", "some-token", "bearer", "some-secret"} {
+				Expect(string(out.Contents())).ToNot(ContainSubstring(secret))
+			}
+		})
+
+		It("redacts credential fields in bodies that are not valid json", func() {
+			response.Body = io.NopCloser(strings.NewReader(`{"properties": {".properties.secret": {"value": {"password": "some-password"}`))
+
+			_, err := traceClient.Do(request)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(out.Contents())).To(ContainSubstring(`{"password": "[REDACTED]"}`))
+			Expect(string(out.Contents())).ToNot(ContainSubstring("some-password"))
+		})
+	})
+
 	When("the underlying http client fails", func() {
 		BeforeEach(func() {
 			fakeClient.DoReturns(nil, errors.New("boom!"))
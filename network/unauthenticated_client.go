@@ -6,22 +6,45 @@ import (
 )
 
 type UnauthenticatedClient struct {
-	target string
-	client *http.Client
+	target         string
+	client         *http.Client
+	connectTimeout time.Duration
 }
 
-func NewUnauthenticatedClient(target string, insecureSkipVerify bool, caCert string, connectTimeout time.Duration, requestTimeout time.Duration) (UnauthenticatedClient, error) {
-	client, err := newHTTPClient(insecureSkipVerify, caCert, requestTimeout, connectTimeout)
+func NewUnauthenticatedClient(target string, insecureSkipVerify bool, caCert string, clientCert string, clientKey string, socksProxy string, connectTimeout time.Duration, requestTimeout time.Duration) (UnauthenticatedClient, error) {
+	client, err := newHTTPClient(insecureSkipVerify, caCert, clientCert, clientKey, socksProxy, requestTimeout, connectTimeout, TransportOptions{})
 	if err != nil {
 		return UnauthenticatedClient{}, err
 	}
 
 	return UnauthenticatedClient{
-		target: target,
-		client: client,
+		target:         target,
+		client:         client,
+		connectTimeout: connectTimeout,
 	}, nil
 }
 
+// WithTransport returns a client whose connections are tuned by options.
+func (c UnauthenticatedClient) WithTransport(options TransportOptions) UnauthenticatedClient {
+	transport := c.client.Transport.(*http.Transport)
+
+	client := *c.client
+	client.Transport = newTransport(transport.TLSClientConfig, transport.Proxy, c.connectTimeout, options)
+	c.client = &client
+
+	return c
+}
+
+// WithRequestTimeout returns a client whose requests, including reading the
+// response body, time out after timeout instead. 0 disables the timeout.
+func (c UnauthenticatedClient) WithRequestTimeout(timeout time.Duration) UnauthenticatedClient {
+	client := *c.client
+	client.Timeout = timeout
+	c.client = &client
+
+	return c
+}
+
 func (c UnauthenticatedClient) Do(request *http.Request) (*http.Response, error) {
 	targetURL, err := parseURL(c.target)
 	if err != nil {
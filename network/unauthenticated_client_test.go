@@ -6,8 +6,10 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
@@ -36,7 +38,7 @@ var _ = Describe("UnauthenticatedClient", func() {
 			}))
 			server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
 
-			client, _ := network.NewUnauthenticatedClient(server.URL, true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			client, _ := network.NewUnauthenticatedClient(server.URL, true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 
 			request, err := http.NewRequest("GET", "/path?query", strings.NewReader("request"))
 			Expect(err).ToNot(HaveOccurred())
@@ -77,7 +79,7 @@ var _ = Describe("UnauthenticatedClient", func() {
 				noScheme.Scheme = ""
 				finalURL := strings.Replace(noScheme.String(), "//", "", 1)
 
-				client, _ := network.NewUnauthenticatedClient(finalURL, true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				client, _ := network.NewUnauthenticatedClient(finalURL, true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 				Expect(err).ToNot(HaveOccurred())
 
 				request, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
@@ -105,7 +107,7 @@ var _ = Describe("UnauthenticatedClient", func() {
 				Expect(err).ToNot(HaveOccurred())
 				pemCert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
 
-				client, err := network.NewUnauthenticatedClient(server.URL, false, pemCert, time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				client, err := network.NewUnauthenticatedClient(server.URL, false, pemCert, "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 				Expect(err).ToNot(HaveOccurred())
 
 				request, err := http.NewRequest("GET", "/path?query", strings.NewReader("request"))
@@ -120,7 +122,7 @@ var _ = Describe("UnauthenticatedClient", func() {
 				Expect(err).ToNot(HaveOccurred())
 				pemCert := writeFile(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
 
-				client, err := network.NewUnauthenticatedClient(server.URL, false, pemCert, time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				client, err := network.NewUnauthenticatedClient(server.URL, false, pemCert, "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 				Expect(err).ToNot(HaveOccurred())
 
 				request, err := http.NewRequest("GET", "/path?query", strings.NewReader("request"))
@@ -129,6 +131,84 @@ var _ = Describe("UnauthenticatedClient", func() {
 				_, err = client.Do(request)
 				Expect(err).ToNot(HaveOccurred())
 			})
+
+			It("trusts every CA of a bundle file along with the ca cert", func() {
+				cert, err := x509.ParseCertificate(server.TLS.Certificates[0].Certificate[0])
+				Expect(err).ToNot(HaveOccurred())
+				_, otherCA, _ := generateClientCertificate()
+				bundle := writeFile(otherCA + string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+
+				caCert, err := network.CABundle(otherCA, bundle)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(strings.Count(caCert, "BEGIN CERTIFICATE")).To(Equal(3))
+
+				client, err := network.NewUnauthenticatedClient(server.URL, false, caCert, "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path?query", strings.NewReader("request"))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = client.Do(request)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns an error when the bundle file has no certificates", func() {
+				bundle := writeFile("not a certificate")
+
+				_, err := network.CABundle("", bundle)
+				Expect(err).To(MatchError(fmt.Sprintf("could not use ca cert file %s: it has no PEM certificates", bundle)))
+
+				_, err = network.CABundle("", "/does/not/exist")
+				Expect(err).To(MatchError(ContainSubstring("could not load ca cert file")))
+			})
+		})
+
+		When("the transport is tuned", func() {
+			It("attempts HTTP/2", func() {
+				server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.Header().Set("X-Proto", req.Proto)
+				}))
+				server.EnableHTTP2 = true
+				server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
+				server.StartTLS()
+				defer server.Close()
+
+				client, err := network.NewUnauthenticatedClient(server.URL, true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+				response, err := client.Do(request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Header.Get("X-Proto")).To(Equal("HTTP/1.1"))
+
+				request, err = http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+				response, err = client.WithTransport(network.TransportOptions{HTTP2: true, IdleConnTimeout: time.Minute, ReadBufferSize: 64 * 1024}).Do(request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.Header.Get("X-Proto")).To(Equal("HTTP/2.0"))
+			})
+
+			It("times out with another request timeout", func() {
+				server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					time.Sleep(300 * time.Millisecond)
+				}))
+				server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
+				defer server.Close()
+
+				client, err := network.NewUnauthenticatedClient(server.URL, true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.WithRequestTimeout(100 * time.Millisecond).Do(request)
+				Expect(err).To(MatchError(ContainSubstring("Client.Timeout exceeded")))
+
+				request, err = http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = client.WithRequestTimeout(0).Do(request)
+				Expect(err).ToNot(HaveOccurred())
+			})
 		})
 
 		It("enforces minimum TLS version 1.2", func() {
@@ -137,7 +217,7 @@ var _ = Describe("UnauthenticatedClient", func() {
 			nonTLS12Server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
 			defer nonTLS12Server.Close()
 
-			client, _ := network.NewUnauthenticatedClient(nonTLS12Server.URL, true, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+			client, _ := network.NewUnauthenticatedClient(nonTLS12Server.URL, true, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 
 			req, err := http.NewRequest("GET", "/some/path", strings.NewReader("request-body"))
 			Expect(err).ToNot(HaveOccurred())
@@ -146,10 +226,132 @@ var _ = Describe("UnauthenticatedClient", func() {
 			Expect(err).To(MatchError(ContainSubstring("protocol version not supported")))
 		})
 
+		When("a client certificate is provided", func() {
+			var (
+				server  *httptest.Server
+				certPEM string
+				keyPEM  string
+			)
+
+			BeforeEach(func() {
+				var cert *x509.Certificate
+				cert, certPEM, keyPEM = generateClientCertificate()
+
+				clientCAs := x509.NewCertPool()
+				clientCAs.AddCert(cert)
+
+				server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					Expect(req.TLS.PeerCertificates).To(HaveLen(1))
+					Expect(req.TLS.PeerCertificates[0].Subject.CommonName).To(Equal("om-client"))
+					w.WriteHeader(http.StatusOK)
+				}))
+				server.TLS = &tls.Config{
+					ClientAuth: tls.RequireAndVerifyClientCert,
+					ClientCAs:  clientCAs,
+				}
+				server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
+				server.StartTLS()
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("presents the certificate loaded from strings", func() {
+				client, err := network.NewUnauthenticatedClient(server.URL, true, "", certPEM, keyPEM, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				response, err := client.Do(request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("presents the certificate loaded from files", func() {
+				client, err := network.NewUnauthenticatedClient(server.URL, true, "", writeFile(certPEM), writeFile(keyPEM), "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				response, err := client.Do(request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns an error when only the certificate is provided", func() {
+				_, err := network.NewUnauthenticatedClient(server.URL, true, "", certPEM, "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).To(MatchError("client-cert and client-key must be provided together"))
+			})
+
+			It("returns an error when the key does not match the certificate", func() {
+				_, _, otherKeyPEM := generateClientCertificate()
+
+				_, err := network.NewUnauthenticatedClient(server.URL, true, "", certPEM, otherKeyPEM, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).To(MatchError(ContainSubstring("could not use client cert")))
+			})
+		})
+
+		When("a socks proxy is provided", func() {
+			var (
+				server *httptest.Server
+				proxy  net.Listener
+				target string
+			)
+
+			BeforeEach(func() {
+				server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}))
+				server.Config.ErrorLog = log.New(GinkgoWriter, "", 0)
+
+				serverURL, err := url.Parse(server.URL)
+				Expect(err).ToNot(HaveOccurred())
+				target = "https://opsman.internal:" + serverURL.Port()
+
+				proxy = startSOCKSProxy("proxy-user", "proxy-password")
+			})
+
+			AfterEach(func() {
+				server.Close()
+				proxy.Close()
+			})
+
+			It("tunnels requests through the proxy", func() {
+				client, err := network.NewUnauthenticatedClient(target, true, "", "", "", "socks5h://proxy-user:proxy-password@"+proxy.Addr().String(), time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				response, err := client.Do(request)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusTeapot))
+			})
+
+			It("returns an error when the proxy rejects the credentials", func() {
+				client, err := network.NewUnauthenticatedClient(target, true, "", "", "", "socks5h://proxy-user:wrong@"+proxy.Addr().String(), time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).ToNot(HaveOccurred())
+
+				request, err := http.NewRequest("GET", "/path", nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = client.Do(request)
+				Expect(err).To(MatchError(ContainSubstring("username/password authentication failed")))
+			})
+
+			It("returns an error when the proxy is not a socks5 url", func() {
+				_, err := network.NewUnauthenticatedClient(target, true, "", "", "", "http://proxy-user:proxy-password@"+proxy.Addr().String(), time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+				Expect(err).To(MatchError(`socks proxy "http://proxy-user:xxxxx@` + proxy.Addr().String() + `" must use the socks5 or socks5h scheme`))
+			})
+		})
+
 		Context("failure cases", func() {
 			When("the target url is empty", func() {
 				It("returns an error", func() {
-					client, _ := network.NewUnauthenticatedClient("", false, "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
+					client, _ := network.NewUnauthenticatedClient("", false, "", "", "", "", time.Duration(5)*time.Second, time.Duration(30)*time.Second)
 					_, err := client.Do(&http.Request{})
 					Expect(err).To(MatchError("target flag is required, run `om help` for more info"))
 				})
@@ -0,0 +1,72 @@
+package omclient
+
+import (
+	"time"
+)
+
+// Certificate is a certificate of the foundation, managed by Ops Manager or
+// configured by the operator.
+type Certificate struct {
+	Issuer     string
+	ValidFrom  time.Time
+	ValidUntil time.Time
+	// Configurable certificates are set in the configuration of a product,
+	// PropertyReference is their property.
+	Configurable      bool
+	PropertyReference string
+	ProductGUID       string
+	// Location is ops_manager or credhub.
+	Location     string
+	VariablePath string
+}
+
+// CertificateAuthority is a CA of Ops Manager. Only the active one signs
+// new certificates.
+type CertificateAuthority struct {
+	GUID      string
+	Issuer    string
+	CreatedOn string
+	ExpiresOn string
+	Active    bool
+	CertPEM   string
+}
+
+// Certificates returns the certificates of the foundation expiring within
+// the duration, e.g. 3m for 3 months, or all of them when it is empty.
+func (c *Client) Certificates(expiresWithin string) ([]Certificate, error) {
+	output, err := c.api.ListCertificates(expiresWithin)
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	var certificates []Certificate
+	for _, certificate := range output {
+		certificates = append(certificates, Certificate{
+			Issuer:            certificate.Issuer,
+			ValidFrom:         certificate.ValidFrom,
+			ValidUntil:        certificate.ValidUntil,
+			Configurable:      certificate.Configurable,
+			PropertyReference: certificate.PropertyReference,
+			ProductGUID:       certificate.ProductGUID,
+			Location:          certificate.Location,
+			VariablePath:      certificate.VariablePath,
+		})
+	}
+
+	return certificates, nil
+}
+
+// CertificateAuthorities returns the CAs of Ops Manager.
+func (c *Client) CertificateAuthorities() ([]CertificateAuthority, error) {
+	output, err := c.api.ListCertificateAuthorities()
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	var cas []CertificateAuthority
+	for _, ca := range output.CAs {
+		cas = append(cas, CertificateAuthority(ca))
+	}
+
+	return cas, nil
+}
@@ -0,0 +1,132 @@
+package omclient
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/network"
+)
+
+const (
+	defaultConnectTimeout = 10 * time.Second
+	defaultRequestTimeout = 30 * time.Minute
+)
+
+// Config describes the Ops Manager to connect to and how to authenticate,
+// like the global flags of om.
+type Config struct {
+	// Target is the location of Ops Manager, e.g. https://opsman.example.com.
+	Target string
+	// UAATarget is the location of the UAA, when it is not Target/uaa.
+	UAATarget string
+
+	// Either a username and password or a client ID and secret are
+	// required. The username and password are used when both are set.
+	Username     string
+	Password     string
+	ClientID     string
+	ClientSecret string
+
+	// DecryptionPassphrase unlocks Ops Manager when it is locked after a
+	// reboot.
+	DecryptionPassphrase string
+
+	// CACert is the path or the PEM of the CA of Ops Manager.
+	CACert            string
+	SkipSSLValidation bool
+
+	// ClientCert and ClientKey are the paths or the PEMs of a client
+	// certificate for mutual TLS.
+	ClientCert string
+	ClientKey  string
+
+	// SOCKSProxy is host:port or socks5://[user:password@]host:port.
+	SOCKSProxy string
+
+	// ConnectTimeout defaults to 10 seconds, RequestTimeout to 30 minutes.
+	ConnectTimeout time.Duration
+	RequestTimeout time.Duration
+
+	// Logger receives the messages of long running operations. They are
+	// discarded when not set.
+	Logger *log.Logger
+}
+
+// Client is safe to use from several goroutines. They share one token, and
+// Ops Manager is unlocked once.
+type Client struct {
+	api    api.Api
+	client httpClient
+}
+
+type httpClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// New returns a client for the Ops Manager described by config. It does not
+// connect to Ops Manager until a method is called.
+func New(config Config) (*Client, error) {
+	if config.Target == "" {
+		return nil, errors.New("the target of Ops Manager is required")
+	}
+
+	if config.ConnectTimeout == 0 {
+		config.ConnectTimeout = defaultConnectTimeout
+	}
+
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = defaultRequestTimeout
+	}
+
+	logger := config.Logger
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+
+	unauthedClient, err := network.NewUnauthenticatedClient(config.Target, config.SkipSSLValidation, config.CACert, config.ClientCert, config.ClientKey, config.SOCKSProxy, config.ConnectTimeout, config.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	oauthClient, err := network.NewOAuthClient(config.UAATarget, config.Target, config.Username, config.Password, config.ClientID, config.ClientSecret, config.SkipSSLValidation, config.CACert, config.ClientCert, config.ClientKey, config.SOCKSProxy, config.ConnectTimeout, config.RequestTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var authedClient httpClient = oauthClient
+	if config.DecryptionPassphrase != "" {
+		authedClient = network.NewDecryptClient(authedClient, unauthedClient, config.DecryptionPassphrase, logger.Writer())
+	}
+
+	return &Client{
+		api: api.New(api.ApiInput{
+			Client:                 authedClient,
+			UnauthedClient:         unauthedClient,
+			ProgressClient:         authedClient,
+			UnauthedProgressClient: unauthedClient,
+			Logger:                 logger,
+		}),
+		client: authedClient,
+	}, nil
+}
+
+// Do sends an authenticated request for the endpoints the client has no
+// method for. The path of the request is relative to the target, e.g.
+// /api/v0/info.
+func (c *Client) Do(request *http.Request) (*http.Response, error) {
+	return c.client.Do(request)
+}
+
+// Version returns the version of Ops Manager, e.g. 3.0.10-build.5.
+func (c *Client) Version() (string, error) {
+	info, err := c.api.Info()
+	if err != nil {
+		return "", newError(err)
+	}
+
+	return info.Version, nil
+}
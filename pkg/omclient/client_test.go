@@ -0,0 +1,222 @@
+package omclient_test
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/pivotal-cf/om/pkg/omclient"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		client *omclient.Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewTLSServer()
+		server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.CombineHandlers(
+			ghttp.VerifyBasicAuth("some-client", "some-secret"),
+			ghttp.RespondWith(http.StatusOK, `{"access_token": "some-token", "token_type": "bearer", "expires_in": 3600}`, http.Header{
+				"Content-Type": []string{"application/json"},
+			}),
+		))
+
+		var err error
+		client, err = omclient.New(omclient.Config{
+			Target:            server.URL(),
+			ClientID:          "some-client",
+			ClientSecret:      "some-secret",
+			SkipSSLValidation: true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	respondWith := func(method, path, body string) {
+		server.RouteToHandler(method, path, ghttp.CombineHandlers(
+			ghttp.VerifyHeaderKV("Authorization", "Bearer some-token"),
+			ghttp.RespondWith(http.StatusOK, body, http.Header{"Content-Type": []string{"application/json"}}),
+		))
+	}
+
+	It("requires a target", func() {
+		_, err := omclient.New(omclient.Config{})
+		Expect(err).To(MatchError("the target of Ops Manager is required"))
+	})
+
+	It("returns the version of Ops Manager", func() {
+		server.RouteToHandler("GET", "/api/v0/info", ghttp.RespondWith(http.StatusOK, `{"info": {"version": "3.0.10-build.5"}}`))
+
+		version, err := client.Version()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal("3.0.10-build.5"))
+	})
+
+	It("lists the staged and deployed products", func() {
+		respondWith("GET", "/api/v0/staged/products", `[{"guid": "cf-guid", "type": "cf", "product_version": "4.0.1"}]`)
+		respondWith("GET", "/api/v0/deployed/products", `[{"guid": "cf-guid", "type": "cf", "product_version": "4.0.0"}]`)
+
+		staged, err := client.StagedProducts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(staged).To(Equal([]omclient.Product{{GUID: "cf-guid", Name: "cf", Version: "4.0.1"}}))
+
+		product, err := client.StagedProduct("cf")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(product).To(Equal(omclient.Product{GUID: "cf-guid", Name: "cf", Version: "4.0.1"}))
+
+		deployed, err := client.DeployedProducts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deployed).To(Equal([]omclient.Product{{GUID: "cf-guid", Name: "cf", Version: "4.0.0"}}))
+	})
+
+	It("lists installations and applies changes", func() {
+		respondWith("GET", "/api/v0/installations", `{"installations": [{"id": 2, "status": "succeeded", "user_name": "admin", "started_at": "2024-01-02T03:04:05Z"}]}`)
+		respondWith("GET", "/api/v0/installations/2/logs", `{"logs": "some logs"}`)
+		respondWith("GET", "/api/v0/staged/products", `[{"guid": "cf-guid", "type": "cf"}]`)
+		respondWith("GET", "/api/v0/deployed/products", `[]`)
+		server.RouteToHandler("POST", "/api/v0/installations", ghttp.CombineHandlers(
+			ghttp.VerifyJSON(`{"ignore_warnings": "false", "deploy_products": ["cf-guid"], "force_latest_variables": false}`),
+			ghttp.RespondWith(http.StatusOK, `{"install": {"id": 3}}`),
+		))
+
+		installations, err := client.Installations()
+		Expect(err).ToNot(HaveOccurred())
+		startedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		Expect(installations).To(Equal([]omclient.Installation{{ID: 2, Status: "succeeded", UserName: "admin", StartedAt: &startedAt}}))
+
+		logs, err := client.InstallationLogs(2)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs).To(Equal("some logs"))
+
+		installation, err := client.ApplyChanges(omclient.ApplyChangesOptions{ProductNames: []string{"cf"}})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(installation.ID).To(Equal(3))
+	})
+
+	It("returns the certificates and the director", func() {
+		respondWith("GET", "/api/v0/deployed/certificates", `{"certificates": [{"issuer": "some-ca", "configurable": true, "property_reference": ".properties.some_cert", "product_guid": "cf-guid", "location": "ops_manager"}]}`)
+		respondWith("GET", "/api/v0/certificate_authorities", `{"certificate_authorities": [{"guid": "old", "active": false, "cert_pem": "old-cert"}, {"guid": "new", "active": true, "cert_pem": "active-cert"}]}`)
+		respondWith("GET", "/api/v0/deployed/director/credentials/bosh_commandline_credentials", `{"credential": "BOSH_CLIENT=some-bosh-client BOSH_CLIENT_SECRET=some-bosh-secret BOSH_CA_CERT=/var/tempest/workspaces/default/root_ca_certificate BOSH_ENVIRONMENT=10.0.0.5 bosh "}`)
+
+		certificates, err := client.Certificates("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(certificates).To(Equal([]omclient.Certificate{{Issuer: "some-ca", Configurable: true, PropertyReference: ".properties.some_cert", ProductGUID: "cf-guid", Location: "ops_manager"}}))
+
+		director, err := client.Director()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(director).To(Equal(omclient.Director{
+			Environment:  "10.0.0.5",
+			Client:       "some-bosh-client",
+			ClientSecret: "some-bosh-secret",
+			CACert:       "active-cert",
+		}))
+	})
+
+	It("authenticates with the username and password when a client is also set", func() {
+		server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.CombineHandlers(
+			ghttp.VerifyBasicAuth("opsman", ""),
+			ghttp.VerifyForm(map[string][]string{
+				"grant_type": {"password"},
+				"username":   {"some-user"},
+				"password":   {"some-password"},
+			}),
+			ghttp.RespondWith(http.StatusOK, `{"access_token": "some-token", "token_type": "bearer", "expires_in": 3600}`, http.Header{
+				"Content-Type": []string{"application/json"},
+			}),
+		))
+		respondWith("GET", "/api/v0/staged/products", `[]`)
+
+		client, err := omclient.New(omclient.Config{
+			Target:            server.URL(),
+			Username:          "some-user",
+			Password:          "some-password",
+			ClientID:          "some-client",
+			ClientSecret:      "some-secret",
+			SkipSSLValidation: true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = client.StagedProducts()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("can be used from several goroutines", func() {
+		server.RouteToHandler("PUT", "/api/v0/unlock", ghttp.RespondWith(http.StatusOK, `{}`))
+		server.RouteToHandler("GET", "/login/ensure_availability", ghttp.RespondWith(http.StatusFound, "", http.Header{
+			"Location": []string{"/auth/cloudfoundry"},
+		}))
+		respondWith("GET", "/api/v0/staged/products", `[{"guid": "cf-guid", "type": "cf"}]`)
+
+		client, err := omclient.New(omclient.Config{
+			Target:               server.URL(),
+			ClientID:             "some-client",
+			ClientSecret:         "some-secret",
+			DecryptionPassphrase: "some-passphrase",
+			SkipSSLValidation:    true,
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				_, err := client.StagedProducts()
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		var unlocks, grants int
+		for _, request := range server.ReceivedRequests() {
+			switch request.URL.Path {
+			case "/api/v0/unlock":
+				unlocks++
+			case "/uaa/oauth/token":
+				grants++
+			}
+		}
+		Expect(unlocks).To(Equal(1))
+		Expect(grants).To(Equal(1))
+	})
+
+	It("sends requests for other endpoints", func() {
+		respondWith("GET", "/api/v0/some/endpoint", `{}`)
+
+		request, err := http.NewRequest("GET", "/api/v0/some/endpoint", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		response, err := client.Do(request)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+	})
+	It("returns errors with the status and the errors of the response", func() {
+		server.RouteToHandler("GET", "/api/v0/staged/products", ghttp.RespondWith(http.StatusUnauthorized, `{"error": "invalid_token"}`))
+
+		_, err := client.StagedProducts()
+
+		var omErr *omclient.Error
+		Expect(errors.As(err, &omErr)).To(BeTrue())
+		Expect(omErr.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(omErr.Endpoint).To(Equal("/api/v0/staged/products"))
+		Expect(omErr.Errors).To(Equal(map[string][]string{"base": {"invalid_token"}}))
+	})
+})
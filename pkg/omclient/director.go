@@ -0,0 +1,40 @@
+package omclient
+
+// Director holds what is needed to target the BOSH director deployed by
+// Ops Manager, like the environment printed by om bosh-env.
+type Director struct {
+	// Environment is the address of the director.
+	Environment  string
+	Client       string
+	ClientSecret string
+	// CACert is the PEM of the active CA of Ops Manager, which signs the
+	// certificate of the director.
+	CACert string
+}
+
+// Director returns the credentials of the BOSH director.
+func (c *Client) Director() (Director, error) {
+	environment, err := c.api.GetBoshEnvironment()
+	if err != nil {
+		return Director{}, newError(err)
+	}
+
+	director := Director{
+		Environment:  environment.Environment,
+		Client:       environment.Client,
+		ClientSecret: environment.ClientSecret,
+	}
+
+	cas, err := c.CertificateAuthorities()
+	if err != nil {
+		return Director{}, newError(err)
+	}
+
+	for _, ca := range cas {
+		if ca.Active {
+			director.CACert = ca.CertPEM
+		}
+	}
+
+	return director, nil
+}
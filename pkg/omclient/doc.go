@@ -0,0 +1,18 @@
+// Package omclient is a client for the Ops Manager API for Go programs.
+//
+// Unlike the other packages of om, whose APIs change with the needs of the
+// om commands, omclient follows the semantic versioning of om: within a
+// major version, exported identifiers are not removed or changed in an
+// incompatible way.
+//
+//	client, err := omclient.New(omclient.Config{
+//		Target:       "https://opsman.example.com",
+//		ClientID:     "some-client",
+//		ClientSecret: "some-secret",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	products, err := client.StagedProducts()
+package omclient
@@ -0,0 +1,51 @@
+package omclient
+
+import (
+	"errors"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+// Error is returned when Ops Manager responds with an unexpected status,
+// e.g. 401 for an expired token or 422 when a request is not valid.
+//
+//	var omErr *omclient.Error
+//	if errors.As(err, &omErr) && omErr.StatusCode == http.StatusNotFound {
+//		// the product is not staged
+//	}
+type Error struct {
+	StatusCode int
+	Method     string
+	Endpoint   string
+	Body       []byte
+
+	// Errors are the errors of the body by the name of the field they are
+	// about, or by "base" when they are about the whole request.
+	Errors map[string][]string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func newError(err error) error {
+	var apiErr *api.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	return &Error{
+		StatusCode: apiErr.StatusCode,
+		Method:     apiErr.Method,
+		Endpoint:   apiErr.Endpoint,
+		Body:       apiErr.Body,
+		Errors:     apiErr.Errors,
+		err:        err,
+	}
+}
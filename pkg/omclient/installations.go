@@ -0,0 +1,85 @@
+package omclient
+
+import (
+	"time"
+
+	"github.com/pivotal-cf/om/api"
+)
+
+// Installation is a run of apply changes.
+type Installation struct {
+	ID int
+	// Status is running, succeeded or failed.
+	Status     string
+	UserName   string
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// ApplyChangesOptions selects what apply changes deploys.
+type ApplyChangesOptions struct {
+	// ProductNames limits the deployment to these products. All the
+	// products are deployed when empty.
+	ProductNames []string
+	// SkipDeployProducts only deploys the BOSH director.
+	SkipDeployProducts bool
+	// IgnoreWarnings applies changes even when the verifiers warn.
+	IgnoreWarnings bool
+}
+
+// Installations returns the installations, the most recent first.
+func (c *Client) Installations() ([]Installation, error) {
+	output, err := c.api.ListInstallations()
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	var installations []Installation
+	for _, installation := range output {
+		installations = append(installations, newInstallation(installation))
+	}
+
+	return installations, nil
+}
+
+// Installation returns the installation with the ID.
+func (c *Client) Installation(id int) (Installation, error) {
+	output, err := c.api.GetInstallation(id)
+	if err != nil {
+		return Installation{}, newError(err)
+	}
+
+	output.ID = id
+	return newInstallation(output), nil
+}
+
+// InstallationLogs returns the logs of the installation with the ID.
+func (c *Client) InstallationLogs(id int) (string, error) {
+	output, err := c.api.GetInstallationLogs(id)
+	if err != nil {
+		return "", newError(err)
+	}
+
+	return output.Logs, nil
+}
+
+// ApplyChanges starts an installation and returns it without waiting for
+// it to finish, see Installation to follow its status.
+func (c *Client) ApplyChanges(options ApplyChangesOptions) (Installation, error) {
+	output, err := c.api.CreateInstallation(options.IgnoreWarnings, !options.SkipDeployProducts, false, options.ProductNames, api.ApplyErrandChanges{})
+	if err != nil {
+		return Installation{}, newError(err)
+	}
+
+	return newInstallation(output), nil
+}
+
+func newInstallation(output api.InstallationsServiceOutput) Installation {
+	return Installation{
+		ID:         output.ID,
+		Status:     output.Status,
+		UserName:   output.UserName,
+		StartedAt:  output.StartedAt,
+		FinishedAt: output.FinishedAt,
+	}
+}
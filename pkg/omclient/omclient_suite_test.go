@@ -0,0 +1,13 @@
+package omclient_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOmclient(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Omclient Suite")
+}
@@ -0,0 +1,50 @@
+package omclient
+
+// Product is a product staged or deployed on Ops Manager.
+type Product struct {
+	GUID    string
+	Name    string
+	Version string
+}
+
+// StagedProducts returns the products staged on Ops Manager, including
+// the BOSH director.
+func (c *Client) StagedProducts() ([]Product, error) {
+	output, err := c.api.ListStagedProducts()
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	var products []Product
+	for _, product := range output.Products {
+		products = append(products, Product{GUID: product.GUID, Name: product.Type, Version: product.ProductVersion})
+	}
+
+	return products, nil
+}
+
+// StagedProduct returns the staged product with the name, e.g. cf.
+func (c *Client) StagedProduct(name string) (Product, error) {
+	output, err := c.api.GetStagedProductByName(name)
+	if err != nil {
+		return Product{}, newError(err)
+	}
+
+	return Product{GUID: output.Product.GUID, Name: output.Product.Type, Version: output.Product.ProductVersion}, nil
+}
+
+// DeployedProducts returns the products deployed by the last successful
+// installation, including the BOSH director.
+func (c *Client) DeployedProducts() ([]Product, error) {
+	output, err := c.api.ListDeployedProducts()
+	if err != nil {
+		return nil, newError(err)
+	}
+
+	var products []Product
+	for _, product := range output {
+		products = append(products, Product{GUID: product.GUID, Name: product.Type, Version: product.ProductVersion})
+	}
+
+	return products, nil
+}
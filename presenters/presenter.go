@@ -36,13 +36,15 @@ type FormattedPresenter interface {
 type MultiPresenter struct {
 	tablePresenter Presenter
 	jsonPresenter  Presenter
+	yamlPresenter  Presenter
 	format         string
 }
 
-func NewPresenter(tablePresenter Presenter, jsonPresenter Presenter) *MultiPresenter {
+func NewPresenter(tablePresenter Presenter, jsonPresenter Presenter, yamlPresenter Presenter) *MultiPresenter {
 	return &MultiPresenter{
 		tablePresenter: tablePresenter,
 		jsonPresenter:  jsonPresenter,
+		yamlPresenter:  yamlPresenter,
 		format:         "table",
 	}
 }
@@ -51,136 +53,73 @@ func (p *MultiPresenter) SetFormat(format string) {
 	p.format = format
 }
 
-func (p *MultiPresenter) PresentAvailableProducts(products []models.Product) {
+func (p *MultiPresenter) presenter() Presenter {
 	switch p.format {
 	case "json":
-		p.jsonPresenter.PresentAvailableProducts(products)
+		return p.jsonPresenter
+	case "yaml":
+		return p.yamlPresenter
 	default:
-		p.tablePresenter.PresentAvailableProducts(products)
+		return p.tablePresenter
 	}
 }
 
+func (p *MultiPresenter) PresentAvailableProducts(products []models.Product) {
+	p.presenter().PresentAvailableProducts(products)
+}
+
 func (p *MultiPresenter) PresentCertificateAuthorities(cas []api.CA) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCertificateAuthorities(cas)
-	default:
-		p.tablePresenter.PresentCertificateAuthorities(cas)
-	}
+	p.presenter().PresentCertificateAuthorities(cas)
 }
 
 func (p *MultiPresenter) PresentCertificateAuthority(ca api.CA) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCertificateAuthority(ca)
-	default:
-		p.tablePresenter.PresentCertificateAuthority(ca)
-	}
+	p.presenter().PresentCertificateAuthority(ca)
 }
 
 func (p *MultiPresenter) PresentGenerateCAResponse(car api.GenerateCAResponse) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentGenerateCAResponse(car)
-	default:
-		p.tablePresenter.PresentGenerateCAResponse(car)
-	}
+	p.presenter().PresentGenerateCAResponse(car)
 }
 
 func (p *MultiPresenter) PresentSSLCertificate(cert api.SSLCertificate) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentSSLCertificate(cert)
-	default:
-		p.tablePresenter.PresentSSLCertificate(cert)
-	}
+	p.presenter().PresentSSLCertificate(cert)
 }
 
 func (p *MultiPresenter) PresentCredentialReferences(ref []string) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCredentialReferences(ref)
-	default:
-		p.tablePresenter.PresentCredentialReferences(ref)
-	}
+	p.presenter().PresentCredentialReferences(ref)
 }
 
 func (p *MultiPresenter) PresentCredentials(creds map[string]string) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentCredentials(creds)
-	default:
-		p.tablePresenter.PresentCredentials(creds)
-	}
+	p.presenter().PresentCredentials(creds)
 }
 
 func (p *MultiPresenter) PresentDeployedProducts(products []api.DiagnosticProduct) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentDeployedProducts(products)
-	default:
-		p.tablePresenter.PresentDeployedProducts(products)
-	}
+	p.presenter().PresentDeployedProducts(products)
 }
 
 func (p *MultiPresenter) PresentErrands(errands []models.Errand) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentErrands(errands)
-	default:
-		p.tablePresenter.PresentErrands(errands)
-	}
+	p.presenter().PresentErrands(errands)
 }
 
 func (p *MultiPresenter) PresentInstallations(i []models.Installation) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentInstallations(i)
-	default:
-		p.tablePresenter.PresentInstallations(i)
-	}
+	p.presenter().PresentInstallations(i)
 }
+
 func (p *MultiPresenter) PresentPendingChanges(c api.PendingChangesOutput) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentPendingChanges(c)
-	default:
-		p.tablePresenter.PresentPendingChanges(c)
-	}
+	p.presenter().PresentPendingChanges(c)
 }
 
 func (p *MultiPresenter) PresentProducts(products models.ProductsVersionsDisplay) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentProducts(products)
-	default:
-		p.tablePresenter.PresentProducts(products)
-	}
+	p.presenter().PresentProducts(products)
 }
 
 func (p *MultiPresenter) PresentStagedProducts(products []api.DiagnosticProduct) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentStagedProducts(products)
-	default:
-		p.tablePresenter.PresentStagedProducts(products)
-	}
+	p.presenter().PresentStagedProducts(products)
 }
 
 func (p *MultiPresenter) PresentDiagnosticReport(report api.DiagnosticReport) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentDiagnosticReport(report)
-	default:
-		p.tablePresenter.PresentDiagnosticReport(report)
-	}
+	p.presenter().PresentDiagnosticReport(report)
 }
 
 func (p *MultiPresenter) PresentLicensedProducts(products []api.ExpiringLicenseOutput) {
-	switch p.format {
-	case "json":
-		p.jsonPresenter.PresentLicensedProducts(products)
-	default:
-		p.tablePresenter.PresentLicensedProducts(products)
-	}
+	p.presenter().PresentLicensedProducts(products)
 }
@@ -158,18 +158,44 @@ func (t TablePresenter) PresentInstallations(installations []models.Installation
 func (t TablePresenter) PresentPendingChanges(output api.PendingChangesOutput) {
 	pendingChanges := output.ChangeList
 
-	t.tableWriter.SetHeader([]string{"PRODUCT", "ACTION", "ERRANDS"})
+	withReasons := false
+	for _, change := range pendingChanges {
+		if len(change.Reasons) > 0 {
+			withReasons = true
+		}
+	}
+
+	header := []string{"PRODUCT", "ACTION", "ERRANDS"}
+	if withReasons {
+		header = append(header, "REASONS")
+	}
+	t.tableWriter.SetHeader(header)
 
 	for _, change := range pendingChanges {
-		if len(change.Errands) == 0 {
-			t.tableWriter.Append([]string{change.GUID, change.Action, ""})
+		rows := len(change.Errands)
+		if len(change.Reasons) > rows {
+			rows = len(change.Reasons)
 		}
-		for i, errand := range change.Errands {
+		if rows == 0 {
+			rows = 1
+		}
+
+		for i := 0; i < rows; i++ {
+			row := []string{"", "", ""}
 			if i == 0 {
-				t.tableWriter.Append([]string{change.GUID, change.Action, errand.Name})
-			} else {
-				t.tableWriter.Append([]string{"", "", errand.Name})
+				row[0], row[1] = change.GUID, change.Action
+			}
+			if i < len(change.Errands) {
+				row[2] = change.Errands[i].Name
+			}
+			if withReasons {
+				reason := ""
+				if i < len(change.Reasons) {
+					reason = change.Reasons[i]
+				}
+				row = append(row, reason)
 			}
+			t.tableWriter.Append(row)
 		}
 	}
 
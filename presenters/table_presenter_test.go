@@ -299,6 +299,18 @@ var _ = Describe("TablePresenter", func() {
 			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"", "", "some-errand-2"}))
 			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"some-product-without-errand", "install", ""}))
 		})
+
+		It("adds a column with the reasons of the changes when they are known", func() {
+			pendingChanges.ChangeList[1].Reasons = []string{"not deployed yet (version 1.0.0)"}
+			tablePresenter.PresentPendingChanges(pendingChanges)
+
+			Expect(fakeTableWriter.SetHeaderArgsForCall(0)).To(Equal([]string{"PRODUCT", "ACTION", "ERRANDS", "REASONS"}))
+
+			Expect(fakeTableWriter.AppendCallCount()).To(Equal(3))
+			Expect(fakeTableWriter.AppendArgsForCall(0)).To(Equal([]string{"some-product", "update", "some-errand", ""}))
+			Expect(fakeTableWriter.AppendArgsForCall(1)).To(Equal([]string{"", "", "some-errand-2", ""}))
+			Expect(fakeTableWriter.AppendArgsForCall(2)).To(Equal([]string{"some-product-without-errand", "install", "", "not deployed yet (version 1.0.0)"}))
+		})
 	})
 
 	Describe("PresentProducts", func() {
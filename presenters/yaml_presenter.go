@@ -0,0 +1,95 @@
+package presenters
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
+)
+
+// YAMLPresenter renders the same documents as the JSONPresenter as YAML,
+// so keys are identical regardless of the format that was chosen.
+type YAMLPresenter struct {
+	stdout io.Writer
+}
+
+func NewYAMLPresenter(stdout io.Writer) YAMLPresenter {
+	return YAMLPresenter{
+		stdout: stdout,
+	}
+}
+
+func (y YAMLPresenter) PresentAvailableProducts(products []models.Product) {
+	y.present(func(j JSONPresenter) { j.PresentAvailableProducts(products) })
+}
+
+func (y YAMLPresenter) PresentCertificateAuthorities(certificateAuthorities []api.CA) {
+	y.present(func(j JSONPresenter) { j.PresentCertificateAuthorities(certificateAuthorities) })
+}
+
+func (y YAMLPresenter) PresentCertificateAuthority(certificateAuthority api.CA) {
+	y.present(func(j JSONPresenter) { j.PresentCertificateAuthority(certificateAuthority) })
+}
+
+func (y YAMLPresenter) PresentGenerateCAResponse(gcar api.GenerateCAResponse) {
+	y.present(func(j JSONPresenter) { j.PresentGenerateCAResponse(gcar) })
+}
+
+func (y YAMLPresenter) PresentSSLCertificate(certificate api.SSLCertificate) {
+	y.present(func(j JSONPresenter) { j.PresentSSLCertificate(certificate) })
+}
+
+func (y YAMLPresenter) PresentCredentialReferences(credentialReferences []string) {
+	y.present(func(j JSONPresenter) { j.PresentCredentialReferences(credentialReferences) })
+}
+
+func (y YAMLPresenter) PresentCredentials(credentials map[string]string) {
+	y.present(func(j JSONPresenter) { j.PresentCredentials(credentials) })
+}
+
+func (y YAMLPresenter) PresentDeployedProducts(deployedProducts []api.DiagnosticProduct) {
+	y.present(func(j JSONPresenter) { j.PresentDeployedProducts(deployedProducts) })
+}
+
+func (y YAMLPresenter) PresentErrands(errands []models.Errand) {
+	y.present(func(j JSONPresenter) { j.PresentErrands(errands) })
+}
+
+func (y YAMLPresenter) PresentInstallations(installations []models.Installation) {
+	y.present(func(j JSONPresenter) { j.PresentInstallations(installations) })
+}
+
+func (y YAMLPresenter) PresentPendingChanges(pendingChangesOutput api.PendingChangesOutput) {
+	y.present(func(j JSONPresenter) { j.PresentPendingChanges(pendingChangesOutput) })
+}
+
+func (y YAMLPresenter) PresentProducts(products models.ProductsVersionsDisplay) {
+	y.present(func(j JSONPresenter) { j.PresentProducts(products) })
+}
+
+func (y YAMLPresenter) PresentStagedProducts(stagedProducts []api.DiagnosticProduct) {
+	y.present(func(j JSONPresenter) { j.PresentStagedProducts(stagedProducts) })
+}
+
+func (y YAMLPresenter) PresentDiagnosticReport(report api.DiagnosticReport) {
+	y.present(func(j JSONPresenter) { j.PresentDiagnosticReport(report) })
+}
+
+func (y YAMLPresenter) PresentLicensedProducts(products []api.ExpiringLicenseOutput) {
+	y.present(func(j JSONPresenter) { j.PresentLicensedProducts(products) })
+}
+
+func (y YAMLPresenter) present(render func(JSONPresenter)) {
+	var buffer bytes.Buffer
+	render(NewJSONPresenter(&buffer))
+
+	contents, err := yaml.JSONToYAML(buffer.Bytes())
+	if err != nil {
+		// the output was not JSON (e.g. a plain text report), print it as is
+		contents = buffer.Bytes()
+	}
+
+	_, _ = y.stdout.Write(contents)
+}
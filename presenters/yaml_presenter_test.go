@@ -0,0 +1,83 @@
+package presenters_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/pivotal-cf/om/api"
+	"github.com/pivotal-cf/om/models"
+	"github.com/pivotal-cf/om/presenters"
+)
+
+var _ = Describe("YAMLPresenter", func() {
+	var (
+		stdout        *gbytes.Buffer
+		yamlPresenter presenters.YAMLPresenter
+	)
+
+	BeforeEach(func() {
+		stdout = gbytes.NewBuffer()
+		yamlPresenter = presenters.NewYAMLPresenter(stdout)
+	})
+
+	It("uses the same keys as the json output", func() {
+		yamlPresenter.PresentStagedProducts([]api.DiagnosticProduct{
+			{Name: "some-product", Version: "1.2.3"},
+		})
+
+		Expect(string(stdout.Contents())).To(MatchYAML(`[{name: some-product, version: "1.2.3"}]`))
+	})
+
+	It("presents credentials", func() {
+		yamlPresenter.PresentCredentials(map[string]string{"identity": "admin", "password": "secret"})
+
+		Expect(string(stdout.Contents())).To(MatchYAML(`{identity: admin, password: secret}`))
+	})
+
+	It("converts json reports", func() {
+		yamlPresenter.PresentPendingChanges(api.PendingChangesOutput{
+			FullReport: `{"product_changes": [{"guid": "some-guid", "action": "install"}]}`,
+		})
+
+		Expect(string(stdout.Contents())).To(MatchYAML(`{product_changes: [{guid: some-guid, action: install}]}`))
+	})
+
+	It("prints an empty list when there is nothing to present", func() {
+		yamlPresenter.PresentErrands([]models.Errand{})
+
+		Expect(string(stdout.Contents())).To(MatchYAML(`[]`))
+	})
+})
+
+var _ = Describe("MultiPresenter", func() {
+	var (
+		tableOutput, jsonOutput, yamlOutput *gbytes.Buffer
+		presenter                           *presenters.MultiPresenter
+	)
+
+	BeforeEach(func() {
+		tableOutput = gbytes.NewBuffer()
+		jsonOutput = gbytes.NewBuffer()
+		yamlOutput = gbytes.NewBuffer()
+
+		presenter = presenters.NewPresenter(
+			presenters.NewJSONPresenter(tableOutput),
+			presenters.NewJSONPresenter(jsonOutput),
+			presenters.NewYAMLPresenter(yamlOutput),
+		)
+	})
+
+	DescribeTable("presents with the selected format",
+		func(format string, output func() *gbytes.Buffer) {
+			presenter.SetFormat(format)
+			presenter.PresentCredentialReferences([]string{".properties.some-credential"})
+
+			Expect(output().Contents()).ToNot(BeEmpty())
+			Expect(len(tableOutput.Contents()) + len(jsonOutput.Contents()) + len(yamlOutput.Contents())).To(Equal(len(output().Contents())))
+		},
+		Entry("table", "table", func() *gbytes.Buffer { return tableOutput }),
+		Entry("json", "json", func() *gbytes.Buffer { return jsonOutput }),
+		Entry("yaml", "yaml", func() *gbytes.Buffer { return yamlOutput }),
+	)
+})
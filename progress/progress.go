@@ -0,0 +1,243 @@
+// Package progress shows the progress of uploads, downloads and
+// installations, as bars for people or as JSON lines for CI dashboards, or
+// hides it.
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cheggaaa/pb/v3"
+)
+
+// Event is a line written by a JSON writer. Transfers report Bytes, Total
+// and Rate; installations report their Status and ID.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Phase string    `json:"phase"`
+	State string    `json:"state"`
+
+	// Bytes is the number of bytes transferred, of Total when it is known.
+	Bytes int64 `json:"bytes,omitempty"`
+	Total int64 `json:"total,omitempty"`
+	// Rate is the average number of bytes per second since the start.
+	Rate int64 `json:"rate,omitempty"`
+
+	ID      int    `json:"id,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Elapsed int64  `json:"elapsed,omitempty"`
+}
+
+// reportInterval is the least time between the events of a transfer
+const reportInterval = time.Second
+
+type jsonWriter struct {
+	logs   io.Writer
+	events io.Writer
+	lock   *sync.Mutex
+}
+
+// NewJSONWriter returns a writer that makes the bars and installations of
+// this package write their progress to events as JSON lines. Anything else
+// written to it, such as log messages, goes to logs unchanged.
+func NewJSONWriter(logs io.Writer, events io.Writer) io.Writer {
+	return jsonWriter{
+		logs:   logs,
+		events: events,
+		lock:   &sync.Mutex{},
+	}
+}
+
+func (w jsonWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.logs.Write(p)
+}
+
+func (w jsonWriter) report(event Event) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	event.Time = time.Now().UTC()
+	contents, _ := json.Marshal(event)
+	_, _ = w.events.Write(append(contents, '\n'))
+}
+
+type quietWriter struct {
+	logs io.Writer
+}
+
+// NewQuietWriter returns a writer that the bars of this package draw nothing
+// to. Anything else written to it goes to logs unchanged.
+func NewQuietWriter(logs io.Writer) io.Writer {
+	return quietWriter{logs: logs}
+}
+
+func (w quietWriter) Write(p []byte) (int, error) {
+	return w.logs.Write(p)
+}
+
+// Report writes the event when writer was returned by NewJSONWriter and drops
+// it otherwise, the bars already show it.
+func Report(writer io.Writer, event Event) {
+	if w, ok := writer.(jsonWriter); ok {
+		w.report(event)
+	}
+}
+
+// template is the pb bar, with the throughput and the estimated time left.
+const template pb.ProgressBarTemplate = `{{counters . }} {{bar . }} {{percent . }} {{speed . "%s/s" "? B/s"}} {{rtime . "ETA %s"}}`
+
+// Bar shows the progress of a transfer of the phase, e.g. upload or
+// download. Written to a JSON writer, it reports events at most every second
+// and when it finishes, to a quiet writer it shows nothing, otherwise it
+// draws a pb bar.
+type Bar struct {
+	text  *pb.ProgressBar
+	quiet bool
+
+	events   jsonWriter
+	phase    string
+	current  atomic.Int64
+	total    atomic.Int64
+	started  time.Time
+	reported atomic.Int64
+	finished atomic.Bool
+}
+
+// NewBar returns a bar of bytes for writer.
+func NewBar(writer io.Writer, phase string) *Bar {
+	switch w := writer.(type) {
+	case jsonWriter:
+		return &Bar{events: w, phase: phase}
+	case quietWriter:
+		return &Bar{quiet: true, phase: phase}
+	}
+
+	text := template.New(0)
+	text.SetWriter(writer)
+	text.Set(pb.Bytes, true)
+
+	return &Bar{text: text, phase: phase}
+}
+
+// SetMaxWidth limits the width of the pb bar.
+func (b *Bar) SetMaxWidth(width int) *Bar {
+	if b.text != nil {
+		b.text.SetMaxWidth(width)
+	}
+
+	return b
+}
+
+func (b *Bar) SetTotal(total int64) *Bar {
+	if b.text != nil {
+		b.text.SetTotal(total)
+		return b
+	}
+
+	b.total.Store(total)
+	return b
+}
+
+func (b *Bar) SetCurrent(current int64) *Bar {
+	if b.text != nil {
+		b.text.SetCurrent(current)
+		return b
+	}
+
+	b.current.Store(current)
+	return b
+}
+
+func (b *Bar) Start() *Bar {
+	if b.text != nil {
+		b.text.Start()
+		return b
+	}
+
+	b.started = time.Now()
+	b.reported.Store(b.started.UnixNano())
+	if !b.quiet {
+		b.events.report(b.event("started"))
+	}
+
+	return b
+}
+
+// Finish stops the bar, the first call reports that the transfer finished.
+func (b *Bar) Finish() {
+	if b.text != nil {
+		b.text.Finish()
+		return
+	}
+
+	if b.finished.CompareAndSwap(false, true) && !b.quiet {
+		b.events.report(b.event("finished"))
+	}
+}
+
+// NewProxyReader counts the bytes read from reader. Closing the proxy
+// closes reader, when it is a io.Closer, and finishes the bar.
+func (b *Bar) NewProxyReader(reader io.Reader) io.ReadCloser {
+	if b.text != nil {
+		return b.text.NewProxyReader(reader)
+	}
+
+	return &proxyReader{reader: reader, bar: b}
+}
+
+func (b *Bar) add(n int) {
+	b.current.Add(int64(n))
+
+	if b.quiet {
+		return
+	}
+
+	now := time.Now().UnixNano()
+	last := b.reported.Load()
+	if time.Duration(now-last) >= reportInterval && b.reported.CompareAndSwap(last, now) {
+		b.events.report(b.event("transferring"))
+	}
+}
+
+func (b *Bar) event(state string) Event {
+	event := Event{
+		Phase: b.phase,
+		State: state,
+		Bytes: b.current.Load(),
+		Total: b.total.Load(),
+	}
+
+	if elapsed := time.Since(b.started).Seconds(); elapsed > 0 && !b.started.IsZero() {
+		event.Rate = int64(float64(event.Bytes) / elapsed)
+	}
+
+	return event
+}
+
+type proxyReader struct {
+	reader io.Reader
+	bar    *Bar
+}
+
+func (r *proxyReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.bar.add(n)
+
+	return n, err
+}
+
+func (r *proxyReader) Close() error {
+	r.bar.Finish()
+
+	if closer, ok := r.reader.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package progress_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+
+	"github.com/pivotal-cf/om/progress"
+)
+
+var _ = Describe("Progress", func() {
+	var logs, events *bytes.Buffer
+
+	BeforeEach(func() {
+		logs = &bytes.Buffer{}
+		events = &bytes.Buffer{}
+	})
+
+	readEvents := func() []progress.Event {
+		var read []progress.Event
+		for _, line := range strings.Split(strings.TrimSpace(events.String()), "\n") {
+			var event progress.Event
+			Expect(json.Unmarshal([]byte(line), &event)).To(Succeed())
+			read = append(read, event)
+		}
+		return read
+	}
+
+	It("reports the start and the end of a transfer as JSON lines", func() {
+		writer := progress.NewJSONWriter(logs, events)
+
+		bar := progress.NewBar(writer, "upload").SetMaxWidth(80)
+		bar.SetTotal(11)
+		bar.Start()
+
+		reader := bar.NewProxyReader(strings.NewReader("some-upload"))
+		contents, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-upload"))
+		Expect(reader.Close()).To(Succeed())
+		bar.Finish()
+
+		reported := readEvents()
+		Expect(reported).To(HaveLen(2))
+		Expect(reported[0]).To(MatchFields(IgnoreExtras, Fields{
+			"Phase": Equal("upload"),
+			"State": Equal("started"),
+			"Total": Equal(int64(11)),
+		}))
+		Expect(reported[1]).To(MatchFields(IgnoreExtras, Fields{
+			"Phase": Equal("upload"),
+			"State": Equal("finished"),
+			"Bytes": Equal(int64(11)),
+			"Total": Equal(int64(11)),
+		}))
+		Expect(reported[1].Rate).To(BeNumerically(">", 0))
+		Expect(reported[1].Time).ToNot(BeZero())
+	})
+
+	It("passes other writes on to the logs", func() {
+		writer := progress.NewJSONWriter(logs, events)
+
+		_, err := writer.Write([]byte("some log message\n"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(logs.String()).To(Equal("some log message\n"))
+		Expect(events.Len()).To(Equal(0))
+	})
+
+	It("reports the events of installations to JSON writers only", func() {
+		progress.Report(logs, progress.Event{Phase: "installation", State: "running", ID: 3})
+		Expect(logs.Len()).To(Equal(0))
+
+		progress.Report(progress.NewJSONWriter(logs, events), progress.Event{Phase: "installation", State: "running", ID: 3, Status: "running"})
+		Expect(readEvents()).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Phase":  Equal("installation"),
+			"State":  Equal("running"),
+			"ID":     Equal(3),
+			"Status": Equal("running"),
+		})))
+	})
+
+	It("draws nothing to quiet writers", func() {
+		writer := progress.NewQuietWriter(logs)
+
+		bar := progress.NewBar(writer, "download")
+		bar.SetTotal(13)
+		bar.Start()
+
+		reader := bar.NewProxyReader(strings.NewReader("some-download"))
+		contents, err := io.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("some-download"))
+		Expect(reader.Close()).To(Succeed())
+		bar.Finish()
+
+		progress.Report(writer, progress.Event{Phase: "installation", State: "running"})
+
+		_, err = writer.Write([]byte("some log message\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs.String()).To(Equal("some log message\n"))
+	})
+
+	It("draws a bar to other writers", func() {
+		bar := progress.NewBar(logs, "download")
+		bar.SetTotal(13)
+		bar.Start()
+
+		_, err := io.ReadAll(bar.NewProxyReader(strings.NewReader("some-download")))
+		Expect(err).ToNot(HaveOccurred())
+		bar.Finish()
+
+		Expect(logs.String()).To(ContainSubstring("13 B"))
+		Expect(logs.String()).To(MatchRegexp(`B/s`))
+		Expect(events.Len()).To(Equal(0))
+	})
+})
@@ -0,0 +1,28 @@
+package renderers
+
+import (
+	"fmt"
+	"strings"
+)
+
+type dotenv struct {
+}
+
+// NewDotenv creates a new renderer for .env files
+func NewDotenv() Renderer {
+	return &dotenv{}
+}
+
+var dotenvEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (renderer *dotenv) RenderEnvironmentVariable(variable string, value string) string {
+	return fmt.Sprintf("%s=\"%s\"", variable, dotenvEscaper.Replace(value))
+}
+
+func (renderer *dotenv) RenderUnsetVariable(variable string) string {
+	return fmt.Sprintf("%s=", variable)
+}
+
+func (renderer *dotenv) Type() string {
+	return ShellTypeDotenv
+}
@@ -0,0 +1,39 @@
+package renderers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/renderers"
+)
+
+var _ = Describe(renderers.ShellTypeDotenv, func() {
+	var (
+		renderer renderers.Renderer
+	)
+
+	BeforeEach(func() {
+		renderer = renderers.NewDotenv()
+	})
+
+	Describe("RenderEnvironmentVariable", func() {
+		Context("WhenSingleLine", func() {
+			It("prints a quoted assignment", func() {
+				result := renderer.RenderEnvironmentVariable("KEY", `va"l\ue`)
+				Expect(result).To(Equal(`KEY="va\"l\\ue"`))
+			})
+		})
+		Context("WhenMultiLine", func() {
+			It("escapes the newlines", func() {
+				result := renderer.RenderEnvironmentVariable("KEY", "1\n2\n3\n4\n")
+				Expect(result).To(Equal(`KEY="1\n2\n3\n4\n"`))
+			})
+		})
+	})
+
+	Describe("Type", func() {
+		It("is dotenv", func() {
+			Expect(renderer.Type()).To(Equal(renderers.ShellTypeDotenv))
+		})
+	})
+})
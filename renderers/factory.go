@@ -35,6 +35,8 @@ func (f *factory) createFromType(shellType string) (Renderer, error) {
 		return NewPowershell(), nil
 	case ShellTypePosix:
 		return NewPosix(), nil
+	case ShellTypeDotenv:
+		return NewDotenv(), nil
 	default:
 		return nil, fmt.Errorf("unrecognized type '%s'", shellType)
 	}
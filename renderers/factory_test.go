@@ -39,5 +39,18 @@ var _ = Describe("Factory", func() {
 				Expect(renderer.Type()).To(Equal(renderers.ShellTypePosix))
 			})
 		})
+
+		Context("WhenTypeGiven", func() {
+			It("creates the renderer of the type", func() {
+				factory = renderers.NewFactory(&fakes.EnvGetter{})
+
+				renderer, err := factory.Create(renderers.ShellTypeDotenv)
+				Expect(err).To(BeNil())
+				Expect(renderer.Type()).To(Equal(renderers.ShellTypeDotenv))
+
+				_, err = factory.Create("fish")
+				Expect(err).To(MatchError("unrecognized type 'fish'"))
+			})
+		})
 	})
 })
@@ -3,4 +3,5 @@ package renderers
 const (
 	ShellTypePowershell = "powershell"
 	ShellTypePosix      = "posix"
+	ShellTypeDotenv     = "dotenv"
 )
@@ -0,0 +1,70 @@
+// Package stepstate keeps the steps that a command of several steps, such as
+// an upgrade of Ops Manager or the rotation of its certificate authority,
+// has completed in a state file, so that re-running the command after a
+// failure continues where the earlier run stopped.
+package stepstate
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v2"
+)
+
+// State is embedded, inline, in the state of a command.
+type State struct {
+	Completed []string `yaml:"completed"`
+}
+
+func (s State) Done(step string) bool {
+	return slices.Contains(s.Completed, step)
+}
+
+func (s *State) Complete(step string) {
+	s.Completed = append(s.Completed, step)
+}
+
+// Read reads the state file into state. A missing state file leaves state
+// as it is.
+func Read(path string, state interface{}) error {
+	contents, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read state file (%s): %s", path, err)
+	}
+
+	err = yaml.Unmarshal(contents, state)
+	if err != nil {
+		return fmt.Errorf("could not parse state file (%s): %s", path, err)
+	}
+
+	return nil
+}
+
+func Write(path string, state interface{}) error {
+	contents, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, contents, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write state file (%s): %s", path, err)
+	}
+
+	return nil
+}
+
+// Remove removes the state file once every step has been completed, so
+// that it is not mistaken for the state of the next run.
+func Remove(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not remove state file (%s): %s", path, err)
+	}
+
+	return nil
+}
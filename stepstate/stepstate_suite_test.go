@@ -0,0 +1,13 @@
+package stepstate_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestStepstate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "stepstate")
+}
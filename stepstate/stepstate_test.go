@@ -0,0 +1,63 @@
+package stepstate_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/pivotal-cf/om/stepstate"
+)
+
+type someState struct {
+	Name            string `yaml:"name"`
+	stepstate.State `yaml:",inline"`
+}
+
+var _ = Describe("stepstate", func() {
+	var stateFile string
+
+	BeforeEach(func() {
+		stateFile = filepath.Join(GinkgoT().TempDir(), "state.yml")
+	})
+
+	It("writes and reads the completed steps", func() {
+		state := someState{Name: "some-name"}
+		state.Complete("first")
+		state.Complete("second")
+		Expect(stepstate.Write(stateFile, state)).To(Succeed())
+
+		contents, err := os.ReadFile(stateFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(MatchYAML("name: some-name\ncompleted: [first, second]\n"))
+
+		var read someState
+		Expect(stepstate.Read(stateFile, &read)).To(Succeed())
+		Expect(read).To(Equal(state))
+		Expect(read.Done("first")).To(BeTrue())
+		Expect(read.Done("third")).To(BeFalse())
+	})
+
+	It("leaves the state as it is without a state file", func() {
+		state := someState{Name: "some-name"}
+		Expect(stepstate.Read(stateFile, &state)).To(Succeed())
+		Expect(state).To(Equal(someState{Name: "some-name"}))
+	})
+
+	It("returns an error for a state file that cannot be parsed", func() {
+		Expect(os.WriteFile(stateFile, []byte("completed: {"), 0644)).To(Succeed())
+
+		var state someState
+		err := stepstate.Read(stateFile, &state)
+		Expect(err).To(MatchError(ContainSubstring("could not parse state file (" + stateFile + ")")))
+	})
+
+	It("removes the state file", func() {
+		Expect(stepstate.Write(stateFile, someState{})).To(Succeed())
+
+		Expect(stepstate.Remove(stateFile)).To(Succeed())
+		Expect(stateFile).ToNot(BeAnExistingFile())
+		Expect(stepstate.Remove(stateFile)).To(Succeed())
+	})
+})
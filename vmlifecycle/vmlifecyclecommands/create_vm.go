@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/pivotal-cf/om/vmlifecycle/vmmanagers"
 )
 
@@ -20,6 +22,8 @@ type CreateVM struct {
 	Config      string   `long:"config"     description:"The YAML configuration file" required:"true"`
 	VarsFile    []string `long:"vars-file"  description:"Load variables from a YAML file for interpolation into config"`
 	VarsEnv     []string `long:"vars-env"   env:"OM_VARS_ENV"  description:"load vars from environment variables by specifying a prefix (e.g.: 'MY' to load MY_var=value)"`
+	VarsStore   []string `long:"vars-store" description:"Resolve variables from a credential store (credhub://, vault://, aws-sm:// or aws-ssm:// URI)"`
+	DryRun      bool     `long:"dry-run"    description:"Print the resources that would be created without changing anything. Fails if the existing VM has drifted from the config"`
 }
 
 func NewCreateVMCommand(stdout, stderr io.Writer, initService initCreateFunc) CreateVM {
@@ -36,7 +40,7 @@ func (c *CreateVM) Execute(args []string) error {
 		return err
 	}
 
-	config, state, err := loadConfigAndState(c.Config, c.StateFile, false, c.VarsEnv, c.VarsFile)
+	config, state, err := loadConfigAndState(c.Config, c.StateFile, false, c.VarsEnv, c.VarsFile, c.VarsStore)
 	if err != nil {
 		return err
 	}
@@ -46,6 +50,24 @@ func (c *CreateVM) Execute(args []string) error {
 		return fmt.Errorf("failed to set p-automator: %s", err)
 	}
 
+	if c.DryRun {
+		plan, err := vmManagerService.PlanCreateVM()
+		if err != nil {
+			return fmt.Errorf("could not plan the VM creation: %s", err)
+		}
+
+		err = writePlan(c.stdout, plan)
+		if err != nil {
+			return err
+		}
+
+		if plan.HasDrift() {
+			return fmt.Errorf("the VM %s has drifted from the config", plan.VMID)
+		}
+
+		return nil
+	}
+
 	status, newState, err := vmManagerService.CreateVM()
 	switch status {
 	case vmmanagers.Success:
@@ -74,6 +96,16 @@ func writeStatefile(filename string, info vmmanagers.StateInfo) error {
 	return os.WriteFile(filename, []byte(fmt.Sprintf("iaas: %s\nvm_id: %s", info.IAAS, info.ID)), 0644)
 }
 
+func writePlan(w io.Writer, plan vmmanagers.Plan) error {
+	contents, err := yaml.Marshal(plan)
+	if err != nil {
+		return fmt.Errorf("could not render the plan: %s", err)
+	}
+
+	_, err = w.Write(contents)
+	return err
+}
+
 func (c *CreateVM) checkImageExists() (err error) {
 	_, err = os.Stat(c.ImageFile)
 	if err != nil {
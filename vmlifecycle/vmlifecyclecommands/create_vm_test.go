@@ -166,6 +166,65 @@ opsman-configuration:
 		})
 	})
 
+	When("--dry-run is set", func() {
+		It("prints the plan without creating the VM or touching the state file", func() {
+			service := &fakes.CreateVMService{}
+			service.PlanCreateVMReturns(vmmanagers.Plan{
+				IAAS:   "gcp",
+				Action: vmmanagers.PlanCreate,
+				Resources: []vmmanagers.PlannedResource{{
+					Action:     vmmanagers.PlanCreate,
+					Type:       "instance",
+					Name:       "some-name",
+					Properties: map[string]string{"public_ip": "1.2.3.4"},
+				}},
+			}, nil)
+			command = createCommand(outWriter, errWriter, service, configStr, "", "", "")
+			command.DryRun = true
+
+			err := command.Execute([]string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(service.CreateVMCallCount()).To(Equal(0))
+			Expect(outWriter.Contents()).To(MatchYAML(`
+iaas: gcp
+action: create
+resources:
+- action: create
+  type: instance
+  name: some-name
+  properties:
+    public_ip: 1.2.3.4
+`))
+			Expect(readFile(command.StateFile)).To(BeEmpty())
+		})
+
+		It("fails when the existing VM has drifted from the config", func() {
+			service := &fakes.CreateVMService{}
+			service.PlanCreateVMReturns(vmmanagers.Plan{
+				IAAS:   "gcp",
+				VMID:   "vm-id",
+				Action: vmmanagers.PlanDrifted,
+				Drift:  []vmmanagers.PropertyDrift{{Property: "machine_type", Expected: "custom-2-8192", Actual: "custom-4-16384"}},
+			}, nil)
+			command = createCommand(outWriter, errWriter, service, configStr, "", "", "iaas: gcp\nvm_id: vm-id")
+			command.DryRun = true
+
+			err := command.Execute([]string{})
+			Expect(err).To(MatchError("the VM vm-id has drifted from the config"))
+			Expect(outWriter).To(gbytes.Say("expected: custom-2-8192"))
+		})
+
+		It("returns an error when the plan fails", func() {
+			service := &fakes.CreateVMService{}
+			service.PlanCreateVMReturns(vmmanagers.Plan{}, errors.New("some error"))
+			command = createCommand(outWriter, errWriter, service, configStr, "", "", "")
+			command.DryRun = true
+
+			err := command.Execute([]string{})
+			Expect(err).To(MatchError("could not plan the VM creation: some error"))
+		})
+	})
+
 	When("using interpolation features", func() {
 		validConfig := `---
 opsman-configuration:
@@ -25,6 +25,8 @@ type DeleteVM struct {
 	Config    string   `long:"config"     description:"The YAML configuration file" required:"true"`
 	VarsFile  []string `long:"vars-file"  description:"Load variables from a YAML file for interpolation into config"`
 	VarsEnv   []string `long:"vars-env"   env:"OM_VARS_ENV"  description:"load vars from environment variables by specifying a prefix (e.g.: 'MY' to load MY_var=value)"`
+	VarsStore []string `long:"vars-store" description:"Resolve variables from a credential store (credhub://, vault://, aws-sm:// or aws-ssm:// URI)"`
+	DryRun    bool     `long:"dry-run"    description:"Print the resources that would be deleted without changing anything"`
 }
 
 func NewDeleteVMCommand(stdout, stderr io.Writer, initService initDeleteFunc) DeleteVM {
@@ -36,7 +38,7 @@ func NewDeleteVMCommand(stdout, stderr io.Writer, initService initDeleteFunc) De
 }
 
 func (c DeleteVM) Execute(args []string) error {
-	config, state, err := loadConfigAndState(c.Config, c.StateFile, true, c.VarsEnv, c.VarsFile)
+	config, state, err := loadConfigAndState(c.Config, c.StateFile, true, c.VarsEnv, c.VarsFile, c.VarsStore)
 	if err != nil {
 		return err
 	}
@@ -51,6 +53,15 @@ func (c DeleteVM) Execute(args []string) error {
 		return nil
 	}
 
+	if c.DryRun {
+		plan, err := vmManagerService.PlanDeleteVM()
+		if err != nil {
+			return fmt.Errorf("could not plan the VM deletion: %s", err)
+		}
+
+		return writePlan(c.stdout, plan)
+	}
+
 	err = vmManagerService.DeleteVM()
 	if err != nil {
 		return fmt.Errorf("delete vm failed, some resources may have not been properly removed: %s", err)
@@ -61,11 +72,12 @@ func (c DeleteVM) Execute(args []string) error {
 	return os.WriteFile(c.StateFile, []byte(fmt.Sprintf(`iaas: %s`, state.IAAS)), 0644)
 }
 
-func loadConfigAndState(configFilename string, stateFilename string, useStateFileError bool, varsEnv []string, varsFile []string) (*vmmanagers.OpsmanConfigFilePayload, vmmanagers.StateInfo, error) {
+func loadConfigAndState(configFilename string, stateFilename string, useStateFileError bool, varsEnv []string, varsFile []string, varsStore []string) (*vmmanagers.OpsmanConfigFilePayload, vmmanagers.StateInfo, error) {
 	configContent, err := interpolateConfig(
 		configFilename,
 		varsFile,
 		varsEnv,
+		varsStore,
 	)
 	if err != nil {
 		return nil, vmmanagers.StateInfo{}, err
@@ -137,12 +149,14 @@ func interpolateConfig(
 	config string,
 	varsFile []string,
 	varsEnv []string,
+	varsStore []string,
 ) ([]byte, error) {
 	configContents, err := interpolate.Execute(interpolate.Options{
 		TemplateFile:  config,
 		VarsFiles:     varsFile,
 		EnvironFunc:   os.Environ,
 		VarsEnvs:      varsEnv,
+		VarsStores:    varsStore,
 		ExpectAllKeys: true,
 	})
 	if err != nil {
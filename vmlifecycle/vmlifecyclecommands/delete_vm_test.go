@@ -92,6 +92,35 @@ opsman-configuration:
 		})
 	})
 
+	When("--dry-run is set", func() {
+		It("prints the plan without deleting the VM", func() {
+			fakeService := &fakes.DeleteVMService{}
+			fakeService.PlanDeleteVMReturns(vmmanagers.Plan{
+				IAAS:      "gcp",
+				VMID:      "some_id",
+				Action:    vmmanagers.PlanDelete,
+				Resources: []vmmanagers.PlannedResource{{Action: vmmanagers.PlanDelete, Type: "instance", Name: "some_id"}},
+			}, nil)
+
+			command := deleteCommand(outWriter, errWriter, fakeService, configStr, "", `{"iaas": "gcp", "vm_id": "some_id"}`)
+			command.DryRun = true
+
+			err := command.Execute([]string{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeService.DeleteVMCallCount()).To(Equal(0))
+			Expect(outWriter.Contents()).To(MatchYAML(`
+iaas: gcp
+vm_id: some_id
+action: delete
+resources:
+- action: delete
+  type: instance
+  name: some_id
+`))
+			Expect(readFile(command.StateFile)).To(MatchYAML(`{"iaas": "gcp", "vm_id": "some_id"}`))
+		})
+	})
+
 	When("vm does not exist", func() {
 		It("no op", func() {
 			fakeService := &fakes.DeleteVMService{}
@@ -9,12 +9,14 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
 
 	"github.com/pivotal-cf/om/interpolate"
+	"github.com/pivotal-cf/om/stepstate"
 	"github.com/pivotal-cf/om/vmlifecycle/extractopsmansemver"
 	"github.com/pivotal-cf/om/vmlifecycle/runner"
 	"github.com/pivotal-cf/om/vmlifecycle/vmmanagers"
@@ -40,12 +42,32 @@ type target struct {
 	DecryptionPassphrase string `yaml:"decryption-passphrase"`
 }
 
+const (
+	stepExportInstallation = "export-installation"
+	stepDeleteVM           = "delete-vm"
+	stepCreateVM           = "create-vm"
+	stepImportInstallation = "import-installation"
+	stepVerifyUpgrade      = "verify-upgrade"
+)
+
+// upgradeState records which steps of an upgrade have run, so that a re-run
+// after a failure continues with the same plan instead of starting over.
+type upgradeState struct {
+	Image           string   `yaml:"image"`
+	Steps           []string `yaml:"steps"`
+	stepstate.State `yaml:",inline"`
+}
+
 type UpgradeOpsman struct {
 	stdout             io.Writer
 	stderr             io.Writer
 	pollingInterval    time.Duration
 	timeout            time.Duration
-	Recreate           bool `long:"recreate" description:"Force recreate the Ops Manager VM"`
+	state              upgradeState
+	Recreate           bool   `long:"recreate" description:"Force recreate the Ops Manager VM"`
+	Export             bool   `long:"export-installation" description:"Export the installation from the running Ops Manager to the --installation path before deleting its VM"`
+	Verify             bool   `long:"verify" description:"After the import, wait until Ops Manager reports the version of the new image"`
+	UpgradeStateFile   string `long:"upgrade-state-file" description:"File to record the completed upgrade steps in, so that re-running a failed upgrade continues where it stopped"`
 	ImportInstallation struct {
 		Installation string `long:"installation" description:"Path to installation" required:"true"`
 		EnvFile      string `long:"env-file" description:"Ops Manager Environment File" required:"true"`
@@ -74,6 +96,15 @@ func (n *UpgradeOpsman) Execute(args []string) error {
 
 	n.setup()
 
+	err := n.loadUpgradeState()
+	if err != nil {
+		return err
+	}
+
+	if len(n.state.Steps) > 0 {
+		return n.resumeUpgrade()
+	}
+
 	outBuf, errBuf, err := n.omRunner.Execute([]interface{}{"--env", n.ImportInstallation.EnvFile, "--skip-ssl-validation", "curl", "--path", "/api/v0/info"})
 	if err != nil {
 		if e, ok := err.(*exec.Error); ok && e.Err == exec.ErrNotFound {
@@ -92,7 +123,7 @@ func (n *UpgradeOpsman) Execute(args []string) error {
 		return err
 	}
 	if compare == UPGRADE {
-		return n.upgradeOpsman()
+		return n.runUpgrade()
 	}
 	if compare == DOWNGRADE {
 		return errors.New("downgrading is not supported by Ops Manager")
@@ -106,7 +137,7 @@ func (n *UpgradeOpsman) Execute(args []string) error {
 
 		_, _ = n.stdout.Write([]byte("recreating the opsman VM\n"))
 
-		return n.upgradeOpsman()
+		return n.runUpgrade()
 	}
 
 	return errors.New("unexpected error in upgrading opsman")
@@ -117,6 +148,7 @@ func (n *UpgradeOpsman) setup() {
 	n.DeleteVM.Config = n.CreateVM.Config
 	n.DeleteVM.VarsFile = n.CreateVM.VarsFile
 	n.DeleteVM.VarsEnv = n.CreateVM.VarsEnv
+	n.DeleteVM.VarsStore = n.CreateVM.VarsStore
 }
 
 func (n *UpgradeOpsman) checkCredentials(errInfo string) error {
@@ -127,14 +159,7 @@ func (n *UpgradeOpsman) checkCredentials(errInfo string) error {
 }
 
 func (n *UpgradeOpsman) createAndImport() error {
-	_, _ = n.stdout.Write([]byte("creating the new opsman vm\n"))
-	err := n.CreateVM.Execute([]string{})
-	if err != nil {
-		return fmt.Errorf("could not create the vm: %s", err)
-	}
-
-	_, _ = n.stdout.Write([]byte("importing the old installation\n"))
-	return n.pollImportInstallation()
+	return n.runUpgrade(stepExportInstallation, stepDeleteVM)
 }
 
 func (n *UpgradeOpsman) compareOpsmanVersions(outBuf *bytes.Buffer) (processingError, error) {
@@ -162,23 +187,136 @@ func (n *UpgradeOpsman) compareOpsmanVersions(outBuf *bytes.Buffer) (processingE
 	return processingError(versionInstalled.Compare(versionToInstall)), nil
 }
 
-func (n *UpgradeOpsman) upgradeOpsman() error {
-	_, _ = n.stdout.Write([]byte("deleting the old opsman vm\n"))
-	err := n.DeleteVM.Execute([]string{})
-	if err != nil {
-		return err
+// runUpgrade plans the upgrade steps, leaving out the skipped ones, and runs
+// them in order.
+func (n *UpgradeOpsman) runUpgrade(skip ...string) error {
+	n.state = upgradeState{Image: filepath.Base(n.CreateVM.ImageFile)}
+
+	for _, step := range []string{stepExportInstallation, stepDeleteVM, stepCreateVM, stepImportInstallation, stepVerifyUpgrade} {
+		if (step == stepExportInstallation && !n.Export) || (step == stepVerifyUpgrade && !n.Verify) {
+			continue
+		}
+
+		skipped := false
+		for _, s := range skip {
+			skipped = skipped || s == step
+		}
+
+		if !skipped {
+			n.state.Steps = append(n.state.Steps, step)
+		}
+	}
+
+	return n.runSteps()
+}
+
+func (n *UpgradeOpsman) resumeUpgrade() error {
+	if len(n.state.Completed) == len(n.state.Steps) {
+		_, _ = n.stdout.Write([]byte(fmt.Sprintf("the upgrade to %s has already been completed\n", n.state.Image)))
+		return nil
 	}
 
-	_, _ = n.stdout.Write([]byte("creating the new opsman vm\n"))
-	err = n.CreateVM.Execute([]string{})
+	_, _ = n.stdout.Write([]byte(fmt.Sprintf("resuming the upgrade to %s\n", n.state.Image)))
+	return n.runSteps()
+}
+
+func (n *UpgradeOpsman) runSteps() error {
+	for _, step := range n.state.Steps {
+		if n.state.Done(step) {
+			_, _ = n.stdout.Write([]byte(fmt.Sprintf("skipping %s, it was completed by an earlier run\n", step)))
+			continue
+		}
+
+		err := n.runStep(step)
+		if err != nil {
+			return err
+		}
+
+		n.state.Complete(step)
+		err = n.saveUpgradeState()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *UpgradeOpsman) runStep(step string) error {
+	switch step {
+	case stepExportInstallation:
+		_, _ = n.stdout.Write([]byte("exporting the installation\n"))
+		_, _, err := n.omRunner.Execute([]interface{}{
+			"--env", n.ImportInstallation.EnvFile,
+			"--skip-ssl-validation",
+			"export-installation",
+			"--output-file", n.ImportInstallation.Installation,
+		})
+		if err != nil {
+			return fmt.Errorf("could not export the installation: %s", err)
+		}
+
+		return n.validateInstallation()
+	case stepDeleteVM:
+		_, _ = n.stdout.Write([]byte("deleting the old opsman vm\n"))
+		return n.DeleteVM.Execute([]string{})
+	case stepCreateVM:
+		_, _ = n.stdout.Write([]byte("creating the new opsman vm\n"))
+		err := n.CreateVM.Execute([]string{})
+		if err != nil {
+			return fmt.Errorf("could not create the vm: %s", err)
+		}
+
+		if n.state.Done(stepDeleteVM) {
+			time.Sleep(n.pollingInterval)
+		}
+
+		return nil
+	case stepImportInstallation:
+		if n.Export {
+			err := n.validateInstallation()
+			if err != nil {
+				return err
+			}
+		}
+
+		_, _ = n.stdout.Write([]byte("importing the old installation\n"))
+		return n.pollImportInstallation()
+	case stepVerifyUpgrade:
+		_, _ = n.stdout.Write([]byte("verifying the new opsman vm\n"))
+		return n.pollVerifyUpgrade()
+	}
+
+	return fmt.Errorf("unknown upgrade step %q", step)
+}
+
+func (n *UpgradeOpsman) loadUpgradeState() error {
+	n.state = upgradeState{}
+
+	if n.UpgradeStateFile == "" {
+		return nil
+	}
+
+	var state upgradeState
+	err := stepstate.Read(n.UpgradeStateFile, &state)
 	if err != nil {
 		return err
 	}
 
-	time.Sleep(n.pollingInterval)
+	// a state file from an upgrade to another image does not apply
+	if state.Image == filepath.Base(n.CreateVM.ImageFile) {
+		n.state = state
+	}
 
-	_, _ = n.stdout.Write([]byte("importing the old installation\n"))
-	return n.pollImportInstallation()
+	return nil
+}
+
+func (n *UpgradeOpsman) saveUpgradeState() error {
+	if n.UpgradeStateFile == "" {
+		return nil
+	}
+
+	return stepstate.Write(n.UpgradeStateFile, n.state)
 }
 
 func (n *UpgradeOpsman) pollImportInstallation() error {
@@ -208,6 +346,39 @@ func (n *UpgradeOpsman) pollImportInstallation() error {
 	return nil
 }
 
+// pollVerifyUpgrade waits for the imported Ops Manager to answer with the
+// version of the image it was created from.
+func (n *UpgradeOpsman) pollVerifyUpgrade() error {
+	start := time.Now()
+	for {
+		outBuf, _, err := n.omRunner.Execute([]interface{}{
+			"--env", n.ImportInstallation.EnvFile,
+			"--skip-ssl-validation",
+			"curl",
+			"--path", "/api/v0/info",
+		})
+		if err == nil {
+			var compare processingError
+			compare, err = n.compareOpsmanVersions(outBuf)
+			if compare == EQUAL {
+				_, _ = n.stdout.Write([]byte("the new opsman vm is running the expected version\n"))
+				return nil
+			}
+
+			if compare != ERROR {
+				err = fmt.Errorf("the version does not match the image %s", n.CreateVM.ImageFile)
+			}
+		}
+
+		if time.Since(start) > n.timeout {
+			return fmt.Errorf("could not verify the upgrade, exceeded %s waiting for opsman: %s", n.timeout, err)
+		}
+
+		_, _ = n.stdout.Write([]byte(fmt.Sprintf("could not verify opsman, polling again in %s, the cause: %s\n", n.pollingInterval, err)))
+		time.Sleep(n.pollingInterval)
+	}
+}
+
 func (n *UpgradeOpsman) validate() error {
 	if _, err := os.Stat(n.CreateVM.Config); err != nil {
 		return fmt.Errorf("could not open config file (%s): %s", n.CreateVM.Config, err)
@@ -221,10 +392,6 @@ func (n *UpgradeOpsman) validate() error {
 		return fmt.Errorf("could not open image file (%s): %s", n.CreateVM.ImageFile, err)
 	}
 
-	if _, err := os.Stat(n.ImportInstallation.Installation); err != nil {
-		return fmt.Errorf("could not open installation file (%s): %s", n.ImportInstallation.Installation, err)
-	}
-
 	if _, err := os.Stat(n.CreateVM.StateFile); err != nil {
 		return fmt.Errorf("could not open state file (%s): %s", n.CreateVM.StateFile, err)
 	}
@@ -235,20 +402,10 @@ func (n *UpgradeOpsman) validate() error {
 		}
 	}
 
-	if zipper, err := zip.OpenReader(n.ImportInstallation.Installation); err != nil {
-		return fmt.Errorf("file: \"%s\" is not a valid zip file", n.ImportInstallation.Installation)
-	} else {
-		defer zipper.Close()
-		found := false
-		for _, f := range zipper.File {
-			if f.Name == "installation.yml" {
-				found = true
-				break
-			}
-		}
-
-		if !found {
-			return fmt.Errorf("file: \"%s\" is not a valid installation file", n.ImportInstallation.Installation)
+	// an exported installation is validated once it has been written
+	if !n.Export {
+		if err := n.validateInstallation(); err != nil {
+			return err
 		}
 	}
 
@@ -259,6 +416,7 @@ func (n *UpgradeOpsman) validate() error {
 		VarsFiles:     n.CreateVM.VarsFile,
 		EnvironFunc:   os.Environ,
 		VarsEnvs:      n.CreateVM.VarsEnv,
+		VarsStores:    n.CreateVM.VarsStore,
 		ExpectAllKeys: true,
 	})
 	if err != nil {
@@ -288,6 +446,31 @@ func (n *UpgradeOpsman) validate() error {
 	return n.validateImportInstallationConfig()
 }
 
+func (n *UpgradeOpsman) validateInstallation() error {
+	if _, err := os.Stat(n.ImportInstallation.Installation); err != nil {
+		return fmt.Errorf("could not open installation file (%s): %s", n.ImportInstallation.Installation, err)
+	}
+
+	if zipper, err := zip.OpenReader(n.ImportInstallation.Installation); err != nil {
+		return fmt.Errorf("file: \"%s\" is not a valid zip file", n.ImportInstallation.Installation)
+	} else {
+		defer zipper.Close()
+		found := false
+		for _, f := range zipper.File {
+			if f.Name == "installation.yml" {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("file: \"%s\" is not a valid installation file", n.ImportInstallation.Installation)
+		}
+	}
+
+	return nil
+}
+
 func (n *UpgradeOpsman) validateImportInstallationConfig() error {
 	target := target{}
 	content, err := os.ReadFile(n.ImportInstallation.EnvFile)
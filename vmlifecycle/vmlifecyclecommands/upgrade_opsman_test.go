@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/onsi/gomega/gbytes"
@@ -802,6 +804,137 @@ project_name: awesome-project
 		})
 	})
 
+	Describe("the upgrade workflow", func() {
+		var (
+			server       *ghttp.Server
+			infoVersions []string
+		)
+
+		BeforeEach(func() {
+			infoVersions = nil
+
+			server = ghttp.NewServer()
+			server.RouteToHandler("PUT", "/api/v0/unlock", ghttp.RespondWith(200, "{}"))
+			server.RouteToHandler("GET", "/login/ensure_availability", ghttp.RespondWith(302, "", map[string][]string{
+				"Location": {"/auth/cloudfoundry"},
+			}))
+			server.RouteToHandler("POST", "/uaa/oauth/token", ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+				"access_token": "some-access-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			}))
+			server.RouteToHandler("GET", "/api/v0/info", func(w http.ResponseWriter, req *http.Request) {
+				version := infoVersions[0]
+				if len(infoVersions) > 1 {
+					infoVersions = infoVersions[1:]
+				}
+
+				ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+					"info": map[string]interface{}{"version": version},
+				})(w, req)
+			})
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newCommand := func() (*vmlifecyclecommands.UpgradeOpsman, *fakes.CreateVMService, *fakes.DeleteVMService, *gbytes.Buffer) {
+			command, createService, deleteService, stdout, _ := createUpgradeOpsmanCommand()
+			command.ImportInstallation.EnvFile = writeFile(createJsonString(map[string]interface{}{
+				"target":                server.URL(),
+				"request-timeout":       1,
+				"connect-timeout":       1,
+				"skip-ssl-validation":   true,
+				"decryption-passphrase": "decryption-passphrase",
+			}))
+
+			image, err := os.CreateTemp("", "OpsManager2.10-build.296onGCP.yml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(image.Close()).To(Succeed())
+			command.CreateVM.ImageFile = image.Name()
+			command.UpgradeStateFile = filepath.Join(GinkgoT().TempDir(), "upgrade-state.yml")
+
+			return command, createService, deleteService, stdout
+		}
+
+		It("exports the installation, replaces the vm, imports and verifies the new version", func() {
+			installation, err := os.ReadFile(createZipFile([]struct{ Name, Body string }{
+				{"installation.yml", ""},
+			}))
+			Expect(err).ToNot(HaveOccurred())
+			server.RouteToHandler("GET", "/api/v0/installation_asset_collection", ghttp.RespondWith(200, installation))
+
+			infoVersions = []string{"2.9-build.100", "2.10-build.296"}
+
+			command, createService, deleteService, stdout := newCommand()
+			command.Export = true
+			command.Verify = true
+			command.ImportInstallation.Installation = filepath.Join(GinkgoT().TempDir(), "installation.zip")
+
+			err = command.Execute([]string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deleteService.DeleteVMCallCount()).To(Equal(1))
+			Expect(createService.CreateVMCallCount()).To(Equal(1))
+			Expect(command.ImportInstallation.Installation).To(BeAnExistingFile())
+			Expect(stdout).To(gbytes.Say("exporting the installation"))
+			Expect(stdout).To(gbytes.Say("the new opsman vm is running the expected version"))
+
+			Expect(readFile(command.UpgradeStateFile)).To(MatchYAML(fmt.Sprintf(`
+image: %s
+steps: [export-installation, delete-vm, create-vm, import-installation, verify-upgrade]
+completed: [export-installation, delete-vm, create-vm, import-installation, verify-upgrade]
+`, filepath.Base(command.CreateVM.ImageFile))))
+		})
+
+		It("continues a failed upgrade from the upgrade state file", func() {
+			command, createService, deleteService, stdout := newCommand()
+			writeSpecifiedFile(command.UpgradeStateFile, fmt.Sprintf(`
+image: %s
+steps: [delete-vm, create-vm, import-installation]
+completed: [delete-vm]
+`, filepath.Base(command.CreateVM.ImageFile)))
+
+			err := command.Execute([]string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deleteService.DeleteVMCallCount()).To(Equal(0))
+			Expect(createService.CreateVMCallCount()).To(Equal(1))
+			Expect(stdout).To(gbytes.Say("resuming the upgrade to"))
+			Expect(stdout).To(gbytes.Say("skipping delete-vm, it was completed by an earlier run"))
+			Expect(readFile(command.UpgradeStateFile)).To(ContainSubstring("completed:\n- delete-vm\n- create-vm\n- import-installation"))
+		})
+
+		It("does nothing once the upgrade has been completed", func() {
+			command, createService, deleteService, stdout := newCommand()
+			writeSpecifiedFile(command.UpgradeStateFile, fmt.Sprintf(`
+image: %s
+steps: [create-vm, import-installation]
+completed: [create-vm, import-installation]
+`, filepath.Base(command.CreateVM.ImageFile)))
+
+			err := command.Execute([]string{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deleteService.DeleteVMCallCount()).To(Equal(0))
+			Expect(createService.CreateVMCallCount()).To(Equal(0))
+			Expect(stdout).To(gbytes.Say("the upgrade to .* has already been completed"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("fails the verification when Ops Manager keeps reporting another version", func() {
+			infoVersions = []string{"2.9-build.100"}
+
+			command, _, _, _ := newCommand()
+			command.Verify = true
+
+			err := command.Execute([]string{})
+			Expect(err).To(MatchError(ContainSubstring("could not verify the upgrade, exceeded 20ms waiting for opsman")))
+			Expect(readFile(command.UpgradeStateFile)).To(ContainSubstring("- import-installation"))
+		})
+	})
+
 	Describe("validate the inputs to ensure we fail fast", func() {
 		var (
 			configContent       string
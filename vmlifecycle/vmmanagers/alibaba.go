@@ -0,0 +1,557 @@
+package vmmanagers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/om/vmlifecycle/runner"
+)
+
+type AlibabaCredential struct {
+	AccessKeyId     string `yaml:"access_key_id" validate:"required"`
+	AccessKeySecret string `yaml:"access_key_secret" validate:"required"`
+	Region          string `yaml:"region" validate:"required"`
+}
+
+type AlibabaConfig struct {
+	AlibabaCredential `yaml:",inline"`
+	VSwitchId         string `yaml:"vswitch_id" validate:"required"`
+	SecurityGroupId   string `yaml:"security_group_id" validate:"required"`
+	KeyPairName       string `yaml:"key_pair_name" validate:"required"`
+	OSSBucket         string `yaml:"oss_bucket" validate:"required"`
+	PublicIP          string `yaml:"public_ip" validate:"omitempty,ip"`
+	PrivateIP         string `yaml:"private_ip" validate:"omitempty,ip"`
+	VMName            string `yaml:"vm_name"`
+	BootDiskSize      string `yaml:"boot_disk_size"`
+	BootDiskCategory  string `yaml:"boot_disk_category"`
+	InstanceType      string `yaml:"instance_type"`
+}
+
+//go:generate counterfeiter -o ./fakes/alibabaRunner.go --fake-name AlibabaRunner . alibabaRunner
+type alibabaRunner interface {
+	Execute(args []interface{}) (*bytes.Buffer, *bytes.Buffer, error)
+}
+
+type AlibabaVMManager struct {
+	Config          *AlibabaConfig
+	Image           string
+	State           StateInfo
+	runner          alibabaRunner
+	pollingInterval time.Duration
+}
+
+func NewAlibabaVMManager(config *OpsmanConfigFilePayload, image string, state StateInfo, alibabaRunner alibabaRunner, t time.Duration) *AlibabaVMManager {
+	return &AlibabaVMManager{
+		Config:          config.OpsmanConfig.Alibaba,
+		Image:           image,
+		State:           state,
+		runner:          alibabaRunner,
+		pollingInterval: t,
+	}
+}
+
+func (a *AlibabaVMManager) DeleteVM() error {
+	err := validateIAASConfig(a.Config.AlibabaCredential)
+	if err != nil {
+		return err
+	}
+
+	if a.State.IAAS != "alibaba" {
+		return fmt.Errorf("authentication file provided is for alibaba, while the state file is for %s", a.State.IAAS)
+	}
+
+	exist, err := a.vmExists()
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return fmt.Errorf("Could not find VM with ID %q.\n       To fix, ensure the VM ID in the statefile matches a VM that exists.\n       If the VM has already been deleted, delete the contents of the statefile.", a.State.ID)
+	}
+
+	return a.deleteVM()
+}
+
+func (a *AlibabaVMManager) CreateVM() (Status, StateInfo, error) {
+	err := a.prepareCreateVM()
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+
+	exist, err := a.vmExists()
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+	if exist {
+		return Exist, a.State, nil
+	}
+
+	imageID, err := a.ensureImage()
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+
+	instanceID, err := a.createVM(imageID)
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+
+	fullState := StateInfo{IAAS: "alibaba", ID: instanceID}
+
+	err = a.waitUntilVMRunning(instanceID)
+	if err != nil {
+		return Incomplete, fullState, err
+	}
+
+	if a.Config.PublicIP != "" {
+		err = a.associateIP(instanceID)
+		if err != nil {
+			return Incomplete, fullState, err
+		}
+	}
+
+	return Success, fullState, nil
+}
+
+func (a *AlibabaVMManager) PlanCreateVM() (Plan, error) {
+	err := a.prepareCreateVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exist, err := a.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	resources := []PlannedResource{
+		{
+			Type: "instance",
+			Name: a.Config.VMName,
+			Properties: map[string]string{
+				"image":          a.imageName(),
+				"instance_type":  a.Config.InstanceType,
+				"vswitch":        a.Config.VSwitchId,
+				"security_group": a.Config.SecurityGroupId,
+				"key_pair":       a.Config.KeyPairName,
+				"private_ip":     a.Config.PrivateIP,
+				"public_ip":      a.Config.PublicIP,
+				"boot_disk":      fmt.Sprintf("%sGB %s", a.Config.BootDiskSize, a.Config.BootDiskCategory),
+			},
+		},
+		{
+			Type: "image",
+			Name: a.imageName(),
+			Properties: map[string]string{
+				"oss_object": fmt.Sprintf("oss://%s/%s", a.Config.OSSBucket, a.imageObject()),
+				"format":     a.imageFormat(),
+			},
+		},
+	}
+	if a.Config.PublicIP != "" {
+		resources = append(resources, PlannedResource{
+			Type: "eip-association",
+			Name: a.Config.PublicIP,
+		})
+	}
+
+	return planCreateVM("alibaba", a.State, exist, resources, a.describeVM)
+}
+
+func (a *AlibabaVMManager) PlanDeleteVM() (Plan, error) {
+	err := validateIAASConfig(a.Config.AlibabaCredential)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if a.State.IAAS != "alibaba" {
+		return Plan{}, fmt.Errorf("authentication file provided is for alibaba, while the state file is for %s", a.State.IAAS)
+	}
+
+	return planDeleteVM("alibaba", a.State, PlannedResource{Type: "instance", Name: a.State.ID}), nil
+}
+
+func (a *AlibabaVMManager) prepareCreateVM() error {
+	if a.State.IAAS != "alibaba" && a.State.IAAS != "" {
+		return fmt.Errorf("authentication file provided is for alibaba, while the state file is for %s", a.State.IAAS)
+	}
+
+	err := validateIAASConfig(a.Config)
+	if err != nil {
+		return err
+	}
+
+	if a.Config.PublicIP == "" && a.Config.PrivateIP == "" {
+		return errors.New("PublicIP and/or PrivateIP must be set")
+	}
+
+	a.addDefaultConfigFields()
+
+	if _, err := os.Stat(a.Image); os.IsNotExist(err) {
+		return fmt.Errorf("could not read image file: %s", err)
+	}
+
+	return nil
+}
+
+func (a *AlibabaVMManager) addDefaultConfigFields() {
+	if a.Config.VMName == "" {
+		a.Config.VMName = "ops-manager-vm"
+	}
+	if a.Config.BootDiskSize == "" {
+		a.Config.BootDiskSize = "200"
+	}
+	if a.Config.BootDiskCategory == "" {
+		a.Config.BootDiskCategory = "cloud_essd"
+	}
+	if a.Config.InstanceType == "" {
+		a.Config.InstanceType = "ecs.g6.large"
+	}
+}
+
+// ensureImage returns the custom image built from the image file. The file
+// is uploaded to the OSS bucket and imported into ECS, unless an image with
+// the same name has been imported by a previous run.
+func (a *AlibabaVMManager) ensureImage() (string, error) {
+	imageName := a.imageName()
+
+	stdout, _, err := a.ecs(`DescribeImages`,
+		`--ImageName`, imageName,
+		`--ImageOwnerAlias`, `self`,
+	)
+	if err != nil {
+		return "", fmt.Errorf("alibaba error listing existing images: %s", err)
+	}
+
+	var images struct {
+		Images struct {
+			Image []struct {
+				ImageId string
+				Status  string
+			}
+		}
+	}
+	err = json.Unmarshal(stdout.Bytes(), &images)
+	if err != nil {
+		return "", fmt.Errorf("alibaba error could not parse images: %s", err)
+	}
+
+	for _, image := range images.Images.Image {
+		if image.Status == "Available" {
+			log.Printf("Using existing image %s (%s)...\n", imageName, image.ImageId)
+			return image.ImageId, nil
+		}
+	}
+
+	log.Printf("Uploading image to oss://%s/%s...\n", a.Config.OSSBucket, a.imageObject())
+	_, _, err = a.runner.Execute(append(a.getAuthArguments(), `oss`, `cp`,
+		a.Image,
+		fmt.Sprintf("oss://%s/%s", a.Config.OSSBucket, a.imageObject()),
+		`--force`,
+	))
+	if err != nil {
+		return "", fmt.Errorf("alibaba error uploading the image to oss: %s", err)
+	}
+
+	stdout, _, err = a.ecs(`ImportImage`,
+		`--ImageName`, imageName,
+		`--Architecture`, `x86_64`,
+		`--OSType`, `linux`,
+		`--Platform`, `Ubuntu`,
+		`--DiskDeviceMapping.1.OSSBucket`, a.Config.OSSBucket,
+		`--DiskDeviceMapping.1.OSSObject`, a.imageObject(),
+		`--DiskDeviceMapping.1.Format`, a.imageFormat(),
+	)
+	if err != nil {
+		return "", fmt.Errorf("alibaba error importing the image: %s", err)
+	}
+
+	var imported struct {
+		ImageId string
+	}
+	err = json.Unmarshal(stdout.Bytes(), &imported)
+	if err != nil {
+		return "", fmt.Errorf("alibaba error could not parse the imported image: %s", err)
+	}
+
+	return imported.ImageId, a.waitUntilImageAvailable(imported.ImageId)
+}
+
+func (a *AlibabaVMManager) waitUntilImageAvailable(imageID string) error {
+	log.Printf("Waiting for image %s to become available...\n", imageID)
+	for range 360 {
+		stdout, _, err := a.ecs(`DescribeImages`,
+			`--ImageId`, imageID,
+			`--Status`, `Creating,Waiting,Available,UnAvailable,CreateFailed`,
+		)
+		if err != nil {
+			return fmt.Errorf("alibaba error could not check the state of image %s: %s", imageID, err)
+		}
+
+		var images struct {
+			Images struct {
+				Image []struct {
+					Status string
+				}
+			}
+		}
+		err = json.Unmarshal(stdout.Bytes(), &images)
+		if err != nil {
+			return fmt.Errorf("alibaba error could not parse images: %s", err)
+		}
+
+		if len(images.Images.Image) > 0 {
+			switch images.Images.Image[0].Status {
+			case "Available":
+				return nil
+			case "UnAvailable", "CreateFailed":
+				return fmt.Errorf("alibaba error importing image %s: image is %s", imageID, images.Images.Image[0].Status)
+			}
+		}
+
+		time.Sleep(a.pollingInterval)
+	}
+
+	return fmt.Errorf("timeout exceeded waiting for image %s to become available", imageID)
+}
+
+func (a *AlibabaVMManager) createVM(imageID string) (string, error) {
+	args := []interface{}{
+		`--ImageId`, imageID,
+		`--InstanceType`, a.Config.InstanceType,
+		`--InstanceName`, a.Config.VMName,
+		`--HostName`, a.Config.VMName,
+		`--SecurityGroupId`, a.Config.SecurityGroupId,
+		`--VSwitchId`, a.Config.VSwitchId,
+		`--KeyPairName`, a.Config.KeyPairName,
+		`--SystemDisk.Size`, a.Config.BootDiskSize,
+		`--SystemDisk.Category`, a.Config.BootDiskCategory,
+		`--Amount`, `1`,
+	}
+	if a.Config.PrivateIP != "" {
+		args = append(args, `--PrivateIpAddress`, a.Config.PrivateIP)
+	}
+
+	stdout, _, err := a.ecs(`RunInstances`, args...)
+	if err != nil {
+		return "", fmt.Errorf("alibaba error creating the vm: %s", err)
+	}
+
+	var instances struct {
+		InstanceIdSets struct {
+			InstanceIdSet []string
+		}
+	}
+	err = json.Unmarshal(stdout.Bytes(), &instances)
+	if err != nil {
+		return "", fmt.Errorf("alibaba error could not parse the created vm: %s", err)
+	}
+	if len(instances.InstanceIdSets.InstanceIdSet) != 1 {
+		return "", fmt.Errorf("alibaba error creating the vm: expected one instance, got %d", len(instances.InstanceIdSets.InstanceIdSet))
+	}
+
+	return instances.InstanceIdSets.InstanceIdSet[0], nil
+}
+
+func (a *AlibabaVMManager) waitUntilVMRunning(instanceID string) error {
+	for range 200 {
+		status, err := a.vmStatus(instanceID)
+		if err != nil {
+			return fmt.Errorf("alibaba error could not check the instance state for %s: %s", instanceID, err)
+		}
+		if status == "Running" {
+			return nil
+		}
+		time.Sleep(a.pollingInterval)
+	}
+
+	return errors.New("timeout exceeded waiting for the VM to enter the running state")
+}
+
+func (a *AlibabaVMManager) associateIP(instanceID string) error {
+	log.Println("Associating Public IP with the VM...")
+	stdout, _, err := a.runner.Execute(append(a.getAuthArguments(), `vpc`, `DescribeEipAddresses`,
+		`--RegionId`, a.Config.Region,
+		`--EipAddress`, a.Config.PublicIP,
+	))
+	if err != nil {
+		return fmt.Errorf("alibaba error finding public IP address: %s", err)
+	}
+
+	var addresses struct {
+		EipAddresses struct {
+			EipAddress []struct {
+				AllocationId string
+			}
+		}
+	}
+	err = json.Unmarshal(stdout.Bytes(), &addresses)
+	if err != nil {
+		return fmt.Errorf("alibaba error could not parse public IP addresses: %s", err)
+	}
+	if len(addresses.EipAddresses.EipAddress) == 0 {
+		return fmt.Errorf("alibaba error finding public IP address: %s is not allocated in %s", a.Config.PublicIP, a.Config.Region)
+	}
+
+	_, _, err = a.runner.Execute(append(a.getAuthArguments(), `vpc`, `AssociateEipAddress`,
+		`--RegionId`, a.Config.Region,
+		`--AllocationId`, addresses.EipAddresses.EipAddress[0].AllocationId,
+		`--InstanceId`, instanceID,
+	))
+	if err != nil {
+		return fmt.Errorf("alibaba error associating the IP address with the VM: %s", err)
+	}
+
+	return nil
+}
+
+func (a *AlibabaVMManager) deleteVM() error {
+	_, _, err := a.ecs(`DeleteInstance`,
+		`--InstanceId`, a.State.ID,
+		`--Force`, `true`,
+	)
+	if err != nil {
+		return fmt.Errorf("alibaba error deleting the vm: %s", err)
+	}
+
+	// best effort, the instance may still be releasing when the retries run out
+	for range 200 {
+		exist, err := a.vmExists()
+		if err != nil {
+			return fmt.Errorf("alibaba error could not query vm status: %s", err)
+		}
+		if !exist {
+			break
+		}
+		time.Sleep(a.pollingInterval)
+	}
+
+	return nil
+}
+
+func (a *AlibabaVMManager) vmExists() (bool, error) {
+	if a.State.ID == "" {
+		return false, nil
+	}
+
+	status, err := a.vmStatus(a.State.ID)
+	if err != nil {
+		return false, fmt.Errorf("alibaba error could not query vm status: %s", err)
+	}
+
+	return status != "", nil
+}
+
+type alibabaInstance struct {
+	Status        string
+	InstanceType  string
+	KeyPairName   string
+	VpcAttributes struct {
+		VSwitchId        string
+		PrivateIpAddress struct {
+			IpAddress []string
+		}
+	}
+	EipAddress struct {
+		IpAddress string
+	}
+	SecurityGroupIds struct {
+		SecurityGroupId []string
+	}
+}
+
+// vmStatus returns the status of the instance, or an empty string if it does
+// not exist.
+func (a *AlibabaVMManager) vmStatus(instanceID string) (string, error) {
+	instance, err := a.getInstance(instanceID)
+	if err != nil || instance == nil {
+		return "", err
+	}
+
+	return instance.Status, nil
+}
+
+func (a *AlibabaVMManager) getInstance(instanceID string) (*alibabaInstance, error) {
+	stdout, _, err := a.ecs(`DescribeInstances`,
+		`--InstanceIds`, fmt.Sprintf(`["%s"]`, instanceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var instances struct {
+		Instances struct {
+			Instance []alibabaInstance
+		}
+	}
+	err = json.Unmarshal(stdout.Bytes(), &instances)
+	if err != nil {
+		return nil, err
+	}
+	if len(instances.Instances.Instance) == 0 {
+		return nil, nil
+	}
+
+	return &instances.Instances.Instance[0], nil
+}
+
+func (a *AlibabaVMManager) describeVM() (map[string]string, error) {
+	instance, err := a.getInstance(a.State.ID)
+	if err != nil {
+		return nil, fmt.Errorf("alibaba error describing the vm: %s", err)
+	}
+	if instance == nil {
+		return nil, fmt.Errorf("alibaba error describing the vm: %s does not exist", a.State.ID)
+	}
+
+	actual := map[string]string{
+		"instance_type":  instance.InstanceType,
+		"vswitch":        instance.VpcAttributes.VSwitchId,
+		"security_group": sortedList(instance.SecurityGroupIds.SecurityGroupId),
+		"key_pair":       instance.KeyPairName,
+		"private_ip":     strings.Join(instance.VpcAttributes.PrivateIpAddress.IpAddress, ","),
+		"public_ip":      instance.EipAddress.IpAddress,
+	}
+
+	return actual, nil
+}
+
+func (a *AlibabaVMManager) imageName() string {
+	name := filepath.Base(a.Image)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func (a *AlibabaVMManager) imageObject() string {
+	return filepath.Base(a.Image)
+}
+
+func (a *AlibabaVMManager) imageFormat() string {
+	switch strings.ToLower(filepath.Ext(a.Image)) {
+	case ".qcow2":
+		return "QCOW2"
+	case ".vhd":
+		return "VHD"
+	default:
+		return "RAW"
+	}
+}
+
+func (a *AlibabaVMManager) ecs(action string, args ...interface{}) (*bytes.Buffer, *bytes.Buffer, error) {
+	fullArgs := append(a.getAuthArguments(), `ecs`, action, `--RegionId`, a.Config.Region)
+	return a.runner.Execute(append(fullArgs, args...))
+}
+
+func (a *AlibabaVMManager) getAuthArguments() []interface{} {
+	return []interface{}{
+		`--mode`, `AK`,
+		`--access-key-id`, runner.Redact(a.Config.AccessKeyId),
+		`--access-key-secret`, runner.Redact(a.Config.AccessKeySecret),
+		`--region`, a.Config.Region,
+	}
+}
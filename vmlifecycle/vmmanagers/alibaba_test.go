@@ -0,0 +1,326 @@
+package vmmanagers_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	"github.com/pivotal-cf/om/vmlifecycle/matchers"
+	"github.com/pivotal-cf/om/vmlifecycle/vmmanagers"
+	"github.com/pivotal-cf/om/vmlifecycle/vmmanagers/fakes"
+)
+
+var _ = Describe("Alibaba VMManager", func() {
+	const configStr = `
+opsman-configuration:
+  alibaba:
+    access_key_id: some-key-id
+    access_key_secret: some-key-secret
+    region: cn-hangzhou
+    vswitch_id: vsw-123
+    security_group_id: sg-123
+    key_pair_name: some-key-pair
+    oss_bucket: some-bucket
+    public_ip: 1.2.3.4
+    private_ip: 10.0.0.2
+    vm_name: awesome-vm
+`
+
+	var (
+		responses map[string][]string
+		image     string
+	)
+
+	// the auth arguments come first, followed by the service and its action
+	action := func(args []interface{}) string {
+		return fmt.Sprintf("%s %s", args[8], args[9])
+	}
+
+	createCommand := func(config string, state vmmanagers.StateInfo) (*vmmanagers.AlibabaVMManager, *fakes.AlibabaRunner) {
+		var validConfig *vmmanagers.OpsmanConfigFilePayload
+		err := yaml.UnmarshalStrict([]byte(config), &validConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		runner := &fakes.AlibabaRunner{}
+		runner.ExecuteStub = func(args []interface{}) (*bytes.Buffer, *bytes.Buffer, error) {
+			outputs := responses[action(args)]
+			if len(outputs) == 0 {
+				return bytes.NewBufferString("{}"), nil, nil
+			}
+
+			output := outputs[0]
+			if len(outputs) > 1 {
+				responses[action(args)] = outputs[1:]
+			}
+			return bytes.NewBufferString(output), nil, nil
+		}
+
+		return vmmanagers.NewAlibabaVMManager(validConfig, image, state, runner, 0), runner
+	}
+
+	callsTo := func(runner *fakes.AlibabaRunner, name string) [][]interface{} {
+		var calls [][]interface{}
+		for i := 0; i < runner.ExecuteCallCount(); i++ {
+			args := runner.ExecuteArgsForCall(i)
+			if action(args) == name {
+				calls = append(calls, args[10:])
+			}
+		}
+		return calls
+	}
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "alibaba")
+		Expect(err).ToNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, dir)
+
+		image = filepath.Join(dir, "ops-manager-alibaba-3.0.1.qcow2")
+		Expect(os.WriteFile(image, []byte("image"), 0600)).To(Succeed())
+
+		responses = map[string][]string{
+			"ecs DescribeImages": {
+				`{"Images": {"Image": []}}`,
+				`{"Images": {"Image": [{"ImageId": "m-123", "Status": "Creating"}]}}`,
+				`{"Images": {"Image": [{"ImageId": "m-123", "Status": "Available"}]}}`,
+			},
+			"ecs ImportImage":          {`{"ImageId": "m-123"}`},
+			"ecs RunInstances":         {`{"InstanceIdSets": {"InstanceIdSet": ["i-123"]}}`},
+			"ecs DescribeInstances":    {`{"Instances": {"Instance": [{"Status": "Starting"}]}}`, `{"Instances": {"Instance": [{"Status": "Running"}]}}`},
+			"vpc DescribeEipAddresses": {`{"EipAddresses": {"EipAddress": [{"AllocationId": "eip-123"}]}}`},
+		}
+	})
+
+	Describe("CreateVM", func() {
+		It("imports the image through oss and boots the vm from it", func() {
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{})
+
+			status, state, err := command.CreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(vmmanagers.Success))
+			Expect(state).To(Equal(vmmanagers.StateInfo{IAAS: "alibaba", ID: "i-123"}))
+
+			args := runner.ExecuteArgsForCall(0)
+			Expect(fmt.Sprint(args[:8])).To(Equal("[--mode AK --access-key-id some-key-id --access-key-secret some-key-secret --region cn-hangzhou]"))
+
+			Expect(callsTo(runner, "oss cp")).To(ConsistOf(
+				matchers.OrderedConsistOf(image, "oss://some-bucket/ops-manager-alibaba-3.0.1.qcow2", "--force"),
+			))
+			Expect(callsTo(runner, "ecs ImportImage")).To(ConsistOf(
+				matchers.OrderedConsistOf(
+					"--RegionId", "cn-hangzhou",
+					"--ImageName", "ops-manager-alibaba-3.0.1",
+					"--Architecture", "x86_64",
+					"--OSType", "linux",
+					"--Platform", "Ubuntu",
+					"--DiskDeviceMapping.1.OSSBucket", "some-bucket",
+					"--DiskDeviceMapping.1.OSSObject", "ops-manager-alibaba-3.0.1.qcow2",
+					"--DiskDeviceMapping.1.Format", "QCOW2",
+				),
+			))
+			Expect(callsTo(runner, "ecs RunInstances")).To(ConsistOf(
+				matchers.OrderedConsistOf(
+					"--RegionId", "cn-hangzhou",
+					"--ImageId", "m-123",
+					"--InstanceType", "ecs.g6.large",
+					"--InstanceName", "awesome-vm",
+					"--HostName", "awesome-vm",
+					"--SecurityGroupId", "sg-123",
+					"--VSwitchId", "vsw-123",
+					"--KeyPairName", "some-key-pair",
+					"--SystemDisk.Size", "200",
+					"--SystemDisk.Category", "cloud_essd",
+					"--Amount", "1",
+					"--PrivateIpAddress", "10.0.0.2",
+				),
+			))
+			Expect(callsTo(runner, "ecs DescribeInstances")).To(HaveLen(2))
+			Expect(callsTo(runner, "vpc AssociateEipAddress")).To(ConsistOf(
+				matchers.OrderedConsistOf(
+					"--RegionId", "cn-hangzhou",
+					"--AllocationId", "eip-123",
+					"--InstanceId", "i-123",
+				),
+			))
+		})
+
+		It("reuses an image imported by a previous run", func() {
+			responses["ecs DescribeImages"] = []string{`{"Images": {"Image": [{"ImageId": "m-456", "Status": "Available"}]}}`}
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{})
+
+			_, _, err := command.CreateVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(callsTo(runner, "oss cp")).To(BeEmpty())
+			Expect(callsTo(runner, "ecs ImportImage")).To(BeEmpty())
+			Expect(callsTo(runner, "ecs RunInstances")[0]).To(ContainElement("m-456"))
+		})
+
+		It("does not associate a public ip when none is configured", func() {
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{})
+			command.Config.PublicIP = ""
+
+			_, _, err := command.CreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(callsTo(runner, "vpc DescribeEipAddresses")).To(BeEmpty())
+		})
+
+		It("returns exist when the vm from the state file is still there", func() {
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{IAAS: "alibaba", ID: "i-123"})
+
+			status, state, err := command.CreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(vmmanagers.Exist))
+			Expect(state.ID).To(Equal("i-123"))
+			Expect(runner.ExecuteCallCount()).To(Equal(1))
+		})
+
+		Describe("failure cases", func() {
+			It("requires a public or private ip", func() {
+				command, _ := createCommand(configStr, vmmanagers.StateInfo{})
+				command.Config.PublicIP = ""
+				command.Config.PrivateIP = ""
+
+				_, _, err := command.CreateVM()
+				Expect(err).To(MatchError("PublicIP and/or PrivateIP must be set"))
+			})
+
+			It("errors when the state file is for another iaas", func() {
+				command, _ := createCommand(configStr, vmmanagers.StateInfo{IAAS: "gcp"})
+
+				_, _, err := command.CreateVM()
+				Expect(err).To(MatchError("authentication file provided is for alibaba, while the state file is for gcp"))
+			})
+
+			It("errors when the image cannot be imported", func() {
+				responses["ecs DescribeImages"] = []string{
+					`{"Images": {"Image": []}}`,
+					`{"Images": {"Image": [{"ImageId": "m-123", "Status": "CreateFailed"}]}}`,
+				}
+				command, runner := createCommand(configStr, vmmanagers.StateInfo{})
+
+				_, _, err := command.CreateVM()
+				Expect(err).To(MatchError("alibaba error importing image m-123: image is CreateFailed"))
+				Expect(callsTo(runner, "ecs RunInstances")).To(BeEmpty())
+			})
+
+			It("returns the incomplete state when the public ip cannot be associated", func() {
+				responses["vpc DescribeEipAddresses"] = []string{`{"EipAddresses": {"EipAddress": []}}`}
+				command, _ := createCommand(configStr, vmmanagers.StateInfo{})
+
+				status, state, err := command.CreateVM()
+				Expect(err).To(MatchError("alibaba error finding public IP address: 1.2.3.4 is not allocated in cn-hangzhou"))
+				Expect(status).To(Equal(vmmanagers.Incomplete))
+				Expect(state).To(Equal(vmmanagers.StateInfo{IAAS: "alibaba", ID: "i-123"}))
+			})
+
+			It("errors when the cli fails", func() {
+				command, runner := createCommand(configStr, vmmanagers.StateInfo{})
+				runner.ExecuteStub = nil
+				runner.ExecuteReturns(nil, nil, errors.New("some error"))
+
+				_, _, err := command.CreateVM()
+				Expect(err).To(MatchError("alibaba error listing existing images: some error"))
+			})
+		})
+	})
+
+	Describe("PlanCreateVM", func() {
+		It("plans the instance, its image and the eip association without calling the cli", func() {
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{})
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.ExecuteCallCount()).To(Equal(0))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanCreate))
+			Expect(plan.Resources).To(Equal([]vmmanagers.PlannedResource{
+				{
+					Action: vmmanagers.PlanCreate,
+					Type:   "instance",
+					Name:   "awesome-vm",
+					Properties: map[string]string{
+						"image":          "ops-manager-alibaba-3.0.1",
+						"instance_type":  "ecs.g6.large",
+						"vswitch":        "vsw-123",
+						"security_group": "sg-123",
+						"key_pair":       "some-key-pair",
+						"private_ip":     "10.0.0.2",
+						"public_ip":      "1.2.3.4",
+						"boot_disk":      "200GB cloud_essd",
+					},
+				},
+				{
+					Action: vmmanagers.PlanCreate,
+					Type:   "image",
+					Name:   "ops-manager-alibaba-3.0.1",
+					Properties: map[string]string{
+						"oss_object": "oss://some-bucket/ops-manager-alibaba-3.0.1.qcow2",
+						"format":     "QCOW2",
+					},
+				},
+				{Action: vmmanagers.PlanCreate, Type: "eip-association", Name: "1.2.3.4"},
+			}))
+		})
+
+		It("reports the properties of the existing instance that drifted", func() {
+			responses["ecs DescribeInstances"] = []string{`{"Instances": {"Instance": [{
+				"Status": "Running",
+				"InstanceType": "ecs.g6.xlarge",
+				"KeyPairName": "some-key-pair",
+				"VpcAttributes": {"VSwitchId": "vsw-123", "PrivateIpAddress": {"IpAddress": ["10.0.0.2"]}},
+				"EipAddress": {"IpAddress": "1.2.3.4"},
+				"SecurityGroupIds": {"SecurityGroupId": ["sg-123"]}
+			}]}}`}
+			command, _ := createCommand(configStr, vmmanagers.StateInfo{IAAS: "alibaba", ID: "i-123"})
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDrifted))
+			Expect(plan.VMID).To(Equal("i-123"))
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "instance_type", Expected: "ecs.g6.large", Actual: "ecs.g6.xlarge"},
+			}))
+		})
+	})
+
+	Describe("DeleteVM", func() {
+		It("deletes the instance and waits until it is gone", func() {
+			responses["ecs DescribeInstances"] = []string{
+				`{"Instances": {"Instance": [{"Status": "Running"}]}}`,
+				`{"Instances": {"Instance": [{"Status": "Stopping"}]}}`,
+				`{"Instances": {"Instance": []}}`,
+			}
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{IAAS: "alibaba", ID: "i-123"})
+
+			err := command.DeleteVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(callsTo(runner, "ecs DeleteInstance")).To(ConsistOf(
+				matchers.OrderedConsistOf("--RegionId", "cn-hangzhou", "--InstanceId", "i-123", "--Force", "true"),
+			))
+			Expect(callsTo(runner, "ecs DescribeInstances")).To(HaveLen(3))
+		})
+
+		It("errors when the vm does not exist", func() {
+			responses["ecs DescribeInstances"] = []string{`{"Instances": {"Instance": []}}`}
+			command, runner := createCommand(configStr, vmmanagers.StateInfo{IAAS: "alibaba", ID: "i-123"})
+
+			err := command.DeleteVM()
+			Expect(err).To(MatchError(ContainSubstring(`Could not find VM with ID "i-123"`)))
+			Expect(callsTo(runner, "ecs DeleteInstance")).To(BeEmpty())
+		})
+
+		It("errors when the state file is for another iaas", func() {
+			command, _ := createCommand(configStr, vmmanagers.StateInfo{IAAS: "aws", ID: "i-123"})
+
+			err := command.DeleteVM()
+			Expect(err).To(MatchError("authentication file provided is for alibaba, while the state file is for aws"))
+		})
+	})
+})
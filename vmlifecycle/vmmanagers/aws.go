@@ -2,6 +2,7 @@ package vmmanagers
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -93,31 +94,11 @@ func (a *AWSVMManager) CreateVM() (Status, StateInfo, error) {
 	iaasConfig := a.Config.OpsmanConfig.AWS
 	latestState := StateInfo{IAAS: "aws"}
 
-	if a.State.IAAS != "aws" && a.State.IAAS != "" {
-		return Unknown, latestState, fmt.Errorf("authentication file provided is for aws, while the state file is for %s", a.State.IAAS)
-	}
-
-	err := validateIAASConfig(iaasConfig)
-	if err != nil {
-		return Unknown, latestState, err
-	}
-
-	err = iaasConfig.validateConfig()
-	if err != nil {
-		return Unknown, latestState, err
-	}
-
-	if a.Config.OpsmanConfig.AWS.PublicIP == "" && a.Config.OpsmanConfig.AWS.PrivateIP == "" {
-		return Unknown, latestState, errors.New("PublicIP and/or PrivateIP must be set")
-	}
-
-	ami, err := amiFromRegion(iaasConfig.Region, a.ImageYaml)
+	ami, err := a.prepareCreateVM()
 	if err != nil {
 		return Unknown, latestState, err
 	}
 
-	a.addDefaultConfigFields()
-
 	exist, err := a.vmExists()
 	if err != nil {
 		return Unknown, latestState, err
@@ -156,6 +137,149 @@ func (a *AWSVMManager) CreateVM() (Status, StateInfo, error) {
 	return Success, StateInfo{IAAS: "aws", ID: instanceID}, nil
 }
 
+func (a *AWSVMManager) PlanCreateVM() (Plan, error) {
+	config := a.Config.OpsmanConfig.AWS
+
+	ami, err := a.prepareCreateVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exist, err := a.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	resources := []PlannedResource{{
+		Type: "instance",
+		Name: config.VMName,
+		Properties: map[string]string{
+			"image":           ami,
+			"instance_type":   config.InstanceType,
+			"subnet":          config.VPCSubnetId,
+			"security_groups": sortedList(config.SecurityGroupIds),
+			"key_pair":        config.KeyPairName,
+			"private_ip":      config.PrivateIP,
+			"public_ip":       config.PublicIP,
+			"boot_disk":       fmt.Sprintf("%sGB %s", config.BootDiskSize, config.BootDiskType),
+			"tags":            sortedList(a.tags()),
+		},
+	}}
+	if config.PublicIP != "" {
+		resources = append(resources, PlannedResource{
+			Type: "elastic-ip-association",
+			Name: config.PublicIP,
+		})
+	}
+
+	return planCreateVM("aws", a.State, exist, resources, a.describeVM)
+}
+
+func (a *AWSVMManager) PlanDeleteVM() (Plan, error) {
+	err := validateIAASConfig(a.Config.OpsmanConfig.AWS.AWSCredential)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if a.State.IAAS != "aws" {
+		return Plan{}, fmt.Errorf("authentication file provided is for aws, while the state file is for %s", a.State.IAAS)
+	}
+
+	_, err = a.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return planDeleteVM("aws", a.State, PlannedResource{Type: "instance", Name: a.State.ID}), nil
+}
+
+func (a *AWSVMManager) prepareCreateVM() (string, error) {
+	iaasConfig := a.Config.OpsmanConfig.AWS
+
+	if a.State.IAAS != "aws" && a.State.IAAS != "" {
+		return "", fmt.Errorf("authentication file provided is for aws, while the state file is for %s", a.State.IAAS)
+	}
+
+	err := validateIAASConfig(iaasConfig)
+	if err != nil {
+		return "", err
+	}
+
+	err = iaasConfig.validateConfig()
+	if err != nil {
+		return "", err
+	}
+
+	if iaasConfig.PublicIP == "" && iaasConfig.PrivateIP == "" {
+		return "", errors.New("PublicIP and/or PrivateIP must be set")
+	}
+
+	ami, err := amiFromRegion(iaasConfig.Region, a.ImageYaml)
+	if err != nil {
+		return "", err
+	}
+
+	a.addDefaultConfigFields()
+
+	return ami, nil
+}
+
+func (a *AWSVMManager) describeVM() (map[string]string, error) {
+	stdout, _, err := a.ExecuteWithInstanceProfile(a.addEnvVars(),
+		[]interface{}{
+			"ec2", "describe-instances",
+			"--instance-ids", a.State.ID,
+			"--query", "Reservations[0].Instances[0]",
+			"--output", "json",
+		})
+	if err != nil {
+		return nil, fmt.Errorf("aws error describing the vm: %s", err)
+	}
+
+	var instance struct {
+		ImageId          string
+		InstanceType     string
+		SubnetId         string
+		KeyName          string
+		PrivateIpAddress string
+		PublicIpAddress  string
+		SecurityGroups   []struct{ GroupId string }
+		Tags             []struct{ Key, Value string }
+	}
+	err = json.Unmarshal(stdout.Bytes(), &instance)
+	if err != nil {
+		return nil, fmt.Errorf("aws error could not parse the vm description: %s", err)
+	}
+
+	var securityGroups, tags []string
+	for _, group := range instance.SecurityGroups {
+		securityGroups = append(securityGroups, group.GroupId)
+	}
+	for _, tag := range instance.Tags {
+		tags = append(tags, fmt.Sprintf("%s=%s", tag.Key, tag.Value))
+	}
+
+	return map[string]string{
+		"image":           instance.ImageId,
+		"instance_type":   instance.InstanceType,
+		"subnet":          instance.SubnetId,
+		"security_groups": sortedList(securityGroups),
+		"key_pair":        instance.KeyName,
+		"private_ip":      instance.PrivateIpAddress,
+		"public_ip":       instance.PublicIpAddress,
+		"tags":            sortedList(tags),
+	}, nil
+}
+
+func (a *AWSVMManager) tags() []string {
+	tags := []string{fmt.Sprintf("Name=%s", a.Config.OpsmanConfig.AWS.VMName)}
+	for key, value := range a.Config.OpsmanConfig.AWS.Tags {
+		tags = append(tags, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	return tags
+}
+
 func (a *AWSVMManager) AddEnvVars() []string {
 	return a.addEnvVars()
 }
@@ -771,6 +771,88 @@ source_profile = svc-account
 region = us-east-1`, "assume-svc-account"),
 	)
 
+	Describe("PlanCreateVM", func() {
+		It("plans the instance and the public ip association without calling aws", func() {
+			command, runner := createValidCommand("1.2.3.4", "10.10.10.10", "us-west-2")
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.ExecuteWithEnvVarsCallCount()).To(Equal(0))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanCreate))
+			Expect(plan.Resources).To(Equal([]vmmanagers.PlannedResource{
+				{
+					Action: vmmanagers.PlanCreate,
+					Type:   "instance",
+					Name:   "awesome-vm",
+					Properties: map[string]string{
+						"image":           "ami-789dc900",
+						"instance_type":   "m3.large",
+						"subnet":          "awesome-subnet",
+						"security_groups": "sg-awesome,sg-great",
+						"key_pair":        "superuser",
+						"private_ip":      "10.10.10.10",
+						"public_ip":       "1.2.3.4",
+						"boot_disk":       "200GB gp3",
+						"tags":            "Name=awesome-vm,Owner=DbAdmin,Stack=Test",
+					},
+				},
+				{Action: vmmanagers.PlanCreate, Type: "elastic-ip-association", Name: "1.2.3.4"},
+			}))
+		})
+
+		It("reports the properties of the existing instance that drifted", func() {
+			command, runner := createValidCommand("1.2.3.4", "10.10.10.10", "us-west-2")
+			command.State = vmmanagers.StateInfo{IAAS: "aws", ID: vmID}
+			runner.ExecuteWithEnvVarsReturnsOnCall(0, bytes.NewBufferString(`[[ "running" ]]`), nil, nil)
+			runner.ExecuteWithEnvVarsReturnsOnCall(1, bytes.NewBufferString(`{
+				"ImageId": "ami-789dc900",
+				"InstanceType": "m5.large",
+				"SubnetId": "awesome-subnet",
+				"KeyName": "superuser",
+				"PrivateIpAddress": "10.10.10.10",
+				"PublicIpAddress": "1.2.3.4",
+				"SecurityGroups": [{"GroupId": "sg-great"}, {"GroupId": "sg-awesome"}],
+				"Tags": [{"Key": "Stack", "Value": "Test"}, {"Key": "Name", "Value": "awesome-vm"}, {"Key": "Owner", "Value": "DbAdmin"}]
+			}`), nil, nil)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, args := runner.ExecuteWithEnvVarsArgsForCall(1)
+			Expect(args).To(matchers.OrderedConsistOf(
+				"ec2", "describe-instances",
+				"--instance-ids", vmID,
+				"--query", "Reservations[0].Instances[0]",
+				"--output", "json",
+			))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDrifted))
+			Expect(plan.VMID).To(Equal(vmID))
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "instance_type", Expected: "m3.large", Actual: "m5.large"},
+			}))
+		})
+	})
+
+	Describe("PlanDeleteVM", func() {
+		It("plans to terminate the instance from the state file", func() {
+			command, runner := createValidCommand("1.2.3.4", "10.10.10.10", "us-west-2")
+			command.State = vmmanagers.StateInfo{IAAS: "aws", ID: vmID}
+			runner.ExecuteWithEnvVarsReturnsOnCall(0, bytes.NewBufferString(`[[ "running" ]]`), nil, nil)
+
+			plan, err := command.PlanDeleteVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan).To(Equal(vmmanagers.Plan{
+				IAAS:      "aws",
+				VMID:      vmID,
+				Action:    vmmanagers.PlanDelete,
+				Resources: []vmmanagers.PlannedResource{{Action: vmmanagers.PlanDelete, Type: "instance", Name: vmID}},
+			}))
+			Expect(runner.ExecuteWithEnvVarsCallCount()).To(Equal(1))
+		})
+	})
+
 	testIAASForPropertiesInExampleFile("AWS")
 })
 
@@ -203,39 +203,7 @@ func (a *AzureVMManager) DeleteVM() error {
 }
 
 func (a *AzureVMManager) CreateVM() (Status, StateInfo, error) {
-	if a.State.IAAS != "azure" && a.State.IAAS != "" {
-		return Unknown, StateInfo{}, fmt.Errorf("authentication file provided is for azure, while the state file is for %s", a.State.IAAS)
-	}
-
-	err := a.validateDeprecatedVars()
-	if err != nil {
-		return Unknown, StateInfo{}, err
-	}
-
-	if a.Config.OpsmanConfig.Azure.UseManagedDisk != "" {
-		_, err := strconv.ParseBool(a.Config.OpsmanConfig.Azure.UseManagedDisk)
-		if err != nil {
-			return Unknown, StateInfo{}, fmt.Errorf("expected \"use_managed_disk\" to be a boolean. Got: %s. %s", a.Config.OpsmanConfig.Azure.UseManagedDisk, err)
-		}
-	}
-
-	err = validateIAASConfig(a.Config.OpsmanConfig.Azure)
-	if err != nil {
-		return Unknown, StateInfo{}, err
-	}
-
-	if a.Config.OpsmanConfig.Azure.PublicIP == "" && a.Config.OpsmanConfig.Azure.PrivateIP == "" {
-		return Unknown, StateInfo{}, errors.New("PublicIP and/or PrivateIP must be set")
-	}
-
-	a.addDefaultConfigFields()
-
-	imageSourceURL, err := a.getImage()
-	if err != nil {
-		return Unknown, StateInfo{}, fmt.Errorf("azure error: %s", err)
-	}
-
-	err = a.authenticate()
+	imageSourceURL, err := a.prepareCreateVM()
 	if err != nil {
 		return Unknown, StateInfo{}, err
 	}
@@ -283,6 +251,180 @@ func (a *AzureVMManager) CreateVM() (Status, StateInfo, error) {
 	return Success, StateInfo{IAAS: "azure", ID: a.Config.OpsmanConfig.Azure.VMName}, nil
 }
 
+func (a *AzureVMManager) PlanCreateVM() (Plan, error) {
+	azure := a.Config.OpsmanConfig.Azure
+
+	imageSourceURL, err := a.prepareCreateVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exist, err := a.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	imageName := a.generateImageName(imageSourceURL)
+	resources := []PlannedResource{
+		{
+			Type: "vm",
+			Name: azure.VMName,
+			Properties: map[string]string{
+				"location":       azure.Location,
+				"resource_group": azure.ResourceGroup,
+				"vm_size":        azure.VMSize,
+				"subnet":         azure.SubnetID,
+				"nsg":            azure.NSG,
+				"private_ip":     azure.PrivateIP,
+				"public_ip":      azure.PublicIP,
+				"boot_disk_size": azure.BootDiskSize,
+				"tags":           sortedList(ParseTagsString(azure.Tags)),
+			},
+		},
+		{
+			Type: "storage-blob",
+			Name: fmt.Sprintf("%s/%s.vhd", azure.Container, imageName),
+			Properties: map[string]string{
+				"source": imageSourceURL,
+			},
+		},
+	}
+	if azure.UseUnmanagedDiskDEPRECATED != "true" && azure.UseManagedDisk != "false" {
+		resources = append(resources, PlannedResource{
+			Type: "image",
+			Name: imageName,
+			Properties: map[string]string{
+				"storage_sku": azure.StorageSKU,
+			},
+		})
+	}
+
+	return planCreateVM("azure", a.State, exist, resources, a.describeVM)
+}
+
+func (a *AzureVMManager) PlanDeleteVM() (Plan, error) {
+	err := validateIAASConfig(a.Config.OpsmanConfig.Azure.AzureCredential)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	a.addDefaultConfigFields()
+
+	err = a.authenticate()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if a.State.IAAS != "azure" {
+		return Plan{}, fmt.Errorf("authentication file provided is for azure, while the state file is for %s", a.State.IAAS)
+	}
+
+	_, err = a.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	azVMInfo, err := a.getVMInfo()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	resources := []PlannedResource{{Type: "vm", Name: a.State.ID}}
+	if azVMInfo.StorageProfile.OSDisk.ManagedDisk.ID != "" {
+		resources = append(resources, PlannedResource{Type: "managed-disk", Name: azVMInfo.StorageProfile.OSDisk.ManagedDisk.ID})
+	} else if azVMInfo.StorageProfile.OSDisk.VHD.URI != "" {
+		resources = append(resources, PlannedResource{Type: "storage-blob", Name: azVMInfo.StorageProfile.OSDisk.VHD.URI})
+	}
+	for _, nic := range azVMInfo.NetworkProfile.NetworkInterfaces {
+		resources = append(resources, PlannedResource{Type: "nic", Name: nic.ID})
+	}
+	if azVMInfo.StorageProfile.ImageReference.ID != "" {
+		resources = append(resources, PlannedResource{Type: "image", Name: azVMInfo.StorageProfile.ImageReference.ID})
+	}
+
+	return planDeleteVM("azure", a.State, resources...), nil
+}
+
+func (a *AzureVMManager) prepareCreateVM() (string, error) {
+	if a.State.IAAS != "azure" && a.State.IAAS != "" {
+		return "", fmt.Errorf("authentication file provided is for azure, while the state file is for %s", a.State.IAAS)
+	}
+
+	err := a.validateDeprecatedVars()
+	if err != nil {
+		return "", err
+	}
+
+	if a.Config.OpsmanConfig.Azure.UseManagedDisk != "" {
+		_, err := strconv.ParseBool(a.Config.OpsmanConfig.Azure.UseManagedDisk)
+		if err != nil {
+			return "", fmt.Errorf("expected \"use_managed_disk\" to be a boolean. Got: %s. %s", a.Config.OpsmanConfig.Azure.UseManagedDisk, err)
+		}
+	}
+
+	err = validateIAASConfig(a.Config.OpsmanConfig.Azure)
+	if err != nil {
+		return "", err
+	}
+
+	if a.Config.OpsmanConfig.Azure.PublicIP == "" && a.Config.OpsmanConfig.Azure.PrivateIP == "" {
+		return "", errors.New("PublicIP and/or PrivateIP must be set")
+	}
+
+	a.addDefaultConfigFields()
+
+	imageSourceURL, err := a.getImage()
+	if err != nil {
+		return "", fmt.Errorf("azure error: %s", err)
+	}
+
+	return imageSourceURL, a.authenticate()
+}
+
+func (a *AzureVMManager) describeVM() (map[string]string, error) {
+	out, _, err := a.runner.ExecuteWithEnvVars(a.addEnvVars(),
+		[]interface{}{
+			"vm", "show",
+			"--show-details",
+			"--name", a.State.ID,
+			"--resource-group", a.Config.OpsmanConfig.Azure.ResourceGroup,
+		})
+	if err != nil {
+		return nil, fmt.Errorf("azure error getting vm info: %s", err)
+	}
+
+	var vm struct {
+		PrivateIps      string            `json:"privateIps"`
+		PublicIps       string            `json:"publicIps"`
+		Tags            map[string]string `json:"tags"`
+		HardwareProfile struct {
+			VMSize string `json:"vmSize"`
+		} `json:"hardwareProfile"`
+		StorageProfile struct {
+			OSDisk struct {
+				DiskSizeGB int `json:"diskSizeGb"`
+			} `json:"osDisk"`
+		} `json:"storageProfile"`
+	}
+	err = json.Unmarshal(out.Bytes(), &vm)
+	if err != nil {
+		return nil, fmt.Errorf("azure error unmarshalling vm info: %s", err)
+	}
+
+	var tags []string
+	for key, value := range vm.Tags {
+		tags = append(tags, fmt.Sprintf("%s=%s", key, value))
+	}
+
+	return map[string]string{
+		"vm_size":        vm.HardwareProfile.VMSize,
+		"private_ip":     vm.PrivateIps,
+		"public_ip":      vm.PublicIps,
+		"boot_disk_size": strconv.Itoa(vm.StorageProfile.OSDisk.DiskSizeGB),
+		"tags":           sortedList(tags),
+	}, nil
+}
+
 func (a *AzureVMManager) addEnvVars() []string {
 	azure := a.Config.OpsmanConfig.Azure
 
@@ -1230,6 +1230,111 @@ opsman-configuration:
 		})
 	})
 
+	Describe("PlanCreateVM", func() {
+		const configStrTemplate = `
+opsman-configuration:
+  azure:
+    vm_name: vm-name
+    subscription_id: subscription
+    resource_group: rg
+    tenant_id: tenant
+    client_id: client
+    client_secret: secret
+    location: %s
+    public_ip: 1.2.3.4
+    private_ip: 10.0.0.3
+    boot_disk_size: 200
+    network_security_group: nsg
+    subnet_id: /subscriptions/sub-guid/resourceGroups/network-resource-group/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/sub1
+    storage_account: account
+    ssh_public_key: asdfasdfs
+    tags: Project=ECommerce Team=Web
+`
+
+		It("plans the vm, the image blob and the image", func() {
+			command, runner, _ := createCommand("westus", configStrTemplate, vmmanagers.StateInfo{})
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.ExecuteWithEnvVarsCallCount()).To(Equal(3))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanCreate))
+			Expect(plan.Resources).To(HaveLen(3))
+			Expect(plan.Resources[0]).To(Equal(vmmanagers.PlannedResource{
+				Action: vmmanagers.PlanCreate,
+				Type:   "vm",
+				Name:   "vm-name",
+				Properties: map[string]string{
+					"location":       "westus",
+					"resource_group": "rg",
+					"vm_size":        "Standard_DS2_v2",
+					"subnet":         "/subscriptions/sub-guid/resourceGroups/network-resource-group/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/sub1",
+					"nsg":            "nsg",
+					"private_ip":     "10.0.0.3",
+					"public_ip":      "1.2.3.4",
+					"boot_disk_size": "200",
+					"tags":           "Project=ECommerce,Team=Web",
+				},
+			}))
+			Expect(plan.Resources[1].Type).To(Equal("storage-blob"))
+			Expect(plan.Resources[1].Name).To(MatchRegexp(`^opsmanagerimage/opsman-image-[0-9a-f]+\.vhd$`))
+			Expect(plan.Resources[1].Properties).To(HaveKeyWithValue("source", "https://opsmanagerwestus.blob.core.windows.net/images/ops-manager-2.2-build.292.vhd"))
+			Expect(plan.Resources[2].Type).To(Equal("image"))
+		})
+
+		It("reports the properties of the existing vm that drifted", func() {
+			command, runner, _ := createCommand("westus", configStrTemplate, vmmanagers.StateInfo{IAAS: "azure", ID: "vm-name"})
+			runner.ExecuteWithEnvVarsReturnsOnCall(3, bytes.NewBufferString(`"some-id"`), nil, nil)
+			runner.ExecuteWithEnvVarsReturnsOnCall(4, bytes.NewBufferString(`{
+				"privateIps": "10.0.0.3",
+				"publicIps": "1.2.3.5",
+				"tags": {"Team": "Web", "Project": "ECommerce"},
+				"hardwareProfile": {"vmSize": "Standard_DS2_v2"},
+				"storageProfile": {"osDisk": {"diskSizeGb": 200}}
+			}`), nil, nil)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, args := runner.ExecuteWithEnvVarsArgsForCall(4)
+			Expect(args).To(matchers.OrderedConsistOf("vm", "show", "--show-details", "--name", "vm-name", "--resource-group", "rg"))
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDrifted))
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "public_ip", Expected: "1.2.3.4", Actual: "1.2.3.5"},
+			}))
+		})
+	})
+
+	Describe("PlanDeleteVM", func() {
+		It("plans to delete the vm and the resources attached to it", func() {
+			command, runner, _ := createCommand("westus", `
+opsman-configuration:
+  azure:
+    subscription_id: subscription
+    resource_group: rg
+    tenant_id: tenant
+    client_id: client
+    client_secret: secret
+    location: %s
+    storage_account: account
+`, vmmanagers.StateInfo{IAAS: "azure", ID: "vm-name"})
+			runner.ExecuteWithEnvVarsReturnsOnCall(3, bytes.NewBufferString(`"some-id"`), nil, nil)
+			runner.ExecuteWithEnvVarsReturnsOnCall(4, bytes.NewBufferString(`{
+				"networkProfile": {"networkInterfaces": [{"id": "nic-id"}]},
+				"storageProfile": {"imageReference": {"id": "image-id"}, "osDisk": {"managedDisk": {"id": "disk-id"}}}
+			}`), nil, nil)
+
+			plan, err := command.PlanDeleteVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.Resources).To(Equal([]vmmanagers.PlannedResource{
+				{Action: vmmanagers.PlanDelete, Type: "vm", Name: "vm-name"},
+				{Action: vmmanagers.PlanDelete, Type: "managed-disk", Name: "disk-id"},
+				{Action: vmmanagers.PlanDelete, Type: "nic", Name: "nic-id"},
+				{Action: vmmanagers.PlanDelete, Type: "image", Name: "image-id"},
+			}))
+		})
+	})
+
 	testIAASForPropertiesInExampleFile("Azure")
 })
 
@@ -22,6 +22,18 @@ type CreateVMService struct {
 		result2 vmmanagers.StateInfo
 		result3 error
 	}
+	PlanCreateVMStub        func() (vmmanagers.Plan, error)
+	planCreateVMMutex       sync.RWMutex
+	planCreateVMArgsForCall []struct {
+	}
+	planCreateVMReturns struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}
+	planCreateVMReturnsOnCall map[int]struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -84,11 +96,68 @@ func (fake *CreateVMService) CreateVMReturnsOnCall(i int, result1 vmmanagers.Sta
 	}{result1, result2, result3}
 }
 
+func (fake *CreateVMService) PlanCreateVM() (vmmanagers.Plan, error) {
+	fake.planCreateVMMutex.Lock()
+	ret, specificReturn := fake.planCreateVMReturnsOnCall[len(fake.planCreateVMArgsForCall)]
+	fake.planCreateVMArgsForCall = append(fake.planCreateVMArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PlanCreateVM", []interface{}{})
+	fake.planCreateVMMutex.Unlock()
+	if fake.PlanCreateVMStub != nil {
+		return fake.PlanCreateVMStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.planCreateVMReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CreateVMService) PlanCreateVMCallCount() int {
+	fake.planCreateVMMutex.RLock()
+	defer fake.planCreateVMMutex.RUnlock()
+	return len(fake.planCreateVMArgsForCall)
+}
+
+func (fake *CreateVMService) PlanCreateVMCalls(stub func() (vmmanagers.Plan, error)) {
+	fake.planCreateVMMutex.Lock()
+	defer fake.planCreateVMMutex.Unlock()
+	fake.PlanCreateVMStub = stub
+}
+
+func (fake *CreateVMService) PlanCreateVMReturns(result1 vmmanagers.Plan, result2 error) {
+	fake.planCreateVMMutex.Lock()
+	defer fake.planCreateVMMutex.Unlock()
+	fake.PlanCreateVMStub = nil
+	fake.planCreateVMReturns = struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CreateVMService) PlanCreateVMReturnsOnCall(i int, result1 vmmanagers.Plan, result2 error) {
+	fake.planCreateVMMutex.Lock()
+	defer fake.planCreateVMMutex.Unlock()
+	fake.PlanCreateVMStub = nil
+	if fake.planCreateVMReturnsOnCall == nil {
+		fake.planCreateVMReturnsOnCall = make(map[int]struct {
+			result1 vmmanagers.Plan
+			result2 error
+		})
+	}
+	fake.planCreateVMReturnsOnCall[i] = struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CreateVMService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.createVMMutex.RLock()
 	defer fake.createVMMutex.RUnlock()
+	fake.planCreateVMMutex.RLock()
+	defer fake.planCreateVMMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
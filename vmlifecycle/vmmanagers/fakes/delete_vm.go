@@ -18,6 +18,18 @@ type DeleteVMService struct {
 	deleteVMReturnsOnCall map[int]struct {
 		result1 error
 	}
+	PlanDeleteVMStub        func() (vmmanagers.Plan, error)
+	planDeleteVMMutex       sync.RWMutex
+	planDeleteVMArgsForCall []struct {
+	}
+	planDeleteVMReturns struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}
+	planDeleteVMReturnsOnCall map[int]struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -74,11 +86,68 @@ func (fake *DeleteVMService) DeleteVMReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *DeleteVMService) PlanDeleteVM() (vmmanagers.Plan, error) {
+	fake.planDeleteVMMutex.Lock()
+	ret, specificReturn := fake.planDeleteVMReturnsOnCall[len(fake.planDeleteVMArgsForCall)]
+	fake.planDeleteVMArgsForCall = append(fake.planDeleteVMArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PlanDeleteVM", []interface{}{})
+	fake.planDeleteVMMutex.Unlock()
+	if fake.PlanDeleteVMStub != nil {
+		return fake.PlanDeleteVMStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.planDeleteVMReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *DeleteVMService) PlanDeleteVMCallCount() int {
+	fake.planDeleteVMMutex.RLock()
+	defer fake.planDeleteVMMutex.RUnlock()
+	return len(fake.planDeleteVMArgsForCall)
+}
+
+func (fake *DeleteVMService) PlanDeleteVMCalls(stub func() (vmmanagers.Plan, error)) {
+	fake.planDeleteVMMutex.Lock()
+	defer fake.planDeleteVMMutex.Unlock()
+	fake.PlanDeleteVMStub = stub
+}
+
+func (fake *DeleteVMService) PlanDeleteVMReturns(result1 vmmanagers.Plan, result2 error) {
+	fake.planDeleteVMMutex.Lock()
+	defer fake.planDeleteVMMutex.Unlock()
+	fake.PlanDeleteVMStub = nil
+	fake.planDeleteVMReturns = struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *DeleteVMService) PlanDeleteVMReturnsOnCall(i int, result1 vmmanagers.Plan, result2 error) {
+	fake.planDeleteVMMutex.Lock()
+	defer fake.planDeleteVMMutex.Unlock()
+	fake.PlanDeleteVMStub = nil
+	if fake.planDeleteVMReturnsOnCall == nil {
+		fake.planDeleteVMReturnsOnCall = make(map[int]struct {
+			result1 vmmanagers.Plan
+			result2 error
+		})
+	}
+	fake.planDeleteVMReturnsOnCall[i] = struct {
+		result1 vmmanagers.Plan
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *DeleteVMService) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.deleteVMMutex.RLock()
 	defer fake.deleteVMMutex.RUnlock()
+	fake.planDeleteVMMutex.RLock()
+	defer fake.planDeleteVMMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,10 +2,12 @@ package vmmanagers
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	yaml "gopkg.in/yaml.v2"
@@ -101,60 +103,199 @@ func (g *GCPVMManager) DeleteVM() error {
 }
 
 func (g *GCPVMManager) CreateVM() (Status, StateInfo, error) {
+	err := g.prepareCreateVM()
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+
+	exist, err := g.vmExists()
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+	if exist {
+		return Exist, g.State, nil
+	}
+
+	err = g.forceRecreateImage()
+	if err != nil {
+		return Unknown, StateInfo{}, err
+	}
+
+	return g.createVM(g.Config.OpsmanConfig.GCP.PublicIP)
+}
+
+func (g *GCPVMManager) PlanCreateVM() (Plan, error) {
+	config := g.Config.OpsmanConfig.GCP
+
+	err := g.prepareCreateVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exist, err := g.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	resources := []PlannedResource{
+		{
+			Type: "instance",
+			Name: config.VMName,
+			Properties: map[string]string{
+				"zone":           config.Zone,
+				"image":          config.VMName + "-image",
+				"machine_type":   g.machineType(),
+				"boot_disk_size": config.BootDiskSize,
+				"subnet":         lastPathSegment(config.VpcSubnet),
+				"private_ip":     config.PrivateIP,
+				"public_ip":      config.PublicIP,
+				"tags":           sortedList(strings.Split(config.Tags, ",")),
+				"hostname":       config.Hostname,
+			},
+		},
+		{
+			Type: "image",
+			Name: config.VMName + "-image",
+			Properties: map[string]string{
+				"source_uri": "https://storage.googleapis.com/" + g.imageUriMap.get("us"),
+			},
+		},
+	}
+
+	return planCreateVM("gcp", g.State, exist, resources, g.describeVM)
+}
+
+func (g *GCPVMManager) PlanDeleteVM() (Plan, error) {
+	err := validateIAASConfig(g.Config.OpsmanConfig.GCP.GCPCredential)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if g.State.IAAS != "gcp" {
+		return Plan{}, fmt.Errorf("authentication file provided is for gcp, while the state file is for %s", g.State.IAAS)
+	}
+
+	err = g.authenticate()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	err = g.setProject()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	_, err = g.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return planDeleteVM("gcp", g.State,
+		PlannedResource{Type: "instance", Name: g.State.ID},
+		PlannedResource{Type: "image", Name: g.State.ID + "-image"},
+	), nil
+}
+
+func (g *GCPVMManager) prepareCreateVM() error {
 	if g.State.IAAS != "gcp" && g.State.IAAS != "" {
-		return Unknown, StateInfo{}, fmt.Errorf("authentication file provided is for gcp, while the state file is for %s", g.State.IAAS)
+		return fmt.Errorf("authentication file provided is for gcp, while the state file is for %s", g.State.IAAS)
 	}
 
 	err := validateIAASConfig(g.Config.OpsmanConfig.GCP)
 	if err != nil {
-		return Unknown, StateInfo{}, err
+		return err
 	}
 
 	if g.Config.OpsmanConfig.GCP.PublicIP == "" && g.Config.OpsmanConfig.GCP.PrivateIP == "" {
-		return Unknown, StateInfo{}, errors.New("PublicIP and/or PrivateIP must be set")
+		return errors.New("PublicIP and/or PrivateIP must be set")
 	}
 
 	if g.Config.OpsmanConfig.GCP.ServiceAccount == "" && g.Config.OpsmanConfig.GCP.ServiceAccountName == "" {
-		return Unknown, StateInfo{}, errors.New("gcp_service_account or gcp_service_account_name must be set")
+		return errors.New("gcp_service_account or gcp_service_account_name must be set")
 	}
 
 	imageUriMap, err := loadImageYaml(g.ImageYaml)
 	g.imageUriMap = imageUriMap
 	if err != nil {
-		return Unknown, StateInfo{}, err
+		return err
 	}
 
 	g.addDefaultConfigFields()
 
 	err = g.authenticate()
 	if err != nil {
-		return Unknown, StateInfo{}, err
+		return err
 	}
 
 	err = g.setProject()
 	if err != nil {
-		return Unknown, StateInfo{}, err
+		return err
 	}
 
-	err = g.setComputeRegion()
+	return g.setComputeRegion()
+}
+
+func (g *GCPVMManager) describeVM() (map[string]string, error) {
+	stdout, _, err := g.gcloudRunner.Execute([]interface{}{
+		"compute", "instances", "describe",
+		g.State.ID,
+		"--zone", g.Config.OpsmanConfig.GCP.Zone,
+		"--format", "json",
+	})
 	if err != nil {
-		return Unknown, StateInfo{}, err
+		return nil, fmt.Errorf("gcloud error describing the VM: %s", err)
+	}
+
+	var instance struct {
+		MachineType       string `json:"machineType"`
+		Hostname          string `json:"hostname"`
+		NetworkInterfaces []struct {
+			NetworkIP     string `json:"networkIP"`
+			Subnetwork    string `json:"subnetwork"`
+			AccessConfigs []struct {
+				NatIP string `json:"natIP"`
+			} `json:"accessConfigs"`
+		} `json:"networkInterfaces"`
+		Tags struct {
+			Items []string `json:"items"`
+		} `json:"tags"`
+	}
+	err = json.Unmarshal(stdout.Bytes(), &instance)
+	if err != nil {
+		return nil, fmt.Errorf("gcloud error could not parse the VM description: %s", err)
 	}
 
-	exist, err := g.vmExists()
-	if err != nil {
-		return Unknown, StateInfo{}, err
+	actual := map[string]string{
+		"machine_type": lastPathSegment(instance.MachineType),
+		"tags":         sortedList(instance.Tags.Items),
+		"hostname":     instance.Hostname,
 	}
-	if exist {
-		return Exist, g.State, nil
+	if len(instance.NetworkInterfaces) > 0 {
+		nic := instance.NetworkInterfaces[0]
+		actual["subnet"] = lastPathSegment(nic.Subnetwork)
+		actual["private_ip"] = nic.NetworkIP
+		actual["public_ip"] = ""
+		if len(nic.AccessConfigs) > 0 {
+			actual["public_ip"] = nic.AccessConfigs[0].NatIP
+		}
 	}
 
-	err = g.forceRecreateImage()
+	return actual, nil
+}
+
+// machineType is the name GCP gives the custom machine type, with the memory
+// configured in GB reported in MB.
+func (g *GCPVMManager) machineType() string {
+	memory, err := strconv.ParseFloat(g.Config.OpsmanConfig.GCP.Memory, 64)
 	if err != nil {
-		return Unknown, StateInfo{}, err
+		return ""
 	}
 
-	return g.createVM(g.Config.OpsmanConfig.GCP.PublicIP)
+	return fmt.Sprintf("custom-%s-%d", g.Config.OpsmanConfig.GCP.CPU, int(memory*1024))
+}
+
+func lastPathSegment(s string) string {
+	return s[strings.LastIndex(s, "/")+1:]
 }
 
 func (g *GCPVMManager) authenticate() error {
@@ -627,5 +627,106 @@ opsman-configuration:
 		})
 	})
 
+	Describe("PlanCreateVM", func() {
+		const configStrTemplate = `
+opsman-configuration:
+  gcp:
+    gcp_service_account: something
+    project: dummy-project
+    region: %s
+    zone: us-west1-c
+    vm_name: opsman-vm
+    vpc_subnet: dummy-subnet
+    tags: good,better
+    custom_cpu: 8
+    custom_memory: 16
+    boot_disk_size: 400
+    private_ip: 10.0.0.2
+`
+
+		It("plans the instance and its image", func() {
+			command, runner := createCommand("us-west1", configStrTemplate)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.ExecuteCallCount()).To(Equal(3))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanCreate))
+			Expect(plan.Resources).To(Equal([]vmmanagers.PlannedResource{
+				{
+					Action: vmmanagers.PlanCreate,
+					Type:   "instance",
+					Name:   "opsman-vm",
+					Properties: map[string]string{
+						"zone":           "us-west1-c",
+						"image":          "opsman-vm-image",
+						"machine_type":   "custom-8-16384",
+						"boot_disk_size": "400",
+						"subnet":         "dummy-subnet",
+						"private_ip":     "10.0.0.2",
+						"public_ip":      "",
+						"tags":           "better,good",
+						"hostname":       "",
+					},
+				},
+				{
+					Action:     vmmanagers.PlanCreate,
+					Type:       "image",
+					Name:       "opsman-vm-image",
+					Properties: map[string]string{"source_uri": "https://storage.googleapis.com/ops-manager-us-uri.tar.gz"},
+				},
+			}))
+		})
+
+		It("reports the properties of the existing instance that drifted", func() {
+			command, runner := createCommand("us-west1", configStrTemplate)
+			command.State.ID = "opsman-vm"
+			runner.ExecuteReturnsOnCall(4, bytes.NewBufferString(`{
+				"machineType": "https://www.googleapis.com/compute/v1/projects/dummy-project/zones/us-west1-c/machineTypes/custom-4-16384",
+				"networkInterfaces": [{
+					"networkIP": "10.0.0.2",
+					"subnetwork": "https://www.googleapis.com/compute/v1/projects/dummy-project/regions/us-west1/subnetworks/dummy-subnet",
+					"accessConfigs": [{"natIP": "35.1.2.3"}]
+				}],
+				"tags": {"items": ["good", "better"]}
+			}`), nil, nil)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(runner.ExecuteArgsForCall(4)).To(matchers.OrderedConsistOf(
+				"compute", "instances", "describe", "opsman-vm",
+				"--zone", "us-west1-c",
+				"--format", "json",
+			))
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDrifted))
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "machine_type", Expected: "custom-8-16384", Actual: "custom-4-16384"},
+			}))
+		})
+	})
+
+	Describe("PlanDeleteVM", func() {
+		It("plans to delete the instance and its image", func() {
+			command, _ := createCommand("us-west1", `
+opsman-configuration:
+  gcp:
+    gcp_service_account: something
+    project: dummy-project
+    region: %s
+    zone: us-west1-c
+`)
+			command.State.ID = "opsman-vm"
+
+			plan, err := command.PlanDeleteVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDelete))
+			Expect(plan.Resources).To(Equal([]vmmanagers.PlannedResource{
+				{Action: vmmanagers.PlanDelete, Type: "instance", Name: "opsman-vm"},
+				{Action: vmmanagers.PlanDelete, Type: "image", Name: "opsman-vm-image"},
+			}))
+		})
+	})
+
 	testIAASForPropertiesInExampleFile("GCP")
 })
@@ -14,17 +14,21 @@ import (
 
 type vmManager interface {
 	CreateVM() (status Status, state StateInfo, err error)
+	PlanCreateVM() (Plan, error)
 	DeleteVM() error
+	PlanDeleteVM() (Plan, error)
 }
 
 //go:generate counterfeiter -o ./fakes/delete_vm.go --fake-name DeleteVMService . DeleteVMService
 type DeleteVMService interface {
 	DeleteVM() error
+	PlanDeleteVM() (Plan, error)
 }
 
 //go:generate counterfeiter -o ./fakes/create_vm.go --fake-name CreateVMService . CreateVMService
 type CreateVMService interface {
 	CreateVM() (Status, StateInfo, error)
+	PlanCreateVM() (Plan, error)
 }
 
 func NewDeleteVMManager(config *OpsmanConfigFilePayload, image string, state StateInfo, outWriter, errWriter io.Writer) (DeleteVMService, error) {
@@ -121,6 +125,22 @@ func initializeVMManager(config *OpsmanConfigFilePayload, image string, state St
 		), nil
 	}
 
+	if config.OpsmanConfig.Alibaba != nil {
+		_, _ = outWriter.Write([]byte(fmt.Sprintln("Using alibaba...")))
+		alibabaCLI, err := runner.NewRunner("aliyun", outWriter, errWriter)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewAlibabaVMManager(
+			config,
+			image,
+			state,
+			alibabaCLI,
+			5*time.Second,
+		), nil
+	}
+
 	return nil, errors.New("unexpected error")
 }
 
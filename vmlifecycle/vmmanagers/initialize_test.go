@@ -86,6 +86,21 @@ var _ = Describe("select the correct vmmanager instance given the correct config
 		})
 	})
 
+	Context("valid alibaba config", func() {
+		It("returns the alibaba vmmanager instance", func() {
+			configContent := &vmmanagers.OpsmanConfigFilePayload{
+				OpsmanConfig: vmmanagers.OpsmanConfig{Alibaba: &vmmanagers.AlibabaConfig{}},
+			}
+			create, err := vmmanagers.NewCreateVMManager(configContent, "", vmmanagers.StateInfo{}, gbytes.NewBuffer(), gbytes.NewBuffer())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reflect.TypeOf(create)).To(Equal(reflect.TypeOf(&vmmanagers.AlibabaVMManager{})))
+
+			delete, err := vmmanagers.NewDeleteVMManager(configContent, "", vmmanagers.StateInfo{}, gbytes.NewBuffer(), gbytes.NewBuffer())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(reflect.TypeOf(delete)).To(Equal(reflect.TypeOf(&vmmanagers.AlibabaVMManager{})))
+		})
+	})
+
 	Describe("failure cases", func() {
 		When("there are multiple iaas", func() {
 			It("returns an error", func() {
@@ -96,6 +111,7 @@ var _ = Describe("select the correct vmmanager instance given the correct config
 						AWS       *vmmanagers.AWSConfig       `yaml:"aws,omitempty"`
 						Azure     *vmmanagers.AzureConfig     `yaml:"azure,omitempty"`
 						Openstack *vmmanagers.OpenstackConfig `yaml:"openstack,omitempty"`
+						Alibaba   *vmmanagers.AlibabaConfig   `yaml:"alibaba,omitempty"`
 						Unknown   map[string]interface{}      `yaml:",inline"`
 					}{GCP: nil, Vsphere: nil, AWS: nil, Azure: &vmmanagers.AzureConfig{}, Openstack: &vmmanagers.OpenstackConfig{}},
 				}
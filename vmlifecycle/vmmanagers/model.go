@@ -26,6 +26,7 @@ type OpsmanConfig struct {
 	AWS       *AWSConfig             `yaml:"aws,omitempty"`
 	Azure     *AzureConfig           `yaml:"azure,omitempty"`
 	Openstack *OpenstackConfig       `yaml:"openstack,omitempty"`
+	Alibaba   *AlibabaConfig         `yaml:"alibaba,omitempty"`
 	Unknown   map[string]interface{} `yaml:",inline"`
 }
 
@@ -2,6 +2,7 @@ package vmmanagers
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -34,6 +35,7 @@ type OpenstackConfig struct {
 	SecurityGroup       string `yaml:"security_group_name" validate:"required"`
 	KeyName             string `yaml:"key_pair_name" validate:"required"`
 	AvailabilityZone    string `yaml:"availability_zone"`
+	PortName            string `yaml:"port_name"`
 }
 
 //go:generate counterfeiter -o ./fakes/openstackRunner.go --fake-name OpenstackRunner . openstackRunner
@@ -84,25 +86,11 @@ func (o *OpenstackVMManager) DeleteVM() error {
 }
 
 func (o *OpenstackVMManager) CreateVM() (Status, StateInfo, error) {
-	if o.State.IAAS != "openstack" && o.State.IAAS != "" {
-		return Unknown, StateInfo{}, fmt.Errorf("authentication file provided is for openstack, while the state file is for %s", o.State.IAAS)
-	}
-
-	err := validateIAASConfig(o.Config)
+	err := o.prepareCreateVM()
 	if err != nil {
 		return Unknown, StateInfo{}, err
 	}
 
-	if o.Config.PublicIP == "" && o.Config.PrivateIP == "" {
-		return Unknown, StateInfo{}, errors.New("PublicIP and/or PrivateIP must be set")
-	}
-
-	o.addDefaultConfigFields()
-
-	if _, err := os.Stat(o.Image); os.IsNotExist(err) {
-		return Unknown, StateInfo{}, fmt.Errorf("could not read image file: %s", err)
-	}
-
 	exist, err := o.vmExists()
 	if err != nil {
 		return Unknown, StateInfo{}, err
@@ -116,7 +104,15 @@ func (o *OpenstackVMManager) CreateVM() (Status, StateInfo, error) {
 		return Unknown, StateInfo{}, err
 	}
 
-	serverID, _, err := o.createVM(imageID)
+	var portID string
+	if o.Config.PortName != "" {
+		portID, err = o.ensurePort()
+		if err != nil {
+			return Unknown, StateInfo{}, err
+		}
+	}
+
+	serverID, _, err := o.createVM(imageID, portID)
 	if err != nil {
 		return Unknown, StateInfo{}, fmt.Errorf("openstack error creating the vm: %s", err)
 	}
@@ -124,7 +120,11 @@ func (o *OpenstackVMManager) CreateVM() (Status, StateInfo, error) {
 	fullState := StateInfo{IAAS: "openstack", ID: serverID}
 
 	if o.Config.PublicIP != "" {
-		err = o.attachIP(serverID)
+		if portID != "" {
+			err = o.associateIP(portID)
+		} else {
+			err = o.attachIP(serverID)
+		}
 		if err != nil {
 			return Incomplete, fullState, err
 		}
@@ -133,6 +133,154 @@ func (o *OpenstackVMManager) CreateVM() (Status, StateInfo, error) {
 	return Success, fullState, nil
 }
 
+func (o *OpenstackVMManager) PlanCreateVM() (Plan, error) {
+	err := o.prepareCreateVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exist, err := o.vmExists()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	resources := []PlannedResource{
+		{
+			Type: "server",
+			Name: o.Config.VMName,
+			Properties: map[string]string{
+				"image":             fmt.Sprintf("%s-image", o.Config.VMName),
+				"flavor":            o.Config.Flavor,
+				"network":           o.Config.NetID,
+				"security_group":    o.Config.SecurityGroup,
+				"key_pair":          o.Config.KeyName,
+				"availability_zone": o.Config.AvailabilityZone,
+				"private_ip":        o.Config.PrivateIP,
+				"public_ip":         o.Config.PublicIP,
+			},
+		},
+		{
+			Type: "image",
+			Name: fmt.Sprintf("%s-image", o.Config.VMName),
+			Properties: map[string]string{
+				"file": o.Image,
+			},
+		},
+	}
+	if o.Config.PortName != "" {
+		resources = append(resources, PlannedResource{
+			Type: "port",
+			Name: o.Config.PortName,
+			Properties: map[string]string{
+				"network":    o.Config.NetID,
+				"private_ip": o.Config.PrivateIP,
+			},
+		})
+	}
+	if o.Config.PublicIP != "" {
+		resources = append(resources, PlannedResource{
+			Type: "floating-ip-association",
+			Name: o.Config.PublicIP,
+		})
+	}
+
+	return planCreateVM("openstack", o.State, exist, resources, o.describeVM)
+}
+
+func (o *OpenstackVMManager) PlanDeleteVM() (Plan, error) {
+	err := validateIAASConfig(o.Config.OpenstackCredential)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if o.State.IAAS != "openstack" {
+		return Plan{}, fmt.Errorf("authentication file provided is for openstack, while the state file is for %s", o.State.IAAS)
+	}
+
+	imageID, err := o.getVMImage()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return planDeleteVM("openstack", o.State,
+		PlannedResource{Type: "server", Name: o.State.ID},
+		PlannedResource{Type: "image", Name: imageID},
+	), nil
+}
+
+func (o *OpenstackVMManager) prepareCreateVM() error {
+	if o.State.IAAS != "openstack" && o.State.IAAS != "" {
+		return fmt.Errorf("authentication file provided is for openstack, while the state file is for %s", o.State.IAAS)
+	}
+
+	err := validateIAASConfig(o.Config)
+	if err != nil {
+		return err
+	}
+
+	if o.Config.PublicIP == "" && o.Config.PrivateIP == "" {
+		return errors.New("PublicIP and/or PrivateIP must be set")
+	}
+
+	o.addDefaultConfigFields()
+
+	if _, err := os.Stat(o.Image); os.IsNotExist(err) {
+		return fmt.Errorf("could not read image file: %s", err)
+	}
+
+	return nil
+}
+
+func (o *OpenstackVMManager) describeVM() (map[string]string, error) {
+	args := append(o.getAuthArguments(), `server`, `show`, o.State.ID,
+		`--format`, `json`)
+
+	stdout, _, err := o.runner.Execute(args)
+	if err != nil {
+		return nil, fmt.Errorf("openstack error describing the vm: %s", err)
+	}
+
+	var server struct {
+		Flavor           interface{} `json:"flavor"`
+		KeyName          string      `json:"key_name"`
+		AvailabilityZone string      `json:"OS-EXT-AZ:availability_zone"`
+		Addresses        interface{} `json:"addresses"`
+	}
+	err = json.Unmarshal(stdout.Bytes(), &server)
+	if err != nil {
+		return nil, fmt.Errorf("openstack error could not parse the vm description: %s", err)
+	}
+
+	// depending on the client version, the flavor is "name (id)" or an object
+	flavor := fmt.Sprint(server.Flavor)
+	if details, ok := server.Flavor.(map[string]interface{}); ok {
+		flavor = fmt.Sprint(details["name"])
+	}
+	flavor = strings.SplitN(flavor, " (", 2)[0]
+
+	actual := map[string]string{
+		"flavor":            flavor,
+		"key_pair":          server.KeyName,
+		"availability_zone": server.AvailabilityZone,
+	}
+
+	// the addresses are only compared for presence, their format differs
+	// between client versions as well
+	addresses := fmt.Sprint(server.Addresses)
+	if !hasAddress(addresses, o.Config.PrivateIP) {
+		actual["private_ip"] = addresses
+	}
+	if !hasAddress(addresses, o.Config.PublicIP) {
+		actual["public_ip"] = addresses
+	}
+
+	return actual, nil
+}
+
+func hasAddress(addresses string, ip string) bool {
+	return ip == "" || regexp.MustCompile(`\b`+regexp.QuoteMeta(ip)+`\b`).MatchString(addresses)
+}
+
 func (o *OpenstackVMManager) forceRecreateImage() (string, error) {
 	imageName := fmt.Sprintf("%s-image", o.Config.VMName)
 	result, err := o.listExistingImages(imageName)
@@ -177,9 +325,52 @@ func (o *OpenstackVMManager) createImage(imageName string) (imageURI string, err
 	return cleanupString(stdout.String()), err
 }
 
-func (o *OpenstackVMManager) createVM(imageID string) (serverID string, state StateInfo, err error) {
-	var nicStr string
+// ensurePort finds or creates the neutron port the VM boots on. The port is
+// not owned by the server, so it outlives the VM and a recreated VM keeps the
+// same private IP and floating IP association.
+func (o *OpenstackVMManager) ensurePort() (string, error) {
+	args := append(o.getAuthArguments(), `port`, `list`,
+		`--network`, o.Config.NetID,
+		`--name`, o.Config.PortName,
+		`--format`, `value`,
+		`--column`, `ID`)
+
+	stdout, _, err := o.runner.Execute(args)
+	if err != nil {
+		return "", fmt.Errorf("openstack error listing ports: %s", err)
+	}
+
+	portIDs := strings.Fields(stdout.String())
+	if len(portIDs) > 1 {
+		return "", fmt.Errorf("openstack error found %d ports named %q on network %s, expected at most one", len(portIDs), o.Config.PortName, o.Config.NetID)
+	}
+	if len(portIDs) == 1 {
+		return portIDs[0], nil
+	}
+
+	args = append(o.getAuthArguments(), `port`, `create`,
+		`--network`, o.Config.NetID,
+		`--security-group`, o.Config.SecurityGroup)
+
 	if o.Config.PrivateIP != "" {
+		args = append(args, `--fixed-ip`, fmt.Sprintf(`ip-address=%s`, o.Config.PrivateIP))
+	}
+
+	args = append(args, `--format`, `value`, `--column`, `id`, o.Config.PortName)
+
+	stdout, _, err = o.runner.Execute(args)
+	if err != nil {
+		return "", fmt.Errorf("openstack error creating port: %s", err)
+	}
+
+	return cleanupString(stdout.String()), nil
+}
+
+func (o *OpenstackVMManager) createVM(imageID string, portID string) (serverID string, state StateInfo, err error) {
+	var nicStr string
+	if portID != "" {
+		nicStr = fmt.Sprintf(`port-id=%s`, portID)
+	} else if o.Config.PrivateIP != "" {
 		nicStr = fmt.Sprintf(`net-id=%s,v4-fixed-ip=%s`, o.Config.NetID, o.Config.PrivateIP)
 	} else {
 		nicStr = fmt.Sprintf(`net-id=%s`, o.Config.NetID)
@@ -189,7 +380,14 @@ func (o *OpenstackVMManager) createVM(imageID string) (serverID string, state St
 		`--flavor`, o.Config.Flavor,
 		`--image`, imageID,
 		`--nic`, nicStr,
-		`--security-group`, o.Config.SecurityGroup,
+	)
+
+	// the security group of an existing port is set on the port itself
+	if portID == "" {
+		args = append(args, `--security-group`, o.Config.SecurityGroup)
+	}
+
+	args = append(args,
 		`--key-name`, o.Config.KeyName,
 		`--format`, `value`,
 		`--column`, `id`,
@@ -230,6 +428,17 @@ func (o *OpenstackVMManager) attachIP(serverID string) error {
 	return nil
 }
 
+func (o *OpenstackVMManager) associateIP(portID string) error {
+	log.Println("Associating Public IP with the VM port...")
+	args := append(o.getAuthArguments(), `floating`, `ip`, `set`,
+		`--port`, portID, o.Config.PublicIP)
+	_, _, err := o.runner.Execute(args)
+	if err != nil {
+		return fmt.Errorf("openstack error associating the IP address with the port: %s", err)
+	}
+	return nil
+}
+
 func (o *OpenstackVMManager) addDefaultConfigFields() {
 	if o.Config.VMName == "" {
 		o.Config.VMName = "ops-manager-vm"
@@ -318,6 +318,93 @@ opsman-configuration:
 				})
 			})
 
+			When("a port name is provided", func() {
+				authArgs := []interface{}{
+					"--os-username", gstruct.Ignore(),
+					"--os-password", gstruct.Ignore(),
+					"--os-auth-url", "https://example.com:5000/v2.0",
+					"--os-project-name", "marker",
+					"--insecure",
+					"--os-project-domain-name", "default",
+					"--os-user-domain-name", "default",
+					"--os-identity-api-version", "3",
+				}
+
+				It("boots the vm on a new port and associates the public IP with it", func() {
+					command, runner := createCommand(configStrTemplate)
+					command.Config.PortName = "awesome-vm-port"
+
+					runner.ExecuteReturnsOnCall(0, bytes.NewBufferString("\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(1, bytes.NewBufferString("custom-image-id\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(2, bytes.NewBufferString("\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(3, bytes.NewBufferString("custom-port-id\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(4, bytes.NewBufferString("custom-server-id\r\n"), nil, nil)
+
+					status, stateInfo, err := command.CreateVM()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(status).To(Equal(vmmanagers.Success))
+					Expect(stateInfo).To(Equal(vmmanagers.StateInfo{IAAS: "openstack", ID: "custom-server-id"}))
+
+					Expect(runner.ExecuteArgsForCall(2)).To(matchers.OrderedConsistOf(append(authArgs,
+						"port", "list",
+						"--network", "590790ef-f90f-4bfd-884f-cb6ece199a82",
+						"--name", "awesome-vm-port",
+						"--format", "value", "--column", "ID",
+					)...))
+					Expect(runner.ExecuteArgsForCall(3)).To(matchers.OrderedConsistOf(append(authArgs,
+						"port", "create",
+						"--network", "590790ef-f90f-4bfd-884f-cb6ece199a82",
+						"--security-group", "marker-sec-group",
+						"--fixed-ip", "ip-address=10.0.0.3",
+						"--format", "value", "--column", "id",
+						"awesome-vm-port",
+					)...))
+					Expect(runner.ExecuteArgsForCall(4)).To(matchers.OrderedConsistOf(append(authArgs,
+						"server", "create",
+						"--flavor", "m1.large", "--image", "custom-image-id",
+						"--nic", "port-id=custom-port-id",
+						"--key-name", "marker-keypair",
+						"--format", "value", "--column", "id",
+						"--wait",
+						"--availability-zone", "zone-01",
+						"awesome-vm",
+					)...))
+					Expect(runner.ExecuteArgsForCall(5)).To(matchers.OrderedConsistOf(append(authArgs,
+						"floating", "ip", "set",
+						"--port", "custom-port-id", "10.10.10.9",
+					)...))
+				})
+
+				It("reuses the port left behind by a previous vm", func() {
+					command, runner := createCommand(configStrTemplate)
+					command.Config.PortName = "awesome-vm-port"
+
+					runner.ExecuteReturnsOnCall(0, bytes.NewBufferString("\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(1, bytes.NewBufferString("custom-image-id\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(2, bytes.NewBufferString("existing-port-id\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(3, bytes.NewBufferString("custom-server-id\r\n"), nil, nil)
+
+					_, _, err := command.CreateVM()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(runner.ExecuteCallCount()).To(Equal(5))
+					Expect(runner.ExecuteArgsForCall(3)).To(ContainElement("port-id=existing-port-id"))
+					Expect(runner.ExecuteArgsForCall(4)).To(ContainElement("existing-port-id"))
+				})
+
+				It("errors when more than one port has the name", func() {
+					command, runner := createCommand(configStrTemplate)
+					command.Config.PortName = "awesome-vm-port"
+
+					runner.ExecuteReturnsOnCall(0, bytes.NewBufferString("\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(1, bytes.NewBufferString("custom-image-id\r\n"), nil, nil)
+					runner.ExecuteReturnsOnCall(2, bytes.NewBufferString("port-1\nport-2\n"), nil, nil)
+
+					status, _, err := command.CreateVM()
+					Expect(err).To(MatchError(`openstack error found 2 ports named "awesome-vm-port" on network 590790ef-f90f-4bfd-884f-cb6ece199a82, expected at most one`))
+					Expect(status).To(Equal(vmmanagers.Unknown))
+				})
+			})
 		})
 
 		DescribeTable("errors when required params are missing", func(param string) {
@@ -517,5 +604,79 @@ opsman-configuration:
 		})
 	})
 
+	Describe("PlanCreateVM", func() {
+		const configStr = `
+opsman-configuration:
+  openstack:
+    auth_url: https://example.com:5000/v2.0
+    project_name: marker
+    net_id: 590790ef-f90f-4bfd-884f-cb6ece199a82
+    username: admin
+    password: password
+    key_pair_name: marker-keypair
+    security_group_name: marker-sec-group
+    vm_name: awesome-vm
+    public_ip: 10.10.10.9
+    private_ip: 10.0.0.3
+    flavor: m1.large
+`
+
+		It("plans the server, its image and the floating ip association", func() {
+			command, runner := createCommand(configStr)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.ExecuteCallCount()).To(Equal(0))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanCreate))
+			var types []string
+			for _, resource := range plan.Resources {
+				types = append(types, resource.Type)
+			}
+			Expect(types).To(Equal([]string{"server", "image", "floating-ip-association"}))
+			Expect(plan.Resources[0].Properties).To(HaveKeyWithValue("flavor", "m1.large"))
+			Expect(plan.Resources[1].Properties).To(Equal(map[string]string{"file": command.Image}))
+		})
+
+		It("reports the properties of the existing server that drifted", func() {
+			command, runner := createCommand(configStr)
+			command.State.ID = "server-id"
+			runner.ExecuteReturnsOnCall(0, bytes.NewBufferString("ACTIVE\n"), nil, nil)
+			runner.ExecuteReturnsOnCall(1, bytes.NewBufferString(`{
+				"flavor": "m1.xlarge (1234)",
+				"key_name": "marker-keypair",
+				"OS-EXT-AZ:availability_zone": "nova",
+				"addresses": "private=10.0.0.3, 10.10.10.9"
+			}`), nil, nil)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(runner.ExecuteArgsForCall(1)).To(ContainElements("server", "show", "server-id", "--format", "json"))
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDrifted))
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "flavor", Expected: "m1.large", Actual: "m1.xlarge"},
+			}))
+		})
+
+		It("reports a missing address as drift", func() {
+			command, runner := createCommand(configStr)
+			command.State.ID = "server-id"
+			runner.ExecuteReturnsOnCall(0, bytes.NewBufferString("ACTIVE\n"), nil, nil)
+			runner.ExecuteReturnsOnCall(1, bytes.NewBufferString(`{
+				"flavor": {"name": "m1.large"},
+				"key_name": "marker-keypair",
+				"addresses": {"private": ["10.0.0.30"]}
+			}`), nil, nil)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "private_ip", Expected: "10.0.0.3", Actual: "map[private:[10.0.0.30]]"},
+				{Property: "public_ip", Expected: "10.10.10.9", Actual: "map[private:[10.0.0.30]]"},
+			}))
+		})
+	})
+
 	testIAASForPropertiesInExampleFile("Openstack")
 })
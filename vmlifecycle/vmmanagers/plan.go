@@ -0,0 +1,120 @@
+package vmmanagers
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	PlanCreate   = "create"
+	PlanDelete   = "delete"
+	PlanNoChange = "no-change"
+	PlanDrifted  = "drifted"
+)
+
+// Plan describes the IaaS resources create-vm or delete-vm would touch,
+// without touching them.
+type Plan struct {
+	IAAS      string            `yaml:"iaas"`
+	VMID      string            `yaml:"vm_id,omitempty"`
+	Action    string            `yaml:"action"`
+	Resources []PlannedResource `yaml:"resources,omitempty"`
+	Drift     []PropertyDrift   `yaml:"drift,omitempty"`
+}
+
+type PlannedResource struct {
+	Action     string            `yaml:"action"`
+	Type       string            `yaml:"type"`
+	Name       string            `yaml:"name"`
+	Properties map[string]string `yaml:"properties,omitempty"`
+}
+
+// PropertyDrift is a property of the existing VM that no longer matches the
+// configuration.
+type PropertyDrift struct {
+	Property string `yaml:"property"`
+	Expected string `yaml:"expected"`
+	Actual   string `yaml:"actual"`
+}
+
+func (p Plan) HasDrift() bool {
+	return len(p.Drift) > 0
+}
+
+// planCreateVM builds the plan for create-vm. The first resource is the VM
+// itself: when it already exists, describeVM returns its current properties
+// and they are compared with the configured ones. Properties left empty in
+// the configuration are not compared.
+func planCreateVM(iaas string, state StateInfo, exists bool, resources []PlannedResource, describeVM func() (map[string]string, error)) (Plan, error) {
+	if !exists {
+		for i := range resources {
+			resources[i].Action = PlanCreate
+		}
+
+		return Plan{IAAS: iaas, Action: PlanCreate, Resources: resources}, nil
+	}
+
+	for i := range resources {
+		resources[i].Action = PlanNoChange
+	}
+
+	actual, err := describeVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	var drift []PropertyDrift
+	for _, property := range sortedKeys(resources[0].Properties) {
+		expected := resources[0].Properties[property]
+		value, ok := actual[property]
+		if !ok || expected == "" || value == expected {
+			continue
+		}
+
+		drift = append(drift, PropertyDrift{Property: property, Expected: expected, Actual: value})
+	}
+
+	action := PlanNoChange
+	if len(drift) > 0 {
+		action = PlanDrifted
+	}
+
+	return Plan{IAAS: iaas, VMID: state.ID, Action: action, Resources: resources, Drift: drift}, nil
+}
+
+func planDeleteVM(iaas string, state StateInfo, resources ...PlannedResource) Plan {
+	if state.ID == "" {
+		return Plan{IAAS: iaas, Action: PlanNoChange}
+	}
+
+	for i := range resources {
+		resources[i].Action = PlanDelete
+	}
+
+	return Plan{IAAS: iaas, VMID: state.ID, Action: PlanDelete, Resources: resources}
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortedList normalises comma separated values, like security groups or
+// tags, so that ordering does not count as drift.
+func sortedList(values []string) string {
+	var cleaned []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			cleaned = append(cleaned, value)
+		}
+	}
+	sort.Strings(cleaned)
+
+	return strings.Join(cleaned, ",")
+}
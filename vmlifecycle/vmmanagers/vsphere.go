@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -33,6 +34,11 @@ type Vcenter struct {
 	ResourcePool      string `yaml:"resource_pool" validate:"required"`
 	HostDEPRECATED    string `yaml:"host,omitempty"`
 	Folder            string `yaml:"folder"`
+
+	// ContentLibrary deploys the VM from an item in this Content Library
+	// instead of importing the OVA directly.
+	ContentLibrary     string `yaml:"content_library,omitempty"`
+	ContentLibraryItem string `yaml:"content_library_item,omitempty"`
 }
 
 type VsphereConfig struct {
@@ -128,23 +134,7 @@ func (v *VsphereVMManager) DeleteVM() error {
 }
 
 func (v *VsphereVMManager) CreateVM() (Status, StateInfo, error) {
-	if v.State.IAAS != "vsphere" && v.State.IAAS != "" {
-		return Unknown, StateInfo{}, fmt.Errorf("authentication file provided is for vsphere, while the state file is for %s", v.State.IAAS)
-	}
-
-	err := v.validateVsphereConfig()
-	if err != nil {
-		return Unknown, StateInfo{}, err
-	}
-
-	err = validateIAASConfig(v.Config.OpsmanConfig.Vsphere)
-	if err != nil {
-		return Unknown, StateInfo{}, err
-	}
-
-	v.addDefaultConfigFields()
-
-	env, err := v.addEnvVars()
+	env, err := v.prepareCreateVM()
 	if err != nil {
 		return Unknown, StateInfo{}, err
 	}
@@ -198,6 +188,137 @@ func (v *VsphereVMManager) CreateVM() (Status, StateInfo, error) {
 	return Success, fullState, nil
 }
 
+func (v *VsphereVMManager) PlanCreateVM() (Plan, error) {
+	config := v.Config.OpsmanConfig.Vsphere
+
+	env, err := v.prepareCreateVM()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	exist, err := v.vmExists(env)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	source := v.ImageOVA
+	if config.Vcenter.ContentLibrary != "" {
+		source = fmt.Sprintf("/%s/%s", config.Vcenter.ContentLibrary, v.contentLibraryItem())
+	}
+
+	resources := []PlannedResource{{
+		Type: "vm",
+		Name: v.createIpath(),
+		Properties: map[string]string{
+			"source":        source,
+			"datastore":     config.Vcenter.Datastore,
+			"resource_pool": config.Vcenter.ResourcePool,
+			"network":       config.Network,
+			"private_ip":    config.PrivateIP,
+			"hostname":      config.Hostname,
+			"cpu":           config.CPU,
+			"memory":        config.Memory,
+			"disk_size":     config.DiskSize,
+			"disk_type":     config.DiskType,
+		},
+	}}
+
+	return planCreateVM("vsphere", v.State, exist, resources, func() (map[string]string, error) {
+		return v.describeVM(env)
+	})
+}
+
+func (v *VsphereVMManager) PlanDeleteVM() (Plan, error) {
+	err := validateIAASConfig(v.Config.OpsmanConfig.Vsphere.Vcenter.VcenterCredential)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	env, err := v.addEnvVars()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	if v.State.IAAS != "vsphere" {
+		return Plan{}, fmt.Errorf("authentication file provided is for vsphere, while the state file is for %s", v.State.IAAS)
+	}
+
+	_, err = v.vmExists(env)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	return planDeleteVM("vsphere", v.State, PlannedResource{Type: "vm", Name: v.State.ID}), nil
+}
+
+func (v *VsphereVMManager) prepareCreateVM() ([]string, error) {
+	if v.State.IAAS != "vsphere" && v.State.IAAS != "" {
+		return nil, fmt.Errorf("authentication file provided is for vsphere, while the state file is for %s", v.State.IAAS)
+	}
+
+	err := v.validateVsphereConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateIAASConfig(v.Config.OpsmanConfig.Vsphere)
+	if err != nil {
+		return nil, err
+	}
+
+	v.addDefaultConfigFields()
+
+	return v.addEnvVars()
+}
+
+func (v *VsphereVMManager) describeVM(env []string) (map[string]string, error) {
+	stdout, _, err := v.runner.ExecuteWithEnvVars(env, []interface{}{
+		"vm.info",
+		"-json",
+		"-vm.ipath=" + v.State.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("govc error: %s", err)
+	}
+
+	var info struct {
+		VirtualMachines []struct {
+			Config struct {
+				Hardware struct {
+					NumCPU   int
+					MemoryMB int
+				}
+			}
+			Guest struct {
+				IpAddress string
+				HostName  string
+			}
+		}
+	}
+	err = json.Unmarshal(stdout.Bytes(), &info)
+	if err != nil {
+		return nil, fmt.Errorf("govc error could not parse the vm info: %s", err)
+	}
+	if len(info.VirtualMachines) == 0 {
+		return nil, fmt.Errorf("govc error: no vm info for %s", v.State.ID)
+	}
+
+	vm := info.VirtualMachines[0]
+	actual := map[string]string{
+		"cpu":    strconv.Itoa(vm.Config.Hardware.NumCPU),
+		"memory": strconv.Itoa(vm.Config.Hardware.MemoryMB / 1024),
+	}
+	// the guest details are only known while VMware Tools is running
+	if vm.Guest.IpAddress != "" {
+		actual["private_ip"] = vm.Guest.IpAddress
+	}
+	if vm.Guest.HostName != "" {
+		actual["hostname"] = vm.Guest.HostName
+	}
+
+	return actual, nil
+}
+
 func (v *VsphereVMManager) createOptionsFile() (optionsFileName string, err error) {
 	options := ovaJSONConfig{
 		DiskProvisioning:   v.Config.OpsmanConfig.Vsphere.DiskType,
@@ -323,11 +444,15 @@ func (v *VsphereVMManager) validateImage() error {
 }
 
 func (v *VsphereVMManager) createVM(env []string, optionFilename string, ipath string) (errBufWriter *bytes.Buffer, err error) {
-	_, errBufWriter, err = v.runner.ExecuteWithEnvVars(env, []interface{}{
-		"import.ova",
-		"-options=" + optionFilename,
-		v.ImageOVA,
-	})
+	if v.Config.OpsmanConfig.Vsphere.Vcenter.ContentLibrary != "" {
+		errBufWriter, err = v.deployFromContentLibrary(env, optionFilename)
+	} else {
+		_, errBufWriter, err = v.runner.ExecuteWithEnvVars(env, []interface{}{
+			"import.ova",
+			"-options=" + optionFilename,
+			v.ImageOVA,
+		})
+	}
 	if err != nil {
 		return errBufWriter, checkFormatedError("govc error: %s", err)
 	}
@@ -356,6 +481,52 @@ func (v *VsphereVMManager) createVM(env []string, optionFilename string, ipath s
 	return errBufWriter, nil
 }
 
+// deployFromContentLibrary references the Content Library item for the image,
+// importing the OVA into the library when the item is not there yet, and
+// deploys the VM from it.
+func (v *VsphereVMManager) deployFromContentLibrary(env []string, optionFilename string) (errBufWriter *bytes.Buffer, err error) {
+	library := v.Config.OpsmanConfig.Vsphere.Vcenter.ContentLibrary
+	item := v.contentLibraryItem()
+	itemPath := fmt.Sprintf("/%s/%s", library, item)
+
+	_, _, err = v.runner.ExecuteWithEnvVars(env, []interface{}{
+		"library.info",
+		itemPath,
+	})
+	if err != nil {
+		log.Printf("importing %s into content library %s as %s\n", v.ImageOVA, library, item)
+
+		_, errBufWriter, err = v.runner.ExecuteWithEnvVars(env, []interface{}{
+			"library.import",
+			"-n=" + item,
+			library,
+			v.ImageOVA,
+		})
+		if err != nil {
+			return errBufWriter, err
+		}
+	} else {
+		log.Printf("using existing item %s from content library %s\n", item, library)
+	}
+
+	_, errBufWriter, err = v.runner.ExecuteWithEnvVars(env, []interface{}{
+		"library.deploy",
+		"-options=" + optionFilename,
+		itemPath,
+		v.Config.OpsmanConfig.Vsphere.VMName,
+	})
+	return errBufWriter, err
+}
+
+func (v *VsphereVMManager) contentLibraryItem() string {
+	if v.Config.OpsmanConfig.Vsphere.Vcenter.ContentLibraryItem != "" {
+		return v.Config.OpsmanConfig.Vsphere.Vcenter.ContentLibraryItem
+	}
+
+	name := filepath.Base(v.ImageOVA)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
 func (v *VsphereVMManager) resetVM(env []string, ipath string) (errBufWriter *bytes.Buffer, err error) {
 	_, errBufWriter, err = v.runner.ExecuteWithEnvVars(env, []interface{}{
 		"vm.power",
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -300,6 +302,96 @@ opsman-configuration:
 					})
 				})
 
+				When("a content_library is configured", func() {
+					contentLibraryConfig := func(item string) string {
+						config := strings.Replace(configStr, "      folder: /datacenter/vm/folder\n", "      folder: /datacenter/vm/folder\n      content_library: opsman-images\n", 1)
+						if item != "" {
+							config = strings.Replace(config, "      content_library: opsman-images\n", "      content_library: opsman-images\n      content_library_item: "+item+"\n", 1)
+						}
+						return config
+					}
+
+					It("imports the OVA into the library when the item does not exist and deploys from it", func() {
+						command, runner := createCommand(contentLibraryConfig(""), opsmanVersionBelow26)
+						runner.ExecuteWithEnvVarsReturnsOnCall(0, nil, nil, errors.New("library.info: not found"))
+
+						status, _, err := command.CreateVM()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(status).To(Equal(vmmanagers.Success))
+
+						itemName := strings.TrimSuffix(filepath.Base(command.ImageOVA), ".ova")
+
+						_, args := runner.ExecuteWithEnvVarsArgsForCall(0)
+						Expect(args).To(matchers.OrderedConsistOf(
+							"library.info",
+							"/opsman-images/"+itemName,
+						))
+
+						_, args = runner.ExecuteWithEnvVarsArgsForCall(1)
+						Expect(args).To(matchers.OrderedConsistOf(
+							"library.import",
+							"-n="+itemName,
+							"opsman-images",
+							MatchRegexp(".*ova"),
+						))
+
+						_, args = runner.ExecuteWithEnvVarsArgsForCall(2)
+						Expect(args).To(matchers.OrderedConsistOf(
+							"library.deploy",
+							MatchRegexp("-options=.*options.json.*"),
+							"/opsman-images/"+itemName,
+							"vm_name",
+						))
+
+						_, _, args = runner.ExecuteWithEnvVarsCtxArgsForCall(0)
+						Expect(args).To(matchers.OrderedConsistOf(
+							"vm.info",
+							"-vm.ipath=/datacenter/vm/folder/vm_name",
+							"-waitip",
+						))
+					})
+
+					It("references an existing item without importing the OVA", func() {
+						command, runner := createCommand(contentLibraryConfig("ops-manager-2.5.2"), opsmanVersionBelow26)
+
+						_, _, err := command.CreateVM()
+						Expect(err).ToNot(HaveOccurred())
+
+						_, args := runner.ExecuteWithEnvVarsArgsForCall(0)
+						Expect(args).To(matchers.OrderedConsistOf(
+							"library.info",
+							"/opsman-images/ops-manager-2.5.2",
+						))
+
+						_, args = runner.ExecuteWithEnvVarsArgsForCall(1)
+						Expect(args).To(matchers.OrderedConsistOf(
+							"library.deploy",
+							MatchRegexp("-options=.*options.json.*"),
+							"/opsman-images/ops-manager-2.5.2",
+							"vm_name",
+						))
+					})
+
+					It("returns that the vm exists when the deploy reports it", func() {
+						command, runner := createCommand(contentLibraryConfig("ops-manager-2.5.2"), opsmanVersionBelow26)
+						runner.ExecuteWithEnvVarsReturnsOnCall(1, nil, bytes.NewBufferString("already exists"), errors.New(""))
+
+						status, _, err := command.CreateVM()
+						Expect(err).ToNot(HaveOccurred())
+						Expect(status).To(Equal(vmmanagers.Exist))
+					})
+
+					It("returns an error when the import into the library fails", func() {
+						command, runner := createCommand(contentLibraryConfig(""), opsmanVersionBelow26)
+						runner.ExecuteWithEnvVarsReturnsOnCall(0, nil, nil, errors.New("library.info: not found"))
+						runner.ExecuteWithEnvVarsReturnsOnCall(1, nil, bytes.NewBufferString(""), errors.New("library not found"))
+
+						_, _, err := command.CreateVM()
+						Expect(err).To(MatchError(ContainSubstring("library not found")))
+						Expect(runner.ExecuteWithEnvVarsCallCount()).To(Equal(2))
+					})
+				})
+
 				It("calls govc with the correct environment variables", func() {
 					command, runner := createCommand(configStr, opsmanVersionBelow26)
 
@@ -750,6 +842,80 @@ opsman-configuration:
 		})
 	})
 
+	Describe("PlanCreateVM", func() {
+		const configStr = `
+opsman-configuration:
+  vsphere:
+    vcenter:
+      url: vcenter.nowhere.nonexist
+      username: goodman
+      password: badguy
+      datastore: datastore
+      datacenter: datacenter
+      insecure: 1
+      resource_pool: resource-pool
+      folder: /datacenter/vm/folder
+    disk_type: thin
+    private_ip: 1.2.3.4
+    dns: 1.1.1.1
+    ntp: ntp.server.xyz
+    ssh_password: password
+    hostname: full.domain.name
+    network: some-edge
+    netmask: 255.255.255.192
+    gateway: 2.2.2.2
+    vm_name: vm_name
+    memory: 16
+    cpu: 4
+`
+
+		It("plans the vm without calling govc", func() {
+			command, runner := createCommand(configStr, opsmanVersionBelow26)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.ExecuteWithEnvVarsCallCount()).To(Equal(0))
+
+			Expect(plan.Action).To(Equal(vmmanagers.PlanCreate))
+			Expect(plan.Resources).To(Equal([]vmmanagers.PlannedResource{{
+				Action: vmmanagers.PlanCreate,
+				Type:   "vm",
+				Name:   "/datacenter/vm/folder/vm_name",
+				Properties: map[string]string{
+					"source":        command.ImageOVA,
+					"datastore":     "datastore",
+					"resource_pool": "resource-pool",
+					"network":       "some-edge",
+					"private_ip":    "1.2.3.4",
+					"hostname":      "full.domain.name",
+					"cpu":           "4",
+					"memory":        "16",
+					"disk_size":     "160",
+					"disk_type":     "thin",
+				},
+			}}))
+		})
+
+		It("reports the properties of the existing vm that drifted", func() {
+			command, runner := createCommand(configStr, opsmanVersionBelow26)
+			command.State.ID = "/datacenter/vm/folder/vm_name"
+			runner.ExecuteWithEnvVarsReturnsOnCall(1, bytes.NewBufferString(`{"virtualMachines": [{
+				"config": {"hardware": {"numCPU": 4, "memoryMB": 8192}},
+				"guest": {"ipAddress": "1.2.3.4", "hostName": "full.domain.name"}
+			}]}`), nil, nil)
+
+			plan, err := command.PlanCreateVM()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, args := runner.ExecuteWithEnvVarsArgsForCall(1)
+			Expect(args).To(matchers.OrderedConsistOf("vm.info", "-json", "-vm.ipath=/datacenter/vm/folder/vm_name"))
+			Expect(plan.Action).To(Equal(vmmanagers.PlanDrifted))
+			Expect(plan.Drift).To(Equal([]vmmanagers.PropertyDrift{
+				{Property: "memory", Expected: "16", Actual: "8"},
+			}))
+		})
+	})
+
 	testIAASForPropertiesInExampleFile("Vsphere")
 })
 